@@ -30,15 +30,20 @@ var sdkLogLevel aws.LogLevelType = aws.LogOff
 // JSON string value must contain the levels seperated by "|" and optionally whitespace.
 func SetSDKLogLevel(sdkLogLevelString string) {
 	var temp aws.LogLevelType = aws.LogOff
+	reproduce := false
 
 	levels := strings.Split(sdkLogLevelString, "|")
 	for _, v := range levels {
 		trimmed := strings.TrimSpace(v)
+		if trimmed == LogDebugWithRequestReproduction {
+			reproduce = true
+		}
 		// If v not in map, then OR with 0 is harmless.
 		temp |= stringToLevelMap[trimmed]
 	}
 
 	sdkLogLevel = temp
+	reproduceFailedRequests.Store(reproduce)
 }
 
 // SDKLogLevel returns the single global value so it can be used in all
@@ -50,3 +50,15 @@ func TestSetSDKLogLevel(t *testing.T) {
 		}
 	}
 }
+
+func TestSetSDKLogLevelWithRequestReproduction(t *testing.T) {
+	t.Cleanup(func() { SetSDKLogLevel("") })
+	SetSDKLogLevel("LogDebugWithSigning | LogDebugWithRequestReproduction")
+	if *SDKLogLevel() != aws.LogDebugWithSigning || !reproduceFailedRequests.Load() {
+		t.Errorf("actual: %v, reproduce: %v", sdkLogLevel, reproduceFailedRequests.Load())
+	}
+	SetSDKLogLevel("LogDebug")
+	if reproduceFailedRequests.Load() {
+		t.Errorf("the reproduction is still logged")
+	}
+}
@@ -5,7 +5,6 @@ package aws
 
 import (
 	"log"
-	"net/http"
 	"os"
 	"time"
 
@@ -101,6 +100,7 @@ func getSession(config *aws.Config) *session.Session {
 		}
 	}
 	log.Printf("D! Successfully created credential sessions\n")
+	ses.Handlers.Complete.PushBackNamed(RequestReproductionHandler)
 	cred, err := ses.Config.Credentials.Get()
 	if err != nil {
 		log.Printf("E! Failed to get credential from session: %v", err)
@@ -128,7 +128,7 @@ func (c *CredentialConfig) rootCredentials() client.ConfigProvider {
 	config := &aws.Config{
 		Region:                        aws.String(c.Region),
 		CredentialsChainVerboseErrors: aws.Bool(true),
-		HTTPClient:                    &http.Client{Timeout: 1 * time.Minute},
+		HTTPClient:                    newHTTPClient(),
 		LogLevel:                      SDKLogLevel(),
 		Logger:                        SDKLogger{},
 	}
@@ -140,7 +140,7 @@ func (c *CredentialConfig) assumeCredentials() client.ConfigProvider {
 	rootCredentials := c.rootCredentials()
 	config := &aws.Config{
 		Region:     aws.String(c.Region),
-		HTTPClient: &http.Client{Timeout: 1 * time.Minute},
+		HTTPClient: newHTTPClient(),
 		LogLevel:   SDKLogLevel(),
 		Logger:     SDKLogger{},
 	}
@@ -179,7 +179,7 @@ func newStsCredentials(c client.ConfigProvider, roleARN string, region string) *
 		Client: newStsClient(c, &aws.Config{
 			Region:              aws.String(region),
 			STSRegionalEndpoint: endpoints.RegionalSTSEndpoint,
-			HTTPClient:          &http.Client{Timeout: 1 * time.Minute},
+			HTTPClient:          newHTTPClient(),
 			LogLevel:            SDKLogLevel(),
 			Logger:              SDKLogger{},
 		}),
@@ -194,7 +194,7 @@ func newStsCredentials(c client.ConfigProvider, roleARN string, region string) *
 			Region:              aws.String(fallbackRegion),
 			Endpoint:            aws.String(getFallbackEndpoint(fallbackRegion)),
 			STSRegionalEndpoint: endpoints.RegionalSTSEndpoint,
-			HTTPClient:          &http.Client{Timeout: 1 * time.Minute},
+			HTTPClient:          newHTTPClient(),
 			LogLevel:            SDKLogLevel(),
 			Logger:              SDKLogger{},
 		}),
@@ -0,0 +1,161 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package aws
+
+import (
+	"context"
+	"log"
+	"net"
+	"slices"
+	"sync"
+	"time"
+)
+
+type dialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+type hostResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// dnsCache dials the hosts of the AWS endpoints through a cache of their addresses. The addresses are resolved
+// again once they are older than the ttl, and when none of them can be dialed, so that the clients follow a
+// change of the addresses, e.g. of a VPC endpoint, even though their connections are long-lived. The idle
+// connections are closed when the addresses of a host change so that they are not reused.
+type dnsCache struct {
+	ttl      time.Duration
+	resolver hostResolver
+	dial     dialFunc
+	// onChange is called when the resolved addresses of a host change.
+	onChange func()
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*dnsEntry
+}
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+	// preferred is the index of the address dialed first, the one after the last failed address.
+	preferred int
+}
+
+func newDNSCache(ttl time.Duration, dial dialFunc, onChange func()) *dnsCache {
+	return &dnsCache{
+		ttl:      ttl,
+		resolver: net.DefaultResolver,
+		dial:     dial,
+		onChange: onChange,
+		now:      time.Now,
+		entries:  make(map[string]*dnsEntry),
+	}
+}
+
+// DialContext dials the address, failing over across the addresses of its host.
+func (c *dnsCache) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || net.ParseIP(host) != nil {
+		return c.dial(ctx, network, address)
+	}
+	addrs, preferred := c.lookup(ctx, network, host)
+	if len(addrs) == 0 {
+		// let the dialer resolve the host and report the error
+		return c.dial(ctx, network, address)
+	}
+	var lastErr error
+	for i := range addrs {
+		index := (preferred + i) % len(addrs)
+		conn, err := c.dial(ctx, network, net.JoinHostPort(addrs[index], port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+		c.failed(host, index)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	log.Printf("D! Failed to dial any of the %d addresses of %s, resolving it again on the next dial", len(addrs), host)
+	c.invalidate(host)
+	return nil, lastErr
+}
+
+// lookup returns the cached addresses of the host in the family of the network, resolving them if they expired. The
+// expired addresses are used until the host resolves again.
+func (c *dnsCache) lookup(ctx context.Context, network, host string) ([]string, int) {
+	now := c.now()
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	if ok && now.Before(entry.expires) {
+		defer c.mu.Unlock()
+		return entry.addrs, entry.preferred
+	}
+	c.mu.Unlock()
+
+	ipAddrs, err := c.resolver.LookupIPAddr(ctx, host)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil || len(ipAddrs) == 0 {
+		if entry, ok = c.entries[host]; ok {
+			log.Printf("D! Failed to resolve %s, using its previous addresses: %v", host, err)
+			return entry.addrs, entry.preferred
+		}
+		return nil, 0
+	}
+	addrs := make([]string, 0, len(ipAddrs))
+	for _, ipAddr := range ipAddrs {
+		if matchesNetwork(network, ipAddr.IP) {
+			addrs = append(addrs, ipAddr.String())
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, 0
+	}
+	previous, ok := c.entries[host]
+	c.entries[host] = &dnsEntry{addrs: addrs, expires: now.Add(c.ttl)}
+	if ok && previous.addrs != nil && !sameAddrs(previous.addrs, addrs) {
+		log.Printf("I! The addresses of %s changed from %v to %v", host, previous.addrs, addrs)
+		if c.onChange != nil {
+			c.onChange()
+		}
+	}
+	return addrs, 0
+}
+
+// failed makes the address after the failed one the first dialed for the host.
+func (c *dnsCache) failed(host string, index int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[host]; ok && len(entry.addrs) > 0 {
+		entry.preferred = (index + 1) % len(entry.addrs)
+	}
+}
+
+// invalidate expires the addresses of the host. They are kept to detect a change and in case the host does not
+// resolve.
+func (c *dnsCache) invalidate(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[host]; ok {
+		entry.expires = time.Time{}
+	}
+}
+
+// matchesNetwork reports whether the IP can be dialed on the network, e.g. only IPv6 addresses on tcp6.
+func matchesNetwork(network string, ip net.IP) bool {
+	switch network {
+	case "tcp4", "udp4":
+		return ip.To4() != nil
+	case "tcp6", "udp6":
+		return ip.To4() == nil
+	}
+	return true
+}
+
+func sameAddrs(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
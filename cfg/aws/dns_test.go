@@ -0,0 +1,166 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+)
+
+type mockResolver struct {
+	addrs   []string
+	err     error
+	lookups int
+}
+
+func (r *mockResolver) LookupIPAddr(_ context.Context, _ string) ([]net.IPAddr, error) {
+	r.lookups++
+	if r.err != nil {
+		return nil, r.err
+	}
+	ipAddrs := make([]net.IPAddr, 0, len(r.addrs))
+	for _, addr := range r.addrs {
+		ipAddrs = append(ipAddrs, net.IPAddr{IP: net.ParseIP(addr)})
+	}
+	return ipAddrs, nil
+}
+
+type mockDialer struct {
+	unreachable map[string]bool
+	dialed      []string
+}
+
+func (d *mockDialer) DialContext(_ context.Context, _, address string) (net.Conn, error) {
+	d.dialed = append(d.dialed, address)
+	if d.unreachable[address] {
+		return nil, errors.New("connection refused")
+	}
+	client, server := net.Pipe()
+	server.Close()
+	return client, nil
+}
+
+func newTestDNSCache(resolver *mockResolver, dialer *mockDialer, onChange func()) (*dnsCache, *time.Time) {
+	now := time.Now()
+	cache := newDNSCache(time.Minute, dialer.DialContext, onChange)
+	cache.resolver = resolver
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestDNSCache(t *testing.T) {
+	resolver := &mockResolver{addrs: []string{"10.0.0.1", "10.0.0.2"}}
+	dialer := &mockDialer{}
+	changes := 0
+	cache, now := newTestDNSCache(resolver, dialer, func() { changes++ })
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		conn, err := cache.DialContext(ctx, "tcp", "logs.us-east-1.amazonaws.com:443")
+		require.NoError(t, err)
+		conn.Close()
+	}
+	assert.Equal(t, 1, resolver.lookups)
+	assert.Equal(t, []string{"10.0.0.1:443", "10.0.0.1:443", "10.0.0.1:443"}, dialer.dialed)
+
+	// the addresses are resolved again once they expire
+	*now = now.Add(time.Minute)
+	resolver.addrs = []string{"10.0.0.2", "10.0.0.1"}
+	_, err := cache.DialContext(ctx, "tcp", "logs.us-east-1.amazonaws.com:443")
+	require.NoError(t, err)
+	assert.Equal(t, 2, resolver.lookups)
+	assert.Equal(t, 0, changes)
+
+	*now = now.Add(time.Minute)
+	resolver.addrs = []string{"10.0.0.3"}
+	_, err = cache.DialContext(ctx, "tcp", "logs.us-east-1.amazonaws.com:443")
+	require.NoError(t, err)
+	assert.Equal(t, 1, changes)
+	assert.Equal(t, "10.0.0.3:443", dialer.dialed[len(dialer.dialed)-1])
+
+	// the previous addresses are used when the host does not resolve
+	*now = now.Add(time.Minute)
+	resolver.err = errors.New("no such host")
+	_, err = cache.DialContext(ctx, "tcp", "logs.us-east-1.amazonaws.com:443")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.3:443", dialer.dialed[len(dialer.dialed)-1])
+}
+
+func TestDNSCacheFailover(t *testing.T) {
+	resolver := &mockResolver{addrs: []string{"10.0.0.1", "10.0.0.2"}}
+	dialer := &mockDialer{unreachable: map[string]bool{"10.0.0.1:443": true}}
+	cache, _ := newTestDNSCache(resolver, dialer, nil)
+	ctx := context.Background()
+
+	_, err := cache.DialContext(ctx, "tcp", "monitoring.us-east-1.amazonaws.com:443")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:443", "10.0.0.2:443"}, dialer.dialed)
+
+	// the address that failed is dialed last
+	dialer.dialed = nil
+	_, err = cache.DialContext(ctx, "tcp", "monitoring.us-east-1.amazonaws.com:443")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2:443"}, dialer.dialed)
+
+	// the host is resolved again when none of its addresses can be dialed
+	dialer.unreachable["10.0.0.2:443"] = true
+	_, err = cache.DialContext(ctx, "tcp", "monitoring.us-east-1.amazonaws.com:443")
+	assert.Error(t, err)
+	assert.Equal(t, 1, resolver.lookups)
+	resolver.addrs = []string{"10.0.0.3"}
+	_, err = cache.DialContext(ctx, "tcp", "monitoring.us-east-1.amazonaws.com:443")
+	require.NoError(t, err)
+	assert.Equal(t, 2, resolver.lookups)
+	assert.Equal(t, "10.0.0.3:443", dialer.dialed[len(dialer.dialed)-1])
+}
+
+func TestDNSCacheWithoutHost(t *testing.T) {
+	resolver := &mockResolver{err: errors.New("no such host")}
+	dialer := &mockDialer{}
+	cache, _ := newTestDNSCache(resolver, dialer, nil)
+	ctx := context.Background()
+
+	_, err := cache.DialContext(ctx, "tcp", "127.0.0.1:443")
+	require.NoError(t, err)
+	_, err = cache.DialContext(ctx, "tcp", "sts.amazonaws.com:443")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1:443", "sts.amazonaws.com:443"}, dialer.dialed)
+	assert.Equal(t, 1, resolver.lookups)
+}
+
+func TestDNSCacheNetwork(t *testing.T) {
+	resolver := &mockResolver{addrs: []string{"10.0.0.1", "2600:1f18::1"}}
+	dialer := &mockDialer{}
+	cache, _ := newTestDNSCache(resolver, dialer, nil)
+
+	_, err := cache.DialContext(context.Background(), "tcp6", "logs.us-east-1.api.aws:443")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"[2600:1f18::1]:443"}, dialer.dialed)
+
+	// the dialer reports the error when the host has no address in the family of the network
+	resolver.addrs = []string{"10.0.0.1"}
+	dialer.dialed = nil
+	_, err = cache.DialContext(context.Background(), "tcp6", "sts.us-east-1.amazonaws.com:443")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sts.us-east-1.amazonaws.com:443"}, dialer.dialed)
+}
+
+func TestNewHTTPClientWithDNSCache(t *testing.T) {
+	t.Setenv(envconfig.CWAGENT_HTTP_DNS_CACHE_TTL, "0")
+	assert.Nil(t, newHTTPClient().Transport)
+
+	t.Setenv(envconfig.CWAGENT_HTTP_DNS_CACHE_TTL, "30")
+	transport, ok := newHTTPClient().Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.NotNil(t, transport.DialContext)
+}
@@ -0,0 +1,107 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package aws
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+)
+
+const (
+	defaultHTTPTimeout = 1 * time.Minute
+	// dialKeepAlive is the keep-alive of the default transport.
+	dialKeepAlive = 30 * time.Second
+)
+
+// newHTTPClient returns the HTTP client of the AWS clients. Its connection pool, timeouts, HTTP/2 support and DNS
+// caching are tuned with the agent.http_client section of the configuration, which is passed in the environment. Without
+// it, the clients share the default transport. With the ipv6 agent.ip_preference, the clients only dial IPv6 addresses.
+func newHTTPClient() *http.Client {
+	client := &http.Client{Timeout: defaultHTTPTimeout}
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return client
+	}
+	transport = transport.Clone()
+	tuned := false
+	if n, ok := getEnvInt(envconfig.CWAGENT_HTTP_MAX_IDLE_CONNS); ok {
+		transport.MaxIdleConns = n
+		tuned = true
+	}
+	if n, ok := getEnvInt(envconfig.CWAGENT_HTTP_MAX_IDLE_CONNS_PER_HOST); ok {
+		transport.MaxIdleConnsPerHost = n
+		tuned = true
+	}
+	if n, ok := getEnvInt(envconfig.CWAGENT_HTTP_MAX_CONNS_PER_HOST); ok {
+		transport.MaxConnsPerHost = n
+		tuned = true
+	}
+	if n, ok := getEnvInt(envconfig.CWAGENT_HTTP_DIAL_TIMEOUT); ok {
+		transport.DialContext = (&net.Dialer{Timeout: time.Duration(n) * time.Second, KeepAlive: dialKeepAlive}).DialContext
+		tuned = true
+	}
+	if n, ok := getEnvInt(envconfig.CWAGENT_HTTP_READ_TIMEOUT); ok {
+		transport.ResponseHeaderTimeout = time.Duration(n) * time.Second
+		tuned = true
+	}
+	if value := os.Getenv(envconfig.CWAGENT_HTTP2); value != "" {
+		if enabled, err := strconv.ParseBool(value); err != nil {
+			log.Printf("W! Ignoring %s=%q, it is not a boolean", envconfig.CWAGENT_HTTP2, value)
+		} else if !enabled {
+			// a non-nil empty map turns off HTTP/2
+			transport.ForceAttemptHTTP2 = false
+			transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+			tuned = true
+		}
+	}
+	if n, ok := getEnvInt(envconfig.CWAGENT_HTTP_DNS_CACHE_TTL); ok && n > 0 {
+		cache := newDNSCache(time.Duration(n)*time.Second, transport.DialContext, transport.CloseIdleConnections)
+		transport.DialContext = cache.DialContext
+		tuned = true
+	}
+	if envconfig.GetIPPreference() == envconfig.IPPreferenceIPv6 {
+		dial := transport.DialContext
+		transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dial(ctx, ipv6Network(network), address)
+		}
+		tuned = true
+	}
+	if tuned {
+		client.Transport = transport
+	}
+	return client
+}
+
+// ipv6Network returns the IPv6 only variant of the network, so that the IPv4 addresses of the hosts are not dialed.
+func ipv6Network(network string) string {
+	switch network {
+	case "tcp", "tcp4":
+		return "tcp6"
+	case "udp", "udp4":
+		return "udp6"
+	}
+	return network
+}
+
+// getEnvInt returns the non-negative integer value of the environment variable, if it is set.
+func getEnvInt(key string) (int, bool) {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Printf("W! Ignoring %s=%q, it is not a non-negative integer", key, value)
+		return 0, false
+	}
+	return n, true
+}
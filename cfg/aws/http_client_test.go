@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package aws
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	client := newHTTPClient()
+	assert.Equal(t, defaultHTTPTimeout, client.Timeout)
+	assert.Nil(t, client.Transport)
+
+	t.Setenv(envconfig.CWAGENT_HTTP_MAX_IDLE_CONNS, "500")
+	t.Setenv(envconfig.CWAGENT_HTTP_MAX_IDLE_CONNS_PER_HOST, "100")
+	t.Setenv(envconfig.CWAGENT_HTTP_MAX_CONNS_PER_HOST, "200")
+	t.Setenv(envconfig.CWAGENT_HTTP_DIAL_TIMEOUT, "5")
+	t.Setenv(envconfig.CWAGENT_HTTP_READ_TIMEOUT, "20")
+	t.Setenv(envconfig.CWAGENT_HTTP2, "false")
+	client = newHTTPClient()
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 500, transport.MaxIdleConns)
+	assert.Equal(t, 100, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 200, transport.MaxConnsPerHost)
+	assert.Equal(t, 20*time.Second, transport.ResponseHeaderTimeout)
+	assert.NotNil(t, transport.DialContext)
+	assert.False(t, transport.ForceAttemptHTTP2)
+	assert.NotNil(t, transport.TLSNextProto)
+	assert.Empty(t, transport.TLSNextProto)
+	// the proxy settings of the default transport are kept
+	assert.NotNil(t, transport.Proxy)
+	assert.NotSame(t, http.DefaultTransport, transport)
+}
+
+func TestNewHTTPClientInvalid(t *testing.T) {
+	t.Setenv(envconfig.CWAGENT_HTTP_MAX_IDLE_CONNS, "-1")
+	t.Setenv(envconfig.CWAGENT_HTTP_READ_TIMEOUT, "soon")
+	t.Setenv(envconfig.CWAGENT_HTTP2, "maybe")
+	assert.Nil(t, newHTTPClient().Transport)
+
+	// HTTP/2 is attempted by default
+	t.Setenv(envconfig.CWAGENT_HTTP2, "true")
+	assert.Nil(t, newHTTPClient().Transport)
+}
+
+func TestNewHTTPClientIPv6(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	t.Setenv(envconfig.CWAGENT_IP_PREFERENCE, envconfig.IPPreferenceIPv6)
+	transport, ok := newHTTPClient().Transport.(*http.Transport)
+	require.True(t, ok)
+	// the IPv4 address is not dialed
+	_, err = transport.DialContext(context.Background(), "tcp", listener.Addr().String())
+	assert.Error(t, err)
+	assert.Equal(t, "tcp6", ipv6Network("tcp4"))
+	assert.Equal(t, "udp6", ipv6Network("udp"))
+	assert.Equal(t, "unix", ipv6Network("unix"))
+
+	t.Setenv(envconfig.CWAGENT_IP_PREFERENCE, envconfig.IPPreferenceDualStack)
+	assert.Nil(t, newHTTPClient().Transport)
+}
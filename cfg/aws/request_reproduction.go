@@ -0,0 +1,233 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// LogDebugWithRequestReproduction is the level of aws_sdk_log_level logging a redacted reproduction of the AWS API
+// calls that fail. Unlike the levels of the AWS SDK, it only logs the failures, and never the credentials.
+const LogDebugWithRequestReproduction = "LogDebugWithRequestReproduction"
+
+// maxSummarizedPayload bounds the payloads decoded to summarize their fields.
+const maxSummarizedPayload = 1 << 20
+
+var reproduceFailedRequests atomic.Bool
+
+// redactedHeaders are left out of the reproduction. The signature is replaced by its scope, and curl signs the
+// request again with --aws-sigv4.
+var redactedHeaders = map[string]struct{}{
+	"Authorization":        {},
+	"X-Amz-Security-Token": {},
+	"X-Amz-Date":           {},
+	"X-Amz-Content-Sha256": {},
+	"Content-Length":       {},
+}
+
+// redactedQueryParameters are the signature and the credentials of the presigned URLs.
+var redactedQueryParameters = []string{"X-Amz-Signature", "X-Amz-Credential", "X-Amz-Security-Token"}
+
+// RequestReproductionHandler logs the endpoint, the proxy, the signature scope, the headers and a summary of the
+// payload of the AWS API calls that failed after their retries, with a curl command sending the same request, so
+// that the signature, endpoint and proxy issues can be diagnosed without a packet capture.
+var RequestReproductionHandler = request.NamedHandler{
+	Name: "cwagent.RequestReproductionHandler",
+	Fn: func(r *request.Request) {
+		if !reproduceFailedRequests.Load() || r.Error == nil || r.HTTPRequest == nil || r.HTTPRequest.URL == nil {
+			return
+		}
+		SDKLogger{}.Log(reproduceRequest(r))
+	},
+}
+
+func reproduceRequest(r *request.Request) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Failed AWS request %s.%s after %d retries", r.ClientInfo.ServiceName, operationName(r), r.RetryCount)
+	if r.HTTPResponse != nil {
+		fmt.Fprintf(&b, " with status %d", r.HTTPResponse.StatusCode)
+	}
+	if r.RequestID != "" {
+		fmt.Fprintf(&b, ", request ID %s", r.RequestID)
+	}
+	fmt.Fprintf(&b, ": %s", strings.NewReplacer("\n", " ", "\t", "").Replace(r.Error.Error()))
+	fmt.Fprintf(&b, "\n  endpoint: %s %s", r.HTTPRequest.Method, redactedURL(r.HTTPRequest.URL))
+	if proxy := requestProxy(r); proxy != nil {
+		fmt.Fprintf(&b, " via proxy %s", proxy.Redacted())
+	}
+	if scope, signedHeaders := signatureScope(r.HTTPRequest.Header.Get("Authorization")); scope != "" {
+		fmt.Fprintf(&b, "\n  signature: scope %s, signed headers %s", scope, signedHeaders)
+	} else {
+		b.WriteString("\n  signature: none")
+	}
+	payload := requestPayload(r)
+	fmt.Fprintf(&b, "\n  payload: %s", summarizePayload(payload, r.HTTPRequest.Header))
+	fmt.Fprintf(&b, "\n  reproduce with: %s", curlCommand(r, len(payload) > 0))
+	return b.String()
+}
+
+// redactedURL replaces the password of the URL, and the signature and the credentials of its query.
+func redactedURL(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for _, key := range redactedQueryParameters {
+		if query.Has(key) {
+			query.Set(key, "xxxxx")
+			redacted = true
+		}
+	}
+	if redacted {
+		copied := *u
+		copied.RawQuery = query.Encode()
+		u = &copied
+	}
+	return u.Redacted()
+}
+
+func operationName(r *request.Request) string {
+	if r.Operation == nil {
+		return ""
+	}
+	return r.Operation.Name
+}
+
+// requestProxy returns the proxy the HTTP client of the request sends it through, if any.
+func requestProxy(r *request.Request) *url.URL {
+	var roundTripper http.RoundTripper = http.DefaultTransport
+	if r.Config.HTTPClient != nil && r.Config.HTTPClient.Transport != nil {
+		roundTripper = r.Config.HTTPClient.Transport
+	}
+	transport, ok := roundTripper.(*http.Transport)
+	if !ok || transport.Proxy == nil {
+		return nil
+	}
+	proxy, err := transport.Proxy(r.HTTPRequest)
+	if err != nil {
+		return nil
+	}
+	return proxy
+}
+
+// signatureScope returns the date, region and service the request was signed for, and its signed headers, without
+// the access key and the signature.
+func signatureScope(authorization string) (string, string) {
+	var scope, signedHeaders string
+	for _, field := range strings.Split(strings.TrimPrefix(authorization, "AWS4-HMAC-SHA256 "), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch key {
+		case "Credential":
+			if _, s, ok := strings.Cut(value, "/"); ok {
+				scope = s
+			}
+		case "SignedHeaders":
+			signedHeaders = value
+		}
+	}
+	return scope, signedHeaders
+}
+
+func requestPayload(r *request.Request) []byte {
+	body := r.Body
+	if body == nil {
+		return nil
+	}
+	offset, err := body.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil
+	}
+	defer body.Seek(offset, io.SeekStart)
+	if _, err = body.Seek(0, io.SeekStart); err != nil {
+		return nil
+	}
+	payload, _ := io.ReadAll(body)
+	return payload
+}
+
+// summarizePayload describes the payload without its values, which can hold the data of the customer. A JSON object
+// is described by its fields, with the number of items of its arrays, and a form by its action.
+func summarizePayload(payload []byte, header http.Header) string {
+	if len(payload) == 0 {
+		return "empty"
+	}
+	summary := fmt.Sprintf("%d bytes", len(payload))
+	if encoding := header.Get("Content-Encoding"); encoding != "" {
+		return summary + ", " + encoding
+	}
+	if len(payload) > maxSummarizedPayload {
+		return summary
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &object); err == nil {
+		fields := make([]string, 0, len(object))
+		for key, value := range object {
+			var items []json.RawMessage
+			if json.Unmarshal(value, &items) == nil {
+				key = fmt.Sprintf("%s[%d]", key, len(items))
+			}
+			fields = append(fields, key)
+		}
+		sort.Strings(fields)
+		return summary + ", JSON with " + strings.Join(fields, ", ")
+	}
+	if strings.HasPrefix(header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(payload)); err == nil {
+			return fmt.Sprintf("%s, form with Action=%s and %d parameters", summary, form.Get("Action"), len(form))
+		}
+	}
+	return summary
+}
+
+// curlCommand returns a curl command sending the request again, signed with the credentials in the environment of
+// the shell. The payload is not logged, and is read from a file.
+func curlCommand(r *request.Request, hasPayload bool) string {
+	region := r.ClientInfo.SigningRegion
+	if region == "" {
+		region = aws.StringValue(r.Config.Region)
+	}
+	service := r.ClientInfo.SigningName
+	if service == "" {
+		service = r.ClientInfo.ServiceName
+	}
+	args := []string{
+		"curl", "-X", r.HTTPRequest.Method, shellQuote(redactedURL(r.HTTPRequest.URL)),
+		"--aws-sigv4", shellQuote("aws:amz:" + region + ":" + service),
+		"--user", `"$AWS_ACCESS_KEY_ID:$AWS_SECRET_ACCESS_KEY"`,
+	}
+	if r.HTTPRequest.Header.Get("X-Amz-Security-Token") != "" {
+		args = append(args, "-H", `"X-Amz-Security-Token: $AWS_SESSION_TOKEN"`)
+	}
+	if proxy := requestProxy(r); proxy != nil {
+		args = append(args, "--proxy", shellQuote(proxy.Redacted()))
+	}
+	keys := make([]string, 0, len(r.HTTPRequest.Header))
+	for key := range r.HTTPRequest.Header {
+		if _, ok := redactedHeaders[http.CanonicalHeaderKey(key)]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range r.HTTPRequest.Header[key] {
+			args = append(args, "-H", shellQuote(key+": "+value))
+		}
+	}
+	if hasPayload {
+		args = append(args, "--data-binary", "@payload")
+	}
+	return strings.Join(args, " ")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
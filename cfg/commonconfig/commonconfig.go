@@ -32,6 +32,7 @@ type CommonConfig struct {
 type Credentials struct {
 	CredentialProfile *string `toml:"shared_credential_profile"`
 	CredentialFile    *string `toml:"shared_credential_file"`
+	Region            *string `toml:"region"`
 }
 
 type Proxy struct {
@@ -69,6 +70,15 @@ func (c *CommonConfig) Parse(r io.Reader) error {
 	return nil
 }
 
+// Region returns the region set alongside the credentials, or an empty string.
+// It is kept out of CredentialsMap so it is not passed on as a credential option.
+func (c CommonConfig) Region() string {
+	if c.Credentials == nil || c.Credentials.Region == nil {
+		return ""
+	}
+	return *c.Credentials.Region
+}
+
 // Temporary functions to enable build
 // TODO: To be removed when all uses of commonconfig stop using map[string]string
 func (c CommonConfig) CredentialsMap() map[string]string {
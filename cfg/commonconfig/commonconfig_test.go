@@ -63,3 +63,16 @@ func TestSSLOnly(t *testing.T) {
 	config.Parse(strings.NewReader(contents))
 	assert.Equal(t, "{ca_bundle_file_path}", *config.SSL.CABundlePath)
 }
+
+func TestRegion(t *testing.T) {
+	contents := `
+				[credentials]
+					shared_credential_profile = "{profile_name}"
+					region = "us-west-2"
+				`
+	config := New()
+	config.Parse(strings.NewReader(contents))
+	assert.Equal(t, "us-west-2", config.Region())
+	assert.NotContains(t, config.CredentialsMap(), "region")
+	assert.Equal(t, "", New().Region())
+}
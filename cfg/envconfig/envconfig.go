@@ -12,15 +12,17 @@ import (
 
 const (
 	//the following are the names of environment variables
-	HTTP_PROXY                  = "HTTP_PROXY"         //nolint:revive
-	HTTPS_PROXY                 = "HTTPS_PROXY"        //nolint:revive
-	NO_PROXY                    = "NO_PROXY"           //nolint:revive
-	AWS_CA_BUNDLE               = "AWS_CA_BUNDLE"      //nolint:revive
-	AWS_SDK_LOG_LEVEL           = "AWS_SDK_LOG_LEVEL"  //nolint:revive
-	CWAGENT_USER_AGENT          = "CWAGENT_USER_AGENT" //nolint:revive
-	CWAGENT_LOG_LEVEL           = "CWAGENT_LOG_LEVEL"  //nolint:revive
-	CWAGENT_USAGE_DATA          = "CWAGENT_USAGE_DATA" //nolint:revive
-	IMDS_NUMBER_RETRY           = "IMDS_NUMBER_RETRY"  //nolint:revive
+	HTTP_PROXY                  = "HTTP_PROXY"               //nolint:revive
+	HTTPS_PROXY                 = "HTTPS_PROXY"              //nolint:revive
+	NO_PROXY                    = "NO_PROXY"                 //nolint:revive
+	AWS_CA_BUNDLE               = "AWS_CA_BUNDLE"            //nolint:revive
+	AWS_SDK_LOG_LEVEL           = "AWS_SDK_LOG_LEVEL"        //nolint:revive
+	CWAGENT_USER_AGENT          = "CWAGENT_USER_AGENT"       //nolint:revive
+	CWAGENT_LOG_LEVEL           = "CWAGENT_LOG_LEVEL"        //nolint:revive
+	CWAGENT_LOG_FORMAT          = "CWAGENT_LOG_FORMAT"       //nolint:revive
+	CWAGENT_LOG_ALLOWED_KEYS    = "CWAGENT_LOG_ALLOWED_KEYS" //nolint:revive
+	CWAGENT_USAGE_DATA          = "CWAGENT_USAGE_DATA"       //nolint:revive
+	IMDS_NUMBER_RETRY           = "IMDS_NUMBER_RETRY"        //nolint:revive
 	RunInContainer              = "RUN_IN_CONTAINER"
 	RunAsHostProcessContainer   = "RUN_AS_HOST_PROCESS_CONTAINER"
 	RunInAWS                    = "RUN_IN_AWS"
@@ -35,12 +37,53 @@ const (
 	CWOtelConfigContent         = "CW_OTEL_CONFIG_CONTENT"
 	CWAgentMergedOtelConfig     = "CWAGENT_MERGED_OTEL_CONFIG"
 	CWAgentLogsBackpressureMode = "CWAGENT_LOGS_BACKPRESSURE_MODE"
+	CWAgentStateDir             = "CWAGENT_STATE_DIR"
+	// AWSLambdaFunctionName is set by the Lambda runtime, for the function and its extensions
+	AWSLambdaFunctionName = "AWS_LAMBDA_FUNCTION_NAME"
 
 	// confused deputy prevention related headers
 	AmzSourceAccount = "AMZ_SOURCE_ACCOUNT" // populates the "x-amz-source-account" header
 	AmzSourceArn     = "AMZ_SOURCE_ARN"     // populates the "x-amz-source-arn" header
 )
 
+// the following tune the HTTP client of the AWS clients, the timeouts are in seconds
+const (
+	CWAGENT_HTTP_MAX_IDLE_CONNS          = "CWAGENT_HTTP_MAX_IDLE_CONNS"          //nolint:revive
+	CWAGENT_HTTP_MAX_IDLE_CONNS_PER_HOST = "CWAGENT_HTTP_MAX_IDLE_CONNS_PER_HOST" //nolint:revive
+	CWAGENT_HTTP_MAX_CONNS_PER_HOST      = "CWAGENT_HTTP_MAX_CONNS_PER_HOST"      //nolint:revive
+	CWAGENT_HTTP_DIAL_TIMEOUT            = "CWAGENT_HTTP_DIAL_TIMEOUT"            //nolint:revive
+	CWAGENT_HTTP_READ_TIMEOUT            = "CWAGENT_HTTP_READ_TIMEOUT"            //nolint:revive
+	CWAGENT_HTTP2                        = "CWAGENT_HTTP2"                        //nolint:revive
+	CWAGENT_HTTP_DNS_CACHE_TTL           = "CWAGENT_HTTP_DNS_CACHE_TTL"           //nolint:revive
+)
+
+// the following select the IP family of the AWS clients from the agent.ip_preference setting, the AWS_* are read by
+// the AWS SDKs
+const (
+	CWAGENT_IP_PREFERENCE                  = "CWAGENT_IP_PREFERENCE"                  //nolint:revive
+	AWS_USE_DUALSTACK_ENDPOINT             = "AWS_USE_DUALSTACK_ENDPOINT"             //nolint:revive
+	AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE = "AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE" //nolint:revive
+)
+
+const (
+	// IPPreferenceIPv4 is the default, the clients and the listeners use IPv4.
+	IPPreferenceIPv4 = "ipv4"
+	// IPPreferenceDualStack makes the clients use the dual-stack endpoints of the AWS services.
+	IPPreferenceDualStack = "dual_stack"
+	// IPPreferenceIPv6 makes the clients only use IPv6, including to reach IMDS, and the listeners bind IPv6 addresses.
+	IPPreferenceIPv6 = "ipv6"
+)
+
+// AWS_EC2_METADATA_V1_DISABLED is read by the AWS SDKs, when true the IMDS clients do not fall back to IMDSv1
+const AWS_EC2_METADATA_V1_DISABLED = "AWS_EC2_METADATA_V1_DISABLED" //nolint:revive
+
+// CWAGENT_HEALTH_ENDPOINT is the address the liveness and readiness probes are served on, from agent.health_endpoint
+const CWAGENT_HEALTH_ENDPOINT = "CWAGENT_HEALTH_ENDPOINT" //nolint:revive
+
+// CWAGENT_EXPORT_LAG_THRESHOLD is how long in seconds the export of a pipeline can lag its collection before a gap is
+// reported, from agent.export_lag_threshold
+const CWAGENT_EXPORT_LAG_THRESHOLD = "CWAGENT_EXPORT_LAG_THRESHOLD" //nolint:revive
+
 const (
 	// TrueValue is the expected string set on an environment variable to indicate true.
 	TrueValue = "True"
@@ -84,6 +127,24 @@ func IsRunningInROSA() bool {
 	return os.Getenv(RunInROSA) == TrueValue
 }
 
+func IsRunningInLambda() bool {
+	return os.Getenv(AWSLambdaFunctionName) != ""
+}
+
 func GetLogsBackpressureMode() string {
 	return os.Getenv(CWAgentLogsBackpressureMode)
 }
+
+// GetIPPreference returns the IP family preferred by the AWS clients, IPPreferenceIPv4 when it is not set.
+func GetIPPreference() string {
+	if preference := os.Getenv(CWAGENT_IP_PREFERENCE); preference != "" {
+		return preference
+	}
+	return IPPreferenceIPv4
+}
+
+// GetStateDir returns the directory the agent writes its state to instead of the install directory, e.g. so that it
+// can run with a read-only root filesystem. It is empty when it is not set.
+func GetStateDir() string {
+	return os.Getenv(CWAgentStateDir)
+}
@@ -42,6 +42,8 @@ func main() {
 
 	isNonInteractiveLinuxMigration := flag.Bool("isNonInteractiveLinuxMigration", false,
 		"If true, it will do the linux config migration. Default value is false.")
+	importLinuxMigrationState := flag.Bool("importLinuxMigrationState", false,
+		"If true, the linux config migration also imports the positions of the awslogs agent, so that the migrated log files are not uploaded again. Default value is false.")
 
 	tracesOnly := flag.Bool("tracesOnly", false, "If true, only trace configuration will be generated")
 	useParameterStore := flag.Bool("useParameterStore", false,
@@ -63,6 +65,8 @@ func main() {
 		config := new(data.Config)
 		ctx.HasExistingLinuxConfig = true
 		ctx.ConfigFilePath = *configFilePath
+		ctx.ImportLinuxMigrationState = *importLinuxMigrationState
+		ctx.ConfigOutputPath = *configOutputPath
 		if ctx.ConfigFilePath == "" {
 			ctx.ConfigFilePath = linux.DefaultFilePathLinuxConfiguration
 		}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	_ "net/http/pprof" // Comment this line to disable pprof endpoint.
@@ -24,6 +25,7 @@ import (
 	"github.com/influxdata/telegraf/agent"
 	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/logger"
+	"github.com/influxdata/telegraf/models"
 	"github.com/influxdata/telegraf/plugins/inputs"
 	"github.com/influxdata/telegraf/plugins/outputs"
 	"github.com/influxdata/wlog"
@@ -35,7 +37,18 @@ import (
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
 	"github.com/aws/amazon-cloudwatch-agent/cmd/amazon-cloudwatch-agent/internal"
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth"
+	agentstats "github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/useragent"
+	"github.com/aws/amazon-cloudwatch-agent/internal/agentid"
+	"github.com/aws/amazon-cloudwatch-agent/internal/configaudit"
+	"github.com/aws/amazon-cloudwatch-agent/internal/control"
+	"github.com/aws/amazon-cloudwatch-agent/internal/crashreport"
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
+	"github.com/aws/amazon-cloudwatch-agent/internal/health"
+	"github.com/aws/amazon-cloudwatch-agent/internal/loadgen"
+	"github.com/aws/amazon-cloudwatch-agent/internal/logrotate"
 	"github.com/aws/amazon-cloudwatch-agent/internal/mapstructure"
 	"github.com/aws/amazon-cloudwatch-agent/internal/merge/confmap"
 	"github.com/aws/amazon-cloudwatch-agent/internal/version"
@@ -94,6 +107,15 @@ var fServiceDisplayName = flag.String("service-display-name", "Telegraf Data Col
 var fRunAsConsole = flag.Bool("console", false, "run as console application (windows only)")
 var fSetEnv = flag.String("setenv", "", "set an env in the configuration file in the format of KEY=VALUE")
 var fStartUpErrorFile = flag.String("startup-error-file", "", "file to touch if agent can't start")
+var fBackfill = flag.Bool("backfill", false, "upload the log files already on disk, including rotated files, and exit")
+var fControl = flag.String("control", "", "list, pause or resume the sources of the running agent through its control socket, or fault and fault-clear to inject faults when agent.fault_injection is set")
+var fControlSource = flag.String("control-source", "", "source pattern to pause or resume, e.g. 'logfile:/var/log/app/*.log' or 'input:cpu'")
+var fControlFault = flag.String("control-fault", "", "fault to inject into the AWS API calls, e.g. 'kind=throttle&operation=PutLogEvents&rate=0.5&duration=5m'")
+var fDeadLetter = flag.String("dead-letter", "", "list, purge or replay the log events CloudWatch Logs rejected, which are kept when logs.dead_letter_queue is set")
+var fDeadLetterDir = flag.String("dead-letter-dir", paths.DeadLetterDir, "directory of the dead-letter store, when logs.dead_letter_queue.path is set")
+var fDeadLetterGroup = flag.String("dead-letter-group", "", "only list, purge or replay the records of the log groups matching the pattern, e.g. '/app/*'")
+var fDeadLetterRepair = flag.Bool("dead-letter-repair", false, "replay events outside the accepted time range with the current time, and truncate events that are too large")
+var fBackfillMaxAge = flag.Duration("backfill-max-age", 7*24*time.Hour, "only backfill files and events newer than this, at most 336h")
 
 var stop chan struct{}
 
@@ -104,6 +126,18 @@ func reloadLoop(
 	aggregatorFilters []string,
 	processorFilters []string,
 ) {
+	stopCrashReport, err := crashreport.Start(paths.CrashDir)
+	if err != nil {
+		log.Printf("W! Unable to keep the crash reports: %v", err)
+		stopCrashReport = func() {}
+	}
+	defer stopCrashReport()
+	log.Printf("I! Agent instance ID %s, run ID %s", agentid.InstanceID(), agentid.RunID())
+	agentstats.UsageFlags().SetValues(map[agentstats.Flag]any{
+		agentstats.FlagInstanceID: agentid.InstanceID(),
+		agentstats.FlagRunID:      agentid.RunID(),
+	})
+
 	reload := make(chan bool, 1)
 	reload <- true
 	for <-reload {
@@ -135,6 +169,7 @@ func reloadLoop(
 				select {
 				case <-profilerTicker.C:
 					profiler.Profiler.ReportAndClear()
+					profiler.Usage.LogTop(10)
 				case <-ctx.Done():
 					profiler.Profiler.ReportAndClear()
 					log.Printf("I! Profiler is stopped during shutdown\n")
@@ -187,7 +222,11 @@ func reloadLoop(
 					_ = f.Close()
 				}
 			}
-			log.Fatalf("E! Error running agent: %v", err)
+			code := errcode.Record(err)
+			log.Printf("E! Error running agent: %v %s", err, errcode.Tag(code))
+			// the agent did not crash, it failed to start
+			stopCrashReport()
+			os.Exit(errcode.ExitCode(code))
 		}
 	}
 }
@@ -248,12 +287,12 @@ func runAgent(ctx context.Context,
 
 	err = loadTomlConfigIntoAgent(c)
 	if err != nil {
-		return err
+		return errcode.New(errcode.Config, err)
 	}
 
 	err = validateAgentFinalConfigAndPlugins(c)
 	if err != nil {
-		return err
+		return errcode.New(errcode.Config, err)
 	}
 
 	ag, err := agent.NewAgent(c)
@@ -261,6 +300,22 @@ func runAgent(ctx context.Context,
 		return err
 	}
 
+	// The files the agent writes itself are rotated as set in agent.log_rotation.
+	if *fTomlConfig != "" {
+		rotation, err := logrotate.Load(*fTomlConfig)
+		if err != nil {
+			return errcode.New(errcode.Config, fmt.Errorf("invalid agent log_rotation: %w", err))
+		}
+		logrotate.Configure(rotation)
+		// the state of the configuration is only there when it was translated by config-translator
+		if state, err := configaudit.Load(configaudit.StatePath(*fTomlConfig)); err != nil {
+			log.Printf("W! Unable to report the configuration state: %v", err)
+		} else if state != nil {
+			configaudit.SetCurrent(state)
+			agentstats.UsageFlags().SetValue(agentstats.FlagConfigHash, state.Hash)
+		}
+	}
+
 	// Setup logging as configured.
 	logConfig := logger.LogConfig{
 		Debug:               ag.Config.Agent.Debug || *fDebug,
@@ -273,7 +328,12 @@ func runAgent(ctx context.Context,
 		LogWithTimezone:     "",
 	}
 
-	writer := logger.NewLogWriter(logConfig)
+	var writer io.Writer
+	if os.Getenv(envconfig.CWAGENT_LOG_FORMAT) == cwaLogger.LogFormatJSON {
+		writer = cwaLogger.NewStructuredLogWriter(logConfig, internal.ConfigKey)
+	} else {
+		writer = cwaLogger.NewTextLogWriter(logConfig)
+	}
 
 	log.Printf("I! Starting AmazonCloudWatchAgent %s with log file %s with log target %s\n", version.Full(), ag.Config.Agent.Logfile, ag.Config.Agent.LogTarget)
 	// Need to set SDK log level before plugins get loaded.
@@ -292,10 +352,13 @@ func runAgent(ctx context.Context,
 		testWaitDuration := time.Duration(*fTestWait) * time.Second
 		return ag.Test(ctx, testWaitDuration)
 	}
+	if *fBackfill {
+		return runBackfill(ctx, c)
+	}
 	if *fPidfile != "" {
 		f, err := os.OpenFile(*fPidfile, os.O_CREATE|os.O_WRONLY, 0644)
 		if err != nil {
-			log.Printf("E! Unable to create pidfile: %s", err)
+			log.Printf("E! Unable to create pidfile: %s", paths.ReadOnlyHint(err))
 		} else {
 			fmt.Fprintf(f, "%d\n", os.Getpid())
 
@@ -313,6 +376,19 @@ func runAgent(ctx context.Context,
 		log.Println("I! Running in ROSA")
 	}
 
+	go func() {
+		if err := control.Serve(ctx, paths.ControlSocketPath); err != nil {
+			log.Printf("W! Unable to start the control socket: %v", err)
+		}
+	}()
+	if endpoint := os.Getenv(envconfig.CWAGENT_HEALTH_ENDPOINT); endpoint != "" {
+		go func() {
+			if err := health.Serve(ctx, endpoint); err != nil {
+				log.Printf("W! Unable to serve the health probes: %v", err)
+			}
+		}()
+	}
+
 	if envconfig.IsSelinuxEnabled() {
 		log.Println("I! SELinux Status: Enabled")
 	}
@@ -331,11 +407,16 @@ func runAgent(ctx context.Context,
 			if errors.Is(err, os.ErrNotExist) {
 				log.Println("I! running in logs-only mode")
 				useragent.Get().SetComponents(&otelcol.Config{}, c)
+				// there are no pipelines to wait for
+				health.SetPipelinesReady(true)
 				return ag.Run(ctx)
 			}
 		}
 	}
 	// Else start OTEL and rely on adapter package to start the logfile plugin.
+	if allowedKeys := os.Getenv(envconfig.CWAGENT_LOG_ALLOWED_KEYS); allowedKeys != "" {
+		cwaLogger.SetAllowedKeys(strings.Split(allowedKeys, ","))
+	}
 	level := cwaLogger.ConvertToAtomicLevel(wlog.LogLevel())
 	logger, loggerOptions := cwaLogger.NewLogger(writer, level)
 
@@ -343,7 +424,7 @@ func runAgent(ctx context.Context,
 	// try merging configs together, will return nil if nothing to merge
 	merged, err := mergeConfigs(otelConfigs)
 	if err != nil {
-		return err
+		return errcode.New(errcode.Config, err)
 	}
 	if merged != nil {
 		_ = os.Setenv(envconfig.CWAgentMergedOtelConfig, toyamlconfig.ToYamlConfig(merged.ToStringMap()))
@@ -365,7 +446,7 @@ func runAgent(ctx context.Context,
 
 	cfg, err := provider.Get(ctx, factories)
 	if err != nil {
-		return err
+		return errcode.New(errcode.Config, err)
 	}
 
 	if _, ok := os.LookupEnv(envconfig.CWAgentMergedOtelConfig); ok {
@@ -377,6 +458,10 @@ func runAgent(ctx context.Context,
 	}
 
 	useragent.Get().SetComponents(cfg, c)
+	// the agenthealth extension reports when the pipelines started, without it the pipelines are not waited for
+	if !hasAgentHealth(cfg) {
+		health.SetPipelinesReady(true)
+	}
 
 	params := getCollectorParams(factories, providerSettings, loggerOptions)
 	cmd := otelcol.NewCommand(params)
@@ -395,6 +480,105 @@ func runAgent(ctx context.Context,
 	return cmd.Execute()
 }
 
+func hasAgentHealth(cfg *otelcol.Config) bool {
+	for _, id := range cfg.Service.Extensions {
+		if id.Type() == agenthealth.TypeStr {
+			return true
+		}
+	}
+	return false
+}
+
+// runBackfill uploads the existing log files using only the log backends and returns once the
+// outputs have flushed everything that was read.
+func runBackfill(ctx context.Context, c *config.Config) error {
+	var backends []*models.RunningOutput
+	for _, output := range c.Outputs {
+		if _, ok := output.Output.(logs.LogBackend); !ok {
+			continue
+		}
+		if err := output.Init(); err != nil {
+			return fmt.Errorf("could not initialize output %s: %w", output.LogName(), err)
+		}
+		if err := output.Output.Connect(); err != nil {
+			return fmt.Errorf("could not connect output %s: %w", output.LogName(), err)
+		}
+		backends = append(backends, output)
+	}
+	if len(backends) == 0 {
+		return errors.New("no log outputs configured for backfill")
+	}
+
+	err := logs.NewLogAgent(c).Backfill(ctx, *fBackfillMaxAge)
+	for _, output := range backends {
+		log.Printf("I! Flushing output %s", output.LogName())
+		if closeErr := output.Output.Close(); closeErr != nil {
+			log.Printf("E! Error closing output %s: %v", output.LogName(), closeErr)
+		}
+	}
+	return err
+}
+
+// runLoadGen generates synthetic load through the pipelines of the running agent until the duration elapses or the
+// command is interrupted. It is left out of the usage since it is only meant for capacity planning in sandboxes.
+func runLoadGen(args []string) error {
+	cfg, err := loadgen.ParseFlags(args, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	return loadgen.Run(ctx, os.Stdout, cfg)
+}
+
+// runDeadLetter lists or purges the dead-letter store directly, so it works while the agent is stopped. Replays
+// go through the control socket, since the events are sent by the running agent.
+func runDeadLetter(w io.Writer, action string) error {
+	if action == control.ActionReplay {
+		output, err := control.Replay(paths.ControlSocketPath, *fDeadLetterGroup, *fDeadLetterRepair)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(w, output)
+		return err
+	}
+	match, err := deadletter.Match(*fDeadLetterGroup)
+	if err != nil {
+		return err
+	}
+	store := deadletter.NewStore(*fDeadLetterDir, 0)
+	var records []deadletter.Record
+	switch action {
+	case "list":
+		records, err = store.Records()
+		if err != nil {
+			return err
+		}
+	case "purge":
+		records, err = store.Take(match)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "Purged %d records from %s\n", len(records), store.Path())
+		return err
+	default:
+		return fmt.Errorf("unknown dead-letter action %q, must be list, purge or replay", action)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for _, record := range records {
+		if match != nil && !match(record) {
+			continue
+		}
+		if err = encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func getCollectorParams(factories otelcol.Factories, providerSettings otelcol.ConfigProviderSettings, loggingOptions []zap.Option) otelcol.CollectorSettings {
 	return otelcol.CollectorSettings{
 		Factories: func() (otelcol.Factories, error) {
@@ -530,6 +714,7 @@ func main() {
 			pprofHostPort = "http://" + pprofHostPort + "/debug/pprof"
 
 			log.Printf("I! Starting pprof HTTP server at: %s\n", pprofHostPort)
+			http.Handle(profiler.UsagePath, profiler.Usage)
 
 			if err := http.ListenAndServe(*pprofAddr, nil); err != nil {
 				log.Fatal("E! " + err.Error())
@@ -542,6 +727,23 @@ func main() {
 		case "version":
 			fmt.Println(version.Full())
 			return
+		case "components":
+			factories, err := defaultcomponents.Factories()
+			if err != nil {
+				log.Fatalf("E! Unable to load components: %v", err)
+			}
+			for _, apply := range registry.Options() {
+				apply(&factories)
+			}
+			if err = internal.PrintComponents(os.Stdout, internal.Components(factories), args[1:]...); err != nil {
+				log.Fatalf("E! Unable to print components: %v", err)
+			}
+			return
+		case "loadgen":
+			if err := runLoadGen(args[1:]); err != nil {
+				log.Fatalf("E! %v", err)
+			}
+			return
 		case "config":
 			config.PrintSampleConfig(
 				sectionFilters,
@@ -614,6 +816,24 @@ func main() {
 			}
 		}
 		return
+	case *fControl != "":
+		var output string
+		var err error
+		if *fControl == control.ActionFault {
+			output, err = control.Fault(paths.ControlSocketPath, *fControlFault)
+		} else {
+			output, err = control.Send(paths.ControlSocketPath, *fControl, *fControlSource)
+		}
+		if err != nil {
+			log.Fatalf("E! %v", err)
+		}
+		fmt.Print(output)
+		return
+	case *fDeadLetter != "":
+		if err := runDeadLetter(os.Stdout, *fDeadLetter); err != nil {
+			log.Fatalf("E! %v", err)
+		}
+		return
 	}
 
 	if runtime.GOOS == "windows" && windowsRunAsService() {
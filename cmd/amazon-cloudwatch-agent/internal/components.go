@@ -0,0 +1,251 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/influxdata/telegraf/plugins/outputs"
+	"go.opentelemetry.io/collector/otelcol"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/version"
+	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter"
+)
+
+const (
+	KindReceiver  = "receiver"
+	KindProcessor = "processor"
+	KindExporter  = "exporter"
+	KindExtension = "extension"
+	// KindInput is a telegraf input. Inputs under metrics_collected run as telegraf_<type> receivers.
+	KindInput = "input"
+	// KindOutput is a telegraf output.
+	KindOutput = "output"
+
+	mainModule     = "github.com/aws/amazon-cloudwatch-agent"
+	unknownVersion = "unknown"
+)
+
+// Component is a receiver, processor, exporter, extension or telegraf plugin compiled into the agent.
+type Component struct {
+	Kind    string
+	Type    string
+	Module  string
+	Version string
+	// JSONKeys are the agent JSON configuration keys that the translator turns into the component.
+	JSONKeys []string
+}
+
+// jsonKeys maps <kind>/<type> to the agent JSON configuration keys that enable the component.
+// Components that every pipeline of a kind uses, like batch, are left out.
+var jsonKeys = map[string][]string{
+	KindReceiver + "/awscontainerinsightreceiver":       {"logs.metrics_collected.kubernetes", "logs.metrics_collected.ecs"},
+	KindReceiver + "/awscontainerinsightskueuereceiver": {"logs.metrics_collected.kubernetes.kueue_container_insights"},
+	KindReceiver + "/awsxray":                           {"traces.traces_collected.xray"},
+	KindReceiver + "/events":                            {"logs.metrics_collected.events"},
+	KindReceiver + "/jmx":                               {"metrics.metrics_collected.jmx"},
+	KindReceiver + "/otlp": {
+		"metrics.metrics_collected.otlp",
+		"traces.traces_collected.otlp",
+		"logs.logs_collected.otlp",
+		"logs.metrics_collected.application_signals",
+		"traces.traces_collected.application_signals",
+	},
+	KindReceiver + "/prometheus": {"metrics.metrics_collected.prometheus"},
+	KindReceiver + "/tcplog":     {"logs.metrics_collected.emf", "logs.metrics_collected.structuredlog"},
+	KindReceiver + "/udplog":     {"logs.metrics_collected.emf", "logs.metrics_collected.structuredlog"},
+
+	KindProcessor + "/awsapplicationsignals": {"logs.metrics_collected.application_signals", "traces.traces_collected.application_signals"},
+	KindProcessor + "/ec2tagger":             {"metrics.append_dimensions"},
+	KindProcessor + "/emfvalidator":          {"logs.emf_metrics"},
+	KindProcessor + "/filter":                {"metrics.metric_transform.drop"},
+	KindProcessor + "/gpuattributes":         {"logs.metrics_collected.kubernetes.accelerated_compute_metrics"},
+	KindProcessor + "/kueueattributes":       {"logs.metrics_collected.kubernetes.kueue_container_insights"},
+	KindProcessor + "/logsdestination":       {"logs.logs_collected.otlp"},
+	KindProcessor + "/metricstransform":      {"metrics.metric_transform.transforms"},
+	KindProcessor + "/namespaceguard":        {"agent.allowed_namespaces"},
+	KindProcessor + "/rollup":                {"metrics.aggregation_dimensions"},
+	KindProcessor + "/tail_sampling":         {"traces.filter.drop_traces"},
+	KindProcessor + "/transform":             {"metrics.transform", "traces.transform"},
+
+	KindExporter + "/awscloudwatch":         {"metrics"},
+	KindExporter + "/awscloudwatchlogs":     {"logs.metrics_collected.emf", "logs.metrics_collected.structuredlog", "logs.metrics_collected.events", "logs.logs_collected.otlp"},
+	KindExporter + "/awsemf":                {"logs.metrics_collected.kubernetes", "logs.metrics_collected.ecs", "logs.metrics_collected.prometheus", "logs.metrics_collected.application_signals"},
+	KindExporter + "/awsxray":               {"traces"},
+	KindExporter + "/loadbalancing":         {"metrics.metrics_destinations.gateway"},
+	KindExporter + "/prometheusremotewrite": {"metrics.metrics_destinations.amp"},
+
+	KindExtension + "/awsproxy":     {"traces.traces_collected.application_signals"},
+	KindExtension + "/sharding":     {"logs.metrics_collected.prometheus.sharding"},
+	KindExtension + "/sigv4auth":    {"metrics.metrics_destinations.amp"},
+	KindExtension + "/standby":      {"agent.standby"},
+	KindExtension + "/xraysampling": {"traces.traces_collected.xray.tcp_proxy.sampling_debug"},
+
+	KindInput + "/auditd":             {"logs.logs_collected.auditd"},
+	KindInput + "/connection_summary": {"logs.logs_collected.connection_summary"},
+	KindInput + "/kernel_events":      {"logs.logs_collected.kernel_events"},
+	KindInput + "/logfile":            {"logs.logs_collected.files"},
+	KindInput + "/nvidia_smi":         {"metrics.metrics_collected.nvidia_gpu"},
+	KindInput + "/prometheus":         {"logs.metrics_collected.prometheus"},
+	KindInput + "/socket_listener":    {"metrics.metrics_collected.collectd"},
+	KindInput + "/win_perf_counters":  {"metrics.metrics_collected.<performance object>"},
+	KindInput + "/windows_event_log":  {"logs.logs_collected.windows_events"},
+
+	KindOutput + "/cloudwatchlogs":  {"logs.logs_collected"},
+	KindOutput + "/kinesislogs":     {"logs.kinesis"},
+	KindOutput + "/opensearch_logs": {"logs.opensearch"},
+}
+
+// Components lists the components in the factories and the registered telegraf plugins, sorted
+// by kind and type.
+func Components(factories otelcol.Factories) []Component {
+	modules := buildModules()
+	var result []Component
+	add := func(kind, typ string, v any) {
+		module, moduleVersion := moduleOf(modules, v)
+		result = append(result, Component{Kind: kind, Type: typ, Module: module, Version: moduleVersion, JSONKeys: configKeys(kind, typ)})
+	}
+	for typ, factory := range factories.Receivers {
+		add(KindReceiver, typ.String(), factory.CreateDefaultConfig())
+	}
+	for typ, factory := range factories.Processors {
+		add(KindProcessor, typ.String(), factory.CreateDefaultConfig())
+	}
+	for typ, factory := range factories.Exporters {
+		add(KindExporter, typ.String(), factory.CreateDefaultConfig())
+	}
+	for typ, factory := range factories.Extensions {
+		add(KindExtension, typ.String(), factory.CreateDefaultConfig())
+	}
+	for name, creator := range inputs.Inputs {
+		add(KindInput, name, creator())
+	}
+	for name, creator := range outputs.Outputs {
+		add(KindOutput, name, creator())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return kindOrder(result[i].Kind) < kindOrder(result[j].Kind)
+		}
+		return result[i].Type < result[j].Type
+	})
+	return result
+}
+
+// PrintComponents writes the components as a table. If filters are given, only the components
+// whose type or JSON keys contain one of them are written.
+func PrintComponents(w io.Writer, components []Component, filters ...string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tTYPE\tVERSION\tJSON KEYS")
+	for _, c := range components {
+		if !matches(c, filters) {
+			continue
+		}
+		keys := strings.Join(c.JSONKeys, ", ")
+		if keys == "" {
+			keys = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Kind, c.Type, c.Version, keys)
+	}
+	return tw.Flush()
+}
+
+func matches(c Component, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, filter := range filters {
+		if strings.Contains(c.Type, filter) {
+			return true
+		}
+		for _, key := range c.JSONKeys {
+			if strings.Contains(key, filter) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func kindOrder(kind string) int {
+	for i, k := range []string{KindReceiver, KindProcessor, KindExporter, KindExtension, KindInput, KindOutput} {
+		if k == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+// buildModules returns the versions of the modules compiled into the binary by path. Replaced
+// modules report the version of the replacement.
+func buildModules() map[string]string {
+	modules := map[string]string{mainModule: version.Number()}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return modules
+	}
+	for _, dep := range info.Deps {
+		if dep.Replace != nil && dep.Replace.Version != "" {
+			modules[dep.Path] = dep.Replace.Version
+		} else {
+			modules[dep.Path] = dep.Version
+		}
+	}
+	return modules
+}
+
+// moduleOf returns the module that defines the type of v and its version. It uses the longest
+// module path that contains the package of the type.
+func moduleOf(modules map[string]string, v any) (string, string) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.PkgPath() == "" {
+		return "", unknownVersion
+	}
+	pkgPath := t.PkgPath()
+	var module string
+	for path := range modules {
+		if (pkgPath == path || strings.HasPrefix(pkgPath, path+"/")) && len(path) > len(module) {
+			module = path
+		}
+	}
+	if module == "" {
+		return "", unknownVersion
+	}
+	return module, modules[module]
+}
+
+// ConfigKey returns the agent JSON configuration key of a component by kind and ID, e.g. exporter
+// and awsemf/containerinsights, or an empty string if there is not exactly one. Receivers adapted
+// from telegraf inputs are looked up as the input.
+func ConfigKey(kind, id string) string {
+	typ, _, _ := strings.Cut(id, "/")
+	if name, ok := strings.CutPrefix(typ, adapter.TelegrafPrefix); ok && kind == KindReceiver {
+		kind, typ = KindInput, name
+	}
+	keys := configKeys(kind, typ)
+	if len(keys) != 1 {
+		return ""
+	}
+	return keys[0]
+}
+
+// configKeys returns the JSON keys of a component. Inputs that are not mapped are assumed to be
+// configured under metrics_collected with their own name.
+func configKeys(kind, typ string) []string {
+	keys, ok := jsonKeys[kind+"/"+typ]
+	if !ok && kind == KindInput {
+		return []string{"metrics.metrics_collected." + typ}
+	}
+	return keys
+}
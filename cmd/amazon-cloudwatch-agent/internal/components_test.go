@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins"
+	"github.com/aws/amazon-cloudwatch-agent/service/defaultcomponents"
+)
+
+func TestComponents(t *testing.T) {
+	factories, err := defaultcomponents.Factories()
+	require.NoError(t, err)
+	components := Components(factories)
+
+	byKey := map[string]Component{}
+	for _, c := range components {
+		byKey[c.Kind+"/"+c.Type] = c
+	}
+	// every mapped component is compiled in
+	for key := range jsonKeys {
+		if key == KindInput+"/win_perf_counters" || key == KindInput+"/windows_event_log" || key == KindOutput+"/kinesislogs" {
+			continue
+		}
+		assert.Contains(t, byKey, key)
+	}
+
+	jmx := byKey[KindReceiver+"/jmx"]
+	assert.Equal(t, "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/jmxreceiver", jmx.Module)
+	assert.NotEqual(t, unknownVersion, jmx.Version)
+	assert.Equal(t, []string{"metrics.metrics_collected.jmx"}, jmx.JSONKeys)
+
+	agenthealth := byKey[KindExtension+"/agenthealth"]
+	assert.Equal(t, mainModule, agenthealth.Module)
+
+	assert.Equal(t, []string{"metrics.metrics_collected.mem"}, byKey[KindInput+"/mem"].JSONKeys)
+	assert.Equal(t, KindReceiver, components[0].Kind)
+	assert.Equal(t, KindOutput, components[len(components)-1].Kind)
+}
+
+func TestPrintComponents(t *testing.T) {
+	components := []Component{
+		{Kind: KindReceiver, Type: "jmx", Version: "v1.0.0", JSONKeys: []string{"metrics.metrics_collected.jmx"}},
+		{Kind: KindProcessor, Type: "batch", Version: "v1.0.0"},
+		{Kind: KindInput, Type: "mem", Version: "v2.0.0", JSONKeys: []string{"metrics.metrics_collected.mem"}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, PrintComponents(&buf, components))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, []string{"KIND", "TYPE", "VERSION", "JSON", "KEYS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"processor", "batch", "v1.0.0", "-"}, strings.Fields(lines[2]))
+
+	buf.Reset()
+	require.NoError(t, PrintComponents(&buf, components, "metrics_collected.jmx", "batch"))
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[1], "jmx")
+	assert.Contains(t, lines[2], "batch")
+}
+
+func TestConfigKey(t *testing.T) {
+	assert.Equal(t, "metrics.metrics_collected.jmx", ConfigKey(KindReceiver, "jmx/host"))
+	assert.Equal(t, "metrics.metrics_collected.cpu", ConfigKey(KindReceiver, "telegraf_cpu"))
+	assert.Equal(t, "metrics.metrics_collected.collectd", ConfigKey(KindInput, "socket_listener"))
+	assert.Equal(t, "metrics.metrics_destinations.amp", ConfigKey(KindExporter, "prometheusremotewrite/amp"))
+	// the otlp receiver is used by several sections
+	assert.Empty(t, ConfigKey(KindReceiver, "otlp/traces"))
+	assert.Empty(t, ConfigKey(KindProcessor, "batch/host"))
+}
@@ -143,7 +143,9 @@ func main() {
 
 	mode = sdkutil.DetectAgentMode(mode)
 
-	region, _ = util.DetectRegion(mode, cc.CredentialsMap())
+	if region = cc.Region(); region == "" {
+		region, _ = util.DetectRegion(mode, cc.CredentialsMap())
+	}
 
 	if region == "" && downloadLocation != locationDefault {
 		fmt.Println("Unable to determine aws-region.")
@@ -6,13 +6,20 @@ package main
 import (
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/internal/configaudit"
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
+	"github.com/aws/amazon-cloudwatch-agent/internal/logrotate"
 	userutil "github.com/aws/amazon-cloudwatch-agent/internal/util/user"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
@@ -21,11 +28,22 @@ import (
 )
 
 const (
-	exitErrorMessage   = "Configuration validation first phase failed. Agent version: %v. Verify the JSON input is only using features supported by this version.\n"
+	exitErrorMessage   = "Configuration validation first phase failed. Agent version: %v. Verify the JSON input is only using features supported by this version. %s\n"
 	exitSuccessMessage = "Configuration validation first phase succeeded"
 	version            = "1.0"
 	envConfigFileName  = "env-config.json"
 	yamlConfigFileName = "amazon-cloudwatch-agent.yaml"
+	// splitYamlConfigFileFormat is the name of the YAML config of the pipelines of the signals with -split-yaml.
+	splitYamlConfigFileFormat = "amazon-cloudwatch-agent-%s.yaml"
+	// pipelineGraphFileName is the graph of the pipelines of the YAML config with -pipeline-graph.
+	pipelineGraphFileName = "amazon-cloudwatch-agent-pipelines.json"
+)
+
+var (
+	// splitYaml also writes the pipelines of each signal into their own YAML config.
+	splitYaml bool
+	// pipelineGraph also writes the graph of the pipelines, with the hash of the config of their components.
+	pipelineGraph bool
 )
 
 func initFlags() {
@@ -36,6 +54,8 @@ func initFlags() {
 	var inputMode = flag.String("mode", "ec2", "Please provide the mode, i.e. ec2, onPremise, onPrem, auto")
 	var inputConfig = flag.String("config", "", "Please provide the common-config file")
 	var multiConfig = flag.String("multi-config", "remove", "valid values: default, append, remove")
+	flag.BoolVar(&splitYaml, "split-yaml", false, "Also write the pipelines of each signal into their own YAML config, e.g. amazon-cloudwatch-agent-logs.yaml, so that they can run in separate processes")
+	flag.BoolVar(&pipelineGraph, "pipeline-graph", false, "Also write the graph of the pipelines, with a hash of the config of each component, into amazon-cloudwatch-agent-pipelines.json, so that the configs of a fleet can be diffed")
 	flag.Parse()
 
 	ctx := context.CurrentContext()
@@ -56,6 +76,7 @@ func initFlags() {
 			log.Fatalf("E! Failed to parse common-config file %s with error: %v", *inputConfig, err)
 		}
 		ctx.SetCredentials(conf.CredentialsMap())
+		ctx.SetRegion(conf.Region())
 		ctx.SetProxy(conf.ProxyMap())
 		ctx.SetSSL(conf.SSLMap())
 		translatorUtil.LoadImdsRetries(conf.IMDS)
@@ -68,9 +89,35 @@ func initFlags() {
 	ctx.SetKubernetesMode(translatorUtil.DetectKubernetesMode(mode))
 }
 
+// recordConfigAudit writes an audit event when the merged json config changed since it was last translated. The
+// audit log is rotated like the agent log, as set in agent.log_rotation. Failing to record the change does not fail
+// the translation.
+func recordConfigAudit(ctx *context.Context, tomlConfigPath string) {
+	state := ctx.ConfigAudit()
+	if state == nil {
+		return
+	}
+	rotation, err := logrotate.Load(tomlConfigPath)
+	if err != nil {
+		log.Printf("W! Unable to record the configuration change, invalid agent log_rotation: %v", err)
+		return
+	}
+	logrotate.Configure(rotation)
+	writer := logrotate.NewWriter(paths.AuditLogFilePath)
+	defer writer.Close()
+	event, err := configaudit.Record(configaudit.StatePath(tomlConfigPath), writer, state, time.Now())
+	if err != nil {
+		log.Printf("W! Unable to record the configuration change: %v", paths.ReadOnlyHint(err))
+		return
+	}
+	if event != nil {
+		log.Printf("I! The configuration changed to %s, sections %s changed, recorded into %s", event.Hash, strings.Join(event.Changed, ", "), paths.AuditLogFilePath)
+	}
+}
+
 /**
  *	config-translator --input ${JSON} --input-dir ${JSON_DIR} --output ${TOML} --mode ${param_mode} --config ${COMMON_CONFIG}
- *  --multi-config [default|append|remove]
+ *  --multi-config [default|append|remove] [--split-yaml] [--pipeline-graph]
  *
  *		multi-config:
  *			default:	only process .tmp files
@@ -90,8 +137,8 @@ func main() {
 			for _, errMessage := range translator.ErrorMessages {
 				log.Println(errMessage)
 			}
-			log.Printf(exitErrorMessage, version)
-			os.Exit(1)
+			log.Printf(exitErrorMessage, version, errcode.Tag(errcode.Config))
+			os.Exit(errcode.ExitConfig)
 		}
 	}()
 	ctx := context.CurrentContext()
@@ -124,12 +171,46 @@ func main() {
 	if err != nil && !errors.Is(err, pipeline.ErrNoPipelines) {
 		log.Panicf("E! Failed to generate YAML configuration validation content: %v", err)
 	}
+	// the directory is only missing when the configuration is written under CWAGENT_STATE_DIR
+	if err = os.MkdirAll(tomlConfigDir, 0755); err != nil {
+		log.Panicf("E! Failed to create the configuration directory: %v", paths.ReadOnlyHint(err))
+	}
 	if err = cmdutil.ConfigToTomlFile(tomlConfig, tomlConfigPath); err != nil {
 		log.Panicf("E! Failed to create the configuration TOML validation file: %v", err)
 	}
 	if err = cmdutil.ConfigToYamlFile(yamlConfig, yamlConfigPath); err != nil {
 		log.Panicf("E! Failed to create the configuration YAML validation file: %v", err)
 	}
+	if splitYaml {
+		splitConfigs, err := cmdutil.TranslateJsonMapToSplitYamlConfigs(mergedJsonConfigMap)
+		if err != nil && !errors.Is(err, pipeline.ErrNoPipelines) {
+			log.Panicf("E! Failed to generate the split YAML configurations: %v", err)
+		}
+		for name, splitConfig := range splitConfigs {
+			splitConfigPath := filepath.Join(tomlConfigDir, fmt.Sprintf(splitYamlConfigFileFormat, name))
+			if err = cmdutil.ConfigToYamlFile(splitConfig, splitConfigPath); err != nil {
+				log.Panicf("E! Failed to create the split configuration YAML file %s: %v", splitConfigPath, err)
+			}
+			log.Printf("I! The %s pipelines have been written into %s", name, splitConfigPath)
+		}
+	}
+	if pipelineGraph {
+		graphPath := filepath.Join(tomlConfigDir, pipelineGraphFileName)
+		graph, err := cmdutil.TranslateJsonMapToPipelineGraph(mergedJsonConfigMap)
+		switch {
+		case errors.Is(err, pipeline.ErrNoPipelines):
+			// like the YAML config, there is no graph without pipelines
+			_ = os.Remove(graphPath)
+		case err != nil:
+			log.Panicf("E! Failed to generate the pipeline graph: %v", err)
+		default:
+			if err = cmdutil.PipelineGraphToJsonFile(graph, graphPath); err != nil {
+				log.Panicf("E! Failed to create the pipeline graph file %s: %v", graphPath, err)
+			}
+			log.Printf("I! The pipeline graph %s has been written into %s", graph.Hash, graphPath)
+		}
+	}
+	recordConfigAudit(ctx, tomlConfigPath)
 	log.Println(exitSuccessMessage)
 	// Put env config into the same folder as the toml config
 	envConfigPath := filepath.Join(tomlConfigDir, envConfigFileName)
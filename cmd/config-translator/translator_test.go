@@ -58,6 +58,19 @@ func TestAgentConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidAgent.json", false, expectedErrorMap)
 }
 
+func TestCredentialSetsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validCredentialSets.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["required"] = 1
+	expectedErrorMap["additional_property_not_allowed"] = 1
+	expectedErrorMap["string_gte"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidCredentialSets.json", false, expectedErrorMap)
+}
+
+func TestInstanceIdentityConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validInstanceIdentity.json", true, map[string]int{})
+}
+
 func TestTracesConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validTrace.json", true, map[string]int{})
 	expectedErrorMap := map[string]int{}
@@ -116,6 +129,8 @@ func TestMetricsConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validLinuxMetrics.json", true, map[string]int{})
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validWindowsMetrics.json", true, map[string]int{})
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validMetricsWithAppSignals.json", true, map[string]int{})
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validMetricsWithAppSignalsRulesFile.json", true, map[string]int{})
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validMetricsWithAppSignalsPrometheusRemoteWrite.json", true, map[string]int{})
 	expectedErrorMap := map[string]int{}
 	expectedErrorMap["invalid_type"] = 2
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidMetricsWithInvalidAggregationDimensions.json", false, expectedErrorMap)
@@ -165,6 +180,103 @@ func TestEthtoolConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validEthtoolConfig.json", true, map[string]int{})
 }
 
+func TestPrometheusTextfileConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validPrometheusTextfileConfig.json", true, map[string]int{})
+}
+
+func TestExecConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validExecConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["array_min_items"] = 1
+	expectedErrorMap["enum"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidExecConfig.json", false, expectedErrorMap)
+}
+
+func TestFileStatsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validFileStatsConfig.json", true, map[string]int{})
+}
+
+func TestCertificatesConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validCertificatesConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["number_any_of"] = 1
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidCertificatesConfig.json", false, expectedErrorMap)
+}
+
+func TestNetworkMeshConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validNetworkMeshConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["number_one_of"] = 1
+	expectedErrorMap["enum"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidNetworkMeshConfig.json", false, expectedErrorMap)
+}
+
+func TestHttpJsonConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validHttpJsonConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["pattern"] = 1
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidHttpJsonConfig.json", false, expectedErrorMap)
+}
+
+func TestJobHeartbeatConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validJobHeartbeatConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["string_gte"] = 1
+	expectedErrorMap["additional_property_not_allowed"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidJobHeartbeatConfig.json", false, expectedErrorMap)
+}
+
+func TestWindowsSecurityConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validWindowsSecurityConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["invalid_type"] = 1
+	expectedErrorMap["additional_property_not_allowed"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidWindowsSecurityConfig.json", false, expectedErrorMap)
+}
+
+func TestSmartConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validSmartConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["array_min_items"] = 1
+	expectedErrorMap["invalid_type"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidSmartConfig.json", false, expectedErrorMap)
+}
+
+func TestEMFMetricsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validEMFMetricsConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["number_not"] = 1
+	expectedErrorMap["enum"] = 1
+	expectedErrorMap["number_lte"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidEMFMetricsConfig.json", false, expectedErrorMap)
+}
+
+func TestOtlpLogsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validOtlpLogsConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["required"] = 1
+	expectedErrorMap["enum"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidOtlpLogsConfig.json", false, expectedErrorMap)
+}
+
+func TestMetricTransformConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validMetricTransformConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["number_one_of"] = 1
+	expectedErrorMap["enum"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidMetricTransformConfig.json", false, expectedErrorMap)
+}
+
+func TestEventsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validEventsConfig.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["required"] = 1
+	expectedErrorMap["string_gte"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidEventsConfig.json", false, expectedErrorMap)
+}
+
 func TestNvidiaGpuConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validNvidiaGpuConfig.json", true, map[string]int{})
 }
@@ -187,6 +299,19 @@ func TestMetricsDestinationsConfig(t *testing.T) {
 	expectedErrorMap["required"] = 1
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidMetricsDestinations.json", false, expectedErrorMap)
 }
+func TestPrometheusJobsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validPrometheusJobs.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["required"] = 1
+	expectedErrorMap["array_min_items"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidPrometheusJobs.json", false, expectedErrorMap)
+}
+func TestPrometheusMetricRelabelConfigs(t *testing.T) {
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["enum"] = 1
+	expectedErrorMap["additional_property_not_allowed"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidPrometheusMetricRelabelConfigs.json", false, expectedErrorMap)
+}
 func TestContainerInsightsJmxConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validContainerInsightsJmx.json", true, map[string]int{})
 }
@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/datadogmigration"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+func main() {
+	configDir := flag.String("configDir", datadogmigration.DefaultConfigDir, "The directory with datadog.yaml and the conf.d checks of the Datadog agent.")
+	configOutputPath := flag.String("configOutputPath", util.ConfigFilePath(), "Specifies where to write the converted configuration file.")
+	flag.Parse()
+
+	result, err := datadogmigration.Convert(*configDir)
+	if err != nil {
+		fmt.Println("Unable to convert the Datadog agent config:", err)
+		os.Exit(1)
+	}
+	util.SaveResultByteArrayToJsonFile(util.SerializeResultMapToJsonByteArray(result.Config), *configOutputPath)
+	if len(result.Unsupported) > 0 {
+		fmt.Println("The following items of the Datadog agent config were not converted:")
+		for _, item := range result.Unsupported {
+			fmt.Println("  " + item)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/mockbackend"
+)
+
+func main() {
+	listen := flag.String("listen", "127.0.0.1:4566", "The address to serve the mock CloudWatch, CloudWatch Logs and X-Ray APIs on.")
+	flag.Parse()
+
+	log.Printf("I! Serving the mock backends on %s, the state is at %s", *listen, mockbackend.StatePath)
+	if err := http.ListenAndServe(*listen, mockbackend.New()); err != nil {
+		log.Fatalf("E! Unable to serve the mock backends: %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/privhelper"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+const helperArg = "-privileged-helper"
+
+// startHelper starts a copy of this binary that stays root and opens the logfile paths of the
+// configuration for the agent. It must be called before the user is changed. The helper exits
+// once the agent, which replaces this process, is gone.
+func startHelper(configMap map[string]any, runAsUser string) error {
+	patterns := helperPatterns(configMap)
+	if len(patterns) == 0 {
+		log.Printf("I! No log files configured, not starting the privileged helper")
+		return nil
+	}
+	u, err := user.Lookup(runAsUser)
+	if err != nil {
+		return err
+	}
+	if _, err = privhelper.NewAllowlist(patterns); err != nil {
+		return err
+	}
+	if err = os.Remove(paths.HelperSocketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: paths.HelperSocketPath, Net: "unix"})
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+	listener.SetUnlinkOnClose(false)
+	// ChangeUser hands the var directory, and with it the socket, to the agent user.
+	if err = os.Chmod(paths.HelperSocketPath, 0600); err != nil {
+		return err
+	}
+	f, err := listener.File()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(self, append([]string{helperArg, u.Uid}, patterns...)...)
+	cmd.ExtraFiles = []*os.File{f}
+	cmd.Stderr = os.Stderr
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	log.Printf("I! Started privileged helper (pid %d) for %d log file patterns", cmd.Process.Pid, len(patterns))
+	return os.Setenv(privhelper.EnvSocket, paths.HelperSocketPath)
+}
+
+// helperPatterns returns the file_path of every logfile file_config in the TOML configuration.
+func helperPatterns(configMap map[string]any) []string {
+	inputs, _ := configMap["inputs"].(map[string]any)
+	var patterns []string
+	for _, logfile := range tables(inputs["logfile"]) {
+		for _, fileConfig := range tables(logfile["file_config"]) {
+			if p, ok := fileConfig["file_path"].(string); ok && p != "" {
+				patterns = append(patterns, p)
+			}
+		}
+	}
+	return patterns
+}
+
+func tables(v any) []map[string]any {
+	switch t := v.(type) {
+	case []map[string]any:
+		return t
+	case []any:
+		var result []map[string]any
+		for _, e := range t {
+			if m, ok := e.(map[string]any); ok {
+				result = append(result, m)
+			}
+		}
+		return result
+	case map[string]any:
+		return []map[string]any{t}
+	}
+	return nil
+}
+
+// runHelper is the entry point of the helper process started by startHelper. The listening
+// socket is inherited as file descriptor 3.
+func runHelper(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: %s <uid> <pattern>...", helperArg)
+	}
+	uid, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+	allow, err := privhelper.NewAllowlist(args[1:])
+	if err != nil {
+		return err
+	}
+	l, err := net.FileListener(os.NewFile(3, "helper-socket"))
+	if err != nil {
+		return err
+	}
+	listener, ok := l.(*net.UnixListener)
+	if !ok {
+		return fmt.Errorf("inherited socket is not a unix listener")
+	}
+	listener.SetUnlinkOnClose(false)
+	parent := os.Getppid()
+	go func() {
+		for range time.Tick(time.Second) {
+			if os.Getppid() != parent {
+				listener.Close()
+				return
+			}
+		}
+	}()
+	return privhelper.Serve(listener, uid, allow)
+}
@@ -0,0 +1,36 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelperPatterns(t *testing.T) {
+	var configMap map[string]any
+	_, err := toml.Decode(`
+[agent]
+  privileged_helper = true
+  run_as_user = "cwagent"
+
+[inputs]
+  [[inputs.logfile]]
+    [[inputs.logfile.file_config]]
+      file_path = "/var/log/secure"
+    [[inputs.logfile.file_config]]
+      file_path = "/var/log/app/**.log"
+`, &configMap)
+	require.NoError(t, err)
+	assert.True(t, helperEnabled(configMap))
+	assert.Equal(t, []string{"/var/log/secure", "/var/log/app/**.log"}, helperPatterns(configMap))
+	assert.False(t, helperEnabled(map[string]any{}))
+	assert.Empty(t, helperPatterns(map[string]any{}))
+}
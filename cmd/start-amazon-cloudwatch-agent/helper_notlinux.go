@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+// +build !linux
+
+package main
+
+import (
+	"errors"
+	"log"
+)
+
+const helperArg = "-privileged-helper"
+
+func startHelper(map[string]any, string) error {
+	log.Printf("W! The privileged helper is only supported on Linux")
+	return nil
+}
+
+func runHelper([]string) error {
+	return errors.ErrUnsupported
+}
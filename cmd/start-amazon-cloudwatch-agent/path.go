@@ -1,8 +1,8 @@
 // Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
 // SPDX-License-Identifier: MIT
 
-//go:build linux || darwin
-// +build linux darwin
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
 
 package main
 
@@ -31,7 +31,7 @@ func startAgent(writer io.WriteCloser) error {
 			"-envconfig", paths.EnvConfigPath,
 		}
 		execArgs = append(execArgs, config.GetOTELConfigArgs(paths.CONFIG_DIR_IN_CONTAINER)...)
-		execArgs = append(execArgs, "-pidfile", paths.AgentDir+"/var/amazon-cloudwatch-agent.pid")
+		execArgs = append(execArgs, "-pidfile", paths.PidFilePath)
 		if err := syscall.Exec(paths.AgentBinaryPath, execArgs, os.Environ()); err != nil {
 			return fmt.Errorf("error exec as agent binary: %w", err)
 		}
@@ -48,6 +48,13 @@ func startAgent(writer io.WriteCloser) error {
 	runAsUser, _ := user.DetectRunAsUser(configMap)
 	log.Printf("I! Detected runAsUser: %v", runAsUser)
 
+	if runAsUser != "" && runAsUser != "root" && helperEnabled(configMap) {
+		if err = startHelper(configMap, runAsUser); err != nil {
+			log.Printf("E! Failed to start privileged helper: %v ", err)
+			return err
+		}
+	}
+
 	_, err = user.ChangeUser(runAsUser)
 	if err != nil {
 		log.Printf("E! Failed to ChangeUser: %v ", err)
@@ -72,7 +79,7 @@ func startAgent(writer io.WriteCloser) error {
 		"-envconfig", paths.EnvConfigPath,
 	}
 	agentCmd = append(agentCmd, config.GetOTELConfigArgs(paths.ConfigDirPath)...)
-	agentCmd = append(agentCmd, "-pidfile", paths.AgentDir+"/var/amazon-cloudwatch-agent.pid")
+	agentCmd = append(agentCmd, "-pidfile", paths.PidFilePath)
 	if err = syscall.Exec(name, agentCmd, os.Environ()); err != nil {
 		// log file is closed, so use fmt here
 		fmt.Printf("E! Exec failed: %v \n", err)
@@ -82,6 +89,12 @@ func startAgent(writer io.WriteCloser) error {
 	return nil
 }
 
+func helperEnabled(configMap map[string]any) bool {
+	agent, _ := configMap["agent"].(map[string]any)
+	enabled, _ := agent["privileged_helper"].(bool)
+	return enabled
+}
+
 func getTOMLConfigMap() (map[string]any, error) {
 	f, err := os.Open(paths.TomlConfigPath)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"os/exec"
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/config"
 	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
 )
@@ -47,6 +48,9 @@ func startAgent(writer io.WriteCloser) error {
 		cmd.Stderr = os.Stderr
 		err := cmd.Run()
 		fmt.Printf("%s \n", err)
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return errcode.New(errcode.FromExitCode(exitErr.ExitCode()), err)
+		}
 		return err
 	}
 
@@ -12,10 +12,10 @@ import (
 	"os/exec"
 	"syscall"
 
-	"gopkg.in/natefinch/lumberjack.v2"
-
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
 	"github.com/aws/amazon-cloudwatch-agent/internal/constants"
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
+	"github.com/aws/amazon-cloudwatch-agent/internal/logrotate"
 	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
 )
 
@@ -41,6 +41,7 @@ func translateConfig() error {
 					log.Printf("I! No json config files found, please provide config, exit now\n")
 					os.Exit(0)
 				}
+				return errcode.New(errcode.FromExitCode(status.ExitStatus()), err)
 			}
 		} else {
 			log.Printf("Return other error: %s\n", err)
@@ -51,22 +52,27 @@ func translateConfig() error {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == helperArg {
+		if err := runHelper(os.Args[2:]); err != nil {
+			log.Fatalf("E! Privileged helper failed: %v", err)
+		}
+		return
+	}
+
 	var writer io.WriteCloser
 
 	if !envconfig.IsRunningInContainer() {
-		writer = &lumberjack.Logger{
-			Filename:   paths.AgentLogFilePath,
-			MaxSize:    100, //MB
-			MaxBackups: 5,   //backup files
-			MaxAge:     7,   //days
-			Compress:   true,
-		}
+		// The configuration is not translated yet, so the few lines logged before the agent starts use the default
+		// rotation, and the agent applies agent.log_rotation to the same file.
+		writer = logrotate.NewWriter(paths.AgentLogFilePath)
 
 		log.SetOutput(writer)
 	}
 
 	if err := translateConfig(); err != nil {
-		log.Fatalf("E! Cannot translate JSON, ERROR is %v \n", err)
+		code := errcode.Classify(err)
+		log.Printf("E! Cannot translate JSON, ERROR is %v %s\n", err, errcode.Tag(code))
+		os.Exit(errcode.ExitCode(code))
 	}
 	log.Printf("I! Config has been translated into TOML %s \n", paths.TomlConfigPath)
 	printFileContents(paths.TomlConfigPath)
@@ -74,8 +80,9 @@ func main() {
 	printFileContents(paths.YamlConfigPath)
 
 	if err := startAgent(writer); err != nil {
-		log.Printf("E! Error when starting Agent, Error is %v \n", err)
-		os.Exit(1)
+		code := errcode.Classify(err)
+		log.Printf("E! Error when starting Agent, Error is %v %s\n", err, errcode.Tag(code))
+		os.Exit(errcode.ExitCode(code))
 	}
 }
 
@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/telegrafmigration"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+func main() {
+	configFilePath := flag.String("configFilePath", telegrafmigration.DefaultConfigFilePath, "The path of the Telegraf config file.")
+	configOutputPath := flag.String("configOutputPath", util.ConfigFilePath(), "Specifies where to write the converted configuration file.")
+	flag.Parse()
+
+	result, err := telegrafmigration.Convert(*configFilePath)
+	if err != nil {
+		fmt.Println("Unable to convert the Telegraf config:", err)
+		os.Exit(1)
+	}
+	util.SaveResultByteArrayToJsonFile(util.SerializeResultMapToJsonByteArray(result.Config), *configOutputPath)
+	if len(result.Unsupported) > 0 {
+		fmt.Println("The following items of the Telegraf config were not converted:")
+		for _, item := range result.Unsupported {
+			fmt.Println("  " + item)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlpfileexporter
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type Config struct {
+	// Directory is where the OTLP files are written. Each signal is written to its own files.
+	Directory string `mapstructure:"directory"`
+	// MaxFileSizeMiB is the size at which the current file is completed and a new one is started.
+	MaxFileSizeMiB int `mapstructure:"max_file_size_mib"`
+	// MaxFiles is the number of completed files kept per signal before the oldest is removed.
+	// Set to 0 to keep every file.
+	MaxFiles int `mapstructure:"max_files"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c.Directory == "" {
+		return errors.New("directory must be set")
+	}
+	if c.MaxFileSizeMiB <= 0 {
+		return errors.New("max_file_size_mib must be greater than 0")
+	}
+	if c.MaxFiles < 0 {
+		return errors.New("max_files must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlpfileexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/otlpfile"
+)
+
+const bytesPerMiB = 1024 * 1024
+
+type fileExporter struct {
+	config *Config
+	signal otlpfile.Signal
+	writer *otlpfile.Writer
+
+	metricsMarshaler pmetric.ProtoMarshaler
+	tracesMarshaler  ptrace.ProtoMarshaler
+	logsMarshaler    plog.ProtoMarshaler
+}
+
+func (e *fileExporter) start(context.Context, component.Host) error {
+	writer, err := otlpfile.NewWriter(e.config.Directory, e.signal, int64(e.config.MaxFileSizeMiB)*bytesPerMiB, e.config.MaxFiles)
+	if err != nil {
+		return err
+	}
+	e.writer = writer
+	return nil
+}
+
+func (e *fileExporter) shutdown(context.Context) error {
+	if e.writer == nil {
+		return nil
+	}
+	return e.writer.Close()
+}
+
+func (e *fileExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
+	payload, err := e.metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.writer.Write(payload)
+}
+
+func (e *fileExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
+	payload, err := e.tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.writer.Write(payload)
+}
+
+func (e *fileExporter) pushLogs(_ context.Context, ld plog.Logs) error {
+	payload, err := e.logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.writer.Write(payload)
+}
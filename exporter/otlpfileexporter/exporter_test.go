@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlpfileexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/otlpfile"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, component.MustNewType(typeStr), factory.Type())
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.Equal(t, &Config{MaxFileSizeMiB: defaultMaxFileSizeMiB, MaxFiles: defaultMaxFiles}, cfg)
+	assert.Error(t, cfg.(*Config).Validate())
+}
+
+func TestExportMetrics(t *testing.T) {
+	dir := t.TempDir()
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Directory = dir
+	require.NoError(t, cfg.Validate())
+
+	exp, err := factory.CreateMetrics(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("test")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	files, err := otlpfile.Files(dir, otlpfile.SignalMetrics)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	var got []pmetric.Metrics
+	require.NoError(t, otlpfile.ReadFile(files[0], func(_ int, payload []byte) error {
+		read, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(payload)
+		got = append(got, read)
+		return err
+	}))
+	require.Len(t, got, 1)
+	assert.Equal(t, md, got[0])
+}
+
+func TestExportTraces(t *testing.T) {
+	dir := t.TempDir()
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Directory = dir
+
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	files, err := otlpfile.Files(dir, otlpfile.SignalTraces)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlpfileexporter
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/otlpfile"
+)
+
+const (
+	typeStr   = "otlp_file"
+	stability = component.StabilityLevelAlpha
+
+	defaultMaxFileSizeMiB = 64
+	defaultMaxFiles       = 100
+)
+
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		component.MustNewType(typeStr),
+		createDefaultConfig,
+		exporter.WithMetrics(createMetricsExporter, stability),
+		exporter.WithTraces(createTracesExporter, stability),
+		exporter.WithLogs(createLogsExporter, stability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxFileSizeMiB: defaultMaxFileSizeMiB,
+		MaxFiles:       defaultMaxFiles,
+	}
+}
+
+func createMetricsExporter(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	e, err := newFileExporter(cfg, otlpfile.SignalMetrics)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetrics(ctx, set, cfg, e.pushMetrics,
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithShutdown(e.shutdown),
+	)
+}
+
+func createTracesExporter(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	e, err := newFileExporter(cfg, otlpfile.SignalTraces)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewTraces(ctx, set, cfg, e.pushTraces,
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithShutdown(e.shutdown),
+	)
+}
+
+func createLogsExporter(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	e, err := newFileExporter(cfg, otlpfile.SignalLogs)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewLogs(ctx, set, cfg, e.pushLogs,
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithShutdown(e.shutdown),
+	)
+}
+
+func newFileExporter(cfg component.Config, signal otlpfile.Signal) (*fileExporter, error) {
+	eCfg, ok := cfg.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid configuration type: %T", cfg)
+	}
+	return &fileExporter{config: eCfg, signal: signal}, nil
+}
@@ -7,12 +7,22 @@ import (
 	"go.opentelemetry.io/collector/component"
 
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/useragent"
 )
 
 type Config struct {
 	IsUsageDataEnabled  bool               `mapstructure:"is_usage_data_enabled"`
 	Stats               *agent.StatsConfig `mapstructure:"stats,omitempty"`
 	IsStatusCodeEnabled bool               `mapstructure:"is_status_code_enabled,omitempty"`
+	// UserAgentAttribution is appended to the User-Agent of the requests, e.g. to identify the team owning the agent.
+	UserAgentAttribution string `mapstructure:"user_agent_attribution,omitempty"`
+	// IsFaultInjectionEnabled adds the handler injecting the faults set through the control socket, which is only
+	// set when agent.fault_injection is.
+	IsFaultInjectionEnabled bool `mapstructure:"is_fault_injection_enabled,omitempty"`
 }
 
 var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	return useragent.ValidateAttribution(cfg.UserAgentAttribution)
+}
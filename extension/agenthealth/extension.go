@@ -6,11 +6,14 @@ package agenthealth
 import (
 	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
 	"go.uber.org/zap"
 
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/faultinject"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/useragent"
+	"github.com/aws/amazon-cloudwatch-agent/internal/health"
 )
 
 type agentHealth struct {
@@ -21,10 +24,28 @@ type agentHealth struct {
 }
 
 var _ awsmiddleware.Extension = (*agentHealth)(nil)
+var _ extensioncapabilities.PipelineWatcher = (*agentHealth)(nil)
+
+// Ready is called by the collector once every pipeline started, which makes the agent ready for the readiness probe.
+func (ah *agentHealth) Ready() error {
+	health.SetPipelinesReady(true)
+	return nil
+}
+
+// NotReady is called by the collector before the pipelines shut down.
+func (ah *agentHealth) NotReady() error {
+	health.SetPipelinesReady(false)
+	return nil
+}
 
 func (ah *agentHealth) Handlers() ([]awsmiddleware.RequestHandler, []awsmiddleware.ResponseHandler) {
 	var responseHandlers []awsmiddleware.ResponseHandler
-	requestHandlers := []awsmiddleware.RequestHandler{useragent.NewHandler(ah.cfg.IsUsageDataEnabled)}
+	requestHandlers := []awsmiddleware.RequestHandler{
+		useragent.NewHandler(ah.cfg.IsUsageDataEnabled, ah.cfg.UserAgentAttribution),
+	}
+	if ah.cfg.IsFaultInjectionEnabled {
+		requestHandlers = append(requestHandlers, faultinject.NewHandler())
+	}
 
 	if !ah.cfg.IsUsageDataEnabled {
 		ah.logger.Debug("Usage data is disabled, skipping stats handlers")
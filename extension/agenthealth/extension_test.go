@@ -9,9 +9,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
 	"go.uber.org/zap"
 
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+	"github.com/aws/amazon-cloudwatch-agent/internal/health"
 )
 
 func TestExtension(t *testing.T) {
@@ -51,3 +53,21 @@ func TestExtensionStatusCodeOnly(t *testing.T) {
 	assert.Len(t, responseHandlers, 0)
 	assert.NoError(t, extension.Shutdown(ctx))
 }
+
+func TestExtensionFaultInjection(t *testing.T) {
+	extension := NewAgentHealth(zap.NewNop(), &Config{IsFaultInjectionEnabled: true})
+	requestHandlers, responseHandlers := extension.Handlers()
+	// user agent, fault injection
+	assert.Len(t, requestHandlers, 2)
+	assert.Len(t, responseHandlers, 0)
+}
+
+func TestExtensionPipelineWatcher(t *testing.T) {
+	extension := NewAgentHealth(zap.NewNop(), &Config{})
+	watcher, ok := extension.(extensioncapabilities.PipelineWatcher)
+	assert.True(t, ok)
+	assert.NoError(t, watcher.Ready())
+	assert.True(t, health.Ready().Checks[0].OK)
+	assert.NoError(t, watcher.NotReady())
+	assert.False(t, health.Ready().Checks[0].OK)
+}
@@ -0,0 +1,39 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinject
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/faultinject"
+)
+
+const handlerID = "cloudwatchagent.FaultInjection"
+
+type faultHandler struct {
+}
+
+var _ awsmiddleware.RequestHandler = (*faultHandler)(nil)
+
+func (fh *faultHandler) ID() string {
+	return handlerID
+}
+
+// Position is after the request is built, so that a failed request keeps its body and headers.
+func (fh *faultHandler) Position() awsmiddleware.HandlerPosition {
+	return awsmiddleware.After
+}
+
+// HandleRequest injects the faults set through the control socket, which does nothing unless the faultinjection
+// extension runs and a fault is set.
+func (fh *faultHandler) HandleRequest(ctx context.Context, r *http.Request) {
+	faultinject.Apply(ctx, awsmiddleware.GetOperationName(ctx), r)
+}
+
+func NewHandler() awsmiddleware.RequestHandler {
+	return &faultHandler{}
+}
@@ -0,0 +1,35 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinject
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/faultinject"
+)
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler()
+	assert.Equal(t, handlerID, handler.ID())
+	assert.Equal(t, awsmiddleware.After, handler.Position())
+
+	r := httptest.NewRequest(http.MethodPost, "https://logs.us-west-2.amazonaws.com/", nil)
+	handler.HandleRequest(context.Background(), r)
+	assert.Equal(t, "logs.us-west-2.amazonaws.com", r.URL.Host)
+
+	require.NoError(t, faultinject.Enable(faultinject.Limits{MaxLatency: time.Second, MaxDuration: time.Hour}))
+	defer faultinject.Disable()
+	require.NoError(t, faultinject.Set(faultinject.Fault{Kind: faultinject.KindError, Operation: "*"}))
+	handler.HandleRequest(context.Background(), r)
+	assert.NotEqual(t, "logs.us-west-2.amazonaws.com", r.URL.Host)
+	assert.Equal(t, "http", r.URL.Scheme)
+}
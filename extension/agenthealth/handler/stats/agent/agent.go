@@ -30,6 +30,9 @@ type Stats struct {
 	RegionType                *string           `json:"rt,omitempty"`
 	Mode                      *string           `json:"m,omitempty"`
 	EntityRejected            *int              `json:"ent,omitempty"`
+	InstanceID                *string           `json:"iid,omitempty"`
+	RunID                     *string           `json:"rid,omitempty"`
+	ConfigHash                *string           `json:"ch,omitempty"`
 	StatusCodes               map[string][5]int `json:"codes,omitempty"` //represents status codes 200,400,408,413,429,
 }
 
@@ -81,6 +84,15 @@ func (s *Stats) Merge(other Stats) {
 	if other.EntityRejected != nil {
 		s.EntityRejected = other.EntityRejected
 	}
+	if other.InstanceID != nil {
+		s.InstanceID = other.InstanceID
+	}
+	if other.RunID != nil {
+		s.RunID = other.RunID
+	}
+	if other.ConfigHash != nil {
+		s.ConfigHash = other.ConfigHash
+	}
 	if other.StatusCodes != nil {
 		if s.StatusCodes == nil {
 			s.StatusCodes = make(map[string][5]int)
@@ -35,6 +35,9 @@ func TestMerge(t *testing.T) {
 		RunningInContainer:        aws.Int(0),
 		RegionType:                aws.String("RegionType"),
 		Mode:                      aws.String("Mode"),
+		InstanceID:                aws.String("InstanceID"),
+		RunID:                     aws.String("RunID"),
+		ConfigHash:                aws.String("ConfigHash"),
 	})
 	assert.EqualValues(t, 1.5, *stats.CPUPercent)
 	assert.EqualValues(t, 133, *stats.MemoryBytes)
@@ -50,6 +53,9 @@ func TestMerge(t *testing.T) {
 	assert.EqualValues(t, 0, *stats.RunningInContainer)
 	assert.EqualValues(t, "RegionType", *stats.RegionType)
 	assert.EqualValues(t, "Mode", *stats.Mode)
+	assert.EqualValues(t, "InstanceID", *stats.InstanceID)
+	assert.EqualValues(t, "RunID", *stats.RunID)
+	assert.EqualValues(t, "ConfigHash", *stats.ConfigHash)
 }
 
 func TestMergeWithStatusCodes(t *testing.T) {
@@ -24,6 +24,9 @@ const (
 	FlagRunningInContainer
 	FlagMode
 	FlagRegionType
+	FlagInstanceID
+	FlagRunID
+	FlagConfigHash
 
 	flagIMDSFallbackSuccessStr       = "imds_fallback_success"
 	flagSharedConfigFallbackStr      = "shared_config_fallback"
@@ -32,6 +35,9 @@ const (
 	flagRunningInContainerStr        = "running_in_container"
 	flagModeStr                      = "mode"
 	flagRegionTypeStr                = "region_type"
+	flagInstanceIDStr                = "instance_id"
+	flagRunIDStr                     = "run_id"
+	flagConfigHashStr                = "config_hash"
 )
 
 type Flag int
@@ -55,6 +61,12 @@ func (f Flag) String() string {
 		return flagRunningInContainerStr
 	case FlagSharedConfigFallback:
 		return flagSharedConfigFallbackStr
+	case FlagInstanceID:
+		return flagInstanceIDStr
+	case FlagRunID:
+		return flagRunIDStr
+	case FlagConfigHash:
+		return flagConfigHashStr
 	}
 	return ""
 }
@@ -83,6 +95,12 @@ func (f *Flag) UnmarshalText(text []byte) error {
 		*f = FlagRunningInContainer
 	case flagSharedConfigFallbackStr:
 		*f = FlagSharedConfigFallback
+	case flagInstanceIDStr:
+		*f = FlagInstanceID
+	case flagRunIDStr:
+		*f = FlagRunID
+	case flagConfigHashStr:
+		*f = FlagConfigHash
 	default:
 		return fmt.Errorf("%w: %s", errUnsupportedFlag, s)
 	}
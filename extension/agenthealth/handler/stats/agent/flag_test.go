@@ -67,6 +67,9 @@ func TestFlag(t *testing.T) {
 		{flag: FlagRegionType, str: flagRegionTypeStr},
 		{flag: FlagRunningInContainer, str: flagRunningInContainerStr},
 		{flag: FlagSharedConfigFallback, str: flagSharedConfigFallbackStr},
+		{flag: FlagInstanceID, str: flagInstanceIDStr},
+		{flag: FlagRunID, str: flagRunIDStr},
+		{flag: FlagConfigHash, str: flagConfigHashStr},
 	}
 	for _, testCase := range testCases {
 		flag := testCase.flag
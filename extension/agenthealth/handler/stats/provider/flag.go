@@ -37,6 +37,9 @@ func (p *flagStats) update() {
 		RunningInContainer:        boolToInt(p.flagSet.IsSet(agent.FlagRunningInContainer)),
 		Mode:                      p.flagSet.GetString(agent.FlagMode),
 		RegionType:                p.flagSet.GetString(agent.FlagRegionType),
+		InstanceID:                p.flagSet.GetString(agent.FlagInstanceID),
+		RunID:                     p.flagSet.GetString(agent.FlagRunID),
+		ConfigHash:                p.flagSet.GetString(agent.FlagConfigHash),
 	})
 }
 
@@ -36,4 +36,9 @@ func TestFlagStats(t *testing.T) {
 	got = fs.getStats()
 	assert.NotNil(t, got.Mode)
 	assert.Equal(t, "test", *got.Mode)
+	fs.flagSet.SetValues(map[agent.Flag]any{agent.FlagInstanceID: "instance", agent.FlagRunID: "run", agent.FlagConfigHash: "hash"})
+	got = fs.getStats()
+	assert.Equal(t, "instance", *got.InstanceID)
+	assert.Equal(t, "run", *got.RunID)
+	assert.Equal(t, "hash", *got.ConfigHash)
 }
@@ -5,7 +5,9 @@ package useragent
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"regexp"
 
 	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
 	"go.uber.org/atomic"
@@ -14,12 +16,27 @@ import (
 const (
 	handlerID          = "cloudwatchagent.UserAgent"
 	headerKeyUserAgent = "User-Agent"
+	// attributionPrefix is the prefix of the AWS SDKs for the application ID of the User-Agent.
+	attributionPrefix = "app/"
 )
 
+// attributionPattern is the characters of a User-Agent token, limited to the length of an application ID.
+var attributionPattern = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+\\-.^_`|~]{1,50}$")
+
+// ValidateAttribution returns an error if the attribution cannot be appended to the User-Agent as a token.
+func ValidateAttribution(attribution string) error {
+	if attribution != "" && !attributionPattern.MatchString(attribution) {
+		return fmt.Errorf("invalid attribution %q: must be at most 50 letters, digits or symbols among !#$%%&'*+-.^_`|~", attribution)
+	}
+	return nil
+}
+
 type userAgentHandler struct {
 	userAgent          UserAgent
 	isUsageDataEnabled bool
-	header             *atomic.String
+	// attribution is appended to the header, so that the API calls can be attributed to their owner, e.g. a team.
+	attribution string
+	header      *atomic.String
 }
 
 var _ awsmiddleware.RequestHandler = (*userAgentHandler)(nil)
@@ -48,20 +65,27 @@ func (uah *userAgentHandler) Header() string {
 }
 
 func (uah *userAgentHandler) refreshHeader() {
-	uah.header.Store(uah.userAgent.Header(uah.isUsageDataEnabled))
+	header := uah.userAgent.Header(uah.isUsageDataEnabled)
+	if uah.attribution != "" {
+		header += separator + attributionPrefix + uah.attribution
+	}
+	uah.header.Store(header)
 }
 
-func newHandler(userAgent UserAgent, isUsageDataEnabled bool) *userAgentHandler {
+func newHandler(userAgent UserAgent, isUsageDataEnabled bool, attribution string) *userAgentHandler {
 	handler := &userAgentHandler{
 		userAgent:          userAgent,
 		header:             &atomic.String{},
 		isUsageDataEnabled: isUsageDataEnabled,
+		attribution:        attribution,
 	}
 	handler.refreshHeader()
 	userAgent.Listen(handler.refreshHeader)
 	return handler
 }
 
-func NewHandler(isUsageDataEnabled bool) awsmiddleware.RequestHandler {
-	return newHandler(Get(), isUsageDataEnabled)
+// NewHandler creates a handler setting the User-Agent of the agent, followed by the attribution if it is set. The
+// attribution is appended even when the usage data is disabled, since it is set by the operator.
+func NewHandler(isUsageDataEnabled bool, attribution string) awsmiddleware.RequestHandler {
+	return newHandler(Get(), isUsageDataEnabled, attribution)
 }
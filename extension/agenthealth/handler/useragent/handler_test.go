@@ -6,6 +6,7 @@ package useragent
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
@@ -18,7 +19,7 @@ import (
 func TestUserAgentHandler(t *testing.T) {
 	t.Setenv(envconfig.CWAGENT_USER_AGENT, "FirstUA")
 	ua := newUserAgent()
-	handler := newHandler(ua, true)
+	handler := newHandler(ua, true, "")
 	assert.Equal(t, handlerID, handler.ID())
 	assert.Equal(t, awsmiddleware.After, handler.Position())
 	req, err := http.NewRequest("", "localhost", nil)
@@ -30,3 +31,22 @@ func TestUserAgentHandler(t *testing.T) {
 	handler.HandleRequest(context.Background(), req)
 	assert.Equal(t, "SecondUA FirstUA", req.Header.Get(headerKeyUserAgent))
 }
+
+func TestUserAgentHandlerWithAttribution(t *testing.T) {
+	t.Setenv(envconfig.CWAGENT_USER_AGENT, "FirstUA")
+	handler := newHandler(newUserAgent(), false, "team-a")
+	req, err := http.NewRequest("", "localhost", nil)
+	require.NoError(t, err)
+	req.Header.Set(headerKeyUserAgent, "aws-sdk-go/1.0")
+	handler.HandleRequest(context.Background(), req)
+	assert.Equal(t, "FirstUA app/team-a aws-sdk-go/1.0", req.Header.Get(headerKeyUserAgent))
+}
+
+func TestValidateAttribution(t *testing.T) {
+	assert.NoError(t, ValidateAttribution(""))
+	assert.NoError(t, ValidateAttribution("team-a"))
+	assert.NoError(t, ValidateAttribution("Payments_Team.v2"))
+	assert.Error(t, ValidateAttribution("team a"))
+	assert.Error(t, ValidateAttribution("team/a"))
+	assert.Error(t, ValidateAttribution(strings.Repeat("a", 51)))
+}
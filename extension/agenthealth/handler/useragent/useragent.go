@@ -10,7 +10,6 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/google/uuid"
 	telegraf "github.com/influxdata/telegraf/config"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/jmxreceiver"
@@ -20,6 +19,7 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+	"github.com/aws/amazon-cloudwatch-agent/internal/agentid"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	"github.com/aws/amazon-cloudwatch-agent/internal/version"
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter"
@@ -199,7 +199,7 @@ func isRunningAsRoot() bool {
 
 func newUserAgent() *userAgent {
 	return &userAgent{
-		id:         uuid.NewString(),
+		id:         agentid.RunID(),
 		isRoot:     isRunningAsRoot(),
 		inputs:     collections.NewSet[string](),
 		processors: collections.NewSet[string](),
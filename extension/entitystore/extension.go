@@ -5,6 +5,8 @@ package entitystore
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -14,12 +16,13 @@ import (
 	"github.com/jellydator/ttlcache/v3"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
 	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
-	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/entityattributes"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
@@ -76,6 +79,17 @@ type EntityStore struct {
 	metadataprovider ec2metadataprovider.MetadataProvider
 
 	podTerminationCheckInterval time.Duration
+
+	// meterProvider is the collector's MeterProvider. The log file stats are reported with it because the
+	// logfile input runs outside the collector and the entity store is the extension it already uses. So are the
+	// retry stats and export watermarks of the outputs, some of which also run outside the collector, the IMDS call
+	// stats and the failures of the supervised components.
+	meterProvider    metric.MeterProvider
+	logSourceMetrics metric.Registration
+	retryMetrics     metric.Registration
+	imdsMetrics      metric.Registration
+	componentMetrics metric.Registration
+	watermarkMetrics metric.Registration
 }
 
 var _ extension.Extension = (*EntityStore)(nil)
@@ -109,12 +123,54 @@ func (e *EntityStore) Start(ctx context.Context, host component.Host) error {
 		// Starting the ttl cache will automatically evict all expired pods from the map
 		go e.StartPodToServiceEnvironmentMappingTtlCache()
 	}
+	if e.meterProvider != nil {
+		registration, err := selftelemetry.LogSources.RegisterMetrics(e.meterProvider)
+		if err != nil {
+			e.logger.Warn("Unable to report log file stats", zap.Error(err))
+		}
+		e.logSourceMetrics = registration
+		registration, err = selftelemetry.Retries.RegisterMetrics(e.meterProvider)
+		if err != nil {
+			e.logger.Warn("Unable to report output retry stats", zap.Error(err))
+		}
+		e.retryMetrics = registration
+		registration, err = selftelemetry.IMDS.RegisterMetrics(e.meterProvider)
+		if err != nil {
+			e.logger.Warn("Unable to report IMDS call stats", zap.Error(err))
+		}
+		e.imdsMetrics = registration
+		registration, err = selftelemetry.Components.RegisterMetrics(e.meterProvider)
+		if err != nil {
+			e.logger.Warn("Unable to report component failure stats", zap.Error(err))
+		}
+		e.componentMetrics = registration
+		registration, err = selftelemetry.Watermarks.RegisterMetrics(e.meterProvider)
+		if err != nil {
+			e.logger.Warn("Unable to report export watermarks", zap.Error(err))
+		}
+		e.watermarkMetrics = registration
+	}
 	e.ready.Store(true)
 	return nil
 }
 
 func (e *EntityStore) Shutdown(_ context.Context) error {
 	close(e.done)
+	if e.logSourceMetrics != nil {
+		_ = e.logSourceMetrics.Unregister()
+	}
+	if e.retryMetrics != nil {
+		_ = e.retryMetrics.Unregister()
+	}
+	if e.imdsMetrics != nil {
+		_ = e.imdsMetrics.Unregister()
+	}
+	if e.componentMetrics != nil {
+		_ = e.componentMetrics.Unregister()
+	}
+	if e.watermarkMetrics != nil {
+		_ = e.watermarkMetrics.Unregister()
+	}
 	if e.eksInfo != nil && e.eksInfo.podToServiceEnvMap != nil {
 		e.eksInfo.podToServiceEnvMap.Stop()
 	}
@@ -147,15 +203,25 @@ func (e *EntityStore) NativeCredentialExists() bool {
 }
 
 // CreateLogFileEntity creates the entity for log events that are being uploaded from a log file in the environment.
-func (e *EntityStore) CreateLogFileEntity(logFileGlob LogFileGlob, logGroupName LogGroupName) *cloudwatchlogs.Entity {
+// The file is the one of the glob the events are read from. On Kubernetes, the logs of a container are associated with
+// the service of its pod, unless the service of the file or log group is configured.
+func (e *EntityStore) CreateLogFileEntity(logFileGlob LogFileGlob, logGroupName LogGroupName, file string) *cloudwatchlogs.Entity {
 	if e.serviceprovider == nil {
 		return nil
 	}
 	serviceAttr := e.serviceprovider.logFileServiceAttribute(logFileGlob, logGroupName)
+	var namespace string
+	if serviceAttr.ServiceNameSource != ServiceNameSourceUserConfiguration && serviceAttr.ServiceNameSource != ServiceNameSourceInstrumentation {
+		if podAttr, podNamespace, ok := e.podServiceAttribute(file); ok {
+			serviceAttr = podAttr
+			namespace = podNamespace
+		}
+	}
 
 	keyAttributes := e.createServiceKeyAttributes(serviceAttr)
 	attributeMap := e.createAttributeMap()
 	addNonEmptyToMap(attributeMap, ServiceNameSourceKey, serviceAttr.ServiceNameSource)
+	addNonEmptyToMap(attributeMap, entityattributes.NamespaceField, namespace)
 	if _, ok := keyAttributes[entityattributes.AwsAccountId]; !ok {
 		return nil
 	}
@@ -165,6 +231,50 @@ func (e *EntityStore) CreateLogFileEntity(logFileGlob LogFileGlob, logGroupName
 	}
 }
 
+// podServiceAttribute returns the service of the pod a container log file belongs to, and its namespace, when the
+// metrics of the pod mapped it to a service.
+func (e *EntityStore) podServiceAttribute(file string) (ServiceAttribute, string, bool) {
+	if e.kubernetesMode == "" || e.eksInfo == nil || e.eksInfo.GetPodServiceEnvironmentMapping() == nil {
+		return ServiceAttribute{}, "", false
+	}
+	pod, namespace, ok := containerLogPod(file)
+	if !ok {
+		return ServiceAttribute{}, "", false
+	}
+	item := e.eksInfo.GetPodServiceEnvironmentMapping().Get(pod, ttlcache.WithDisableTouchOnHit[string, ServiceEnvironment]())
+	if item == nil || item.Value().ServiceName == "" {
+		return ServiceAttribute{}, "", false
+	}
+	serviceEnv := item.Value()
+	return ServiceAttribute{
+		ServiceName:       serviceEnv.ServiceName,
+		ServiceNameSource: serviceEnv.ServiceNameSource,
+		Environment:       serviceEnv.Environment,
+	}, namespace, true
+}
+
+// containerLogPod returns the pod and the namespace of a container log file from the names the kubelet gives them,
+// /var/log/containers/<pod>_<namespace>_<container>-<id>.log and /var/log/pods/<namespace>_<pod>_<uid>/<container>/<n>.log.
+// Neither the pods nor the namespaces can have an underscore in their name.
+func containerLogPod(file string) (string, string, bool) {
+	dir, name := filepath.Split(filepath.Clean(file))
+	if filepath.Base(dir) == "containers" {
+		parts := strings.Split(strings.TrimSuffix(name, ".log"), "_")
+		if len(parts) == 3 && parts[0] != "" && parts[1] != "" {
+			return parts[0], parts[1], true
+		}
+		return "", "", false
+	}
+	podDir := filepath.Dir(filepath.Clean(dir))
+	if filepath.Base(filepath.Dir(podDir)) == "pods" {
+		parts := strings.Split(filepath.Base(podDir), "_")
+		if len(parts) == 3 && parts[0] != "" && parts[1] != "" {
+			return parts[1], parts[0], true
+		}
+	}
+	return "", "", false
+}
+
 // GetMetricServiceNameAndSource gets the service name source for service metrics if not customer provided
 func (e *EntityStore) GetMetricServiceNameAndSource() (string, string) {
 	if e.serviceprovider == nil {
@@ -261,8 +371,7 @@ func (e *EntityStore) createServiceKeyAttributes(serviceAttr ServiceAttribute) m
 }
 
 var getMetaDataProvider = func() ec2metadataprovider.MetadataProvider {
-	mdCredentialConfig := &configaws.CredentialConfig{}
-	return ec2metadataprovider.NewMetadataProvider(mdCredentialConfig.Credentials(), retryer.GetDefaultRetryNumber())
+	return ec2metadataprovider.SharedMetadataProvider()
 }
 
 var getEC2Provider = func(region string, ec2CredentialConfig *configaws.CredentialConfig) ec2iface.EC2API {
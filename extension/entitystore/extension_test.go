@@ -18,6 +18,7 @@ import (
 	"github.com/jellydator/ttlcache/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"golang.org/x/exp/maps"
@@ -340,7 +341,7 @@ func TestEntityStore_createLogFileRID(t *testing.T) {
 		nativeCredential: &session.Session{},
 	}
 
-	entity := e.CreateLogFileEntity(glob, group)
+	entity := e.CreateLogFileEntity(glob, group, "file")
 
 	expectedEntity := cloudwatchlogs.Entity{
 		KeyAttributes: map[string]*string{
@@ -360,6 +361,116 @@ func TestEntityStore_createLogFileRID(t *testing.T) {
 	assert.Equal(t, dereferenceMap(expectedEntity.Attributes), dereferenceMap(entity.Attributes))
 }
 
+func TestEntityStore_createLogFileRID_ContainerLog(t *testing.T) {
+	accountId := "123456789012"
+	glob := LogFileGlob("/var/log/containers/*.log")
+	group := LogGroupName("group")
+	e := EntityStore{
+		mode:             config.ModeEC2,
+		kubernetesMode:   config.ModeEKS,
+		ec2Info:          EC2Info{InstanceID: "i-abcd1234", AccountID: accountId},
+		eksInfo:          newEKSInfo(zap.NewNop()),
+		nativeCredential: &session.Session{},
+	}
+	e.AddPodServiceEnvironmentMapping("checkout-7d9f8b-x2x4z", "checkout", "eks:cluster/shop", ServiceNameSourceK8sWorkload)
+
+	testCases := map[string]struct {
+		serviceAttr ServiceAttribute
+		file        string
+		wantKey     map[string]string
+		wantAttr    map[string]string
+	}{
+		"Mapped": {
+			serviceAttr: ServiceAttribute{ServiceName: ServiceNameUnknown, ServiceNameSource: ServiceNameSourceUnknown},
+			file:        "/var/log/containers/checkout-7d9f8b-x2x4z_shop_app-0123456789abcdef.log",
+			wantKey: map[string]string{
+				entityattributes.ServiceName:           "checkout",
+				entityattributes.DeploymentEnvironment: "eks:cluster/shop",
+				entityattributes.EntityType:            Service,
+				entityattributes.AwsAccountId:          accountId,
+			},
+			wantAttr: map[string]string{
+				ServiceNameSourceKey:            ServiceNameSourceK8sWorkload,
+				entityattributes.NamespaceField: "shop",
+			},
+		},
+		"Unmapped": {
+			serviceAttr: ServiceAttribute{ServiceName: ServiceNameUnknown, ServiceNameSource: ServiceNameSourceUnknown},
+			file:        "/var/log/containers/cart-5c6d7e-a1b2c_shop_app-0123456789abcdef.log",
+			wantKey: map[string]string{
+				entityattributes.ServiceName:  ServiceNameUnknown,
+				entityattributes.EntityType:   Service,
+				entityattributes.AwsAccountId: accountId,
+			},
+			wantAttr: map[string]string{
+				ServiceNameSourceKey: ServiceNameSourceUnknown,
+			},
+		},
+		"Configured": {
+			serviceAttr: ServiceAttribute{ServiceName: "payments", ServiceNameSource: ServiceNameSourceUserConfiguration},
+			file:        "/var/log/containers/checkout-7d9f8b-x2x4z_shop_app-0123456789abcdef.log",
+			wantKey: map[string]string{
+				entityattributes.ServiceName:  "payments",
+				entityattributes.EntityType:   Service,
+				entityattributes.AwsAccountId: accountId,
+			},
+			wantAttr: map[string]string{
+				ServiceNameSourceKey: ServiceNameSourceUserConfiguration,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sp := new(mockServiceProvider)
+			sp.On("logFileServiceAttribute", glob, group).Return(testCase.serviceAttr)
+			sp.On("getAutoScalingGroup").Return("")
+			e.serviceprovider = sp
+			entity := e.CreateLogFileEntity(glob, group, testCase.file)
+			require.NotNil(t, entity)
+			assert.Equal(t, testCase.wantKey, dereferenceMap(entity.KeyAttributes))
+			testCase.wantAttr[InstanceIDKey] = "i-abcd1234"
+			testCase.wantAttr[PlatformType] = EC2PlatForm
+			assert.Equal(t, testCase.wantAttr, dereferenceMap(entity.Attributes))
+		})
+	}
+}
+
+func TestContainerLogPod(t *testing.T) {
+	testCases := map[string]struct {
+		file          string
+		wantPod       string
+		wantNamespace string
+		wantOK        bool
+	}{
+		"Containers": {
+			file:          "/var/log/containers/checkout-7d9f8b-x2x4z_shop_app-0123456789abcdef.log",
+			wantPod:       "checkout-7d9f8b-x2x4z",
+			wantNamespace: "shop",
+			wantOK:        true,
+		},
+		"Pods": {
+			file:          "/var/log/pods/shop_checkout-7d9f8b-x2x4z_1b2c3d4e-0000-1111-2222-333344445555/app/0.log",
+			wantPod:       "checkout-7d9f8b-x2x4z",
+			wantNamespace: "shop",
+			wantOK:        true,
+		},
+		"Other": {
+			file: "/var/log/messages",
+		},
+		"OtherUnderContainers": {
+			file: "/var/log/containers/agent.log",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pod, namespace, ok := containerLogPod(testCase.file)
+			assert.Equal(t, testCase.wantOK, ok)
+			assert.Equal(t, testCase.wantPod, pod)
+			assert.Equal(t, testCase.wantNamespace, namespace)
+		})
+	}
+}
+
 func TestEntityStore_createLogFileRID_ServiceProviderIsEmpty(t *testing.T) {
 	instanceId := "i-abcd1234"
 	glob := LogFileGlob("glob")
@@ -370,7 +481,7 @@ func TestEntityStore_createLogFileRID_ServiceProviderIsEmpty(t *testing.T) {
 		nativeCredential: &session.Session{},
 	}
 
-	entity := e.CreateLogFileEntity(glob, group)
+	entity := e.CreateLogFileEntity(glob, group, "file")
 
 	assert.Nil(t, entity)
 }
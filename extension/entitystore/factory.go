@@ -43,8 +43,9 @@ func createExtension(_ context.Context, settings extension.Settings, cfg compone
 	mutex.Lock()
 	defer mutex.Unlock()
 	entityStore = &EntityStore{
-		logger: settings.Logger,
-		config: cfg.(*Config),
+		logger:        settings.Logger,
+		config:        cfg.(*Config),
+		meterProvider: settings.MeterProvider,
 	}
 	return entityStore, nil
 }
@@ -0,0 +1,30 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinjection
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type Config struct {
+	// MaxLatency bounds the latency a fault can add to a call.
+	MaxLatency time.Duration `mapstructure:"max_latency"`
+	// MaxDuration bounds how long a fault lasts, and is how long the faults set without a duration last.
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c.MaxLatency <= 0 {
+		return errors.New("max_latency must be positive")
+	}
+	if c.MaxDuration <= 0 {
+		return errors.New("max_duration must be positive")
+	}
+	return nil
+}
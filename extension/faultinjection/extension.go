@@ -0,0 +1,39 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinjection
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/faultinject"
+)
+
+// faultInjection lets the faults set through the control socket be injected into the AWS API calls of the agent
+// while it runs. Without it, the control socket refuses the faults, so that they cannot be set on an agent that was
+// not configured for testing.
+type faultInjection struct {
+	logger *zap.Logger
+	config *Config
+}
+
+var _ extension.Extension = (*faultInjection)(nil)
+
+func (f *faultInjection) Start(_ context.Context, _ component.Host) error {
+	if err := faultinject.Enable(faultinject.Limits{MaxLatency: f.config.MaxLatency, MaxDuration: f.config.MaxDuration}); err != nil {
+		return err
+	}
+	f.logger.Warn("Fault injection is enabled, the faults set through the control socket fail or delay the AWS API calls of the agent",
+		zap.Duration("max_latency", f.config.MaxLatency), zap.Duration("max_duration", f.config.MaxDuration))
+	return nil
+}
+
+// Shutdown clears the faults, so that they never outlive the configuration that allowed them.
+func (f *faultInjection) Shutdown(_ context.Context) error {
+	faultinject.Disable()
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinjection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/faultinject"
+)
+
+func TestExtension(t *testing.T) {
+	ext := &faultInjection{logger: zap.NewNop(), config: &Config{MaxLatency: time.Second, MaxDuration: time.Minute}}
+	assert.False(t, faultinject.Enabled())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	assert.True(t, faultinject.Enabled())
+
+	require.NoError(t, faultinject.Set(faultinject.Fault{Kind: faultinject.KindLatency, Operation: "*", Latency: time.Second}))
+	assert.Error(t, faultinject.Set(faultinject.Fault{Kind: faultinject.KindLatency, Operation: "*", Latency: time.Minute}), "above max_latency")
+	assert.Error(t, faultinject.Set(faultinject.Fault{Kind: faultinject.KindError, Operation: "*", Duration: time.Hour}), "above max_duration")
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+	assert.False(t, faultinject.Enabled())
+	assert.Empty(t, faultinject.Statuses())
+}
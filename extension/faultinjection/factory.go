@@ -0,0 +1,41 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinjection
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	defaultMaxLatency  = time.Minute
+	defaultMaxDuration = time.Hour
+)
+
+var (
+	TypeStr, _ = component.NewType("faultinjection")
+)
+
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		TypeStr,
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelDevelopment,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxLatency:  defaultMaxLatency,
+		MaxDuration: defaultMaxDuration,
+	}
+}
+
+func createExtension(_ context.Context, settings extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return &faultInjection{logger: settings.Logger, config: cfg.(*Config)}, nil
+}
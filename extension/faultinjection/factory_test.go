@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinjection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.Equal(t, &Config{MaxLatency: defaultMaxLatency, MaxDuration: defaultMaxDuration}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestCreateExtension(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	got, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(), cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func TestValidate(t *testing.T) {
+	assert.Error(t, (&Config{MaxDuration: defaultMaxDuration}).Validate())
+	assert.Error(t, (&Config{MaxLatency: defaultMaxLatency}).Validate())
+}
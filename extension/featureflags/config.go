@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package featureflags
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/featureflag"
+)
+
+type Config struct {
+	// Flags are the settings of the flags in the agent config, which the SSM parameter overrides.
+	Flags map[string]featureflag.Setting `mapstructure:"flags,omitempty"`
+	// SSMParameter is the name of the SSM parameter holding the settings of the flags of the fleet, as a JSON object
+	// of the same form as Flags.
+	SSMParameter string `mapstructure:"ssm_parameter,omitempty"`
+	// RefreshInterval is how often the SSM parameter is read.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+	// CachePath keeps the last settings read from the SSM parameter, which are used until the parameter is read
+	// again, e.g. when the agent restarts without access to SSM.
+	CachePath string `mapstructure:"cache_path,omitempty"`
+
+	Region   string `mapstructure:"region,omitempty"`
+	Profile  string `mapstructure:"profile,omitempty"`
+	RoleARN  string `mapstructure:"role_arn,omitempty"`
+	Filename string `mapstructure:"shared_credential_file,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	for name, setting := range c.Flags {
+		if err := setting.Validate(); err != nil {
+			return fmt.Errorf("flag %s: %w", name, err)
+		}
+	}
+	if c.SSMParameter != "" && c.RefreshInterval <= 0 {
+		return errors.New("refresh_interval must be positive")
+	}
+	return nil
+}
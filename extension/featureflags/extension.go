@@ -0,0 +1,201 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/featureflag"
+)
+
+const (
+	sourceConfig = "agent config"
+	sourceSSM    = "SSM parameter"
+)
+
+// parameterReader reads the settings of the flags of the fleet.
+type parameterReader interface {
+	Read() (map[string]featureflag.Setting, error)
+}
+
+// Resolver sets the feature flags of the agent from the agent config and the SSM parameter. The parameter is read
+// every refresh interval, so that a rollout or a rollback reaches the fleet without restarting the agents. While the
+// parameter cannot be read, the flags keep the settings last read from it, which are cached across restarts.
+type Resolver struct {
+	logger     *zap.Logger
+	config     *Config
+	reader     parameterReader
+	instanceID func() string
+
+	mu       sync.Mutex
+	id       string
+	fromSSM  map[string]featureflag.Setting
+	done     chan struct{}
+	wg       sync.WaitGroup
+	shutdown bool
+}
+
+var _ extension.Extension = (*Resolver)(nil)
+
+func newResolver(logger *zap.Logger, config *Config, reader parameterReader, instanceID func() string) *Resolver {
+	return &Resolver{
+		logger:     logger,
+		config:     config,
+		reader:     reader,
+		instanceID: instanceID,
+		done:       make(chan struct{}),
+	}
+}
+
+// Start applies the flags of the agent config and of the cached parameter before any data arrives, and starts
+// reading the parameter.
+func (r *Resolver) Start(_ context.Context, _ component.Host) error {
+	r.id = r.instanceID()
+	if r.reader == nil {
+		r.apply()
+		return nil
+	}
+	if cached, err := r.readCache(); err != nil {
+		r.logger.Warn("Unable to read the cached feature flags", zap.String("path", r.config.CachePath), zap.Error(err))
+	} else {
+		r.fromSSM = cached
+	}
+	r.apply()
+	r.wg.Add(1)
+	go r.run()
+	return nil
+}
+
+// Shutdown stops reading the parameter and resets the flags to their defaults, so that the flags removed from the
+// agent config do not outlive a reload.
+func (r *Resolver) Shutdown(_ context.Context) error {
+	r.mu.Lock()
+	if !r.shutdown {
+		r.shutdown = true
+		close(r.done)
+	}
+	r.mu.Unlock()
+	r.wg.Wait()
+	featureflag.Apply(r.id)
+	return nil
+}
+
+func (r *Resolver) run() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.config.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		r.refresh()
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh reads the parameter and applies it when it changed.
+func (r *Resolver) refresh() {
+	settings, err := r.reader.Read()
+	if err != nil {
+		r.logger.Warn("Unable to read the feature flags, keeping the last ones", zap.String("parameter", r.config.SSMParameter), zap.Error(err))
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if reflect.DeepEqual(settings, r.fromSSM) {
+		return
+	}
+	r.fromSSM = settings
+	r.apply()
+	if err = r.writeCache(settings); err != nil {
+		r.logger.Warn("Unable to cache the feature flags", zap.String("path", r.config.CachePath), zap.Error(err))
+	}
+}
+
+func (r *Resolver) apply() {
+	featureflag.Apply(r.id,
+		featureflag.Source{Name: sourceConfig, Settings: r.config.Flags},
+		featureflag.Source{Name: sourceSSM, Settings: r.fromSSM},
+	)
+}
+
+func (r *Resolver) readCache() (map[string]featureflag.Setting, error) {
+	if r.config.CachePath == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(r.config.CachePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseSettings(content)
+}
+
+// writeCache replaces the file with a rename, so that a crash never leaves partial settings behind.
+func (r *Resolver) writeCache(settings map[string]featureflag.Setting) error {
+	if r.config.CachePath == "" {
+		return nil
+	}
+	content, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(r.config.CachePath), 0755); err != nil {
+		return err
+	}
+	tmp := r.config.CachePath + ".tmp"
+	if err = os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.config.CachePath)
+}
+
+func parseSettings(content []byte) (map[string]featureflag.Setting, error) {
+	var settings map[string]featureflag.Setting
+	if err := json.Unmarshal(content, &settings); err != nil {
+		return nil, err
+	}
+	for name, setting := range settings {
+		if err := setting.Validate(); err != nil {
+			return nil, fmt.Errorf("flag %s: %w", name, err)
+		}
+	}
+	return settings, nil
+}
+
+// ssmReader reads the settings from an SSM parameter.
+type ssmReader struct {
+	client ssmiface.SSMAPI
+	name   string
+}
+
+func (s *ssmReader) Read() (map[string]featureflag.Setting, error) {
+	output, err := s.client.GetParameter(&ssm.GetParameterInput{Name: aws.String(s.name)})
+	if err != nil {
+		return nil, err
+	}
+	settings, err := parseSettings([]byte(aws.StringValue(output.Parameter.Value)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid feature flags in SSM parameter %s: %w", s.name, err)
+	}
+	return settings, nil
+}
@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/featureflag"
+)
+
+var (
+	testFlagA = featureflag.Register("test_featureflags_a", false, "test flag a")
+	testFlagB = featureflag.Register("test_featureflags_b", true, "test flag b")
+)
+
+type fakeReader struct {
+	mu       sync.Mutex
+	settings map[string]featureflag.Setting
+	err      error
+	reads    int
+}
+
+func (r *fakeReader) Read() (map[string]featureflag.Setting, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reads++
+	return r.settings, r.err
+}
+
+func (r *fakeReader) set(settings map[string]featureflag.Setting, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.settings, r.err = settings, err
+}
+
+func TestResolver(t *testing.T) {
+	cache := filepath.Join(t.TempDir(), "feature-flags.json")
+	reader := &fakeReader{err: errors.New("unreachable")}
+	cfg := &Config{
+		Flags: map[string]featureflag.Setting{
+			"test_featureflags_a": {Enabled: true},
+			"test_featureflags_b": {Enabled: true},
+		},
+		SSMParameter: "/cwagent/flags",
+		// refreshes are run by the test
+		RefreshInterval: time.Hour,
+		CachePath:       cache,
+	}
+	r := newResolver(zap.NewNop(), cfg, reader, func() string { return "instance" })
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.True(t, testFlagA.Enabled())
+	assert.True(t, testFlagB.Enabled())
+
+	reader.set(map[string]featureflag.Setting{"test_featureflags_b": {Enabled: false}}, nil)
+	r.refresh()
+	assert.True(t, testFlagA.Enabled())
+	assert.False(t, testFlagB.Enabled(), "the SSM parameter overrides the agent config")
+	content, err := os.ReadFile(cache)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"test_featureflags_b":{"enabled":false}}`, string(content))
+
+	// the last settings are kept while the parameter cannot be read
+	reader.set(nil, errors.New("unreachable"))
+	r.refresh()
+	assert.False(t, testFlagB.Enabled())
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.False(t, testFlagA.Enabled(), "the flags are reset on shutdown")
+	assert.True(t, testFlagB.Enabled())
+
+	// a restart without access to SSM uses the cached settings
+	r = newResolver(zap.NewNop(), cfg, reader, func() string { return "instance" })
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.False(t, testFlagB.Enabled())
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestResolverWithoutParameter(t *testing.T) {
+	cfg := &Config{Flags: map[string]featureflag.Setting{"test_featureflags_a": {Enabled: true}}}
+	r := newResolver(zap.NewNop(), cfg, nil, func() string { return "instance" })
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.True(t, testFlagA.Enabled())
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.False(t, testFlagA.Enabled())
+}
+
+type mockSSM struct {
+	ssmiface.SSMAPI
+	value *string
+}
+
+func (m *mockSSM) GetParameter(*ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+	if m.value == nil {
+		return nil, awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil)
+	}
+	return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: m.value}}, nil
+}
+
+func TestSSMReader(t *testing.T) {
+	client := &mockSSM{}
+	reader := &ssmReader{client: client, name: "/cwagent/flags"}
+	_, err := reader.Read()
+	assert.Error(t, err)
+
+	client.value = aws.String(`{"a": true, "b": {"enabled": true, "rollout_percent": 10}}`)
+	got, err := reader.Read()
+	require.NoError(t, err)
+	percent := 10.0
+	assert.Equal(t, map[string]featureflag.Setting{
+		"a": {Enabled: true},
+		"b": {Enabled: true, RolloutPercent: &percent},
+	}, got)
+
+	client.value = aws.String(`{"b": {"enabled": true, "rollout_percent": 200}}`)
+	_, err = reader.Read()
+	assert.Error(t, err)
+}
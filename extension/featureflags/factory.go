@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package featureflags
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/internal/agentid"
+)
+
+const defaultRefreshInterval = 5 * time.Minute
+
+var (
+	TypeStr, _ = component.NewType("featureflags")
+)
+
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		TypeStr,
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelAlpha,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		RefreshInterval: defaultRefreshInterval,
+	}
+}
+
+func createExtension(_ context.Context, settings extension.Settings, cfg component.Config) (extension.Extension, error) {
+	config := cfg.(*Config)
+	var reader parameterReader
+	if config.SSMParameter != "" {
+		credentialConfig := &configaws.CredentialConfig{
+			Region:   config.Region,
+			Profile:  config.Profile,
+			RoleARN:  config.RoleARN,
+			Filename: config.Filename,
+		}
+		client := ssm.New(credentialConfig.Credentials(), &aws.Config{
+			LogLevel: configaws.SDKLogLevel(),
+			Logger:   configaws.SDKLogger{},
+		})
+		reader = &ssmReader{client: client, name: config.SSMParameter}
+	}
+	return newResolver(settings.Logger, config, reader, agentid.InstanceID), nil
+}
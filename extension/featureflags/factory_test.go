@@ -0,0 +1,67 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package featureflags
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/featureflag"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.Equal(t, &Config{RefreshInterval: defaultRefreshInterval}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestCreateExtension(t *testing.T) {
+	original := paths.AgentIDPath
+	paths.AgentIDPath = filepath.Join(t.TempDir(), "agent-id.json")
+	t.Cleanup(func() { paths.AgentIDPath = original })
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	got, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(), cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, got.(*Resolver).reader)
+	assert.NoError(t, got.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, got.Shutdown(context.Background()))
+
+	cfg.SSMParameter = "/cwagent/flags"
+	got, err = NewFactory().Create(context.Background(), extensiontest.NewNopSettings(), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "/cwagent/flags", got.(*Resolver).reader.(*ssmReader).name)
+}
+
+func TestValidate(t *testing.T) {
+	invalid := 150.0
+	testCases := map[string]struct {
+		modify  func(*Config)
+		wantErr bool
+	}{
+		"Valid": {modify: func(*Config) {}},
+		"InvalidRollout": {modify: func(c *Config) {
+			c.Flags = map[string]featureflag.Setting{"a": {Enabled: true, RolloutPercent: &invalid}}
+		}, wantErr: true},
+		"MissingRefresh": {modify: func(c *Config) { c.SSMParameter = "/cwagent/flags"; c.RefreshInterval = 0 }, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			testCase.modify(cfg)
+			if testCase.wantErr {
+				assert.Error(t, cfg.Validate())
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
@@ -22,6 +22,10 @@ import (
 const (
 	deletionDelay              = 2 * time.Minute
 	jitterKubernetesAPISeconds = 10
+
+	// the keys of the EndpointSlices are stored again on every resync, and deleted when they are not within entryTTL
+	resyncPeriod = 10 * time.Minute
+	entryTTL     = 3 * resyncPeriod
 )
 
 type KubernetesMetadata struct {
@@ -55,12 +59,13 @@ func (e *KubernetesMetadata) Start(_ context.Context, _ component.Host) error {
 	// jitter calls to the kubernetes api (a precaution to prevent overloading api server)
 	jitterSleep(jitterKubernetesAPISeconds)
 
-	timedDeleter := &k8sclient.TimedDeleter{Delay: deletionDelay}
-	sharedInformerFactory := informers.NewSharedInformerFactory(clientset, 0)
+	timedDeleter := &k8sclient.TimedDeleter{Delay: deletionDelay, TTL: entryTTL}
+	sharedInformerFactory := informers.NewSharedInformerFactory(clientset, resyncPeriod)
 
 	e.endpointSliceWatcher = k8sclient.NewEndpointSliceWatcher(e.logger, sharedInformerFactory, timedDeleter)
 	e.safeStopCh = &k8sclient.SafeChannel{Ch: make(chan struct{}), Closed: false}
 
+	go timedDeleter.Run(e.safeStopCh.Ch)
 	e.endpointSliceWatcher.Run(e.safeStopCh.Ch)
 
 	e.endpointSliceWatcher.WaitForCacheSync(e.safeStopCh.Ch)
@@ -0,0 +1,34 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package localauth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// minRotationInterval leaves the clients time to read the secret before the one they use stops being accepted.
+const minRotationInterval = time.Minute
+
+type Config struct {
+	// SecretPath is the file the secret is written to, which only the user the agent runs as can read.
+	SecretPath string `mapstructure:"secret_path"`
+	// RotationInterval is how often a new secret is minted. The previous secret is accepted until the next rotation.
+	RotationInterval time.Duration `mapstructure:"rotation_interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c.SecretPath == "" {
+		return errors.New("secret_path must be set")
+	}
+	if c.RotationInterval < minRotationInterval {
+		return fmt.Errorf("rotation_interval must be at least %v", minRotationInterval)
+	}
+	return nil
+}
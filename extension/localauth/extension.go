@@ -0,0 +1,165 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package localauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.uber.org/zap"
+)
+
+const (
+	// authorizationHeader is matched regardless of case, as gRPC lowercases the metadata and HTTP canonicalizes
+	// the headers.
+	authorizationHeader = "Authorization"
+	bearerScheme        = "Bearer "
+	secretSize          = 32
+)
+
+var errUnauthenticated = errors.New("missing or invalid local auth secret")
+
+// Authenticator mints the shared secret the clients of the local endpoints present as a bearer token, so that only
+// the processes which can read the secret file, e.g. root, can send data to the agent on a shared host. A new
+// secret is minted every time the agent starts and every rotation interval, and never outlives the agent.
+type Authenticator struct {
+	logger *zap.Logger
+	config *Config
+
+	mu       sync.RWMutex
+	current  []byte
+	previous []byte
+	done     chan struct{}
+	wg       sync.WaitGroup
+	shutdown bool
+}
+
+var _ auth.Server = (*Authenticator)(nil)
+
+func newAuthenticator(logger *zap.Logger, config *Config) *Authenticator {
+	return &Authenticator{
+		logger: logger,
+		config: config,
+		done:   make(chan struct{}),
+	}
+}
+
+// Start mints the first secret before the receivers accept connections.
+func (a *Authenticator) Start(_ context.Context, _ component.Host) error {
+	if err := a.rotate(); err != nil {
+		return err
+	}
+	a.wg.Add(1)
+	go a.run()
+	return nil
+}
+
+// Shutdown stops the rotation and removes the secret file, since the secret is no longer accepted.
+func (a *Authenticator) Shutdown(_ context.Context) error {
+	a.mu.Lock()
+	if !a.shutdown {
+		a.shutdown = true
+		close(a.done)
+	}
+	a.mu.Unlock()
+	a.wg.Wait()
+	if err := os.Remove(a.config.SecretPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove the local auth secret %s: %w", a.config.SecretPath, err)
+	}
+	return nil
+}
+
+// Authenticate accepts the requests with the current or the previous secret as their bearer token.
+func (a *Authenticator) Authenticate(ctx context.Context, sources map[string][]string) (context.Context, error) {
+	for key, values := range sources {
+		if !strings.EqualFold(key, authorizationHeader) {
+			continue
+		}
+		for _, value := range values {
+			if len(value) > len(bearerScheme) && strings.EqualFold(value[:len(bearerScheme)], bearerScheme) &&
+				a.valid([]byte(value[len(bearerScheme):])) {
+				return ctx, nil
+			}
+		}
+	}
+	return ctx, errUnauthenticated
+}
+
+func (a *Authenticator) valid(token []byte) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	// both are compared, so that the time taken does not tell which one matched
+	current := subtle.ConstantTimeCompare(token, a.current)
+	previous := subtle.ConstantTimeCompare(token, a.previous)
+	return current|previous == 1
+}
+
+func (a *Authenticator) run() {
+	defer a.wg.Done()
+	ticker := time.NewTicker(a.config.RotationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.done:
+			return
+		case <-ticker.C:
+			if err := a.rotate(); err != nil {
+				a.logger.Error("Unable to rotate the local auth secret, the clients keep the current one", zap.Error(err))
+			}
+		}
+	}
+}
+
+// rotate accepts a new secret before writing it, so that the clients reading the file right away are not rejected.
+// The previous secret stays valid until the next rotation, for the clients which have not read the file yet.
+func (a *Authenticator) rotate() error {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return fmt.Errorf("unable to mint the local auth secret: %w", err)
+	}
+	encoded := []byte(hex.EncodeToString(secret))
+	a.mu.Lock()
+	a.previous, a.current = a.current, encoded
+	a.mu.Unlock()
+	if err := writeSecret(a.config.SecretPath, encoded); err != nil {
+		return fmt.Errorf("unable to write the local auth secret to %s: %w", a.config.SecretPath, err)
+	}
+	return nil
+}
+
+// writeSecret replaces the file with a rename, so that the clients never read a partial secret. The file is
+// restricted before the secret is written to it.
+func writeSecret(path string, secret []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err = restrict(f); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err = f.Write(secret); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,105 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package localauth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	a := newAuthenticator(zap.NewNop(), &Config{
+		SecretPath:       filepath.Join(t.TempDir(), "var", "secret"),
+		RotationInterval: time.Hour,
+	})
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = a.Shutdown(context.Background()) })
+	return a
+}
+
+func readSecret(t *testing.T, a *Authenticator) string {
+	content, err := os.ReadFile(a.config.SecretPath)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestAuthenticate(t *testing.T) {
+	a := newTestAuthenticator(t)
+	secret := readSecret(t, a)
+	assert.Len(t, secret, 2*secretSize)
+
+	testCases := map[string]struct {
+		sources map[string][]string
+		wantErr bool
+	}{
+		"HTTP":          {sources: map[string][]string{"Authorization": {"Bearer " + secret}}},
+		"GRPC":          {sources: map[string][]string{"authorization": {"bearer " + secret}}},
+		"SecondValue":   {sources: map[string][]string{"Authorization": {"Basic abc", "Bearer " + secret}}},
+		"Missing":       {sources: map[string][]string{"Content-Type": {"application/json"}}, wantErr: true},
+		"WrongSecret":   {sources: map[string][]string{"Authorization": {"Bearer " + secret[1:] + "0"}}, wantErr: true},
+		"EmptyToken":    {sources: map[string][]string{"Authorization": {"Bearer "}}, wantErr: true},
+		"NoScheme":      {sources: map[string][]string{"Authorization": {secret}}, wantErr: true},
+		"OtherHeader":   {sources: map[string][]string{"X-Token": {"Bearer " + secret}}, wantErr: true},
+		"NoAuthSources": {sources: nil, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := a.Authenticate(context.Background(), testCase.sources)
+			if testCase.wantErr {
+				assert.ErrorIs(t, err, errUnauthenticated)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	a := newTestAuthenticator(t)
+	first := readSecret(t, a)
+	require.NoError(t, a.rotate())
+	second := readSecret(t, a)
+	assert.NotEqual(t, first, second)
+
+	authenticate := func(secret string) error {
+		_, err := a.Authenticate(context.Background(), map[string][]string{"Authorization": {"Bearer " + secret}})
+		return err
+	}
+	// the clients which have not read the new secret yet are accepted until the next rotation
+	assert.NoError(t, authenticate(first))
+	assert.NoError(t, authenticate(second))
+	require.NoError(t, a.rotate())
+	assert.Error(t, authenticate(first))
+	assert.NoError(t, authenticate(second))
+	assert.NoError(t, authenticate(readSecret(t, a)))
+
+	entries, err := os.ReadDir(filepath.Dir(a.config.SecretPath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files are removed")
+}
+
+func TestSecretFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the file is restricted with an ACL")
+	}
+	a := newTestAuthenticator(t)
+	info, err := os.Stat(a.config.SecretPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	require.NoError(t, a.Shutdown(context.Background()))
+	_, err = os.Stat(a.config.SecretPath)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	// a second shutdown is a no-op
+	assert.NoError(t, a.Shutdown(context.Background()))
+}
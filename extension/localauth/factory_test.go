@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package localauth
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.Equal(t, &Config{RotationInterval: defaultRotationInterval}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestCreateExtension(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.SecretPath = filepath.Join(t.TempDir(), "secret")
+	got, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(), cfg)
+	assert.NoError(t, err)
+	assert.Implements(t, (*auth.Server)(nil), got)
+}
+
+func TestValidate(t *testing.T) {
+	testCases := map[string]struct {
+		modify  func(*Config)
+		wantErr bool
+	}{
+		"Valid":       {modify: func(*Config) {}},
+		"MissingPath": {modify: func(c *Config) { c.SecretPath = "" }, wantErr: true},
+		"ShortRotation": {modify: func(c *Config) {
+			c.RotationInterval = time.Second
+		}, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.SecretPath = "/tmp/secret"
+			testCase.modify(cfg)
+			if testCase.wantErr {
+				assert.Error(t, cfg.Validate())
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package localauth
+
+import "os"
+
+// restrict makes the file readable only by its owner, the user the agent runs as.
+func restrict(f *os.File) error {
+	return f.Chmod(0600)
+}
@@ -0,0 +1,30 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package localauth
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// protectedDACL grants Local System and the Administrators full access, and does not inherit the access of the
+// Users to the agent directory.
+const protectedDACL = "D:P(A;;FA;;;SY)(A;;FA;;;BA)"
+
+// restrict makes the file readable only by Local System and the Administrators.
+func restrict(f *os.File) error {
+	sd, err := windows.SecurityDescriptorFromString(protectedDACL)
+	if err != nil {
+		return err
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return err
+	}
+	return windows.SetSecurityInfo(windows.Handle(f.Fd()), windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION, nil, nil, dacl, nil)
+}
@@ -0,0 +1,41 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package sharding
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type Config struct {
+	// Peers is a DNS name that resolves to the addresses of all replicas, e.g. a Kubernetes
+	// headless service. It is resolved again every refresh interval.
+	Peers string `mapstructure:"peers,omitempty"`
+	// Replicas is a static list of all replicas, used instead of Peers.
+	Replicas []string `mapstructure:"replicas,omitempty"`
+	// Replica identifies this agent among the replicas. Defaults to the local address found in the
+	// replicas.
+	Replica string `mapstructure:"replica,omitempty"`
+	// RefreshInterval is how often Peers is resolved.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+	// Labels are the target labels hashed together with the job name to pick the replica.
+	Labels []string `mapstructure:"labels"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if (c.Peers == "") == (len(c.Replicas) == 0) {
+		return errors.New("exactly one of peers and replicas must be set")
+	}
+	if c.Peers != "" && c.RefreshInterval <= 0 {
+		return errors.New("refresh_interval must be positive")
+	}
+	if len(c.Labels) == 0 {
+		return errors.New("labels must not be empty")
+	}
+	return nil
+}
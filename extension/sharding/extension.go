@@ -0,0 +1,201 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package sharding
+
+import (
+	"context"
+	"hash/fnv"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+)
+
+// Sharder splits the scrape targets between the replicas of a deployment. Each target is owned by
+// the replica with the highest rendezvous hash of the replica and the target key, so when a
+// replica joins or leaves, only the targets it gains or loses move.
+//
+// The agent always counts itself as a replica. A replica that has just started therefore scrapes
+// its share of the targets before the others see it, which may scrape some targets twice for up
+// to one refresh interval instead of leaving them unscraped.
+type Sharder struct {
+	logger *zap.Logger
+	config *Config
+	ready  atomic.Bool
+
+	lookupHost     func(ctx context.Context, host string) ([]string, error)
+	interfaceAddrs func() ([]net.Addr, error)
+
+	mu      sync.RWMutex
+	replica string
+	members []string
+	changed chan struct{}
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ extension.Extension = (*Sharder)(nil)
+
+func newSharder(logger *zap.Logger, config *Config) *Sharder {
+	return &Sharder{
+		logger:         logger,
+		config:         config,
+		lookupHost:     net.DefaultResolver.LookupHost,
+		interfaceAddrs: net.InterfaceAddrs,
+		changed:        make(chan struct{}),
+	}
+}
+
+// Start resolves the replicas once before the receivers start, so the first scrape is already
+// sharded, and then keeps resolving them in the background.
+func (s *Sharder) Start(ctx context.Context, _ component.Host) error {
+	s.refresh(ctx)
+	s.ready.Store(true)
+	if s.config.Peers != "" {
+		var refreshCtx context.Context
+		refreshCtx, s.cancel = context.WithCancel(context.Background())
+		s.wg.Add(1)
+		go s.run(refreshCtx)
+	}
+	return nil
+}
+
+func (s *Sharder) Shutdown(_ context.Context) error {
+	s.ready.Store(false)
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	return nil
+}
+
+// Labels returns the target labels that make up the key of a target.
+func (s *Sharder) Labels() []string {
+	return s.config.Labels
+}
+
+// Owns returns true if this replica owns the key.
+func (s *Sharder) Owns(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return owner(s.members, key) == s.replica
+}
+
+// Members returns this replica and all replicas, sorted.
+func (s *Sharder) Members() (string, []string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.replica, s.members
+}
+
+// Changed returns a channel which is closed the next time the replicas change.
+func (s *Sharder) Changed() <-chan struct{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.changed
+}
+
+func (s *Sharder) run(ctx context.Context) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refresh(ctx)
+		}
+	}
+}
+
+// refresh updates the replicas. If the peers cannot be resolved, the last known replicas are kept.
+func (s *Sharder) refresh(ctx context.Context) {
+	replicas := s.config.Replicas
+	if s.config.Peers != "" {
+		var err error
+		replicas, err = s.lookupHost(ctx, s.config.Peers)
+		if err != nil {
+			s.logger.Warn("Unable to resolve the sharding peers, keeping the current replicas", zap.String("peers", s.config.Peers), zap.Error(err))
+			s.mu.RLock()
+			empty := len(s.members) == 0
+			s.mu.RUnlock()
+			if !empty {
+				return
+			}
+			replicas = nil
+		}
+	}
+	replica := s.config.Replica
+	if replica == "" {
+		replica = s.localReplica(replicas)
+	}
+	members := append([]string{replica}, replicas...)
+	slices.Sort(members)
+	members = slices.Compact(members)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if replica == s.replica && slices.Equal(members, s.members) {
+		return
+	}
+	s.replica = replica
+	s.members = members
+	close(s.changed)
+	s.changed = make(chan struct{})
+	s.logger.Info("Sharding replicas changed", zap.String("replica", replica), zap.Strings("replicas", members))
+}
+
+// localReplica returns the first replica that is an address of this host, or the hostname.
+func (s *Sharder) localReplica(replicas []string) string {
+	if addrs, err := s.interfaceAddrs(); err == nil {
+		local := map[string]struct{}{}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				local[ipNet.IP.String()] = struct{}{}
+			}
+		}
+		for _, replica := range replicas {
+			if _, ok := local[replica]; ok {
+				return replica
+			}
+		}
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// owner returns the member with the highest rendezvous hash for the key.
+func owner(members []string, key string) string {
+	var best string
+	var bestScore uint64
+	for _, member := range members {
+		if score := score(member, key); best == "" || score > bestScore {
+			best, bestScore = member, score
+		}
+	}
+	return best
+}
+
+func score(member, key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(member))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	// FNV alone spreads similar inputs poorly, so finish with the splitmix64 mixer.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
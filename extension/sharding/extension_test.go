@@ -0,0 +1,114 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func TestOwner(t *testing.T) {
+	members := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	counts := map[string]int{}
+	moved := 0
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("job\x0010.1.%d.%d:9100", i/256, i%256)
+		before := owner(members, key)
+		counts[before]++
+		// removing a member only moves its own keys
+		if after := owner(members[:2], key); after != before {
+			assert.Equal(t, "10.0.0.3", before)
+			moved++
+		}
+	}
+	for _, member := range members {
+		assert.InDelta(t, 1000, counts[member], 150, member)
+	}
+	assert.Equal(t, counts["10.0.0.3"], moved)
+	assert.Empty(t, owner(nil, "key"))
+}
+
+type resolver struct {
+	mu    sync.Mutex
+	addrs []string
+	err   error
+}
+
+func (r *resolver) LookupHost(_ context.Context, _ string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.addrs, r.err
+}
+
+func (r *resolver) set(addrs []string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addrs, r.err = addrs, err
+}
+
+func TestSharderPeers(t *testing.T) {
+	r := &resolver{addrs: []string{"10.0.0.2", "10.0.0.1"}}
+	s := newSharder(zap.NewNop(), &Config{Peers: "cwagent.svc", RefreshInterval: 10 * time.Millisecond, Labels: defaultLabels})
+	s.lookupHost = r.LookupHost
+	s.interfaceAddrs = func() ([]net.Addr, error) {
+		return []net.Addr{&net.IPNet{IP: net.ParseIP("127.0.0.1")}, &net.IPNet{IP: net.ParseIP("10.0.0.2")}}, nil
+	}
+	require.NoError(t, s.Start(context.Background(), componenttest.NewNopHost()))
+	defer s.Shutdown(context.Background())
+
+	replica, members := s.Members()
+	assert.Equal(t, "10.0.0.2", replica)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, members)
+
+	// a new replica triggers a rebalance
+	changed := s.Changed()
+	r.set([]string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, nil)
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("replicas did not change")
+	}
+	_, members = s.Members()
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, members)
+
+	// resolution failures keep the last replicas
+	changed = s.Changed()
+	r.set(nil, errors.New("no such host"))
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-changed:
+		t.Fatal("replicas changed")
+	default:
+	}
+	_, members = s.Members()
+	assert.Len(t, members, 3)
+}
+
+func TestSharderReplicas(t *testing.T) {
+	s := newSharder(zap.NewNop(), &Config{Replicas: []string{"b", "a"}, Replica: "c", Labels: defaultLabels})
+	require.NoError(t, s.Start(context.Background(), componenttest.NewNopHost()))
+	defer s.Shutdown(context.Background())
+
+	// the agent always counts itself
+	replica, members := s.Members()
+	assert.Equal(t, "c", replica)
+	assert.Equal(t, []string{"a", "b", "c"}, members)
+	owned := 0
+	for i := 0; i < 300; i++ {
+		if s.Owns(fmt.Sprint(i)) {
+			owned++
+		}
+	}
+	assert.InDelta(t, 100, owned, 30)
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package sharding
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	defaultRefreshInterval = 30 * time.Second
+)
+
+var (
+	TypeStr, _ = component.NewType("sharding")
+	sharder    *Sharder
+	mutex      sync.RWMutex
+
+	defaultLabels = []string{"__address__"}
+)
+
+// GetSharder returns the started sharding extension, or nil if sharding is not enabled.
+func GetSharder() *Sharder {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	if sharder != nil && sharder.ready.Load() {
+		return sharder
+	}
+	return nil
+}
+
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		TypeStr,
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelAlpha,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		RefreshInterval: defaultRefreshInterval,
+		Labels:          append([]string(nil), defaultLabels...),
+	}
+}
+
+func createExtension(_ context.Context, settings extension.Settings, cfg component.Config) (extension.Extension, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	sharder = newSharder(settings.Logger, cfg.(*Config))
+	return sharder, nil
+}
@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package sharding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.Equal(t, &Config{RefreshInterval: defaultRefreshInterval, Labels: defaultLabels}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.Error(t, cfg.(*Config).Validate())
+}
+
+func TestCreateExtension(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Replicas = []string{"a", "b"}
+	cfg.Replica = "a"
+	assert.NoError(t, cfg.Validate())
+	got, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(), cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, GetSharder())
+	assert.NoError(t, got.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, got, GetSharder())
+	assert.NoError(t, got.Shutdown(context.Background()))
+	assert.Nil(t, GetSharder())
+}
+
+func TestValidate(t *testing.T) {
+	testCases := map[string]struct {
+		cfg     Config
+		wantErr bool
+	}{
+		"Peers":        {cfg: Config{Peers: "cwagent.svc", RefreshInterval: defaultRefreshInterval, Labels: defaultLabels}},
+		"Replicas":     {cfg: Config{Replicas: []string{"a"}, Labels: defaultLabels}},
+		"Both":         {cfg: Config{Peers: "cwagent.svc", Replicas: []string{"a"}, RefreshInterval: defaultRefreshInterval, Labels: defaultLabels}, wantErr: true},
+		"Neither":      {cfg: Config{RefreshInterval: defaultRefreshInterval, Labels: defaultLabels}, wantErr: true},
+		"NoRefresh":    {cfg: Config{Peers: "cwagent.svc", Labels: defaultLabels}, wantErr: true},
+		"MissingLabel": {cfg: Config{Replicas: []string{"a"}}, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if testCase.wantErr {
+				assert.Error(t, testCase.cfg.Validate())
+			} else {
+				assert.NoError(t, testCase.cfg.Validate())
+			}
+		})
+	}
+}
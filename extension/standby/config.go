@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package standby
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type Config struct {
+	// Lock is where the lease is kept, either the path of a file both agents can write to or
+	// ssm:<parameter name> for an SSM parameter.
+	Lock string `mapstructure:"lock"`
+	// Owner identifies this agent in the lease. Both agents of a pair must use different owners.
+	Owner string `mapstructure:"owner"`
+	// LeaseDuration is how long the standby waits after the last renewal before it takes over.
+	LeaseDuration time.Duration `mapstructure:"lease_duration"`
+	// RenewInterval is how often the active agent renews the lease and the standby checks it.
+	RenewInterval time.Duration `mapstructure:"renew_interval"`
+	// Sources are the control patterns paused while the agent is the standby.
+	Sources []string `mapstructure:"sources"`
+
+	Region   string `mapstructure:"region,omitempty"`
+	Profile  string `mapstructure:"profile,omitempty"`
+	RoleARN  string `mapstructure:"role_arn,omitempty"`
+	Filename string `mapstructure:"shared_credential_file,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c.Lock == "" || c.Lock == ssmLockPrefix {
+		return errors.New("lock must be set")
+	}
+	if c.Owner == "" {
+		return errors.New("owner must be set")
+	}
+	if c.RenewInterval <= 0 || c.LeaseDuration < 2*c.RenewInterval {
+		return errors.New("lease_duration must be at least twice the renew_interval")
+	}
+	if len(c.Sources) == 0 {
+		return errors.New("sources must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package standby
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/control"
+)
+
+// Elector runs one agent of an active/standby pair. Both agents receive the pushed data, e.g.
+// through a virtual IP, but only the one holding the lease forwards it. The other keeps its
+// sources paused until the lease expires and then takes over.
+//
+// The lease is renewed every renew interval and confirmed by reading it back. If both agents
+// take an expired lease at the same time, the one that wrote last wins on the next renewal, so
+// both can be active for at most one renew interval.
+type Elector struct {
+	logger *zap.Logger
+	config *Config
+	store  leaseStore
+	now    func() time.Time
+
+	mu      sync.Mutex
+	active  bool
+	expires time.Time
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ extension.Extension = (*Elector)(nil)
+
+func newElector(logger *zap.Logger, config *Config, store leaseStore) *Elector {
+	return &Elector{
+		logger: logger,
+		config: config,
+		store:  store,
+		now:    time.Now,
+		done:   make(chan struct{}),
+	}
+}
+
+// Start pauses the sources before any data arrives and starts the election.
+func (e *Elector) Start(_ context.Context, _ component.Host) error {
+	for _, source := range e.config.Sources {
+		if err := control.Pause(source); err != nil {
+			return err
+		}
+	}
+	e.logger.Info("Starting as standby", zap.String("lock", e.config.Lock), zap.String("owner", e.config.Owner))
+	e.wg.Add(1)
+	go e.run()
+	return nil
+}
+
+// Shutdown stops the election and releases the lease so the other agent takes over without
+// waiting for it to expire.
+func (e *Elector) Shutdown(_ context.Context) error {
+	close(e.done)
+	e.wg.Wait()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.active {
+		if err := e.store.Write(Lease{Owner: e.config.Owner, Expires: e.now()}); err != nil {
+			e.logger.Warn("Unable to release the standby lease", zap.Error(err))
+		}
+	}
+	e.active = false
+	for _, source := range e.config.Sources {
+		control.Resume(source)
+	}
+	return nil
+}
+
+// Active returns true if the agent holds the lease.
+func (e *Elector) Active() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.active
+}
+
+func (e *Elector) run() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(e.config.RenewInterval)
+	defer ticker.Stop()
+	for {
+		e.elect()
+		select {
+		case <-e.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// elect takes or renews the lease if it is free or already ours, and becomes active or standby
+// accordingly. If the lock cannot be reached, the agent stays active until its own lease expires.
+func (e *Elector) elect() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.now()
+	acquired, err := e.acquire(now)
+	if err != nil {
+		e.logger.Warn("Unable to reach the standby lock", zap.String("lock", e.config.Lock), zap.Error(err))
+		if e.active && !now.Before(e.expires) {
+			e.setActive(false)
+		}
+		return
+	}
+	e.setActive(acquired)
+}
+
+func (e *Elector) acquire(now time.Time) (bool, error) {
+	lease, err := e.store.Read()
+	if err != nil {
+		return false, err
+	}
+	if lease.Owner != e.config.Owner && !lease.expired(now) {
+		return false, nil
+	}
+	lease = Lease{Owner: e.config.Owner, Expires: now.Add(e.config.LeaseDuration)}
+	if err = e.store.Write(lease); err != nil {
+		return false, err
+	}
+	confirmed, err := e.store.Read()
+	if err != nil {
+		return false, err
+	}
+	if confirmed.Owner != e.config.Owner {
+		return false, nil
+	}
+	e.expires = lease.Expires
+	return true, nil
+}
+
+// setActive must be called with the lock held.
+func (e *Elector) setActive(active bool) {
+	if active == e.active {
+		return
+	}
+	e.active = active
+	for _, source := range e.config.Sources {
+		if active {
+			control.Resume(source)
+		} else if err := control.Pause(source); err != nil {
+			e.logger.Error("Unable to pause source", zap.String("source", source), zap.Error(err))
+		}
+	}
+	if active {
+		e.logger.Info("Became active", zap.String("owner", e.config.Owner))
+	} else {
+		e.logger.Info("Became standby", zap.String("owner", e.config.Owner))
+	}
+}
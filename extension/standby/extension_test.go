@@ -0,0 +1,119 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package standby
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/control"
+)
+
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type failingStore struct {
+	leaseStore
+	err error
+}
+
+func (s *failingStore) Read() (Lease, error) {
+	if s.err != nil {
+		return Lease{}, s.err
+	}
+	return s.leaseStore.Read()
+}
+
+func newTestElector(owner, source string, store leaseStore, c *clock) *Elector {
+	cfg := &Config{
+		Lock:          "test",
+		Owner:         owner,
+		LeaseDuration: 30 * time.Second,
+		// elections are run by the test
+		RenewInterval: time.Hour,
+		Sources:       []string{source},
+	}
+	e := newElector(zap.NewNop(), cfg, store)
+	e.now = c.Now
+	return e
+}
+
+func TestElector(t *testing.T) {
+	store := &fileStore{path: filepath.Join(t.TempDir(), "lease")}
+	c := &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	a := newTestElector("a", "input:standby_a", store, c)
+	b := newTestElector("b", "input:standby_b", store, c)
+
+	// the first agent to start takes the free lease
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, a.Active, time.Second, 10*time.Millisecond)
+	require.NoError(t, b.Start(context.Background(), componenttest.NewNopHost()))
+	b.elect()
+	assert.False(t, b.Active())
+	assert.False(t, control.Paused("input:standby_a"))
+	assert.True(t, control.Paused("input:standby_b"))
+
+	// a stops renewing, b takes over once the lease expires
+	c.Add(20 * time.Second)
+	b.elect()
+	assert.False(t, b.Active())
+	c.Add(20 * time.Second)
+	b.elect()
+	assert.True(t, b.Active())
+	assert.False(t, control.Paused("input:standby_b"))
+	a.elect()
+	assert.False(t, a.Active())
+	assert.True(t, control.Paused("input:standby_a"))
+
+	// b releases the lease on shutdown so a takes over right away
+	require.NoError(t, b.Shutdown(context.Background()))
+	assert.False(t, control.Paused("input:standby_b"))
+	a.elect()
+	assert.True(t, a.Active())
+	require.NoError(t, a.Shutdown(context.Background()))
+	assert.False(t, control.Paused("input:standby_a"))
+}
+
+func TestElectorUnreachableLock(t *testing.T) {
+	store := &failingStore{leaseStore: &fileStore{path: filepath.Join(t.TempDir(), "lease")}}
+	c := &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	e := newTestElector("a", "input:standby_unreachable", store, c)
+	t.Cleanup(func() { control.Resume("input:standby_unreachable") })
+
+	e.elect()
+	assert.True(t, e.Active())
+
+	// stays active while its own lease is valid
+	store.err = errors.New("unreachable")
+	c.Add(20 * time.Second)
+	e.elect()
+	assert.True(t, e.Active())
+	c.Add(20 * time.Second)
+	e.elect()
+	assert.False(t, e.Active())
+	assert.True(t, control.Paused("input:standby_unreachable"))
+}
@@ -0,0 +1,47 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package standby
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	defaultLeaseDuration = 30 * time.Second
+	defaultRenewInterval = 10 * time.Second
+)
+
+var (
+	TypeStr, _ = component.NewType("standby")
+
+	// defaultSources are the push based inputs of gateway deployments. The patterns also match
+	// aliased inputs.
+	defaultSources = []string{"input:statsd*", "input:socket_listener*"}
+)
+
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		TypeStr,
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelAlpha,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		LeaseDuration: defaultLeaseDuration,
+		RenewInterval: defaultRenewInterval,
+		Sources:       append([]string(nil), defaultSources...),
+	}
+}
+
+func createExtension(_ context.Context, settings extension.Settings, cfg component.Config) (extension.Extension, error) {
+	config := cfg.(*Config)
+	return newElector(settings.Logger, config, newStore(config)), nil
+}
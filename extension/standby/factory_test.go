@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package standby
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.Equal(t, &Config{
+		LeaseDuration: defaultLeaseDuration,
+		RenewInterval: defaultRenewInterval,
+		Sources:       defaultSources,
+	}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.Error(t, cfg.(*Config).Validate())
+}
+
+func TestCreateExtension(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Lock = filepath.Join(t.TempDir(), "lease")
+	cfg.Owner = "gateway-a"
+	assert.NoError(t, cfg.Validate())
+	got, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(), cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+	assert.IsType(t, &fileStore{}, got.(*Elector).store)
+	assert.NoError(t, got.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, got.Shutdown(context.Background()))
+
+	cfg.Lock = "ssm:/cwagent/gateway/lease"
+	got, err = NewFactory().Create(context.Background(), extensiontest.NewNopSettings(), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "/cwagent/gateway/lease", got.(*Elector).store.(*ssmStore).name)
+}
+
+func TestValidate(t *testing.T) {
+	testCases := map[string]struct {
+		modify  func(*Config)
+		wantErr bool
+	}{
+		"Valid":          {modify: func(*Config) {}},
+		"MissingLock":    {modify: func(c *Config) { c.Lock = "" }, wantErr: true},
+		"EmptySSM":       {modify: func(c *Config) { c.Lock = "ssm:" }, wantErr: true},
+		"MissingOwner":   {modify: func(c *Config) { c.Owner = "" }, wantErr: true},
+		"ShortLease":     {modify: func(c *Config) { c.LeaseDuration = c.RenewInterval }, wantErr: true},
+		"MissingSources": {modify: func(c *Config) { c.Sources = nil }, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Lock = "/mnt/shared/lease"
+			cfg.Owner = "gateway-a"
+			testCase.modify(cfg)
+			if testCase.wantErr {
+				assert.Error(t, cfg.Validate())
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
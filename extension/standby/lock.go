@@ -0,0 +1,137 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package standby
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+)
+
+const ssmLockPrefix = "ssm:"
+
+// Lease is the content of the lock. The agent that owns an unexpired lease is the active one.
+type Lease struct {
+	Owner   string    `json:"owner"`
+	Expires time.Time `json:"expires"`
+}
+
+func (l Lease) expired(now time.Time) bool {
+	return l.Owner == "" || !now.Before(l.Expires)
+}
+
+// leaseStore reads and writes the lease. Neither backend can compare and swap, so a write is
+// confirmed by reading the lease back.
+type leaseStore interface {
+	Read() (Lease, error)
+	Write(Lease) error
+}
+
+func newStore(cfg *Config) leaseStore {
+	if name, ok := strings.CutPrefix(cfg.Lock, ssmLockPrefix); ok {
+		credentialConfig := &configaws.CredentialConfig{
+			Region:   cfg.Region,
+			Profile:  cfg.Profile,
+			RoleARN:  cfg.RoleARN,
+			Filename: cfg.Filename,
+		}
+		client := ssm.New(credentialConfig.Credentials(), &aws.Config{
+			LogLevel: configaws.SDKLogLevel(),
+			Logger:   configaws.SDKLogger{},
+		})
+		return &ssmStore{client: client, name: name}
+	}
+	return &fileStore{path: cfg.Lock}
+}
+
+// fileStore keeps the lease in a file on a shared file system.
+type fileStore struct {
+	path string
+}
+
+func (s *fileStore) Read() (Lease, error) {
+	var lease Lease
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return lease, nil
+	}
+	if err != nil {
+		return lease, err
+	}
+	if len(content) == 0 {
+		return lease, nil
+	}
+	if err = json.Unmarshal(content, &lease); err != nil {
+		return lease, fmt.Errorf("invalid lease in %s: %w", s.path, err)
+	}
+	return lease, nil
+}
+
+// Write replaces the file with a rename so the other agent never reads a partial lease.
+func (s *fileStore) Write(lease Lease) error {
+	content, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// ssmStore keeps the lease in an SSM parameter.
+type ssmStore struct {
+	client ssmiface.SSMAPI
+	name   string
+}
+
+func (s *ssmStore) Read() (Lease, error) {
+	var lease Lease
+	output, err := s.client.GetParameter(&ssm.GetParameterInput{Name: aws.String(s.name)})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == ssm.ErrCodeParameterNotFound {
+			return lease, nil
+		}
+		return lease, err
+	}
+	if err = json.Unmarshal([]byte(aws.StringValue(output.Parameter.Value)), &lease); err != nil {
+		return lease, fmt.Errorf("invalid lease in SSM parameter %s: %w", s.name, err)
+	}
+	return lease, nil
+}
+
+func (s *ssmStore) Write(lease Lease) error {
+	content, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.PutParameter(&ssm.PutParameterInput{
+		Name:      aws.String(s.name),
+		Value:     aws.String(string(content)),
+		Type:      aws.String(ssm.ParameterTypeString),
+		Overwrite: aws.Bool(true),
+	})
+	return err
+}
@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package standby
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSSM struct {
+	ssmiface.SSMAPI
+	value *string
+}
+
+func (m *mockSSM) GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+	if m.value == nil {
+		return nil, awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil)
+	}
+	return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Name: input.Name, Value: m.value}}, nil
+}
+
+func (m *mockSSM) PutParameter(input *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+	m.value = input.Value
+	return &ssm.PutParameterOutput{}, nil
+}
+
+func TestStores(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lease")
+	stores := map[string]leaseStore{
+		"File": &fileStore{path: path},
+		"SSM":  &ssmStore{client: &mockSSM{}, name: "/cwagent/lease"},
+	}
+	expires := time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC)
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			lease, err := store.Read()
+			require.NoError(t, err)
+			assert.True(t, lease.expired(time.Time{}))
+
+			require.NoError(t, store.Write(Lease{Owner: "a", Expires: expires}))
+			lease, err = store.Read()
+			require.NoError(t, err)
+			assert.Equal(t, Lease{Owner: "a", Expires: expires}, lease)
+			assert.False(t, lease.expired(expires.Add(-time.Second)))
+			assert.True(t, lease.expired(expires))
+		})
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+	_, err := stores["File"].Read()
+	assert.Error(t, err)
+	_, err = (&ssmStore{client: &mockSSM{value: aws.String("{")}}).Read()
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package xraysampling
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type Config struct {
+	// Endpoint is the address the X-Ray SDKs send their sampling requests to.
+	Endpoint string `mapstructure:"endpoint"`
+	// ProxyEndpoint is the address of the awsxray receiver proxy the requests are forwarded to.
+	ProxyEndpoint string `mapstructure:"proxy_endpoint"`
+	// MaxRecentDecisions is the number of sampling statistics reports kept for the debug endpoint.
+	MaxRecentDecisions int `mapstructure:"max_recent_decisions"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c.Endpoint == "" || c.ProxyEndpoint == "" {
+		return errors.New("endpoint and proxy_endpoint must be set")
+	}
+	if c.Endpoint == c.ProxyEndpoint {
+		return errors.New("endpoint and proxy_endpoint must be different")
+	}
+	return nil
+}
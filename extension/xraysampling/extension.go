@@ -0,0 +1,171 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package xraysampling
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+)
+
+const (
+	DebugPath = "/debug/sampling"
+
+	getSamplingRulesPath   = "/GetSamplingRules"
+	getSamplingTargetsPath = "/SamplingTargets"
+)
+
+// Inspector sits in front of the awsxray receiver proxy. It forwards every request unchanged and records
+// the sampling rules, reservoir targets and statistics reports that pass through so they can be inspected
+// on the debug endpoint.
+type Inspector struct {
+	logger *zap.Logger
+	config *Config
+	stats  *stats
+	server *http.Server
+}
+
+var _ extension.Extension = (*Inspector)(nil)
+
+func newInspector(logger *zap.Logger, config *Config) *Inspector {
+	return &Inspector{
+		logger: logger,
+		config: config,
+		stats:  newStats(config.MaxRecentDecisions),
+	}
+}
+
+func (i *Inspector) Start(_ context.Context, _ component.Host) error {
+	target, err := url.Parse("http://" + i.config.ProxyEndpoint)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", i.config.Endpoint)
+	if err != nil {
+		return err
+	}
+	i.server = &http.Server{Handler: i.handler(target), ReadHeaderTimeout: 90 * time.Second}
+	go func() {
+		if err := i.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			i.logger.Error("X-Ray sampling inspector stopped", zap.Error(err))
+		}
+	}()
+	i.logger.Info("X-Ray sampling debug endpoint started", zap.String("address", i.config.Endpoint+DebugPath))
+	return nil
+}
+
+func (i *Inspector) Shutdown(ctx context.Context) error {
+	if i.server != nil {
+		return i.server.Shutdown(ctx)
+	}
+	return nil
+}
+
+// Status returns a snapshot of what has been recorded so far.
+func (i *Inspector) Status() Status {
+	return i.stats.status()
+}
+
+func (i *Inspector) handler(target *url.URL) http.Handler {
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.ModifyResponse = i.inspectResponse
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		i.stats.recordError()
+		i.logger.Debug("unable to forward X-Ray sampling request", zap.String("path", r.URL.Path), zap.Error(err))
+		w.WriteHeader(http.StatusBadGateway)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(DebugPath, i.debugHandler)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if isPath(r, getSamplingTargetsPath) && r.Body != nil {
+			body, err := readAndRestore(&r.Body)
+			if err == nil {
+				var input getSamplingTargetsInput
+				if err = json.Unmarshal(body, &input); err == nil {
+					i.stats.recordStatistics(input)
+				}
+			}
+			if err != nil {
+				i.logger.Debug("unable to decode sampling statistics", zap.Error(err))
+			}
+		}
+		proxy.ServeHTTP(w, r)
+	})
+	return mux
+}
+
+func (i *Inspector) inspectResponse(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		i.stats.recordError()
+		return nil
+	}
+	var record func([]byte) error
+	switch {
+	case isPath(resp.Request, getSamplingRulesPath):
+		record = func(body []byte) error {
+			var output getSamplingRulesOutput
+			if err := json.Unmarshal(body, &output); err != nil {
+				return err
+			}
+			i.stats.recordRules(output)
+			return nil
+		}
+	case isPath(resp.Request, getSamplingTargetsPath):
+		record = func(body []byte) error {
+			var output getSamplingTargetsOutput
+			if err := json.Unmarshal(body, &output); err != nil {
+				return err
+			}
+			i.stats.recordTargets(output)
+			return nil
+		}
+	default:
+		return nil
+	}
+	body, err := readAndRestore(&resp.Body)
+	if err != nil {
+		return err
+	}
+	if err = record(body); err != nil {
+		i.logger.Debug("unable to decode X-Ray sampling response", zap.String("path", resp.Request.URL.Path), zap.Error(err))
+	}
+	return nil
+}
+
+func (i *Inspector) debugHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(i.Status()); err != nil {
+		i.logger.Debug("unable to write X-Ray sampling status", zap.Error(err))
+	}
+}
+
+func isPath(r *http.Request, path string) bool {
+	return r != nil && strings.HasSuffix(r.URL.Path, path)
+}
+
+// readAndRestore reads the body and replaces it with a copy so it can still be forwarded.
+func readAndRestore(body *io.ReadCloser) ([]byte, error) {
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
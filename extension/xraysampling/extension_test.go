@@ -0,0 +1,112 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package xraysampling
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testRules = `{"SamplingRuleRecords":[{"SamplingRule":{"RuleName":"Default","Priority":10000,"FixedRate":0.05,"ReservoirSize":1,"ServiceName":"*","Host":"*","HTTPMethod":"*","URLPath":"*"}},
+{"SamplingRule":{"RuleName":"checkout","Priority":1,"FixedRate":0.5,"ReservoirSize":10,"ServiceName":"checkout","Host":"*","HTTPMethod":"POST","URLPath":"/buy"}}]}`
+	testStatistics = `{"SamplingStatisticsDocuments":[{"RuleName":"checkout","ClientID":"abc","Timestamp":1700000000,"RequestCount":20,"SampledCount":12,"BorrowCount":1},
+{"RuleName":"Default","ClientID":"abc","Timestamp":1700000000,"RequestCount":5,"SampledCount":1,"BorrowCount":0}]}`
+	testTargets = `{"SamplingTargetDocuments":[{"RuleName":"checkout","FixedRate":0.5,"ReservoirQuota":8,"ReservoirQuotaTTL":1700000010,"Interval":10}],"UnprocessedStatistics":[]}`
+)
+
+func newTestInspector(t *testing.T, maxRecent int) (*Inspector, http.Handler, *[]string) {
+	var forwarded []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		forwarded = append(forwarded, string(body))
+		switch r.URL.Path {
+		case getSamplingRulesPath:
+			_, _ = w.Write([]byte(testRules))
+		case getSamplingTargetsPath:
+			_, _ = w.Write([]byte(testTargets))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+	target, err := url.Parse(upstream.URL)
+	require.NoError(t, err)
+	inspector := newInspector(zap.NewNop(), &Config{MaxRecentDecisions: maxRecent})
+	return inspector, inspector.handler(target), &forwarded
+}
+
+func post(t *testing.T, handler http.Handler, path, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return recorder
+}
+
+func TestInspector(t *testing.T) {
+	inspector, handler, forwarded := newTestInspector(t, 10)
+
+	got := post(t, handler, getSamplingRulesPath, "{}")
+	assert.Equal(t, http.StatusOK, got.Code)
+	assert.JSONEq(t, testRules, got.Body.String())
+
+	got = post(t, handler, getSamplingTargetsPath, testStatistics)
+	assert.Equal(t, http.StatusOK, got.Code)
+	assert.JSONEq(t, testTargets, got.Body.String())
+	assert.Equal(t, []string{"{}", testStatistics}, *forwarded)
+
+	status := inspector.Status()
+	assert.EqualValues(t, 1, status.RulesRequests)
+	assert.EqualValues(t, 1, status.TargetsRequests)
+	require.Len(t, status.Rules, 2)
+	assert.Equal(t, "Default", status.Rules[0].Name)
+	assert.Nil(t, status.Rules[0].Target)
+	checkout := status.Rules[1]
+	assert.Equal(t, "checkout", checkout.Name)
+	assert.Equal(t, "POST", checkout.Rule.HTTPMethod)
+	assert.EqualValues(t, 20, checkout.RequestCount)
+	assert.EqualValues(t, 12, checkout.SampledCount)
+	assert.EqualValues(t, 1, checkout.BorrowCount)
+	require.NotNil(t, checkout.Target)
+	assert.EqualValues(t, 8, *checkout.Target.ReservoirQuota)
+	require.Len(t, status.RecentDecisions, 2)
+	assert.Equal(t, "abc", status.RecentDecisions[0].ClientID)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, DebugPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var served Status
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &served))
+	assert.Len(t, served.Rules, 2)
+	assert.Len(t, served.RecentDecisions, 2)
+}
+
+func TestInspectorRecentDecisionsLimit(t *testing.T) {
+	inspector, handler, _ := newTestInspector(t, 3)
+	for i := 0; i < 3; i++ {
+		post(t, handler, getSamplingTargetsPath, testStatistics)
+	}
+	status := inspector.Status()
+	assert.Len(t, status.RecentDecisions, 3)
+	assert.EqualValues(t, 60, status.Rules[1].RequestCount)
+	assert.Equal(t, "Default", status.RecentDecisions[2].RuleName)
+}
+
+func TestInspectorErrors(t *testing.T) {
+	inspector, handler, _ := newTestInspector(t, 3)
+	got := post(t, handler, "/Unknown", "")
+	assert.Equal(t, http.StatusNotFound, got.Code)
+	got = post(t, handler, getSamplingTargetsPath, "not json")
+	assert.Equal(t, http.StatusOK, got.Code)
+	assert.EqualValues(t, 1, inspector.Status().Errors)
+	assert.EqualValues(t, 0, inspector.Status().TargetsRequests)
+}
@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package xraysampling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.Equal(t, &Config{MaxRecentDecisions: defaultMaxRecentDecisions}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.Error(t, cfg.(*Config).Validate())
+}
+
+func TestCreateExtension(t *testing.T) {
+	cfg := &Config{Endpoint: "127.0.0.1:0", ProxyEndpoint: "127.0.0.1:2001"}
+	got, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(), cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+	assert.NoError(t, got.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, got.Shutdown(context.Background()))
+}
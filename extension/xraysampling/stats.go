@@ -0,0 +1,186 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package xraysampling
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// The request and response documents of the X-Ray GetSamplingRules and GetSamplingTargets APIs. Only the
+// fields shown by the debug endpoint are decoded.
+type samplingRule struct {
+	RuleName      string  `json:"RuleName"`
+	Priority      int64   `json:"Priority"`
+	FixedRate     float64 `json:"FixedRate"`
+	ReservoirSize int64   `json:"ReservoirSize"`
+	ServiceName   string  `json:"ServiceName"`
+	ServiceType   string  `json:"ServiceType"`
+	Host          string  `json:"Host"`
+	HTTPMethod    string  `json:"HTTPMethod"`
+	URLPath       string  `json:"URLPath"`
+}
+
+type getSamplingRulesOutput struct {
+	SamplingRuleRecords []struct {
+		SamplingRule samplingRule `json:"SamplingRule"`
+	} `json:"SamplingRuleRecords"`
+}
+
+type samplingStatistics struct {
+	RuleName     string  `json:"RuleName"`
+	ClientID     string  `json:"ClientID"`
+	Timestamp    float64 `json:"Timestamp"`
+	RequestCount int64   `json:"RequestCount"`
+	SampledCount int64   `json:"SampledCount"`
+	BorrowCount  int64   `json:"BorrowCount"`
+}
+
+type getSamplingTargetsInput struct {
+	SamplingStatisticsDocuments []samplingStatistics `json:"SamplingStatisticsDocuments"`
+}
+
+type samplingTarget struct {
+	RuleName          string   `json:"RuleName"`
+	FixedRate         float64  `json:"FixedRate"`
+	ReservoirQuota    *int64   `json:"ReservoirQuota,omitempty"`
+	ReservoirQuotaTTL *float64 `json:"ReservoirQuotaTTL,omitempty"`
+	Interval          *int64   `json:"Interval,omitempty"`
+}
+
+type getSamplingTargetsOutput struct {
+	SamplingTargetDocuments []samplingTarget `json:"SamplingTargetDocuments"`
+}
+
+// RuleStatus is what the debug endpoint reports for each sampling rule.
+type RuleStatus struct {
+	Name string        `json:"name"`
+	Rule *samplingRule `json:"rule,omitempty"`
+	// Target is the last reservoir quota and rate assigned by X-Ray.
+	Target *samplingTarget `json:"target,omitempty"`
+	// The totals of the statistics reported by the SDKs since the agent started.
+	RequestCount int64     `json:"request_count"`
+	SampledCount int64     `json:"sampled_count"`
+	BorrowCount  int64     `json:"borrow_count"`
+	LastReport   time.Time `json:"last_report,omitempty"`
+}
+
+// Decision is one sampling statistics report from an SDK.
+type Decision struct {
+	Time         time.Time `json:"time"`
+	RuleName     string    `json:"rule_name"`
+	ClientID     string    `json:"client_id"`
+	RequestCount int64     `json:"request_count"`
+	SampledCount int64     `json:"sampled_count"`
+	BorrowCount  int64     `json:"borrow_count"`
+}
+
+// Status is the document served by the debug endpoint.
+type Status struct {
+	RulesRequests   int64         `json:"get_sampling_rules_requests"`
+	TargetsRequests int64         `json:"get_sampling_targets_requests"`
+	Errors          int64         `json:"errors"`
+	RulesUpdated    time.Time     `json:"rules_updated,omitempty"`
+	Rules           []*RuleStatus `json:"rules"`
+	RecentDecisions []Decision    `json:"recent_decisions"`
+}
+
+type stats struct {
+	mu              sync.Mutex
+	maxRecent       int
+	rulesRequests   int64
+	targetsRequests int64
+	errors          int64
+	rulesUpdated    time.Time
+	rules           map[string]*RuleStatus
+	recent          []Decision
+}
+
+func newStats(maxRecent int) *stats {
+	return &stats{maxRecent: maxRecent, rules: map[string]*RuleStatus{}}
+}
+
+func (s *stats) rule(name string) *RuleStatus {
+	status, ok := s.rules[name]
+	if !ok {
+		status = &RuleStatus{Name: name}
+		s.rules[name] = status
+	}
+	return status
+}
+
+func (s *stats) recordError() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors++
+}
+
+func (s *stats) recordRules(output getSamplingRulesOutput) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rulesRequests++
+	s.rulesUpdated = time.Now()
+	for _, record := range output.SamplingRuleRecords {
+		rule := record.SamplingRule
+		s.rule(rule.RuleName).Rule = &rule
+	}
+}
+
+func (s *stats) recordStatistics(input getSamplingTargetsInput) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.targetsRequests++
+	for _, doc := range input.SamplingStatisticsDocuments {
+		reported := time.Unix(0, int64(doc.Timestamp*float64(time.Second)))
+		status := s.rule(doc.RuleName)
+		status.RequestCount += doc.RequestCount
+		status.SampledCount += doc.SampledCount
+		status.BorrowCount += doc.BorrowCount
+		status.LastReport = reported
+		s.recent = append(s.recent, Decision{
+			Time:         reported,
+			RuleName:     doc.RuleName,
+			ClientID:     doc.ClientID,
+			RequestCount: doc.RequestCount,
+			SampledCount: doc.SampledCount,
+			BorrowCount:  doc.BorrowCount,
+		})
+	}
+	if s.maxRecent > 0 && len(s.recent) > s.maxRecent {
+		s.recent = append([]Decision(nil), s.recent[len(s.recent)-s.maxRecent:]...)
+	}
+}
+
+func (s *stats) recordTargets(output getSamplingTargetsOutput) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, target := range output.SamplingTargetDocuments {
+		target := target
+		s.rule(target.RuleName).Target = &target
+	}
+}
+
+func (s *stats) status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := Status{
+		RulesRequests:   s.rulesRequests,
+		TargetsRequests: s.targetsRequests,
+		Errors:          s.errors,
+		RulesUpdated:    s.rulesUpdated,
+		Rules:           make([]*RuleStatus, 0, len(s.rules)),
+		RecentDecisions: append([]Decision{}, s.recent...),
+	}
+	names := make([]string, 0, len(s.rules))
+	for name := range s.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rule := *s.rules[name]
+		status.Rules = append(status.Rules, &rule)
+	}
+	return status
+}
@@ -12,8 +12,13 @@ import (
 	"sync"
 
 	"github.com/aws/aws-sdk-go/aws/request"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/featureflag"
 )
 
+// requestCompression gates the compression, e.g. to roll it back on the agents behind a proxy rejecting it.
+var requestCompression = featureflag.Register("request_compression", true, "Compress the PutLogEvents and PutMetricData requests with gzip")
+
 var gzipPool = sync.Pool{
 	New: func() interface{} {
 		return gzip.NewWriter(io.Discard)
@@ -31,7 +36,7 @@ func NewRequestCompressionHandler(opNames []string) request.NamedHandler {
 				}
 			}
 
-			if !match {
+			if !match || !requestCompression.Enabled() {
 				return
 			}
 
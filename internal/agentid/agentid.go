@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package agentid identifies the agent, so that the fleet tooling can track each agent across its restarts. The
+// instance ID of the agent is persisted and kept across its restarts and upgrades, while the run ID changes with every
+// process of the agent.
+package agentid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+// machineIDPaths are read for the ID of the machine, which the images of the OS regenerate for every new machine.
+var machineIDPaths = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+
+var (
+	runID     = uuid.NewString()
+	startTime = time.Now()
+
+	once       sync.Once
+	instanceID string
+)
+
+// Identity identifies the process of the agent.
+type Identity struct {
+	// InstanceID is the ID of the agent, kept across its restarts.
+	InstanceID string `json:"instance_id"`
+	// RunID is the ID of the process of the agent.
+	RunID     string    `json:"run_id"`
+	StartTime time.Time `json:"start_time"`
+}
+
+// Get returns the identity of the agent. The instance ID is loaded from paths.AgentIDPath the first time, and
+// generated when the file does not exist yet.
+func Get() Identity {
+	return Identity{InstanceID: InstanceID(), RunID: runID, StartTime: startTime}
+}
+
+// InstanceID returns the persisted ID of the agent.
+func InstanceID() string {
+	once.Do(func() {
+		instanceID = load(paths.AgentIDPath, fingerprint())
+	})
+	return instanceID
+}
+
+// RunID returns the ID of the process of the agent.
+func RunID() string {
+	return runID
+}
+
+// record is the content of paths.AgentIDPath.
+type record struct {
+	InstanceID string `json:"instance_id"`
+	// Host is the fingerprint of the host the ID was generated on. The ID is generated again when it changes, e.g.
+	// when the file was baked into an image that new machines are launched from.
+	Host    string    `json:"host"`
+	Created time.Time `json:"created"`
+}
+
+// load returns the instance ID of the file if it was generated on the host, or generates and saves a new one. An ID
+// that cannot be saved is only used by this process.
+func load(path, host string) string {
+	content, err := os.ReadFile(path)
+	if err == nil {
+		var r record
+		if err = json.Unmarshal(content, &r); err == nil && r.InstanceID != "" {
+			if r.Host == host {
+				return r.InstanceID
+			}
+			log.Printf("I! Agent instance ID %s was generated on another host, generating a new one", r.InstanceID)
+		} else {
+			log.Printf("W! Unable to read the agent instance ID from %s, generating a new one: %v", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Printf("W! Unable to read the agent instance ID from %s, generating a new one: %v", path, err)
+	}
+	r := record{InstanceID: uuid.NewString(), Host: host, Created: time.Now().UTC()}
+	if err = save(path, r); err != nil {
+		log.Printf("W! Unable to save the agent instance ID, it changes with every restart: %v", paths.ReadOnlyHint(err))
+	} else {
+		log.Printf("I! Generated agent instance ID %s", r.InstanceID)
+	}
+	return r.InstanceID
+}
+
+func save(path string, r record) error {
+	content, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// the file is renamed into place, so that a crash never leaves a partial ID behind
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// fingerprint hashes the hostname and the machine ID, so that the file does not reveal them.
+func fingerprint() string {
+	hostname, _ := os.Hostname()
+	parts := []string{hostname}
+	for _, path := range machineIDPaths {
+		if content, err := os.ReadFile(path); err == nil {
+			parts = append(parts, strings.TrimSpace(string(content)))
+			break
+		}
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
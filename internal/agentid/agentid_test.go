@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agentid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "var", "agent-id.json")
+
+	id := load(path, "host-a")
+	assert.NotEmpty(t, id)
+	assert.FileExists(t, path)
+	assert.Equal(t, id, load(path, "host-a"), "the ID is kept across restarts")
+
+	other := load(path, "host-b")
+	assert.NotEqual(t, id, other, "a file baked into an image is not reused by another host")
+	assert.Equal(t, other, load(path, "host-b"))
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+	assert.NotEqual(t, other, load(path, "host-b"))
+}
+
+func TestLoadWithoutWriteAccess(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(dir, nil, 0644))
+	path := filepath.Join(dir, "agent-id.json")
+
+	id := load(path, "host-a")
+	assert.NotEmpty(t, id)
+	assert.NotEqual(t, id, load(path, "host-a"))
+}
+
+func TestGet(t *testing.T) {
+	paths.AgentIDPath = filepath.Join(t.TempDir(), "agent-id.json")
+	identity := Get()
+	assert.NotEmpty(t, identity.InstanceID)
+	assert.Equal(t, identity.InstanceID, Get().InstanceID)
+	assert.Equal(t, RunID(), identity.RunID)
+	assert.NotEmpty(t, identity.RunID)
+	assert.False(t, identity.StartTime.IsZero())
+	assert.Len(t, fingerprint(), 16)
+}
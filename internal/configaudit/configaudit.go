@@ -0,0 +1,330 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package configaudit tracks who owns each section of the configuration and records an audit event whenever the
+// effective configuration changes, for the changes to be traced back to their owners in regulated environments.
+package configaudit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SectionKey is the key of the JSON config which sets the ownership of its sections, e.g.
+	// {"ownership": {"logs.logs_collected.files": {"owner": "team-a"}}}.
+	SectionKey = "ownership"
+	// StateFileName is the name of the file, next to the TOML config, which keeps the state of the configuration it
+	// was translated from.
+	StateFileName = "amazon-cloudwatch-agent-config-audit.json"
+
+	eventConfigChange = "config_change"
+	pathSeparator     = "."
+)
+
+var (
+	mu      sync.RWMutex
+	current *State
+)
+
+// Ownership is the metadata of a section of the configuration.
+type Ownership struct {
+	Owner       string            `json:"owner,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func (o Ownership) equal(other Ownership) bool {
+	return o.Owner == other.Owner && maps.Equal(o.Annotations, other.Annotations)
+}
+
+// Section is the state of a section of the configuration, i.e. a top level key or a section with an ownership, whose
+// path is the keys to it joined with dots.
+type Section struct {
+	// Hash is empty when the section is owned but not set.
+	Hash        string            `json:"hash,omitempty"`
+	Owner       string            `json:"owner,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Sources are the config files which set the section.
+	Sources []string `json:"sources,omitempty"`
+}
+
+// State is the state of the effective configuration, merged from all the config files.
+type State struct {
+	// Hash changes whenever the effective configuration does.
+	Hash     string             `json:"hash"`
+	Sources  []string           `json:"sources,omitempty"`
+	Sections map[string]Section `json:"sections"`
+}
+
+// Event is the audit event of a change of the effective configuration.
+type Event struct {
+	Time         time.Time `json:"time"`
+	Event        string    `json:"event"`
+	Hash         string    `json:"hash"`
+	PreviousHash string    `json:"previous_hash,omitempty"`
+	// Changed are the sections added, removed or changed, including the changes of their ownership.
+	Changed []string `json:"changed_sections"`
+	// Sections are the changed sections, as they were before for the removed ones.
+	Sections map[string]Section `json:"sections"`
+	Sources  []string           `json:"sources,omitempty"`
+}
+
+// Collector collects the ownership and the sources of the sections from each config file, before they are merged
+// into the effective configuration.
+type Collector struct {
+	sources   []string
+	ownership map[string]Ownership
+	// owners are the config files which set the ownership of each section.
+	owners map[string]string
+	// keys are the paths of the objects set in each config file.
+	keys map[string]map[string]bool
+}
+
+func NewCollector() *Collector {
+	return &Collector{ownership: map[string]Ownership{}, owners: map[string]string{}, keys: map[string]map[string]bool{}}
+}
+
+// Add removes the ownership from the config file and records it. The ownership of a section can only be set once.
+func (c *Collector) Add(source string, jsonConfigMap map[string]any) error {
+	c.sources = append(c.sources, source)
+	value, ok := jsonConfigMap[SectionKey]
+	delete(jsonConfigMap, SectionKey)
+	keys := map[string]bool{}
+	collectKeys(jsonConfigMap, "", keys)
+	c.keys[source] = keys
+	if !ok {
+		return nil
+	}
+	ownership, err := parseOwnership(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s in %s: %w", SectionKey, source, err)
+	}
+	for path, o := range ownership {
+		if other, ok := c.owners[path]; ok && !c.ownership[path].equal(o) {
+			return fmt.Errorf("conflicting %s of section %q in %s and %s", SectionKey, path, other, source)
+		}
+		c.ownership[path] = o
+		c.owners[path] = source
+	}
+	return nil
+}
+
+// State returns the state of the effective configuration.
+func (c *Collector) State(jsonConfigMap map[string]any) (*State, error) {
+	hash, err := jsonHash(jsonConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	sources := append([]string(nil), c.sources...)
+	sort.Strings(sources)
+	state := &State{Hash: hash, Sources: sources, Sections: map[string]Section{}}
+	paths := make([]string, 0, len(jsonConfigMap)+len(c.ownership))
+	for key := range jsonConfigMap {
+		paths = append(paths, key)
+	}
+	for path := range c.ownership {
+		paths = append(paths, path)
+	}
+	for _, path := range paths {
+		var section Section
+		if value, ok := lookup(jsonConfigMap, path); ok {
+			if section.Hash, err = jsonHash(value); err != nil {
+				return nil, err
+			}
+		}
+		o := c.ownership[path]
+		section.Owner = o.Owner
+		section.Annotations = o.Annotations
+		for _, source := range sources {
+			if c.keys[source][path] {
+				section.Sources = append(section.Sources, source)
+			}
+		}
+		state.Sections[path] = section
+	}
+	return state, nil
+}
+
+// Changed returns the sections of the state which are not the same in the previous one, sorted.
+func (s *State) Changed(previous *State) []string {
+	var changed []string
+	for path, section := range s.Sections {
+		if previous == nil || !sameSection(previous.Sections[path], section) {
+			changed = append(changed, path)
+		}
+	}
+	if previous != nil {
+		for path := range previous.Sections {
+			if _, ok := s.Sections[path]; !ok {
+				changed = append(changed, path)
+			}
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// sameSection compares the sections without their sources, which do not change the effective configuration.
+func sameSection(a, b Section) bool {
+	return a.Hash == b.Hash && Ownership{Owner: a.Owner, Annotations: a.Annotations}.equal(Ownership{Owner: b.Owner, Annotations: b.Annotations})
+}
+
+// Record compares the state to the one saved in the file, and writes an audit event to the writer if any section
+// changed, before saving the state. The event is returned, or nil if nothing changed.
+func Record(path string, w io.Writer, state *State, now time.Time) (*Event, error) {
+	previous, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	var event *Event
+	if changed := state.Changed(previous); len(changed) != 0 {
+		if event, err = writeEvent(w, previous, state, changed, now); err != nil {
+			return nil, err
+		}
+	}
+	// the state is saved even without changes, for the sources to be up to date
+	return event, save(path, state)
+}
+
+func writeEvent(w io.Writer, previous, state *State, changed []string, now time.Time) (*Event, error) {
+	event := &Event{
+		Time:     now.UTC(),
+		Event:    eventConfigChange,
+		Hash:     state.Hash,
+		Changed:  changed,
+		Sections: map[string]Section{},
+		Sources:  state.Sources,
+	}
+	for _, path := range changed {
+		section, ok := state.Sections[path]
+		if !ok {
+			section = previous.Sections[path]
+		}
+		event.Sections[path] = section
+	}
+	if previous != nil {
+		event.PreviousHash = previous.Hash
+	}
+	content, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(append(content, '\n')); err != nil {
+		return nil, fmt.Errorf("unable to write the audit event: %w", err)
+	}
+	return event, nil
+}
+
+// Load returns the state saved in the file, or nil if there is none.
+func Load(path string) (*State, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state State
+	if err = json.Unmarshal(content, &state); err != nil {
+		return nil, fmt.Errorf("unable to read the configuration state from %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+func save(path string, state *State) error {
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	// the file is renamed into place, so that a crash never leaves a partial state behind
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// StatePath returns the path of the state file of the TOML config.
+func StatePath(tomlConfigPath string) string {
+	return filepath.Join(filepath.Dir(tomlConfigPath), StateFileName)
+}
+
+// SetCurrent sets the state of the configuration the agent runs with, for its self-telemetry.
+func SetCurrent(state *State) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = state
+}
+
+// Current returns the state of the configuration the agent runs with, or nil if it is unknown.
+func Current() *State {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+func parseOwnership(value any) (map[string]Ownership, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	var ownership map[string]Ownership
+	if err = decoder.Decode(&ownership); err != nil {
+		return nil, err
+	}
+	for path := range ownership {
+		if path == "" || strings.HasPrefix(path, pathSeparator) || strings.HasSuffix(path, pathSeparator) {
+			return nil, fmt.Errorf("invalid section %q", path)
+		}
+	}
+	return ownership, nil
+}
+
+// collectKeys adds the paths of the objects nested in the map.
+func collectKeys(m map[string]any, prefix string, keys map[string]bool) {
+	for key, value := range m {
+		path := prefix + key
+		keys[path] = true
+		if nested, ok := value.(map[string]any); ok {
+			collectKeys(nested, path+pathSeparator, keys)
+		}
+	}
+}
+
+func lookup(m map[string]any, path string) (any, bool) {
+	var value any = m
+	for _, key := range strings.Split(path, pathSeparator) {
+		nested, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = nested[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// jsonHash is the SHA-256 of the value encoded in JSON, whose objects have their keys sorted.
+func jsonHash(value any) (string, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,126 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package configaudit
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfigs() (map[string]any, map[string]any) {
+	metrics := map[string]any{
+		"metrics": map[string]any{"namespace": "CWAgent"},
+		"ownership": map[string]any{
+			"metrics": map[string]any{"owner": "platform", "annotations": map[string]any{"ticket": "CHG-1"}},
+		},
+	}
+	logs := map[string]any{
+		"agent": map[string]any{"region": "us-east-1"},
+		"logs": map[string]any{
+			"logs_collected": map[string]any{
+				"files": map[string]any{"collect_list": []any{map[string]any{"file_path": "/var/log/app.log"}}},
+			},
+		},
+		"ownership": map[string]any{
+			"logs.logs_collected.files": map[string]any{"owner": "app"},
+		},
+	}
+	return metrics, logs
+}
+
+func TestCollector(t *testing.T) {
+	metrics, logs := testConfigs()
+	c := NewCollector()
+	require.NoError(t, c.Add("file_metrics", metrics))
+	require.NoError(t, c.Add("file_logs", logs))
+	assert.NotContains(t, metrics, SectionKey)
+	assert.NotContains(t, logs, SectionKey)
+
+	merged := map[string]any{"agent": logs["agent"], "metrics": metrics["metrics"], "logs": logs["logs"]}
+	state, err := c.State(merged)
+	require.NoError(t, err)
+	assert.Len(t, state.Hash, 64)
+	assert.Equal(t, []string{"file_logs", "file_metrics"}, state.Sources)
+	assert.Len(t, state.Sections, 4)
+	assert.Equal(t, "platform", state.Sections["metrics"].Owner)
+	assert.Equal(t, map[string]string{"ticket": "CHG-1"}, state.Sections["metrics"].Annotations)
+	assert.Equal(t, []string{"file_metrics"}, state.Sections["metrics"].Sources)
+	files := state.Sections["logs.logs_collected.files"]
+	assert.Equal(t, "app", files.Owner)
+	assert.Equal(t, []string{"file_logs"}, files.Sources)
+	assert.NotEmpty(t, files.Hash)
+	assert.NotEqual(t, state.Sections["logs"].Hash, files.Hash)
+	assert.Empty(t, state.Sections["agent"].Owner)
+
+	same, err := c.State(merged)
+	require.NoError(t, err)
+	assert.Empty(t, same.Changed(state))
+}
+
+func TestCollectorInvalid(t *testing.T) {
+	c := NewCollector()
+	assert.ErrorContains(t, c.Add("file_a", map[string]any{"ownership": []any{"metrics"}}), "invalid ownership in file_a")
+	assert.ErrorContains(t, c.Add("file_a", map[string]any{"ownership": map[string]any{"metrics": map[string]any{"team": "a"}}}), "unknown field")
+	assert.ErrorContains(t, c.Add("file_a", map[string]any{"ownership": map[string]any{"metrics.": map[string]any{}}}), `invalid section "metrics."`)
+
+	require.NoError(t, c.Add("file_a", map[string]any{"ownership": map[string]any{"metrics": map[string]any{"owner": "a"}}}))
+	require.NoError(t, c.Add("file_b", map[string]any{"ownership": map[string]any{"metrics": map[string]any{"owner": "a"}}}))
+	assert.EqualError(t, c.Add("file_c", map[string]any{"ownership": map[string]any{"metrics": map[string]any{"owner": "c"}}}),
+		`conflicting ownership of section "metrics" in file_b and file_c`)
+}
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), StateFileName)
+	now := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	metrics, logs := testConfigs()
+	c := NewCollector()
+	require.NoError(t, c.Add("file_metrics", metrics))
+	require.NoError(t, c.Add("file_logs", logs))
+	state, err := c.State(map[string]any{"agent": logs["agent"], "metrics": metrics["metrics"]})
+	require.NoError(t, err)
+
+	// the first configuration is a change from nothing
+	var buf bytes.Buffer
+	event, err := Record(path, &buf, state, now)
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Empty(t, event.PreviousHash)
+	assert.Equal(t, []string{"agent", "logs.logs_collected.files", "metrics"}, event.Changed)
+	var written Event
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &written))
+	assert.Equal(t, *event, written)
+
+	buf.Reset()
+	event, err = Record(path, &buf, state, now)
+	require.NoError(t, err)
+	assert.Nil(t, event)
+	assert.Zero(t, buf.Len())
+
+	// the agent section is removed and the owner of metrics changes
+	c = NewCollector()
+	require.NoError(t, c.Add("file_metrics", map[string]any{
+		"metrics":   map[string]any{"namespace": "CWAgent"},
+		"ownership": map[string]any{"metrics": map[string]any{"owner": "observability"}},
+	}))
+	changed, err := c.State(map[string]any{"metrics": map[string]any{"namespace": "CWAgent"}})
+	require.NoError(t, err)
+	event, err = Record(path, &buf, changed, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, state.Hash, event.PreviousHash)
+	assert.Equal(t, changed.Hash, event.Hash)
+	assert.Equal(t, []string{"agent", "logs.logs_collected.files", "metrics"}, event.Changed)
+	assert.Equal(t, "observability", event.Sections["metrics"].Owner)
+	assert.Equal(t, state.Sections["agent"], event.Sections["agent"])
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, changed, loaded)
+}
@@ -0,0 +1,144 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package control lets operators pause and resume individual inputs and log sources of a running agent.
+// Sources are identified by the same keys as the profiler usage report, e.g. logfile:/var/log/messages or
+// input:cpu, and can be matched with filepath.Match patterns.
+package control
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	registry = newState()
+)
+
+type state struct {
+	mu       sync.RWMutex
+	sources  map[string]int
+	patterns map[string]struct{}
+	// numPatterns lets Paused skip the lock when nothing is paused.
+	numPatterns atomic.Int32
+	changed     chan struct{}
+}
+
+// Source is a registered source and whether it is currently paused.
+type Source struct {
+	Name   string `json:"name"`
+	Paused bool   `json:"paused"`
+}
+
+func newState() *state {
+	return &state{
+		sources:  make(map[string]int),
+		patterns: make(map[string]struct{}),
+		changed:  make(chan struct{}),
+	}
+}
+
+// Register adds the source to the list served by the control socket. Call Unregister when the source stops.
+func Register(key string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.sources[key]++
+}
+
+// Unregister removes a source added with Register.
+func Unregister(key string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if registry.sources[key] <= 1 {
+		delete(registry.sources, key)
+	} else {
+		registry.sources[key]--
+	}
+}
+
+// Paused returns true if the source matches any paused pattern.
+func Paused(key string) bool {
+	if registry.numPatterns.Load() == 0 {
+		return false
+	}
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.paused(key)
+}
+
+// Changed returns a channel which is closed the next time a pattern is paused or resumed.
+func Changed() <-chan struct{} {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.changed
+}
+
+// Pause pauses every source matching the pattern, including sources registered later.
+func Pause(pattern string) error {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid source pattern %q: %w", pattern, err)
+	}
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.patterns[pattern] = struct{}{}
+	registry.notify()
+	return nil
+}
+
+// Resume removes a pattern added with Pause. Returns false if the pattern was not paused.
+func Resume(pattern string) bool {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, ok := registry.patterns[pattern]; !ok {
+		return false
+	}
+	delete(registry.patterns, pattern)
+	registry.notify()
+	return true
+}
+
+// Sources returns the registered sources sorted by name.
+func Sources() []Source {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	sources := make([]Source, 0, len(registry.sources))
+	for key := range registry.sources {
+		sources = append(sources, Source{Name: key, Paused: registry.paused(key)})
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+	return sources
+}
+
+// PausedPatterns returns the paused patterns sorted.
+func PausedPatterns() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	patterns := make([]string, 0, len(registry.patterns))
+	for pattern := range registry.patterns {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+func (s *state) paused(key string) bool {
+	if _, ok := s.patterns[key]; ok {
+		return true
+	}
+	for pattern := range s.patterns {
+		if ok, _ := filepath.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// notify must be called with the lock held.
+func (s *state) notify() {
+	s.numPatterns.Store(int32(len(s.patterns)))
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resetState(t *testing.T) {
+	original := registry
+	registry = newState()
+	t.Cleanup(func() { registry = original })
+}
+
+func TestPauseResume(t *testing.T) {
+	resetState(t)
+	Register("logfile:/var/log/app/a.log")
+	Register("logfile:/var/log/app/b.log")
+	Register("input:cpu")
+	assert.False(t, Paused("input:cpu"))
+
+	changed := Changed()
+	assert.NoError(t, Pause("logfile:/var/log/app/*.log"))
+	select {
+	case <-changed:
+	default:
+		t.Fatal("expected change notification")
+	}
+	assert.True(t, Paused("logfile:/var/log/app/a.log"))
+	assert.True(t, Paused("logfile:/var/log/app/b.log"))
+	assert.True(t, Paused("logfile:/var/log/app/new.log"))
+	assert.False(t, Paused("logfile:/var/log/app/nested/c.log"))
+	assert.False(t, Paused("input:cpu"))
+	assert.Equal(t, []Source{
+		{Name: "input:cpu"},
+		{Name: "logfile:/var/log/app/a.log", Paused: true},
+		{Name: "logfile:/var/log/app/b.log", Paused: true},
+	}, Sources())
+
+	assert.NoError(t, Pause("input:cpu"))
+	assert.Equal(t, []string{"input:cpu", "logfile:/var/log/app/*.log"}, PausedPatterns())
+	assert.False(t, Resume("input:mem"))
+	assert.True(t, Resume("logfile:/var/log/app/*.log"))
+	assert.False(t, Paused("logfile:/var/log/app/a.log"))
+	assert.True(t, Paused("input:cpu"))
+
+	assert.Error(t, Pause("logfile:[a"))
+}
+
+func TestRegister(t *testing.T) {
+	resetState(t)
+	Register("input:cpu")
+	Register("input:cpu")
+	Unregister("input:cpu")
+	assert.Len(t, Sources(), 1)
+	Unregister("input:cpu")
+	assert.Empty(t, Sources())
+}
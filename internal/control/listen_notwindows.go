@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package control
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+// listen creates the socket in a private directory, where no other user can connect to it before it is restricted
+// to the user the agent runs as, and then moves it into place, replacing the socket left behind by a previous run.
+func listen(socketPath string) (*net.UnixListener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(socketPath), ".control-")
+	if err != nil {
+		return nil, paths.ReadOnlyHint(err)
+	}
+	defer os.RemoveAll(dir)
+	tmpPath := filepath.Join(dir, filepath.Base(socketPath))
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmpPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	listener.SetUnlinkOnClose(false)
+	if err = os.Chmod(tmpPath, 0600); err == nil {
+		err = os.Rename(tmpPath, socketPath)
+	}
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
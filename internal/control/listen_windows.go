@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package control
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows"
+)
+
+// protectedDACL grants Local System and the Administrators full access to the directory of the socket and to the
+// socket, which inherits it, and does not inherit the access of the Users to the agent directory.
+const protectedDACL = "D:P(A;OICI;FA;;;SY)(A;OICI;FA;;;BA)"
+
+// listen restricts the directory of the socket before creating the socket in it, so that no other user can connect
+// to it.
+func listen(socketPath string) (*net.UnixListener, error) {
+	if err := restrict(filepath.Dir(socketPath)); err != nil {
+		return nil, err
+	}
+	// remove the socket left behind by a previous run
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	listener.SetUnlinkOnClose(false)
+	return listener, nil
+}
+
+func restrict(dir string) error {
+	sd, err := windows.SecurityDescriptorFromString(protectedDACL)
+	if err != nil {
+		return err
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return err
+	}
+	return windows.SetNamedSecurityInfo(dir, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION, nil, nil, dacl, nil)
+}
@@ -0,0 +1,278 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/agentid"
+	"github.com/aws/amazon-cloudwatch-agent/internal/configaudit"
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
+	"github.com/aws/amazon-cloudwatch-agent/internal/faultinject"
+	"github.com/aws/amazon-cloudwatch-agent/internal/featureflag"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+const (
+	ActionList   = "list"
+	ActionPause  = "pause"
+	ActionResume = "resume"
+	// ActionReplay sends the records of the dead-letter store again, optionally repaired.
+	ActionReplay = "replay"
+	// ActionFault injects a fault into the AWS API calls, and ActionFaultClear removes them, when the faultinjection
+	// extension runs.
+	ActionFault      = "fault"
+	ActionFaultClear = "fault-clear"
+
+	sourceParam = "source"
+	repairParam = "repair"
+)
+
+// Status is the response of every control request.
+type Status struct {
+	// Agent identifies the agent, across its restarts with the instance ID and for this process with the run ID.
+	Agent   agentid.Identity `json:"agent"`
+	Sources []Source         `json:"sources"`
+	Paused  []string         `json:"paused"`
+	// Errors are the errors recorded since the agent started, by error code.
+	Errors []errcode.Summary `json:"errors"`
+	// LogSources is the progress of the agent through each log file it is tailing.
+	LogSources []selftelemetry.LogSourceStatus `json:"log_sources"`
+	// Retries are the retries of the requests each output sent to AWS.
+	Retries []selftelemetry.RetryStatus `json:"retries"`
+	// IMDS are the calls of the agent to the EC2 instance metadata service, by operation.
+	IMDS []selftelemetry.IMDSStatus `json:"imds"`
+	// Components are the receivers and exporters that failed and were isolated and restarted.
+	Components []selftelemetry.ComponentStatus `json:"components"`
+	// Watermarks are the timestamps of the data collected and exported by each output, and the gaps of its export.
+	Watermarks []selftelemetry.WatermarkStatus `json:"watermarks"`
+	// FeatureFlags are the behaviors of the agent gated by a feature flag, and whether they are enabled.
+	FeatureFlags []featureflag.Status `json:"feature_flags"`
+	// Faults are the faults injected into the AWS API calls, when the faultinjection extension runs.
+	Faults []faultinject.Status `json:"faults"`
+	// Config is the state of the configuration the agent runs with, with the owner of each section.
+	Config *configaudit.State `json:"config,omitempty"`
+}
+
+// Serve listens on the unix socket until the context is done. Only the user the agent runs as can connect to the
+// socket, which is created with 0600 permissions, or only Local System and the Administrators on Windows. The socket
+// is not removed when the listener closes, since the next Serve after a reload replaces it.
+func Serve(ctx context.Context, socketPath string) error {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0755); err != nil {
+		return paths.ReadOnlyHint(err)
+	}
+	listener, err := listen(socketPath)
+	if err != nil {
+		return paths.ReadOnlyHint(err)
+	}
+	server := &http.Server{Handler: Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	log.Printf("I! Control socket listening on %s", socketPath)
+	if err = server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Handler serves GET /list, POST /pause?source=<pattern>, POST /resume?source=<pattern>,
+// POST /replay?source=<log group pattern>&repair=true, POST /fault?kind=<kind>&operation=<pattern> and
+// POST /fault-clear.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/"+ActionList, func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK)
+	})
+	mux.HandleFunc("/"+ActionPause, func(w http.ResponseWriter, r *http.Request) {
+		if !checkRequest(w, r) {
+			return
+		}
+		source := r.URL.Query().Get(sourceParam)
+		if err := Pause(source); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("I! Paused sources matching %q", source)
+		writeStatus(w, http.StatusOK)
+	})
+	mux.HandleFunc("/"+ActionResume, func(w http.ResponseWriter, r *http.Request) {
+		if !checkRequest(w, r) {
+			return
+		}
+		source := r.URL.Query().Get(sourceParam)
+		if !Resume(source) {
+			http.Error(w, fmt.Sprintf("%q is not paused", source), http.StatusNotFound)
+			return
+		}
+		log.Printf("I! Resumed sources matching %q", source)
+		writeStatus(w, http.StatusOK)
+	})
+	mux.HandleFunc("/"+ActionReplay, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		source := r.URL.Query().Get(sourceParam)
+		repair := r.URL.Query().Get(repairParam) == "true"
+		result, err := deadletter.Replay(source, repair)
+		if errors.Is(err, deadletter.ErrNoStore) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("I! Replayed %d dead-letter records, %d repaired", result.Replayed, result.Repaired)
+		writeJSON(w, http.StatusOK, result)
+	})
+	mux.HandleFunc("/"+ActionFault, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		fault, err := faultinject.ParseFault(r.URL.Query())
+		if err == nil {
+			err = faultinject.Set(fault)
+		}
+		if errors.Is(err, faultinject.ErrDisabled) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("W! Injecting %s faults into the %q calls", fault.Kind, fault.Operation)
+		writeStatus(w, http.StatusOK)
+	})
+	mux.HandleFunc("/"+ActionFaultClear, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		log.Printf("I! Cleared %d faults", faultinject.Clear())
+		writeStatus(w, http.StatusOK)
+	})
+	return mux
+}
+
+func checkRequest(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return false
+	}
+	if r.URL.Query().Get(sourceParam) == "" {
+		http.Error(w, "missing source", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeStatus(w http.ResponseWriter, code int) {
+	writeJSON(w, code, Status{
+		Agent:        agentid.Get(),
+		Sources:      Sources(),
+		Paused:       PausedPatterns(),
+		Errors:       errcode.Summaries(),
+		LogSources:   selftelemetry.LogSources.Statuses(),
+		Retries:      selftelemetry.Retries.Statuses(),
+		IMDS:         selftelemetry.IMDS.Statuses(),
+		Components:   selftelemetry.Components.Statuses(),
+		Watermarks:   selftelemetry.Watermarks.Statuses(),
+		FeatureFlags: featureflag.Statuses(),
+		Faults:       faultinject.Statuses(),
+		Config:       configaudit.Current(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v)
+}
+
+// Send runs the action against the agent listening on the socket and returns the response body.
+func Send(socketPath, action, source string) (string, error) {
+	query := url.Values{}
+	if source != "" {
+		query.Set(sourceParam, source)
+	}
+	return send(socketPath, action, query)
+}
+
+// Replay asks the agent listening on the socket to replay the dead-letter records of the matching log groups.
+func Replay(socketPath, groupPattern string, repair bool) (string, error) {
+	query := url.Values{}
+	if groupPattern != "" {
+		query.Set(sourceParam, groupPattern)
+	}
+	if repair {
+		query.Set(repairParam, "true")
+	}
+	return send(socketPath, ActionReplay, query)
+}
+
+// Fault asks the agent listening on the socket to inject the fault, given as query parameters, e.g.
+// kind=throttle&operation=PutLogEvents&rate=0.5&duration=5m.
+func Fault(socketPath, fault string) (string, error) {
+	query, err := url.ParseQuery(fault)
+	if err != nil {
+		return "", fmt.Errorf("invalid fault %q: %w", fault, err)
+	}
+	return send(socketPath, ActionFault, query)
+}
+
+// send runs the action with the query parameters and returns the response body.
+func send(socketPath, action string, query url.Values) (string, error) {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+	method := http.MethodPost
+	if action == ActionList {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequest(method, "http://agent/"+action, nil)
+	if err != nil {
+		return "", err
+	}
+	req.URL.RawQuery = query.Encode()
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to reach the agent on %s: %w", socketPath, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s failed: %s", action, strings.TrimSpace(string(body)))
+	}
+	return string(body), nil
+}
@@ -0,0 +1,132 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/agentid"
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
+	"github.com/aws/amazon-cloudwatch-agent/internal/faultinject"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+func TestServe(t *testing.T) {
+	resetState(t)
+	errcode.Reset()
+	t.Cleanup(errcode.Reset)
+	Register("logfile:/var/log/app.log")
+	stats := selftelemetry.LogSources.Register("logfile:/var/log/app.log", "/var/log/app.log", "app", "stream")
+	defer selftelemetry.LogSources.Unregister("logfile:/var/log/app.log")
+	stats.RecordParseFailure()
+	selftelemetry.Retries.Register("cloudwatchlogs").RecordRetry(true, false)
+	errcode.Record(errcode.New(errcode.Config, errors.New("invalid interval")))
+	paths.AgentIDPath = filepath.Join(t.TempDir(), paths.AGENT_ID)
+	// keep the path short since unix socket paths are limited to around 100 characters
+	dir, err := os.MkdirTemp("", "cwa")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "control.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Serve(ctx, socketPath) }()
+	require.Eventually(t, func() bool {
+		_, err := Send(socketPath, ActionList, "")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	info, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	// the private directory the socket is created in is removed once it is in place
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	output, err := Send(socketPath, ActionPause, "logfile:/var/log/*.log")
+	require.NoError(t, err)
+	var status Status
+	require.NoError(t, json.Unmarshal([]byte(output), &status))
+	assert.Equal(t, []Source{{Name: "logfile:/var/log/app.log", Paused: true}}, status.Sources)
+	assert.Equal(t, agentid.InstanceID(), status.Agent.InstanceID)
+	assert.Equal(t, agentid.RunID(), status.Agent.RunID)
+	require.Len(t, status.Errors, 1)
+	assert.Equal(t, errcode.Config, status.Errors[0].Code)
+	assert.EqualValues(t, 1, status.Errors[0].Count)
+	assert.Equal(t, "invalid interval", status.Errors[0].LastError)
+	require.Len(t, status.LogSources, 1)
+	assert.Equal(t, "app", status.LogSources[0].LogGroup)
+	assert.EqualValues(t, 1, status.LogSources[0].ParseFailures)
+	require.NotEmpty(t, status.Retries)
+	assert.Equal(t, "cloudwatchlogs", status.Retries[0].Output)
+	assert.EqualValues(t, 1, status.Retries[0].Throttled)
+	assert.True(t, Paused("logfile:/var/log/app.log"))
+
+	_, err = Send(socketPath, ActionPause, "")
+	assert.ErrorContains(t, err, "missing source")
+	_, err = Send(socketPath, ActionResume, "input:cpu")
+	assert.ErrorContains(t, err, "not paused")
+
+	_, err = Send(socketPath, ActionResume, "logfile:/var/log/*.log")
+	require.NoError(t, err)
+	assert.False(t, Paused("logfile:/var/log/app.log"))
+
+	_, err = Replay(socketPath, "", false)
+	assert.ErrorContains(t, err, "not enabled")
+	store := deadletter.NewStore(dir, 0)
+	var replayed []deadletter.Record
+	deadletter.Register(store, func(record deadletter.Record) { replayed = append(replayed, record) })
+	defer deadletter.Unregister(store)
+	require.NoError(t, store.Add(deadletter.Record{Reason: deadletter.ReasonTooOld, Group: "/app", Message: "old"}))
+	output, err = Replay(socketPath, "/app", true)
+	require.NoError(t, err)
+	var result deadletter.ReplayResult
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, deadletter.ReplayResult{Replayed: 1, Repaired: 1}, result)
+	require.Len(t, replayed, 1)
+	assert.False(t, replayed[0].Timestamp.IsZero())
+
+	_, err = Fault(socketPath, "kind=throttle&operation=PutLogEvents")
+	assert.ErrorContains(t, err, "not enabled")
+	require.NoError(t, faultinject.Enable(faultinject.Limits{MaxLatency: time.Second, MaxDuration: time.Hour}))
+	defer faultinject.Disable()
+	output, err = Fault(socketPath, "kind=throttle&operation=PutLogEvents&rate=0.5&duration=5m")
+	require.NoError(t, err)
+	status = Status{}
+	require.NoError(t, json.Unmarshal([]byte(output), &status))
+	require.Len(t, status.Faults, 1)
+	assert.Equal(t, faultinject.KindThrottle, status.Faults[0].Kind)
+	assert.Equal(t, 0.5, status.Faults[0].Rate)
+	_, err = Fault(socketPath, "kind=outage&operation=PutLogEvents")
+	assert.ErrorContains(t, err, "kind must be")
+	output, err = Send(socketPath, ActionFaultClear, "")
+	require.NoError(t, err)
+	status = Status{}
+	require.NoError(t, json.Unmarshal([]byte(output), &status))
+	assert.Empty(t, status.Faults)
+
+	cancel()
+	assert.NoError(t, <-done)
+
+	// a new control socket replaces the one left behind
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	go func() { done <- Serve(ctx, socketPath) }()
+	require.Eventually(t, func() bool {
+		_, err := Send(socketPath, ActionList, "")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package crashreport keeps the fatal errors of the agent, e.g. an unrecovered panic, with the identity of the agent
+// that crashed, so that the crashes can be attributed to an agent of the fleet after it restarted.
+package crashreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/agentid"
+	"github.com/aws/amazon-cloudwatch-agent/internal/version"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+const (
+	reportPrefix = "crash-"
+	reportSuffix = ".log"
+	// maxReports is how many reports of the previous crashes are kept.
+	maxReports = 10
+)
+
+// Header is the first line of a report.
+type Header struct {
+	agentid.Identity
+	Version string `json:"version"`
+}
+
+// Start opens the report of the process in dir, which the runtime writes the fatal errors of the agent to. The report
+// is removed by the returned function when the agent exits without crashing, so only the reports of the crashes are
+// left in dir.
+func Start(dir string) (func(), error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, paths.ReadOnlyHint(err)
+	}
+	prune(dir)
+	identity := agentid.Get()
+	path := filepath.Join(dir, fmt.Sprintf("%s%s-%s%s", reportPrefix, identity.StartTime.UTC().Format("20060102T150405Z"), identity.RunID, reportSuffix))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, paths.ReadOnlyHint(err)
+	}
+	header, _ := json.Marshal(Header{Identity: identity, Version: version.Full()})
+	if _, err = fmt.Fprintf(f, "%s\n", header); err == nil {
+		err = debug.SetCrashOutput(f, debug.CrashOptions{})
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	return func() {
+		_ = debug.SetCrashOutput(nil, debug.CrashOptions{})
+		f.Close()
+		if err := os.Remove(path); err != nil {
+			log.Printf("W! Unable to remove the crash report %s: %v", path, err)
+		}
+	}, nil
+}
+
+// prune removes the oldest reports past maxReports. The reports are named after the start time of their process, so
+// they sort from the oldest.
+func prune(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	var reports []string
+	for _, entry := range entries {
+		if name := entry.Name(); strings.HasPrefix(name, reportPrefix) && strings.HasSuffix(name, reportSuffix) {
+			reports = append(reports, name)
+		}
+	}
+	if len(reports) > 0 {
+		log.Printf("W! Found %d crash reports of the previous runs of the agent in %s", len(reports), dir)
+	}
+	sort.Strings(reports)
+	for len(reports) >= maxReports {
+		_ = os.Remove(filepath.Join(dir, reports[0]))
+		reports = reports[1:]
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package crashreport
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/agentid"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+func TestStart(t *testing.T) {
+	paths.AgentIDPath = filepath.Join(t.TempDir(), paths.AGENT_ID)
+	dir := filepath.Join(t.TempDir(), paths.CRASH)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	// the reports of previous crashes, past which the oldest are removed
+	for i := 0; i < maxReports+2; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("%s202601%02dT000000Z-run%s", reportPrefix, i, reportSuffix)), nil, 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.log"), nil, 0644))
+
+	stop, err := Start(dir)
+	require.NoError(t, err)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, maxReports+1)
+	assert.NoFileExists(t, filepath.Join(dir, reportPrefix+"20260100T000000Z-run"+reportSuffix))
+	assert.NoFileExists(t, filepath.Join(dir, reportPrefix+"20260101T000000Z-run"+reportSuffix))
+	assert.FileExists(t, filepath.Join(dir, "other.log"))
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+agentid.RunID()+reportSuffix))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	f, err := os.Open(matches[0])
+	require.NoError(t, err)
+	scanner := bufio.NewScanner(f)
+	require.True(t, scanner.Scan())
+	f.Close()
+	var header Header
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &header))
+	identity := agentid.Get()
+	assert.Equal(t, identity.InstanceID, header.InstanceID)
+	assert.Equal(t, identity.RunID, header.RunID)
+	assert.True(t, identity.StartTime.Equal(header.StartTime))
+	assert.NotEmpty(t, header.Version)
+
+	stop()
+	assert.NoFileExists(t, matches[0], "the report is removed when the agent did not crash")
+}
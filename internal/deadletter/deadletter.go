@@ -0,0 +1,262 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package deadletter keeps the log events CloudWatch Logs permanently rejected, so they can be inspected and
+// replayed instead of being lost. The same store holds the events the agent quarantined itself.
+package deadletter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/logrotate"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+const (
+	// ReasonTooOld is used for events older than CloudWatch Logs accepts, which is 14 days or the retention of the
+	// log group.
+	ReasonTooOld = "too_old"
+	// ReasonTooNew is used for events more than 2 hours in the future.
+	ReasonTooNew = "too_new"
+	// ReasonExpired is used for events older than the retention of the log group.
+	ReasonExpired = "expired"
+	// ReasonInvalid is used for the events of a request rejected as invalid, e.g. because an event is too large.
+	ReasonInvalid = "invalid"
+	// ReasonSensitive is used for events quarantined because sensitive data was detected in them. They are never
+	// repaired.
+	ReasonSensitive = "sensitive"
+
+	fileName = "cloudwatchlogs.jsonl"
+	fileMode = 0600
+
+	// maxMessageSize is the largest message CloudWatch Logs accepts, which is 256 KB minus the 26 bytes of
+	// overhead counted for every event.
+	maxMessageSize = 256*1024 - 26
+	repairSuffix   = "[Truncated...]"
+)
+
+// Record is a rejected log event and where it was going.
+type Record struct {
+	RejectedAt time.Time `json:"rejected_at"`
+	Reason     string    `json:"reason"`
+	Group      string    `json:"log_group"`
+	Stream     string    `json:"log_stream"`
+	Class      string    `json:"log_group_class,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+	Message    string    `json:"message"`
+}
+
+// Store appends records to a file in its directory until the file reaches the maximum size, after which the records
+// older than the retention of agent.log_rotation are removed to make room, and new records are dropped if there is
+// still none.
+type Store struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+}
+
+// NewStore creates a store in the directory. A maxSize that is not positive does not limit the size.
+func NewStore(dir string, maxSize int64) *Store {
+	return &Store{path: filepath.Join(dir, fileName), maxSize: maxSize}
+}
+
+// Path returns the file the records are written to.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Add appends the records. Returns an error if the store is full or cannot be written, in which case none of the
+// records were kept.
+func (s *Store) Add(records ...Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := encode(&buf, records); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.maxSize > 0 {
+		size := s.size()
+		if size+int64(buf.Len()) > s.maxSize {
+			expired, err := s.expire(time.Now())
+			if err != nil {
+				return err
+			}
+			if expired > 0 {
+				size = s.size()
+			}
+		}
+		if size+int64(buf.Len()) > s.maxSize {
+			return fmt.Errorf("dead-letter store %s is full", s.path)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return paths.ReadOnlyHint(err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, fileMode)
+	if err != nil {
+		return paths.ReadOnlyHint(err)
+	}
+	if _, err = f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Records returns the stored records, oldest first.
+func (s *Store) Records() ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read()
+}
+
+// Take removes the records that match and returns them. The others stay in the store.
+func (s *Store) Take(match func(Record) bool) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	var taken, kept []Record
+	for _, record := range records {
+		if match == nil || match(record) {
+			taken = append(taken, record)
+		} else {
+			kept = append(kept, record)
+		}
+	}
+	if len(taken) == 0 {
+		return nil, nil
+	}
+	return taken, s.write(kept)
+}
+
+// Purge removes every record.
+func (s *Store) Purge() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) size() int64 {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// expire removes the records kept longer than the retention of agent.log_rotation, and returns how many there were.
+func (s *Store) expire(now time.Time) (int, error) {
+	maxAge := logrotate.Current().MaxAge()
+	if maxAge <= 0 {
+		return 0, nil
+	}
+	records, err := s.read()
+	if err != nil {
+		return 0, err
+	}
+	var kept []Record
+	for _, record := range records {
+		if now.Sub(record.RejectedAt) < maxAge {
+			kept = append(kept, record)
+		}
+	}
+	if len(kept) == len(records) {
+		return 0, nil
+	}
+	return len(records) - len(kept), s.write(kept)
+}
+
+func (s *Store) read() ([]Record, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var records []Record
+	decoder := json.NewDecoder(f)
+	for decoder.More() {
+		var record Record
+		if err = decoder.Decode(&record); err != nil {
+			return nil, fmt.Errorf("invalid record %d in %s: %w", len(records)+1, s.path, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// write replaces the file with the records, through a temporary file so a crash cannot lose them.
+func (s *Store) write(records []Record) error {
+	if len(records) == 0 {
+		if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fileMode)
+	if err != nil {
+		return err
+	}
+	if err = encode(f, records); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// encode writes one record per line. HTML is not escaped so the messages stay readable.
+func encode(w io.Writer, records []Record) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Repair changes a record so CloudWatch Logs can accept it. Events outside the accepted time range are given the
+// current time, and messages that are too large are truncated. Returns false if the record cannot be repaired.
+func Repair(record Record, now time.Time) (Record, bool) {
+	switch record.Reason {
+	case ReasonTooOld, ReasonTooNew, ReasonExpired:
+		record.Timestamp = now
+		return record, true
+	case ReasonInvalid:
+		if len(record.Message) <= maxMessageSize {
+			return record, false
+		}
+		cut := maxMessageSize - len(repairSuffix)
+		for cut > 0 && !utf8.RuneStart(record.Message[cut]) {
+			cut--
+		}
+		record.Message = record.Message[:cut] + repairSuffix
+		return record, true
+	}
+	return record, false
+}
@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package deadletter
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/logrotate"
+)
+
+func testRecord(group, reason, message string) Record {
+	return Record{
+		RejectedAt: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		Reason:     reason,
+		Group:      group,
+		Stream:     "stream",
+		Timestamp:  time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+		Message:    message,
+	}
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(t.TempDir(), 0)
+	records, err := store.Records()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	app := testRecord("/app", ReasonTooOld, "<b>old</b>")
+	system := testRecord("/system", ReasonInvalid, "invalid")
+	require.NoError(t, store.Add(app))
+	require.NoError(t, store.Add(system, app))
+	content, err := os.ReadFile(store.Path())
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(content), "\n"))
+	assert.Contains(t, string(content), "<b>old</b>")
+	info, err := os.Stat(store.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(fileMode), info.Mode().Perm())
+
+	records, err = store.Records()
+	require.NoError(t, err)
+	assert.Equal(t, []Record{app, system, app}, records)
+
+	match, err := Match("/app*")
+	require.NoError(t, err)
+	taken, err := store.Take(match)
+	require.NoError(t, err)
+	assert.Equal(t, []Record{app, app}, taken)
+	records, err = store.Records()
+	require.NoError(t, err)
+	assert.Equal(t, []Record{system}, records)
+
+	taken, err = store.Take(match)
+	require.NoError(t, err)
+	assert.Empty(t, taken)
+
+	require.NoError(t, store.Purge())
+	records, err = store.Records()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	require.NoError(t, store.Purge())
+}
+
+func TestStoreMaxSize(t *testing.T) {
+	store := NewStore(t.TempDir(), 300)
+	record := testRecord("/app", ReasonTooOld, "message")
+	record.RejectedAt = time.Now()
+	require.NoError(t, store.Add(record))
+	assert.ErrorContains(t, store.Add(record, record), "is full")
+	records, err := store.Records()
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestStoreMaxAge(t *testing.T) {
+	t.Cleanup(func() { logrotate.Configure(logrotate.DefaultConfig()) })
+	store := NewStore(t.TempDir(), 400)
+	old := testRecord("/old", ReasonTooOld, "message")
+	recent := testRecord("/recent", ReasonTooOld, "message")
+	recent.RejectedAt = time.Now().Add(-time.Hour)
+	require.NoError(t, store.Add(old, recent))
+
+	// the records are kept until the store is full
+	logrotate.Configure(logrotate.Config{MaxSizeMB: 1, MaxAgeDays: 0})
+	assert.ErrorContains(t, store.Add(recent), "is full")
+	logrotate.Configure(logrotate.Config{MaxSizeMB: 1, MaxAgeDays: 1})
+	require.NoError(t, store.Add(recent))
+	records, err := store.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "/recent", records[0].Group)
+	assert.Equal(t, "/recent", records[1].Group)
+}
+
+func TestStoreInvalidRecord(t *testing.T) {
+	store := NewStore(t.TempDir(), 0)
+	require.NoError(t, store.Add(testRecord("/app", ReasonTooOld, "message")))
+	f, err := os.OpenFile(store.Path(), os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("{not json\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	_, err = store.Records()
+	assert.ErrorContains(t, err, "invalid record 2")
+}
+
+func TestRepair(t *testing.T) {
+	now := time.Now()
+	for _, reason := range []string{ReasonTooOld, ReasonTooNew, ReasonExpired} {
+		repaired, ok := Repair(testRecord("/app", reason, "message"), now)
+		assert.True(t, ok)
+		assert.Equal(t, now, repaired.Timestamp)
+		assert.Equal(t, "message", repaired.Message)
+	}
+
+	_, ok := Repair(testRecord("/app", ReasonInvalid, "message"), now)
+	assert.False(t, ok)
+
+	large := testRecord("/app", ReasonInvalid, strings.Repeat("€", maxMessageSize/3+10))
+	repaired, ok := Repair(large, now)
+	assert.True(t, ok)
+	assert.LessOrEqual(t, len(repaired.Message), maxMessageSize)
+	assert.True(t, strings.HasSuffix(repaired.Message, repairSuffix))
+	assert.True(t, strings.HasPrefix(repaired.Message, "€€"))
+	assert.Equal(t, large.Timestamp, repaired.Timestamp)
+}
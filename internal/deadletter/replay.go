@@ -0,0 +1,97 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package deadletter
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNoStore is returned by Replay when no destination of the running agent keeps a dead-letter store.
+	ErrNoStore = errors.New("the dead-letter store is not enabled")
+
+	registry struct {
+		sync.Mutex
+		store  *Store
+		replay func(Record)
+	}
+)
+
+// ReplayResult is the outcome of a Replay.
+type ReplayResult struct {
+	// Replayed is the number of records sent again. Records that are rejected again go back to the store.
+	Replayed int `json:"replayed"`
+	// Repaired is the number of replayed records changed by Repair.
+	Repaired int `json:"repaired"`
+}
+
+// Register makes the records of the store replayable by the running agent. The function sends a record to the
+// destination it was rejected by.
+func Register(store *Store, replay func(Record)) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.store = store
+	registry.replay = replay
+}
+
+// Unregister removes a store added with Register.
+func Unregister(store *Store) {
+	registry.Lock()
+	defer registry.Unlock()
+	if registry.store == store {
+		registry.store = nil
+		registry.replay = nil
+	}
+}
+
+// Match returns a filter for Take that selects the records of the log groups matching the filepath.Match pattern.
+// An empty pattern selects every record.
+func Match(groupPattern string) (func(Record) bool, error) {
+	if groupPattern == "" {
+		return nil, nil
+	}
+	if _, err := filepath.Match(groupPattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid log group pattern %q: %w", groupPattern, err)
+	}
+	return func(record Record) bool {
+		ok, _ := filepath.Match(groupPattern, record.Group)
+		return ok
+	}, nil
+}
+
+// Replay takes the records of the log groups matching the pattern out of the registered store and sends them
+// again. With repair, the records are passed through Repair first.
+func Replay(groupPattern string, repair bool) (ReplayResult, error) {
+	var result ReplayResult
+	match, err := Match(groupPattern)
+	if err != nil {
+		return result, err
+	}
+	registry.Lock()
+	store, replay := registry.store, registry.replay
+	registry.Unlock()
+	if store == nil {
+		return result, ErrNoStore
+	}
+	records, err := store.Take(match)
+	if err != nil {
+		return result, err
+	}
+	now := time.Now()
+	for _, record := range records {
+		if repair {
+			var repaired bool
+			if record, repaired = Repair(record, now); repaired {
+				result.Repaired++
+			}
+		}
+		replay(record)
+		result.Replayed++
+	}
+	return result, nil
+}
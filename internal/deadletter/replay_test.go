@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package deadletter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	match, err := Match("")
+	assert.NoError(t, err)
+	assert.Nil(t, match)
+	_, err = Match("[")
+	assert.Error(t, err)
+}
+
+func TestReplay(t *testing.T) {
+	_, err := Replay("", false)
+	assert.ErrorIs(t, err, ErrNoStore)
+
+	store := NewStore(t.TempDir(), 0)
+	var replayed []Record
+	Register(store, func(record Record) { replayed = append(replayed, record) })
+	defer Unregister(store)
+	require.NoError(t, store.Add(
+		testRecord("/app", ReasonTooOld, "old"),
+		testRecord("/system", ReasonTooNew, "new"),
+		testRecord("/app", ReasonInvalid, "invalid"),
+	))
+
+	result, err := Replay("/app", true)
+	require.NoError(t, err)
+	assert.Equal(t, ReplayResult{Replayed: 2, Repaired: 1}, result)
+	require.Len(t, replayed, 2)
+	assert.Equal(t, "old", replayed[0].Message)
+	assert.WithinDuration(t, time.Now(), replayed[0].Timestamp, time.Minute)
+	assert.Equal(t, "invalid", replayed[1].Message)
+
+	replayed = nil
+	result, err = Replay("", false)
+	require.NoError(t, err)
+	assert.Equal(t, ReplayResult{Replayed: 1}, result)
+	require.Len(t, replayed, 1)
+	assert.Equal(t, testRecord("/system", ReasonTooNew, "new").Timestamp, replayed[0].Timestamp)
+
+	_, err = Replay("[", false)
+	assert.Error(t, err)
+
+	Unregister(store)
+	_, err = Replay("", false)
+	assert.ErrorIs(t, err, ErrNoStore)
+}
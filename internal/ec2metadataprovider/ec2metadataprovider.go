@@ -5,16 +5,34 @@ package ec2metadataprovider
 
 import (
 	"context"
+	"errors"
 	"log"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
 	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+)
+
+const (
+	// fetchTokenHandlerName is the name of the handler of the SDK requesting the IMDSv2 token.
+	fetchTokenHandlerName = "FetchTokenHandler"
+	// tokenPath is the path of the IMDSv2 token requests.
+	tokenPath = "/latest/api/token"
+	// tokenRecheckInterval is how long the IMDSv2 token is not requested once it could not be reached.
+	tokenRecheckInterval = 30 * time.Minute
 )
 
 type MetadataProvider interface {
@@ -29,10 +47,78 @@ type MetadataProvider interface {
 type metadataClient struct {
 	metadataFallbackDisabled *ec2metadata.EC2Metadata
 	metadataFallbackEnabled  *ec2metadata.EC2Metadata
+	// metadataV1 does not request the IMDSv2 token. It is used while the token cannot be reached.
+	metadataV1 *ec2metadata.EC2Metadata
+	// fallbackAllowed is false when IMDSv1 is turned off with AWS_EC2_METADATA_V1_DISABLED.
+	fallbackAllowed bool
+	now             func() time.Time
+
+	mu                    sync.Mutex
+	tokenUnreachableUntil time.Time
 }
 
 var _ MetadataProvider = (*metadataClient)(nil)
 
+var (
+	sharedOnce     sync.Once
+	sharedProvider MetadataProvider
+)
+
+// SharedMetadataProvider returns the metadata provider shared by the components of the agent, so that they reuse the
+// IMDSv2 token and skip requesting it once it is found unreachable.
+func SharedMetadataProvider() MetadataProvider {
+	sharedOnce.Do(func() {
+		sharedProvider = newSharedProvider()
+	})
+	return sharedProvider
+}
+
+// newSharedProvider creates the shared provider. IMDS does not need credentials, so they are not resolved. When the
+// session cannot be created, e.g. because the CA bundle cannot be loaded, every call of the provider fails with the
+// error.
+func newSharedProvider() MetadataProvider {
+	ses, err := session.NewSession(&aws.Config{
+		LogLevel: configaws.SDKLogLevel(),
+		Logger:   configaws.SDKLogger{},
+	})
+	if err != nil {
+		log.Printf("E! Failed to create the session of the metadata provider: %v", err)
+		return errorProvider{err: err}
+	}
+	return NewMetadataProvider(ses, retryer.GetDefaultRetryNumber())
+}
+
+// errorProvider is the metadata provider whose calls all fail with the error.
+type errorProvider struct {
+	err error
+}
+
+var _ MetadataProvider = errorProvider{}
+
+func (p errorProvider) Get(context.Context) (ec2metadata.EC2InstanceIdentityDocument, error) {
+	return ec2metadata.EC2InstanceIdentityDocument{}, p.err
+}
+
+func (p errorProvider) Hostname(context.Context) (string, error) {
+	return "", p.err
+}
+
+func (p errorProvider) InstanceID(context.Context) (string, error) {
+	return "", p.err
+}
+
+func (p errorProvider) InstanceTags(context.Context) ([]string, error) {
+	return nil, p.err
+}
+
+func (p errorProvider) ClientIAMRole(context.Context) (string, error) {
+	return "", p.err
+}
+
+func (p errorProvider) InstanceTagValue(context.Context, string) (string, error) {
+	return "", p.err
+}
+
 func NewMetadataProvider(p client.ConfigProvider, retries int) MetadataProvider {
 	disableFallbackConfig := &aws.Config{
 		LogLevel:                  configaws.SDKLogLevel(),
@@ -44,32 +130,38 @@ func NewMetadataProvider(p client.ConfigProvider, retries int) MetadataProvider
 		LogLevel: configaws.SDKLogLevel(),
 		Logger:   configaws.SDKLogger{},
 	}
+	metadataV1 := ec2metadata.New(p, enableFallbackConfig)
+	metadataV1.Handlers.Sign.RemoveByName(fetchTokenHandlerName)
+	fallbackDisabled, _ := strconv.ParseBool(os.Getenv(envconfig.AWS_EC2_METADATA_V1_DISABLED))
 	return &metadataClient{
 		metadataFallbackDisabled: ec2metadata.New(p, disableFallbackConfig),
 		metadataFallbackEnabled:  ec2metadata.New(p, enableFallbackConfig),
+		metadataV1:               metadataV1,
+		fallbackAllowed:          !fallbackDisabled,
+		now:                      time.Now,
 	}
 }
 
 func (c *metadataClient) InstanceID(ctx context.Context) (string, error) {
-	return withMetadataFallbackRetry(ctx, c, func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
+	return withMetadataFallbackRetry(ctx, c, "instance_id", func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
 		return metadataClient.GetMetadataWithContext(ctx, "instance-id")
 	})
 }
 
 func (c *metadataClient) Hostname(ctx context.Context) (string, error) {
-	return withMetadataFallbackRetry(ctx, c, func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
+	return withMetadataFallbackRetry(ctx, c, "hostname", func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
 		return metadataClient.GetMetadataWithContext(ctx, "hostname")
 	})
 }
 
 func (c *metadataClient) ClientIAMRole(ctx context.Context) (string, error) {
-	return withMetadataFallbackRetry(ctx, c, func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
+	return withMetadataFallbackRetry(ctx, c, "iam_role", func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
 		return metadataClient.GetMetadataWithContext(ctx, "iam/security-credentials")
 	})
 }
 
 func (c *metadataClient) InstanceTags(ctx context.Context) ([]string, error) {
-	tags, err := withMetadataFallbackRetry(ctx, c, func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
+	tags, err := withMetadataFallbackRetry(ctx, c, "instance_tags", func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
 		return metadataClient.GetMetadataWithContext(ctx, "tags/instance")
 	})
 	if err != nil {
@@ -80,25 +172,75 @@ func (c *metadataClient) InstanceTags(ctx context.Context) ([]string, error) {
 
 func (c *metadataClient) InstanceTagValue(ctx context.Context, tagKey string) (string, error) {
 	path := "tags/instance/" + tagKey
-	return withMetadataFallbackRetry(ctx, c, func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
+	return withMetadataFallbackRetry(ctx, c, "instance_tag_value", func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
 		return metadataClient.GetMetadataWithContext(ctx, path)
 	})
 }
 
 func (c *metadataClient) Get(ctx context.Context) (ec2metadata.EC2InstanceIdentityDocument, error) {
-	return withMetadataFallbackRetry(ctx, c, func(metadataClient *ec2metadata.EC2Metadata) (ec2metadata.EC2InstanceIdentityDocument, error) {
+	return withMetadataFallbackRetry(ctx, c, "identity_document", func(metadataClient *ec2metadata.EC2Metadata) (ec2metadata.EC2InstanceIdentityDocument, error) {
 		return metadataClient.GetInstanceIdentityDocumentWithContext(ctx)
 	})
 }
 
-func withMetadataFallbackRetry[T any](ctx context.Context, c *metadataClient, operation func(*ec2metadata.EC2Metadata) (T, error)) (T, error) {
-	result, err := operation(c.metadataFallbackDisabled)
-	if err != nil {
+// tokenUnreachable reports whether the IMDSv2 token was recently found unreachable.
+func (c *metadataClient) tokenUnreachable() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now().Before(c.tokenUnreachableUntil)
+}
+
+func (c *metadataClient) setTokenUnreachable() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokenUnreachableUntil.IsZero() {
+		log.Printf("W! The IMDSv2 token could not be reached but IMDSv1 could, so IMDSv1 is used. In a container, "+
+			"the hop limit of the metadata options of the instance may be too low, see HttpPutResponseHopLimit. "+
+			"The token is requested again in %v", tokenRecheckInterval)
+	}
+	c.tokenUnreachableUntil = c.now().Add(tokenRecheckInterval)
+}
+
+func (c *metadataClient) resetTokenUnreachable() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokenUnreachableUntil = time.Time{}
+}
+
+// isTokenUnreachable reports whether the IMDSv2 token request failed without a response, e.g. because the response
+// was dropped when the hop limit is exceeded.
+func isTokenUnreachable(err error) bool {
+	var requestFailure awserr.RequestFailure
+	if errors.As(err, &requestFailure) && requestFailure.StatusCode() != 0 {
+		return false
+	}
+	return strings.Contains(err.Error(), tokenPath)
+}
+
+func withMetadataFallbackRetry[T any](ctx context.Context, c *metadataClient, operation string, fn func(*ec2metadata.EC2Metadata) (T, error)) (T, error) {
+	if c.fallbackAllowed && c.tokenUnreachable() {
+		result, err := fn(c.metadataV1)
+		if err != nil {
+			// e.g. IMDSv1 was turned off on the instance, request the token on the next call
+			c.resetTokenUnreachable()
+		}
+		selftelemetry.IMDS.Record(operation, true, err)
+		return result, err
+	}
+	result, err := fn(c.metadataFallbackDisabled)
+	if err != nil && c.fallbackAllowed {
 		log.Printf("D! could not perform operation without imds v1 fallback enable thus enable fallback")
-		result, err = operation(c.metadataFallbackEnabled)
+		tokenUnreachable := isTokenUnreachable(err)
+		result, err = fn(c.metadataFallbackEnabled)
 		if err == nil {
 			agent.UsageFlags().Set(agent.FlagIMDSFallbackSuccess)
+			if tokenUnreachable {
+				c.setTokenUnreachable()
+			}
 		}
+		selftelemetry.IMDS.Record(operation, true, err)
+		return result, err
 	}
+	selftelemetry.IMDS.Record(operation, false, err)
 	return result, err
 }
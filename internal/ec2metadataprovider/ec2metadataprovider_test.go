@@ -5,14 +5,23 @@ package ec2metadataprovider
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/awstesting/mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
 )
 
 func TestMetadataProvider_Get(t *testing.T) {
@@ -70,3 +79,105 @@ func TestMetadataProvider_available(t *testing.T) {
 		})
 	}
 }
+
+// newHopLimitServer serves IMDSv1 but drops the connections of the IMDSv2 token requests, like IMDS does for a
+// container when the hop limit of the instance is exceeded.
+func newHopLimitServer(t *testing.T) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	var tokenRequests, metadataRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			tokenRequests.Add(1)
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+		metadataRequests.Add(1)
+		_, _ = w.Write([]byte("i-1234567890"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &tokenRequests, &metadataRequests
+}
+
+func newTestSession(t *testing.T, endpoint string) *session.Session {
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "false")
+	ses, err := session.NewSessionWithOptions(session.Options{EC2IMDSEndpoint: endpoint})
+	require.NoError(t, err)
+	return ses
+}
+
+func getIMDSStatus(operation string) selftelemetry.IMDSStatus {
+	for _, status := range selftelemetry.IMDS.Statuses() {
+		if status.Operation == operation {
+			return status
+		}
+	}
+	return selftelemetry.IMDSStatus{Operation: operation}
+}
+
+func TestMetadataProviderTokenUnreachable(t *testing.T) {
+	server, tokenRequests, metadataRequests := newHopLimitServer(t)
+	c := NewMetadataProvider(newTestSession(t, server.URL), 0).(*metadataClient)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	before := getIMDSStatus("instance_id")
+
+	instanceID, err := c.InstanceID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "i-1234567890", instanceID)
+	assert.True(t, c.tokenUnreachable())
+	tokens := tokenRequests.Load()
+	assert.Positive(t, tokens)
+
+	// the token is not requested again until the recheck interval
+	instanceID, err = c.InstanceID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "i-1234567890", instanceID)
+	assert.Equal(t, tokens, tokenRequests.Load())
+	assert.EqualValues(t, 2, metadataRequests.Load())
+
+	now = now.Add(tokenRecheckInterval)
+	assert.False(t, c.tokenUnreachable())
+	_, err = c.InstanceID(context.Background())
+	require.NoError(t, err)
+	assert.Greater(t, tokenRequests.Load(), tokens)
+
+	after := getIMDSStatus("instance_id")
+	assert.EqualValues(t, 3, after.Calls-before.Calls)
+	assert.EqualValues(t, 3, after.Fallbacks-before.Fallbacks)
+	assert.EqualValues(t, 0, after.Failures-before.Failures)
+}
+
+func TestMetadataProviderFallbackDisabled(t *testing.T) {
+	server, _, metadataRequests := newHopLimitServer(t)
+	t.Setenv(envconfig.AWS_EC2_METADATA_V1_DISABLED, "true")
+	c := NewMetadataProvider(newTestSession(t, server.URL), 0).(*metadataClient)
+	before := getIMDSStatus("hostname")
+
+	_, err := c.Hostname(context.Background())
+	assert.Error(t, err)
+	assert.False(t, c.tokenUnreachable())
+	assert.EqualValues(t, 0, metadataRequests.Load())
+
+	after := getIMDSStatus("hostname")
+	assert.EqualValues(t, 1, after.Calls-before.Calls)
+	assert.EqualValues(t, 0, after.Fallbacks-before.Fallbacks)
+	assert.EqualValues(t, 1, after.Failures-before.Failures)
+}
+
+func TestSharedMetadataProvider(t *testing.T) {
+	assert.Same(t, SharedMetadataProvider(), SharedMetadataProvider())
+}
+
+func TestSharedMetadataProviderWithoutSession(t *testing.T) {
+	t.Setenv("AWS_CA_BUNDLE", filepath.Join(t.TempDir(), "missing.pem"))
+	p := newSharedProvider()
+	require.IsType(t, errorProvider{}, p)
+
+	_, err := p.Hostname(context.Background())
+	assert.Error(t, err)
+	_, err = p.Get(context.Background())
+	assert.Error(t, err)
+	_, err = p.InstanceTagValue(context.Background(), "Name")
+	assert.Error(t, err)
+}
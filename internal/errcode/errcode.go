@@ -0,0 +1,211 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package errcode defines the stable error codes of the agent. The codes appear in the agent log as
+// error_code=<code>, in the status of the control socket, and in the exit code of the process, so
+// automation can tell configuration errors apart from transient failures. Codes are never renamed
+// or renumbered.
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// Code is a class of errors.
+type Code string
+
+const (
+	// Unknown is any error that does not match another code.
+	Unknown Code = "UNKNOWN"
+	// Config is an invalid or missing configuration. Retrying does not help.
+	Config Code = "CONFIG_ERROR"
+	// Credential is missing, expired or insufficient credentials.
+	Credential Code = "CREDENTIAL_ERROR"
+	// EndpointUnreachable is a network failure on the way to an endpoint.
+	EndpointUnreachable Code = "ENDPOINT_UNREACHABLE"
+	// QuotaExceeded is a throttled request or an exceeded service limit.
+	QuotaExceeded Code = "QUOTA_EXCEEDED"
+)
+
+// Exit codes of the agent processes. They follow sysexits.h.
+const (
+	ExitUnknown             = 1
+	ExitEndpointUnreachable = 69 // EX_UNAVAILABLE
+	ExitQuotaExceeded       = 75 // EX_TEMPFAIL
+	ExitCredential          = 77 // EX_NOPERM
+	ExitConfig              = 78 // EX_CONFIG
+)
+
+var exitCodes = map[Code]int{
+	Unknown:             ExitUnknown,
+	Config:              ExitConfig,
+	Credential:          ExitCredential,
+	EndpointUnreachable: ExitEndpointUnreachable,
+	QuotaExceeded:       ExitQuotaExceeded,
+}
+
+// AWS error codes by class. Throttling errors are matched by the SDK.
+var (
+	credentialErrorCodes = map[string]struct{}{
+		"NoCredentialProviders":       {},
+		"SharedCredsLoad":             {},
+		"EC2RoleRequestError":         {},
+		"AccessDenied":                {},
+		"AccessDeniedException":       {},
+		"UnrecognizedClientException": {},
+		"InvalidClientTokenId":        {},
+		"ExpiredToken":                {},
+		"ExpiredTokenException":       {},
+		"InvalidSignatureException":   {},
+		"SignatureDoesNotMatch":       {},
+		"IncompleteSignature":         {},
+		"MissingAuthenticationToken":  {},
+	}
+	quotaErrorCodes = map[string]struct{}{
+		"LimitExceeded":                 {},
+		"LimitExceededException":        {},
+		"ServiceQuotaExceededException": {},
+	}
+)
+
+// Error is an error with a code.
+type Error struct {
+	Code Code
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns err with the code, or nil if err is nil.
+func New(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// Classify returns the code of the first *Error in the chain of err. Errors without one are
+// classified by their AWS error code or as network errors.
+func Classify(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var codeErr *Error
+	if errors.As(err, &codeErr) {
+		return codeErr.Code
+	}
+	if request.IsErrorThrottle(err) {
+		return QuotaExceeded
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		if _, ok := credentialErrorCodes[awsErr.Code()]; ok {
+			return Credential
+		}
+		if _, ok := quotaErrorCodes[awsErr.Code()]; ok {
+			return QuotaExceeded
+		}
+		if awsErr.Code() == request.ErrCodeRequestError || awsErr.Code() == request.ErrCodeResponseTimeout {
+			return EndpointUnreachable
+		}
+		// the SDK does not unwrap the original error
+		if orig := awsErr.OrigErr(); orig != nil && orig != err {
+			return Classify(orig)
+		}
+		return Unknown
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return EndpointUnreachable
+	}
+	return Unknown
+}
+
+// ExitCode returns the process exit code of the code.
+func ExitCode(code Code) int {
+	if exitCode, ok := exitCodes[code]; ok {
+		return exitCode
+	}
+	return ExitUnknown
+}
+
+// FromExitCode returns the code of a process that exited with the exit code, e.g. a child
+// process of the agent.
+func FromExitCode(exitCode int) Code {
+	for code, c := range exitCodes {
+		if c == exitCode {
+			return code
+		}
+	}
+	return Unknown
+}
+
+// Tag returns the marker of the code in log messages, error_code=<code>.
+func Tag(code Code) string {
+	return fmt.Sprintf("error_code=%s", code)
+}
+
+// Summary is the number of errors recorded with a code and the last one.
+type Summary struct {
+	Code      Code      `json:"code"`
+	Count     int64     `json:"count"`
+	LastError string    `json:"last_error"`
+	LastTime  time.Time `json:"last_time"`
+}
+
+var (
+	mu        sync.Mutex
+	summaries = map[Code]*Summary{}
+)
+
+// Record classifies err, counts it for the status and returns its code.
+func Record(err error) Code {
+	code := Classify(err)
+	if code == "" {
+		return code
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	summary, ok := summaries[code]
+	if !ok {
+		summary = &Summary{Code: code}
+		summaries[code] = summary
+	}
+	summary.Count++
+	summary.LastError = err.Error()
+	summary.LastTime = time.Now()
+	return code
+}
+
+// Summaries returns the recorded errors sorted by code.
+func Summaries() []Summary {
+	mu.Lock()
+	defer mu.Unlock()
+	result := make([]Summary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Code < result[j].Code })
+	return result
+}
+
+// Reset clears the recorded errors.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	summaries = map[Code]*Summary{}
+}
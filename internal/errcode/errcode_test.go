@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	testCases := map[string]struct {
+		err  error
+		want Code
+	}{
+		"Nil":        {err: nil, want: ""},
+		"Unknown":    {err: errors.New("boom"), want: Unknown},
+		"Wrapped":    {err: fmt.Errorf("loading: %w", New(Config, errors.New("bad interval"))), want: Config},
+		"Credential": {err: awserr.New("ExpiredTokenException", "expired", nil), want: Credential},
+		"NoCredentialProviders": {
+			err:  awserr.New("NoCredentialProviders", "no valid providers in chain", nil),
+			want: Credential,
+		},
+		"Throttling":    {err: awserr.New("ThrottlingException", "rate exceeded", nil), want: QuotaExceeded},
+		"LimitExceeded": {err: awserr.New("LimitExceeded", "limit", nil), want: QuotaExceeded},
+		"RequestError":  {err: awserr.New("RequestError", "send request failed", errors.New("dial")), want: EndpointUnreachable},
+		"OrigErr": {
+			err:  awserr.New("SerializationError", "failed", &net.OpError{Op: "dial", Err: errors.New("connection refused")}),
+			want: EndpointUnreachable,
+		},
+		"Net": {err: fmt.Errorf("scrape: %w", &net.DNSError{Err: "no such host", Name: "example"}), want: EndpointUnreachable},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, Classify(testCase.err))
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 78, ExitCode(Config))
+	assert.Equal(t, 77, ExitCode(Credential))
+	assert.Equal(t, 69, ExitCode(EndpointUnreachable))
+	assert.Equal(t, 75, ExitCode(QuotaExceeded))
+	assert.Equal(t, 1, ExitCode(Unknown))
+	assert.Equal(t, 1, ExitCode(""))
+	for code := range exitCodes {
+		assert.Equal(t, code, FromExitCode(ExitCode(code)))
+	}
+	assert.Equal(t, Unknown, FromExitCode(2))
+}
+
+func TestRecord(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+	assert.Equal(t, QuotaExceeded, Record(awserr.New("ThrottlingException", "first", nil)))
+	assert.Equal(t, QuotaExceeded, Record(awserr.New("ThrottlingException", "second", nil)))
+	assert.Equal(t, Config, Record(New(Config, errors.New("bad"))))
+	assert.Equal(t, Code(""), Record(nil))
+
+	summaries := Summaries()
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, Config, summaries[0].Code)
+	assert.Equal(t, QuotaExceeded, summaries[1].Code)
+	assert.EqualValues(t, 2, summaries[1].Count)
+	assert.Contains(t, summaries[1].LastError, "second")
+	assert.False(t, summaries[1].LastTime.IsZero())
+	assert.Equal(t, "error_code=QUOTA_EXCEEDED", Tag(QuotaExceeded))
+}
@@ -0,0 +1,143 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package failover picks the region the outputs publish to, so that they publish to a failover region while the
+// endpoint of their primary region is unhealthy instead of buffering until it recovers.
+package failover
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const (
+	// DefaultAfter is how long the endpoint of a region fails before the outputs fail over from it.
+	DefaultAfter = 5 * time.Minute
+
+	// probeInterval is how often a request is sent to the primary region while in a failover region.
+	probeInterval = time.Minute
+
+	// MarkerKey is the dimension of the metrics and the field of the log events published to a failover region. Its
+	// value is the primary region.
+	MarkerKey = "FailoverFrom"
+)
+
+// Regions tracks the health of the endpoints of a primary region and of its failover regions. The requests are sent
+// to the primary region until it has failed for the failover period, then to the next region, and so on. While in a
+// failover region, a request is sent to the primary region once a minute, and the outputs go back to it as soon as
+// one succeeds.
+type Regions struct {
+	name    string
+	regions []string
+	after   time.Duration
+	now     func() time.Time
+
+	mu sync.Mutex
+	// active is the index of the region the requests are sent to.
+	active int
+	// failingSince is when the requests to the active region started to fail, zero while they succeed.
+	failingSince time.Time
+	// lastProbe is when a request was last sent to the primary region while in a failover region.
+	lastProbe time.Time
+}
+
+// New returns the regions of an output, which its logs are prefixed with. It returns nil for the outputs without
+// failover regions, which always publish to their primary region.
+func New(name, primary string, failoverRegions []string, after time.Duration) *Regions {
+	if len(failoverRegions) == 0 {
+		return nil
+	}
+	if after <= 0 {
+		after = DefaultAfter
+	}
+	return &Regions{
+		name:    name,
+		regions: append([]string{primary}, failoverRegions...),
+		after:   after,
+		now:     time.Now,
+	}
+}
+
+// Primary returns the primary region.
+func (r *Regions) Primary() string {
+	return r.regions[0]
+}
+
+// All returns the primary region followed by the failover regions.
+func (r *Regions) All() []string {
+	return r.regions
+}
+
+// IsFailover returns true if the region is not the primary one.
+func (r *Regions) IsFailover(region string) bool {
+	return r != nil && region != r.regions[0]
+}
+
+// Next returns the region to send the next request to.
+func (r *Regions) Next() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.active != 0 {
+		if now := r.now(); now.Sub(r.lastProbe) >= probeInterval {
+			r.lastProbe = now
+			return r.regions[0]
+		}
+	}
+	return r.regions[r.active]
+}
+
+// Record updates the health of a region with the result of a request to it. Only the errors of an unavailable
+// endpoint count as failures, since the other errors mean that the endpoint handled the request.
+func (r *Regions) Record(region string, err error) {
+	if r == nil {
+		return
+	}
+	unhealthy := IsUnavailable(err)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case region == r.regions[0] && r.active != 0:
+		if !unhealthy {
+			log.Printf("I! %s: region %s is healthy again, failing back from %s", r.name, region, r.regions[r.active])
+			r.active = 0
+			r.failingSince = time.Time{}
+		}
+	case region != r.regions[r.active]:
+		// the result of a request sent before the active region changed
+	case !unhealthy:
+		r.failingSince = time.Time{}
+	case r.failingSince.IsZero():
+		r.failingSince = r.now()
+	case r.now().Sub(r.failingSince) >= r.after:
+		r.active = (r.active + 1) % len(r.regions)
+		r.failingSince = time.Time{}
+		r.lastProbe = r.now()
+		log.Printf("W! %s: region %s has been unavailable for %v, failing over to %s: %v", r.name, region, r.after, r.regions[r.active], err)
+	}
+}
+
+// IsUnavailable returns true for the errors of an endpoint that cannot be reached or fails to handle the requests.
+func IsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var failure awserr.RequestFailure
+	if errors.As(err, &failure) && failure.StatusCode() >= http.StatusInternalServerError {
+		return true
+	}
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return true
+	}
+	switch awsErr.Code() {
+	case request.ErrCodeRequestError, request.ErrCodeResponseTimeout, "InternalFailure", "InternalServiceFault", "ServiceUnavailable":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package failover
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegions(t *testing.T) {
+	now := time.Now()
+	r := New("cloudwatch", "us-east-1", []string{"us-west-2", "eu-west-1"}, time.Minute)
+	r.now = func() time.Time { return now }
+	unavailable := awserr.New(request.ErrCodeRequestError, "send request failed", errors.New("connection refused"))
+
+	assert.Equal(t, "us-east-1", r.Next())
+	r.Record("us-east-1", unavailable)
+	now = now.Add(30 * time.Second)
+	r.Record("us-east-1", unavailable)
+	assert.Equal(t, "us-east-1", r.Next(), "the region has not failed for the failover period yet")
+
+	// a success resets the failover period
+	r.Record("us-east-1", nil)
+	now = now.Add(time.Minute)
+	r.Record("us-east-1", unavailable)
+	assert.Equal(t, "us-east-1", r.Next())
+
+	now = now.Add(time.Minute)
+	r.Record("us-east-1", unavailable)
+	assert.Equal(t, "us-west-2", r.Next())
+	assert.True(t, r.IsFailover("us-west-2"))
+
+	// the results of the requests sent before the failover do not count
+	r.Record("us-east-1", unavailable)
+	assert.Equal(t, "us-west-2", r.Next())
+
+	// the primary region is probed once a minute
+	now = now.Add(time.Minute)
+	assert.Equal(t, "us-east-1", r.Next())
+	assert.Equal(t, "us-west-2", r.Next())
+	r.Record("us-east-1", unavailable)
+	assert.Equal(t, "us-west-2", r.Next())
+
+	// the next failover region is used when the failover region fails too
+	r.Record("us-west-2", unavailable)
+	now = now.Add(30 * time.Second)
+	r.Record("us-west-2", unavailable)
+	now = now.Add(30 * time.Second)
+	r.Record("us-west-2", unavailable)
+	assert.Equal(t, "eu-west-1", r.Next())
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, "us-east-1", r.Next())
+	r.Record("us-east-1", awserr.New("InvalidParameterValue", "bad request", nil))
+	assert.Equal(t, "us-east-1", r.Next(), "a request the primary region handled makes it healthy")
+	assert.False(t, r.IsFailover("us-east-1"))
+}
+
+func TestRegionsWithoutFailover(t *testing.T) {
+	r := New("cloudwatch", "us-east-1", nil, time.Minute)
+	assert.Nil(t, r)
+	assert.False(t, r.IsFailover("us-east-1"))
+}
+
+func TestIsUnavailable(t *testing.T) {
+	testCases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"WithoutError":        {err: nil, want: false},
+		"WithNonAWSError":     {err: errors.New("dial tcp: i/o timeout"), want: true},
+		"WithRequestError":    {err: awserr.New(request.ErrCodeRequestError, "send request failed", nil), want: true},
+		"WithServerError":     {err: awserr.NewRequestFailure(awserr.New("InternalFailure", "", nil), http.StatusInternalServerError, ""), want: true},
+		"WithUnavailable":     {err: awserr.NewRequestFailure(awserr.New("Unknown", "", nil), http.StatusServiceUnavailable, ""), want: true},
+		"WithThrottling":      {err: awserr.NewRequestFailure(awserr.New("Throttling", "", nil), http.StatusBadRequest, ""), want: false},
+		"WithInvalidRequest":  {err: awserr.New("InvalidParameterValue", "", nil), want: false},
+		"WithInternalFailure": {err: awserr.New("InternalServiceFault", "", nil), want: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, IsUnavailable(testCase.err))
+		})
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package faultinject injects latency, errors and throttles into the AWS API calls of the agent while the
+// faultinjection extension runs, so that operators can check how their pipelines buffer and alert during an outage
+// before a real one. Faults are set through the control socket and expire on their own.
+package faultinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	KindLatency  Kind = "latency"
+	KindError    Kind = "error"
+	KindThrottle Kind = "throttle"
+
+	KindParam      = "kind"
+	OperationParam = "operation"
+	RateParam      = "rate"
+	LatencyParam   = "latency"
+	StatusParam    = "status"
+	DurationParam  = "duration"
+
+	defaultErrorStatus    = http.StatusInternalServerError
+	defaultThrottleStatus = http.StatusBadRequest
+)
+
+var (
+	// ErrDisabled is returned when a fault is set while the faultinjection extension is not running.
+	ErrDisabled = errors.New("fault injection is not enabled, set agent.fault_injection in the configuration")
+
+	registry = &state{faults: map[faultKey]*fault{}}
+)
+
+// Kind is the effect of a fault on the calls it is injected into.
+type Kind string
+
+// Limits bound the faults that can be set, so that a mistyped fault cannot stall the agent for hours.
+type Limits struct {
+	MaxLatency  time.Duration
+	MaxDuration time.Duration
+}
+
+// Fault is injected into a share of the calls of the matching operations until it expires.
+type Fault struct {
+	Kind Kind
+	// Operation is a filepath.Match pattern of the API operations, e.g. PutLogEvents or Put*.
+	Operation string
+	// Rate is the share of the calls the fault is injected into, between 0 and 1.
+	Rate float64
+	// Latency delays the calls of a latency fault.
+	Latency time.Duration
+	// StatusCode is the HTTP status of the responses of an error or throttle fault.
+	StatusCode int
+	// Duration is how long the fault lasts.
+	Duration time.Duration
+}
+
+// Status is a fault that is set, and the number of calls it was injected into.
+type Status struct {
+	Kind       Kind      `json:"kind"`
+	Operation  string    `json:"operation"`
+	Rate       float64   `json:"rate"`
+	Latency    string    `json:"latency,omitempty"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Expires    time.Time `json:"expires"`
+	Injected   int64     `json:"injected"`
+}
+
+type faultKey struct {
+	kind      Kind
+	operation string
+}
+
+type fault struct {
+	Fault
+	expires  time.Time
+	injected atomic.Int64
+}
+
+type state struct {
+	mu      sync.Mutex
+	enabled bool
+	limits  Limits
+	server  *server
+	faults  map[faultKey]*fault
+	// numFaults lets Apply skip the lock when no fault is set.
+	numFaults atomic.Int32
+}
+
+// Enable lets faults be set, and starts the local endpoint the calls failed by a fault are sent to.
+func Enable(limits Limits) error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if registry.enabled {
+		registry.limits = limits
+		return nil
+	}
+	s, err := startServer()
+	if err != nil {
+		return fmt.Errorf("unable to start the fault injection endpoint: %w", err)
+	}
+	registry.enabled = true
+	registry.limits = limits
+	registry.server = s
+	return nil
+}
+
+// Disable clears the faults and stops the local endpoint.
+func Disable() {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if !registry.enabled {
+		return
+	}
+	registry.enabled = false
+	registry.server.close()
+	registry.server = nil
+	registry.faults = map[faultKey]*fault{}
+	registry.numFaults.Store(0)
+}
+
+// Enabled returns true while the faultinjection extension runs.
+func Enabled() bool {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	return registry.enabled
+}
+
+// Set adds the fault, or replaces the fault of the same kind and operation.
+func Set(f Fault) error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if !registry.enabled {
+		return ErrDisabled
+	}
+	if err := registry.validate(&f); err != nil {
+		return err
+	}
+	registry.faults[faultKey{kind: f.Kind, operation: f.Operation}] = &fault{Fault: f, expires: time.Now().Add(f.Duration)}
+	registry.numFaults.Store(int32(len(registry.faults)))
+	return nil
+}
+
+// Clear removes every fault, and returns how many were set.
+func Clear() int {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	n := len(registry.faults)
+	registry.faults = map[faultKey]*fault{}
+	registry.numFaults.Store(0)
+	return n
+}
+
+// Statuses returns the faults that did not expire, sorted by operation and kind.
+func Statuses() []Status {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.expire(time.Now())
+	statuses := make([]Status, 0, len(registry.faults))
+	for _, f := range registry.faults {
+		status := Status{
+			Kind:       f.Kind,
+			Operation:  f.Operation,
+			Rate:       f.Rate,
+			StatusCode: f.StatusCode,
+			Expires:    f.expires,
+			Injected:   f.injected.Load(),
+		}
+		if f.Latency > 0 {
+			status.Latency = f.Latency.String()
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Operation != statuses[j].Operation {
+			return statuses[i].Operation < statuses[j].Operation
+		}
+		return statuses[i].Kind < statuses[j].Kind
+	})
+	return statuses
+}
+
+// Apply injects the faults of the operation into the request before it is sent. A latency fault delays it, and an
+// error or a throttle sends it to the local endpoint, which fails it like the service would. Since the request is
+// only built once, the retries of a failed request fail too.
+func Apply(ctx context.Context, operation string, r *http.Request) {
+	if registry.numFaults.Load() == 0 {
+		return
+	}
+	latency, failure, addr := registry.pick(operation)
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+	if failure != nil && r != nil && r.URL != nil {
+		r.URL.Scheme = "http"
+		r.URL.Host = addr
+		r.Host = ""
+		r.Header.Set(kindHeader, string(failure.Kind))
+		r.Header.Set(statusHeader, strconv.Itoa(failure.StatusCode))
+	}
+}
+
+// pick returns the latency to add to a call of the operation, and the error or throttle to fail it with, if any.
+func (s *state) pick(operation string) (time.Duration, *fault, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.enabled {
+		return 0, nil, ""
+	}
+	s.expire(time.Now())
+	var latency time.Duration
+	var failure *fault
+	// the throttles win over the errors, and are checked first so that their counts stay accurate
+	for _, kind := range []Kind{KindLatency, KindThrottle, KindError} {
+		for _, f := range s.faults {
+			if f.Kind != kind || (kind != KindLatency && failure != nil) || !f.matches(operation) {
+				continue
+			}
+			if rand.Float64() >= f.Rate { // nolint:gosec
+				continue
+			}
+			f.injected.Add(1)
+			if kind == KindLatency {
+				latency = max(latency, f.Latency)
+			} else {
+				failure = f
+			}
+		}
+	}
+	return latency, failure, s.server.addr
+}
+
+// expire must be called with the lock held.
+func (s *state) expire(now time.Time) {
+	for key, f := range s.faults {
+		if !now.Before(f.expires) {
+			delete(s.faults, key)
+		}
+	}
+	s.numFaults.Store(int32(len(s.faults)))
+}
+
+// validate fills the defaults of the fault and checks it against the limits. It must be called with the lock held.
+func (s *state) validate(f *Fault) error {
+	if _, err := filepath.Match(f.Operation, ""); err != nil || f.Operation == "" {
+		return fmt.Errorf("invalid operation pattern %q", f.Operation)
+	}
+	if f.Rate == 0 {
+		f.Rate = 1
+	}
+	if f.Rate < 0 || f.Rate > 1 {
+		return fmt.Errorf("rate must be between 0 and 1, but got %v", f.Rate)
+	}
+	if f.Duration == 0 {
+		f.Duration = s.limits.MaxDuration
+	}
+	if f.Duration < 0 || f.Duration > s.limits.MaxDuration {
+		return fmt.Errorf("duration must be positive and at most %v, but got %v", s.limits.MaxDuration, f.Duration)
+	}
+	switch f.Kind {
+	case KindLatency:
+		if f.Latency <= 0 || f.Latency > s.limits.MaxLatency {
+			return fmt.Errorf("latency must be positive and at most %v, but got %v", s.limits.MaxLatency, f.Latency)
+		}
+		f.StatusCode = 0
+	case KindError, KindThrottle:
+		if f.Latency != 0 {
+			return fmt.Errorf("latency is only set for %s faults", KindLatency)
+		}
+		if f.StatusCode == 0 {
+			f.StatusCode = defaultErrorStatus
+			if f.Kind == KindThrottle {
+				f.StatusCode = defaultThrottleStatus
+			}
+		}
+		if f.StatusCode < 400 || f.StatusCode > 599 {
+			return fmt.Errorf("status must be between 400 and 599, but got %d", f.StatusCode)
+		}
+	default:
+		return fmt.Errorf("kind must be %s, %s or %s, but got %q", KindLatency, KindError, KindThrottle, f.Kind)
+	}
+	return nil
+}
+
+func (f *fault) matches(operation string) bool {
+	if f.Operation == operation {
+		return true
+	}
+	ok, _ := filepath.Match(f.Operation, operation)
+	return ok
+}
+
+// ParseFault reads a fault from the query parameters of a control request, e.g.
+// kind=throttle&operation=PutLogEvents&rate=0.5&duration=5m.
+func ParseFault(query url.Values) (Fault, error) {
+	f := Fault{Kind: Kind(query.Get(KindParam)), Operation: query.Get(OperationParam)}
+	var err error
+	if v := query.Get(RateParam); v != "" {
+		if f.Rate, err = strconv.ParseFloat(v, 64); err != nil {
+			return f, fmt.Errorf("invalid %s %q: %w", RateParam, v, err)
+		}
+	}
+	if v := query.Get(LatencyParam); v != "" {
+		if f.Latency, err = time.ParseDuration(v); err != nil {
+			return f, fmt.Errorf("invalid %s %q: %w", LatencyParam, v, err)
+		}
+	}
+	if v := query.Get(StatusParam); v != "" {
+		if f.StatusCode, err = strconv.Atoi(v); err != nil {
+			return f, fmt.Errorf("invalid %s %q: %w", StatusParam, v, err)
+		}
+	}
+	if v := query.Get(DurationParam); v != "" {
+		if f.Duration, err = time.ParseDuration(v); err != nil {
+			return f, fmt.Errorf("invalid %s %q: %w", DurationParam, v, err)
+		}
+	}
+	return f, nil
+}
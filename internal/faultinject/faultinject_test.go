@@ -0,0 +1,184 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinject
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLimits = Limits{MaxLatency: time.Second, MaxDuration: time.Hour}
+
+func enable(t *testing.T) {
+	require.NoError(t, Enable(testLimits))
+	t.Cleanup(Disable)
+}
+
+func TestSet(t *testing.T) {
+	assert.ErrorIs(t, Set(Fault{Kind: KindError, Operation: "*"}), ErrDisabled)
+	enable(t)
+	assert.True(t, Enabled())
+
+	require.NoError(t, Set(Fault{Kind: KindThrottle, Operation: "PutLogEvents", Rate: 0.5}))
+	require.NoError(t, Set(Fault{Kind: KindLatency, Operation: "Put*", Latency: 100 * time.Millisecond, Duration: time.Minute}))
+	// the fault of the same kind and operation is replaced
+	require.NoError(t, Set(Fault{Kind: KindThrottle, Operation: "PutLogEvents"}))
+	statuses := Statuses()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, KindLatency, statuses[0].Kind)
+	assert.Equal(t, "100ms", statuses[0].Latency)
+	assert.Equal(t, KindThrottle, statuses[1].Kind)
+	assert.Equal(t, "PutLogEvents", statuses[1].Operation)
+	assert.EqualValues(t, 1, statuses[1].Rate)
+	assert.Equal(t, http.StatusBadRequest, statuses[1].StatusCode)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), statuses[1].Expires, time.Minute)
+
+	for name, f := range map[string]Fault{
+		"NoOperation":     {Kind: KindError},
+		"InvalidKind":     {Kind: "outage", Operation: "*"},
+		"InvalidRate":     {Kind: KindError, Operation: "*", Rate: 2},
+		"TooLong":         {Kind: KindError, Operation: "*", Duration: 2 * time.Hour},
+		"TooSlow":         {Kind: KindLatency, Operation: "*", Latency: time.Minute},
+		"NoLatency":       {Kind: KindLatency, Operation: "*"},
+		"ErrorLatency":    {Kind: KindError, Operation: "*", Latency: time.Second},
+		"InvalidStatus":   {Kind: KindError, Operation: "*", StatusCode: 200},
+		"InvalidPattern":  {Kind: KindError, Operation: "[*"},
+		"NegativeLatency": {Kind: KindLatency, Operation: "*", Latency: -time.Second},
+	} {
+		assert.Error(t, Set(f), name)
+	}
+
+	assert.Equal(t, 2, Clear())
+	assert.Empty(t, Statuses())
+	Disable()
+	assert.False(t, Enabled())
+}
+
+func TestExpire(t *testing.T) {
+	enable(t)
+	require.NoError(t, Set(Fault{Kind: KindError, Operation: "*", Duration: time.Millisecond}))
+	assert.Eventually(t, func() bool {
+		return len(Statuses()) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.EqualValues(t, 0, registry.numFaults.Load())
+}
+
+func TestParseFault(t *testing.T) {
+	query, err := url.ParseQuery("kind=latency&operation=Put*&rate=0.25&latency=2s&duration=5m")
+	require.NoError(t, err)
+	f, err := ParseFault(query)
+	require.NoError(t, err)
+	assert.Equal(t, Fault{Kind: KindLatency, Operation: "Put*", Rate: 0.25, Latency: 2 * time.Second, Duration: 5 * time.Minute}, f)
+
+	f, err = ParseFault(url.Values{KindParam: {"error"}, OperationParam: {"*"}, StatusParam: {"503"}})
+	require.NoError(t, err)
+	assert.Equal(t, 503, f.StatusCode)
+
+	for _, param := range []string{RateParam, LatencyParam, StatusParam, DurationParam} {
+		_, err = ParseFault(url.Values{param: {"invalid"}})
+		assert.Error(t, err, param)
+	}
+}
+
+// applyHandler is how the agenthealth middleware injects the faults into the AWS SDK.
+var applyHandler = request.NamedHandler{Name: "faultinject", Fn: func(r *request.Request) {
+	Apply(r.Context(), r.Operation.Name, r.HTTPRequest)
+}}
+
+func newSession(t *testing.T, endpoint string) *session.Session {
+	ses, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-west-2"),
+		Endpoint:    aws.String(endpoint),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+		MaxRetries:  aws.Int(1),
+	})
+	require.NoError(t, err)
+	ses.Handlers.Build.PushBackNamed(applyHandler)
+	return ses
+}
+
+func TestApply(t *testing.T) {
+	var calls atomic.Int32
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer service.Close()
+	enable(t)
+	logsClient := cloudwatchlogs.New(newSession(t, service.URL))
+	input := &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String("group"),
+		LogStreamName: aws.String("stream"),
+		LogEvents:     []*cloudwatchlogs.InputLogEvent{{Message: aws.String("message"), Timestamp: aws.Int64(1)}},
+	}
+
+	_, err := logsClient.PutLogEvents(input)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, calls.Load())
+
+	require.NoError(t, Set(Fault{Kind: KindThrottle, Operation: "PutLogEvents"}))
+	req, _ := logsClient.PutLogEventsRequest(input)
+	err = req.Send()
+	var awsErr awserr.Error
+	require.ErrorAs(t, err, &awsErr)
+	assert.Equal(t, "ThrottlingException", awsErr.Code())
+	assert.True(t, req.IsErrorThrottle())
+	assert.Equal(t, 1, req.RetryCount, "the retries are failed too")
+	assert.EqualValues(t, 1, calls.Load(), "the failed calls do not reach the service")
+	// the other operations are not affected
+	_, err = logsClient.DescribeLogGroups(&cloudwatchlogs.DescribeLogGroupsInput{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls.Load())
+
+	Clear()
+	require.NoError(t, Set(Fault{Kind: KindError, Operation: "PutMetricData", StatusCode: http.StatusServiceUnavailable}))
+	metricsClient := cloudwatch.New(newSession(t, service.URL))
+	_, err = metricsClient.PutMetricData(&cloudwatch.PutMetricDataInput{
+		Namespace:  aws.String("namespace"),
+		MetricData: []*cloudwatch.MetricDatum{{MetricName: aws.String("metric"), Value: aws.Float64(1)}},
+	})
+	require.ErrorAs(t, err, &awsErr)
+	assert.Equal(t, "InternalFailure", awsErr.Code())
+	var reqErr awserr.RequestFailure
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, http.StatusServiceUnavailable, reqErr.StatusCode())
+	assert.EqualValues(t, 2, calls.Load())
+	assert.EqualValues(t, 1, Statuses()[0].Injected, "once for the call and its retries")
+}
+
+func TestApplyLatency(t *testing.T) {
+	enable(t)
+	require.NoError(t, Set(Fault{Kind: KindLatency, Operation: "PutLogEvents", Latency: 50 * time.Millisecond}))
+	r := httptest.NewRequest(http.MethodPost, "https://logs.us-west-2.amazonaws.com/", nil)
+	start := time.Now()
+	Apply(context.Background(), "PutLogEvents", r)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, "logs.us-west-2.amazonaws.com", r.URL.Host, "latency does not fail the call")
+
+	// the latency ends with the context of the call
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start = time.Now()
+	Apply(ctx, "PutLogEvents", r)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	start = time.Now()
+	Apply(context.Background(), "DescribeLogGroups", r)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
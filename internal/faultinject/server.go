@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinject
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// kindHeader and statusHeader tell the local endpoint how to fail the request.
+	kindHeader   = "X-Cwagent-Injected-Fault"
+	statusHeader = "X-Cwagent-Injected-Status"
+
+	injectedMessage = "fault injected by the CloudWatch agent"
+)
+
+// server fails the requests sent to it like the service would, with the error codes the AWS SDKs retry or not.
+type server struct {
+	addr     string
+	http     *http.Server
+	requests atomic.Int64
+}
+
+func startServer() (*server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &server{addr: listener.Addr().String()}
+	s.http = &http.Server{Handler: http.HandlerFunc(s.serve), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		_ = s.http.Serve(listener)
+	}()
+	return s, nil
+}
+
+func (s *server) close() {
+	_ = s.http.Close()
+}
+
+// serve answers with an XML error to the query protocol calls, e.g. PutMetricData, and a JSON error to the others.
+// The throttles use the codes the AWS SDKs back off on.
+func (s *server) serve(w http.ResponseWriter, r *http.Request) {
+	status, err := strconv.Atoi(r.Header.Get(statusHeader))
+	if err != nil {
+		status = defaultErrorStatus
+	}
+	requestID := fmt.Sprintf("injected-fault-%d", s.requests.Add(1))
+	w.Header().Set("X-Amzn-Requestid", requestID)
+	throttle := Kind(r.Header.Get(kindHeader)) == KindThrottle
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		code, errorType := "InternalFailure", "Receiver"
+		if throttle {
+			code = "Throttling"
+		}
+		if status < http.StatusInternalServerError {
+			errorType = "Sender"
+			if !throttle {
+				code = "InvalidParameterValue"
+			}
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, "<ErrorResponse><Error><Type>%s</Type><Code>%s</Code><Message>%s</Message></Error><RequestId>%s</RequestId></ErrorResponse>",
+			errorType, code, html.EscapeString(injectedMessage), requestID)
+		return
+	}
+	code := "ServiceUnavailableException"
+	if throttle {
+		code = "ThrottlingException"
+	} else if status < http.StatusInternalServerError {
+		code = "InvalidParameterException"
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.Header().Set("X-Amzn-Errortype", code)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"__type": code, "message": injectedMessage})
+}
@@ -0,0 +1,169 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package featureflag gates the new behaviors of the agent, so that they can be rolled out gradually across a fleet
+// and rolled back without a new release. Each flag is registered by the package whose behavior it gates, with the
+// default of the release, and is set from the agent config or an SSM parameter by the featureflags extension.
+package featureflag
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Flag is a behavior of the agent that can be turned on and off while the agent runs.
+type Flag struct {
+	name        string
+	description string
+	defaultOn   bool
+	enabled     atomic.Bool
+
+	mu sync.Mutex
+	// source is where the value of the flag was set from, empty for the default.
+	source string
+}
+
+// Name returns the name of the flag, e.g. logs_request_compression.
+func (f *Flag) Name() string {
+	return f.name
+}
+
+// Enabled returns true if the behavior gated by the flag is on. It is safe to call on the hot paths.
+func (f *Flag) Enabled() bool {
+	return f.enabled.Load()
+}
+
+var (
+	mu    sync.RWMutex
+	flags = map[string]*Flag{}
+)
+
+// Register declares a flag with its default. It is meant to be called from the variable declarations of a package,
+// and panics if the flag is already registered.
+func Register(name string, defaultOn bool, description string) *Flag {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := flags[name]; ok {
+		panic(fmt.Sprintf("feature flag %s is already registered", name))
+	}
+	f := &Flag{name: name, description: description, defaultOn: defaultOn}
+	f.enabled.Store(defaultOn)
+	flags[name] = f
+	return f
+}
+
+// Setting is the value of a flag in the agent config or the SSM parameter. It is either a boolean, or an object
+// enabling the flag on rollout_percent percent of the agents, e.g. {"enabled": true, "rollout_percent": 10}.
+type Setting struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// RolloutPercent is the percentage of the agents the flag is enabled on. Each agent is in or out of the rollout
+	// based on its instance ID, so that raising the percentage only adds agents.
+	RolloutPercent *float64 `json:"rollout_percent,omitempty" mapstructure:"rollout_percent,omitempty"`
+}
+
+func (s *Setting) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &s.Enabled); err == nil {
+		return nil
+	}
+	type setting Setting
+	return json.Unmarshal(data, (*setting)(s))
+}
+
+// Validate checks that the rollout percentage is between 0 and 100.
+func (s Setting) Validate() error {
+	if s.RolloutPercent != nil && (*s.RolloutPercent < 0 || *s.RolloutPercent > 100) {
+		return fmt.Errorf("rollout_percent must be between 0 and 100, but got %v", *s.RolloutPercent)
+	}
+	return nil
+}
+
+// enabledFor returns whether the setting enables the flag on the agent.
+func (s Setting) enabledFor(name, instanceID string) bool {
+	if !s.Enabled || s.RolloutPercent == nil {
+		return s.Enabled
+	}
+	return float64(bucket(name, instanceID)) < *s.RolloutPercent
+}
+
+// bucket places the agent in one of 100 buckets, which differ between the flags so that the same agents are not the
+// first ones to get every flag.
+func bucket(name, instanceID string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name + "/" + instanceID))
+	return h.Sum32() % 100
+}
+
+// Apply sets the flags of each source, the later sources overriding the earlier ones, and resets the other flags to
+// their defaults. The settings of the flags that are not registered are ignored, since they may be meant for another
+// version of the agent.
+func Apply(instanceID string, sources ...Source) {
+	mu.RLock()
+	defer mu.RUnlock()
+	values := map[string]bool{}
+	from := map[string]string{}
+	for _, source := range sources {
+		for name, setting := range source.Settings {
+			if _, ok := flags[name]; !ok {
+				log.Printf("D! Ignoring feature flag %s of the %s, it is not used by this version of the agent", name, source.Name)
+				continue
+			}
+			values[name] = setting.enabledFor(name, instanceID)
+			from[name] = source.Name
+		}
+	}
+	for name, f := range flags {
+		enabled, ok := values[name]
+		if !ok {
+			enabled = f.defaultOn
+		}
+		if f.enabled.Swap(enabled) != enabled {
+			state := "disabled"
+			if enabled {
+				state = "enabled"
+			}
+			if source := from[name]; source != "" {
+				log.Printf("I! Feature flag %s is %s by the %s", name, state, source)
+			} else {
+				log.Printf("I! Feature flag %s is %s by default", name, state)
+			}
+		}
+		f.mu.Lock()
+		f.source = from[name]
+		f.mu.Unlock()
+	}
+}
+
+// Source is a set of settings, e.g. from the agent config.
+type Source struct {
+	// Name describes the source in the logs and the status, e.g. "agent config".
+	Name     string
+	Settings map[string]Setting
+}
+
+// Status is the state of a flag.
+type Status struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	// Source is where the flag was set from, empty when it has its default.
+	Source string `json:"source,omitempty"`
+}
+
+// Statuses returns the state of the registered flags, sorted by name.
+func Statuses() []Status {
+	mu.RLock()
+	defer mu.RUnlock()
+	statuses := make([]Status, 0, len(flags))
+	for _, f := range flags {
+		f.mu.Lock()
+		statuses = append(statuses, Status{Name: f.name, Description: f.description, Enabled: f.Enabled(), Source: f.source})
+		f.mu.Unlock()
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -0,0 +1,112 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package featureflag
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func register(t *testing.T, name string, defaultOn bool) *Flag {
+	f := Register(name, defaultOn, "test flag "+name)
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(flags, name)
+	})
+	return f
+}
+
+func TestRegister(t *testing.T) {
+	on := register(t, "test_register_on", true)
+	off := register(t, "test_register_off", false)
+	assert.Equal(t, "test_register_on", on.Name())
+	assert.True(t, on.Enabled())
+	assert.False(t, off.Enabled())
+	assert.Panics(t, func() { Register("test_register_on", false, "duplicate") })
+}
+
+func TestApply(t *testing.T) {
+	a := register(t, "test_apply_a", false)
+	b := register(t, "test_apply_b", true)
+	c := register(t, "test_apply_c", false)
+
+	Apply("instance",
+		Source{Name: "agent config", Settings: map[string]Setting{
+			"test_apply_a": {Enabled: true},
+			"test_apply_b": {Enabled: true},
+			"unknown":      {Enabled: true},
+		}},
+		Source{Name: "SSM parameter", Settings: map[string]Setting{
+			"test_apply_b": {Enabled: false},
+		}},
+	)
+	assert.True(t, a.Enabled())
+	assert.False(t, b.Enabled(), "the later source overrides the earlier one")
+	assert.False(t, c.Enabled())
+
+	var got []Status
+	for _, status := range Statuses() {
+		if status.Name == a.Name() || status.Name == b.Name() || status.Name == c.Name() {
+			got = append(got, status)
+		}
+	}
+	assert.Equal(t, []Status{
+		{Name: "test_apply_a", Description: "test flag test_apply_a", Enabled: true, Source: "agent config"},
+		{Name: "test_apply_b", Description: "test flag test_apply_b", Enabled: false, Source: "SSM parameter"},
+		{Name: "test_apply_c", Description: "test flag test_apply_c", Enabled: false},
+	}, got)
+
+	// the flags without a setting are reset to their defaults
+	Apply("instance")
+	assert.False(t, a.Enabled())
+	assert.True(t, b.Enabled())
+	for _, status := range Statuses() {
+		assert.Empty(t, status.Source)
+	}
+}
+
+func TestRollout(t *testing.T) {
+	percent := func(p float64) *float64 { return &p }
+	assert.False(t, Setting{Enabled: true, RolloutPercent: percent(0)}.enabledFor("flag", "instance"))
+	assert.True(t, Setting{Enabled: true, RolloutPercent: percent(100)}.enabledFor("flag", "instance"))
+	assert.False(t, Setting{Enabled: false, RolloutPercent: percent(100)}.enabledFor("flag", "instance"))
+
+	// raising the percentage only adds agents
+	var previous map[string]bool
+	for _, p := range []float64{10, 50, 90} {
+		enabled := map[string]bool{}
+		for i := 0; i < 1000; i++ {
+			id := fmt.Sprintf("instance-%d", i)
+			if (Setting{Enabled: true, RolloutPercent: percent(p)}).enabledFor("flag", id) {
+				enabled[id] = true
+			}
+		}
+		assert.InDelta(t, p*10, len(enabled), 50)
+		for id := range previous {
+			assert.True(t, enabled[id], id)
+		}
+		previous = enabled
+	}
+}
+
+func TestSettingUnmarshalJSON(t *testing.T) {
+	var got map[string]Setting
+	require.NoError(t, json.Unmarshal([]byte(`{"a": true, "b": false, "c": {"enabled": true, "rollout_percent": 25}}`), &got))
+	percent := 25.0
+	assert.Equal(t, map[string]Setting{
+		"a": {Enabled: true},
+		"b": {Enabled: false},
+		"c": {Enabled: true, RolloutPercent: &percent},
+	}, got)
+	assert.NoError(t, got["c"].Validate())
+
+	percent = 101
+	assert.Error(t, Setting{Enabled: true, RolloutPercent: &percent}.Validate())
+	assert.Error(t, json.Unmarshal([]byte(`{"a": "yes"}`), &got))
+}
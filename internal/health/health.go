@@ -0,0 +1,124 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package health serves the liveness and readiness probes of the agent, e.g. for Kubernetes. The agent is live while
+// it serves the probes, and ready once its pipelines started and its outputs can export to AWS.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+)
+
+const (
+	PathLiveness  = "/healthz"
+	PathReadiness = "/readyz"
+
+	CheckPipelines   = "pipelines"
+	CheckCredentials = "credentials"
+	CheckExporters   = "exporters"
+)
+
+var pipelinesReady atomic.Bool
+
+// SetPipelinesReady is called with true once every pipeline started, and with false once they are shutting down.
+func SetPipelinesReady(ready bool) {
+	pipelinesReady.Store(ready)
+}
+
+// Check is the result of one of the conditions of the readiness.
+type Check struct {
+	Name string `json:"name"`
+	OK   bool   `json:"ok"`
+	// Message is why the check failed.
+	Message string `json:"message,omitempty"`
+}
+
+// Readiness is the response of the readiness probe.
+type Readiness struct {
+	Ready  bool    `json:"ready"`
+	Checks []Check `json:"checks"`
+}
+
+// Ready checks that the pipelines started, that no output failed to resolve its credentials and that no output gave
+// up on a request since a request last succeeded. The outputs are only checked once they sent a request.
+func Ready() Readiness {
+	checks := []Check{{Name: CheckPipelines, OK: pipelinesReady.Load()}}
+	if !checks[0].OK {
+		checks[0].Message = "the pipelines have not started"
+	}
+	var credentialErrors, unreachable []string
+	for _, status := range selftelemetry.Retries.Statuses() {
+		if status.LastErrorCode == errcode.Credential {
+			credentialErrors = append(credentialErrors, fmt.Sprintf("%s: %s", status.Output, status.LastError))
+		} else if status.Unreachable {
+			unreachable = append(unreachable, status.Output)
+		}
+	}
+	checks = append(checks, newCheck(CheckCredentials, credentialErrors, ""),
+		newCheck(CheckExporters, unreachable, "requests were dropped by "))
+	readiness := Readiness{Ready: true, Checks: checks}
+	for _, check := range checks {
+		readiness.Ready = readiness.Ready && check.OK
+	}
+	return readiness
+}
+
+func newCheck(name string, failures []string, prefix string) Check {
+	if len(failures) == 0 {
+		return Check{Name: name, OK: true}
+	}
+	return Check{Name: name, Message: prefix + strings.Join(failures, ", ")}
+}
+
+// Handler serves GET /healthz, which always succeeds, and GET /readyz, which fails with 503 Service Unavailable until
+// the agent is ready.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathLiveness, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc(PathReadiness, func(w http.ResponseWriter, _ *http.Request) {
+		readiness := Ready()
+		code := http.StatusOK
+		if !readiness.Ready {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(readiness)
+	})
+	return mux
+}
+
+// Serve listens on the address, e.g. 0.0.0.0:13133, until the context is done.
+func Serve(ctx context.Context, address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	log.Printf("I! Health probes listening on %s", listener.Addr())
+	if err = server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
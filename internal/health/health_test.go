@@ -0,0 +1,79 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+)
+
+func getReadiness(t *testing.T, server *httptest.Server) (int, Readiness) {
+	t.Helper()
+	resp, err := http.Get(server.URL + PathReadiness)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var readiness Readiness
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&readiness))
+	return resp.StatusCode, readiness
+}
+
+func TestHandler(t *testing.T) {
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+	t.Cleanup(func() { SetPipelinesReady(false) })
+
+	resp, err := http.Get(server.URL + PathLiveness)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	code, readiness := getReadiness(t, server)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, readiness.Ready)
+	assert.Equal(t, Check{Name: CheckPipelines, Message: "the pipelines have not started"}, readiness.Checks[0])
+
+	SetPipelinesReady(true)
+	code, readiness = getReadiness(t, server)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, Readiness{Ready: true, Checks: []Check{
+		{Name: CheckPipelines, OK: true},
+		{Name: CheckCredentials, OK: true},
+		{Name: CheckExporters, OK: true},
+	}}, readiness)
+}
+
+func TestReadyOutputs(t *testing.T) {
+	SetPipelinesReady(true)
+	t.Cleanup(func() { SetPipelinesReady(false) })
+	stats := selftelemetry.Retries.Register(t.Name())
+	t.Cleanup(stats.RecordSuccess)
+
+	// a failed request that is retried does not change the readiness
+	stats.RecordError(errors.New("connection reset"))
+	assert.True(t, Ready().Ready)
+
+	stats.RecordDropped()
+	readiness := Ready()
+	assert.False(t, readiness.Ready)
+	assert.Equal(t, Check{Name: CheckExporters, Message: "requests were dropped by " + t.Name()}, readiness.Checks[2])
+
+	stats.RecordError(awserr.New("NoCredentialProviders", "no valid providers in chain", nil))
+	readiness = Ready()
+	assert.False(t, readiness.Ready)
+	assert.False(t, readiness.Checks[1].OK)
+	assert.Contains(t, readiness.Checks[1].Message, "no valid providers in chain")
+	assert.True(t, readiness.Checks[2].OK)
+
+	stats.RecordSuccess()
+	assert.True(t, Ready().Ready)
+}
@@ -173,7 +173,7 @@ func (w *EndpointSliceWatcher) handleSliceAdd(obj interface{}) {
 	// Insert them into our ipToWorkload / serviceToWorkload, and track the keys.
 	keys := make([]string, 0, len(pairs))
 	for _, kv := range pairs {
-		w.IPToPodMetadata.Store(kv.key, kv.value)
+		w.deleter.Store(w.IPToPodMetadata, kv.key, kv.value)
 		keys = append(keys, kv.key)
 	}
 
@@ -184,8 +184,8 @@ func (w *EndpointSliceWatcher) handleSliceAdd(obj interface{}) {
 // handleSliceUpdate handles an update from oldSlice -> newSlice.
 // Instead of blindly removing all old keys and adding new ones, it diffs them:
 //   - remove only keys that no longer exist,
-//   - add only new keys that didn't exist before,
-//   - keep those that haven't changed.
+//   - store the others again, whether or not they existed before, which keeps
+//     those that haven't changed past the TTL of the deleter.
 func (w *EndpointSliceWatcher) handleSliceUpdate(oldObj, newObj interface{}) {
 	oldSlice := oldObj.(*discv1.EndpointSlice)
 	newSlice := newObj.(*discv1.EndpointSlice)
@@ -230,12 +230,10 @@ func (w *EndpointSliceWatcher) handleSliceUpdate(oldObj, newObj interface{}) {
 		}
 	}
 
-	// 4) For each key in newKeys that wasn't in oldKeys, we need to store it
-	//    in the appropriate sync.Map. We'll look up the value from newPairs.
+	// 4) Store every key in newKeys, including those that were in oldKeys,
+	//    so that they are kept past the TTL of the deleter.
 	for _, kv := range newPairs {
-		if _, alreadyHad := oldKeysSet[kv.key]; !alreadyHad {
-			w.IPToPodMetadata.Store(kv.key, kv.value)
-		}
+		w.deleter.Store(w.IPToPodMetadata, kv.key, kv.value)
 	}
 
 	// 5) Update sliceToKeysMap for the new slice UID
@@ -249,7 +247,11 @@ func (w *EndpointSliceWatcher) handleSliceUpdate(oldObj, newObj interface{}) {
 
 // handleSliceDelete removes any IP->workload or service->workload keys that were created by this slice.
 func (w *EndpointSliceWatcher) handleSliceDelete(obj interface{}) {
-	slice := obj.(*discv1.EndpointSlice)
+	slice, ok := deletedObject[*discv1.EndpointSlice](obj)
+	if !ok {
+		w.logger.Error("failed to get deleted EndpointSlice", zap.Any("object", obj))
+		return
+	}
 	w.logger.Debug("Received EndpointSlice Delete",
 		zap.String("uid", string(slice.UID)),
 		zap.String("name", slice.Name),
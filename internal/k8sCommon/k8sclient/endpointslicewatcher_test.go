@@ -16,11 +16,16 @@ import (
 	discv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 )
 
 // MockDeleter deletes a key immediately, useful for testing.
 type MockDeleter struct{}
 
+func (md *MockDeleter) Store(m *sync.Map, key, value interface{}) {
+	m.Store(key, value)
+}
+
 func (md *MockDeleter) DeleteWithDelay(m *sync.Map, key interface{}) {
 	m.Delete(key)
 }
@@ -153,6 +158,23 @@ func TestEndpointSliceDeletion(t *testing.T) {
 	assert.False(t, ok, "expected sliceToKeysMap entry for UID %s to be deleted", slice.UID)
 }
 
+// TestEndpointSliceDeletionMissed verifies that the keys are removed when the deletion
+// of the EndpointSlice was missed by the watch, and only found when the informer relisted.
+func TestEndpointSliceDeletionMissed(t *testing.T) {
+	watcher := newEndpointSliceWatcherForTest()
+
+	slice := createTestEndpointSlice("uid-1", "testns", "mysvc", "workload-76977669dc-lwx64",
+		[]string{"1.2.3.4"}, []int32{80}, nil)
+	watcher.handleSliceAdd(slice)
+
+	watcher.handleSliceDelete(cache.DeletedFinalStateUnknown{Key: "testns/mysvc", Obj: slice})
+
+	for _, key := range []string{"1.2.3.4", "1.2.3.4:80"} {
+		_, ok := watcher.IPToPodMetadata.Load(key)
+		assert.False(t, ok, "expected IPToPodMetadata key %s to be deleted", key)
+	}
+}
+
 // TestEndpointSliceUpdate verifies that on updates, keys are added and/or removed as appropriate.
 func TestEndpointSliceUpdate(t *testing.T) {
 	// --- Subtest: Complete change (no overlap) ---
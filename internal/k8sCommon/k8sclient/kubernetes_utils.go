@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"sync"
 	"time"
+
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -102,19 +104,103 @@ func (sc *SafeChannel) Close() {
 	}
 }
 
-// Deleter represents a type that can delete a key from a map after a certain delay.
+// Deleter represents a type that can delete a key from a map after a certain delay. The keys are stored through it, so
+// that storing a key again cancels its pending deletion.
 type Deleter interface {
+	Store(m *sync.Map, key, value interface{})
 	DeleteWithDelay(m *sync.Map, key interface{})
 }
 
-// TimedDeleter deletes a key after a specified delay.
+// TimedDeleter soft-deletes the keys: a key is deleted after the Delay, unless it is stored again before, e.g. when a
+// pod restarts with the same IP, so that its attributes do not flap. When the TTL is set, the keys that are not stored
+// again within it are deleted as well, so that the workloads whose deletion was missed stop contributing attributes.
+// The watchers store the keys of their objects again on each resync of their informer, which must be shorter than the TTL.
 type TimedDeleter struct {
 	Delay time.Duration
+	TTL   time.Duration
+
+	mu         sync.Mutex
+	entries    map[deleterKey]*deleterEntry
+	generation uint64
+}
+
+type deleterKey struct {
+	m   *sync.Map
+	key interface{}
+}
+
+// deleterEntry tracks when a key was last stored, and its generation, which changes every time it is stored.
+type deleterEntry struct {
+	storedAt   time.Time
+	generation uint64
+}
+
+func (td *TimedDeleter) Store(m *sync.Map, key, value interface{}) {
+	td.mu.Lock()
+	defer td.mu.Unlock()
+	m.Store(key, value)
+	if td.entries == nil {
+		td.entries = make(map[deleterKey]*deleterEntry)
+	}
+	td.generation++
+	td.entries[deleterKey{m: m, key: key}] = &deleterEntry{storedAt: time.Now(), generation: td.generation}
 }
 
 func (td *TimedDeleter) DeleteWithDelay(m *sync.Map, key interface{}) {
-	go func() {
-		time.Sleep(td.Delay)
+	k := deleterKey{m: m, key: key}
+	td.mu.Lock()
+	var generation uint64
+	if e, ok := td.entries[k]; ok {
+		generation = e.generation
+	}
+	td.mu.Unlock()
+	time.AfterFunc(td.Delay, func() {
+		td.mu.Lock()
+		defer td.mu.Unlock()
+		if e, ok := td.entries[k]; ok && e.generation != generation {
+			// stored again since the deletion
+			return
+		}
 		m.Delete(key)
-	}()
+		delete(td.entries, k)
+	})
+}
+
+// Run deletes the keys that were not stored within the TTL, until the stop channel is closed. It returns immediately
+// when the TTL is not set.
+func (td *TimedDeleter) Run(stopCh <-chan struct{}) {
+	if td.TTL <= 0 {
+		return
+	}
+	ticker := time.NewTicker(td.TTL / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case now := <-ticker.C:
+			td.expire(now)
+		}
+	}
+}
+
+func (td *TimedDeleter) expire(now time.Time) {
+	td.mu.Lock()
+	defer td.mu.Unlock()
+	for k, e := range td.entries {
+		if now.Sub(e.storedAt) > td.TTL {
+			k.m.Delete(k.key)
+			delete(td.entries, k)
+		}
+	}
+}
+
+// deletedObject returns the object of a delete event. When the deletion was missed by the watch, and only found when
+// the informer listed the objects again, the event holds the last known state of the object.
+func deletedObject[T any](obj interface{}) (T, bool) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	o, ok := obj.(T)
+	return o, ok
 }
@@ -4,7 +4,14 @@
 package k8sclient
 
 import (
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 func TestInferWorkloadName(t *testing.T) {
@@ -86,3 +93,66 @@ func TestInferWorkloadName(t *testing.T) {
 		})
 	}
 }
+
+func TestTimedDeleter(t *testing.T) {
+	t.Run("DeleteWithDelay", func(t *testing.T) {
+		td := &TimedDeleter{Delay: 10 * time.Millisecond}
+		m := &sync.Map{}
+		td.Store(m, "1.2.3.4", "pod")
+		td.DeleteWithDelay(m, "1.2.3.4")
+		_, ok := m.Load("1.2.3.4")
+		assert.True(t, ok, "the key is kept during the delay")
+		assert.Eventually(t, func() bool {
+			_, ok := m.Load("1.2.3.4")
+			return !ok
+		}, time.Second, 5*time.Millisecond)
+	})
+	t.Run("StoredAgain", func(t *testing.T) {
+		td := &TimedDeleter{Delay: 10 * time.Millisecond}
+		m := &sync.Map{}
+		td.Store(m, "1.2.3.4", "pod")
+		td.DeleteWithDelay(m, "1.2.3.4")
+		td.Store(m, "1.2.3.4", "pod")
+		time.Sleep(50 * time.Millisecond)
+		_, ok := m.Load("1.2.3.4")
+		assert.True(t, ok, "storing the key again cancels its deletion")
+
+		// a deletion scheduled before the key was deleted and stored again is cancelled too
+		td.DeleteWithDelay(m, "1.2.3.4")
+		td.DeleteWithDelay(m, "1.2.3.4")
+		time.Sleep(5 * time.Millisecond)
+		td.Store(m, "1.2.3.4", "restarted")
+		time.Sleep(50 * time.Millisecond)
+		value, ok := m.Load("1.2.3.4")
+		assert.True(t, ok)
+		assert.Equal(t, "restarted", value)
+	})
+	t.Run("TTL", func(t *testing.T) {
+		td := &TimedDeleter{Delay: time.Minute, TTL: time.Minute}
+		m := &sync.Map{}
+		td.Store(m, "stale", "pod")
+		td.Store(m, "fresh", "pod")
+		td.entries[deleterKey{m: m, key: "stale"}].storedAt = time.Now().Add(-2 * time.Minute)
+		td.expire(time.Now())
+		_, ok := m.Load("stale")
+		assert.False(t, ok, "the keys not stored within the TTL are deleted")
+		_, ok = m.Load("fresh")
+		assert.True(t, ok)
+	})
+	t.Run("RunWithoutTTL", func(t *testing.T) {
+		td := &TimedDeleter{Delay: time.Minute}
+		td.Run(make(chan struct{}))
+	})
+}
+
+func TestDeletedObject(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}}
+	got, ok := deletedObject[*corev1.Pod](pod)
+	assert.True(t, ok)
+	assert.Equal(t, pod, got)
+	got, ok = deletedObject[*corev1.Pod](cache.DeletedFinalStateUnknown{Key: "default/pod", Obj: pod})
+	assert.True(t, ok)
+	assert.Equal(t, pod, got)
+	_, ok = deletedObject[*corev1.Pod](&corev1.Service{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,203 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package loadgen generates synthetic metrics, logs and traces at fixed rates and sends them through the pipelines of
+// a running agent, so that the agent resources and CloudWatch quotas needed by a workload can be measured before it
+// reaches production. Metrics are sent as EMF to the emf listener, logs are appended to a file that the agent tails
+// and traces are sent to the OTLP receiver. The namespace and log groups should be sandboxes that are safe to fill.
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	SignalMetrics = "metrics"
+	SignalLogs    = "logs"
+	SignalTraces  = "traces"
+
+	defaultNamespace    = "CWAgent/LoadGen"
+	defaultEMFEndpoint  = "127.0.0.1:25888"
+	defaultOTLPEndpoint = "127.0.0.1:4318"
+	defaultServiceName  = "cloudwatch-agent-loadgen"
+
+	reportInterval = 10 * time.Second
+)
+
+// Config is the load to generate. Rates are per second and per signal.
+type Config struct {
+	Signals      []string
+	Rate         int
+	Duration     time.Duration
+	Cardinality  int
+	Namespace    string
+	EMFEndpoint  string
+	OTLPEndpoint string
+	LogFile      string
+	LogSize      int
+	ServiceName  string
+}
+
+// ParseFlags parses the arguments of the loadgen subcommand.
+func ParseFlags(args []string, output io.Writer) (Config, error) {
+	var cfg Config
+	var signals string
+	fs := flag.NewFlagSet("loadgen", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&signals, "signals", SignalMetrics, "comma separated signals to generate: metrics, logs and traces")
+	fs.IntVar(&cfg.Rate, "rate", 100, "metric datums, log events or spans to generate per second for each signal")
+	fs.DurationVar(&cfg.Duration, "duration", 5*time.Minute, "how long to generate load for, 0 runs until interrupted")
+	fs.IntVar(&cfg.Cardinality, "cardinality", 10, "number of distinct metric series, log sources and span names")
+	fs.StringVar(&cfg.Namespace, "namespace", defaultNamespace, "sandbox CloudWatch namespace of the generated metrics")
+	fs.StringVar(&cfg.EMFEndpoint, "emf-endpoint", defaultEMFEndpoint, "TCP address of the agent emf listener")
+	fs.StringVar(&cfg.OTLPEndpoint, "otlp-endpoint", defaultOTLPEndpoint, "HTTP address of the agent OTLP traces receiver")
+	fs.StringVar(&cfg.LogFile, "log-file", "", "file to append log events to, collected by the agent into a sandbox log group")
+	fs.IntVar(&cfg.LogSize, "log-size", 256, "size of each log event in bytes")
+	fs.StringVar(&cfg.ServiceName, "service-name", defaultServiceName, "service.name of the generated spans")
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	for _, signal := range strings.Split(signals, ",") {
+		if signal = strings.TrimSpace(signal); signal != "" {
+			cfg.Signals = append(cfg.Signals, signal)
+		}
+	}
+	return cfg, cfg.Validate()
+}
+
+func (c Config) Validate() error {
+	if len(c.Signals) == 0 {
+		return errors.New("no signals to generate")
+	}
+	for _, signal := range c.Signals {
+		switch signal {
+		case SignalMetrics, SignalTraces:
+		case SignalLogs:
+			if c.LogFile == "" {
+				return errors.New("log-file is required to generate logs")
+			}
+		default:
+			return fmt.Errorf("unsupported signal %q, must be one of %s, %s or %s", signal, SignalMetrics, SignalLogs, SignalTraces)
+		}
+	}
+	if c.Rate <= 0 {
+		return fmt.Errorf("rate must be greater than 0, got %d", c.Rate)
+	}
+	if c.Cardinality <= 0 {
+		return fmt.Errorf("cardinality must be greater than 0, got %d", c.Cardinality)
+	}
+	if c.Duration < 0 {
+		return fmt.Errorf("duration must not be negative, got %v", c.Duration)
+	}
+	return nil
+}
+
+// generator sends a batch of n datums, events or spans. The sequence number of the first one is passed so that the
+// generators cycle through the configured cardinality.
+type generator interface {
+	signal() string
+	generate(ctx context.Context, seq int, n int) error
+	Close() error
+}
+
+type stats struct {
+	sent, failed int64
+}
+
+// Run generates the load until the duration elapses or the context is cancelled, reporting the throughput to w.
+func Run(ctx context.Context, w io.Writer, cfg Config) error {
+	return run(ctx, w, cfg, time.Second)
+}
+
+func run(ctx context.Context, w io.Writer, cfg Config, interval time.Duration) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	generators := make([]generator, 0, len(cfg.Signals))
+	defer func() {
+		for _, g := range generators {
+			_ = g.Close()
+		}
+	}()
+	for _, signal := range cfg.Signals {
+		g, err := newGenerator(signal, cfg)
+		if err != nil {
+			return err
+		}
+		generators = append(generators, g)
+	}
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	fmt.Fprintf(w, "Generating %d/s of %s %s\n", cfg.Rate, strings.Join(cfg.Signals, ", "), durationString(cfg.Duration))
+	counts := make([]stats, len(generators))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	report := time.NewTicker(reportInterval)
+	defer report.Stop()
+	start := time.Now()
+	seq := 0
+	for {
+		select {
+		case <-ctx.Done():
+			printStats(w, generators, counts, time.Since(start))
+			return nil
+		case <-report.C:
+			printStats(w, generators, counts, time.Since(start))
+		case <-ticker.C:
+			if ctx.Err() != nil {
+				continue
+			}
+			for i, g := range generators {
+				if err := g.generate(ctx, seq, cfg.Rate); err != nil {
+					counts[i].failed += int64(cfg.Rate)
+					if ctx.Err() == nil {
+						fmt.Fprintf(w, "Failed to send %s: %v\n", g.signal(), err)
+					}
+				} else {
+					counts[i].sent += int64(cfg.Rate)
+				}
+			}
+			seq += cfg.Rate
+		}
+	}
+}
+
+func newGenerator(signal string, cfg Config) (generator, error) {
+	switch signal {
+	case SignalMetrics:
+		return newMetricsGenerator(cfg), nil
+	case SignalLogs:
+		return newLogsGenerator(cfg)
+	case SignalTraces:
+		return newTracesGenerator(cfg), nil
+	}
+	return nil, fmt.Errorf("unsupported signal %q", signal)
+}
+
+func printStats(w io.Writer, generators []generator, counts []stats, elapsed time.Duration) {
+	seconds := elapsed.Seconds()
+	if seconds <= 0 {
+		seconds = 1
+	}
+	for i, g := range generators {
+		sent := counts[i].sent
+		fmt.Fprintf(w, "%s: sent %d (%.1f/s), failed %d\n", g.signal(), sent, float64(sent)/seconds, counts[i].failed)
+	}
+}
+
+func durationString(d time.Duration) string {
+	if d == 0 {
+		return "until interrupted"
+	}
+	return "for " + d.String()
+}
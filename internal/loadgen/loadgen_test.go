@@ -0,0 +1,180 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package loadgen
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func TestParseFlags(t *testing.T) {
+	testCases := map[string]struct {
+		args    []string
+		want    Config
+		wantErr string
+	}{
+		"Defaults": {
+			want: Config{
+				Signals:      []string{SignalMetrics},
+				Rate:         100,
+				Duration:     5 * time.Minute,
+				Cardinality:  10,
+				Namespace:    defaultNamespace,
+				EMFEndpoint:  defaultEMFEndpoint,
+				OTLPEndpoint: defaultOTLPEndpoint,
+				LogSize:      256,
+				ServiceName:  defaultServiceName,
+			},
+		},
+		"AllSignals": {
+			args: []string{"-signals", "metrics, logs,traces", "-rate", "5", "-log-file", "/tmp/loadgen.log", "-duration", "0"},
+			want: Config{
+				Signals:      []string{SignalMetrics, SignalLogs, SignalTraces},
+				Rate:         5,
+				Cardinality:  10,
+				Namespace:    defaultNamespace,
+				EMFEndpoint:  defaultEMFEndpoint,
+				OTLPEndpoint: defaultOTLPEndpoint,
+				LogFile:      "/tmp/loadgen.log",
+				LogSize:      256,
+				ServiceName:  defaultServiceName,
+			},
+		},
+		"LogsWithoutFile": {
+			args:    []string{"-signals", "logs"},
+			wantErr: "log-file is required",
+		},
+		"UnsupportedSignal": {
+			args:    []string{"-signals", "profiles"},
+			wantErr: `unsupported signal "profiles"`,
+		},
+		"InvalidRate": {
+			args:    []string{"-rate", "0"},
+			wantErr: "rate must be greater than 0",
+		},
+		"InvalidCardinality": {
+			args:    []string{"-cardinality", "-1"},
+			wantErr: "cardinality must be greater than 0",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseFlags(testCase.args, io.Discard)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	var mu sync.Mutex
+	var events []map[string]any
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			var event map[string]any
+			if json.Unmarshal(scanner.Bytes(), &event) == nil {
+				mu.Lock()
+				events = append(events, event)
+				mu.Unlock()
+			}
+		}
+	}()
+
+	var spans int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tracesPath, r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := ptraceotlp.NewExportRequest()
+		require.NoError(t, req.UnmarshalProto(body))
+		mu.Lock()
+		spans += req.Traces().SpanCount()
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	logFile := filepath.Join(t.TempDir(), "loadgen.log")
+	cfg := Config{
+		Signals:      []string{SignalMetrics, SignalLogs, SignalTraces},
+		Rate:         3,
+		Duration:     950 * time.Millisecond,
+		Cardinality:  2,
+		Namespace:    "Sandbox",
+		EMFEndpoint:  listener.Addr().String(),
+		OTLPEndpoint: strings.TrimPrefix(server.URL, "http://"),
+		LogFile:      logFile,
+		LogSize:      80,
+		ServiceName:  "checkout",
+	}
+	var out bytes.Buffer
+	require.NoError(t, run(context.Background(), &out, cfg, 100*time.Millisecond))
+	assert.Contains(t, out.String(), "Generating 3/s of metrics, logs, traces for 950ms")
+	assert.Contains(t, out.String(), "failed 0")
+
+	content, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	require.GreaterOrEqual(t, len(lines), 3)
+	assert.Len(t, lines[0], 80)
+	assert.Contains(t, lines[1], "source=source-1 seq=1 ")
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(events) == len(lines) && spans == len(lines)
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "series-1", events[1][seriesDimension])
+	metrics := events[0]["_aws"].(map[string]any)["CloudWatchMetrics"].([]any)
+	assert.Equal(t, "Sandbox", metrics[0].(map[string]any)["Namespace"])
+}
+
+func TestRunReportsFailures(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	cfg := Config{
+		Signals:     []string{SignalMetrics},
+		Rate:        1,
+		Duration:    300 * time.Millisecond,
+		Cardinality: 1,
+		EMFEndpoint: addr,
+	}
+	var out bytes.Buffer
+	require.NoError(t, run(context.Background(), &out, cfg, 100*time.Millisecond))
+	assert.Contains(t, out.String(), "Failed to send metrics")
+	assert.Contains(t, out.String(), "metrics: sent 0 (0.0/s)")
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// logsGenerator appends log lines to a file, so that the events go through the same tailing, filtering and batching as
+// the application logs would. The agent must be configured to collect the file into a sandbox log group.
+type logsGenerator struct {
+	file        *os.File
+	size        int
+	cardinality int
+}
+
+var _ generator = (*logsGenerator)(nil)
+
+func newLogsGenerator(cfg Config) (*logsGenerator, error) {
+	file, err := os.OpenFile(cfg.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open log file: %w", err)
+	}
+	return &logsGenerator{file: file, size: cfg.LogSize, cardinality: cfg.Cardinality}, nil
+}
+
+func (g *logsGenerator) signal() string {
+	return SignalLogs
+}
+
+func (g *logsGenerator) generate(_ context.Context, seq int, n int) error {
+	var buf bytes.Buffer
+	timestamp := time.Now().UTC().Format(time.RFC3339Nano)
+	for i := seq; i < seq+n; i++ {
+		line := fmt.Sprintf("%s INFO source=source-%d seq=%d loadgen event", timestamp, i%g.cardinality, i)
+		if padding := g.size - len(line) - 1; padding > 0 {
+			line += " " + strings.Repeat("x", padding)
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	_, err := g.file.Write(buf.Bytes())
+	return err
+}
+
+func (g *logsGenerator) Close() error {
+	return g.file.Close()
+}
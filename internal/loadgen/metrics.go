@@ -0,0 +1,90 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	metricName      = "LoadGenValue"
+	seriesDimension = "Series"
+	dialTimeout     = 5 * time.Second
+)
+
+// metricsGenerator writes one EMF event per datum to the TCP emf listener. Each datum belongs to one of the series
+// given by the cardinality, which is the number of metrics the namespace will contain.
+type metricsGenerator struct {
+	namespace   string
+	endpoint    string
+	cardinality int
+	conn        net.Conn
+}
+
+var _ generator = (*metricsGenerator)(nil)
+
+func newMetricsGenerator(cfg Config) *metricsGenerator {
+	return &metricsGenerator{
+		namespace:   cfg.Namespace,
+		endpoint:    cfg.EMFEndpoint,
+		cardinality: cfg.Cardinality,
+	}
+}
+
+func (g *metricsGenerator) signal() string {
+	return SignalMetrics
+}
+
+func (g *metricsGenerator) generate(ctx context.Context, seq int, n int) error {
+	var buf bytes.Buffer
+	timestamp := time.Now().UnixMilli()
+	encoder := json.NewEncoder(&buf)
+	for i := seq; i < seq+n; i++ {
+		if err := encoder.Encode(g.event(timestamp, i)); err != nil {
+			return err
+		}
+	}
+	if g.conn == nil {
+		dialer := net.Dialer{Timeout: dialTimeout}
+		conn, err := dialer.DialContext(ctx, "tcp", g.endpoint)
+		if err != nil {
+			return err
+		}
+		g.conn = conn
+	}
+	if _, err := g.conn.Write(buf.Bytes()); err != nil {
+		// reconnect on the next batch, e.g. after the agent restarted
+		_ = g.conn.Close()
+		g.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (g *metricsGenerator) event(timestamp int64, seq int) map[string]any {
+	return map[string]any{
+		"_aws": map[string]any{
+			"Timestamp": timestamp,
+			"CloudWatchMetrics": []map[string]any{{
+				"Namespace":  g.namespace,
+				"Dimensions": [][]string{{seriesDimension}},
+				"Metrics":    []map[string]string{{"Name": metricName, "Unit": "Count"}},
+			}},
+		},
+		seriesDimension: fmt.Sprintf("series-%d", seq%g.cardinality),
+		metricName:      seq % 100,
+	}
+}
+
+func (g *metricsGenerator) Close() error {
+	if g.conn == nil {
+		return nil
+	}
+	return g.conn.Close()
+}
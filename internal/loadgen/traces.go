@@ -0,0 +1,107 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+)
+
+const (
+	tracesPath   = "/v1/traces"
+	httpTimeout  = 10 * time.Second
+	spanDuration = 5 * time.Millisecond
+	scopeName    = "github.com/aws/amazon-cloudwatch-agent/internal/loadgen"
+)
+
+// tracesGenerator sends each batch of spans in one OTLP/HTTP request. Every span is the root of its own trace, so
+// that the number of spans is also the number of trace segments sent to X-Ray.
+type tracesGenerator struct {
+	url         string
+	serviceName string
+	cardinality int
+	client      *http.Client
+	random      *rand.Rand
+}
+
+var _ generator = (*tracesGenerator)(nil)
+
+func newTracesGenerator(cfg Config) *tracesGenerator {
+	return &tracesGenerator{
+		url:         "http://" + cfg.OTLPEndpoint + tracesPath,
+		serviceName: cfg.ServiceName,
+		cardinality: cfg.Cardinality,
+		client:      &http.Client{Timeout: httpTimeout},
+		random:      rand.New(rand.NewSource(time.Now().UnixNano())), // nolint:gosec
+	}
+}
+
+func (g *tracesGenerator) signal() string {
+	return SignalTraces
+}
+
+func (g *tracesGenerator) generate(ctx context.Context, seq int, n int) error {
+	body, err := ptraceotlp.NewExportRequestFromTraces(g.traces(seq, n)).MarshalProto()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (g *tracesGenerator) traces(seq int, n int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(semconv.AttributeServiceName, g.serviceName)
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(scopeName)
+	end := time.Now()
+	start := end.Add(-spanDuration)
+	for i := seq; i < seq+n; i++ {
+		span := ss.Spans().AppendEmpty()
+		var traceID pcommon.TraceID
+		var spanID pcommon.SpanID
+		// X-Ray trace IDs start with the epoch seconds of the trace
+		epoch := uint32(start.Unix())
+		traceID[0], traceID[1], traceID[2], traceID[3] = byte(epoch>>24), byte(epoch>>16), byte(epoch>>8), byte(epoch)
+		_, _ = g.random.Read(traceID[4:])
+		_, _ = g.random.Read(spanID[:])
+		span.SetTraceID(traceID)
+		span.SetSpanID(spanID)
+		span.SetName(fmt.Sprintf("operation-%d", i%g.cardinality))
+		span.SetKind(ptrace.SpanKindServer)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(end))
+		span.Attributes().PutInt("loadgen.seq", int64(i))
+	}
+	return td
+}
+
+func (g *tracesGenerator) Close() error {
+	g.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package logformat is the library of the parsing templates of well-known log formats, which the log files select by
+// name instead of writing the regular expressions of their format.
+package logformat
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format is the parsing of the events of a log format. The patterns are regular expressions in the syntax of the
+// file config.
+type Format struct {
+	// TimestampRegex captures the timestamp of the event in its first group, which is parsed with the first of the
+	// TimestampLayouts it matches.
+	TimestampRegex   string
+	TimestampLayouts []string
+	// MultiLineStartPattern matches the first line of an event, for the formats whose events span lines.
+	MultiLineStartPattern string
+	// FieldPattern extracts the fields of the event in its named groups.
+	FieldPattern string
+	// LevelPattern captures the level of the event in its first group, for the formats which have one.
+	LevelPattern string
+}
+
+const (
+	// versionSeparator separates the name of a format from its version, e.g. "nginx_access@v1".
+	versionSeparator = "@v"
+
+	// the timestamps of the formats, e.g. "10/Oct/2000:13:55:36 -0700" for the common log format
+	commonLogTimestamp = `\[(\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4})\]`
+	commonLogLayout    = "02/Jan/2006:15:04:05 -0700"
+	// e.g. "2024-01-02 15:04:05,123" or "2024-01-02T15:04:05.123". The fraction is parsed after the seconds.
+	isoLocalTimestamp = `^(\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}(?:[.,]\d{1,9})?)`
+	// e.g. "2016-04-15T20:17:00.310Z" or "2016-04-15T20:17:00+02:00"
+	rfc3339Timestamp = `(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d{1,9})?(?:Z|[+-]\d{2}:?\d{2}))`
+
+	// the fields of the common and combined log formats, e.g. `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700]
+	// "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://example.com/" "Mozilla/4.08"`
+	commonLogFields = `^(?P<remote_addr>\S+) \S+ (?P<remote_user>\S+) \[[^\]]+\] "(?P<method>[A-Z]+) (?P<path>[^ "]*)(?: (?P<protocol>[^"]*))?" (?P<status>\d{3}) (?P<body_bytes_sent>\d+|-)(?: "(?P<referer>[^"]*)" "(?P<user_agent>[^"]*)")?`
+)
+
+// formats are the versions of each format, the first being version 1. A version is never changed once released,
+// so that the files selecting it keep being parsed the same way, and the changes are released as a new version.
+var formats = map[string][]Format{
+	// nginx access log in the default combined format
+	"nginx_access": {{
+		TimestampRegex:   commonLogTimestamp,
+		TimestampLayouts: []string{commonLogLayout},
+		FieldPattern:     commonLogFields,
+	}},
+	// e.g. "2024/01/02 15:04:05 [error] 1234#0: *5 open() failed"
+	"nginx_error": {{
+		TimestampRegex:        `^(\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2})`,
+		TimestampLayouts:      []string{"2006/01/02 15:04:05"},
+		MultiLineStartPattern: `^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} `,
+		FieldPattern:          `^\S+ \S+ \[(?P<level>\w+)\] (?P<pid>\d+)#(?P<tid>\d+):(?: \*(?P<connection>\d+))?`,
+		LevelPattern:          `^\S+ \S+ \[(\w+)\]`,
+	}},
+	// Apache access log in the common or combined format
+	"apache_access": {{
+		TimestampRegex:   commonLogTimestamp,
+		TimestampLayouts: []string{commonLogLayout},
+		FieldPattern:     commonLogFields,
+	}},
+	// e.g. "[Wed Oct 11 14:32:52.123456 2000] [core:error] [pid 35708:tid 4328636416] [client 72.15.99.187] ..."
+	"apache_error": {{
+		TimestampRegex:        `^\[(\w{3} \w{3} [ \d]\d \d{2}:\d{2}:\d{2}(?:\.\d{1,6})? \d{4})\]`,
+		TimestampLayouts:      []string{"Mon Jan _2 15:04:05 2006"},
+		MultiLineStartPattern: `^\[\w{3} \w{3} [ \d]\d `,
+		FieldPattern:          `^\[[^\]]+\] \[(?:(?P<module>\w+):)?(?P<level>\w+)\] \[pid (?P<pid>\d+)(?::tid (?P<tid>\d+))?\](?: \[client (?P<client>[^\]]+)\])?`,
+		LevelPattern:          `^\[[^\]]+\] \[(?:\w+:)?(\w+)\]`,
+	}},
+	// BSD syslog (RFC 3164), e.g. "Jan  2 15:04:05 host sshd[123]: Accepted publickey for ec2-user"
+	"syslog": {{
+		TimestampRegex:   `^(\w{3} [ \d]\d \d{2}:\d{2}:\d{2})`,
+		TimestampLayouts: []string{"Jan _2 15:04:05"},
+		FieldPattern:     `^\w{3} [ \d]\d \d{2}:\d{2}:\d{2} (?P<hostname>\S+) (?P<program>[^\s\[:]+)(?:\[(?P<pid>\d+)\])?:`,
+	}},
+	// syslog (RFC 5424), e.g. "<34>1 2003-10-11T22:14:15.003Z host su 123 ID47 - 'su root' failed"
+	"syslog_rfc5424": {{
+		TimestampRegex:   `^(?:<\d+>)?\d+ ` + rfc3339Timestamp,
+		TimestampLayouts: []string{"2006-01-02T15:04:05Z07:00", "2006-01-02T15:04:05Z0700"},
+		FieldPattern:     `^(?:<(?P<priority>\d+)>)?\d+ \S+ (?P<hostname>\S+) (?P<app_name>\S+) (?P<procid>\S+) (?P<msgid>\S+)`,
+	}},
+	// Java application log in the default layout of log4j and logback, whose stack traces span lines, e.g.
+	// "2024-01-02 15:04:05,123 ERROR [main] com.example.App - Request failed"
+	"java": {{
+		TimestampRegex:        isoLocalTimestamp,
+		TimestampLayouts:      []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05"},
+		MultiLineStartPattern: `^\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}`,
+		FieldPattern:          `^\S+ \S+\s+(?P<level>[A-Z]+)\s+\[(?P<thread>[^\]]+)\]\s+(?P<logger>\S+)`,
+		LevelPattern:          `^\S+ \S+\s+(TRACE|DEBUG|INFO|WARN|ERROR|FATAL)\b`,
+	}},
+	// Envoy access log in the default format, e.g. `[2016-04-15T20:17:00.310Z] "POST /api/v1/locations HTTP/2" 204 -
+	// 154 0 226 100 "10.0.35.28" "nsq2http" "cc21d9b0-cf5c-432b-8c7e-98aeb7988cd2" "locations" "tcp://10.0.2.1:80"`
+	"envoy_access": {{
+		TimestampRegex:   `^\[` + rfc3339Timestamp + `\]`,
+		TimestampLayouts: []string{"2006-01-02T15:04:05Z07:00", "2006-01-02T15:04:05Z0700"},
+		FieldPattern:     `^\[[^\]]+\] "(?P<method>\S+) (?P<path>\S+) (?P<protocol>[^"]+)" (?P<response_code>\d+) (?P<response_flags>\S+) (?P<bytes_received>\d+) (?P<bytes_sent>\d+) (?P<duration>\d+) (?P<upstream_service_time>\S+) "(?P<x_forwarded_for>[^"]*)" "(?P<user_agent>[^"]*)" "(?P<request_id>[^"]*)" "(?P<authority>[^"]*)" "(?P<upstream_host>[^"]*)"`,
+	}},
+}
+
+// Lookup returns the format of a name, e.g. "nginx_access" for its latest version or "nginx_access@v1" for version 1.
+func Lookup(name string) (Format, error) {
+	base, version, versioned := strings.Cut(name, versionSeparator)
+	versions, ok := formats[base]
+	if !ok {
+		return Format{}, fmt.Errorf("unknown log format %q, must be one of %v", name, Names())
+	}
+	if !versioned {
+		return versions[len(versions)-1], nil
+	}
+	v, err := strconv.Atoi(version)
+	if err != nil || v < 1 || v > len(versions) {
+		return Format{}, fmt.Errorf("unknown version of log format %q, the versions are v1 to v%d", name, len(versions))
+	}
+	return versions[v-1], nil
+}
+
+// Names returns the names of the formats, sorted.
+func Names() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,122 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logformat
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	latest, err := Lookup("nginx_access")
+	require.NoError(t, err)
+	v1, err := Lookup("nginx_access@v1")
+	require.NoError(t, err)
+	assert.Equal(t, latest, v1)
+
+	_, err = Lookup("nginx_access@v9")
+	assert.ErrorContains(t, err, "v1 to v1")
+	_, err = Lookup("nginx_access@vlatest")
+	assert.Error(t, err)
+	_, err = Lookup("iis")
+	assert.ErrorContains(t, err, "apache_access")
+	assert.Len(t, Names(), len(formats))
+}
+
+func TestFormats(t *testing.T) {
+	testCases := map[string]struct {
+		line         string
+		continuation string
+		want         time.Time
+		wantFields   map[string]string
+		wantLevel    string
+	}{
+		"nginx_access": {
+			line:       `192.168.1.10 - - [02/Jan/2024:15:04:05 +0000] "GET /index.html HTTP/1.1" 200 612 "-" "curl/8.0"`,
+			want:       time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+			wantFields: map[string]string{"remote_addr": "192.168.1.10", "method": "GET", "path": "/index.html", "status": "200", "user_agent": "curl/8.0"},
+		},
+		"nginx_error": {
+			line:         "2024/01/02 15:04:05 [error] 1234#0: *5 open() \"/usr/share/nginx/html/x\" failed",
+			continuation: "  while reading response header",
+			want:         time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+			wantFields:   map[string]string{"level": "error", "pid": "1234", "connection": "5"},
+			wantLevel:    "error",
+		},
+		"apache_access": {
+			line:       `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`,
+			want:       time.Date(2000, 10, 10, 20, 55, 36, 0, time.UTC),
+			wantFields: map[string]string{"remote_addr": "127.0.0.1", "remote_user": "frank", "status": "200", "body_bytes_sent": "2326"},
+		},
+		"apache_error": {
+			line:         "[Wed Oct 11 14:32:52.123456 2000] [core:error] [pid 35708:tid 4328636416] [client 72.15.99.187] File does not exist",
+			continuation: "AH00128: /var/www/missing",
+			want:         time.Date(2000, 10, 11, 14, 32, 52, 123456000, time.UTC),
+			wantFields:   map[string]string{"module": "core", "level": "error", "pid": "35708", "client": "72.15.99.187"},
+			wantLevel:    "error",
+		},
+		"syslog": {
+			line:       "Jan  2 15:04:05 ip-10-0-0-1 sshd[123]: Accepted publickey for ec2-user",
+			want:       time.Date(0, 1, 2, 15, 4, 5, 0, time.UTC),
+			wantFields: map[string]string{"hostname": "ip-10-0-0-1", "program": "sshd", "pid": "123"},
+		},
+		"syslog_rfc5424": {
+			line:       "<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - 'su root' failed",
+			want:       time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+			wantFields: map[string]string{"priority": "34", "hostname": "mymachine.example.com", "app_name": "su", "msgid": "ID47"},
+		},
+		"java": {
+			line:         "2024-01-02 15:04:05,123 ERROR [main] com.example.App - Request failed",
+			continuation: "\tat com.example.App.main(App.java:10)",
+			want:         time.Date(2024, 1, 2, 15, 4, 5, 123000000, time.UTC),
+			wantFields:   map[string]string{"level": "ERROR", "thread": "main", "logger": "com.example.App"},
+			wantLevel:    "ERROR",
+		},
+		"envoy_access": {
+			line:       `[2016-04-15T20:17:00.310Z] "POST /api/v1/locations HTTP/2" 204 - 154 0 226 100 "10.0.35.28" "nsq2http" "cc21d9b0-cf5c-432b-8c7e-98aeb7988cd2" "locations" "tcp://10.0.2.1:80"`,
+			want:       time.Date(2016, 4, 15, 20, 17, 0, 310000000, time.UTC),
+			wantFields: map[string]string{"method": "POST", "path": "/api/v1/locations", "response_code": "204", "authority": "locations", "upstream_host": "tcp://10.0.2.1:80"},
+		},
+	}
+	assert.Len(t, testCases, len(formats))
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			format, err := Lookup(name)
+			require.NoError(t, err)
+
+			match := regexp.MustCompile(format.TimestampRegex).FindStringSubmatch(testCase.line)
+			require.Len(t, match, 2)
+			var got time.Time
+			for _, layout := range format.TimestampLayouts {
+				if got, err = time.ParseInLocation(layout, match[1], time.UTC); err == nil {
+					break
+				}
+			}
+			require.NoError(t, err)
+			assert.True(t, testCase.want.Equal(got), "got %v", got)
+
+			fieldPattern := regexp.MustCompile(format.FieldPattern)
+			fields := fieldPattern.FindStringSubmatch(testCase.line)
+			require.NotNil(t, fields)
+			for field, want := range testCase.wantFields {
+				assert.Equal(t, want, fields[fieldPattern.SubexpIndex(field)], field)
+			}
+
+			if format.MultiLineStartPattern != "" {
+				start := regexp.MustCompile(format.MultiLineStartPattern)
+				assert.True(t, start.MatchString(testCase.line))
+				assert.False(t, start.MatchString(testCase.continuation))
+			}
+			if format.LevelPattern != "" {
+				level := regexp.MustCompile(format.LevelPattern).FindStringSubmatch(testCase.line)
+				require.Len(t, level, 2)
+				assert.Equal(t, testCase.wantLevel, level[1])
+			}
+		})
+	}
+}
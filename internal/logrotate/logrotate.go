@@ -0,0 +1,168 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package logrotate rotates, compresses and removes the files the agent writes itself, e.g. its own log, with the
+// settings of agent.log_rotation, so that an agent left alone cannot fill the disk.
+package logrotate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	// SectionKey is the table of the agent section of the TOML configuration the settings are read from.
+	SectionKey = "log_rotation"
+
+	// The defaults are the retention of the agent log published in the public documentation.
+	DefaultMaxSizeMB  = 100
+	DefaultMaxBackups = 5
+	DefaultMaxAgeDays = 7
+)
+
+var (
+	mu      sync.Mutex
+	current = DefaultConfig()
+)
+
+// Config is how the files are rotated and how long the rotated files are kept.
+type Config struct {
+	// MaxSizeMB is the size at which a file is rotated.
+	MaxSizeMB int `toml:"max_size_mb"`
+	// MaxBackups is the number of rotated files kept per file. 0 keeps all of them.
+	MaxBackups int `toml:"max_backups"`
+	// MaxAgeDays is how long the rotated files are kept. 0 keeps them until MaxBackups is reached.
+	MaxAgeDays int `toml:"max_age_days"`
+	// Compress gzips the rotated files.
+	Compress bool `toml:"compress"`
+	// Interval rotates the files at least this often, in seconds, whatever their size. 0 only rotates by size.
+	Interval int `toml:"interval"`
+}
+
+// DefaultConfig returns the settings used when agent.log_rotation is not set.
+func DefaultConfig() Config {
+	return Config{
+		MaxSizeMB:  DefaultMaxSizeMB,
+		MaxBackups: DefaultMaxBackups,
+		MaxAgeDays: DefaultMaxAgeDays,
+		Compress:   true,
+	}
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if c.MaxSizeMB <= 0 {
+		errs = append(errs, fmt.Errorf("max_size_mb must be positive, but got %d", c.MaxSizeMB))
+	}
+	if c.MaxBackups < 0 {
+		errs = append(errs, fmt.Errorf("max_backups must not be negative, but got %d", c.MaxBackups))
+	}
+	if c.MaxAgeDays < 0 {
+		errs = append(errs, fmt.Errorf("max_age_days must not be negative, but got %d", c.MaxAgeDays))
+	}
+	if c.Interval < 0 {
+		errs = append(errs, fmt.Errorf("interval must not be negative, but got %d", c.Interval))
+	}
+	return errors.Join(errs...)
+}
+
+// MaxAge returns how long the rotated files are kept, or 0 if they are not removed by age.
+func (c Config) MaxAge() time.Duration {
+	return time.Duration(c.MaxAgeDays) * 24 * time.Hour
+}
+
+// Configure sets the settings of the writers created afterward. It is called once the configuration is loaded.
+func Configure(c Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = c
+}
+
+// Current returns the settings set by Configure, or the defaults.
+func Current() Config {
+	mu.Lock()
+	defer mu.Unlock()
+	return current
+}
+
+// Load reads the settings from the agent section of the TOML configuration, and returns the defaults if they are
+// not set.
+func Load(tomlPath string) (Config, error) {
+	var file struct {
+		Agent struct {
+			LogRotation *Config `toml:"log_rotation"`
+		} `toml:"agent"`
+	}
+	if _, err := toml.DecodeFile(tomlPath, &file); err != nil {
+		return DefaultConfig(), err
+	}
+	if file.Agent.LogRotation == nil {
+		return DefaultConfig(), nil
+	}
+	return *file.Agent.LogRotation, file.Agent.LogRotation.Validate()
+}
+
+// Writer appends to a file, which it rotates when it reaches the maximum size or the interval passes.
+type Writer struct {
+	mu       sync.Mutex
+	logger   *lumberjack.Logger
+	interval time.Duration
+	rotateAt time.Time
+	now      func() time.Time
+}
+
+// NewWriter returns a writer of the file with the current settings. The directory of the file is created if needed.
+func NewWriter(filename string) *Writer {
+	c := Current()
+	_ = os.MkdirAll(filepath.Dir(filename), 0755)
+	return &Writer{
+		logger: &lumberjack.Logger{
+			Filename:   filename,
+			MaxSize:    c.MaxSizeMB,
+			MaxBackups: c.MaxBackups,
+			MaxAge:     c.MaxAgeDays,
+			Compress:   c.Compress,
+		},
+		interval: time.Duration(c.Interval) * time.Second,
+		now:      time.Now,
+	}
+}
+
+// Write rotates the file first when the interval passed since the writer started, or since the last rotation.
+func (w *Writer) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.interval > 0 {
+		now := w.now()
+		if w.rotateAt.IsZero() {
+			w.rotateAt = now.Add(w.interval)
+		} else if !now.Before(w.rotateAt) {
+			w.rotateAt = now.Add(w.interval)
+			if err := w.logger.Rotate(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return w.logger.Write(b)
+}
+
+// Rotate starts a new file, whatever the size of the current one.
+func (w *Writer) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.interval > 0 {
+		w.rotateAt = w.now().Add(w.interval)
+	}
+	return w.logger.Rotate()
+}
+
+func (w *Writer) Close() error {
+	return w.logger.Close()
+}
@@ -0,0 +1,97 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logrotate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+	assert.NoError(t, Config{MaxSizeMB: 1}.Validate())
+	for name, c := range map[string]Config{
+		"NoSize":           {},
+		"NegativeBackups":  {MaxSizeMB: 1, MaxBackups: -1},
+		"NegativeAge":      {MaxSizeMB: 1, MaxAgeDays: -1},
+		"NegativeInterval": {MaxSizeMB: 1, Interval: -1},
+	} {
+		assert.Error(t, c.Validate(), name)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("[agent]\n  interval = \"60s\"\n"), 0600))
+	c, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig(), c)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[agent]
+  interval = "60s"
+  [agent.log_rotation]
+    compress = false
+    interval = 3600
+    max_age_days = 0
+    max_backups = 2
+    max_size_mb = 10
+`), 0600))
+	c, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, Config{MaxSizeMB: 10, MaxBackups: 2, Interval: 3600}, c)
+	assert.Zero(t, c.MaxAge())
+
+	require.NoError(t, os.WriteFile(path, []byte("[agent.log_rotation]\n  max_size_mb = 0\n"), 0600))
+	_, err = Load(path)
+	assert.Error(t, err)
+
+	_, err = Load(filepath.Join(dir, "missing.toml"))
+	assert.Error(t, err)
+}
+
+func TestWriter(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultConfig()) })
+	Configure(Config{MaxSizeMB: 1, MaxBackups: 1, Interval: 60})
+	assert.Equal(t, 60, Current().Interval)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs", "agent.log")
+	w := NewWriter(path)
+	defer w.Close()
+	now := time.Now()
+	w.now = func() time.Time { return now }
+
+	_, err := w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	now = now.Add(30 * time.Second)
+	_, err = w.Write([]byte("second\n"))
+	require.NoError(t, err)
+	assert.Len(t, files(t, dir), 1, "the interval did not pass")
+
+	now = now.Add(30 * time.Second)
+	_, err = w.Write([]byte("third\n"))
+	require.NoError(t, err)
+	assert.Len(t, files(t, dir), 2)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(content))
+
+	// the oldest rotated files are removed past max_backups
+	require.NoError(t, w.Rotate())
+	assert.Eventually(t, func() bool {
+		return len(files(t, dir)) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func files(t *testing.T, dir string) []string {
+	matches, err := filepath.Glob(filepath.Join(dir, "logs", "agent*.log*"))
+	require.NoError(t, err)
+	return matches
+}
@@ -0,0 +1,221 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package otlpfile implements the on disk format shared by the otlp_file exporter and receiver. Each file holds
+// a single signal and starts with a magic header followed by length prefixed OTLP protobuf payloads
+// (MetricsData, TracesData or LogsData), so files can be copied off an air-gapped host and replayed later.
+package otlpfile
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Signal string
+
+const (
+	SignalMetrics Signal = "metrics"
+	SignalTraces  Signal = "traces"
+	SignalLogs    Signal = "logs"
+
+	// Extension is used for completed files that are ready to be transferred and replayed.
+	Extension = ".otlp"
+	// partialExtension is used for the file currently being written.
+	partialExtension = ".otlp.partial"
+
+	timeFormat = "20060102T150405.000000000Z"
+	// maxPayloadSize protects the reader from corrupted length prefixes.
+	maxPayloadSize = 256 * 1024 * 1024
+)
+
+var (
+	magic = []byte("CWAOTLP1")
+
+	ErrInvalidFile = errors.New("not an otlp file")
+)
+
+// Writer appends payloads for one signal to rotating files in a directory.
+type Writer struct {
+	dir      string
+	signal   Signal
+	maxSize  int64
+	maxFiles int
+
+	mu   sync.Mutex
+	file *os.File
+	// size is the offset after the last complete payload of the file.
+	size int64
+}
+
+// NewWriter creates the directory if needed and completes any partial file left over from a previous run.
+// Files are rotated once they reach maxSize bytes and at most maxFiles completed files are kept, oldest
+// first removed. A maxFiles <= 0 keeps every file.
+func NewWriter(dir string, signal Signal, maxSize int64, maxFiles int) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	partials, err := filepath.Glob(filepath.Join(dir, string(signal)+"-*"+partialExtension))
+	if err != nil {
+		return nil, err
+	}
+	for _, partial := range partials {
+		if err = os.Rename(partial, strings.TrimSuffix(partial, partialExtension)+Extension); err != nil {
+			return nil, err
+		}
+	}
+	return &Writer{dir: dir, signal: signal, maxSize: maxSize, maxFiles: maxFiles}, nil
+}
+
+// Write appends the payload to the current file and rotates it if it is full. The payload is written once Write
+// returns nil, even if the rotation failed.
+func (w *Writer) Write(payload []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+	// write the prefix and the payload at once, so a crash loses at most the payload being written
+	record := make([]byte, 4+len(payload))
+	binary.BigEndian.PutUint32(record, uint32(len(payload)))
+	copy(record[4:], payload)
+	if _, err := w.file.Write(record); err != nil {
+		return errors.Join(err, w.truncate())
+	}
+	w.size += int64(len(record))
+	if w.maxSize > 0 && w.size >= w.maxSize {
+		if err := w.rotate(); err != nil {
+			log.Printf("E! Failed to rotate otlp file in %s: %v", w.dir, err)
+		}
+	}
+	return nil
+}
+
+// truncate drops what a failed write left after the last complete payload, so that the next payload does not
+// follow a partial one. If the file cannot be truncated it is completed, which the reader ends at the partial
+// payload like after a crash.
+func (w *Writer) truncate() error {
+	err := w.file.Truncate(w.size)
+	if err == nil {
+		_, err = w.file.Seek(w.size, io.SeekStart)
+	}
+	if err != nil {
+		return errors.Join(err, w.rotate())
+	}
+	return nil
+}
+
+// Close completes the current file.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	return w.rotate()
+}
+
+func (w *Writer) open() error {
+	name := fmt.Sprintf("%s-%s%s", w.signal, time.Now().UTC().Format(timeFormat), partialExtension)
+	file, err := os.OpenFile(filepath.Join(w.dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(magic); err != nil {
+		file.Close()
+		return err
+	}
+	w.file = file
+	w.size = int64(len(magic))
+	return nil
+}
+
+func (w *Writer) rotate() error {
+	path := w.file.Name()
+	err := errors.Join(w.file.Sync(), w.file.Close())
+	w.file = nil
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(path, strings.TrimSuffix(path, partialExtension)+Extension); err != nil {
+		return err
+	}
+	return w.removeOldest()
+}
+
+func (w *Writer) removeOldest() error {
+	if w.maxFiles <= 0 {
+		return nil
+	}
+	files, err := Files(w.dir, w.signal)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for len(files) > w.maxFiles {
+		errs = errors.Join(errs, os.Remove(files[0]))
+		files = files[1:]
+	}
+	return errs
+}
+
+// Files returns the completed files of the signal in the directory, oldest first.
+func Files(dir string, signal Signal) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, string(signal)+"-*"+Extension))
+	if err != nil {
+		return nil, err
+	}
+	// the timestamp in the name sorts lexically
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadFile calls fn with every payload in the file, in order. A payload cut short by a crash ends the file
+// without an error. fn receives the index of the payload so callers can resume a partially replayed file.
+func ReadFile(path string, fn func(index int, payload []byte) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	r := bufio.NewReader(file)
+
+	header := make([]byte, len(magic))
+	if _, err = io.ReadFull(r, header); err != nil || string(header) != string(magic) {
+		return fmt.Errorf("%w: %s", ErrInvalidFile, path)
+	}
+	var prefix [4]byte
+	for index := 0; ; index++ {
+		if _, err = io.ReadFull(r, prefix[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+		size := binary.BigEndian.Uint32(prefix[:])
+		if size > maxPayloadSize {
+			return fmt.Errorf("%w: payload of %d bytes in %s", ErrInvalidFile, size, path)
+		}
+		payload := make([]byte, size)
+		if _, err = io.ReadFull(r, payload); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+		if err = fn(index, payload); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlpfile
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, path string) []string {
+	t.Helper()
+	var payloads []string
+	require.NoError(t, ReadFile(path, func(_ int, payload []byte) error {
+		payloads = append(payloads, string(payload))
+		return nil
+	}))
+	return payloads
+}
+
+func TestWriterRotation(t *testing.T) {
+	dir := t.TempDir()
+	// every payload is 4+5 bytes, so the header and two payloads fill a file
+	w, err := NewWriter(dir, SignalMetrics, int64(len(magic)+18), 2)
+	require.NoError(t, err)
+	for _, payload := range []string{"one..", "two..", "three", "four.", "five."} {
+		require.NoError(t, w.Write([]byte(payload)))
+	}
+	files, err := Files(dir, SignalMetrics)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, []string{"one..", "two.."}, readAll(t, files[0]))
+	assert.Equal(t, []string{"three", "four."}, readAll(t, files[1]))
+
+	require.NoError(t, w.Close())
+	files, err = Files(dir, SignalMetrics)
+	require.NoError(t, err)
+	// the oldest file was removed to keep at most two
+	require.Len(t, files, 2)
+	assert.Equal(t, []string{"three", "four."}, readAll(t, files[0]))
+	assert.Equal(t, []string{"five."}, readAll(t, files[1]))
+
+	traces, err := Files(dir, SignalTraces)
+	require.NoError(t, err)
+	assert.Empty(t, traces)
+}
+
+func TestWriterRecoversPartialFile(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir, SignalLogs, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]byte("complete")))
+	// simulate a crash in the middle of a payload
+	_, err = w.file.Write([]byte{0, 0, 0, 10, 'p', 'a'})
+	require.NoError(t, err)
+	require.NoError(t, w.file.Close())
+
+	files, err := Files(dir, SignalLogs)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	_, err = NewWriter(dir, SignalLogs, 0, 0)
+	require.NoError(t, err)
+	files, err = Files(dir, SignalLogs)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, []string{"complete"}, readAll(t, files[0]))
+}
+
+func TestWriterRotationFailed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the directory of an open file cannot be removed on Windows")
+	}
+	dir := t.TempDir()
+	w, err := NewWriter(dir, SignalMetrics, int64(len(magic)+9), 0)
+	require.NoError(t, err)
+	// the file being written is still open, but it can no longer be renamed once its directory is gone
+	require.NoError(t, w.open())
+	require.NoError(t, os.RemoveAll(dir))
+	assert.NoError(t, w.Write([]byte("one..")))
+	assert.Nil(t, w.file)
+}
+
+func TestReadFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics-invalid"+Extension)
+	require.NoError(t, os.WriteFile(path, []byte("not otlp"), 0600))
+	assert.ErrorIs(t, ReadFile(path, func(int, []byte) error { return nil }), ErrInvalidFile)
+}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package privhelper lets the agent keep reading files that need root after it drops privileges.
+// The start wrapper leaves a small helper process running as root that opens the files allowed by
+// the configuration and passes the open descriptors to the agent over a unix socket. The agent never
+// regains root and the helper opens nothing outside its allowlist.
+package privhelper
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gobwas/glob"
+)
+
+const (
+	// EnvSocket holds the path of the helper socket in the agent's environment. It is unset when no
+	// helper is running.
+	EnvSocket = "CWAGENT_HELPER_SOCKET"
+
+	okReply        = "ok"
+	maxRequestSize = 4096
+)
+
+var ErrNotRunning = errors.New("privileged helper is not running")
+
+// Allowlist holds the absolute file patterns the helper may open. Patterns use the same glob
+// syntax as the logfile file_path option, including "**".
+type Allowlist struct {
+	globs []glob.Glob
+}
+
+func NewAllowlist(patterns []string) (*Allowlist, error) {
+	a := &Allowlist{}
+	for _, p := range patterns {
+		if !filepath.IsAbs(p) {
+			return nil, fmt.Errorf("helper pattern %q is not an absolute path", p)
+		}
+		g, err := glob.Compile(filepath.Clean(p), filepath.Separator)
+		if err != nil {
+			return nil, fmt.Errorf("invalid helper pattern %q: %w", p, err)
+		}
+		a.globs = append(a.globs, g)
+	}
+	return a, nil
+}
+
+// Allowed reports whether path matches one of the patterns. Callers pass the path with symlinks
+// resolved so that a link inside an allowed directory cannot expose other files.
+func (a *Allowlist) Allowed(path string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+	path = filepath.Clean(path)
+	for _, g := range a.globs {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
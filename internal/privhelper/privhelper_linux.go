@@ -0,0 +1,143 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+// +build linux
+
+package privhelper
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const requestTimeout = 5 * time.Second
+
+// Serve answers open requests on l until it is closed. Only processes running as uid, or root,
+// may connect.
+func Serve(l *net.UnixListener, uid int, allow *Allowlist) error {
+	for {
+		conn, err := l.AcceptUnix()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go handle(conn, uid, allow)
+	}
+}
+
+func handle(conn *net.UnixConn, uid int, allow *Allowlist) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(requestTimeout))
+	if err := checkPeer(conn, uid); err != nil {
+		log.Printf("W! Privileged helper rejected connection: %v", err)
+		return
+	}
+	line, err := bufio.NewReaderSize(conn, maxRequestSize).ReadString('\n')
+	if err != nil {
+		return
+	}
+	name := strings.TrimSuffix(line, "\n")
+	f, err := open(name, allow)
+	if err != nil {
+		log.Printf("W! Privileged helper refused to open %s: %v", name, err)
+		_, _ = conn.Write([]byte(err.Error()))
+		return
+	}
+	defer f.Close()
+	if _, _, err = conn.WriteMsgUnix([]byte(okReply), unix.UnixRights(int(f.Fd())), nil); err != nil {
+		log.Printf("W! Privileged helper failed to pass %s: %v", name, err)
+	}
+}
+
+func open(name string, allow *Allowlist) (*os.File, error) {
+	resolved, err := filepath.EvalSymlinks(name)
+	if err != nil {
+		return nil, err
+	}
+	if !allow.Allowed(resolved) {
+		return nil, fmt.Errorf("%s is not allowed by the configuration", resolved)
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", resolved)
+	}
+	return os.OpenFile(resolved, os.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK, 0)
+}
+
+func checkPeer(conn *net.UnixConn, uid int) error {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var cred *unix.Ucred
+	var credErr error
+	if err = raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return err
+	}
+	if credErr != nil {
+		return credErr
+	}
+	if cred.Uid != 0 && int(cred.Uid) != uid {
+		return fmt.Errorf("peer uid %d is not the agent user", cred.Uid)
+	}
+	return nil
+}
+
+// Open asks the helper named by EnvSocket to open name for reading and returns the received file.
+func Open(name string) (*os.File, error) {
+	socket := os.Getenv(EnvSocket)
+	if socket == "" {
+		return nil, ErrNotRunning
+	}
+	if len(name) >= maxRequestSize || strings.ContainsRune(name, '\n') {
+		return nil, fmt.Errorf("invalid file name %q", name)
+	}
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: socket, Net: "unix"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach privileged helper: %w", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(requestTimeout))
+	if _, err = conn.Write([]byte(name + "\n")); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, maxRequestSize)
+	oob := make([]byte, unix.CmsgSpace(4))
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, err
+	}
+	if reply := string(buf[:n]); reply != okReply {
+		return nil, fmt.Errorf("privileged helper: %s", reply)
+	}
+	msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(msgs) != 1 {
+		return nil, fmt.Errorf("privileged helper sent no file for %s", name)
+	}
+	fds, err := unix.ParseUnixRights(&msgs[0])
+	if err != nil || len(fds) != 1 {
+		return nil, fmt.Errorf("privileged helper sent no file for %s", name)
+	}
+	if err = unix.SetNonblock(fds[0], false); err != nil {
+		unix.Close(fds[0])
+		return nil, err
+	}
+	return os.NewFile(uintptr(fds[0]), name), nil
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+// +build linux
+
+package privhelper
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeAndOpen(t *testing.T) {
+	dir := t.TempDir()
+	logs := filepath.Join(dir, "logs")
+	require.NoError(t, os.Mkdir(logs, 0755))
+	allowed := filepath.Join(logs, "app.log")
+	require.NoError(t, os.WriteFile(allowed, []byte("hello\n"), 0600))
+	secret := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secret, []byte("secret\n"), 0600))
+	require.NoError(t, os.Symlink(secret, filepath.Join(logs, "link.log")))
+
+	allow, err := NewAllowlist([]string{filepath.Join(logs, "*.log")})
+	require.NoError(t, err)
+	socket := filepath.Join(dir, "helper.sock")
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: socket, Net: "unix"})
+	require.NoError(t, err)
+	done := make(chan error)
+	go func() { done <- Serve(l, os.Getuid(), allow) }()
+	t.Setenv(EnvSocket, socket)
+
+	f, err := Open(allowed)
+	require.NoError(t, err)
+	b, err := io.ReadAll(f)
+	f.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(b))
+
+	_, err = Open(secret)
+	assert.ErrorContains(t, err, "is not allowed")
+	_, err = Open(filepath.Join(logs, "link.log"))
+	assert.ErrorContains(t, err, "is not allowed")
+	_, err = Open(filepath.Join(logs, "missing.log"))
+	assert.Error(t, err)
+
+	require.NoError(t, l.Close())
+	assert.NoError(t, <-done)
+}
+
+func TestOpenWithoutHelper(t *testing.T) {
+	t.Setenv(EnvSocket, "")
+	_, err := Open("/var/log/secure")
+	assert.ErrorIs(t, err, ErrNotRunning)
+}
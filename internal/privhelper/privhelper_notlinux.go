@@ -0,0 +1,21 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+// +build !linux
+
+package privhelper
+
+import (
+	"errors"
+	"net"
+	"os"
+)
+
+func Serve(*net.UnixListener, int, *Allowlist) error {
+	return errors.ErrUnsupported
+}
+
+func Open(string) (*os.File, error) {
+	return nil, ErrNotRunning
+}
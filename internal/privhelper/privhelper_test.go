@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package privhelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowlist(t *testing.T) {
+	a, err := NewAllowlist([]string{"/var/log/secure", "/var/log/app/*.log", "/opt/**/audit.log"})
+	require.NoError(t, err)
+	assert.True(t, a.Allowed("/var/log/secure"))
+	assert.True(t, a.Allowed("/var/log/app/server.log"))
+	assert.True(t, a.Allowed("/var/log/app/../app/server.log"))
+	assert.True(t, a.Allowed("/opt/a/b/audit.log"))
+	assert.False(t, a.Allowed("/var/log/app/nested/server.log"))
+	assert.False(t, a.Allowed("/etc/shadow"))
+	assert.False(t, a.Allowed("var/log/secure"))
+
+	_, err = NewAllowlist([]string{"relative/*.log"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package retryer
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff is an exponential backoff with jitter. The wait doubles from Base with every retry, up to Max, and
+// is Max once Steps retries were made.
+type Backoff struct {
+	Base  time.Duration
+	Steps int
+	Max   time.Duration
+}
+
+// Delay returns how long to wait before the next retry, given the number of retries already made.
+func (b Backoff) Delay(retries int) time.Duration {
+	d := b.Max
+	if retries < b.Steps {
+		d = b.Base * time.Duration(1<<int64(retries))
+		if b.Max > 0 && d > b.Max {
+			d = b.Max
+		}
+	}
+	return WithJitter(d)
+}
+
+// WithJitter returns a random duration between half of d and d, so that the requests failing together are not
+// retried together.
+func WithJitter(d time.Duration) time.Duration {
+	if d < 2 {
+		return d
+	}
+	return time.Duration(rand.Int63n(int64(d/2)) + int64(d/2)) // nolint:gosec
+}
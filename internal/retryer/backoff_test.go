@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package retryer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Base: 200 * time.Millisecond, Steps: 5, Max: 2 * time.Second}
+	testCases := []struct {
+		retries int
+		want    time.Duration
+	}{
+		{retries: 0, want: 200 * time.Millisecond},
+		{retries: 1, want: 400 * time.Millisecond},
+		{retries: 3, want: 1600 * time.Millisecond},
+		// capped before the last step
+		{retries: 4, want: 2 * time.Second},
+		{retries: 5, want: 2 * time.Second},
+		{retries: 100, want: 2 * time.Second},
+	}
+	for _, testCase := range testCases {
+		for i := 0; i < 10; i++ {
+			got := b.Delay(testCase.retries)
+			assert.GreaterOrEqual(t, got, testCase.want/2, "retries %d", testCase.retries)
+			assert.Less(t, got, testCase.want, "retries %d", testCase.retries)
+		}
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	assert.Zero(t, WithJitter(0))
+	assert.Equal(t, time.Duration(1), WithJitter(1))
+	got := WithJitter(time.Minute)
+	assert.GreaterOrEqual(t, got, 30*time.Second)
+	assert.Less(t, got, time.Minute)
+}
@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package retryer
+
+import (
+	"sync"
+)
+
+// Budget limits the retries of an output to a share of its successful requests, so that an outage of the
+// service does not multiply the requests the agent sends to it. Every retry withdraws a token and every success
+// deposits a fraction of one, up to the capacity. The budget starts full.
+type Budget struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	ratio    float64
+}
+
+func NewBudget(capacity int, ratio float64) *Budget {
+	return &Budget{tokens: float64(capacity), capacity: float64(capacity), ratio: ratio}
+}
+
+// Withdraw takes a token for a retry and reports whether there was one left.
+func (b *Budget) Withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Deposit refills the budget after a successful request.
+func (b *Budget) Deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+b.ratio, b.capacity)
+}
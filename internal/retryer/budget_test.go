@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package retryer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget(t *testing.T) {
+	b := NewBudget(2, 0.5)
+	assert.True(t, b.Withdraw())
+	assert.True(t, b.Withdraw())
+	assert.False(t, b.Withdraw())
+
+	// two successes earn back a retry
+	b.Deposit()
+	assert.False(t, b.Withdraw())
+	b.Deposit()
+	assert.True(t, b.Withdraw())
+
+	// never more than the capacity
+	for i := 0; i < 10; i++ {
+		b.Deposit()
+	}
+	assert.True(t, b.Withdraw())
+	assert.True(t, b.Withdraw())
+	assert.False(t, b.Withdraw())
+}
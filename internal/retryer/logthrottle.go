@@ -60,6 +60,15 @@ func (r *LogThrottleRetryer) ShouldRetry(req *request.Request) bool {
 	return r.DefaultRetryer.ShouldRetry(req)
 }
 
+// RetryRules waits at least as long as the throttled response asked, up to the longest throttle delay of the SDK.
+func (r *LogThrottleRetryer) RetryRules(req *request.Request) time.Duration {
+	d := r.DefaultRetryer.RetryRules(req)
+	if after, ok := RetryAfter(req.Error); ok && after > d {
+		return min(after, client.DefaultRetryerMaxThrottleDelay)
+	}
+	return d
+}
+
 func (r *LogThrottleRetryer) Stop() {
 	if r != nil {
 		close(r.done)
@@ -0,0 +1,102 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package retryer
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+)
+
+const (
+	// defaultBudgetCapacity is the number of retries an output can make without any request succeeding.
+	defaultBudgetCapacity = 100
+	// defaultBudgetRatio is the share of a retry every successful request earns back.
+	defaultBudgetRatio = 0.1
+)
+
+var (
+	outputsMu sync.Mutex
+	outputs   = map[string]*outputRetries{}
+)
+
+// outputRetries is the state the policies of an output share.
+type outputRetries struct {
+	budget *Budget
+	stats  *selftelemetry.RetryStats
+}
+
+func retriesFor(output string) *outputRetries {
+	outputsMu.Lock()
+	defer outputsMu.Unlock()
+	r, ok := outputs[output]
+	if !ok {
+		r = &outputRetries{
+			budget: NewBudget(defaultBudgetCapacity, defaultBudgetRatio),
+			stats:  selftelemetry.Retries.Register(output),
+		}
+		outputs[output] = r
+	}
+	return r
+}
+
+// Policy decides how long the outputs wait before retrying a failed request to AWS. It waits for the backoff,
+// or for as long as the throttled response asked if that is longer. The policies of an output share a retry
+// budget, which only the throttled requests and the server errors spend: once they spent it, the retries wait the
+// longest delay of the backoff until enough requests succeed again, like an open circuit breaker, instead of adding
+// to the load of a failing service. A request failing on its own, e.g. to a missing log group, does not slow down
+// the retries of the others. The retries are counted in the self-telemetry of the output.
+type Policy struct {
+	backoff Backoff
+	*outputRetries
+}
+
+func NewPolicy(output string, backoff Backoff) *Policy {
+	return &Policy{backoff: backoff, outputRetries: retriesFor(output)}
+}
+
+// Wait returns how long to wait before retrying a request that failed with err, given the number of retries of
+// the request already made.
+func (p *Policy) Wait(retries int, err error) time.Duration {
+	d := p.backoff.Delay(retries)
+	if after, ok := RetryAfter(err); ok && after > d {
+		d = after
+	}
+	exhausted := overloaded(err) && !p.budget.Withdraw()
+	if exhausted {
+		d = max(d, WithJitter(p.backoff.Max))
+	}
+	p.stats.RecordRetry(err != nil && request.IsErrorThrottle(err), exhausted)
+	p.stats.RecordError(err)
+	return d
+}
+
+// overloaded returns true if err is a throttle or a server error, which means the service is failing for every
+// request rather than for the one that failed.
+func overloaded(err error) bool {
+	if err == nil {
+		return false
+	}
+	if request.IsErrorThrottle(err) {
+		return true
+	}
+	var failure interface{ StatusCode() int }
+	return errors.As(err, &failure) && (failure.StatusCode() == http.StatusTooManyRequests || failure.StatusCode() >= http.StatusInternalServerError)
+}
+
+// Succeeded refills the budget after a request succeeded.
+func (p *Policy) Succeeded() {
+	p.budget.Deposit()
+	p.stats.RecordSuccess()
+}
+
+// Dropped counts a request given up on after its retries failed.
+func (p *Policy) Dropped() {
+	p.stats.RecordDropped()
+}
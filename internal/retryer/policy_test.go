@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package retryer
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+)
+
+func findRetryStatus(output string) selftelemetry.RetryStatus {
+	for _, status := range selftelemetry.Retries.Statuses() {
+		if status.Output == output {
+			return status
+		}
+	}
+	return selftelemetry.RetryStatus{}
+}
+
+func TestPolicy(t *testing.T) {
+	backoff := Backoff{Base: time.Millisecond, Steps: 2, Max: time.Second}
+	p := NewPolicy(t.Name(), backoff)
+	assert.Same(t, p.outputRetries, NewPolicy(t.Name(), Backoff{}).outputRetries)
+	p.budget = NewBudget(1, 1)
+
+	// a request failing on its own does not spend the budget
+	assert.Less(t, p.Wait(0, errors.New("failed")), time.Millisecond)
+	assert.Less(t, p.Wait(0, errors.New("failed")), time.Millisecond)
+	throttled := retryAfterError{
+		RequestFailure: awserr.NewRequestFailure(awserr.New("ThrottlingException", "slow down", nil), http.StatusTooManyRequests, "id"),
+		after:          10 * time.Second,
+	}
+	assert.Equal(t, 10*time.Second, p.Wait(0, throttled))
+	unavailable := awserr.NewRequestFailure(awserr.New("ServiceUnavailable", "unavailable", nil), http.StatusServiceUnavailable, "id")
+	got := p.Wait(0, unavailable)
+	assert.GreaterOrEqual(t, got, 500*time.Millisecond)
+	assert.Less(t, got, time.Second)
+	// the budget is spent, but the throttled response asked for longer than the longest backoff
+	assert.Equal(t, 10*time.Second, p.Wait(0, throttled))
+	assert.Less(t, p.Wait(0, errors.New("failed")), time.Millisecond)
+
+	p.Succeeded()
+	assert.Less(t, p.Wait(0, unavailable), time.Millisecond)
+	assert.Less(t, p.Wait(0, errors.New("failed")), time.Millisecond)
+	p.Dropped()
+
+	assert.Equal(t, selftelemetry.RetryStatus{
+		Output:          t.Name(),
+		Retries:         8,
+		Throttled:       2,
+		BudgetExhausted: 2,
+		Dropped:         1,
+		LastError:       "failed",
+		LastErrorCode:   errcode.Unknown,
+		Unreachable:     true,
+	}, findRetryStatus(t.Name()))
+}
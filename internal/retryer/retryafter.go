@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package retryer
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const retryAfterHeader = "Retry-After"
+
+// RetryAfterHandler keeps the Retry-After header of a throttled or unavailable response on the error of the
+// request, so that the retries of the SDK and of the outputs wait at least as long as the service asked. Add it
+// to the UnmarshalError handlers of the client.
+var RetryAfterHandler = request.NamedHandler{
+	Name: "cwagent.RetryAfterHandler",
+	Fn: func(req *request.Request) {
+		if req.HTTPResponse == nil {
+			return
+		}
+		switch req.HTTPResponse.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		default:
+			return
+		}
+		var failure awserr.RequestFailure
+		if !errors.As(req.Error, &failure) {
+			return
+		}
+		if after, ok := parseRetryAfter(req.HTTPResponse.Header.Get(retryAfterHeader), time.Now()); ok {
+			req.Error = retryAfterError{RequestFailure: failure, after: after}
+		}
+	},
+}
+
+// retryAfterError is a request failure whose response asked to wait before retrying. It keeps the code and
+// status of the failure, so it is still recognized as a throttling error.
+type retryAfterError struct {
+	awserr.RequestFailure
+	after time.Duration
+}
+
+func (e retryAfterError) RetryAfter() time.Duration {
+	return e.after
+}
+
+func (e retryAfterError) Unwrap() error {
+	return e.RequestFailure
+}
+
+// RetryAfter returns how long the response of the failed request asked to wait before retrying, if it did.
+func RetryAfter(err error) (time.Duration, bool) {
+	var hint interface{ RetryAfter() time.Duration }
+	if !errors.As(err, &hint) {
+		return 0, false
+	}
+	return hint.RetryAfter(), true
+}
+
+// parseRetryAfter reads the header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(at.Sub(now), 0), true
+}
@@ -0,0 +1,91 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package retryer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		"Empty":      {value: ""},
+		"Seconds":    {value: " 5 ", want: 5 * time.Second, wantOK: true},
+		"Negative":   {value: "-5", want: 0, wantOK: true},
+		"Date":       {value: "Mon, 01 Jan 2024 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		"PastDate":   {value: "Mon, 01 Jan 2024 11:00:00 GMT", want: 0, wantOK: true},
+		"NotADate":   {value: "soon"},
+		"Fractional": {value: "1.5"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseRetryAfter(testCase.value, now)
+			assert.Equal(t, testCase.wantOK, ok)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestRetryAfterHandler(t *testing.T) {
+	newRequest := func(status int, retryAfter string, err error) *request.Request {
+		header := http.Header{}
+		if retryAfter != "" {
+			header.Set(retryAfterHeader, retryAfter)
+		}
+		return &request.Request{
+			HTTPResponse: &http.Response{StatusCode: status, Header: header},
+			Error:        err,
+		}
+	}
+	throttled := awserr.NewRequestFailure(awserr.New("ThrottlingException", "slow down", nil), http.StatusTooManyRequests, "id")
+
+	req := newRequest(http.StatusTooManyRequests, "3", throttled)
+	RetryAfterHandler.Fn(req)
+	after, ok := RetryAfter(fmt.Errorf("put failed: %w", req.Error))
+	require.True(t, ok)
+	assert.Equal(t, 3*time.Second, after)
+	assert.True(t, request.IsErrorThrottle(req.Error))
+	var failure awserr.RequestFailure
+	require.True(t, errors.As(req.Error, &failure))
+	assert.Equal(t, "ThrottlingException", failure.Code())
+	assert.Equal(t, http.StatusTooManyRequests, failure.StatusCode())
+
+	for name, req := range map[string]*request.Request{
+		"NoHeader":      newRequest(http.StatusTooManyRequests, "", throttled),
+		"OtherStatus":   newRequest(http.StatusBadRequest, "3", throttled),
+		"NotAWSFailure": newRequest(http.StatusServiceUnavailable, "3", errors.New("unavailable")),
+		"NoResponse":    {Error: throttled},
+	} {
+		t.Run(name, func(t *testing.T) {
+			RetryAfterHandler.Fn(req)
+			_, ok := RetryAfter(req.Error)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestLogThrottleRetryerRetryRules(t *testing.T) {
+	r := &LogThrottleRetryer{}
+	req := &request.Request{
+		Error: retryAfterError{
+			RequestFailure: awserr.NewRequestFailure(awserr.New("Throttling", "slow down", nil), http.StatusServiceUnavailable, "id"),
+			after:          time.Hour,
+		},
+		HTTPResponse: &http.Response{StatusCode: http.StatusServiceUnavailable},
+	}
+	assert.Equal(t, 300*time.Second, r.RetryRules(req))
+}
@@ -0,0 +1,131 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package schedule evaluates recurring time windows, such as the windows during which an input is allowed to collect.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is a recurring daily time range. Start and End are "HH:MM" wall clock times in Timezone
+// (the host's local time when empty). A window whose End is earlier than its Start runs past midnight,
+// and Days then refers to the day the window opens on. Empty Days means every day.
+type Window struct {
+	Days     []string `mapstructure:"days,omitempty" toml:"days"`
+	Start    string   `mapstructure:"start" toml:"start"`
+	End      string   `mapstructure:"end" toml:"end"`
+	Timezone string   `mapstructure:"timezone,omitempty" toml:"timezone"`
+}
+
+var dayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+type window struct {
+	days     [7]bool
+	start    time.Duration
+	end      time.Duration
+	location *time.Location
+}
+
+// Schedule is a compiled set of windows. A nil Schedule is always active.
+type Schedule struct {
+	windows []window
+}
+
+// Validate checks that every window can be compiled.
+func Validate(windows []Window) error {
+	_, err := New(windows)
+	return err
+}
+
+// New compiles the windows. It returns nil when there are no windows, meaning collection is never restricted.
+func New(windows []Window) (*Schedule, error) {
+	if len(windows) == 0 {
+		return nil, nil
+	}
+	s := &Schedule{windows: make([]window, 0, len(windows))}
+	for i, w := range windows {
+		compiled, err := compile(w)
+		if err != nil {
+			return nil, fmt.Errorf("window %d: %w", i, err)
+		}
+		s.windows = append(s.windows, compiled)
+	}
+	return s, nil
+}
+
+func compile(w Window) (window, error) {
+	var out window
+	var err error
+	if out.start, err = parseClock(w.Start); err != nil {
+		return out, fmt.Errorf("invalid start: %w", err)
+	}
+	if out.end, err = parseClock(w.End); err != nil {
+		return out, fmt.Errorf("invalid end: %w", err)
+	}
+	if out.start == out.end {
+		return out, errors.New("start and end must differ")
+	}
+	out.location = time.Local
+	if w.Timezone != "" {
+		if out.location, err = time.LoadLocation(w.Timezone); err != nil {
+			return out, fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+	if len(w.Days) == 0 {
+		out.days = [7]bool{true, true, true, true, true, true, true}
+	}
+	for _, d := range w.Days {
+		day, ok := dayNames[strings.ToLower(d)]
+		if !ok {
+			return out, fmt.Errorf("invalid day %q", d)
+		}
+		out.days[day] = true
+	}
+	return out, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Active reports whether t falls inside any window.
+func (s *Schedule) Active(t time.Time) bool {
+	if s == nil {
+		return true
+	}
+	for _, w := range s.windows {
+		if w.active(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w window) active(t time.Time) bool {
+	t = t.In(w.location)
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.start < w.end {
+		return w.days[t.Weekday()] && clock >= w.start && clock < w.end
+	}
+	// the window wraps past midnight: the tail belongs to the previous day's window
+	if clock >= w.start {
+		return w.days[t.Weekday()]
+	}
+	return clock < w.end && w.days[(t.Weekday()+6)%7]
+}
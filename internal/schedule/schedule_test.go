@@ -0,0 +1,60 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	s, err := New(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+	assert.True(t, s.Active(time.Now()))
+
+	for name, w := range map[string]Window{
+		"BadStart":    {Start: "9am", End: "17:00"},
+		"BadEnd":      {Start: "09:00", End: "25:00"},
+		"Empty":       {Start: "09:00", End: "09:00"},
+		"BadDay":      {Days: []string{"monday"}, Start: "09:00", End: "17:00"},
+		"BadTimezone": {Start: "09:00", End: "17:00", Timezone: "Mars/Olympus"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, Validate([]Window{w}))
+		})
+	}
+}
+
+func TestActive(t *testing.T) {
+	s, err := New([]Window{
+		{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", End: "17:00", Timezone: "UTC"},
+		{Days: []string{"Sat"}, Start: "22:00", End: "02:00", Timezone: "UTC"},
+	})
+	require.NoError(t, err)
+
+	for name, testCase := range map[string]struct {
+		at   string
+		want bool
+	}{
+		"WeekdayOpen":        {at: "2024-01-08T09:00:00Z", want: true},
+		"WeekdayBeforeClose": {at: "2024-01-08T16:59:59Z", want: true},
+		"WeekdayClose":       {at: "2024-01-08T17:00:00Z", want: false},
+		"WeekdayEarly":       {at: "2024-01-08T08:59:00Z", want: false},
+		"SaturdayNight":      {at: "2024-01-13T23:00:00Z", want: true},
+		"SundayEarly":        {at: "2024-01-14T01:30:00Z", want: true},
+		"SundayAfterWindow":  {at: "2024-01-14T02:00:00Z", want: false},
+		"SaturdayEarly":      {at: "2024-01-13T01:00:00Z", want: false},
+		"OtherTimezone":      {at: "2024-01-08T12:00:00-08:00", want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, testCase.at)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, s.Active(at))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const AttributeComponent = "component"
+
+var (
+	// Components tracks the failures of the receivers and exporters the agent isolates and restarts instead of
+	// crashing.
+	Components = newComponentRegistry()
+)
+
+type componentStats struct {
+	attrs attribute.Set
+
+	failures atomic.Int64
+	panics   atomic.Int64
+	restarts atomic.Int64
+
+	mu      sync.Mutex
+	lastErr string
+	failing bool
+}
+
+// ComponentStatus is a snapshot of the failures of one component.
+type ComponentStatus struct {
+	Component string `json:"component"`
+	// Failures is the number of times the component failed, including Panics.
+	Failures int64 `json:"failures"`
+	Panics   int64 `json:"panics"`
+	Restarts int64 `json:"restarts"`
+	// LastError is the last failure of the component.
+	LastError string `json:"last_error,omitempty"`
+	// Failing is true when the component has not succeeded since it last failed.
+	Failing bool `json:"failing"`
+}
+
+type componentRegistry struct {
+	mu         sync.RWMutex
+	components map[string]*componentStats
+}
+
+func newComponentRegistry() *componentRegistry {
+	return &componentRegistry{components: make(map[string]*componentStats)}
+}
+
+// RecordFailure counts a failure of the component, e.g. input:cpu, and whether it panicked. The method is safe to
+// call concurrently.
+func (r *componentRegistry) RecordFailure(component string, err error, panicked bool) {
+	s := r.stats(component)
+	s.failures.Add(1)
+	if panicked {
+		s.panics.Add(1)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastErr = err.Error()
+	s.failing = true
+}
+
+// RecordRestart counts a restart of the component after it failed.
+func (r *componentRegistry) RecordRestart(component string) {
+	r.stats(component).restarts.Add(1)
+}
+
+// RecordSuccess marks the component as no longer failing. Components that never failed are not tracked.
+func (r *componentRegistry) RecordSuccess(component string) {
+	r.mu.RLock()
+	s, ok := r.components[component]
+	r.mu.RUnlock()
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = false
+}
+
+func (r *componentRegistry) stats(component string) *componentStats {
+	r.mu.RLock()
+	s, ok := r.components[component]
+	r.mu.RUnlock()
+	if ok {
+		return s
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok = r.components[component]; !ok {
+		s = &componentStats{attrs: attribute.NewSet(attribute.String(AttributeComponent, component))}
+		r.components[component] = s
+	}
+	return s
+}
+
+// Statuses returns a snapshot of every component that failed sorted by name.
+func (r *componentRegistry) Statuses() []ComponentStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	statuses := make([]ComponentStatus, 0, len(r.components))
+	for component, s := range r.components {
+		s.mu.Lock()
+		statuses = append(statuses, ComponentStatus{
+			Component: component,
+			Failures:  s.failures.Load(),
+			Panics:    s.panics.Load(),
+			Restarts:  s.restarts.Load(),
+			LastError: s.lastErr,
+			Failing:   s.failing,
+		})
+		s.mu.Unlock()
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Component < statuses[j].Component })
+	return statuses
+}
+
+// RegisterMetrics reports the failures of every component with the provider. Unregister the returned registration
+// when the provider shuts down.
+func (r *componentRegistry) RegisterMetrics(mp metric.MeterProvider) (metric.Registration, error) {
+	meter := mp.Meter(scopeName)
+	failures, err := meter.Int64ObservableCounter("component_failures",
+		metric.WithDescription("Number of times a receiver or exporter failed or panicked"),
+		metric.WithUnit("{failures}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	restarts, err := meter.Int64ObservableCounter("component_restarts",
+		metric.WithDescription("Number of times a receiver or exporter was restarted after it failed"),
+		metric.WithUnit("{restarts}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		for _, s := range r.components {
+			attrs := metric.WithAttributeSet(s.attrs)
+			o.ObserveInt64(failures, s.failures.Load(), attrs)
+			o.ObserveInt64(restarts, s.restarts.Load(), attrs)
+		}
+		return nil
+	}, failures, restarts)
+}
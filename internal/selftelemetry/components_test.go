@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestComponents(t *testing.T) {
+	r := newComponentRegistry()
+	r.RecordSuccess("input:cpu")
+	r.RecordFailure("input:cpu", errors.New("panic: nil map"), true)
+	r.RecordRestart("input:cpu")
+	r.RecordFailure("output:cloudwatch/publish", errors.New("timeout"), false)
+	r.RecordSuccess("output:cloudwatch/publish")
+
+	assert.Equal(t, []ComponentStatus{
+		{Component: "input:cpu", Failures: 1, Panics: 1, Restarts: 1, LastError: "panic: nil map", Failing: true},
+		{Component: "output:cloudwatch/publish", Failures: 1, LastError: "timeout"},
+	}, r.Statuses())
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	registration, err := r.RegisterMetrics(mp)
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		data, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		assert.Len(t, data.DataPoints, 2)
+		for _, point := range data.DataPoints {
+			got[m.Name] += point.Value
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"component_failures": 2,
+		"component_restarts": 1,
+	}, got)
+	assert.NoError(t, registration.Unregister())
+}
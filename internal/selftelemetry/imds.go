@@ -0,0 +1,132 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const AttributeOperation = "operation"
+
+var (
+	// IMDS tracks the calls the components make to the EC2 instance metadata service.
+	IMDS = newIMDSRegistry()
+)
+
+type imdsStats struct {
+	attrs attribute.Set
+
+	calls     atomic.Int64
+	failures  atomic.Int64
+	fallbacks atomic.Int64
+}
+
+// IMDSStatus is a snapshot of the calls of one operation.
+type IMDSStatus struct {
+	Operation string `json:"operation"`
+	Calls     int64  `json:"calls"`
+	// Failures is the number of calls that failed, including after falling back to IMDSv1.
+	Failures int64 `json:"failures"`
+	// Fallbacks is the number of calls made with IMDSv1.
+	Fallbacks int64 `json:"fallbacks"`
+}
+
+type imdsRegistry struct {
+	mu         sync.RWMutex
+	operations map[string]*imdsStats
+}
+
+func newIMDSRegistry() *imdsRegistry {
+	return &imdsRegistry{operations: make(map[string]*imdsStats)}
+}
+
+// Record counts a call of the operation, e.g. hostname, and whether it fell back to IMDSv1 or failed. The method is
+// safe to call concurrently.
+func (r *imdsRegistry) Record(operation string, fallback bool, err error) {
+	s := r.stats(operation)
+	s.calls.Add(1)
+	if fallback {
+		s.fallbacks.Add(1)
+	}
+	if err != nil {
+		s.failures.Add(1)
+	}
+}
+
+func (r *imdsRegistry) stats(operation string) *imdsStats {
+	r.mu.RLock()
+	s, ok := r.operations[operation]
+	r.mu.RUnlock()
+	if ok {
+		return s
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok = r.operations[operation]; !ok {
+		s = &imdsStats{attrs: attribute.NewSet(attribute.String(AttributeOperation, operation))}
+		r.operations[operation] = s
+	}
+	return s
+}
+
+// Statuses returns a snapshot of every operation sorted by name.
+func (r *imdsRegistry) Statuses() []IMDSStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	statuses := make([]IMDSStatus, 0, len(r.operations))
+	for operation, s := range r.operations {
+		statuses = append(statuses, IMDSStatus{
+			Operation: operation,
+			Calls:     s.calls.Load(),
+			Failures:  s.failures.Load(),
+			Fallbacks: s.fallbacks.Load(),
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Operation < statuses[j].Operation })
+	return statuses
+}
+
+// RegisterMetrics reports the calls of every operation with the provider. Unregister the returned registration
+// when the provider shuts down.
+func (r *imdsRegistry) RegisterMetrics(mp metric.MeterProvider) (metric.Registration, error) {
+	meter := mp.Meter(scopeName)
+	calls, err := meter.Int64ObservableCounter("imds_calls",
+		metric.WithDescription("Number of calls to the EC2 instance metadata service"),
+		metric.WithUnit("{calls}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	failures, err := meter.Int64ObservableCounter("imds_call_failures",
+		metric.WithDescription("Number of calls to the EC2 instance metadata service that failed"),
+		metric.WithUnit("{calls}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	fallbacks, err := meter.Int64ObservableCounter("imds_v1_fallbacks",
+		metric.WithDescription("Number of calls to the EC2 instance metadata service made with IMDSv1"),
+		metric.WithUnit("{calls}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		for _, s := range r.operations {
+			attrs := metric.WithAttributeSet(s.attrs)
+			o.ObserveInt64(calls, s.calls.Load(), attrs)
+			o.ObserveInt64(failures, s.failures.Load(), attrs)
+			o.ObserveInt64(fallbacks, s.fallbacks.Load(), attrs)
+		}
+		return nil
+	}, calls, failures, fallbacks)
+}
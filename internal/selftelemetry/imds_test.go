@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestIMDS(t *testing.T) {
+	r := newIMDSRegistry()
+	r.Record("hostname", false, nil)
+	r.Record("hostname", true, nil)
+	r.Record("instance_tags", true, errors.New("timeout"))
+
+	assert.Equal(t, []IMDSStatus{
+		{Operation: "hostname", Calls: 2, Fallbacks: 1},
+		{Operation: "instance_tags", Calls: 1, Failures: 1, Fallbacks: 1},
+	}, r.Statuses())
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	registration, err := r.RegisterMetrics(mp)
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		data, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		assert.Len(t, data.DataPoints, 2)
+		for _, point := range data.DataPoints {
+			got[m.Name] += point.Value
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"imds_calls":         3,
+		"imds_call_failures": 1,
+		"imds_v1_fallbacks":  2,
+	}, got)
+	assert.NoError(t, registration.Unregister())
+}
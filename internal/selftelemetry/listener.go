@@ -0,0 +1,88 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const (
+	scopeName = "github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+
+	AttributeReceiver  = "receiver"
+	AttributeTransport = "transport"
+	AttributeReason    = "reason"
+
+	// ReasonDropped is used when a request was received but discarded before it could be processed.
+	ReasonDropped = "dropped"
+	// ReasonInvalid is used when a request could not be parsed.
+	ReasonInvalid = "invalid"
+	// ReasonTooLarge is used when a request was dropped for exceeding a size limit.
+	ReasonTooLarge = "too_large"
+)
+
+// Listener records the requests received by a network listener that is not instrumented by the collector, e.g.
+// the telegraf statsd input. The metrics line up with the http.server.* and rpc.server.* metrics the collector
+// records for the OTLP receivers, so operators can check whether applications are reaching the agent at all.
+type Listener struct {
+	attrs    metric.MeasurementOption
+	requests metric.Int64Counter
+	size     metric.Int64Counter
+	errors   metric.Int64Counter
+}
+
+// NewListener creates the instruments with the given provider. A nil provider records nothing.
+func NewListener(mp metric.MeterProvider, receiver, transport string) (*Listener, error) {
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+	meter := mp.Meter(scopeName)
+	l := &Listener{
+		attrs: metric.WithAttributeSet(attribute.NewSet(
+			attribute.String(AttributeReceiver, receiver),
+			attribute.String(AttributeTransport, transport),
+		)),
+	}
+	var err error
+	if l.requests, err = meter.Int64Counter("receiver_requests",
+		metric.WithDescription("Number of requests or packets received by the listener"),
+		metric.WithUnit("{requests}"),
+	); err != nil {
+		return nil, err
+	}
+	if l.size, err = meter.Int64Counter("receiver_request_size",
+		metric.WithDescription("Number of bytes received by the listener"),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, err
+	}
+	if l.errors, err = meter.Int64Counter("receiver_errors",
+		metric.WithDescription("Number of requests the listener failed to read, parse or queue"),
+		metric.WithUnit("{requests}"),
+	); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Record counts one request of the given size.
+func (l *Listener) Record(ctx context.Context, size int) {
+	if l == nil {
+		return
+	}
+	l.requests.Add(ctx, 1, l.attrs)
+	l.size.Add(ctx, int64(size), l.attrs)
+}
+
+// RecordError counts one failed request.
+func (l *Listener) RecordError(ctx context.Context, reason string) {
+	if l == nil {
+		return
+	}
+	l.errors.Add(ctx, 1, l.attrs, metric.WithAttributes(attribute.String(AttributeReason, reason)))
+}
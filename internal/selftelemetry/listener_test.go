@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestListener(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	l, err := NewListener(mp, "telegraf_statsd", "udp")
+	require.NoError(t, err)
+	ctx := context.Background()
+	l.Record(ctx, 10)
+	l.Record(ctx, 5)
+	l.RecordError(ctx, ReasonInvalid)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := map[string]metricdata.Sum[int64]{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m.Data.(metricdata.Sum[int64])
+	}
+	require.Len(t, got, 3)
+	assert.EqualValues(t, 2, got["receiver_requests"].DataPoints[0].Value)
+	assert.EqualValues(t, 15, got["receiver_request_size"].DataPoints[0].Value)
+	errPoint := got["receiver_errors"].DataPoints[0]
+	assert.EqualValues(t, 1, errPoint.Value)
+	reason, ok := errPoint.Attributes.Value(AttributeReason)
+	assert.True(t, ok)
+	assert.Equal(t, attribute.StringValue(ReasonInvalid), reason)
+	receiver, _ := errPoint.Attributes.Value(AttributeReceiver)
+	assert.Equal(t, "telegraf_statsd", receiver.AsString())
+}
+
+func TestListenerNil(t *testing.T) {
+	var l *Listener
+	l.Record(context.Background(), 1)
+	l.RecordError(context.Background(), ReasonDropped)
+	l, err := NewListener(nil, "telegraf_statsd", "udp")
+	require.NoError(t, err)
+	l.Record(context.Background(), 1)
+}
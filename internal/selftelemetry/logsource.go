@@ -0,0 +1,304 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	AttributeLogSource = "log_source"
+	AttributeLogGroup  = "log_group"
+	AttributeLogStream = "log_stream"
+	AttributeDetector  = "detector"
+)
+
+var (
+	// LogSources tracks the progress of every log file the agent is tailing.
+	LogSources = newLogSourceRegistry()
+)
+
+// LogSource records how far the agent is through one log file. The methods are safe to call from the tailer
+// while the stats are read by the control socket or the collector's metric readers.
+type LogSource struct {
+	name   string
+	path   string
+	group  string
+	stream string
+	attrs  attribute.Set
+	refs   int
+
+	bytesRead     atomic.Int64
+	offset        atomic.Int64
+	lastEvent     atomic.Int64
+	lastEventTime atomic.Int64
+	dropped       atomic.Int64
+	parseFailures atomic.Int64
+
+	detectionsMu sync.Mutex
+	detections   map[string]int64
+}
+
+// LogSourceStatus is a snapshot of a LogSource served by the control socket.
+type LogSourceStatus struct {
+	Name      string `json:"name"`
+	LogGroup  string `json:"log_group"`
+	LogStream string `json:"log_stream"`
+	// BytesRead is the number of bytes read from the file since the agent started.
+	BytesRead int64 `json:"bytes_read"`
+	// LagBytes is the size of the file past the offset the agent has read up to.
+	LagBytes int64 `json:"lag_bytes"`
+	// LagSeconds is how much older the last event read is than the last write to the file, or 0 once the agent
+	// has caught up.
+	LagSeconds float64 `json:"lag_seconds"`
+	// LastEvent is when the agent last emitted an event for the file. It is not set until the first event.
+	LastEvent *time.Time `json:"last_event,omitempty"`
+	// LastEventAgeSeconds is the time since LastEvent, or -1 before the first event.
+	LastEventAgeSeconds float64 `json:"last_event_age_seconds"`
+	EventsDropped       int64   `json:"events_dropped"`
+	ParseFailures       int64   `json:"parse_failures"`
+	// SensitiveData is the number of events each sensitive data detector found sensitive data in.
+	SensitiveData map[string]int64 `json:"sensitive_data,omitempty"`
+}
+
+// AddRead counts the bytes of a line read from the file and moves the read offset.
+func (s *LogSource) AddRead(bytes int, offset int64) {
+	if s == nil {
+		return
+	}
+	s.bytesRead.Add(int64(bytes))
+	s.offset.Store(offset)
+}
+
+// RecordEvent marks an event as emitted at now. The timestamp is the one of the event if it was parsed from the
+// log line, and is used to work out the lag.
+func (s *LogSource) RecordEvent(now time.Time, timestamp time.Time) {
+	if s == nil {
+		return
+	}
+	if timestamp.IsZero() {
+		timestamp = now
+	}
+	s.lastEvent.Store(now.UnixNano())
+	s.lastEventTime.Store(timestamp.UnixNano())
+}
+
+// RecordDropped counts an event that was read but not sent, e.g. because it was sampled out during a burst.
+func (s *LogSource) RecordDropped() {
+	if s == nil {
+		return
+	}
+	s.dropped.Add(1)
+}
+
+// RecordParseFailure counts an event whose timestamp could not be parsed with the configured format.
+func (s *LogSource) RecordParseFailure() {
+	if s == nil {
+		return
+	}
+	s.parseFailures.Add(1)
+}
+
+// RecordDetection counts an event the named sensitive data detector found sensitive data in.
+func (s *LogSource) RecordDetection(detector string) {
+	if s == nil {
+		return
+	}
+	s.detectionsMu.Lock()
+	defer s.detectionsMu.Unlock()
+	if s.detections == nil {
+		s.detections = make(map[string]int64)
+	}
+	s.detections[detector]++
+}
+
+func (s *LogSource) status(now time.Time) LogSourceStatus {
+	status := LogSourceStatus{
+		Name:                s.name,
+		LogGroup:            s.group,
+		LogStream:           s.stream,
+		BytesRead:           s.bytesRead.Load(),
+		LastEventAgeSeconds: -1,
+		EventsDropped:       s.dropped.Load(),
+		ParseFailures:       s.parseFailures.Load(),
+	}
+	s.detectionsMu.Lock()
+	if len(s.detections) > 0 {
+		status.SensitiveData = make(map[string]int64, len(s.detections))
+		for detector, count := range s.detections {
+			status.SensitiveData[detector] = count
+		}
+	}
+	s.detectionsMu.Unlock()
+	if lastEvent := s.lastEvent.Load(); lastEvent != 0 {
+		t := time.Unix(0, lastEvent)
+		status.LastEvent = &t
+		status.LastEventAgeSeconds = now.Sub(t).Seconds()
+	}
+	// the file is checked on demand so the tailer does not have to stat it after every line
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return status
+	}
+	// a file truncated or rotated since the last read is not counted as lag
+	if lag := info.Size() - s.offset.Load(); lag > 0 {
+		status.LagBytes = lag
+		if eventTime := s.lastEventTime.Load(); eventTime != 0 {
+			status.LagSeconds = max(info.ModTime().Sub(time.Unix(0, eventTime)).Seconds(), 0)
+		}
+	}
+	return status
+}
+
+type logSourceRegistry struct {
+	mu      sync.RWMutex
+	sources map[string]*LogSource
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+func newLogSourceRegistry() *logSourceRegistry {
+	return &logSourceRegistry{sources: make(map[string]*LogSource), now: time.Now}
+}
+
+// Register returns the stats of the named source, creating them if needed. The same file tailed twice shares
+// its stats. Call Unregister when the tailer stops.
+func (r *logSourceRegistry) Register(name, path, group, stream string) *LogSource {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sources[name]
+	if !ok {
+		s = &LogSource{
+			name:   name,
+			path:   path,
+			group:  group,
+			stream: stream,
+			attrs: attribute.NewSet(
+				attribute.String(AttributeLogSource, name),
+				attribute.String(AttributeLogGroup, group),
+				attribute.String(AttributeLogStream, stream),
+			),
+		}
+		r.sources[name] = s
+	}
+	s.refs++
+	return s
+}
+
+// Unregister removes a source added with Register.
+func (r *logSourceRegistry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sources[name]
+	if !ok {
+		return
+	}
+	if s.refs <= 1 {
+		delete(r.sources, name)
+	} else {
+		s.refs--
+	}
+}
+
+// Statuses returns a snapshot of every registered source sorted by name.
+func (r *logSourceRegistry) Statuses() []LogSourceStatus {
+	now := r.now()
+	r.mu.RLock()
+	sources := make([]*LogSource, 0, len(r.sources))
+	for _, s := range r.sources {
+		sources = append(sources, s)
+	}
+	r.mu.RUnlock()
+	statuses := make([]LogSourceStatus, 0, len(sources))
+	for _, s := range sources {
+		statuses = append(statuses, s.status(now))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// RegisterMetrics reports the stats of every registered source with the provider, so they are served with the
+// rest of the agent's self-telemetry. Unregister the returned registration when the provider shuts down.
+func (r *logSourceRegistry) RegisterMetrics(mp metric.MeterProvider) (metric.Registration, error) {
+	meter := mp.Meter(scopeName)
+	bytesRead, err := meter.Int64ObservableCounter("logfile_bytes_read",
+		metric.WithDescription("Number of bytes read from the log file"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	lagBytes, err := meter.Int64ObservableGauge("logfile_lag_bytes",
+		metric.WithDescription("Number of bytes written to the log file that have not been read yet"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	lagSeconds, err := meter.Float64ObservableGauge("logfile_lag_seconds",
+		metric.WithDescription("How much older the last event read is than the last write to the log file"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	lastEventAge, err := meter.Float64ObservableGauge("logfile_last_event_age",
+		metric.WithDescription("Time since the last event was emitted for the log file"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	dropped, err := meter.Int64ObservableCounter("logfile_events_dropped",
+		metric.WithDescription("Number of events read from the log file that were not sent"),
+		metric.WithUnit("{events}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	parseFailures, err := meter.Int64ObservableCounter("logfile_parse_failures",
+		metric.WithDescription("Number of events whose timestamp could not be parsed"),
+		metric.WithUnit("{events}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	sensitiveData, err := meter.Int64ObservableCounter("logfile_sensitive_data_detected",
+		metric.WithDescription("Number of events a sensitive data detector found sensitive data in"),
+		metric.WithUnit("{events}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		now := r.now()
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		for _, s := range r.sources {
+			status := s.status(now)
+			attrs := metric.WithAttributeSet(s.attrs)
+			o.ObserveInt64(bytesRead, status.BytesRead, attrs)
+			o.ObserveInt64(lagBytes, status.LagBytes, attrs)
+			o.ObserveFloat64(lagSeconds, status.LagSeconds, attrs)
+			// a source without events has no age, which is better left out than reported as 0
+			if status.LastEvent != nil {
+				o.ObserveFloat64(lastEventAge, status.LastEventAgeSeconds, attrs)
+			}
+			o.ObserveInt64(dropped, status.EventsDropped, attrs)
+			o.ObserveInt64(parseFailures, status.ParseFailures, attrs)
+			for detector, count := range status.SensitiveData {
+				o.ObserveInt64(sensitiveData, count, metric.WithAttributes(append(s.attrs.ToSlice(), attribute.String(AttributeDetector, detector))...))
+			}
+		}
+		return nil
+	}, bytesRead, lagBytes, lagSeconds, lastEventAge, dropped, parseFailures, sensitiveData)
+}
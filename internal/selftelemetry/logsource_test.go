@@ -0,0 +1,123 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestLogSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, os.WriteFile(path, []byte("line 1\nline 2\nline 3\n"), 0600))
+	modTime := time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	now := modTime.Add(time.Minute)
+
+	r := newLogSourceRegistry()
+	r.now = func() time.Time { return now }
+	s := r.Register("logfile:"+path, path, "group", "stream")
+	assert.Same(t, s, r.Register("logfile:"+path, path, "group", "stream"))
+
+	statuses := r.Statuses()
+	require.Len(t, statuses, 1)
+	assert.Nil(t, statuses[0].LastEvent)
+	assert.EqualValues(t, -1, statuses[0].LastEventAgeSeconds)
+	assert.EqualValues(t, 21, statuses[0].LagBytes)
+
+	s.AddRead(7, 7)
+	s.RecordEvent(modTime.Add(10*time.Second), modTime.Add(-10*time.Second))
+	s.RecordDropped()
+	s.RecordParseFailure()
+	s.RecordDetection("pan")
+	s.RecordDetection("pan")
+	statuses = r.Statuses()
+	require.Len(t, statuses, 1)
+	got := statuses[0]
+	assert.Equal(t, "group", got.LogGroup)
+	assert.Equal(t, "stream", got.LogStream)
+	assert.EqualValues(t, 7, got.BytesRead)
+	assert.EqualValues(t, 14, got.LagBytes)
+	assert.EqualValues(t, 10, got.LagSeconds)
+	require.NotNil(t, got.LastEvent)
+	assert.EqualValues(t, 50, got.LastEventAgeSeconds)
+	assert.EqualValues(t, 1, got.EventsDropped)
+	assert.EqualValues(t, 1, got.ParseFailures)
+	assert.Equal(t, map[string]int64{"pan": 2}, got.SensitiveData)
+
+	// caught up
+	s.AddRead(14, 21)
+	got = r.Statuses()[0]
+	assert.EqualValues(t, 21, got.BytesRead)
+	assert.Zero(t, got.LagBytes)
+	assert.Zero(t, got.LagSeconds)
+
+	r.Unregister("logfile:" + path)
+	assert.Len(t, r.Statuses(), 1)
+	r.Unregister("logfile:" + path)
+	assert.Empty(t, r.Statuses())
+}
+
+func TestLogSourcesMetrics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, os.WriteFile(path, []byte("line 1\nline 2\n"), 0600))
+	r := newLogSourceRegistry()
+	s := r.Register("logfile:"+path, path, "group", "stream")
+	r.Register("logfile:/missing.log", "/missing.log", "group", "stream")
+	s.AddRead(7, 7)
+	s.RecordEvent(time.Now(), time.Time{})
+	s.RecordDetection("secret")
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	registration, err := r.RegisterMetrics(mp)
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := map[string]int{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			got[m.Name] = len(data.DataPoints)
+			if m.Name == "logfile_bytes_read" {
+				for _, point := range data.DataPoints {
+					source, _ := point.Attributes.Value(AttributeLogSource)
+					if source.AsString() == "logfile:"+path {
+						assert.EqualValues(t, 7, point.Value)
+					}
+				}
+			}
+		case metricdata.Gauge[int64]:
+			got[m.Name] = len(data.DataPoints)
+			for _, point := range data.DataPoints {
+				source, _ := point.Attributes.Value(AttributeLogSource)
+				if source.AsString() == "logfile:"+path {
+					assert.EqualValues(t, 7, point.Value)
+				}
+			}
+		case metricdata.Gauge[float64]:
+			got[m.Name] = len(data.DataPoints)
+		}
+	}
+	assert.Equal(t, map[string]int{
+		"logfile_bytes_read":              2,
+		"logfile_lag_bytes":               2,
+		"logfile_lag_seconds":             2,
+		"logfile_last_event_age":          1,
+		"logfile_events_dropped":          2,
+		"logfile_parse_failures":          2,
+		"logfile_sensitive_data_detected": 1,
+	}, got)
+	assert.NoError(t, registration.Unregister())
+}
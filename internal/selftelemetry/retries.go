@@ -0,0 +1,203 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
+)
+
+const AttributeOutput = "output"
+
+var (
+	// Retries tracks the retries of the requests every output sends to AWS.
+	Retries = newRetryRegistry()
+)
+
+// RetryStats counts the retries of the requests of one output. The methods are safe to call concurrently.
+type RetryStats struct {
+	output string
+	attrs  attribute.Set
+
+	retries         atomic.Int64
+	throttled       atomic.Int64
+	budgetExhausted atomic.Int64
+	dropped         atomic.Int64
+
+	// mu guards the state of the output since a request last succeeded.
+	mu          sync.Mutex
+	lastErr     error
+	unreachable bool
+}
+
+// RetryStatus is a snapshot of a RetryStats served by the control socket.
+type RetryStatus struct {
+	Output string `json:"output"`
+	// Retries is the number of requests that failed and were retried.
+	Retries int64 `json:"retries"`
+	// Throttled is the number of the retries for a throttled request.
+	Throttled int64 `json:"throttled"`
+	// BudgetExhausted is the number of the retries made once the retry budget of the output was spent, which
+	// waited the longest backoff.
+	BudgetExhausted int64 `json:"budget_exhausted"`
+	// Dropped is the number of requests given up on after their retries failed.
+	Dropped int64 `json:"dropped"`
+	// LastError is the last error of a request since a request last succeeded.
+	LastError string `json:"last_error,omitempty"`
+	// LastErrorCode is the code of LastError, e.g. CREDENTIAL_ERROR.
+	LastErrorCode errcode.Code `json:"last_error_code,omitempty"`
+	// Unreachable is set when a request was given up on since a request last succeeded.
+	Unreachable bool `json:"unreachable"`
+}
+
+// RecordRetry counts a retry of a failed request.
+func (s *RetryStats) RecordRetry(throttled, budgetExhausted bool) {
+	if s == nil {
+		return
+	}
+	s.retries.Add(1)
+	if throttled {
+		s.throttled.Add(1)
+	}
+	if budgetExhausted {
+		s.budgetExhausted.Add(1)
+	}
+}
+
+// RecordError keeps the error of a failed request until a request succeeds.
+func (s *RetryStats) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastErr = err
+}
+
+// RecordDropped counts a request given up on. The output is unreachable until a request succeeds.
+func (s *RetryStats) RecordDropped() {
+	if s == nil {
+		return
+	}
+	s.dropped.Add(1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unreachable = true
+}
+
+// RecordSuccess clears the error and the unreachable state of the output.
+func (s *RetryStats) RecordSuccess() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastErr = nil
+	s.unreachable = false
+}
+
+func (s *RetryStats) status() RetryStatus {
+	status := RetryStatus{
+		Output:          s.output,
+		Retries:         s.retries.Load(),
+		Throttled:       s.throttled.Load(),
+		BudgetExhausted: s.budgetExhausted.Load(),
+		Dropped:         s.dropped.Load(),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastErr != nil {
+		status.LastError = s.lastErr.Error()
+		status.LastErrorCode = errcode.Classify(s.lastErr)
+	}
+	status.Unreachable = s.unreachable
+	return status
+}
+
+type retryRegistry struct {
+	mu      sync.RWMutex
+	outputs map[string]*RetryStats
+}
+
+func newRetryRegistry() *retryRegistry {
+	return &retryRegistry{outputs: make(map[string]*RetryStats)}
+}
+
+// Register returns the stats of the named output, creating them if needed.
+func (r *retryRegistry) Register(output string) *RetryStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.outputs[output]
+	if !ok {
+		s = &RetryStats{output: output, attrs: attribute.NewSet(attribute.String(AttributeOutput, output))}
+		r.outputs[output] = s
+	}
+	return s
+}
+
+// Statuses returns a snapshot of every registered output sorted by name.
+func (r *retryRegistry) Statuses() []RetryStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	statuses := make([]RetryStatus, 0, len(r.outputs))
+	for _, s := range r.outputs {
+		statuses = append(statuses, s.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Output < statuses[j].Output })
+	return statuses
+}
+
+// RegisterMetrics reports the stats of every registered output with the provider. Unregister the returned
+// registration when the provider shuts down.
+func (r *retryRegistry) RegisterMetrics(mp metric.MeterProvider) (metric.Registration, error) {
+	meter := mp.Meter(scopeName)
+	retries, err := meter.Int64ObservableCounter("output_retries",
+		metric.WithDescription("Number of failed requests to AWS that were retried"),
+		metric.WithUnit("{requests}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	throttled, err := meter.Int64ObservableCounter("output_retries_throttled",
+		metric.WithDescription("Number of retries of requests to AWS that were throttled"),
+		metric.WithUnit("{requests}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	budgetExhausted, err := meter.Int64ObservableCounter("output_retry_budget_exhausted",
+		metric.WithDescription("Number of retries made once the retry budget of the output was spent"),
+		metric.WithUnit("{requests}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	dropped, err := meter.Int64ObservableCounter("output_requests_dropped",
+		metric.WithDescription("Number of requests to AWS given up on after their retries failed"),
+		metric.WithUnit("{requests}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		for _, s := range r.outputs {
+			status := s.status()
+			attrs := metric.WithAttributeSet(s.attrs)
+			o.ObserveInt64(retries, status.Retries, attrs)
+			o.ObserveInt64(throttled, status.Throttled, attrs)
+			o.ObserveInt64(budgetExhausted, status.BudgetExhausted, attrs)
+			o.ObserveInt64(dropped, status.Dropped, attrs)
+		}
+		return nil
+	}, retries, throttled, budgetExhausted, dropped)
+}
@@ -0,0 +1,84 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
+)
+
+func TestRetries(t *testing.T) {
+	r := newRetryRegistry()
+	logs := r.Register("cloudwatchlogs")
+	assert.Same(t, logs, r.Register("cloudwatchlogs"))
+	metrics := r.Register("cloudwatch")
+
+	logs.RecordRetry(false, false)
+	logs.RecordRetry(true, false)
+	logs.RecordRetry(true, true)
+	logs.RecordDropped()
+	metrics.RecordRetry(false, false)
+	var nilStats *RetryStats
+	nilStats.RecordRetry(true, true)
+	nilStats.RecordDropped()
+
+	assert.Equal(t, []RetryStatus{
+		{Output: "cloudwatch", Retries: 1},
+		{Output: "cloudwatchlogs", Retries: 3, Throttled: 2, BudgetExhausted: 1, Dropped: 1, Unreachable: true},
+	}, r.Statuses())
+}
+
+func TestRetriesLastError(t *testing.T) {
+	r := newRetryRegistry()
+	logs := r.Register("cloudwatchlogs")
+
+	logs.RecordError(awserr.New("NoCredentialProviders", "no valid providers in chain", nil))
+	logs.RecordDropped()
+	status := r.Statuses()[0]
+	assert.Equal(t, errcode.Credential, status.LastErrorCode)
+	assert.Contains(t, status.LastError, "no valid providers in chain")
+	assert.True(t, status.Unreachable)
+
+	logs.RecordSuccess()
+	assert.Equal(t, []RetryStatus{{Output: "cloudwatchlogs", Dropped: 1}}, r.Statuses())
+}
+
+func TestRetriesMetrics(t *testing.T) {
+	r := newRetryRegistry()
+	r.Register("cloudwatch").RecordRetry(true, false)
+	r.Register("cloudwatchlogs").RecordDropped()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	registration, err := r.RegisterMetrics(mp)
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		data, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		assert.Len(t, data.DataPoints, 2)
+		for _, point := range data.DataPoints {
+			got[m.Name] += point.Value
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"output_retries":                1,
+		"output_retries_throttled":      1,
+		"output_retry_budget_exhausted": 0,
+		"output_requests_dropped":       1,
+	}, got)
+	assert.NoError(t, registration.Unregister())
+}
@@ -0,0 +1,225 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+)
+
+// DefaultExportLagThreshold is how long the export of a pipeline can lag its collection before a gap is reported,
+// unless agent.export_lag_threshold is set. It is above the flush intervals of the outputs.
+const DefaultExportLagThreshold = 5 * time.Minute
+
+var (
+	// Watermarks tracks the timestamps of the data collected and exported by every pipeline to detect the stalls of
+	// their export, e.g. a wedged exporter.
+	Watermarks = newWatermarkRegistry(exportLagThreshold())
+)
+
+// exportLagThreshold reads CWAGENT_EXPORT_LAG_THRESHOLD, which is in seconds.
+func exportLagThreshold() time.Duration {
+	if seconds, err := strconv.Atoi(os.Getenv(envconfig.CWAGENT_EXPORT_LAG_THRESHOLD)); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return DefaultExportLagThreshold
+}
+
+// WatermarkStats holds the high-watermarks of one pipeline. The methods are safe to call concurrently.
+type WatermarkStats struct {
+	pipeline  string
+	attrs     attribute.Set
+	threshold time.Duration
+	now       func() time.Time
+
+	gaps atomic.Int64
+
+	mu sync.Mutex
+	// collected and exported are the latest timestamps of the data collected and exported.
+	collected time.Time
+	exported  time.Time
+	// progressAt is when the export last advanced, or when the collection got ahead of the export.
+	progressAt time.Time
+	gapStart   time.Time
+}
+
+// WatermarkStatus is a snapshot of a WatermarkStats served by the control socket.
+type WatermarkStatus struct {
+	Pipeline  string    `json:"pipeline"`
+	Collected time.Time `json:"collected"`
+	Exported  time.Time `json:"exported"`
+	// Lag is how far the export is behind the collection.
+	Lag time.Duration `json:"lag"`
+	// Gaps is the number of times the lag went over the threshold.
+	Gaps int64 `json:"gaps"`
+	// InGap is set while the lag is over the threshold.
+	InGap bool `json:"in_gap"`
+}
+
+// Collected raises the collection watermark to the timestamp of data the pipeline received.
+func (s *WatermarkStats) Collected(timestamp time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if !timestamp.After(s.collected) {
+		return
+	}
+	if !s.pending() {
+		s.progressAt = now
+	}
+	s.collected = timestamp
+	s.check(now)
+}
+
+// Exported raises the export watermark to the timestamp of data the pipeline exported.
+func (s *WatermarkStats) Exported(timestamp time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if !timestamp.After(s.exported) {
+		return
+	}
+	s.exported = timestamp
+	s.progressAt = now
+	s.check(now)
+}
+
+// pending reports whether collected data is waiting to be exported.
+func (s *WatermarkStats) pending() bool {
+	return s.collected.After(s.exported)
+}
+
+// lag is the longer of how far the exported data is behind the collected data, and of how long the export has not
+// advanced while data is waiting.
+func (s *WatermarkStats) lag(now time.Time) time.Duration {
+	if !s.pending() {
+		return 0
+	}
+	lag := now.Sub(s.progressAt)
+	if !s.exported.IsZero() {
+		lag = max(lag, s.collected.Sub(s.exported))
+	}
+	return lag
+}
+
+// check reports a gap once the lag goes over the threshold, and its end once the export catches up.
+func (s *WatermarkStats) check(now time.Time) time.Duration {
+	lag := s.lag(now)
+	switch {
+	case lag > s.threshold && s.gapStart.IsZero():
+		s.gapStart = now
+		s.gaps.Add(1)
+		log.Printf("W! The export of %s lags its collection by %v, the data collected since %v has not been exported. "+
+			"The output may be wedged or unable to reach AWS", s.pipeline, lag.Truncate(time.Second), s.exported.Format(time.RFC3339))
+	case lag <= s.threshold && !s.gapStart.IsZero():
+		log.Printf("I! The export of %s caught up with its collection after a gap of %v", s.pipeline, now.Sub(s.gapStart).Truncate(time.Second))
+		s.gapStart = time.Time{}
+	}
+	return lag
+}
+
+func (s *WatermarkStats) status() WatermarkStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lag := s.check(s.now())
+	return WatermarkStatus{
+		Pipeline:  s.pipeline,
+		Collected: s.collected,
+		Exported:  s.exported,
+		Lag:       lag,
+		Gaps:      s.gaps.Load(),
+		InGap:     !s.gapStart.IsZero(),
+	}
+}
+
+type watermarkRegistry struct {
+	threshold time.Duration
+	now       func() time.Time
+
+	mu        sync.RWMutex
+	pipelines map[string]*WatermarkStats
+}
+
+func newWatermarkRegistry(threshold time.Duration) *watermarkRegistry {
+	return &watermarkRegistry{threshold: threshold, now: time.Now, pipelines: make(map[string]*WatermarkStats)}
+}
+
+// Register returns the watermarks of the pipeline, e.g. cloudwatchlogs. Outputs registering the same pipeline
+// share its watermarks.
+func (r *watermarkRegistry) Register(pipeline string) *WatermarkStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.pipelines[pipeline]; ok {
+		return s
+	}
+	s := &WatermarkStats{
+		pipeline:  pipeline,
+		attrs:     attribute.NewSet(attribute.String(AttributeOutput, pipeline)),
+		threshold: r.threshold,
+		now:       r.now,
+	}
+	r.pipelines[pipeline] = s
+	return s
+}
+
+// Statuses returns a snapshot of every pipeline sorted by name. Taking the snapshot reports the gaps of pipelines
+// that stopped receiving data.
+func (r *watermarkRegistry) Statuses() []WatermarkStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	statuses := make([]WatermarkStatus, 0, len(r.pipelines))
+	for _, s := range r.pipelines {
+		statuses = append(statuses, s.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Pipeline < statuses[j].Pipeline })
+	return statuses
+}
+
+// RegisterMetrics reports the export lag and gaps of every pipeline with the provider. Unregister the returned
+// registration when the provider shuts down.
+func (r *watermarkRegistry) RegisterMetrics(mp metric.MeterProvider) (metric.Registration, error) {
+	meter := mp.Meter(scopeName)
+	lag, err := meter.Float64ObservableGauge("export_lag",
+		metric.WithDescription("How far the export of the pipeline is behind its collection"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	gaps, err := meter.Int64ObservableCounter("export_gaps",
+		metric.WithDescription("Number of times the export lag of the pipeline went over the threshold"),
+		metric.WithUnit("{gaps}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		for _, s := range r.pipelines {
+			status := s.status()
+			attrs := metric.WithAttributeSet(s.attrs)
+			o.ObserveFloat64(lag, status.Lag.Seconds(), attrs)
+			o.ObserveInt64(gaps, status.Gaps, attrs)
+		}
+		return nil
+	}, lag, gaps)
+}
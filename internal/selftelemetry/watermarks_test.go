@@ -0,0 +1,85 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package selftelemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestWatermarks(t *testing.T) {
+	r := newWatermarkRegistry(time.Minute)
+	now := time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	s := r.Register("cloudwatchlogs")
+	assert.Same(t, s, r.Register("cloudwatchlogs"))
+
+	s.Collected(now.Add(-time.Second))
+	s.Exported(now.Add(-time.Second))
+	assert.Equal(t, []WatermarkStatus{
+		{Pipeline: "cloudwatchlogs", Collected: now.Add(-time.Second), Exported: now.Add(-time.Second)},
+	}, r.Statuses())
+
+	// the exporter is wedged while the data keeps being collected
+	for i := 0; i < 3; i++ {
+		now = now.Add(30 * time.Second)
+		s.Collected(now)
+	}
+	status := r.Statuses()[0]
+	assert.Equal(t, 91*time.Second, status.Lag)
+	assert.True(t, status.InGap)
+	assert.Equal(t, int64(1), status.Gaps)
+
+	// older data does not lower the watermarks
+	s.Collected(now.Add(-time.Hour))
+	s.Exported(now)
+	status = r.Statuses()[0]
+	assert.Equal(t, now, status.Collected)
+	assert.Zero(t, status.Lag)
+	assert.False(t, status.InGap)
+	assert.Equal(t, int64(1), status.Gaps)
+}
+
+func TestWatermarksStalledWithoutExport(t *testing.T) {
+	r := newWatermarkRegistry(time.Minute)
+	now := time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	s := r.Register("cloudwatch")
+	var nilStats *WatermarkStats
+	nilStats.Collected(now)
+
+	// data that was never exported only lags once the export has not advanced for the threshold
+	s.Collected(now.Add(-time.Hour))
+	assert.Equal(t, time.Duration(0), r.Statuses()[0].Lag)
+	now = now.Add(2 * time.Minute)
+	status := r.Statuses()[0]
+	assert.Equal(t, 2*time.Minute, status.Lag)
+	assert.True(t, status.InGap)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	registration, err := r.RegisterMetrics(mp)
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := map[string]float64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Gauge[float64]:
+			got[m.Name] = data.DataPoints[0].Value
+		case metricdata.Sum[int64]:
+			got[m.Name] = float64(data.DataPoints[0].Value)
+		}
+	}
+	assert.Equal(t, map[string]float64{"export_lag": 120, "export_gaps": 1}, got)
+	assert.NoError(t, registration.Unregister())
+}
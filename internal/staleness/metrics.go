@@ -0,0 +1,199 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package staleness
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+)
+
+// MetricsTracker ends the OpenTelemetry series, which are told apart by their resource, scope, metric name and
+// data point attributes. It is not safe for concurrent use.
+type MetricsTracker struct {
+	policy  Policy
+	tracker *Tracker[[16]byte, pmetric.Metrics]
+}
+
+func NewMetricsTracker(cfg Config) *MetricsTracker {
+	return &MetricsTracker{policy: cfg.Policy, tracker: NewTracker[[16]byte, pmetric.Metrics](cfg.Timeout)}
+}
+
+// Observe records that the series of the data points reported.
+func (t *MetricsTracker) Observe(md pmetric.Metrics, now time.Time) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceKey := pdatautil.MapHash(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				metricKey := pdatautil.Hash(
+					pdatautil.WithString(string(resourceKey[:])),
+					pdatautil.WithString(sm.Scope().Name()),
+					pdatautil.WithString(m.Name()),
+				)
+				o := observer{tracker: t.tracker, rm: rm, sm: sm, m: m, key: metricKey, now: now}
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					observe(o, m.Gauge().DataPoints(), func(dest pmetric.Metric) pmetric.NumberDataPoint {
+						return dest.Gauge().DataPoints().AppendEmpty()
+					})
+				case pmetric.MetricTypeSum:
+					observe(o, m.Sum().DataPoints(), func(dest pmetric.Metric) pmetric.NumberDataPoint {
+						return dest.Sum().DataPoints().AppendEmpty()
+					})
+				case pmetric.MetricTypeHistogram:
+					observe(o, m.Histogram().DataPoints(), func(dest pmetric.Metric) pmetric.HistogramDataPoint {
+						return dest.Histogram().DataPoints().AppendEmpty()
+					})
+				case pmetric.MetricTypeExponentialHistogram:
+					observe(o, m.ExponentialHistogram().DataPoints(), func(dest pmetric.Metric) pmetric.ExponentialHistogramDataPoint {
+						return dest.ExponentialHistogram().DataPoints().AppendEmpty()
+					})
+				case pmetric.MetricTypeSummary:
+					observe(o, m.Summary().DataPoints(), func(dest pmetric.Metric) pmetric.SummaryDataPoint {
+						return dest.Summary().DataPoints().AppendEmpty()
+					})
+				}
+			}
+		}
+	}
+}
+
+// Expire returns the data points ending the series that did not report for the timeout, stamped with the time.
+func (t *MetricsTracker) Expire(now time.Time) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	timestamp := pcommon.NewTimestampFromTime(now)
+	for _, template := range t.tracker.Expire(now) {
+		metric.RangeMetrics(template, func(m pmetric.Metric) {
+			end(m, t.policy, timestamp)
+		})
+		template.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	}
+	return md
+}
+
+// Len returns the number of series tracked.
+func (t *MetricsTracker) Len() int {
+	return t.tracker.Len()
+}
+
+type observer struct {
+	tracker *Tracker[[16]byte, pmetric.Metrics]
+	rm      pmetric.ResourceMetrics
+	sm      pmetric.ScopeMetrics
+	m       pmetric.Metric
+	key     [16]byte
+	now     time.Time
+}
+
+// observe keeps a copy of the first data point of each series, with its resource, scope and metric, as the template
+// of the data point ending it.
+func observe[T metric.DataPoint[T]](o observer, dps metric.DataPoints[T], appendTo func(pmetric.Metric) T) {
+	metric.RangeDataPoints(dps, func(dp T) {
+		key := pdatautil.Hash(pdatautil.WithString(string(o.key[:])), pdatautil.WithMap(dp.Attributes()))
+		if o.tracker.Seen(key, o.now) {
+			return
+		}
+		template := pmetric.NewMetrics()
+		rm := template.ResourceMetrics().AppendEmpty()
+		o.rm.Resource().CopyTo(rm.Resource())
+		rm.SetSchemaUrl(o.rm.SchemaUrl())
+		sm := rm.ScopeMetrics().AppendEmpty()
+		o.sm.Scope().CopyTo(sm.Scope())
+		sm.SetSchemaUrl(o.sm.SchemaUrl())
+		m := sm.Metrics().AppendEmpty()
+		copyMetadata(o.m, m)
+		dp.CopyTo(appendTo(m))
+		o.tracker.Add(key, template, o.now)
+	})
+}
+
+// copyMetadata copies the metric without its data points.
+func copyMetadata(from, to pmetric.Metric) {
+	to.SetName(from.Name())
+	to.SetDescription(from.Description())
+	to.SetUnit(from.Unit())
+	switch from.Type() {
+	case pmetric.MetricTypeGauge:
+		to.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		to.SetEmptySum().SetAggregationTemporality(from.Sum().AggregationTemporality())
+		to.Sum().SetIsMonotonic(from.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		to.SetEmptyHistogram().SetAggregationTemporality(from.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		to.SetEmptyExponentialHistogram().SetAggregationTemporality(from.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		to.SetEmptySummary()
+	}
+}
+
+// end turns the data points of the template into the ones ending their series.
+func end(m pmetric.Metric, policy Policy, timestamp pcommon.Timestamp) {
+	absent := pmetric.DefaultDataPointFlags.WithNoRecordedValue(true)
+	endNumber := func(dp pmetric.NumberDataPoint) {
+		dp.SetTimestamp(timestamp)
+		if policy == PolicyAbsent {
+			dp.SetFlags(absent)
+		} else if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			dp.SetIntValue(0)
+		} else {
+			dp.SetDoubleValue(0)
+		}
+	}
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		metric.RangeDataPoints(m.Gauge().DataPoints(), endNumber)
+	case pmetric.MetricTypeSum:
+		metric.RangeDataPoints(m.Sum().DataPoints(), endNumber)
+	case pmetric.MetricTypeHistogram:
+		metric.RangeDataPoints(m.Histogram().DataPoints(), func(dp pmetric.HistogramDataPoint) {
+			dp.SetTimestamp(timestamp)
+			if policy == PolicyAbsent {
+				dp.SetFlags(absent)
+				return
+			}
+			dp.SetCount(0)
+			dp.SetSum(0)
+			dp.RemoveMin()
+			dp.RemoveMax()
+			dp.BucketCounts().FromRaw(make([]uint64, dp.BucketCounts().Len()))
+		})
+	case pmetric.MetricTypeExponentialHistogram:
+		metric.RangeDataPoints(m.ExponentialHistogram().DataPoints(), func(dp pmetric.ExponentialHistogramDataPoint) {
+			dp.SetTimestamp(timestamp)
+			if policy == PolicyAbsent {
+				dp.SetFlags(absent)
+				return
+			}
+			dp.SetCount(0)
+			dp.SetSum(0)
+			dp.SetZeroCount(0)
+			dp.RemoveMin()
+			dp.RemoveMax()
+			dp.Positive().BucketCounts().FromRaw(nil)
+			dp.Negative().BucketCounts().FromRaw(nil)
+		})
+	case pmetric.MetricTypeSummary:
+		metric.RangeDataPoints(m.Summary().DataPoints(), func(dp pmetric.SummaryDataPoint) {
+			dp.SetTimestamp(timestamp)
+			if policy == PolicyAbsent {
+				dp.SetFlags(absent)
+				return
+			}
+			dp.SetCount(0)
+			dp.SetSum(0)
+			dp.QuantileValues().RemoveIf(func(pmetric.SummaryDataPointValueAtQuantile) bool { return true })
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package staleness ends the series that stop reporting, e.g. the series of the hosts and pods scaled in, the same
+// way in every component that aggregates or rolls up metrics, so that autoscaling does not leave series that look
+// like they are still there.
+package staleness
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// PolicyStop lets a series end without a data point, which is what happens when no policy is set.
+	PolicyStop Policy = "stop"
+	// PolicyZero ends a series with a data point of 0.
+	PolicyZero Policy = "zero"
+	// PolicyAbsent ends a series with a data point flagged as having no recorded value, the staleness marker of
+	// OpenTelemetry and Prometheus. CloudWatch has no such marker, so the series just stops there.
+	PolicyAbsent Policy = "absent"
+
+	DefaultTimeout = 5 * time.Minute
+
+	minCheckInterval = time.Second
+)
+
+// Policy is how a series that stopped reporting is ended.
+type Policy string
+
+type Config struct {
+	Policy Policy `mapstructure:"policy,omitempty"`
+	// Timeout is how long a series goes without a data point before it is ended. It must be longer than the
+	// interval the series is reported at.
+	Timeout time.Duration `mapstructure:"timeout,omitempty"`
+}
+
+func (c Config) Validate() error {
+	switch c.Policy {
+	case "", PolicyStop:
+		return nil
+	case PolicyZero, PolicyAbsent:
+	default:
+		return fmt.Errorf("unsupported staleness policy %q, must be one of %q, %q or %q", c.Policy, PolicyStop, PolicyZero, PolicyAbsent)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("staleness timeout must be positive, but got %v", c.Timeout)
+	}
+	return nil
+}
+
+// Enabled returns true if the series are ended with a data point, and so have to be tracked.
+func (c Config) Enabled() bool {
+	return c.Policy == PolicyZero || c.Policy == PolicyAbsent
+}
+
+// CheckInterval is how often the series are checked, so that they end at most a quarter of the timeout late.
+func (c Config) CheckInterval() time.Duration {
+	return max(c.Timeout/4, minCheckInterval)
+}
+
+type entry[V any] struct {
+	value V
+	seen  time.Time
+}
+
+// Tracker remembers the first value of each series, usually a template of the data point ending it, and when the
+// series was last seen. It is not safe for concurrent use.
+type Tracker[K comparable, V any] struct {
+	timeout time.Duration
+	series  map[K]*entry[V]
+}
+
+func NewTracker[K comparable, V any](timeout time.Duration) *Tracker[K, V] {
+	return &Tracker[K, V]{timeout: timeout, series: map[K]*entry[V]{}}
+}
+
+// Seen records that the series reported, and returns false if it is not tracked yet, in which case Add should be
+// called with its value.
+func (t *Tracker[K, V]) Seen(key K, now time.Time) bool {
+	e, ok := t.series[key]
+	if ok {
+		e.seen = now
+	}
+	return ok
+}
+
+// Add tracks the series.
+func (t *Tracker[K, V]) Add(key K, value V, now time.Time) {
+	t.series[key] = &entry[V]{value: value, seen: now}
+}
+
+// Expire stops tracking the series that did not report for the timeout, and returns their values.
+func (t *Tracker[K, V]) Expire(now time.Time) []V {
+	var expired []V
+	for key, e := range t.series {
+		if now.Sub(e.seen) >= t.timeout {
+			expired = append(expired, e.value)
+			delete(t.series, key)
+		}
+	}
+	return expired
+}
+
+// Len returns the number of series tracked.
+func (t *Tracker[K, V]) Len() int {
+	return len(t.series)
+}
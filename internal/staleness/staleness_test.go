@@ -0,0 +1,151 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package staleness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+)
+
+func TestConfig(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.NoError(t, Config{Policy: PolicyStop}.Validate())
+	assert.NoError(t, Config{Policy: PolicyZero, Timeout: time.Minute}.Validate())
+	assert.Error(t, Config{Policy: PolicyAbsent}.Validate())
+	assert.Error(t, Config{Policy: "nan", Timeout: time.Minute}.Validate())
+
+	assert.False(t, Config{Policy: PolicyStop}.Enabled())
+	assert.True(t, Config{Policy: PolicyAbsent, Timeout: time.Minute}.Enabled())
+	assert.Equal(t, 15*time.Second, Config{Timeout: time.Minute}.CheckInterval())
+	assert.Equal(t, time.Second, Config{Timeout: time.Second}.CheckInterval())
+}
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker[string, int](time.Minute)
+	now := time.Now()
+	assert.False(t, tracker.Seen("a", now))
+	tracker.Add("a", 1, now)
+	tracker.Add("b", 2, now)
+	assert.Equal(t, 2, tracker.Len())
+
+	now = now.Add(30 * time.Second)
+	assert.True(t, tracker.Seen("a", now))
+	assert.Empty(t, tracker.Expire(now))
+
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, []int{2}, tracker.Expire(now))
+	assert.Equal(t, 1, tracker.Len())
+	assert.Equal(t, []int{1}, tracker.Expire(now.Add(30*time.Second)))
+	assert.Zero(t, tracker.Len())
+}
+
+func testMetrics(hosts ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "app")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("cpu")
+	gauge.SetUnit("Percent")
+	gauge.SetEmptyGauge()
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("duration")
+	summary.SetEmptySummary()
+	for _, host := range hosts {
+		dp := gauge.Gauge().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("host", host)
+		dp.SetDoubleValue(42)
+		sumDP := sum.Sum().DataPoints().AppendEmpty()
+		sumDP.Attributes().PutStr("host", host)
+		sumDP.SetIntValue(10)
+		histogramDP := histogram.Histogram().DataPoints().AppendEmpty()
+		histogramDP.Attributes().PutStr("host", host)
+		histogramDP.SetCount(3)
+		histogramDP.SetSum(6)
+		histogramDP.SetMin(1)
+		histogramDP.ExplicitBounds().FromRaw([]float64{2})
+		histogramDP.BucketCounts().FromRaw([]uint64{1, 2})
+		summaryDP := summary.Summary().DataPoints().AppendEmpty()
+		summaryDP.Attributes().PutStr("host", host)
+		summaryDP.SetCount(1)
+		summaryDP.QuantileValues().AppendEmpty().SetValue(5)
+	}
+	return md
+}
+
+func TestMetricsTracker(t *testing.T) {
+	tracker := NewMetricsTracker(Config{Policy: PolicyZero, Timeout: time.Minute})
+	now := time.Now()
+	tracker.Observe(testMetrics("a"), now)
+	assert.Equal(t, 4, tracker.Len())
+	tracker.Observe(testMetrics("b"), now)
+	assert.Equal(t, 8, tracker.Len())
+
+	// host a keeps reporting, b is scaled in
+	now = now.Add(30 * time.Second)
+	tracker.Observe(testMetrics("a"), now)
+	assert.Zero(t, tracker.Expire(now).DataPointCount())
+	now = now.Add(30 * time.Second)
+	md := tracker.Expire(now)
+	require.Equal(t, 4, md.DataPointCount())
+	assert.Equal(t, 4, tracker.Len())
+
+	byName := map[string]pmetric.Metric{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		assert.Equal(t, map[string]any{"service.name": "app"}, rm.Resource().Attributes().AsRaw())
+		sm := rm.ScopeMetrics().At(0)
+		assert.Equal(t, "scope", sm.Scope().Name())
+		m := sm.Metrics().At(0)
+		byName[m.Name()] = m
+	}
+	gauge := byName["cpu"].Gauge().DataPoints().At(0)
+	assert.Equal(t, "Percent", byName["cpu"].Unit())
+	assert.Equal(t, map[string]any{"host": "b"}, gauge.Attributes().AsRaw())
+	assert.Zero(t, gauge.DoubleValue())
+	assert.Equal(t, now.UnixNano(), gauge.Timestamp().AsTime().UnixNano())
+	assert.False(t, gauge.Flags().NoRecordedValue())
+	sum := byName["requests"].Sum()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+	assert.True(t, sum.IsMonotonic())
+	assert.Equal(t, pmetric.NumberDataPointValueTypeInt, sum.DataPoints().At(0).ValueType())
+	assert.Zero(t, sum.DataPoints().At(0).IntValue())
+	histogram := byName["latency"].Histogram().DataPoints().At(0)
+	assert.Zero(t, histogram.Count())
+	assert.False(t, histogram.HasMin())
+	assert.Equal(t, []uint64{0, 0}, histogram.BucketCounts().AsRaw())
+	assert.Equal(t, []float64{2}, histogram.ExplicitBounds().AsRaw())
+	summary := byName["duration"].Summary().DataPoints().At(0)
+	assert.Zero(t, summary.Count())
+	assert.Zero(t, summary.QuantileValues().Len())
+}
+
+func TestMetricsTrackerAbsent(t *testing.T) {
+	tracker := NewMetricsTracker(Config{Policy: PolicyAbsent, Timeout: time.Minute})
+	now := time.Now()
+	tracker.Observe(testMetrics("a"), now)
+	md := tracker.Expire(now.Add(time.Minute))
+	require.Equal(t, 4, md.DataPointCount())
+	metric.RangeMetrics(md, func(m pmetric.Metric) {
+		if m.Type() == pmetric.MetricTypeGauge {
+			assert.True(t, m.Gauge().DataPoints().At(0).Flags().NoRecordedValue())
+			assert.EqualValues(t, 42, m.Gauge().DataPoints().At(0).DoubleValue())
+		}
+	})
+	assert.Zero(t, tracker.Len())
+}
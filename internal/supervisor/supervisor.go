@@ -0,0 +1,91 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package supervisor isolates the failures of the receivers and exporters of the agent. A component that panics or
+// keeps failing is restarted with backoff and reported in the self-telemetry instead of taking down every pipeline.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+)
+
+// stableAfter is how long a component must run before its next failure restarts the backoff from Base.
+const stableAfter = time.Minute
+
+// DefaultBackoff waits from 1 second up to 5 minutes between the restarts of a component.
+var DefaultBackoff = retryer.Backoff{Base: time.Second, Steps: 9, Max: 5 * time.Minute}
+
+// PanicError is returned by Call when the function panicked.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Call runs fn and recovers from its panic, which is returned as a *PanicError. The failures and successes of fn are
+// recorded against the component, e.g. input:cpu.
+func Call(component string, fn func() error) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			panicErr := &PanicError{Value: value, Stack: debug.Stack()}
+			log.Printf("E! %s panicked: %v\n%s", component, value, panicErr.Stack)
+			err = panicErr
+		}
+		if err != nil {
+			var panicErr *PanicError
+			selftelemetry.Components.RecordFailure(component, err, errors.As(err, &panicErr))
+		} else {
+			selftelemetry.Components.RecordSuccess(component)
+		}
+	}()
+	return fn()
+}
+
+// Go runs fn in a goroutine until it returns nil or the context is done. When fn fails or panics, it is restarted
+// once the backoff elapsed.
+func Go(ctx context.Context, component string, backoff retryer.Backoff, fn func(context.Context) error) {
+	go run(ctx, component, backoff, fn, false)
+}
+
+// Retry is Go for a function that already failed once, it waits for the backoff before running fn.
+func Retry(ctx context.Context, component string, backoff retryer.Backoff, fn func(context.Context) error) {
+	go run(ctx, component, backoff, fn, true)
+}
+
+func run(ctx context.Context, component string, backoff retryer.Backoff, fn func(context.Context) error, failed bool) {
+	var restarts int
+	for {
+		if failed {
+			delay := backoff.Delay(restarts)
+			restarts++
+			log.Printf("W! Restarting %s in %v", component, delay)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			selftelemetry.Components.RecordRestart(component)
+		}
+		start := time.Now()
+		err := Call(component, func() error { return fn(ctx) })
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		log.Printf("E! %s failed: %v", component, err)
+		if time.Since(start) >= stableAfter {
+			restarts = 0
+		}
+		failed = true
+	}
+}
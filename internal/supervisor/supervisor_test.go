@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+)
+
+var testBackoff = retryer.Backoff{Base: time.Millisecond, Steps: 2, Max: 5 * time.Millisecond}
+
+func statusOf(t *testing.T, component string) selftelemetry.ComponentStatus {
+	t.Helper()
+	for _, status := range selftelemetry.Components.Statuses() {
+		if status.Component == component {
+			return status
+		}
+	}
+	return selftelemetry.ComponentStatus{}
+}
+
+func TestCall(t *testing.T) {
+	err := Call(t.Name(), func() error {
+		var m map[string]int
+		m["key"] = 1
+		return nil
+	})
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Contains(t, err.Error(), "assignment to entry in nil map")
+	assert.NotEmpty(t, panicErr.Stack)
+
+	assert.EqualError(t, Call(t.Name(), func() error { return errors.New("failed") }), "failed")
+	status := statusOf(t, t.Name())
+	assert.Equal(t, int64(2), status.Failures)
+	assert.Equal(t, int64(1), status.Panics)
+	assert.True(t, status.Failing)
+
+	assert.NoError(t, Call(t.Name(), func() error { return nil }))
+	assert.False(t, statusOf(t, t.Name()).Failing)
+}
+
+func TestGo(t *testing.T) {
+	var calls atomic.Int32
+	done := make(chan struct{})
+	Go(context.Background(), t.Name(), testBackoff, func(context.Context) error {
+		switch calls.Add(1) {
+		case 1:
+			panic("first")
+		case 2:
+			return errors.New("second")
+		}
+		close(done)
+		return nil
+	})
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the function was not restarted")
+	}
+	assert.Eventually(t, func() bool { return !statusOf(t, t.Name()).Failing }, 5*time.Second, time.Millisecond)
+	status := statusOf(t, t.Name())
+	assert.Equal(t, int64(2), status.Failures)
+	assert.Equal(t, int64(2), status.Restarts)
+}
+
+func TestRetryStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls atomic.Int32
+	Retry(ctx, t.Name(), retryer.Backoff{Base: time.Hour, Steps: 1, Max: time.Hour}, func(context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+	time.Sleep(10 * time.Millisecond)
+	assert.Zero(t, calls.Load())
+}
@@ -0,0 +1,202 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package lsm explains permission errors caused by Linux security modules. When the agent
+// cannot read a file, Diagnose looks for SELinux or AppArmor denials in the audit logs so
+// the error says which policy blocked the access and how to allow it.
+package lsm
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ModuleSELinux  = "selinux"
+	ModuleAppArmor = "apparmor"
+
+	// cacheTTL bounds how often the audit logs are scanned for the same path, since the
+	// callers retry failed files on every collection.
+	cacheTTL = time.Minute
+)
+
+// Denial is a single access denial recorded by a security module.
+type Denial struct {
+	Module string
+	// Operation is the denied permission, e.g. "read" for SELinux or "open" for AppArmor.
+	Operation string
+	// Name is the file name from the record. SELinux only records the base name.
+	Name string
+	// Source is the SELinux source context or the AppArmor profile.
+	Source string
+	// Target is the SELinux target context. It is empty for AppArmor.
+	Target string
+	// Class is the SELinux object class, e.g. "file".
+	Class string
+	Pid   int
+	Comm  string
+}
+
+// DeniedError wraps a permission error with the security module that caused it.
+type DeniedError struct {
+	Path string
+	// Module is the enforcing module. Denial is nil when no matching record was found.
+	Module string
+	Denial *Denial
+	Err    error
+}
+
+func (e *DeniedError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v: ", e.Err)
+	switch {
+	case e.Denial != nil && e.Denial.Module == ModuleSELinux:
+		fmt.Fprintf(&b, "SELinux denied %s (scontext=%s tcontext=%s tclass=%s); ", e.Denial.Operation, e.Denial.Source, e.Denial.Target, e.Denial.Class)
+		fmt.Fprintf(&b, "label the file with a type the agent may read, e.g. semanage fcontext -a -t var_log_t '%s' && restorecon -v '%s', or build a local policy module with audit2allow", e.Path, e.Path)
+	case e.Denial != nil && e.Denial.Module == ModuleAppArmor:
+		fmt.Fprintf(&b, "AppArmor profile %q denied %s; ", e.Denial.Source, e.Denial.Operation)
+		fmt.Fprintf(&b, "add a rule such as \"%s r,\" to the profile and reload it with apparmor_parser -r", e.Path)
+	case e.Module == ModuleSELinux:
+		b.WriteString("SELinux is enforcing but no matching denial was found in the audit log; check with ausearch -m avc -ts recent")
+	default:
+		b.WriteString("AppArmor confines the agent but no matching denial was found in the audit log; check with journalctl -k | grep apparmor")
+	}
+	return b.String()
+}
+
+func (e *DeniedError) Unwrap() error {
+	return e.Err
+}
+
+type cacheEntry struct {
+	module string
+	denial *Denial
+	at     time.Time
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]cacheEntry{}
+	// now is replaced in tests.
+	now = time.Now
+)
+
+// Diagnose returns err unchanged unless it is a permission error for path and a security
+// module is enforcing on this host, in which case it returns a *DeniedError.
+func Diagnose(path string, err error) error {
+	if err == nil || !errors.Is(err, fs.ErrPermission) {
+		return err
+	}
+	cacheMu.Lock()
+	entry, ok := cache[path]
+	cacheMu.Unlock()
+	if !ok || now().Sub(entry.at) > cacheTTL {
+		module := enforcingModule()
+		var denial *Denial
+		if module != "" {
+			denial = findDenial(path)
+		}
+		entry = cacheEntry{module: module, denial: denial, at: now()}
+		cacheMu.Lock()
+		cache[path] = entry
+		cacheMu.Unlock()
+	}
+	if entry.module == "" {
+		return err
+	}
+	return &DeniedError{Path: path, Module: entry.module, Denial: entry.denial, Err: err}
+}
+
+// ParseDenial parses an audit or kernel log line. It returns false when the line is not an
+// SELinux AVC or AppArmor denial.
+func ParseDenial(line string) (*Denial, bool) {
+	var module string
+	switch {
+	case strings.Contains(line, "avc:") && strings.Contains(line, "denied"):
+		module = ModuleSELinux
+	case strings.Contains(line, `apparmor="DENIED"`):
+		module = ModuleAppArmor
+	default:
+		return nil, false
+	}
+	fields := parseFields(line)
+	d := &Denial{
+		Module: module,
+		Name:   fields["name"],
+		Comm:   fields["comm"],
+	}
+	fmt.Sscanf(fields["pid"], "%d", &d.Pid)
+	if module == ModuleSELinux {
+		d.Source = fields["scontext"]
+		d.Target = fields["tcontext"]
+		d.Class = fields["tclass"]
+		if start := strings.Index(line, "{"); start >= 0 {
+			if end := strings.Index(line[start:], "}"); end > 0 {
+				d.Operation = strings.TrimSpace(line[start+1 : start+end])
+			}
+		}
+		if p := fields["path"]; p != "" {
+			d.Name = p
+		}
+	} else {
+		d.Source = fields["profile"]
+		d.Operation = fields["operation"]
+	}
+	return d, true
+}
+
+// Matches reports whether the denial is for path and was raised against the process with
+// the given pid or command name. Kernel comm values are cut to 15 bytes.
+func (d *Denial) Matches(path string, pid int, comm string) bool {
+	if d.Name != path && d.Name != filepath.Base(path) {
+		return false
+	}
+	if pid != 0 && d.Pid == pid {
+		return true
+	}
+	if len(comm) > 15 {
+		comm = comm[:15]
+	}
+	return comm != "" && d.Comm == comm
+}
+
+// parseFields splits the key=value pairs of an audit record. Quoted values keep their spaces.
+func parseFields(line string) map[string]string {
+	fields := map[string]string{}
+	for len(line) > 0 {
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			break
+		}
+		key := line[:eq]
+		if sp := strings.LastIndexAny(key, " \t"); sp >= 0 {
+			key = key[sp+1:]
+		}
+		line = line[eq+1:]
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			end := strings.IndexByte(line[1:], '"')
+			if end < 0 {
+				value, line = line[1:], ""
+			} else {
+				value, line = line[1:end+1], line[end+2:]
+			}
+		} else {
+			end := strings.IndexAny(line, " \t")
+			if end < 0 {
+				value, line = line, ""
+			} else {
+				value, line = line[:end], line[end:]
+			}
+		}
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+	return fields
+}
@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+// +build linux
+
+package lsm
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxScanBytes is how much of the end of each log is searched for denials.
+const maxScanBytes = 1 << 20
+
+var (
+	selinuxEnforceFile = "/sys/fs/selinux/enforce"
+	// The AppArmor specific file is used by newer kernels that stack security modules.
+	apparmorAttrFiles = []string{"/proc/self/attr/apparmor/current", "/proc/self/attr/current"}
+	apparmorEnabled   = "/sys/module/apparmor/parameters/enabled"
+	auditLogs         = []string{"/var/log/audit/audit.log", "/var/log/kern.log", "/var/log/syslog", "/var/log/messages"}
+)
+
+// enforcingModule returns the security module that confines the agent, if any.
+func enforcingModule() string {
+	if b, err := os.ReadFile(selinuxEnforceFile); err == nil && strings.TrimSpace(string(b)) == "1" {
+		return ModuleSELinux
+	}
+	if b, err := os.ReadFile(apparmorEnabled); err != nil || !strings.HasPrefix(strings.TrimSpace(string(b)), "Y") {
+		return ""
+	}
+	for _, f := range apparmorAttrFiles {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		label := strings.TrimSpace(strings.TrimRight(string(b), "\x00"))
+		if label != "" && label != "unconfined" && !strings.HasSuffix(label, "(complain)") {
+			return ModuleAppArmor
+		}
+		return ""
+	}
+	return ""
+}
+
+// findDenial returns the most recent denial for path raised against this process.
+func findDenial(path string) *Denial {
+	pid := os.Getpid()
+	comm := ""
+	if b, err := os.ReadFile("/proc/self/comm"); err == nil {
+		comm = strings.TrimSpace(string(b))
+	}
+	for _, log := range auditLogs {
+		if d := scanLog(log, path, pid, comm); d != nil {
+			return d
+		}
+	}
+	return nil
+}
+
+func scanLog(name, path string, pid int, comm string) *Denial {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.Size() > maxScanBytes {
+		if _, err := f.Seek(-maxScanBytes, io.SeekEnd); err != nil {
+			return nil
+		}
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil
+	}
+	var found *Denial
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxScanBytes)
+	for scanner.Scan() {
+		if d, ok := ParseDenial(scanner.Text()); ok && d.Matches(path, pid, comm) {
+			found = d
+		}
+	}
+	return found
+}
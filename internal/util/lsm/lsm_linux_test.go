@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+// +build linux
+
+package lsm
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnoseSELinux(t *testing.T) {
+	dir := t.TempDir()
+	enforce := filepath.Join(dir, "enforce")
+	audit := filepath.Join(dir, "audit.log")
+	require.NoError(t, os.WriteFile(enforce, []byte("1\n"), 0600))
+	line := fmt.Sprintf(`type=AVC msg=audit(1700000000.123:456): avc:  denied  { read } for  pid=%d comm="x" name="secure" scontext=a tcontext=b tclass=file permissive=0`, os.Getpid())
+	require.NoError(t, os.WriteFile(audit, []byte("unrelated\n"+line+"\n"), 0600))
+
+	defer func(e, aa string, a []string, n func() time.Time) {
+		selinuxEnforceFile, apparmorEnabled, auditLogs, now = e, aa, a, n
+		cache = map[string]cacheEntry{}
+	}(selinuxEnforceFile, apparmorEnabled, auditLogs, now)
+	selinuxEnforceFile = enforce
+	apparmorEnabled = filepath.Join(dir, "apparmor")
+	auditLogs = []string{filepath.Join(dir, "missing.log"), audit}
+	current := time.Now()
+	now = func() time.Time { return current }
+
+	err := Diagnose("/var/log/secure", &fs.PathError{Op: "open", Path: "/var/log/secure", Err: fs.ErrPermission})
+	var denied *DeniedError
+	require.True(t, errors.As(err, &denied))
+	assert.Equal(t, ModuleSELinux, denied.Module)
+	require.NotNil(t, denied.Denial)
+	assert.Equal(t, "b", denied.Denial.Target)
+
+	// Cached until the TTL expires, even once enforcement is turned off.
+	require.NoError(t, os.WriteFile(enforce, []byte("0\n"), 0600))
+	err = Diagnose("/var/log/secure", fs.ErrPermission)
+	assert.True(t, errors.As(err, &denied))
+	current = current.Add(2 * cacheTTL)
+	err = Diagnose("/var/log/secure", fs.ErrPermission)
+	assert.Equal(t, fs.ErrPermission, err)
+}
@@ -0,0 +1,15 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+// +build !linux
+
+package lsm
+
+func enforcingModule() string {
+	return ""
+}
+
+func findDenial(string) *Denial {
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package lsm
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	selinuxLine  = `type=AVC msg=audit(1700000000.123:456): avc:  denied  { read } for  pid=1234 comm="amazon-cloudwat" name="secure" dev="xvda1" ino=4242 scontext=system_u:system_r:cwagent_t:s0 tcontext=system_u:object_r:var_log_t:s0 tclass=file permissive=0`
+	apparmorLine = `Oct 14 10:00:00 host kernel: audit: type=1400 audit(1700000000.123:457): apparmor="DENIED" operation="open" profile="/opt/aws/amazon-cloudwatch-agent/bin/amazon-cloudwatch-agent" name="/var/log/app/app.log" pid=1234 comm="amazon-cloudwat" requested_mask="r" denied_mask="r" fsuid=0 ouid=0`
+)
+
+func TestParseDenial(t *testing.T) {
+	d, ok := ParseDenial(selinuxLine)
+	require.True(t, ok)
+	assert.Equal(t, &Denial{
+		Module:    ModuleSELinux,
+		Operation: "read",
+		Name:      "secure",
+		Source:    "system_u:system_r:cwagent_t:s0",
+		Target:    "system_u:object_r:var_log_t:s0",
+		Class:     "file",
+		Pid:       1234,
+		Comm:      "amazon-cloudwat",
+	}, d)
+
+	d, ok = ParseDenial(apparmorLine)
+	require.True(t, ok)
+	assert.Equal(t, &Denial{
+		Module:    ModuleAppArmor,
+		Operation: "open",
+		Name:      "/var/log/app/app.log",
+		Source:    "/opt/aws/amazon-cloudwatch-agent/bin/amazon-cloudwatch-agent",
+		Pid:       1234,
+		Comm:      "amazon-cloudwat",
+	}, d)
+
+	_, ok = ParseDenial(`type=SYSCALL msg=audit(1700000000.123:456): arch=c000003e syscall=257 success=no exit=-13`)
+	assert.False(t, ok)
+}
+
+func TestDenialMatches(t *testing.T) {
+	d, _ := ParseDenial(selinuxLine)
+	assert.True(t, d.Matches("/var/log/secure", 1234, ""))
+	assert.True(t, d.Matches("/var/log/secure", 1, "amazon-cloudwatch-agent"))
+	assert.False(t, d.Matches("/var/log/secure", 1, "other"))
+	assert.False(t, d.Matches("/var/log/messages", 1234, ""))
+
+	d, _ = ParseDenial(apparmorLine)
+	assert.True(t, d.Matches("/var/log/app/app.log", 1234, ""))
+	assert.False(t, d.Matches("/var/log/other/app.log", 1234, ""))
+}
+
+func TestDiagnoseIgnoresOtherErrors(t *testing.T) {
+	assert.Nil(t, Diagnose("/var/log/secure", nil))
+	err := fmt.Errorf("open /var/log/secure: %w", fs.ErrNotExist)
+	assert.Same(t, err, Diagnose("/var/log/secure", err))
+}
+
+func TestDeniedErrorMessage(t *testing.T) {
+	d, _ := ParseDenial(selinuxLine)
+	err := &DeniedError{Path: "/var/log/secure", Module: ModuleSELinux, Denial: d, Err: fs.ErrPermission}
+	assert.True(t, errors.Is(err, fs.ErrPermission))
+	assert.Contains(t, err.Error(), "SELinux denied read (scontext=system_u:system_r:cwagent_t:s0 tcontext=system_u:object_r:var_log_t:s0 tclass=file)")
+	assert.Contains(t, err.Error(), "restorecon -v '/var/log/secure'")
+
+	d, _ = ParseDenial(apparmorLine)
+	err = &DeniedError{Path: "/var/log/app/app.log", Module: ModuleAppArmor, Denial: d, Err: fs.ErrPermission}
+	assert.Contains(t, err.Error(), `AppArmor profile "/opt/aws/amazon-cloudwatch-agent/bin/amazon-cloudwatch-agent" denied open`)
+	assert.Contains(t, err.Error(), `"/var/log/app/app.log r,"`)
+
+	err = &DeniedError{Path: "/var/log/secure", Module: ModuleSELinux, Err: fs.ErrPermission}
+	assert.Contains(t, err.Error(), "no matching denial was found")
+}
@@ -0,0 +1,167 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+// +build windows
+
+package security
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// https://docs.microsoft.com/en-us/windows/win32/api/winbase/nf-winbase-logonuserw
+const (
+	LOGON32_LOGON_BATCH      = 4
+	LOGON32_PROVIDER_DEFAULT = 0
+	CRED_TYPE_GENERIC        = 1
+)
+
+var (
+	procLogonUser               = advapi32.NewProc("LogonUserW")
+	procImpersonateLoggedOnUser = advapi32.NewProc("ImpersonateLoggedOnUser")
+	procCredRead                = advapi32.NewProc("CredReadW")
+	procCredFree                = advapi32.NewProc("CredFree")
+)
+
+// https://docs.microsoft.com/en-us/windows/win32/api/wincred/ns-wincred-credentialw
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// Account is a Windows account to open files as. The password is read from the generic
+// credential named CredentialTarget in the Credential Manager of the account the agent runs
+// as, so it never appears in the agent configuration. Username overrides the user name
+// stored with the credential and may be given as DOMAIN\user or user@domain.
+type Account struct {
+	Username         string
+	CredentialTarget string
+}
+
+var (
+	tokensMu sync.Mutex
+	tokens   = map[Account]windows.Token{}
+)
+
+// OpenFileAs calls open for name while impersonating the account. The returned handle keeps
+// the access granted at open time after the impersonation ends. The logon token is kept for
+// later calls, so a changed password only takes effect after a restart.
+func OpenFileAs(account Account, name string, open func(string) (*os.File, error)) (*os.File, error) {
+	token, err := logon(account)
+	if err != nil {
+		return nil, err
+	}
+	// Impersonation applies to the calling thread only.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if ret, _, err := procImpersonateLoggedOnUser.Call(uintptr(token)); ret == 0 {
+		return nil, fmt.Errorf("unable to impersonate %s: %w", account.Username, err)
+	}
+	f, openErr := open(name)
+	if err := windows.RevertToSelf(); err != nil {
+		// The thread must not be reused with the account's token, so keep it locked until the
+		// goroutine exits, at which point the runtime terminates it.
+		runtime.LockOSThread()
+		if f != nil {
+			f.Close()
+		}
+		return nil, fmt.Errorf("unable to revert impersonation of %s: %w", account.Username, err)
+	}
+	return f, openErr
+}
+
+func logon(account Account) (windows.Token, error) {
+	tokensMu.Lock()
+	defer tokensMu.Unlock()
+	if token, ok := tokens[account]; ok {
+		return token, nil
+	}
+	storedUser, password, err := readCredential(account.CredentialTarget)
+	if err != nil {
+		return 0, err
+	}
+	username := account.Username
+	if username == "" {
+		username = storedUser
+	}
+	user, domain := splitUsername(username)
+	userp, err := windows.UTF16PtrFromString(user)
+	if err != nil {
+		return 0, err
+	}
+	var domainp *uint16
+	if domain != "" {
+		if domainp, err = windows.UTF16PtrFromString(domain); err != nil {
+			return 0, err
+		}
+	}
+	passwordp, err := windows.UTF16PtrFromString(password)
+	if err != nil {
+		return 0, err
+	}
+	var token windows.Token
+	ret, _, err := procLogonUser.Call(
+		uintptr(unsafe.Pointer(userp)),
+		uintptr(unsafe.Pointer(domainp)),
+		uintptr(unsafe.Pointer(passwordp)),
+		LOGON32_LOGON_BATCH,
+		LOGON32_PROVIDER_DEFAULT,
+		uintptr(unsafe.Pointer(&token)),
+	)
+	if ret == 0 {
+		return 0, fmt.Errorf("unable to log on as %s: %w", username, err)
+	}
+	tokens[account] = token
+	return token, nil
+}
+
+// readCredential returns the user name and password of a generic credential.
+func readCredential(target string) (string, string, error) {
+	targetp, err := windows.UTF16PtrFromString(target)
+	if err != nil {
+		return "", "", err
+	}
+	var cred *credential
+	ret, _, err := procCredRead.Call(uintptr(unsafe.Pointer(targetp)), CRED_TYPE_GENERIC, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		return "", "", fmt.Errorf("unable to read credential %s: %w", target, err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+	var user string
+	if cred.UserName != nil {
+		user = windows.UTF16PtrToString(cred.UserName)
+	}
+	// cmdkey and the Credential Manager store the password as UTF-16 without a terminator.
+	blob := unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)
+	utf16 := make([]uint16, len(blob)/2)
+	for i := range utf16 {
+		utf16[i] = uint16(blob[2*i]) | uint16(blob[2*i+1])<<8
+	}
+	return user, windows.UTF16ToString(utf16), nil
+}
+
+// splitUsername splits DOMAIN\user. A user@domain name is passed whole with no domain.
+func splitUsername(username string) (string, string) {
+	if domain, user, ok := strings.Cut(username, `\`); ok {
+		return user, domain
+	}
+	return username, ""
+}
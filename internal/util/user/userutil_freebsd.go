@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build freebsd
+// +build freebsd
+
+package user
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// switchUser drops the supplementary groups of root before changing the gid and uid. Unlike
+// Linux, setuid on FreeBSD applies to every thread of the process.
+func switchUser(execUser *user.User, uid, gid int) error {
+	if err := syscall.Setgroups([]int{gid}); err != nil {
+		log.Printf("E! Failed to set groups: %v", err)
+		return err
+	}
+
+	if err := syscall.Setgid(gid); err != nil {
+		log.Printf("E! Failed to set gid: %v", err)
+		return err
+	}
+
+	if err := syscall.Setuid(uid); err != nil {
+		log.Printf("E! Failed to set uid: %v", err)
+		return err
+	}
+
+	if err := os.Setenv("HOME", execUser.HomeDir); err != nil {
+		log.Printf("E! Failed to set HOME: %v", err)
+		return err
+	}
+	log.Printf("I! Set HOME: %v", execUser.HomeDir)
+
+	return nil
+}
+
+func ChangeUser(runAsUser string) (string, error) {
+	if runAsUser == "" {
+		runAsUser = "root"
+	}
+
+	execUser, err := user.Lookup(runAsUser)
+	if err != nil {
+		log.Printf("E! Failed to get runAsUser: %v", err)
+		return runAsUser, err
+	}
+
+	uid, err := strconv.Atoi(execUser.Uid)
+	if err != nil {
+		return runAsUser, fmt.Errorf("UID %s cannot be converted to integer uid: %w", execUser.Uid, err)
+	}
+
+	gid, err := strconv.Atoi(execUser.Gid)
+	if err != nil {
+		return runAsUser, fmt.Errorf("GID %s cannot be converted to integer gid: %w", execUser.Gid, err)
+	}
+
+	if err := changeFileOwner(uid, gid); err != nil {
+		return runAsUser, fmt.Errorf("error change ownership of dirs: %w", err)
+	}
+
+	if runAsUser == "root" {
+		return "root", nil
+	}
+
+	if err := switchUser(execUser, uid, gid); err != nil {
+		log.Printf("E! failed switching to %q: %v", runAsUser, err)
+		return runAsUser, err
+	}
+
+	return runAsUser, nil
+}
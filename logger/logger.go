@@ -21,11 +21,11 @@ type TelegrafWrapperEncoder struct {
 }
 
 func NewLogger(writer io.Writer, level zap.AtomicLevel) (*zap.Logger, []zap.Option) {
-	core := zapcore.NewCore(
+	core := newRedactingCore(zapcore.NewCore(
 		createTelegrafWrapperEncoder(),
 		zapcore.AddSync(writer),
 		loggerLevel,
-	)
+	))
 	option := zap.WrapCore(func(zapcore.Core) zapcore.Core {
 		return core
 	})
@@ -35,11 +35,11 @@ func NewLogger(writer io.Writer, level zap.AtomicLevel) (*zap.Logger, []zap.Opti
 	return logger, []zap.Option{option}
 }
 func getLoggingOptions(writer io.Writer) []zap.Option {
-	core := zapcore.NewCore(
+	core := newRedactingCore(zapcore.NewCore(
 		createTelegrafWrapperEncoder(),
 		zapcore.AddSync(writer),
 		loggerLevel,
-	)
+	))
 	option := zap.WrapCore(func(zapcore.Core) zapcore.Core {
 		return core
 	})
@@ -0,0 +1,138 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// Redacted replaces the values kept out of the agent log.
+	Redacted = "[REDACTED]"
+	// AllowAllKeys in the allowed keys turns off redaction.
+	AllowAllKeys = "*"
+)
+
+var (
+	// The fields the collector adds to the logger of every component, which never hold customer data.
+	componentKeys = []string{"kind", "name", "data_type", "stability", "pipeline", "exporter_in_pipeline", "receiver_in_pipeline", "error"}
+
+	allowedKeys atomic.Pointer[keySet]
+)
+
+type keySet struct {
+	all  bool
+	keys map[string]bool
+}
+
+func newKeySet(keys []string) *keySet {
+	s := &keySet{keys: make(map[string]bool, len(componentKeys)+len(keys))}
+	for _, key := range componentKeys {
+		s.keys[key] = true
+	}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == AllowAllKeys {
+			s.all = true
+		}
+		if key != "" {
+			s.keys[key] = true
+		}
+	}
+	return s
+}
+
+// SetAllowedKeys sets the keys of the fields whose values may appear in the agent log, on top of those identifying
+// the component. Loggers created before the call keep the fields they were created with as they were.
+func SetAllowedKeys(keys []string) {
+	allowedKeys.Store(newKeySet(keys))
+}
+
+// redactingCore keeps customer log content and attribute values out of the log. Field values that can hold them,
+// i.e. strings and structures, are replaced unless their key is allowed. Numbers, durations and errors are kept.
+type redactingCore struct {
+	zapcore.Core
+	// dumpsTelemetry is set for the debug exporter, whose messages are the telemetry it receives.
+	dumpsTelemetry bool
+}
+
+var _ zapcore.Core = (*redactingCore)(nil)
+
+func newRedactingCore(core zapcore.Core) zapcore.Core {
+	return &redactingCore{Core: core}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{
+		Core:           c.Core.With(redact(allowedKeys.Load(), fields)),
+		dumpsTelemetry: c.dumpsTelemetry || isDebugExporter(fields),
+	}
+}
+
+func (c *redactingCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *redactingCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	allowed := allowedKeys.Load()
+	if c.dumpsTelemetry && !allowed.all {
+		e.Message = Redacted
+	}
+	return c.Core.Write(e, redact(allowed, fields))
+}
+
+// redact returns the fields with the values that are not allowed replaced. The fields are only copied if one of
+// them changes.
+func redact(allowed *keySet, fields []zapcore.Field) []zapcore.Field {
+	if allowed.all {
+		return fields
+	}
+	var redacted []zapcore.Field
+	for i, field := range fields {
+		if allowed.keys[field.Key] || !mayHoldContent(field.Type) {
+			continue
+		}
+		if redacted == nil {
+			redacted = append(make([]zapcore.Field, 0, len(fields)), fields...)
+		}
+		redacted[i] = zap.String(field.Key, Redacted)
+	}
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+func mayHoldContent(t zapcore.FieldType) bool {
+	switch t {
+	case zapcore.StringType, zapcore.ByteStringType, zapcore.BinaryType, zapcore.StringerType,
+		zapcore.ReflectType, zapcore.ArrayMarshalerType, zapcore.ObjectMarshalerType:
+		return true
+	}
+	return false
+}
+
+func isDebugExporter(fields []zapcore.Field) bool {
+	var kind, name string
+	for _, field := range fields {
+		switch field.Key {
+		case "kind":
+			kind = field.String
+		case "name":
+			name = field.String
+		}
+	}
+	return kind == "exporter" && (name == "debug" || strings.HasPrefix(name, "debug/"))
+}
+
+func init() {
+	SetAllowedKeys(nil)
+}
@@ -0,0 +1,105 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactingCore(t *testing.T) {
+	t.Cleanup(func() { SetAllowedKeys(nil) })
+	testCases := map[string]struct {
+		allowedKeys []string
+		want        map[string]any
+	}{
+		"Default": {
+			want: map[string]any{
+				"kind":     "exporter",
+				"name":     "awscloudwatchlogs",
+				"body":     Redacted,
+				"pod":      Redacted,
+				"labels":   Redacted,
+				"count":    int64(3),
+				"duration": time.Second,
+				"error":    "failed",
+			},
+		},
+		"Allowed": {
+			allowedKeys: []string{"pod", " "},
+			want: map[string]any{
+				"kind":     "exporter",
+				"name":     "awscloudwatchlogs",
+				"body":     Redacted,
+				"pod":      "pod-1",
+				"labels":   Redacted,
+				"count":    int64(3),
+				"duration": time.Second,
+				"error":    "failed",
+			},
+		},
+		"AllowAll": {
+			allowedKeys: []string{AllowAllKeys},
+			want: map[string]any{
+				"kind":     "exporter",
+				"name":     "awscloudwatchlogs",
+				"body":     "customer log line",
+				"pod":      "pod-1",
+				"labels":   map[string]any{"k": "v"},
+				"count":    int64(3),
+				"duration": time.Second,
+				"error":    "failed",
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			SetAllowedKeys(testCase.allowedKeys)
+			core, logs := observer.New(zapcore.DebugLevel)
+			logger := zap.New(newRedactingCore(core)).With(
+				zap.String("kind", "exporter"),
+				zap.String("name", "awscloudwatchlogs"),
+				zap.String("pod", "pod-1"),
+			)
+			logger.Debug("message",
+				zap.String("body", "customer log line"),
+				zap.Any("labels", map[string]any{"k": "v"}),
+				zap.Int("count", 3),
+				zap.Duration("duration", time.Second),
+				zap.Error(errors.New("failed")),
+			)
+			entries := logs.AllUntimed()
+			require.Len(t, entries, 1)
+			assert.Equal(t, "message", entries[0].Message)
+			assert.Equal(t, testCase.want, entries[0].ContextMap())
+		})
+	}
+}
+
+func TestRedactingCoreDebugExporter(t *testing.T) {
+	t.Cleanup(func() { SetAllowedKeys(nil) })
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(newRedactingCore(core)).With(
+		zap.String("kind", "exporter"),
+		zap.String("data_type", "logs"),
+		zap.String("name", "debug/application_signals"),
+	)
+	logger.Info("ResourceLog #0\nBody: Str(customer log line)", zap.Int("log records", 1))
+	logger.Debug("dropped")
+	SetAllowedKeys([]string{AllowAllKeys})
+	logger.Info("ResourceLog #0")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	assert.Equal(t, Redacted, entries[0].Message)
+	assert.Equal(t, int64(1), entries[0].ContextMap()["log records"])
+	assert.Equal(t, "ResourceLog #0", entries[1].Message)
+}
@@ -0,0 +1,186 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	telegraflogger "github.com/influxdata/telegraf/logger"
+	"github.com/influxdata/wlog"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/logrotate"
+)
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+
+	// Fields of the structured log entries.
+	FieldTime      = "time"
+	FieldLevel     = "level"
+	FieldMessage   = "message"
+	FieldKind      = "kind"
+	FieldComponent = "component"
+	FieldPipeline  = "pipeline"
+	FieldConfigKey = "config_key"
+	FieldErrorCode = "error_code"
+)
+
+// ConfigKeyFunc returns the agent JSON configuration key of a component, or an empty string if
+// there is not exactly one. The kind is receiver, processor, exporter or extension for OTel
+// components and input, output, processor or aggregator for telegraf plugins.
+type ConfigKeyFunc func(kind, name string) string
+
+var (
+	// telegraf plugins log with a [inputs.cpu] prefix, the agent itself with [agent] and similar.
+	pluginPrefix = regexp.MustCompile(`^\[(inputs|outputs|processors|aggregators)\.([^\]\s]+)\] ?`)
+	namePrefix   = regexp.MustCompile(`^\[([\w.-]+)\] ?`)
+
+	// errcode.Tag marks messages with the error code.
+	errorCodeTag = regexp.MustCompile(`\berror_code=([A-Z_]+)\b`)
+
+	levelNames = map[byte]string{'D': "DEBUG", 'I': "INFO", 'W': "WARN", 'E': "ERROR"}
+)
+
+// destination is the writer of the last NewStructuredLogWriter call, closed when it is replaced.
+var destination io.Writer
+
+type structuredWriter struct {
+	mu        sync.Mutex
+	w         io.Writer
+	configKey ConfigKeyFunc
+	now       func() time.Time
+}
+
+// NewStructuredLogWriter sets up the standard logger the same way telegraf's NewLogWriter does,
+// but writes every entry as a JSON object on its own line. Entries of OTel components keep the
+// fields the collector adds, with name renamed to component. Only the stderr, file and lumberjack
+// targets are supported.
+func NewStructuredLogWriter(cfg telegraflogger.LogConfig, configKey ConfigKeyFunc) io.Writer {
+	setDestination(cfg)
+	w := newStructuredWriter(destination, configKey)
+	log.SetOutput(w)
+	return w
+}
+
+// setDestination sets the log level of the configuration, and replaces the destination of the previous writer.
+func setDestination(cfg telegraflogger.LogConfig) {
+	log.SetFlags(0)
+	switch {
+	case cfg.Debug:
+		wlog.SetLevel(wlog.DEBUG)
+	case cfg.Quiet:
+		wlog.SetLevel(wlog.ERROR)
+	default:
+		wlog.SetLevel(wlog.INFO)
+	}
+	if closer, ok := destination.(io.Closer); ok && destination != os.Stderr {
+		closer.Close()
+	}
+	destination = logDestination(cfg)
+}
+
+func newStructuredWriter(w io.Writer, configKey ConfigKeyFunc) *structuredWriter {
+	return &structuredWriter{w: w, configKey: configKey, now: time.Now}
+}
+
+func logDestination(cfg telegraflogger.LogConfig) io.Writer {
+	if cfg.Logfile == "" {
+		return os.Stderr
+	}
+	switch cfg.LogTarget {
+	case LogTargetLumberjack:
+		return logrotate.NewWriter(cfg.Logfile)
+	case telegraflogger.LogTargetFile:
+		f, err := os.OpenFile(cfg.Logfile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			log.Printf("E! Unable to open %s (%s), using stderr", cfg.Logfile, err)
+			return os.Stderr
+		}
+		return f
+	default:
+		return os.Stderr
+	}
+}
+
+// Write converts a single log line, e.g. "W! [inputs.cpu] message", to a JSON object. Lines below
+// the current log level are dropped.
+func (s *structuredWriter) Write(b []byte) (int, error) {
+	line := strings.TrimRight(string(b), "\n")
+	level := "INFO"
+	if len(line) >= 2 && line[1] == wlog.Delimiter {
+		if name, ok := levelNames[line[0]]; ok {
+			if wlog.Levels[line[0]] < wlog.LogLevel() {
+				return len(b), nil
+			}
+			level = name
+			line = strings.TrimPrefix(line[2:], " ")
+		}
+	}
+
+	entry := s.parse(line)
+	entry[FieldTime] = s.now().UTC().Format(time.RFC3339)
+	entry[FieldLevel] = level
+	if message, _ := entry[FieldMessage].(string); message != "" {
+		if m := errorCodeTag.FindStringSubmatch(message); m != nil {
+			entry[FieldErrorCode] = m[1]
+		}
+	}
+	if kind, _ := entry[FieldKind].(string); kind != "" && s.configKey != nil {
+		if component, _ := entry[FieldComponent].(string); component != "" {
+			if key := s.configKey(kind, component); key != "" {
+				entry[FieldConfigKey] = key
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(entry); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (s *structuredWriter) parse(line string) map[string]any {
+	entry := map[string]any{}
+	// OTel components log through the TelegrafWrapperEncoder, which writes JSON.
+	if strings.HasPrefix(line, "{") && json.Unmarshal([]byte(line), &entry) == nil {
+		rename(entry, "msg", FieldMessage)
+		rename(entry, "name", FieldComponent)
+		return entry
+	}
+	entry = map[string]any{}
+	if m := pluginPrefix.FindStringSubmatch(line); m != nil {
+		entry[FieldKind] = strings.TrimSuffix(m[1], "s")
+		entry[FieldComponent] = m[2]
+		line = line[len(m[0]):]
+	} else if m = namePrefix.FindStringSubmatch(line); m != nil {
+		entry[FieldComponent] = m[1]
+		line = line[len(m[0]):]
+	}
+	entry[FieldMessage] = line
+	return entry
+}
+
+func rename(entry map[string]any, from, to string) {
+	if v, ok := entry[from]; ok {
+		delete(entry, from)
+		entry[to] = v
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/influxdata/wlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestStructuredWriter(t *testing.T) {
+	wlog.SetLevel(wlog.INFO)
+	t.Cleanup(func() { wlog.SetLevel(wlog.INFO) })
+
+	configKey := func(kind, name string) string {
+		if kind == "input" && name == "cpu" {
+			return "metrics.metrics_collected.cpu"
+		}
+		if kind == "exporter" && name == "awsemf/containerinsights" {
+			return "logs.metrics_collected.kubernetes"
+		}
+		return ""
+	}
+	testCases := map[string]struct {
+		line string
+		want map[string]any
+	}{
+		"Plugin": {
+			line: "E! [inputs.cpu] Error in plugin: unable to read\n",
+			want: map[string]any{
+				FieldLevel:     "ERROR",
+				FieldKind:      "input",
+				FieldComponent: "cpu",
+				FieldConfigKey: "metrics.metrics_collected.cpu",
+				FieldMessage:   "Error in plugin: unable to read",
+			},
+		},
+		"ErrorCode": {
+			line: "E! [outputs.cloudwatchlogs] Aws error received when sending logs to g/s: ThrottlingException error_code=QUOTA_EXCEEDED",
+			want: map[string]any{
+				FieldLevel:     "ERROR",
+				FieldKind:      "output",
+				FieldComponent: "cloudwatchlogs",
+				FieldErrorCode: "QUOTA_EXCEEDED",
+				FieldMessage:   "Aws error received when sending logs to g/s: ThrottlingException error_code=QUOTA_EXCEEDED",
+			},
+		},
+		"Agent": {
+			line: "I! [agent] Config: Interval:1m0s",
+			want: map[string]any{
+				FieldLevel:     "INFO",
+				FieldComponent: "agent",
+				FieldMessage:   "Config: Interval:1m0s",
+			},
+		},
+		"NoPrefix": {
+			line: "starting",
+			want: map[string]any{
+				FieldLevel:   "INFO",
+				FieldMessage: "starting",
+			},
+		},
+		"OTel": {
+			line: `W! {"kind":"exporter","data_type":"metrics","name":"awsemf/containerinsights","msg":"dropped","count":3}`,
+			want: map[string]any{
+				FieldLevel:     "WARN",
+				FieldKind:      "exporter",
+				FieldComponent: "awsemf/containerinsights",
+				FieldConfigKey: "logs.metrics_collected.kubernetes",
+				FieldMessage:   "dropped",
+				"data_type":    "metrics",
+				"count":        float64(3),
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := newStructuredWriter(&buf, configKey)
+			w.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+			n, err := w.Write([]byte(testCase.line))
+			require.NoError(t, err)
+			assert.Equal(t, len(testCase.line), n)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			testCase.want[FieldTime] = "2024-01-02T03:04:05Z"
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestStructuredWriterLevel(t *testing.T) {
+	wlog.SetLevel(wlog.WARN)
+	t.Cleanup(func() { wlog.SetLevel(wlog.INFO) })
+	var buf bytes.Buffer
+	w := newStructuredWriter(&buf, nil)
+	_, err := w.Write([]byte("I! dropped"))
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+	_, err = w.Write([]byte("E! kept"))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"message":"kept"`)
+}
+
+func TestStructuredWriterWithZap(t *testing.T) {
+	wlog.SetLevel(wlog.INFO)
+	var buf bytes.Buffer
+	logger, _ := NewLogger(newStructuredWriter(&buf, nil), zap.NewAtomicLevelAt(zapcore.InfoLevel))
+	logger.With(zap.String("kind", "processor"), zap.String("name", "batch/host"), zap.String("pipeline", "metrics/host")).Info("started")
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "INFO", got[FieldLevel])
+	assert.Equal(t, "processor", got[FieldKind])
+	assert.Equal(t, "batch/host", got[FieldComponent])
+	assert.Equal(t, "metrics/host", got[FieldPipeline])
+	assert.Equal(t, "started", got[FieldMessage])
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"io"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	telegraflogger "github.com/influxdata/telegraf/logger"
+	"github.com/influxdata/wlog"
+)
+
+var levelPrefix = regexp.MustCompile(`^[DIWE]!`)
+
+type textWriter struct {
+	w        io.Writer
+	timezone *time.Location
+	now      func() time.Time
+}
+
+// NewTextLogWriter sets up the standard logger like telegraf's NewLogWriter, with the same layout, but writes the
+// lumberjack target through logrotate so that it follows agent.log_rotation instead of the fixed retention of
+// telegraf. The other targets are left to telegraf.
+func NewTextLogWriter(cfg telegraflogger.LogConfig) io.Writer {
+	if cfg.LogTarget != LogTargetLumberjack || cfg.Logfile == "" {
+		return telegraflogger.NewLogWriter(cfg)
+	}
+	setDestination(cfg)
+	w := newTextWriter(destination, cfg.LogWithTimezone)
+	log.SetOutput(w)
+	return w
+}
+
+// newTextWriter falls back to UTC for an unknown timezone, which telegraf does for an empty one.
+func newTextWriter(w io.Writer, timezone string) *textWriter {
+	if strings.ToLower(timezone) == "local" {
+		timezone = "Local"
+	}
+	tz, err := time.LoadLocation(timezone)
+	if err != nil {
+		tz = time.UTC
+	}
+	return &textWriter{w: wlog.NewWriter(w), timezone: tz, now: time.Now}
+}
+
+// Write prefixes the line with the time, and with the info level if it has none, e.g.
+// "2024-01-01T00:00:00Z I! message".
+func (t *textWriter) Write(b []byte) (int, error) {
+	prefix := t.now().In(t.timezone).Format(time.RFC3339) + " "
+	if !levelPrefix.Match(b) {
+		prefix += "I! "
+	}
+	return t.w.Write(append([]byte(prefix), b...))
+}
@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	telegraflogger "github.com/influxdata/telegraf/logger"
+	"github.com/influxdata/wlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/logrotate"
+)
+
+func TestTextWriter(t *testing.T) {
+	wlog.SetLevel(wlog.INFO)
+	t.Cleanup(func() { wlog.SetLevel(wlog.INFO) })
+	var buf bytes.Buffer
+	w := newTextWriter(&buf, "")
+	w.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600)) }
+
+	_, err := w.Write([]byte("W! [inputs.cpu] warning\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("no level\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("D! dropped\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-02T02:04:05Z W! [inputs.cpu] warning\n2024-01-02T02:04:05Z I! no level\n", buf.String())
+}
+
+func TestNewTextLogWriter(t *testing.T) {
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		setDestination(telegraflogger.LogConfig{})
+		logrotate.Configure(logrotate.DefaultConfig())
+	})
+	logrotate.Configure(logrotate.Config{MaxSizeMB: 1, MaxBackups: 1})
+	path := filepath.Join(t.TempDir(), "logs", "agent.log")
+	NewTextLogWriter(telegraflogger.LogConfig{LogTarget: LogTargetLumberjack, Logfile: path})
+	require.IsType(t, &logrotate.Writer{}, destination)
+
+	log.Printf("I! message")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Regexp(t, `^\S+ I! message\n$`, string(content))
+}
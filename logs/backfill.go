@@ -0,0 +1,111 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// MaxBackfillAge is the oldest event CloudWatch Logs accepts in PutLogEvents.
+const MaxBackfillAge = 14 * 24 * time.Hour
+
+var errNoBackfillCollection = errors.New("no log collection supports backfill")
+
+// A BackfillCollection is a LogCollection that can do a one-time upload of the log files
+// already on disk, including rotated files.
+type BackfillCollection interface {
+	LogCollection
+	// FindBackfillSrc returns sources for every existing file modified within maxAge. The sources
+	// read each file until EOF and then stop instead of following it.
+	FindBackfillSrc(maxAge time.Duration) []LogSrc
+}
+
+// backfillTarget is the log stream a backfill source publishes to.
+type backfillTarget struct {
+	destination string
+	group       string
+	stream      string
+}
+
+type backfillPair struct {
+	src  LogSrc
+	dest LogDest
+}
+
+// Backfill uploads the existing log files of all collections that support it and returns
+// once every source has reached the end of its file or the context is done.
+func (l *LogAgent) Backfill(ctx context.Context, maxAge time.Duration) error {
+	if maxAge <= 0 || maxAge > MaxBackfillAge {
+		return fmt.Errorf("backfill max age must be between 0 and %v, got %v", MaxBackfillAge, maxAge)
+	}
+	log.Printf("I! [logagent] starting backfill of files modified in the last %v", maxAge)
+	l.findBackends()
+
+	// the sources of a collection come oldest first, so the ones publishing to the same log stream run one after the
+	// other to upload the rotated files before the active one
+	var targets []backfillTarget
+	queues := map[backfillTarget][]backfillPair{}
+	found := false
+	for _, input := range l.Config.Inputs {
+		collection, ok := input.Input.(BackfillCollection)
+		if !ok {
+			continue
+		}
+		found = true
+		if err := collection.Start(nil); err != nil {
+			log.Printf("E! [logagent] could not start log collection %v for backfill err %v", input.Config.Name, err)
+			continue
+		}
+		for _, src := range collection.FindBackfillSrc(maxAge) {
+			dest := l.createDest(src)
+			if dest == nil {
+				src.Stop()
+				continue
+			}
+			target := backfillTarget{destination: src.Destination(), group: src.Group(), stream: src.Stream()}
+			if _, ok := queues[target]; !ok {
+				targets = append(targets, target)
+			}
+			queues[target] = append(queues[target], backfillPair{src: src, dest: dest})
+		}
+	}
+	if !found {
+		return errNoBackfillCollection
+	}
+
+	var wg sync.WaitGroup
+	for _, target := range targets {
+		wg.Add(1)
+		go func(pairs []backfillPair) {
+			defer wg.Done()
+			for i, pair := range pairs {
+				if ctx.Err() != nil {
+					for _, rest := range pairs[i:] {
+						rest.src.Stop()
+					}
+					return
+				}
+				l.runSrcToDest(pair.src, pair.dest)
+			}
+		}(queues[target])
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		log.Printf("I! [logagent] backfill completed")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+type stubEvent struct {
+	msg string
+}
+
+func (e stubEvent) Message() string { return e.msg }
+func (e stubEvent) Time() time.Time { return time.Time{} }
+func (e stubEvent) Done()           {}
+
+type stubSrc struct {
+	msgs    []string
+	delay   time.Duration
+	stopped chan struct{}
+	once    sync.Once
+}
+
+var _ LogSrc = (*stubSrc)(nil)
+
+func (s *stubSrc) SetOutput(fn func(LogEvent)) {
+	go func() {
+		time.Sleep(s.delay)
+		for _, m := range s.msgs {
+			fn(stubEvent{msg: m})
+		}
+		fn(nil)
+	}()
+}
+func (s *stubSrc) Entity() *cloudwatchlogs.Entity { return nil }
+func (s *stubSrc) Group() string                  { return "group" }
+func (s *stubSrc) Stream() string                 { return "stream" }
+func (s *stubSrc) Destination() string            { return "stub_backend" }
+func (s *stubSrc) Description() string            { return "stub" }
+func (s *stubSrc) Retention() int                 { return -1 }
+func (s *stubSrc) Class() string                  { return "" }
+func (s *stubSrc) Stop()                          { s.once.Do(func() { close(s.stopped) }) }
+
+type stubCollection struct {
+	srcs   []LogSrc
+	maxAge time.Duration
+}
+
+func (c *stubCollection) SampleConfig() string              { return "" }
+func (c *stubCollection) Description() string               { return "" }
+func (c *stubCollection) Gather(telegraf.Accumulator) error { return nil }
+func (c *stubCollection) Start(telegraf.Accumulator) error  { return nil }
+func (c *stubCollection) FindLogSrc() []LogSrc              { return nil }
+func (c *stubCollection) FindBackfillSrc(d time.Duration) []LogSrc {
+	c.maxAge = d
+	return c.srcs
+}
+
+type stubDest struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (d *stubDest) Publish(events []LogEvent) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range events {
+		d.msgs = append(d.msgs, e.Message())
+	}
+	return nil
+}
+
+type stubBackend struct {
+	dest *stubDest
+}
+
+func (b *stubBackend) SampleConfig() string          { return "" }
+func (b *stubBackend) Description() string           { return "" }
+func (b *stubBackend) Connect() error                { return nil }
+func (b *stubBackend) Close() error                  { return nil }
+func (b *stubBackend) Write([]telegraf.Metric) error { return nil }
+func (b *stubBackend) CreateDest(string, string, int, string, LogSrc) LogDest {
+	return b.dest
+}
+
+func TestBackfill(t *testing.T) {
+	src := &stubSrc{msgs: []string{"a", "b", "c"}, stopped: make(chan struct{})}
+	collection := &stubCollection{srcs: []LogSrc{src}}
+	backend := &stubBackend{dest: &stubDest{}}
+
+	c := config.NewConfig()
+	c.Inputs = append(c.Inputs, models.NewRunningInput(collection, &models.InputConfig{Name: "stub"}))
+	c.Outputs = append(c.Outputs, models.NewRunningOutput(backend, &models.OutputConfig{Name: "stub_backend"}, 0, 0))
+
+	require.NoError(t, NewLogAgent(c).Backfill(context.Background(), time.Hour))
+	assert.Equal(t, time.Hour, collection.maxAge)
+	assert.Equal(t, []string{"a", "b", "c"}, backend.dest.msgs)
+	select {
+	case <-src.stopped:
+	default:
+		t.Fatal("source was not stopped after backfill")
+	}
+}
+
+func TestBackfillOrder(t *testing.T) {
+	// the oldest file is the slowest to read, which must not let the newer ones publish first
+	srcs := []LogSrc{
+		&stubSrc{msgs: []string{"rotated.2 a", "rotated.2 b"}, delay: 50 * time.Millisecond, stopped: make(chan struct{})},
+		&stubSrc{msgs: []string{"rotated.1 a", "rotated.1 b"}, stopped: make(chan struct{})},
+		&stubSrc{msgs: []string{"active a", "active b"}, stopped: make(chan struct{})},
+	}
+	collection := &stubCollection{srcs: srcs}
+	backend := &stubBackend{dest: &stubDest{}}
+
+	c := config.NewConfig()
+	c.Inputs = append(c.Inputs, models.NewRunningInput(collection, &models.InputConfig{Name: "stub"}))
+	c.Outputs = append(c.Outputs, models.NewRunningOutput(backend, &models.OutputConfig{Name: "stub_backend"}, 0, 0))
+
+	require.NoError(t, NewLogAgent(c).Backfill(context.Background(), time.Hour))
+	assert.Equal(t, []string{"rotated.2 a", "rotated.2 b", "rotated.1 a", "rotated.1 b", "active a", "active b"}, backend.dest.msgs)
+}
+
+func TestBackfillInvalid(t *testing.T) {
+	c := config.NewConfig()
+	l := NewLogAgent(c)
+	assert.Error(t, l.Backfill(context.Background(), 0))
+	assert.Error(t, l.Backfill(context.Background(), MaxBackfillAge+time.Hour))
+	assert.ErrorIs(t, l.Backfill(context.Background(), time.Hour), errNoBackfillCollection)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/supervisor"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/tail"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 )
@@ -48,6 +49,32 @@ type LogSrc interface {
 	Stop()
 }
 
+// A LogRoute is a log group, other than its own, that a RoutedLogSrc sends its events to.
+type LogRoute struct {
+	Group string
+	Class string
+}
+
+// A RoutedLogSrc sends its events to other log groups while the time windows of their routes are open,
+// e.g. to a log group of the Infrequent Access class outside of business hours.
+type RoutedLogSrc interface {
+	LogSrc
+	Routes() []LogRoute
+	// Route returns the index in Routes of the route of the events published at t, or -1 for the source's own
+	// log group.
+	Route(t time.Time) int
+}
+
+// A StreamedLogSrc names the log stream of each of its events after their content, e.g. after the pod that logged
+// them, instead of publishing them all to the log stream of Stream.
+type StreamedLogSrc interface {
+	LogSrc
+	// EventStreams reports whether the log stream depends on the events.
+	EventStreams() bool
+	// EventStream returns the log stream of the event.
+	EventStream(e LogEvent) string
+}
+
 // A LogBackend is able to return a LogDest of a given name.
 // The same name should always return the same LogDest.
 type LogBackend interface {
@@ -83,23 +110,14 @@ func NewLogAgent(c *config.Config) *LogAgent {
 // based on the configured "destination", and "name"
 func (l *LogAgent) Run(ctx context.Context) {
 	log.Printf("I! [logagent] starting")
-	for _, output := range l.Config.Outputs {
-		backend, ok := output.Output.(LogBackend)
-		if !ok {
-			continue
-		}
-		log.Printf("I! [logagent] found plugin %v is a log backend", output.Config.Name)
-		name := output.Config.Alias
-		if name == "" {
-			name = output.Config.Name
-		}
-		l.backends[name] = backend
-	}
+	l.findBackends()
 
 	for _, input := range l.Config.Inputs {
 		if collection, ok := input.Input.(LogCollection); ok {
 			log.Printf("I! [logagent] found plugin %v is a log collection", input.Config.Name)
-			err := collection.Start(nil)
+			err := supervisor.Call("input:"+input.Config.Name, func() error {
+				return collection.Start(nil)
+			})
 			if err != nil {
 				log.Printf("E! could not start log collection %v err %v", input.Config.Name, err)
 			}
@@ -116,22 +134,11 @@ func (l *LogAgent) Run(ctx context.Context) {
 			for _, c := range l.collections {
 				srcs := c.FindLogSrc()
 				for _, src := range srcs {
-					dname := src.Destination()
-					logGroup := src.Group()
-					logStream := src.Stream()
-					description := src.Description()
-					retention := src.Retention()
-					logGroupClass := src.Class()
-					backend, ok := l.backends[dname]
-					if !ok {
-						log.Printf("E! [logagent] Failed to find destination %s for log source %s/%s(%s) ", dname, logGroup, logStream, description)
+					dest := l.createDest(src)
+					if dest == nil {
 						continue
 					}
-					retention = l.checkRetentionAlreadyAttempted(retention, logGroup)
-					dest := backend.CreateDest(logGroup, logStream, retention, logGroupClass, src)
-					l.destNames[dest] = dname
-					log.Printf("I! [logagent] piping log from %s/%s(%s) to %s with retention %d", logGroup, logStream, description, dname, retention)
-					go l.runSrcToDest(src, dest)
+					l.superviseSrcToDest(ctx, src, dest, "output:"+l.destNames[dest])
 				}
 			}
 		case <-ctx.Done():
@@ -140,10 +147,125 @@ func (l *LogAgent) Run(ctx context.Context) {
 	}
 }
 
+func (l *LogAgent) findBackends() {
+	for _, output := range l.Config.Outputs {
+		backend, ok := output.Output.(LogBackend)
+		if !ok {
+			continue
+		}
+		log.Printf("I! [logagent] found plugin %v is a log backend", output.Config.Name)
+		name := output.Config.Alias
+		if name == "" {
+			name = output.Config.Name
+		}
+		l.backends[name] = backend
+	}
+}
+
+// createDest returns the LogDest for the source or nil if the source's destination has no backend.
+func (l *LogAgent) createDest(src LogSrc) LogDest {
+	dname := src.Destination()
+	logGroup := src.Group()
+	logStream := src.Stream()
+	description := src.Description()
+	retention := src.Retention()
+	backend, ok := l.backends[dname]
+	if !ok {
+		log.Printf("E! [logagent] Failed to find destination %s for log source %s/%s(%s) ", dname, logGroup, logStream, description)
+		return nil
+	}
+	retention = l.checkRetentionAlreadyAttempted(retention, logGroup)
+	var dest LogDest
+	if streamed, ok := src.(StreamedLogSrc); ok && streamed.EventStreams() {
+		dest = &streamedDest{src: streamed, dests: map[string]LogDest{}, create: func(stream string) LogDest {
+			streamDest := l.createStreamDest(backend, src, stream, retention)
+			// the retention is set with the first log stream of the group
+			retention = -1
+			return streamDest
+		}}
+		log.Printf("I! [logagent] piping log from %s/%s(%s) to %s with retention %d, in log streams named after the events", logGroup, logStream, description, dname, retention)
+	} else {
+		dest = l.createStreamDest(backend, src, logStream, retention)
+		log.Printf("I! [logagent] piping log from %s/%s(%s) to %s with retention %d", logGroup, logStream, description, dname, retention)
+	}
+	l.destNames[dest] = dname
+	return dest
+}
+
+// createStreamDest returns the LogDest publishing the events of the source to the log stream, in the log group of
+// the source or of the route they are published in.
+func (l *LogAgent) createStreamDest(backend LogBackend, src LogSrc, stream string, retention int) LogDest {
+	dest := backend.CreateDest(src.Group(), stream, retention, src.Class(), src)
+	if routed, ok := src.(RoutedLogSrc); ok && len(routed.Routes()) > 0 {
+		dest = l.createRoutedDest(backend, routed, stream, dest)
+	}
+	return dest
+}
+
+// createRoutedDest returns a LogDest publishing the events of the source to the log group of the route they are
+// published in, and to dest outside of the routes.
+func (l *LogAgent) createRoutedDest(backend LogBackend, src RoutedLogSrc, stream string, dest LogDest) LogDest {
+	routed := &routedDest{src: src, dests: []LogDest{dest}, now: time.Now}
+	for _, route := range src.Routes() {
+		retention := l.checkRetentionAlreadyAttempted(src.Retention(), route.Group)
+		routed.dests = append(routed.dests, backend.CreateDest(route.Group, stream, retention, route.Class, src))
+		log.Printf("I! [logagent] routing log from %s/%s(%s) to %s/%s during its windows", src.Group(), stream, src.Description(), route.Group, stream)
+	}
+	return routed
+}
+
+// streamedDest publishes the events of a StreamedLogSrc to the destination of their log stream, which is created
+// with the first event of the stream. The source bounds the number of log streams.
+type streamedDest struct {
+	src    StreamedLogSrc
+	dests  map[string]LogDest
+	create func(stream string) LogDest
+}
+
+func (d *streamedDest) Publish(events []LogEvent) error {
+	for _, e := range events {
+		stream := d.src.EventStream(e)
+		dest, ok := d.dests[stream]
+		if !ok {
+			dest = d.create(stream)
+			d.dests[stream] = dest
+		}
+		if err := dest.Publish([]LogEvent{e}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// routedDest publishes the events of a RoutedLogSrc to the destination of their route. dests holds the destination
+// of the source's own log group first, followed by the destinations of its routes.
+type routedDest struct {
+	src   RoutedLogSrc
+	dests []LogDest
+	now   func() time.Time
+}
+
+func (d *routedDest) Publish(events []LogEvent) error {
+	return d.dests[d.src.Route(d.now())+1].Publish(events)
+}
+
+// superviseSrcToDest runs the source until it stops. A panic while publishing its events restarts the publishing
+// with backoff, the source and the other sources keep running in the meantime.
+func (l *LogAgent) superviseSrcToDest(ctx context.Context, src LogSrc, dest LogDest, component string) {
+	eventsCh := l.startSrc(src)
+	supervisor.Go(ctx, component, supervisor.DefaultBackoff, func(context.Context) error {
+		l.publishSrcToDest(src, dest, eventsCh)
+		return nil
+	})
+}
+
 func (l *LogAgent) runSrcToDest(src LogSrc, dest LogDest) {
-	eventsCh := make(chan LogEvent)
-	defer src.Stop()
+	l.publishSrcToDest(src, dest, l.startSrc(src))
+}
 
+// startSrc returns the channel the events of the source are sent to, which is closed once the source stopped.
+func (l *LogAgent) startSrc(src LogSrc) <-chan LogEvent {
+	eventsCh := make(chan LogEvent)
 	closed := false
 	src.SetOutput(func(e LogEvent) {
 		if closed {
@@ -157,18 +279,24 @@ func (l *LogAgent) runSrcToDest(src LogSrc, dest LogDest) {
 		}
 		eventsCh <- e
 	})
+	return eventsCh
+}
 
+// publishSrcToDest publishes the events of the source until it stopped or the destination failed, and then stops
+// the source. A panic leaves the source running so that the publishing can be restarted.
+func (l *LogAgent) publishSrcToDest(src LogSrc, dest LogDest, eventsCh <-chan LogEvent) {
 	for e := range eventsCh {
 		err := dest.Publish([]LogEvent{e})
 		if err == ErrOutputStopped {
 			log.Printf("I! [logagent] Log destination %v has stopped, finalizing %v/%v", l.destNames[dest], src.Group(), src.Stream())
-			return
+			break
 		}
 		if err != nil {
 			log.Printf("E! [logagent] Failed to publish log to %v, error: %v", l.destNames[dest], err)
-			return
+			break
 		}
 	}
+	src.Stop()
 }
 
 func (l *LogAgent) checkRetentionAlreadyAttempted(retention int, logGroup string) int {
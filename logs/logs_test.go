@@ -4,10 +4,13 @@
 package logs
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/influxdata/telegraf/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRetentionAlreadySet(t *testing.T) {
@@ -20,3 +23,100 @@ func TestRetentionAlreadySet(t *testing.T) {
 	assert.Equal(t, -1, secondAttempt)
 	assert.True(t, l.retentionAlreadyAttempted["logGroup1"])
 }
+
+type stubRoutedSrc struct {
+	*stubSrc
+	route int
+}
+
+func (s *stubRoutedSrc) Routes() []LogRoute {
+	return []LogRoute{{Group: "group-ia", Class: "INFREQUENT_ACCESS"}}
+}
+
+func (s *stubRoutedSrc) Route(time.Time) int {
+	return s.route
+}
+
+func TestRoutedDest(t *testing.T) {
+	l := NewLogAgent(config.NewConfig())
+	src := &stubRoutedSrc{stubSrc: &stubSrc{}, route: -1}
+	l.backends["stub_backend"] = &stubBackend{dest: &stubDest{}}
+	d, ok := l.createDest(src).(*routedDest)
+	require.True(t, ok)
+	require.Len(t, d.dests, 2)
+
+	own, offHours := &stubDest{}, &stubDest{}
+	d.dests = []LogDest{own, offHours}
+	assert.NoError(t, d.Publish([]LogEvent{stubEvent{msg: "business hours"}}))
+	src.route = 0
+	assert.NoError(t, d.Publish([]LogEvent{stubEvent{msg: "off hours"}}))
+	assert.Equal(t, []string{"business hours"}, own.msgs)
+	assert.Equal(t, []string{"off hours"}, offHours.msgs)
+}
+
+type stubStreamedSrc struct {
+	*stubSrc
+}
+
+func (s *stubStreamedSrc) EventStreams() bool {
+	return true
+}
+
+func (s *stubStreamedSrc) EventStream(e LogEvent) string {
+	return e.Message()[:5]
+}
+
+type streamBackend struct {
+	stubBackend
+	dests map[string]*stubDest
+}
+
+func (b *streamBackend) CreateDest(_ string, stream string, _ int, _ string, _ LogSrc) LogDest {
+	b.dests[stream] = &stubDest{}
+	return b.dests[stream]
+}
+
+func TestStreamedDest(t *testing.T) {
+	l := NewLogAgent(config.NewConfig())
+	backend := &streamBackend{dests: map[string]*stubDest{}}
+	l.backends["stub_backend"] = backend
+	d, ok := l.createDest(&stubStreamedSrc{stubSrc: &stubSrc{}}).(*streamedDest)
+	require.True(t, ok)
+	assert.Empty(t, backend.dests)
+
+	require.NoError(t, d.Publish([]LogEvent{stubEvent{msg: "pod-a first"}, stubEvent{msg: "pod-b first"}}))
+	require.NoError(t, d.Publish([]LogEvent{stubEvent{msg: "pod-a second"}}))
+	require.Len(t, backend.dests, 2)
+	assert.Equal(t, []string{"pod-a first", "pod-a second"}, backend.dests["pod-a"].msgs)
+	assert.Equal(t, []string{"pod-b first"}, backend.dests["pod-b"].msgs)
+}
+
+type panickingDest struct {
+	stubDest
+	panicked bool
+}
+
+func (d *panickingDest) Publish(events []LogEvent) error {
+	if !d.panicked {
+		d.panicked = true
+		panic("publish failed")
+	}
+	return d.stubDest.Publish(events)
+}
+
+func TestSuperviseSrcToDest(t *testing.T) {
+	l := NewLogAgent(config.NewConfig())
+	src := &stubSrc{msgs: []string{"a", "b", "c"}, stopped: make(chan struct{})}
+	dest := &panickingDest{}
+
+	l.superviseSrcToDest(context.Background(), src, dest, "output:"+t.Name())
+	select {
+	case <-src.stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("source was not stopped after publishing")
+	}
+	// the event being published when it panicked is lost, the publishing restarts with the next one
+	dest.mu.Lock()
+	defer dest.mu.Unlock()
+	assert.Equal(t, []string{"b", "c"}, dest.msgs)
+}
@@ -90,6 +90,19 @@ if ! id cwagent >/dev/null 2>&1; then
     echo "create user cwagent, result: $?"
 fi
 
+%post
+# Migrate the config and positions of the awslogs agent on the first install, unless the agent is already configured.
+AWSLOGS_CONFIG=/var/awslogs/etc/awslogs.conf
+MIGRATED_CONFIG=/opt/aws/amazon-cloudwatch-agent/etc/awslogs-migration.json
+if [ $1 -eq 1 ] && [ -f "${AWSLOGS_CONFIG}" ] && [ -z "$(ls -A /opt/aws/amazon-cloudwatch-agent/etc/amazon-cloudwatch-agent.d 2>/dev/null)" ]; then
+    if /opt/aws/amazon-cloudwatch-agent/bin/amazon-cloudwatch-agent-config-wizard -isNonInteractiveLinuxMigration -importLinuxMigrationState -configFilePath "${AWSLOGS_CONFIG}" -configOutputPath "${MIGRATED_CONFIG}" >/dev/null; then
+        /opt/aws/amazon-cloudwatch-agent/bin/amazon-cloudwatch-agent-ctl -a fetch-config -m auto -c "file:${MIGRATED_CONFIG}"
+        echo "migrated ${AWSLOGS_CONFIG} to ${MIGRATED_CONFIG}, stop the awslogs agent before starting amazon-cloudwatch-agent"
+    else
+        echo "unable to migrate ${AWSLOGS_CONFIG}, result: $?"
+    fi
+fi
+
 %preun
 # Stop the agent after uninstall
 if [ $1 -eq 0 ] ; then
@@ -14,6 +14,9 @@ Param (
     [string]$Mode = 'ec2',
     [Parameter(Mandatory = $false)]
     [string]$LogLevel = '',
+    [Parameter(Mandatory = $false)]
+    [Alias('n')]
+    [string]$SourcePattern = '',
     [parameter(ValueFromRemainingArguments=$true)]
     $unsupportedVars
 )
@@ -25,11 +28,12 @@ $UsageString = @"
 
 
         usage:  amazon-cloudwatch-agent-ctl.ps1 -a
-                stop|start|status|fetch-config|append-config|remove-config|set-log-level
+                stop|start|status|fetch-config|append-config|remove-config|set-log-level|list-sources|pause-source|resume-source
                 [-m ec2|onPremise|onPrem|auto]
                 [-c default|all|ssm:<parameter-store-name>|file:<file-path>]
                 [-s]
                 [-l INFO|DEBUG|WARN|ERROR|OFF]
+                [-n <source-pattern>]
 
         e.g.
         1. apply a SSM parameter store config on EC2 instance and restart the agent afterwards:
@@ -38,6 +42,8 @@ $UsageString = @"
             amazon-cloudwatch-agent-ctl.ps1 -a append-config -m onPremise -c file:c:\config.json -s
         3. query agent status:
             amazon-cloudwatch-agent-ctl.ps1 -a status
+        4. stop publishing a log file until it is resumed:
+            amazon-cloudwatch-agent-ctl.ps1 -a pause-source -n logfile:c:\logs\debug.log
 
         -a: action
             stop:                                   stop amazon-cloudwatch-agent if running.
@@ -47,6 +53,9 @@ $UsageString = @"
             append-config:                          append json config with the existing json configs if any, followed by -c. Target config can be based on the location (ssm parameter store name, file name), or 'default'.
             remove-config:                          remove config for agent, followed by -c. Target config can be based on the location (ssm parameter store name, file name), or 'all'.
             set-log-level:                          sets the log level, followed by -l to provide the level in all caps.
+            list-sources:                           list the inputs and log files of the running agent and whether they are paused.
+            pause-source:                           pause the inputs or log files matching -n until they are resumed or the agent restarts.
+            resume-source:                          resume the inputs or log files paused with the same -n.
 
         -m: mode
             ec2:                                    indicate this is on ec2 host.
@@ -65,6 +74,9 @@ $UsageString = @"
         -l: log level to set the agent to INFO, DEBUG, WARN, ERROR, or OFF
             this parameter is used for 'set-log-level' only.
 
+        -n: source pattern, e.g. logfile:c:\logs\*.log or input:win_perf_counters, as shown by 'list-sources'
+            this parameter is used for 'pause-source' and 'resume-source' only.
+
 "@
 
 $CWAServiceName = 'AmazonCloudWatchAgent'
@@ -93,6 +105,8 @@ $JSON="${CWAProgramData}\amazon-cloudwatch-agent.json"
 $JSON_DIR = "${CWAProgramData}\Configs"
 $COMMON_CONIG="${CWAProgramData}\common-config.toml"
 $ENV_CONFIG="${CWAProgramData}\env-config.json"
+# Written by install.ps1 from the installer properties and consumed on the first start.
+$INSTALL_OPTIONS="${CWAProgramData}\install-options.json"
 
 $EC2 = $false
 # WMI is unavailable on Nano, CIM is unavailable on 2003
@@ -112,10 +126,20 @@ Function AgentStart() {
     )
 
     if (${service_name} -eq $CWAServiceName -And !(Test-Path -LiteralPath "${TOML}")) {
-        Write-Output "amazon-cloudwatch-agent is not configured. Applying amazon-cloudwatch-agent default configuration."
-        $ConfigLocation = 'default'
+        if (Test-Path -LiteralPath "${INSTALL_OPTIONS}") {
+            $options = Get-Content -LiteralPath "${INSTALL_OPTIONS}" -Raw | ConvertFrom-Json
+            Write-Output "amazon-cloudwatch-agent is not configured. Applying amazon-cloudwatch-agent configuration $($options.config_location) from the installer."
+            $ConfigLocation = $options.config_location
+            if ($options.PSObject.Properties['mode']) {
+                $EC2 = ModeIsEC2 -mode $options.mode
+            }
+        } else {
+            Write-Output "amazon-cloudwatch-agent is not configured. Applying amazon-cloudwatch-agent default configuration."
+            $ConfigLocation = 'default'
+        }
         CWAConfig -multi_config 'default'
     }
+    Remove-Item -LiteralPath "${INSTALL_OPTIONS}" -Force -ErrorAction SilentlyContinue
 
     $svc = Get-Service -Name "${service_name}" -ErrorAction SilentlyContinue
     if (!$svc) {
@@ -389,6 +413,24 @@ Function CWATestEC2() {
     return !$error
 }
 
+Function ModeIsEC2() {
+    Param (
+        [Parameter(Mandatory = $true)]
+        [string]$mode
+    )
+
+    switch -exact ($mode) {
+        ec2 { return $true }
+        onPremise { return $false }
+        onPrem { return $false }
+        auto { return CWATestEC2 }
+        default {
+           Write-Output "Invalid mode: ${mode}`n${UsageString}"
+           Exit 1
+        }
+    }
+}
+
 Function SetLogLevelAll() {
     switch -exact ($LogLevel) {
         INFO { }
@@ -406,6 +448,21 @@ Function SetLogLevelAll() {
     CheckCMDResult "" "Set CWAGENT_LOG_LEVEL to ${LogLevel}"
 }
 
+Function ControlSourceAll() {
+    param (
+        [Parameter(Mandatory = $true)]
+        [string]$control_action
+    )
+
+    if ($control_action -ne 'list' -and !$SourcePattern) {
+        Write-Output "Missing source pattern`n${UsageString}"
+        Exit 1
+    }
+
+    & cmd /c "`"${CWAProgramFiles}\amazon-cloudwatch-agent.exe`" --control ${control_action} --control-source `"${SourcePattern}`" 2>&1"
+    CheckCMDResult "" ""
+}
+
 Function main() {
     if (Get-Command 'Get-CimInstance' -CommandType Cmdlet -ErrorAction SilentlyContinue) {
         $CIM = $true
@@ -420,16 +477,7 @@ Function main() {
         exit 0
     }
 
-    switch -exact ($Mode) {
-        ec2 { $EC2 = $true }
-        onPremise { $EC2 = $false }
-        onPrem { $EC2 = $false }
-        auto { $EC2 = CWATestEC2 }
-        default {
-           Write-Output "Invalid mode: ${Mode}`n${UsageString}"
-           Exit 1
-        }
-    }
+    $EC2 = ModeIsEC2 -mode $Mode
 
     switch -exact ($Action) {
         stop { StopAll }
@@ -442,6 +490,9 @@ Function main() {
         cond-restart { CondRestartAll }
         preun { PreunAll }
         set-log-level { SetLogLevelAll }
+        list-sources { ControlSourceAll -control_action 'list' }
+        pause-source { ControlSourceAll -control_action 'pause' }
+        resume-source { ControlSourceAll -control_action 'resume' }
         default {
            Write-Output "Invalid action: ${Action}`n${UsageString}"
            Exit 1
@@ -1,6 +1,26 @@
 # Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
 # SPDX-License-Identifier: MIT
 
+# The MSI passes its public properties through to these parameters so the agent can be
+# configured in the same step as the install, e.g. from GPO or Intune:
+#   msiexec /i amazon-cloudwatch-agent.msi CONFIG_LOCATION=ssm:AmazonCloudWatch-Config.json REGION=us-west-2 HTTPS_PROXY=http://proxy:3128
+# The values are written to common-config.toml and install-options.json and are applied the
+# first time the agent is started without a configuration.
+Param (
+    [Parameter(Mandatory = $false)]
+    [string]$ConfigLocation = '',
+    [Parameter(Mandatory = $false)]
+    [string]$Mode = '',
+    [Parameter(Mandatory = $false)]
+    [string]$Region = '',
+    [Parameter(Mandatory = $false)]
+    [string]$HttpProxy = '',
+    [Parameter(Mandatory = $false)]
+    [string]$HttpsProxy = '',
+    [Parameter(Mandatory = $false)]
+    [string]$NoProxy = ''
+)
+
 Set-StrictMode -Version 2.0
 $ErrorActionPreference = "Stop"
 
@@ -16,6 +36,12 @@ if ($Env:ProgramData) {
 }
 
 $Cmd = "${CWAProgramFiles}\amazon-cloudwatch-agent-ctl.ps1"
+$CommonConfig = "${CWAProgramData}\common-config.toml"
+$InstallOptions = "${CWAProgramData}\install-options.json"
+
+Function TomlString($value) {
+    return '"' + ($value -replace '\\', '\\' -replace '"', '\"') + '"'
+}
 
 New-Item -ItemType Directory -Force -Path "${CWAProgramFiles}" | Out-Null
 New-Item -ItemType Directory -Force -Path "${CWAProgramData}\Logs" | Out-Null
@@ -40,4 +66,35 @@ New-Item -ItemType Directory -Force -Path "${CWAProgramData}\Configs" | Out-Null
 "common-config.toml"
 ) | ForEach-Object { Copy-Item ".\$_" -Destination "${CWAProgramData}" -Force }
 
-& "${Cmd}" -Action cond-restart
+$credentials = @()
+if ($Region) {
+    $credentials += "region = $(TomlString $Region)"
+}
+$proxy = @()
+if ($HttpProxy) {
+    $proxy += "http_proxy = $(TomlString $HttpProxy)"
+}
+if ($HttpsProxy) {
+    $proxy += "https_proxy = $(TomlString $HttpsProxy)"
+}
+if ($NoProxy) {
+    $proxy += "no_proxy = $(TomlString $NoProxy)"
+}
+if ($credentials) {
+    Add-Content -LiteralPath "${CommonConfig}" -Value (@("", "[credentials]") + $credentials)
+}
+if ($proxy) {
+    Add-Content -LiteralPath "${CommonConfig}" -Value (@("", "[proxy]") + $proxy)
+}
+
+if ($ConfigLocation) {
+    $options = @{ config_location = $ConfigLocation }
+    if ($Mode) {
+        $options.mode = $Mode
+    }
+    ConvertTo-Json $options | Set-Content -LiteralPath "${InstallOptions}"
+    # The options are applied on start only when no configuration exists yet, so upgrades keep theirs.
+    & "${Cmd}" -Action start
+} else {
+    & "${Cmd}" -Action cond-restart
+}
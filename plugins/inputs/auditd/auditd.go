@@ -0,0 +1,493 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package auditd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/tail"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+const (
+	SourceFile   = "file"
+	SourceSocket = "socket"
+
+	defaultFilePath = "/var/log/audit/audit.log"
+	// retryInterval is how long to wait before opening the audit log again after it failed.
+	retryInterval = time.Minute
+	// settleTime is how long the records of an event are waited for. The kernel ends the events made of several
+	// records with an EOE record, but auditd does not log it.
+	settleTime = time.Second
+	// maxPending is the number of events whose records are waited for at most. The oldest one is written when it is
+	// reached.
+	maxPending = 1024
+)
+
+// Event is one log event: the records of an audit event, with the fields of its process and its result.
+type Event struct {
+	Time   time.Time `json:"time"`
+	Serial uint64    `json:"serial"`
+	Host   string    `json:"host,omitempty"`
+	// Type is the type of the first record, e.g. SYSCALL or USER_LOGIN.
+	Type string `json:"type"`
+	// Keys are the keys of the audit rules which matched the event.
+	Keys []string `json:"keys,omitempty"`
+	// Result is success or failure.
+	Result  string `json:"result,omitempty"`
+	Syscall string `json:"syscall,omitempty"`
+	PID     string `json:"pid,omitempty"`
+	UID     string `json:"uid,omitempty"`
+	// AUID is the login UID of the user, which does not change when they switch users, e.g. with sudo.
+	AUID string `json:"auid,omitempty"`
+	Comm string `json:"comm,omitempty"`
+	Exe  string `json:"exe,omitempty"`
+	// Command is the command line of the process, from the PROCTITLE record.
+	Command string              `json:"command,omitempty"`
+	Records []map[string]string `json:"records"`
+}
+
+type Plugin struct {
+	// Source is file to follow the log of auditd, or socket to read the records from the kernel alongside it.
+	Source        string          `toml:"source"`
+	FilePath      string          `toml:"file_path"`
+	Keys          []string        `toml:"keys"`
+	RecordTypes   []string        `toml:"record_types"`
+	LogGroupName  string          `toml:"log_group_name"`
+	LogStreamName string          `toml:"log_stream_name"`
+	LogGroupClass string          `toml:"log_group_class"`
+	Destination   string          `toml:"destination"`
+	Retention     int             `toml:"retention_in_days"`
+	Log           telegraf.Logger `toml:"-"`
+
+	src      *auditEvents
+	srcFound bool
+}
+
+func (p *Plugin) Description() string {
+	return "Write the events of the Linux audit system, filtered by the keys of their rules, into a log stream"
+}
+
+func (p *Plugin) SampleConfig() string {
+	return `
+  ## file to follow the log of auditd, or socket to read the records from the kernel.
+  source = "file"
+  file_path = "/var/log/audit/audit.log"
+  ## Keys of the audit rules whose events are written, e.g. set with auditctl -k. All the events are written when
+  ## neither keys nor record_types are set.
+  # keys = ["identity", "privileged"]
+  ## Types of the records whose events are written.
+  # record_types = ["USER_LOGIN"]
+
+  log_group_name = "audit-events"
+  log_stream_name = "STREAM_NAME"
+  destination = "cloudwatchlogs"
+`
+}
+
+func (p *Plugin) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+func (p *Plugin) Start(telegraf.Accumulator) error {
+	if p.src != nil {
+		return nil
+	}
+	var open func() (recordReader, error)
+	switch p.Source {
+	case SourceFile, "":
+		path := p.FilePath
+		if path == "" {
+			path = defaultFilePath
+		}
+		open = func() (recordReader, error) { return openFile(path, p.Log) }
+	case SourceSocket:
+		open = openSocket
+	default:
+		return fmt.Errorf("unknown audit source %q", p.Source)
+	}
+	host, _ := os.Hostname()
+	p.src = &auditEvents{
+		plugin:      p,
+		host:        host,
+		keys:        p.Keys,
+		recordTypes: p.RecordTypes,
+		open:        open,
+		now:         time.Now,
+		done:        make(chan struct{}),
+	}
+	return nil
+}
+
+func (p *Plugin) FindLogSrc() []logs.LogSrc {
+	if p.src == nil || p.srcFound {
+		return nil
+	}
+	p.srcFound = true
+	return []logs.LogSrc{p.src}
+}
+
+func (p *Plugin) Stop() {
+	if p.src != nil {
+		p.src.Stop()
+	}
+}
+
+// recordReader reads the records of the audit log.
+type recordReader interface {
+	// Read returns the next record, and blocks until there is one or the reader is closed.
+	Read() (record, error)
+	Close() error
+}
+
+// auditEvents is the log source of the plugin.
+type auditEvents struct {
+	plugin      *Plugin
+	host        string
+	keys        []string
+	recordTypes []string
+	open        func() (recordReader, error)
+	now         func() time.Time
+
+	outputFn  func(logs.LogEvent)
+	startOnce sync.Once
+	stopOnce  sync.Once
+	done      chan struct{}
+
+	mu     sync.Mutex
+	reader recordReader
+
+	// pending are the records of the events which are not complete yet, by serial.
+	pending map[uint64]*pendingEvent
+}
+
+type pendingEvent struct {
+	records []record
+	updated time.Time
+}
+
+var _ logs.LogSrc = (*auditEvents)(nil)
+
+func (a *auditEvents) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	a.outputFn = fn
+	a.startOnce.Do(func() { go a.run() })
+}
+
+func (a *auditEvents) Group() string {
+	return a.plugin.LogGroupName
+}
+
+func (a *auditEvents) Stream() string {
+	return a.plugin.LogStreamName
+}
+
+func (a *auditEvents) Destination() string {
+	return a.plugin.Destination
+}
+
+func (a *auditEvents) Description() string {
+	return "audit events"
+}
+
+func (a *auditEvents) Retention() int {
+	return a.plugin.Retention
+}
+
+func (a *auditEvents) Class() string {
+	return a.plugin.LogGroupClass
+}
+
+func (a *auditEvents) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+// Stop closes the audit log, which unblocks the pending read.
+func (a *auditEvents) Stop() {
+	a.stopOnce.Do(func() {
+		close(a.done)
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if a.reader != nil {
+			a.reader.Close()
+		}
+	})
+}
+
+func (a *auditEvents) run() {
+	for {
+		if err := a.follow(); err != nil {
+			log.Printf("W! [auditd] Unable to read the audit log, retrying in %v: %v", retryInterval, err)
+		}
+		select {
+		case <-a.done:
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// follow reads the records of the audit log until it is closed or fails, and writes their events.
+func (a *auditEvents) follow() error {
+	reader, err := a.open()
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	select {
+	case <-a.done:
+		a.mu.Unlock()
+		return reader.Close()
+	default:
+	}
+	a.reader = reader
+	a.mu.Unlock()
+	defer reader.Close()
+
+	records := make(chan record)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			r, err := reader.Read()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case records <- r:
+			case <-a.done:
+				return
+			}
+		}
+	}()
+
+	a.pending = map[uint64]*pendingEvent{}
+	ticker := time.NewTicker(settleTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case r := <-records:
+			a.add(r)
+		case <-ticker.C:
+			a.flush(a.now().Add(-settleTime))
+		case err = <-errs:
+			a.flush(a.now())
+			select {
+			case <-a.done:
+				return nil
+			default:
+			}
+			return err
+		case <-a.done:
+			return nil
+		}
+	}
+}
+
+// add adds a record to its event, and writes the event when the record ends it.
+func (a *auditEvents) add(r record) {
+	e, ok := a.pending[r.serial]
+	if r.typ == typeEOE {
+		if ok {
+			delete(a.pending, r.serial)
+			a.publish(e.records)
+		}
+		return
+	}
+	if !ok {
+		if len(a.pending) >= maxPending {
+			oldest := r.serial
+			for serial := range a.pending {
+				oldest = min(oldest, serial)
+			}
+			a.publish(a.pending[oldest].records)
+			delete(a.pending, oldest)
+		}
+		e = &pendingEvent{}
+		a.pending[r.serial] = e
+	}
+	e.records = append(e.records, r)
+	e.updated = a.now()
+}
+
+// flush writes the events which did not get a record since before.
+func (a *auditEvents) flush(before time.Time) {
+	var serials []uint64
+	for serial, e := range a.pending {
+		if !e.updated.After(before) {
+			serials = append(serials, serial)
+		}
+	}
+	slices.Sort(serials)
+	for _, serial := range serials {
+		a.publish(a.pending[serial].records)
+		delete(a.pending, serial)
+	}
+}
+
+// newEvent returns the event of its records.
+func newEvent(records []record, host string) Event {
+	e := Event{
+		Time:    records[0].time,
+		Serial:  records[0].serial,
+		Host:    host,
+		Type:    records[0].typ,
+		Records: make([]map[string]string, 0, len(records)),
+	}
+	// the fields of the process are taken from the first record which has them, which is the SYSCALL record of the
+	// events of the system calls
+	first := func(key string) string {
+		for _, r := range records {
+			if v, ok := r.fields[key]; ok {
+				return v
+			}
+		}
+		return ""
+	}
+	for _, r := range records {
+		fields := make(map[string]string, len(r.fields)+1)
+		for k, v := range r.fields {
+			fields[k] = v
+		}
+		fields[typeField] = r.typ
+		e.Records = append(e.Records, fields)
+		if r.typ == "PROCTITLE" {
+			e.Command = strings.TrimSpace(strings.ReplaceAll(r.fields["proctitle"], "\x00", " "))
+		}
+	}
+	if key := first("key"); key != "" {
+		e.Keys = strings.Split(key, keySeparator)
+	}
+	result := first("success")
+	if result == "" {
+		// the result of the events of user space programs, e.g. res=success
+		result = first("res")
+	}
+	switch result {
+	case "yes", "success", "1":
+		e.Result = "success"
+	case "no", "failed", "0":
+		e.Result = "failure"
+	}
+	// the name of the system call when auditd interprets the fields
+	if e.Syscall = first("SYSCALL"); e.Syscall == "" {
+		e.Syscall = first("syscall")
+	}
+	e.PID = first("pid")
+	e.UID = first("uid")
+	e.AUID = first("auid")
+	e.Comm = first("comm")
+	e.Exe = first("exe")
+	return e
+}
+
+// match returns true if the event has one of the keys or record types, or if neither are set.
+func (a *auditEvents) match(e Event) bool {
+	if len(a.keys) == 0 && len(a.recordTypes) == 0 {
+		return true
+	}
+	for _, key := range e.Keys {
+		if slices.Contains(a.keys, key) {
+			return true
+		}
+	}
+	for _, r := range e.Records {
+		if slices.Contains(a.recordTypes, r[typeField]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *auditEvents) publish(records []record) {
+	e := newEvent(records, a.host)
+	if !a.match(e) {
+		return
+	}
+	content, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("E! [auditd] Unable to encode event: %v", err)
+		return
+	}
+	a.outputFn(&logEvent{msg: string(content), t: e.Time})
+}
+
+// fileReader follows the log of auditd across its rotations.
+type fileReader struct {
+	tailer *tail.Tail
+}
+
+// openFile opens the log of auditd after its last record, so that the events logged before the agent started are not
+// written again when it restarts.
+func openFile(path string, logger telegraf.Logger) (recordReader, error) {
+	tailer, err := tail.TailFile(path, tail.Config{
+		ReOpen:   true,
+		Follow:   true,
+		Location: &tail.SeekInfo{Whence: io.SeekEnd},
+		Poll:     true,
+		Logger:   logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &fileReader{tailer: tailer}, nil
+}
+
+func (f *fileReader) Read() (record, error) {
+	for line := range f.tailer.Lines {
+		if line.Err != nil {
+			continue
+		}
+		if r, err := parseLine(line.Text); err == nil {
+			return r, nil
+		}
+	}
+	if err := f.tailer.Err(); err != nil {
+		return record{}, err
+	}
+	return record{}, io.EOF
+}
+
+func (f *fileReader) Close() error {
+	return f.tailer.Stop()
+}
+
+type logEvent struct {
+	msg string
+	t   time.Time
+}
+
+func (e *logEvent) Message() string {
+	return e.msg
+}
+
+func (e *logEvent) Time() time.Time {
+	return e.t
+}
+
+func (e *logEvent) Done() {}
+
+func init() {
+	inputs.Add("auditd", func() telegraf.Input {
+		return &Plugin{
+			Source:      SourceFile,
+			FilePath:    defaultFilePath,
+			Destination: "cloudwatchlogs",
+		}
+	})
+}
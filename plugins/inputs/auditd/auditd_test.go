@@ -0,0 +1,241 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package auditd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	syscallLine   = `type=SYSCALL msg=audit(1700000000.123:456): arch=c000003e syscall=59 success=yes exit=0 a0=55d0 a1=10 ppid=100 pid=101 auid=1000 uid=0 comm="sudo" exe="/usr/bin/sudo" key="privileged"` + "\x1d" + `ARCH=x86_64 SYSCALL=execve AUID="alice" UID="root"`
+	execveLine    = `type=EXECVE msg=audit(1700000000.123:456): argc=3 a0="ls" a1=2D6C20612062 a2="/"`
+	proctitleLine = `type=PROCTITLE msg=audit(1700000000.123:456): proctitle=7375646F006C73002F`
+	loginLine     = `type=USER_LOGIN msg=audit(1700000001.000:457): pid=200 uid=0 auid=1000 ses=3 msg='op=login id=1000 exe="/usr/sbin/sshd" hostname=10.0.0.1 addr=10.0.0.1 terminal=ssh res=failed'`
+)
+
+func TestParseLine(t *testing.T) {
+	r, err := parseLine(syscallLine)
+	require.NoError(t, err)
+	assert.Equal(t, "SYSCALL", r.typ)
+	assert.Equal(t, uint64(456), r.serial)
+	assert.Equal(t, time.UnixMilli(1700000000123).UTC(), r.time)
+	assert.Equal(t, "privileged", r.fields["key"])
+	assert.Equal(t, "/usr/bin/sudo", r.fields["exe"])
+	// the arguments of the system calls are numbers
+	assert.Equal(t, "10", r.fields["a1"])
+	// the fields auditd interprets
+	assert.Equal(t, "execve", r.fields["SYSCALL"])
+	assert.Equal(t, "alice", r.fields["AUID"])
+
+	r, err = parseLine(execveLine)
+	require.NoError(t, err)
+	assert.Equal(t, "-l a b", r.fields["a1"])
+	assert.Equal(t, "/", r.fields["a2"])
+
+	r, err = parseLine(loginLine)
+	require.NoError(t, err)
+	assert.Equal(t, "USER_LOGIN", r.typ)
+	assert.Equal(t, "failed", r.fields["res"])
+	assert.Equal(t, "10.0.0.1", r.fields["hostname"])
+	assert.NotContains(t, r.fields, "msg")
+
+	r, err = parseLine(`node=host-1 type=UNKNOWN[1327] msg=audit(1700000000.123:456): proctitle="top" key=(null)`)
+	require.NoError(t, err)
+	assert.Equal(t, "PROCTITLE", r.typ)
+	assert.Equal(t, map[string]string{"proctitle": "top"}, r.fields)
+
+	_, err = parseLine("not a record")
+	assert.ErrorIs(t, err, errNoHeader)
+	_, err = parseLine("type=SYSCALL msg=audit(malformed")
+	assert.ErrorIs(t, err, errNoHeader)
+}
+
+func TestNewEvent(t *testing.T) {
+	var records []record
+	for _, line := range []string{syscallLine, execveLine, proctitleLine} {
+		r, err := parseLine(line)
+		require.NoError(t, err)
+		records = append(records, r)
+	}
+	e := newEvent(records, "host-1")
+	assert.Equal(t, time.UnixMilli(1700000000123).UTC(), e.Time)
+	assert.Equal(t, uint64(456), e.Serial)
+	assert.Equal(t, "host-1", e.Host)
+	assert.Equal(t, "SYSCALL", e.Type)
+	assert.Equal(t, []string{"privileged"}, e.Keys)
+	assert.Equal(t, "success", e.Result)
+	assert.Equal(t, "execve", e.Syscall)
+	assert.Equal(t, "101", e.PID)
+	assert.Equal(t, "0", e.UID)
+	assert.Equal(t, "1000", e.AUID)
+	assert.Equal(t, "sudo", e.Comm)
+	assert.Equal(t, "/usr/bin/sudo", e.Exe)
+	assert.Equal(t, "sudo ls /", e.Command)
+	require.Len(t, e.Records, 3)
+	assert.Equal(t, "EXECVE", e.Records[1][typeField])
+
+	r, err := parseLine(loginLine)
+	require.NoError(t, err)
+	e = newEvent([]record{r}, "")
+	assert.Equal(t, "failure", e.Result)
+	assert.Empty(t, e.Keys)
+
+	// an event which matched several rules
+	r, err = parseLine(`type=SYSCALL msg=audit(1700000000.123:456): syscall=2 key=6964656E7469747901706173737764`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"identity", "passwd"}, newEvent([]record{r}, "").Keys)
+}
+
+func TestMatch(t *testing.T) {
+	login := Event{Records: []map[string]string{{typeField: "USER_LOGIN"}}}
+	privileged := Event{Keys: []string{"privileged"}, Records: []map[string]string{{typeField: "SYSCALL"}}}
+	assert.True(t, (&auditEvents{}).match(login))
+	a := &auditEvents{keys: []string{"privileged", "identity"}}
+	assert.True(t, a.match(privileged))
+	assert.False(t, a.match(login))
+	a.recordTypes = []string{"USER_LOGIN"}
+	assert.True(t, a.match(login))
+	assert.False(t, a.match(Event{Keys: []string{"other"}}))
+}
+
+// fakeReader returns the records of the lines, and blocks until it is closed once they are all read.
+type fakeReader struct {
+	lines  chan string
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (r *fakeReader) Read() (record, error) {
+	select {
+	case line := <-r.lines:
+		return parseLine(line)
+	case <-r.closed:
+		return record{}, os.ErrClosed
+	}
+}
+
+func (r *fakeReader) Close() error {
+	r.once.Do(func() { close(r.closed) })
+	return nil
+}
+
+func TestPlugin(t *testing.T) {
+	p := &Plugin{
+		LogGroupName:  "group",
+		LogStreamName: "stream",
+		Destination:   "cloudwatchlogs",
+		Retention:     7,
+		Keys:          []string{"privileged"},
+	}
+	require.NoError(t, p.Start(nil))
+	srcs := p.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Empty(t, p.FindLogSrc())
+
+	src := srcs[0]
+	assert.Equal(t, "group", src.Group())
+	assert.Equal(t, "stream", src.Stream())
+	assert.Equal(t, "cloudwatchlogs", src.Destination())
+	assert.Equal(t, 7, src.Retention())
+
+	reader := &fakeReader{lines: make(chan string, 10), closed: make(chan struct{})}
+	for _, line := range []string{
+		loginLine,
+		syscallLine,
+		execveLine,
+		`type=EOE msg=audit(1700000000.123:456): `,
+		`type=SYSCALL msg=audit(1700000002.000:458): syscall=2 success=no key="privileged"`,
+	} {
+		reader.lines <- line
+	}
+	p.src.open = func() (recordReader, error) {
+		return reader, nil
+	}
+	events := make(chan logs.LogEvent, 10)
+	src.SetOutput(func(e logs.LogEvent) { events <- e })
+
+	// the login is filtered out, the event with the EOE record is written first and the last one when its records
+	// are not waited for anymore
+	for _, want := range []uint64{456, 458} {
+		select {
+		case e := <-events:
+			var got Event
+			require.NoError(t, json.Unmarshal([]byte(e.Message()), &got))
+			assert.Equal(t, want, got.Serial)
+			assert.Equal(t, got.Time, e.Time())
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "no event published", "serial %d", want)
+		}
+	}
+
+	p.Stop()
+	select {
+	case <-reader.closed:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "audit log not closed")
+	}
+	assert.Empty(t, events)
+}
+
+func TestAddMaxPending(t *testing.T) {
+	var published []uint64
+	a := &auditEvents{pending: map[uint64]*pendingEvent{}, now: time.Now}
+	a.outputFn = func(e logs.LogEvent) {
+		var got Event
+		require.NoError(t, json.Unmarshal([]byte(e.Message()), &got))
+		published = append(published, got.Serial)
+	}
+	for serial := uint64(1); serial <= maxPending+1; serial++ {
+		a.add(record{typ: "SYSCALL", serial: serial})
+	}
+	assert.Equal(t, []uint64{1}, published)
+	assert.Len(t, a.pending, maxPending)
+	a.flush(time.Now())
+	assert.Len(t, published, maxPending+1)
+	assert.Empty(t, a.pending)
+}
+
+func TestFileReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	require.NoError(t, os.WriteFile(path, []byte(loginLine+"\n"), 0600))
+	reader, err := openFile(path, nil)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	records := make(chan record)
+	go func() {
+		if r, err := reader.Read(); err == nil {
+			records <- r
+		}
+	}()
+	// the file is appended to until the tailer reached its end, as the records logged before are not read
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case r := <-records:
+			assert.Equal(t, uint64(456), r.serial)
+			return
+		case <-ticker.C:
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+			require.NoError(t, err)
+			_, err = f.WriteString("not a record\n" + syscallLine + "\n")
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+		case <-timeout:
+			require.Fail(t, "no record read")
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package auditd
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	typeField = "type"
+	// typeEOE is the record which ends the events made of several records.
+	typeEOE = "EOE"
+	// enrichedSeparator separates the raw fields of a record from those auditd interprets with log_format = ENRICHED,
+	// e.g. UID="root".
+	enrichedSeparator = "\x1d"
+	// keySeparator separates the keys of an event which matched several rules.
+	keySeparator = "\x01"
+	nullValue    = "(null)"
+)
+
+var (
+	// e.g. "audit(1700000000.123:456): "
+	headerPattern = regexp.MustCompile(`^audit\((\d+)\.(\d{3}):(\d+)\):\s*`)
+	// e.g. "UNKNOWN[1337]", the type auditd logs the records whose type it does not know with.
+	unknownTypePattern = regexp.MustCompile(`^UNKNOWN\[(\d+)\]$`)
+	// hexPattern matches the values the kernel encodes in hexadecimal, as they contain spaces, quotes or control
+	// characters.
+	hexPattern = regexp.MustCompile(`^(?:[0-9A-F]{2})+$`)
+
+	errNoHeader = errors.New("no audit header")
+)
+
+// hexFields are the fields which are written in hexadecimal when they are not quoted.
+var hexFields = map[string]bool{
+	"acct":      true,
+	"cmd":       true,
+	"comm":      true,
+	"cwd":       true,
+	"data":      true,
+	"exe":       true,
+	"key":       true,
+	"name":      true,
+	"path":      true,
+	"proctitle": true,
+}
+
+// recordTypes are the names of the record types, as auditd logs them, by their number on the audit socket.
+var recordTypes = map[uint16]string{
+	1100: "USER_AUTH",
+	1101: "USER_ACCT",
+	1102: "USER_MGMT",
+	1103: "CRED_ACQ",
+	1104: "CRED_DISP",
+	1105: "USER_START",
+	1106: "USER_END",
+	1107: "USER_AVC",
+	1108: "USER_CHAUTHTOK",
+	1109: "USER_ERR",
+	1110: "CRED_REFR",
+	1111: "USYS_CONFIG",
+	1112: "USER_LOGIN",
+	1113: "USER_LOGOUT",
+	1114: "ADD_USER",
+	1115: "DEL_USER",
+	1116: "ADD_GROUP",
+	1117: "DEL_GROUP",
+	1123: "USER_CMD",
+	1124: "USER_TTY",
+	1300: "SYSCALL",
+	1302: "PATH",
+	1303: "IPC",
+	1304: "SOCKETCALL",
+	1305: "CONFIG_CHANGE",
+	1306: "SOCKADDR",
+	1307: "CWD",
+	1309: "EXECVE",
+	1318: "OBJ_PID",
+	1319: "TTY",
+	1320: typeEOE,
+	1321: "BPRM_FCAPS",
+	1322: "CAPSET",
+	1323: "MMAP",
+	1324: "NETFILTER_PKT",
+	1325: "NETFILTER_CFG",
+	1326: "SECCOMP",
+	1327: "PROCTITLE",
+	1328: "FEATURE_CHANGE",
+	1329: "REPLACE",
+	1330: "KERN_MODULE",
+	1331: "FANOTIFY",
+	1334: "BPF",
+	1400: "AVC",
+	1700: "ANOM_PROMISCUOUS",
+	1701: "ANOM_ABEND",
+	1702: "ANOM_LINK",
+}
+
+// recordType returns the name of a record type on the audit socket, or UNKNOWN[<type>] as auditd logs it.
+func recordType(t uint16) string {
+	if name, ok := recordTypes[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN[%d]", t)
+}
+
+// record is one line of the audit log. The records of an event share its time and serial.
+type record struct {
+	typ    string
+	time   time.Time
+	serial uint64
+	fields map[string]string
+}
+
+// parseLine returns the record of a line of audit.log, e.g.
+// `type=SYSCALL msg=audit(1700000000.123:456): arch=c000003e syscall=59 success=yes ... key="exec"`.
+func parseLine(line string) (record, error) {
+	prefix, body, ok := strings.Cut(line, "msg=audit(")
+	if !ok {
+		return record{}, errNoHeader
+	}
+	typ := parseFields(prefix, nil)[typeField]
+	if m := unknownTypePattern.FindStringSubmatch(typ); m != nil {
+		// the agent can know the type when auditd does not, e.g. when it is older than the kernel
+		if t, err := strconv.ParseUint(m[1], 10, 16); err == nil {
+			typ = recordType(uint16(t))
+		}
+	}
+	return parseRecord(typ, "audit("+body)
+}
+
+// parseRecord returns the record of the given type from its message on the audit socket, e.g.
+// `audit(1700000000.123:456): arch=c000003e syscall=59 ...`.
+func parseRecord(typ, message string) (record, error) {
+	m := headerPattern.FindStringSubmatch(message)
+	if m == nil || typ == "" {
+		return record{}, errNoHeader
+	}
+	seconds, _ := strconv.ParseInt(m[1], 10, 64)
+	millis, _ := strconv.ParseInt(m[2], 10, 64)
+	serial, err := strconv.ParseUint(m[3], 10, 64)
+	if err != nil {
+		return record{}, err
+	}
+	raw, enriched, _ := strings.Cut(message[len(m[0]):], enrichedSeparator)
+	fields := parseFields(raw, func(key string) bool {
+		return hexFields[key] || typ == "EXECVE" && isArgument(key)
+	})
+	for k, v := range parseFields(enriched, nil) {
+		fields[k] = v
+	}
+	return record{
+		typ:    typ,
+		time:   time.Unix(seconds, millis*int64(time.Millisecond)).UTC(),
+		serial: serial,
+		fields: fields,
+	}, nil
+}
+
+// parseFields returns the key=value fields of a record. The values of the messages of user space programs, e.g.
+// msg='op=login acct="alice" res=success', are parsed into the fields of the record. The unquoted values of the
+// fields decode returns true for are decoded when they are in hexadecimal.
+func parseFields(s string, decode func(key string) bool) map[string]string {
+	fields := map[string]string{}
+	for {
+		s = strings.TrimLeft(s, " \n\x00")
+		if s == "" {
+			return fields
+		}
+		key, rest, ok := strings.Cut(s, "=")
+		if !ok || strings.ContainsRune(key, ' ') {
+			// not a field, e.g. the trailing text of a malformed record
+			return fields
+		}
+		var value string
+		var quoted bool
+		switch rest[0:min(1, len(rest))] {
+		case `"`, `'`:
+			end := strings.IndexByte(rest[1:], rest[0])
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			value, s = rest[1:1+end], rest[min(len(rest), end+2):]
+			quoted = true
+			if rest[0] == '\'' && key == "msg" {
+				for k, v := range parseFields(value, decode) {
+					fields[k] = v
+				}
+				continue
+			}
+		default:
+			end := strings.IndexAny(rest, " \n\x00")
+			if end < 0 {
+				end = len(rest)
+			}
+			value, s = rest[:end], rest[end:]
+		}
+		if value == nullValue {
+			continue
+		}
+		if !quoted && decode != nil && decode(key) && hexPattern.MatchString(value) {
+			if decoded, err := hex.DecodeString(value); err == nil {
+				value = string(decoded)
+			}
+		}
+		fields[key] = value
+	}
+}
+
+// isArgument returns true for the arguments of an EXECVE record, e.g. a0 and a1, which are encoded in hexadecimal
+// like the untrusted strings.
+func isArgument(key string) bool {
+	if len(key) < 2 || key[0] != 'a' {
+		return false
+	}
+	_, err := strconv.Atoi(key[1:])
+	return err == nil
+}
@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+// +build linux
+
+package auditd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// auditNetlinkReadLog is the multicast group of the audit socket the kernel sends a copy of the records to, for
+	// the readers other than auditd. Reading it needs CAP_AUDIT_READ.
+	auditNetlinkReadLog = 1
+	// maxMessageLength is the longest message of the audit socket, MAX_AUDIT_MESSAGE_LENGTH.
+	maxMessageLength = 8970
+	// firstRecordType is the first type of the records, the types below it are those of the control messages.
+	firstRecordType = 1100
+)
+
+// socketReader reads the records the kernel multicasts on the audit socket.
+type socketReader struct {
+	file *os.File
+	buf  []byte
+}
+
+// openSocket joins the multicast group of the audit socket, which gets the records of the rules loaded with auditctl
+// whether or not auditd runs.
+func openSocket() (recordReader, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC|unix.SOCK_NONBLOCK, unix.NETLINK_AUDIT)
+	if err != nil {
+		return nil, err
+	}
+	if err = unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: auditNetlinkReadLog}); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	// the file is non-blocking, so that closing it unblocks the pending read
+	return &socketReader{file: os.NewFile(uintptr(fd), "audit"), buf: make([]byte, maxMessageLength+unix.NLMSG_HDRLEN)}, nil
+}
+
+// Read returns the record of the next message. Each message of the multicast group is one record, whose length in
+// the header leaves out the header on some kernels, so it is not used.
+func (s *socketReader) Read() (record, error) {
+	for {
+		n, err := s.file.Read(s.buf)
+		if err != nil {
+			return record{}, err
+		}
+		if n < unix.NLMSG_HDRLEN {
+			continue
+		}
+		typ := binary.NativeEndian.Uint16(s.buf[4:6])
+		if typ < firstRecordType {
+			continue
+		}
+		message := string(bytes.TrimRight(s.buf[unix.NLMSG_HDRLEN:n], "\x00\n"))
+		if r, err := parseRecord(recordType(typ), message); err == nil {
+			return r, nil
+		}
+	}
+}
+
+func (s *socketReader) Close() error {
+	return s.file.Close()
+}
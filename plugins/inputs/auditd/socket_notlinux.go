@@ -0,0 +1,15 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+// +build !linux
+
+package auditd
+
+import (
+	"errors"
+)
+
+func openSocket() (recordReader, error) {
+	return nil, errors.ErrUnsupported
+}
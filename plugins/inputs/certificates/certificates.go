@@ -0,0 +1,180 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+import (
+	"crypto/sha1" // nolint:gosec
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/globpath"
+)
+
+const (
+	measurement = "certificates"
+
+	subjectTag    = "subject"
+	thumbprintTag = "thumbprint"
+	sourceTag     = "source"
+
+	fieldDaysToExpiry = "days_to_expiry"
+
+	// maxFileSize bounds the files read, so that a glob matching something other than certificates does not load a
+	// large file into memory.
+	maxFileSize = 4 << 20
+)
+
+var errStoresNotSupported = errors.New("certificate stores are only supported on Windows")
+
+// Certificates reports how many days are left before the certificates of files and of the Windows certificate stores
+// expire, so that an alarm can fire before a certificate expires and breaks the services using it.
+type Certificates struct {
+	Paths  []string        `toml:"paths"`
+	Stores []string        `toml:"stores"`
+	Log    telegraf.Logger `toml:"-"`
+
+	globs []*globpath.GlobPath
+	now   func() time.Time
+}
+
+func (c *Certificates) Description() string {
+	return "Report the days left before the certificates of files and certificate stores expire"
+}
+
+func (c *Certificates) SampleConfig() string {
+	return `
+  ## Globs of the PEM or DER files with the certificates. ** matches any number of directories.
+  paths = ["/etc/pki/tls/certs/*.pem", "/etc/nginx/ssl/*.crt"]
+  ## Names of the certificate stores of the local machine, e.g. "My" for the personal store. Only supported on Windows.
+  stores = []
+`
+}
+
+func (c *Certificates) Init() error {
+	c.globs = make([]*globpath.GlobPath, 0, len(c.Paths))
+	for _, pattern := range c.Paths {
+		g, err := globpath.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+		c.globs = append(c.globs, g)
+	}
+	if len(c.Stores) > 0 && !storesSupported {
+		return errStoresNotSupported
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+	return nil
+}
+
+func (c *Certificates) Gather(acc telegraf.Accumulator) error {
+	now := c.now()
+	for _, g := range c.globs {
+		for path, info := range g.Match() {
+			if !info.Mode().IsRegular() {
+				continue
+			}
+			if info.Size() > maxFileSize {
+				acc.AddError(fmt.Errorf("certificates %s: file is larger than %d bytes", path, maxFileSize))
+				continue
+			}
+			certs, err := readFile(path)
+			if err != nil {
+				acc.AddError(fmt.Errorf("certificates %s: %w", path, err))
+				continue
+			}
+			c.addCertificates(acc, path, certs, now)
+		}
+	}
+	for _, store := range c.Stores {
+		certs, err := readStore(store)
+		if err != nil {
+			acc.AddError(fmt.Errorf("certificates store %s: %w", store, err))
+			continue
+		}
+		c.addCertificates(acc, store, certs, now)
+	}
+	return nil
+}
+
+func (c *Certificates) addCertificates(acc telegraf.Accumulator, source string, certs []*x509.Certificate, now time.Time) {
+	for _, cert := range certs {
+		tags := map[string]string{
+			subjectTag:    subject(cert),
+			thumbprintTag: thumbprint(cert),
+			sourceTag:     source,
+		}
+		// Expired certificates are reported with a negative number of days, so that they keep alarming until they
+		// are replaced.
+		fields := map[string]interface{}{
+			fieldDaysToExpiry: cert.NotAfter.Sub(now).Hours() / 24,
+		}
+		acc.AddGauge(measurement, fields, tags, now)
+	}
+}
+
+// readFile parses the certificates of a file, either PEM blocks or a DER certificate. PEM blocks that are not
+// certificates, like the private key of a certificate, are skipped.
+func readFile(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseCertificates(data)
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	var foundPEM bool
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		foundPEM = true
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if foundPEM {
+		return certs, nil
+	}
+	return x509.ParseCertificates(data)
+}
+
+// subject is the common name of the certificate, or its full subject when it has none.
+func subject(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	return cert.Subject.String()
+}
+
+// thumbprint is the SHA-1 hash of the certificate, the way Windows and the AWS console identify certificates.
+func thumbprint(cert *x509.Certificate) string {
+	sum := sha1.Sum(cert.Raw) // nolint:gosec
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func init() {
+	inputs.Add("certificates", func() telegraf.Input {
+		return &Certificates{}
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGather(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	web := createCertificate(t, pkix.Name{CommonName: "www.example.com"}, now.Add(30*24*time.Hour))
+	intermediate := createCertificate(t, pkix.Name{Organization: []string{"Example"}}, now.Add(365*24*time.Hour))
+	expired := createCertificate(t, pkix.Name{CommonName: "old.example.com"}, now.Add(-36*time.Hour))
+
+	bundle := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: web.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intermediate.Raw})...)
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})...)
+	writeFile(t, filepath.Join(dir, "web.pem"), bundle)
+	writeFile(t, filepath.Join(dir, "nested", "old.crt"), expired.Raw)
+	writeFile(t, filepath.Join(dir, "invalid.pem"), []byte("not a certificate"))
+
+	c := &Certificates{
+		Paths: []string{filepath.Join(dir, "*.pem"), filepath.Join(dir, "**", "*.crt")},
+		Log:   testutil.Logger{},
+		now:   func() time.Time { return now },
+	}
+	require.NoError(t, c.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{fieldDaysToExpiry: 30.0}, map[string]string{
+		subjectTag:    "www.example.com",
+		thumbprintTag: thumbprint(web),
+		sourceTag:     filepath.Join(dir, "web.pem"),
+	})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{fieldDaysToExpiry: 365.0}, map[string]string{
+		subjectTag:    "O=Example",
+		thumbprintTag: thumbprint(intermediate),
+		sourceTag:     filepath.Join(dir, "web.pem"),
+	})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{fieldDaysToExpiry: -1.5}, map[string]string{
+		subjectTag:    "old.example.com",
+		thumbprintTag: thumbprint(expired),
+		sourceTag:     filepath.Join(dir, "nested", "old.crt"),
+	})
+	assert.Len(t, acc.Metrics, 3)
+	assert.Len(t, acc.Errors, 1)
+}
+
+func TestThumbprint(t *testing.T) {
+	cert := createCertificate(t, pkix.Name{CommonName: "www.example.com"}, time.Now())
+	assert.Regexp(t, "^[0-9A-F]{40}$", thumbprint(cert))
+}
+
+func TestInitWithStores(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("certificate stores are supported on Windows")
+	}
+	c := &Certificates{Stores: []string{"My"}}
+	assert.ErrorIs(t, c.Init(), errStoresNotSupported)
+}
+
+func TestInitWithInvalidGlob(t *testing.T) {
+	c := &Certificates{Paths: []string{"/etc/ssl/**/[a"}}
+	assert.Error(t, c.Init())
+}
+
+func createCertificate(t *testing.T, subject pkix.Name, notAfter time.Time) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      subject,
+		NotBefore:    notAfter.Add(-2 * 365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
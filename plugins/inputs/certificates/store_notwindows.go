@@ -0,0 +1,14 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package certificates
+
+import "crypto/x509"
+
+const storesSupported = false
+
+func readStore(string) ([]*x509.Certificate, error) {
+	return nil, errStoresNotSupported
+}
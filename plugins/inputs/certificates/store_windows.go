@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package certificates
+
+import (
+	"crypto/x509"
+	"errors"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const storesSupported = true
+
+// readStore returns the certificates of a system store of the local machine. The store is opened read only, so the
+// agent does not create a store that does not exist.
+func readStore(name string) ([]*x509.Certificate, error) {
+	storeName, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+	store, err := windows.CertOpenStore(windows.CERT_STORE_PROV_SYSTEM_W, 0, 0,
+		windows.CERT_SYSTEM_STORE_LOCAL_MACHINE|windows.CERT_STORE_READONLY_FLAG|windows.CERT_STORE_OPEN_EXISTING_FLAG,
+		uintptr(unsafe.Pointer(storeName)))
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CertCloseStore(store, 0)
+
+	var certs []*x509.Certificate
+	var ctx *windows.CertContext
+	for {
+		ctx, err = windows.CertEnumCertificatesInStore(store, ctx)
+		if err != nil {
+			// The enumeration ends with CRYPT_E_NOT_FOUND, after which the context has been freed.
+			if errors.Is(err, windows.Errno(windows.CRYPT_E_NOT_FOUND)) {
+				return certs, nil
+			}
+			return nil, err
+		}
+		// The encoded certificate is owned by the context, which is freed by the next call, so it is copied.
+		encoded := unsafe.Slice(ctx.EncodedCert, ctx.Length)
+		cert, err := x509.ParseCertificate(append([]byte(nil), encoded...))
+		if err != nil {
+			// A certificate the Go parser rejects does not prevent the others of the store from being reported.
+			continue
+		}
+		certs = append(certs, cert)
+	}
+}
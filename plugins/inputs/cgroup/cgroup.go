@@ -0,0 +1,413 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroup
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+const (
+	measurement = "cgroup"
+	cgroupTag   = "cgroup"
+	userTag     = "user"
+
+	fieldCPULimit            = "cpu_limit"
+	fieldCPUUsagePercent     = "cpu_usage_percent"
+	fieldCPUThrottledPercent = "cpu_throttled_percent"
+	fieldMemoryLimit         = "memory_limit"
+	fieldMemoryUsed          = "memory_used"
+	fieldMemoryWorkingSet    = "memory_working_set"
+	fieldMemoryUsedPercent   = "memory_used_percent"
+
+	// unlimited is the value of cpu.max and memory.max when the cgroup has no limit.
+	unlimited = "max"
+
+	sliceSuffix = ".slice"
+	// userSlicePrefix is the prefix of the slices systemd-logind creates for the sessions and services of each user,
+	// e.g. user-1000.slice.
+	userSlicePrefix = "user-"
+)
+
+// cpuSample is the cumulative CPU of a cgroup at a collection, to report the usage since.
+type cpuSample struct {
+	time        time.Time
+	usageMicros uint64
+	periods     uint64
+	throttled   uint64
+}
+
+// Cgroup reports the CPU and memory of cgroups (v2) against their own limits rather than the capacity of the host. An
+// agent whose container is limited to 1 CPU on a 64 CPU host reports 100% when it uses all of it, not 1.5%.
+type Cgroup struct {
+	// Paths are the globs of the cgroups to report on, relative to the root of the cgroup hierarchy, e.g.
+	// "kubepods.slice/*" for the co-located workloads.
+	Paths []string `toml:"paths"`
+	// IncludeSelf reports on the cgroup of the agent.
+	IncludeSelf bool `toml:"include_self"`
+	// Slices reports on every systemd slice, e.g. system.slice and the slices of the batch jobs.
+	Slices bool `toml:"slices"`
+	// Users reports on the slice of each user, tagged with the name of the user.
+	Users bool            `toml:"users"`
+	Log   telegraf.Logger `toml:"-"`
+
+	root     string
+	procRoot string
+	now      func() time.Time
+	numCPU   int
+	previous map[string]cpuSample
+	// userNames caches the names of the users by UID.
+	userNames map[string]string
+}
+
+func (c *Cgroup) Description() string {
+	return "Report the CPU and memory utilization of cgroups against their own limits"
+}
+
+func (c *Cgroup) SampleConfig() string {
+	return `
+  ## Globs of the cgroups to report on, relative to the root of the cgroup hierarchy.
+  # paths = ["kubepods.slice/*"]
+  ## Report on the cgroup of the agent.
+  include_self = true
+  ## Report on every systemd slice.
+  # slices = false
+  ## Report on the slice of each user, with the name of the user.
+  # users = false
+`
+}
+
+func (c *Cgroup) Init() error {
+	if c.root == "" {
+		sysRoot := "/sys"
+		if hostSys := os.Getenv("HOST_SYS"); hostSys != "" {
+			sysRoot = hostSys
+		}
+		c.root = filepath.Join(sysRoot, "fs", "cgroup")
+	}
+	if c.procRoot == "" {
+		c.procRoot = "/proc"
+		if hostProc := os.Getenv("HOST_PROC"); hostProc != "" {
+			c.procRoot = hostProc
+		}
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+	if c.numCPU == 0 {
+		c.numCPU = runtime.NumCPU()
+	}
+	c.previous = map[string]cpuSample{}
+	c.userNames = map[string]string{}
+	return nil
+}
+
+func (c *Cgroup) Gather(acc telegraf.Accumulator) error {
+	if _, err := os.Stat(filepath.Join(c.root, "cgroup.controllers")); err != nil {
+		return fmt.Errorf("no cgroup v2 hierarchy in %s: %w", c.root, err)
+	}
+	cgroups, err := c.cgroups()
+	if err != nil {
+		return err
+	}
+	hostMemory, err := c.hostMemory()
+	if err != nil {
+		acc.AddError(fmt.Errorf("unable to read the memory of the host: %w", err))
+	}
+	seen := map[string]bool{}
+	for _, cgroup := range cgroups {
+		seen[cgroup] = true
+		fields := map[string]interface{}{}
+		dir := filepath.Join(c.root, cgroup)
+		if err = c.readCPU(cgroup, dir, fields); err != nil {
+			acc.AddError(fmt.Errorf("unable to read the CPU of cgroup %s: %w", cgroup, err))
+		}
+		if err = readMemory(dir, hostMemory, fields); err != nil {
+			acc.AddError(fmt.Errorf("unable to read the memory of cgroup %s: %w", cgroup, err))
+		}
+		if len(fields) != 0 {
+			tags := map[string]string{cgroupTag: cgroup}
+			if uid, ok := userSliceUID(cgroup); ok && c.Users {
+				tags[userTag] = c.userName(uid)
+			}
+			acc.AddGauge(measurement, fields, tags)
+		}
+	}
+	// the cgroups which are gone, e.g. of the pods which were deleted, are forgotten
+	for cgroup := range c.previous {
+		if !seen[cgroup] {
+			delete(c.previous, cgroup)
+		}
+	}
+	return nil
+}
+
+// cgroups returns the paths of the cgroups to report on, relative to the root and starting with "/".
+func (c *Cgroup) cgroups() ([]string, error) {
+	var cgroups []string
+	seen := map[string]bool{}
+	add := func(cgroup string) {
+		if !seen[cgroup] {
+			seen[cgroup] = true
+			cgroups = append(cgroups, cgroup)
+		}
+	}
+	if c.IncludeSelf {
+		self, err := c.self()
+		if err != nil {
+			return nil, fmt.Errorf("unable to find the cgroup of the agent: %w", err)
+		}
+		add(self)
+	}
+	for _, pattern := range c.Paths {
+		matches, err := filepath.Glob(filepath.Join(c.root, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cgroup path %q: %w", pattern, err)
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.IsDir() {
+				continue
+			}
+			rel, err := filepath.Rel(c.root, match)
+			if err != nil {
+				continue
+			}
+			add(filepath.Clean("/" + rel))
+		}
+	}
+	if c.Slices || c.Users {
+		slices, err := c.slices()
+		if err != nil {
+			return nil, fmt.Errorf("unable to find the systemd slices: %w", err)
+		}
+		for _, slice := range slices {
+			if _, ok := userSliceUID(slice); c.Slices || ok {
+				add(slice)
+			}
+		}
+	}
+	return cgroups, nil
+}
+
+// slices returns the systemd slices of the hierarchy. A slice only contains other slices, services and scopes, so
+// only the slices are walked.
+func (c *Cgroup) slices() ([]string, error) {
+	var slices []string
+	err := filepath.WalkDir(c.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == c.root {
+				return err
+			}
+			// the cgroups can be removed while they are walked
+			return nil
+		}
+		if !d.IsDir() || path == c.root {
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), sliceSuffix) {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(c.root, path)
+		if err == nil {
+			slices = append(slices, filepath.Clean("/"+rel))
+		}
+		return nil
+	})
+	return slices, err
+}
+
+// userSliceUID returns the UID of the user of a slice of systemd-logind, e.g. 1000 for /user.slice/user-1000.slice.
+func userSliceUID(cgroup string) (string, bool) {
+	name := filepath.Base(cgroup)
+	uid, ok := strings.CutPrefix(strings.TrimSuffix(name, sliceSuffix), userSlicePrefix)
+	if !ok || !strings.HasSuffix(name, sliceSuffix) {
+		return "", false
+	}
+	if _, err := strconv.ParseUint(uid, 10, 32); err != nil {
+		return "", false
+	}
+	return uid, true
+}
+
+// userName returns the name of the user, or the UID when the user is not known to the host, e.g. of a directory
+// service which cannot be reached.
+func (c *Cgroup) userName(uid string) string {
+	if name, ok := c.userNames[uid]; ok {
+		return name
+	}
+	name := uid
+	if u, err := user.LookupId(uid); err == nil {
+		name = u.Username
+	} else {
+		c.Log.Debugf("Unable to look up the user %s: %v", uid, err)
+	}
+	c.userNames[uid] = name
+	return name
+}
+
+// self returns the cgroup of the agent from the "0::<path>" line of /proc/self/cgroup. Within a cgroup namespace, as
+// in most containers, it is "/".
+func (c *Cgroup) self() (string, error) {
+	content, err := os.ReadFile(filepath.Join(c.procRoot, "self", "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			return filepath.Clean("/" + path), nil
+		}
+	}
+	return "", errors.New("not in a cgroup v2 hierarchy")
+}
+
+// hostMemory returns the MemTotal of the host, in bytes, which limits the cgroups without a memory limit.
+func (c *Cgroup) hostMemory() (uint64, error) {
+	f, err := os.Open(filepath.Join(c.procRoot, "meminfo"))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) >= 2 && parts[0] == "MemTotal:" {
+			total, err := strconv.ParseUint(parts[1], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return total * 1024, nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, errors.New("no MemTotal")
+}
+
+// readCPU reports the CPU limit of the cgroup in CPUs, the CPU it used since the previous collection as a share of
+// the limit, and the share of the periods in which it was throttled.
+func (c *Cgroup) readCPU(cgroup, dir string, fields map[string]interface{}) error {
+	limit := float64(c.numCPU)
+	if content, err := os.ReadFile(filepath.Join(dir, "cpu.max")); err == nil {
+		// e.g. "max 100000" or "50000 100000"
+		parts := strings.Fields(string(content))
+		if len(parts) == 2 && parts[0] != unlimited {
+			quota, quotaErr := strconv.ParseFloat(parts[0], 64)
+			period, periodErr := strconv.ParseFloat(parts[1], 64)
+			if quotaErr != nil || periodErr != nil || period == 0 {
+				return fmt.Errorf("invalid cpu.max %q", strings.TrimSpace(string(content)))
+			}
+			limit = min(quota/period, limit)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	fields[fieldCPULimit] = limit
+
+	stat, err := readKeyValues(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return err
+	}
+	sample := cpuSample{time: c.now(), usageMicros: stat["usage_usec"], periods: stat["nr_periods"], throttled: stat["nr_throttled"]}
+	previous, ok := c.previous[cgroup]
+	c.previous[cgroup] = sample
+	if !ok || sample.usageMicros < previous.usageMicros {
+		return nil
+	}
+	if elapsed := sample.time.Sub(previous.time); elapsed > 0 && limit > 0 {
+		used := time.Duration(sample.usageMicros-previous.usageMicros) * time.Microsecond
+		fields[fieldCPUUsagePercent] = 100 * used.Seconds() / (elapsed.Seconds() * limit)
+	}
+	if periods := sample.periods - previous.periods; sample.periods >= previous.periods && periods > 0 && sample.throttled >= previous.throttled {
+		fields[fieldCPUThrottledPercent] = 100 * float64(sample.throttled-previous.throttled) / float64(periods)
+	}
+	return nil
+}
+
+// readMemory reports the memory limit of the cgroup, the memory it uses and its working set, which leaves out the
+// inactive page cache the kernel reclaims before it reaches the limit, as a share of the limit.
+func readMemory(dir string, hostMemory uint64, fields map[string]interface{}) error {
+	current, err := readUint(filepath.Join(dir, "memory.current"))
+	if os.IsNotExist(err) {
+		// the memory controller is not enabled for the cgroup
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	limit := hostMemory
+	content, err := os.ReadFile(filepath.Join(dir, "memory.max"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if value := strings.TrimSpace(string(content)); err == nil && value != unlimited {
+		memoryMax, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid memory.max %q", value)
+		}
+		if limit == 0 || memoryMax < limit {
+			limit = memoryMax
+		}
+	}
+	workingSet := current
+	if stat, err := readKeyValues(filepath.Join(dir, "memory.stat")); err == nil {
+		if inactive := stat["inactive_file"]; inactive < workingSet {
+			workingSet -= inactive
+		} else {
+			workingSet = 0
+		}
+	}
+	fields[fieldMemoryUsed] = current
+	fields[fieldMemoryWorkingSet] = workingSet
+	if limit > 0 {
+		fields[fieldMemoryLimit] = limit
+		fields[fieldMemoryUsedPercent] = 100 * float64(workingSet) / float64(limit)
+	}
+	return nil
+}
+
+func readUint(path string) (uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+}
+
+// readKeyValues parses the "key value" lines of files like cpu.stat and memory.stat.
+func readKeyValues(path string) (map[string]uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]uint64{}
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		if values[key], err = strconv.ParseUint(strings.TrimSpace(value), 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid %s in %s: %w", key, filepath.Base(path), err)
+		}
+	}
+	return values, nil
+}
+
+func init() {
+	inputs.Add("cgroup", func() telegraf.Input {
+		return &Cgroup{IncludeSelf: true}
+	})
+}
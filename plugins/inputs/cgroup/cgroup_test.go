@@ -0,0 +1,189 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+}
+
+func newTestCgroup(t *testing.T) (*Cgroup, string, *time.Time) {
+	root := filepath.Join(t.TempDir(), "cgroup")
+	procRoot := filepath.Join(t.TempDir(), "proc")
+	writeFiles(t, root, map[string]string{"cgroup.controllers": "cpu memory\n"})
+	writeFiles(t, procRoot, map[string]string{"meminfo": "MemTotal:       16000000 kB\nMemFree:        8000000 kB\n"})
+	writeFiles(t, filepath.Join(procRoot, "self"), map[string]string{"cgroup": "0::/system.slice/agent.service\n"})
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	c := &Cgroup{
+		IncludeSelf: true,
+		Log:         testutil.Logger{},
+		root:        root,
+		procRoot:    procRoot,
+		now:         func() time.Time { return now },
+		numCPU:      8,
+	}
+	require.NoError(t, c.Init())
+	return c, root, &now
+}
+
+func TestGather(t *testing.T) {
+	c, root, now := newTestCgroup(t)
+	c.Paths = []string{"kubepods.slice/*"}
+	self := filepath.Join(root, "system.slice", "agent.service")
+	writeFiles(t, self, map[string]string{
+		"cpu.max":        "50000 100000\n",
+		"cpu.stat":       "usage_usec 1000000\nnr_periods 100\nnr_throttled 10\n",
+		"memory.current": "200000000\n",
+		"memory.max":     "500000000\n",
+		"memory.stat":    "anon 100000000\ninactive_file 50000000\n",
+	})
+	pod := filepath.Join(root, "kubepods.slice", "pod1")
+	writeFiles(t, pod, map[string]string{
+		"cpu.max":        "max 100000\n",
+		"cpu.stat":       "usage_usec 0\nnr_periods 0\nnr_throttled 0\n",
+		"memory.current": "1600000000\n",
+		"memory.max":     "max\n",
+	})
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	// the CPU usage is only reported from the second collection
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldCPULimit:          0.5,
+		fieldMemoryLimit:       uint64(500000000),
+		fieldMemoryUsed:        uint64(200000000),
+		fieldMemoryWorkingSet:  uint64(150000000),
+		fieldMemoryUsedPercent: 30.0,
+	}, map[string]string{cgroupTag: "/system.slice/agent.service"})
+
+	*now = now.Add(10 * time.Second)
+	writeFiles(t, self, map[string]string{"cpu.stat": "usage_usec 5000000\nnr_periods 200\nnr_throttled 60\n"})
+	writeFiles(t, pod, map[string]string{"cpu.stat": "usage_usec 8000000\nnr_periods 0\nnr_throttled 0\n"})
+	acc.ClearMetrics()
+	require.NoError(t, c.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldCPULimit:            0.5,
+		fieldCPUUsagePercent:     80.0,
+		fieldCPUThrottledPercent: 50.0,
+		fieldMemoryLimit:         uint64(500000000),
+		fieldMemoryUsed:          uint64(200000000),
+		fieldMemoryWorkingSet:    uint64(150000000),
+		fieldMemoryUsedPercent:   30.0,
+	}, map[string]string{cgroupTag: "/system.slice/agent.service"})
+	// without limits, the capacity of the host is the limit
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldCPULimit:          8.0,
+		fieldCPUUsagePercent:   10.0,
+		fieldMemoryLimit:       uint64(16000000 * 1024),
+		fieldMemoryUsed:        uint64(1600000000),
+		fieldMemoryWorkingSet:  uint64(1600000000),
+		fieldMemoryUsedPercent: 100 * 1600000000 / float64(16000000*1024),
+	}, map[string]string{cgroupTag: "/kubepods.slice/pod1"})
+
+	// the samples of the deleted cgroups are forgotten
+	require.NoError(t, os.RemoveAll(pod))
+	require.NoError(t, c.Gather(acc))
+	assert.Len(t, c.previous, 1)
+}
+
+func TestGatherSelfInNamespace(t *testing.T) {
+	c, root, _ := newTestCgroup(t)
+	writeFiles(t, filepath.Join(c.procRoot, "self"), map[string]string{"cgroup": "0::/\n"})
+	writeFiles(t, root, map[string]string{"cpu.max": "200000 100000\n", "cpu.stat": "usage_usec 0\n"})
+	acc := &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	m, ok := acc.Get(measurement)
+	require.True(t, ok)
+	assert.Equal(t, "/", m.Tags[cgroupTag])
+	assert.Equal(t, 2.0, m.Fields[fieldCPULimit])
+	// the memory controller is not enabled
+	assert.NotContains(t, m.Fields, fieldMemoryUsed)
+}
+
+func TestGatherWithoutCgroupV2(t *testing.T) {
+	c, root, _ := newTestCgroup(t)
+	require.NoError(t, os.Remove(filepath.Join(root, "cgroup.controllers")))
+	assert.ErrorContains(t, c.Gather(&testutil.Accumulator{}), "no cgroup v2 hierarchy")
+}
+
+func TestGatherSlicesAndUsers(t *testing.T) {
+	c, root, _ := newTestCgroup(t)
+	c.IncludeSelf = false
+	c.Users = true
+	for _, dir := range []string{
+		"system.slice",
+		filepath.Join("system.slice", "agent.service"),
+		"user.slice",
+		filepath.Join("user.slice", "user-0.slice"),
+		filepath.Join("user.slice", "user-0.slice", "session-1.scope"),
+		filepath.Join("user.slice", "user-4000000.slice"),
+		filepath.Join("user.slice", "user-name.slice"),
+		"slurm.slice",
+		filepath.Join("slurm.slice", "job-1.slice"),
+	} {
+		writeFiles(t, filepath.Join(root, dir), map[string]string{"cpu.stat": "usage_usec 0\n"})
+	}
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	tags := map[string]string{}
+	for _, m := range acc.GetTelegrafMetrics() {
+		tags[m.Tags()[cgroupTag]] = m.Tags()[userTag]
+	}
+	// the users unknown to the host are tagged with their UID
+	assert.Equal(t, map[string]string{
+		"/user.slice/user-0.slice":       "root",
+		"/user.slice/user-4000000.slice": "4000000",
+	}, tags)
+
+	c.Users = false
+	c.Slices = true
+	acc = &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	var cgroups []string
+	for _, m := range acc.GetTelegrafMetrics() {
+		cgroups = append(cgroups, m.Tags()[cgroupTag])
+		assert.NotContains(t, m.Tags(), userTag)
+	}
+	assert.ElementsMatch(t, []string{
+		"/system.slice",
+		"/user.slice",
+		"/user.slice/user-0.slice",
+		"/user.slice/user-4000000.slice",
+		"/user.slice/user-name.slice",
+		"/slurm.slice",
+		"/slurm.slice/job-1.slice",
+	}, cgroups)
+}
+
+func TestUserSliceUID(t *testing.T) {
+	for cgroup, want := range map[string]string{
+		"/user.slice/user-1000.slice": "1000",
+		"/user.slice":                 "",
+		"/user.slice/user-abc.slice":  "",
+		"/user.slice/user-1000.scope": "",
+	} {
+		uid, ok := userSliceUID(cgroup)
+		assert.Equal(t, want != "", ok, cgroup)
+		assert.Equal(t, want, uid, cgroup)
+	}
+}
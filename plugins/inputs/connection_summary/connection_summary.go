@@ -0,0 +1,320 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package connection_summary
+
+import (
+	"encoding/json"
+	"log"
+	"net/netip"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+const (
+	defaultSummaryInterval = time.Minute
+	defaultSampleInterval  = 10 * time.Second
+	defaultTopN            = 100
+)
+
+// connection is an outbound socket of the host.
+type connection struct {
+	Protocol string
+	Local    netip.AddrPort
+	Remote   netip.AddrPort
+	Process  string
+}
+
+// summaryKey groups the connections of an interval into a summary record.
+type summaryKey struct {
+	Protocol string
+	Address  netip.Addr
+	Port     uint16
+	Process  string
+}
+
+// Summary is one log event: the outbound connections a process had to a destination during an interval.
+type Summary struct {
+	Start              time.Time `json:"start"`
+	End                time.Time `json:"end"`
+	Protocol           string    `json:"protocol,omitempty"`
+	DestinationAddress string    `json:"destination_address,omitempty"`
+	DestinationPort    uint16    `json:"destination_port,omitempty"`
+	Process            string    `json:"process,omitempty"`
+	Connections        int       `json:"connections"`
+	// OtherDestinations is only set on the record that sums up the destinations beyond top_n.
+	OtherDestinations int `json:"other_destinations,omitempty"`
+}
+
+type Plugin struct {
+	SummaryInterval config.Duration `toml:"summary_interval"`
+	SampleInterval  config.Duration `toml:"sample_interval"`
+	TopN            int             `toml:"top_n"`
+	IncludeLoopback bool            `toml:"include_loopback"`
+	LogGroupName    string          `toml:"log_group_name"`
+	LogStreamName   string          `toml:"log_stream_name"`
+	LogGroupClass   string          `toml:"log_group_class"`
+	Destination     string          `toml:"destination"`
+	Retention       int             `toml:"retention_in_days"`
+	Log             telegraf.Logger `toml:"-"`
+
+	src      *connectionSummary
+	srcFound bool
+}
+
+func (p *Plugin) Description() string {
+	return "Summarize the outbound connections of the host, by destination, port and process, into a log stream"
+}
+
+func (p *Plugin) SampleConfig() string {
+	return `
+  ## How often a summary is written, and how often the connections are sampled within it.
+  summary_interval = "60s"
+  sample_interval = "10s"
+  ## Number of destinations written per summary, ordered by connections.
+  ## The remaining ones are added up in a single record.
+  top_n = 100
+  ## Include connections to loopback addresses.
+  include_loopback = false
+
+  log_group_name = "connection-summary"
+  log_stream_name = "STREAM_NAME"
+  destination = "cloudwatchlogs"
+`
+}
+
+func (p *Plugin) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+func (p *Plugin) Start(telegraf.Accumulator) error {
+	if p.src != nil {
+		return nil
+	}
+	summaryInterval := time.Duration(p.SummaryInterval)
+	if summaryInterval <= 0 {
+		summaryInterval = defaultSummaryInterval
+	}
+	sampleInterval := time.Duration(p.SampleInterval)
+	if sampleInterval <= 0 || sampleInterval > summaryInterval {
+		sampleInterval = min(defaultSampleInterval, summaryInterval)
+	}
+	topN := p.TopN
+	if topN <= 0 {
+		topN = defaultTopN
+	}
+	p.src = &connectionSummary{
+		plugin:          p,
+		summaryInterval: summaryInterval,
+		sampleInterval:  sampleInterval,
+		topN:            topN,
+		read:            readConnections,
+		done:            make(chan struct{}),
+	}
+	return nil
+}
+
+func (p *Plugin) FindLogSrc() []logs.LogSrc {
+	if p.src == nil || p.srcFound {
+		return nil
+	}
+	p.srcFound = true
+	return []logs.LogSrc{p.src}
+}
+
+func (p *Plugin) Stop() {
+	if p.src != nil {
+		p.src.Stop()
+	}
+}
+
+// connectionSummary is the log source of the plugin.
+type connectionSummary struct {
+	plugin          *Plugin
+	summaryInterval time.Duration
+	sampleInterval  time.Duration
+	topN            int
+	read            func() ([]connection, error)
+
+	outputFn  func(logs.LogEvent)
+	startOnce sync.Once
+	stopOnce  sync.Once
+	done      chan struct{}
+
+	start time.Time
+	seen  map[connection]struct{}
+}
+
+var _ logs.LogSrc = (*connectionSummary)(nil)
+
+func (c *connectionSummary) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	c.outputFn = fn
+	c.startOnce.Do(func() { go c.run() })
+}
+
+func (c *connectionSummary) Group() string {
+	return c.plugin.LogGroupName
+}
+
+func (c *connectionSummary) Stream() string {
+	return c.plugin.LogStreamName
+}
+
+func (c *connectionSummary) Destination() string {
+	return c.plugin.Destination
+}
+
+func (c *connectionSummary) Description() string {
+	return "connection summary"
+}
+
+func (c *connectionSummary) Retention() int {
+	return c.plugin.Retention
+}
+
+func (c *connectionSummary) Class() string {
+	return c.plugin.LogGroupClass
+}
+
+func (c *connectionSummary) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+func (c *connectionSummary) Stop() {
+	c.stopOnce.Do(func() { close(c.done) })
+}
+
+func (c *connectionSummary) run() {
+	sampleTicker := time.NewTicker(c.sampleInterval)
+	defer sampleTicker.Stop()
+	summaryTicker := time.NewTicker(c.summaryInterval)
+	defer summaryTicker.Stop()
+
+	c.reset(time.Now())
+	c.sample()
+	for {
+		select {
+		case <-sampleTicker.C:
+			c.sample()
+		case now := <-summaryTicker.C:
+			c.sample()
+			for _, s := range c.summarize(now) {
+				c.publish(s)
+			}
+			c.reset(now)
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *connectionSummary) reset(now time.Time) {
+	c.start = now
+	c.seen = make(map[connection]struct{})
+}
+
+// sample adds the connections open now to the current interval. A connection that stays open is counted once.
+func (c *connectionSummary) sample() {
+	conns, err := c.read()
+	if err != nil {
+		log.Printf("W! [connection_summary] Unable to read connections: %v", err)
+		return
+	}
+	for _, conn := range conns {
+		if !c.plugin.IncludeLoopback && conn.Remote.Addr().IsLoopback() {
+			continue
+		}
+		c.seen[conn] = struct{}{}
+	}
+}
+
+// summarize returns the records of the current interval, the destinations with the most connections first.
+func (c *connectionSummary) summarize(end time.Time) []Summary {
+	counts := make(map[summaryKey]int)
+	for conn := range c.seen {
+		key := summaryKey{Protocol: conn.Protocol, Address: conn.Remote.Addr(), Port: conn.Remote.Port(), Process: conn.Process}
+		counts[key]++
+	}
+	summaries := make([]Summary, 0, len(counts))
+	for key, n := range counts {
+		summaries = append(summaries, Summary{
+			Start:              c.start,
+			End:                end,
+			Protocol:           key.Protocol,
+			DestinationAddress: key.Address.String(),
+			DestinationPort:    key.Port,
+			Process:            key.Process,
+			Connections:        n,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Connections != b.Connections {
+			return a.Connections > b.Connections
+		}
+		if a.DestinationAddress != b.DestinationAddress {
+			return a.DestinationAddress < b.DestinationAddress
+		}
+		if a.DestinationPort != b.DestinationPort {
+			return a.DestinationPort < b.DestinationPort
+		}
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+		return a.Process < b.Process
+	})
+	if len(summaries) <= c.topN {
+		return summaries
+	}
+	other := Summary{Start: c.start, End: end, OtherDestinations: len(summaries) - c.topN}
+	for _, s := range summaries[c.topN:] {
+		other.Connections += s.Connections
+	}
+	return append(summaries[:c.topN], other)
+}
+
+func (c *connectionSummary) publish(s Summary) {
+	content, err := json.Marshal(s)
+	if err != nil {
+		log.Printf("E! [connection_summary] Unable to encode summary: %v", err)
+		return
+	}
+	c.outputFn(&logEvent{msg: string(content), t: s.End})
+}
+
+type logEvent struct {
+	msg string
+	t   time.Time
+}
+
+func (e *logEvent) Message() string {
+	return e.msg
+}
+
+func (e *logEvent) Time() time.Time {
+	return e.t
+}
+
+func (e *logEvent) Done() {}
+
+func init() {
+	inputs.Add("connection_summary", func() telegraf.Input {
+		return &Plugin{
+			SummaryInterval: config.Duration(defaultSummaryInterval),
+			SampleInterval:  config.Duration(defaultSampleInterval),
+			TopN:            defaultTopN,
+			Destination:     "cloudwatchlogs",
+		}
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package connection_summary
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+func testConnection(remote, process string, localPort uint16) connection {
+	return connection{
+		Protocol: "tcp",
+		Local:    netip.AddrPortFrom(netip.MustParseAddr("10.0.0.1"), localPort),
+		Remote:   netip.MustParseAddrPort(remote),
+		Process:  process,
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	samples := [][]connection{
+		{
+			testConnection("10.0.0.2:443", "curl", 40000),
+			testConnection("10.0.0.2:443", "curl", 40001),
+			testConnection("10.0.0.3:5432", "app", 40002),
+			testConnection("127.0.0.1:8080", "app", 40003),
+		},
+		{
+			// still open, counted once
+			testConnection("10.0.0.2:443", "curl", 40000),
+			testConnection("10.0.0.2:443", "curl", 40004),
+			testConnection("10.0.0.4:53", "app", 40005),
+		},
+	}
+	p := &Plugin{TopN: 2, LogGroupName: "group", LogStreamName: "stream", Destination: "cloudwatchlogs"}
+	require.NoError(t, p.Start(nil))
+	c := p.src
+	c.read = func() ([]connection, error) {
+		s := samples[0]
+		samples = samples[1:]
+		return s, nil
+	}
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	c.reset(start)
+	c.sample()
+	c.sample()
+
+	end := start.Add(time.Minute)
+	assert.Equal(t, []Summary{
+		{Start: start, End: end, Protocol: "tcp", DestinationAddress: "10.0.0.2", DestinationPort: 443, Process: "curl", Connections: 3},
+		{Start: start, End: end, Protocol: "tcp", DestinationAddress: "10.0.0.3", DestinationPort: 5432, Process: "app", Connections: 1},
+		{Start: start, End: end, Connections: 1, OtherDestinations: 1},
+	}, c.summarize(end))
+}
+
+func TestPlugin(t *testing.T) {
+	p := &Plugin{
+		SummaryInterval: config.Duration(50 * time.Millisecond),
+		SampleInterval:  config.Duration(time.Hour),
+		IncludeLoopback: true,
+		LogGroupName:    "group",
+		LogStreamName:   "stream",
+		Destination:     "cloudwatchlogs",
+		Retention:       7,
+	}
+	require.NoError(t, p.Start(nil))
+	srcs := p.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Empty(t, p.FindLogSrc())
+
+	src := srcs[0]
+	assert.Equal(t, "group", src.Group())
+	assert.Equal(t, "stream", src.Stream())
+	assert.Equal(t, "cloudwatchlogs", src.Destination())
+	assert.Equal(t, 7, src.Retention())
+	assert.Equal(t, 50*time.Millisecond, p.src.summaryInterval)
+	assert.Equal(t, 50*time.Millisecond, p.src.sampleInterval)
+	assert.Equal(t, defaultTopN, p.src.topN)
+
+	p.src.read = func() ([]connection, error) {
+		return []connection{testConnection("127.0.0.1:8080", "app", 40000)}, nil
+	}
+	events := make(chan logs.LogEvent, 10)
+	src.SetOutput(func(e logs.LogEvent) { events <- e })
+	defer p.Stop()
+
+	select {
+	case e := <-events:
+		var s Summary
+		require.NoError(t, json.Unmarshal([]byte(e.Message()), &s))
+		assert.Equal(t, "127.0.0.1", s.DestinationAddress)
+		assert.EqualValues(t, 8080, s.DestinationPort)
+		assert.Equal(t, "app", s.Process)
+		assert.Equal(t, 1, s.Connections)
+		assert.True(t, e.Time().Equal(s.End))
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "no summary published")
+	}
+}
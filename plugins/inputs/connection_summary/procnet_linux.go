@@ -0,0 +1,165 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+// +build linux
+
+package connection_summary
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// https://github.com/torvalds/linux/blob/master/include/net/tcp_states.h
+const (
+	stateEstablished = "01"
+	stateSynSent     = "02"
+	stateListen      = "0A"
+)
+
+var procRoot = "/proc"
+
+type socket struct {
+	protocol string
+	local    netip.AddrPort
+	remote   netip.AddrPort
+	state    string
+	inode    string
+}
+
+// readConnections returns the outbound TCP and connected UDP sockets of the host. TCP sockets whose local port is
+// listening were accepted, not opened by the host, and are left out.
+func readConnections() ([]connection, error) {
+	var sockets []socket
+	for _, table := range []struct{ file, protocol string }{
+		{"tcp", "tcp"}, {"tcp6", "tcp"}, {"udp", "udp"}, {"udp6", "udp"},
+	} {
+		s, err := readSocketTable(filepath.Join(procRoot, "net", table.file), table.protocol)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// IPv6 is disabled
+				continue
+			}
+			return nil, err
+		}
+		sockets = append(sockets, s...)
+	}
+	listening := make(map[uint16]bool)
+	for _, s := range sockets {
+		if s.protocol == "tcp" && s.state == stateListen {
+			listening[s.local.Port()] = true
+		}
+	}
+	var processes map[string]string
+	var conns []connection
+	for _, s := range sockets {
+		if !isOutbound(s, listening) {
+			continue
+		}
+		if processes == nil {
+			processes = socketProcesses()
+		}
+		conns = append(conns, connection{Protocol: s.protocol, Local: s.local, Remote: s.remote, Process: processes[s.inode]})
+	}
+	return conns, nil
+}
+
+func isOutbound(s socket, listening map[uint16]bool) bool {
+	if s.remote.Port() == 0 || s.remote.Addr().IsUnspecified() {
+		return false
+	}
+	switch s.protocol {
+	case "tcp":
+		return (s.state == stateEstablished || s.state == stateSynSent) && !listening[s.local.Port()]
+	case "udp":
+		return s.state == stateEstablished
+	}
+	return false
+}
+
+// readSocketTable parses one of the /proc/net socket tables.
+func readSocketTable(name, protocol string) ([]socket, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var sockets []socket
+	scanner := bufio.NewScanner(f)
+	// skip the header
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		local, err := parseAddrPort(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		remote, err := parseAddrPort(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		sockets = append(sockets, socket{protocol: protocol, local: local, remote: remote, state: fields[3], inode: fields[9]})
+	}
+	return sockets, scanner.Err()
+}
+
+// parseAddrPort parses an address as the kernel prints it: the address in hex, as 32 bit words in host byte order,
+// and the port in hex.
+func parseAddrPort(s string) (netip.AddrPort, error) {
+	addrHex, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return netip.AddrPort{}, fmt.Errorf("invalid address %q", s)
+	}
+	b, err := hex.DecodeString(addrHex)
+	if err != nil || (len(b) != 4 && len(b) != 16) {
+		return netip.AddrPort{}, fmt.Errorf("invalid address %q", s)
+	}
+	for i := 0; i < len(b); i += 4 {
+		binary.NativeEndian.PutUint32(b[i:], binary.BigEndian.Uint32(b[i:]))
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return netip.AddrPort{}, fmt.Errorf("invalid port %q", s)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return netip.AddrPortFrom(addr.Unmap(), uint16(port)), nil
+}
+
+// socketProcesses maps socket inodes to the name of the process that has them open. Processes the agent is not
+// allowed to inspect are skipped.
+func socketProcesses() map[string]string {
+	processes := make(map[string]string)
+	pids, _ := filepath.Glob(filepath.Join(procRoot, "[0-9]*"))
+	for _, pid := range pids {
+		fds, err := os.ReadDir(filepath.Join(pid, "fd"))
+		if err != nil {
+			continue
+		}
+		var comm string
+		for _, fd := range fds {
+			target, err := os.Readlink(filepath.Join(pid, "fd", fd.Name()))
+			if err != nil || !strings.HasPrefix(target, "socket:[") {
+				continue
+			}
+			if comm == "" {
+				content, _ := os.ReadFile(filepath.Join(pid, "comm"))
+				if comm = strings.TrimSpace(string(content)); comm == "" {
+					comm = filepath.Base(pid)
+				}
+			}
+			processes[strings.TrimSuffix(strings.TrimPrefix(target, "socket:["), "]")] = comm
+		}
+	}
+	return processes
+}
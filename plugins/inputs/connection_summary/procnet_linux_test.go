@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+// +build linux
+
+package connection_summary
+
+import (
+	"fmt"
+	"net/netip"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddrPort(t *testing.T) {
+	testCases := map[string]string{
+		"0100007F:1F90":                         "127.0.0.1:8080",
+		"0F02000A:D2F4":                         "10.0.2.15:54004",
+		"00000000:0000":                         "0.0.0.0:0",
+		"B80D0120000000000000000001000000:0050": "[2001:db8::1]:80",
+		"0000000000000000FFFF00000100007F:0016": "127.0.0.1:22",
+	}
+	for input, want := range testCases {
+		got, err := parseAddrPort(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, netip.MustParseAddrPort(want), got, input)
+	}
+	for _, input := range []string{"", "0100007F", "0100007:0016", "0100007F:XYZ"} {
+		_, err := parseAddrPort(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestReadConnections(t *testing.T) {
+	orig := procRoot
+	defer func() { procRoot = orig }()
+	procRoot = filepath.Join("testdata", "proc")
+
+	conns, err := readConnections()
+	require.NoError(t, err)
+	var got []string
+	for _, c := range conns {
+		got = append(got, fmt.Sprintf("%s %s %s %s", c.Protocol, c.Local, c.Remote, c.Process))
+	}
+	assert.ElementsMatch(t, []string{
+		"tcp 10.0.2.15:54004 13.12.11.10:443 curl",
+		"tcp 127.0.0.1:41904 127.0.0.1:8080 ",
+		"tcp [fe80::21b:cff:152b:1bfe]:50856 [2001:db8::1]:80 ",
+		"udp 10.0.2.15:45506 192.168.0.2:53 ",
+	}, got)
+}
@@ -0,0 +1,15 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+// +build !linux
+
+package connection_summary
+
+import (
+	"errors"
+)
+
+func readConnections() ([]connection, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,212 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/influxdata/telegraf/plugins/parsers/json"
+	"github.com/influxdata/telegraf/plugins/parsers/prometheus"
+	"github.com/kballard/go-shellquote"
+)
+
+const (
+	DataFormatJSON       = "json"
+	DataFormatPrometheus = "prometheus"
+
+	defaultMetricName     = "exec"
+	defaultTimeout        = 5 * time.Second
+	defaultMaxConcurrency = 4
+	// maxOutputSize bounds the output read from a command, so that a runaway script cannot exhaust the memory of the
+	// agent.
+	maxOutputSize = 1 << 20
+)
+
+type parser interface {
+	Parse(buf []byte) ([]telegraf.Metric, error)
+}
+
+// Exec runs commands on every interval and publishes the metrics they write to stdout, in JSON or in the Prometheus
+// text format.
+type Exec struct {
+	Commands       []string        `toml:"commands"`
+	DataFormat     string          `toml:"data_format"`
+	MetricName     string          `toml:"metric_name"`
+	TagKeys        []string        `toml:"tag_keys"`
+	Timeout        config.Duration `toml:"timeout"`
+	MaxConcurrency int             `toml:"max_concurrency"`
+	RunAsUser      string          `toml:"run_as_user"`
+	RunAsGroup     string          `toml:"run_as_group"`
+	Log            telegraf.Logger `toml:"-"`
+
+	commands [][]string
+	parser   parser
+	sandbox  *sandbox
+}
+
+func (e *Exec) Description() string {
+	return "Run commands on every interval and publish the metrics they write to stdout"
+}
+
+func (e *Exec) SampleConfig() string {
+	return `
+  ## Commands to run, split into arguments like a shell would. They are not run in a shell.
+  commands = ["/opt/checks/queue_depth.sh --queue orders"]
+  ## Format of the output of the commands, "json" or "prometheus".
+  data_format = "json"
+  ## Name the fields of the JSON output are prefixed with, and the JSON string fields added as tags.
+  metric_name = "exec"
+  tag_keys = []
+  ## Commands still running after the timeout are killed, with the processes they started.
+  timeout = "5s"
+  ## Number of commands run at the same time.
+  max_concurrency = 4
+  ## User and group the commands run as, which requires the agent to run as root. Not supported on Windows.
+  run_as_user = ""
+  run_as_group = ""
+`
+}
+
+func (e *Exec) Init() error {
+	e.commands = make([][]string, 0, len(e.Commands))
+	for _, command := range e.Commands {
+		args, err := shellquote.Split(command)
+		if err != nil || len(args) == 0 {
+			return fmt.Errorf("invalid command %q: %v", command, err)
+		}
+		e.commands = append(e.commands, args)
+	}
+	if e.MetricName == "" {
+		e.MetricName = defaultMetricName
+	}
+	switch e.DataFormat {
+	case "", DataFormatJSON:
+		p, err := json.New(&json.Config{MetricName: e.MetricName, TagKeys: e.TagKeys})
+		if err != nil {
+			return err
+		}
+		e.parser = p
+	case DataFormatPrometheus:
+		e.parser = &prometheus.Parser{}
+	default:
+		return fmt.Errorf("invalid data_format %q, must be %q or %q", e.DataFormat, DataFormatJSON, DataFormatPrometheus)
+	}
+	if e.Timeout <= 0 {
+		e.Timeout = config.Duration(defaultTimeout)
+	}
+	if e.MaxConcurrency <= 0 {
+		e.MaxConcurrency = defaultMaxConcurrency
+	}
+	var err error
+	e.sandbox, err = newSandbox(e.RunAsUser, e.RunAsGroup)
+	return err
+}
+
+func (e *Exec) Gather(acc telegraf.Accumulator) error {
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, e.MaxConcurrency)
+	for _, args := range e.commands {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(args []string) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			if err := e.gatherCommand(acc, args); err != nil {
+				acc.AddError(fmt.Errorf("exec %s: %w", args[0], err))
+			}
+		}(args)
+	}
+	wg.Wait()
+	return nil
+}
+
+// gatherCommand runs the command and adds the metrics of its output. The metrics are published as gauges with the
+// values the command wrote, counters included.
+func (e *Exec) gatherCommand(acc telegraf.Accumulator, args []string) error {
+	out, err := e.run(args)
+	if err != nil {
+		return err
+	}
+	metrics, err := e.parser.Parse(out)
+	if err != nil {
+		return fmt.Errorf("unable to parse the output: %w", err)
+	}
+	for _, m := range metrics {
+		acc.AddGauge(m.Name(), m.Fields(), m.Tags(), m.Time())
+	}
+	return nil
+}
+
+func (e *Exec) run(args []string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(e.Timeout))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &limitedWriter{w: &stderr, n: 1024}
+	if err := e.sandbox.apply(cmd); err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+	out, readErr := io.ReadAll(io.LimitReader(stdout, maxOutputSize+1))
+	if readErr == nil && len(out) > maxOutputSize {
+		readErr = fmt.Errorf("output is larger than %d bytes", maxOutputSize)
+		cancel()
+	}
+	err = cmd.Wait()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out after %s", time.Duration(e.Timeout))
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	if err != nil {
+		if stderr.Len() > 0 {
+			return nil, fmt.Errorf("%w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// limitedWriter keeps the first n bytes written to it and discards the rest.
+type limitedWriter struct {
+	w io.Writer
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.n > 0 {
+		k := min(len(p), l.n)
+		l.n -= k
+		if _, err := l.w.Write(p[:k]); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func init() {
+	inputs.Add("exec", func() telegraf.Input {
+		return &Exec{}
+	})
+}
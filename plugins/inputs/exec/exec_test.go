@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package exec
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatherJSON(t *testing.T) {
+	e := &Exec{
+		Commands: []string{"testdata/json.sh orders", "testdata/json.sh 'returns queue'"},
+		TagKeys:  []string{"queue"},
+		Log:      testutil.Logger{},
+	}
+	require.NoError(t, e.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	for _, queue := range []string{"orders", "returns queue"} {
+		acc.AssertContainsTaggedFields(t, "exec", map[string]any{"depth": 42.0, "oldest_seconds": 1.5}, map[string]string{"queue": queue})
+	}
+}
+
+func TestGatherPrometheus(t *testing.T) {
+	e := &Exec{
+		Commands:   []string{"testdata/prometheus.sh"},
+		DataFormat: DataFormatPrometheus,
+		Log:        testutil.Logger{},
+	}
+	require.NoError(t, e.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	acc.AssertContainsTaggedFields(t, "prometheus", map[string]any{"jobs_failed_total": 3.0}, map[string]string{"job": "backup"})
+	acc.AssertContainsTaggedFields(t, "prometheus", map[string]any{"jobs_running": 2.0}, map[string]string{})
+}
+
+func TestGatherErrors(t *testing.T) {
+	e := &Exec{
+		Commands: []string{"testdata/fail.sh", "testdata/missing.sh", "testdata/prometheus.sh", "testdata/json.sh orders"},
+		Log:      testutil.Logger{},
+	}
+	require.NoError(t, e.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	require.Len(t, acc.Errors, 3)
+	var messages []string
+	for _, err := range acc.Errors {
+		messages = append(messages, err.Error())
+	}
+	assert.Contains(t, messages, "exec testdata/fail.sh: exit status 2: queue not found")
+	assert.True(t, acc.HasField("exec", "depth"))
+}
+
+func TestGatherTimeout(t *testing.T) {
+	e := &Exec{
+		Commands: []string{"testdata/sleep.sh"},
+		Timeout:  config.Duration(100 * time.Millisecond),
+		Log:      testutil.Logger{},
+	}
+	require.NoError(t, e.Init())
+	acc := &testutil.Accumulator{}
+	start := time.Now()
+	require.NoError(t, e.Gather(acc))
+	assert.Less(t, time.Since(start), 5*time.Second)
+	require.Len(t, acc.Errors, 1)
+	assert.EqualError(t, acc.Errors[0], "exec testdata/sleep.sh: timed out after 100ms")
+}
+
+func TestInit(t *testing.T) {
+	e := &Exec{Commands: []string{"testdata/json.sh"}}
+	require.NoError(t, e.Init())
+	assert.Equal(t, defaultMetricName, e.MetricName)
+	assert.Equal(t, config.Duration(defaultTimeout), e.Timeout)
+	assert.Equal(t, defaultMaxConcurrency, e.MaxConcurrency)
+
+	assert.Error(t, (&Exec{Commands: []string{"testdata/json.sh 'unterminated"}}).Init())
+	assert.Error(t, (&Exec{Commands: []string{" "}}).Init())
+	assert.Error(t, (&Exec{Commands: []string{"testdata/json.sh"}, DataFormat: "influx"}).Init())
+	assert.Error(t, (&Exec{Commands: []string{"testdata/json.sh"}, RunAsUser: "no-such-user-for-exec"}).Init())
+	assert.Error(t, (&Exec{Commands: []string{"testdata/json.sh"}, RunAsGroup: "no-such-group-for-exec"}).Init())
+}
+
+func TestRunAsUser(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("running commands as another user requires root")
+	}
+	e := &Exec{
+		Commands:   []string{"testdata/id.sh"},
+		RunAsUser:  "65534",
+		RunAsGroup: "65534",
+		Log:        testutil.Logger{},
+	}
+	require.NoError(t, e.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	acc.AssertContainsFields(t, "exec", map[string]any{"uid": 65534.0, "gid": 65534.0})
+}
@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package exec
+
+import (
+	"fmt"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// sandbox holds the credentials the commands run with. A nil Credential runs them as the user of the agent.
+type sandbox struct {
+	credential *syscall.Credential
+}
+
+func newSandbox(runAsUser, runAsGroup string) (*sandbox, error) {
+	s := &sandbox{}
+	if runAsUser == "" && runAsGroup == "" {
+		return s, nil
+	}
+	var uid, gid uint64
+	var err error
+	if runAsUser != "" {
+		var u *user.User
+		if u, err = lookupUser(runAsUser); err != nil {
+			return nil, fmt.Errorf("invalid run_as_user %q: %w", runAsUser, err)
+		}
+		if uid, err = strconv.ParseUint(u.Uid, 10, 32); err != nil {
+			return nil, fmt.Errorf("invalid uid of run_as_user %q: %w", runAsUser, err)
+		}
+		// Default to the primary group of the user rather than keeping the group of the agent.
+		if gid, err = strconv.ParseUint(u.Gid, 10, 32); err != nil {
+			return nil, fmt.Errorf("invalid gid of run_as_user %q: %w", runAsUser, err)
+		}
+	} else {
+		uid = uint64(syscall.Getuid())
+	}
+	if runAsGroup != "" {
+		var g *user.Group
+		if g, err = lookupGroup(runAsGroup); err != nil {
+			return nil, fmt.Errorf("invalid run_as_group %q: %w", runAsGroup, err)
+		}
+		if gid, err = strconv.ParseUint(g.Gid, 10, 32); err != nil {
+			return nil, fmt.Errorf("invalid gid of run_as_group %q: %w", runAsGroup, err)
+		}
+	}
+	// Drop the supplementary groups of the agent, which would otherwise be kept by the commands.
+	s.credential = &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid), Groups: []uint32{}}
+	return s, nil
+}
+
+// apply runs the command in its own process group, so that the processes it starts are killed with it on timeout.
+func (s *sandbox) apply(cmd *exec.Cmd) error {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Credential: s.credential}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	// Do not wait for the output of processes that survived the group kill, e.g. after calling setsid.
+	cmd.WaitDelay = time.Second
+	return nil
+}
+
+// lookupUser accepts a user name or a numeric uid.
+func lookupUser(name string) (*user.User, error) {
+	if u, err := user.Lookup(name); err == nil {
+		return u, nil
+	} else if _, convErr := strconv.Atoi(name); convErr != nil {
+		return nil, err
+	}
+	return user.LookupId(name)
+}
+
+// lookupGroup accepts a group name or a numeric gid.
+func lookupGroup(name string) (*user.Group, error) {
+	if g, err := user.LookupGroup(name); err == nil {
+		return g, nil
+	} else if _, convErr := strconv.Atoi(name); convErr != nil {
+		return nil, err
+	}
+	return user.LookupGroupId(name)
+}
@@ -0,0 +1,3 @@
+#!/bin/sh
+echo "queue not found" >&2
+exit 2
@@ -0,0 +1,2 @@
+#!/bin/sh
+echo "{\"queue\": \"$1\", \"depth\": 42, \"oldest_seconds\": 1.5}"
@@ -0,0 +1,7 @@
+#!/bin/sh
+cat <<'METRICS'
+# TYPE jobs_failed_total counter
+jobs_failed_total{job="backup"} 3
+# TYPE jobs_running gauge
+jobs_running 2
+METRICS
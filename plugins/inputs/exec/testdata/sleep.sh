@@ -0,0 +1,4 @@
+#!/bin/sh
+# The child keeps the output open, so the command only returns once the whole process group is killed.
+sleep 30 &
+sleep 30
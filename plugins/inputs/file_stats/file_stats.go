@@ -0,0 +1,105 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package file_stats
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/globpath"
+)
+
+const (
+	measurement = "file_stats"
+	patternTag  = "pattern"
+
+	fieldCount            = "count"
+	fieldSizeBytes        = "size_bytes"
+	fieldLargestSizeBytes = "largest_size_bytes"
+	fieldOldestAgeSeconds = "oldest_age_seconds"
+	fieldNewestAgeSeconds = "newest_age_seconds"
+)
+
+// FileStats reports how many files match each of a list of globs, how large they are and how long ago they were last
+// modified. The stats of each glob are published as one set of metrics with the glob as a dimension, so that a spool
+// directory with many files does not create a metric per file.
+type FileStats struct {
+	Files []string        `toml:"files"`
+	Log   telegraf.Logger `toml:"-"`
+
+	globs []*globpath.GlobPath
+	now   func() time.Time
+}
+
+func (f *FileStats) Description() string {
+	return "Report the count, size and age of the files matching globs"
+}
+
+func (f *FileStats) SampleConfig() string {
+	return `
+  ## Globs of the files to report on. ** matches any number of directories.
+  files = ["/var/spool/postfix/deferred/**", "/backup/*.tar.gz"]
+`
+}
+
+func (f *FileStats) Init() error {
+	f.globs = make([]*globpath.GlobPath, 0, len(f.Files))
+	for _, pattern := range f.Files {
+		g, err := globpath.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+		f.globs = append(f.globs, g)
+	}
+	if f.now == nil {
+		f.now = time.Now
+	}
+	return nil
+}
+
+func (f *FileStats) Gather(acc telegraf.Accumulator) error {
+	now := f.now()
+	for i, g := range f.globs {
+		var count, size, largest int64
+		var oldest, newest time.Time
+		// Directories are skipped, so /var/spool/** only counts the files in the spool.
+		for _, info := range g.Match() {
+			if !info.Mode().IsRegular() {
+				continue
+			}
+			count++
+			size += info.Size()
+			largest = max(largest, info.Size())
+			if modTime := info.ModTime(); count == 1 {
+				oldest, newest = modTime, modTime
+			} else if modTime.Before(oldest) {
+				oldest = modTime
+			} else if modTime.After(newest) {
+				newest = modTime
+			}
+		}
+		fields := map[string]interface{}{
+			fieldCount:            count,
+			fieldSizeBytes:        size,
+			fieldLargestSizeBytes: largest,
+		}
+		// There is no age without files. The metrics are left out rather than reported as 0, which would look like a
+		// file that was just written.
+		if count > 0 {
+			fields[fieldOldestAgeSeconds] = now.Sub(oldest).Seconds()
+			fields[fieldNewestAgeSeconds] = now.Sub(newest).Seconds()
+		}
+		acc.AddGauge(measurement, fields, map[string]string{patternTag: f.Files[i]}, now)
+	}
+	return nil
+}
+
+func init() {
+	inputs.Add("file_stats", func() telegraf.Input {
+		return &FileStats{}
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package file_stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGather(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	writeFile(t, filepath.Join(dir, "spool", "a.msg"), 10, now.Add(-time.Hour))
+	writeFile(t, filepath.Join(dir, "spool", "nested", "b.msg"), 30, now.Add(-time.Minute))
+	writeFile(t, filepath.Join(dir, "spool", "nested", "c.msg"), 20, now.Add(-10*time.Minute))
+	writeFile(t, filepath.Join(dir, "backup.tar.gz"), 100, now.Add(-24*time.Hour))
+
+	spool := filepath.Join(dir, "spool", "**")
+	backup := filepath.Join(dir, "*.tar.gz")
+	missing := filepath.Join(dir, "missing", "*.tar.gz")
+	f := &FileStats{Files: []string{spool, backup, missing}, Log: testutil.Logger{}, now: func() time.Time { return now }}
+	require.NoError(t, f.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, f.Gather(acc))
+
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldCount:            int64(3),
+		fieldSizeBytes:        int64(60),
+		fieldLargestSizeBytes: int64(30),
+		fieldOldestAgeSeconds: 3600.0,
+		fieldNewestAgeSeconds: 60.0,
+	}, map[string]string{patternTag: spool})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldCount:            int64(1),
+		fieldSizeBytes:        int64(100),
+		fieldLargestSizeBytes: int64(100),
+		fieldOldestAgeSeconds: 86400.0,
+		fieldNewestAgeSeconds: 86400.0,
+	}, map[string]string{patternTag: backup})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldCount:            int64(0),
+		fieldSizeBytes:        int64(0),
+		fieldLargestSizeBytes: int64(0),
+	}, map[string]string{patternTag: missing})
+}
+
+func TestInitWithInvalidGlob(t *testing.T) {
+	f := &FileStats{Files: []string{"/var/spool/**/[a"}}
+	assert.Error(t, f.Init())
+}
+
+func writeFile(t *testing.T, path string, size int, modTime time.Time) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
@@ -0,0 +1,259 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/common/tls"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/jmespath/go-jmespath"
+)
+
+const (
+	urlTag = "url"
+
+	defaultMetricName = "http_json"
+	defaultTimeout    = 5 * time.Second
+	// maxResponseSize bounds the body read from an endpoint, so that a misbehaving service cannot exhaust the memory
+	// of the agent.
+	maxResponseSize = 10 << 20
+)
+
+// Metric is a value extracted from the responses with a JMESPath expression.
+type Metric struct {
+	Name  string `toml:"name"`
+	Query string `toml:"query"`
+}
+
+// HTTPJSON polls HTTP endpoints returning JSON on every interval and publishes the values JMESPath expressions
+// extract from the responses.
+type HTTPJSON struct {
+	URLs            []string          `toml:"urls"`
+	Headers         map[string]string `toml:"headers"`
+	Username        string            `toml:"username"`
+	Password        string            `toml:"password"`
+	BearerTokenFile string            `toml:"bearer_token_file"`
+	Timeout         config.Duration   `toml:"timeout"`
+	MetricName      string            `toml:"metric_name"`
+	Metrics         []Metric          `toml:"metrics"`
+	Dimensions      map[string]string `toml:"dimensions"`
+	tls.ClientConfig
+	Log telegraf.Logger `toml:"-"`
+
+	client     *http.Client
+	metrics    map[string]*jmespath.JMESPath
+	dimensions map[string]*jmespath.JMESPath
+}
+
+func (h *HTTPJSON) Description() string {
+	return "Poll HTTP endpoints returning JSON and publish the values extracted with JMESPath expressions"
+}
+
+func (h *HTTPJSON) SampleConfig() string {
+	return `
+  ## Endpoints to poll with a GET request. Each one is published with its url as a dimension.
+  urls = ["http://localhost:8080/admin/stats"]
+  ## Headers sent with the requests.
+  # headers = {"X-Api-Key" = "secret"}
+  ## Basic authentication.
+  # username = ""
+  # password = ""
+  ## File the bearer token sent in the Authorization header is read from, on every request.
+  # bearer_token_file = "/etc/cwagent/token"
+  ## TLS configuration.
+  # tls_ca = "/etc/cwagent/ca.pem"
+  # tls_cert = "/etc/cwagent/cert.pem"
+  # tls_key = "/etc/cwagent/key.pem"
+  # insecure_skip_verify = false
+  timeout = "5s"
+  ## Name the metrics are prefixed with.
+  metric_name = "http_json"
+  ## Values to publish, extracted with JMESPath expressions. They must evaluate to a number or a boolean.
+  [[inputs.http_json.metrics]]
+    name = "queue_depth"
+    query = "queues[?name=='orders'].depth | [0]"
+  ## Dimensions added to the metrics, extracted with JMESPath expressions.
+  # [inputs.http_json.dimensions]
+  #   version = "build.version"
+`
+}
+
+func (h *HTTPJSON) Init() error {
+	if len(h.URLs) == 0 {
+		return fmt.Errorf("no urls configured")
+	}
+	if len(h.Metrics) == 0 {
+		return fmt.Errorf("no metrics configured")
+	}
+	if h.MetricName == "" {
+		h.MetricName = defaultMetricName
+	}
+	if h.Timeout <= 0 {
+		h.Timeout = config.Duration(defaultTimeout)
+	}
+	h.metrics = make(map[string]*jmespath.JMESPath, len(h.Metrics))
+	for _, m := range h.Metrics {
+		if m.Name == "" {
+			return fmt.Errorf("metric with query %q has no name", m.Query)
+		}
+		if _, ok := h.metrics[m.Name]; ok {
+			return fmt.Errorf("duplicate metric %q", m.Name)
+		}
+		expr, err := jmespath.Compile(m.Query)
+		if err != nil {
+			return fmt.Errorf("invalid query %q of metric %q: %w", m.Query, m.Name, err)
+		}
+		h.metrics[m.Name] = expr
+	}
+	h.dimensions = make(map[string]*jmespath.JMESPath, len(h.Dimensions))
+	for name, query := range h.Dimensions {
+		expr, err := jmespath.Compile(query)
+		if err != nil {
+			return fmt.Errorf("invalid query %q of dimension %q: %w", query, name, err)
+		}
+		h.dimensions[name] = expr
+	}
+	tlsConfig, err := h.ClientConfig.TLSConfig()
+	if err != nil {
+		return err
+	}
+	h.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+		Timeout: time.Duration(h.Timeout),
+	}
+	return nil
+}
+
+func (h *HTTPJSON) Gather(acc telegraf.Accumulator) error {
+	var wg sync.WaitGroup
+	for _, url := range h.URLs {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			if err := h.gatherURL(acc, url); err != nil {
+				acc.AddError(fmt.Errorf("http_json %s: %w", url, err))
+			}
+		}(url)
+	}
+	wg.Wait()
+	return nil
+}
+
+// gatherURL polls the endpoint and adds the values extracted from its response. A query evaluating to null, e.g.
+// because the field is missing, is skipped, while a query evaluating to anything but a number or a boolean is an
+// error.
+func (h *HTTPJSON) gatherURL(acc telegraf.Accumulator, url string) error {
+	doc, err := h.fetch(url)
+	if err != nil {
+		return err
+	}
+	fields := make(map[string]interface{}, len(h.metrics))
+	for name, expr := range h.metrics {
+		result, err := expr.Search(doc)
+		if err != nil {
+			return fmt.Errorf("unable to evaluate the query of metric %q: %w", name, err)
+		}
+		switch v := result.(type) {
+		case nil:
+		case float64:
+			fields[name] = v
+		case bool:
+			if v {
+				fields[name] = 1.0
+			} else {
+				fields[name] = 0.0
+			}
+		default:
+			return fmt.Errorf("query of metric %q evaluated to %T, not a number", name, result)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	tags := map[string]string{urlTag: url}
+	for name, expr := range h.dimensions {
+		result, err := expr.Search(doc)
+		if err != nil {
+			return fmt.Errorf("unable to evaluate the query of dimension %q: %w", name, err)
+		}
+		switch v := result.(type) {
+		case nil:
+		case string:
+			tags[name] = v
+		case float64:
+			tags[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			tags[name] = strconv.FormatBool(v)
+		default:
+			return fmt.Errorf("query of dimension %q evaluated to %T, not a string", name, result)
+		}
+	}
+	acc.AddGauge(h.MetricName, fields, tags)
+	return nil
+}
+
+func (h *HTTPJSON) fetch(url string) (interface{}, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range h.Headers {
+		if strings.EqualFold(k, "host") {
+			req.Host = v
+		} else {
+			req.Header.Set(k, v)
+		}
+	}
+	if h.Username != "" || h.Password != "" {
+		req.SetBasicAuth(h.Username, h.Password)
+	}
+	if h.BearerTokenFile != "" {
+		token, err := os.ReadFile(h.BearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxResponseSize {
+		return nil, fmt.Errorf("response is larger than %d bytes", maxResponseSize)
+	}
+	var doc interface{}
+	if err = json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	return doc, nil
+}
+
+func init() {
+	inputs.Add("http_json", func() telegraf.Input {
+		return &HTTPJSON{}
+	})
+}
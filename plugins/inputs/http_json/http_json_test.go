@@ -0,0 +1,135 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stats = `{
+  "build": {"version": "1.4.2"},
+  "healthy": true,
+  "queues": [
+    {"name": "orders", "depth": 42},
+    {"name": "returns", "depth": 3}
+  ]
+}`
+
+func TestGather(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("s3cret\n"), 0600))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" || r.Header.Get("X-Team") != "storage" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(stats))
+	}))
+	defer server.Close()
+
+	h := &HTTPJSON{
+		URLs:            []string{server.URL},
+		Headers:         map[string]string{"X-Team": "storage"},
+		BearerTokenFile: tokenFile,
+		MetricName:      "admin",
+		Metrics: []Metric{
+			{Name: "orders_depth", Query: "queues[?name=='orders'].depth | [0]"},
+			{Name: "healthy", Query: "healthy"},
+			{Name: "missing", Query: "queues[?name=='refunds'].depth | [0]"},
+		},
+		Dimensions: map[string]string{"version": "build.version"},
+		Log:        testutil.Logger{},
+	}
+	require.NoError(t, h.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, h.Gather(acc))
+
+	require.Empty(t, acc.Errors)
+	acc.AssertContainsTaggedFields(t, "admin", map[string]interface{}{
+		"orders_depth": 42.0,
+		"healthy":      1.0,
+	}, map[string]string{urlTag: server.URL, "version": "1.4.2"})
+}
+
+func TestGatherBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "cwagent" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(stats))
+	}))
+	defer server.Close()
+
+	h := &HTTPJSON{
+		URLs:     []string{server.URL},
+		Username: "cwagent",
+		Password: "pass",
+		Metrics:  []Metric{{Name: "depth", Query: "sum(queues[].depth)"}},
+		Log:      testutil.Logger{},
+	}
+	require.NoError(t, h.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, h.Gather(acc))
+
+	require.Empty(t, acc.Errors)
+	acc.AssertContainsTaggedFields(t, defaultMetricName, map[string]interface{}{"depth": 45.0}, map[string]string{urlTag: server.URL})
+}
+
+func TestGatherErrors(t *testing.T) {
+	testCases := map[string]struct {
+		status int
+		body   string
+		query  string
+	}{
+		"Status":    {status: http.StatusServiceUnavailable, body: stats, query: "healthy"},
+		"NotJSON":   {status: http.StatusOK, body: "ok", query: "healthy"},
+		"NotNumber": {status: http.StatusOK, body: stats, query: "build.version"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(testCase.status)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			h := &HTTPJSON{URLs: []string{server.URL}, Metrics: []Metric{{Name: "value", Query: testCase.query}}, Log: testutil.Logger{}}
+			require.NoError(t, h.Init())
+			acc := &testutil.Accumulator{}
+			require.NoError(t, h.Gather(acc))
+
+			assert.Len(t, acc.Errors, 1)
+			assert.Zero(t, acc.NMetrics())
+		})
+	}
+}
+
+func TestInitErrors(t *testing.T) {
+	testCases := map[string]*HTTPJSON{
+		"NoURLs":       {Metrics: []Metric{{Name: "value", Query: "value"}}},
+		"NoMetrics":    {URLs: []string{"http://localhost"}},
+		"NoName":       {URLs: []string{"http://localhost"}, Metrics: []Metric{{Query: "value"}}},
+		"Duplicate":    {URLs: []string{"http://localhost"}, Metrics: []Metric{{Name: "value", Query: "a"}, {Name: "value", Query: "b"}}},
+		"InvalidQuery": {URLs: []string{"http://localhost"}, Metrics: []Metric{{Name: "value", Query: "queues[?"}}},
+		"InvalidDimension": {
+			URLs:       []string{"http://localhost"},
+			Metrics:    []Metric{{Name: "value", Query: "value"}},
+			Dimensions: map[string]string{"version": "build.["},
+		},
+	}
+	for name, h := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, h.Init())
+		})
+	}
+}
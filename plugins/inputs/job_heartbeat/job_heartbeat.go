@@ -0,0 +1,334 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package job_heartbeat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+)
+
+const (
+	measurement = "job"
+	jobTag      = "job"
+
+	markerStart   = "start"
+	markerStop    = "stop"
+	markerSuccess = "success"
+	markerFailure = "failure"
+
+	heartbeatPath  = "/v1/heartbeats"
+	defaultTimeout = 24 * time.Hour
+	// maxRequestSize bounds the body of a request, which only holds a marker.
+	maxRequestSize = 64 << 10
+	// maxRuns bounds the number of runs waiting for their stop marker, so that jobs never stopping cannot exhaust
+	// the memory of the agent.
+	maxRuns = 10000
+)
+
+var (
+	jobNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.\-]{1,255}$`)
+	markers        = collections.NewSet(markerStart, markerStop, markerSuccess, markerFailure)
+
+	errTooManyRuns = errors.New("too many runs in progress")
+)
+
+// heartbeat is a marker a job posts to the endpoint.
+type heartbeat struct {
+	Job    string `json:"job"`
+	Marker string `json:"marker"`
+	// RunID tells the runs of a job running concurrently apart.
+	RunID string `json:"run_id"`
+	// ExitCode of the job, for the stop marker. The run failed if it is not 0.
+	ExitCode *int `json:"exit_code"`
+}
+
+type runKey struct {
+	job   string
+	runID string
+}
+
+// run is a finished run of a job.
+type run struct {
+	job      string
+	end      time.Time
+	duration time.Duration
+	// started is false for the runs whose start marker was not received, which have no duration.
+	started bool
+	success bool
+}
+
+// JobHeartbeat turns the start and stop markers short-lived jobs, e.g. cron jobs, send to a local endpoint or drop
+// in a directory into duration and success metrics of their runs.
+type JobHeartbeat struct {
+	ServiceAddress string          `toml:"service_address"`
+	DropDirectory  string          `toml:"drop_directory"`
+	Timeout        config.Duration `toml:"timeout"`
+	Log            telegraf.Logger `toml:"-"`
+
+	mu       sync.Mutex
+	started  map[runKey]time.Time
+	finished []run
+
+	server *http.Server
+	wg     sync.WaitGroup
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+func (j *JobHeartbeat) Description() string {
+	return "Publish the duration and success of the runs of batch jobs from the markers they send"
+}
+
+func (j *JobHeartbeat) SampleConfig() string {
+	return `
+  ## Address of the endpoint the jobs post their markers to.
+  service_address = "127.0.0.1:25891"
+  ## Directory the jobs can drop <job>.start, <job>.stop, <job>.success and <job>.failure files in instead.
+  # drop_directory = "/var/run/amazon-cloudwatch-agent/jobs"
+  ## Runs without a stop marker after the timeout are published as failed.
+  timeout = "24h"
+`
+}
+
+func (j *JobHeartbeat) Init() error {
+	if j.ServiceAddress == "" && j.DropDirectory == "" {
+		return errors.New("either service_address or drop_directory must be set")
+	}
+	if j.Timeout <= 0 {
+		j.Timeout = config.Duration(defaultTimeout)
+	}
+	if j.now == nil {
+		j.now = time.Now
+	}
+	j.started = map[runKey]time.Time{}
+	return nil
+}
+
+func (j *JobHeartbeat) Start(telegraf.Accumulator) error {
+	if j.ServiceAddress == "" {
+		return nil
+	}
+	listener, err := net.Listen("tcp", j.ServiceAddress)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %w", j.ServiceAddress, err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(heartbeatPath, j.handleHeartbeat)
+	j.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		if err := j.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			j.Log.Errorf("Heartbeat server on %s stopped: %v", j.ServiceAddress, err)
+		}
+	}()
+	return nil
+}
+
+func (j *JobHeartbeat) Stop() {
+	if j.server != nil {
+		_ = j.server.Close()
+		j.wg.Wait()
+	}
+}
+
+// Gather publishes the runs finished since the last call. The runs are published with the time they ended, so
+// that the average of the success metric is the success rate of the job over a period.
+func (j *JobHeartbeat) Gather(acc telegraf.Accumulator) error {
+	if j.DropDirectory != "" {
+		if err := j.scanDirectory(); err != nil {
+			acc.AddError(fmt.Errorf("job_heartbeat %s: %w", j.DropDirectory, err))
+		}
+	}
+	j.mu.Lock()
+	j.expireRuns()
+	finished := j.finished
+	j.finished = nil
+	j.mu.Unlock()
+
+	for _, r := range finished {
+		fields := map[string]interface{}{"success": 0.0}
+		if r.success {
+			fields["success"] = 1.0
+		}
+		if r.started {
+			fields["duration"] = r.duration.Seconds()
+		}
+		acc.AddGauge(measurement, fields, map[string]string{jobTag: r.job}, r.end)
+	}
+	return nil
+}
+
+func (j *JobHeartbeat) handleHeartbeat(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, errors.New("only POST is allowed"))
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxRequestSize))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request must be at most %d bytes", maxRequestSize))
+		return
+	}
+	var hb heartbeat
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&hb); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON: %w", err))
+		return
+	}
+	if err = j.record(hb, j.now()); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errTooManyRuns) {
+			status = http.StatusServiceUnavailable
+		}
+		writeError(w, status, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// marker is a file dropped in the directory.
+type marker struct {
+	path string
+	heartbeat
+	at time.Time
+}
+
+// scanDirectory records the markers dropped in the directory since the last scan and removes them. The files are
+// named <job>.<marker> and the time they were last modified is the time of the marker. The file of a stop marker
+// can hold the exit code of the job.
+func (j *JobHeartbeat) scanDirectory() error {
+	entries, err := os.ReadDir(j.DropDirectory)
+	if err != nil {
+		return err
+	}
+	var dropped []marker
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		// the other files are left alone, the directory might not only hold markers
+		ext := filepath.Ext(entry.Name())
+		if !markers.Contains(strings.TrimPrefix(ext, ".")) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		m := marker{
+			path:      filepath.Join(j.DropDirectory, entry.Name()),
+			heartbeat: heartbeat{Job: strings.TrimSuffix(entry.Name(), ext), Marker: ext[1:]},
+			at:        info.ModTime(),
+		}
+		if m.Marker == markerStop {
+			if content, err := os.ReadFile(m.path); err == nil {
+				if code, err := strconv.Atoi(strings.TrimSpace(string(content))); err == nil {
+					m.ExitCode = &code
+				}
+			}
+		}
+		dropped = append(dropped, m)
+	}
+	// the start marker of a run comes first when its stop marker was dropped in the same second
+	sort.SliceStable(dropped, func(a, b int) bool {
+		if dropped[a].at.Equal(dropped[b].at) {
+			return dropped[a].Marker == markerStart && dropped[b].Marker != markerStart
+		}
+		return dropped[a].at.Before(dropped[b].at)
+	})
+	for _, m := range dropped {
+		if err = j.record(m.heartbeat, m.at); err != nil {
+			j.Log.Warnf("Ignoring marker %s: %v", m.path, err)
+		}
+		if err = os.Remove(m.path); err != nil && !os.IsNotExist(err) {
+			j.Log.Warnf("Unable to remove marker %s: %v", m.path, err)
+		}
+	}
+	return nil
+}
+
+// record starts or finishes a run of the job. A run starting again before it finished is published as failed,
+// as is a run still going after the timeout.
+func (j *JobHeartbeat) record(hb heartbeat, at time.Time) error {
+	if !jobNamePattern.MatchString(hb.Job) {
+		return fmt.Errorf("job must be 1 to 255 letters, digits, '.', '-' or '_', got %q", hb.Job)
+	}
+	key := runKey{job: hb.Job, runID: hb.RunID}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	switch hb.Marker {
+	case markerStart:
+		if start, ok := j.started[key]; ok {
+			j.finish(key, start, at, false)
+		} else if len(j.started) >= maxRuns {
+			return errTooManyRuns
+		}
+		j.started[key] = at
+	case markerStop, markerSuccess, markerFailure:
+		success := hb.Marker == markerSuccess || (hb.Marker == markerStop && (hb.ExitCode == nil || *hb.ExitCode == 0))
+		start, ok := j.started[key]
+		if !ok {
+			j.finished = append(j.finished, run{job: hb.Job, end: at, success: success})
+			return nil
+		}
+		delete(j.started, key)
+		j.finish(key, start, at, success)
+	default:
+		return fmt.Errorf("marker must be %s, %s, %s or %s, got %q", markerStart, markerStop, markerSuccess, markerFailure, hb.Marker)
+	}
+	return nil
+}
+
+func (j *JobHeartbeat) finish(key runKey, start, end time.Time, success bool) {
+	duration := end.Sub(start)
+	if duration < 0 {
+		duration = 0
+	}
+	j.finished = append(j.finished, run{job: key.job, end: end, duration: duration, started: true, success: success})
+}
+
+// expireRuns finishes the runs started longer than the timeout ago as failed.
+func (j *JobHeartbeat) expireRuns() {
+	timeout := time.Duration(j.Timeout)
+	deadline := j.now().Add(-timeout)
+	for key, start := range j.started {
+		if start.Before(deadline) {
+			delete(j.started, key)
+			j.finish(key, start, start.Add(timeout), false)
+		}
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func init() {
+	inputs.Add("job_heartbeat", func() telegraf.Input {
+		return &JobHeartbeat{}
+	})
+}
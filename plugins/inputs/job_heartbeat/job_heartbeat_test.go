@@ -0,0 +1,134 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package job_heartbeat
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJobHeartbeat(t *testing.T, dir string, now *time.Time) *JobHeartbeat {
+	j := &JobHeartbeat{
+		ServiceAddress: "127.0.0.1:0",
+		DropDirectory:  dir,
+		Timeout:        config.Duration(time.Hour),
+		Log:            testutil.Logger{},
+		now:            func() time.Time { return *now },
+	}
+	require.NoError(t, j.Init())
+	return j
+}
+
+func post(j *JobHeartbeat, body string) int {
+	rec := httptest.NewRecorder()
+	j.handleHeartbeat(rec, httptest.NewRequest(http.MethodPost, heartbeatPath, strings.NewReader(body)))
+	return rec.Code
+}
+
+func TestHandleHeartbeat(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	j := newTestJobHeartbeat(t, "", &now)
+
+	assert.Equal(t, http.StatusAccepted, post(j, `{"job": "backup", "marker": "start"}`))
+	now = now.Add(90 * time.Second)
+	assert.Equal(t, http.StatusAccepted, post(j, `{"job": "backup", "marker": "stop", "exit_code": 0}`))
+	assert.Equal(t, http.StatusAccepted, post(j, `{"job": "report", "run_id": "a", "marker": "start"}`))
+	assert.Equal(t, http.StatusAccepted, post(j, `{"job": "report", "run_id": "b", "marker": "start"}`))
+	now = now.Add(10 * time.Second)
+	assert.Equal(t, http.StatusAccepted, post(j, `{"job": "report", "run_id": "a", "marker": "failure"}`))
+	assert.Equal(t, http.StatusAccepted, post(j, `{"job": "cleanup", "marker": "stop", "exit_code": 2}`))
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, j.Gather(acc))
+	require.Len(t, acc.Metrics, 3)
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{"duration": 90.0, "success": 1.0}, map[string]string{jobTag: "backup"})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{"duration": 10.0, "success": 0.0}, map[string]string{jobTag: "report"})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{"success": 0.0}, map[string]string{jobTag: "cleanup"})
+	assert.Equal(t, now, acc.Metrics[1].Time)
+
+	// run b of report is still going until it times out
+	acc.ClearMetrics()
+	require.NoError(t, j.Gather(acc))
+	assert.Empty(t, acc.Metrics)
+	now = now.Add(time.Hour)
+	require.NoError(t, j.Gather(acc))
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{"duration": 3600.0, "success": 0.0}, map[string]string{jobTag: "report"})
+}
+
+func TestHandleHeartbeatErrors(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	j := newTestJobHeartbeat(t, "", &now)
+
+	assert.Equal(t, http.StatusBadRequest, post(j, `{"job": "backup"`))
+	assert.Equal(t, http.StatusBadRequest, post(j, `{"job": "backup", "marker": "begin"}`))
+	assert.Equal(t, http.StatusBadRequest, post(j, `{"job": "nightly backup", "marker": "start"}`))
+	assert.Equal(t, http.StatusBadRequest, post(j, `{"job": "backup", "marker": "start", "status": "ok"}`))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(j, `{"job": "`+strings.Repeat("a", maxRequestSize)+`"}`))
+
+	rec := httptest.NewRecorder()
+	j.handleHeartbeat(rec, httptest.NewRequest(http.MethodGet, heartbeatPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestRestartedRun(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	j := newTestJobHeartbeat(t, "", &now)
+
+	require.NoError(t, j.record(heartbeat{Job: "backup", Marker: markerStart}, now))
+	require.NoError(t, j.record(heartbeat{Job: "backup", Marker: markerStart}, now.Add(time.Minute)))
+	require.NoError(t, j.record(heartbeat{Job: "backup", Marker: markerSuccess}, now.Add(2*time.Minute)))
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, j.Gather(acc))
+	require.Len(t, acc.Metrics, 2)
+	assert.Equal(t, map[string]interface{}{"duration": 60.0, "success": 0.0}, acc.Metrics[0].Fields)
+	assert.Equal(t, map[string]interface{}{"duration": 60.0, "success": 1.0}, acc.Metrics[1].Fields)
+}
+
+func TestDropDirectory(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Unix(1700000000, 0)
+	j := newTestJobHeartbeat(t, dir, &now)
+
+	drop := func(name, content string, at time.Time) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		require.NoError(t, os.Chtimes(path, at, at))
+	}
+	drop("db.backup.start", "", now)
+	drop("db.backup.stop", "1\n", now.Add(30*time.Second))
+	drop("report.start", "", now)
+	drop("report.success", "", now)
+	drop("README", "", now)
+	drop("notes.txt", "", now)
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, j.Gather(acc))
+	require.Empty(t, acc.Errors)
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{"duration": 30.0, "success": 0.0}, map[string]string{jobTag: "db.backup"})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{"duration": 0.0, "success": 1.0}, map[string]string{jobTag: "report"})
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "README", entries[0].Name())
+	assert.Equal(t, "notes.txt", entries[1].Name())
+}
+
+func TestInit(t *testing.T) {
+	assert.Error(t, (&JobHeartbeat{}).Init())
+	j := &JobHeartbeat{DropDirectory: t.TempDir()}
+	require.NoError(t, j.Init())
+	assert.Equal(t, config.Duration(defaultTimeout), j.Timeout)
+}
@@ -0,0 +1,356 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+const (
+	EventOOMKill   = "oom_kill"
+	EventHungTask  = "hung_task"
+	EventDiskError = "disk_error"
+
+	defaultNamespace = "CWAgent"
+	hostDimension    = "host"
+	// retryInterval is how long to wait before opening the kernel log again after it failed.
+	retryInterval = time.Minute
+)
+
+// eventMetrics are the counters each kind of event adds to.
+var eventMetrics = map[string]string{
+	EventOOMKill:   "kernel_oom_kills",
+	EventHungTask:  "kernel_hung_tasks",
+	EventDiskError: "kernel_disk_errors",
+}
+
+var (
+	// e.g. "Out of memory: Killed process 1234 (java) total-vm:..." or "Memory cgroup out of memory: Killed process..."
+	oomKillPattern = regexp.MustCompile(`[Oo]ut of memory: Kill(?:ed)? process (\d+) \(([^)]*)\)`)
+	// e.g. "INFO: task kworker/0:1:123 blocked for more than 120 seconds."
+	hungTaskPattern = regexp.MustCompile(`task (\S+):(\d+) blocked for more than (\d+) seconds`)
+	// e.g. "I/O error, dev nvme0n1, sector 2048 op 0x1:(WRITE)", "Buffer I/O error on dev sda1, logical block 0"
+	// or "EXT4-fs error (device sda1): ..."
+	diskErrorPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`I/O error,? (?:on )?dev (\w+)`),
+		regexp.MustCompile(`Buffer I/O error on (?:dev|device) (\w+)`),
+		regexp.MustCompile(`\w+-fs error \(device (\w+)\)`),
+	}
+)
+
+// Event is one log event: a kernel message that points to a problem of the node.
+type Event struct {
+	Event   string `json:"event"`
+	Message string `json:"message"`
+	Host    string `json:"host,omitempty"`
+	// KernelTime is the time of the message in seconds since boot, as the kernel reports it.
+	KernelTime float64 `json:"kernel_time"`
+	Process    string  `json:"process,omitempty"`
+	PID        int     `json:"pid,omitempty"`
+	Device     string  `json:"device,omitempty"`
+	// BlockedSeconds is how long a hung task was blocked for when it was reported.
+	BlockedSeconds int `json:"blocked_seconds,omitempty"`
+}
+
+type Plugin struct {
+	Namespace     string          `toml:"namespace"`
+	LogGroupName  string          `toml:"log_group_name"`
+	LogStreamName string          `toml:"log_stream_name"`
+	LogGroupClass string          `toml:"log_group_class"`
+	Destination   string          `toml:"destination"`
+	Retention     int             `toml:"retention_in_days"`
+	Log           telegraf.Logger `toml:"-"`
+
+	src      *kernelEvents
+	srcFound bool
+}
+
+func (p *Plugin) Description() string {
+	return "Write the OOM kills, hung tasks and disk errors the kernel logs to /dev/kmsg into a log stream"
+}
+
+func (p *Plugin) SampleConfig() string {
+	return `
+  ## Namespace of the counters of the events, written as embedded metrics.
+  namespace = "CWAgent"
+
+  log_group_name = "kernel-events"
+  log_stream_name = "STREAM_NAME"
+  destination = "cloudwatchlogs"
+`
+}
+
+func (p *Plugin) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+func (p *Plugin) Start(telegraf.Accumulator) error {
+	if p.src != nil {
+		return nil
+	}
+	namespace := p.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	host, _ := os.Hostname()
+	p.src = &kernelEvents{
+		plugin:    p,
+		namespace: namespace,
+		host:      host,
+		open:      openKmsg,
+		done:      make(chan struct{}),
+	}
+	return nil
+}
+
+func (p *Plugin) FindLogSrc() []logs.LogSrc {
+	if p.src == nil || p.srcFound {
+		return nil
+	}
+	p.srcFound = true
+	return []logs.LogSrc{p.src}
+}
+
+func (p *Plugin) Stop() {
+	if p.src != nil {
+		p.src.Stop()
+	}
+}
+
+// kernelEvents is the log source of the plugin.
+type kernelEvents struct {
+	plugin    *Plugin
+	namespace string
+	host      string
+	// open returns the kernel log, positioned after the messages logged before the agent started. Each read returns
+	// one record.
+	open func() (io.ReadCloser, error)
+
+	outputFn  func(logs.LogEvent)
+	startOnce sync.Once
+	stopOnce  sync.Once
+	done      chan struct{}
+
+	mu     sync.Mutex
+	reader io.ReadCloser
+}
+
+var _ logs.LogSrc = (*kernelEvents)(nil)
+
+func (k *kernelEvents) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	k.outputFn = fn
+	k.startOnce.Do(func() { go k.run() })
+}
+
+func (k *kernelEvents) Group() string {
+	return k.plugin.LogGroupName
+}
+
+func (k *kernelEvents) Stream() string {
+	return k.plugin.LogStreamName
+}
+
+func (k *kernelEvents) Destination() string {
+	return k.plugin.Destination
+}
+
+func (k *kernelEvents) Description() string {
+	return "kernel events"
+}
+
+func (k *kernelEvents) Retention() int {
+	return k.plugin.Retention
+}
+
+func (k *kernelEvents) Class() string {
+	return k.plugin.LogGroupClass
+}
+
+func (k *kernelEvents) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+// Stop closes the kernel log, which unblocks the pending read.
+func (k *kernelEvents) Stop() {
+	k.stopOnce.Do(func() {
+		close(k.done)
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		if k.reader != nil {
+			k.reader.Close()
+		}
+	})
+}
+
+func (k *kernelEvents) run() {
+	for {
+		if err := k.follow(); err != nil {
+			log.Printf("W! [kernel_events] Unable to read the kernel log, retrying in %v: %v", retryInterval, err)
+		}
+		select {
+		case <-k.done:
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// follow reads the records of the kernel log until it is closed or fails.
+func (k *kernelEvents) follow() error {
+	reader, err := k.open()
+	if err != nil {
+		return err
+	}
+	k.mu.Lock()
+	select {
+	case <-k.done:
+		k.mu.Unlock()
+		return reader.Close()
+	default:
+	}
+	k.reader = reader
+	k.mu.Unlock()
+	defer reader.Close()
+
+	buf := make([]byte, 8192)
+	for {
+		n, err := reader.Read(buf)
+		if err != nil {
+			select {
+			case <-k.done:
+				return nil
+			default:
+			}
+			if errors.Is(err, errOverwritten) {
+				// the kernel overwrote records before they were read, the next read returns the oldest one left
+				log.Printf("W! [kernel_events] Kernel log records were overwritten before they were read")
+				continue
+			}
+			return err
+		}
+		if e, ok := k.parse(string(buf[:n])); ok {
+			k.publish(e)
+		}
+	}
+}
+
+// parse returns the event of a /dev/kmsg record, e.g. "3,1234,5678901,-;Out of memory: Killed process ...", and
+// false if the message is not one the plugin reports. The continuation lines of a record are left out.
+func (k *kernelEvents) parse(record string) (Event, bool) {
+	header, message, ok := strings.Cut(record, ";")
+	if !ok {
+		return Event{}, false
+	}
+	message, _, _ = strings.Cut(message, "\n")
+	e, ok := classify(message)
+	if !ok {
+		return Event{}, false
+	}
+	e.Host = k.host
+	if fields := strings.Split(header, ","); len(fields) >= 3 {
+		if micros, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			e.KernelTime = float64(micros) / float64(time.Second/time.Microsecond)
+		}
+	}
+	return e, true
+}
+
+// classify returns the event of a kernel message, and false if it is not an OOM kill, a hung task or a disk error.
+func classify(message string) (Event, bool) {
+	if m := oomKillPattern.FindStringSubmatch(message); m != nil {
+		pid, _ := strconv.Atoi(m[1])
+		return Event{Event: EventOOMKill, Message: message, PID: pid, Process: m[2]}, true
+	}
+	if m := hungTaskPattern.FindStringSubmatch(message); m != nil {
+		pid, _ := strconv.Atoi(m[2])
+		blocked, _ := strconv.Atoi(m[3])
+		return Event{Event: EventHungTask, Message: message, Process: m[1], PID: pid, BlockedSeconds: blocked}, true
+	}
+	for _, pattern := range diskErrorPatterns {
+		if m := pattern.FindStringSubmatch(message); m != nil {
+			return Event{Event: EventDiskError, Message: message, Device: m[1]}, true
+		}
+	}
+	return Event{}, false
+}
+
+// encode returns the log of the event. It is an EMF log, so that each event also counts towards the metric of its
+// kind, by host.
+func (k *kernelEvents) encode(e Event, t time.Time) ([]byte, error) {
+	content, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err = json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+	metric := eventMetrics[e.Event]
+	fields[metric] = 1
+	dimensions := []string{}
+	if e.Host != "" {
+		dimensions = append(dimensions, hostDimension)
+	}
+	fields["_aws"] = map[string]any{
+		"Timestamp": t.UnixMilli(),
+		"CloudWatchMetrics": []map[string]any{{
+			"Namespace":  k.namespace,
+			"Dimensions": [][]string{dimensions},
+			"Metrics":    []map[string]string{{"Name": metric, "Unit": "Count"}},
+		}},
+	}
+	return json.Marshal(fields)
+}
+
+func (k *kernelEvents) publish(e Event) {
+	now := time.Now()
+	content, err := k.encode(e, now)
+	if err != nil {
+		log.Printf("E! [kernel_events] Unable to encode event: %v", err)
+		return
+	}
+	k.outputFn(&logEvent{msg: string(content), t: now})
+}
+
+type logEvent struct {
+	msg string
+	t   time.Time
+}
+
+func (e *logEvent) Message() string {
+	return e.msg
+}
+
+func (e *logEvent) Time() time.Time {
+	return e.t
+}
+
+func (e *logEvent) Done() {}
+
+func init() {
+	inputs.Add("kernel_events", func() telegraf.Input {
+		return &Plugin{
+			Namespace:   defaultNamespace,
+			Destination: "cloudwatchlogs",
+		}
+	})
+}
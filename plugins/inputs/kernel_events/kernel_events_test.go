@@ -0,0 +1,174 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+func TestClassify(t *testing.T) {
+	testCases := map[string]struct {
+		message string
+		want    Event
+		wantOK  bool
+	}{
+		"OOMKill": {
+			message: "Out of memory: Killed process 1234 (java) total-vm:8000000kB, anon-rss:4000000kB, file-rss:0kB",
+			want:    Event{Event: EventOOMKill, PID: 1234, Process: "java"},
+			wantOK:  true,
+		},
+		"CgroupOOMKill": {
+			message: "Memory cgroup out of memory: Killed process 42 (node) total-vm:100kB",
+			want:    Event{Event: EventOOMKill, PID: 42, Process: "node"},
+			wantOK:  true,
+		},
+		"HungTask": {
+			message: "INFO: task kworker/0:1:123 blocked for more than 120 seconds.",
+			want:    Event{Event: EventHungTask, PID: 123, Process: "kworker/0:1", BlockedSeconds: 120},
+			wantOK:  true,
+		},
+		"BlockIOError": {
+			message: "I/O error, dev nvme1n1, sector 2048 op 0x1:(WRITE) flags 0x800 phys_seg 1 prio class 0",
+			want:    Event{Event: EventDiskError, Device: "nvme1n1"},
+			wantOK:  true,
+		},
+		"BufferIOError": {
+			message: "Buffer I/O error on dev sda1, logical block 0, lost async page write",
+			want:    Event{Event: EventDiskError, Device: "sda1"},
+			wantOK:  true,
+		},
+		"FilesystemError": {
+			message: "EXT4-fs error (device xvda1): ext4_find_entry:1455: inode #2: comm ls: reading directory lblock 0",
+			want:    Event{Event: EventDiskError, Device: "xvda1"},
+			wantOK:  true,
+		},
+		"Other": {
+			message: "eth0: renamed from veth1234",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := classify(testCase.message)
+			assert.Equal(t, testCase.wantOK, ok)
+			if testCase.wantOK {
+				testCase.want.Message = testCase.message
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	k := &kernelEvents{host: "node-1"}
+	e, ok := k.parse("3,1021,5123456789,-;Out of memory: Killed process 1234 (java)\n SUBSYSTEM=memory\n")
+	require.True(t, ok)
+	assert.Equal(t, Event{
+		Event:      EventOOMKill,
+		Message:    "Out of memory: Killed process 1234 (java)",
+		Host:       "node-1",
+		KernelTime: 5123.456789,
+		Process:    "java",
+		PID:        1234,
+	}, e)
+
+	_, ok = k.parse("6,1022,5123456790,-;eth0: link up")
+	assert.False(t, ok)
+	_, ok = k.parse("not a record")
+	assert.False(t, ok)
+}
+
+func TestEncode(t *testing.T) {
+	k := &kernelEvents{namespace: "Kernel"}
+	content, err := k.encode(Event{Event: EventHungTask, Message: "hung", Host: "node-1", Process: "dd", PID: 7}, time.UnixMilli(1700000000000))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"event": "hung_task",
+		"message": "hung",
+		"host": "node-1",
+		"kernel_time": 0,
+		"process": "dd",
+		"pid": 7,
+		"kernel_hung_tasks": 1,
+		"_aws": {
+			"Timestamp": 1700000000000,
+			"CloudWatchMetrics": [{
+				"Namespace": "Kernel",
+				"Dimensions": [["host"]],
+				"Metrics": [{"Name": "kernel_hung_tasks", "Unit": "Count"}]
+			}]
+		}
+	}`, string(content))
+}
+
+// recordReader returns one record per read, like /dev/kmsg, and blocks until it is closed once they are all read.
+type recordReader struct {
+	records chan string
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func (r *recordReader) Read(p []byte) (int, error) {
+	select {
+	case record := <-r.records:
+		return copy(p, record), nil
+	case <-r.closed:
+		return 0, io.EOF
+	}
+}
+
+func (r *recordReader) Close() error {
+	r.once.Do(func() { close(r.closed) })
+	return nil
+}
+
+func TestPlugin(t *testing.T) {
+	p := &Plugin{LogGroupName: "group", LogStreamName: "stream", Destination: "cloudwatchlogs", Retention: 7}
+	require.NoError(t, p.Start(nil))
+	srcs := p.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Empty(t, p.FindLogSrc())
+
+	src := srcs[0]
+	assert.Equal(t, "group", src.Group())
+	assert.Equal(t, "stream", src.Stream())
+	assert.Equal(t, "cloudwatchlogs", src.Destination())
+	assert.Equal(t, 7, src.Retention())
+	assert.Equal(t, defaultNamespace, p.src.namespace)
+
+	reader := &recordReader{records: make(chan string, 3), closed: make(chan struct{})}
+	reader.records <- "6,1,100,-;eth0: link up"
+	reader.records <- "3,2,200,-;Buffer I/O error on dev sdb, logical block 0"
+	p.src.open = func() (io.ReadCloser, error) {
+		return reader, nil
+	}
+	events := make(chan logs.LogEvent, 10)
+	src.SetOutput(func(e logs.LogEvent) { events <- e })
+
+	select {
+	case e := <-events:
+		var got map[string]any
+		require.NoError(t, json.Unmarshal([]byte(e.Message()), &got))
+		assert.Equal(t, EventDiskError, got["event"])
+		assert.Equal(t, "sdb", got["device"])
+		assert.EqualValues(t, 1, got["kernel_disk_errors"])
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "no event published")
+	}
+
+	p.Stop()
+	select {
+	case <-reader.closed:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "kernel log not closed")
+	}
+}
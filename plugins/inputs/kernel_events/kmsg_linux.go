@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+// +build linux
+
+package kernel_events
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+var kmsgPath = "/dev/kmsg"
+
+// errOverwritten is returned by a read of /dev/kmsg when the record it was at was overwritten in the ring buffer.
+var errOverwritten = syscall.EPIPE
+
+// openKmsg opens the kernel log after its last record, so that the messages logged before the agent started are not
+// written again when it restarts.
+func openKmsg() (io.ReadCloser, error) {
+	f, err := os.Open(kmsgPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = f.Seek(0, io.SeekEnd); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
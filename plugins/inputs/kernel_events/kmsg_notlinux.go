@@ -0,0 +1,18 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+// +build !linux
+
+package kernel_events
+
+import (
+	"errors"
+	"io"
+)
+
+var errOverwritten = errors.New("kernel log record overwritten")
+
+func openKmsg() (io.ReadCloser, error) {
+	return nil, errors.ErrUnsupported
+}
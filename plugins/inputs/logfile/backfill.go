@@ -0,0 +1,104 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/globpath"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/tail"
+)
+
+// Verify LogFile implements BackfillCollection
+var _ logs.BackfillCollection = (*LogFile)(nil)
+
+// FindBackfillSrc creates a source for every existing file (including rotated files) that matches a file config and was
+// modified within maxAge. Each file is read from its saved offset, or from the beginning, until EOF. Events with a
+// parsed timestamp older than maxAge are skipped since CloudWatch Logs would reject them.
+func (t *LogFile) FindBackfillSrc(maxAge time.Duration) []logs.LogSrc {
+	if !t.started {
+		t.Log.Warnf("not started with file state folder %s", t.FileStateFolder)
+		return nil
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	var srcs []logs.LogSrc
+	for i := range t.FileConfig {
+		fileconfig := &t.FileConfig[i]
+		if fileconfig.Pipe {
+			continue
+		}
+		files, err := t.getBackfillFiles(fileconfig, cutoff)
+		if err != nil {
+			t.Log.Errorf("Failed to find backfill files for file config %v, with error: %v", fileconfig.FilePath, err)
+			continue
+		}
+		for _, filename := range files {
+			seekFile := &tail.SeekInfo{Whence: io.SeekStart, Offset: 0}
+			if offset, err := t.restoreState(filename); err == nil {
+				seekFile.Offset = offset
+			}
+			src, err := t.newTailerSrc(fileconfig, filename, seekFile, false)
+			if err != nil {
+				t.Log.Errorf("Failed to open file %v for backfill with error: %v", filename, err)
+				continue
+			}
+			src.minTimestamp = cutoff
+			t.Log.Infof("Backfilling %s from offset %d", filename, seekFile.Offset)
+			srcs = append(srcs, src)
+		}
+	}
+	return srcs
+}
+
+// getBackfillFiles returns every file matching the file config that was modified after the cutoff, oldest first so
+// rotated files are uploaded before the active one.
+func (t *LogFile) getBackfillFiles(fileconfig *FileConfig, cutoff time.Time) ([]string, error) {
+	g, err := globpath.Compile(fileconfig.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("file_path glob %s failed to compile, %s", fileconfig.FilePath, err)
+	}
+
+	type backfillFile struct {
+		name    string
+		modTime time.Time
+	}
+	var files []backfillFile
+	for matchedFileName, matchedFileInfo := range g.Match() {
+		if t.FileStateFolder != "" && strings.HasPrefix(matchedFileName, t.FileStateFolder) {
+			continue
+		}
+		if isCompressedFile(matchedFileName) {
+			continue
+		}
+		if isDir, err := isDirectory(matchedFileName); err != nil || isDir {
+			continue
+		}
+		if fileconfig.BlacklistRegexP != nil && fileconfig.BlacklistRegexP.MatchString(filepath.Base(matchedFileName)) {
+			continue
+		}
+		if matchedFileInfo.ModTime().Before(cutoff) {
+			continue
+		}
+		files = append(files, backfillFile{name: matchedFileName, modTime: matchedFileInfo.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].modTime.Equal(files[j].modTime) {
+			return files[i].name < files[j].name
+		}
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.name)
+	}
+	return names, nil
+}
@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+func TestFindBackfillSrc(t *testing.T) {
+	multilineWaitPeriod = 10 * time.Millisecond
+	dir := t.TempDir()
+	now := time.Now()
+	writeFile := func(name, content string, modTime time.Time) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+		return path
+	}
+	rotated := writeFile("app.log.1", "2024-01-01T00:00:00Z too old\n"+now.Add(-2*time.Hour).UTC().Format(time.RFC3339)+" rotated\n", now.Add(-time.Hour))
+	active := writeFile("app.log", now.UTC().Format(time.RFC3339)+" active\n", now)
+	writeFile("app.log.2", "expired file\n", now.Add(-10*24*time.Hour))
+	writeFile("app.log.3.gz", "compressed\n", now)
+
+	tt := NewLogFile()
+	tt.Log = TestLogger{t}
+	tt.FileStateFolder = filepath.Join(dir, "state")
+	tt.FileConfig = []FileConfig{{
+		FilePath:        filepath.Join(dir, "app.log*"),
+		LogGroupName:    "group",
+		LogStreamName:   "stream",
+		TimestampRegex:  `^(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z)`,
+		TimestampLayout: []string{time.RFC3339},
+		Timezone:        "UTC",
+		AutoRemoval:     true,
+	}}
+	require.NoError(t, tt.FileConfig[0].init())
+	tt.started = true
+
+	srcs := tt.FindBackfillSrc(7 * 24 * time.Hour)
+	require.Len(t, srcs, 2)
+	assert.Equal(t, rotated, srcs[0].Description())
+	assert.Equal(t, active, srcs[1].Description())
+
+	var messages []string
+	for _, src := range srcs {
+		assert.Equal(t, "group", src.Group())
+		assert.Equal(t, "stream", src.Stream())
+		done := make(chan struct{})
+		src.SetOutput(func(e logs.LogEvent) {
+			if e == nil {
+				close(done)
+				return
+			}
+			messages = append(messages, e.Message())
+			e.Done()
+		})
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("backfill source %s did not stop at EOF", src.Description())
+		}
+		src.Stop()
+	}
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages[0], "rotated")
+	assert.Contains(t, messages[1], "active")
+
+	// backfill never removes files even if auto_removal is set
+	assert.FileExists(t, rotated)
+	assert.FileExists(t, active)
+	tt.Stop()
+}
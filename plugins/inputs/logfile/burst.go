@@ -0,0 +1,168 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"time"
+)
+
+const (
+	defaultBurstSampleRate = 0.1
+	defaultBurstCooldown   = 60
+	// burstSummaryInterval is how often the counts of a burst are published while it lasts.
+	burstSummaryInterval = time.Minute
+	burstRateWindow      = time.Second
+)
+
+// BurstConfig switches a file to sampled upload while its event rate is above the threshold. A summary event
+// with the number of events kept and dropped is published to the same log stream while the burst lasts.
+type BurstConfig struct {
+	// Threshold is the rate in events per second above which the file is sampled.
+	Threshold int `toml:"threshold"`
+	// SampleRate is the fraction of events uploaded during a burst.
+	SampleRate float64 `toml:"sample_rate"`
+	// Cooldown is the number of seconds the rate must stay below the threshold before sampling stops.
+	Cooldown int `toml:"cooldown"`
+}
+
+func (c *BurstConfig) init() error {
+	if c.Threshold <= 0 {
+		return fmt.Errorf("burst_detection threshold must be positive, but got %d", c.Threshold)
+	}
+	if c.SampleRate == 0 {
+		c.SampleRate = defaultBurstSampleRate
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return fmt.Errorf("burst_detection sample_rate must be between 0 and 1, but got %v", c.SampleRate)
+	}
+	if c.Cooldown == 0 {
+		c.Cooldown = defaultBurstCooldown
+	}
+	if c.Cooldown < 0 {
+		return fmt.Errorf("burst_detection cooldown must be positive, but got %d", c.Cooldown)
+	}
+	return nil
+}
+
+// burstSummary is the body of the event published with the counts of a burst.
+type burstSummary struct {
+	Burst struct {
+		Start     time.Time `json:"start"`
+		End       time.Time `json:"end"`
+		Kept      int       `json:"events_kept"`
+		Dropped   int       `json:"events_dropped"`
+		Threshold int       `json:"threshold"`
+		Ongoing   bool      `json:"ongoing"`
+	} `json:"cwagent_log_burst"`
+}
+
+// burstDetector measures the event rate of a single file. It is only used from the tailer goroutine.
+type burstDetector struct {
+	name     string
+	cfg      BurstConfig
+	every    int
+	cooldown time.Duration
+
+	windowStart time.Time
+	windowCount int
+
+	active      bool
+	quietSince  time.Time
+	seen        int
+	summary     burstSummary
+	lastSummary time.Time
+}
+
+func newBurstDetector(name string, cfg *BurstConfig) *burstDetector {
+	if cfg == nil || cfg.Threshold <= 0 {
+		return nil
+	}
+	every := 0
+	if cfg.SampleRate > 0 {
+		every = int(math.Round(1 / cfg.SampleRate))
+	}
+	return &burstDetector{
+		name:     name,
+		cfg:      *cfg,
+		every:    every,
+		cooldown: time.Duration(cfg.Cooldown) * time.Second,
+	}
+}
+
+// admit counts an event at now and reports whether it should be uploaded. When the counts of a burst are due,
+// summary is the message to publish for them.
+func (b *burstDetector) admit(now time.Time) (keep bool, summary string) {
+	if b.windowStart.IsZero() {
+		b.windowStart = now
+	}
+	if elapsed := now.Sub(b.windowStart); elapsed >= burstRateWindow {
+		summary = b.evaluate(now, float64(b.windowCount)/elapsed.Seconds())
+		b.windowStart = now
+		b.windowCount = 0
+	}
+	b.windowCount++
+
+	if !b.active {
+		return true, summary
+	}
+	keep = b.every > 0 && b.seen%b.every == 0
+	b.seen++
+	if keep {
+		b.summary.Burst.Kept++
+	} else {
+		b.summary.Burst.Dropped++
+	}
+	if summary == "" && now.Sub(b.lastSummary) >= burstSummaryInterval {
+		summary = b.flush(now, true)
+	}
+	return keep, summary
+}
+
+func (b *burstDetector) evaluate(now time.Time, rate float64) string {
+	if rate > float64(b.cfg.Threshold) {
+		b.quietSince = time.Time{}
+		if !b.active {
+			log.Printf("W! [logfile] %s exceeded %d events per second, uploading %v of its events until the rate drops", b.name, b.cfg.Threshold, b.cfg.SampleRate)
+			b.active = true
+			b.seen = 0
+			b.summary = burstSummary{}
+			b.summary.Burst.Start = now
+			b.summary.Burst.Threshold = b.cfg.Threshold
+			b.lastSummary = now
+		}
+		return ""
+	}
+	if !b.active {
+		return ""
+	}
+	if b.quietSince.IsZero() {
+		// the rate was measured from the start of the window, so that is when the file went quiet
+		b.quietSince = b.windowStart
+	}
+	if now.Sub(b.quietSince) < b.cooldown {
+		return ""
+	}
+	log.Printf("I! [logfile] %s is back below %d events per second, uploading all of its events", b.name, b.cfg.Threshold)
+	b.active = false
+	return b.flush(now, false)
+}
+
+// flush returns the summary of the counts since the last one and resets them.
+func (b *burstDetector) flush(now time.Time, ongoing bool) string {
+	b.summary.Burst.End = now
+	b.summary.Burst.Ongoing = ongoing
+	content, err := json.Marshal(b.summary)
+	b.summary.Burst.Start = now
+	b.summary.Burst.Kept = 0
+	b.summary.Burst.Dropped = 0
+	b.lastSummary = now
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
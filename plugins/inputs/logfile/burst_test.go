@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBurstConfigInit(t *testing.T) {
+	cfg := &BurstConfig{Threshold: 100}
+	require.NoError(t, cfg.init())
+	assert.Equal(t, defaultBurstSampleRate, cfg.SampleRate)
+	assert.Equal(t, defaultBurstCooldown, cfg.Cooldown)
+
+	assert.Error(t, (&BurstConfig{}).init())
+	assert.Error(t, (&BurstConfig{Threshold: 100, SampleRate: 2}).init())
+	assert.Error(t, (&BurstConfig{Threshold: 100, Cooldown: -1}).init())
+	assert.Nil(t, newBurstDetector("test", nil))
+}
+
+func TestBurstDetector(t *testing.T) {
+	b := newBurstDetector("test", &BurstConfig{Threshold: 10, SampleRate: 0.25, Cooldown: 5})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// below the threshold every event is kept
+	for i := 0; i < 10; i++ {
+		keep, summary := b.admit(now.Add(time.Duration(i) * 100 * time.Millisecond))
+		assert.True(t, keep)
+		assert.Empty(t, summary)
+	}
+
+	// 100 events in the next second starts the burst on the following window
+	now = now.Add(time.Second)
+	for i := 0; i < 100; i++ {
+		keep, _ := b.admit(now.Add(time.Duration(i) * 10 * time.Millisecond))
+		assert.True(t, keep)
+	}
+	now = now.Add(time.Second)
+	var kept int
+	for i := 0; i < 100; i++ {
+		keep, summary := b.admit(now.Add(time.Duration(i) * 10 * time.Millisecond))
+		assert.Empty(t, summary)
+		if keep {
+			kept++
+		}
+	}
+	assert.True(t, b.active)
+	assert.Equal(t, 25, kept)
+
+	// the burst ends after the rate stays below the threshold for the cooldown
+	now = now.Add(time.Second)
+	// the rate is still above the threshold, so the event is sampled
+	keep, summary := b.admit(now)
+	assert.True(t, keep)
+	assert.Empty(t, summary)
+	keep, _ = b.admit(now.Add(time.Millisecond))
+	assert.False(t, keep)
+	keep, summary = b.admit(now.Add(6 * time.Second))
+	assert.True(t, keep)
+	require.NotEmpty(t, summary)
+	assert.False(t, b.active)
+
+	var got burstSummary
+	require.NoError(t, json.Unmarshal([]byte(summary), &got))
+	assert.Equal(t, 26, got.Burst.Kept)
+	assert.Equal(t, 76, got.Burst.Dropped)
+	assert.Equal(t, 10, got.Burst.Threshold)
+	assert.False(t, got.Burst.Ongoing)
+}
+
+func TestBurstDetectorPeriodicSummary(t *testing.T) {
+	b := newBurstDetector("test", &BurstConfig{Threshold: 1, SampleRate: 0.5, Cooldown: 60})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var summaries []string
+	// 5 events per second for two minutes
+	for i := 0; i < 600; i++ {
+		if _, summary := b.admit(now.Add(time.Duration(i) * 200 * time.Millisecond)); summary != "" {
+			summaries = append(summaries, summary)
+		}
+	}
+	require.Len(t, summaries, 1)
+	var got burstSummary
+	require.NoError(t, json.Unmarshal([]byte(summaries[0]), &got))
+	assert.True(t, got.Burst.Ongoing)
+	assert.Equal(t, time.Minute, got.Burst.End.Sub(got.Burst.Start))
+}
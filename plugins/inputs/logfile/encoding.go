@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+const (
+	encodingAuto    = "auto"
+	encodingUTF8    = "utf-8"
+	encodingUTF16LE = "utf-16le"
+	encodingUTF16BE = "utf-16be"
+
+	// encodingSniffSize is the number of bytes read from the head of a file to guess its encoding.
+	encodingSniffSize = 4096
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// detectEncoding guesses the encoding of a log file from its byte order mark. Files without a BOM
+// are checked for the NUL byte pattern of ASCII text stored as UTF-16 (common for Windows application
+// logs), otherwise they are treated as UTF-8.
+func detectEncoding(filename string, open func(string) (*os.File, error)) (string, error) {
+	f, err := open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	buf := make([]byte, encodingSniffSize)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	return detectEncodingFromBytes(buf[:n]), nil
+}
+
+func detectEncodingFromBytes(b []byte) string {
+	switch {
+	case bytes.HasPrefix(b, bomUTF8):
+		return encodingUTF8
+	case bytes.HasPrefix(b, bomUTF16LE):
+		return encodingUTF16LE
+	case bytes.HasPrefix(b, bomUTF16BE):
+		return encodingUTF16BE
+	}
+	// Only look at whole code units.
+	if len(b) < 2 {
+		return encodingUTF8
+	}
+	b = b[:len(b)-len(b)%2]
+	var evenNUL, oddNUL int
+	for i := 0; i < len(b); i += 2 {
+		if b[i] == 0 {
+			evenNUL++
+		}
+		if b[i+1] == 0 {
+			oddNUL++
+		}
+	}
+	units := len(b) / 2
+	// ASCII heavy UTF-16 text has a NUL in almost every code unit on one side and none on the other.
+	switch {
+	case oddNUL*10 >= units*9 && evenNUL == 0:
+		return encodingUTF16LE
+	case evenNUL*10 >= units*9 && oddNUL == 0:
+		return encodingUTF16BE
+	}
+	return encodingUTF8
+}
+
+// isUTF16Encoding reports whether the tailer has to split lines on UTF-16LE line feeds.
+func isUTF16Encoding(enc string) bool {
+	switch enc {
+	case "utf-16", "utf-16le", "UTF-16", "UTF-16LE":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestDetectEncodingFromBytes(t *testing.T) {
+	utf16le, err := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder().String("hello world\r\n")
+	require.NoError(t, err)
+	utf16be, err := unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewEncoder().String("hello world\r\n")
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		input []byte
+		want  string
+	}{
+		"Empty":           {input: nil, want: encodingUTF8},
+		"PlainASCII":      {input: []byte("hello world\n"), want: encodingUTF8},
+		"UTF8BOM":         {input: append([]byte{0xEF, 0xBB, 0xBF}, "hello"...), want: encodingUTF8},
+		"UTF16LEBOM":      {input: append([]byte{0xFF, 0xFE}, utf16le...), want: encodingUTF16LE},
+		"UTF16BEBOM":      {input: append([]byte{0xFE, 0xFF}, utf16be...), want: encodingUTF16BE},
+		"UTF16LENoBOM":    {input: []byte(utf16le), want: encodingUTF16LE},
+		"UTF16BENoBOM":    {input: []byte(utf16be), want: encodingUTF16BE},
+		"BinaryWithNULs":  {input: []byte{0x00, 0x00, 0x01, 0x00, 0x7F, 0x45, 0x4C, 0x46}, want: encodingUTF8},
+		"SingleByteInput": {input: []byte{0x00}, want: encodingUTF8},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, detectEncodingFromBytes(testCase.input))
+		})
+	}
+}
+
+func TestFileConfigEncodingFor(t *testing.T) {
+	dir := t.TempDir()
+	utf16File := filepath.Join(dir, "utf16.log")
+	content, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String("line1\r\nline2\r\n")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(utf16File, []byte(content), 0600))
+	utf8File := filepath.Join(dir, "utf8.log")
+	require.NoError(t, os.WriteFile(utf8File, []byte("line1\nline2\n"), 0600))
+
+	fileConfig := &FileConfig{FilePath: filepath.Join(dir, "*.log"), Encoding: encodingAuto}
+	require.NoError(t, fileConfig.init())
+	assert.Nil(t, fileConfig.Enc)
+
+	name, enc := fileConfig.encodingFor(utf16File)
+	assert.Equal(t, encodingUTF16LE, name)
+	assert.NotNil(t, enc)
+	assert.True(t, isUTF16Encoding(name))
+
+	name, enc = fileConfig.encodingFor(utf8File)
+	assert.Equal(t, encodingUTF8, name)
+	assert.Nil(t, enc)
+
+	name, enc = fileConfig.encodingFor(filepath.Join(dir, "missing.log"))
+	assert.Equal(t, encodingUTF8, name)
+	assert.Nil(t, enc)
+}
@@ -16,8 +16,10 @@ import (
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/ianaindex"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/logformat"
 	"github.com/aws/amazon-cloudwatch-agent/internal/logscommon"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/globpath"
 	"github.com/aws/amazon-cloudwatch-agent/profiler"
 )
 
@@ -32,9 +34,14 @@ type FileConfig struct {
 	FilePath string `toml:"file_path"`
 	//The blacklist used to filter out some files
 	Blacklist string `toml:"blacklist"`
+	//Globs of the files and directories left out of the file path glob
+	ExcludePaths []string `toml:"exclude_paths"`
+	//Seconds between the searches for new files matching the file path, every scan of the log agent if 0
+	DiscoveryInterval int `toml:"discovery_interval"`
 
 	PublishMultiLogs bool `toml:"publish_multi_logs"`
 
+	//The encoding of the file, "auto" detects it per file from the byte order mark
 	Encoding string `toml:"encoding"`
 	//The log group name for the input log file.
 	LogGroupName string `toml:"log_group_name"`
@@ -43,6 +50,10 @@ type FileConfig struct {
 	//log group class
 	LogGroupClass string `toml:"log_group_class"`
 
+	//The name of a well-known log format, e.g. "nginx_access" or "nginx_access@v1", whose parsing templates are used
+	//for the timestamp, multiline, field and level patterns that are not set
+	Format string `toml:"format"`
+
 	//The regex of the timestampFromLogLine presents in the log entry
 	TimestampRegex string `toml:"timestamp_regex"`
 	//The timestampFromLogLine layout used in GoLang to parse the timestampFromLogLine.
@@ -89,6 +100,27 @@ type FileConfig struct {
 
 	Filters []*LogFilter `toml:"filters"`
 
+	//Sample the file's events while its event rate is above a threshold
+	BurstDetection *BurstConfig `toml:"burst_detection"`
+
+	//Send the file's events to other log groups during time windows, the first route with an open window is used
+	Routes []*RouteConfig `toml:"routes"`
+
+	//Name the log streams after the fields of the events that the log stream name references
+	LogStreamFields *LogStreamFieldsConfig `toml:"log_stream_fields"`
+
+	//Upload the events of the aggregated levels as per-minute counts
+	LevelAggregation *LevelAggregationConfig `toml:"level_aggregation"`
+
+	//Detect sensitive data in the file's events and tag, redact or quarantine them
+	SensitiveData *SensitiveDataConfig `toml:"sensitive_data"`
+
+	//Rewrite the file's events as flat JSON objects for Contributor Insights rules
+	ContributorInsights *ContributorInsightsConfig `toml:"contributor_insights"`
+
+	//Windows account to open the file as, for files only that account can read
+	RunAs *RunAsConfig `toml:"run_as"`
+
 	//Customer specified service.name
 	ServiceName string `toml:"service_name"`
 	//Customer specified deployment.environment
@@ -102,6 +134,8 @@ type FileConfig struct {
 	MultiLineStartPatternP *regexp.Regexp
 	//Regexp go type blacklist regex
 	BlacklistRegexP *regexp.Regexp
+	//Compiled exclude_paths globs
+	excludePathsG []*globpath.GlobPath
 	//Decoder object
 	Enc         encoding.Encoding
 	sampleCount int
@@ -110,7 +144,7 @@ type FileConfig struct {
 // Initialize some variables in the FileConfig object based on the rest info fetched from the configuration file.
 func (config *FileConfig) init() error {
 	var err error
-	if !(config.Encoding == "" || config.Encoding == "utf_8" || config.Encoding == "utf-8" || config.Encoding == "utf8" || config.Encoding == "ascii") {
+	if !(config.Encoding == "" || config.Encoding == encodingAuto || config.Encoding == "utf_8" || config.Encoding == "utf-8" || config.Encoding == "utf8" || config.Encoding == "ascii") {
 		if config.Enc, _ = charset.Lookup(config.Encoding); config.Enc == nil {
 			if config.Enc, _ = ianaindex.IANA.Encoding(config.Encoding); config.Enc == nil {
 				msg := fmt.Sprintf("E! the encoding %s is not supported.", config.Encoding)
@@ -123,6 +157,9 @@ func (config *FileConfig) init() error {
 	if config.LogGroupName == "" && !config.PublishMultiLogs {
 		config.LogGroupName = logGroupName(config.FilePath)
 	}
+	if err = config.applyFormat(); err != nil {
+		return err
+	}
 	//If the timezone info is not specified, we will use the Local timezone as default value.
 	if config.Timezone == time.UTC.String() {
 		config.TimezoneLoc = time.UTC
@@ -153,6 +190,18 @@ func (config *FileConfig) init() error {
 		}
 	}
 
+	config.excludePathsG = nil
+	for _, excludePath := range config.ExcludePaths {
+		g, err := globpath.Compile(excludePath)
+		if err != nil {
+			return fmt.Errorf("exclude_paths glob %s failed to compile, %s", excludePath, err)
+		}
+		config.excludePathsG = append(config.excludePathsG, g)
+	}
+	if config.DiscoveryInterval < 0 {
+		return fmt.Errorf("discovery_interval must not be negative, but got %d", config.DiscoveryInterval)
+	}
+
 	if config.MaxEventSize == 0 {
 		config.MaxEventSize = defaultMaxEventSize
 	}
@@ -160,6 +209,9 @@ func (config *FileConfig) init() error {
 	if config.TruncateSuffix == "" {
 		config.TruncateSuffix = defaultTruncateSuffix
 	}
+	if config.MaxEventSize < len(config.TruncateSuffix) {
+		return fmt.Errorf("max_event_size %d is smaller than truncate_suffix %q", config.MaxEventSize, config.TruncateSuffix)
+	}
 	if config.RetentionInDays == 0 {
 		config.RetentionInDays = -1
 	}
@@ -171,9 +223,85 @@ func (config *FileConfig) init() error {
 		}
 	}
 
+	if config.BurstDetection != nil {
+		if err = config.BurstDetection.init(); err != nil {
+			return err
+		}
+	}
+	for _, route := range config.Routes {
+		if err = route.init(); err != nil {
+			return err
+		}
+	}
+	if config.LogStreamFields != nil {
+		if err = config.LogStreamFields.init(config.LogStreamName); err != nil {
+			return err
+		}
+	}
+	if config.LevelAggregation != nil {
+		if err = config.LevelAggregation.init(); err != nil {
+			return err
+		}
+	}
+	if config.SensitiveData != nil {
+		if err = config.SensitiveData.init(); err != nil {
+			return err
+		}
+	}
+	if config.ContributorInsights != nil {
+		if err = config.ContributorInsights.init(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyFormat fills the patterns of the file that are not set from the templates of its format.
+func (config *FileConfig) applyFormat() error {
+	if config.Format == "" {
+		return nil
+	}
+	format, err := logformat.Lookup(config.Format)
+	if err != nil {
+		return err
+	}
+	if config.TimestampRegex == "" && len(config.TimestampLayout) == 0 {
+		config.TimestampRegex = format.TimestampRegex
+		config.TimestampLayout = format.TimestampLayouts
+	}
+	if config.MultiLineStartPattern == "" {
+		config.MultiLineStartPattern = format.MultiLineStartPattern
+	}
+	if config.LogStreamFields != nil && config.LogStreamFields.Pattern == "" {
+		config.LogStreamFields.Pattern = format.FieldPattern
+	}
+	if config.ContributorInsights != nil && config.ContributorInsights.Pattern == "" {
+		config.ContributorInsights.Pattern = format.FieldPattern
+	}
+	if config.LevelAggregation != nil && config.LevelAggregation.LevelPattern == "" {
+		config.LevelAggregation.LevelPattern = format.LevelPattern
+	}
 	return nil
 }
 
+// isExcluded reports whether the file, or a directory it is in, matches one of the exclude_paths.
+func (config *FileConfig) isExcluded(filename string) bool {
+	if len(config.excludePathsG) == 0 {
+		return false
+	}
+	for path := filepath.Clean(filename); ; path = filepath.Dir(path) {
+		for _, g := range config.excludePathsG {
+			if g.MatchString(path) {
+				return true
+			}
+		}
+		if parent := filepath.Dir(path); parent == path {
+			return false
+		}
+	}
+}
+
 // Try to parse the timestampFromLogLine value from the log entry line.
 // The parser logic will be based on the timestampFromLogLine regex, and time zone info.
 // If the parsing operation encounters any issue, int64(0) is returned.
@@ -184,7 +312,8 @@ func (config *FileConfig) timestampFromLogLine(logValue string) (time.Time, stri
 	index := config.TimestampRegexP.FindStringSubmatchIndex(logValue)
 	if len(index) > 3 {
 		timestampContent := (logValue)[index[2]:index[3]]
-		if len(index) > 5 {
+		// the fraction of the seconds is an optional 2nd submatch, which is -1 when it did not participate
+		if len(index) > 5 && index[4] >= 0 {
 			start := index[4] - index[2]
 			end := index[5] - index[2]
 			//append "000" to 2nd submatch in order to guarantee the fractional second at least has 3 digits
@@ -266,3 +395,22 @@ func logGroupName(filePath string) string {
 	suffix := filepath.Ext(filePath)
 	return strings.TrimSuffix(filePath, suffix)
 }
+
+// encodingFor returns the encoding name and decoder used for the given file. When the encoding is "auto", it is
+// detected from the file content, falling back to UTF-8 if the file cannot be read.
+func (config *FileConfig) encodingFor(filename string) (string, encoding.Encoding) {
+	if config.Encoding != encodingAuto {
+		return config.Encoding, config.Enc
+	}
+	name, err := detectEncoding(filename, config.openFile)
+	if err != nil {
+		log.Printf("W! [logfile] Unable to detect encoding of %s, defaulting to utf-8: %v", filename, err)
+		return encodingUTF8, nil
+	}
+	if name == encodingUTF8 {
+		return name, nil
+	}
+	enc, _ := charset.Lookup(name)
+	log.Printf("I! [logfile] Detected encoding %s for %s", name, filename)
+	return name, enc
+}
@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -325,6 +326,20 @@ func TestTimestampParserWithFracSeconds(t *testing.T) {
 	assert.Equal(t, trimmedTimestampString, modifiedLogEntry)
 }
 
+func TestTimestampParserWithOptionalFracSeconds(t *testing.T) {
+	timestampRegex := "(\\d{2} \\w{3} \\d{4} \\d{2}:\\d{2}:\\d{2}(?:,(\\d{1,9}))? \\w{3})"
+	fileConfig := &FileConfig{
+		TimestampRegexP: regexp.MustCompile(timestampRegex),
+		TimestampLayout: []string{"02 Jan 2006 15:04:05,.000 MST", "02 Jan 2006 15:04:05 MST"},
+		TimezoneLoc:     time.UTC}
+
+	timestamp, _ := fileConfig.timestampFromLogLine("19 Jun 2017 14:25:18,234088 UTC [INFO] This is a test message.")
+	assert.Equal(t, time.Unix(1497882318, 234000000).UnixNano(), timestamp.UnixNano())
+	// the line without the fraction does not set the 2nd submatch
+	timestamp, _ = fileConfig.timestampFromLogLine("19 Jun 2017 14:25:18 UTC [INFO] This is a test message.")
+	assert.Equal(t, time.Unix(1497882318, 0).UnixNano(), timestamp.UnixNano())
+}
+
 func TestNonAllowlistedTimezone(t *testing.T) {
 	fileConfig := &FileConfig{
 		Timezone: "EST",
@@ -351,6 +366,64 @@ func TestMultiLineStartPattern(t *testing.T) {
 	assert.False(t, multiLineStart, "This should not be a multi-line start line.")
 }
 
+func TestFileConfigFormat(t *testing.T) {
+	fileConfig := &FileConfig{
+		FilePath:         "/var/log/app.log",
+		Format:           "java",
+		LevelAggregation: &LevelAggregationConfig{},
+		LogStreamName:    "{thread}",
+		LogStreamFields:  &LogStreamFieldsConfig{},
+	}
+	require.NoError(t, fileConfig.init())
+	timestamp, _ := fileConfig.timestampFromLogLine("2024-01-02 15:04:05,123 ERROR [main] com.example.App - Request failed")
+	assert.Equal(t, 123, timestamp.Nanosecond()/int(time.Millisecond))
+	assert.True(t, fileConfig.isMultilineStart("2024-01-02 15:04:06,000 INFO [main] com.example.App - Started"))
+	assert.False(t, fileConfig.isMultilineStart("\tat com.example.App.main(App.java:10)"))
+	assert.NotEmpty(t, fileConfig.LogStreamFields.Pattern)
+	assert.NotEmpty(t, fileConfig.LevelAggregation.LevelPattern)
+
+	// the patterns that are set are kept
+	fileConfig = &FileConfig{FilePath: "/var/log/app.log", Format: "java@v1", MultiLineStartPattern: "^---"}
+	require.NoError(t, fileConfig.init())
+	assert.Equal(t, "^---", fileConfig.MultiLineStartPattern)
+	assert.NotNil(t, fileConfig.TimestampRegexP)
+
+	assert.Error(t, (&FileConfig{FilePath: "/var/log/app.log", Format: "cobol"}).init())
+}
+
+// Any line of a syslog file, however malformed, should be read with a log stream name CloudWatch Logs accepts
+func FuzzSyslogLine(f *testing.F) {
+	for _, line := range []string{
+		"Jan  2 15:04:05 host sshd[123]: Accepted publickey for ec2-user",
+		"Jan 12 15:04:05 ip-10-0-0-1 kernel: Out of memory",
+		"<34>1 2003-10-11T22:14:15.003Z host su 123 ID47 - 'su root' failed",
+		"<165>1 2003-08-24T05:14:15.000003-07:00 192.0.2.1 myproc 8710 - - %% It's time to make the do-nuts.",
+	} {
+		f.Add(line)
+	}
+	var configs []*FileConfig
+	for _, format := range []string{"syslog", "syslog_rfc5424"} {
+		config := &FileConfig{
+			FilePath:        "/var/log/messages",
+			Format:          format,
+			LogStreamName:   "{hostname}",
+			LogStreamFields: &LogStreamFieldsConfig{},
+		}
+		require.NoError(f, config.init())
+		configs = append(configs, config)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		for _, config := range configs {
+			config.timestampFromLogLine(line)
+			config.isMultilineStart(line)
+			stream := newStreamNamer("messages", config.LogStreamName, config.LogStreamFields).stream(line)
+			assert.True(t, utf8.ValidString(stream), "log stream %q of %q", stream, line)
+			assert.NotEmpty(t, stream)
+			assert.LessOrEqual(t, len(stream), maxLogStreamNameLength)
+		}
+	})
+}
+
 func TestFileConfigInitWithFilters(t *testing.T) {
 	filter1 := LogFilter{
 		Type:       includeFilterType,
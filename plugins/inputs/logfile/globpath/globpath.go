@@ -37,22 +37,50 @@ func Compile(path string) (*GlobPath, error) {
 		return &out, nil
 	}
 
-	// Escapes the `\` in windows since glob use it for escape sequence
+	// a ** directory also matches no directory, so /var/log/**/*.log matches /var/log/app.log
+	var globs anyGlob
+	for _, variant := range zeroDirVariants(path) {
+		// Escapes the `\` in windows since glob use it for escape sequence
+		if runtime.GOOS == "windows" {
+			variant = escapeSeparator(variant)
+		}
+		g, err := glob.Compile(variant, os.PathSeparator)
+		if err != nil {
+			return nil, err
+		}
+		globs = append(globs, g)
+	}
+	out.g = globs
 	if runtime.GOOS == "windows" {
 		path = escapeSeparator(path)
 	}
-
-	var err error
-	if out.g, err = glob.Compile(path, os.PathSeparator); err != nil {
-		return nil, err
-	}
 	// Get the root directory for this filepath
 	out.root = findRootDir(path)
 	return &out, nil
 }
 
+// MatchString reports whether the path matches the glob without looking at the file system.
+func (g *GlobPath) MatchString(path string) bool {
+	if !g.hasMeta && !g.hasSuperMeta {
+		return path == g.path
+	}
+	return g.g.Match(path)
+}
+
 func (g *GlobPath) Match() map[string]os.FileInfo {
+	return g.MatchExcluding(nil)
+}
+
+// MatchExcluding is like Match, but it leaves out the paths for which exclude is true. The directories that are
+// excluded are not walked.
+func (g *GlobPath) MatchExcluding(exclude func(path string) bool) map[string]os.FileInfo {
+	if exclude == nil {
+		exclude = func(string) bool { return false }
+	}
 	if !g.hasMeta && !g.hasSuperMeta {
+		if exclude(g.path) {
+			return map[string]os.FileInfo{}
+		}
 		out := make(map[string]os.FileInfo)
 		info, err := os.Stat(g.path)
 		if info != nil {
@@ -65,6 +93,9 @@ func (g *GlobPath) Match() map[string]os.FileInfo {
 		out := make(map[string]os.FileInfo)
 		files, _ := filepath.Glob(g.path)
 		for _, file := range files {
+			if exclude(file) {
+				continue
+			}
 			info, err := os.Stat(file)
 			if info != nil {
 				out[file] = info
@@ -74,14 +105,20 @@ func (g *GlobPath) Match() map[string]os.FileInfo {
 		}
 		return out
 	}
-	return walkFilePath(g.root, g.g)
+	return walkFilePath(g.root, g.g, exclude)
 }
 
 // walk the filepath from the given root and return a list of files that match
 // the given glob.
-func walkFilePath(root string, g glob.Glob) map[string]os.FileInfo {
+func walkFilePath(root string, g glob.Glob, exclude func(path string) bool) map[string]os.FileInfo {
 	matchedFiles := make(map[string]os.FileInfo)
 	walkfn := func(path string, info os.FileInfo, _ error) error {
+		if info != nil && path != root && exclude(path) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		if info != nil && g.Match(path) {
 			matchedFiles[path] = info
 		}
@@ -91,6 +128,32 @@ func walkFilePath(root string, g glob.Glob) map[string]os.FileInfo {
 	return matchedFiles
 }
 
+// anyGlob matches a path if any of its globs does.
+type anyGlob []glob.Glob
+
+func (a anyGlob) Match(path string) bool {
+	for _, g := range a {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// zeroDirVariants returns the path and the paths with one or more of its ** directories removed.
+func zeroDirVariants(path string) []string {
+	superDir := "**" + sepStr
+	i := strings.Index(path, superDir)
+	if i < 0 || (i > 0 && !strings.HasSuffix(path[:i], sepStr)) {
+		return []string{path}
+	}
+	var variants []string
+	for _, rest := range zeroDirVariants(path[i+len(superDir):]) {
+		variants = append(variants, path[:i+len(superDir)]+rest, path[:i]+rest)
+	}
+	return variants
+}
+
 // find the root dir of the given path (could include globs).
 // ie:
 //
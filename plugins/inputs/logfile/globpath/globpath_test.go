@@ -220,6 +220,54 @@ func TestFindNestedTextFile(t *testing.T) {
 	assert.Len(t, matches, 1)
 }
 
+func TestSuperAsteriskMatchesNoDirectory(t *testing.T) {
+	dir := getTestdataDir()
+	g, err := Compile(dir + "/**/*.log")
+	require.NoError(t, err)
+	matches := g.Match()
+	assert.Len(t, matches, 2)
+	assert.Contains(t, matches, dir+"/log1.log")
+
+	g, err = Compile(dir + "/**/nested2/**/*.txt")
+	require.NoError(t, err)
+	assert.Len(t, g.Match(), 1)
+	assert.True(t, g.MatchString(dir+"/nested1/nested2/nested.txt"))
+}
+
+func TestZeroDirVariants(t *testing.T) {
+	assert.Equal(t, []string{"/var/log/app.log"}, zeroDirVariants("/var/log/app.log"))
+	assert.Equal(t, []string{"/var/**/app.log", "/var/app.log"}, zeroDirVariants("/var/**/app.log"))
+	assert.ElementsMatch(t, []string{"/a/**/b/**/c", "/a/b/**/c", "/a/**/b/c", "/a/b/c"}, zeroDirVariants("/a/**/b/**/c"))
+	// only whole directories are removed
+	assert.Equal(t, []string{"/var/log**/app.log"}, zeroDirVariants("/var/log**/app.log"))
+}
+
+func TestMatchExcluding(t *testing.T) {
+	dir := getTestdataDir()
+	var visited []string
+	excludeNested := func(path string) bool {
+		visited = append(visited, path)
+		return path == dir+"/nested1"
+	}
+	g, err := Compile(dir + "/**")
+	require.NoError(t, err)
+	matches := g.MatchExcluding(excludeNested)
+	assert.Len(t, matches, 3)
+	assert.NotContains(t, matches, dir+"/nested1")
+	// the excluded directory is not walked
+	assert.NotContains(t, visited, dir+"/nested1/nested2")
+
+	excludeLog2 := func(path string) bool { return filepath.Base(path) == "log2.log" }
+	g, err = Compile(dir + "/*.log")
+	require.NoError(t, err)
+	assert.Len(t, g.MatchExcluding(excludeLog2), 1)
+	g, err = Compile(dir + "/log2.log")
+	require.NoError(t, err)
+	assert.Empty(t, g.MatchExcluding(excludeLog2))
+	assert.True(t, g.MatchString(dir+"/log2.log"))
+	assert.False(t, g.MatchString(dir+"/log1.log"))
+}
+
 func getTestdataDir() string {
 	_, filename, _, _ := runtime.Caller(1)
 	return strings.Replace(filename, "globpath_test.go", "testdata", 1)
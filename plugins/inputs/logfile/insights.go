@@ -0,0 +1,159 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
+)
+
+const (
+	// insightsMessageKey is the field of the line of the events that are not JSON objects.
+	insightsMessageKey = "message"
+	// insightsSeparator joins the keys of the nested objects, and the indexes of the arrays, into a top level key.
+	insightsSeparator = "_"
+)
+
+var (
+	// insightsFieldPattern matches the field names rules reference by a JSON path, e.g. $.client_ip.
+	insightsFieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// invalidInsightsChars are the characters left out of the field names, which are replaced with _.
+	invalidInsightsChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+// ContributorInsightsConfig rewrites the events of the file as flat JSON objects, so that Contributor Insights rules
+// can match them by the same keys whatever the layout of the events. The nested fields of JSON events are moved to
+// the top level, and the events that are not JSON objects get the named groups of the pattern and their line as
+// the message field.
+type ContributorInsightsConfig struct {
+	// Pattern is the regular expression whose named groups are extracted as fields from the events that are not JSON.
+	Pattern string `toml:"pattern"`
+	// Fields are the only fields of the events when set, in which the fields an event misses are null.
+	Fields []string `toml:"fields"`
+
+	pattern *regexp.Regexp
+}
+
+func (c *ContributorInsightsConfig) init() error {
+	if c.Pattern != "" {
+		var err error
+		if c.pattern, err = regexp.Compile(c.Pattern); err != nil {
+			return fmt.Errorf("contributor_insights pattern %q is invalid: %w", c.Pattern, err)
+		}
+	}
+	seen := map[string]struct{}{}
+	for _, field := range c.Fields {
+		if !insightsFieldPattern.MatchString(field) {
+			return fmt.Errorf("contributor_insights field %q must be letters, digits and _, not starting with a digit", field)
+		}
+		if _, ok := seen[field]; ok {
+			return fmt.Errorf("contributor_insights field %q is listed twice", field)
+		}
+		seen[field] = struct{}{}
+	}
+	return nil
+}
+
+// insightsFormatter rewrites the events of a file for Contributor Insights.
+type insightsFormatter struct {
+	group  string
+	stream string
+	config *ContributorInsightsConfig
+}
+
+func newInsightsFormatter(group, stream string, config *ContributorInsightsConfig) *insightsFormatter {
+	if config == nil {
+		return nil
+	}
+	return &insightsFormatter{group: group, stream: stream, config: config}
+}
+
+// format returns the event as a flat JSON object. The keys are sorted, so that the events with the same fields have
+// the same layout.
+func (f *insightsFormatter) format(msg string) string {
+	fields := f.fields(msg)
+	if len(f.config.Fields) > 0 {
+		selected := make(map[string]interface{}, len(f.config.Fields))
+		for _, field := range f.config.Fields {
+			selected[field] = fields[field]
+		}
+		fields = selected
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return msg
+	}
+	return string(b)
+}
+
+// fields returns the fields of a JSON object event flattened, or the named groups of the pattern with the message
+// otherwise.
+func (f *insightsFormatter) fields(msg string) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if trimmed := strings.TrimSpace(msg); strings.HasPrefix(trimmed, "{") {
+		var object map[string]interface{}
+		if json.Unmarshal([]byte(trimmed), &object) == nil {
+			flattenInsightsFields(fields, "", object)
+			return fields
+		}
+	}
+	if f.config.pattern != nil {
+		if match := f.config.pattern.FindStringSubmatch(msg); match != nil {
+			for i, name := range f.config.pattern.SubexpNames() {
+				if name != "" && match[i] != "" {
+					fields[insightsFieldName(name)] = match[i]
+				}
+			}
+		} else {
+			profiler.Profiler.AddStats([]string{"logfile", f.group, f.stream, "messages", "insights_unmatched"}, 1)
+		}
+	}
+	fields[insightsMessageKey] = msg
+	return fields
+}
+
+// flattenInsightsFields adds the scalars of the value to the fields, keyed by their path from the top level. The
+// keys are visited in order, so that the first of the keys that have the same field name always wins.
+func flattenInsightsFields(fields map[string]interface{}, prefix string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			flattenInsightsFields(fields, joinInsightsKey(prefix, insightsFieldName(key)), v[key])
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenInsightsFields(fields, joinInsightsKey(prefix, strconv.Itoa(i)), item)
+		}
+	default:
+		if prefix == "" {
+			return
+		}
+		if _, ok := fields[prefix]; !ok {
+			fields[prefix] = v
+		}
+	}
+}
+
+func joinInsightsKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + insightsSeparator + key
+}
+
+// insightsFieldName replaces the characters a JSON path cannot reference without quoting.
+func insightsFieldName(key string) string {
+	return invalidInsightsChars.ReplaceAllString(key, insightsSeparator)
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContributorInsightsConfigInit(t *testing.T) {
+	require.NoError(t, (&ContributorInsightsConfig{}).init())
+	require.NoError(t, (&ContributorInsightsConfig{Pattern: `ip=(?P<client_ip>\S+)`, Fields: []string{"client_ip", "_status"}}).init())
+
+	assert.Error(t, (&ContributorInsightsConfig{Pattern: "ip=(?P<client_ip"}).init())
+	assert.Error(t, (&ContributorInsightsConfig{Fields: []string{"client.ip"}}).init())
+	assert.Error(t, (&ContributorInsightsConfig{Fields: []string{"1st"}}).init())
+	assert.Error(t, (&ContributorInsightsConfig{Fields: []string{"status", "status"}}).init())
+}
+
+func TestInsightsFormatterJSON(t *testing.T) {
+	config := &ContributorInsightsConfig{}
+	require.NoError(t, config.init())
+	f := newInsightsFormatter("app", "i-123", config)
+
+	assert.Equal(t, `{"client_ip":"10.0.0.1","http_status":500,"msg":"failed","tags_0":"a","tags_1":"b"}`,
+		f.format(`{"msg": "failed", "http": {"status": 500}, "client": {"ip": "10.0.0.1"}, "tags": ["a", "b"]}`))
+	// the keys a JSON path cannot reference are renamed, and the first of the keys with the same name wins
+	assert.Equal(t, `{"request_id":"1","user_agent":"curl"}`,
+		f.format(`{"user-agent": "curl", "request": {"id": "1"}, "request.id": "2"}`))
+	assert.Nil(t, newInsightsFormatter("app", "i-123", nil))
+}
+
+func TestInsightsFormatterPattern(t *testing.T) {
+	config := &ContributorInsightsConfig{Pattern: `^(?P<client_ip>\S+) .* (?P<status>\d{3})$`}
+	require.NoError(t, config.init())
+	f := newInsightsFormatter("app", "i-123", config)
+
+	assert.Equal(t, `{"client_ip":"10.0.0.1","message":"10.0.0.1 GET / 404","status":"404"}`, f.format("10.0.0.1 GET / 404"))
+	assert.Equal(t, `{"message":"starting"}`, f.format("starting"))
+}
+
+func TestInsightsFormatterFields(t *testing.T) {
+	config := &ContributorInsightsConfig{Pattern: `status=(?P<status>\d+)`, Fields: []string{"status", "client_ip"}}
+	require.NoError(t, config.init())
+	f := newInsightsFormatter("app", "i-123", config)
+
+	// every event has the same fields, null when it misses them
+	assert.Equal(t, `{"client_ip":"10.0.0.1","status":200}`, f.format(`{"status": 200, "client_ip": "10.0.0.1", "path": "/"}`))
+	assert.Equal(t, `{"client_ip":null,"status":"500"}`, f.format("GET / status=500"))
+	assert.Equal(t, `{"client_ip":null,"status":null}`, f.format("starting"))
+}
@@ -0,0 +1,145 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+)
+
+const (
+	// defaultLevelPattern finds the first level word of an event, e.g. "INFO", "level=info" or "\"level\":\"info\"".
+	defaultLevelPattern = `(?i)\b(TRACE|DEBUG|INFO|NOTICE|WARN|WARNING|ERR|ERROR|CRITICAL|FATAL)\b`
+	// levelSummaryInterval is how often the counts of the aggregated levels are published.
+	levelSummaryInterval = time.Minute
+)
+
+var (
+	defaultAggregatedLevels = []string{"TRACE", "DEBUG", "INFO"}
+	// levelAliases are the spellings of a level counted as the same level.
+	levelAliases = map[string]string{
+		"WARNING": "WARN",
+		"ERR":     "ERROR",
+	}
+)
+
+// LevelAggregationConfig uploads the events of the aggregated levels as per-minute counts instead of verbatim.
+// The events of the other levels, and the events without a level, are still uploaded.
+type LevelAggregationConfig struct {
+	// Levels are the levels whose events are counted, defaults to TRACE, DEBUG and INFO.
+	Levels []string `toml:"levels"`
+	// LevelPattern finds the level of an event. The first capture group, or the whole match without one, is the
+	// level. Only the first match of an event is used.
+	LevelPattern string `toml:"level_pattern"`
+
+	levelRegexP *regexp.Regexp
+}
+
+func (c *LevelAggregationConfig) init() error {
+	if len(c.Levels) == 0 {
+		c.Levels = defaultAggregatedLevels
+	}
+	for _, level := range c.Levels {
+		if strings.TrimSpace(level) == "" {
+			return errors.New("level_aggregation levels must not be empty")
+		}
+	}
+	pattern := c.LevelPattern
+	if pattern == "" {
+		pattern = defaultLevelPattern
+	}
+	var err error
+	if c.levelRegexP, err = regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("level_aggregation level_pattern %q is not a valid regex: %w", c.LevelPattern, err)
+	}
+	return nil
+}
+
+func normalizeLevel(level string) string {
+	level = strings.ToUpper(strings.TrimSpace(level))
+	if alias, ok := levelAliases[level]; ok {
+		return alias
+	}
+	return level
+}
+
+// levelSummary is the body of the event published with the counts of the aggregated levels.
+type levelSummary struct {
+	Levels struct {
+		Start  time.Time      `json:"start"`
+		End    time.Time      `json:"end"`
+		Counts map[string]int `json:"counts"`
+	} `json:"cwagent_log_levels"`
+}
+
+// levelAggregator counts the events of the aggregated levels of a single file. It is only used from the tailer
+// goroutine.
+type levelAggregator struct {
+	pattern *regexp.Regexp
+	levels  collections.Set[string]
+
+	start  time.Time
+	counts map[string]int
+}
+
+func newLevelAggregator(cfg *LevelAggregationConfig) *levelAggregator {
+	if cfg == nil || cfg.levelRegexP == nil {
+		return nil
+	}
+	levels := collections.NewSet[string]()
+	for _, level := range cfg.Levels {
+		levels.Add(normalizeLevel(level))
+	}
+	return &levelAggregator{
+		pattern: cfg.levelRegexP,
+		levels:  levels,
+		counts:  map[string]int{},
+	}
+}
+
+// admit reports whether the event should be uploaded. The events of the aggregated levels are counted instead.
+func (a *levelAggregator) admit(msg string, now time.Time) bool {
+	match := a.pattern.FindStringSubmatch(msg)
+	if match == nil {
+		return true
+	}
+	level := match[0]
+	if len(match) > 1 {
+		level = match[1]
+	}
+	level = normalizeLevel(level)
+	if !a.levels.Contains(level) {
+		return true
+	}
+	if a.start.IsZero() {
+		a.start = now
+	}
+	a.counts[level]++
+	return false
+}
+
+// flush returns the summary of the counts once they are older than the summary interval, or right away when
+// force is set, and resets them. It returns an empty string when nothing was counted.
+func (a *levelAggregator) flush(now time.Time, force bool) string {
+	if len(a.counts) == 0 || (!force && now.Sub(a.start) < levelSummaryInterval) {
+		return ""
+	}
+	var summary levelSummary
+	summary.Levels.Start = a.start
+	summary.Levels.End = now
+	summary.Levels.Counts = a.counts
+	a.start = time.Time{}
+	a.counts = map[string]int{}
+	content, err := json.Marshal(summary)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
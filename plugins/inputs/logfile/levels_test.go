@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelAggregationConfigInit(t *testing.T) {
+	cfg := &LevelAggregationConfig{}
+	require.NoError(t, cfg.init())
+	assert.Equal(t, defaultAggregatedLevels, cfg.Levels)
+	assert.Equal(t, defaultLevelPattern, cfg.levelRegexP.String())
+
+	assert.Error(t, (&LevelAggregationConfig{Levels: []string{" "}}).init())
+	assert.Error(t, (&LevelAggregationConfig{LevelPattern: "level=("}).init())
+	assert.Nil(t, newLevelAggregator(nil))
+}
+
+func TestLevelAggregator(t *testing.T) {
+	cfg := &LevelAggregationConfig{}
+	require.NoError(t, cfg.init())
+	a := newLevelAggregator(cfg)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, a.admit("2024-01-01T00:00:00Z INFO request served", now))
+	assert.False(t, a.admit(`{"level":"debug","msg":"cache miss"}`, now))
+	assert.False(t, a.admit("level=info msg=ready", now.Add(time.Second)))
+	assert.True(t, a.admit("2024-01-01T00:00:01Z WARN disk almost full, see info", now))
+	assert.True(t, a.admit("ERROR connection refused", now))
+	assert.True(t, a.admit("panic: runtime error", now))
+	assert.True(t, a.admit("goroutine 1 [running]:", now))
+
+	assert.Empty(t, a.flush(now.Add(30*time.Second), false))
+	summary := a.flush(now.Add(time.Minute), false)
+	require.NotEmpty(t, summary)
+	var got levelSummary
+	require.NoError(t, json.Unmarshal([]byte(summary), &got))
+	assert.Equal(t, map[string]int{"INFO": 2, "DEBUG": 1}, got.Levels.Counts)
+	assert.Equal(t, now, got.Levels.Start)
+	assert.Equal(t, now.Add(time.Minute), got.Levels.End)
+
+	// nothing is published until an event is counted again
+	assert.Empty(t, a.flush(now.Add(3*time.Minute), true))
+	assert.False(t, a.admit("INFO done", now.Add(3*time.Minute)))
+	assert.NotEmpty(t, a.flush(now.Add(3*time.Minute), true))
+}
+
+func TestLevelAggregatorPattern(t *testing.T) {
+	cfg := &LevelAggregationConfig{Levels: []string{"warning", "I"}, LevelPattern: `^([A-Z])\d{4}`}
+	require.NoError(t, cfg.init())
+	a := newLevelAggregator(cfg)
+	now := time.Now()
+
+	assert.False(t, a.admit("I0101 12:00:00.000000 1 server.go:42] serving", now))
+	assert.True(t, a.admit("E0101 12:00:00.000000 1 server.go:42] failed", now))
+	assert.True(t, a.admit("INFO not a klog line", now))
+	assert.Equal(t, map[string]int{"I": 1}, a.counts)
+	assert.True(t, a.levels.Contains("WARN"))
+}
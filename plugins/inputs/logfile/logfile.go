@@ -4,6 +4,7 @@
 package logfile
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -18,9 +19,12 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/extension/entitystore"
 	"github.com/aws/amazon-cloudwatch-agent/internal/logscommon"
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/lsm"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/globpath"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/tail"
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
 )
 
 type LogFile struct {
@@ -34,6 +38,7 @@ type LogFile struct {
 	Log telegraf.Logger `toml:"-"`
 
 	configs           map[*FileConfig]map[string]*tailerSrc
+	lastDiscovery     map[*FileConfig]time.Time
 	done              chan struct{}
 	removeTailerSrcCh chan *tailerSrc
 	started           bool
@@ -43,6 +48,7 @@ func NewLogFile() *LogFile {
 
 	return &LogFile{
 		configs:           make(map[*FileConfig]map[string]*tailerSrc),
+		lastDiscovery:     make(map[*FileConfig]time.Time),
 		done:              make(chan struct{}),
 		removeTailerSrcCh: make(chan *tailerSrc, 100),
 	}
@@ -53,6 +59,7 @@ const sampleConfig = `
   ## These accept standard unix glob matching rules, but with the addition of
   ## ** as a "super asterisk". ie:
   ##   "/var/log/**.log"  -> recursively find all .log files in /var/log
+  ##   "/var/log/**/*.log" -> the same, a ** directory also matches no directory
   ##   "/var/log/*/*.log" -> find all .log files with a parent dir in /var/log
   ##   "/var/log/apache.log" -> just tail the apache log file
   ##
@@ -69,6 +76,10 @@ const sampleConfig = `
       file_path = "/tmp/logfile.log*"
       ## Regular expression for log files to ignore
       blacklist = "logfile.log.bak"
+      ## Globs of the files and directories to leave out of file_path
+      exclude_paths = ["/tmp/archive/**"]
+      ## Seconds between the searches for new files matching file_path, every second if 0
+      discovery_interval = 0
       ## Publish all log files that match file_path
       publish_multi_logs = false
       log_group_name = "logfile.log"
@@ -107,7 +118,7 @@ func (t *LogFile) Start(acc telegraf.Accumulator) error {
 	// Create the log file state folder.
 	err := os.MkdirAll(t.FileStateFolder, 0755)
 	if err != nil {
-		return fmt.Errorf("failed to create state file directory %s: %v", t.FileStateFolder, err)
+		return fmt.Errorf("failed to create state file directory %s: %w", t.FileStateFolder, paths.ReadOnlyHint(err))
 	}
 
 	// Clean state file on init and regularly
@@ -166,6 +177,9 @@ func (t *LogFile) FindLogSrc() []logs.LogSrc {
 			es.AddServiceAttrEntryForLogFile(entitystore.LogFileGlob(fileconfig.FilePath), fileconfig.ServiceName, fileconfig.Environment)
 		}
 
+		if !t.discoveryDue(fileconfig) {
+			continue
+		}
 		targetFiles, err := t.getTargetFiles(fileconfig)
 		if err != nil {
 			t.Log.Errorf("Failed to find target files for file config %v, with error: %v", fileconfig.FilePath, err)
@@ -187,77 +201,12 @@ func (t *LogFile) FindLogSrc() []logs.LogSrc {
 				}
 			}
 
-			var seekFile *tail.SeekInfo
-			offset, err := t.restoreState(filename)
-			if err == nil { // Missing state file would be an error too
-				seekFile = &tail.SeekInfo{Whence: io.SeekStart, Offset: offset}
-			} else if !fileconfig.Pipe && !fileconfig.FromBeginning {
-				seekFile = &tail.SeekInfo{Whence: io.SeekEnd, Offset: 0}
-			}
-
-			isutf16 := false
-			if fileconfig.Encoding == "utf-16" || fileconfig.Encoding == "utf-16le" || fileconfig.Encoding == "UTF-16" || fileconfig.Encoding == "UTF-16LE" {
-				isutf16 = true
-			}
-
-			tailer, err := tail.TailFile(filename,
-				tail.Config{
-					ReOpen:      false,
-					Follow:      true,
-					Location:    seekFile,
-					MustExist:   true,
-					Pipe:        fileconfig.Pipe,
-					Poll:        true,
-					MaxLineSize: fileconfig.MaxEventSize,
-					IsUTF16:     isutf16,
-				})
-
+			src, err := t.newTailerSrc(fileconfig, filename, t.getSeekInfo(fileconfig, filename), true)
 			if err != nil {
 				t.Log.Errorf("Failed to tail file %v with error: %v", filename, err)
 				continue
 			}
 
-			var mlCheck func(string) bool
-			if fileconfig.MultiLineStartPattern != "" {
-				mlCheck = fileconfig.isMultilineStart
-			}
-
-			groupName := fileconfig.LogGroupName
-			streamName := fileconfig.LogStreamName
-
-			// In case of multilog, the group and stream has to be generated here
-			// since it is based on the actual file name
-			if fileconfig.PublishMultiLogs {
-				if groupName == "" {
-					groupName = generateLogGroupName(filename)
-				} else {
-					streamName = generateLogStreamName(filename, fileconfig.LogStreamName)
-				}
-			}
-
-			destination := fileconfig.Destination
-			if destination == "" {
-				destination = t.Destination
-			}
-
-			src := NewTailerSrc(
-				groupName, streamName,
-				t.Destination,
-				t.getStateFilePath(filename),
-				fileconfig.LogGroupClass,
-				fileconfig.FilePath,
-				tailer,
-				fileconfig.AutoRemoval,
-				mlCheck,
-				fileconfig.Filters,
-				fileconfig.timestampFromLogLine,
-				fileconfig.Enc,
-				fileconfig.MaxEventSize,
-				fileconfig.TruncateSuffix,
-				fileconfig.RetentionInDays,
-				fileconfig.BackpressureMode,
-			)
-
 			src.AddCleanUpFn(func(ts *tailerSrc) func() {
 				return func() {
 					select {
@@ -277,6 +226,117 @@ func (t *LogFile) FindLogSrc() []logs.LogSrc {
 	return srcs
 }
 
+// discoveryDue reports whether the file config should search for new files, and records the search. Walking
+// large directory trees every second is expensive, so the discovery_interval can space the searches out.
+func (t *LogFile) discoveryDue(fileconfig *FileConfig) bool {
+	if fileconfig.DiscoveryInterval <= 0 {
+		return true
+	}
+	now := time.Now()
+	if last, ok := t.lastDiscovery[fileconfig]; ok && now.Sub(last) < time.Duration(fileconfig.DiscoveryInterval)*time.Second {
+		return false
+	}
+	if t.lastDiscovery == nil {
+		t.lastDiscovery = make(map[*FileConfig]time.Time)
+	}
+	t.lastDiscovery[fileconfig] = now
+	return true
+}
+
+// getSeekInfo returns where to start tailing the file. It resumes from the saved state if there is one.
+func (t *LogFile) getSeekInfo(fileconfig *FileConfig, filename string) *tail.SeekInfo {
+	offset, err := t.restoreState(filename)
+	if err == nil { // Missing state file would be an error too
+		return &tail.SeekInfo{Whence: io.SeekStart, Offset: offset}
+	} else if !fileconfig.Pipe && !fileconfig.FromBeginning {
+		return &tail.SeekInfo{Whence: io.SeekEnd, Offset: 0}
+	}
+	return nil
+}
+
+// newTailerSrc opens the file and creates the tailer source for it. If follow is false, the source stops at EOF.
+func (t *LogFile) newTailerSrc(fileconfig *FileConfig, filename string, seekFile *tail.SeekInfo, follow bool) (*tailerSrc, error) {
+	encodingName, enc := fileconfig.encodingFor(filename)
+	isutf16 := isUTF16Encoding(encodingName)
+
+	tailer, err := tail.TailFile(filename,
+		tail.Config{
+			ReOpen:      false,
+			Follow:      follow,
+			Location:    seekFile,
+			MustExist:   true,
+			Pipe:        fileconfig.Pipe,
+			Poll:        true,
+			MaxLineSize: fileconfig.MaxEventSize,
+			IsUTF16:     isutf16,
+			Open:        fileconfig.opener(),
+		})
+	if err != nil {
+		return nil, diagnoseAccess(fileconfig, filename, err)
+	}
+
+	var mlCheck func(string) bool
+	if fileconfig.MultiLineStartPattern != "" {
+		mlCheck = fileconfig.isMultilineStart
+	}
+
+	groupName := fileconfig.LogGroupName
+	streamName := fileconfig.LogStreamName
+
+	// In case of multilog, the group and stream has to be generated here
+	// since it is based on the actual file name
+	if fileconfig.PublishMultiLogs {
+		if groupName == "" {
+			groupName = generateLogGroupName(filename)
+		} else {
+			streamName = generateLogStreamName(filename, fileconfig.LogStreamName)
+		}
+	}
+
+	destination := fileconfig.Destination
+	if destination == "" {
+		destination = t.Destination
+	}
+
+	src := NewTailerSrc(
+		groupName, streamName,
+		destination,
+		t.getStateFilePath(filename),
+		fileconfig.LogGroupClass,
+		fileconfig.FilePath,
+		tailer,
+		// never remove files that are only read once
+		fileconfig.AutoRemoval && follow,
+		mlCheck,
+		fileconfig.Filters,
+		fileconfig.timestampFromLogLine,
+		enc,
+		fileconfig.MaxEventSize,
+		fileconfig.TruncateSuffix,
+		fileconfig.RetentionInDays,
+		fileconfig.BackpressureMode,
+	)
+	src.burst = newBurstDetector(filename, fileconfig.BurstDetection)
+	src.levels = newLevelAggregator(fileconfig.LevelAggregation)
+	src.routes = fileconfig.Routes
+	src.streams = newStreamNamer(groupName, streamName, fileconfig.LogStreamFields)
+	src.sensitive = newSensitiveScanner(fileconfig.SensitiveData)
+	src.insights = newInsightsFormatter(groupName, streamName, fileconfig.ContributorInsights)
+	src.parsesTimestamp = fileconfig.TimestampRegexP != nil
+	return src, nil
+}
+
+// diagnoseAccess explains permission errors caused by SELinux or AppArmor and counts them
+// so denied files show up in the agent's stats as well as its log.
+func diagnoseAccess(fileconfig *FileConfig, filename string, err error) error {
+	err = lsm.Diagnose(filename, err)
+	var denied *lsm.DeniedError
+	if errors.As(err, &denied) {
+		profiler.Profiler.AddStats([]string{"logfile", fileconfig.LogGroupName, fileconfig.LogStreamName, "access_denied", denied.Module}, 1)
+	}
+	return err
+}
+
 func (t *LogFile) getTargetFiles(fileconfig *FileConfig) ([]string, error) {
 	filePath := fileconfig.FilePath
 	blacklistP := fileconfig.BlacklistRegexP
@@ -288,7 +348,7 @@ func (t *LogFile) getTargetFiles(fileconfig *FileConfig) ([]string, error) {
 	var targetFileList []string
 	var targetFileName string
 	var targetModTime time.Time
-	for matchedFileName, matchedFileInfo := range g.Match() {
+	for matchedFileName, matchedFileInfo := range g.MatchExcluding(fileconfig.isExcluded) {
 		if t.FileStateFolder != "" && strings.HasPrefix(matchedFileName, t.FileStateFolder) {
 			continue
 		}
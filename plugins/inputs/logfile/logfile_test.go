@@ -1111,6 +1111,64 @@ func TestLogFileMultiLogsReadingWithBlacklist(t *testing.T) {
 	tt.Stop()
 }
 
+func TestGetTargetFilesWithExcludePaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.log", "app/a.log", "app/archive/old.log", "app/a.log.bak"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("line\n"), 0600))
+	}
+
+	tt := NewLogFile()
+	tt.Log = TestLogger{t}
+	tt.FileConfig = []FileConfig{{
+		FilePath:         filepath.Join(dir, "**", "*.log*"),
+		PublishMultiLogs: true,
+		ExcludePaths:     []string{filepath.Join(dir, "**", "archive"), filepath.Join(dir, "**", "*.bak")},
+	}}
+	require.NoError(t, tt.FileConfig[0].init())
+	files, err := tt.getTargetFiles(&tt.FileConfig[0])
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{filepath.Join(dir, "b.log"), filepath.Join(dir, "app", "a.log")}, files)
+
+	assert.True(t, tt.FileConfig[0].isExcluded(filepath.Join(dir, "app", "archive", "2024", "old.log")))
+	assert.False(t, tt.FileConfig[0].isExcluded(filepath.Join(dir, "app", "a.log")))
+
+	tt.FileConfig[0].ExcludePaths = []string{"[invalid"}
+	assert.Error(t, tt.FileConfig[0].init())
+}
+
+func TestLogFileDiscoveryInterval(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "first.log"), []byte("line\n"), 0600))
+
+	tt := NewLogFile()
+	tt.Log = TestLogger{t}
+	tt.FileStateFolder = t.TempDir()
+	tt.FileConfig = []FileConfig{{
+		FilePath:          filepath.Join(dir, "*.log"),
+		FromBeginning:     true,
+		PublishMultiLogs:  true,
+		DiscoveryInterval: 3600,
+	}}
+	require.NoError(t, tt.FileConfig[0].init())
+	tt.started = true
+	defer tt.Stop()
+
+	srcs := tt.FindLogSrc()
+	require.Len(t, srcs, 1)
+	defer srcs[0].Stop()
+
+	// the new file is only found once the interval has passed
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "second.log"), []byte("line\n"), 0600))
+	assert.Empty(t, tt.FindLogSrc())
+	tt.lastDiscovery[&tt.FileConfig[0]] = time.Now().Add(-time.Hour)
+	srcs = tt.FindLogSrc()
+	require.Len(t, srcs, 1)
+	defer srcs[0].Stop()
+	assert.Equal(t, filepath.Join(dir, "second.log"), srcs[0].(*tailerSrc).tailer.Filename)
+}
+
 func TestGenerateLogGroupName(t *testing.T) {
 	multilineWaitPeriod = 10 * time.Millisecond
 	fileName := "C:\\tmp\\soak Test\\tmp0.log"
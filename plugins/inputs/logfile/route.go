@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/schedule"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+// RouteConfig sends the events of the file to another log group while one of the windows of the route is open.
+type RouteConfig struct {
+	LogGroupName  string            `toml:"log_group_name"`
+	LogGroupClass string            `toml:"log_group_class"`
+	Windows       []schedule.Window `toml:"windows"`
+
+	schedule *schedule.Schedule
+}
+
+func (c *RouteConfig) init() error {
+	if c.LogGroupName == "" {
+		return errors.New("route log_group_name must be set")
+	}
+	if len(c.Windows) == 0 {
+		return fmt.Errorf("route to %s must have at least one window", c.LogGroupName)
+	}
+	var err error
+	if c.schedule, err = schedule.New(c.Windows); err != nil {
+		return fmt.Errorf("route to %s: %w", c.LogGroupName, err)
+	}
+	return nil
+}
+
+func (ts *tailerSrc) Routes() []logs.LogRoute {
+	routes := make([]logs.LogRoute, len(ts.routes))
+	for i, route := range ts.routes {
+		routes[i] = logs.LogRoute{Group: route.LogGroupName, Class: route.LogGroupClass}
+	}
+	return routes
+}
+
+// Route returns the first of the routes of the file with an open window at t, or -1 when the events go to the log
+// group of the file.
+func (ts *tailerSrc) Route(t time.Time) int {
+	for i, route := range ts.routes {
+		if route.schedule.Active(t) {
+			return i
+		}
+	}
+	return -1
+}
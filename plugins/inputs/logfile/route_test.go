@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/schedule"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+func TestRouteConfigInit(t *testing.T) {
+	assert.Error(t, (&RouteConfig{Windows: []schedule.Window{{Start: "19:00", End: "07:00"}}}).init())
+	assert.Error(t, (&RouteConfig{LogGroupName: "app-ia"}).init())
+	assert.Error(t, (&RouteConfig{LogGroupName: "app-ia", Windows: []schedule.Window{{Start: "19:00", End: "7am"}}}).init())
+	assert.NoError(t, (&RouteConfig{LogGroupName: "app-ia", Windows: []schedule.Window{{Start: "19:00", End: "07:00"}}}).init())
+}
+
+func TestTailerSrcRoute(t *testing.T) {
+	offHours := &RouteConfig{
+		LogGroupName:  "app-ia",
+		LogGroupClass: "INFREQUENT_ACCESS",
+		Windows: []schedule.Window{
+			{Start: "19:00", End: "07:00", Timezone: "UTC"},
+			{Days: []string{"sat", "sun"}, Start: "00:00", End: "23:59", Timezone: "UTC"},
+		},
+	}
+	release := &RouteConfig{
+		LogGroupName: "app-release",
+		Windows:      []schedule.Window{{Days: []string{"tue"}, Start: "18:00", End: "20:00", Timezone: "UTC"}},
+	}
+	require.NoError(t, offHours.init())
+	require.NoError(t, release.init())
+	ts := &tailerSrc{group: "app", routes: []*RouteConfig{offHours, release}}
+
+	assert.Equal(t, []logs.LogRoute{{Group: "app-ia", Class: "INFREQUENT_ACCESS"}, {Group: "app-release"}}, ts.Routes())
+	// Tuesday 2024-01-02
+	assert.Equal(t, -1, ts.Route(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 0, ts.Route(time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 1, ts.Route(time.Date(2024, 1, 2, 18, 30, 0, 0, time.UTC)))
+	// the first route with an open window is used
+	assert.Equal(t, 0, ts.Route(time.Date(2024, 1, 2, 19, 30, 0, 0, time.UTC)))
+	assert.Equal(t, 0, ts.Route(time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, -1, (&tailerSrc{}).Route(time.Now()))
+}
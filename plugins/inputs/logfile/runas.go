@@ -0,0 +1,35 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"os"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/tail"
+)
+
+// RunAsConfig names the Windows account a file is opened as. The password is the generic
+// credential CredentialTarget in the Credential Manager of the account the agent runs as.
+type RunAsConfig struct {
+	//Account as DOMAIN\user or user@domain, defaults to the user stored with the credential
+	Username string `toml:"username"`
+	//Name of the generic credential holding the password
+	CredentialTarget string `toml:"credential_target"`
+}
+
+// openFile opens a file of this config for reading, as the run_as account if there is one.
+func (config *FileConfig) openFile(name string) (*os.File, error) {
+	if config.RunAs == nil {
+		return tail.OpenFile(name)
+	}
+	return openFileAs(config.RunAs, name)
+}
+
+// opener returns the open function for the tailer, or nil to use the default.
+func (config *FileConfig) opener() func(string) (*os.File, error) {
+	if config.RunAs == nil {
+		return nil
+	}
+	return config.openFile
+}
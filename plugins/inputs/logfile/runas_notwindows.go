@@ -0,0 +1,16 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+// +build !windows
+
+package logfile
+
+import (
+	"errors"
+	"os"
+)
+
+func openFileAs(*RunAsConfig, string) (*os.File, error) {
+	return nil, errors.New("run_as is only supported on Windows")
+}
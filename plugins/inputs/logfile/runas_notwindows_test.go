@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+// +build !windows
+
+package logfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileConfigOpenFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, os.WriteFile(name, []byte("line\n"), 0600))
+
+	config := &FileConfig{FilePath: name}
+	assert.Nil(t, config.opener())
+	f, err := config.openFile(name)
+	require.NoError(t, err)
+	f.Close()
+
+	config.RunAs = &RunAsConfig{CredentialTarget: "CWAgent/app"}
+	assert.NotNil(t, config.opener())
+	_, err = config.openFile(name)
+	assert.ErrorContains(t, err, "only supported on Windows")
+}
@@ -0,0 +1,19 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+// +build windows
+
+package logfile
+
+import (
+	"os"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/security"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/tail"
+)
+
+func openFileAs(runAs *RunAsConfig, name string) (*os.File, error) {
+	account := security.Account{Username: runAs.Username, CredentialTarget: runAs.CredentialTarget}
+	return security.OpenFileAs(account, name, tail.OpenFile)
+}
@@ -0,0 +1,449 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+const (
+	// SensitiveActionTag uploads the event with the names of the detectors that matched.
+	SensitiveActionTag = "tag"
+	// SensitiveActionRedact uploads the event with the sensitive data replaced.
+	SensitiveActionRedact = "redact"
+	// SensitiveActionQuarantine keeps the event in a local store instead of uploading it.
+	SensitiveActionQuarantine = "quarantine"
+
+	// DetectorPAN finds payment card numbers, which are validated with the Luhn checksum.
+	DetectorPAN = "pan"
+	// DetectorSecret finds random looking tokens such as keys and passwords by their entropy.
+	DetectorSecret = "secret"
+	// DetectorRegex finds the matches of a pattern.
+	DetectorRegex = "regex"
+
+	defaultSensitiveMinScore   = 0.5
+	defaultSecretMinLength     = 20
+	defaultSecretMinEntropy    = 4.0
+	defaultQuarantineMaxSizeMB = 100
+
+	// sensitiveTagKey is the field added to JSON events by the tag action.
+	sensitiveTagKey = "cwagent_sensitive_data"
+)
+
+var (
+	// Runs of 13 to 19 digits, optionally grouped with spaces or dashes.
+	panPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+
+	quarantineStores = struct {
+		sync.Mutex
+		stores map[string]*deadletter.Store
+	}{stores: make(map[string]*deadletter.Store)}
+)
+
+// SensitiveDataConfig scores the events of a file with detectors and applies the action to the events that score
+// at least the minimum.
+type SensitiveDataConfig struct {
+	// Detectors are the detectors to run, the pan and secret detectors if empty.
+	Detectors []*DetectorConfig `toml:"detectors"`
+	// Action is tag, redact or quarantine, redact by default.
+	Action string `toml:"action"`
+	// MinScore is the score between 0 and 1 a finding needs for the action to apply.
+	MinScore float64 `toml:"min_score"`
+	// QuarantineDir is where quarantined events are kept.
+	QuarantineDir string `toml:"quarantine_dir"`
+	// QuarantineMaxSizeMB is the size after which newly quarantined events are dropped.
+	QuarantineMaxSizeMB int `toml:"quarantine_max_size_mb"`
+}
+
+// DetectorConfig configures one detector. Only the fields of its type are used.
+type DetectorConfig struct {
+	Type string `toml:"type"`
+	// Name identifies the detector in tags, redactions and metrics, the type by default.
+	Name string `toml:"name"`
+	// Pattern is the regular expression of a regex detector.
+	Pattern string `toml:"pattern"`
+	// Score is the score of the matches of a regex detector, 1 by default.
+	Score float64 `toml:"score"`
+	// MinLength is the length from which a token is checked by a secret detector.
+	MinLength int `toml:"min_length"`
+	// MinEntropy is the Shannon entropy in bits per character from which a token is a secret.
+	MinEntropy float64 `toml:"min_entropy"`
+}
+
+func (c *SensitiveDataConfig) init() error {
+	switch c.Action {
+	case "":
+		c.Action = SensitiveActionRedact
+	case SensitiveActionTag, SensitiveActionRedact, SensitiveActionQuarantine:
+	default:
+		return fmt.Errorf("sensitive_data action must be %s, %s or %s, but got %q", SensitiveActionTag, SensitiveActionRedact, SensitiveActionQuarantine, c.Action)
+	}
+	if c.MinScore == 0 {
+		c.MinScore = defaultSensitiveMinScore
+	}
+	if c.MinScore < 0 || c.MinScore > 1 {
+		return fmt.Errorf("sensitive_data min_score must be between 0 and 1, but got %v", c.MinScore)
+	}
+	if c.QuarantineDir == "" {
+		c.QuarantineDir = paths.QuarantineDir
+	}
+	if c.QuarantineMaxSizeMB == 0 {
+		c.QuarantineMaxSizeMB = defaultQuarantineMaxSizeMB
+	}
+	if c.QuarantineMaxSizeMB < 0 {
+		return fmt.Errorf("sensitive_data quarantine_max_size_mb must be positive, but got %d", c.QuarantineMaxSizeMB)
+	}
+	if len(c.Detectors) == 0 {
+		c.Detectors = []*DetectorConfig{{Type: DetectorPAN}, {Type: DetectorSecret}}
+	}
+	for _, d := range c.Detectors {
+		if err := d.init(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *DetectorConfig) init() error {
+	if c.Name == "" {
+		c.Name = c.Type
+	}
+	switch c.Type {
+	case DetectorPAN:
+	case DetectorSecret:
+		if c.MinLength == 0 {
+			c.MinLength = defaultSecretMinLength
+		}
+		if c.MinEntropy == 0 {
+			c.MinEntropy = defaultSecretMinEntropy
+		}
+		if c.MinLength < 0 || c.MinEntropy < 0 {
+			return fmt.Errorf("sensitive_data detector %s min_length and min_entropy must be positive", c.Name)
+		}
+	case DetectorRegex:
+		if c.Pattern == "" {
+			return fmt.Errorf("sensitive_data detector %s needs a pattern", c.Name)
+		}
+		if _, err := regexp.Compile(c.Pattern); err != nil {
+			return fmt.Errorf("sensitive_data detector %s pattern has issue, regexp: Compile( %v ): %v", c.Name, c.Pattern, err)
+		}
+		if c.Score == 0 {
+			c.Score = 1
+		}
+		if c.Score < 0 || c.Score > 1 {
+			return fmt.Errorf("sensitive_data detector %s score must be between 0 and 1, but got %v", c.Name, c.Score)
+		}
+	default:
+		return fmt.Errorf("sensitive_data detector type must be %s, %s or %s, but got %q", DetectorPAN, DetectorSecret, DetectorRegex, c.Type)
+	}
+	return nil
+}
+
+// finding is a span of a message a detector considers sensitive, with how confident it is between 0 and 1.
+type finding struct {
+	start, end int
+	score      float64
+}
+
+type detector interface {
+	name() string
+	detect(msg string) []finding
+}
+
+func newDetector(c *DetectorConfig) detector {
+	switch c.Type {
+	case DetectorPAN:
+		return panDetector{detectorName: detectorName(c.Name)}
+	case DetectorSecret:
+		return secretDetector{detectorName: detectorName(c.Name), minLength: c.MinLength, minEntropy: c.MinEntropy}
+	case DetectorRegex:
+		return regexDetector{detectorName: detectorName(c.Name), re: regexp.MustCompile(c.Pattern), score: c.Score}
+	}
+	return nil
+}
+
+type detectorName string
+
+func (n detectorName) name() string {
+	return string(n)
+}
+
+type panDetector struct {
+	detectorName
+}
+
+// detect scores card numbers that pass the Luhn check, higher when they start like the numbers of the major
+// card networks.
+func (d panDetector) detect(msg string) []finding {
+	var findings []finding
+	for _, m := range panPattern.FindAllStringIndex(msg, -1) {
+		digits := strings.NewReplacer(" ", "", "-", "").Replace(msg[m[0]:m[1]])
+		if !luhnValid(digits) {
+			continue
+		}
+		score := 0.8
+		if digits[0] >= '3' && digits[0] <= '6' {
+			score = 1
+		}
+		findings = append(findings, finding{start: m[0], end: m[1], score: score})
+	}
+	return findings
+}
+
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+type secretDetector struct {
+	detectorName
+	minLength  int
+	minEntropy float64
+}
+
+// detect scores tokens that mix upper case letters, lower case letters and digits by their entropy, from 0.5 at
+// the minimum to 1 at one bit per character above it. Hex strings such as UUIDs and hashes do not mix cases, so they are left alone.
+func (d secretDetector) detect(msg string) []finding {
+	var findings []finding
+	for start := 0; start < len(msg); {
+		if !isTokenChar(msg[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(msg) && isTokenChar(msg[end]) {
+			end++
+		}
+		// base64 padding
+		for end < len(msg) && msg[end] == '=' {
+			end++
+		}
+		token := msg[start:end]
+		if len(token) >= d.minLength && mixesCharClasses(token) {
+			if entropy := shannonEntropy(token); entropy >= d.minEntropy {
+				findings = append(findings, finding{start: start, end: end, score: math.Min(1, 0.5+(entropy-d.minEntropy)/2)})
+			}
+		}
+		start = end
+	}
+	return findings
+}
+
+func isTokenChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '+' || c == '/' || c == '_' || c == '-'
+}
+
+func mixesCharClasses(token string) bool {
+	var upper, lower, digit bool
+	for i := 0; i < len(token); i++ {
+		switch c := token[i]; {
+		case c >= 'A' && c <= 'Z':
+			upper = true
+		case c >= 'a' && c <= 'z':
+			lower = true
+		case c >= '0' && c <= '9':
+			digit = true
+		}
+	}
+	return upper && lower && digit
+}
+
+func shannonEntropy(s string) float64 {
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+	var entropy float64
+	for _, n := range counts {
+		if n > 0 {
+			p := float64(n) / float64(len(s))
+			entropy -= p * math.Log2(p)
+		}
+	}
+	return entropy
+}
+
+type regexDetector struct {
+	detectorName
+	re    *regexp.Regexp
+	score float64
+}
+
+func (d regexDetector) detect(msg string) []finding {
+	matches := d.re.FindAllStringIndex(msg, -1)
+	findings := make([]finding, 0, len(matches))
+	for _, m := range matches {
+		if m[0] < m[1] {
+			findings = append(findings, finding{start: m[0], end: m[1], score: d.score})
+		}
+	}
+	return findings
+}
+
+// sensitiveScanner applies the sensitive data config of a file. It is only used from the tailer goroutine.
+type sensitiveScanner struct {
+	action    string
+	minScore  float64
+	detectors []detector
+	store     *deadletter.Store
+}
+
+func newSensitiveScanner(cfg *SensitiveDataConfig) *sensitiveScanner {
+	if cfg == nil || len(cfg.Detectors) == 0 {
+		return nil
+	}
+	s := &sensitiveScanner{action: cfg.Action, minScore: cfg.MinScore}
+	for _, d := range cfg.Detectors {
+		s.detectors = append(s.detectors, newDetector(d))
+	}
+	if s.action == SensitiveActionQuarantine {
+		s.store = quarantineStore(cfg.QuarantineDir, int64(cfg.QuarantineMaxSizeMB)*1024*1024)
+	}
+	return s
+}
+
+// quarantineStore returns the store of the directory, shared by every file quarantining to it.
+func quarantineStore(dir string, maxSize int64) *deadletter.Store {
+	quarantineStores.Lock()
+	defer quarantineStores.Unlock()
+	store, ok := quarantineStores.stores[dir]
+	if !ok {
+		store = deadletter.NewStore(dir, maxSize)
+		quarantineStores.stores[dir] = store
+	}
+	return store
+}
+
+type sensitiveSpan struct {
+	start, end int
+	name       string
+}
+
+// scan returns the names of the detectors with findings of at least the minimum score, and the message with the
+// tag or redact action applied.
+func (s *sensitiveScanner) scan(msg string) (string, []string) {
+	var detected []string
+	var spans []sensitiveSpan
+	score := 0.0
+	for _, d := range s.detectors {
+		found := false
+		for _, f := range d.detect(msg) {
+			if f.score < s.minScore {
+				continue
+			}
+			found = true
+			score = math.Max(score, f.score)
+			spans = append(spans, sensitiveSpan{start: f.start, end: f.end, name: d.name()})
+		}
+		if found {
+			detected = append(detected, d.name())
+		}
+	}
+	if len(detected) == 0 {
+		return msg, nil
+	}
+	switch s.action {
+	case SensitiveActionTag:
+		return tagMessage(msg, detected, score), detected
+	case SensitiveActionRedact:
+		return redactSpans(msg, spans), detected
+	}
+	return msg, detected
+}
+
+// tagMessage adds the detectors to the event, as a field of JSON objects or a prefix of other messages.
+func tagMessage(msg string, detected []string, score float64) string {
+	tag, _ := json.Marshal(struct {
+		Detectors []string `json:"detectors"`
+		Score     float64  `json:"score"`
+	}{detected, math.Round(score*100) / 100})
+	trimmed := strings.TrimSpace(msg)
+	if strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+		i := strings.IndexByte(msg, '{') + 1
+		separator := ","
+		if strings.HasPrefix(strings.TrimSpace(msg[i:]), "}") {
+			separator = ""
+		}
+		return msg[:i] + `"` + sensitiveTagKey + `":` + string(tag) + separator + msg[i:]
+	}
+	return "[" + sensitiveTagKey + ":" + strings.Join(detected, ",") + "] " + msg
+}
+
+// redactSpans replaces the spans with the name of the detector that found them. Overlapping spans are merged.
+func redactSpans(msg string, spans []sensitiveSpan) string {
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(spans); {
+		span := spans[i]
+		for i++; i < len(spans) && spans[i].start < span.end; i++ {
+			span.end = max(span.end, spans[i].end)
+		}
+		b.WriteString(msg[last:span.start])
+		b.WriteString("[REDACTED:" + span.name + "]")
+		last = span.end
+	}
+	b.WriteString(msg[last:])
+	return b.String()
+}
+
+// scanSensitive applies the sensitive data action of the file to the event. Returns false if the event was
+// quarantined and must not be sent.
+func (ts *tailerSrc) scanSensitive(e *LogEvent) bool {
+	msg, detected := ts.sensitive.scan(e.msg)
+	if len(detected) == 0 {
+		return true
+	}
+	for _, name := range detected {
+		ts.stats.RecordDetection(name)
+	}
+	profiler.Profiler.AddStats([]string{"logfile", ts.group, ts.stream, "messages", "sensitive_data"}, 1)
+	if ts.sensitive.action != SensitiveActionQuarantine {
+		e.msg = msg
+		return true
+	}
+	now := time.Now()
+	timestamp := e.t
+	if timestamp.IsZero() {
+		timestamp = now
+	}
+	err := ts.sensitive.store.Add(deadletter.Record{
+		RejectedAt: now,
+		Reason:     deadletter.ReasonSensitive,
+		Group:      ts.group,
+		Stream:     ts.stream,
+		Class:      ts.class,
+		Timestamp:  timestamp,
+		Message:    e.msg,
+	})
+	if err != nil {
+		// the event is dropped rather than uploaded with the sensitive data
+		log.Printf("E! [logfile] Dropping an event of %s with sensitive data that could not be quarantined: %v", ts.tailer.Filename, err)
+	}
+	ts.stats.RecordDropped()
+	return false
+}
@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
+)
+
+const (
+	testPAN    = "4111 1111 1111 1111"
+	testSecret = "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"
+)
+
+func TestSensitiveDataConfigInit(t *testing.T) {
+	cfg := &SensitiveDataConfig{}
+	require.NoError(t, cfg.init())
+	assert.Equal(t, SensitiveActionRedact, cfg.Action)
+	assert.Equal(t, defaultSensitiveMinScore, cfg.MinScore)
+	assert.Equal(t, defaultQuarantineMaxSizeMB, cfg.QuarantineMaxSizeMB)
+	require.Len(t, cfg.Detectors, 2)
+	assert.Equal(t, DetectorPAN, cfg.Detectors[0].Name)
+	assert.Equal(t, defaultSecretMinLength, cfg.Detectors[1].MinLength)
+	assert.Equal(t, defaultSecretMinEntropy, cfg.Detectors[1].MinEntropy)
+
+	cfg = &SensitiveDataConfig{Detectors: []*DetectorConfig{{Type: DetectorRegex, Name: "ssn", Pattern: `\d{3}-\d{2}-\d{4}`}}}
+	require.NoError(t, cfg.init())
+	assert.Equal(t, 1.0, cfg.Detectors[0].Score)
+
+	assert.Error(t, (&SensitiveDataConfig{Action: "drop"}).init())
+	assert.Error(t, (&SensitiveDataConfig{MinScore: 2}).init())
+	assert.Error(t, (&SensitiveDataConfig{QuarantineMaxSizeMB: -1}).init())
+	assert.Error(t, (&SensitiveDataConfig{Detectors: []*DetectorConfig{{Type: "email"}}}).init())
+	assert.Error(t, (&SensitiveDataConfig{Detectors: []*DetectorConfig{{Type: DetectorRegex}}}).init())
+	assert.Error(t, (&SensitiveDataConfig{Detectors: []*DetectorConfig{{Type: DetectorRegex, Pattern: "("}}}).init())
+	assert.Nil(t, newSensitiveScanner(nil))
+}
+
+func TestPANDetector(t *testing.T) {
+	d := panDetector{detectorName: DetectorPAN}
+	testCases := map[string]struct {
+		msg   string
+		score float64
+	}{
+		"Spaces":        {msg: "paid with " + testPAN + " today", score: 1},
+		"Dashes":        {msg: "card=5500-0000-0000-0004", score: 1},
+		"OtherNetwork":  {msg: "card 8000000000000003", score: 0.8},
+		"FailsChecksum": {msg: "card 4111 1111 1111 1112"},
+		"TooShort":      {msg: "order 411111111111"},
+		"PartOfNumber":  {msg: "id 41111111111111111111111"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			findings := d.detect(testCase.msg)
+			if testCase.score == 0 {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, testCase.score, findings[0].score)
+		})
+	}
+}
+
+func TestSecretDetector(t *testing.T) {
+	d := secretDetector{detectorName: DetectorSecret, minLength: defaultSecretMinLength, minEntropy: defaultSecretMinEntropy}
+	findings := d.detect("aws_secret_access_key=" + testSecret + " ok")
+	require.Len(t, findings, 1)
+	assert.Equal(t, testSecret, ("aws_secret_access_key=" + testSecret)[findings[0].start:findings[0].end])
+	assert.Greater(t, findings[0].score, 0.5)
+
+	assert.Empty(t, d.detect("request 123e4567-e89b-12d3-a456-426614174000 done"))
+	assert.Empty(t, d.detect("internationalization of the ConfigurationManager2"))
+	assert.Empty(t, d.detect("short Ab1"))
+}
+
+func TestSensitiveScanner(t *testing.T) {
+	newScanner := func(action string) *sensitiveScanner {
+		cfg := &SensitiveDataConfig{Action: action, QuarantineDir: t.TempDir(), Detectors: []*DetectorConfig{
+			{Type: DetectorPAN},
+			{Type: DetectorSecret},
+			{Type: DetectorRegex, Name: "ssn", Pattern: `\b\d{3}-\d{2}-\d{4}\b`, Score: 0.4},
+		}}
+		require.NoError(t, cfg.init())
+		return newSensitiveScanner(cfg)
+	}
+	msg := "card " + testPAN + " key " + testSecret + " ssn 123-45-6789"
+
+	got, detected := newScanner(SensitiveActionRedact).scan(msg)
+	// the regex detector scores below the minimum
+	assert.Equal(t, []string{DetectorPAN, DetectorSecret}, detected)
+	assert.Equal(t, "card [REDACTED:pan] key [REDACTED:secret] ssn 123-45-6789", got)
+
+	got, detected = newScanner(SensitiveActionTag).scan("card " + testPAN)
+	assert.Equal(t, []string{DetectorPAN}, detected)
+	assert.Equal(t, "[cwagent_sensitive_data:pan] card "+testPAN, got)
+	got, _ = newScanner(SensitiveActionTag).scan(`{"card":"` + testPAN + `"}`)
+	assert.Equal(t, `{"cwagent_sensitive_data":{"detectors":["pan"],"score":1},"card":"`+testPAN+`"}`, got)
+
+	got, detected = newScanner(SensitiveActionRedact).scan("nothing to see")
+	assert.Empty(t, detected)
+	assert.Equal(t, "nothing to see", got)
+}
+
+func TestRedactSpans(t *testing.T) {
+	got := redactSpans("0123456789", []sensitiveSpan{{start: 6, end: 8, name: "b"}, {start: 1, end: 4, name: "a"}, {start: 2, end: 5, name: "c"}})
+	assert.Equal(t, "0[REDACTED:a]5[REDACTED:b]89", got)
+}
+
+func TestTailerSrcQuarantine(t *testing.T) {
+	cfg := &SensitiveDataConfig{Action: SensitiveActionQuarantine, QuarantineDir: t.TempDir()}
+	require.NoError(t, cfg.init())
+	ts := &tailerSrc{group: "group", stream: "stream", sensitive: newSensitiveScanner(cfg)}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, ts.scanSensitive(&LogEvent{msg: "nothing to see", t: timestamp}))
+	assert.False(t, ts.scanSensitive(&LogEvent{msg: "card " + testPAN, t: timestamp}))
+
+	records, err := ts.sensitive.store.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, deadletter.ReasonSensitive, records[0].Reason)
+	assert.Equal(t, "group", records[0].Group)
+	assert.Equal(t, timestamp, records[0].Timestamp)
+	assert.Equal(t, "card "+testPAN, records[0].Message)
+	assert.Same(t, ts.sensitive.store, quarantineStore(cfg.QuarantineDir, 0))
+}
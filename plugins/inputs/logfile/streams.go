@@ -0,0 +1,154 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
+)
+
+const (
+	defaultMaxLogStreams         = 100
+	defaultOverflowLogStreamName = "overflow"
+	// maxLogStreamNameLength is the longest log stream name CloudWatch Logs accepts.
+	maxLogStreamNameLength = 512
+)
+
+var (
+	// fieldPlaceholder matches the references to the fields of the events in the log_stream_name, e.g. "{pod_name}".
+	// The placeholders of the host, like {instance_id}, are resolved when the configuration is translated.
+	fieldPlaceholder = regexp.MustCompile(`\{(\w+)\}`)
+	// invalidStreamChars are the characters CloudWatch Logs does not accept in a log stream name.
+	invalidStreamChars = strings.NewReplacer(":", "_", "*", "_")
+)
+
+// LogStreamFieldsConfig names the log streams of the events of the file after the fields of the events that the
+// log_stream_name references, e.g. "{pod_name}". The fields are the named groups of the pattern, and the top level
+// fields of the JSON events.
+type LogStreamFieldsConfig struct {
+	// Pattern is the regular expression whose named groups are extracted from the events as fields.
+	Pattern string `toml:"pattern"`
+	// MaxLogStreams is the number of log streams the file's events are published to, past which the events of new
+	// log streams go to the overflow log stream.
+	MaxLogStreams int `toml:"max_log_streams"`
+	// OverflowLogStreamName is the log stream of the events missing a field, and of the events past the maximum.
+	OverflowLogStreamName string `toml:"overflow_log_stream_name"`
+
+	pattern *regexp.Regexp
+}
+
+func (c *LogStreamFieldsConfig) init(logStreamName string) error {
+	if !fieldPlaceholder.MatchString(logStreamName) {
+		return fmt.Errorf("log_stream_fields needs a log_stream_name referencing fields, e.g. {pod_name}, but got %q", logStreamName)
+	}
+	if c.Pattern != "" {
+		var err error
+		if c.pattern, err = regexp.Compile(c.Pattern); err != nil {
+			return fmt.Errorf("log_stream_fields pattern %q is invalid: %w", c.Pattern, err)
+		}
+	}
+	if c.MaxLogStreams == 0 {
+		c.MaxLogStreams = defaultMaxLogStreams
+	}
+	if c.MaxLogStreams < 0 {
+		return fmt.Errorf("log_stream_fields max_log_streams must be positive, but got %d", c.MaxLogStreams)
+	}
+	if c.OverflowLogStreamName == "" {
+		c.OverflowLogStreamName = defaultOverflowLogStreamName
+	}
+	return nil
+}
+
+// streamNamer resolves the log_stream_name of the file for each event.
+type streamNamer struct {
+	group    string
+	template string
+	config   *LogStreamFieldsConfig
+	// streams are the log streams the events were published to so far, up to the maximum.
+	streams map[string]struct{}
+}
+
+func newStreamNamer(group, template string, config *LogStreamFieldsConfig) *streamNamer {
+	if config == nil {
+		return nil
+	}
+	return &streamNamer{group: group, template: template, config: config, streams: map[string]struct{}{}}
+}
+
+// stream returns the log stream of the event, or the overflow log stream when the event misses a field of the
+// template or when its log stream would be one more than the maximum.
+func (n *streamNamer) stream(msg string) string {
+	fields := n.fields(msg)
+	missing := false
+	name := fieldPlaceholder.ReplaceAllStringFunc(n.template, func(placeholder string) string {
+		value := fields[placeholder[1:len(placeholder)-1]]
+		if value == "" {
+			missing = true
+		}
+		return value
+	})
+	if missing {
+		return n.config.OverflowLogStreamName
+	}
+	name = truncateMessage(strings.ToValidUTF8(invalidStreamChars.Replace(name), "_"), maxLogStreamNameLength, "")
+	if _, ok := n.streams[name]; !ok {
+		if len(n.streams) >= n.config.MaxLogStreams {
+			profiler.Profiler.AddStats([]string{"logfile", n.group, n.template, "messages", "stream_overflowed"}, 1)
+			return n.config.OverflowLogStreamName
+		}
+		n.streams[name] = struct{}{}
+	}
+	return name
+}
+
+// fields returns the named groups of the pattern matched in the event, and the top level fields of a JSON event
+// that the pattern does not set.
+func (n *streamNamer) fields(msg string) map[string]string {
+	fields := map[string]string{}
+	if n.config.pattern != nil {
+		if match := n.config.pattern.FindStringSubmatch(msg); match != nil {
+			for i, name := range n.config.pattern.SubexpNames() {
+				if name != "" && match[i] != "" {
+					fields[name] = match[i]
+				}
+			}
+		}
+	}
+	if trimmed := strings.TrimSpace(msg); strings.HasPrefix(trimmed, "{") {
+		var object map[string]interface{}
+		if json.Unmarshal([]byte(trimmed), &object) == nil {
+			for key, value := range object {
+				if _, ok := fields[key]; ok {
+					continue
+				}
+				switch value.(type) {
+				case string, float64, bool:
+					fields[key] = fmt.Sprint(value)
+				}
+			}
+		}
+	}
+	return fields
+}
+
+func (ts *tailerSrc) EventStreams() bool {
+	return ts.streams != nil
+}
+
+// EventStream returns the log stream the event was named after when it was read. The events the file does not
+// read, like the summaries of the bursts and the counts of the aggregated levels, go to the overflow log stream.
+func (ts *tailerSrc) EventStream(e logs.LogEvent) string {
+	if ts.streams == nil {
+		return ts.stream
+	}
+	if le, ok := e.(*LogEvent); ok && le.stream != "" {
+		return le.stream
+	}
+	return ts.streams.config.OverflowLogStreamName
+}
@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogStreamFieldsConfigInit(t *testing.T) {
+	c := &LogStreamFieldsConfig{}
+	require.NoError(t, c.init("{pod_name}"))
+	assert.Equal(t, defaultMaxLogStreams, c.MaxLogStreams)
+	assert.Equal(t, defaultOverflowLogStreamName, c.OverflowLogStreamName)
+
+	assert.Error(t, (&LogStreamFieldsConfig{}).init("i-1234567890"))
+	assert.Error(t, (&LogStreamFieldsConfig{Pattern: "pod=(?P<pod_name"}).init("{pod_name}"))
+	assert.Error(t, (&LogStreamFieldsConfig{MaxLogStreams: -1}).init("{pod_name}"))
+}
+
+func TestStreamNamer(t *testing.T) {
+	config := &LogStreamFieldsConfig{Pattern: `region=(?P<request_region>[\w-]+)`, MaxLogStreams: 2, OverflowLogStreamName: "other"}
+	require.NoError(t, config.init("i-123/{request_region}"))
+	n := newStreamNamer("app", "i-123/{request_region}", config)
+
+	assert.Equal(t, "i-123/us-east-1", n.stream("GET / region=us-east-1"))
+	assert.Equal(t, "other", n.stream("GET / without region"))
+	assert.Equal(t, "i-123/eu-west-1", n.stream("GET / region=eu-west-1"))
+	// past the maximum, only the known log streams are used
+	assert.Equal(t, "other", n.stream("GET / region=ap-south-1"))
+	assert.Equal(t, "i-123/us-east-1", n.stream("POST / region=us-east-1"))
+	assert.Nil(t, newStreamNamer("app", "i-123", nil))
+}
+
+func TestStreamNamerJSON(t *testing.T) {
+	config := &LogStreamFieldsConfig{}
+	require.NoError(t, config.init("{namespace}.{pod_name}"))
+	n := newStreamNamer("app", "{namespace}.{pod_name}", config)
+
+	assert.Equal(t, "default.web-7d4b9", n.stream(`{"pod_name": "web-7d4b9", "namespace": "default", "msg": "ready"}`))
+	assert.Equal(t, "overflow", n.stream(`{"pod_name": "web-7d4b9", "msg": "ready"}`))
+	assert.Equal(t, "overflow", n.stream(`{"pod_name": "web-7d4b9", "namespace": {"name": "default"}}`))
+	// the characters CloudWatch Logs does not accept are replaced
+	assert.Equal(t, "default.web_1_", n.stream(`{"pod_name": "web:1*", "namespace": "default"}`))
+	// the names are cut to the limit of CloudWatch Logs without splitting a character
+	long := strings.Repeat("é", maxLogStreamNameLength)
+	assert.Equal(t, "default."+long[:maxLogStreamNameLength-len("default.")], n.stream(`{"pod_name": "`+long+`", "namespace": "default"}`))
+}
+
+func TestTailerSrcEventStream(t *testing.T) {
+	config := &LogStreamFieldsConfig{Pattern: `pod=(?P<pod_name>\S+)`}
+	require.NoError(t, config.init("{pod_name}"))
+	ts := &tailerSrc{group: "app", stream: "{pod_name}"}
+	assert.False(t, ts.EventStreams())
+	assert.Equal(t, "{pod_name}", ts.EventStream(&LogEvent{msg: "pod=web"}))
+
+	ts.streams = newStreamNamer("app", "{pod_name}", config)
+	assert.True(t, ts.EventStreams())
+	assert.Equal(t, "web", ts.EventStream(&LogEvent{msg: "pod=web", stream: "web"}))
+	// the burst summaries and the level counts have no log stream of their own
+	assert.Equal(t, "overflow", ts.EventStream(&LogEvent{msg: `{"cwagent_burst": {}}`}))
+}
@@ -68,6 +68,9 @@ type Config struct {
 
 	// Special handling for utf16
 	IsUTF16 bool
+
+	// Opens the file instead of OpenFile, e.g. as another account
+	Open func(name string) (*os.File, error)
 }
 
 type Tail struct {
@@ -119,7 +122,7 @@ func TailFile(filename string, config Config) (*Tail, error) {
 
 	if t.MustExist {
 		var err error
-		t.file, err = OpenFile(t.Filename)
+		t.file, err = t.open()
 		if err != nil {
 			return nil, err
 		}
@@ -192,11 +195,18 @@ func (tail *Tail) CloseFile() {
 	}
 }
 
+func (tail *Tail) open() (*os.File, error) {
+	if tail.Config.Open != nil {
+		return tail.Config.Open(tail.Filename)
+	}
+	return OpenFile(tail.Filename)
+}
+
 func (tail *Tail) Reopen(resetOffset bool) error {
 	tail.CloseFile()
 	for {
 		var err error
-		tail.file, err = OpenFile(tail.Filename)
+		tail.file, err = tail.open()
 		if resetOffset {
 			tail.curOffset = 0
 		}
@@ -7,9 +7,24 @@
 package tail
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/privhelper"
 )
 
+// OpenFile opens name for reading. Files the agent user may not read are requested from the
+// privileged helper when one is running.
 func OpenFile(name string) (file *os.File, err error) {
-	return os.Open(name)
+	file, err = os.Open(name)
+	if err == nil || !errors.Is(err, fs.ErrPermission) || os.Getenv(privhelper.EnvSocket) == "" {
+		return file, err
+	}
+	file, helperErr := privhelper.Open(name)
+	if helperErr != nil {
+		return nil, fmt.Errorf("%w (%v)", err, helperErr)
+	}
+	return file, nil
 }
@@ -8,21 +8,28 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"golang.org/x/text/encoding"
 
 	"github.com/aws/amazon-cloudwatch-agent/extension/entitystore"
+	"github.com/aws/amazon-cloudwatch-agent/internal/control"
 	"github.com/aws/amazon-cloudwatch-agent/internal/logscommon"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/tail"
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 )
 
 const (
 	stateFileMode      = 0644
 	tailCloseThreshold = 3 * time.Second
+	// byteOrderMark is left at the start of the first line by decoders that ignore the BOM.
+	byteOrderMark = "\uFEFF"
 )
 
 var (
@@ -46,6 +53,8 @@ type LogEvent struct {
 	t      time.Time
 	offset fileOffset
 	src    *tailerSrc
+	// stream is the log stream of the event when the file's log streams are named after the events.
+	stream string
 }
 
 func (le LogEvent) Message() string {
@@ -57,6 +66,7 @@ func (le LogEvent) Time() time.Time {
 }
 
 func (le LogEvent) Done() {
+	profiler.Usage.AddInFlight(le.src.usageKey, -len(le.msg))
 	le.src.Done(le.offset)
 }
 
@@ -85,10 +95,35 @@ type tailerSrc struct {
 	backpressureFdDrop bool
 	buffer             chan *LogEvent
 	stopOnce           sync.Once
+	// minTimestamp skips events with an older parsed timestamp, used when backfilling.
+	minTimestamp time.Time
+	// usageKey attributes the work done for the file in the profiler usage report.
+	usageKey string
+	// outputWait is the time spent in the current loop iteration waiting on the destination, which is
+	// not counted as busy time.
+	outputWait time.Duration
+	// burst samples the events of the file while its event rate is above the configured threshold.
+	burst *burstDetector
+	// routes send the events of the file to other log groups during their windows.
+	routes []*RouteConfig
+	// levels counts the events of the aggregated levels instead of uploading them.
+	levels *levelAggregator
+	// streams names the log stream of each event after its fields, when the log_stream_name references them.
+	streams *streamNamer
+	// sensitive detects sensitive data in the events of the file and tags, redacts or quarantines them.
+	sensitive *sensitiveScanner
+	// insights rewrites the events of the file as flat JSON objects for Contributor Insights.
+	insights *insightsFormatter
+	// parsesTimestamp is set when the file has a timestamp_format, so a zero timestamp is a parse failure.
+	parsesTimestamp bool
+	// stats reports the progress through the file in the agent's self-telemetry and the control socket.
+	stats *selftelemetry.LogSource
 }
 
 // Verify tailerSrc implements LogSrc
 var _ logs.LogSrc = (*tailerSrc)(nil)
+var _ logs.RoutedLogSrc = (*tailerSrc)(nil)
+var _ logs.StreamedLogSrc = (*tailerSrc)(nil)
 
 func NewTailerSrc(
 	group, stream, destination, stateFilePath, logClass, fileGlobPath string,
@@ -123,6 +158,7 @@ func NewTailerSrc(
 
 		offsetCh: make(chan fileOffset, 2000),
 		done:     make(chan struct{}),
+		usageKey: profiler.SourceKey(profiler.SourceLogFile, tailer.Filename),
 	}
 
 	if ts.backpressureFdDrop {
@@ -194,7 +230,7 @@ func (ts *tailerSrc) AddCleanUpFn(f func()) {
 func (ts *tailerSrc) Entity() *cloudwatchlogs.Entity {
 	es := entitystore.GetEntityStore()
 	if es != nil {
-		return es.CreateLogFileEntity(entitystore.LogFileGlob(ts.fileGlobPath), entitystore.LogGroupName(ts.group))
+		return es.CreateLogFileEntity(entitystore.LogFileGlob(ts.fileGlobPath), entitystore.LogGroupName(ts.group), ts.tailer.Filename)
 	}
 	return nil
 }
@@ -208,12 +244,31 @@ func (ts *tailerSrc) runTail() {
 	var cnt int
 	fo := &fileOffset{}
 	ignoreUntilNextEvent := false
+	var busySince time.Time
+	control.Register(ts.usageKey)
+	defer control.Unregister(ts.usageKey)
+	ts.stats = selftelemetry.LogSources.Register(ts.usageKey, ts.tailer.Filename, ts.group, ts.stream)
+	defer selftelemetry.LogSources.Unregister(ts.usageKey)
 
 	for {
+		if !busySince.IsZero() {
+			profiler.Usage.AddBusy(ts.usageKey, time.Since(busySince)-ts.outputWait)
+			busySince = time.Time{}
+			ts.outputWait = 0
+		}
+		// a paused file is not read, so the saved offset stays where it was and the lines are read on resume
+		lines := ts.tailer.Lines
+		if control.Paused(ts.usageKey) {
+			lines = nil
+		}
 		select {
-		case line, ok := <-ts.tailer.Lines:
+		case <-control.Changed():
+			continue
+		case line, ok := <-lines:
+			busySince = time.Now()
 			if !ok {
 				ts.publishEvent(msgBuf, fo)
+				ts.publishLevelCounts(fo, true)
 				return
 			}
 
@@ -221,6 +276,8 @@ func (ts *tailerSrc) runTail() {
 				log.Printf("E! [logfile] Error tailing line in file %s, Error: %s\n", ts.tailer.Filename, line.Err)
 				continue
 			}
+			// the line ending is not part of the text, count it as a single newline
+			ts.stats.AddRead(len(line.Text)+1, line.Offset)
 
 			text := line.Text
 			if ts.enc != nil {
@@ -228,9 +285,17 @@ func (ts *tailerSrc) runTail() {
 				text, err = ts.enc.NewDecoder().String(text)
 				if err != nil {
 					log.Printf("E! [logfile] Cannot decode the log file content for %s: %v\n", ts.tailer.Filename, err)
+					ts.stats.RecordDropped()
 					continue
 				}
 			}
+			text = strings.TrimPrefix(text, byteOrderMark)
+			// Binary content or a wrongly configured encoding produces byte sequences that CloudWatch Logs
+			// rejects, so replace them instead of failing the whole batch.
+			if !utf8.ValidString(text) {
+				text = strings.ToValidUTF8(text, string(utf8.RuneError))
+				profiler.Profiler.AddStats([]string{"logfile", ts.group, ts.stream, "messages", "invalid_utf8"}, 1)
+			}
 
 			if ts.isMLStart == nil {
 				msgBuf.Reset()
@@ -248,8 +313,12 @@ func (ts *tailerSrc) runTail() {
 				msgBuf.WriteString("\n")
 				msgBuf.WriteString(text)
 				if msgBuf.Len() > ts.maxEventSize {
-					msgBuf.Truncate(ts.maxEventSize - len(ts.truncateSuffix))
-					msgBuf.WriteString(ts.truncateSuffix)
+					truncated := truncateMessage(msgBuf.String(), ts.maxEventSize, ts.truncateSuffix)
+					msgBuf.Reset()
+					msgBuf.WriteString(truncated)
+					ts.recordTruncation()
+					// the rest of the multiline event is dropped
+					ignoreUntilNextEvent = true
 				}
 				fo.SetOffset(line.Offset)
 				continue
@@ -270,6 +339,7 @@ func (ts *tailerSrc) runTail() {
 				msgBuf.Reset()
 				cnt = 0
 			}
+			ts.publishLevelCounts(fo, false)
 		case <-ts.done:
 			return
 		}
@@ -282,46 +352,108 @@ func (ts *tailerSrc) publishEvent(msgBuf bytes.Buffer, fo *fileOffset) {
 		return
 	}
 	msg := msgBuf.String()
+	if ts.maxEventSize > 0 && len(msg) > ts.maxEventSize {
+		msg = truncateMessage(msg, ts.maxEventSize, ts.truncateSuffix)
+		ts.recordTruncation()
+	}
 	timestamp, modifiedMsg := ts.timestampFn(msg)
+	if ts.parsesTimestamp && timestamp.IsZero() {
+		ts.stats.RecordParseFailure()
+	}
+	if !ts.minTimestamp.IsZero() && !timestamp.IsZero() && timestamp.Before(ts.minTimestamp) {
+		ts.Done(*fo)
+		return
+	}
 	e := &LogEvent{
 		msg:    modifiedMsg,
 		t:      timestamp,
 		offset: *fo,
 		src:    ts,
 	}
-	if ShouldPublish(ts.group, ts.stream, ts.filters, e) {
-		if ts.backpressureFdDrop {
+	if !ShouldPublish(ts.group, ts.stream, ts.filters, e) {
+		return
+	}
+	if ts.levels != nil && !ts.levels.admit(e.msg, time.Now()) {
+		profiler.Profiler.AddStats([]string{"logfile", ts.group, ts.stream, "messages", "level_aggregated"}, 1)
+		ts.Done(*fo)
+		return
+	}
+	if ts.burst != nil {
+		keep, summary := ts.burst.admit(time.Now())
+		if summary != "" {
+			ts.send(&LogEvent{msg: summary, t: time.Now(), offset: *fo, src: ts})
+		}
+		if !keep {
+			profiler.Profiler.AddStats([]string{"logfile", ts.group, ts.stream, "messages", "burst_dropped"}, 1)
+			ts.stats.RecordDropped()
+			ts.Done(*fo)
+			return
+		}
+	}
+	if ts.sensitive != nil && !ts.scanSensitive(e) {
+		ts.Done(*fo)
+		return
+	}
+	// the event is rewritten once it is redacted, and before the log stream is named after its flattened fields
+	if ts.insights != nil {
+		e.msg = ts.insights.format(e.msg)
+	}
+	// the log stream is named after the event once it is redacted, so that it never holds sensitive data
+	if ts.streams != nil {
+		e.stream = ts.streams.stream(e.msg)
+	}
+	ts.send(e)
+}
+
+// publishLevelCounts sends the counts of the aggregated levels once they are due, or right away when force is set.
+func (ts *tailerSrc) publishLevelCounts(fo *fileOffset, force bool) {
+	if ts.levels == nil {
+		return
+	}
+	now := time.Now()
+	if summary := ts.levels.flush(now, force); summary != "" {
+		ts.send(&LogEvent{msg: summary, t: now, offset: *fo, src: ts})
+	}
+}
+
+func (ts *tailerSrc) send(e *LogEvent) {
+	profiler.Usage.AddEmitted(ts.usageKey, 1, len(e.msg))
+	profiler.Usage.AddInFlight(ts.usageKey, len(e.msg))
+	ts.stats.RecordEvent(time.Now(), e.t)
+	defer func(start time.Time) {
+		ts.outputWait += time.Since(start)
+	}(time.Now())
+	if !ts.backpressureFdDrop {
+		ts.outputFn(e)
+		return
+	}
+	select {
+	case ts.buffer <- e:
+		// successfully sent
+	case <-ts.done:
+		return
+	default:
+		// sender buffer is full. start timer to close file then retry
+		timer := time.NewTimer(tailCloseThreshold)
+		defer timer.Stop()
+
+		for {
 			select {
 			case ts.buffer <- e:
-				// successfully sent
-			case <-ts.done:
-				return
-			default:
-				// sender buffer is full. start timer to close file then retry
-				timer := time.NewTimer(tailCloseThreshold)
-				defer timer.Stop()
-
-				for {
-					select {
-					case ts.buffer <- e:
-						// sent event after buffer gets freed up
-						if ts.tailer.IsFileClosed() { // skip file closing if not already closed
-							if err := ts.tailer.Reopen(false); err != nil {
-								log.Printf("E! [logfile] error reopening file %s: %v", ts.tailer.Filename, err)
-							}
-						}
-						return
-					case <-timer.C:
-						// timer expired without successful send, close file
-						log.Printf("D! [logfile] tailer sender buffer blocked after retrying, closing file %v", ts.tailer.Filename)
-						ts.tailer.CloseFile()
-					case <-ts.done:
-						return
+				// sent event after buffer gets freed up
+				if ts.tailer.IsFileClosed() { // skip file closing if not already closed
+					if err := ts.tailer.Reopen(false); err != nil {
+						log.Printf("E! [logfile] error reopening file %s: %v", ts.tailer.Filename, err)
 					}
 				}
+				return
+			case <-timer.C:
+				// timer expired without successful send, close file
+				log.Printf("D! [logfile] tailer sender buffer blocked after retrying, closing file %v", ts.tailer.Filename)
+				ts.tailer.CloseFile()
+			case <-ts.done:
+				return
 			}
-		} else {
-			ts.outputFn(e)
 		}
 	}
 }
@@ -415,3 +547,23 @@ func (ts *tailerSrc) saveState(offset int64) error {
 	content := []byte(strconv.FormatInt(offset, 10) + "\n" + ts.tailer.Filename)
 	return os.WriteFile(ts.stateFilePath, content, stateFileMode)
 }
+
+func (ts *tailerSrc) recordTruncation() {
+	profiler.Profiler.AddStats([]string{"logfile", ts.group, ts.stream, "messages", "truncated"}, 1)
+}
+
+// truncateMessage cuts msg so that, including the suffix, it fits in maxSize bytes. The cut is moved back
+// to a rune boundary so the truncated event is still valid UTF-8.
+func truncateMessage(msg string, maxSize int, suffix string) string {
+	if len(msg) <= maxSize {
+		return msg
+	}
+	cut := maxSize - len(suffix)
+	if cut < 0 {
+		cut = 0
+	}
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+	return msg[:cut] + suffix
+}
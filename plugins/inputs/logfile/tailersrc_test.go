@@ -15,11 +15,14 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/control"
 	"github.com/aws/amazon-cloudwatch-agent/internal/logscommon"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/tail"
 	"github.com/aws/amazon-cloudwatch-agent/profiler"
@@ -297,6 +300,85 @@ func TestTailerSrcFiltersSingleLineLogs(t *testing.T) {
 	assertExpectedLogsPublished(t, n, int(*resources.consumed))
 }
 
+func TestTailerSrcUsage(t *testing.T) {
+	original := multilineWaitPeriod
+	defer resetState(original)
+	resources := setupTailer(t, nil, defaultMaxEventSize, false, "")
+	defer teardown(resources)
+
+	matchedLog := "ERROR: this has an error in it."
+	publishLogsToFile(resources.file, matchedLog, "Some other log message", 10, 0)
+	require.NoError(t, os.Remove(resources.file.Name()))
+	<-*resources.done
+
+	key := profiler.SourceKey(profiler.SourceLogFile, resources.file.Name())
+	var got *profiler.SourceUsage
+	for _, row := range profiler.Usage.Report("cpu", 0) {
+		if row.Source == key {
+			got = &row
+		}
+	}
+	require.NotNil(t, got)
+	assert.EqualValues(t, *resources.consumed, got.Records)
+	assert.Greater(t, got.EgressBytes, int64(got.Records)*int64(len(matchedLog)-1))
+	assert.Positive(t, got.Busy)
+	assert.Zero(t, got.InFlightBytes)
+}
+
+func TestTailerSrcPause(t *testing.T) {
+	original := multilineWaitPeriod
+	defer resetState(original)
+	resources := setupTailer(t, nil, defaultMaxEventSize, false, "")
+	defer teardown(resources)
+
+	key := profiler.SourceKey(profiler.SourceLogFile, resources.file.Name())
+	require.NoError(t, control.Pause(key))
+	defer control.Resume(key)
+	publishLogsToFile(resources.file, "ERROR: this has an error in it.", "Some other log message", 10, 0)
+	time.Sleep(time.Second)
+	assert.EqualValues(t, 0, atomic.LoadInt32(resources.consumed))
+	assert.Contains(t, control.Sources(), control.Source{Name: key, Paused: true})
+
+	assert.True(t, control.Resume(key))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(resources.consumed) == 5 }, 5*time.Second, 100*time.Millisecond)
+	require.NoError(t, os.Remove(resources.file.Name()))
+	<-*resources.done
+}
+
+func TestTailerSrcLogSourceStats(t *testing.T) {
+	original := multilineWaitPeriod
+	defer resetState(original)
+	resources := setupTailer(t, nil, defaultMaxEventSize, false, "")
+	defer teardown(resources)
+
+	publishLogsToFile(resources.file, "ERROR: this has an error in it.", "Some other log message", 4, 0)
+	info, err := resources.file.Stat()
+	require.NoError(t, err)
+	key := profiler.SourceKey(profiler.SourceLogFile, resources.file.Name())
+	var got selftelemetry.LogSourceStatus
+	require.Eventually(t, func() bool {
+		for _, status := range selftelemetry.LogSources.Statuses() {
+			if status.Name == key {
+				got = status
+				return got.BytesRead == info.Size()
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, t.Name(), got.LogGroup)
+	assert.Zero(t, got.LagBytes)
+	assert.Zero(t, got.LagSeconds)
+	require.NotNil(t, got.LastEvent)
+	assert.GreaterOrEqual(t, got.LastEventAgeSeconds, 0.0)
+	assert.Zero(t, got.EventsDropped)
+
+	require.NoError(t, os.Remove(resources.file.Name()))
+	<-*resources.done
+	for _, status := range selftelemetry.LogSources.Statuses() {
+		assert.NotEqual(t, key, status.Name)
+	}
+}
+
 func TestTailerSrcFiltersMultiLineLogs(t *testing.T) {
 	original := multilineWaitPeriod
 	defer resetState(original)
@@ -556,3 +638,24 @@ func TestTailerSrcCloseFileDescriptorOnBufferBlock(t *testing.T) {
 	finalCount := tail.OpenFileCount.Load()
 	assert.LessOrEqual(t, finalCount, initialCount, "File count should not increase")
 }
+
+func TestTruncateMessage(t *testing.T) {
+	testCases := map[string]struct {
+		msg     string
+		maxSize int
+		want    string
+	}{
+		"WithinLimit":   {msg: "short", maxSize: 10, want: "short"},
+		"ExactLimit":    {msg: "0123456789", maxSize: 10, want: "0123456789"},
+		"ASCII":         {msg: "0123456789abcdef", maxSize: 10, want: "0123456[T]"},
+		"RuneBoundary":  {msg: "aaaaaaééé", maxSize: 10, want: "aaaaaa[T]"},
+		"SuffixTooLong": {msg: "0123456789", maxSize: 2, want: "[T]"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := truncateMessage(testCase.msg, testCase.maxSize, "[T]")
+			assert.Equal(t, testCase.want, got)
+			assert.True(t, utf8.ValidString(got))
+		})
+	}
+}
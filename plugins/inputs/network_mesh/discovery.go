@@ -0,0 +1,110 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+)
+
+// peer is an instance of the mesh. The ID and availability zone are only known for the peers discovered with EC2.
+type peer struct {
+	id               string
+	address          string
+	availabilityZone string
+}
+
+func (p peer) tags(localZone string) map[string]string {
+	tags := map[string]string{
+		peerTag:        p.id,
+		peerAddressTag: p.address,
+	}
+	if p.id == "" {
+		tags[peerTag] = p.address
+	}
+	if p.availabilityZone != "" {
+		tags[peerAvailabilityZoneTag] = p.availabilityZone
+	}
+	if localZone != "" {
+		tags[availabilityZoneTag] = localZone
+	}
+	return tags
+}
+
+type discoverer interface {
+	discover() ([]peer, error)
+}
+
+// ec2Discoverer finds the running instances with a tag, by their private IP address.
+type ec2Discoverer struct {
+	client  ec2iface.EC2API
+	filters []*ec2.Filter
+}
+
+func newEC2Discoverer(client ec2iface.EC2API, key, value string) *ec2Discoverer {
+	filters := []*ec2.Filter{
+		{Name: aws.String("instance-state-name"), Values: aws.StringSlice([]string{ec2.InstanceStateNameRunning})},
+	}
+	if value == "" {
+		filters = append(filters, &ec2.Filter{Name: aws.String("tag-key"), Values: aws.StringSlice([]string{key})})
+	} else {
+		filters = append(filters, &ec2.Filter{Name: aws.String("tag:" + key), Values: aws.StringSlice([]string{value})})
+	}
+	return &ec2Discoverer{client: client, filters: filters}
+}
+
+func (d *ec2Discoverer) discover() ([]peer, error) {
+	var peers []peer
+	err := d.client.DescribeInstancesPages(&ec2.DescribeInstancesInput{Filters: d.filters},
+		func(page *ec2.DescribeInstancesOutput, _ bool) bool {
+			for _, reservation := range page.Reservations {
+				for _, instance := range reservation.Instances {
+					if aws.StringValue(instance.PrivateIpAddress) == "" {
+						continue
+					}
+					p := peer{
+						id:      aws.StringValue(instance.InstanceId),
+						address: aws.StringValue(instance.PrivateIpAddress),
+					}
+					if instance.Placement != nil {
+						p.availabilityZone = aws.StringValue(instance.Placement.AvailabilityZone)
+					}
+					peers = append(peers, p)
+				}
+			}
+			return true
+		})
+	return peers, err
+}
+
+// ssmDiscoverer reads the addresses of the peers from an SSM parameter, separated by commas or whitespace, for the
+// fleets that are not on EC2 or whose instances cannot call DescribeInstances.
+type ssmDiscoverer struct {
+	client ssmiface.SSMAPI
+	name   string
+}
+
+func (d *ssmDiscoverer) discover() ([]peer, error) {
+	output, err := d.client.GetParameter(&ssm.GetParameterInput{Name: aws.String(d.name)})
+	if err != nil {
+		return nil, err
+	}
+	var peers []peer
+	if output.Parameter == nil {
+		return peers, nil
+	}
+	for _, address := range strings.FieldsFunc(aws.StringValue(output.Parameter.Value), isSeparator) {
+		peers = append(peers, peer{address: address})
+	}
+	return peers, nil
+}
+
+func isSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
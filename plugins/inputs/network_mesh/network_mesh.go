@@ -0,0 +1,257 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+)
+
+const (
+	measurement = "network_mesh"
+
+	MethodTCP  = "tcp"
+	MethodICMP = "icmp"
+
+	peerTag                 = "peer"
+	peerAddressTag          = "peer_address"
+	peerAvailabilityZoneTag = "peer_availability_zone"
+	availabilityZoneTag     = "availability_zone"
+
+	defaultPort              = 22
+	defaultCount             = 5
+	defaultTimeout           = time.Second
+	defaultDiscoveryInterval = 5 * time.Minute
+	defaultMaxConcurrency    = 16
+)
+
+// NetworkMesh measures the latency and packet loss to the other instances of a fleet, which it discovers with an EC2
+// tag or an SSM parameter. When every instance runs it, the metrics cover each pair of instances, so that a network
+// issue can be narrowed down to an availability zone, a placement group or a single instance.
+type NetworkMesh struct {
+	PeerTagKey        string          `toml:"peer_tag_key"`
+	PeerTagValue      string          `toml:"peer_tag_value"`
+	PeerParameter     string          `toml:"peer_parameter"`
+	Method            string          `toml:"method"`
+	Port              int             `toml:"port"`
+	Count             int             `toml:"count"`
+	Timeout           config.Duration `toml:"timeout"`
+	DiscoveryInterval config.Duration `toml:"discovery_interval"`
+	MaxConcurrency    int             `toml:"max_concurrency"`
+	Region            string          `toml:"region"`
+	RoleARN           string          `toml:"role_arn"`
+	Profile           string          `toml:"profile"`
+	Filename          string          `toml:"shared_credential_file"`
+	Log               telegraf.Logger `toml:"-"`
+
+	discoverer discoverer
+	prober     prober
+	localAddrs map[string]struct{}
+
+	peers        []peer
+	localZone    string
+	discoveredAt time.Time
+	now          func() time.Time
+}
+
+func (n *NetworkMesh) Description() string {
+	return "Measure the latency and packet loss to the peer instances discovered with an EC2 tag or an SSM parameter"
+}
+
+func (n *NetworkMesh) SampleConfig() string {
+	return `
+  ## The peers are the running instances with the tag, or the addresses of the SSM parameter, separated by commas or
+  ## whitespace. Only one of them can be set.
+  peer_tag_key = "MeshGroup"
+  peer_tag_value = "checkout"
+  # peer_parameter = "/network-mesh/checkout"
+  ## How the peers are probed, "tcp" connects to the port and "icmp" sends echo requests.
+  method = "tcp"
+  port = 22
+  ## Probes sent to each peer on every interval, and how long to wait for each of them.
+  count = 5
+  timeout = "1s"
+  ## How often the peers are discovered again.
+  discovery_interval = "5m"
+  ## Number of peers probed at the same time.
+  max_concurrency = 16
+  region = "us-east-1"
+`
+}
+
+func (n *NetworkMesh) Init() error {
+	if (n.PeerTagKey == "") == (n.PeerParameter == "") {
+		return errors.New("exactly one of peer_tag_key and peer_parameter must be set")
+	}
+	switch n.Method {
+	case "", MethodTCP:
+		if n.Port == 0 {
+			n.Port = defaultPort
+		}
+		if n.Port < 0 || n.Port > 65535 {
+			return fmt.Errorf("invalid port %d", n.Port)
+		}
+		if n.prober == nil {
+			n.prober = &tcpProber{port: n.Port}
+		}
+	case MethodICMP:
+		if n.prober == nil {
+			n.prober = &icmpProber{}
+		}
+	default:
+		return fmt.Errorf("invalid method %q, must be %q or %q", n.Method, MethodTCP, MethodICMP)
+	}
+	if n.Count <= 0 {
+		n.Count = defaultCount
+	}
+	if n.Timeout <= 0 {
+		n.Timeout = config.Duration(defaultTimeout)
+	}
+	if n.DiscoveryInterval <= 0 {
+		n.DiscoveryInterval = config.Duration(defaultDiscoveryInterval)
+	}
+	if n.MaxConcurrency <= 0 {
+		n.MaxConcurrency = defaultMaxConcurrency
+	}
+	if n.now == nil {
+		n.now = time.Now
+	}
+	if n.localAddrs == nil {
+		addrs, err := interfaceAddrs()
+		if err != nil {
+			return fmt.Errorf("unable to list the addresses of the host: %w", err)
+		}
+		n.localAddrs = addrs
+	}
+	if n.discoverer == nil {
+		n.discoverer = n.newDiscoverer()
+	}
+	return nil
+}
+
+func (n *NetworkMesh) newDiscoverer() discoverer {
+	credentialConfig := &configaws.CredentialConfig{
+		Region:   n.Region,
+		RoleARN:  n.RoleARN,
+		Profile:  n.Profile,
+		Filename: n.Filename,
+	}
+	awsConfig := &aws.Config{
+		LogLevel: configaws.SDKLogLevel(),
+		Logger:   configaws.SDKLogger{},
+	}
+	if n.PeerParameter != "" {
+		return &ssmDiscoverer{client: ssm.New(credentialConfig.Credentials(), awsConfig), name: n.PeerParameter}
+	}
+	return newEC2Discoverer(ec2.New(credentialConfig.Credentials(), awsConfig), n.PeerTagKey, n.PeerTagValue)
+}
+
+func (n *NetworkMesh) Gather(acc telegraf.Accumulator) error {
+	peers, localZone := n.discover(acc)
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, n.MaxConcurrency)
+	for _, p := range peers {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(p peer) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			rtts, err := n.prober.probe(p.address, n.Count, time.Duration(n.Timeout))
+			if err != nil {
+				acc.AddError(fmt.Errorf("network_mesh %s: %w", p.address, err))
+				return
+			}
+			acc.AddFields(measurement, probeFields(n.Count, rtts), p.tags(localZone))
+		}(p)
+	}
+	wg.Wait()
+	return nil
+}
+
+// discover returns the peers other than this host, which are discovered again once the discovery interval elapsed.
+// The peers found previously are kept when the discovery fails, so that a throttled API call does not interrupt the
+// metrics.
+func (n *NetworkMesh) discover(acc telegraf.Accumulator) ([]peer, string) {
+	now := n.now()
+	if !n.discoveredAt.IsZero() && now.Sub(n.discoveredAt) < time.Duration(n.DiscoveryInterval) {
+		return n.peers, n.localZone
+	}
+	found, err := n.discoverer.discover()
+	if err != nil {
+		acc.AddError(fmt.Errorf("network_mesh: unable to discover the peers: %w", err))
+		return n.peers, n.localZone
+	}
+	n.discoveredAt = now
+	n.peers = n.peers[:0]
+	for _, p := range found {
+		if _, ok := n.localAddrs[p.address]; ok {
+			n.localZone = p.availabilityZone
+			continue
+		}
+		n.peers = append(n.peers, p)
+	}
+	return n.peers, n.localZone
+}
+
+// probeFields has the same fields as the ping input of Telegraf. The response times are only set when at least one
+// probe was answered.
+func probeFields(sent int, rtts []time.Duration) map[string]interface{} {
+	fields := map[string]interface{}{
+		"packets_transmitted": sent,
+		"packets_received":    len(rtts),
+		"percent_packet_loss": float64(sent-len(rtts)) / float64(sent) * 100,
+	}
+	if len(rtts) == 0 {
+		return fields
+	}
+	minRTT, maxRTT, sum := rtts[0], rtts[0], time.Duration(0)
+	for _, rtt := range rtts {
+		minRTT = min(minRTT, rtt)
+		maxRTT = max(maxRTT, rtt)
+		sum += rtt
+	}
+	fields["minimum_response_ms"] = milliseconds(minRTT)
+	fields["average_response_ms"] = milliseconds(sum / time.Duration(len(rtts)))
+	fields["maximum_response_ms"] = milliseconds(maxRTT)
+	return fields
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// interfaceAddrs returns the IP addresses of the host, which are excluded from the peers.
+func interfaceAddrs() (map[string]struct{}, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			result[ipNet.IP.String()] = struct{}{}
+		}
+	}
+	return result, nil
+}
+
+func init() {
+	inputs.Add("network_mesh", func() telegraf.Input {
+		return &NetworkMesh{}
+	})
+}
@@ -0,0 +1,286 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDiscoverer struct {
+	peers []peer
+	err   error
+	calls int
+}
+
+func (m *mockDiscoverer) discover() ([]peer, error) {
+	m.calls++
+	return m.peers, m.err
+}
+
+type mockProber struct {
+	rtts map[string][]time.Duration
+}
+
+func (m *mockProber) probe(address string, count int, _ time.Duration) ([]time.Duration, error) {
+	rtts, ok := m.rtts[address]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return rtts[:min(count, len(rtts))], nil
+}
+
+type mockEC2 struct {
+	ec2iface.EC2API
+	input *ec2.DescribeInstancesInput
+	pages []*ec2.DescribeInstancesOutput
+}
+
+func (m *mockEC2) DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	m.input = input
+	for i, page := range m.pages {
+		if !fn(page, i == len(m.pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+type mockSSM struct {
+	ssmiface.SSMAPI
+	value string
+}
+
+func (m *mockSSM) GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+	return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Name: input.Name, Value: aws.String(m.value)}}, nil
+}
+
+func TestGather(t *testing.T) {
+	d := &mockDiscoverer{peers: []peer{
+		{id: "i-self", address: "10.0.1.10", availabilityZone: "us-east-1a"},
+		{id: "i-same-zone", address: "10.0.1.11", availabilityZone: "us-east-1a"},
+		{id: "i-other-zone", address: "10.0.2.12", availabilityZone: "us-east-1b"},
+		{id: "i-down", address: "10.0.2.13", availabilityZone: "us-east-1b"},
+		{id: "i-unknown", address: "10.0.2.14", availabilityZone: "us-east-1b"},
+	}}
+	n := &NetworkMesh{
+		PeerTagKey: "MeshGroup",
+		Count:      4,
+		discoverer: d,
+		prober: &mockProber{rtts: map[string][]time.Duration{
+			"10.0.1.11": {time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond, 2 * time.Millisecond},
+			"10.0.2.12": {2 * time.Millisecond, 4 * time.Millisecond},
+			"10.0.2.13": nil,
+		}},
+		localAddrs: map[string]struct{}{"127.0.0.1": {}, "10.0.1.10": {}},
+	}
+	require.NoError(t, n.Init())
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, n.Gather(acc))
+
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"packets_transmitted": 4,
+		"packets_received":    4,
+		"percent_packet_loss": float64(0),
+		"minimum_response_ms": float64(1),
+		"average_response_ms": float64(2),
+		"maximum_response_ms": float64(3),
+	}, map[string]string{
+		peerTag:                 "i-same-zone",
+		peerAddressTag:          "10.0.1.11",
+		peerAvailabilityZoneTag: "us-east-1a",
+		availabilityZoneTag:     "us-east-1a",
+	})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"packets_transmitted": 4,
+		"packets_received":    2,
+		"percent_packet_loss": float64(50),
+		"minimum_response_ms": float64(2),
+		"average_response_ms": float64(3),
+		"maximum_response_ms": float64(4),
+	}, map[string]string{
+		peerTag:                 "i-other-zone",
+		peerAddressTag:          "10.0.2.12",
+		peerAvailabilityZoneTag: "us-east-1b",
+		availabilityZoneTag:     "us-east-1a",
+	})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"packets_transmitted": 4,
+		"packets_received":    0,
+		"percent_packet_loss": float64(100),
+	}, map[string]string{
+		peerTag:                 "i-down",
+		peerAddressTag:          "10.0.2.13",
+		peerAvailabilityZoneTag: "us-east-1b",
+		availabilityZoneTag:     "us-east-1a",
+	})
+	assert.Len(t, acc.Metrics, 3)
+	require.Len(t, acc.Errors, 1)
+	assert.ErrorContains(t, acc.Errors[0], "network_mesh 10.0.2.14: no such host")
+}
+
+func TestGatherRediscovers(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	d := &mockDiscoverer{peers: []peer{{address: "10.0.1.11"}}}
+	n := &NetworkMesh{
+		PeerParameter:     "/mesh/peers",
+		DiscoveryInterval: config.Duration(5 * time.Minute),
+		discoverer:        d,
+		prober:            &mockProber{rtts: map[string][]time.Duration{"10.0.1.11": {time.Millisecond}, "10.0.1.12": {time.Millisecond}}},
+		localAddrs:        map[string]struct{}{},
+		now:               func() time.Time { return now },
+	}
+	require.NoError(t, n.Init())
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, n.Gather(acc))
+	assert.Equal(t, "10.0.1.11", acc.Metrics[0].Tags[peerTag])
+
+	// The peers are not discovered again before the interval elapsed.
+	d.peers = []peer{{address: "10.0.1.12"}}
+	now = now.Add(time.Minute)
+	acc.ClearMetrics()
+	require.NoError(t, n.Gather(acc))
+	assert.Equal(t, 1, d.calls)
+	assert.Equal(t, "10.0.1.11", acc.Metrics[0].Tags[peerTag])
+
+	now = now.Add(5 * time.Minute)
+	acc.ClearMetrics()
+	require.NoError(t, n.Gather(acc))
+	assert.Equal(t, 2, d.calls)
+	assert.Equal(t, "10.0.1.12", acc.Metrics[0].Tags[peerTag])
+
+	// The peers found previously are kept when the discovery fails.
+	d.err = errors.New("throttled")
+	now = now.Add(5 * time.Minute)
+	acc.ClearMetrics()
+	require.NoError(t, n.Gather(acc))
+	assert.Equal(t, 3, d.calls)
+	require.Len(t, acc.Metrics, 1)
+	assert.Equal(t, "10.0.1.12", acc.Metrics[0].Tags[peerTag])
+	require.Len(t, acc.Errors, 1)
+	assert.ErrorContains(t, acc.Errors[0], "unable to discover the peers: throttled")
+}
+
+func TestInit(t *testing.T) {
+	testCases := map[string]struct {
+		mesh    NetworkMesh
+		wantErr string
+	}{
+		"WithoutDiscovery": {
+			mesh:    NetworkMesh{},
+			wantErr: "exactly one of peer_tag_key and peer_parameter must be set",
+		},
+		"WithBothDiscoveries": {
+			mesh:    NetworkMesh{PeerTagKey: "MeshGroup", PeerParameter: "/mesh/peers"},
+			wantErr: "exactly one of peer_tag_key and peer_parameter must be set",
+		},
+		"WithInvalidMethod": {
+			mesh:    NetworkMesh{PeerTagKey: "MeshGroup", Method: "udp"},
+			wantErr: `invalid method "udp", must be "tcp" or "icmp"`,
+		},
+		"WithInvalidPort": {
+			mesh:    NetworkMesh{PeerTagKey: "MeshGroup", Port: 70000},
+			wantErr: "invalid port 70000",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testCase.mesh.discoverer = &mockDiscoverer{}
+			assert.EqualError(t, testCase.mesh.Init(), testCase.wantErr)
+		})
+	}
+
+	n := &NetworkMesh{PeerTagKey: "MeshGroup", discoverer: &mockDiscoverer{}}
+	require.NoError(t, n.Init())
+	assert.Equal(t, &tcpProber{port: defaultPort}, n.prober)
+	assert.Equal(t, defaultCount, n.Count)
+	assert.Equal(t, config.Duration(defaultTimeout), n.Timeout)
+	assert.Equal(t, config.Duration(defaultDiscoveryInterval), n.DiscoveryInterval)
+	assert.Equal(t, defaultMaxConcurrency, n.MaxConcurrency)
+	assert.Contains(t, n.localAddrs, "127.0.0.1")
+}
+
+func TestEC2Discoverer(t *testing.T) {
+	client := &mockEC2{pages: []*ec2.DescribeInstancesOutput{
+		{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+			{InstanceId: aws.String("i-1"), PrivateIpAddress: aws.String("10.0.1.11"), Placement: &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")}},
+			{InstanceId: aws.String("i-2")},
+		}}}},
+		{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+			{InstanceId: aws.String("i-3"), PrivateIpAddress: aws.String("10.0.2.12")},
+		}}}},
+	}}
+	peers, err := newEC2Discoverer(client, "MeshGroup", "checkout").discover()
+	require.NoError(t, err)
+	assert.Equal(t, []peer{
+		{id: "i-1", address: "10.0.1.11", availabilityZone: "us-east-1a"},
+		{id: "i-3", address: "10.0.2.12"},
+	}, peers)
+	assert.Equal(t, []*ec2.Filter{
+		{Name: aws.String("instance-state-name"), Values: aws.StringSlice([]string{"running"})},
+		{Name: aws.String("tag:MeshGroup"), Values: aws.StringSlice([]string{"checkout"})},
+	}, client.input.Filters)
+
+	_, err = newEC2Discoverer(client, "MeshGroup", "").discover()
+	require.NoError(t, err)
+	assert.Equal(t, &ec2.Filter{Name: aws.String("tag-key"), Values: aws.StringSlice([]string{"MeshGroup"})}, client.input.Filters[1])
+}
+
+func TestSSMDiscoverer(t *testing.T) {
+	d := &ssmDiscoverer{client: &mockSSM{value: "10.0.1.11,10.0.2.12\n db.internal  "}, name: "/mesh/peers"}
+	peers, err := d.discover()
+	require.NoError(t, err)
+	assert.Equal(t, []peer{{address: "10.0.1.11"}, {address: "10.0.2.12"}, {address: "db.internal"}}, peers)
+	assert.Equal(t, map[string]string{peerTag: "db.internal", peerAddressTag: "db.internal"}, peers[2].tags(""))
+}
+
+func TestTCPProber(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	rtts, err := (&tcpProber{port: port}).probe("127.0.0.1", 3, time.Second)
+	require.NoError(t, err)
+	assert.Len(t, rtts, 3)
+
+	// A refused connection is answered by the peer.
+	require.NoError(t, listener.Close())
+	rtts, err = (&tcpProber{port: port}).probe("127.0.0.1", 2, time.Second)
+	require.NoError(t, err)
+	assert.Len(t, rtts, 2)
+}
+
+func TestICMPProber(t *testing.T) {
+	conn, _, err := listenICMP()
+	if err != nil {
+		t.Skipf("ICMP sockets are not allowed: %v", err)
+	}
+	conn.Close()
+	rtts, err := (&icmpProber{}).probe("127.0.0.1", 2, time.Second)
+	require.NoError(t, err)
+	assert.Len(t, rtts, 2)
+}
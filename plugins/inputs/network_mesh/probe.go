@@ -0,0 +1,143 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// protocolICMP is the IANA number of ICMP for IPv4, used to parse the replies.
+const protocolICMP = 1
+
+// prober sends count probes to the address and returns the round trip times of the probes that were answered. An
+// error means that the peer could not be probed at all, not that the probes were lost.
+type prober interface {
+	probe(address string, count int, timeout time.Duration) ([]time.Duration, error)
+}
+
+// tcpProber measures the time to connect to a port. A refused connection is answered by the peer as quickly as an
+// accepted one, so the port does not need to be open, only allowed by the security groups and firewalls.
+type tcpProber struct {
+	port int
+}
+
+func (p *tcpProber) probe(address string, count int, timeout time.Duration) ([]time.Duration, error) {
+	ip, err := net.ResolveIPAddr("ip", address)
+	if err != nil {
+		return nil, err
+	}
+	target := net.JoinHostPort(ip.String(), strconv.Itoa(p.port))
+	var rtts []time.Duration
+	for i := 0; i < count; i++ {
+		start := time.Now()
+		conn, err := net.DialTimeout("tcp", target, timeout)
+		rtt := time.Since(start)
+		if err == nil {
+			conn.Close()
+		} else if !isRefused(err) {
+			continue
+		}
+		rtts = append(rtts, rtt)
+	}
+	return rtts, nil
+}
+
+// icmpProber sends ICMP echo requests. It uses the unprivileged ICMP sockets when they are allowed, e.g. by the
+// net.ipv4.ping_group_range sysctl on Linux, and raw sockets otherwise, which require root or Administrator.
+type icmpProber struct{}
+
+func (p *icmpProber) probe(address string, count int, timeout time.Duration) ([]time.Duration, error) {
+	ip, err := net.ResolveIPAddr("ip4", address)
+	if err != nil {
+		return nil, err
+	}
+	conn, privileged, err := listenICMP()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	var dst net.Addr = ip
+	if !privileged {
+		dst = &net.UDPAddr{IP: ip.IP}
+	}
+	// The kernel replaces the ID of the echo requests of the unprivileged sockets on Linux, and the raw sockets
+	// receive the replies to every process, so the replies are matched on a token in their data.
+	id := rand.Intn(1 << 16) // nolint:gosec
+	token := make([]byte, 8)
+	binary.BigEndian.PutUint64(token, rand.Uint64()) // nolint:gosec
+	buf := make([]byte, 1500)
+	var rtts []time.Duration
+	for seq := 0; seq < count; seq++ {
+		request, err := (&icmp.Message{
+			Type: ipv4.ICMPTypeEcho,
+			Body: &icmp.Echo{ID: id, Seq: seq, Data: token},
+		}).Marshal(nil)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		if _, err = conn.WriteTo(request, dst); err != nil {
+			continue
+		}
+		if err = conn.SetReadDeadline(start.Add(timeout)); err != nil {
+			return nil, err
+		}
+		for {
+			n, from, err := conn.ReadFrom(buf)
+			if err != nil {
+				break
+			}
+			if isEchoReply(buf[:n], seq, token) && sameIP(from, ip.IP) {
+				rtts = append(rtts, time.Since(start))
+				break
+			}
+		}
+	}
+	return rtts, nil
+}
+
+func listenICMP() (*icmp.PacketConn, bool, error) {
+	if conn, err := icmp.ListenPacket("udp4", "0.0.0.0"); err == nil {
+		return conn, false, nil
+	}
+	conn, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to open an ICMP socket: %w", err)
+	}
+	return conn, true, nil
+}
+
+func isEchoReply(b []byte, seq int, token []byte) bool {
+	msg, err := icmp.ParseMessage(protocolICMP, b)
+	if err != nil || msg.Type != ipv4.ICMPTypeEchoReply {
+		return false
+	}
+	echo, ok := msg.Body.(*icmp.Echo)
+	return ok && echo.Seq == seq && bytes.Equal(echo.Data, token)
+}
+
+func sameIP(addr net.Addr, ip net.IP) bool {
+	switch a := addr.(type) {
+	case *net.IPAddr:
+		return a.IP.Equal(ip)
+	case *net.UDPAddr:
+		return a.IP.Equal(ip)
+	}
+	return false
+}
+
+func isRefused(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && isConnRefused(opErr.Err)
+}
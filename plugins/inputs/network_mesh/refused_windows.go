@@ -0,0 +1,16 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package network_mesh
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows"
+)
+
+func isConnRefused(err error) bool {
+	return errors.Is(err, windows.WSAECONNREFUSED)
+}
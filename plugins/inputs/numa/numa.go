@@ -0,0 +1,172 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package numa
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+const (
+	measurement = "numa"
+	nodeTag     = "node"
+
+	fieldMemTotal       = "mem_total"
+	fieldMemFree        = "mem_free"
+	fieldMemUsed        = "mem_used"
+	fieldMemUsedPercent = "mem_used_percent"
+)
+
+// meminfoFields maps the lines of the meminfo of a node to the fields, in bytes.
+var meminfoFields = map[string]string{
+	"MemTotal":  fieldMemTotal,
+	"MemFree":   fieldMemFree,
+	"MemUsed":   fieldMemUsed,
+	"FilePages": "file_pages",
+	"AnonPages": "anon_pages",
+}
+
+// numastatFields maps the counters of the numastat of a node, in pages, to the fields. The numa_ prefix is dropped
+// since it is the measurement. numa_miss and other_node grow when the memory is allocated on another node than the
+// one the task runs on, which costs memory bandwidth and latency.
+var numastatFields = map[string]string{
+	"numa_hit":     "hit",
+	"numa_miss":    "miss",
+	"numa_foreign": "foreign",
+	"local_node":   "local_node",
+	"other_node":   "other_node",
+}
+
+// Numa reports the memory of each NUMA node, and how often the allocations missed the local node.
+type Numa struct {
+	Log telegraf.Logger `toml:"-"`
+
+	nodeDir string
+	// counters are the numastat counters of the previous collection by node and field, to report the pages since.
+	counters map[string]uint64
+}
+
+func (n *Numa) Description() string {
+	return "Report the memory and the allocation misses of each NUMA node"
+}
+
+func (n *Numa) SampleConfig() string {
+	return ""
+}
+
+func (n *Numa) Init() error {
+	if n.nodeDir == "" {
+		sysRoot := "/sys"
+		if hostSys := os.Getenv("HOST_SYS"); hostSys != "" {
+			sysRoot = hostSys
+		}
+		n.nodeDir = filepath.Join(sysRoot, "devices", "system", "node")
+	}
+	n.counters = map[string]uint64{}
+	return nil
+}
+
+func (n *Numa) Gather(acc telegraf.Accumulator) error {
+	nodes, err := filepath.Glob(filepath.Join(n.nodeDir, "node[0-9]*"))
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("no NUMA node in %s", n.nodeDir)
+	}
+	for _, dir := range nodes {
+		node := strings.TrimPrefix(filepath.Base(dir), "node")
+		fields := map[string]interface{}{}
+		if err = readMeminfo(filepath.Join(dir, "meminfo"), fields); err != nil {
+			acc.AddError(fmt.Errorf("unable to read the memory of NUMA node %s: %w", node, err))
+			continue
+		}
+		if err = n.readNumastat(node, filepath.Join(dir, "numastat"), fields); err != nil {
+			acc.AddError(fmt.Errorf("unable to read the numastat of NUMA node %s: %w", node, err))
+		}
+		acc.AddGauge(measurement, fields, map[string]string{nodeTag: node})
+	}
+	return nil
+}
+
+// readMeminfo parses the lines of the meminfo of a node, e.g. "Node 0 MemTotal: 16000000 kB".
+func readMeminfo(path string, fields map[string]interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) < 4 {
+			continue
+		}
+		field, ok := meminfoFields[strings.TrimSuffix(parts[2], ":")]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseUint(parts[3], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", parts[2], err)
+		}
+		if len(parts) > 4 && parts[4] == "kB" {
+			value *= 1024
+		}
+		fields[field] = value
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+	total, _ := fields[fieldMemTotal].(uint64)
+	used, ok := fields[fieldMemUsed].(uint64)
+	if total > 0 && ok {
+		fields[fieldMemUsedPercent] = 100 * float64(used) / float64(total)
+	}
+	return nil
+}
+
+// readNumastat parses the counters of the numastat of a node, e.g. "numa_miss 10". The counters are cumulative, so
+// they are reported as the pages since the previous collection.
+func (n *Numa) readNumastat(node, path string, fields map[string]interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	values := map[string]uint64{}
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		if values[key], err = strconv.ParseUint(strings.TrimSpace(value), 10, 64); err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	for key, field := range numastatFields {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		counterKey := node + "/" + field
+		if previous, ok := n.counters[counterKey]; ok && value >= previous {
+			fields[field] = value - previous
+		}
+		n.counters[counterKey] = value
+	}
+	return nil
+}
+
+func init() {
+	inputs.Add("numa", func() telegraf.Input {
+		return &Numa{}
+	})
+}
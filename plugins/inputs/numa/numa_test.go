@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package numa
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGather(t *testing.T) {
+	n := &Numa{Log: testutil.Logger{}, nodeDir: filepath.Join("testdata", "node")}
+	require.NoError(t, n.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, n.Gather(acc))
+	assert.Empty(t, acc.Errors)
+
+	// the numastat counters are only reported from the second collection
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldMemTotal:       uint64(16000000 * 1024),
+		fieldMemFree:        uint64(4000000 * 1024),
+		fieldMemUsed:        uint64(12000000 * 1024),
+		fieldMemUsedPercent: 75.0,
+		"file_pages":        uint64(3000000 * 1024),
+		"anon_pages":        uint64(5000000 * 1024),
+	}, map[string]string{nodeTag: "0"})
+	assert.Len(t, acc.Metrics, 2)
+
+	acc.ClearMetrics()
+	require.NoError(t, n.Gather(acc))
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldMemTotal:       uint64(16000000 * 1024),
+		fieldMemFree:        uint64(12000000 * 1024),
+		fieldMemUsed:        uint64(4000000 * 1024),
+		fieldMemUsedPercent: 25.0,
+		"file_pages":        uint64(1000000 * 1024),
+		"anon_pages":        uint64(2000000 * 1024),
+		"hit":               uint64(0),
+		"miss":              uint64(0),
+		"foreign":           uint64(0),
+		"local_node":        uint64(0),
+		"other_node":        uint64(0),
+	}, map[string]string{nodeTag: "1"})
+}
+
+func TestGatherNumastat(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "node0")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "meminfo"), []byte("Node 0 MemTotal: 100 kB\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "numastat"), []byte("numa_miss 10\nother_node 5\n"), 0600))
+	n := &Numa{Log: testutil.Logger{}, nodeDir: filepath.Dir(dir)}
+	require.NoError(t, n.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, n.Gather(acc))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "numastat"), []byte("numa_miss 25\nother_node 5\n"), 0600))
+	acc.ClearMetrics()
+	require.NoError(t, n.Gather(acc))
+	m, ok := acc.Get(measurement)
+	require.True(t, ok)
+	assert.Equal(t, uint64(15), m.Fields["miss"])
+	assert.Equal(t, uint64(0), m.Fields["other_node"])
+	// the used percent needs the used memory
+	assert.NotContains(t, m.Fields, fieldMemUsedPercent)
+}
+
+func TestGatherWithoutNodes(t *testing.T) {
+	n := &Numa{Log: testutil.Logger{}, nodeDir: t.TempDir()}
+	require.NoError(t, n.Init())
+	assert.ErrorContains(t, n.Gather(&testutil.Accumulator{}), "no NUMA node")
+}
@@ -0,0 +1,134 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+const (
+	measurement = "pressure"
+	resourceTag = "resource"
+
+	fieldAvg10     = "avg10"
+	fieldAvg60     = "avg60"
+	fieldAvg300    = "avg300"
+	fieldStallTime = "stall_time"
+)
+
+// resources are the files of /proc/pressure, one for each resource the tasks can stall on.
+var resources = []string{"cpu", "io", "memory"}
+
+// Pressure reports the Linux pressure stall information (PSI) of the CPU, IO and memory. The some_* metrics are the
+// share of time at least one task stalled on the resource, and the full_* ones the share of time all the non-idle
+// tasks stalled at once, which is the time lost to a noisy neighbor or to reclaim.
+type Pressure struct {
+	Log telegraf.Logger `toml:"-"`
+
+	procRoot string
+	// totals are the stall times of the previous collection by resource and line, to report the stall time since.
+	totals map[string]uint64
+}
+
+func (p *Pressure) Description() string {
+	return "Report the pressure stall information of the CPU, IO and memory"
+}
+
+func (p *Pressure) SampleConfig() string {
+	return ""
+}
+
+func (p *Pressure) Init() error {
+	if p.procRoot == "" {
+		p.procRoot = "/proc"
+		if hostProc := os.Getenv("HOST_PROC"); hostProc != "" {
+			p.procRoot = hostProc
+		}
+	}
+	p.totals = map[string]uint64{}
+	return nil
+}
+
+func (p *Pressure) Gather(acc telegraf.Accumulator) error {
+	var found bool
+	for _, resource := range resources {
+		fields, err := p.read(resource)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			acc.AddError(fmt.Errorf("unable to read the %s pressure: %w", resource, err))
+			continue
+		}
+		found = true
+		acc.AddGauge(measurement, fields, map[string]string{resourceTag: resource})
+	}
+	if !found {
+		return fmt.Errorf("no pressure stall information in %s, it requires Linux 4.20 or later with CONFIG_PSI", filepath.Join(p.procRoot, "pressure"))
+	}
+	return nil
+}
+
+// read parses the lines of a pressure file, e.g.
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//
+// into the some_* and full_* fields. The total is the cumulative stall time in microseconds, so it is reported as
+// the stall time since the previous collection.
+func (p *Pressure) read(resource string) (map[string]interface{}, error) {
+	f, err := os.Open(filepath.Join(p.procRoot, "pressure", resource))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fields := map[string]interface{}{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) == 0 {
+			continue
+		}
+		line := parts[0]
+		for _, part := range parts[1:] {
+			key, value, ok := strings.Cut(part, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case fieldAvg10, fieldAvg60, fieldAvg300:
+				avg, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s %s: %w", line, key, err)
+				}
+				fields[line+"_"+key] = avg
+			case "total":
+				total, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s total: %w", line, err)
+				}
+				totalKey := resource + "/" + line
+				if previous, ok := p.totals[totalKey]; ok && total >= previous {
+					fields[line+"_"+fieldStallTime] = total - previous
+				}
+				p.totals[totalKey] = total
+			}
+		}
+	}
+	return fields, scanner.Err()
+}
+
+func init() {
+	inputs.Add("pressure", func() telegraf.Input {
+		return &Pressure{}
+	})
+}
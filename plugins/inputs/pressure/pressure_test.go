@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGather(t *testing.T) {
+	p := &Pressure{Log: testutil.Logger{}, procRoot: "testdata"}
+	require.NoError(t, p.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, p.Gather(acc))
+	assert.Empty(t, acc.Errors)
+
+	// the stall time is only reported from the second collection
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"some_avg10":  10.0,
+		"some_avg60":  5.0,
+		"some_avg300": 2.0,
+		"full_avg10":  4.0,
+		"full_avg60":  2.0,
+		"full_avg300": 1.0,
+	}, map[string]string{resourceTag: "io"})
+	assert.Equal(t, 3, len(acc.Metrics))
+
+	acc.ClearMetrics()
+	require.NoError(t, p.Gather(acc))
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"some_avg10":      1.5,
+		"some_avg60":      0.75,
+		"some_avg300":     0.25,
+		"some_stall_time": uint64(0),
+		"full_avg10":      0.0,
+		"full_avg60":      0.0,
+		"full_avg300":     0.0,
+		"full_stall_time": uint64(0),
+	}, map[string]string{resourceTag: "cpu"})
+}
+
+func TestGatherStallTime(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "pressure"), 0755))
+	write := func(total int) {
+		content := fmt.Sprintf("some avg10=0.00 avg60=0.00 avg300=0.00 total=%d\n", total)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "pressure", "memory"), []byte(content), 0600))
+	}
+	p := &Pressure{Log: testutil.Logger{}, procRoot: dir}
+	require.NoError(t, p.Init())
+	write(1000)
+	acc := &testutil.Accumulator{}
+	require.NoError(t, p.Gather(acc))
+	write(4000)
+	acc.ClearMetrics()
+	require.NoError(t, p.Gather(acc))
+	stall, ok := acc.Get(measurement)
+	require.True(t, ok)
+	assert.Equal(t, uint64(3000), stall.Fields["some_stall_time"])
+	assert.Equal(t, "memory", stall.Tags[resourceTag])
+}
+
+func TestGatherWithoutPressure(t *testing.T) {
+	p := &Pressure{Log: testutil.Logger{}, procRoot: t.TempDir()}
+	require.NoError(t, p.Init())
+	assert.ErrorContains(t, p.Gather(&testutil.Accumulator{}), "no pressure stall information")
+}
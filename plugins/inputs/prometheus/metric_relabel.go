@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"fmt"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/model/relabel"
+)
+
+// supportedRelabelActions are the relabel actions the agent configuration can set. The other actions, e.g. hashmod and
+// labelmap, are only supported in the prometheus configuration file.
+var supportedRelabelActions = map[relabel.Action]bool{
+	relabel.Keep:    true,
+	relabel.Drop:    true,
+	relabel.Replace: true,
+}
+
+// MetricRelabelConfig is a metric_relabel_configs entry of the agent configuration. The fields have the same meaning
+// as in the prometheus configuration, but the translator always sets the regex, separator and replacement.
+type MetricRelabelConfig struct {
+	SourceLabels []string `toml:"source_labels"`
+	Separator    string   `toml:"separator"`
+	Regex        string   `toml:"regex"`
+	TargetLabel  string   `toml:"target_label"`
+	Replacement  string   `toml:"replacement"`
+	Action       string   `toml:"action"`
+}
+
+func newMetricRelabelConfigs(cfgs []*MetricRelabelConfig) ([]*relabel.Config, error) {
+	var result []*relabel.Config
+	for i, cfg := range cfgs {
+		action := relabel.Action(cfg.Action)
+		if !supportedRelabelActions[action] {
+			return nil, fmt.Errorf("metric_relabel_configs[%d] has an unsupported action %q", i, cfg.Action)
+		}
+		regex, err := relabel.NewRegexp(cfg.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("metric_relabel_configs[%d] has an invalid regex %q: %w", i, cfg.Regex, err)
+		}
+		rc := &relabel.Config{
+			Separator:   cfg.Separator,
+			Regex:       regex,
+			TargetLabel: cfg.TargetLabel,
+			Replacement: cfg.Replacement,
+			Action:      action,
+		}
+		for _, label := range cfg.SourceLabels {
+			rc.SourceLabels = append(rc.SourceLabels, model.LabelName(label))
+		}
+		if err = rc.Validate(); err != nil {
+			return nil, fmt.Errorf("metric_relabel_configs[%d] is invalid: %w", i, err)
+		}
+		result = append(result, rc)
+	}
+	return result, nil
+}
+
+// appendMetricRelabelConfigs adds the relabel configs of the agent configuration to every scrape config, after the
+// ones of the prometheus configuration file.
+func appendMetricRelabelConfigs(prometheusConfig *config.Config, metricRelabelConfigs []*relabel.Config) {
+	if len(metricRelabelConfigs) == 0 {
+		return
+	}
+	for _, sc := range prometheusConfig.ScrapeConfigs {
+		sc.MetricRelabelConfigs = append(sc.MetricRelabelConfigs, metricRelabelConfigs...)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMetricRelabelConfigs(t *testing.T) {
+	testCases := map[string]struct {
+		cfgs    []*MetricRelabelConfig
+		wantErr string
+	}{
+		"Valid": {
+			cfgs: []*MetricRelabelConfig{
+				{SourceLabels: []string{"__name__"}, Separator: ";", Regex: "go_.*", Replacement: "$1", Action: "drop"},
+				{SourceLabels: []string{"pod"}, Separator: ";", Regex: "(.*)", TargetLabel: "PodName", Replacement: "$1", Action: "replace"},
+			},
+		},
+		"UnsupportedAction": {
+			cfgs:    []*MetricRelabelConfig{{Regex: "(.*)", Action: "labelmap"}},
+			wantErr: `metric_relabel_configs[0] has an unsupported action "labelmap"`,
+		},
+		"InvalidRegex": {
+			cfgs:    []*MetricRelabelConfig{{SourceLabels: []string{"pod"}, Regex: "(", Action: "keep"}},
+			wantErr: `metric_relabel_configs[0] has an invalid regex "("`,
+		},
+		"MissingTargetLabel": {
+			cfgs:    []*MetricRelabelConfig{{SourceLabels: []string{"pod"}, Regex: "(.*)", Replacement: "$1", Action: "replace"}},
+			wantErr: "requires 'target_label' value",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := newMetricRelabelConfigs(testCase.cfgs)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, got, len(testCase.cfgs))
+		})
+	}
+}
+
+func TestAppendMetricRelabelConfigs(t *testing.T) {
+	metricRelabelConfigs, err := newMetricRelabelConfigs([]*MetricRelabelConfig{
+		{SourceLabels: []string{"__name__"}, Separator: ";", Regex: "go_.*", Replacement: "$1", Action: "drop"},
+		{SourceLabels: []string{"pod"}, Separator: ";", Regex: "(.*)", TargetLabel: "PodName", Replacement: "$1", Action: "replace"},
+	})
+	require.NoError(t, err)
+	fileConfig := &relabel.Config{
+		SourceLabels: model.LabelNames{"pod"},
+		Regex:        relabel.MustNewRegexp("(.*)-debug"),
+		TargetLabel:  "pod",
+		Replacement:  "$1",
+		Action:       relabel.Replace,
+	}
+	cfg := &config.Config{ScrapeConfigs: []*config.ScrapeConfig{
+		{JobName: "a", MetricRelabelConfigs: []*relabel.Config{fileConfig}},
+		{JobName: "b"},
+	}}
+	appendMetricRelabelConfigs(cfg, metricRelabelConfigs)
+	require.Len(t, cfg.ScrapeConfigs[0].MetricRelabelConfigs, 3)
+	assert.Same(t, fileConfig, cfg.ScrapeConfigs[0].MetricRelabelConfigs[0])
+	assert.Len(t, cfg.ScrapeConfigs[1].MetricRelabelConfigs, 2)
+
+	got, keep := relabel.Process(labels.FromStrings("__name__", "http_requests_total", "pod", "web-debug"), cfg.ScrapeConfigs[0].MetricRelabelConfigs...)
+	assert.True(t, keep)
+	assert.Equal(t, "web", got.Get("PodName"))
+	_, keep = relabel.Process(labels.FromStrings("__name__", "go_goroutines"), cfg.ScrapeConfigs[1].MetricRelabelConfigs...)
+	assert.False(t, keep)
+}
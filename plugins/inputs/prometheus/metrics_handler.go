@@ -11,6 +11,7 @@ import (
 	"github.com/influxdata/telegraf"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/containerinsightscommon"
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
 )
 
 // Use metricMaterial instead of mbMetric to avoid unnecessary tags&fields copy
@@ -27,6 +28,7 @@ type metricsHandler struct {
 	filter      *MetricsFilter
 	clusterName string
 	mtHandler   *metricsTypeHandler
+	summaries   *SummaryQuantiles
 }
 
 func (mh *metricsHandler) start(shutDownChan chan interface{}, wg *sync.WaitGroup) {
@@ -43,12 +45,26 @@ func (mh *metricsHandler) start(shutDownChan chan interface{}, wg *sync.WaitGrou
 }
 
 func (mh *metricsHandler) handle(pmb PrometheusMetricBatch) {
+	if len(pmb) > 0 {
+		// each batch holds the samples of a single scrape
+		usageKey := profiler.SourceKey(profiler.SourcePrometheus, pmb[0].jobBeforeRelabel+"/"+pmb[0].instanceBeforeRelabel)
+		defer func(start time.Time, samples int) {
+			profiler.Usage.AddBusy(usageKey, time.Since(start))
+			profiler.Usage.AddEmitted(usageKey, samples, 0)
+		}(time.Now(), len(pmb))
+	}
+
 	// Add metric type info
 	pmb = mh.mtHandler.Handle(pmb)
 
 	// Filter out Histogram and untyped Metrics and adding logging
 	pmb = mh.filter.Filter(pmb)
 
+	// convert or drop the summary quantiles
+	if mh.summaries != nil {
+		pmb = mh.summaries.Handle(pmb)
+	}
+
 	// do calculation: calculate delta for counter
 	pmb = mh.calculator.Calculate(pmb)
 
@@ -24,6 +24,8 @@ type Prometheus struct {
 	PrometheusConfigPath string                                      `toml:"prometheus_config_path"`
 	ClusterName          string                                      `toml:"cluster_name"`
 	ECSSDConfig          *ecsservicediscovery.ServiceDiscoveryConfig `toml:"ecs_service_discovery"`
+	SummaryQuantiles     []*SummaryQuantilesConfig                   `toml:"summary_quantiles"`
+	MetricRelabelConfigs []*MetricRelabelConfig                      `toml:"metric_relabel_configs"`
 	mbCh                 chan PrometheusMetricBatch
 	shutDownChan         chan interface{}
 	wg                   sync.WaitGroup
@@ -43,6 +45,14 @@ func (p *Prometheus) Gather(_ telegraf.Accumulator) error {
 }
 
 func (p *Prometheus) Start(accIn telegraf.Accumulator) error {
+	summaries, err := NewSummaryQuantiles(p.SummaryQuantiles)
+	if err != nil {
+		return err
+	}
+	metricRelabelConfigs, err := newMetricRelabelConfigs(p.MetricRelabelConfigs)
+	if err != nil {
+		return err
+	}
 	mth := NewMetricsTypeHandler()
 
 	receiver := &metricsReceiver{pmbCh: p.mbCh}
@@ -53,6 +63,7 @@ func (p *Prometheus) Start(accIn telegraf.Accumulator) error {
 		filter:      NewMetricsFilter(),
 		clusterName: p.ClusterName,
 		mtHandler:   mth,
+		summaries:   summaries,
 	}
 
 	var configurer *awsmiddleware.Configurer
@@ -76,7 +87,7 @@ func (p *Prometheus) Start(accIn telegraf.Accumulator) error {
 
 	// Start scraping prometheus metrics from prometheus endpoints
 	p.wg.Add(1)
-	go Start(p.PrometheusConfigPath, receiver, p.shutDownChan, &p.wg, mth)
+	go Start(p.PrometheusConfigPath, metricRelabelConfigs, receiver, p.shutDownChan, &p.wg, mth)
 
 	// Start filter our prometheus metrics, calculate delta value if its a Counter or Summary count sum
 	// and convert Prometheus metrics to Telegraf Metrics
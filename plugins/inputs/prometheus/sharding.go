@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+)
+
+// targetSharder is the part of the sharding extension used to filter the targets.
+type targetSharder interface {
+	Labels() []string
+	Owns(key string) bool
+	Changed() <-chan struct{}
+}
+
+// shardTargets passes on the targets from the discovery manager that this replica owns. The
+// discovery manager always sends every target, so when the replicas change, the last targets are
+// filtered again and sent to rebalance.
+func shardTargets(ctx context.Context, sharder targetSharder, in <-chan map[string][]*targetgroup.Group, logger log.Logger) <-chan map[string][]*targetgroup.Group {
+	out := make(chan map[string][]*targetgroup.Group)
+	go func() {
+		var last map[string][]*targetgroup.Group
+		changed := sharder.Changed()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case tsets, ok := <-in:
+				if !ok {
+					close(out)
+					return
+				}
+				last = tsets
+			case <-changed:
+				if last == nil {
+					changed = sharder.Changed()
+					continue
+				}
+			}
+			// taken before filtering so a change during filtering is not missed
+			changed = sharder.Changed()
+			filtered, owned, total := filterTargets(sharder, last)
+			level.Debug(logger).Log("msg", "Sharded scrape targets", "owned", owned, "total", total)
+			select {
+			case <-ctx.Done():
+				return
+			case out <- filtered:
+			}
+		}
+	}()
+	return out
+}
+
+func filterTargets(sharder targetSharder, tsets map[string][]*targetgroup.Group) (map[string][]*targetgroup.Group, int, int) {
+	owned, total := 0, 0
+	filtered := make(map[string][]*targetgroup.Group, len(tsets))
+	for job, groups := range tsets {
+		filteredGroups := make([]*targetgroup.Group, 0, len(groups))
+		for _, group := range groups {
+			if group == nil {
+				continue
+			}
+			// keep empty groups so the scrape manager drops the targets that moved away
+			filteredGroup := &targetgroup.Group{Labels: group.Labels, Source: group.Source}
+			for _, target := range group.Targets {
+				total++
+				if sharder.Owns(targetKey(job, sharder.Labels(), group.Labels, target)) {
+					owned++
+					filteredGroup.Targets = append(filteredGroup.Targets, target)
+				}
+			}
+			filteredGroups = append(filteredGroups, filteredGroup)
+		}
+		filtered[job] = filteredGroups
+	}
+	return filtered, owned, total
+}
+
+// targetKey joins the job name and the values of the labels. Target labels take precedence over
+// the labels of the group, the same way Prometheus merges them.
+func targetKey(job string, labels []string, groupLabels, target model.LabelSet) string {
+	var sb strings.Builder
+	sb.WriteString(job)
+	for _, label := range labels {
+		value, ok := target[model.LabelName(label)]
+		if !ok {
+			value = groupLabels[model.LabelName(label)]
+		}
+		sb.WriteByte(0)
+		sb.WriteString(string(value))
+	}
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSharder struct {
+	mu      sync.Mutex
+	owned   map[string]bool
+	changed chan struct{}
+}
+
+func (m *mockSharder) Labels() []string {
+	return []string{"__address__"}
+}
+
+func (m *mockSharder) Owns(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.owned[key]
+}
+
+func (m *mockSharder) Changed() <-chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.changed
+}
+
+func (m *mockSharder) set(owned map[string]bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.owned = owned
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+func addresses(tsets map[string][]*targetgroup.Group) []string {
+	var got []string
+	for _, groups := range tsets {
+		for _, group := range groups {
+			for _, target := range group.Targets {
+				got = append(got, string(target[model.AddressLabel]))
+			}
+		}
+	}
+	return got
+}
+
+func TestShardTargets(t *testing.T) {
+	sharder := &mockSharder{
+		owned:   map[string]bool{"node\x00a:9100": true},
+		changed: make(chan struct{}),
+	}
+	in := make(chan map[string][]*targetgroup.Group)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := shardTargets(ctx, sharder, in, log.NewNopLogger())
+
+	tsets := map[string][]*targetgroup.Group{
+		"node": {{
+			Source:  "0",
+			Targets: []model.LabelSet{{model.AddressLabel: "a:9100"}, {model.AddressLabel: "b:9100"}},
+		}},
+	}
+	in <- tsets
+	select {
+	case got := <-out:
+		assert.Equal(t, []string{"a:9100"}, addresses(got))
+		require.Len(t, got["node"], 1)
+		assert.Equal(t, "0", got["node"][0].Source)
+	case <-time.After(time.Second):
+		t.Fatal("no targets")
+	}
+
+	// a rebalance sends the last targets again
+	sharder.set(map[string]bool{"node\x00b:9100": true})
+	select {
+	case got := <-out:
+		assert.Equal(t, []string{"b:9100"}, addresses(got))
+	case <-time.After(time.Second):
+		t.Fatal("no rebalance")
+	}
+	// the discovered targets are not modified
+	assert.Len(t, tsets["node"][0].Targets, 2)
+}
+
+func TestTargetKey(t *testing.T) {
+	labels := []string{"__address__", "zone"}
+	assert.Equal(t, "job\x00a:9100\x00us-west-2a", targetKey("job", labels, model.LabelSet{"zone": "us-west-2a"}, model.LabelSet{model.AddressLabel: "a:9100"}))
+	assert.Equal(t, "job\x00a:9100\x00us-west-2b", targetKey("job", labels, model.LabelSet{"zone": "us-west-2a"}, model.LabelSet{model.AddressLabel: "a:9100", "zone": "us-west-2b"}))
+}
@@ -46,6 +46,8 @@ import (
 	promRuntime "github.com/prometheus/prometheus/util/runtime"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/klogr"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/sharding"
 )
 
 var (
@@ -80,7 +82,7 @@ func init() {
 	prometheus.MustRegister(v.NewCollector("prometheus"))
 }
 
-func Start(configFilePath string, receiver storage.Appendable, shutDownChan chan interface{}, wg *sync.WaitGroup, mth *metricsTypeHandler) {
+func Start(configFilePath string, metricRelabelConfigs []*relabel.Config, receiver storage.Appendable, shutDownChan chan interface{}, wg *sync.WaitGroup, mth *metricsTypeHandler) {
 	logLevel := &promlog.AllowedLevel{}
 	logLevel.Set("info")
 
@@ -125,12 +127,18 @@ func Start(configFilePath string, receiver storage.Appendable, shutDownChan chan
 	taManager.AttachReloadConfigHandler(
 		func(prometheusConfig *config.Config) {
 			relabelScrapeConfigs(prometheusConfig, logger)
+			appendMetricRelabelConfigs(prometheusConfig, metricRelabelConfigs)
 		},
 	)
 
 	mth.SetScrapeManager(scrapeManager)
 
 	var reloaders = []func(cfg *config.Config) error{
+		// The metric relabel configs of the agent configuration apply after the ones of the file.
+		func(cfg *config.Config) error {
+			appendMetricRelabelConfigs(cfg, metricRelabelConfigs)
+			return nil
+		},
 		// The Scrape and notifier managers need to reload before the Discovery manager as
 		// they need to read the most updated config when receiving the new targets list.
 		scrapeManager.ApplyConfig,
@@ -210,7 +218,12 @@ func Start(configFilePath string, receiver storage.Appendable, shutDownChan chan
 				<-reloadReady.C
 
 				level.Info(logger).Log("msg", "start discovery")
-				err := scrapeManager.Run(discoveryManagerScrape.SyncCh())
+				syncCh := discoveryManagerScrape.SyncCh()
+				if sharder := sharding.GetSharder(); sharder != nil {
+					level.Info(logger).Log("msg", "Sharding scrape targets across replicas")
+					syncCh = shardTargets(ctxScrape, sharder, syncCh, log.With(logger, "component", "sharding"))
+				}
+				err := scrapeManager.Run(syncCh)
 				level.Info(logger).Log("msg", "Scrape manager stopped", "error", err)
 				return err
 			},
@@ -0,0 +1,135 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+const (
+	quantileLabel = "quantile"
+
+	// SummaryQuantilesActionStatistics replaces the quantile label of the summary quantiles with a statistic suffix of
+	// the metric name, e.g. _p99, so that the quantiles are merged with the _sum and _count into the same EMF event.
+	SummaryQuantilesActionStatistics = "statistics"
+	// SummaryQuantilesActionDrop drops the summary quantiles and only keeps the _sum and _count.
+	SummaryQuantilesActionDrop = "drop"
+)
+
+// SummaryQuantilesConfig sets how the quantiles of the summaries with a name matching one of the selectors are sent.
+type SummaryQuantilesConfig struct {
+	MetricSelectors []string `toml:"metric_selectors"`
+	Action          string   `toml:"action"`
+	// Quantiles limits the quantiles the statistics action keeps. All of them are kept if it is empty.
+	Quantiles []float64 `toml:"quantiles"`
+}
+
+type summaryQuantilesRule struct {
+	selectors []*regexp.Regexp
+	action    string
+	quantiles []float64
+}
+
+// SummaryQuantiles applies the first rule with a selector matching the name of a summary to its quantiles. The
+// summaries without a matching rule are not changed.
+type SummaryQuantiles struct {
+	rules []summaryQuantilesRule
+}
+
+func NewSummaryQuantiles(configs []*SummaryQuantilesConfig) (*SummaryQuantiles, error) {
+	sq := &SummaryQuantiles{}
+	for i, cfg := range configs {
+		if cfg.Action != SummaryQuantilesActionStatistics && cfg.Action != SummaryQuantilesActionDrop {
+			return nil, fmt.Errorf("summary_quantiles[%d] has an unsupported action %q", i, cfg.Action)
+		}
+		rule := summaryQuantilesRule{action: cfg.Action, quantiles: cfg.Quantiles}
+		for _, selector := range cfg.MetricSelectors {
+			regex, err := regexp.Compile(selector)
+			if err != nil {
+				return nil, fmt.Errorf("summary_quantiles[%d] has an invalid metric selector %q: %w", i, selector, err)
+			}
+			rule.selectors = append(rule.selectors, regex)
+		}
+		for _, q := range cfg.Quantiles {
+			if q < 0 || q > 1 {
+				return nil, fmt.Errorf("summary_quantiles[%d] has an invalid quantile %v", i, q)
+			}
+		}
+		sq.rules = append(sq.rules, rule)
+	}
+	return sq, nil
+}
+
+func (sq *SummaryQuantiles) Handle(pmb PrometheusMetricBatch) (result PrometheusMetricBatch) {
+	if len(sq.rules) == 0 {
+		return pmb
+	}
+	for _, pm := range pmb {
+		quantile, ok := pm.tags[quantileLabel]
+		if !pm.isSummary() || !ok {
+			result = append(result, pm)
+			continue
+		}
+		rule := sq.rule(pm.metricName)
+		if rule == nil {
+			result = append(result, pm)
+			continue
+		}
+		if rule.action == SummaryQuantilesActionDrop {
+			continue
+		}
+		q, err := strconv.ParseFloat(quantile, 64)
+		if err != nil {
+			log.Printf("D! Drop summary %s with invalid quantile %q", pm.metricName, quantile)
+			continue
+		}
+		if !rule.keeps(q) {
+			continue
+		}
+		pm.metricName = pm.metricName + "_" + statistic(q)
+		delete(pm.tags, quantileLabel)
+		result = append(result, pm)
+	}
+	return result
+}
+
+func (sq *SummaryQuantiles) rule(metricName string) *summaryQuantilesRule {
+	for i, rule := range sq.rules {
+		for _, selector := range rule.selectors {
+			if selector.MatchString(metricName) {
+				return &sq.rules[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (r *summaryQuantilesRule) keeps(q float64) bool {
+	if len(r.quantiles) == 0 {
+		return true
+	}
+	for _, quantile := range r.quantiles {
+		if statistic(quantile) == statistic(q) {
+			return true
+		}
+	}
+	return false
+}
+
+// statistic is the name of the CloudWatch statistic of the quantile, e.g. p99 or p99.9, and min and max for the 0 and
+// 1 quantiles.
+func statistic(q float64) string {
+	switch q {
+	case 0:
+		return "min"
+	case 1:
+		return "max"
+	}
+	// round to avoid the floating point error of the multiplication, e.g. 0.999 * 100 = 99.89999999999999
+	return "p" + strconv.FormatFloat(math.Round(q*100*1000)/1000, 'f', -1, 64)
+}
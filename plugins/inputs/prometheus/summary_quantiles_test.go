@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func summaryBatch(name string) PrometheusMetricBatch {
+	pmb := PrometheusMetricBatch{
+		{metricName: name + "_sum", metricType: "summary", metricValue: 12, tags: map[string]string{"job": "api"}},
+		{metricName: name + "_count", metricType: "summary", metricValue: 3, tags: map[string]string{"job": "api"}},
+	}
+	for _, quantile := range []string{"0", "0.5", "0.9", "0.999", "1"} {
+		pmb = append(pmb, &PrometheusMetric{
+			metricName:  name,
+			metricType:  "summary",
+			metricValue: 1,
+			tags:        map[string]string{"job": "api", quantileLabel: quantile},
+		})
+	}
+	return pmb
+}
+
+func TestSummaryQuantiles(t *testing.T) {
+	testCases := map[string]struct {
+		configs []*SummaryQuantilesConfig
+		want    []string
+	}{
+		"WithoutRules": {
+			want: []string{"latency_sum", "latency_count", "latency", "latency", "latency", "latency", "latency", "up"},
+		},
+		"WithStatistics": {
+			configs: []*SummaryQuantilesConfig{
+				{MetricSelectors: []string{"^latency$"}, Action: SummaryQuantilesActionStatistics},
+			},
+			want: []string{"latency_sum", "latency_count", "latency_min", "latency_p50", "latency_p90", "latency_p99.9", "latency_max", "up"},
+		},
+		"WithStatisticsQuantiles": {
+			configs: []*SummaryQuantilesConfig{
+				{MetricSelectors: []string{"^latency$"}, Action: SummaryQuantilesActionStatistics, Quantiles: []float64{0.5, 0.999}},
+			},
+			want: []string{"latency_sum", "latency_count", "latency_p50", "latency_p99.9", "up"},
+		},
+		"WithDrop": {
+			configs: []*SummaryQuantilesConfig{
+				{MetricSelectors: []string{"^lat"}, Action: SummaryQuantilesActionDrop},
+				{MetricSelectors: []string{".*"}, Action: SummaryQuantilesActionStatistics},
+			},
+			want: []string{"latency_sum", "latency_count", "up"},
+		},
+		"WithoutMatch": {
+			configs: []*SummaryQuantilesConfig{
+				{MetricSelectors: []string{"^rpc_"}, Action: SummaryQuantilesActionDrop},
+			},
+			want: []string{"latency_sum", "latency_count", "latency", "latency", "latency", "latency", "latency", "up"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sq, err := NewSummaryQuantiles(testCase.configs)
+			require.NoError(t, err)
+			pmb := append(summaryBatch("latency"), &PrometheusMetric{metricName: "up", metricType: "gauge", metricValue: 1, tags: map[string]string{"job": "api"}})
+			var got []string
+			for _, pm := range sq.Handle(pmb) {
+				got = append(got, pm.metricName)
+				if pm.metricName != "latency" {
+					assert.NotContains(t, pm.tags, quantileLabel)
+				}
+			}
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestSummaryQuantilesMerge(t *testing.T) {
+	sq, err := NewSummaryQuantiles([]*SummaryQuantilesConfig{
+		{MetricSelectors: []string{".*"}, Action: SummaryQuantilesActionStatistics},
+	})
+	require.NoError(t, err)
+	// without the quantile label, the statistics are sent in the same event as the sum and count
+	mms := mergeMetrics(sq.Handle(summaryBatch("latency")))
+	require.Len(t, mms, 1)
+	assert.Len(t, mms[0].fields, 7)
+	assert.Contains(t, mms[0].fields, "latency_p99.9")
+}
+
+func TestNewSummaryQuantiles(t *testing.T) {
+	_, err := NewSummaryQuantiles([]*SummaryQuantilesConfig{{MetricSelectors: []string{".*"}, Action: "histogram"}})
+	assert.ErrorContains(t, err, `unsupported action "histogram"`)
+	_, err = NewSummaryQuantiles([]*SummaryQuantilesConfig{{MetricSelectors: []string{"(a"}, Action: SummaryQuantilesActionDrop}})
+	assert.ErrorContains(t, err, "invalid metric selector")
+	_, err = NewSummaryQuantiles([]*SummaryQuantilesConfig{{MetricSelectors: []string{".*"}, Action: SummaryQuantilesActionStatistics, Quantiles: []float64{1.5}}})
+	assert.ErrorContains(t, err, "invalid quantile 1.5")
+}
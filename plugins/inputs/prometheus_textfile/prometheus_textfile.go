@@ -0,0 +1,176 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus_textfile
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	DefaultDirectory = "/var/lib/node_exporter/textfile_collector"
+
+	// The metrics node_exporter adds about the files it read.
+	mtimeMetric       = "node_textfile_mtime_seconds"
+	scrapeErrorMetric = "node_textfile_scrape_error"
+
+	fileExtension = ".prom"
+	fileLabel     = "file"
+	valueField    = "value"
+)
+
+// Plugin reads the *.prom files of a directory the way the textfile collector of node_exporter does. Every sample is
+// published as a gauge named after it, with its labels as dimensions.
+type Plugin struct {
+	Directory string          `toml:"directory"`
+	Log       telegraf.Logger `toml:"-"`
+}
+
+func (p *Plugin) Description() string {
+	return "Read the metrics of the Prometheus text files in a directory, like the node_exporter textfile collector"
+}
+
+func (p *Plugin) SampleConfig() string {
+	return `
+  ## Directory with the *.prom files. Other files are ignored.
+  directory = "/var/lib/node_exporter/textfile_collector"
+`
+}
+
+func (p *Plugin) Gather(acc telegraf.Accumulator) error {
+	directory := p.Directory
+	if directory == "" {
+		directory = DefaultDirectory
+	}
+	paths, err := filepath.Glob(filepath.Join(directory, "*"+fileExtension))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	scrapeError := 0.0
+	for _, path := range paths {
+		mtime, err := p.gatherFile(acc, path)
+		if err != nil {
+			p.Log.Errorf("Unable to read %s: %v", path, err)
+			scrapeError = 1
+			continue
+		}
+		acc.AddGauge(mtimeMetric, map[string]any{valueField: mtime}, map[string]string{fileLabel: filepath.Base(path)})
+	}
+	acc.AddGauge(scrapeErrorMetric, map[string]any{valueField: scrapeError}, nil)
+	return nil
+}
+
+// gatherFile adds the samples of the file and returns its modification time in seconds. Nothing is added when the file
+// is not valid, so that a file a job is still writing does not publish part of its metrics.
+func (p *Plugin) gatherFile(acc telegraf.Accumulator, path string) (float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(f)
+	if err != nil {
+		return 0, err
+	}
+	var samples []sample
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			// Like node_exporter, reject the files with timestamps since the samples are published at the time they are read.
+			if m.TimestampMs != nil {
+				return 0, fmt.Errorf("metric %s has a timestamp, which is not supported", family.GetName())
+			}
+			samples = append(samples, toSamples(family.GetName(), family.GetType(), m)...)
+		}
+	}
+	for _, s := range samples {
+		acc.AddGauge(s.name, map[string]any{valueField: s.value}, s.tags)
+	}
+	return float64(info.ModTime().UnixNano()) / 1e9, nil
+}
+
+type sample struct {
+	name  string
+	value float64
+	tags  map[string]string
+}
+
+// toSamples flattens a metric back into the samples of the exposition format. Histograms and summaries become their
+// _bucket or quantile, _sum and _count series.
+func toSamples(name string, metricType dto.MetricType, m *dto.Metric) []sample {
+	labels := map[string]string{}
+	for _, label := range m.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	newSample := func(name string, value float64, extraName, extraValue string) sample {
+		tags := make(map[string]string, len(labels)+1)
+		for k, v := range labels {
+			tags[k] = v
+		}
+		if extraName != "" {
+			tags[extraName] = extraValue
+		}
+		return sample{name: name, value: value, tags: tags}
+	}
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		return []sample{newSample(name, m.GetCounter().GetValue(), "", "")}
+	case dto.MetricType_GAUGE:
+		return []sample{newSample(name, m.GetGauge().GetValue(), "", "")}
+	case dto.MetricType_SUMMARY:
+		summary := m.GetSummary()
+		samples := make([]sample, 0, len(summary.GetQuantile())+2)
+		for _, q := range summary.GetQuantile() {
+			samples = append(samples, newSample(name, q.GetValue(), "quantile", formatFloat(q.GetQuantile())))
+		}
+		return append(samples,
+			newSample(name+"_sum", summary.GetSampleSum(), "", ""),
+			newSample(name+"_count", float64(summary.GetSampleCount()), "", ""))
+	case dto.MetricType_HISTOGRAM:
+		histogram := m.GetHistogram()
+		samples := make([]sample, 0, len(histogram.GetBucket())+3)
+		infSeen := false
+		for _, b := range histogram.GetBucket() {
+			if math.IsInf(b.GetUpperBound(), 1) {
+				infSeen = true
+			}
+			samples = append(samples, newSample(name+"_bucket", float64(b.GetCumulativeCount()), "le", formatFloat(b.GetUpperBound())))
+		}
+		if !infSeen {
+			samples = append(samples, newSample(name+"_bucket", float64(histogram.GetSampleCount()), "le", "+Inf"))
+		}
+		return append(samples,
+			newSample(name+"_sum", histogram.GetSampleSum(), "", ""),
+			newSample(name+"_count", float64(histogram.GetSampleCount()), "", ""))
+	default:
+		return []sample{newSample(name, m.GetUntyped().GetValue(), "", "")}
+	}
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func init() {
+	inputs.Add("prometheus_textfile", func() telegraf.Input {
+		return &Plugin{}
+	})
+}
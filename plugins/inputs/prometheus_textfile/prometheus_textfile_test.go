@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus_textfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGather(t *testing.T) {
+	p := &Plugin{Directory: "testdata", Log: testutil.Logger{}}
+	acc := &testutil.Accumulator{}
+	require.NoError(t, p.Gather(acc))
+
+	for _, m := range []struct {
+		name  string
+		value float64
+		tags  map[string]string
+	}{
+		{"backup_last_success_timestamp_seconds", 1.7e9, map[string]string{"job": "db"}},
+		{"backup_runs_total", 12, map[string]string{"job": "db", "result": "ok"}},
+		{"backup_size_bytes", 1024, map[string]string{}},
+		{"backup_duration_seconds_bucket", 3, map[string]string{"le": "10"}},
+		{"backup_duration_seconds_bucket", 6, map[string]string{"le": "+Inf"}},
+		{"backup_duration_seconds_sum", 200, map[string]string{}},
+		{"backup_duration_seconds_count", 6, map[string]string{}},
+		{"backup_lag_seconds", 1.5, map[string]string{"quantile": "0.5"}},
+		{"backup_lag_seconds_sum", 9, map[string]string{}},
+		{"backup_lag_seconds_count", 4, map[string]string{}},
+		{scrapeErrorMetric, 1, map[string]string{}},
+	} {
+		acc.AssertContainsTaggedFields(t, m.name, map[string]any{valueField: m.value}, m.tags)
+	}
+	assert.True(t, acc.HasTag(mtimeMetric, fileLabel))
+	for _, m := range acc.GetTelegrafMetrics() {
+		assert.NotEqual(t, "not_read", m.Name())
+		assert.NotEqual(t, "backup_partial", m.Name())
+		assert.NotEqual(t, "backup_timestamped", m.Name())
+		if m.Name() == mtimeMetric {
+			file, _ := m.GetTag(fileLabel)
+			assert.Contains(t, []string{"backup.prom", "latency.prom"}, file)
+		}
+	}
+}
+
+func TestGatherWithoutErrors(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty.prom"), nil, 0644))
+	p := &Plugin{Directory: dir, Log: testutil.Logger{}}
+	acc := &testutil.Accumulator{}
+	require.NoError(t, p.Gather(acc))
+	acc.AssertContainsTaggedFields(t, scrapeErrorMetric, map[string]any{valueField: 0.0}, map[string]string{})
+	acc.AssertContainsTaggedFields(t, mtimeMetric, map[string]any{valueField: mtimeOf(t, filepath.Join(dir, "empty.prom"))}, map[string]string{fileLabel: "empty.prom"})
+
+	acc = &testutil.Accumulator{}
+	p.Directory = filepath.Join(dir, "missing")
+	require.NoError(t, p.Gather(acc))
+	assert.Len(t, acc.GetTelegrafMetrics(), 1)
+	acc.AssertContainsTaggedFields(t, scrapeErrorMetric, map[string]any{valueField: 0.0}, map[string]string{})
+}
+
+func mtimeOf(t *testing.T, path string) float64 {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return float64(info.ModTime().UnixNano()) / 1e9
+}
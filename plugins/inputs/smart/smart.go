@@ -0,0 +1,313 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package smart
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+)
+
+const (
+	measurement = "smart"
+
+	deviceTag = "device"
+	modelTag  = "model"
+	serialTag = "serial"
+
+	fieldPredictedFailure     = "predicted_failure"
+	fieldTemperature          = "temperature"
+	fieldPowerOnHours         = "power_on_hours"
+	fieldReallocatedSectors   = "reallocated_sectors"
+	fieldPendingSectors       = "pending_sectors"
+	fieldUncorrectableSectors = "uncorrectable_sectors"
+	fieldPercentageUsed       = "percentage_used"
+	fieldAvailableSpare       = "available_spare"
+	fieldMediaErrors          = "media_errors"
+	fieldCriticalWarning      = "critical_warning"
+
+	defaultBinPath = "smartctl"
+	defaultTimeout = 30 * time.Second
+
+	// exitFatal are the bits of the exit status of smartctl which mean that the device could not be read. The other
+	// bits report the health of the device, whose details are in the output.
+	exitFatal = 0x3
+)
+
+// The ATA attributes reported as a count of sectors.
+const (
+	attributeReallocatedSectors   = 5
+	attributePendingSectors       = 197
+	attributeUncorrectableSectors = 198
+)
+
+// wearAttributes are the ATA attributes of the SSD vendors whose normalized value is the percentage of the rated
+// endurance left, in the order they are looked up.
+var wearAttributes = []int{
+	231, // SSD_Life_Left
+	233, // Media_Wearout_Indicator
+	177, // Wear_Leveling_Count
+	202, // Percent_Lifetime_Remain
+}
+
+// Smart reports the SMART health of the disks of bare-metal and on-premises hosts, read with smartctl, so that a
+// failing disk can be replaced before it fails.
+type Smart struct {
+	BinPath string          `toml:"bin_path"`
+	UseSudo bool            `toml:"use_sudo"`
+	Devices []string        `toml:"devices"`
+	Timeout config.Duration `toml:"timeout"`
+	Log     telegraf.Logger `toml:"-"`
+
+	// run runs smartctl with the arguments and returns its output. It is replaced in tests.
+	run func(args ...string) ([]byte, error)
+}
+
+func (s *Smart) Description() string {
+	return "Report the SMART health of the disks read with smartctl"
+}
+
+func (s *Smart) SampleConfig() string {
+	return `
+  ## Path of smartctl 7.0 or later, looked up in the PATH by default.
+  # bin_path = "/usr/sbin/smartctl"
+  ## Run smartctl with sudo, which needs to be allowed without a password.
+  # use_sudo = false
+  ## Devices to read, e.g. "/dev/sda" or "/dev/sda -d sat". All the devices smartctl finds by default.
+  # devices = []
+  ## Timeout of each call of smartctl.
+  # timeout = "30s"
+`
+}
+
+func (s *Smart) Init() error {
+	if s.Timeout <= 0 {
+		s.Timeout = config.Duration(defaultTimeout)
+	}
+	if s.run != nil {
+		return nil
+	}
+	binPath := s.BinPath
+	if binPath == "" {
+		binPath = defaultBinPath
+	}
+	binPath, err := exec.LookPath(binPath)
+	if err != nil {
+		return fmt.Errorf("smartctl not found, it is part of the smartmontools package: %w", err)
+	}
+	s.run = func(args ...string) ([]byte, error) {
+		cmd := exec.Command(binPath, args...)
+		if s.UseSudo {
+			cmd = exec.Command("sudo", append([]string{"-n", binPath}, args...)...)
+		}
+		return internal.StdOutputTimeout(cmd, time.Duration(s.Timeout))
+	}
+	return nil
+}
+
+func (s *Smart) Gather(acc telegraf.Accumulator) error {
+	devices := s.Devices
+	if len(devices) == 0 {
+		scanned, err := s.scan()
+		if err != nil {
+			return err
+		}
+		devices = scanned
+	}
+	for _, device := range devices {
+		if err := s.gatherDevice(acc, device); err != nil {
+			acc.AddError(fmt.Errorf("unable to read the SMART data of %s: %w", device, err))
+		}
+	}
+	return nil
+}
+
+// scan returns the devices smartctl finds, with their type, e.g. "/dev/sda -d sat".
+func (s *Smart) scan() ([]string, error) {
+	var output scanOutput
+	if err := s.smartctl(&output, "--json", "--scan-open"); err != nil {
+		return nil, fmt.Errorf("unable to scan the devices: %w", err)
+	}
+	devices := make([]string, 0, len(output.Devices))
+	for _, device := range output.Devices {
+		if device.Type != "" {
+			devices = append(devices, device.Name+" -d "+device.Type)
+		} else {
+			devices = append(devices, device.Name)
+		}
+	}
+	return devices, nil
+}
+
+func (s *Smart) gatherDevice(acc telegraf.Accumulator, device string) error {
+	// the disks in standby are not spun up, and have no SMART data until they are
+	args := append([]string{"--json", "--info", "--health", "--attributes", "--log=devstat", "--nocheck=standby,0"}, strings.Fields(device)...)
+	var output deviceOutput
+	if err := s.smartctl(&output, args...); err != nil {
+		return err
+	}
+	if output.SmartStatus == nil {
+		s.Log.Debugf("No SMART data for %s, it may be in standby or not support SMART", device)
+		return nil
+	}
+	fields := map[string]interface{}{
+		fieldPredictedFailure: 0,
+	}
+	if !output.SmartStatus.Passed {
+		fields[fieldPredictedFailure] = 1
+	}
+	if output.Temperature != nil {
+		fields[fieldTemperature] = output.Temperature.Current
+	}
+	if output.PowerOnTime != nil {
+		fields[fieldPowerOnHours] = output.PowerOnTime.Hours
+	}
+	if output.ATAAttributes != nil {
+		attributes := map[int]ataAttribute{}
+		for _, attribute := range output.ATAAttributes.Table {
+			attributes[attribute.ID] = attribute
+		}
+		for id, field := range map[int]string{
+			attributeReallocatedSectors:   fieldReallocatedSectors,
+			attributePendingSectors:       fieldPendingSectors,
+			attributeUncorrectableSectors: fieldUncorrectableSectors,
+		} {
+			if attribute, ok := attributes[id]; ok {
+				fields[field] = attribute.Raw.Value
+			}
+		}
+		if output.EnduranceUsed != nil {
+			fields[fieldPercentageUsed] = output.EnduranceUsed.CurrentPercent
+		} else {
+			for _, id := range wearAttributes {
+				if attribute, ok := attributes[id]; ok && attribute.Value <= 100 {
+					fields[fieldPercentageUsed] = 100 - attribute.Value
+					break
+				}
+			}
+		}
+	}
+	if nvme := output.NVMeLog; nvme != nil {
+		fields[fieldPercentageUsed] = nvme.PercentageUsed
+		fields[fieldAvailableSpare] = nvme.AvailableSpare
+		fields[fieldMediaErrors] = nvme.MediaErrors
+		fields[fieldCriticalWarning] = nvme.CriticalWarning
+	}
+	tags := map[string]string{deviceTag: filepath.Base(output.Device.Name)}
+	if output.ModelName != "" {
+		tags[modelTag] = output.ModelName
+	}
+	if output.SerialNumber != "" {
+		tags[serialTag] = output.SerialNumber
+	}
+	acc.AddGauge(measurement, fields, tags)
+	return nil
+}
+
+// smartctl runs smartctl and decodes its JSON output. smartctl exits with a non-zero status when a disk is failing,
+// which is only an error when the output says the device could not be read.
+func (s *Smart) smartctl(v interface{ status() smartctlStatus }, args ...string) error {
+	out, runErr := s.run(args...)
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return runErr
+	}
+	if err := json.Unmarshal(out, v); err != nil {
+		if runErr != nil {
+			return runErr
+		}
+		return fmt.Errorf("invalid output of smartctl: %w", err)
+	}
+	status := v.status()
+	if status.ExitStatus&exitFatal != 0 {
+		for _, message := range status.Messages {
+			if message.Severity == "error" {
+				return errors.New(message.String)
+			}
+		}
+		return fmt.Errorf("smartctl exited with status %d", status.ExitStatus)
+	}
+	return nil
+}
+
+type smartctlStatus struct {
+	ExitStatus int `json:"exit_status"`
+	Messages   []struct {
+		String   string `json:"string"`
+		Severity string `json:"severity"`
+	} `json:"messages"`
+}
+
+type scanOutput struct {
+	Smartctl smartctlStatus `json:"smartctl"`
+	Devices  []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"devices"`
+}
+
+func (o *scanOutput) status() smartctlStatus {
+	return o.Smartctl
+}
+
+type ataAttribute struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+	Raw   struct {
+		Value int64 `json:"value"`
+	} `json:"raw"`
+}
+
+// deviceOutput is the part of the output of smartctl --json --info --health --attributes the plugin reports.
+type deviceOutput struct {
+	Smartctl smartctlStatus `json:"smartctl"`
+	Device   struct {
+		Name string `json:"name"`
+	} `json:"device"`
+	ModelName    string `json:"model_name"`
+	SerialNumber string `json:"serial_number"`
+	SmartStatus  *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature *struct {
+		Current int `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime *struct {
+		Hours int64 `json:"hours"`
+	} `json:"power_on_time"`
+	ATAAttributes *struct {
+		Table []ataAttribute `json:"table"`
+	} `json:"ata_smart_attributes"`
+	// EnduranceUsed is read from the device statistics of the ATA SSDs by smartctl 7.3 or later.
+	EnduranceUsed *struct {
+		CurrentPercent int `json:"current_percent"`
+	} `json:"endurance_used"`
+	NVMeLog *struct {
+		CriticalWarning int   `json:"critical_warning"`
+		AvailableSpare  int   `json:"available_spare"`
+		PercentageUsed  int   `json:"percentage_used"`
+		MediaErrors     int64 `json:"media_errors"`
+	} `json:"nvme_smart_health_information_log"`
+}
+
+func (o *deviceOutput) status() smartctlStatus {
+	return o.Smartctl
+}
+
+func init() {
+	inputs.Add("smart", func() telegraf.Input {
+		return &Smart{Timeout: config.Duration(defaultTimeout)}
+	})
+}
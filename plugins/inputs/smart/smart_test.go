@@ -0,0 +1,117 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package smart
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSmartctl returns the output of testdata/<file>.json for the calls with the device or the option of outputs.
+func fakeSmartctl(t *testing.T, outputs map[string]string) func(args ...string) ([]byte, error) {
+	return func(args ...string) ([]byte, error) {
+		var key string
+		for _, arg := range args {
+			if arg == "--scan-open" || strings.HasPrefix(arg, "/dev/") {
+				key = arg
+			}
+		}
+		file, ok := outputs[key]
+		if !ok {
+			t.Fatalf("unexpected call of smartctl %v", args)
+		}
+		content, err := os.ReadFile(filepath.Join("testdata", file+".json"))
+		require.NoError(t, err)
+		if file == "sda" || file == "open_failed" {
+			// smartctl exits with a non-zero status on failing disks and on errors
+			return content, &exec.ExitError{}
+		}
+		return content, nil
+	}
+}
+
+func TestGather(t *testing.T) {
+	s := &Smart{
+		Log: testutil.Logger{},
+		run: fakeSmartctl(t, map[string]string{
+			"--scan-open": "scan",
+			"/dev/sda":    "sda",
+			"/dev/sdb":    "sdb",
+			"/dev/nvme0":  "nvme0",
+		}),
+	}
+	require.NoError(t, s.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	require.Len(t, acc.Metrics, 3)
+
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldPredictedFailure:     1,
+		fieldTemperature:          38,
+		fieldPowerOnHours:         int64(39870),
+		fieldReallocatedSectors:   int64(4032),
+		fieldPendingSectors:       int64(16),
+		fieldUncorrectableSectors: int64(8),
+	}, map[string]string{deviceTag: "sda", modelTag: "ST4000NM0035-1V4107", serialTag: "ZC1234AB"})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldPredictedFailure:   0,
+		fieldTemperature:        31,
+		fieldPowerOnHours:       int64(12011),
+		fieldReallocatedSectors: int64(0),
+		fieldPercentageUsed:     6,
+	}, map[string]string{deviceTag: "sdb", modelTag: "Samsung SSD 860 EVO 1TB", serialTag: "S3Z9NB0K123456X"})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		fieldPredictedFailure: 0,
+		fieldTemperature:      42,
+		fieldPowerOnHours:     int64(8760),
+		fieldPercentageUsed:   3,
+		fieldAvailableSpare:   100,
+		fieldMediaErrors:      int64(0),
+		fieldCriticalWarning:  0,
+	}, map[string]string{deviceTag: "nvme0", modelTag: "Amazon EC2 NVMe Instance Storage", serialTag: "AWS22C8A0B1C2D3E4F5"})
+}
+
+func TestGatherDevices(t *testing.T) {
+	s := &Smart{
+		Log:     testutil.Logger{},
+		Devices: []string{"/dev/sdc -d sat", "/dev/sdd"},
+		run: fakeSmartctl(t, map[string]string{
+			"/dev/sdc": "standby",
+			"/dev/sdd": "open_failed",
+		}),
+	}
+	require.NoError(t, s.Init())
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	// the disk in standby is skipped, and the one which cannot be opened is an error
+	assert.Empty(t, acc.Metrics)
+	require.Len(t, acc.Errors, 1)
+	assert.ErrorContains(t, acc.Errors[0], "/dev/sdd")
+	assert.ErrorContains(t, acc.Errors[0], "Permission denied")
+}
+
+func TestScanError(t *testing.T) {
+	s := &Smart{
+		Log: testutil.Logger{},
+		run: func(...string) ([]byte, error) {
+			return nil, errors.New("signal: killed")
+		},
+	}
+	require.NoError(t, s.Init())
+	assert.ErrorContains(t, s.Gather(&testutil.Accumulator{}), "signal: killed")
+}
+
+func TestInitWithoutSmartctl(t *testing.T) {
+	s := &Smart{BinPath: filepath.Join(t.TempDir(), "smartctl"), Log: testutil.Logger{}}
+	assert.Error(t, s.Init())
+}
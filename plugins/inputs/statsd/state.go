@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/influxdata/telegraf"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+)
+
+// counterState is what the state file holds between runs of the agent. Published is the end of the last window
+// whose counters were handed to the accumulator. Windows are the counters aggregated after that and not yet
+// published, keyed by the time they were taken.
+type counterState struct {
+	Published time.Time       `json:"published"`
+	Windows   []counterWindow `json:"windows,omitempty"`
+}
+
+type counterWindow struct {
+	Time     time.Time       `json:"time"`
+	Counters []counterRecord `json:"counters"`
+}
+
+type counterRecord struct {
+	Name   string            `json:"name"`
+	Tags   map[string]string `json:"tags"`
+	Fields map[string]int64  `json:"fields"`
+}
+
+// persistCounters reports whether the delta counters survive restarts. Cumulative counters, with delete_counters
+// off, start from zero on every run.
+func (s *Statsd) persistCounters() bool {
+	return s.StateFile != "" && s.DeleteCounters
+}
+
+// loadState restores the counter windows that a previous run aggregated but did not publish. Windows that end at
+// or before the published boundary were already sent and are dropped so that they are not counted twice.
+func (s *Statsd) loadState() {
+	if !s.persistCounters() {
+		return
+	}
+	content, err := os.ReadFile(s.StateFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("W! Unable to read statsd state file %s: %v", s.StateFile, err)
+		}
+		return
+	}
+	var state counterState
+	if err = json.Unmarshal(content, &state); err != nil {
+		log.Printf("W! Ignoring invalid statsd state file %s: %v", s.StateFile, err)
+		return
+	}
+	s.published = state.Published
+	for _, w := range state.Windows {
+		if !w.Time.After(state.Published) {
+			continue
+		}
+		s.restored = append(s.restored, w)
+	}
+	if len(s.restored) > 0 {
+		log.Printf("I! Restored %d unpublished statsd counter windows from %s", len(s.restored), s.StateFile)
+	}
+}
+
+// publishRestored hands the restored windows to the accumulator with the time they were taken, so they land in the
+// aggregation window they belong to. The state file still holds them, so it is marked to be written.
+func (s *Statsd) publishRestored(acc telegraf.Accumulator) {
+	if len(s.restored) > 0 {
+		s.stateDirty = true
+	}
+	for _, w := range s.restored {
+		for _, c := range w.Counters {
+			fields := make(map[string]interface{}, len(c.Fields))
+			for k, v := range c.Fields {
+				fields[k] = v
+			}
+			acc.AddFields(c.Name, fields, c.Tags, w.Time)
+		}
+	}
+	s.restored = nil
+}
+
+// saveState writes the published boundary and, when pending is set, the counters not yet gathered. The file is only
+// written when it holds windows published since, or there are counters to keep. It must be called with the lock held.
+func (s *Statsd) saveState(pending time.Time) {
+	keepPending := !pending.IsZero() && len(s.counters) > 0
+	if !s.persistCounters() || (!s.stateDirty && !keepPending) {
+		return
+	}
+	state := counterState{Published: s.published, Windows: s.restored}
+	if keepPending {
+		w := counterWindow{Time: pending}
+		for _, c := range s.counters {
+			fields := make(map[string]int64, len(c.fields))
+			for k, v := range c.fields {
+				fields[k] = v.(int64)
+			}
+			w.Counters = append(w.Counters, counterRecord{Name: c.name, Tags: c.tags, Fields: fields})
+		}
+		state.Windows = append(state.Windows, w)
+	}
+	content, err := json.Marshal(state)
+	if err != nil {
+		log.Printf("W! Unable to encode statsd state: %v", err)
+		return
+	}
+	if err = writeFileAtomic(s.StateFile, content); err != nil {
+		log.Printf("W! Unable to write statsd state file %s: %v", s.StateFile, paths.ReadOnlyHint(err))
+		return
+	}
+	s.stateDirty = false
+}
+
+// writeFileAtomic replaces name with content, so a crash while writing leaves the previous state in place.
+func writeFileAtomic(name string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStateStatsd(stateFile string) *Statsd {
+	s := NewTestStatsd()
+	s.DeleteCounters = true
+	s.StateFile = stateFile
+	s.loadState()
+	return s
+}
+
+func counterValues(acc *testutil.Accumulator) []int64 {
+	var values []int64
+	for _, m := range acc.GetTelegrafMetrics() {
+		if v, ok := m.GetField("value"); ok {
+			values = append(values, v.(int64))
+		}
+	}
+	return values
+}
+
+func TestState_RestoresUnpublishedCounters(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state", "statsd_counters.json")
+
+	s := newStateStatsd(stateFile)
+	require.NoError(t, s.parseStatsdLine("requests:1|c"))
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	assert.Equal(t, []int64{1}, counterValues(acc))
+
+	// received after the last interval, then the agent stops
+	require.NoError(t, s.parseStatsdLine("requests:2|c"))
+	stopped := time.Now()
+	s.saveState(stopped)
+
+	s = newStateStatsd(stateFile)
+	require.Len(t, s.restored, 1)
+	require.NoError(t, s.parseStatsdLine("requests:3|c"))
+	acc = &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	assert.ElementsMatch(t, []int64{2, 3}, counterValues(acc))
+	for _, m := range acc.GetTelegrafMetrics() {
+		if v, _ := m.GetField("value"); v == int64(2) {
+			assert.True(t, m.Time().Equal(stopped))
+		}
+	}
+
+	// the restored counters were published and must not come back after another restart
+	s = newStateStatsd(stateFile)
+	assert.Empty(t, s.restored)
+	acc = &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	assert.Empty(t, counterValues(acc))
+}
+
+func TestState_CrashBeforeGather(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "statsd_counters.json")
+
+	s := newStateStatsd(stateFile)
+	require.NoError(t, s.parseStatsdLine("requests:2|c"))
+	s.saveState(time.Now())
+
+	// the agent restarts and dies before the first interval
+	s = newStateStatsd(stateFile)
+	require.Len(t, s.restored, 1)
+
+	s = newStateStatsd(stateFile)
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	assert.Equal(t, []int64{2}, counterValues(acc))
+}
+
+func TestState_SkipsPublishedWindows(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "statsd_counters.json")
+	content := `{"published":"2024-01-01T00:01:00Z","windows":[` +
+		`{"time":"2024-01-01T00:00:30Z","counters":[{"name":"old","tags":{},"fields":{"value":1}}]},` +
+		`{"time":"2024-01-01T00:01:30Z","counters":[{"name":"new","tags":{},"fields":{"value":2}}]}]}`
+	require.NoError(t, os.WriteFile(stateFile, []byte(content), 0644))
+
+	s := newStateStatsd(stateFile)
+	require.Len(t, s.restored, 1)
+	assert.Equal(t, "new", s.restored[0].Counters[0].Name)
+}
+
+func TestState_Disabled(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "statsd_counters.json")
+
+	s := newStateStatsd(stateFile)
+	s.DeleteCounters = false
+	require.NoError(t, s.parseStatsdLine("requests:2|c"))
+	s.saveState(time.Now())
+	require.NoError(t, s.Gather(&testutil.Accumulator{}))
+	assert.NoFileExists(t, stateFile)
+
+	require.NoError(t, os.WriteFile(stateFile, []byte("not json"), 0644))
+	s = newStateStatsd(stateFile)
+	assert.Empty(t, s.restored)
+}
+
+func TestState_WritesOnlyWhenDirty(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "statsd_counters.json")
+
+	s := newStateStatsd(stateFile)
+	require.NoError(t, s.parseStatsdLine("requests:1|c"))
+	require.NoError(t, s.Gather(&testutil.Accumulator{}))
+	s.saveState(time.Now())
+	assert.NoFileExists(t, stateFile)
+
+	require.NoError(t, s.parseStatsdLine("requests:2|c"))
+	s.saveState(time.Now())
+	require.FileExists(t, stateFile)
+
+	// the file is rewritten once the restored window is published, then left alone
+	s = newStateStatsd(stateFile)
+	require.NoError(t, s.Gather(&testutil.Accumulator{}))
+	assert.Empty(t, newStateStatsd(stateFile).restored)
+	require.NoError(t, os.Remove(stateFile))
+	require.NoError(t, s.Gather(&testutil.Accumulator{}))
+	assert.NoFileExists(t, stateFile)
+}
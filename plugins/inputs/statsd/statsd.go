@@ -4,9 +4,11 @@
 package statsd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net"
 	"sort"
 	"strconv"
@@ -17,7 +19,10 @@ import (
 	//"github.com/influxdata/telegraf/plugins/parsers/graphite"
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/inputs"
+	otelmetric "go.opentelemetry.io/otel/metric"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+	"github.com/aws/amazon-cloudwatch-agent/internal/supervisor"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/statsd/graphite"
 )
@@ -31,6 +36,17 @@ const (
 
 	defaultSeparator           = "_"
 	defaultAllowPendingMessage = 10000
+
+	// maxLineLength bounds a statsd line. The longer ones are dropped rather than split into buckets and tags.
+	maxLineLength = 8 * 1024
+	// invalidLogInterval limits how often the invalid lines are logged, so that a buggy application sending them
+	// continuously does not flood the agent log. They are all counted in the listener telemetry.
+	invalidLogInterval = time.Minute
+)
+
+var (
+	errInvalidLine = errors.New("Error Parsing statsd line")
+	errLineTooLong = fmt.Errorf("statsd line is longer than %d bytes", maxLineLength)
 )
 
 var dropwarn = "E! Error: statsd message queue full. " +
@@ -56,6 +72,9 @@ type Statsd struct {
 	// statsd protocol (http://docs.datadoghq.com/guides/dogstatsd/)
 	ParseDataDogTags bool
 
+	// StateFile keeps the delta counters that have not been published across restarts of the agent.
+	StateFile string `toml:"state_file"`
+
 	// UDPPacketSize is deprecated, it's only here for legacy support
 	// we now always create 1 max size buffer and then copy only what we need
 	// into the in channel
@@ -79,12 +98,24 @@ type Statsd struct {
 	sets     map[string]cachedset
 	timings  map[string]cachedtimings
 
+	// published is the time of the last Gather. restored are the counters a previous run did not publish.
+	published time.Time
+	restored  []counterWindow
+	// stateDirty is set when the state file holds windows that were published since it was written.
+	stateDirty bool
+
 	// bucket -> influx templates
 	Templates []string
 
 	listener *net.UDPConn
+	// telemetry records the packets received by the listener. Nil unless SetTelemetry is called.
+	telemetry *selftelemetry.Listener
 
 	graphiteParser *graphite.GraphiteParser
+
+	// invalidLogged is when an invalid line was last logged, and invalidSuppressed the lines not logged since.
+	invalidLogged     time.Time
+	invalidSuppressed int
 }
 
 // One statsd metric, form is <bucket>:<value>|<mtype>|@<samplerate>
@@ -166,6 +197,11 @@ const sampleConfig = `
   ## The aggregation interval for the metrics
   metric_aggregation_interval = "60s"
 
+  ## File that keeps the counters received since the last interval across
+  ## restarts, so they are neither dropped nor counted twice. Only used when
+  ## delete_counters is true.
+  # state_file = "/opt/aws/amazon-cloudwatch-agent/logs/state/statsd_counters.json"
+
 `
 
 func (_ *Statsd) SampleConfig() string {
@@ -191,12 +227,15 @@ func (s *Statsd) Gather(acc telegraf.Accumulator) error {
 		s.gauges = make(map[string]cachedgauge)
 	}
 
+	s.publishRestored(acc)
 	for _, metric := range s.counters {
 		acc.AddFields(metric.name, metric.fields, metric.tags, now)
 	}
 	if s.DeleteCounters {
 		s.counters = make(map[string]cachedcounter)
 	}
+	s.published = now
+	s.saveState(time.Time{})
 
 	for _, metric := range s.sets {
 		fields := make(map[string]interface{})
@@ -212,6 +251,16 @@ func (s *Statsd) Gather(acc telegraf.Accumulator) error {
 	return nil
 }
 
+// SetTelemetry records the packets, bytes and errors seen by the UDP listener with the collector's MeterProvider.
+func (s *Statsd) SetTelemetry(receiver string, mp otelmetric.MeterProvider) {
+	telemetry, err := selftelemetry.NewListener(mp, receiver, "udp")
+	if err != nil {
+		log.Printf("W! Unable to create statsd listener telemetry: %v", err)
+		return
+	}
+	s.telemetry = telemetry
+}
+
 func (s *Statsd) Start(_ telegraf.Accumulator) error {
 	// Make data structures
 	s.done = make(chan struct{})
@@ -221,6 +270,7 @@ func (s *Statsd) Start(_ telegraf.Accumulator) error {
 	s.counters = make(map[string]cachedcounter)
 	s.sets = make(map[string]cachedset)
 	s.timings = make(map[string]cachedtimings)
+	s.loadState()
 
 	if s.MetricSeparator == "" {
 		s.MetricSeparator = defaultSeparator
@@ -255,14 +305,17 @@ func (s *Statsd) udpListen() error {
 			n, _, err := s.listener.ReadFromUDP(buf)
 			if err != nil && !strings.Contains(err.Error(), "closed network") {
 				log.Printf("E! Error READ: %s\n", err.Error())
+				s.telemetry.RecordError(context.Background(), selftelemetry.ReasonInvalid)
 				continue
 			}
 			bufCopy := make([]byte, n)
 			copy(bufCopy, buf[:n])
+			s.telemetry.Record(context.Background(), n)
 
 			select {
 			case s.in <- bufCopy:
 			default:
+				s.telemetry.RecordError(context.Background(), selftelemetry.ReasonDropped)
 				s.drops++
 				if s.drops == 1 || s.AllowedPendingMessages == 0 || s.drops%s.AllowedPendingMessages == 0 {
 					log.Printf(dropwarn, s.drops)
@@ -274,7 +327,8 @@ func (s *Statsd) udpListen() error {
 
 // parser monitors the s.in channel, if there is a packet ready, it parses the
 // packet into statsd strings and then calls parseStatsdLine, which parses a
-// single statsd metric into a struct.
+// single statsd metric into a struct. A packet whose parsing panics is dropped
+// without stopping the parser.
 func (s *Statsd) parser() error {
 	defer s.wg.Done()
 	var packet []byte
@@ -283,20 +337,56 @@ func (s *Statsd) parser() error {
 		case <-s.done:
 			return nil
 		case packet = <-s.in:
-			lines := strings.Split(string(packet), "\n")
-			for _, line := range lines {
-				line = strings.TrimSpace(line)
-				if line != "" {
-					s.parseStatsdLine(line)
-				}
+			if err := supervisor.Call("input:statsd", func() error {
+				s.parsePacket(packet)
+				return nil
+			}); err != nil {
+				s.telemetry.RecordError(context.Background(), selftelemetry.ReasonInvalid)
 			}
 		}
 	}
 }
 
+func (s *Statsd) parsePacket(packet []byte) {
+	lines := strings.Split(string(packet), "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if err := s.parseStatsdLine(line); err != nil {
+			reason := selftelemetry.ReasonInvalid
+			if errors.Is(err, errLineTooLong) {
+				reason = selftelemetry.ReasonTooLarge
+			}
+			s.telemetry.RecordError(context.Background(), reason)
+		}
+	}
+}
+
+// logInvalid logs an invalid line at most once per invalidLogInterval, with the number of lines not logged since.
+func (s *Statsd) logInvalid(format string, args ...interface{}) {
+	now := time.Now()
+	if now.Sub(s.invalidLogged) < invalidLogInterval {
+		s.invalidSuppressed++
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if s.invalidSuppressed > 0 {
+		msg = fmt.Sprintf("%s (%d more invalid statsd lines since the last one logged)", msg, s.invalidSuppressed)
+	}
+	log.Print(msg)
+	s.invalidLogged = now
+	s.invalidSuppressed = 0
+}
+
 // parseStatsdLine will parse the given statsd line, validating it as it goes.
 // If the line is valid, it will be cached for the next call to Gather()
 func (s *Statsd) parseStatsdLine(line string) error {
+	if len(line) > maxLineLength {
+		s.logInvalid("E! Error: dropping a statsd line of %d bytes, the limit is %d", len(line), maxLineLength)
+		return errLineTooLong
+	}
 
 	lineTags := make(map[string]string)
 	if s.ParseDataDogTags {
@@ -338,8 +428,8 @@ func (s *Statsd) parseStatsdLine(line string) error {
 	// Validate splitting the line on ":"
 	bits := strings.Split(line, ":")
 	if len(bits) < 2 {
-		log.Printf("E! Error: splitting ':', Unable to parse metric: %s\n", line)
-		return errors.New("Error Parsing statsd line")
+		s.logInvalid("E! Error: splitting ':', Unable to parse metric: %s", line)
+		return errInvalidLine
 	}
 
 	// Extract bucket name from individual metric bits
@@ -354,22 +444,24 @@ func (s *Statsd) parseStatsdLine(line string) error {
 		// Validate splitting the bit on "|"
 		pipesplit := strings.Split(bit, "|")
 		if len(pipesplit) < 2 {
-			log.Printf("E! Error: splitting '|', Unable to parse metric: %s\n", line)
-			return errors.New("Error Parsing statsd line")
+			s.logInvalid("E! Error: splitting '|', Unable to parse metric: %s", line)
+			return errInvalidLine
 		} else if len(pipesplit) > 2 {
 			sr := pipesplit[2]
 			errmsg := "E! Error: parsing sample rate, %s, it must be in format like: " +
-				"@0.1, @0.5, etc. Ignoring sample rate for line: %s\n"
+				"@0.1, @0.5, etc. Ignoring sample rate for line: %s"
 			if strings.Contains(sr, "@") && len(sr) > 1 {
 				samplerate, err := strconv.ParseFloat(sr[1:], 64)
 				if err != nil {
-					log.Printf(errmsg, err.Error(), line)
+					s.logInvalid(errmsg, err.Error(), line)
+				} else if !(samplerate > 0 && samplerate <= 1) || math.IsInf(1/samplerate, 0) {
+					s.logInvalid(errmsg, "it must be greater than 0 and at most 1", line)
 				} else {
 					// sample rate successfully parsed
 					m.samplerate = samplerate
 				}
 			} else {
-				log.Printf(errmsg, "", line)
+				s.logInvalid(errmsg, "", line)
 			}
 		}
 
@@ -378,15 +470,15 @@ func (s *Statsd) parseStatsdLine(line string) error {
 		case "g", "c", "s", "ms", "h":
 			m.mtype = pipesplit[1]
 		default:
-			log.Printf("E! Error: Statsd Metric type %s unsupported", pipesplit[1])
-			return errors.New("Error Parsing statsd line")
+			s.logInvalid("E! Error: Statsd Metric type %s unsupported", pipesplit[1])
+			return errInvalidLine
 		}
 
 		// Parse the value
 		if strings.HasPrefix(pipesplit[0], "-") || strings.HasPrefix(pipesplit[0], "+") {
 			if m.mtype != "g" && m.mtype != "c" {
-				log.Printf("E! Error: +- values are only supported for gauges & counters: %s\n", line)
-				return errors.New("Error Parsing statsd line")
+				s.logInvalid("E! Error: +- values are only supported for gauges & counters: %s", line)
+				return errInvalidLine
 			}
 			m.additive = true
 		}
@@ -394,9 +486,9 @@ func (s *Statsd) parseStatsdLine(line string) error {
 		switch m.mtype {
 		case "g", "ms", "h":
 			v, err := strconv.ParseFloat(pipesplit[0], 64)
-			if err != nil {
-				log.Printf("E! Error: parsing value to float64: %s\n", line)
-				return errors.New("Error Parsing statsd line")
+			if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+				s.logInvalid("E! Error: parsing value to float64: %s", line)
+				return errInvalidLine
 			}
 			m.floatvalue = v
 		case "c":
@@ -404,15 +496,20 @@ func (s *Statsd) parseStatsdLine(line string) error {
 			v, err := strconv.ParseInt(pipesplit[0], 10, 64)
 			if err != nil {
 				v2, err2 := strconv.ParseFloat(pipesplit[0], 64)
-				if err2 != nil {
-					log.Printf("E! Error: parsing value to int64: %s\n", line)
-					return errors.New("Error Parsing statsd line")
+				if err2 != nil || !fitsInt64(v2) {
+					s.logInvalid("E! Error: parsing value to int64: %s", line)
+					return errInvalidLine
 				}
 				v = int64(v2)
 			}
 			// If a sample rate is given with a counter, divide value by the rate
 			if m.samplerate != 0 && m.mtype == "c" {
-				v = int64(float64(v) / m.samplerate)
+				scaled := float64(v) / m.samplerate
+				if !fitsInt64(scaled) {
+					s.logInvalid("E! Error: counter value overflows int64 with the sample rate: %s", line)
+					return errInvalidLine
+				}
+				v = int64(scaled)
 			}
 			m.intvalue = v
 		case "s":
@@ -454,6 +551,11 @@ func (s *Statsd) parseStatsdLine(line string) error {
 	return nil
 }
 
+// fitsInt64 reports whether v converts to an int64 without overflowing. NaN does not.
+func fitsInt64(v float64) bool {
+	return v >= math.MinInt64 && v < math.MaxInt64
+}
+
 // parseName parses the given bucket name with the list of bucket maps in the
 // config file. If there is a match, it will parse the name of the metric and
 // map of tags.
@@ -609,6 +711,9 @@ func (s *Statsd) Stop() {
 	s.listener.Close()
 	s.wg.Wait()
 	close(s.in)
+	s.Lock()
+	s.saveState(time.Now())
+	s.Unlock()
 	log.Println("D! Stopped the statsd service")
 }
 
@@ -4,13 +4,19 @@
 package statsd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/influxdata/telegraf/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/seh1"
@@ -32,6 +38,109 @@ func NewTestStatsd() *Statsd {
 	return &s
 }
 
+// Invalid lines should be counted in the listener telemetry
+func TestParser_Telemetry(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	s := NewTestStatsd()
+	s.SetTelemetry("telegraf_statsd", sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	s.wg.Add(1)
+	go s.parser()
+	defer func() {
+		close(s.done)
+		s.wg.Wait()
+	}()
+	s.in <- []byte("valid:45|c\ninvalid\nvalid.timer:45|ms")
+
+	assert.Eventually(t, func() bool {
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name == "receiver_errors" {
+					return m.Data.(metricdata.Sum[int64]).DataPoints[0].Value == 1
+				}
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+}
+
+// Lines that are too long should be counted apart from the invalid ones, and
+// a packet should never stop the parser
+func TestParser_TooLongLine(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	s := NewTestStatsd()
+	s.SetTelemetry("telegraf_statsd", sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	s.parsePacket([]byte("valid:1|c\nlong:1|c|#" + strings.Repeat("x", maxLineLength) + "\nvalid:1|c"))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var reasons []string
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "receiver_errors" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				reason, _ := dp.Attributes.Value("reason")
+				reasons = append(reasons, reason.AsString())
+			}
+		}
+	}
+	assert.Equal(t, []string{"too_large"}, reasons)
+	require.NoError(t, test_validate_counter("valid", 2, s.counters))
+}
+
+// Invalid lines should be logged at most once per interval
+func TestParse_InvalidLogRateLimited(t *testing.T) {
+	s := NewTestStatsd()
+	for i := 0; i < 3; i++ {
+		assert.Error(t, s.parseStatsdLine("invalid"))
+	}
+	assert.Equal(t, 2, s.invalidSuppressed)
+	s.invalidLogged = time.Now().Add(-invalidLogInterval)
+	assert.Error(t, s.parseStatsdLine("invalid"))
+	assert.Equal(t, 0, s.invalidSuppressed)
+}
+
+// Any line, however malformed, should either be rejected or cached with
+// values CloudWatch accepts
+func FuzzParseStatsdLine(f *testing.F) {
+	for _, line := range []string{
+		"valid:45|c",
+		"valid:45|c|@0.1",
+		"valid.timer:45|ms|@0.5",
+		"valid:+10|g:-3|g",
+		"users.unique:101|s",
+		"cpu.idle,host=localhost,region=us-west:1|g",
+		"users.online:1|c|@0.5|#country:china,environment:production",
+		"users.online:1|c|#sometagwithnovalue",
+		"scientific.notation:4.6968460083008E-5|h",
+	} {
+		f.Add(line, true)
+	}
+	f.Fuzz(func(t *testing.T, line string, dataDogTags bool) {
+		s := NewTestStatsd()
+		s.ParseDataDogTags = dataDogTags
+		s.Templates = []string{"measurement.measurement.field"}
+		if err := s.parseStatsdLine(line); err != nil {
+			return
+		}
+		for _, g := range s.gauges {
+			for _, v := range g.fields {
+				// additive gauges can overflow to infinity, but never become NaN
+				assert.False(t, math.IsNaN(v.(float64)), "gauge %s of %q", g.name, line)
+			}
+		}
+		for _, timing := range s.timings {
+			for _, v := range timing.fields {
+				d := v.(distribution.Distribution)
+				assert.False(t, math.IsNaN(d.Sum()) || math.IsInf(d.SampleCount(), 0), "timing %s of %q", timing.name, line)
+			}
+		}
+	})
+}
+
 // Valid lines should be parsed and their values should be cached
 func TestParse_ValidLines(t *testing.T) {
 	s := NewTestStatsd()
@@ -322,6 +431,11 @@ func TestParse_InvalidLines(t *testing.T) {
 		"invalid.value:foobar|c",
 		"invalid.value:d11|c",
 		"invalid.value:1d1|c",
+		"invalid.value:NaN|g",
+		"invalid.value:+Inf|ms",
+		"invalid.value:1e300|c",
+		"invalid.value:1e18|c|@0.001",
+		"too.long:1|c|#" + strings.Repeat("x", maxLineLength),
 	}
 	for _, line := range invalid_lines {
 		err := s.parseStatsdLine(line)
@@ -337,6 +451,11 @@ func TestParse_InvalidSampleRate(t *testing.T) {
 	invalid_lines := []string{
 		"invalid.sample.rate:45|c|0.1",
 		"invalid.sample.rate.2:45|c|@foo",
+		"invalid.sample.rate.3:45|c|@0",
+		"invalid.sample.rate.4:45|c|@-0.5",
+		"invalid.sample.rate.5:45|c|@2",
+		"invalid.sample.rate.6:45|c|@NaN",
+		"invalid.sample.rate.7:45|c|@1e-320",
 		"invalid.sample.rate:45|g|@0.1",
 		"invalid.sample.rate:45|s|@0.1",
 	}
@@ -363,6 +482,31 @@ func TestParse_InvalidSampleRate(t *testing.T) {
 			45,
 			s.counters,
 		},
+		{
+			"invalid_sample_rate_3",
+			45,
+			s.counters,
+		},
+		{
+			"invalid_sample_rate_4",
+			45,
+			s.counters,
+		},
+		{
+			"invalid_sample_rate_5",
+			45,
+			s.counters,
+		},
+		{
+			"invalid_sample_rate_6",
+			45,
+			s.counters,
+		},
+		{
+			"invalid_sample_rate_7",
+			45,
+			s.counters,
+		},
 	}
 
 	for _, test := range counter_validations {
@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package win_perf_counters
+
+import (
+	"strings"
+)
+
+// hasWildcard reports whether the counter name is a pattern that is expanded against the counters of the object.
+func hasWildcard(counter string) bool {
+	return strings.ContainsAny(counter, "*?")
+}
+
+// matchWildcard reports whether name matches pattern, ignoring case. "*" matches any sequence of characters,
+// including "/" which is common in counter names, and "?" matches a single character.
+func matchWildcard(pattern, name string) bool {
+	p := []rune(strings.ToLower(pattern))
+	n := []rune(strings.ToLower(name))
+	var pi, ni int
+	starP, starN := -1, 0
+	for ni < len(n) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == n[ni]):
+			pi++
+			ni++
+		case pi < len(p) && p[pi] == '*':
+			starP, starN = pi, ni
+			pi++
+		case starP >= 0:
+			pi = starP + 1
+			starN++
+			ni = starN
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
+
+// expandCounters resolves the counter patterns against the counters available on the object. Counters without
+// a wildcard are kept as they are, so that missing counters are still reported. Counters matching one of the
+// exclude patterns are dropped.
+func expandCounters(patterns, exclude, available []string) []string {
+	var counters []string
+	seen := map[string]bool{}
+	add := func(counter string) {
+		if seen[counter] || excluded(counter, exclude) {
+			return
+		}
+		seen[counter] = true
+		counters = append(counters, counter)
+	}
+	for _, pattern := range patterns {
+		if !hasWildcard(pattern) {
+			add(pattern)
+			continue
+		}
+		for _, counter := range available {
+			if matchWildcard(pattern, counter) {
+				add(counter)
+			}
+		}
+	}
+	return counters
+}
+
+func excluded(counter string, exclude []string) bool {
+	for _, pattern := range exclude {
+		if matchWildcard(pattern, counter) {
+			return true
+		}
+	}
+	return false
+}
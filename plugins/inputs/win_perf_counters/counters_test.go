@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package win_perf_counters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchWildcard(t *testing.T) {
+	testCases := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*", "Pages/sec", true},
+		{"Page*", "Pages/sec", true},
+		{"*/sec", "Pages/sec", true},
+		{"*/SEC", "Pages/sec", true},
+		{"% * Time", "% Processor Time", true},
+		{"% * Time", "% Processor Time Total", false},
+		{"Page?/sec", "Pages/sec", true},
+		{"Page?/sec", "Page/sec", false},
+		{"Cache Faults/sec", "Cache Faults/sec", true},
+		{"", "Cache Faults/sec", false},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.want, matchWildcard(testCase.pattern, testCase.name), "%s ~ %s", testCase.pattern, testCase.name)
+	}
+}
+
+func TestExpandCounters(t *testing.T) {
+	available := []string{"Batch Requests/sec", "SQL Compilations/sec", "SQL Re-Compilations/sec", "User Connections"}
+	assert.Equal(t, available, expandCounters([]string{"*"}, nil, available))
+	assert.Equal(t,
+		[]string{"Batch Requests/sec", "User Connections"},
+		expandCounters([]string{"*"}, []string{"SQL *"}, available))
+	// explicit counters are kept even if the object does not have them, and duplicates are dropped
+	assert.Equal(t,
+		[]string{"Missing", "User Connections", "Batch Requests/sec", "SQL Compilations/sec", "SQL Re-Compilations/sec"},
+		expandCounters([]string{"Missing", "User Connections", "*"}, nil, available))
+	assert.Empty(t, expandCounters([]string{"*"}, []string{"*"}, available))
+}
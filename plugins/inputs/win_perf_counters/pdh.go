@@ -208,6 +208,8 @@ var (
 	pdh_AddEnglishCounterW        *syscall.Proc
 	pdh_CloseQuery                *syscall.Proc
 	pdh_CollectQueryData          *syscall.Proc
+	pdh_EnumObjectItemsW          *syscall.Proc
+	pdh_EnumObjectsW              *syscall.Proc
 	pdh_GetFormattedCounterValue  *syscall.Proc
 	pdh_GetFormattedCounterArrayW *syscall.Proc
 	pdh_OpenQuery                 *syscall.Proc
@@ -223,6 +225,8 @@ func init() {
 	pdh_AddEnglishCounterW, _ = libpdhDll.FindProc("PdhAddEnglishCounterW") // XXX: only supported on versions > Vista.
 	pdh_CloseQuery = libpdhDll.MustFindProc("PdhCloseQuery")
 	pdh_CollectQueryData = libpdhDll.MustFindProc("PdhCollectQueryData")
+	pdh_EnumObjectItemsW = libpdhDll.MustFindProc("PdhEnumObjectItemsW")
+	pdh_EnumObjectsW = libpdhDll.MustFindProc("PdhEnumObjectsW")
 	pdh_GetFormattedCounterValue = libpdhDll.MustFindProc("PdhGetFormattedCounterValue")
 	pdh_GetFormattedCounterArrayW = libpdhDll.MustFindProc("PdhGetFormattedCounterArrayW")
 	pdh_OpenQuery = libpdhDll.MustFindProc("PdhOpenQuery")
@@ -406,6 +410,77 @@ func PdhOpenQuery(szDataSource uintptr, dwUserData uintptr, phQuery *PDH_HQUERY)
 	return uint32(ret)
 }
 
+// PERF_DETAIL_WIZARD includes the counters of all detail levels when enumerating objects.
+const PERF_DETAIL_WIZARD = 400
+
+// Refreshes the list of performance objects and counters known to pdh.dll, so that counters registered after
+// the first enumeration (e.g. by a newly installed SQL Server instance) are found by PdhEnumObjectItems.
+func PdhRefreshObjects() uint32 {
+	var size uint32
+	ret, _, _ := pdh_EnumObjectsW.Call(
+		0,
+		0,
+		0,
+		uintptr(unsafe.Pointer(&size)),
+		PERF_DETAIL_WIZARD,
+		1)
+
+	return uint32(ret)
+}
+
+// Returns the localized names of the counters of the object on the local computer.
+func PdhEnumObjectCounters(objectName string) ([]string, uint32) {
+	pobject, _ := syscall.UTF16PtrFromString(objectName)
+	var counterSize, instanceSize uint32
+	ret, _, _ := pdh_EnumObjectItemsW.Call(
+		0,
+		0,
+		uintptr(unsafe.Pointer(pobject)),
+		0,
+		uintptr(unsafe.Pointer(&counterSize)),
+		0,
+		uintptr(unsafe.Pointer(&instanceSize)),
+		PERF_DETAIL_WIZARD,
+		0)
+	if uint32(ret) != PDH_MORE_DATA {
+		return nil, uint32(ret)
+	}
+	if counterSize == 0 {
+		return nil, ERROR_SUCCESS
+	}
+
+	counterBuf := make([]uint16, counterSize)
+	instanceBuf := make([]uint16, instanceSize+1)
+	ret, _, _ = pdh_EnumObjectItemsW.Call(
+		0,
+		0,
+		uintptr(unsafe.Pointer(pobject)),
+		uintptr(unsafe.Pointer(&counterBuf[0])),
+		uintptr(unsafe.Pointer(&counterSize)),
+		uintptr(unsafe.Pointer(&instanceBuf[0])),
+		uintptr(unsafe.Pointer(&instanceSize)),
+		PERF_DETAIL_WIZARD,
+		0)
+	if uint32(ret) != ERROR_SUCCESS {
+		return nil, uint32(ret)
+	}
+
+	// the counters are a list of null terminated strings, ending with an empty string
+	var counters []string
+	for start := 0; start < len(counterBuf); {
+		end := start
+		for end < len(counterBuf) && counterBuf[end] != 0 {
+			end++
+		}
+		if end == start {
+			break
+		}
+		counters = append(counters, syscall.UTF16ToString(counterBuf[start:end]))
+		start = end + 1
+	}
+	return counters, ERROR_SUCCESS
+}
+
 // Validates a path. Will return ERROR_SUCCESS when ok, or PDH_CSTATUS_BAD_COUNTERNAME when the path is
 // erroneous.
 func PdhValidatePath(path string) uint32 {
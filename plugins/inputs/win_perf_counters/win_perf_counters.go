@@ -11,12 +11,17 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
 	"github.com/influxdata/telegraf/plugins/inputs"
 )
 
+// defaultCountersRefreshInterval is how often the counters of objects with wildcard counters are rediscovered.
+const defaultCountersRefreshInterval = 5 * time.Minute
+
 var sampleConfig = `
   ## By default this plugin returns basic CPU and Disk statistics.
   ## See the README file for more examples.
@@ -42,6 +47,15 @@ var sampleConfig = `
     # Print out when the performance counter is missing from object, counter or instance.
     # WarnOnMissing = false
 
+  [[inputs.win_perf_counters.object]]
+    # Every counter of the object, rediscovered every CountersRefreshInterval (default 5m) so that counters
+    # added later are picked up. "*" and "?" can be used in Counters and CountersExclude.
+    ObjectName = "SQLServer:SQL Statistics"
+    Instances = ["------"]
+    Counters = ["*"]
+    CountersExclude = ["*Re-Compilations*"]
+    Measurement = "win_sql"
+
   [[inputs.win_perf_counters.object]]
     # Disk times and queues
     ObjectName = "LogicalDisk"
@@ -77,21 +91,28 @@ type Win_PerfCounters struct {
 	DisableReplacer bool
 	TestName        string
 	PreVistaSupport bool
-	Object          []perfobject
+	// CountersRefreshInterval is how often wildcard counters are expanded again.
+	CountersRefreshInterval config.Duration
+	Object                  []perfobject
 	// Valid queries end up in this map.
 	gItemList        map[int]*item
 	testConfigParsed bool
 	testObject       string
+	// hasWildcards is set when a counter of any object is a pattern, so the config is periodically parsed
+	// again to pick up counters that were added to the object.
+	hasWildcards bool
+	lastRefresh  time.Time
 }
 
 type perfobject struct {
-	ObjectName    string
-	Counters      []string
-	Instances     []string
-	Measurement   string
-	WarnOnMissing bool
-	FailOnMissing bool
-	IncludeTotal  bool
+	ObjectName      string
+	Counters        []string
+	CountersExclude []string
+	Instances       []string
+	Measurement     string
+	WarnOnMissing   bool
+	FailOnMissing   bool
+	IncludeTotal    bool
 }
 
 // Parsed configuration ends up here after it has been validated for valid
@@ -177,10 +198,12 @@ func (m *Win_PerfCounters) ParseConfig(metrics *itemList) error {
 
 	m.configParsed = true
 	m.gItemList = make(map[int]*item)
+	m.hasWildcards = false
+	m.lastRefresh = time.Now()
 
 	if len(m.Object) > 0 {
 		for _, PerfObject := range m.Object {
-			for _, counter := range PerfObject.Counters {
+			for _, counter := range m.objectCounters(PerfObject) {
 				for _, instance := range PerfObject.Instances {
 					objectname := PerfObject.ObjectName
 
@@ -216,6 +239,27 @@ func (m *Win_PerfCounters) ParseConfig(metrics *itemList) error {
 	}
 }
 
+// objectCounters returns the counters to query for the object, with the wildcard counters expanded.
+func (m *Win_PerfCounters) objectCounters(PerfObject perfobject) []string {
+	wildcard := false
+	for _, counter := range PerfObject.Counters {
+		wildcard = wildcard || hasWildcard(counter)
+	}
+	if !wildcard {
+		return expandCounters(PerfObject.Counters, PerfObject.CountersExclude, nil)
+	}
+	m.hasWildcards = true
+	available, ret := PdhEnumObjectCounters(PerfObject.ObjectName)
+	if ret != ERROR_SUCCESS && (PerfObject.FailOnMissing || PerfObject.WarnOnMissing) {
+		log.Printf("W! Unable to list the counters of object '%s'. Error: %s", PerfObject.ObjectName, PdhFormatError(ret))
+	}
+	counters := expandCounters(PerfObject.Counters, PerfObject.CountersExclude, available)
+	if m.PrintValid {
+		fmt.Printf("Object '%s' has %d matching counters\n", PerfObject.ObjectName, len(counters))
+	}
+	return counters
+}
+
 func (m *Win_PerfCounters) Cleanup(metrics *itemList) {
 	// Cleanup
 
@@ -247,6 +291,17 @@ func (m *Win_PerfCounters) Gather(acc telegraf.Accumulator) error {
 		m.configParsed = false
 	}
 
+	refreshInterval := time.Duration(m.CountersRefreshInterval)
+	if refreshInterval <= 0 {
+		refreshInterval = defaultCountersRefreshInterval
+	}
+	if m.configParsed && m.hasWildcards && time.Since(m.lastRefresh) >= refreshInterval {
+		// rediscover the counters so that the ones added to the objects since the last parse are collected
+		m.CleanupTestMode()
+		PdhRefreshObjects()
+		m.configParsed = false
+	}
+
 	// We only need to parse the config during the init, it uses the global variable after.
 	if m.configParsed == false {
 
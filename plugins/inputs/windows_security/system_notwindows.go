@@ -0,0 +1,10 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package windows_security
+
+func newSystem() (system, error) {
+	return nil, errNotSupported
+}
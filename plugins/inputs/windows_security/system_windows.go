@@ -0,0 +1,219 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package windows_security
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+	"github.com/go-ole/go-ole/oleutil"
+	"github.com/yusufpapurcu/wmi"
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	defenderNamespace = `root\Microsoft\Windows\Defender`
+	defenderQuery     = "SELECT AntivirusEnabled, RealTimeProtectionEnabled, AntivirusSignatureAge, QuickScanAge FROM MSFT_MpComputerStatus"
+
+	// updateCriteria selects the applicable software updates which are neither installed nor hidden by an admin.
+	updateCriteria = "IsInstalled=0 and IsHidden=0 and Type='Software'"
+	// securityUpdatesCategory is the ID of the Security Updates classification of Windows Update.
+	securityUpdatesCategory = "0fa1201d-4330-4fa8-8ae9-b877473b6441"
+
+	// sFalse is returned by CoInitializeEx when COM is already initialized on the thread.
+	sFalse = 0x00000001
+	// wbemEInvalidNamespace and wbemEInvalidClass are returned by WMI when Defender is not installed.
+	wbemEInvalidNamespace = 0x8004100e
+	wbemEInvalidClass     = 0x80041010
+)
+
+// rebootKeys are the registry keys which exist while a reboot is pending, e.g. to finish installing updates.
+var rebootKeys = []string{
+	`SOFTWARE\Microsoft\Windows\CurrentVersion\Component Based Servicing\RebootPending`,
+	`SOFTWARE\Microsoft\Windows\CurrentVersion\WindowsUpdate\Auto Update\RebootRequired`,
+}
+
+// mpComputerStatus is the MSFT_MpComputerStatus class of WMI.
+type mpComputerStatus struct {
+	AntivirusEnabled          bool
+	RealTimeProtectionEnabled bool
+	AntivirusSignatureAge     uint32
+	QuickScanAge              uint32
+}
+
+type windowsSystem struct{}
+
+func newSystem() (system, error) {
+	return windowsSystem{}, nil
+}
+
+func (windowsSystem) defender() (defenderStatus, error) {
+	var dst []mpComputerStatus
+	if err := wmi.QueryNamespace(defenderQuery, &dst, defenderNamespace); err != nil {
+		if isWMIError(err, wbemEInvalidNamespace, wbemEInvalidClass) {
+			return defenderStatus{}, errDefenderNotInstalled
+		}
+		return defenderStatus{}, err
+	}
+	if len(dst) == 0 {
+		return defenderStatus{}, errDefenderNotInstalled
+	}
+	return defenderStatus{
+		AntivirusEnabled:          dst[0].AntivirusEnabled,
+		RealTimeProtectionEnabled: dst[0].RealTimeProtectionEnabled,
+		SignatureAge:              dst[0].AntivirusSignatureAge,
+		QuickScanAge:              dst[0].QuickScanAge,
+	}, nil
+}
+
+// isWMIError returns true if err is one of the WMI error codes, which are reported either as the result of the call
+// or in its exception info.
+func isWMIError(err error, codes ...uint32) bool {
+	var oleErr *ole.OleError
+	if !errors.As(err, &oleErr) {
+		return false
+	}
+	code := uint32(oleErr.Code())
+	if info, ok := oleErr.SubError().(ole.EXCEPINFO); ok {
+		code = info.SCODE()
+	}
+	for _, c := range codes {
+		if code == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (windowsSystem) rebootPending() (bool, error) {
+	for _, path := range rebootKeys {
+		key, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.QUERY_VALUE)
+		if err == nil {
+			key.Close()
+			return true, nil
+		}
+		if !errors.Is(err, registry.ErrNotExist) {
+			return false, err
+		}
+	}
+	// the files replaced by an update which were in use are renamed on the next boot
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Control\Session Manager`, registry.QUERY_VALUE)
+	if err != nil {
+		return false, err
+	}
+	defer key.Close()
+	renames, _, err := key.GetStringsValue("PendingFileRenameOperations")
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(renames) > 0, nil
+}
+
+// missingUpdates searches the updates with the Windows Update Agent API. The COM objects are used from a single
+// thread, which is locked for the search.
+func (windowsSystem) missingUpdates(online bool) (updateCounts, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if err := ole.CoInitializeEx(0, ole.COINIT_MULTITHREADED); err != nil {
+		var oleErr *ole.OleError
+		if !errors.As(err, &oleErr) || oleErr.Code() != sFalse {
+			return updateCounts{}, err
+		}
+	}
+	defer ole.CoUninitialize()
+
+	unknown, err := oleutil.CreateObject("Microsoft.Update.Session")
+	if err != nil {
+		return updateCounts{}, err
+	}
+	defer unknown.Release()
+	session, err := unknown.QueryInterface(ole.IID_IDispatch)
+	if err != nil {
+		return updateCounts{}, err
+	}
+	defer session.Release()
+	searcherRaw, err := oleutil.CallMethod(session, "CreateUpdateSearcher")
+	if err != nil {
+		return updateCounts{}, err
+	}
+	defer searcherRaw.Clear()
+	searcher := searcherRaw.ToIDispatch()
+	if _, err = oleutil.PutProperty(searcher, "Online", online); err != nil {
+		return updateCounts{}, err
+	}
+	resultRaw, err := oleutil.CallMethod(searcher, "Search", updateCriteria)
+	if err != nil {
+		return updateCounts{}, err
+	}
+	defer resultRaw.Clear()
+	updatesRaw, err := oleutil.GetProperty(resultRaw.ToIDispatch(), "Updates")
+	if err != nil {
+		return updateCounts{}, err
+	}
+	defer updatesRaw.Clear()
+	updates := updatesRaw.ToIDispatch()
+	count, err := oleutil.GetProperty(updates, "Count")
+	if err != nil {
+		return updateCounts{}, err
+	}
+
+	var counts updateCounts
+	for i := 0; i < int(count.Val); i++ {
+		itemRaw, err := oleutil.GetProperty(updates, "Item", i)
+		if err != nil {
+			return updateCounts{}, err
+		}
+		item := itemRaw.ToIDispatch()
+		counts.Missing++
+		if severity, err := oleutil.GetProperty(item, "MsrcSeverity"); err == nil {
+			if severity.ToString() == "Critical" {
+				counts.Critical++
+			}
+			severity.Clear()
+		}
+		if isSecurityUpdate(item) {
+			counts.Security++
+		}
+		itemRaw.Clear()
+	}
+	return counts, nil
+}
+
+// isSecurityUpdate returns true if the update is classified as a security update.
+func isSecurityUpdate(update *ole.IDispatch) bool {
+	categoriesRaw, err := oleutil.GetProperty(update, "Categories")
+	if err != nil {
+		return false
+	}
+	defer categoriesRaw.Clear()
+	categories := categoriesRaw.ToIDispatch()
+	count, err := oleutil.GetProperty(categories, "Count")
+	if err != nil {
+		return false
+	}
+	for i := 0; i < int(count.Val); i++ {
+		categoryRaw, err := oleutil.GetProperty(categories, "Item", i)
+		if err != nil {
+			return false
+		}
+		id, err := oleutil.GetProperty(categoryRaw.ToIDispatch(), "CategoryID")
+		categoryRaw.Clear()
+		if err != nil {
+			continue
+		}
+		security := strings.EqualFold(id.ToString(), securityUpdatesCategory)
+		id.Clear()
+		if security {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_security
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+const (
+	measurement = "windows_security"
+
+	fieldDefenderAntivirusEnabled          = "defender_antivirus_enabled"
+	fieldDefenderRealTimeProtectionEnabled = "defender_real_time_protection_enabled"
+	fieldDefenderSignatureAge              = "defender_signature_age_days"
+	fieldDefenderQuickScanAge              = "defender_quick_scan_age_days"
+	fieldRebootPending                     = "reboot_pending"
+	fieldUpdatesMissing                    = "updates_missing"
+	fieldUpdatesMissingCritical            = "updates_missing_critical"
+	fieldUpdatesMissingSecurity            = "updates_missing_security"
+
+	defaultUpdateCheckInterval = time.Hour
+	// notAvailable is the age Defender reports for a signature update or a scan that never happened.
+	notAvailable = math.MaxUint32
+)
+
+var (
+	errNotSupported = errors.New("the windows_security input is only supported on Windows")
+	// errDefenderNotInstalled is returned when the host has no Defender to query, e.g. when another antivirus
+	// replaced it.
+	errDefenderNotInstalled = errors.New("no Microsoft Defender on the host")
+)
+
+// defenderStatus is the state of Microsoft Defender Antivirus.
+type defenderStatus struct {
+	AntivirusEnabled          bool
+	RealTimeProtectionEnabled bool
+	// SignatureAge and QuickScanAge are in days.
+	SignatureAge uint32
+	QuickScanAge uint32
+}
+
+// updateCounts are the applicable updates which are not installed.
+type updateCounts struct {
+	Missing  int
+	Critical int
+	Security int
+}
+
+// system reads the security posture of the host.
+type system interface {
+	defender() (defenderStatus, error)
+	rebootPending() (bool, error)
+	// missingUpdates searches the updates which are not installed, which takes from seconds to minutes.
+	missingUpdates(online bool) (updateCounts, error)
+}
+
+// WindowsSecurity reports the state of Microsoft Defender, whether a reboot is pending, and the number of missing
+// updates, so that the security posture of a Windows fleet can be alarmed on.
+type WindowsSecurity struct {
+	UpdateCheckInterval config.Duration `toml:"update_check_interval"`
+	SearchOnline        bool            `toml:"search_online"`
+	Log                 telegraf.Logger `toml:"-"`
+
+	system system
+	// now is replaced in tests.
+	now func() time.Time
+
+	defenderMissing bool
+
+	mu          sync.Mutex
+	updates     *updateCounts
+	lastSearch  time.Time
+	searching   bool
+	searchGroup sync.WaitGroup
+}
+
+func (w *WindowsSecurity) Description() string {
+	return "Report the state of Microsoft Defender, pending reboots and missing updates of Windows"
+}
+
+func (w *WindowsSecurity) SampleConfig() string {
+	return `
+  ## How often the missing updates are searched.
+  update_check_interval = "1h"
+  ## Search the update service instead of the updates known to the last scan of Windows Update. It takes longer
+  ## and needs access to the update service.
+  search_online = false
+`
+}
+
+func (w *WindowsSecurity) Init() error {
+	if w.system == nil {
+		s, err := newSystem()
+		if err != nil {
+			return err
+		}
+		w.system = s
+	}
+	if w.UpdateCheckInterval <= 0 {
+		w.UpdateCheckInterval = config.Duration(defaultUpdateCheckInterval)
+	}
+	if w.now == nil {
+		w.now = time.Now
+	}
+	return nil
+}
+
+func (w *WindowsSecurity) Gather(acc telegraf.Accumulator) error {
+	fields := map[string]interface{}{}
+	if !w.defenderMissing {
+		status, err := w.system.defender()
+		switch {
+		case errors.Is(err, errDefenderNotInstalled):
+			// the other metrics are still reported, without querying Defender every collection
+			w.Log.Infof("Microsoft Defender is not installed, its metrics are not reported")
+			w.defenderMissing = true
+		case err != nil:
+			acc.AddError(err)
+		default:
+			fields[fieldDefenderAntivirusEnabled] = boolValue(status.AntivirusEnabled)
+			fields[fieldDefenderRealTimeProtectionEnabled] = boolValue(status.RealTimeProtectionEnabled)
+			if status.SignatureAge != notAvailable {
+				fields[fieldDefenderSignatureAge] = status.SignatureAge
+			}
+			if status.QuickScanAge != notAvailable {
+				fields[fieldDefenderQuickScanAge] = status.QuickScanAge
+			}
+		}
+	}
+	if pending, err := w.system.rebootPending(); err != nil {
+		acc.AddError(err)
+	} else {
+		fields[fieldRebootPending] = boolValue(pending)
+	}
+	if updates := w.checkUpdates(); updates != nil {
+		fields[fieldUpdatesMissing] = updates.Missing
+		fields[fieldUpdatesMissingCritical] = updates.Critical
+		fields[fieldUpdatesMissingSecurity] = updates.Security
+	}
+	if len(fields) > 0 {
+		acc.AddGauge(measurement, fields, nil)
+	}
+	return nil
+}
+
+// checkUpdates returns the result of the last search of the missing updates, and starts a new search in the
+// background when the last one is older than the update check interval. It returns nil until the first search ends.
+func (w *WindowsSecurity) checkUpdates() *updateCounts {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.now()
+	if !w.searching && (w.lastSearch.IsZero() || now.Sub(w.lastSearch) >= time.Duration(w.UpdateCheckInterval)) {
+		w.searching = true
+		w.lastSearch = now
+		w.searchGroup.Add(1)
+		go w.search()
+	}
+	return w.updates
+}
+
+// search runs a search of the missing updates. A failed search keeps the result of the previous one, and is retried
+// after the update check interval.
+func (w *WindowsSecurity) search() {
+	defer w.searchGroup.Done()
+	start := time.Now()
+	counts, err := w.system.missingUpdates(w.SearchOnline)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.searching = false
+	if err != nil {
+		w.Log.Warnf("Unable to search the missing updates: %v", err)
+		return
+	}
+	w.Log.Debugf("Found %d missing updates in %v", counts.Missing, time.Since(start))
+	w.updates = &counts
+}
+
+func boolValue(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func init() {
+	inputs.Add("windows_security", func() telegraf.Input {
+		return &WindowsSecurity{UpdateCheckInterval: config.Duration(defaultUpdateCheckInterval)}
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_security
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSystem struct {
+	mu          sync.Mutex
+	status      defenderStatus
+	defenderErr error
+	defenderN   int
+	pending     bool
+	updates     updateCounts
+	updatesErr  error
+	searches    int
+	online      bool
+}
+
+func (f *fakeSystem) defender() (defenderStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.defenderN++
+	return f.status, f.defenderErr
+}
+
+func (f *fakeSystem) rebootPending() (bool, error) {
+	return f.pending, nil
+}
+
+func (f *fakeSystem) missingUpdates(online bool) (updateCounts, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.searches++
+	f.online = online
+	return f.updates, f.updatesErr
+}
+
+func newTestWindowsSecurity(t *testing.T, s *fakeSystem, now *time.Time) *WindowsSecurity {
+	w := &WindowsSecurity{
+		UpdateCheckInterval: config.Duration(time.Hour),
+		SearchOnline:        true,
+		Log:                 testutil.Logger{},
+		system:              s,
+		now:                 func() time.Time { return *now },
+	}
+	require.NoError(t, w.Init())
+	return w
+}
+
+func TestGather(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := &fakeSystem{
+		status:  defenderStatus{AntivirusEnabled: true, RealTimeProtectionEnabled: false, SignatureAge: 3, QuickScanAge: notAvailable},
+		pending: true,
+		updates: updateCounts{Missing: 5, Critical: 1, Security: 2},
+	}
+	w := newTestWindowsSecurity(t, s, &now)
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, w.Gather(acc))
+	// the missing updates are reported once the search in the background ends
+	acc.AssertContainsFields(t, measurement, map[string]interface{}{
+		fieldDefenderAntivirusEnabled:          1,
+		fieldDefenderRealTimeProtectionEnabled: 0,
+		fieldDefenderSignatureAge:              uint32(3),
+		fieldRebootPending:                     1,
+	})
+	w.searchGroup.Wait()
+	assert.True(t, s.online)
+
+	acc.ClearMetrics()
+	require.NoError(t, w.Gather(acc))
+	acc.AssertContainsFields(t, measurement, map[string]interface{}{
+		fieldDefenderAntivirusEnabled:          1,
+		fieldDefenderRealTimeProtectionEnabled: 0,
+		fieldDefenderSignatureAge:              uint32(3),
+		fieldRebootPending:                     1,
+		fieldUpdatesMissing:                    5,
+		fieldUpdatesMissingCritical:            1,
+		fieldUpdatesMissingSecurity:            2,
+	})
+	w.searchGroup.Wait()
+	assert.Equal(t, 1, s.searches, "the updates are searched every update check interval")
+}
+
+func TestSearchUpdates(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := &fakeSystem{updates: updateCounts{Missing: 2}}
+	w := newTestWindowsSecurity(t, s, &now)
+
+	assert.Nil(t, w.checkUpdates())
+	w.searchGroup.Wait()
+	assert.Equal(t, &updateCounts{Missing: 2}, w.checkUpdates())
+
+	// a failed search keeps the previous result
+	s.updatesErr = errors.New("service unavailable")
+	now = now.Add(time.Hour)
+	assert.Equal(t, &updateCounts{Missing: 2}, w.checkUpdates())
+	w.searchGroup.Wait()
+	assert.Equal(t, &updateCounts{Missing: 2}, w.checkUpdates())
+	assert.Equal(t, 2, s.searches)
+
+	s.updatesErr = nil
+	s.updates = updateCounts{}
+	now = now.Add(time.Hour)
+	w.checkUpdates()
+	w.searchGroup.Wait()
+	assert.Equal(t, &updateCounts{}, w.checkUpdates())
+	assert.Equal(t, 3, s.searches)
+}
+
+func TestDefenderNotInstalled(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := &fakeSystem{defenderErr: errDefenderNotInstalled}
+	w := newTestWindowsSecurity(t, s, &now)
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, w.Gather(acc))
+	require.NoError(t, w.Gather(acc))
+	w.searchGroup.Wait()
+	assert.Equal(t, 1, s.defenderN, "Defender is not queried again")
+	assert.Empty(t, acc.Errors)
+	for _, m := range acc.Metrics {
+		assert.NotContains(t, m.Fields, fieldDefenderAntivirusEnabled)
+		assert.Contains(t, m.Fields, fieldRebootPending)
+	}
+}
+
+func TestDefenderError(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := &fakeSystem{defenderErr: errors.New("access denied")}
+	w := newTestWindowsSecurity(t, s, &now)
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, w.Gather(acc))
+	require.NoError(t, w.Gather(acc))
+	w.searchGroup.Wait()
+	assert.Equal(t, 2, s.defenderN)
+	assert.Len(t, acc.Errors, 2)
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math/rand"
 	"strings"
 	"sync"
 	"time"
@@ -37,19 +38,47 @@ type Aggregator interface {
 
 var _ Aggregator = (*aggregator)(nil)
 
+// AggregationAlignment places the aggregation windows on the wall clock. Windows always start on a multiple of the
+// aggregation interval since the Unix epoch, shifted by Offset, so agents with the same interval and offset
+// aggregate over the same windows. Delay postpones the flush of each window, so that a fleet of agents does not
+// publish at the same instant.
+type AggregationAlignment struct {
+	Offset time.Duration
+	Delay  time.Duration
+}
+
+// NewAggregationAlignment returns an alignment with the offset and a random delay of up to jitter.
+func NewAggregationAlignment(offset, jitter time.Duration) AggregationAlignment {
+	alignment := AggregationAlignment{Offset: offset}
+	if jitter > 0 {
+		alignment.Delay = time.Duration(rand.Int63n(int64(jitter)))
+	}
+	return alignment
+}
+
+// forDuration reduces the offset and delay to less than one aggregation interval.
+func (a AggregationAlignment) forDuration(d time.Duration) AggregationAlignment {
+	if d <= 0 {
+		return a
+	}
+	return AggregationAlignment{Offset: a.Offset % d, Delay: a.Delay % d}
+}
+
 type aggregator struct {
 	durationMap  map[time.Duration]*durationAggregator
 	metricChan   chan<- *aggregationDatum
 	shutdownChan <-chan struct{}
 	wg           *sync.WaitGroup
+	alignment    AggregationAlignment
 }
 
-func NewAggregator(metricChan chan<- *aggregationDatum, shutdownChan <-chan struct{}, wg *sync.WaitGroup) Aggregator {
+func NewAggregator(metricChan chan<- *aggregationDatum, shutdownChan <-chan struct{}, wg *sync.WaitGroup, alignment AggregationAlignment) Aggregator {
 	return &aggregator{
 		durationMap:  make(map[time.Duration]*durationAggregator),
 		metricChan:   metricChan,
 		shutdownChan: shutdownChan,
 		wg:           wg,
+		alignment:    alignment,
 	}
 }
 
@@ -75,7 +104,7 @@ func (agg *aggregator) AddMetric(m *aggregationDatum) {
 	aggDurationMapKey := m.aggregationInterval.Truncate(time.Second)
 	durationAgg, ok := agg.durationMap[aggDurationMapKey]
 	if !ok {
-		durationAgg = newDurationAggregator(aggDurationMapKey, agg.metricChan, agg.shutdownChan, agg.wg)
+		durationAgg = newDurationAggregator(aggDurationMapKey, agg.alignment, agg.metricChan, agg.shutdownChan, agg.wg)
 		agg.durationMap[aggDurationMapKey] = durationAgg
 	}
 	// auto configure high resolution
@@ -87,6 +116,7 @@ func (agg *aggregator) AddMetric(m *aggregationDatum) {
 
 type durationAggregator struct {
 	aggregationDuration time.Duration
+	alignment           AggregationAlignment
 	metricChan          chan<- *aggregationDatum
 	shutdownChan        <-chan struct{}
 	wg                  *sync.WaitGroup
@@ -97,12 +127,14 @@ type durationAggregator struct {
 }
 
 func newDurationAggregator(durationInSeconds time.Duration,
+	alignment AggregationAlignment,
 	metricChan chan<- *aggregationDatum,
 	shutdownChan <-chan struct{},
 	wg *sync.WaitGroup) *durationAggregator {
 
 	durationAgg := &durationAggregator{
 		aggregationDuration: durationInSeconds,
+		alignment:           alignment.forDuration(durationInSeconds),
 		metricChan:          metricChan,
 		shutdownChan:        shutdownChan,
 		wg:                  wg,
@@ -120,7 +152,7 @@ func (durationAgg *durationAggregator) aggregating() {
 	// Sleep to align the interval to the wall clock.
 	// This initial sleep is not interrupted if the aggregator gets shutdown.
 	now := time.Now()
-	time.Sleep(now.Truncate(durationAgg.aggregationDuration).Add(durationAgg.aggregationDuration).Sub(now))
+	time.Sleep(durationAgg.windowStart(now).Add(durationAgg.aggregationDuration + durationAgg.alignment.Delay).Sub(now))
 	durationAgg.ticker = time.NewTicker(durationAgg.aggregationDuration)
 	defer durationAgg.ticker.Stop()
 	for {
@@ -134,7 +166,7 @@ func (durationAgg *durationAggregator) aggregating() {
 				continue
 			}
 			// https://docs.aws.amazon.com/AmazonCloudWatch/latest/APIReference/API_MetricDatum.html
-			aggregatedTime := m.Timestamp.Truncate(durationAgg.aggregationDuration)
+			aggregatedTime := durationAgg.windowStart(*m.Timestamp)
 			metricMapKey := getAggregationKey(m, aggregatedTime.Unix())
 			aggregatedMetric, ok := durationAgg.metricMap[metricMapKey]
 			if !ok {
@@ -164,11 +196,11 @@ func (durationAgg *durationAggregator) aggregating() {
 					aggregatedMetric.distribution.AddDistribution(m.distribution)
 				}
 			}
-		case <-durationAgg.ticker.C:
-			durationAgg.flush()
+		case now := <-durationAgg.ticker.C:
+			durationAgg.flush(now.Add(-durationAgg.alignment.Delay))
 		case <-durationAgg.shutdownChan:
 			log.Printf("D! CloudWatch: aggregating routine receives the shutdown signal, do the final flush now for aggregation interval %v", durationAgg.aggregationDuration)
-			durationAgg.flush()
+			durationAgg.flush(time.Time{})
 			log.Printf("D! CloudWatch: aggregating routine receives the shutdown signal, exiting.")
 			durationAgg.wg.Done()
 			return
@@ -180,9 +212,19 @@ func (durationAgg *durationAggregator) addMetric(m *aggregationDatum) {
 	durationAgg.aggregationChan <- m
 }
 
-func (durationAgg *durationAggregator) flush() {
-	for _, v := range durationAgg.metricMap {
+// windowStart returns the start of the aggregation window that t falls into.
+func (durationAgg *durationAggregator) windowStart(t time.Time) time.Time {
+	offset := durationAgg.alignment.Offset
+	return t.Add(-offset).Truncate(durationAgg.aggregationDuration).Add(offset)
+}
+
+// flush sends the metrics of the windows that ended at or before cutoff. A zero cutoff sends all of them.
+func (durationAgg *durationAggregator) flush(cutoff time.Time) {
+	for k, v := range durationAgg.metricMap {
+		if !cutoff.IsZero() && durationAgg.windowStart(*v.Timestamp).Add(durationAgg.aggregationDuration).After(cutoff) {
+			continue
+		}
 		durationAgg.metricChan <- v
+		delete(durationAgg.metricMap, k)
 	}
-	durationAgg.metricMap = make(map[string]*aggregationDatum)
 }
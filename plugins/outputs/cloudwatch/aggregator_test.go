@@ -174,11 +174,64 @@ func TestDurationAggregator_aggregating(t *testing.T) {
 	close(durationAgg.metricChan)
 }
 
+func TestAggregationAlignment(t *testing.T) {
+	assert.Equal(t, AggregationAlignment{Offset: 15 * time.Second}, NewAggregationAlignment(15*time.Second, 0))
+	for i := 0; i < 100; i++ {
+		a := NewAggregationAlignment(0, 10*time.Second)
+		assert.GreaterOrEqual(t, a.Delay, time.Duration(0))
+		assert.Less(t, a.Delay, 10*time.Second)
+	}
+	a := AggregationAlignment{Offset: 75 * time.Second, Delay: 130 * time.Second}
+	assert.Equal(t, AggregationAlignment{Offset: 15 * time.Second, Delay: 10 * time.Second}, a.forDuration(time.Minute))
+}
+
+func TestDurationAggregator_windowStart(t *testing.T) {
+	durationAgg := &durationAggregator{
+		aggregationDuration: time.Minute,
+		alignment:           AggregationAlignment{Offset: 15 * time.Second},
+	}
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, base.Add(-45*time.Second), durationAgg.windowStart(base))
+	assert.Equal(t, base.Add(15*time.Second), durationAgg.windowStart(base.Add(15*time.Second)))
+	assert.Equal(t, base.Add(15*time.Second), durationAgg.windowStart(base.Add(74*time.Second)))
+
+	durationAgg.alignment = AggregationAlignment{}
+	assert.Equal(t, base, durationAgg.windowStart(base.Add(59*time.Second)))
+}
+
+// TestDurationAggregator_flushEndedWindows verifies that a delayed flush only sends the windows that have ended and
+// keeps the current one.
+func TestDurationAggregator_flushEndedWindows(t *testing.T) {
+	metricChan := make(chan *aggregationDatum, metricChanBufferSize)
+	durationAgg := &durationAggregator{
+		aggregationDuration: time.Minute,
+		alignment:           AggregationAlignment{Delay: 10 * time.Second},
+		metricChan:          metricChan,
+		metricMap:           make(map[string]*aggregationDatum),
+	}
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	tags := map[string]string{"d1key": "d1value"}
+	ended := makeTestMetric("metric", 1, base.Add(30*time.Second), tags, time.Minute, "Percent")
+	current := makeTestMetric("metric", 2, base.Add(65*time.Second), tags, time.Minute, "Percent")
+	durationAgg.metricMap["ended"] = ended
+	durationAgg.metricMap["current"] = current
+
+	// the ticker fires at 10:01:10, which flushes the windows that ended by 10:01:00
+	durationAgg.flush(base.Add(70 * time.Second).Add(-durationAgg.alignment.Delay))
+	assert.Len(t, metricChan, 1)
+	assert.Equal(t, ended, <-metricChan)
+	assert.Len(t, durationAgg.metricMap, 1)
+
+	durationAgg.flush(time.Time{})
+	assert.Equal(t, current, <-metricChan)
+	assert.Empty(t, durationAgg.metricMap)
+}
+
 func testPreparation() (chan *aggregationDatum, chan struct{}, Aggregator) {
 	distribution.NewDistribution = seh1.NewSEH1Distribution
 	metricChan := make(chan *aggregationDatum, metricChanBufferSize)
 	shutdownChan := make(chan struct{})
-	aggregator := NewAggregator(metricChan, shutdownChan, &wg)
+	aggregator := NewAggregator(metricChan, shutdownChan, &wg, AggregationAlignment{})
 	return metricChan, shutdownChan, aggregator
 }
 
@@ -0,0 +1,144 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+)
+
+const (
+	compositeAlarmSuffix = "health"
+	alarmQueryID         = "q1"
+)
+
+// alarmName is the name of the alarm created for the template.
+func (c *AlarmsConfig) alarmName(template string) string {
+	return c.NamePrefix + "-" + template
+}
+
+func (c *AlarmsConfig) tags() []*cloudwatch.Tag {
+	keys := make([]string, 0, len(c.Tags))
+	for key := range c.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tags := make([]*cloudwatch.Tag, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, &cloudwatch.Tag{Key: aws.String(key), Value: aws.String(c.Tags[key])})
+	}
+	return tags
+}
+
+// createAlarms creates the alarms that do not exist yet, then records it in the state file. The alarms are only
+// created once, so the alarms edited or deleted since are left alone on the next start.
+func (c *CloudWatch) createAlarms(ctx context.Context) error {
+	cfg := c.config.Alarms
+	if cfg.StateFile != "" {
+		if _, err := os.Stat(cfg.StateFile); err == nil {
+			log.Printf("D! The alarms were already created according to %s", cfg.StateFile)
+			return nil
+		}
+	}
+	names := make([]string, 0, len(cfg.Templates)+1)
+	for _, template := range cfg.Templates {
+		names = append(names, cfg.alarmName(template.Name))
+	}
+	compositeName := cfg.alarmName(compositeAlarmSuffix)
+	existing, err := c.existingAlarms(append(names, compositeName))
+	if err != nil {
+		return fmt.Errorf("unable to describe the alarms: %w", err)
+	}
+	tags := cfg.tags()
+	for i, template := range cfg.Templates {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if existing.Contains(names[i]) {
+			continue
+		}
+		input := &cloudwatch.PutMetricAlarmInput{
+			AlarmName:          aws.String(names[i]),
+			ComparisonOperator: aws.String(template.ComparisonOperator),
+			EvaluationPeriods:  aws.Int64(int64(template.EvaluationPeriods)),
+			Threshold:          aws.Float64(template.Threshold),
+			Metrics: []*cloudwatch.MetricDataQuery{{
+				Id:         aws.String(alarmQueryID),
+				Expression: aws.String(template.Query),
+				Period:     aws.Int64(int64(template.Period.Seconds())),
+				ReturnData: aws.Bool(true),
+			}},
+			Tags: tags,
+		}
+		if template.Description != "" {
+			input.AlarmDescription = aws.String(template.Description)
+		}
+		if template.TreatMissingData != "" {
+			input.TreatMissingData = aws.String(template.TreatMissingData)
+		}
+		if !cfg.Composite && len(cfg.AlarmActions) > 0 {
+			input.AlarmActions = aws.StringSlice(cfg.AlarmActions)
+		}
+		if _, err = c.svc.PutMetricAlarm(input); err != nil {
+			return fmt.Errorf("unable to create alarm %s: %w", names[i], err)
+		}
+		log.Printf("I! Created alarm %s", names[i])
+	}
+	if cfg.Composite && len(names) > 0 && !existing.Contains(compositeName) {
+		rules := make([]string, 0, len(names))
+		for _, name := range names {
+			rules = append(rules, fmt.Sprintf("ALARM(%q)", name))
+		}
+		input := &cloudwatch.PutCompositeAlarmInput{
+			AlarmName:        aws.String(compositeName),
+			AlarmRule:        aws.String(strings.Join(rules, " OR ")),
+			AlarmDescription: aws.String("In ALARM while any alarm created by the CloudWatch agent for the host is"),
+			Tags:             tags,
+		}
+		if len(cfg.AlarmActions) > 0 {
+			input.AlarmActions = aws.StringSlice(cfg.AlarmActions)
+		}
+		if _, err = c.svc.PutCompositeAlarm(input); err != nil {
+			return fmt.Errorf("unable to create composite alarm %s: %w", compositeName, err)
+		}
+		log.Printf("I! Created composite alarm %s", compositeName)
+	}
+	if cfg.StateFile != "" {
+		if err = os.MkdirAll(filepath.Dir(cfg.StateFile), 0755); err == nil {
+			err = os.WriteFile(cfg.StateFile, []byte(strings.Join(names, "\n")+"\n"), 0644)
+		}
+		if err != nil {
+			log.Printf("W! Unable to record the creation of the alarms in %s: %v", cfg.StateFile, err)
+		}
+	}
+	return nil
+}
+
+// existingAlarms returns the names of the metric and composite alarms that already exist.
+func (c *CloudWatch) existingAlarms(names []string) (collections.Set[string], error) {
+	existing := collections.NewSet[string]()
+	err := c.svc.DescribeAlarmsPages(&cloudwatch.DescribeAlarmsInput{
+		AlarmNames: aws.StringSlice(names),
+		AlarmTypes: aws.StringSlice([]string{cloudwatch.AlarmTypeMetricAlarm, cloudwatch.AlarmTypeCompositeAlarm}),
+	}, func(output *cloudwatch.DescribeAlarmsOutput, _ bool) bool {
+		for _, alarm := range output.MetricAlarms {
+			existing.Add(aws.StringValue(alarm.AlarmName))
+		}
+		for _, alarm := range output.CompositeAlarms {
+			existing.Add(aws.StringValue(alarm.AlarmName))
+		}
+		return true
+	})
+	return existing, err
+}
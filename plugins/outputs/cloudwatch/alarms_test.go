@@ -0,0 +1,126 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+)
+
+func (svc *mockCloudWatchClient) DescribeAlarmsPages(
+	input *cloudwatch.DescribeAlarmsInput,
+	fn func(*cloudwatch.DescribeAlarmsOutput, bool) bool,
+) error {
+	args := svc.Called(input)
+	fn(args.Get(0).(*cloudwatch.DescribeAlarmsOutput), true)
+	return args.Error(1)
+}
+
+func (svc *mockCloudWatchClient) PutMetricAlarm(
+	input *cloudwatch.PutMetricAlarmInput,
+) (*cloudwatch.PutMetricAlarmOutput, error) {
+	args := svc.Called(input)
+	return &cloudwatch.PutMetricAlarmOutput{}, args.Error(0)
+}
+
+func (svc *mockCloudWatchClient) PutCompositeAlarm(
+	input *cloudwatch.PutCompositeAlarmInput,
+) (*cloudwatch.PutCompositeAlarmOutput, error) {
+	args := svc.Called(input)
+	return &cloudwatch.PutCompositeAlarmOutput{}, args.Error(0)
+}
+
+func testAlarmsConfig(stateFile string) *AlarmsConfig {
+	return &AlarmsConfig{
+		NamePrefix:   "CWAgent-i-123",
+		AlarmActions: []string{"arn:aws:sns:us-east-1:123456789012:alerts"},
+		Composite:    true,
+		Tags:         map[string]string{"amazon-cloudwatch-agent:host": "i-123"},
+		StateFile:    stateFile,
+		Templates: []AlarmTemplate{
+			{
+				Name:               "disk_full",
+				Query:              `SELECT MAX("disk_used_percent") FROM "CWAgent" WHERE "InstanceId" = 'i-123' AND "path" = '/'`,
+				Period:             5 * time.Minute,
+				EvaluationPeriods:  2,
+				ComparisonOperator: cloudwatch.ComparisonOperatorGreaterThanThreshold,
+				Threshold:          90,
+			},
+			{
+				Name:               "memory",
+				Query:              `SELECT MAX("mem_used_percent") FROM "CWAgent" WHERE "InstanceId" = 'i-123'`,
+				Period:             5 * time.Minute,
+				EvaluationPeriods:  3,
+				ComparisonOperator: cloudwatch.ComparisonOperatorGreaterThanThreshold,
+				Threshold:          90,
+			},
+		},
+	}
+}
+
+func TestCreateAlarms(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state", "alarms")
+	svc := new(mockCloudWatchClient)
+	svc.On("DescribeAlarmsPages", mock.Anything).Return(&cloudwatch.DescribeAlarmsOutput{
+		MetricAlarms: []*cloudwatch.MetricAlarm{{AlarmName: aws.String("CWAgent-i-123-memory")}},
+	}, nil).Once()
+	svc.On("PutMetricAlarm", mock.Anything).Return(nil).Once()
+	svc.On("PutCompositeAlarm", mock.Anything).Return(nil).Once()
+	c := &CloudWatch{svc: svc, config: &Config{Alarms: testAlarmsConfig(stateFile)}}
+
+	require.NoError(t, c.createAlarms(context.Background()))
+	svc.AssertExpectations(t)
+	// the existing alarm is left alone
+	input := svc.Calls[1].Arguments.Get(0).(*cloudwatch.PutMetricAlarmInput)
+	assert.Equal(t, "CWAgent-i-123-disk_full", *input.AlarmName)
+	assert.Empty(t, input.AlarmActions)
+	assert.Equal(t, int64(300), *input.Metrics[0].Period)
+	assert.Equal(t, "amazon-cloudwatch-agent:host", *input.Tags[0].Key)
+	composite := svc.Calls[2].Arguments.Get(0).(*cloudwatch.PutCompositeAlarmInput)
+	assert.Equal(t, "CWAgent-i-123-health", *composite.AlarmName)
+	assert.Equal(t, `ALARM("CWAgent-i-123-disk_full") OR ALARM("CWAgent-i-123-memory")`, *composite.AlarmRule)
+	assert.Equal(t, []*string{aws.String("arn:aws:sns:us-east-1:123456789012:alerts")}, composite.AlarmActions)
+
+	// the alarms are not created again once recorded in the state file
+	_, err := os.Stat(stateFile)
+	require.NoError(t, err)
+	require.NoError(t, c.createAlarms(context.Background()))
+	svc.AssertNumberOfCalls(t, "DescribeAlarmsPages", 1)
+}
+
+func TestCreateAlarmsWithoutComposite(t *testing.T) {
+	svc := new(mockCloudWatchClient)
+	svc.On("DescribeAlarmsPages", mock.Anything).Return(&cloudwatch.DescribeAlarmsOutput{}, nil)
+	svc.On("PutMetricAlarm", mock.Anything).Return(nil)
+	cfg := testAlarmsConfig("")
+	cfg.Composite = false
+	c := &CloudWatch{svc: svc, config: &Config{Alarms: cfg}}
+
+	require.NoError(t, c.createAlarms(context.Background()))
+	svc.AssertNumberOfCalls(t, "PutMetricAlarm", 2)
+	svc.AssertNotCalled(t, "PutCompositeAlarm", mock.Anything)
+	input := svc.Calls[1].Arguments.Get(0).(*cloudwatch.PutMetricAlarmInput)
+	assert.Equal(t, []*string{aws.String("arn:aws:sns:us-east-1:123456789012:alerts")}, input.AlarmActions)
+}
+
+func TestAlarmsConfigValidate(t *testing.T) {
+	cfg := testAlarmsConfig("")
+	assert.NoError(t, cfg.Validate())
+	cfg.Templates = append(cfg.Templates, cfg.Templates[0])
+	assert.ErrorContains(t, cfg.Validate(), "duplicate alarm template")
+	cfg.Templates = []AlarmTemplate{{Name: "disk_full", Query: "SELECT 1", EvaluationPeriods: 1, ComparisonOperator: "GreaterThanThreshold"}}
+	assert.ErrorContains(t, cfg.Validate(), "'period'")
+	cfg.NamePrefix = ""
+	assert.ErrorContains(t, cfg.Validate(), "'alarms::name_prefix'")
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,11 +27,17 @@ import (
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
 	"github.com/aws/amazon-cloudwatch-agent/handlers"
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
+	"github.com/aws/amazon-cloudwatch-agent/internal/failover"
 	"github.com/aws/amazon-cloudwatch-agent/internal/publisher"
 	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
+	"github.com/aws/amazon-cloudwatch-agent/internal/supervisor"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch/cloudwatchiface"
 )
 
@@ -48,6 +55,17 @@ const (
 	MaxDimensions                         = 30
 )
 
+// retryPolicy backs off from backoffRetryBase, doubling for every retry up to defaultRetryCount, then waits a
+// minute.
+var retryPolicy = retryer.NewPolicy("cloudwatch", retryer.Backoff{
+	Base:  backoffRetryBase,
+	Steps: defaultRetryCount + 1,
+	Max:   time.Minute,
+})
+
+// watermarks tracks the timestamps of the metrics consumed and published, to report when the publishing stalls.
+var watermarks = selftelemetry.Watermarks.Register("cloudwatch")
+
 const (
 	opPutLogEvents  = "PutLogEvents"
 	opPutMetricData = "PutMetricData"
@@ -72,6 +90,22 @@ type CloudWatch struct {
 	aggregatorShutdownChan chan struct{}
 	aggregatorWaitGroup    sync.WaitGroup
 	lastRequestBytes       int
+	// cancelAlarms stops the creation of the alarms on shutdown.
+	cancelAlarms context.CancelFunc
+	// catalog collects the metadata of the metrics published, when it is exported.
+	catalog *metadataCatalog
+	// regions picks the region the requests are sent to, when there are failover regions.
+	regions *failover.Regions
+	// failoverSvcs are the clients of the failover regions.
+	failoverSvcs map[string]cloudwatchiface.CloudWatchAPI
+	// series tracks the series published, to end them with a 0 once they stop reporting.
+	series *staleness.Tracker[string, staleSeries]
+}
+
+// staleSeries is the datum ending a series, without its value and timestamp.
+type staleSeries struct {
+	entity string
+	datum  cloudwatch.MetricDatum
 }
 
 // Compile time interface check.
@@ -99,23 +133,48 @@ func (c *CloudWatch) Start(_ context.Context, host component.Host) error {
 	configProvider := credentialConfig.Credentials()
 	logger := models.NewLogger("outputs", "cloudwatch", "")
 	logThrottleRetryer := retryer.NewLogThrottleRetryer(logger)
-	svc := cloudwatch.New(
-		configProvider,
-		&aws.Config{
-			Endpoint: aws.String(c.config.EndpointOverride),
-			Retryer:  logThrottleRetryer,
-			LogLevel: configaws.SDKLogLevel(),
-			Logger:   configaws.SDKLogger{},
-		})
-	svc.Handlers.Build.PushBackNamed(handlers.NewRequestCompressionHandler([]string{opPutLogEvents, opPutMetricData}))
-	if c.config.MiddlewareID != nil {
-		awsmiddleware.TryConfigure(c.logger, host, *c.config.MiddlewareID, awsmiddleware.SDKv1(&svc.Handlers))
+	newClient := func(region string) *cloudwatch.CloudWatch {
+		svc := cloudwatch.New(
+			configProvider,
+			&aws.Config{
+				Region:   aws.String(region),
+				Endpoint: aws.String(c.config.EndpointOverride),
+				Retryer:  logThrottleRetryer,
+				LogLevel: configaws.SDKLogLevel(),
+				Logger:   configaws.SDKLogger{},
+			})
+		svc.Handlers.Build.PushBackNamed(handlers.NewRequestCompressionHandler([]string{opPutLogEvents, opPutMetricData}))
+		svc.Handlers.UnmarshalError.PushBackNamed(retryer.RetryAfterHandler)
+		if c.config.MiddlewareID != nil {
+			awsmiddleware.TryConfigure(c.logger, host, *c.config.MiddlewareID, awsmiddleware.SDKv1(&svc.Handlers))
+		}
+		return svc
 	}
 	//Format unique roll up list
 	c.config.RollupDimensions = GetUniqueRollupList(c.config.RollupDimensions)
-	c.svc = svc
+	c.svc = newClient(c.config.Region)
+	c.regions = failover.New("cloudwatch", c.config.Region, c.config.FailoverRegions, c.config.FailoverAfter)
+	if c.regions != nil {
+		c.failoverSvcs = map[string]cloudwatchiface.CloudWatchAPI{}
+		for _, region := range c.config.FailoverRegions {
+			c.failoverSvcs[region] = newClient(region)
+		}
+	}
 	c.retryer = logThrottleRetryer
+	if c.config.MetadataExport != nil {
+		c.catalog = acquireCatalog(*c.config.MetadataExport, func() metadataLogsAPI {
+			return cloudwatchlogs.New(configProvider, &aws.Config{
+				LogLevel: configaws.SDKLogLevel(),
+				Logger:   configaws.SDKLogger{},
+			})
+		})
+	}
 	c.startRoutines()
+	if c.config.Alarms != nil && len(c.config.Alarms.Templates) > 0 {
+		var ctx context.Context
+		ctx, c.cancelAlarms = context.WithCancel(context.Background())
+		supervisor.Go(ctx, "output:cloudwatch/alarms", supervisor.DefaultBackoff, c.createAlarms)
+	}
 	return nil
 }
 
@@ -125,11 +184,22 @@ func (c *CloudWatch) startRoutines() {
 	c.datumBatchChan = make(chan map[string][]*cloudwatch.MetricDatum, datumBatchChanBufferSize)
 	c.shutdownChan = make(chan struct{})
 	c.aggregatorShutdownChan = make(chan struct{})
-	c.aggregator = NewAggregator(c.metricChan, c.aggregatorShutdownChan, &c.aggregatorWaitGroup)
+	alignment := NewAggregationAlignment(c.config.AggregationOffset, c.config.AggregationJitter)
+	c.aggregator = NewAggregator(c.metricChan, c.aggregatorShutdownChan, &c.aggregatorWaitGroup, alignment)
 	perRequestConstSize := overallConstPerRequestSize + len(c.config.Namespace) + namespaceOverheads
 	c.metricDatumBatch = newMetricDatumBatch(c.config.MaxDatumsPerCall, perRequestConstSize)
-	go c.pushMetricDatum()
-	go c.publish()
+	if c.config.Staleness.Policy == staleness.PolicyZero {
+		c.series = staleness.NewTracker[string, staleSeries](c.config.Staleness.Timeout)
+	}
+	// a panic while batching or publishing restarts the routine instead of crashing the agent
+	supervisor.Go(context.Background(), "output:cloudwatch/batch", supervisor.DefaultBackoff, func(context.Context) error {
+		c.pushMetricDatum()
+		return nil
+	})
+	supervisor.Go(context.Background(), "output:cloudwatch/publish", supervisor.DefaultBackoff, func(context.Context) error {
+		c.publish()
+		return nil
+	})
 }
 
 func (c *CloudWatch) Shutdown(ctx context.Context) error {
@@ -145,9 +215,15 @@ func (c *CloudWatch) Shutdown(ctx context.Context) error {
 	if metricChanLen, datumBatchChanLen := len(c.metricChan), len(c.datumBatchChan); metricChanLen != 0 || datumBatchChanLen != 0 {
 		log.Printf("D! CloudWatch Close, metricChan length = %v, datumBatchChan length = %v.", metricChanLen, datumBatchChanLen)
 	}
+	if c.cancelAlarms != nil {
+		c.cancelAlarms()
+	}
 	close(c.shutdownChan)
 	c.publisher.Close()
 	c.retryer.Stop()
+	if c.catalog != nil {
+		c.catalog.release()
+	}
 	log.Println("D! Stopped the CloudWatch output plugin")
 	return nil
 }
@@ -157,9 +233,15 @@ func (c *CloudWatch) Shutdown(ctx context.Context) error {
 // This method can block when publishing is backed up.
 func (c *CloudWatch) ConsumeMetrics(ctx context.Context, metrics pmetric.Metrics) error {
 	datums := ConvertOtelMetrics(metrics)
+	c.catalog.recordMetrics(c.config.Namespace, metrics)
+	var latest time.Time
 	for _, d := range datums {
+		if d.Timestamp != nil && d.Timestamp.After(latest) {
+			latest = *d.Timestamp
+		}
 		c.aggregator.AddMetric(d)
 	}
+	watermarks.Collected(latest)
 	return nil
 }
 
@@ -173,32 +255,12 @@ func (c *CloudWatch) pushMetricDatum() {
 		select {
 		case metric := <-c.metricChan:
 			entity, datums := c.BuildMetricDatum(metric)
-			numberOfPartitions := len(datums)
-			/* We currently do not account for entity information as a part of the payload size.
-			This is by design and should be revisited once the SDK protocol changes.
-			In the meantime there has been a payload limit increase applied in the background to accommodate this decision
-
-			Otherwise to include entity size you would do something like this:
-			c.metricDatumBatch.Size += calculateEntitySize(entity)
-
-			In addition to calculating the size of the entity object, you might also need to account for any extra bytes that get
-			added on an individual metric level when entity data is present (depends on how the sdk protocol changes)—something like:
-			c.metricDatumBatch.Size += payload(datums[i], entityPresent=true)
-
-			File diff that could be useful: https://github.com/aws/amazon-cloudwatch-agent/compare/af960d7...459ef7c
-			*/
-			for i := 0; i < numberOfPartitions; i++ {
-				entityStr := entityToString(entity)
-				c.metricDatumBatch.Partition[entityStr] = append(c.metricDatumBatch.Partition[entityStr], datums[i])
-				c.metricDatumBatch.Size += payload(datums[i])
-				c.metricDatumBatch.Count++
-				if c.metricDatumBatch.isFull() {
-					// if batch is full
-					c.datumBatchChan <- c.metricDatumBatch.Partition
-					c.metricDatumBatch.clear()
-				}
-			}
+			c.catalog.recordDatums(c.config.Namespace, datums)
+			entityStr := entityToString(entity)
+			c.trackSeries(entityStr, datums, time.Now())
+			c.addToBatch(entityStr, datums)
 		case <-ticker.C:
+			c.endStaleSeries(time.Now())
 			if c.timeToPublish(c.metricDatumBatch) {
 				// if the time to publish comes
 				c.lastRequestBytes = c.metricDatumBatch.Size
@@ -211,6 +273,84 @@ func (c *CloudWatch) pushMetricDatum() {
 	}
 }
 
+// addToBatch adds the datums of an entity to the batch, and queues the batch up for sending each time it is full.
+func (c *CloudWatch) addToBatch(entityStr string, datums []*cloudwatch.MetricDatum) {
+	/* We currently do not account for entity information as a part of the payload size.
+	This is by design and should be revisited once the SDK protocol changes.
+	In the meantime there has been a payload limit increase applied in the background to accommodate this decision
+
+	Otherwise to include entity size you would do something like this:
+	c.metricDatumBatch.Size += calculateEntitySize(entity)
+
+	In addition to calculating the size of the entity object, you might also need to account for any extra bytes that get
+	added on an individual metric level when entity data is present (depends on how the sdk protocol changes)—something like:
+	c.metricDatumBatch.Size += payload(datums[i], entityPresent=true)
+
+	File diff that could be useful: https://github.com/aws/amazon-cloudwatch-agent/compare/af960d7...459ef7c
+	*/
+	for _, datum := range datums {
+		c.metricDatumBatch.Partition[entityStr] = append(c.metricDatumBatch.Partition[entityStr], datum)
+		c.metricDatumBatch.Size += payload(datum)
+		c.metricDatumBatch.Count++
+		if c.metricDatumBatch.isFull() {
+			// if batch is full
+			c.datumBatchChan <- c.metricDatumBatch.Partition
+			c.metricDatumBatch.clear()
+		}
+	}
+}
+
+// trackSeries records that the series of the datums reported, when they are ended once they stop reporting. A
+// rolled up series goes on as long as one of the series it is rolled up from reports.
+func (c *CloudWatch) trackSeries(entityStr string, datums []*cloudwatch.MetricDatum, now time.Time) {
+	if c.series == nil {
+		return
+	}
+	for _, datum := range datums {
+		key := seriesKey(entityStr, datum)
+		if c.series.Seen(key, now) {
+			continue
+		}
+		c.series.Add(key, staleSeries{
+			entity: entityStr,
+			datum: cloudwatch.MetricDatum{
+				MetricName:        datum.MetricName,
+				Dimensions:        datum.Dimensions,
+				Unit:              datum.Unit,
+				StorageResolution: datum.StorageResolution,
+			},
+		}, now)
+	}
+}
+
+// endStaleSeries publishes a 0 for each series that did not report for the staleness timeout.
+func (c *CloudWatch) endStaleSeries(now time.Time) {
+	if c.series == nil {
+		return
+	}
+	for _, series := range c.series.Expire(now) {
+		datum := series.datum
+		datum.Value = aws.Float64(0)
+		datum.Timestamp = aws.Time(now)
+		c.addToBatch(series.entity, []*cloudwatch.MetricDatum{&datum})
+	}
+}
+
+// seriesKey tells the series apart by entity, metric name and dimensions.
+func seriesKey(entityStr string, datum *cloudwatch.MetricDatum) string {
+	var b strings.Builder
+	b.WriteString(entityStr)
+	b.WriteByte(0)
+	b.WriteString(aws.StringValue(datum.MetricName))
+	for _, dimension := range datum.Dimensions {
+		b.WriteByte(0)
+		b.WriteString(aws.StringValue(dimension.Name))
+		b.WriteByte('=')
+		b.WriteString(aws.StringValue(dimension.Value))
+	}
+	return b.String()
+}
+
 type MetricDatumBatch struct {
 	MaxDatumsPerCall    int
 	Partition           map[string][]*cloudwatch.MetricDatum
@@ -339,13 +479,9 @@ func (c *CloudWatch) pushMetricDatumBatch() {
 	}
 }
 
-// backoffSleep sleeps some amount of time based on number of retries done.
-func (c *CloudWatch) backoffSleep() {
-	d := 1 * time.Minute
-	if c.retries <= defaultRetryCount {
-		d = backoffRetryBase * time.Duration(1<<c.retries)
-	}
-	d = (d / 2) + publishJitter(d/2)
+// backoffSleep sleeps some amount of time based on number of retries done and the error of the last attempt.
+func (c *CloudWatch) backoffSleep(err error) {
+	d := retryPolicy.Wait(c.retries, err)
 	log.Printf("W! cloudwatch: %v retries, going to sleep %v ms before retrying.",
 		c.retries, d.Milliseconds())
 	c.retries++
@@ -385,12 +521,18 @@ func (c *CloudWatch) WriteToCloudWatch(req interface{}) {
 
 	var err error
 	for i := 0; i < defaultRetryCount; i++ {
-		_, err = c.svc.PutMetricData(params)
+		region, svc, isFailover := c.client()
+		input := params
+		if isFailover {
+			input = withFailoverDimension(params, c.config.Region)
+		}
+		_, err = svc.PutMetricData(input)
+		c.regions.Record(region, err)
 		if err != nil {
 			awsErr, ok := err.(awserr.Error)
 			if !ok {
 				log.Printf("E! cloudwatch: Cannot cast PutMetricData error %v into awserr.Error.", err)
-				c.backoffSleep()
+				c.backoffSleep(err)
 				continue
 			}
 			switch awsErr.Code() {
@@ -398,21 +540,81 @@ func (c *CloudWatch) WriteToCloudWatch(req interface{}) {
 				log.Printf("W! cloudwatch: PutMetricData, error: %s, message: %s",
 					awsErr.Code(),
 					awsErr.Message())
-				c.backoffSleep()
+				c.backoffSleep(err)
 				continue
 
 			default:
 				log.Printf("E! cloudwatch: code: %s, message: %s, original error: %+v", awsErr.Code(), awsErr.Message(), awsErr.OrigErr())
-				c.backoffSleep()
+				c.backoffSleep(err)
+				// the next attempt may be sent to a failover region
+				if c.regions != nil && failover.IsUnavailable(err) {
+					continue
+				}
 			}
 		} else {
 			c.retries = 0
+			retryPolicy.Succeeded()
+			watermarks.Exported(latestTimestamp(entityToMetricDatum))
 		}
 		break
 	}
 	if err != nil {
-		log.Println("E! cloudwatch: WriteToCloudWatch failure, err: ", err)
+		retryPolicy.Dropped()
+		log.Printf("E! cloudwatch: WriteToCloudWatch failure, err: %v %s", err, errcode.Tag(errcode.Record(err)))
+	}
+}
+
+// client returns the client of the region to send the next request to, and whether it is a failover region.
+func (c *CloudWatch) client() (string, cloudwatchiface.CloudWatchAPI, bool) {
+	if c.regions == nil {
+		return c.config.Region, c.svc, false
+	}
+	region := c.regions.Next()
+	if !c.regions.IsFailover(region) {
+		return region, c.svc, false
+	}
+	return region, c.failoverSvcs[region], true
+}
+
+// withFailoverDimension returns a copy of the request with the dimension marking the metrics published to a failover
+// region, so that they can be told apart from the metrics of the hosts of that region. The repeated dimension barely
+// grows the compressed request.
+func withFailoverDimension(params *cloudwatch.PutMetricDataInput, primary string) *cloudwatch.PutMetricDataInput {
+	marked := *params
+	marked.MetricData = withDimension(params.MetricData, primary)
+	marked.EntityMetricData = make([]*cloudwatch.EntityMetricData, len(params.EntityMetricData))
+	for i, entityMetricData := range params.EntityMetricData {
+		copied := *entityMetricData
+		copied.MetricData = withDimension(entityMetricData.MetricData, primary)
+		marked.EntityMetricData[i] = &copied
+	}
+	return &marked
+}
+
+func withDimension(datums []*cloudwatch.MetricDatum, primary string) []*cloudwatch.MetricDatum {
+	marked := make([]*cloudwatch.MetricDatum, len(datums))
+	for i, datum := range datums {
+		copied := *datum
+		if len(copied.Dimensions) < MaxDimensions {
+			copied.Dimensions = append(copied.Dimensions[:len(copied.Dimensions):len(copied.Dimensions)],
+				&cloudwatch.Dimension{Name: aws.String(failover.MarkerKey), Value: aws.String(primary)})
+		}
+		marked[i] = &copied
+	}
+	return marked
+}
+
+// latestTimestamp returns the latest timestamp of the datums of a request.
+func latestTimestamp(entityToMetricDatum map[string][]*cloudwatch.MetricDatum) time.Time {
+	var latest time.Time
+	for _, datums := range entityToMetricDatum {
+		for _, datum := range datums {
+			if datum.Timestamp != nil && datum.Timestamp.After(latest) {
+				latest = *datum.Timestamp
+			}
+		}
 	}
+	return latest
 }
 
 // BuildMetricDatum may just return the datum as-is.
@@ -11,12 +11,14 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
 	"github.com/stretchr/testify/assert"
@@ -25,7 +27,10 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/failover"
 	"github.com/aws/amazon-cloudwatch-agent/internal/publisher"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch/cloudwatchiface"
@@ -516,6 +521,67 @@ func TestPublish(t *testing.T) {
 	cw.Shutdown(ctx)
 }
 
+// panicOnceQueue panics on its first Enqueue, which runs on the publish routine.
+type panicOnceQueue struct {
+	publisher.Queue
+	panicked atomic.Bool
+}
+
+func (q *panicOnceQueue) Enqueue(req interface{}) {
+	if q.panicked.CompareAndSwap(false, true) {
+		panic("enqueue")
+	}
+	q.Queue.Enqueue(req)
+}
+
+// TestPublishRestart verifies the publish routine still flushes once restarted after a panic, and still stops on
+// Shutdown.
+func TestPublishRestart(t *testing.T) {
+	const component = "output:cloudwatch/publish"
+	status := func() selftelemetry.ComponentStatus {
+		for _, s := range selftelemetry.Components.Statuses() {
+			if s.Component == component {
+				return s
+			}
+		}
+		return selftelemetry.ComponentStatus{}
+	}
+	restarts := status().Restarts
+
+	var calls atomic.Int32
+	svc := new(mockCloudWatchClient)
+	svc.On("PutMetricData", mock.Anything).Return(&cloudwatch.PutMetricDataOutput{}, nil).Run(func(mock.Arguments) {
+		calls.Add(1)
+	})
+	queue := &panicOnceQueue{Queue: publisher.NewNonBlockingFifoQueue(10)}
+	cw := &CloudWatch{
+		svc: svc,
+		config: &Config{
+			ForceFlushInterval: time.Second,
+			MaxDatumsPerCall:   defaultMaxDatumsPerCall,
+			MaxValuesPerDatum:  defaultMaxValuesPerDatum,
+		},
+	}
+	cw.publisher, _ = publisher.NewPublisher(queue, 10, 2*time.Second, cw.WriteToCloudWatch)
+	cw.startRoutines()
+	ctx := context.Background()
+
+	// the batch being published when the routine panics is lost
+	require.NoError(t, cw.ConsumeMetrics(ctx, createTestMetrics(1, 1, 1, "")))
+	require.Eventually(t, queue.panicked.Load, 5*time.Second, 10*time.Millisecond)
+	assert.Zero(t, calls.Load())
+
+	require.NoError(t, cw.ConsumeMetrics(ctx, createTestMetrics(1, 1, 1, "")))
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, restarts+1, status().Restarts)
+	assert.True(t, status().Failing)
+
+	require.NoError(t, cw.Shutdown(ctx))
+	// the restarted routine returns, which clears its failure
+	assert.Eventually(t, func() bool { return !status().Failing }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, restarts+1, status().Restarts)
+}
+
 func TestMiddleware(t *testing.T) {
 	t.Setenv("AWS_ACCESS_KEY_ID", "test")
 	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
@@ -569,20 +635,20 @@ func TestBackoffRetries(t *testing.T) {
 	leniency := 200 * time.Millisecond
 	for i := 0; i <= defaultRetryCount; i++ {
 		start := time.Now()
-		c.backoffSleep()
+		c.backoffSleep(nil)
 		// Expect time since start is between sleeps[i]/2 and sleeps[i].
 		// Except that github automation fails on this for MacOs, so allow leniency.
 		assert.Less(sleeps[i]/2, time.Since(start))
 		assert.Greater(sleeps[i]+leniency, time.Since(start))
 	}
 	start := time.Now()
-	c.backoffSleep()
+	c.backoffSleep(nil)
 	assert.Less(30*time.Second, time.Since(start))
 	assert.Greater(60*time.Second, time.Since(start))
 	// reset
 	c.retries = 0
 	start = time.Now()
-	c.backoffSleep()
+	c.backoffSleep(nil)
 	assert.Greater(200*time.Millisecond+leniency, time.Since(start))
 }
 
@@ -601,6 +667,44 @@ func TestCloudWatch_metricDatumBatchFull(t *testing.T) {
 	assert.False(t, c.metricDatumBatchFull())
 }
 
+func TestEndStaleSeries(t *testing.T) {
+	c := &CloudWatch{
+		config: &Config{
+			MaxDatumsPerCall: defaultMaxDatumsPerCall,
+			Staleness:        staleness.Config{Policy: staleness.PolicyZero, Timeout: time.Minute},
+		},
+		datumBatchChan: make(chan map[string][]*cloudwatch.MetricDatum, datumBatchChanBufferSize),
+	}
+	c.metricDatumBatch = newMetricDatumBatch(c.config.MaxDatumsPerCall, 0)
+	c.series = staleness.NewTracker[string, staleSeries](c.config.Staleness.Timeout)
+	datum := func(host string) *cloudwatch.MetricDatum {
+		return &cloudwatch.MetricDatum{
+			MetricName: aws.String("cpu"),
+			Dimensions: BuildDimensions(map[string]string{"host": host}),
+			Unit:       aws.String(cloudwatch.StandardUnitPercent),
+			Value:      aws.Float64(42),
+		}
+	}
+	rollup := &cloudwatch.MetricDatum{MetricName: aws.String("cpu"), Value: aws.Float64(42)}
+	now := time.Now()
+	c.trackSeries("", []*cloudwatch.MetricDatum{datum("a"), rollup}, now)
+	c.trackSeries("", []*cloudwatch.MetricDatum{datum("b"), rollup}, now)
+	assert.Equal(t, 3, c.series.Len())
+
+	// host a keeps reporting, b is scaled in
+	now = now.Add(time.Minute)
+	c.trackSeries("", []*cloudwatch.MetricDatum{datum("a"), rollup}, now)
+	c.endStaleSeries(now)
+	datums := c.metricDatumBatch.Partition[""]
+	require.Len(t, datums, 1)
+	assert.Equal(t, "cpu", *datums[0].MetricName)
+	assert.Equal(t, BuildDimensions(map[string]string{"host": "b"}), datums[0].Dimensions)
+	assert.Equal(t, cloudwatch.StandardUnitPercent, *datums[0].Unit)
+	assert.Zero(t, *datums[0].Value)
+	assert.Equal(t, now, *datums[0].Timestamp)
+	assert.Equal(t, 2, c.series.Len())
+}
+
 func TestCreateEntityMetricData(t *testing.T) {
 	svc := new(mockCloudWatchClient)
 	cw := newCloudWatchClient(svc, time.Second)
@@ -709,3 +813,29 @@ func TestWriteToCloudWatchEntity(t *testing.T) {
 
 	assert.Equal(t, expectedPMDInput, input)
 }
+
+func TestWriteToCloudWatchFailover(t *testing.T) {
+	primary := new(mockCloudWatchClient)
+	primary.On("PutMetricData", mock.Anything).Return(&cloudwatch.PutMetricDataOutput{}, awserr.New(request.ErrCodeRequestError, "send request failed", nil))
+	var input *cloudwatch.PutMetricDataInput
+	secondary := new(mockCloudWatchClient)
+	secondary.On("PutMetricData", mock.Anything).Run(func(args mock.Arguments) {
+		input = args.Get(0).(*cloudwatch.PutMetricDataInput)
+	}).Return(&cloudwatch.PutMetricDataOutput{}, nil)
+
+	cw := newCloudWatchClient(primary, time.Second)
+	cw.config.Region = "us-east-1"
+	// the primary region fails over on its second failure
+	cw.regions = failover.New("cloudwatch", "us-east-1", []string{"us-west-2"}, time.Nanosecond)
+	cw.failoverSvcs = map[string]cloudwatchiface.CloudWatchAPI{"us-west-2": secondary}
+	host := &cloudwatch.Dimension{Name: aws.String("host"), Value: aws.String("a")}
+	datum := &cloudwatch.MetricDatum{MetricName: aws.String("mem_used_percent"), Value: aws.Float64(1), Dimensions: []*cloudwatch.Dimension{host}}
+	cw.WriteToCloudWatch(map[string][]*cloudwatch.MetricDatum{"": {datum}})
+
+	primary.AssertNumberOfCalls(t, "PutMetricData", 2)
+	secondary.AssertNumberOfCalls(t, "PutMetricData", 1)
+	require.NotNil(t, input)
+	require.Len(t, input.MetricData, 1)
+	assert.Equal(t, []*cloudwatch.Dimension{host, {Name: aws.String("FailoverFrom"), Value: aws.String("us-east-1")}}, input.MetricData[0].Dimensions)
+	assert.Equal(t, []*cloudwatch.Dimension{host}, datum.Dimensions, "the datums of the batch are not changed")
+}
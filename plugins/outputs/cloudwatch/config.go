@@ -5,10 +5,13 @@ package cloudwatch
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/resourcetotelemetry"
 	"go.opentelemetry.io/collector/component"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
 )
 
 // Config represent a configuration for the CloudWatch metrics exporter.
@@ -27,6 +30,23 @@ type Config struct {
 	RollupDimensions         [][]string      `mapstructure:"rollup_dimensions,omitempty"`
 	DropOriginalConfigs      map[string]bool `mapstructure:"drop_original_metrics,omitempty"`
 	Namespace                string          `mapstructure:"namespace"`
+	// AggregationOffset shifts the start of the aggregation windows from the wall clock boundary of each interval.
+	AggregationOffset time.Duration `mapstructure:"aggregation_offset,omitempty"`
+	// AggregationJitter is the maximum random delay before an aggregation window is published. It spreads the
+	// PutMetricData calls of a fleet without changing the windows the metrics are aggregated over.
+	AggregationJitter time.Duration `mapstructure:"aggregation_jitter,omitempty"`
+	// Alarms are created for the host the first time the exporter starts.
+	Alarms *AlarmsConfig `mapstructure:"alarms,omitempty"`
+	// MetadataExport exports the catalog of the metrics published, for the catalogs and dashboards of the metrics to
+	// be generated from.
+	MetadataExport *MetadataExportConfig `mapstructure:"metadata_export,omitempty"`
+	// FailoverRegions are published to, in order, while the endpoint of the region is unavailable for FailoverAfter.
+	// The metrics published to them have the FailoverFrom dimension.
+	FailoverRegions []string      `mapstructure:"failover_regions,omitempty"`
+	FailoverAfter   time.Duration `mapstructure:"failover_after,omitempty"`
+	// Staleness ends the series, original or rolled up, that stop reporting. CloudWatch has no staleness marker, so
+	// only the zero policy publishes a data point.
+	Staleness staleness.Config `mapstructure:"staleness,omitempty"`
 
 	// ResourceToTelemetrySettings is the option for converting resource
 	// attributes to telemetry attributes.
@@ -37,6 +57,45 @@ type Config struct {
 	MiddlewareID *component.ID `mapstructure:"middleware,omitempty"`
 }
 
+// AlarmsConfig is the set of alarms created for the host, for the hosts that are not covered by infrastructure as
+// code.
+type AlarmsConfig struct {
+	// NamePrefix is prepended to the name of every alarm, e.g. CWAgent-i-0123456789abcdef0.
+	NamePrefix   string   `mapstructure:"name_prefix"`
+	AlarmActions []string `mapstructure:"alarm_actions,omitempty"`
+	// Composite creates a composite alarm in ALARM while any of the alarms is. It takes the alarm actions instead of
+	// the alarms.
+	Composite bool `mapstructure:"composite,omitempty"`
+	// Tags are added to every alarm, so the alarms of a host can be found and cleaned up once it is gone.
+	Tags map[string]string `mapstructure:"tags,omitempty"`
+	// StateFile records that the alarms were created, so the alarms deleted since are not created again on restart.
+	StateFile string          `mapstructure:"state_file,omitempty"`
+	Templates []AlarmTemplate `mapstructure:"templates"`
+}
+
+// MetadataExportConfig is where the catalog of the metrics is exported to. The file is replaced by the whole
+// catalog, while the log group receives an event for each metric added or changed.
+type MetadataExportConfig struct {
+	FilePath      string `mapstructure:"file_path,omitempty"`
+	LogGroupName  string `mapstructure:"log_group_name,omitempty"`
+	LogStreamName string `mapstructure:"log_stream_name,omitempty"`
+	// Interval is how often the changes of the catalog are exported.
+	Interval time.Duration `mapstructure:"interval,omitempty"`
+}
+
+// AlarmTemplate is an alarm on a Metrics Insights query.
+type AlarmTemplate struct {
+	Name        string `mapstructure:"name"`
+	Description string `mapstructure:"description,omitempty"`
+	// Query must return a single time series, e.g. SELECT MAX(mem_used_percent) FROM CWAgent WHERE host = 'a'.
+	Query              string        `mapstructure:"query"`
+	Period             time.Duration `mapstructure:"period"`
+	EvaluationPeriods  int           `mapstructure:"evaluation_periods"`
+	ComparisonOperator string        `mapstructure:"comparison_operator"`
+	Threshold          float64       `mapstructure:"threshold"`
+	TreatMissingData   string        `mapstructure:"treat_missing_data,omitempty"`
+}
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid.
@@ -50,5 +109,86 @@ func (c *Config) Validate() error {
 	if c.ForceFlushInterval < time.Millisecond {
 		return errors.New("'force_flush_interval' must be at least 1 millisecond")
 	}
+	if c.AggregationOffset < 0 {
+		return errors.New("'aggregation_offset' must not be negative")
+	}
+	if c.AggregationJitter < 0 {
+		return errors.New("'aggregation_jitter' must not be negative")
+	}
+	if c.MetadataExport != nil {
+		if err := c.MetadataExport.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := c.Staleness.Validate(); err != nil {
+		return err
+	}
+	if err := validateFailover(c.Region, c.EndpointOverride, c.FailoverRegions, c.FailoverAfter); err != nil {
+		return err
+	}
+	if c.Alarms != nil {
+		return c.Alarms.Validate()
+	}
+	return nil
+}
+
+// validateFailover checks that the failover regions are distinct from the region and from each other.
+func validateFailover(region, endpointOverride string, failoverRegions []string, after time.Duration) error {
+	if len(failoverRegions) == 0 {
+		return nil
+	}
+	if endpointOverride != "" {
+		return errors.New("'failover_regions' cannot be set with 'endpoint_override'")
+	}
+	if after < 0 {
+		return errors.New("'failover_after' must not be negative")
+	}
+	seen := map[string]bool{region: true}
+	for _, failoverRegion := range failoverRegions {
+		if failoverRegion == "" || seen[failoverRegion] {
+			return fmt.Errorf("'failover_regions' must be distinct from each other and from the region, but got %q", failoverRegion)
+		}
+		seen[failoverRegion] = true
+	}
+	return nil
+}
+
+// Validate checks that the catalog is exported somewhere.
+func (c *MetadataExportConfig) Validate() error {
+	if c.FilePath == "" && c.LogGroupName == "" {
+		return errors.New("'metadata_export' must set 'file_path' or 'log_group_name'")
+	}
+	if c.LogGroupName != "" && c.LogStreamName == "" {
+		return errors.New("'metadata_export::log_stream_name' must be set with 'log_group_name'")
+	}
+	if c.Interval < 0 {
+		return errors.New("'metadata_export::interval' must not be negative")
+	}
+	return nil
+}
+
+// Validate checks that the alarms can be created.
+func (c *AlarmsConfig) Validate() error {
+	if c.NamePrefix == "" {
+		return errors.New("'alarms::name_prefix' must be set")
+	}
+	names := map[string]bool{}
+	for _, template := range c.Templates {
+		switch {
+		case template.Name == "":
+			return errors.New("'alarms::templates::name' must be set")
+		case names[template.Name]:
+			return fmt.Errorf("duplicate alarm template %q", template.Name)
+		case template.Query == "":
+			return fmt.Errorf("'query' must be set for alarm template %q", template.Name)
+		case template.Period < time.Second:
+			return fmt.Errorf("'period' must be at least 1 second for alarm template %q", template.Name)
+		case template.EvaluationPeriods < 1:
+			return fmt.Errorf("'evaluation_periods' must be at least 1 for alarm template %q", template.Name)
+		case template.ComparisonOperator == "":
+			return fmt.Errorf("'comparison_operator' must be set for alarm template %q", template.Name)
+		}
+		names[template.Name] = true
+	}
 	return nil
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/otelcol/otelcoltest"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
 )
 
 // TestConfig will verify various config files can be loaded.
@@ -65,6 +67,8 @@ func TestConfig(t *testing.T) {
 	assert.Equal(t, 7, c2.MaxDatumsPerCall)
 	assert.Equal(t, 9, c2.MaxValuesPerDatum)
 	assert.Equal(t, 60*time.Second, c2.ForceFlushInterval)
+	assert.Equal(t, 15*time.Second, c2.AggregationOffset)
+	assert.Equal(t, 10*time.Second, c2.AggregationJitter)
 	// todo: verify MetricDecorations
 }
 
@@ -111,3 +115,43 @@ func TestConfigDropOriginConfigs(t *testing.T) {
 	assert.True(t, drop["cpu_usage"])
 	assert.True(t, drop["foo_bar"])
 }
+
+func TestConfigFailover(t *testing.T) {
+	testCases := map[string]struct {
+		regions          []string
+		endpointOverride string
+		after            time.Duration
+		wantErr          string
+	}{
+		"WithoutRegions":      {},
+		"WithRegions":         {regions: []string{"us-west-2", "eu-west-1"}, after: time.Minute},
+		"WithPrimaryRegion":   {regions: []string{"us-east-1"}, wantErr: `'failover_regions' must be distinct from each other and from the region, but got "us-east-1"`},
+		"WithDuplicateRegion": {regions: []string{"us-west-2", "us-west-2"}, wantErr: `'failover_regions' must be distinct from each other and from the region, but got "us-west-2"`},
+		"WithEndpoint":        {regions: []string{"us-west-2"}, endpointOverride: "https://monitoring.example.com", wantErr: "'failover_regions' cannot be set with 'endpoint_override'"},
+		"WithNegativeAfter":   {regions: []string{"us-west-2"}, after: -time.Second, wantErr: "'failover_after' must not be negative"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Region = "us-east-1"
+			cfg.EndpointOverride = testCase.endpointOverride
+			cfg.FailoverRegions = testCase.regions
+			cfg.FailoverAfter = testCase.after
+			err := cfg.Validate()
+			if testCase.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigStaleness(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Region = "us-east-1"
+	cfg.Staleness = staleness.Config{Policy: staleness.PolicyZero, Timeout: time.Minute}
+	assert.NoError(t, cfg.Validate())
+	cfg.Staleness.Policy = "nan"
+	assert.EqualError(t, cfg.Validate(), `unsupported staleness policy "nan", must be one of "stop", "zero" or "absent"`)
+}
@@ -0,0 +1,324 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	cloudwatchutil "github.com/aws/amazon-cloudwatch-agent/internal/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/internal/supervisor"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+const defaultMetadataExportInterval = 5 * time.Minute
+
+// MetricMetadata is the entry of a metric in the catalog of the metrics published by the agent.
+type MetricMetadata struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Unit        string `json:"unit,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Origin is the input the metric is collected by, e.g. "cpu" or "awsebsnvmereceiver".
+	Origin string `json:"origin,omitempty"`
+	// Dimensions are the sets of dimension names the metric is published with, including the rollups.
+	Dimensions [][]string `json:"dimensions"`
+}
+
+// metadataLogsAPI is the part of the CloudWatch Logs API the catalog is exported with.
+type metadataLogsAPI interface {
+	CreateLogGroup(*cloudwatchlogs.CreateLogGroupInput) (*cloudwatchlogs.CreateLogGroupOutput, error)
+	CreateLogStream(*cloudwatchlogs.CreateLogStreamInput) (*cloudwatchlogs.CreateLogStreamOutput, error)
+	PutLogEvents(*cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error)
+}
+
+// metadataCatalog collects the metadata of the metrics the exporters publish and exports it periodically. The
+// exporters exporting to the same place share a catalog, so it covers every namespace they publish to.
+type metadataCatalog struct {
+	config MetadataExportConfig
+	logs   metadataLogsAPI
+
+	mu      sync.Mutex
+	metrics map[string]*MetricMetadata
+	// dimensions are the joined dimension sets of each metric already in its entry.
+	dimensions map[string]map[string]struct{}
+	// changed are the metrics added or changed since the last export.
+	changed       map[string]struct{}
+	streamCreated bool
+
+	refs   int
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var (
+	catalogsMu sync.Mutex
+	// catalogs are the catalogs of the exporters, by the place they are exported to.
+	catalogs = map[MetadataExportConfig]*metadataCatalog{}
+)
+
+// acquireCatalog returns the catalog exported to the place of the config, starting its export on first use.
+func acquireCatalog(config MetadataExportConfig, newLogs func() metadataLogsAPI) *metadataCatalog {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	c, ok := catalogs[config]
+	if !ok {
+		c = newMetadataCatalog(config)
+		if config.LogGroupName != "" {
+			c.logs = newLogs()
+		}
+		var ctx context.Context
+		ctx, c.cancel = context.WithCancel(context.Background())
+		supervisor.Go(ctx, "output:cloudwatch/metadata", supervisor.DefaultBackoff, c.run)
+		catalogs[config] = c
+	}
+	c.refs++
+	return c
+}
+
+// release stops the export once no exporter uses the catalog, after exporting it one last time.
+func (c *metadataCatalog) release() {
+	catalogsMu.Lock()
+	c.refs--
+	last := c.refs == 0
+	if last {
+		delete(catalogs, c.config)
+	}
+	catalogsMu.Unlock()
+	if last {
+		c.cancel()
+		<-c.done
+	}
+}
+
+func newMetadataCatalog(config MetadataExportConfig) *metadataCatalog {
+	if config.Interval <= 0 {
+		config.Interval = defaultMetadataExportInterval
+	}
+	return &metadataCatalog{
+		config:     config,
+		metrics:    map[string]*MetricMetadata{},
+		dimensions: map[string]map[string]struct{}{},
+		changed:    map[string]struct{}{},
+		done:       make(chan struct{}),
+	}
+}
+
+func (c *metadataCatalog) run(ctx context.Context) error {
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.export()
+		case <-ctx.Done():
+			defer close(c.done)
+			c.export()
+			return nil
+		}
+	}
+}
+
+// entry returns the entry of the metric, creating it. The lock must be held.
+func (c *metadataCatalog) entry(namespace, name string) (string, *MetricMetadata) {
+	key := namespace + "\x00" + name
+	m, ok := c.metrics[key]
+	if !ok {
+		m = &MetricMetadata{Namespace: namespace, Name: name, Dimensions: [][]string{}}
+		c.metrics[key] = m
+		c.dimensions[key] = map[string]struct{}{}
+		c.changed[key] = struct{}{}
+	}
+	return key, m
+}
+
+// recordMetrics records the unit, description and origin of the metrics consumed by an exporter.
+func (c *metadataCatalog) recordMetrics(namespace string, metrics pmetric.Metrics) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		scopeMetrics := metrics.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			origin := scopeMetrics.At(j).Scope().Name()
+			ms := scopeMetrics.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				key, entry := c.entry(namespace, m.Name())
+				unit, _, _ := cloudwatchutil.ToStandardUnit(m.Unit())
+				if unit == entry.Unit && (m.Description() == "" || m.Description() == entry.Description) &&
+					(origin == "" || origin == entry.Origin) {
+					continue
+				}
+				entry.Unit = unit
+				if m.Description() != "" {
+					entry.Description = m.Description()
+				}
+				if origin != "" {
+					entry.Origin = origin
+				}
+				c.changed[key] = struct{}{}
+			}
+		}
+	}
+}
+
+// recordDatums records the dimension sets of the datums published by an exporter.
+func (c *metadataCatalog) recordDatums(namespace string, datums []*cloudwatch.MetricDatum) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, datum := range datums {
+		key, entry := c.entry(namespace, aws.StringValue(datum.MetricName))
+		names := make([]string, 0, len(datum.Dimensions))
+		for _, dimension := range datum.Dimensions {
+			names = append(names, aws.StringValue(dimension.Name))
+		}
+		sort.Strings(names)
+		joined := strings.Join(names, "\x00")
+		if _, ok := c.dimensions[key][joined]; ok {
+			continue
+		}
+		c.dimensions[key][joined] = struct{}{}
+		entry.Dimensions = append(entry.Dimensions, names)
+		c.changed[key] = struct{}{}
+	}
+}
+
+// snapshot returns the copies of all the entries, and of the ones changed since the last snapshot, sorted by
+// namespace and name.
+func (c *metadataCatalog) snapshot() (all, changed []MetricMetadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.metrics))
+	for key := range c.metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		entry := *c.metrics[key]
+		entry.Dimensions = append(make([][]string, 0, len(entry.Dimensions)), entry.Dimensions...)
+		sort.Slice(entry.Dimensions, func(i, j int) bool {
+			return strings.Join(entry.Dimensions[i], ",") < strings.Join(entry.Dimensions[j], ",")
+		})
+		all = append(all, entry)
+		if _, ok := c.changed[key]; ok {
+			changed = append(changed, entry)
+		}
+	}
+	c.changed = map[string]struct{}{}
+	return all, changed
+}
+
+// export writes the catalog to the file and sends the changed entries to the log group, when anything changed.
+func (c *metadataCatalog) export() {
+	all, changed := c.snapshot()
+	if len(changed) == 0 {
+		return
+	}
+	if c.config.FilePath != "" {
+		if err := writeCatalogFile(c.config.FilePath, all); err != nil {
+			log.Printf("E! Failed to write the metric metadata to %s: %v", c.config.FilePath, err)
+		}
+	}
+	if c.logs != nil {
+		if err := c.putCatalogEvents(changed); err != nil {
+			log.Printf("E! Failed to send the metric metadata to log group %s: %v", c.config.LogGroupName, err)
+			c.requeue(changed)
+		}
+	}
+}
+
+// requeue marks the entries as changed again, for the ones that failed to be sent to be sent on the next export.
+func (c *metadataCatalog) requeue(entries []MetricMetadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range entries {
+		c.changed[entry.Namespace+"\x00"+entry.Name] = struct{}{}
+	}
+}
+
+// writeCatalogFile replaces the file with the catalog, through a temporary file so it is never read half written.
+func writeCatalogFile(path string, entries []MetricMetadata) error {
+	content, err := json.MarshalIndent(struct {
+		Metrics []MetricMetadata `json:"metrics"`
+	}{Metrics: entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// putCatalogEvents sends an event for each entry, creating the log group and the log stream the first time.
+func (c *metadataCatalog) putCatalogEvents(entries []MetricMetadata) error {
+	if !c.streamCreated {
+		if err := c.createLogStream(); err != nil {
+			return err
+		}
+		c.streamCreated = true
+	}
+	timestamp := time.Now().UnixMilli()
+	events := make([]*cloudwatchlogs.InputLogEvent, 0, len(entries))
+	for _, entry := range entries {
+		message, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		events = append(events, &cloudwatchlogs.InputLogEvent{Message: aws.String(string(message)), Timestamp: aws.Int64(timestamp)})
+	}
+	_, err := c.logs.PutLogEvents(&cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String(c.config.LogGroupName),
+		LogStreamName: aws.String(c.config.LogStreamName),
+		LogEvents:     events,
+	})
+	return err
+}
+
+func (c *metadataCatalog) createLogStream() error {
+	streamInput := &cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  aws.String(c.config.LogGroupName),
+		LogStreamName: aws.String(c.config.LogStreamName),
+	}
+	_, err := c.logs.CreateLogStream(streamInput)
+	if isAWSErrorCode(err, cloudwatchlogs.ErrCodeResourceNotFoundException) {
+		_, err = c.logs.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{LogGroupName: aws.String(c.config.LogGroupName)})
+		if err != nil && !isAWSErrorCode(err, cloudwatchlogs.ErrCodeResourceAlreadyExistsException) {
+			return fmt.Errorf("unable to create log group: %w", err)
+		}
+		_, err = c.logs.CreateLogStream(streamInput)
+	}
+	if err != nil && !isAWSErrorCode(err, cloudwatchlogs.ErrCodeResourceAlreadyExistsException) {
+		return fmt.Errorf("unable to create log stream: %w", err)
+	}
+	return nil
+}
+
+func isAWSErrorCode(err error, code string) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == code
+}
@@ -0,0 +1,132 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+type stubMetadataLogs struct {
+	groupMissing bool
+	groups       []string
+	events       []string
+}
+
+func (s *stubMetadataLogs) CreateLogGroup(input *cloudwatchlogs.CreateLogGroupInput) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	s.groups = append(s.groups, *input.LogGroupName)
+	s.groupMissing = false
+	return &cloudwatchlogs.CreateLogGroupOutput{}, nil
+}
+
+func (s *stubMetadataLogs) CreateLogStream(*cloudwatchlogs.CreateLogStreamInput) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	if s.groupMissing {
+		return nil, awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "", nil)
+	}
+	return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+}
+
+func (s *stubMetadataLogs) PutLogEvents(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	for _, event := range input.LogEvents {
+		s.events = append(s.events, *event.Message)
+	}
+	return &cloudwatchlogs.PutLogEventsOutput{}, nil
+}
+
+func datumWithDimensions(name string, dimensions ...string) *cloudwatch.MetricDatum {
+	datum := &cloudwatch.MetricDatum{MetricName: aws.String(name)}
+	for _, dimension := range dimensions {
+		datum.Dimensions = append(datum.Dimensions, &cloudwatch.Dimension{Name: aws.String(dimension), Value: aws.String("v")})
+	}
+	return datum
+}
+
+func TestMetadataCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog", "metrics.json")
+	logs := &stubMetadataLogs{groupMissing: true}
+	c := newMetadataCatalog(MetadataExportConfig{FilePath: path, LogGroupName: "catalog", LogStreamName: "i-123"})
+	c.logs = logs
+
+	metrics := pmetric.NewMetrics()
+	sm := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("cpu")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("cpu_usage_idle")
+	m.SetUnit("%")
+	m.SetDescription("Percentage of time the CPU is idle")
+	m.SetEmptyGauge()
+	c.recordMetrics("CWAgent", metrics)
+	c.recordDatums("CWAgent", []*cloudwatch.MetricDatum{
+		datumWithDimensions("cpu_usage_idle", "host", "cpu"),
+		datumWithDimensions("cpu_usage_idle", "cpu", "host"),
+		datumWithDimensions("cpu_usage_idle", "host"),
+	})
+	c.recordDatums("Team/App", []*cloudwatch.MetricDatum{datumWithDimensions("requests")})
+	c.export()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var got struct {
+		Metrics []MetricMetadata `json:"metrics"`
+	}
+	require.NoError(t, json.Unmarshal(content, &got))
+	assert.Equal(t, []MetricMetadata{
+		{
+			Namespace:   "CWAgent",
+			Name:        "cpu_usage_idle",
+			Unit:        "Percent",
+			Description: "Percentage of time the CPU is idle",
+			Origin:      "cpu",
+			Dimensions:  [][]string{{"cpu", "host"}, {"host"}},
+		},
+		{Namespace: "Team/App", Name: "requests", Dimensions: [][]string{{}}},
+	}, got.Metrics)
+	assert.Equal(t, []string{"catalog"}, logs.groups)
+	assert.Len(t, logs.events, 2)
+
+	// only the changes are sent to the log group, while the file keeps the whole catalog
+	c.recordMetrics("CWAgent", metrics)
+	c.export()
+	assert.Len(t, logs.events, 2)
+	c.recordDatums("Team/App", []*cloudwatch.MetricDatum{datumWithDimensions("requests", "route")})
+	c.export()
+	require.Len(t, logs.events, 3)
+	assert.JSONEq(t, `{"namespace": "Team/App", "name": "requests", "dimensions": [[], ["route"]]}`, logs.events[2])
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(content, &got))
+	assert.Len(t, got.Metrics, 2)
+}
+
+func TestAcquireCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	config := MetadataExportConfig{FilePath: path, Interval: time.Hour}
+	first := acquireCatalog(config, nil)
+	second := acquireCatalog(config, nil)
+	assert.Same(t, first, second)
+
+	first.recordDatums("CWAgent", []*cloudwatch.MetricDatum{datumWithDimensions("mem_used_percent", "host")})
+	first.release()
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "the catalog is exported by the last exporter to stop")
+	second.release()
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+
+	var nilCatalog *metadataCatalog
+	nilCatalog.recordMetrics("CWAgent", pmetric.NewMetrics())
+	nilCatalog.recordDatums("CWAgent", nil)
+}
@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
@@ -61,11 +62,17 @@ const (
 	*/
 )
 
-// Set seed once.
-var seededRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+// Set seed once. The publish routines of the outputs share it, and restart concurrently after a panic, so it is
+// guarded by seededRandMu.
+var (
+	seededRandMu sync.Mutex
+	seededRand   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
 
 // publishJitter returns a random duration between 0 and the given publishInterval.
 func publishJitter(publishInterval time.Duration) time.Duration {
+	seededRandMu.Lock()
+	defer seededRandMu.Unlock()
 	jitter := seededRand.Int63n(int64(publishInterval))
 	return time.Duration(jitter)
 }
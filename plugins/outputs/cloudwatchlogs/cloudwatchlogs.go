@@ -5,6 +5,7 @@ package cloudwatchlogs
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -24,6 +25,8 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/useragent"
 	"github.com/aws/amazon-cloudwatch-agent/handlers"
 	"github.com/aws/amazon-cloudwatch-agent/internal"
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
+	"github.com/aws/amazon-cloudwatch-agent/internal/failover"
 	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
@@ -72,22 +75,69 @@ type CloudWatchLogs struct {
 
 	ForceFlushInterval internal.Duration `toml:"force_flush_interval"` // unit is second
 
+	// DeadLetterDir keeps the log events CloudWatch Logs permanently rejects, up to DeadLetterMaxSizeMB.
+	DeadLetterDir       string `toml:"dead_letter_dir"`
+	DeadLetterMaxSizeMB int    `toml:"dead_letter_max_size_mb"`
+
+	// BufferLimitMB bounds the size of the log events read and not yet sent. The log files stop being read once it
+	// is reached, and resume once the events are sent. 0 does not limit.
+	BufferLimitMB int `toml:"buffer_limit_mb"`
+
+	// LogGroupPolicies are attached to the log groups the agent creates.
+	LogGroupPolicies []LogGroupPolicy `toml:"log_group_policy"`
+
+	// FailoverRegions are sent to, in order, while the endpoint of the region is unavailable for FailoverAfter. The
+	// log events sent to them have the FailoverFrom field when they are JSON objects.
+	FailoverRegions []string          `toml:"failover_regions"`
+	FailoverAfter   internal.Duration `toml:"failover_after"`
+
+	// UserAgentAttribution is appended to the User-Agent of the calls to CloudWatch Logs.
+	UserAgentAttribution string `toml:"user_agent_attribution"`
+
 	Log telegraf.Logger `toml:"-"`
 
 	pusherStopChan  chan struct{}
 	pusherWaitGroup sync.WaitGroup
 	cwDests         map[pusher.Target]*cwDest
 	workerPool      pusher.WorkerPool
+	bufferLimit     *pusher.BufferLimit
 	targetManager   pusher.TargetManager
 	once            sync.Once
 	middleware      awsmiddleware.Middleware
+	// destMu guards cwDests, which dead-letter replays add to from the control socket.
+	destMu        sync.Mutex
+	deadLetter    *deadletter.Store
+	groupPolicies []pusher.GroupPolicy
+	regions       *failover.Regions
 }
 
 func (c *CloudWatchLogs) Connect() error {
+	policies, err := c.buildGroupPolicies()
+	if err != nil {
+		return err
+	}
+	c.groupPolicies = policies
+	if err = c.validateFailover(); err != nil {
+		return err
+	}
+	if c.UserAgentAttribution != "" {
+		if err = useragent.ValidateAttribution(c.UserAgentAttribution); err != nil {
+			return err
+		}
+		c.middleware = newMiddleware(c.UserAgentAttribution)
+	}
+	c.regions = failover.New("cloudwatchlogs", c.Region, c.FailoverRegions, c.FailoverAfter.Duration)
+	if c.DeadLetterDir != "" {
+		c.deadLetter = deadletter.NewStore(c.DeadLetterDir, int64(c.DeadLetterMaxSizeMB)*1024*1024)
+		deadletter.Register(c.deadLetter, c.replay)
+	}
 	return nil
 }
 
 func (c *CloudWatchLogs) Close() error {
+	if c.deadLetter != nil {
+		deadletter.Unregister(c.deadLetter)
+	}
 	close(c.pusherStopChan)
 	c.pusherWaitGroup.Wait()
 
@@ -129,12 +179,16 @@ func (c *CloudWatchLogs) CreateDest(group, stream string, retention int, logGrou
 }
 
 func (c *CloudWatchLogs) getDest(t pusher.Target, logSrc logs.LogSrc) *cwDest {
+	c.destMu.Lock()
+	defer c.destMu.Unlock()
 	if cwd, ok := c.cwDests[t]; ok {
 		return cwd
 	}
 
 	logThrottleRetryer := retryer.NewLogThrottleRetryer(c.Log)
-	client := c.createClient(logThrottleRetryer)
+	client := pusher.NewService(c.Log, c.Region, c.regions, func(region string) *cloudwatchlogs.CloudWatchLogs {
+		return c.createClient(logThrottleRetryer, region)
+	})
 	agent.UsageFlags().SetValue(agent.FlagRegionType, c.RegionType)
 	agent.UsageFlags().SetValue(agent.FlagMode, c.Mode)
 	if containerInsightsRegexp.MatchString(t.Group) {
@@ -144,17 +198,42 @@ func (c *CloudWatchLogs) getDest(t pusher.Target, logSrc logs.LogSrc) *cwDest {
 		if c.Concurrency > 0 {
 			c.workerPool = pusher.NewWorkerPool(c.Concurrency)
 		}
-		c.targetManager = pusher.NewTargetManager(c.Log, client)
+		c.targetManager = pusher.NewTargetManager(c.Log, client, c.groupPolicies)
+		c.bufferLimit = pusher.NewBufferLimit(c.BufferLimitMB * 1024 * 1024)
 	})
-	p := pusher.NewPusher(c.Log, t, client, c.targetManager, logSrc, c.workerPool, c.ForceFlushInterval.Duration, maxRetryTimeout, c.pusherStopChan, &c.pusherWaitGroup)
+	p := pusher.NewPusher(c.Log, t, client, c.targetManager, logSrc, c.workerPool, c.ForceFlushInterval.Duration, maxRetryTimeout, c.pusherStopChan, &c.pusherWaitGroup, c.deadLetter, c.bufferLimit)
 	cwd := &cwDest{pusher: p, retryer: logThrottleRetryer}
 	c.cwDests[t] = cwd
 	return cwd
 }
 
-func (c *CloudWatchLogs) createClient(retryer aws.RequestRetryer) *cloudwatchlogs.CloudWatchLogs {
+// replay sends a dead-letter record to its log stream again. It goes back to the store if it is rejected again.
+func (c *CloudWatchLogs) replay(record deadletter.Record) {
+	t := pusher.Target{Group: record.Group, Stream: record.Stream, Class: record.Class, Retention: -1}
+	c.getDest(t, nil).AddEvent(&structuredLogEvent{msg: record.Message, t: record.Timestamp})
+}
+
+// validateFailover checks that the failover regions are distinct from the region and from each other.
+func (c *CloudWatchLogs) validateFailover() error {
+	if len(c.FailoverRegions) == 0 {
+		return nil
+	}
+	if c.EndpointOverride != "" {
+		return errors.New("failover_regions cannot be set with endpoint_override")
+	}
+	seen := map[string]bool{c.Region: true}
+	for _, region := range c.FailoverRegions {
+		if region == "" || seen[region] {
+			return fmt.Errorf("failover_regions must be distinct from each other and from the region, but got %q", region)
+		}
+		seen[region] = true
+	}
+	return nil
+}
+
+func (c *CloudWatchLogs) createClient(requestRetryer aws.RequestRetryer, region string) *cloudwatchlogs.CloudWatchLogs {
 	credentialConfig := &configaws.CredentialConfig{
-		Region:    c.Region,
+		Region:    region,
 		AccessKey: c.AccessKey,
 		SecretKey: c.SecretKey,
 		RoleARN:   c.RoleARN,
@@ -166,12 +245,13 @@ func (c *CloudWatchLogs) createClient(retryer aws.RequestRetryer) *cloudwatchlog
 		credentialConfig.Credentials(),
 		&aws.Config{
 			Endpoint: aws.String(c.EndpointOverride),
-			Retryer:  retryer,
+			Retryer:  requestRetryer,
 			LogLevel: configaws.SDKLogLevel(),
 			Logger:   configaws.SDKLogger{},
 		},
 	)
 	client.Handlers.Build.PushBackNamed(handlers.NewRequestCompressionHandler([]string{"PutLogEvents"}))
+	client.Handlers.UnmarshalError.PushBackNamed(retryer.RetryAfterHandler)
 	if c.middleware != nil {
 		if err := awsmiddleware.NewConfigurer(c.middleware.Handlers()).Configure(awsmiddleware.SDKv1(&client.Handlers)); err != nil {
 			c.Log.Errorf("Unable to configure middleware on cloudwatch logs client: %v", err)
@@ -227,7 +307,8 @@ func (c *CloudWatchLogs) getLogEventFromMetric(metric telegraf.Metric) *structur
 	if metric.HasField(LogEntryField) {
 		var ok bool
 		if message, ok = metric.Fields()[LogEntryField].(string); !ok {
-			c.Log.Warnf("The log entry value field is not string type: %v", metric.Fields())
+			// the fields are the content of the log entry, so only the type is logged
+			c.Log.Warnf("The log entry value field is not string type: %T", metric.Fields()[LogEntryField])
 			return nil
 		}
 	} else {
@@ -401,14 +482,19 @@ func init() {
 			ForceFlushInterval: internal.Duration{Duration: defaultFlushTimeout},
 			pusherStopChan:     make(chan struct{}),
 			cwDests:            make(map[pusher.Target]*cwDest),
-			middleware: agenthealth.NewAgentHealth(
-				zap.NewNop(),
-				&agenthealth.Config{
-					IsUsageDataEnabled:  envconfig.IsUsageDataEnabled(),
-					Stats:               &agent.StatsConfig{Operations: []string{"PutLogEvents"}},
-					IsStatusCodeEnabled: true,
-				},
-			),
+			middleware:         newMiddleware(""),
 		}
 	})
 }
+
+func newMiddleware(attribution string) awsmiddleware.Middleware {
+	return agenthealth.NewAgentHealth(
+		zap.NewNop(),
+		&agenthealth.Config{
+			IsUsageDataEnabled:   envconfig.IsUsageDataEnabled(),
+			Stats:                &agent.StatsConfig{Operations: []string{"PutLogEvents"}},
+			IsStatusCodeEnabled:  true,
+			UserAgentAttribution: attribution,
+		},
+	)
+}
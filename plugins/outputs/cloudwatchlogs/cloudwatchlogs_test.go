@@ -5,10 +5,14 @@ package cloudwatchlogs
 
 import (
 	"testing"
+	"time"
 
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/plugins/outputs"
 	"github.com/influxdata/telegraf/testutil"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/structuredlogscommon"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
@@ -102,3 +106,86 @@ func TestDuplicateDestination(t *testing.T) {
 	// Then the destination for cloudwatchlogs endpoint would be the same
 	require.Equal(t, d1, d2)
 }
+
+func TestBufferLimitDisabledByDefault(t *testing.T) {
+	c := outputs.Outputs["cloudwatchlogs"]().(*CloudWatchLogs)
+	c.Log = testutil.Logger{Name: "test"}
+	c.AccessKey = "access_key"
+	c.SecretKey = "secret_key"
+	c.CreateDest("FILENAME", "", -1, util.StandardLogGroupClass, nil)
+	require.Nil(t, c.bufferLimit)
+}
+
+func TestConnectWithFailover(t *testing.T) {
+	testCases := map[string]struct {
+		endpointOverride string
+		failoverRegions  []string
+		wantErr          bool
+	}{
+		"WithoutFailoverRegions": {},
+		"WithFailoverRegions":    {failoverRegions: []string{"us-west-2", "eu-west-1"}},
+		"WithEndpointOverride":   {endpointOverride: "https://logs.example.com", failoverRegions: []string{"us-west-2"}, wantErr: true},
+		"WithPrimaryRegion":      {failoverRegions: []string{"us-east-1"}, wantErr: true},
+		"WithDuplicateRegion":    {failoverRegions: []string{"us-west-2", "us-west-2"}, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := &CloudWatchLogs{
+				Log:              testutil.Logger{Name: "test"},
+				Region:           "us-east-1",
+				EndpointOverride: testCase.endpointOverride,
+				FailoverRegions:  testCase.failoverRegions,
+				AccessKey:        "access_key",
+				SecretKey:        "secret_key",
+				cwDests:          make(map[pusher.Target]*cwDest),
+				pusherStopChan:   make(chan struct{}),
+			}
+			err := c.Connect()
+			if testCase.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.failoverRegions != nil, c.regions != nil)
+			require.NotNil(t, c.CreateDest("G1", "S1", -1, "", nil))
+		})
+	}
+}
+
+func BenchmarkGetLogEventFromMetric(b *testing.B) {
+	c := &CloudWatchLogs{Log: testutil.Logger{Name: "test"}}
+	tags := map[string]string{
+		"ClusterName": "prod",
+		"Namespace":   "default",
+		"PodName":     "checkout",
+		"Type":        "Pod",
+	}
+	fields := map[string]interface{}{
+		"pod_cpu_utilization":    12.5,
+		"pod_memory_utilization": 40.25,
+		"pod_network_rx_bytes":   int64(1024),
+		"pod_number_of_restarts": 2,
+		"pod_status":             "Running",
+		"Timestamp":              time.Now(),
+	}
+	rules := []structuredlogscommon.MetricRule{{
+		Namespace:     "ContainerInsights",
+		DimensionSets: [][]string{{"ClusterName"}, {"ClusterName", "Namespace", "PodName"}},
+		Metrics: []structuredlogscommon.MetricAttr{
+			{Name: "pod_cpu_utilization", Unit: "Percent"},
+			{Name: "pod_memory_utilization", Unit: "Percent"},
+			{Name: "pod_network_rx_bytes", Unit: "Bytes/Second"},
+		},
+	}}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		m := metric.New("pod", tags, fields, time.Now())
+		structuredlogscommon.AttachMetricRule(m, rules)
+		b.StartTimer()
+		if c.getLogEventFromMetric(m) == nil {
+			b.Fatal("no log event")
+		}
+	}
+}
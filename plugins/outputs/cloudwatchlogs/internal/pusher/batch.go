@@ -65,6 +65,9 @@ type logEventBatch struct {
 	minT, maxT time.Time
 	// Callbacks to execute when batch is successfully sent.
 	doneCallbacks []func()
+	// reserved is the size of the messages of the events, released from the limit once the batch is sent or dropped.
+	reserved int
+	limit    *BufferLimit
 }
 
 func newLogEventBatch(target Target, entityProvider logs.LogEntityProvider) *logEventBatch {
@@ -121,6 +124,12 @@ func (b *logEventBatch) done() {
 	}
 }
 
+// release frees the space the events of the batch take in the limit of the output.
+func (b *logEventBatch) release() {
+	b.limit.release(b.reserved)
+	b.reserved = 0
+}
+
 // build creates a cloudwatchlogs.PutLogEventsInput from the batch. The log events in the batch must be in
 // chronological order by their timestamp.
 func (b *logEventBatch) build() *cloudwatchlogs.PutLogEventsInput {
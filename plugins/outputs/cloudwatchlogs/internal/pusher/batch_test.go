@@ -4,6 +4,7 @@
 package pusher
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -161,3 +162,35 @@ func TestLogEventBatch(t *testing.T) {
 		assert.Equal(t, testEntity, input.Entity, "Entity should be set from the EntityProvider")
 	})
 }
+
+func BenchmarkLogEventBatch(b *testing.B) {
+	message := strings.Repeat("x", 200)
+	now := time.Now()
+	testCases := []struct {
+		name   string
+		offset time.Duration
+	}{
+		{name: "Ordered", offset: time.Millisecond},
+		{name: "Unordered", offset: -time.Millisecond},
+	}
+	for _, testCase := range testCases {
+		events := make([]*logEvent, 1000)
+		for i := range events {
+			events[i] = newLogEvent(now.Add(time.Duration(i)*testCase.offset), message, func() {})
+		}
+		b.Run(testCase.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+				for _, event := range events {
+					if !batch.inTimeRange(event.timestamp) || !batch.hasSpace(event.eventBytes) {
+						b.Fatal("batch unexpectedly full")
+					}
+					batch.append(event)
+				}
+				_ = batch.build()
+				batch.done()
+			}
+		})
+	}
+}
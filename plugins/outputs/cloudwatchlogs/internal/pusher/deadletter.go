@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/influxdata/telegraf"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+// keepRejected writes the records to the dead-letter store. Returns false if there is no store or the records
+// could not be written, in which case they are lost.
+func keepRejected(logger telegraf.Logger, store *deadletter.Store, target Target, records ...deadletter.Record) bool {
+	if store == nil || len(records) == 0 {
+		return false
+	}
+	if err := store.Add(records...); err != nil {
+		logger.Errorf("Unable to keep %d rejected log events for %v/%v: %v", len(records), target.Group, target.Stream, err)
+		return false
+	}
+	logger.Warnf("Kept %d rejected log events for %v/%v in %s", len(records), target.Group, target.Stream, store.Path())
+	return true
+}
+
+func newRejectedRecord(target Target, reason string, timestamp time.Time, message string, now time.Time) deadletter.Record {
+	return deadletter.Record{
+		RejectedAt: now,
+		Reason:     reason,
+		Group:      target.Group,
+		Stream:     target.Stream,
+		Class:      target.Class,
+		Timestamp:  timestamp,
+		Message:    message,
+	}
+}
+
+// rejectedRecords builds a record for every event the reason function returns a reason for.
+func rejectedRecords(target Target, events []*cloudwatchlogs.InputLogEvent, reason func(int) string) []deadletter.Record {
+	now := time.Now()
+	var records []deadletter.Record
+	for i, event := range events {
+		if r := reason(i); r != "" {
+			records = append(records, newRejectedRecord(target, r, time.UnixMilli(aws.Int64Value(event.Timestamp)), aws.StringValue(event.Message), now))
+		}
+	}
+	return records
+}
+
+// keepRejectedEvents keeps the events of an accepted request that CloudWatch Logs rejected. The indexes in the
+// info refer to the events of the request.
+func (s *sender) keepRejectedEvents(target Target, events []*cloudwatchlogs.InputLogEvent, info *cloudwatchlogs.RejectedLogEventsInfo) {
+	expiredEnd := int(aws.Int64Value(info.ExpiredLogEventEndIndex))
+	tooOldEnd := int(aws.Int64Value(info.TooOldLogEventEndIndex))
+	tooNewStart := len(events)
+	if info.TooNewLogEventStartIndex != nil {
+		tooNewStart = int(*info.TooNewLogEventStartIndex)
+	}
+	keepRejected(s.logger, s.deadLetter, target, rejectedRecords(target, events, func(i int) string {
+		switch {
+		case i < expiredEnd:
+			return deadletter.ReasonExpired
+		case i < tooOldEnd:
+			return deadletter.ReasonTooOld
+		case i >= tooNewStart:
+			return deadletter.ReasonTooNew
+		}
+		return ""
+	})...)
+}
@@ -0,0 +1,160 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/influxdata/telegraf"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/failover"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+// maxEventBytes is the largest message of a log event CloudWatch Logs accepts.
+const maxEventBytes = 256*1024 - 26
+
+// failoverService sends the requests of the pushers and of the target manager to the region the failover regions
+// pick. The log events sent to a failover region are marked with the FailoverFrom field when they are JSON objects,
+// e.g. the EMF logs. Their log group and stream are created in the failover region when they are missing, since the
+// target manager only initializes each target once.
+type failoverService struct {
+	logger  telegraf.Logger
+	regions *failover.Regions
+	clients map[string]cloudWatchLogsService
+}
+
+var _ cloudWatchLogsService = (*failoverService)(nil)
+
+// NewService returns the client of the region, or a service failing over between the clients of the regions when
+// there are failover regions.
+func NewService(logger telegraf.Logger, region string, regions *failover.Regions, newClient func(region string) *cloudwatchlogs.CloudWatchLogs) cloudWatchLogsService {
+	if regions == nil {
+		return newClient(region)
+	}
+	s := &failoverService{logger: logger, regions: regions, clients: map[string]cloudWatchLogsService{}}
+	for _, r := range regions.All() {
+		s.clients[r] = newClient(r)
+	}
+	return s
+}
+
+func (s *failoverService) PutLogEvents(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	region := s.regions.Next()
+	client := s.clients[region]
+	if !s.regions.IsFailover(region) {
+		output, err := client.PutLogEvents(input)
+		s.regions.Record(region, err)
+		return output, err
+	}
+	input = withFailoverField(input, s.regions.Primary())
+	output, err := client.PutLogEvents(input)
+	var notFound *cloudwatchlogs.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		if err = s.createTarget(client, input.LogGroupName, input.LogStreamName); err == nil {
+			s.logger.Infof("Created log stream %v/%v in failover region %v", aws.StringValue(input.LogGroupName), aws.StringValue(input.LogStreamName), region)
+			output, err = client.PutLogEvents(input)
+		}
+	}
+	s.regions.Record(region, err)
+	return output, err
+}
+
+// createTarget creates the log group and stream of a batch in a failover region.
+func (s *failoverService) createTarget(client cloudWatchLogsService, group, stream *string) error {
+	_, err := client.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{LogGroupName: group, LogStreamName: stream})
+	var notFound *cloudwatchlogs.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		if _, err = client.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{LogGroupName: group}); err != nil && !isAlreadyExists(err) {
+			return err
+		}
+		_, err = client.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{LogGroupName: group, LogStreamName: stream})
+	}
+	if isAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+func isAlreadyExists(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == cloudwatchlogs.ErrCodeResourceAlreadyExistsException
+}
+
+// withFailoverField returns a copy of the request with the field marking the log events sent to a failover region
+// added to the messages that are JSON objects. The other messages are sent unchanged, since they have no fields.
+func withFailoverField(input *cloudwatchlogs.PutLogEventsInput, primary string) *cloudwatchlogs.PutLogEventsInput {
+	field := strconv.Quote(failover.MarkerKey) + ":" + strconv.Quote(primary)
+	marked := *input
+	marked.LogEvents = make([]*cloudwatchlogs.InputLogEvent, len(input.LogEvents))
+	for i, event := range input.LogEvents {
+		marked.LogEvents[i] = event
+		message := strings.TrimSpace(aws.StringValue(event.Message))
+		if !strings.HasPrefix(message, "{") || len(message)+len(field)+1 > maxEventBytes {
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if json.Unmarshal([]byte(message), &fields) != nil {
+			continue
+		}
+		if _, ok := fields[failover.MarkerKey]; ok {
+			continue
+		}
+		separator := ","
+		if len(fields) == 0 {
+			separator = ""
+		}
+		copied := *event
+		copied.Message = aws.String("{" + field + separator + message[1:])
+		marked.LogEvents[i] = &copied
+	}
+	return &marked
+}
+
+func (s *failoverService) CreateLogStream(input *cloudwatchlogs.CreateLogStreamInput) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	region := s.regions.Next()
+	output, err := s.clients[region].CreateLogStream(input)
+	s.regions.Record(region, err)
+	return output, err
+}
+
+func (s *failoverService) CreateLogGroup(input *cloudwatchlogs.CreateLogGroupInput) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	region := s.regions.Next()
+	output, err := s.clients[region].CreateLogGroup(input)
+	s.regions.Record(region, err)
+	return output, err
+}
+
+func (s *failoverService) PutRetentionPolicy(input *cloudwatchlogs.PutRetentionPolicyInput) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+	region := s.regions.Next()
+	output, err := s.clients[region].PutRetentionPolicy(input)
+	s.regions.Record(region, err)
+	return output, err
+}
+
+func (s *failoverService) DescribeLogGroups(input *cloudwatchlogs.DescribeLogGroupsInput) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	region := s.regions.Next()
+	output, err := s.clients[region].DescribeLogGroups(input)
+	s.regions.Record(region, err)
+	return output, err
+}
+
+func (s *failoverService) PutDataProtectionPolicy(input *cloudwatchlogs.PutDataProtectionPolicyInput) (*cloudwatchlogs.PutDataProtectionPolicyOutput, error) {
+	region := s.regions.Next()
+	output, err := s.clients[region].PutDataProtectionPolicy(input)
+	s.regions.Record(region, err)
+	return output, err
+}
+
+func (s *failoverService) PutIndexPolicy(input *cloudwatchlogs.PutIndexPolicyInput) (*cloudwatchlogs.PutIndexPolicyOutput, error) {
+	region := s.regions.Next()
+	output, err := s.clients[region].PutIndexPolicy(input)
+	s.regions.Record(region, err)
+	return output, err
+}
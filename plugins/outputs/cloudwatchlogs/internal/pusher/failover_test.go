@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/failover"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestFailoverService(t *testing.T) {
+	primary := new(mockLogsService)
+	primary.On("PutLogEvents", mock.Anything).Return((*cloudwatchlogs.PutLogEventsOutput)(nil), awserr.New(request.ErrCodeRequestError, "send request failed", nil))
+	secondary := new(mockLogsService)
+	notFound := &cloudwatchlogs.ResourceNotFoundException{}
+	secondary.On("PutLogEvents", mock.Anything).Return((*cloudwatchlogs.PutLogEventsOutput)(nil), notFound).Once()
+	var sent *cloudwatchlogs.PutLogEventsInput
+	secondary.On("PutLogEvents", mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(0).(*cloudwatchlogs.PutLogEventsInput)
+	}).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil).Once()
+	secondary.On("CreateLogStream", mock.Anything).Return((*cloudwatchlogs.CreateLogStreamOutput)(nil), notFound).Once()
+	secondary.On("CreateLogGroup", mock.Anything).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil).Once()
+	secondary.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil).Once()
+
+	s := &failoverService{
+		logger: testutil.NewNopLogger(),
+		// the primary region fails over on its second failure
+		regions: failover.New("cloudwatchlogs", "us-east-1", []string{"us-west-2"}, time.Nanosecond),
+		clients: map[string]cloudWatchLogsService{"us-east-1": primary, "us-west-2": secondary},
+	}
+	input := &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String("app"),
+		LogStreamName: aws.String("i-123"),
+		LogEvents: []*cloudwatchlogs.InputLogEvent{
+			{Message: aws.String("started"), Timestamp: aws.Int64(1)},
+			{Message: aws.String(`{"_aws":{},"latency":3}`), Timestamp: aws.Int64(2)},
+			{Message: aws.String(`{}`), Timestamp: aws.Int64(3)},
+			{Message: aws.String(`{"FailoverFrom":"eu-west-1"}`), Timestamp: aws.Int64(4)},
+			{Message: aws.String(`{"truncated":`), Timestamp: aws.Int64(5)},
+		},
+	}
+	_, err := s.PutLogEvents(input)
+	assert.Error(t, err)
+	_, err = s.PutLogEvents(input)
+	assert.Error(t, err)
+	_, err = s.PutLogEvents(input)
+	require.NoError(t, err)
+
+	primary.AssertNumberOfCalls(t, "PutLogEvents", 2)
+	secondary.AssertExpectations(t)
+	require.NotNil(t, sent)
+	var messages []string
+	for _, event := range sent.LogEvents {
+		messages = append(messages, *event.Message)
+	}
+	assert.Equal(t, []string{
+		"started",
+		`{"FailoverFrom":"us-east-1","_aws":{},"latency":3}`,
+		`{"FailoverFrom":"us-east-1"}`,
+		`{"FailoverFrom":"eu-west-1"}`,
+		`{"truncated":`,
+	}, messages)
+	assert.Equal(t, `{"_aws":{},"latency":3}`, *input.LogEvents[1].Message, "the events of the batch are not changed")
+}
@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"sync"
+)
+
+// BufferLimit bounds the bytes of the log events the pushers of an output hold until they are sent. Once the limit
+// is reached, e.g. while CloudWatch Logs is unreachable, adding an event blocks until a batch is sent or dropped, so
+// the log files stop being read instead of the events piling up in memory. A nil BufferLimit does not limit.
+type BufferLimit struct {
+	limit int
+
+	mu   sync.Mutex
+	used int
+	// released is closed, and replaced, when bytes are released.
+	released chan struct{}
+}
+
+func NewBufferLimit(limit int) *BufferLimit {
+	if limit <= 0 {
+		return nil
+	}
+	return &BufferLimit{limit: limit, released: make(chan struct{})}
+}
+
+// acquire adds n bytes to the buffer once it is below the limit. A single event larger than the limit is accepted
+// by an empty buffer. It returns whether it had to wait, and false for ok if stop was closed while waiting.
+func (b *BufferLimit) acquire(n int, stop <-chan struct{}) (waited, ok bool) {
+	if b == nil {
+		return false, true
+	}
+	for {
+		b.mu.Lock()
+		if b.used < b.limit {
+			b.used += n
+			b.mu.Unlock()
+			return waited, true
+		}
+		released := b.released
+		b.mu.Unlock()
+		waited = true
+		select {
+		case <-released:
+		case <-stop:
+			return waited, false
+		}
+	}
+}
+
+// add adds n bytes to the buffer without waiting, for the events which are dropped rather than waited for.
+func (b *BufferLimit) add(n int) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used += n
+}
+
+// release removes n bytes from the buffer and wakes up the pushers waiting on it.
+func (b *BufferLimit) release(n int) {
+	if b == nil || n == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used = max(b.used-n, 0)
+	close(b.released)
+	b.released = make(chan struct{})
+}
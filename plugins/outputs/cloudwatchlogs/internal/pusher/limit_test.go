@@ -0,0 +1,94 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+func TestBufferLimit(t *testing.T) {
+	assert.Nil(t, NewBufferLimit(0))
+	var unlimited *BufferLimit
+	waited, ok := unlimited.acquire(100, nil)
+	assert.False(t, waited)
+	assert.True(t, ok)
+	unlimited.add(100)
+	unlimited.release(100)
+
+	b := NewBufferLimit(10)
+	// an event larger than the limit is accepted by an empty buffer
+	waited, ok = b.acquire(20, nil)
+	assert.False(t, waited)
+	assert.True(t, ok)
+
+	acquired := make(chan bool)
+	go func() {
+		waited, ok := b.acquire(5, nil)
+		assert.True(t, ok)
+		acquired <- waited
+	}()
+	select {
+	case <-acquired:
+		assert.Fail(t, "acquired over the limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+	b.release(15)
+	select {
+	case waited = <-acquired:
+		assert.True(t, waited)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "not acquired once released")
+	}
+	assert.Equal(t, 10, b.used)
+
+	stop := make(chan struct{})
+	close(stop)
+	_, ok = b.acquire(5, stop)
+	assert.False(t, ok)
+	b.release(100)
+	assert.Equal(t, 0, b.used)
+}
+
+func TestQueueWaitsForBufferLimit(t *testing.T) {
+	var wg sync.WaitGroup
+	var s stubLogsService
+	unblock := make(chan struct{})
+	s.ple = func(*cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+		<-unblock
+		return &cloudwatchlogs.PutLogEventsOutput{}, nil
+	}
+	stop, q := testPreparation(t, -1, &s, time.Hour, time.Hour, nil, &wg)
+	q.limit = NewBufferLimit(10)
+	defer func() {
+		close(stop)
+		wg.Wait()
+	}()
+
+	q.AddEvent(newStubLogEvent("0123456789", time.Now()))
+	time.Sleep(10 * time.Millisecond)
+	added := make(chan struct{})
+	go func() {
+		q.AddEvent(newStubLogEvent("next", time.Now()))
+		close(added)
+	}()
+	triggerSend(t, q)
+	select {
+	case <-added:
+		require.Fail(t, "event added while the first one is being sent")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(unblock)
+	select {
+	case <-added:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "event not added once the first one was sent")
+	}
+}
@@ -10,7 +10,8 @@ import (
 )
 
 type WorkerPool interface {
-	Submit(task func())
+	// Submit returns false if the pool was stopped before the task could be submitted.
+	Submit(task func()) bool
 	Stop()
 }
 
@@ -53,17 +54,18 @@ func (p *workerPool) worker() {
 }
 
 // Submit adds a task to the pool. Blocks until a worker is available to receive the task or the pool is stopped.
-func (p *workerPool) Submit(task func()) {
+func (p *workerPool) Submit(task func()) bool {
 	p.stopLock.RLock()
 	defer p.stopLock.RUnlock()
 	select {
 	case <-p.stopCh:
-		return
+		return false
 	default:
 		select {
 		case p.tasks <- task:
+			return true
 		case <-p.stopCh:
-			return
+			return false
 		}
 	}
 }
@@ -87,10 +89,14 @@ func (p *workerPool) Stop() {
 	}
 }
 
-// senderPool wraps a Sender with a WorkerPool for concurrent sending.
+// senderPool wraps the Sender of a target with a WorkerPool shared by the targets, so that the targets are sent
+// concurrently. The batches of the target are sent one at a time, in order, so a target that is slow or retrying
+// holds at most one worker and leaves the others to the other targets.
 type senderPool struct {
 	workerPool WorkerPool
 	sender     Sender
+	// inFlight holds a token while a batch of the target is submitted or being sent.
+	inFlight chan struct{}
 }
 
 var _ Sender = (*senderPool)(nil)
@@ -99,14 +105,21 @@ func newSenderPool(workerPool WorkerPool, sender Sender) Sender {
 	return &senderPool{
 		workerPool: workerPool,
 		sender:     sender,
+		inFlight:   make(chan struct{}, 1),
 	}
 }
 
-// Send submits a send task to the worker pool.
+// Send submits a send task to the worker pool once the previous batch of the target was sent, which blocks the
+// queue of the target in the meantime.
 func (s *senderPool) Send(batch *logEventBatch) {
-	s.workerPool.Submit(func() {
+	s.inFlight <- struct{}{}
+	submitted := s.workerPool.Submit(func() {
+		defer func() { <-s.inFlight }()
 		s.sender.Send(batch)
 	})
+	if !submitted {
+		<-s.inFlight
+	}
 }
 
 // SetRetryDuration sets the retry duration on the wrapped Sender.
@@ -108,7 +108,7 @@ func TestSenderPool(t *testing.T) {
 	stop := make(chan struct{})
 	mockService := new(mockLogsService)
 	mockService.On("PutLogEvents", mock.Anything).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil)
-	s := newSender(logger, mockService, nil, time.Second, stop)
+	s := newSender(logger, mockService, nil, time.Second, stop, nil)
 	p := NewWorkerPool(12)
 	sp := newSenderPool(p, s)
 
@@ -134,3 +134,66 @@ func TestSenderPool(t *testing.T) {
 	p.Stop()
 	assert.Equal(t, int32(200), completed.Load())
 }
+
+// recordingSender records the streams of the batches it sends, waiting on its gate for each.
+type recordingSender struct {
+	gate chan struct{}
+	mu   sync.Mutex
+	sent []string
+}
+
+func (s *recordingSender) Send(batch *logEventBatch) {
+	if s.gate != nil {
+		<-s.gate
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, batch.Stream)
+}
+
+func (s *recordingSender) SetRetryDuration(time.Duration) {}
+
+func (s *recordingSender) RetryDuration() time.Duration {
+	return 0
+}
+
+func (s *recordingSender) Sent() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.sent...)
+}
+
+func TestSenderPoolSequencesTarget(t *testing.T) {
+	p := NewWorkerPool(4)
+	slow := &recordingSender{gate: make(chan struct{})}
+	fast := &recordingSender{}
+	slowPool, fastPool := newSenderPool(p, slow), newSenderPool(p, fast)
+
+	queued := make(chan struct{})
+	go func() {
+		for _, stream := range []string{"1", "2", "3"} {
+			slowPool.Send(&logEventBatch{Target: Target{Group: "G", Stream: stream}})
+		}
+		close(queued)
+	}()
+	// the batches of the slow target wait for each other rather than taking the other workers
+	for _, stream := range []string{"a", "b", "c", "d", "e"} {
+		fastPool.Send(&logEventBatch{Target: Target{Group: "G", Stream: stream}})
+	}
+	assert.Eventually(t, func() bool {
+		return len(fast.Sent()) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, slow.Sent())
+
+	for i := 0; i < 3; i++ {
+		slow.gate <- struct{}{}
+	}
+	<-queued
+	p.Stop()
+	assert.Equal(t, []string{"1", "2", "3"}, slow.Sent())
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, fast.Sent())
+
+	// once the pool is stopped, sending does not block
+	slowPool.Send(&logEventBatch{})
+	slowPool.Send(&logEventBatch{})
+}
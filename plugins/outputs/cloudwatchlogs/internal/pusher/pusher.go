@@ -9,6 +9,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 )
 
@@ -23,7 +24,8 @@ type Pusher struct {
 }
 
 // NewPusher creates a new Pusher instance with a new Queue and Sender. Calls PutRetentionPolicy using the
-// TargetManager.
+// TargetManager. Events CloudWatch Logs will not accept are kept in the dead-letter store if there is one. The limit
+// is shared by the pushers of the output.
 func NewPusher(
 	logger telegraf.Logger,
 	target Target,
@@ -35,9 +37,11 @@ func NewPusher(
 	retryDuration time.Duration,
 	stop <-chan struct{},
 	wg *sync.WaitGroup,
+	deadLetter *deadletter.Store,
+	limit *BufferLimit,
 ) *Pusher {
-	s := createSender(logger, service, targetManager, workerPool, retryDuration, stop)
-	q := newQueue(logger, target, flushTimeout, entityProvider, s, stop, wg)
+	s := createSender(logger, service, targetManager, workerPool, retryDuration, stop, deadLetter)
+	q := newQueue(logger, target, flushTimeout, entityProvider, s, stop, wg, deadLetter, limit)
 	targetManager.PutRetentionPolicy(target)
 	return &Pusher{
 		Target:         target,
@@ -57,8 +61,9 @@ func createSender(
 	workerPool WorkerPool,
 	retryDuration time.Duration,
 	stop <-chan struct{},
+	deadLetter *deadletter.Store,
 ) Sender {
-	s := newSender(logger, service, targetManager, retryDuration, stop)
+	s := newSender(logger, service, targetManager, retryDuration, stop, deadLetter)
 	if workerPool == nil {
 		return s
 	}
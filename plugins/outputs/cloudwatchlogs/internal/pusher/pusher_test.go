@@ -87,6 +87,8 @@ func setupPusher(t *testing.T, workerPool WorkerPool, stop chan struct{}, wg *sy
 		time.Minute,
 		stop,
 		wg,
+		nil,
+		nil,
 	)
 
 	assert.NotNil(t, pusher)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/influxdata/telegraf"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/profiler"
 )
@@ -40,6 +41,10 @@ type queue struct {
 	initNonBlockingChOnce sync.Once
 	startNonBlockCh       chan struct{}
 	wg                    *sync.WaitGroup
+	// deadLetter keeps the events discarded for being out of the accepted time range.
+	deadLetter *deadletter.Store
+	// limit bounds the bytes of the events held by the queues of the output until they are sent.
+	limit *BufferLimit
 }
 
 func newQueue(
@@ -50,6 +55,8 @@ func newQueue(
 	sender Sender,
 	stop <-chan struct{},
 	wg *sync.WaitGroup,
+	deadLetter *deadletter.Store,
+	limit *BufferLimit,
 ) Queue {
 	q := &queue{
 		target:          target,
@@ -64,6 +71,8 @@ func newQueue(
 		stop:            stop,
 		startNonBlockCh: make(chan struct{}),
 		wg:              wg,
+		deadLetter:      deadLetter,
+		limit:           limit,
 	}
 	q.flushTimeout.Store(flushTimeout)
 	q.wg.Add(1)
@@ -71,10 +80,18 @@ func newQueue(
 	return q
 }
 
-// AddEvent adds an event to the queue blocking if full.
+// AddEvent adds an event to the queue blocking if full, or while the events held by the output are over the limit.
 func (q *queue) AddEvent(e logs.LogEvent) {
 	if !hasValidTime(e) {
-		q.logger.Errorf("The log entry in (%v/%v) with timestamp (%v) comparing to the current time (%v) is out of accepted time range. Discard the log entry.", q.target.Group, q.target.Stream, e.Time(), time.Now())
+		q.discard(e)
+		return
+	}
+	waited, ok := q.limit.acquire(len(e.Message()), q.stop)
+	if waited {
+		q.addStats("bufferLimitWait", 1)
+		q.logger.Debugf("Paused adding events to %v/%v until the events buffered for CloudWatch Logs dropped below the limit", q.target.Group, q.target.Stream)
+	}
+	if !ok {
 		return
 	}
 	q.eventsCh <- e
@@ -84,7 +101,7 @@ func (q *queue) AddEvent(e logs.LogEvent) {
 // the queue.
 func (q *queue) AddEventNonBlocking(e logs.LogEvent) {
 	if !hasValidTime(e) {
-		q.logger.Errorf("The log entry in (%v/%v) with timestamp (%v) comparing to the current time (%v) is out of accepted time range. Discard the log entry.", q.target.Group, q.target.Stream, e.Time(), time.Now())
+		q.discard(e)
 		return
 	}
 
@@ -94,17 +111,33 @@ func (q *queue) AddEventNonBlocking(e logs.LogEvent) {
 	})
 
 	// Drain the channel until new event can be added
+	q.limit.add(len(e.Message()))
 	for {
 		select {
 		case q.nonBlockingEventsCh <- e:
 			return
 		default:
-			<-q.nonBlockingEventsCh
+			dropped := <-q.nonBlockingEventsCh
+			q.limit.release(len(dropped.Message()))
 			q.addStats("emfMetricDrop", 1)
 		}
 	}
 }
 
+// discard drops an event that is out of the accepted time range, keeping it in the dead-letter store if there is
+// one. A kept event is marked done, since it can be replayed from the store.
+func (q *queue) discard(e logs.LogEvent) {
+	now := time.Now()
+	q.logger.Errorf("The log entry in (%v/%v) with timestamp (%v) comparing to the current time (%v) is out of accepted time range. Discard the log entry.", q.target.Group, q.target.Stream, e.Time(), now)
+	reason := deadletter.ReasonTooOld
+	if e.Time().After(now) {
+		reason = deadletter.ReasonTooNew
+	}
+	if keepRejected(q.logger, q.deadLetter, q.target, newRejectedRecord(q.target, reason, e.Time(), e.Message(), now)) {
+		e.Done()
+	}
+}
+
 // start is the main loop for processing events and managing the queue.
 func (q *queue) start() {
 	defer q.wg.Done()
@@ -141,6 +174,8 @@ func (q *queue) start() {
 				q.send()
 			}
 			q.batch.append(event)
+			q.batch.reserved += len(e.Message())
+			watermarks.Collected(event.timestamp)
 		case <-q.flushCh:
 			lastSentTime, _ := q.lastSentTime.Load().(time.Time)
 			flushTimeout, _ := q.flushTimeout.Load().(time.Duration)
@@ -162,6 +197,7 @@ func (q *queue) start() {
 func (q *queue) send() {
 	if len(q.batch.events) > 0 {
 		q.batch.addDoneCallback(q.onSuccessCallback(q.batch.bufferedSize))
+		q.batch.limit = q.limit
 		q.sender.Send(q.batch)
 		q.batch = newLogEventBatch(q.target, q.entityProvider)
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/influxdata/telegraf"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
@@ -66,6 +67,14 @@ func (s *stubLogsService) DescribeLogGroups(in *cloudwatchlogs.DescribeLogGroups
 	return nil, nil
 }
 
+func (s *stubLogsService) PutDataProtectionPolicy(*cloudwatchlogs.PutDataProtectionPolicyInput) (*cloudwatchlogs.PutDataProtectionPolicyOutput, error) {
+	return nil, nil
+}
+
+func (s *stubLogsService) PutIndexPolicy(*cloudwatchlogs.PutIndexPolicyInput) (*cloudwatchlogs.PutIndexPolicyOutput, error) {
+	return nil, nil
+}
+
 func TestAddSingleEvent_WithAccountId(t *testing.T) {
 	t.Parallel()
 	var wg sync.WaitGroup
@@ -348,6 +357,33 @@ func TestIgnoreOutOfTimeRangeEvent(t *testing.T) {
 	wg.Wait()
 }
 
+func TestOutOfTimeRangeEventIsKeptInDeadLetter(t *testing.T) {
+	t.Parallel()
+	var wg sync.WaitGroup
+	var s stubLogsService
+
+	stop, q := testPreparation(t, -1, &s, 10*time.Millisecond, 2*time.Hour, nil, &wg)
+	q.deadLetter = deadletter.NewStore(t.TempDir(), 0)
+	var done int
+	old := newStubLogEvent("old", time.Now().Add(-15*24*time.Hour))
+	old.done = func() { done++ }
+	q.AddEvent(old)
+	q.AddEventNonBlocking(newStubLogEvent("new", time.Now().Add(2*time.Hour+time.Minute)))
+
+	records, err := q.deadLetter.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, deadletter.ReasonTooOld, records[0].Reason)
+	require.Equal(t, "old", records[0].Message)
+	require.Equal(t, "G", records[0].Group)
+	require.Equal(t, "S", records[0].Stream)
+	require.Equal(t, deadletter.ReasonTooNew, records[1].Reason)
+	require.Equal(t, 1, done)
+
+	close(stop)
+	wg.Wait()
+}
+
 func TestAddMultipleEvents(t *testing.T) {
 	t.Parallel()
 	var wg sync.WaitGroup
@@ -674,8 +710,8 @@ func testPreparationWithLogger(
 ) (chan struct{}, *queue) {
 	t.Helper()
 	stop := make(chan struct{})
-	tm := NewTargetManager(logger, service)
-	s := newSender(logger, service, tm, retryDuration, stop)
+	tm := NewTargetManager(logger, service, nil)
+	s := newSender(logger, service, tm, retryDuration, stop, nil)
 	q := newQueue(
 		logger,
 		Target{"G", "S", util.StandardLogGroupClass, retention},
@@ -684,6 +720,8 @@ func testPreparationWithLogger(
 		s,
 		stop,
 		wg,
+		nil,
+		nil,
 	)
 	return stop, q.(*queue)
 }
@@ -5,7 +5,6 @@ package pusher
 
 import (
 	"errors"
-	"math/rand"
 	"net"
 	"strings"
 	"time"
@@ -13,6 +12,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/internal/selftelemetry"
 )
 
 const (
@@ -34,6 +36,22 @@ const (
 	maxRetryDelay = 1 * time.Minute
 )
 
+// output is the name the retries of the pusher are counted under.
+const output = "cloudwatchlogs"
+
+var (
+	backoffShort = retryer.Backoff{Base: baseRetryDelayShort, Steps: numBackoffRetriesShort, Max: maxRetryDelay}
+	backoffLong  = retryer.Backoff{Base: baseRetryDelayLong, Steps: numBackoffRetriesLong, Max: maxRetryDelay}
+
+	// the policies share the retry budget and stats of the output, so the successes and drops are counted with
+	// the short one.
+	retryPolicyShort = retryer.NewPolicy(output, backoffShort)
+	retryPolicyLong  = retryer.NewPolicy(output, backoffLong)
+
+	// watermarks tracks the timestamps of the events queued and published, to report when the publishing stalls.
+	watermarks = selftelemetry.Watermarks.Register(output)
+)
+
 type retryWaitStrategy int
 
 const (
@@ -41,29 +59,6 @@ const (
 	retryLong
 )
 
-// retryWaitShort returns a duration to wait before retrying a request using the short retry strategy
-func retryWaitShort(retryCount int) time.Duration {
-	return retryWait(baseRetryDelayShort, numBackoffRetriesShort, retryCount)
-}
-
-// retryWaitLong returns a duration to wait before retrying a request using the long retry strategy.
-// this strategy is used for errors that should not be retried too quickly
-func retryWaitLong(retryCount int) time.Duration {
-	return retryWait(baseRetryDelayLong, numBackoffRetriesLong, retryCount)
-}
-
-func retryWait(baseRetryDelay time.Duration, maxBackoffRetries int, retryCount int) time.Duration {
-	d := maxRetryDelay
-	if retryCount < maxBackoffRetries {
-		d = baseRetryDelay * time.Duration(1<<int64(retryCount))
-	}
-	return withJitter(d)
-}
-
-func withJitter(d time.Duration) time.Duration {
-	return time.Duration(rand.Int63n(int64(d/2)) + int64(d/2)) // nolint:gosec
-}
-
 // chooseRetryWaitStrategy decides if a "long" or "short" retry strategy should be used when the PutLogEvents API call
 // returns an error. A short retry strategy should be used for most errors, while a long retry strategy is used for
 // errors where retrying too quickly could cause excessive strain on the backend servers.
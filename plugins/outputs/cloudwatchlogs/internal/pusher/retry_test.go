@@ -143,7 +143,7 @@ func TestRetryWaitShort(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("%d", tt.retryCount), func(t *testing.T) {
 			for _ = range 1000 {
-				duration := retryWaitShort(tt.retryCount)
+				duration := backoffShort.Delay(tt.retryCount)
 				assert.GreaterOrEqual(t, duration, tt.minDuration, "retryWaitShort(%v) should be greater than or equal to %v", tt.retryCount, tt.minDuration)
 				assert.LessOrEqual(t, duration, tt.maxDuration, "retryWaitShort(%v) should be less than or equal to %v", tt.retryCount, tt.maxDuration)
 			}
@@ -203,7 +203,7 @@ func TestRetryWaitLong(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("%d", tt.retryCount), func(t *testing.T) {
 			for _ = range 1000 {
-				duration := retryWaitLong(tt.retryCount)
+				duration := backoffLong.Delay(tt.retryCount)
 				assert.GreaterOrEqual(t, duration, tt.minDuration, "retryWaitLong(%v) should be greater than or equal to %v", tt.retryCount, tt.minDuration)
 				assert.LessOrEqual(t, duration, tt.maxDuration, "retryWaitLong(%v) should be less than or equal to %v", tt.retryCount, tt.maxDuration)
 			}
@@ -11,6 +11,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/influxdata/telegraf"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
+	"github.com/aws/amazon-cloudwatch-agent/internal/errcode"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 )
 
@@ -20,6 +22,8 @@ type cloudWatchLogsService interface {
 	CreateLogGroup(input *cloudwatchlogs.CreateLogGroupInput) (*cloudwatchlogs.CreateLogGroupOutput, error)
 	PutRetentionPolicy(input *cloudwatchlogs.PutRetentionPolicyInput) (*cloudwatchlogs.PutRetentionPolicyOutput, error)
 	DescribeLogGroups(input *cloudwatchlogs.DescribeLogGroupsInput) (*cloudwatchlogs.DescribeLogGroupsOutput, error)
+	PutDataProtectionPolicy(input *cloudwatchlogs.PutDataProtectionPolicyInput) (*cloudwatchlogs.PutDataProtectionPolicyOutput, error)
+	PutIndexPolicy(input *cloudwatchlogs.PutIndexPolicyInput) (*cloudwatchlogs.PutIndexPolicyOutput, error)
 }
 
 type Sender interface {
@@ -34,6 +38,7 @@ type sender struct {
 	targetManager TargetManager
 	logger        telegraf.Logger
 	stop          <-chan struct{}
+	deadLetter    *deadletter.Store
 }
 
 func newSender(
@@ -42,12 +47,14 @@ func newSender(
 	targetManager TargetManager,
 	retryDuration time.Duration,
 	stop <-chan struct{},
+	deadLetter *deadletter.Store,
 ) Sender {
 	s := &sender{
 		logger:        logger,
 		service:       service,
 		targetManager: targetManager,
 		stop:          stop,
+		deadLetter:    deadLetter,
 	}
 	s.retryDuration.Store(retryDuration)
 	return s
@@ -56,6 +63,7 @@ func newSender(
 // Send attempts to send a batch of log events to CloudWatch Logs. Will retry failed attempts until it reaches the
 // RetryDuration or an unretryable error.
 func (s *sender) Send(batch *logEventBatch) {
+	defer batch.release()
 	if len(batch.events) == 0 {
 		return
 	}
@@ -67,6 +75,8 @@ func (s *sender) Send(batch *logEventBatch) {
 	for {
 		output, err := s.service.PutLogEvents(input)
 		if err == nil {
+			retryPolicyShort.Succeeded()
+			watermarks.Exported(batch.maxT)
 			if output.RejectedLogEventsInfo != nil {
 				info := output.RejectedLogEventsInfo
 				if info.TooOldLogEventEndIndex != nil {
@@ -78,6 +88,7 @@ func (s *sender) Send(batch *logEventBatch) {
 				if info.ExpiredLogEventEndIndex != nil {
 					s.logger.Warnf("%d log events for log '%s/%s' are expired", *info.ExpiredLogEventEndIndex, batch.Group, batch.Stream)
 				}
+				s.keepRejectedEvents(batch.Target, input.LogEvents, info)
 			}
 			batch.done()
 			s.logger.Debugf("Pusher published %v log events to group: %v stream: %v with size %v KB in %v.", len(batch.events), batch.Group, batch.Stream, batch.bufferedSize/1024, time.Since(startTime))
@@ -86,7 +97,7 @@ func (s *sender) Send(batch *logEventBatch) {
 
 		var awsErr awserr.Error
 		if !errors.As(err, &awsErr) {
-			s.logger.Errorf("Non aws error received when sending logs to %v/%v: %v. CloudWatch agent will not retry and logs will be missing!", batch.Group, batch.Stream, err)
+			s.logger.Errorf("Non aws error received when sending logs to %v/%v: %v. CloudWatch agent will not retry and logs will be missing! %s", batch.Group, batch.Stream, err, errcode.Tag(errcode.Record(err)))
 			return
 		}
 
@@ -96,26 +107,37 @@ func (s *sender) Send(batch *logEventBatch) {
 				s.logger.Errorf("Unable to create log stream %v/%v: %v", batch.Group, batch.Stream, targetErr)
 				break
 			}
-		case *cloudwatchlogs.InvalidParameterException,
-			*cloudwatchlogs.DataAlreadyAcceptedException:
+		case *cloudwatchlogs.DataAlreadyAcceptedException:
+			// an earlier attempt of this batch succeeded, so its events are delivered
+			s.logger.Warnf("Batch to %v/%v was already accepted: %v", batch.Group, batch.Stream, e)
+			watermarks.Exported(batch.maxT)
+			batch.done()
+			return
+		case *cloudwatchlogs.InvalidParameterException:
 			s.logger.Errorf("%v, will not retry the request", e)
+			if keepRejected(s.logger, s.deadLetter, batch.Target, rejectedRecords(batch.Target, input.LogEvents, func(int) string {
+				return deadletter.ReasonInvalid
+			})...) {
+				batch.done()
+			}
 			return
 		default:
-			s.logger.Errorf("Aws error received when sending logs to %v/%v: %v", batch.Group, batch.Stream, awsErr)
+			s.logger.Errorf("Aws error received when sending logs to %v/%v: %v %s", batch.Group, batch.Stream, awsErr, errcode.Tag(errcode.Record(err)))
 		}
 
 		// retry wait strategy depends on the type of error returned
 		var wait time.Duration
 		if chooseRetryWaitStrategy(err) == retryLong {
-			wait = retryWaitLong(retryCountLong)
+			wait = retryPolicyLong.Wait(retryCountLong, err)
 			retryCountLong++
 		} else {
-			wait = retryWaitShort(retryCountShort)
+			wait = retryPolicyShort.Wait(retryCountShort, err)
 			retryCountShort++
 		}
 
 		if time.Since(startTime)+wait > s.RetryDuration() {
 			s.logger.Errorf("All %v retries to %v/%v failed for PutLogEvents, request dropped.", retryCountShort+retryCountLong-1, batch.Group, batch.Stream)
+			retryPolicyShort.Dropped()
 			return
 		}
 
@@ -124,6 +146,7 @@ func (s *sender) Send(batch *logEventBatch) {
 		select {
 		case <-s.stop:
 			s.logger.Errorf("Stop requested after %v retries to %v/%v failed for PutLogEvents, request dropped.", retryCountShort+retryCountLong-1, batch.Group, batch.Stream)
+			retryPolicyShort.Dropped()
 			return
 		case <-time.After(wait):
 		}
@@ -10,8 +10,11 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/deadletter"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
 )
@@ -45,6 +48,16 @@ func (m *mockLogsService) DescribeLogGroups(input *cloudwatchlogs.DescribeLogGro
 	return args.Get(0).(*cloudwatchlogs.DescribeLogGroupsOutput), args.Error(1)
 }
 
+func (m *mockLogsService) PutDataProtectionPolicy(input *cloudwatchlogs.PutDataProtectionPolicyInput) (*cloudwatchlogs.PutDataProtectionPolicyOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*cloudwatchlogs.PutDataProtectionPolicyOutput), args.Error(1)
+}
+
+func (m *mockLogsService) PutIndexPolicy(input *cloudwatchlogs.PutIndexPolicyInput) (*cloudwatchlogs.PutIndexPolicyOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*cloudwatchlogs.PutIndexPolicyOutput), args.Error(1)
+}
+
 type mockTargetManager struct {
 	mock.Mock
 }
@@ -75,7 +88,7 @@ func TestSender(t *testing.T) {
 		mockManager := new(mockTargetManager)
 		mockService.On("PutLogEvents", mock.Anything).Return(&cloudwatchlogs.PutLogEventsOutput{RejectedLogEventsInfo: rejectedInfo}, nil).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}), nil)
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -93,7 +106,7 @@ func TestSender(t *testing.T) {
 		mockManager.On("InitTarget", mock.Anything).Return(nil).Once()
 		mockService.On("PutLogEvents", mock.Anything).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}), nil)
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -109,12 +122,66 @@ func TestSender(t *testing.T) {
 		mockService.On("PutLogEvents", mock.Anything).
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, &cloudwatchlogs.InvalidParameterException{}).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}), nil)
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("DeadLetter/RejectedLogEvents", func(t *testing.T) {
+		now := time.Now()
+		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+		for i, message := range []string{"expired", "too old", "accepted", "too new"} {
+			batch.append(newLogEvent(now.Add(time.Duration(i)*time.Millisecond), message, nil))
+		}
+		rejectedInfo := &cloudwatchlogs.RejectedLogEventsInfo{
+			ExpiredLogEventEndIndex:  aws.Int64(1),
+			TooOldLogEventEndIndex:   aws.Int64(2),
+			TooNewLogEventStartIndex: aws.Int64(3),
+		}
+		mockService := new(mockLogsService)
+		mockService.On("PutLogEvents", mock.Anything).Return(&cloudwatchlogs.PutLogEventsOutput{RejectedLogEventsInfo: rejectedInfo}, nil).Once()
+
+		store := deadletter.NewStore(t.TempDir(), 0)
+		s := newSender(logger, mockService, new(mockTargetManager), time.Second, make(chan struct{}), store)
+		s.Send(batch)
+
+		records, err := store.Records()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, deadletter.ReasonExpired, records[0].Reason)
+		assert.Equal(t, "expired", records[0].Message)
+		assert.Equal(t, deadletter.ReasonTooOld, records[1].Reason)
+		assert.Equal(t, deadletter.ReasonTooNew, records[2].Reason)
+		assert.Equal(t, "too new", records[2].Message)
+		assert.Equal(t, "G", records[2].Group)
+		assert.Equal(t, now.Add(3*time.Millisecond).UnixMilli(), records[2].Timestamp.UnixMilli())
+	})
+
+	t.Run("DeadLetter/InvalidParameter", func(t *testing.T) {
+		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+		batch.append(newLogEvent(time.Now(), "Test message", nil))
+		var done bool
+		batch.addDoneCallback(func() { done = true })
+
+		mockService := new(mockLogsService)
+		mockService.On("PutLogEvents", mock.Anything).
+			Return(&cloudwatchlogs.PutLogEventsOutput{}, &cloudwatchlogs.InvalidParameterException{}).Once()
+
+		store := deadletter.NewStore(t.TempDir(), 0)
+		s := newSender(logger, mockService, new(mockTargetManager), time.Second, make(chan struct{}), store)
+		s.Send(batch)
+
+		mockService.AssertExpectations(t)
+		records, err := store.Records()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, deadletter.ReasonInvalid, records[0].Reason)
+		assert.Equal(t, "Test message", records[0].Message)
+		// the events are kept, so the batch counts as done and the file offsets move on
+		assert.True(t, done)
+	})
+
 	t.Run("Error/DataAlreadyAccepted", func(t *testing.T) {
 		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
 		batch.append(newLogEvent(time.Now(), "Test message", nil))
@@ -124,10 +191,39 @@ func TestSender(t *testing.T) {
 		mockService.On("PutLogEvents", mock.Anything).
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, &cloudwatchlogs.DataAlreadyAcceptedException{}).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		var done bool
+		batch.addDoneCallback(func() { done = true })
+
+		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}), nil)
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
+		assert.True(t, done)
+	})
+
+	t.Run("Send/RepeatedContent", func(t *testing.T) {
+		// the same log line can legitimately be written twice at the same time, both are delivered
+		now := time.Now()
+		first := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+		first.append(newLogEvent(now, "Test message", nil))
+		second := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+		second.append(newLogEvent(now, "Test message", nil))
+
+		mockService := new(mockLogsService)
+		mockManager := new(mockTargetManager)
+		mockService.On("PutLogEvents", mock.Anything).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil).Twice()
+
+		var firstDone, secondDone bool
+		first.addDoneCallback(func() { firstDone = true })
+		second.addDoneCallback(func() { secondDone = true })
+
+		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}), nil)
+		s.Send(first)
+		s.Send(second)
+
+		mockService.AssertExpectations(t)
+		assert.True(t, firstDone)
+		assert.True(t, secondDone)
 	})
 
 	t.Run("Error/DropOnGeneric", func(t *testing.T) {
@@ -139,7 +235,7 @@ func TestSender(t *testing.T) {
 		mockService.On("PutLogEvents", mock.Anything).
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, errors.New("test")).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}), nil)
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -156,7 +252,7 @@ func TestSender(t *testing.T) {
 		mockService.On("PutLogEvents", mock.Anything).
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, nil).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}), nil)
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -171,7 +267,7 @@ func TestSender(t *testing.T) {
 		mockService.On("PutLogEvents", mock.Anything).
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, awserr.New("SomeAWSError", "Some AWS error", nil)).Once()
 
-		s := newSender(logger, mockService, mockManager, 100*time.Millisecond, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, 100*time.Millisecond, make(chan struct{}), nil)
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -187,7 +283,7 @@ func TestSender(t *testing.T) {
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, awserr.New("SomeAWSError", "Some AWS error", nil)).Once()
 
 		stopCh := make(chan struct{})
-		s := newSender(logger, mockService, mockManager, time.Second, stopCh)
+		s := newSender(logger, mockService, mockManager, time.Second, stopCh, nil)
 
 		go func() {
 			time.Sleep(50 * time.Millisecond)
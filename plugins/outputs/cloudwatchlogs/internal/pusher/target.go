@@ -10,8 +10,10 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/gobwas/glob"
 	"github.com/influxdata/telegraf"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 )
 
@@ -24,11 +26,28 @@ const (
 	numBackoffRetries   = 5
 )
 
+var (
+	targetBackoff     = retryer.Backoff{Base: baseRetryDelay, Steps: numBackoffRetries, Max: maxRetryDelayTarget}
+	targetRetryPolicy = retryer.NewPolicy(output, targetBackoff)
+)
+
 type Target struct {
 	Group, Stream, Class string
 	Retention            int
 }
 
+// GroupPolicy holds the policies attached to the log groups the agent creates whose name matches Pattern.
+type GroupPolicy struct {
+	Pattern glob.Glob
+	// DataProtectionPolicy is the JSON data protection policy document, if any.
+	DataProtectionPolicy string
+	// IndexPolicy is the JSON field index policy document, if any.
+	IndexPolicy string
+	// KMSKeyID is the ARN of the KMS key the log group is encrypted with, if any. Unlike the policies, it is set
+	// when the log group is created.
+	KMSKeyID string
+}
+
 type TargetManager interface {
 	InitTarget(target Target) error
 	PutRetentionPolicy(target Target)
@@ -42,19 +61,30 @@ type targetManager struct {
 	mu    sync.Mutex
 	dlg   chan Target
 	prp   chan Target
+	// policies are checked in order and the first one matching a new log group is applied to it.
+	policies []GroupPolicy
+	gpc      chan groupPolicyTarget
 }
 
-func NewTargetManager(logger telegraf.Logger, service cloudWatchLogsService) TargetManager {
+type groupPolicyTarget struct {
+	group  string
+	policy GroupPolicy
+}
+
+func NewTargetManager(logger telegraf.Logger, service cloudWatchLogsService, policies []GroupPolicy) TargetManager {
 	tm := &targetManager{
-		logger:  logger,
-		service: service,
-		cache:   make(map[Target]struct{}),
-		dlg:     make(chan Target, retentionChannelSize),
-		prp:     make(chan Target, retentionChannelSize),
+		logger:   logger,
+		service:  service,
+		cache:    make(map[Target]struct{}),
+		dlg:      make(chan Target, retentionChannelSize),
+		prp:      make(chan Target, retentionChannelSize),
+		policies: policies,
+		gpc:      make(chan groupPolicyTarget, retentionChannelSize),
 	}
 
 	go tm.processDescribeLogGroup()
 	go tm.processPutRetentionPolicy()
+	go tm.processGroupPolicies()
 	return tm
 }
 
@@ -76,6 +106,12 @@ func (m *targetManager) InitTarget(target Target) error {
 				m.dlg <- target
 			}
 		}
+		if newGroup {
+			if policy, ok := m.groupPolicy(target.Group); ok {
+				m.logger.Debugf("sending new log group %v to gpc channel", target.Group)
+				m.gpc <- groupPolicyTarget{group: target.Group, policy: policy}
+			}
+		}
 		m.cache[target] = struct{}{}
 	}
 	return nil
@@ -146,6 +182,9 @@ func (m *targetManager) createLogGroup(t Target) error {
 			LogGroupName: &t.Group,
 		}
 	}
+	if policy, ok := m.groupPolicy(t.Group); ok && policy.KMSKeyID != "" {
+		input.KmsKeyId = aws.String(policy.KMSKeyID)
+	}
 	_, err := m.service.CreateLogGroup(input)
 	if err == nil {
 		m.logger.Debugf("successfully created log group %v", t.Group)
@@ -173,7 +212,7 @@ func (m *targetManager) processDescribeLogGroup() {
 			currentRetention, err := m.getRetention(target)
 			if err != nil {
 				m.logger.Errorf("failed to describe log group retention for target %v: %v", target, err)
-				time.Sleep(m.calculateBackoff(attempt))
+				time.Sleep(targetRetryPolicy.Wait(attempt, err))
 				continue
 			}
 
@@ -219,10 +258,11 @@ func (m *targetManager) processPutRetentionPolicy() {
 			}
 
 			m.logger.Debugf("retrying to update retention policy for target (%v) %v: %v", attempt, target, err)
-			time.Sleep(m.calculateBackoff(attempt))
+			time.Sleep(targetRetryPolicy.Wait(attempt, err))
 		}
 
 		if !updated {
+			targetRetryPolicy.Dropped()
 			m.logger.Errorf("failed to update retention policy for target %v after %d attempts", target, numBackoffRetries)
 		}
 	}
@@ -242,13 +282,62 @@ func (m *targetManager) updateRetentionPolicy(target Target) error {
 	return nil
 }
 
-func (m *targetManager) calculateBackoff(retryCount int) time.Duration {
-	delay := baseRetryDelay
-	if retryCount < numBackoffRetries {
-		delay = baseRetryDelay * time.Duration(1<<int64(retryCount))
+func (m *targetManager) groupPolicy(group string) (GroupPolicy, bool) {
+	for _, policy := range m.policies {
+		if policy.Pattern != nil && policy.Pattern.Match(group) {
+			return policy, true
+		}
+	}
+	return GroupPolicy{}, false
+}
+
+func (m *targetManager) processGroupPolicies() {
+	for t := range m.gpc {
+		if t.policy.DataProtectionPolicy != "" {
+			m.retryGroupPolicy("data protection", t.group, m.putDataProtectionPolicy(t.group, t.policy.DataProtectionPolicy))
+		}
+		if t.policy.IndexPolicy != "" {
+			m.retryGroupPolicy("field index", t.group, m.putIndexPolicy(t.group, t.policy.IndexPolicy))
+		}
+	}
+}
+
+func (m *targetManager) retryGroupPolicy(kind, group string, put func() error) {
+	for attempt := 0; attempt < numBackoffRetries; attempt++ {
+		err := put()
+		if err == nil {
+			m.logger.Debugf("successfully put %s policy for log group %v", kind, group)
+			return
+		}
+		m.logger.Debugf("retrying to put %s policy for log group (%v) %v: %v", kind, attempt, group, err)
+		time.Sleep(targetRetryPolicy.Wait(attempt, err))
 	}
-	if delay > maxRetryDelayTarget {
-		delay = maxRetryDelayTarget
+	targetRetryPolicy.Dropped()
+	m.logger.Errorf("failed to put %s policy for log group %v after %d attempts", kind, group, numBackoffRetries)
+}
+
+func (m *targetManager) putDataProtectionPolicy(group, document string) func() error {
+	return func() error {
+		_, err := m.service.PutDataProtectionPolicy(&cloudwatchlogs.PutDataProtectionPolicyInput{
+			LogGroupIdentifier: aws.String(group),
+			PolicyDocument:     aws.String(document),
+		})
+		if err != nil {
+			return fmt.Errorf("put data protection policy failed: %w", err)
+		}
+		return nil
+	}
+}
+
+func (m *targetManager) putIndexPolicy(group, document string) func() error {
+	return func() error {
+		_, err := m.service.PutIndexPolicy(&cloudwatchlogs.PutIndexPolicyInput{
+			LogGroupIdentifier: aws.String(group),
+			PolicyDocument:     aws.String(document),
+		})
+		if err != nil {
+			return fmt.Errorf("put index policy failed: %w", err)
+		}
+		return nil
 	}
-	return withJitter(delay)
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/gobwas/glob"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
@@ -27,7 +28,7 @@ func TestTargetManager(t *testing.T) {
 		mockService := new(mockLogsService)
 		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil).Once()
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 
 		assert.NoError(t, err)
@@ -44,7 +45,7 @@ func TestTargetManager(t *testing.T) {
 		mockService.On("CreateLogGroup", mock.Anything).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil).Once()
 		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, &cloudwatchlogs.ResourceAlreadyExistsException{}).Once()
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 
 		assert.NoError(t, err)
@@ -61,7 +62,7 @@ func TestTargetManager(t *testing.T) {
 		mockService.On("CreateLogGroup", mock.Anything).Return(&cloudwatchlogs.CreateLogGroupOutput{}, &cloudwatchlogs.ResourceAlreadyExistsException{}).Once()
 		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil).Once()
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 
 		assert.NoError(t, err)
@@ -78,7 +79,7 @@ func TestTargetManager(t *testing.T) {
 		mockService.On("CreateLogGroup", mock.Anything).Return(&cloudwatchlogs.CreateLogGroupOutput{}, &cloudwatchlogs.ResourceAlreadyExistsException{}).Once()
 		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, &cloudwatchlogs.AccessDeniedException{}).Once()
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 
 		assert.Error(t, err)
@@ -95,7 +96,7 @@ func TestTargetManager(t *testing.T) {
 		mockService.On("CreateLogGroup", mock.Anything).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil).Once()
 		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, &cloudwatchlogs.ResourceAlreadyExistsException{}).Once()
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 
 		assert.NoError(t, err)
@@ -112,7 +113,7 @@ func TestTargetManager(t *testing.T) {
 		mockService.On("CreateLogGroup", mock.Anything).
 			Return(&cloudwatchlogs.CreateLogGroupOutput{}, awserr.New("SomeAWSError", "Failed to create log group", nil)).Once()
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 
 		assert.Error(t, err)
@@ -135,7 +136,7 @@ func TestTargetManager(t *testing.T) {
 		}, nil).Once()
 		mockService.On("PutRetentionPolicy", mock.Anything).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil).Once()
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 		assert.NoError(t, err)
 		// Wait for async operations to complete
@@ -158,7 +159,7 @@ func TestTargetManager(t *testing.T) {
 			},
 		}, nil).Once()
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 		assert.NoError(t, err)
 		time.Sleep(100 * time.Millisecond)
@@ -176,7 +177,7 @@ func TestTargetManager(t *testing.T) {
 		mockService.On("DescribeLogGroups", mock.Anything).
 			Return(&cloudwatchlogs.DescribeLogGroupsOutput{}, &cloudwatchlogs.ResourceNotFoundException{}).Times(numBackoffRetries)
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 		assert.NoError(t, err)
 		time.Sleep(30 * time.Second)
@@ -203,7 +204,7 @@ func TestTargetManager(t *testing.T) {
 			Return(&cloudwatchlogs.PutRetentionPolicyOutput{},
 				awserr.New("SomeAWSError", "Failed to set retention policy", nil)).Times(numBackoffRetries)
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 		assert.NoError(t, err)
 		time.Sleep(30 * time.Second)
@@ -216,7 +217,7 @@ func TestTargetManager(t *testing.T) {
 
 		mockService := new(mockLogsService)
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		manager.PutRetentionPolicy(target)
 
 		mockService.AssertNotCalled(t, "PutRetentionPolicy", mock.Anything)
@@ -237,7 +238,7 @@ func TestTargetManager(t *testing.T) {
 			return &cloudwatchlogs.CreateLogStreamOutput{}, nil
 		}
 
-		manager := NewTargetManager(logger, service)
+		manager := NewTargetManager(logger, service, nil)
 		var wg sync.WaitGroup
 		for i := 0; i < 50; i++ {
 			wg.Add(1)
@@ -259,7 +260,7 @@ func TestTargetManager(t *testing.T) {
 		mockService := new(mockLogsService)
 		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil).Once()
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 		assert.NoError(t, err)
 
@@ -282,7 +283,7 @@ func TestTargetManager(t *testing.T) {
 			return *input.LogGroupName == target.Group && *input.RetentionInDays == int64(target.Retention)
 		})).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil).Once()
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 		assert.NoError(t, err)
 
@@ -303,7 +304,7 @@ func TestTargetManager(t *testing.T) {
 		// fails but should retry
 		mockService.On("PutRetentionPolicy", mock.Anything).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, awserr.New("InternalError", "Internal error", nil)).Times(numBackoffRetries)
 
-		manager := NewTargetManager(logger, mockService)
+		manager := NewTargetManager(logger, mockService, nil)
 		err := manager.InitTarget(target)
 		assert.NoError(t, err)
 
@@ -315,12 +316,75 @@ func TestTargetManager(t *testing.T) {
 	})
 }
 
-func TestCalculateBackoff(t *testing.T) {
-	manager := &targetManager{}
+func TestTargetManagerGroupPolicies(t *testing.T) {
+	logger := testutil.NewNopLogger()
+	policies := []GroupPolicy{
+		{Pattern: glob.MustCompile("/secure/*"), KMSKeyID: "arn:aws:kms:us-east-1:123456789012:key/app"},
+		{Pattern: glob.MustCompile("/app/*"), DataProtectionPolicy: `{"Name":"app"}`, IndexPolicy: `{"Fields":["RequestId"]}`},
+		{Pattern: glob.MustCompile("*"), IndexPolicy: `{"Fields":["TraceId"]}`},
+	}
+
+	t.Run("NewLogGroup", func(t *testing.T) {
+		target := Target{Group: "/app/web", Stream: "S"}
+
+		mockService := new(mockLogsService)
+		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "Log group not found", nil)).Once()
+		mockService.On("CreateLogGroup", mock.MatchedBy(func(input *cloudwatchlogs.CreateLogGroupInput) bool {
+			return *input.LogGroupName == target.Group && input.KmsKeyId == nil
+		})).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil).Once()
+		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil).Once()
+		mockService.On("PutDataProtectionPolicy", mock.MatchedBy(func(input *cloudwatchlogs.PutDataProtectionPolicyInput) bool {
+			return *input.LogGroupIdentifier == target.Group && *input.PolicyDocument == `{"Name":"app"}`
+		})).Return(&cloudwatchlogs.PutDataProtectionPolicyOutput{}, nil).Once()
+		// fails once and should retry
+		mockService.On("PutIndexPolicy", mock.Anything).Return(&cloudwatchlogs.PutIndexPolicyOutput{}, awserr.New("InternalError", "Internal error", nil)).Once()
+		mockService.On("PutIndexPolicy", mock.MatchedBy(func(input *cloudwatchlogs.PutIndexPolicyInput) bool {
+			return *input.LogGroupIdentifier == target.Group && *input.PolicyDocument == `{"Fields":["RequestId"]}`
+		})).Return(&cloudwatchlogs.PutIndexPolicyOutput{}, nil).Once()
+
+		manager := NewTargetManager(logger, mockService, policies)
+		assert.NoError(t, manager.InitTarget(target))
+
+		time.Sleep(3 * time.Second)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("NewLogGroup/KMSKey", func(t *testing.T) {
+		mockService := new(mockLogsService)
+		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "Log group not found", nil)).Once()
+		mockService.On("CreateLogGroup", mock.MatchedBy(func(input *cloudwatchlogs.CreateLogGroupInput) bool {
+			return input.KmsKeyId != nil && *input.KmsKeyId == "arn:aws:kms:us-east-1:123456789012:key/app"
+		})).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil).Once()
+		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil).Once()
+
+		manager := NewTargetManager(logger, mockService, policies)
+		assert.NoError(t, manager.InitTarget(Target{Group: "/secure/payments", Stream: "S"}))
+
+		time.Sleep(100 * time.Millisecond)
+		mockService.AssertExpectations(t)
+		mockService.AssertNotCalled(t, "PutDataProtectionPolicy", mock.Anything)
+		mockService.AssertNotCalled(t, "PutIndexPolicy", mock.Anything)
+	})
+
+	t.Run("ExistingLogGroup", func(t *testing.T) {
+		mockService := new(mockLogsService)
+		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil).Once()
+
+		manager := NewTargetManager(logger, mockService, policies)
+		assert.NoError(t, manager.InitTarget(Target{Group: "/app/web", Stream: "S"}))
+
+		time.Sleep(100 * time.Millisecond)
+		mockService.AssertExpectations(t)
+		mockService.AssertNotCalled(t, "PutDataProtectionPolicy", mock.Anything)
+		mockService.AssertNotCalled(t, "PutIndexPolicy", mock.Anything)
+	})
+}
+
+func TestTargetBackoff(t *testing.T) {
 	// should never exceed 30sec of total wait time
 	totalDelay := time.Duration(0)
 	for i := 0; i < numBackoffRetries; i++ {
-		delay := manager.calculateBackoff(i)
+		delay := targetBackoff.Delay(i)
 		totalDelay += delay
 	}
 	assert.True(t, totalDelay <= 30*time.Second, "Total delay across all attempts should not exceed 30 seconds, but was %v", totalDelay)
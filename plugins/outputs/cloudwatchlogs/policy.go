@@ -0,0 +1,142 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/gobwas/glob"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
+)
+
+const (
+	dataProtectionPolicyName    = "cwagent-data-protection-policy"
+	dataProtectionPolicyVersion = "2021-06-01"
+	// maxFieldIndexes is the number of field indexes CloudWatch Logs allows in a log group's index policy.
+	maxFieldIndexes = 20
+)
+
+// LogGroupPolicy is attached to the log groups the agent creates whose name matches LogGroupName, which supports
+// the * and ? wildcards. A data protection policy is either built from DataProtectionIdentifiers or given as a
+// JSON document in DataProtectionPolicy. The log groups are encrypted with the KMS key of KMSKeyID.
+// TODO: a custom encryption context for KMSKeyID, which CreateLogGroup does not take, see the README.
+type LogGroupPolicy struct {
+	LogGroupName              string   `toml:"log_group_name"`
+	DataProtectionIdentifiers []string `toml:"data_protection_identifiers"`
+	DataProtectionPolicy      string   `toml:"data_protection_policy"`
+	FieldIndexes              []string `toml:"field_indexes"`
+	KMSKeyID                  string   `toml:"kms_key_id"`
+}
+
+type dataProtectionStatement struct {
+	Sid            string                 `json:"Sid"`
+	DataIdentifier []string               `json:"DataIdentifier"`
+	Operation      map[string]interface{} `json:"Operation"`
+}
+
+type dataProtectionDocument struct {
+	Name      string                    `json:"Name"`
+	Version   string                    `json:"Version"`
+	Statement []dataProtectionStatement `json:"Statement"`
+}
+
+type indexPolicyDocument struct {
+	Fields []string `json:"Fields"`
+}
+
+// buildGroupPolicies validates the configured log group policies and renders their policy documents.
+func (c *CloudWatchLogs) buildGroupPolicies() ([]pusher.GroupPolicy, error) {
+	var policies []pusher.GroupPolicy
+	for _, p := range c.LogGroupPolicies {
+		if p.LogGroupName == "" {
+			return nil, errors.New("log group policy needs a log_group_name")
+		}
+		pattern, err := glob.Compile(p.LogGroupName)
+		if err != nil {
+			return nil, fmt.Errorf("log group policy log_group_name %s is invalid: %w", p.LogGroupName, err)
+		}
+		policy := pusher.GroupPolicy{Pattern: pattern}
+		switch {
+		case len(p.DataProtectionIdentifiers) > 0 && p.DataProtectionPolicy != "":
+			return nil, fmt.Errorf("log group policy for %s cannot set both data_protection_identifiers and data_protection_policy", p.LogGroupName)
+		case len(p.DataProtectionIdentifiers) > 0:
+			policy.DataProtectionPolicy, err = dataProtectionPolicy(p.DataProtectionIdentifiers, c.Region)
+			if err != nil {
+				return nil, err
+			}
+		case p.DataProtectionPolicy != "":
+			if !json.Valid([]byte(p.DataProtectionPolicy)) {
+				return nil, fmt.Errorf("log group policy for %s has a data_protection_policy that is not valid JSON", p.LogGroupName)
+			}
+			policy.DataProtectionPolicy = p.DataProtectionPolicy
+		}
+		if len(p.FieldIndexes) > maxFieldIndexes {
+			return nil, fmt.Errorf("log group policy for %s has %d field_indexes, at most %d are allowed", p.LogGroupName, len(p.FieldIndexes), maxFieldIndexes)
+		}
+		if len(p.FieldIndexes) > 0 {
+			document, err := json.Marshal(indexPolicyDocument{Fields: p.FieldIndexes})
+			if err != nil {
+				return nil, err
+			}
+			policy.IndexPolicy = string(document)
+		}
+		if p.KMSKeyID != "" {
+			// CloudWatch Logs only accepts the ARN of a key, not its ID or an alias
+			if parsed, err := arn.Parse(p.KMSKeyID); err != nil || parsed.Service != "kms" || !strings.HasPrefix(parsed.Resource, "key/") {
+				return nil, fmt.Errorf("log group policy for %s has a kms_key_id %s that is not the ARN of a KMS key", p.LogGroupName, p.KMSKeyID)
+			}
+			policy.KMSKeyID = p.KMSKeyID
+		}
+		if policy.DataProtectionPolicy == "" && policy.IndexPolicy == "" && policy.KMSKeyID == "" {
+			return nil, fmt.Errorf("log group policy for %s sets neither a data protection policy, field_indexes nor a kms_key_id", p.LogGroupName)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// dataProtectionPolicy builds a policy that audits and masks the given data identifiers. Managed identifiers such
+// as EmailAddress are turned into their ARN in the partition of the region, identifiers that are ARNs are kept.
+func dataProtectionPolicy(identifiers []string, region string) (string, error) {
+	partition := endpoints.AwsPartitionID
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		partition = p.ID()
+	}
+	arns := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		if identifier == "" {
+			return "", errors.New("data protection identifier cannot be empty")
+		}
+		if !strings.HasPrefix(identifier, "arn:") {
+			identifier = fmt.Sprintf("arn:%s:dataprotection::aws:data-identifier/%s", partition, identifier)
+		}
+		arns = append(arns, identifier)
+	}
+	document, err := json.Marshal(dataProtectionDocument{
+		Name:    dataProtectionPolicyName,
+		Version: dataProtectionPolicyVersion,
+		Statement: []dataProtectionStatement{
+			{
+				Sid:            "audit-policy",
+				DataIdentifier: arns,
+				Operation:      map[string]interface{}{"Audit": map[string]interface{}{"FindingsDestination": map[string]interface{}{}}},
+			},
+			{
+				Sid:            "redact-policy",
+				DataIdentifier: arns,
+				Operation:      map[string]interface{}{"Deidentify": map[string]interface{}{"MaskConfig": map[string]interface{}{}}},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(document), nil
+}
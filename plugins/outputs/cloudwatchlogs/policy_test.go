@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildGroupPolicies(t *testing.T) {
+	c := &CloudWatchLogs{Region: "cn-north-1", LogGroupPolicies: []LogGroupPolicy{
+		{LogGroupName: "/app/*", DataProtectionIdentifiers: []string{"EmailAddress", "arn:aws-cn:dataprotection::123456789012:data-identifier/custom"}, FieldIndexes: []string{"RequestId"}},
+		{LogGroupName: "/audit", DataProtectionPolicy: `{"Name":"audit"}`},
+		{LogGroupName: "/secure/*", KMSKeyID: "arn:aws-cn:kms:cn-north-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"},
+	}}
+	policies, err := c.buildGroupPolicies()
+	require.NoError(t, err)
+	require.Len(t, policies, 3)
+	assert.True(t, policies[0].Pattern.Match("/app/web/access"))
+	assert.False(t, policies[0].Pattern.Match("/audit"))
+	assert.JSONEq(t, `{
+		"Name": "cwagent-data-protection-policy",
+		"Version": "2021-06-01",
+		"Statement": [
+			{"Sid": "audit-policy", "DataIdentifier": ["arn:aws-cn:dataprotection::aws:data-identifier/EmailAddress", "arn:aws-cn:dataprotection::123456789012:data-identifier/custom"], "Operation": {"Audit": {"FindingsDestination": {}}}},
+			{"Sid": "redact-policy", "DataIdentifier": ["arn:aws-cn:dataprotection::aws:data-identifier/EmailAddress", "arn:aws-cn:dataprotection::123456789012:data-identifier/custom"], "Operation": {"Deidentify": {"MaskConfig": {}}}}
+		]
+	}`, policies[0].DataProtectionPolicy)
+	assert.Equal(t, `{"Fields":["RequestId"]}`, policies[0].IndexPolicy)
+	assert.Equal(t, `{"Name":"audit"}`, policies[1].DataProtectionPolicy)
+	assert.Empty(t, policies[1].IndexPolicy)
+	assert.Empty(t, policies[1].KMSKeyID)
+	assert.Equal(t, "arn:aws-cn:kms:cn-north-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab", policies[2].KMSKeyID)
+	assert.Empty(t, policies[2].DataProtectionPolicy)
+
+	for name, policy := range map[string]LogGroupPolicy{
+		"MissingName":   {FieldIndexes: []string{"RequestId"}},
+		"InvalidName":   {LogGroupName: "/app/[", FieldIndexes: []string{"RequestId"}},
+		"Empty":         {LogGroupName: "/app"},
+		"Both":          {LogGroupName: "/app", DataProtectionIdentifiers: []string{"EmailAddress"}, DataProtectionPolicy: `{}`},
+		"InvalidJSON":   {LogGroupName: "/app", DataProtectionPolicy: `{`},
+		"EmptyID":       {LogGroupName: "/app", DataProtectionIdentifiers: []string{""}},
+		"TooManyFields": {LogGroupName: "/app", FieldIndexes: make([]string, maxFieldIndexes+1)},
+		"KeyID":         {LogGroupName: "/app", KMSKeyID: "1234abcd-12ab-34cd-56ef-1234567890ab"},
+		"KeyAlias":      {LogGroupName: "/app", KMSKeyID: "arn:aws:kms:us-east-1:123456789012:alias/logs"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&CloudWatchLogs{LogGroupPolicies: []LogGroupPolicy{policy}}).buildGroupPolicies()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kinesislogs
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/influxdata/telegraf"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	// PutRecords accepts at most 500 records and 5MiB per request.
+	maxRecordsPerRequest = 500
+	maxRequestSize       = 5 * 1024 * 1024
+	// Each record, including its partition key, can be at most 1MiB.
+	maxRecordSize = 1024 * 1024
+
+	eventBufferSize = 1000
+	initialBackoff  = 200 * time.Millisecond
+	maxBackoff      = 30 * time.Second
+)
+
+var retryPolicy = retryer.NewPolicy("kinesislogs", retryer.Backoff{Base: initialBackoff, Steps: 8, Max: maxBackoff})
+
+// record is the JSON document written to the stream for every log event.
+type record struct {
+	LogGroupName  string `json:"log_group_name"`
+	LogStreamName string `json:"log_stream_name"`
+	Timestamp     int64  `json:"timestamp"`
+	Message       string `json:"message"`
+}
+
+type pendingRecord struct {
+	partitionKey string
+	data         []byte
+	dones        []func()
+}
+
+func (r *pendingRecord) size() int {
+	return len(r.data) + len(r.partitionKey)
+}
+
+type kinesisDest struct {
+	log          telegraf.Logger
+	client       kinesisiface.KinesisAPI
+	streamName   string
+	partitionKey *partitionKeyTemplate
+	group        string
+	stream       string
+	aggregation  bool
+	flushTimeout time.Duration
+
+	events chan logs.LogEvent
+	stopCh <-chan struct{}
+
+	records []*pendingRecord
+	size    int
+}
+
+var _ logs.LogDest = (*kinesisDest)(nil)
+
+func newKinesisDest(
+	log telegraf.Logger,
+	client kinesisiface.KinesisAPI,
+	streamName string,
+	partitionKey *partitionKeyTemplate,
+	group, stream string,
+	aggregation bool,
+	flushTimeout time.Duration,
+	stopCh <-chan struct{},
+	wg *sync.WaitGroup,
+) *kinesisDest {
+	d := &kinesisDest{
+		log:          log,
+		client:       client,
+		streamName:   streamName,
+		partitionKey: partitionKey,
+		group:        group,
+		stream:       stream,
+		aggregation:  aggregation,
+		flushTimeout: flushTimeout,
+		events:       make(chan logs.LogEvent, eventBufferSize),
+		stopCh:       stopCh,
+	}
+	wg.Add(1)
+	go d.run(wg)
+	return d
+}
+
+func (d *kinesisDest) Publish(events []logs.LogEvent) error {
+	for _, e := range events {
+		select {
+		case <-d.stopCh:
+			return logs.ErrOutputStopped
+		default:
+		}
+		select {
+		case d.events <- e:
+		case <-d.stopCh:
+			return logs.ErrOutputStopped
+		}
+	}
+	return nil
+}
+
+func (d *kinesisDest) run(wg *sync.WaitGroup) {
+	defer wg.Done()
+	ticker := time.NewTicker(d.flushTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case e := <-d.events:
+			d.add(e)
+		case <-ticker.C:
+			d.flush()
+		case <-d.stopCh:
+			// drain what the log agent already handed over before the final flush
+			for {
+				select {
+				case e := <-d.events:
+					d.add(e)
+				default:
+					d.flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (d *kinesisDest) add(e logs.LogEvent) {
+	t := e.Time()
+	if t.IsZero() {
+		t = time.Now()
+	}
+	data, err := json.Marshal(record{
+		LogGroupName:  d.group,
+		LogStreamName: d.stream,
+		Timestamp:     t.UnixMilli(),
+		Message:       e.Message(),
+	})
+	if err != nil {
+		d.log.Errorf("Unable to encode log event for stream %s: %v", d.streamName, err)
+		e.Done()
+		return
+	}
+	data = append(data, '\n')
+
+	if d.aggregation && len(d.records) > 0 {
+		last := d.records[len(d.records)-1]
+		if last.size()+len(data) <= maxRecordSize && d.size+len(data) <= maxRequestSize {
+			last.data = append(last.data, data...)
+			last.dones = append(last.dones, e.Done)
+			d.size += len(data)
+			return
+		}
+	}
+
+	r := &pendingRecord{partitionKey: d.partitionKey.render(), data: data, dones: []func(){e.Done}}
+	if r.size() > maxRecordSize {
+		d.log.Errorf("Dropping log event of %d bytes for stream %s, larger than the Kinesis record limit", r.size(), d.streamName)
+		e.Done()
+		return
+	}
+	if len(d.records) >= maxRecordsPerRequest || d.size+r.size() > maxRequestSize {
+		d.flush()
+	}
+	d.records = append(d.records, r)
+	d.size += r.size()
+}
+
+// flush sends the pending records, retrying the failed records with backoff until they are accepted
+// or the output is stopped.
+func (d *kinesisDest) flush() {
+	if len(d.records) == 0 {
+		return
+	}
+	pending := d.records
+	d.records = nil
+	d.size = 0
+
+	for retries := 0; ; retries++ {
+		var err error
+		pending, err = d.putRecords(pending)
+		if len(pending) == 0 {
+			retryPolicy.Succeeded()
+			return
+		}
+		select {
+		case <-d.stopCh:
+			d.log.Errorf("Dropping %d records for stream %s after the output stopped", len(pending), d.streamName)
+			retryPolicy.Dropped()
+			return
+		case <-time.After(retryPolicy.Wait(retries, err)):
+		}
+	}
+}
+
+// putRecords sends the records once and returns the ones that need to be retried, with the error of the request
+// if it failed as a whole.
+func (d *kinesisDest) putRecords(pending []*pendingRecord) ([]*pendingRecord, error) {
+	entries := make([]*kinesis.PutRecordsRequestEntry, len(pending))
+	for i, r := range pending {
+		entries[i] = &kinesis.PutRecordsRequestEntry{
+			Data:         r.data,
+			PartitionKey: aws.String(r.partitionKey),
+		}
+	}
+	output, err := d.client.PutRecords(&kinesis.PutRecordsInput{
+		StreamName: aws.String(d.streamName),
+		Records:    entries,
+	})
+	if err != nil {
+		d.log.Warnf("PutRecords to stream %s failed, will retry: %v", d.streamName, err)
+		return pending, err
+	}
+
+	var failed []*pendingRecord
+	for i, result := range output.Records {
+		if i >= len(pending) {
+			break
+		}
+		if result.ErrorCode != nil {
+			failed = append(failed, pending[i])
+			continue
+		}
+		for _, done := range pending[i].dones {
+			done()
+		}
+	}
+	// the records without a result are not known to be written
+	if len(output.Records) < len(pending) {
+		failed = append(failed, pending[len(output.Records):]...)
+	}
+	if len(failed) > 0 {
+		d.log.Debugf("%d of %d records to stream %s failed, will retry", len(failed), len(pending), d.streamName)
+	}
+	return failed, nil
+}
@@ -0,0 +1,153 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kinesislogs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/outputs"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	defaultFlushTimeout = 5 * time.Second
+	defaultPartitionKey = "{log_group_name}/{log_stream_name}"
+)
+
+// KinesisLogs is a log backend that publishes log events to a Kinesis Data Stream instead of CloudWatch Logs.
+type KinesisLogs struct {
+	Region           string `toml:"region"`
+	EndpointOverride string `toml:"endpoint_override"`
+	AccessKey        string `toml:"access_key"`
+	SecretKey        string `toml:"secret_key"`
+	RoleARN          string `toml:"role_arn"`
+	Profile          string `toml:"profile"`
+	Filename         string `toml:"shared_credential_file"`
+	Token            string `toml:"token"`
+
+	StreamName string `toml:"stream_name"`
+	// PartitionKey is a template supporting {log_group_name}, {log_stream_name}, {hostname} and {random}.
+	PartitionKey string `toml:"partition_key"`
+	// Aggregation packs the events of a flush into as few records as possible, newline delimited.
+	Aggregation bool `toml:"aggregation"`
+
+	ForceFlushInterval internal.Duration `toml:"force_flush_interval"` // unit is second
+
+	Log telegraf.Logger `toml:"-"`
+
+	client    kinesisiface.KinesisAPI
+	dests     map[target]*kinesisDest
+	mu        sync.Mutex
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+	waitGroup sync.WaitGroup
+}
+
+var _ logs.LogBackend = (*KinesisLogs)(nil)
+
+type target struct {
+	group, stream string
+}
+
+func (k *KinesisLogs) Connect() error {
+	return nil
+}
+
+func (k *KinesisLogs) Close() error {
+	k.stopOnce.Do(func() { close(k.stopCh) })
+	k.waitGroup.Wait()
+	return nil
+}
+
+// Write is a no-op since the plugin only accepts log events through the log agent.
+func (k *KinesisLogs) Write(_ []telegraf.Metric) error {
+	return nil
+}
+
+func (k *KinesisLogs) CreateDest(group, stream string, _ int, _ string, _ logs.LogSrc) logs.LogDest {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	t := target{group: group, stream: stream}
+	if d, ok := k.dests[t]; ok {
+		return d
+	}
+	if k.client == nil {
+		k.client = k.createClient()
+	}
+	partitionKey := k.PartitionKey
+	if partitionKey == "" {
+		partitionKey = defaultPartitionKey
+	}
+	d := newKinesisDest(k.Log, k.client, k.StreamName, newPartitionKeyTemplate(partitionKey, group, stream), group, stream, k.Aggregation, k.ForceFlushInterval.Duration, k.stopCh, &k.waitGroup)
+	k.dests[t] = d
+	return d
+}
+
+func (k *KinesisLogs) createClient() kinesisiface.KinesisAPI {
+	credentialConfig := &configaws.CredentialConfig{
+		Region:    k.Region,
+		AccessKey: k.AccessKey,
+		SecretKey: k.SecretKey,
+		RoleARN:   k.RoleARN,
+		Profile:   k.Profile,
+		Filename:  k.Filename,
+		Token:     k.Token,
+	}
+	client := kinesis.New(
+		credentialConfig.Credentials(),
+		&aws.Config{
+			Endpoint: aws.String(k.EndpointOverride),
+			LogLevel: configaws.SDKLogLevel(),
+			Logger:   configaws.SDKLogger{},
+		},
+	)
+	client.Handlers.UnmarshalError.PushBackNamed(retryer.RetryAfterHandler)
+	return client
+}
+
+// Description returns a one-sentence description on the Output
+func (k *KinesisLogs) Description() string {
+	return "Configuration for AWS Kinesis Data Streams log output."
+}
+
+var sampleConfig = `
+  ## Amazon REGION
+  region = "us-east-1"
+
+  ## Amazon Credentials, loaded the same way as the cloudwatchlogs output
+  #role_arn = ""
+
+  ## The Kinesis data stream to publish log events to.
+  stream_name = "<stream_name>"
+
+  ## Partition key template. Supports {log_group_name}, {log_stream_name}, {hostname} and {random}.
+  partition_key = "{log_group_name}/{log_stream_name}"
+
+  ## Pack multiple newline delimited events into a single record.
+  aggregation = false
+`
+
+// SampleConfig returns the default configuration of the Output
+func (k *KinesisLogs) SampleConfig() string {
+	return sampleConfig
+}
+
+func init() {
+	outputs.Add("kinesis_logs", func() telegraf.Output {
+		return &KinesisLogs{
+			ForceFlushInterval: internal.Duration{Duration: defaultFlushTimeout},
+			dests:              make(map[target]*kinesisDest),
+			stopCh:             make(chan struct{}),
+		}
+	})
+}
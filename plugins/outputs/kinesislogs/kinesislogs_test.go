@@ -0,0 +1,193 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kinesislogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+type mockKinesis struct {
+	kinesisiface.KinesisAPI
+	mu       sync.Mutex
+	inputs   []*kinesis.PutRecordsInput
+	failures int
+	errs     int
+	// missing is the number of results left out of the next output
+	missing int
+}
+
+func (m *mockKinesis) PutRecords(input *kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.errs > 0 {
+		m.errs--
+		return nil, errors.New("unavailable")
+	}
+	m.inputs = append(m.inputs, input)
+	output := &kinesis.PutRecordsOutput{}
+	for range input.Records {
+		entry := &kinesis.PutRecordsResultEntry{SequenceNumber: aws.String("1")}
+		if m.failures > 0 {
+			m.failures--
+			entry = &kinesis.PutRecordsResultEntry{ErrorCode: aws.String(kinesis.ErrCodeProvisionedThroughputExceededException)}
+		}
+		output.Records = append(output.Records, entry)
+	}
+	if m.missing > 0 {
+		output.Records = output.Records[:len(output.Records)-m.missing]
+		m.missing = 0
+	}
+	return output, nil
+}
+
+func (m *mockKinesis) records() []*kinesis.PutRecordsRequestEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []*kinesis.PutRecordsRequestEntry
+	for _, input := range m.inputs {
+		entries = append(entries, input.Records...)
+	}
+	return entries
+}
+
+type testEvent struct {
+	msg  string
+	t    time.Time
+	done *atomic.Int32
+}
+
+func (e testEvent) Message() string { return e.msg }
+func (e testEvent) Time() time.Time { return e.t }
+func (e testEvent) Done()           { e.done.Add(1) }
+
+func newTestKinesisLogs(client kinesisiface.KinesisAPI, aggregation bool) *KinesisLogs {
+	return &KinesisLogs{
+		StreamName:         "stream",
+		PartitionKey:       "{log_group_name}-{log_stream_name}",
+		Aggregation:        aggregation,
+		ForceFlushInterval: internal.Duration{Duration: 50 * time.Millisecond},
+		Log:                testutil.Logger{Name: "kinesis_logs"},
+		client:             client,
+		dests:              make(map[target]*kinesisDest),
+		stopCh:             make(chan struct{}),
+	}
+}
+
+func TestKinesisLogsPublish(t *testing.T) {
+	client := &mockKinesis{}
+	k := newTestKinesisLogs(client, false)
+	dest := k.CreateDest("group", "stream", -1, "", nil)
+	assert.Same(t, dest, k.CreateDest("group", "stream", -1, "", nil))
+
+	var done atomic.Int32
+	ts := time.UnixMilli(1700000000000)
+	require.NoError(t, dest.Publish([]logs.LogEvent{
+		testEvent{msg: "first", t: ts, done: &done},
+		testEvent{msg: "second", t: ts, done: &done},
+	}))
+	assert.Eventually(t, func() bool { return done.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, k.Close())
+
+	entries := client.records()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "group-stream", *entries[0].PartitionKey)
+	var r record
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(entries[0].Data), &r))
+	assert.Equal(t, record{LogGroupName: "group", LogStreamName: "stream", Timestamp: 1700000000000, Message: "first"}, r)
+	assert.Equal(t, logs.ErrOutputStopped, dest.Publish([]logs.LogEvent{testEvent{msg: "late", done: &done}}))
+	// closing again does not panic
+	require.NoError(t, k.Close())
+}
+
+func TestKinesisLogsAggregation(t *testing.T) {
+	client := &mockKinesis{}
+	k := newTestKinesisLogs(client, true)
+	dest := k.CreateDest("group", "stream", -1, "", nil)
+
+	var done atomic.Int32
+	var events []logs.LogEvent
+	for i := 0; i < 10; i++ {
+		events = append(events, testEvent{msg: "event", done: &done})
+	}
+	require.NoError(t, dest.Publish(events))
+	assert.Eventually(t, func() bool { return done.Load() == 10 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, k.Close())
+
+	entries := client.records()
+	require.Len(t, entries, 1)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(entries[0].Data)), "\n"), 10)
+}
+
+func TestKinesisLogsRetry(t *testing.T) {
+	client := &mockKinesis{errs: 1, failures: 1}
+	k := newTestKinesisLogs(client, false)
+	dest := k.CreateDest("group", "stream", -1, "", nil)
+
+	var done atomic.Int32
+	require.NoError(t, dest.Publish([]logs.LogEvent{
+		testEvent{msg: "first", done: &done},
+		testEvent{msg: "second", done: &done},
+	}))
+	assert.Eventually(t, func() bool { return done.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, k.Close())
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	// the first successful call has one throttled record that is sent again on its own
+	require.Len(t, client.inputs, 2)
+	assert.Len(t, client.inputs[0].Records, 2)
+	assert.Len(t, client.inputs[1].Records, 1)
+}
+
+func TestKinesisLogsMissingResults(t *testing.T) {
+	client := &mockKinesis{missing: 1}
+	k := newTestKinesisLogs(client, false)
+	dest := k.CreateDest("group", "stream", -1, "", nil)
+
+	var done atomic.Int32
+	require.NoError(t, dest.Publish([]logs.LogEvent{
+		testEvent{msg: "first", done: &done},
+		testEvent{msg: "second", done: &done},
+	}))
+	assert.Eventually(t, func() bool { return done.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, k.Close())
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	// the record without a result is sent again
+	require.Len(t, client.inputs, 2)
+	require.Len(t, client.inputs[1].Records, 1)
+	assert.Contains(t, string(client.inputs[1].Records[0].Data), "second")
+}
+
+func TestPartitionKeyTemplate(t *testing.T) {
+	p := newPartitionKeyTemplate("{log_group_name}/{log_stream_name}", "group", "stream")
+	assert.Equal(t, "group/stream", p.render())
+
+	p = newPartitionKeyTemplate("{random}", "group", "stream")
+	assert.NotEqual(t, "{random}", p.render())
+
+	p = newPartitionKeyTemplate("", "group", "stream")
+	assert.Equal(t, "default", p.render())
+
+	p = newPartitionKeyTemplate(strings.Repeat("é", 300), "group", "stream")
+	assert.Len(t, []rune(p.render()), maxPartitionKeyLength)
+}
@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kinesislogs
+
+import (
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	randomPlaceholder = "{random}"
+	// Kinesis partition keys are limited to 256 characters.
+	maxPartitionKeyLength = 256
+)
+
+// partitionKeyTemplate resolves the static placeholders once and only generates {random} per record.
+type partitionKeyTemplate struct {
+	resolved string
+	random   bool
+}
+
+func newPartitionKeyTemplate(template, group, stream string) *partitionKeyTemplate {
+	hostname, _ := os.Hostname()
+	resolved := strings.NewReplacer(
+		"{log_group_name}", group,
+		"{log_stream_name}", stream,
+		"{hostname}", hostname,
+	).Replace(template)
+	return &partitionKeyTemplate{
+		resolved: resolved,
+		random:   strings.Contains(resolved, randomPlaceholder),
+	}
+}
+
+func (p *partitionKeyTemplate) render() string {
+	key := p.resolved
+	if p.random {
+		key = strings.ReplaceAll(key, randomPlaceholder, strconv.FormatUint(rand.Uint64(), 36)) // nolint:gosec
+	}
+	if key == "" {
+		key = "default"
+	}
+	if runes := []rune(key); len(runes) > maxPartitionKeyLength {
+		key = string(runes[:maxPartitionKeyLength])
+	}
+	return key
+}
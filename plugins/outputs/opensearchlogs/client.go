@@ -0,0 +1,124 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package opensearchlogs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+const (
+	bulkPath = "/_bulk"
+	// contentSHA256Header is the hash of the body, which the serverless collections require on the signed requests.
+	contentSHA256Header = "X-Amz-Content-Sha256"
+	retryAfterHeader    = "Retry-After"
+	// maxErrorBodySize is how much of the body of a failed response is kept in its error.
+	maxErrorBodySize = 1024
+)
+
+// bulkAPI sends the newline delimited actions and documents of a bulk request.
+type bulkAPI interface {
+	Bulk(body []byte) (*bulkResponse, error)
+}
+
+// bulkResponse is the result of each action of a bulk request, in the order of the request.
+type bulkResponse struct {
+	Errors bool                       `json:"errors"`
+	Items  []map[string]bulkItemResult `json:"items"`
+}
+
+type bulkItemResult struct {
+	Status int `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error,omitempty"`
+}
+
+// status returns the status of the action of an item, whatever its kind.
+func status(item map[string]bulkItemResult) (int, string) {
+	for _, result := range item {
+		if result.Error != nil {
+			return result.Status, result.Error.Type + ": " + result.Error.Reason
+		}
+		return result.Status, ""
+	}
+	return 0, "no result"
+}
+
+// retryable returns true for the statuses of the requests and the actions which can succeed when they are sent again.
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// requestError is the failure of a bulk request as a whole.
+type requestError struct {
+	status int
+	body   string
+	after  time.Duration
+}
+
+func (e *requestError) Error() string {
+	return fmt.Sprintf("bulk request failed with status %d: %s", e.status, e.body)
+}
+
+// StatusCode returns the status of the response, which the retry policy spends its budget on when the domain is
+// throttling or failing.
+func (e *requestError) StatusCode() int {
+	return e.status
+}
+
+// RetryAfter returns how long the throttled request asked to wait, which the retry policy waits at least.
+func (e *requestError) RetryAfter() time.Duration {
+	return e.after
+}
+
+// bulkClient signs the bulk requests with SigV4.
+type bulkClient struct {
+	url     string
+	service string
+	region  string
+	signer  *v4.Signer
+	client  *http.Client
+}
+
+func (c *bulkClient) Bulk(body []byte) (*bulkResponse, error) {
+	req, err := http.NewRequest(http.MethodPost, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	sum := sha256.Sum256(body)
+	req.Header.Set(contentSHA256Header, hex.EncodeToString(sum[:]))
+	if _, err = c.signer.Sign(req, bytes.NewReader(body), c.service, c.region, time.Now()); err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		e := &requestError{status: resp.StatusCode, body: string(content)}
+		if seconds, err := strconv.Atoi(resp.Header.Get(retryAfterHeader)); err == nil && seconds > 0 {
+			e.after = time.Duration(seconds) * time.Second
+		}
+		return nil, e
+	}
+	var result bulkResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("unable to decode the bulk response: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,244 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package opensearchlogs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	// The domains accept bulk requests of at least 10MiB, whatever their instance type.
+	maxDocumentsPerRequest = 1000
+	maxRequestSize         = 5 * 1024 * 1024
+
+	eventBufferSize = 1000
+	initialBackoff  = 200 * time.Millisecond
+	maxBackoff      = 30 * time.Second
+	timestampFormat = "2006-01-02T15:04:05.000Z07:00"
+)
+
+var retryPolicy = retryer.NewPolicy("opensearchlogs", retryer.Backoff{Base: initialBackoff, Steps: 8, Max: maxBackoff})
+
+// document is the JSON document indexed for every log event.
+type document struct {
+	Timestamp     string `json:"@timestamp"`
+	LogGroupName  string `json:"log_group_name"`
+	LogStreamName string `json:"log_stream_name"`
+	Message       string `json:"message"`
+}
+
+// action creates the document in its index. Unlike index, it also writes to the data streams.
+type action struct {
+	Create struct {
+		Index string `json:"_index"`
+	} `json:"create"`
+}
+
+// pendingDocument is the action and the document of an event, newline delimited.
+type pendingDocument struct {
+	data []byte
+	done func()
+}
+
+type openSearchDest struct {
+	log          telegraf.Logger
+	client       bulkAPI
+	index        *indexTemplate
+	group        string
+	stream       string
+	flushTimeout time.Duration
+
+	events chan logs.LogEvent
+	stopCh <-chan struct{}
+
+	documents []*pendingDocument
+	size      int
+}
+
+var _ logs.LogDest = (*openSearchDest)(nil)
+
+func newOpenSearchDest(
+	log telegraf.Logger,
+	client bulkAPI,
+	index *indexTemplate,
+	group, stream string,
+	flushTimeout time.Duration,
+	stopCh <-chan struct{},
+	wg *sync.WaitGroup,
+) *openSearchDest {
+	d := &openSearchDest{
+		log:          log,
+		client:       client,
+		index:        index,
+		group:        group,
+		stream:       stream,
+		flushTimeout: flushTimeout,
+		events:       make(chan logs.LogEvent, eventBufferSize),
+		stopCh:       stopCh,
+	}
+	wg.Add(1)
+	go d.run(wg)
+	return d
+}
+
+func (d *openSearchDest) Publish(events []logs.LogEvent) error {
+	for _, e := range events {
+		select {
+		case <-d.stopCh:
+			return logs.ErrOutputStopped
+		default:
+		}
+		select {
+		case d.events <- e:
+		case <-d.stopCh:
+			return logs.ErrOutputStopped
+		}
+	}
+	return nil
+}
+
+func (d *openSearchDest) run(wg *sync.WaitGroup) {
+	defer wg.Done()
+	ticker := time.NewTicker(d.flushTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case e := <-d.events:
+			d.add(e)
+		case <-ticker.C:
+			d.flush()
+		case <-d.stopCh:
+			// drain what the log agent already handed over before the final flush
+			for {
+				select {
+				case e := <-d.events:
+					d.add(e)
+				default:
+					d.flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (d *openSearchDest) add(e logs.LogEvent) {
+	t := e.Time()
+	if t.IsZero() {
+		t = time.Now()
+	}
+	var a action
+	a.Create.Index = d.index.render(t)
+	header, err := json.Marshal(a)
+	if err != nil {
+		d.log.Errorf("Unable to encode the action of a log event for %s/%s: %v", d.group, d.stream, err)
+		e.Done()
+		return
+	}
+	doc, err := json.Marshal(document{
+		Timestamp:     t.UTC().Format(timestampFormat),
+		LogGroupName:  d.group,
+		LogStreamName: d.stream,
+		Message:       e.Message(),
+	})
+	if err != nil {
+		d.log.Errorf("Unable to encode log event for %s/%s: %v", d.group, d.stream, err)
+		e.Done()
+		return
+	}
+	data := make([]byte, 0, len(header)+len(doc)+2)
+	data = append(append(append(append(data, header...), '\n'), doc...), '\n')
+	if len(data) > maxRequestSize {
+		d.log.Errorf("Dropping log event of %d bytes for %s/%s, larger than the bulk request limit", len(data), d.group, d.stream)
+		e.Done()
+		return
+	}
+	if len(d.documents) >= maxDocumentsPerRequest || d.size+len(data) > maxRequestSize {
+		d.flush()
+	}
+	d.documents = append(d.documents, &pendingDocument{data: data, done: e.Done})
+	d.size += len(data)
+}
+
+// flush sends the pending documents, retrying the throttled and failed documents with backoff until they are
+// indexed or the output is stopped.
+func (d *openSearchDest) flush() {
+	if len(d.documents) == 0 {
+		return
+	}
+	pending := d.documents
+	d.documents = nil
+	d.size = 0
+
+	for retries := 0; ; retries++ {
+		var err error
+		pending, err = d.bulk(pending)
+		if len(pending) == 0 {
+			retryPolicy.Succeeded()
+			return
+		}
+		select {
+		case <-d.stopCh:
+			d.log.Errorf("Dropping %d documents for %s/%s after the output stopped", len(pending), d.group, d.stream)
+			retryPolicy.Dropped()
+			return
+		case <-time.After(retryPolicy.Wait(retries, err)):
+		}
+	}
+}
+
+// bulk sends the documents once and returns the ones that need to be retried, with the error of the request if it
+// failed as a whole. The documents OpenSearch rejects, e.g. as they do not match the mapping of the index, are
+// dropped since they would be rejected again.
+func (d *openSearchDest) bulk(pending []*pendingDocument) ([]*pendingDocument, error) {
+	var body []byte
+	for _, doc := range pending {
+		body = append(body, doc.data...)
+	}
+	resp, err := d.client.Bulk(body)
+	if err != nil {
+		var re *requestError
+		if errors.As(err, &re) && (re.status == http.StatusBadRequest || re.status == http.StatusRequestEntityTooLarge) {
+			d.log.Errorf("Dropping %d documents for %s/%s: %v", len(pending), d.group, d.stream, err)
+			retryPolicy.Dropped()
+			for _, doc := range pending {
+				doc.done()
+			}
+			return nil, nil
+		}
+		d.log.Warnf("Bulk request for %s/%s failed, will retry: %v", d.group, d.stream, err)
+		return pending, err
+	}
+
+	var failed []*pendingDocument
+	for i, doc := range pending {
+		if i >= len(resp.Items) {
+			failed = append(failed, doc)
+			continue
+		}
+		status, reason := status(resp.Items[i])
+		switch {
+		case status/100 == 2:
+		case retryable(status):
+			failed = append(failed, doc)
+			continue
+		default:
+			d.log.Errorf("Dropping document for %s/%s rejected with status %d: %s", d.group, d.stream, status, reason)
+		}
+		doc.done()
+	}
+	if len(failed) > 0 {
+		d.log.Debugf("%d of %d documents for %s/%s failed, will retry", len(failed), len(pending), d.group, d.stream)
+	}
+	return failed, nil
+}
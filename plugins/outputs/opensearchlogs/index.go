@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package opensearchlogs
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	datePlaceholder = "{date}"
+	dateFormat      = "2006.01.02"
+	// OpenSearch index names are limited to 255 bytes.
+	maxIndexLength = 255
+	fallbackIndex  = "logs"
+)
+
+// invalidIndexCharacters replaces the characters OpenSearch does not allow in index names, e.g. the slashes of the
+// log group names.
+var invalidIndexCharacters = strings.NewReplacer(
+	`\`, "-", "/", "-", "*", "-", "?", "-", `"`, "-", "<", "-", ">", "-", "|", "-", " ", "-", ",", "-", "#", "-",
+	":", "-",
+)
+
+// indexTemplate resolves the static placeholders once and only the {date} of each event.
+type indexTemplate struct {
+	resolved string
+	date     bool
+}
+
+func newIndexTemplate(template, group, stream string) *indexTemplate {
+	hostname, _ := os.Hostname()
+	resolved := strings.NewReplacer(
+		// e.g. aws-lambda-app for /aws/lambda/app
+		"{log_group_name}", strings.Trim(group, "/"),
+		"{log_stream_name}", stream,
+		"{hostname}", hostname,
+	).Replace(template)
+	return &indexTemplate{
+		resolved: resolved,
+		date:     strings.Contains(resolved, datePlaceholder),
+	}
+}
+
+// render returns the index of an event, with its UTC date so that each day of logs has its own index.
+func (t *indexTemplate) render(at time.Time) string {
+	index := t.resolved
+	if t.date {
+		index = strings.ReplaceAll(index, datePlaceholder, at.UTC().Format(dateFormat))
+	}
+	index = strings.TrimLeft(strings.ToLower(invalidIndexCharacters.Replace(index)), "-_+")
+	if len(index) > maxIndexLength {
+		index = strings.ToValidUTF8(index[:maxIndexLength], "")
+	}
+	if index == "" || index == "." || index == ".." {
+		index = fallbackIndex
+	}
+	return index
+}
@@ -0,0 +1,156 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package opensearchlogs
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/outputs"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	defaultFlushTimeout = 5 * time.Second
+	defaultIndex        = "{log_group_name}-{date}"
+	// defaultService is the signing name of the managed domains. The serverless collections use aoss.
+	defaultService = "es"
+	requestTimeout = time.Minute
+)
+
+// OpenSearchLogs is a log backend that indexes log events into an Amazon OpenSearch Service domain or serverless
+// collection with the bulk API, instead of publishing them to CloudWatch Logs.
+type OpenSearchLogs struct {
+	Region    string `toml:"region"`
+	AccessKey string `toml:"access_key"`
+	SecretKey string `toml:"secret_key"`
+	RoleARN   string `toml:"role_arn"`
+	Profile   string `toml:"profile"`
+	Filename  string `toml:"shared_credential_file"`
+	Token     string `toml:"token"`
+
+	// Endpoint is the URL of the domain or collection, e.g. https://search-logs-abc.us-east-1.es.amazonaws.com.
+	Endpoint string `toml:"endpoint"`
+	// Service is the name the requests are signed for, es or aoss.
+	Service string `toml:"service"`
+	// Index is a template supporting {log_group_name}, {log_stream_name}, {hostname} and {date}.
+	Index string `toml:"index"`
+
+	ForceFlushInterval internal.Duration `toml:"force_flush_interval"` // unit is second
+
+	Log telegraf.Logger `toml:"-"`
+
+	client    bulkAPI
+	dests     map[target]*openSearchDest
+	mu        sync.Mutex
+	stopCh    chan struct{}
+	waitGroup sync.WaitGroup
+}
+
+var _ logs.LogBackend = (*OpenSearchLogs)(nil)
+
+type target struct {
+	group, stream string
+}
+
+func (o *OpenSearchLogs) Connect() error {
+	return nil
+}
+
+func (o *OpenSearchLogs) Close() error {
+	close(o.stopCh)
+	o.waitGroup.Wait()
+	return nil
+}
+
+// Write is a no-op since the plugin only accepts log events through the log agent.
+func (o *OpenSearchLogs) Write(_ []telegraf.Metric) error {
+	return nil
+}
+
+func (o *OpenSearchLogs) CreateDest(group, stream string, _ int, _ string, _ logs.LogSrc) logs.LogDest {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	t := target{group: group, stream: stream}
+	if d, ok := o.dests[t]; ok {
+		return d
+	}
+	if o.client == nil {
+		o.client = o.createClient()
+	}
+	index := o.Index
+	if index == "" {
+		index = defaultIndex
+	}
+	d := newOpenSearchDest(o.Log, o.client, newIndexTemplate(index, group, stream), group, stream, o.ForceFlushInterval.Duration, o.stopCh, &o.waitGroup)
+	o.dests[t] = d
+	return d
+}
+
+func (o *OpenSearchLogs) createClient() bulkAPI {
+	credentialConfig := &configaws.CredentialConfig{
+		Region:    o.Region,
+		AccessKey: o.AccessKey,
+		SecretKey: o.SecretKey,
+		RoleARN:   o.RoleARN,
+		Profile:   o.Profile,
+		Filename:  o.Filename,
+		Token:     o.Token,
+	}
+	service := o.Service
+	if service == "" {
+		service = defaultService
+	}
+	return &bulkClient{
+		url:     strings.TrimSuffix(o.Endpoint, "/") + bulkPath,
+		service: service,
+		region:  o.Region,
+		signer:  v4.NewSigner(credentialConfig.Credentials().ClientConfig(service).Config.Credentials),
+		client:  &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Description returns a one-sentence description on the Output
+func (o *OpenSearchLogs) Description() string {
+	return "Configuration for Amazon OpenSearch Service log output."
+}
+
+var sampleConfig = `
+  ## Amazon REGION
+  region = "us-east-1"
+
+  ## Amazon Credentials, loaded the same way as the cloudwatchlogs output
+  #role_arn = ""
+
+  ## The URL of the OpenSearch domain or serverless collection.
+  endpoint = "https://search-logs-abc.us-east-1.es.amazonaws.com"
+
+  ## es for the domains, aoss for the serverless collections.
+  service = "es"
+
+  ## Index template. Supports {log_group_name}, {log_stream_name}, {hostname} and {date}.
+  index = "{log_group_name}-{date}"
+`
+
+// SampleConfig returns the default configuration of the Output
+func (o *OpenSearchLogs) SampleConfig() string {
+	return sampleConfig
+}
+
+func init() {
+	outputs.Add("opensearch_logs", func() telegraf.Output {
+		return &OpenSearchLogs{
+			ForceFlushInterval: internal.Duration{Duration: defaultFlushTimeout},
+			dests:              make(map[target]*openSearchDest),
+			stopCh:             make(chan struct{}),
+		}
+	})
+}
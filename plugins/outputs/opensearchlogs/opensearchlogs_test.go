@@ -0,0 +1,197 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package opensearchlogs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+// fakeOpenSearch records the bulk requests, and answers them with the statuses of the next responses.
+type fakeOpenSearch struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	// statuses are the statuses of the requests, then those of their items. The items succeed when there are none.
+	statuses [][]int
+}
+
+func (f *fakeOpenSearch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r)
+	f.bodies = append(f.bodies, body)
+	var statuses []int
+	if len(f.statuses) > 0 {
+		statuses, f.statuses = f.statuses[0], f.statuses[1:]
+	}
+	if len(statuses) > 0 && statuses[0] != http.StatusOK {
+		w.Header().Set(retryAfterHeader, "0")
+		w.WriteHeader(statuses[0])
+		return
+	}
+	resp := bulkResponse{}
+	for i := 0; i < bytes.Count(body, []byte("\n"))/2; i++ {
+		result := bulkItemResult{Status: http.StatusCreated}
+		if i+1 < len(statuses) {
+			result.Status = statuses[i+1]
+		}
+		resp.Items = append(resp.Items, map[string]bulkItemResult{"create": result})
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// documents returns the indexes and the documents of the requests.
+func (f *fakeOpenSearch) documents(t *testing.T) ([]string, []document) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var indexes []string
+	var docs []document
+	for _, body := range f.bodies {
+		scanner := bufio.NewScanner(bytes.NewReader(body))
+		for scanner.Scan() {
+			var a action
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &a))
+			require.True(t, scanner.Scan())
+			var doc document
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &doc))
+			indexes = append(indexes, a.Create.Index)
+			docs = append(docs, doc)
+		}
+	}
+	return indexes, docs
+}
+
+type testEvent struct {
+	msg  string
+	t    time.Time
+	done *atomic.Int32
+}
+
+func (e testEvent) Message() string { return e.msg }
+func (e testEvent) Time() time.Time { return e.t }
+func (e testEvent) Done()           { e.done.Add(1) }
+
+func newTestOpenSearchLogs(t *testing.T, server *fakeOpenSearch) *OpenSearchLogs {
+	s := httptest.NewServer(server)
+	t.Cleanup(s.Close)
+	return &OpenSearchLogs{
+		Index:              "logs-{log_group_name}-{date}",
+		ForceFlushInterval: internal.Duration{Duration: 50 * time.Millisecond},
+		Log:                testutil.Logger{Name: "opensearch_logs"},
+		client: &bulkClient{
+			url:     s.URL + bulkPath,
+			service: "aoss",
+			region:  "us-east-1",
+			signer:  v4.NewSigner(credentials.NewStaticCredentials("AKID", "SECRET", "")),
+			client:  s.Client(),
+		},
+		dests:  make(map[target]*openSearchDest),
+		stopCh: make(chan struct{}),
+	}
+}
+
+func TestOpenSearchLogsPublish(t *testing.T) {
+	server := &fakeOpenSearch{}
+	o := newTestOpenSearchLogs(t, server)
+	dest := o.CreateDest("/aws/App", "stream", -1, "", nil)
+	assert.Same(t, dest, o.CreateDest("/aws/App", "stream", -1, "", nil))
+
+	var done atomic.Int32
+	ts := time.Date(2024, 3, 1, 23, 59, 59, 0, time.UTC)
+	require.NoError(t, dest.Publish([]logs.LogEvent{
+		testEvent{msg: "first", t: ts, done: &done},
+		testEvent{msg: "second", t: ts.Add(time.Second), done: &done},
+	}))
+	assert.Eventually(t, func() bool { return done.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, o.Close())
+
+	indexes, docs := server.documents(t)
+	// each day of logs has its own index
+	assert.Equal(t, []string{"logs-aws-app-2024.03.01", "logs-aws-app-2024.03.02"}, indexes)
+	assert.Equal(t, document{
+		Timestamp:     "2024-03-01T23:59:59.000Z",
+		LogGroupName:  "/aws/App",
+		LogStreamName: "stream",
+		Message:       "first",
+	}, docs[0])
+
+	require.Len(t, server.requests, 1)
+	r := server.requests[0]
+	assert.Equal(t, bulkPath, r.URL.Path)
+	assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+	assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/aoss/aws4_request")
+	assert.NotEmpty(t, r.Header.Get(contentSHA256Header))
+	assert.Equal(t, logs.ErrOutputStopped, dest.Publish([]logs.LogEvent{testEvent{msg: "late", done: &done}}))
+}
+
+func TestOpenSearchLogsRetry(t *testing.T) {
+	server := &fakeOpenSearch{statuses: [][]int{
+		{http.StatusTooManyRequests},
+		// the first document is throttled, the second rejected by the mapping and the third indexed
+		{http.StatusOK, http.StatusTooManyRequests, http.StatusBadRequest, http.StatusCreated},
+	}}
+	o := newTestOpenSearchLogs(t, server)
+	dest := o.CreateDest("group", "stream", -1, "", nil)
+
+	var done atomic.Int32
+	require.NoError(t, dest.Publish([]logs.LogEvent{
+		testEvent{msg: "first", done: &done},
+		testEvent{msg: "second", done: &done},
+		testEvent{msg: "third", done: &done},
+	}))
+	assert.Eventually(t, func() bool { return done.Load() == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, o.Close())
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Len(t, server.bodies, 3)
+	assert.Equal(t, server.bodies[0], server.bodies[1])
+	// the throttled document is sent again on its own
+	assert.Equal(t, 2, bytes.Count(server.bodies[2], []byte("\n")))
+	assert.Contains(t, string(server.bodies[2]), `"message":"first"`)
+}
+
+func TestOpenSearchLogsRejectedRequest(t *testing.T) {
+	server := &fakeOpenSearch{statuses: [][]int{{http.StatusRequestEntityTooLarge}}}
+	o := newTestOpenSearchLogs(t, server)
+	dest := o.CreateDest("group", "stream", -1, "", nil)
+
+	var done atomic.Int32
+	require.NoError(t, dest.Publish([]logs.LogEvent{testEvent{msg: "event", done: &done}}))
+	assert.Eventually(t, func() bool { return done.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, o.Close())
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Len(t, server.requests, 1)
+}
+
+func TestIndexTemplate(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 10, 0, 0, 0, time.FixedZone("", -12*60*60))
+	assert.Equal(t, "app-stream-2024.03.01", newIndexTemplate("{log_group_name}-{log_stream_name}-{date}", "App", "Stream").render(ts))
+	assert.Equal(t, "2024.03.01", newIndexTemplate("{date}", "group", "stream").render(ts.Add(-10*time.Hour)))
+	assert.Equal(t, "var-log-messages", newIndexTemplate("{log_group_name}", "_/var/log/messages", "").render(ts))
+	assert.Equal(t, fallbackIndex, newIndexTemplate("{log_stream_name}", "group", "").render(ts))
+	assert.Len(t, newIndexTemplate(strings.Repeat("a", 300), "", "").render(ts), maxIndexLength)
+}
@@ -9,16 +9,33 @@ import (
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/processors/k8sdecorator"
 
 	// Enabled cloudwatch-agent input plugins
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/auditd"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/certificates"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/cgroup"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/connection_summary"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/exec"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/file_stats"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/http_json"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/job_heartbeat"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/kernel_events"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/network_mesh"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/numa"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/nvidia_smi"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/pressure"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/prometheus"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/prometheus_textfile"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/smart"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/statsd"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/win_perf_counters"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/windows_event_log"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/windows_security"
 
 	// Enabled cloudwatch-agent output plugins
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatch"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/outputs/kinesislogs"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/outputs/opensearchlogs"
 
 	// Enabled telegraf input plugins
 	// NOTE: any plugins that are dependencies of the plugins enabled will be enabled too
@@ -13,6 +13,7 @@ const (
 	CWMetricAttributeRemoteOperation          = "RemoteOperation"
 	CWMetricAttributeRemoteResourceIdentifier = "RemoteResourceIdentifier"
 	CWMetricAttributeRemoteResourceType       = "RemoteResourceType"
+	CWMetricAttributeExceptionType            = "ExceptionType"
 )
 
 // Platform attribute used as CloudWatch EMF log field and X-Ray trace annotation.
@@ -32,8 +33,14 @@ const (
 const (
 	MetricAttributeECSCluster                = "ECS.Cluster"
 	MetricAttributeECSTaskId                 = "ECS.TaskId"
+	MetricAttributeECSService                = "ECS.Service"
+	MetricAttributeECSLaunchType             = "ECS.LaunchType"
 	MetricAttributeECSTaskDefinitionFamily   = "ECS.TaskDefinitionFamily"
 	MetricAttributeECSTaskDefinitionRevision = "ECS.TaskDefinitionRevision"
+
+	MetricAttributeLambdaFunction        = "Lambda.Function"
+	MetricAttributeLambdaFunctionVersion = "Lambda.FunctionVersion"
+	MetricAttributeLambdaMaxMemory       = "Lambda.MaxMemory"
 )
 
 // Telemetry attributes used as CloudWatch EMF log fields.
@@ -62,4 +69,5 @@ var CWMetricAttributes = []string{
 	CWMetricAttributeRemoteOperation,
 	CWMetricAttributeRemoteResourceIdentifier,
 	CWMetricAttributeRemoteResourceType,
+	CWMetricAttributeExceptionType,
 }
@@ -15,15 +15,59 @@ type Config struct {
 	Resolvers []Resolver     `mapstructure:"resolvers"`
 	Rules     []rules.Rule   `mapstructure:"rules"`
 	Limiter   *LimiterConfig `mapstructure:"limiter"`
+	// ExceptionMetrics turns the exception events of spans into exception counts per operation when set.
+	ExceptionMetrics *ExceptionMetricsConfig `mapstructure:"exception_metrics"`
+	// NumWorkers is the number of goroutines the resources of a batch are processed on. Batches are processed
+	// sequentially when it is 0 or 1.
+	NumWorkers int `mapstructure:"num_workers,omitempty"`
+	// RulesFile is a YAML or JSON file with the rules under a rules key, used instead of Rules. It is read again every
+	// ReloadInterval, so that the rules can be changed without restarting the agent.
+	RulesFile      string        `mapstructure:"rules_file,omitempty"`
+	ReloadInterval time.Duration `mapstructure:"reload_interval,omitempty"`
+	// ResolverMaxWait is how long the metrics received before the resolvers are ready, e.g. while the kubernetes API
+	// is unavailable, are held to be resolved once they are. They are processed unresolved after it.
+	// DefaultResolverMaxWait is used when it is 0.
+	ResolverMaxWait time.Duration `mapstructure:"resolver_max_wait,omitempty"`
+	// ResolverMaxPendingDataPoints bounds the data points held until the resolvers are ready, the others are
+	// processed unresolved. DefaultResolverMaxPendingDataPoints is used when it is 0.
+	ResolverMaxPendingDataPoints int `mapstructure:"resolver_max_pending_data_points,omitempty"`
+	// Sampling drops part of the spans once their attributes are resolved. All the spans are kept when it is nil.
+	Sampling *SamplingConfig `mapstructure:"sampling,omitempty"`
+}
+
+// SamplingConfig samples the spans of the traces pipeline. The first rule matching a span applies, e.g. to keep all
+// the error spans and a percentage of the others.
+type SamplingConfig struct {
+	// Percentage of the spans matching none of the rules that are kept. DefaultSamplingPercentage is used when it
+	// is nil.
+	Percentage *float64             `mapstructure:"sampling_percentage,omitempty"`
+	Rules      []rules.SamplingRule `mapstructure:"rules,omitempty"`
+}
+
+// DefaultPercentage returns the percentage of the spans matching none of the rules that are kept.
+func (sc *SamplingConfig) DefaultPercentage() float64 {
+	if sc.Percentage == nil {
+		return DefaultSamplingPercentage
+	}
+	return *sc.Percentage
+}
+
+type ExceptionMetricsConfig struct {
+	// MaxExceptionTypes is the number of distinct exception types counted per operation, the others are counted as
+	// DefaultOtherExceptionType.
+	MaxExceptionTypes int `mapstructure:"max_exception_types"`
 }
 
 type LimiterConfig struct {
-	Threshold                 int             `mapstructure:"drop_threshold"`
-	Disabled                  bool            `mapstructure:"disabled"`
-	LogDroppedMetrics         bool            `mapstructure:"log_dropped_metrics"`
-	RotationInterval          time.Duration   `mapstructure:"rotation_interval"`
-	GarbageCollectionInterval time.Duration   `mapstructure:"garbage_collection_interval"`
-	ParentContext             context.Context `mapstructure:"-"`
+	Threshold                 int           `mapstructure:"drop_threshold"`
+	Disabled                  bool          `mapstructure:"disabled"`
+	LogDroppedMetrics         bool          `mapstructure:"log_dropped_metrics"`
+	RotationInterval          time.Duration `mapstructure:"rotation_interval"`
+	GarbageCollectionInterval time.Duration `mapstructure:"garbage_collection_interval"`
+	// MaxOperations is the number of operations of each service, by call volume, whose metrics are kept. The metrics
+	// of the other operations are aggregated under the AllOther operation. The operations are not limited when it is 0.
+	MaxOperations int             `mapstructure:"max_operations,omitempty"`
+	ParentContext context.Context `mapstructure:"-"`
 }
 
 const (
@@ -32,6 +76,26 @@ const (
 	DefaultGCInterval       = 10 * time.Minute
 )
 
+const DefaultReloadInterval = 1 * time.Minute
+
+const (
+	DefaultResolverMaxWait              = 5 * time.Minute
+	DefaultResolverMaxPendingDataPoints = 100000
+)
+
+const DefaultSamplingPercentage = 100
+
+const (
+	DefaultMaxExceptionTypes  = 10
+	DefaultOtherExceptionType = "Other"
+)
+
+func NewDefaultExceptionMetricsConfig() *ExceptionMetricsConfig {
+	return &ExceptionMetricsConfig{
+		MaxExceptionTypes: DefaultMaxExceptionTypes,
+	}
+}
+
 func NewDefaultLimiterConfig() *LimiterConfig {
 	return &LimiterConfig{
 		Threshold:                 DefaultThreshold,
@@ -62,14 +126,46 @@ func (cfg *Config) Validate() error {
 			if resolver.Name == "" {
 				return errors.New("name must not be empty for k8s resolver")
 			}
-		case PlatformEC2, PlatformECS, PlatformGeneric:
+		case PlatformEC2, PlatformECS, PlatformLambda, PlatformGeneric:
 		default:
 			return errors.New("unknown resolver")
 		}
+		if resolver.CacheSize < 0 {
+			return errors.New("cache_size must not be negative")
+		}
+		if resolver.CacheTTL < 0 {
+			return errors.New("cache_ttl must not be negative")
+		}
 	}
 
 	if cfg.Limiter != nil {
 		cfg.Limiter.Validate()
+		if cfg.Limiter.MaxOperations < 0 {
+			return errors.New("max_operations must not be negative")
+		}
+	}
+	if cfg.ExceptionMetrics != nil && cfg.ExceptionMetrics.MaxExceptionTypes <= 0 {
+		return errors.New("max_exception_types must be positive")
+	}
+	if cfg.NumWorkers < 0 {
+		return errors.New("num_workers must not be negative")
+	}
+	if cfg.RulesFile != "" && len(cfg.Rules) > 0 {
+		return errors.New("rules and rules_file must not both be set")
+	}
+	if cfg.ReloadInterval < 0 {
+		return errors.New("reload_interval must not be negative")
+	}
+	if cfg.ResolverMaxWait < 0 {
+		return errors.New("resolver_max_wait must not be negative")
+	}
+	if cfg.ResolverMaxPendingDataPoints < 0 {
+		return errors.New("resolver_max_pending_data_points must not be negative")
+	}
+	if cfg.Sampling != nil {
+		if _, err := rules.NewSampler(cfg.Sampling.Rules, cfg.Sampling.DefaultPercentage()); err != nil {
+			return err
+		}
 	}
 	return nil
 }
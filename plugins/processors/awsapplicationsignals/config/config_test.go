@@ -5,8 +5,11 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
 )
 
 func TestValidatePassed(t *testing.T) {
@@ -30,6 +33,10 @@ func TestValidatePassed(t *testing.T) {
 			"testECS",
 			NewECSResolver("test"),
 		},
+		{
+			"testLambda",
+			NewLambdaResolver(""),
+		},
 		{
 			"testGeneric",
 			NewGenericResolver("test"),
@@ -80,3 +87,77 @@ func TestValidateFailedOnEmptyResolverName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFailedOnInvalidMaxExceptionTypes(t *testing.T) {
+	config := Config{
+		Resolvers:        []Resolver{NewEC2Resolver("")},
+		ExceptionMetrics: &ExceptionMetricsConfig{MaxExceptionTypes: 0},
+	}
+	assert.NotNil(t, config.Validate())
+	config.ExceptionMetrics = NewDefaultExceptionMetricsConfig()
+	assert.Nil(t, config.Validate())
+}
+
+func TestValidateFailedOnNegativeNumWorkers(t *testing.T) {
+	config := Config{
+		Resolvers:  []Resolver{NewEC2Resolver("")},
+		NumWorkers: -1,
+	}
+	assert.NotNil(t, config.Validate())
+	config.NumWorkers = 4
+	assert.Nil(t, config.Validate())
+}
+
+func TestValidateFailedOnNegativeCache(t *testing.T) {
+	resolver := NewEKSResolver("test")
+	resolver.CacheSize = -1
+	config := Config{Resolvers: []Resolver{resolver}}
+	assert.NotNil(t, config.Validate())
+	config.Resolvers[0].CacheSize = 100
+	config.Resolvers[0].CacheTTL = -time.Second
+	assert.NotNil(t, config.Validate())
+	config.Resolvers[0].CacheTTL = time.Minute
+	assert.Nil(t, config.Validate())
+}
+
+func TestValidateFailedOnRulesAndRulesFile(t *testing.T) {
+	config := Config{
+		Resolvers: []Resolver{NewEC2Resolver("")},
+		Rules:     []rules.Rule{{Action: rules.AllowListActionDrop}},
+		RulesFile: "/etc/amazon-cloudwatch-agent/appsignals-rules.yaml",
+	}
+	assert.NotNil(t, config.Validate())
+	config.Rules = nil
+	assert.Nil(t, config.Validate())
+	config.ReloadInterval = -time.Second
+	assert.NotNil(t, config.Validate())
+}
+
+func TestValidateFailedOnNegativeResolverRetry(t *testing.T) {
+	config := Config{
+		Resolvers:       []Resolver{NewEKSResolver("test")},
+		ResolverMaxWait: -time.Second,
+	}
+	assert.NotNil(t, config.Validate())
+	config.ResolverMaxWait = time.Minute
+	config.ResolverMaxPendingDataPoints = -1
+	assert.NotNil(t, config.Validate())
+	config.ResolverMaxPendingDataPoints = 1000
+	assert.Nil(t, config.Validate())
+}
+
+func TestValidateFailedOnInvalidSampling(t *testing.T) {
+	percentage := 150.0
+	config := Config{
+		Resolvers: []Resolver{NewEC2Resolver("")},
+		Sampling:  &SamplingConfig{Percentage: &percentage},
+	}
+	assert.NotNil(t, config.Validate())
+	percentage = 5
+	assert.Nil(t, config.Validate())
+	config.Sampling.Rules = []rules.SamplingRule{{StatusCode: "failed", Percentage: 100}}
+	assert.NotNil(t, config.Validate())
+	config.Sampling.Rules[0].StatusCode = "ERROR"
+	assert.Nil(t, config.Validate())
+	assert.EqualValues(t, DefaultSamplingPercentage, (&SamplingConfig{}).DefaultPercentage())
+}
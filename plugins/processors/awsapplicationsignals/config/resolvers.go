@@ -3,6 +3,8 @@
 
 package config
 
+import "time"
+
 const (
 	// PlatformGeneric Platforms other than Amazon EKS
 	PlatformGeneric = "generic"
@@ -14,11 +16,26 @@ const (
 	PlatformEC2 = "ec2"
 	// PlatformECS Amazon ECS
 	PlatformECS = "ecs"
+	// PlatformLambda AWS Lambda, with the agent running as an extension
+	PlatformLambda = "lambda"
+)
+
+const (
+	// DefaultCacheSize is the number of IP lookups the kubernetes resolvers cache by default.
+	DefaultCacheSize = 10000
+	// DefaultCacheTTL is how long the kubernetes resolvers use a cached IP lookup by default.
+	DefaultCacheTTL = 30 * time.Second
 )
 
 type Resolver struct {
 	Name     string `mapstructure:"name"`
 	Platform string `mapstructure:"platform"`
+	// CacheSize is the number of IP lookups the eks and k8s resolvers cache, the least recently used ones are evicted
+	// first. DefaultCacheSize is used when it is 0.
+	CacheSize int `mapstructure:"cache_size,omitempty"`
+	// CacheTTL is how long the eks and k8s resolvers use a cached IP lookup. IPs that cannot be resolved are cached
+	// for at most 10s. DefaultCacheTTL is used when it is 0.
+	CacheTTL time.Duration `mapstructure:"cache_ttl,omitempty"`
 }
 
 func NewEKSResolver(name string) Resolver {
@@ -49,6 +66,13 @@ func NewECSResolver(name string) Resolver {
 	}
 }
 
+func NewLambdaResolver(name string) Resolver {
+	return Resolver{
+		Name:     name,
+		Platform: PlatformLambda,
+	}
+}
+
 func NewGenericResolver(name string) Resolver {
 	return Resolver{
 		Name:     name,
@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/confmap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
+)
+
+type rulesFile struct {
+	Rules []rules.Rule `mapstructure:"rules"`
+}
+
+// ParseRulesFile parses the content of a rules file, which has the same rules as the processor configuration:
+//
+//	rules:
+//	  - selectors:
+//	      - dimension: Operation
+//	        match: "GET /health"
+//	    action: drop
+//
+// JSON is accepted as well, since it is valid YAML.
+func ParseRulesFile(data []byte) ([]rules.Rule, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid rules file: %w", err)
+	}
+	var parsed rulesFile
+	if err := confmap.NewFromStringMap(raw).Unmarshal(&parsed); err != nil {
+		return nil, fmt.Errorf("invalid rules file: %w", err)
+	}
+	return parsed.Rules, nil
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
+)
+
+func TestParseRulesFile(t *testing.T) {
+	expected := []rules.Rule{
+		{
+			Selectors: []rules.Selector{{Dimension: "Operation", Match: "GET /health"}},
+			Action:    rules.AllowListActionDrop,
+			RuleName:  "drop-health",
+		},
+		{
+			Selectors:    []rules.Selector{{Dimension: "Operation", Match: "*"}},
+			Replacements: []rules.Replacement{{TargetDimension: "RemoteOperation", Value: "Other"}},
+			Action:       rules.AllowListActionReplace,
+		},
+	}
+
+	yamlRules, err := ParseRulesFile([]byte(`
+rules:
+  - selectors:
+      - dimension: Operation
+        match: "GET /health"
+    action: drop
+    rule_name: drop-health
+  - selectors:
+      - dimension: Operation
+        match: "*"
+    replacements:
+      - target_dimension: RemoteOperation
+        value: Other
+    action: replace
+`))
+	require.NoError(t, err)
+	assert.Equal(t, expected, yamlRules)
+
+	jsonRules, err := ParseRulesFile([]byte(`{"rules": [
+		{"selectors": [{"dimension": "Operation", "match": "GET /health"}], "action": "drop", "rule_name": "drop-health"},
+		{"selectors": [{"dimension": "Operation", "match": "*"}], "replacements": [{"target_dimension": "RemoteOperation", "value": "Other"}], "action": "replace"}
+	]}`))
+	require.NoError(t, err)
+	assert.Equal(t, expected, jsonRules)
+
+	_, err = ParseRulesFile([]byte(`rules: [`))
+	assert.Error(t, err)
+	_, err = ParseRulesFile([]byte(`rules: [{"selector": []}]`))
+	assert.Error(t, err)
+}
@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/processor/processorhelper"
 
 	appsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/exceptions"
 )
 
 const (
@@ -94,7 +95,14 @@ func createProcessor(
 	if !ok {
 		return nil, errors.New("could not initialize awsapplicationsignalsprocessor")
 	}
-	ap := &awsapplicationsignalsprocessor{logger: params.Logger, config: pCfg}
+	telemetry, err := newProcessorTelemetry(params.MeterProvider, params.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	ap := &awsapplicationsignalsprocessor{logger: params.Logger, config: pCfg, telemetry: telemetry}
+	if pCfg.ExceptionMetrics != nil {
+		ap.exceptions = exceptions.Get(params.ID, pCfg.ExceptionMetrics)
+	}
 
 	return ap, nil
 }
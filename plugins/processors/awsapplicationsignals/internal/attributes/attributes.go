@@ -18,12 +18,19 @@ const (
 	AWSHostedInEnvironment                = "aws.hostedin.environment"
 	AWSRemoteDbUser                       = "aws.remote.db.user"
 	AWSRemoteResourceCfnPrimaryIdentifier = "aws.remote.resource.cfn.primary.identifier"
+	AWSLambdaFunctionName                 = "aws.lambda.function.name"
+	AWSLambdaFunctionArn                  = "aws.lambda.function.arn"
 
 	AWSECSClusterName = "aws.ecs.cluster.name"
 	AWSECSTaskID      = "aws.ecs.task.id"
+	AWSECSServiceName = "aws.ecs.service.name"
+	AWSECSLaunchType  = "aws.ecs.launch.type"
 
 	// resource detection processor attributes
 	ResourceDetectionHostId   = "host.id"
 	ResourceDetectionHostName = "host.name"
 	ResourceDetectionASG      = "ec2.tag.aws:autoscaling:groupName"
+
+	// semantic convention attributes missing from the semconv version in use
+	ServerAddress = "server.address"
 )
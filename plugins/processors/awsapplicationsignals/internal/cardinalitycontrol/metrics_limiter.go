@@ -118,6 +118,11 @@ func (m *MetricsLimiter) Admit(metricName string, attributes, resourceAttributes
 		return true, nil
 	}
 
+	// The processor can admit the data points of several resources at once, so the visit records of the service are
+	// locked from the admission check to the insertion.
+	svc.rwLock.Lock()
+	defer svc.rwLock.Unlock()
+
 	if !svc.admitMetricData(metricData) {
 		svc.rollupMetricData(attributes)
 
@@ -130,10 +135,6 @@ func (m *MetricsLimiter) Admit(metricName string, attributes, resourceAttributes
 	}
 
 	svc.totalMetricSent++
-
-	svc.rwLock.RLock()
-	defer svc.rwLock.RUnlock()
-
 	svc.totalCount++
 	svc.InsertMetricDataToPrimary(metricData)
 	svc.InsertMetricDataToSecondary(metricData)
@@ -156,22 +157,15 @@ func (m *MetricsLimiter) filterAWSDeclaredAttributes(attributes, resourceAttribu
 }
 
 func (m *MetricsLimiter) removeStaleServices() {
-	var svcToRemove []string
-	for name, svc := range m.services {
-		if svc.rotations > 3 {
-			if svc.countSnapshot[0] == svc.countSnapshot[1] && svc.countSnapshot[1] == svc.countSnapshot[2] {
-				svc.cancelFunc()
-				svcToRemove = append(svcToRemove, name)
-			}
-		}
-	}
-
 	m.mapLock.Lock()
 	defer m.mapLock.Unlock()
 
-	for _, name := range svcToRemove {
-		m.logger.Info("remove stale service " + name + ".")
-		delete(m.services, name)
+	for name, svc := range m.services {
+		if svc.isStale() {
+			svc.cancelFunc()
+			m.logger.Info("remove stale service " + name + ".")
+			delete(m.services, name)
+		}
 	}
 }
 
@@ -194,6 +188,13 @@ type service struct {
 	totalMetricSent int
 }
 
+// isStale returns whether the service has not sent any metric during the last rotations.
+func (s *service) isStale() bool {
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+	return s.rotations > 3 && s.countSnapshot[0] == s.countSnapshot[1] && s.countSnapshot[1] == s.countSnapshot[2]
+}
+
 func (s *service) InsertMetricDataToPrimary(md *MetricData) {
 	s.primaryCMS.Insert(md)
 	updatedFrequency := s.primaryCMS.Get(md)
@@ -373,7 +374,6 @@ func newService(name string, limit int, rotationInterval time.Duration, parentCt
 		for {
 			select {
 			case <-rotationTicker.C:
-				svc.logger.Info(fmt.Sprintf("[%s] rotating visit records, current rotation %d", name, svc.rotations))
 				if err := rotateVisitRecords(svc); err != nil {
 					svc.logger.Error(fmt.Sprintf("[%s] failed to rotate visit records.", name), zap.Error(err))
 				}
@@ -394,6 +394,8 @@ func rotateVisitRecords(svc *service) error {
 	svc.rwLock.Lock()
 	defer svc.rwLock.Unlock()
 
+	svc.logger.Info(fmt.Sprintf("[%s] rotating visit records, current rotation %d", svc.name, svc.rotations))
+
 	cmsDepth := svc.primaryCMS.depth
 	cmsWidth := svc.primaryCMS.width
 	topKLimit := svc.primaryTopK.sizeLimit
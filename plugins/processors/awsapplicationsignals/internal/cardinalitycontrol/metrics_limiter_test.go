@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"math/rand"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -110,6 +112,35 @@ func TestAdmitLowCardinalityAttributes(t *testing.T) {
 	assert.Equal(t, 0, rejectCount)
 }
 
+func TestAdmitConcurrently(t *testing.T) {
+	config := awsapplicationsignalsconfig.LimiterConfig{
+		Threshold:         10,
+		Disabled:          false,
+		LogDroppedMetrics: false,
+		RotationInterval:  awsapplicationsignalsconfig.DefaultRotationInterval,
+	}
+	config.Validate()
+
+	limiter := NewMetricsLimiter(&config, logger)
+
+	var wg sync.WaitGroup
+	var rejectCount atomic.Int32
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if ok, _ := limiter.Admit("latency", newFixedAttributes(i%10), emptyResourceAttributes); !ok {
+					rejectCount.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(0), rejectCount.Load())
+	assert.Equal(t, 800, limiter.(*MetricsLimiter).services["app"].totalCount)
+}
+
 func TestAdmitReservedMetrics(t *testing.T) {
 	config := awsapplicationsignalsconfig.LimiterConfig{
 		Threshold:         10,
@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cardinalitycontrol
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+)
+
+// OverflowOperationValue is the operation the metrics of the operations outside of the top-K of their service are
+// aggregated under.
+const OverflowOperationValue = "AllOther"
+
+// OperationsLimiter keeps the metrics of the top-K operations of each service by call volume, and aggregates the
+// others under the OverflowOperationValue operation. It bounds the metrics of the services with dynamic operations,
+// e.g. one per URL path, without dropping their calls. The call volume of the operations is estimated over the
+// rotation interval like the MetricsLimiter does.
+type OperationsLimiter struct {
+	maxOperations    int
+	rotationInterval time.Duration
+
+	logger   *zap.Logger
+	ctx      context.Context
+	mapLock  sync.RWMutex
+	services map[string]*service
+}
+
+func NewOperationsLimiter(limiterConfig *config.LimiterConfig, logger *zap.Logger) *OperationsLimiter {
+	ctx := limiterConfig.ParentContext
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	limiter := &OperationsLimiter{
+		maxOperations:    limiterConfig.MaxOperations,
+		rotationInterval: limiterConfig.RotationInterval,
+		logger:           logger,
+		ctx:              ctx,
+		services:         map[string]*service{},
+	}
+	if limiter.rotationInterval <= 0 {
+		limiter.rotationInterval = config.DefaultRotationInterval
+	}
+	gcInterval := limiterConfig.GarbageCollectionInterval
+	if gcInterval <= 0 {
+		gcInterval = config.DefaultGCInterval
+	}
+	go func() {
+		ticker := time.NewTicker(gcInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				limiter.removeStaleServices()
+			}
+		}
+	}()
+	logger.Info("operations limiter created", zap.Int("maxOperations", limiterConfig.MaxOperations))
+	return limiter
+}
+
+// Admit records the calls of the operation of the data point, e.g. the count of its latency histogram, and replaces
+// the operation with OverflowOperationValue when it is not in the top-K of its service. It returns whether the
+// operation was kept.
+func (l *OperationsLimiter) Admit(attributes pcommon.Map, calls int) bool {
+	serviceAttr, ok := attributes.Get(common.CWMetricAttributeLocalService)
+	if !ok {
+		return true
+	}
+	operationAttr, ok := attributes.Get(common.CWMetricAttributeLocalOperation)
+	if !ok {
+		return true
+	}
+	if reserved, _ := attributes.Get(common.AttributeTmpReserved); reserved.Bool() {
+		return true
+	}
+	svc := l.service(serviceAttr.AsString())
+	operation := &MetricData{
+		hashKey:   operationAttr.AsString(),
+		service:   svc.name,
+		frequency: calls,
+	}
+
+	svc.rwLock.Lock()
+	defer svc.rwLock.Unlock()
+	svc.totalCount++
+	if calls > 0 {
+		svc.InsertMetricDataToPrimary(operation)
+		svc.InsertMetricDataToSecondary(operation)
+	}
+	if svc.admitMetricData(operation) {
+		return true
+	}
+	attributes.PutStr(common.CWMetricAttributeLocalOperation, OverflowOperationValue)
+	svc.totalRollup++
+	return false
+}
+
+func (l *OperationsLimiter) service(name string) *service {
+	l.mapLock.RLock()
+	svc := l.services[name]
+	l.mapLock.RUnlock()
+	if svc != nil {
+		return svc
+	}
+	l.mapLock.Lock()
+	defer l.mapLock.Unlock()
+	if svc = l.services[name]; svc == nil {
+		svc = newService(name, l.maxOperations, l.rotationInterval, l.ctx, l.logger)
+		l.services[name] = svc
+	}
+	return svc
+}
+
+func (l *OperationsLimiter) removeStaleServices() {
+	l.mapLock.Lock()
+	defer l.mapLock.Unlock()
+	for name, svc := range l.services {
+		if svc.isStale() {
+			svc.cancelFunc()
+			delete(l.services, name)
+		}
+	}
+}
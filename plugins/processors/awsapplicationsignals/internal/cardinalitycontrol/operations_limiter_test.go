@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cardinalitycontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	awsapplicationsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+)
+
+func newOperationAttributes(service, operation string) pcommon.Map {
+	attr := pcommon.NewMap()
+	attr.PutStr(common.CWMetricAttributeLocalService, service)
+	attr.PutStr(common.CWMetricAttributeLocalOperation, operation)
+	attr.PutStr(common.CWMetricAttributeRemoteService, "db")
+	return attr
+}
+
+func TestOperationsLimiter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	limiter := NewOperationsLimiter(&awsapplicationsignalsconfig.LimiterConfig{
+		MaxOperations:    2,
+		RotationInterval: time.Hour,
+		ParentContext:    ctx,
+	}, logger)
+
+	assert.True(t, limiter.Admit(newOperationAttributes("checkout", "GET /cart"), 100))
+	assert.True(t, limiter.Admit(newOperationAttributes("checkout", "POST /order"), 50))
+	// the data points counting no calls, e.g. the errors, do not change the top operations
+	assert.True(t, limiter.Admit(newOperationAttributes("checkout", "POST /order"), 0))
+
+	// the tail is aggregated under the overflow operation, the other attributes are kept
+	attr := newOperationAttributes("checkout", "GET /item/1")
+	assert.False(t, limiter.Admit(attr, 1))
+	operation, _ := attr.Get(common.CWMetricAttributeLocalOperation)
+	assert.Equal(t, OverflowOperationValue, operation.Str())
+	remoteService, _ := attr.Get(common.CWMetricAttributeRemoteService)
+	assert.Equal(t, "db", remoteService.Str())
+
+	// an operation gaining more calls than the least called one replaces it
+	assert.True(t, limiter.Admit(newOperationAttributes("checkout", "GET /search"), 500))
+	assert.False(t, limiter.Admit(newOperationAttributes("checkout", "POST /order"), 0))
+
+	// the services are limited separately
+	assert.True(t, limiter.Admit(newOperationAttributes("payments", "POST /charge"), 1))
+
+	// the operations kept by the rules are never aggregated
+	attr = newOperationAttributes("checkout", "GET /item/2")
+	attr.PutBool(common.AttributeTmpReserved, true)
+	assert.True(t, limiter.Admit(attr, 1))
+	_, reserved := attr.Get(common.AttributeTmpReserved)
+	assert.True(t, reserved, "the reserved attribute is left for the metrics limiter")
+
+	// the data points without an operation are not limited
+	assert.True(t, limiter.Admit(pcommon.NewMap(), 1))
+}
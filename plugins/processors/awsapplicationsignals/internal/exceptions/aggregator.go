@@ -0,0 +1,168 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exceptions
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	appsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+const (
+	MetricName = "Exception"
+	// TelemetrySource is the Telemetry.Source of the exception metrics.
+	TelemetrySource = "SpanEvent"
+
+	exceptionEventName   = "exception"
+	unknownExceptionType = "UnknownException"
+)
+
+var (
+	registryMu sync.Mutex
+	registry   = map[component.ID]*Aggregator{}
+)
+
+// Get returns the aggregator of the processor with the given ID, so that the instance of the processor in the traces
+// pipeline counts the exceptions that the instance in the metrics pipeline emits.
+func Get(id component.ID, cfg *appsignalsconfig.ExceptionMetricsConfig) *Aggregator {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if a, ok := registry[id]; ok {
+		return a
+	}
+	a := NewAggregator(cfg.MaxExceptionTypes)
+	registry[id] = a
+	return a
+}
+
+type operationKey struct {
+	service, environment, operation string
+}
+
+type countKey struct {
+	operationKey
+	exceptionType string
+}
+
+// Aggregator counts the exception events of the entry spans of each operation by exception type.
+type Aggregator struct {
+	mu       sync.Mutex
+	maxTypes int
+	// types are the exception types seen per operation, which stay capped across flushes
+	types  map[operationKey]map[string]struct{}
+	counts map[countKey]int64
+	start  time.Time
+}
+
+func NewAggregator(maxTypes int) *Aggregator {
+	return &Aggregator{
+		maxTypes: maxTypes,
+		types:    map[operationKey]map[string]struct{}{},
+		counts:   map[countKey]int64{},
+		start:    time.Now(),
+	}
+}
+
+// RecordSpan counts the exception events of a span that is the entry point of an operation. The span attributes are
+// expected to have been processed by the resolvers so that the environment is set.
+func (a *Aggregator) RecordSpan(span ptrace.Span) {
+	if !isEntrySpan(span) {
+		return
+	}
+	attributes := span.Attributes()
+	service, ok := attributes.Get(attr.AWSLocalService)
+	if !ok {
+		return
+	}
+	operation, ok := attributes.Get(attr.AWSLocalOperation)
+	if !ok {
+		return
+	}
+	key := operationKey{service: service.Str(), operation: operation.Str()}
+	if environment, ok := attributes.Get(attr.AWSLocalEnvironment); ok {
+		key.environment = environment.Str()
+	}
+
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		if event.Name() != exceptionEventName {
+			continue
+		}
+		exceptionType := unknownExceptionType
+		if val, ok := event.Attributes().Get(semconv.AttributeExceptionType); ok && val.Str() != "" {
+			exceptionType = val.Str()
+		}
+		a.add(key, exceptionType)
+	}
+}
+
+func (a *Aggregator) add(key operationKey, exceptionType string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	types, ok := a.types[key]
+	if !ok {
+		types = map[string]struct{}{}
+		a.types[key] = types
+	}
+	if _, ok := types[exceptionType]; !ok {
+		if len(types) >= a.maxTypes {
+			exceptionType = appsignalsconfig.DefaultOtherExceptionType
+		} else {
+			types[exceptionType] = struct{}{}
+		}
+	}
+	a.counts[countKey{operationKey: key, exceptionType: exceptionType}]++
+}
+
+// AppendTo adds the exception counts since the last call to the metrics as a delta sum.
+func (a *Aggregator) AppendTo(md pmetric.Metrics) {
+	a.mu.Lock()
+	counts := a.counts
+	start, end := a.start, time.Now()
+	a.counts = map[countKey]int64{}
+	a.start = end
+	a.mu.Unlock()
+	if len(counts) == 0 {
+		return
+	}
+
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(MetricName)
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.SetIsMonotonic(true)
+	for key, count := range counts {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(end))
+		dp.SetIntValue(count)
+		attributes := dp.Attributes()
+		attributes.PutStr(attr.AWSLocalService, key.service)
+		attributes.PutStr(attr.AWSLocalOperation, key.operation)
+		if key.environment != "" {
+			attributes.PutStr(attr.AWSLocalEnvironment, key.environment)
+		}
+		attributes.PutStr(common.CWMetricAttributeExceptionType, key.exceptionType)
+		attributes.PutStr(common.MetricAttributeTelemetrySource, TelemetrySource)
+	}
+}
+
+// isEntrySpan checks for the spans that Fault and Error are reported for, so that an exception recorded on several
+// spans of the same operation is only counted once.
+func isEntrySpan(span ptrace.Span) bool {
+	if val, ok := span.Attributes().Get(attr.AWSSpanKind); ok {
+		return val.Str() == "SERVER" || val.Str() == "LOCAL_ROOT"
+	}
+	return span.Kind() == ptrace.SpanKindServer || span.ParentSpanID().IsEmpty()
+}
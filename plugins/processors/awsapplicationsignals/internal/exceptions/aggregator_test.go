@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exceptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	appsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+func newSpan(kind string, operation string, exceptionTypes ...string) ptrace.Span {
+	span := ptrace.NewSpan()
+	span.SetParentSpanID([8]byte{1})
+	span.Attributes().PutStr(attr.AWSSpanKind, kind)
+	span.Attributes().PutStr(attr.AWSLocalService, "checkout")
+	span.Attributes().PutStr(attr.AWSLocalEnvironment, "ec2:default")
+	span.Attributes().PutStr(attr.AWSLocalOperation, operation)
+	span.Events().AppendEmpty().SetName("log")
+	for _, exceptionType := range exceptionTypes {
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		if exceptionType != "" {
+			event.Attributes().PutStr(semconv.AttributeExceptionType, exceptionType)
+		}
+	}
+	return span
+}
+
+func TestAggregator(t *testing.T) {
+	a := NewAggregator(2)
+	a.RecordSpan(newSpan("SERVER", "GET /cart", "java.io.IOException", "java.io.IOException", ""))
+	a.RecordSpan(newSpan("LOCAL_ROOT", "GET /cart", "java.lang.IllegalStateException"))
+	// recorded on the server span already
+	a.RecordSpan(newSpan("CLIENT", "GET /cart", "java.io.IOException"))
+	a.RecordSpan(newSpan("SERVER", "POST /cart", "java.lang.NullPointerException"))
+
+	md := pmetric.NewMetrics()
+	a.AppendTo(md)
+	require.Equal(t, 1, md.MetricCount())
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, MetricName, m.Name())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+
+	got := map[[2]string]int64{}
+	dps := m.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		attributes := dp.Attributes().AsRaw()
+		assert.Equal(t, "checkout", attributes[attr.AWSLocalService])
+		assert.Equal(t, "ec2:default", attributes[attr.AWSLocalEnvironment])
+		assert.Equal(t, TelemetrySource, attributes[common.MetricAttributeTelemetrySource])
+		got[[2]string{attributes[attr.AWSLocalOperation].(string), attributes[common.CWMetricAttributeExceptionType].(string)}] = dp.IntValue()
+	}
+	assert.Equal(t, map[[2]string]int64{
+		{"GET /cart", "java.io.IOException"}:                      2,
+		{"GET /cart", unknownExceptionType}:                       1,
+		{"GET /cart", appsignalsconfig.DefaultOtherExceptionType}: 1,
+		{"POST /cart", "java.lang.NullPointerException"}:          1,
+	}, got)
+
+	// counts are reset, the exception types stay capped
+	md = pmetric.NewMetrics()
+	a.AppendTo(md)
+	assert.Equal(t, 0, md.MetricCount())
+	a.RecordSpan(newSpan("SERVER", "GET /cart", "java.lang.IllegalStateException"))
+	a.AppendTo(md)
+	require.Equal(t, 1, md.MetricCount())
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	exceptionType, _ := dp.Attributes().Get(common.CWMetricAttributeExceptionType)
+	assert.Equal(t, appsignalsconfig.DefaultOtherExceptionType, exceptionType.Str())
+}
+
+func TestAggregatorSpanKind(t *testing.T) {
+	a := NewAggregator(10)
+	span := ptrace.NewSpan()
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetParentSpanID([8]byte{1})
+	span.Attributes().PutStr(attr.AWSLocalService, "checkout")
+	span.Attributes().PutStr(attr.AWSLocalOperation, "GET /cart")
+	span.Events().AppendEmpty().SetName("exception")
+	a.RecordSpan(span)
+	// missing the operation
+	noOperation := ptrace.NewSpan()
+	noOperation.Attributes().PutStr(attr.AWSLocalService, "checkout")
+	noOperation.Events().AppendEmpty().SetName("exception")
+	a.RecordSpan(noOperation)
+
+	md := pmetric.NewMetrics()
+	a.AppendTo(md)
+	require.Equal(t, 1, md.DataPointCount())
+	attributes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes()
+	_, ok := attributes.Get(attr.AWSLocalEnvironment)
+	assert.False(t, ok)
+}
+
+func TestGet(t *testing.T) {
+	cfg := appsignalsconfig.NewDefaultExceptionMetricsConfig()
+	id := component.MustNewIDWithName("awsapplicationsignals", "exceptions")
+	a := Get(id, cfg)
+	assert.Same(t, a, Get(id, cfg))
+	assert.NotSame(t, a, Get(component.MustNewID("awsapplicationsignals"), cfg))
+	assert.Equal(t, appsignalsconfig.DefaultMaxExceptionTypes, a.maxTypes)
+}
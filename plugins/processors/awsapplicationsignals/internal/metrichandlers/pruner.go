@@ -15,7 +15,7 @@ import (
 type Pruner struct {
 }
 
-func (p *Pruner) ShouldBeDropped(attributes pcommon.Map) (bool, error) {
+func (p *Pruner) ShouldBeDropped(attributes, _ pcommon.Map) (bool, error) {
 	for _, attributeKey := range common.CWMetricAttributes {
 		if val, ok := attributes.Get(attributeKey); ok {
 			if !isAsciiPrintable(val.Str()) {
@@ -47,7 +47,7 @@ func TestMetricPrunerWithIndexableAttribute(t *testing.T) {
 		attributes.PutStr(common.MetricAttributeTelemetrySource, "UnitTest")
 		attributes.PutStr(common.CWMetricAttributeLocalService, tt.val)
 		t.Run(tt.name, func(t *testing.T) {
-			got, _ := p.ShouldBeDropped(attributes)
+			got, _ := p.ShouldBeDropped(attributes, pcommon.NewMap())
 			if got != tt.want {
 				t.Errorf("ShouldBeDropped() got = %v, want %v", got, tt.want)
 			}
@@ -78,7 +78,7 @@ func TestMetricPrunerWithNonIndexableAttribute(t *testing.T) {
 		attributes.PutStr(common.MetricAttributeTelemetrySource, "UnitTest")
 		attributes.PutStr(common.AttributeEC2InstanceId, tt.val)
 		t.Run(tt.name, func(t *testing.T) {
-			got, _ := p.ShouldBeDropped(attributes)
+			got, _ := p.ShouldBeDropped(attributes, pcommon.NewMap())
 			if got != tt.want {
 				t.Errorf("ShouldBeDropped() got = %v, want %v", got, tt.want)
 			}
@@ -104,7 +104,7 @@ func TestMetricPrunerWithNoTelemetrySourceAttribute(t *testing.T) {
 		attributes := pcommon.NewMap()
 		attributes.PutStr(common.AttributeEC2InstanceId, tt.val)
 		t.Run(tt.name, func(t *testing.T) {
-			got, _ := p.ShouldBeDropped(attributes)
+			got, _ := p.ShouldBeDropped(attributes, pcommon.NewMap())
 			if got != tt.want {
 				t.Errorf("ShouldBeDropped() got = %v, want %v", got, tt.want)
 			}
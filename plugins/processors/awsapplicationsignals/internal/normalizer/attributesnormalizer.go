@@ -44,6 +44,11 @@ var attributesRenamingForMetric = map[string]string{
 	attr.AWSRemoteResourceCfnPrimaryIdentifier: common.MetricAttributeRemoteResourceCfnPrimaryIdentifier,
 	attr.AWSECSClusterName:                     common.MetricAttributeECSCluster,
 	attr.AWSECSTaskID:                          common.MetricAttributeECSTaskId,
+	attr.AWSECSServiceName:                     common.MetricAttributeECSService,
+	attr.AWSECSLaunchType:                      common.MetricAttributeECSLaunchType,
+	semconv.AttributeFaaSName:                  common.MetricAttributeLambdaFunction,
+	semconv.AttributeFaaSVersion:               common.MetricAttributeLambdaFunctionVersion,
+	semconv.AttributeFaaSMaxMemory:             common.MetricAttributeLambdaMaxMemory,
 }
 
 var resourceAttributesRenamingForTrace = map[string]string{
@@ -92,6 +97,7 @@ func NewAttributesNormalizer(logger *zap.Logger) *attributesNormalizer {
 
 func (n *attributesNormalizer) Process(attributes, resourceAttributes pcommon.Map, isTrace bool) error {
 	n.copyResourceAttributesToAttributes(attributes, resourceAttributes, isTrace)
+	normalizeDatabaseAttributes(attributes, isTrace)
 	truncateAttributesByLength(attributes)
 	n.renameAttributes(attributes, resourceAttributes, isTrace)
 	n.normalizeTelemetryAttributes(attributes, resourceAttributes, isTrace)
@@ -0,0 +1,250 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package normalizer
+
+import (
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	deprecatedsemconv "go.opentelemetry.io/collector/semconv/v1.18.0"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+const (
+	unknownRemoteService   = "UnknownRemoteService"
+	unknownRemoteOperation = "UnknownRemoteOperation"
+)
+
+var (
+	// a list of placeholders, e.g. "IN (?, ?, ?)", collapses to one so the statement doesn't vary with the list length
+	placeholderListRegexp = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+
+	// the keyword preceding the table name for each statement verb
+	tableKeywords = map[string]string{
+		"SELECT":   "FROM",
+		"DELETE":   "FROM",
+		"INSERT":   "INTO",
+		"REPLACE":  "INTO",
+		"MERGE":    "INTO",
+		"UPDATE":   "",
+		"CREATE":   "TABLE",
+		"DROP":     "TABLE",
+		"ALTER":    "TABLE",
+		"TRUNCATE": "TABLE",
+	}
+
+	tableAttributes = []string{semconv.AttributeDBSQLTable, semconv.AttributeDBMongoDBCollection, semconv.AttributeDBCassandraTable}
+)
+
+// normalizeDatabaseAttributes derives the remote service and operation of a database client call from its db.*
+// attributes, so that they are the host of the database and the verb and table of the statement, e.g. "SELECT users",
+// rather than the raw statement. The statement is removed from metrics and sanitized on traces.
+func normalizeDatabaseAttributes(attributes pcommon.Map, isTrace bool) {
+	dbSystem, ok := attributes.Get(semconv.AttributeDBSystem)
+	if !ok || dbSystem.Str() == "" {
+		return
+	}
+	var statement string
+	if val, ok := attributes.Get(semconv.AttributeDBStatement); ok {
+		statement = val.Str()
+	}
+
+	if val, ok := attributes.Get(attr.AWSRemoteService); !ok || val.Str() == "" || val.Str() == unknownRemoteService {
+		remoteService := dbSystem.Str()
+		if host := databaseHost(attributes); host != "" {
+			remoteService = host
+		}
+		attributes.PutStr(attr.AWSRemoteService, remoteService)
+	}
+
+	sanitized := sanitizeStatement(statement)
+	if val, ok := attributes.Get(attr.AWSRemoteOperation); !ok || val.Str() == "" || val.Str() == unknownRemoteOperation || (statement != "" && val.Str() == statement) {
+		if operation := databaseOperation(attributes, sanitized); operation != "" {
+			attributes.PutStr(attr.AWSRemoteOperation, operation)
+		} else if ok && val.Str() == statement {
+			attributes.PutStr(attr.AWSRemoteOperation, unknownRemoteOperation)
+		}
+	}
+
+	if statement == "" {
+		return
+	}
+	if isTrace {
+		attributes.PutStr(semconv.AttributeDBStatement, sanitized)
+	} else {
+		attributes.Remove(semconv.AttributeDBStatement)
+	}
+}
+
+func databaseHost(attributes pcommon.Map) string {
+	for _, key := range []string{attr.ServerAddress, deprecatedsemconv.AttributeNetPeerName} {
+		if val, ok := attributes.Get(key); ok && val.Str() != "" {
+			return val.Str()
+		}
+	}
+	return ""
+}
+
+// databaseOperation prefers the db.operation and table attributes set by the instrumentation over parsing the
+// statement.
+func databaseOperation(attributes pcommon.Map, statement string) string {
+	verb, table := parseStatement(statement)
+	if val, ok := attributes.Get(semconv.AttributeDBOperation); ok && val.Str() != "" {
+		verb = strings.ToUpper(val.Str())
+	}
+	for _, key := range tableAttributes {
+		if val, ok := attributes.Get(key); ok && val.Str() != "" {
+			table = val.Str()
+			break
+		}
+	}
+	if verb == "" {
+		return ""
+	}
+	if table == "" {
+		return verb
+	}
+	return verb + " " + table
+}
+
+// parseStatement returns the verb of a sanitized statement and the table it operates on, if any.
+func parseStatement(statement string) (string, string) {
+	tokens := strings.Fields(statement)
+	if len(tokens) == 0 {
+		return "", ""
+	}
+	start := 0
+	// the verb of a common table expression is the first one after the closing parenthesis of its queries
+	if strings.EqualFold(tokens[0], "WITH") {
+		for i := 1; i < len(tokens); i++ {
+			if _, ok := tableKeywords[strings.ToUpper(tokens[i])]; ok && strings.HasSuffix(tokens[i-1], ")") {
+				start = i
+				break
+			}
+		}
+	}
+	verb := strings.ToUpper(tokens[start])
+	if !isWord(verb) {
+		return "", ""
+	}
+	keyword, ok := tableKeywords[verb]
+	if !ok {
+		return verb, ""
+	}
+	for i := start + 1; i < len(tokens); i++ {
+		if keyword != "" && !strings.EqualFold(tokens[i], keyword) {
+			continue
+		}
+		j := i + 1
+		if keyword == "" {
+			j = i
+		}
+		// skip modifiers like IF NOT EXISTS, IGNORE or ONLY
+		for j < len(tokens) && isTableModifier(tokens[j]) {
+			j++
+		}
+		if j < len(tokens) {
+			return verb, tableName(tokens[j])
+		}
+		break
+	}
+	return verb, ""
+}
+
+func isTableModifier(token string) bool {
+	switch strings.ToUpper(token) {
+	case "IF", "NOT", "EXISTS", "IGNORE", "ONLY", "LOW_PRIORITY", "TEMPORARY":
+		return true
+	}
+	return false
+}
+
+func isWord(token string) bool {
+	for _, r := range token {
+		if (r < 'A' || r > 'Z') && r != '_' {
+			return false
+		}
+	}
+	return token != ""
+}
+
+// tableName strips the quoting and anything following the name, e.g. the column list of an insert. Subqueries
+// have no table name.
+func tableName(token string) string {
+	if strings.HasPrefix(token, "(") {
+		return ""
+	}
+	if i := strings.IndexAny(token, "(,;"); i >= 0 {
+		token = token[:i]
+	}
+	return strings.Trim(strings.NewReplacer("`", "", `"`, "", "[", "", "]", "").Replace(token), ".")
+}
+
+// sanitizeStatement replaces the string and numeric literals of a statement with ? and removes its comments, so
+// that it neither leaks the values it was run with nor varies with them.
+func sanitizeStatement(statement string) string {
+	var b strings.Builder
+	b.Grow(len(statement))
+	space := false
+	writeSpace := func() {
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+	}
+	for i := 0; i < len(statement); i++ {
+		c := statement[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+		case c == '-' && i+1 < len(statement) && statement[i+1] == '-':
+			for i < len(statement) && statement[i] != '\n' {
+				i++
+			}
+			space = true
+		case c == '/' && i+1 < len(statement) && statement[i+1] == '*':
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				i = len(statement)
+			} else {
+				i += end + 3
+			}
+			space = true
+		case c == '\'':
+			// a quote in a string literal is escaped by doubling it
+			for i++; i < len(statement); i++ {
+				if statement[i] == '\'' {
+					if i+1 < len(statement) && statement[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			writeSpace()
+			b.WriteByte('?')
+		case isDigit(c) && (i == 0 || !isIdentifier(statement[i-1])):
+			for i+1 < len(statement) && (isIdentifier(statement[i+1]) || statement[i+1] == '.') {
+				i++
+			}
+			writeSpace()
+			b.WriteByte('?')
+		default:
+			writeSpace()
+			b.WriteByte(c)
+		}
+	}
+	return placeholderListRegexp.ReplaceAllString(b.String(), "(?)")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifier(c byte) bool {
+	return isDigit(c) || c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
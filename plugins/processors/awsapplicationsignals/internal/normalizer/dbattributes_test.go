@@ -0,0 +1,141 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package normalizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	deprecatedsemconv "go.opentelemetry.io/collector/semconv/v1.18.0"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+func TestSanitizeStatement(t *testing.T) {
+	testCases := map[string]string{
+		"SELECT * FROM users WHERE id = 42":                            "SELECT * FROM users WHERE id = ?",
+		"select name from users where email = 'a''b@example.com'":      "select name from users where email = ?",
+		"SELECT * FROM t1 WHERE id IN (1, 2, 3) AND x = -1.5e3":        "SELECT * FROM t1 WHERE id IN (?) AND x = -?",
+		"UPDATE orders SET total = 9.99 -- retry\nWHERE id = 0x1F":     "UPDATE orders SET total = ? WHERE id = ?",
+		"/* app=web */ DELETE   FROM\tsessions WHERE expires < '2024'": "DELETE FROM sessions WHERE expires < ?",
+		"INSERT INTO logs (msg) VALUES ('unterminated":                 "INSERT INTO logs (msg) VALUES (?",
+	}
+	for statement, want := range testCases {
+		assert.Equal(t, want, sanitizeStatement(statement), statement)
+	}
+}
+
+func TestParseStatement(t *testing.T) {
+	testCases := map[string][2]string{
+		"SELECT * FROM users WHERE id = ?":                         {"SELECT", "users"},
+		"select count(*) from `shop`.`orders`":                     {"SELECT", "shop.orders"},
+		"INSERT INTO logs(msg, level) VALUES (?)":                  {"INSERT", "logs"},
+		"INSERT IGNORE INTO logs VALUES (?)":                       {"INSERT", "logs"},
+		"UPDATE \"Accounts\" SET balance = ?":                      {"UPDATE", "Accounts"},
+		"DELETE FROM [dbo].[sessions]":                             {"DELETE", "dbo.sessions"},
+		"CREATE TABLE IF NOT EXISTS events (id int)":               {"CREATE", "events"},
+		"WITH recent AS (SELECT * FROM a) SELECT * FROM recent, b": {"SELECT", "recent"},
+		"SELECT * FROM (SELECT ? FROM dual) t":                     {"SELECT", ""},
+		"BEGIN":                                                    {"BEGIN", ""},
+		"GET session:?":                                            {"GET", ""},
+		"? + ?":                                                    {"", ""},
+		"":                                                         {"", ""},
+	}
+	for statement, want := range testCases {
+		verb, table := parseStatement(statement)
+		assert.Equal(t, want, [2]string{verb, table}, statement)
+	}
+}
+
+func TestNormalizeDatabaseAttributes(t *testing.T) {
+	testCases := map[string]struct {
+		attributes map[string]any
+		isTrace    bool
+		want       map[string]any
+	}{
+		"NotDatabase": {
+			attributes: map[string]any{semconv.AttributeDBStatement: "SELECT 1"},
+			want:       map[string]any{semconv.AttributeDBStatement: "SELECT 1"},
+		},
+		"Metric": {
+			attributes: map[string]any{
+				semconv.AttributeDBSystem:    "mysql",
+				semconv.AttributeDBStatement: "SELECT * FROM users WHERE id = 42",
+				attr.ServerAddress:           "db.example.com",
+				attr.AWSRemoteOperation:      "SELECT * FROM users WHERE id = 42",
+			},
+			want: map[string]any{
+				semconv.AttributeDBSystem: "mysql",
+				attr.ServerAddress:        "db.example.com",
+				attr.AWSRemoteService:     "db.example.com",
+				attr.AWSRemoteOperation:   "SELECT users",
+			},
+		},
+		"Trace": {
+			attributes: map[string]any{
+				semconv.AttributeDBSystem:              "postgresql",
+				semconv.AttributeDBStatement:           "UPDATE accounts SET balance = 10 WHERE id = 'abc'",
+				deprecatedsemconv.AttributeNetPeerName: "pg.internal",
+				attr.AWSRemoteService:                  unknownRemoteService,
+			},
+			isTrace: true,
+			want: map[string]any{
+				semconv.AttributeDBSystem:              "postgresql",
+				semconv.AttributeDBStatement:           "UPDATE accounts SET balance = ? WHERE id = ?",
+				deprecatedsemconv.AttributeNetPeerName: "pg.internal",
+				attr.AWSRemoteService:                  "pg.internal",
+				attr.AWSRemoteOperation:                "UPDATE accounts",
+			},
+		},
+		"OperationAttributes": {
+			attributes: map[string]any{
+				semconv.AttributeDBSystem:            "mongodb",
+				semconv.AttributeDBOperation:         "find",
+				semconv.AttributeDBMongoDBCollection: "carts",
+			},
+			want: map[string]any{
+				semconv.AttributeDBSystem:            "mongodb",
+				semconv.AttributeDBOperation:         "find",
+				semconv.AttributeDBMongoDBCollection: "carts",
+				attr.AWSRemoteService:                "mongodb",
+				attr.AWSRemoteOperation:              "FIND carts",
+			},
+		},
+		"KeepsExisting": {
+			attributes: map[string]any{
+				semconv.AttributeDBSystem:    "mysql",
+				semconv.AttributeDBStatement: "SELECT 1",
+				attr.AWSRemoteService:        "orders-db",
+				attr.AWSRemoteOperation:      "HealthCheck",
+			},
+			want: map[string]any{
+				semconv.AttributeDBSystem: "mysql",
+				attr.AWSRemoteService:     "orders-db",
+				attr.AWSRemoteOperation:   "HealthCheck",
+			},
+		},
+		"UnparsableStatement": {
+			attributes: map[string]any{
+				semconv.AttributeDBSystem:    "other_sql",
+				semconv.AttributeDBStatement: "{call refresh(1)}",
+				attr.AWSRemoteOperation:      "{call refresh(1)}",
+			},
+			want: map[string]any{
+				semconv.AttributeDBSystem: "other_sql",
+				attr.AWSRemoteService:     "other_sql",
+				attr.AWSRemoteOperation:   unknownRemoteOperation,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes := pcommon.NewMap()
+			assert.NoError(t, attributes.FromRaw(testCase.attributes))
+			normalizeDatabaseAttributes(attributes, testCase.isTrace)
+			assert.Equal(t, testCase.want, attributes.AsRaw())
+		})
+	}
+}
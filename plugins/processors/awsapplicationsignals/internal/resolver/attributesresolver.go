@@ -25,6 +25,7 @@ const (
 	AttributePlatformEKS     = "AWS::EKS"
 	AttributePlatformECS     = "AWS::ECS"
 	AttributePlatformK8S     = "K8s"
+	AttributePlatformLambda  = "AWS::Lambda"
 )
 
 var GenericInheritedAttributes = map[string]string{
@@ -45,6 +46,12 @@ type subResolver interface {
 	Stop(ctx context.Context) error
 }
 
+// readiness is implemented by the sub resolvers depending on a backend, like the kubernetes API, which cannot resolve
+// the attributes until the backend has been reached.
+type readiness interface {
+	Ready() bool
+}
+
 type attributesResolver struct {
 	subResolvers []subResolver
 }
@@ -55,15 +62,19 @@ func NewAttributesResolver(resolvers []appsignalsconfig.Resolver, logger *zap.Lo
 	for _, resolver := range resolvers {
 		switch resolver.Platform {
 		case appsignalsconfig.PlatformEKS, appsignalsconfig.PlatformK8s:
-			subResolvers = append(subResolvers, getKubernetesResolver(resolver.Platform, resolver.Name, logger), newKubernetesResourceAttributesResolver(resolver.Platform, resolver.Name))
+			subResolvers = append(subResolvers, getKubernetesResolver(resolver.Platform, resolver.Name, resolver.CacheSize, resolver.CacheTTL, logger), newKubernetesResourceAttributesResolver(resolver.Platform, resolver.Name))
 		case appsignalsconfig.PlatformEC2:
 			subResolvers = append(subResolvers, newResourceAttributesResolver(resolver.Platform, AttributePlatformEC2, DefaultInheritedAttributes))
 		case appsignalsconfig.PlatformECS:
 			subResolvers = append(subResolvers, newECSResourceAttributesResolver(resolver.Platform, resolver.Name))
+		case appsignalsconfig.PlatformLambda:
+			subResolvers = append(subResolvers, newLambdaResourceAttributesResolver(resolver.Name))
 		default:
 			subResolvers = append(subResolvers, newResourceAttributesResolver(resolver.Platform, AttributePlatformGeneric, GenericInheritedAttributes))
 		}
 	}
+	// Lambda functions are invoked from any platform
+	subResolvers = append(subResolvers, newLambdaResolver())
 	return &attributesResolver{
 		subResolvers: subResolvers,
 	}
@@ -79,6 +90,16 @@ func (r *attributesResolver) Process(attributes, resourceAttributes pcommon.Map,
 	return nil
 }
 
+// Ready is false while one of the sub resolvers cannot resolve the attributes yet.
+func (r *attributesResolver) Ready() bool {
+	for _, subResolver := range r.subResolvers {
+		if rd, ok := subResolver.(readiness); ok && !rd.Ready() {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *attributesResolver) Stop(ctx context.Context) error {
 	var errs error
 	for _, subResolver := range r.subResolvers {
@@ -204,3 +204,15 @@ func TestAttributesResolver_Stop(t *testing.T) {
 	mockSubResolver1.AssertExpectations(t)
 	mockSubResolver2.AssertExpectations(t)
 }
+
+func TestAttributesResolverReady(t *testing.T) {
+	k8sResolver := &kubernetesResolver{}
+	resolver := &attributesResolver{subResolvers: []subResolver{
+		newResourceAttributesResolver(config.PlatformGeneric, AttributePlatformGeneric, GenericInheritedAttributes),
+		k8sResolver,
+	}}
+	assert.False(t, resolver.Ready())
+	k8sResolver.synced.Store(true)
+	assert.True(t, resolver.Ready())
+	assert.True(t, (&attributesResolver{}).Ready())
+}
@@ -27,9 +27,21 @@ func (e *ecsResourceAttributesResolver) Process(attributes, resourceAttributes p
 		}
 	}
 
+	ecsUtil := ecsutil.GetECSUtilSingleton()
 	clusterName, taskId := getECSResourcesFromResourceAttributes(resourceAttributes)
 	if clusterName == "" {
-		clusterName = ecsutil.GetECSUtilSingleton().Cluster
+		clusterName = ecsUtil.Cluster
+	}
+	var serviceName string
+	if isAgentTask(resourceAttributes, ecsUtil.TaskARN, ecsUtil.LaunchType) {
+		if taskId == "" {
+			_, taskId = parseTaskARN(ecsUtil.TaskARN)
+		}
+		serviceName = ecsUtil.ServiceName
+	}
+	launchType := strings.ToLower(ecsUtil.LaunchType)
+	if val, ok := resourceAttributes.Get(semconv.AttributeAWSECSLaunchtype); ok {
+		launchType = val.Str()
 	}
 
 	attributes.PutStr(common.AttributePlatformType, e.platformType)
@@ -38,6 +50,12 @@ func (e *ecsResourceAttributesResolver) Process(attributes, resourceAttributes p
 	if taskId != "" {
 		attributes.PutStr(attr.AWSECSTaskID, taskId)
 	}
+	if serviceName != "" {
+		attributes.PutStr(attr.AWSECSServiceName, serviceName)
+	}
+	if launchType != "" {
+		attributes.PutStr(attr.AWSECSLaunchType, launchType)
+	}
 	return nil
 }
 
@@ -86,20 +104,40 @@ func getECSResourcesFromResourceAttributes(resourceAttributes pcommon.Map) (clus
 		clusterName = parts[len(parts)-1]
 	}
 	if taskAttr, ok := resourceAttributes.Get(semconv.AttributeAWSECSTaskARN); ok {
-		parts := strings.SplitAfterN(taskAttr.Str(), ":task/", 2)
-		if len(parts) == 2 {
-			taskParts := strings.Split(parts[1], "/")
-			// New Task ARN format "task/cluster-name/task-id".
-			if len(taskParts) == 2 {
-				taskId = taskParts[1]
-				if clusterName == "" {
-					clusterName = taskParts[0]
-				}
-			} else if len(taskParts) == 1 {
-				// Legacy Task ARN format "task/task-id".
-				taskId = taskParts[0]
-			}
+		var taskClusterName string
+		taskClusterName, taskId = parseTaskARN(taskAttr.Str())
+		if clusterName == "" {
+			clusterName = taskClusterName
+		}
+	}
+	return
+}
+
+// parseTaskARN returns the cluster name and task ID of a task ARN. The cluster name is empty for the legacy format.
+func parseTaskARN(taskARN string) (clusterName, taskId string) {
+	parts := strings.SplitAfterN(taskARN, ":task/", 2)
+	if len(parts) == 2 {
+		taskParts := strings.Split(parts[1], "/")
+		// New Task ARN format "task/cluster-name/task-id".
+		if len(taskParts) == 2 {
+			clusterName, taskId = taskParts[0], taskParts[1]
+		} else if len(taskParts) == 1 {
+			// Legacy Task ARN format "task/task-id".
+			taskId = taskParts[0]
 		}
 	}
 	return
 }
+
+// isAgentTask returns whether the telemetry comes from the task of the agent, whose service name and task ID the task
+// metadata endpoint gives. That is the case when the telemetry has the task ARN of the agent, or, when it has no task
+// ARN, on Fargate, where the agent can only run as a sidecar of the service.
+func isAgentTask(resourceAttributes pcommon.Map, agentTaskARN, agentLaunchType string) bool {
+	if agentTaskARN == "" {
+		return false
+	}
+	if taskAttr, ok := resourceAttributes.Get(semconv.AttributeAWSECSTaskARN); ok && taskAttr.Str() != "" {
+		return taskAttr.Str() == agentTaskARN
+	}
+	return strings.EqualFold(agentLaunchType, semconv.AttributeAWSECSLaunchtypeFargate)
+}
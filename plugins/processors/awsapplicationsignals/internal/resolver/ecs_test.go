@@ -84,6 +84,80 @@ func TestResourceAttributesResolverWithECSClusterName(t *testing.T) {
 	ecsutil.GetECSUtilSingleton().Cluster = ""
 }
 
+func TestResourceAttributesResolverWithECSTaskMetadata(t *testing.T) {
+	const agentTaskARN = "arn:aws:ecs:us-west-1:123456789123:task/my-cluster/10838bed-421f-43ef-870a-f43feacbbb5b"
+	testCases := []struct {
+		name               string
+		ecsTaskArn         string
+		ecsLaunchType      string
+		agentLaunchType    string
+		expectedTaskId     string
+		expectedService    string
+		expectedLaunchType string
+	}{
+		{
+			name:               "testFargateSidecar",
+			agentLaunchType:    "FARGATE",
+			expectedTaskId:     "10838bed-421f-43ef-870a-f43feacbbb5b",
+			expectedService:    "my-service",
+			expectedLaunchType: "fargate",
+		},
+		{
+			name:               "testEC2WithoutTaskArn",
+			agentLaunchType:    "EC2",
+			expectedLaunchType: "ec2",
+		},
+		{
+			name:               "testEC2SameTask",
+			ecsTaskArn:         agentTaskARN,
+			agentLaunchType:    "EC2",
+			expectedTaskId:     "10838bed-421f-43ef-870a-f43feacbbb5b",
+			expectedService:    "my-service",
+			expectedLaunchType: "ec2",
+		},
+		{
+			name:               "testEC2OtherTask",
+			ecsTaskArn:         "arn:aws:ecs:us-west-1:123456789123:task/my-cluster/other-task",
+			ecsLaunchType:      "ec2",
+			agentLaunchType:    "EC2",
+			expectedTaskId:     "other-task",
+			expectedLaunchType: "ec2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ecsUtil := ecsutil.GetECSUtilSingleton()
+			ecsUtil.Cluster = "my-cluster"
+			ecsUtil.TaskARN = agentTaskARN
+			ecsUtil.ServiceName = "my-service"
+			ecsUtil.LaunchType = tc.agentLaunchType
+			resolver := newECSResourceAttributesResolver(appsignalsconfig.PlatformECS, "")
+
+			attributes := pcommon.NewMap()
+			resourceAttributes := pcommon.NewMap()
+			if tc.ecsTaskArn != "" {
+				resourceAttributes.PutStr(semconv.AttributeAWSECSTaskARN, tc.ecsTaskArn)
+			}
+			if tc.ecsLaunchType != "" {
+				resourceAttributes.PutStr(semconv.AttributeAWSECSLaunchtype, tc.ecsLaunchType)
+			}
+
+			assert.NoError(t, resolver.Process(attributes, resourceAttributes))
+
+			attribute, ok := attributes.Get(attr.AWSECSClusterName)
+			assert.True(t, ok)
+			assert.Equal(t, "my-cluster", attribute.Str())
+			assert.Equal(t, tc.expectedTaskId, getStr(attributes, attr.AWSECSTaskID))
+			assert.Equal(t, tc.expectedService, getStr(attributes, attr.AWSECSServiceName))
+			assert.Equal(t, tc.expectedLaunchType, getStr(attributes, attr.AWSECSLaunchType))
+			assert.Equal(t, "ecs:my-cluster", getStr(attributes, attr.AWSLocalEnvironment))
+		})
+	}
+	ecsUtil := ecsutil.GetECSUtilSingleton()
+	ecsUtil.Cluster, ecsUtil.TaskARN, ecsUtil.ServiceName, ecsUtil.LaunchType = "", "", "", ""
+}
+
 func TestGetClusterName(t *testing.T) {
 	resourceAttributes := pcommon.NewMap()
 	resourceAttributes.PutStr(semconv.AttributeAWSECSClusterARN, "arn:aws:ecs:us-west-2:123456789123:cluster/my-cluster")
@@ -73,11 +73,13 @@ func (w *endpointSliceWatcher) Run(stopCh chan struct{}) {
 	go w.informer.Run(stopCh)
 }
 
-func (w *endpointSliceWatcher) waitForCacheSync(stopCh chan struct{}) {
+func (w *endpointSliceWatcher) waitForCacheSync(stopCh chan struct{}) bool {
 	if !cache.WaitForNamedCacheSync("endpointSliceWatcher", stopCh, w.informer.HasSynced) {
-		w.logger.Fatal("timed out waiting for endpointSliceWatcher cache to sync")
+		w.logger.Info("endpointSliceWatcher: Stopped before the cache synced")
+		return false
 	}
 	w.logger.Info("endpointSliceWatcher: Cache synced")
+	return true
 }
 
 // extractEndpointSliceKeyValuePairs computes the relevant mappings from an EndpointSlice.
@@ -163,11 +165,7 @@ func (w *endpointSliceWatcher) handleSliceAdd(obj interface{}) {
 	// Insert them into our ipToWorkload / serviceToWorkload, and track the keys.
 	keys := make([]string, 0, len(pairs))
 	for _, kv := range pairs {
-		if kv.isService {
-			w.serviceToWorkload.Store(kv.key, kv.value)
-		} else {
-			w.ipToWorkload.Store(kv.key, kv.value)
-		}
+		w.storePair(kv)
 		keys = append(keys, kv.key)
 	}
 
@@ -178,8 +176,8 @@ func (w *endpointSliceWatcher) handleSliceAdd(obj interface{}) {
 // handleSliceUpdate handles an update from oldSlice -> newSlice.
 // Instead of blindly removing all old keys and adding new ones, it diffs them:
 //   - remove only keys that no longer exist,
-//   - add only new keys that didn't exist before,
-//   - keep those that haven't changed.
+//   - store the others again, whether or not they existed before, which keeps
+//     those that haven't changed past the TTL of the deleter.
 func (w *endpointSliceWatcher) handleSliceUpdate(oldObj, newObj interface{}) {
 	oldSlice := oldObj.(*discv1.EndpointSlice)
 	newSlice := newObj.(*discv1.EndpointSlice)
@@ -218,16 +216,10 @@ func (w *endpointSliceWatcher) handleSliceUpdate(oldObj, newObj interface{}) {
 		}
 	}
 
-	// 4) For each key in newKeys that wasn't in oldKeys, we need to store it
-	//    in the appropriate sync.Map. We'll look up the value from newPairs.
+	// 4) Store every key in newKeys in the appropriate sync.Map, including those
+	//    that were in oldKeys, so that they are kept past the TTL of the deleter.
 	for _, kv := range newPairs {
-		if _, alreadyHad := oldKeysSet[kv.key]; !alreadyHad {
-			if kv.isService {
-				w.serviceToWorkload.Store(kv.key, kv.value)
-			} else {
-				w.ipToWorkload.Store(kv.key, kv.value)
-			}
-		}
+		w.storePair(kv)
 	}
 
 	// 5) Update sliceToKeysMap for the new slice UID
@@ -236,9 +228,22 @@ func (w *endpointSliceWatcher) handleSliceUpdate(oldObj, newObj interface{}) {
 	w.sliceToKeysMap.Store(newUID, newKeys)
 }
 
+// storePair stores one mapping in ipToWorkload or serviceToWorkload.
+func (w *endpointSliceWatcher) storePair(kv kvPair) {
+	if kv.isService {
+		w.deleter.Store(w.serviceToWorkload, kv.key, kv.value)
+	} else {
+		w.deleter.Store(w.ipToWorkload, kv.key, kv.value)
+	}
+}
+
 // handleSliceDelete removes any IP->workload or service->workload keys that were created by this slice.
 func (w *endpointSliceWatcher) handleSliceDelete(obj interface{}) {
-	slice := obj.(*discv1.EndpointSlice)
+	slice, ok := deletedObject[*discv1.EndpointSlice](obj)
+	if !ok {
+		w.logger.Error("failed to get deleted EndpointSlice", zap.Any("object", obj))
+		return
+	}
 	w.removeSliceKeys(slice)
 }
 
@@ -16,6 +16,7 @@ import (
 	discv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 )
 
 func newEndpointSliceWatcherForTest() *endpointSliceWatcher {
@@ -148,6 +149,24 @@ func TestEndpointSliceDeletion(t *testing.T) {
 	assert.False(t, ok, "expected sliceToKeysMap entry for UID %s to be deleted", slice.UID)
 }
 
+// TestEndpointSliceDeletionMissed verifies that the keys are removed when the deletion
+// of the EndpointSlice was missed by the watch, and only found when the informer relisted.
+func TestEndpointSliceDeletionMissed(t *testing.T) {
+	watcher := newEndpointSliceWatcherForTest()
+
+	slice := createTestEndpointSlice("uid-1", "testns", "mysvc", "workload-76977669dc-lwx64", []string{"1.2.3.4"}, []int32{80})
+	watcher.handleSliceAdd(slice)
+
+	watcher.handleSliceDelete(cache.DeletedFinalStateUnknown{Key: "testns/mysvc", Obj: slice})
+
+	for _, key := range []string{"1.2.3.4", "1.2.3.4:80", "mysvc@testns"} {
+		_, ok := watcher.ipToWorkload.Load(key)
+		_, okSvc := watcher.serviceToWorkload.Load(key)
+		assert.False(t, ok, "expected ipToWorkload key %s to be deleted", key)
+		assert.False(t, okSvc, "expected serviceToWorkload key %s to be deleted", key)
+	}
+}
+
 // TestEndpointSliceUpdate verifies that on updates, keys are added and/or removed as appropriate.
 func TestEndpointSliceUpdate(t *testing.T) {
 	// --- Subtest: Complete change (no overlap) ---
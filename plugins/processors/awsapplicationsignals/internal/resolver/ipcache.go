@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+)
+
+// maxNegativeCacheTTL bounds how long an IP that cannot be resolved is cached, so that the workload of a new pod is
+// picked up soon after its endpoints are watched.
+const maxNegativeCacheTTL = 10 * time.Second
+
+type workloadLookup struct {
+	workload  string
+	namespace string
+	found     bool
+}
+
+// ipCache caches the workload and namespace resolved for an IP, including the IPs that cannot be resolved, so that
+// the resolver does not search the watcher maps for every datapoint. It is shared by the metric and trace processors.
+type ipCache struct {
+	cache       *ttlcache.Cache[string, workloadLookup]
+	negativeTTL time.Duration
+}
+
+func newIPCache(size int, ttl time.Duration) *ipCache {
+	if size == 0 {
+		size = config.DefaultCacheSize
+	}
+	if ttl == 0 {
+		ttl = config.DefaultCacheTTL
+	}
+	return &ipCache{
+		cache: ttlcache.New[string, workloadLookup](
+			ttlcache.WithTTL[string, workloadLookup](ttl),
+			ttlcache.WithCapacity[string, workloadLookup](uint64(size)),
+			// the lookups expire even if they are hit, so that the changes of the workloads are picked up
+			ttlcache.WithDisableTouchOnHit[string, workloadLookup](),
+		),
+		negativeTTL: min(ttl, maxNegativeCacheTTL),
+	}
+}
+
+// getOrLoad returns the cached lookup of the IP, or loads and caches it.
+func (c *ipCache) getOrLoad(ip string, load func(ip string) (string, string, error)) (string, string, error) {
+	if item := c.cache.Get(ip); item != nil {
+		if lookup := item.Value(); lookup.found {
+			return lookup.workload, lookup.namespace, nil
+		}
+		return "", "", errors.New("no kubernetes workload found for ip: " + ip)
+	}
+	workload, namespace, err := load(ip)
+	if err != nil {
+		c.cache.Set(ip, workloadLookup{}, c.negativeTTL)
+		return "", "", err
+	}
+	c.cache.Set(ip, workloadLookup{workload: workload, namespace: namespace, found: true}, ttlcache.DefaultTTL)
+	return workload, namespace, nil
+}
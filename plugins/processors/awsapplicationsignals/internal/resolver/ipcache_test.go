@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIPCache(t *testing.T) {
+	resolver := &kubernetesResolver{
+		logger:                   zap.NewNop(),
+		ipToWorkloadAndNamespace: &sync.Map{},
+		ipToServiceAndNamespace:  &sync.Map{},
+		serviceToWorkload:        &sync.Map{},
+		ipCache:                  newIPCache(2, time.Hour),
+	}
+	resolver.ipToWorkloadAndNamespace.Store("1.2.3.4", "checkout@shop")
+
+	workload, namespace, err := resolver.getWorkloadAndNamespaceByIP("1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, "checkout", workload)
+	assert.Equal(t, "shop", namespace)
+
+	// the cached lookup is used until it expires
+	resolver.ipToWorkloadAndNamespace.Store("1.2.3.4", "cart@shop")
+	workload, _, err = resolver.getWorkloadAndNamespaceByIP("1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, "checkout", workload)
+
+	// so is the lookup of an IP that cannot be resolved
+	_, _, err = resolver.getWorkloadAndNamespaceByIP("5.6.7.8")
+	assert.Error(t, err)
+	resolver.ipToWorkloadAndNamespace.Store("5.6.7.8", "payment@shop")
+	_, _, err = resolver.getWorkloadAndNamespaceByIP("5.6.7.8")
+	assert.Error(t, err)
+
+	// the least recently used lookup is evicted
+	_, _, err = resolver.getWorkloadAndNamespaceByIP("9.9.9.9")
+	assert.Error(t, err)
+	workload, _, err = resolver.getWorkloadAndNamespaceByIP("1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, "cart", workload)
+}
+
+func TestIPCacheExpiration(t *testing.T) {
+	cache := newIPCache(0, 20*time.Millisecond)
+	assert.Equal(t, 20*time.Millisecond, cache.negativeTTL)
+	calls := 0
+	load := func(string) (string, string, error) {
+		calls++
+		return "checkout", "shop", nil
+	}
+	for i := 0; i < 3; i++ {
+		_, _, err := cache.getOrLoad("1.2.3.4", load)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, calls)
+	time.Sleep(30 * time.Millisecond)
+	_, _, err := cache.getOrLoad("1.2.3.4", load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	assert.Equal(t, maxNegativeCacheTTL, newIPCache(0, 0).negativeTTL)
+}
@@ -10,6 +10,7 @@ import (
 	"math/rand"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -36,6 +37,11 @@ const (
 	// metric data that arrives within those 2 minutes, containing the old IP, will still get mapped correctly to a service.
 	deletionDelay = 2 * time.Minute
 
+	// The informers replay their objects to the watchers every resyncPeriod, which store their keys again. The keys
+	// that are not stored again within entryTTL, e.g. those of the workloads whose deletion was missed, are deleted.
+	resyncPeriod = 10 * time.Minute
+	entryTTL     = 3 * resyncPeriod
+
 	jitterKubernetesAPISeconds = 10
 
 	// this is an environmental variable that might deprecate in future
@@ -67,8 +73,13 @@ type kubernetesResolver struct {
 	// if ListEndpointSlice is used, we can get serviceToWorkload directly from endpointSlice watcher
 	serviceToWorkload *sync.Map //
 
+	// ipCache caches the lookups of getWorkloadAndNamespaceByIP, they are not cached when it is nil
+	ipCache *ipCache
+
 	safeStopCh *safeChannel // trace and metric processors share the same kubernetesResolver and might close the same channel separately
 	useListPod bool
+	// synced is set once the caches of the watchers are synced
+	synced atomic.Bool
 }
 
 var (
@@ -81,7 +92,7 @@ func jitterSleep(seconds int) {
 	time.Sleep(jitter)
 }
 
-func getKubernetesResolver(platformCode, clusterName string, logger *zap.Logger) subResolver {
+func getKubernetesResolver(platformCode, clusterName string, cacheSize int, cacheTTL time.Duration, logger *zap.Logger) subResolver {
 	once.Do(func() {
 		config, err := clientcmd.BuildConfigFromFlags("", "")
 		if err != nil {
@@ -93,29 +104,19 @@ func getKubernetesResolver(platformCode, clusterName string, logger *zap.Logger)
 			logger.Fatal("Failed to create kubernetes client", zap.Error(err))
 		}
 
-		// jitter calls to the kubernetes api
-		jitterSleep(jitterKubernetesAPISeconds)
-
 		useListPod := (os.Getenv(appSignalsUseListPod) == "true")
+		sharedInformerFactory := informers.NewSharedInformerFactory(clientset, resyncPeriod)
+		timedDeleter := &TimedDeleter{Delay: deletionDelay, TTL: entryTTL}
+		safeStopCh := &safeChannel{ch: make(chan struct{}), closed: false}
+		go timedDeleter.Run(safeStopCh.ch)
 
+		// the watchers are started in the background, so that the agent starts while the kubernetes API is
+		// unavailable. The resolver is not ready until their caches are synced.
 		if useListPod {
-			sharedInformerFactory := informers.NewSharedInformerFactory(clientset, 0)
-			timedDeleter := &TimedDeleter{Delay: deletionDelay}
-
 			poWatcher := newPodWatcher(logger, sharedInformerFactory, timedDeleter)
 			svcWatcher := newServiceWatcher(logger, sharedInformerFactory, timedDeleter)
-
-			safeStopCh := &safeChannel{ch: make(chan struct{}), closed: false}
-			// initialize the pod and service watchers for the cluster
-			poWatcher.run(safeStopCh.ch)
-			svcWatcher.Run(safeStopCh.ch)
-			// wait for caches to sync (for once) so that clients knows about the pods and services in the cluster
-			poWatcher.waitForCacheSync(safeStopCh.ch)
-			svcWatcher.waitForCacheSync(safeStopCh.ch)
-
 			serviceToWorkload := &sync.Map{}
 			svcToWorkloadMapper := newServiceToWorkloadMapper(svcWatcher.serviceAndNamespaceToSelectors, poWatcher.workloadAndNamespaceToLabels, serviceToWorkload, logger, timedDeleter)
-			svcToWorkloadMapper.Start(safeStopCh.ch)
 
 			instance = &kubernetesResolver{
 				logger:                         logger,
@@ -130,24 +131,25 @@ func getKubernetesResolver(platformCode, clusterName string, logger *zap.Logger)
 				serviceToWorkload:              serviceToWorkload,
 				workloadPodCount:               poWatcher.workloadPodCount,
 				ipToWorkloadAndNamespace:       nil,
+				ipCache:                        newIPCache(cacheSize, cacheTTL),
 				safeStopCh:                     safeStopCh,
 				useListPod:                     useListPod,
 			}
+			go instance.start(func() bool {
+				// initialize the pod and service watchers for the cluster
+				poWatcher.run(safeStopCh.ch)
+				svcWatcher.Run(safeStopCh.ch)
+				// wait for caches to sync (for once) so that clients knows about the pods and services in the cluster
+				if !poWatcher.waitForCacheSync(safeStopCh.ch) || !svcWatcher.waitForCacheSync(safeStopCh.ch) {
+					return false
+				}
+				svcToWorkloadMapper.Start(safeStopCh.ch)
+				return true
+			})
 		} else {
-			sharedInformerFactory := informers.NewSharedInformerFactory(clientset, 0)
-			timedDeleter := &TimedDeleter{Delay: deletionDelay}
-
 			svcWatcher := newServiceWatcher(logger, sharedInformerFactory, timedDeleter)
 			endptSliceWatcher := newEndpointSliceWatcher(logger, sharedInformerFactory, timedDeleter)
 
-			safeStopCh := &safeChannel{ch: make(chan struct{}), closed: false}
-			// initialize the pod and service watchers for the cluster
-			svcWatcher.Run(safeStopCh.ch)
-			endptSliceWatcher.Run(safeStopCh.ch)
-			// wait for caches to sync (for once) so that clients knows about the pods and services in the cluster
-			svcWatcher.waitForCacheSync(safeStopCh.ch)
-			endptSliceWatcher.waitForCacheSync(safeStopCh.ch)
-
 			instance = &kubernetesResolver{
 				logger:                       logger,
 				clientset:                    clientset,
@@ -160,16 +162,37 @@ func getKubernetesResolver(platformCode, clusterName string, logger *zap.Logger)
 				workloadPodCount:             nil,
 				ipToServiceAndNamespace:      svcWatcher.ipToServiceAndNamespace,
 				serviceToWorkload:            endptSliceWatcher.serviceToWorkload, // endpointSlice also provides service → workload mapping
+				ipCache:                      newIPCache(cacheSize, cacheTTL),
 				safeStopCh:                   safeStopCh,
 				useListPod:                   useListPod,
 			}
-
+			go instance.start(func() bool {
+				// initialize the service and endpoint slice watchers for the cluster
+				svcWatcher.Run(safeStopCh.ch)
+				endptSliceWatcher.Run(safeStopCh.ch)
+				// wait for caches to sync (for once) so that clients knows about the services and workloads in the cluster
+				return svcWatcher.waitForCacheSync(safeStopCh.ch) && endptSliceWatcher.waitForCacheSync(safeStopCh.ch)
+			})
 		}
 	})
 
 	return instance
 }
 
+// start runs the watchers, which returns once their caches are synced, and marks the resolver ready when they are.
+func (e *kubernetesResolver) start(runWatchers func() bool) {
+	// jitter calls to the kubernetes api
+	jitterSleep(jitterKubernetesAPISeconds)
+	if runWatchers() {
+		e.synced.Store(true)
+	}
+}
+
+// Ready is false until the caches of the watchers are synced, the IPs cannot be resolved before.
+func (e *kubernetesResolver) Ready() bool {
+	return e.synced.Load()
+}
+
 func (e *kubernetesResolver) Stop(_ context.Context) error {
 	e.safeStopCh.Close()
 	return nil
@@ -177,6 +200,13 @@ func (e *kubernetesResolver) Stop(_ context.Context) error {
 
 // add a method to kubernetesResolver
 func (e *kubernetesResolver) getWorkloadAndNamespaceByIP(ip string) (string, string, error) {
+	if e.ipCache != nil {
+		return e.ipCache.getOrLoad(ip, e.lookupWorkloadAndNamespaceByIP)
+	}
+	return e.lookupWorkloadAndNamespaceByIP(ip)
+}
+
+func (e *kubernetesResolver) lookupWorkloadAndNamespaceByIP(ip string) (string, string, error) {
 	var workload, namespace string
 
 	if e.useListPod {
@@ -24,6 +24,10 @@ import (
 // MockDeleter deletes a key immediately, useful for testing.
 type MockDeleter struct{}
 
+func (md *MockDeleter) Store(m *sync.Map, key, value interface{}) {
+	m.Store(key, value)
+}
+
 func (md *MockDeleter) DeleteWithDelay(m *sync.Map, key interface{}) {
 	m.Delete(key)
 }
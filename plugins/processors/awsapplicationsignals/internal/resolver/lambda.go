@@ -0,0 +1,118 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	deprecatedsemconv "go.opentelemetry.io/collector/semconv/v1.18.0"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+const (
+	lambdaRemoteService      = "AWS::Lambda"
+	lambdaResourceType       = "AWS::Lambda::Function"
+	lambdaInvokeOperation    = "Invoke"
+	lambdaRPCService         = "Lambda"
+	lambdaLatestQualifier    = "$LATEST"
+	lambdaInvokedProviderAWS = "aws"
+	// the environment Application Signals gives to services running on Lambda
+	lambdaRemoteEnvironment = "lambda:default"
+)
+
+// lambdaResolver names the Lambda functions invoked by a service after the function, the name the function reports
+// as its own service, so that callers on EC2, ECS or Kubernetes connect to it in the service map. Aliases are kept
+// in the remote resource identifier, while versions and $LATEST are dropped because they change with every deployment.
+type lambdaResolver struct {
+}
+
+func newLambdaResolver() *lambdaResolver {
+	return &lambdaResolver{}
+}
+
+func (l *lambdaResolver) Process(attributes, _ pcommon.Map) error {
+	if !isLambdaInvoke(attributes) {
+		return nil
+	}
+	name, qualifier := lambdaFunction(attributes)
+	if name == "" {
+		return nil
+	}
+	attributes.PutStr(attr.AWSRemoteService, name)
+	attributes.PutStr(attr.AWSRemoteResourceType, lambdaResourceType)
+	identifier := name
+	if isLambdaAlias(qualifier) {
+		identifier = name + ":" + qualifier
+	}
+	attributes.PutStr(attr.AWSRemoteResourceIdentifier, identifier)
+	if _, ok := attributes.Get(attr.AWSRemoteEnvironment); !ok {
+		attributes.PutStr(attr.AWSRemoteEnvironment, lambdaRemoteEnvironment)
+	}
+	return nil
+}
+
+func (l *lambdaResolver) Stop(_ context.Context) error {
+	return nil
+}
+
+// isLambdaInvoke checks for an Invoke call made with an AWS SDK, or a call to a function as a service hosted on AWS.
+// Other Lambda API calls, e.g. ListFunctions, keep AWS::Lambda as their remote service.
+func isLambdaInvoke(attributes pcommon.Map) bool {
+	if getStr(attributes, semconv.AttributeRPCService) == lambdaRPCService {
+		return getStr(attributes, semconv.AttributeRPCMethod) == lambdaInvokeOperation
+	}
+	if getStr(attributes, attr.AWSRemoteService) == lambdaRemoteService {
+		return getStr(attributes, attr.AWSRemoteOperation) == lambdaInvokeOperation
+	}
+	return getStr(attributes, deprecatedsemconv.AttributeFaaSInvokedProvider) == lambdaInvokedProviderAWS
+}
+
+// lambdaFunction returns the name and qualifier of the invoked function, which may be given as a name, a name with a
+// qualifier, or a full or partial ARN.
+func lambdaFunction(attributes pcommon.Map) (string, string) {
+	for _, key := range []string{attr.AWSLambdaFunctionArn, attr.AWSLambdaFunctionName, deprecatedsemconv.AttributeFaaSInvokedName} {
+		if val := getStr(attributes, key); val != "" {
+			return parseLambdaFunction(val)
+		}
+	}
+	if getStr(attributes, attr.AWSRemoteResourceType) == lambdaResourceType {
+		return parseLambdaFunction(getStr(attributes, attr.AWSRemoteResourceIdentifier))
+	}
+	return "", ""
+}
+
+func parseLambdaFunction(function string) (string, string) {
+	parts := strings.Split(function, ":")
+	// arn:aws:lambda:us-east-1:123456789012:function:name[:qualifier] or 123456789012:function:name[:qualifier]
+	for i, part := range parts {
+		if part == "function" && i+1 < len(parts) && (i == 1 || i == 5) {
+			parts = parts[i+1:]
+			break
+		}
+	}
+	if len(parts) >= 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+func isLambdaAlias(qualifier string) bool {
+	if qualifier == "" || qualifier == lambdaLatestQualifier {
+		return false
+	}
+	_, err := strconv.Atoi(qualifier)
+	return err != nil
+}
+
+func getStr(attributes pcommon.Map, key string) string {
+	if val, ok := attributes.Get(key); ok {
+		return val.Str()
+	}
+	return ""
+}
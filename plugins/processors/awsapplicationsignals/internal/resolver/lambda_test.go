@@ -0,0 +1,107 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	deprecatedsemconv "go.opentelemetry.io/collector/semconv/v1.18.0"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+func TestLambdaResolver(t *testing.T) {
+	testCases := map[string]struct {
+		attributes map[string]any
+		want       map[string]any
+	}{
+		"SDKInvokeWithAlias": {
+			attributes: map[string]any{
+				semconv.AttributeRPCService: "Lambda",
+				semconv.AttributeRPCMethod:  "Invoke",
+				attr.AWSRemoteService:       "AWS::Lambda",
+				attr.AWSLambdaFunctionName:  "arn:aws:lambda:us-east-1:123456789012:function:checkout:prod",
+			},
+			want: map[string]any{
+				semconv.AttributeRPCService:      "Lambda",
+				semconv.AttributeRPCMethod:       "Invoke",
+				attr.AWSLambdaFunctionName:       "arn:aws:lambda:us-east-1:123456789012:function:checkout:prod",
+				attr.AWSRemoteService:            "checkout",
+				attr.AWSRemoteResourceType:       "AWS::Lambda::Function",
+				attr.AWSRemoteResourceIdentifier: "checkout:prod",
+				attr.AWSRemoteEnvironment:        "lambda:default",
+			},
+		},
+		"RemoteResourceWithVersion": {
+			attributes: map[string]any{
+				attr.AWSRemoteService:            "AWS::Lambda",
+				attr.AWSRemoteOperation:          "Invoke",
+				attr.AWSRemoteResourceType:       "AWS::Lambda::Function",
+				attr.AWSRemoteResourceIdentifier: "checkout:12",
+				attr.AWSRemoteEnvironment:        "lambda:prod",
+			},
+			want: map[string]any{
+				attr.AWSRemoteService:            "checkout",
+				attr.AWSRemoteOperation:          "Invoke",
+				attr.AWSRemoteResourceType:       "AWS::Lambda::Function",
+				attr.AWSRemoteResourceIdentifier: "checkout",
+				attr.AWSRemoteEnvironment:        "lambda:prod",
+			},
+		},
+		"FaaSInvokedName": {
+			attributes: map[string]any{
+				deprecatedsemconv.AttributeFaaSInvokedProvider: "aws",
+				deprecatedsemconv.AttributeFaaSInvokedName:     "123456789012:function:checkout:$LATEST",
+			},
+			want: map[string]any{
+				deprecatedsemconv.AttributeFaaSInvokedProvider: "aws",
+				deprecatedsemconv.AttributeFaaSInvokedName:     "123456789012:function:checkout:$LATEST",
+				attr.AWSRemoteService:                          "checkout",
+				attr.AWSRemoteResourceType:                     "AWS::Lambda::Function",
+				attr.AWSRemoteResourceIdentifier:               "checkout",
+				attr.AWSRemoteEnvironment:                      "lambda:default",
+			},
+		},
+		"ControlPlaneCall": {
+			attributes: map[string]any{
+				semconv.AttributeRPCService: "Lambda",
+				semconv.AttributeRPCMethod:  "ListFunctions",
+				attr.AWSRemoteService:       "AWS::Lambda",
+			},
+			want: map[string]any{
+				semconv.AttributeRPCService: "Lambda",
+				semconv.AttributeRPCMethod:  "ListFunctions",
+				attr.AWSRemoteService:       "AWS::Lambda",
+			},
+		},
+		"MissingFunction": {
+			attributes: map[string]any{attr.AWSRemoteService: "AWS::Lambda", attr.AWSRemoteOperation: "Invoke"},
+			want:       map[string]any{attr.AWSRemoteService: "AWS::Lambda", attr.AWSRemoteOperation: "Invoke"},
+		},
+		"NotLambda": {
+			attributes: map[string]any{attr.AWSRemoteService: "AWS::S3", attr.AWSRemoteOperation: "GetObject"},
+			want:       map[string]any{attr.AWSRemoteService: "AWS::S3", attr.AWSRemoteOperation: "GetObject"},
+		},
+	}
+	resolver := newLambdaResolver()
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes := pcommon.NewMap()
+			assert.NoError(t, attributes.FromRaw(testCase.attributes))
+			assert.NoError(t, resolver.Process(attributes, pcommon.NewMap()))
+			assert.Equal(t, testCase.want, attributes.AsRaw())
+		})
+	}
+}
+
+func TestAttributesResolverIncludesLambda(t *testing.T) {
+	attributesResolver := NewAttributesResolver([]config.Resolver{config.NewEC2Resolver("")}, zap.NewNop())
+	assert.Len(t, attributesResolver.subResolvers, 2)
+	assert.IsType(t, &lambdaResolver{}, attributesResolver.subResolvers[1])
+}
@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	appsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+// environment variables the Lambda runtime sets for the function and its extensions
+const (
+	envLambdaFunctionName    = "AWS_LAMBDA_FUNCTION_NAME"
+	envLambdaFunctionVersion = "AWS_LAMBDA_FUNCTION_VERSION"
+	envLambdaMemorySize      = "AWS_LAMBDA_FUNCTION_MEMORY_SIZE"
+	envRegion                = "AWS_REGION"
+)
+
+// lambdaFunctionInfo describes the function the agent runs in as an extension.
+type lambdaFunctionInfo struct {
+	name    string
+	version string
+	// maxMemory is in bytes, like faas.max_memory
+	maxMemory int64
+	region    string
+}
+
+// lambdaFunctionInfoFromEnv reads the function from the environment, which does not change for the lifetime of the
+// execution environment.
+func lambdaFunctionInfoFromEnv() lambdaFunctionInfo {
+	info := lambdaFunctionInfo{
+		name:    os.Getenv(envLambdaFunctionName),
+		version: os.Getenv(envLambdaFunctionVersion),
+		region:  os.Getenv(envRegion),
+	}
+	if memorySize, err := strconv.ParseInt(os.Getenv(envLambdaMemorySize), 10, 64); err == nil {
+		info.maxMemory = memorySize * 1024 * 1024
+	}
+	return info
+}
+
+type lambdaResourceAttributesResolver struct {
+	resourceAttributesResolver
+	hostIn   string
+	function lambdaFunctionInfo
+}
+
+func newLambdaResourceAttributesResolver(hostIn string) *lambdaResourceAttributesResolver {
+	return &lambdaResourceAttributesResolver{
+		resourceAttributesResolver: resourceAttributesResolver{
+			defaultEnvPrefix: appsignalsconfig.PlatformLambda,
+			platformType:     AttributePlatformLambda,
+			attributeMap: map[string]string{
+				semconv.AttributeDeploymentEnvironment: attr.AWSLocalEnvironment,
+			},
+		},
+		hostIn:   hostIn,
+		function: lambdaFunctionInfoFromEnv(),
+	}
+}
+
+func (l *lambdaResourceAttributesResolver) Process(attributes, resourceAttributes pcommon.Map) error {
+	for attrKey, mappingKey := range l.attributeMap {
+		if val, ok := resourceAttributes.Get(attrKey); ok {
+			attributes.PutStr(mappingKey, val.Str())
+		}
+	}
+	attributes.PutStr(common.AttributePlatformType, l.platformType)
+	attributes.PutStr(attr.AWSLocalEnvironment, l.getLocalEnvironment(attributes, resourceAttributes))
+	if l.function.name != "" {
+		attributes.PutStr(semconv.AttributeFaaSName, l.function.name)
+	}
+	if l.function.version != "" {
+		attributes.PutStr(semconv.AttributeFaaSVersion, l.function.version)
+	}
+	if l.function.maxMemory > 0 {
+		attributes.PutInt(semconv.AttributeFaaSMaxMemory, l.function.maxMemory)
+	}
+	if l.function.region != "" {
+		attributes.PutStr(semconv.AttributeCloudRegion, l.function.region)
+	}
+	return nil
+}
+
+// getLocalEnvironment determines the environment based on the following priority:
+// 1. aws.local.environment (from deployment.environment)
+// 2. aws.hostedin.environment (deprecated soon)
+// 3. hosted_in (user-specified)
+// 4. Hardcoded `default`, the environment callers of the function give it as their remote environment
+func (l *lambdaResourceAttributesResolver) getLocalEnvironment(attributes, resourceAttributes pcommon.Map) string {
+	if val, ok := attributes.Get(attr.AWSLocalEnvironment); ok {
+		return val.Str()
+	}
+	if val, found := resourceAttributes.Get(attr.AWSHostedInEnvironment); found {
+		return val.Str()
+	}
+	if l.hostIn != "" {
+		return generateLocalEnvironment(l.defaultEnvPrefix, l.hostIn)
+	}
+	return generateLocalEnvironment(l.defaultEnvPrefix, AttributeEnvironmentDefault)
+}
+
+func (l *lambdaResourceAttributesResolver) Stop(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	appsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+func TestLambdaResourceAttributesResolver(t *testing.T) {
+	t.Setenv(envLambdaFunctionName, "checkout")
+	t.Setenv(envLambdaFunctionVersion, "$LATEST")
+	t.Setenv(envLambdaMemorySize, "512")
+	t.Setenv(envRegion, "us-west-2")
+
+	testCases := map[string]struct {
+		hostIn              string
+		attributes          map[string]any
+		resourceAttributes  map[string]any
+		expectedEnvironment string
+	}{
+		"Default": {
+			expectedEnvironment: "lambda:default",
+		},
+		"HostIn": {
+			hostIn:              "prod",
+			expectedEnvironment: "lambda:prod",
+		},
+		"DeploymentEnvironment": {
+			hostIn:              "prod",
+			resourceAttributes:  map[string]any{semconv.AttributeDeploymentEnvironment: "staging"},
+			expectedEnvironment: "staging",
+		},
+		"HostedInEnvironment": {
+			resourceAttributes:  map[string]any{attr.AWSHostedInEnvironment: "beta"},
+			expectedEnvironment: "beta",
+		},
+		"LocalEnvironment": {
+			attributes:          map[string]any{attr.AWSLocalEnvironment: "gamma"},
+			expectedEnvironment: "gamma",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resolver := newLambdaResourceAttributesResolver(testCase.hostIn)
+			attributes := pcommon.NewMap()
+			assert.NoError(t, attributes.FromRaw(testCase.attributes))
+			resourceAttributes := pcommon.NewMap()
+			assert.NoError(t, resourceAttributes.FromRaw(testCase.resourceAttributes))
+
+			assert.NoError(t, resolver.Process(attributes, resourceAttributes))
+
+			assert.Equal(t, map[string]any{
+				common.AttributePlatformType:   AttributePlatformLambda,
+				attr.AWSLocalEnvironment:       testCase.expectedEnvironment,
+				semconv.AttributeFaaSName:      "checkout",
+				semconv.AttributeFaaSVersion:   "$LATEST",
+				semconv.AttributeFaaSMaxMemory: int64(512 * 1024 * 1024),
+				semconv.AttributeCloudRegion:   "us-west-2",
+			}, attributes.AsRaw())
+		})
+	}
+}
+
+func TestLambdaResourceAttributesResolverWithoutEnv(t *testing.T) {
+	t.Setenv(envLambdaFunctionName, "")
+	t.Setenv(envLambdaFunctionVersion, "")
+	t.Setenv(envLambdaMemorySize, "")
+	t.Setenv(envRegion, "")
+
+	resolver := newLambdaResourceAttributesResolver("")
+	attributes := pcommon.NewMap()
+	assert.NoError(t, resolver.Process(attributes, pcommon.NewMap()))
+	assert.Equal(t, map[string]any{
+		common.AttributePlatformType: AttributePlatformLambda,
+		attr.AWSLocalEnvironment:     "lambda:default",
+	}, attributes.AsRaw())
+}
+
+func TestAttributesResolverWithLambda(t *testing.T) {
+	t.Setenv(envLambdaFunctionName, "checkout")
+
+	resolver := NewAttributesResolver([]appsignalsconfig.Resolver{appsignalsconfig.NewLambdaResolver("")}, zap.NewNop())
+	attributes := pcommon.NewMap()
+	assert.NoError(t, resolver.Process(attributes, pcommon.NewMap(), false))
+
+	platform, _ := attributes.Get(common.AttributePlatformType)
+	assert.Equal(t, AttributePlatformLambda, platform.Str())
+	name, _ := attributes.Get(semconv.AttributeFaaSName)
+	assert.Equal(t, "checkout", name.Str())
+}
@@ -22,11 +22,11 @@ func (p *podWatcher) removeHostNetworkRecords(pod *corev1.Pod) {
 func (p *podWatcher) handlePodAdd(pod *corev1.Pod) {
 	if pod.Spec.HostNetwork && pod.Status.HostIP != "" {
 		for _, port := range getHostNetworkPorts(pod) {
-			p.ipToPod.Store(pod.Status.HostIP+":"+port, pod.Name)
+			p.deleter.Store(p.ipToPod, pod.Status.HostIP+":"+port, pod.Name)
 		}
 	}
 	if pod.Status.PodIP != "" {
-		p.ipToPod.Store(pod.Status.PodIP, pod.Name)
+		p.deleter.Store(p.ipToPod, pod.Status.PodIP, pod.Name)
 	}
 }
 
@@ -37,21 +37,13 @@ func (p *podWatcher) handlePodUpdate(newPod *corev1.Pod, oldPod *corev1.Pod) {
 			p.logger.Debug("deleting host ip from cache", zap.String("hostNetwork", oldPod.Status.HostIP))
 			p.removeHostNetworkRecords(oldPod)
 		}
-		if newPod.Status.HostIP != "" {
-			for _, port := range getHostNetworkPorts(newPod) {
-				p.ipToPod.Store(newPod.Status.HostIP+":"+port, newPod.Name)
-			}
-		}
 	}
-	if oldPod.Status.PodIP != newPod.Status.PodIP {
-		if oldPod.Status.PodIP != "" {
-			p.logger.Debug("deleting pod ip from cache", zap.String("podNetwork", oldPod.Status.PodIP))
-			p.deleter.DeleteWithDelay(p.ipToPod, oldPod.Status.PodIP)
-		}
-		if newPod.Status.PodIP != "" {
-			p.ipToPod.Store(newPod.Status.PodIP, newPod.Name)
-		}
+	if oldPod.Status.PodIP != newPod.Status.PodIP && oldPod.Status.PodIP != "" {
+		p.logger.Debug("deleting pod ip from cache", zap.String("podNetwork", oldPod.Status.PodIP))
+		p.deleter.DeleteWithDelay(p.ipToPod, oldPod.Status.PodIP)
 	}
+	// the IPs are stored again even if they did not change, so that they are kept past the TTL of the deleter
+	p.handlePodAdd(newPod)
 }
 
 func (p *podWatcher) onAddOrUpdatePod(pod, oldPod *corev1.Pod) {
@@ -64,13 +56,13 @@ func (p *podWatcher) onAddOrUpdatePod(pod, oldPod *corev1.Pod) {
 	workloadAndNamespace := getWorkloadAndNamespace(pod)
 
 	if workloadAndNamespace != "" {
-		p.podToWorkloadAndNamespace.Store(pod.Name, workloadAndNamespace)
+		p.deleter.Store(p.podToWorkloadAndNamespace, pod.Name, workloadAndNamespace)
 		podLabels := mapset.NewSet[string]()
 		for key, value := range pod.ObjectMeta.Labels {
 			podLabels.Add(key + "=" + value)
 		}
 		if podLabels.Cardinality() > 0 {
-			p.workloadAndNamespaceToLabels.Store(workloadAndNamespace, podLabels)
+			p.deleter.Store(p.workloadAndNamespaceToLabels, workloadAndNamespace, podLabels)
 		}
 		if oldPod == nil {
 			p.workloadPodCount[workloadAndNamespace]++
@@ -79,8 +71,7 @@ func (p *podWatcher) onAddOrUpdatePod(pod, oldPod *corev1.Pod) {
 	}
 }
 
-func (p *podWatcher) onDeletePod(obj interface{}) {
-	pod := obj.(*corev1.Pod)
+func (p *podWatcher) onDeletePod(pod *corev1.Pod) {
 	if pod.Spec.HostNetwork && pod.Status.HostIP != "" {
 		p.logger.Debug("deleting host ip from cache", zap.String("hostNetwork", pod.Status.HostIP))
 		p.removeHostNetworkRecords(pod)
@@ -145,9 +136,13 @@ func (p *podWatcher) run(stopCh chan struct{}) {
 			p.onAddOrUpdatePod(pod, oldPod)
 		},
 		DeleteFunc: func(obj interface{}) {
-			pod := obj.(*corev1.Pod)
+			pod, ok := deletedObject[*corev1.Pod](obj)
+			if !ok {
+				p.logger.Error("failed to get deleted pod", zap.Any("object", obj))
+				return
+			}
 			p.logger.Debug("list and watch for pods: DELETE " + pod.Name)
-			p.onDeletePod(obj)
+			p.onDeletePod(pod)
 		},
 	})
 
@@ -155,12 +150,13 @@ func (p *podWatcher) run(stopCh chan struct{}) {
 
 }
 
-func (p *podWatcher) waitForCacheSync(stopCh chan struct{}) {
+func (p *podWatcher) waitForCacheSync(stopCh chan struct{}) bool {
 	if !cache.WaitForNamedCacheSync("podWatcher", stopCh, p.informer.HasSynced) {
-		p.logger.Fatal("timed out waiting for kubernetes pod watcher caches to sync")
+		p.logger.Info("podWatcher: Stopped before the cache synced")
+		return false
 	}
-
 	p.logger.Info("podWatcher: Cache synced")
+	return true
 }
 
 // minimizePod removes fields that could contain large objects, and retain essential
@@ -53,7 +53,7 @@ func (m *serviceToWorkloadMapper) mapServiceToWorkload() {
 		if len(workloads) > 1 {
 			m.logger.Info("Multiple workloads found for service. You will get unexpected results.", zap.String("service", serviceAndNamespace), zap.Strings("workloads", workloads))
 		} else if len(workloads) == 1 {
-			m.serviceToWorkload.Store(serviceAndNamespace, workloads[0])
+			m.deleter.Store(m.serviceToWorkload, serviceAndNamespace, workloads[0])
 		} else {
 			m.logger.Debug("No workload found for service", zap.String("service", serviceAndNamespace))
 			m.deleter.DeleteWithDelay(m.serviceToWorkload, serviceAndNamespace)
@@ -50,7 +50,11 @@ func (s *serviceWatcher) Run(stopCh chan struct{}) {
 			s.onAddOrUpdateService(service)
 		},
 		DeleteFunc: func(obj interface{}) {
-			service := obj.(*corev1.Service)
+			service, ok := deletedObject[*corev1.Service](obj)
+			if !ok {
+				s.logger.Error("failed to get deleted service", zap.Any("object", obj))
+				return
+			}
 			s.logger.Debug("list and watch for services: DELETE " + service.Name)
 			s.onDeleteService(service, s.deleter)
 		},
@@ -58,12 +62,13 @@ func (s *serviceWatcher) Run(stopCh chan struct{}) {
 	go s.informer.Run(stopCh)
 }
 
-func (s *serviceWatcher) waitForCacheSync(stopCh chan struct{}) {
+func (s *serviceWatcher) waitForCacheSync(stopCh chan struct{}) bool {
 	if !cache.WaitForNamedCacheSync("serviceWatcher", stopCh, s.informer.HasSynced) {
-		s.logger.Fatal("timed out waiting for kubernetes service watcher caches to sync")
+		s.logger.Info("serviceWatcher: Stopped before the cache synced")
+		return false
 	}
-
 	s.logger.Info("serviceWatcher: Cache synced")
+	return true
 }
 
 func (s *serviceWatcher) onAddOrUpdateService(service *corev1.Service) {
@@ -80,14 +85,14 @@ func (s *serviceWatcher) onAddOrUpdateService(service *corev1.Service) {
 	//
 	// we ignore such case for now and may need to consider it in the future
 	if service.Spec.ClusterIP != "" && service.Spec.ClusterIP != corev1.ClusterIPNone {
-		s.ipToServiceAndNamespace.Store(service.Spec.ClusterIP, getServiceAndNamespace(service))
+		s.deleter.Store(s.ipToServiceAndNamespace, service.Spec.ClusterIP, getServiceAndNamespace(service))
 	}
 	labelSet := mapset.NewSet[string]()
 	for key, value := range service.Spec.Selector {
 		labelSet.Add(key + "=" + value)
 	}
 	if labelSet.Cardinality() > 0 {
-		s.serviceAndNamespaceToSelectors.Store(getServiceAndNamespace(service), labelSet)
+		s.deleter.Store(s.serviceAndNamespaceToSelectors, getServiceAndNamespace(service), labelSet)
 	}
 }
 
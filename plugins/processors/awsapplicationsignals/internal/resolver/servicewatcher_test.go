@@ -16,7 +16,7 @@ import (
 
 func newServiceWatcherForTesting(ipToServiceAndNamespace, serviceAndNamespaceToSelectors *sync.Map) *serviceWatcher {
 	logger, _ := zap.NewDevelopment()
-	return &serviceWatcher{ipToServiceAndNamespace, serviceAndNamespaceToSelectors, logger, nil, nil}
+	return &serviceWatcher{ipToServiceAndNamespace, serviceAndNamespaceToSelectors, logger, nil, mockDeleter}
 }
 
 func TestOnAddOrUpdateService(t *testing.T) {
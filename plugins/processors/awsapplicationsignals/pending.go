@@ -0,0 +1,103 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awsapplicationsignals
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// readinessChecker is implemented by the attributes resolver, which cannot resolve the attributes until its backends,
+// like the kubernetes API, have been reached.
+type readinessChecker interface {
+	Ready() bool
+}
+
+// pendingResource is a resource of a batch held until the resolvers are ready.
+type pendingResource struct {
+	// metrics only holds the resource
+	metrics    pmetric.Metrics
+	dataPoints int
+	deadline   time.Time
+}
+
+// pendingMetrics holds the resources of the metric batches received before the resolvers are ready, and hands them
+// back with a later batch, so that they are resolved instead of being published with the attributes that could not
+// be resolved, like the IP of a remote service. The held data points are bounded, and they are only held for maxWait.
+type pendingMetrics struct {
+	resolver      readinessChecker
+	maxWait       time.Duration
+	maxDataPoints int
+
+	mu         sync.Mutex
+	resources  []pendingResource
+	dataPoints int
+}
+
+func newPendingMetrics(resolver readinessChecker, maxWait time.Duration, maxDataPoints int) *pendingMetrics {
+	return &pendingMetrics{
+		resolver:      resolver,
+		maxWait:       maxWait,
+		maxDataPoints: maxDataPoints,
+	}
+}
+
+// process holds the resources of the batch while the resolvers are not ready and moves the held resources back into
+// the batch, all of them once the resolvers are ready and only the ones held for longer than maxWait otherwise. It
+// returns the number of data points of the batch that are processed before the resolvers are ready.
+func (p *pendingMetrics) process(md pmetric.Metrics, now time.Time) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.resolver.Ready() {
+		p.release(md, func(pendingResource) bool { return true })
+		return 0
+	}
+	// the expired resources are released first, to make room for the resources of the batch
+	expired := pmetric.NewMetrics()
+	unresolved := p.release(expired, func(r pendingResource) bool { return !now.Before(r.deadline) })
+	unresolved += p.hold(md, now)
+	expired.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	return unresolved
+}
+
+// hold moves the resources of the batch into the held resources as long as they fit. It returns the number of data
+// points that did not fit.
+func (p *pendingMetrics) hold(md pmetric.Metrics, now time.Time) int {
+	unresolved := 0
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		held := pmetric.NewMetrics()
+		rm.MoveTo(held.ResourceMetrics().AppendEmpty())
+		dataPoints := held.DataPointCount()
+		if p.dataPoints+dataPoints > p.maxDataPoints {
+			held.ResourceMetrics().At(0).MoveTo(rm)
+			unresolved += dataPoints
+			return false
+		}
+		p.resources = append(p.resources, pendingResource{metrics: held, dataPoints: dataPoints, deadline: now.Add(p.maxWait)})
+		p.dataPoints += dataPoints
+		return true
+	})
+	return unresolved
+}
+
+// release moves the held resources matching the filter back into the batch. It returns the number of data points
+// released.
+func (p *pendingMetrics) release(md pmetric.Metrics, filter func(pendingResource) bool) int {
+	released := 0
+	kept := p.resources[:0]
+	for _, r := range p.resources {
+		if !filter(r) {
+			kept = append(kept, r)
+			continue
+		}
+		r.metrics.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+		released += r.dataPoints
+	}
+	clear(p.resources[len(kept):])
+	p.resources = kept
+	p.dataPoints -= released
+	return released
+}
@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awsapplicationsignals
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type stubReadiness struct {
+	ready bool
+}
+
+func (s *stubReadiness) Ready() bool {
+	return s.ready
+}
+
+// newPendingTestMetrics creates a batch with a resource per service, each with a gauge of n data points.
+func newPendingTestMetrics(n int, services ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, service := range services {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", service)
+		dps := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+		for i := 0; i < n; i++ {
+			dps.AppendEmpty().SetIntValue(int64(i))
+		}
+	}
+	return md
+}
+
+func resourceServices(md pmetric.Metrics) []string {
+	var services []string
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		service, _ := md.ResourceMetrics().At(i).Resource().Attributes().Get("service.name")
+		services = append(services, service.Str())
+	}
+	return services
+}
+
+func TestPendingMetrics(t *testing.T) {
+	resolver := &stubReadiness{}
+	p := newPendingMetrics(resolver, time.Minute, 5)
+	now := time.Now()
+
+	// held while the resolvers are not ready, as long as they fit
+	md := newPendingTestMetrics(2, "a", "b", "c")
+	assert.Equal(t, 2, p.process(md, now))
+	assert.Equal(t, []string{"c"}, resourceServices(md))
+	assert.Equal(t, 4, p.dataPoints)
+
+	md = newPendingTestMetrics(1, "d")
+	assert.Equal(t, 0, p.process(md, now.Add(30*time.Second)))
+	assert.Empty(t, resourceServices(md))
+
+	// released unresolved after the max wait, which makes room for the batch
+	md = newPendingTestMetrics(2, "e")
+	assert.Equal(t, 4, p.process(md, now.Add(time.Minute)))
+	assert.Equal(t, []string{"a", "b"}, resourceServices(md))
+	assert.Equal(t, 3, p.dataPoints)
+
+	// released with the batch once the resolvers are ready
+	resolver.ready = true
+	md = newPendingTestMetrics(1, "f")
+	assert.Equal(t, 0, p.process(md, now.Add(70*time.Second)))
+	assert.Equal(t, []string{"f", "d", "e"}, resourceServices(md))
+	assert.Equal(t, 4, md.DataPointCount())
+	assert.Empty(t, p.resources)
+	assert.Zero(t, p.dataPoints)
+}
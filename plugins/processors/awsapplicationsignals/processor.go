@@ -5,6 +5,9 @@ package awsapplicationsignals
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
+	"time"
 	"unicode"
 
 	"go.opentelemetry.io/collector/component"
@@ -17,6 +20,7 @@ import (
 
 	appsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/cardinalitycontrol"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/exceptions"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/metrichandlers"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/normalizer"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/resolver"
@@ -26,6 +30,11 @@ import (
 const (
 	failedToProcessAttribute            = "failed to process attributes"
 	failedToProcessAttributeWithLimiter = "failed to process attributes with limiter, keep the data"
+
+	// dryRunLogInterval is how often one of the data points the rules in dry run would drop or change is logged.
+	dryRunLogInterval = time.Minute
+	// unresolvedLogInterval is how often the data points processed before the resolvers are ready are logged.
+	unresolvedLogInterval = time.Minute
 )
 
 var metricCaser = cases.Title(language.English)
@@ -36,31 +45,60 @@ type attributesMutator interface {
 }
 
 type allowListMutator interface {
-	ShouldBeDropped(attributes pcommon.Map) (bool, error)
+	ShouldBeDropped(attributes, resourceAttributes pcommon.Map) (bool, error)
+}
+
+// dryRunner is implemented by the allow list mutators with rules in dry run, which report the data points those rules
+// would drop instead of dropping them.
+type dryRunner interface {
+	WouldBeDropped(attributes, resourceAttributes pcommon.Map) bool
+}
+
+// allowListRule is an allow list mutator with the reason the data points it drops are counted under.
+type allowListRule struct {
+	allowListMutator
+	reason string
 }
 
 type stopper interface {
 	Stop(context.Context) error
 }
 
+// ruleSet is the compiled rules of the processor. It is replaced as a whole when the rules file changes, so that a
+// batch is processed with either the old or the new rules.
+type ruleSet struct {
+	allowlistMutators []allowListRule
+	replaceActions    *rules.ReplaceActions
+	hashActions       *rules.HashActions
+}
+
 type awsapplicationsignalsprocessor struct {
-	logger             *zap.Logger
-	config             *appsignalsconfig.Config
-	replaceActions     *rules.ReplaceActions
-	allowlistMutators  []allowListMutator
-	metricMutators     []attributesMutator
-	traceMutators      []attributesMutator
-	limiter            cardinalitycontrol.Limiter
+	logger         *zap.Logger
+	config         *appsignalsconfig.Config
+	metricRules    atomic.Pointer[ruleSet]
+	traceRules     atomic.Pointer[ruleSet]
+	metricMutators []attributesMutator
+	traceMutators  []attributesMutator
+	limiter        cardinalitycontrol.Limiter
+	// operationsLimiter aggregates the operations outside of the top-K of their service before the limiter runs
+	operationsLimiter  *cardinalitycontrol.OperationsLimiter
 	aggregationMutator metrichandlers.AggregationMutator
 	stoppers           []stopper
+	// exceptions is shared by the instances of the processor in the traces and metrics pipelines
+	exceptions *exceptions.Aggregator
+	telemetry  *processorTelemetry
+	// sampler decides which spans are kept once their attributes are resolved, all the spans are kept when it is nil
+	sampler *rules.Sampler
+	// dryRunLogged is when a data point the rules in dry run would drop was last logged, in Unix nanoseconds.
+	dryRunLogged atomic.Int64
+	// pending holds the metrics received before the resolvers are ready
+	pending *pendingMetrics
+	// unresolvedLogged is when data points processed before the resolvers were ready were last logged, in Unix
+	// nanoseconds.
+	unresolvedLogged atomic.Int64
 }
 
 func (ap *awsapplicationsignalsprocessor) StartMetrics(ctx context.Context, _ component.Host) error {
-	attributesResolver := resolver.NewAttributesResolver(ap.config.Resolvers, ap.logger)
-	ap.stoppers = []stopper{attributesResolver}
-	attributesNormalizer := normalizer.NewAttributesNormalizer(ap.logger)
-	ap.metricMutators = []attributesMutator{attributesResolver, attributesNormalizer}
-
 	limiterConfig := ap.config.Limiter
 	if limiterConfig == nil {
 		limiterConfig = appsignalsconfig.NewDefaultLimiterConfig()
@@ -69,34 +107,117 @@ func (ap *awsapplicationsignalsprocessor) StartMetrics(ctx context.Context, _ co
 		limiterConfig.ParentContext = ctx
 	}
 
+	pruner := metrichandlers.NewPruner()
+	compile := func(rs []rules.Rule) (*ruleSet, error) {
+		replaceActions, err := rules.NewReplacer(rs, !limiterConfig.Disabled)
+		if err != nil {
+			return nil, err
+		}
+		hashActions, err := rules.NewHasher(rs)
+		if err != nil {
+			return nil, err
+		}
+		return &ruleSet{
+			allowlistMutators: []allowListRule{
+				{allowListMutator: pruner, reason: dropReasonInvalid},
+				{allowListMutator: rules.NewKeeper(rs, !limiterConfig.Disabled), reason: dropReasonKeep},
+				{allowListMutator: rules.NewDropper(rs), reason: dropReasonDrop},
+			},
+			replaceActions: replaceActions,
+			hashActions:    hashActions,
+		}, nil
+	}
+	reloader, err := ap.loadRules(&ap.metricRules, compile)
+	if err != nil {
+		return err
+	}
+
+	attributesResolver := resolver.NewAttributesResolver(ap.config.Resolvers, ap.logger)
+	ap.stoppers = []stopper{attributesResolver}
+	if reloader != nil {
+		ap.stoppers = append(ap.stoppers, reloader)
+	}
+	attributesNormalizer := normalizer.NewAttributesNormalizer(ap.logger)
+	ap.metricMutators = []attributesMutator{attributesResolver, attributesNormalizer}
+
+	maxWait := ap.config.ResolverMaxWait
+	if maxWait == 0 {
+		maxWait = appsignalsconfig.DefaultResolverMaxWait
+	}
+	maxPendingDataPoints := ap.config.ResolverMaxPendingDataPoints
+	if maxPendingDataPoints == 0 {
+		maxPendingDataPoints = appsignalsconfig.DefaultResolverMaxPendingDataPoints
+	}
+	ap.pending = newPendingMetrics(attributesResolver, maxWait, maxPendingDataPoints)
+
 	if !limiterConfig.Disabled {
+		if limiterConfig.MaxOperations > 0 {
+			ap.operationsLimiter = cardinalitycontrol.NewOperationsLimiter(limiterConfig, ap.logger)
+		}
 		ap.limiter = cardinalitycontrol.NewMetricsLimiter(limiterConfig, ap.logger)
 	} else {
 		ap.logger.Info("metrics limiter is disabled.")
 	}
 
-	ap.replaceActions = rules.NewReplacer(ap.config.Rules, !limiterConfig.Disabled)
-
-	pruner := metrichandlers.NewPruner()
-	keeper := rules.NewKeeper(ap.config.Rules, !limiterConfig.Disabled)
-	dropper := rules.NewDropper(ap.config.Rules)
-	ap.allowlistMutators = []allowListMutator{pruner, keeper, dropper}
-
 	ap.aggregationMutator = metrichandlers.NewAggregationMutator()
 
 	return nil
 }
 
 func (ap *awsapplicationsignalsprocessor) StartTraces(_ context.Context, _ component.Host) error {
+	reloader, err := ap.loadRules(&ap.traceRules, func(rs []rules.Rule) (*ruleSet, error) {
+		replaceActions, err := rules.NewReplacer(rs, false)
+		if err != nil {
+			return nil, err
+		}
+		hashActions, err := rules.NewHasher(rs)
+		if err != nil {
+			return nil, err
+		}
+		return &ruleSet{replaceActions: replaceActions, hashActions: hashActions}, nil
+	})
+	if err != nil {
+		return err
+	}
 	attributesResolver := resolver.NewAttributesResolver(ap.config.Resolvers, ap.logger)
 	attributesNormalizer := normalizer.NewAttributesNormalizer(ap.logger)
-	customReplacer := rules.NewReplacer(ap.config.Rules, false)
 
 	ap.stoppers = append(ap.stoppers, attributesResolver)
-	ap.traceMutators = append(ap.traceMutators, attributesResolver, attributesNormalizer, customReplacer)
+	if reloader != nil {
+		ap.stoppers = append(ap.stoppers, reloader)
+	}
+	ap.traceMutators = append(ap.traceMutators, attributesResolver, attributesNormalizer)
+	if ap.config.Sampling != nil {
+		if ap.sampler, err = rules.NewSampler(ap.config.Sampling.Rules, ap.config.Sampling.DefaultPercentage()); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// loadRules compiles the rules of the configuration, or of the rules file when it is set, into current. The returned
+// reloader, which is nil without a rules file, compiles the rules again when the file changes.
+func (ap *awsapplicationsignalsprocessor) loadRules(current *atomic.Pointer[ruleSet], compile func([]rules.Rule) (*ruleSet, error)) (*rulesReloader, error) {
+	if ap.config.RulesFile == "" {
+		rs, err := compile(ap.config.Rules)
+		if err != nil {
+			return nil, err
+		}
+		current.Store(rs)
+		return nil, nil
+	}
+	interval := ap.config.ReloadInterval
+	if interval == 0 {
+		interval = appsignalsconfig.DefaultReloadInterval
+	}
+	reloader := newRulesReloader(ap.config.RulesFile, interval, compile, current, ap.logger)
+	if err := reloader.load(); err != nil {
+		return nil, err
+	}
+	reloader.start()
+	return reloader, nil
+}
+
 func (ap *awsapplicationsignalsprocessor) Shutdown(ctx context.Context) error {
 	for _, stopper := range ap.stoppers {
 		err := stopper.Stop(ctx)
@@ -107,12 +228,14 @@ func (ap *awsapplicationsignalsprocessor) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-func (ap *awsapplicationsignalsprocessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (ap *awsapplicationsignalsprocessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	current := ap.traceRules.Load()
 	rss := td.ResourceSpans()
-	for i := 0; i < rss.Len(); i++ {
+	ap.forEachResource(rss.Len(), func(i int) {
 		rs := rss.At(i)
 		ilss := rs.ScopeSpans()
 		resourceAttributes := rs.Resource().Attributes()
+		var sampledOut int64
 		for j := 0; j < ilss.Len(); j++ {
 			ils := ilss.At(j)
 			spans := ils.Spans()
@@ -124,18 +247,51 @@ func (ap *awsapplicationsignalsprocessor) processTraces(_ context.Context, td pt
 						ap.logger.Debug("failed to Process span", zap.Error(err))
 					}
 				}
+				if err := current.replaceActions.Process(span.Attributes(), resourceAttributes, true); err != nil {
+					ap.logger.Debug("failed to Process span", zap.Error(err))
+				}
+				if err := current.hashActions.Process(span.Attributes(), resourceAttributes, true); err != nil {
+					ap.logger.Debug("failed to Process span", zap.Error(err))
+				}
+				if ap.exceptions != nil {
+					ap.exceptions.RecordSpan(span)
+				}
+			}
+			// the spans are sampled after their attributes are resolved and their exceptions counted
+			if ap.sampler != nil {
+				spans.RemoveIf(func(span ptrace.Span) bool {
+					if ap.sampler.Keep(span, resourceAttributes) {
+						return false
+					}
+					sampledOut++
+					return true
+				})
 			}
 		}
-	}
+		ap.telemetry.recordSampledOut(ctx, sampledOut)
+	})
 	return td, nil
 }
 
 func (ap *awsapplicationsignalsprocessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	if ap.pending != nil {
+		if unresolved := ap.pending.process(md, time.Now()); unresolved > 0 {
+			ap.logUnresolved(unresolved)
+		}
+	}
+	if ap.exceptions != nil {
+		ap.exceptions.AppendTo(md)
+	}
+	current := ap.metricRules.Load()
 	rms := md.ResourceMetrics()
-	for i := 0; i < rms.Len(); i++ {
+	ap.forEachResource(rms.Len(), func(i int) {
 		rs := rms.At(i)
 		ilms := rs.ScopeMetrics()
 		resourceAttributes := rs.Resource().Attributes()
+		stats := &dataPointStats{
+			dropped:       make([]int64, len(current.allowlistMutators)),
+			dryRunDropped: make([]int64, len(current.allowlistMutators)),
+		}
 		for j := 0; j < ilms.Len(); j++ {
 			ils := ilms.At(j)
 			metrics := ils.Metrics()
@@ -145,196 +301,175 @@ func (ap *awsapplicationsignalsprocessor) processMetrics(ctx context.Context, md
 				if len(m.Name()) > 0 && !unicode.IsUpper(rune(m.Name()[0])) {
 					m.SetName(metricCaser.String(m.Name())) // Ensure metric name is in sentence case
 				}
-				ap.processMetricAttributes(ctx, m, resourceAttributes)
+				ap.processMetricAttributes(ctx, m, resourceAttributes, current, stats)
 				ap.aggregationMutator.ProcessMetrics(ctx, m, resourceAttributes)
 			}
 		}
-	}
+		ap.telemetry.record(ctx, stats, current.allowlistMutators)
+	})
 	return md, nil
 }
 
+// forEachResource calls process with the index of each of the n resources of a batch, on up to num_workers
+// goroutines. Each resource, with its resource attributes and data points, is only processed by one goroutine, so
+// the mutators only share the state they already synchronize, like the caches of the resolvers and the limiter.
+func (ap *awsapplicationsignalsprocessor) forEachResource(n int, process func(i int)) {
+	workers := min(ap.config.NumWorkers, n)
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			process(i)
+		}
+		return
+	}
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < n; i = int(next.Add(1) - 1) {
+				process(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 // Attributes are provided for each log and trace, but not at the metric level
 // Need to process attributes for every data point within a metric.
-func (ap *awsapplicationsignalsprocessor) processMetricAttributes(_ context.Context, m pmetric.Metric, resourceAttribes pcommon.Map) {
-	// This is a lot of repeated code, but since there is no single parent superclass
-	// between metric data types, we can't use polymorphism.
+func (ap *awsapplicationsignalsprocessor) processMetricAttributes(_ context.Context, m pmetric.Metric, resourceAttribes pcommon.Map, rs *ruleSet, stats *dataPointStats) {
 	switch m.Type() {
 	case pmetric.MetricTypeGauge:
-		dps := m.Gauge().DataPoints()
-		for i := 0; i < dps.Len(); i++ {
-			for _, mutator := range ap.metricMutators {
-				err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false)
-				if err != nil {
-					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-				}
-			}
-		}
-		dps.RemoveIf(func(d pmetric.NumberDataPoint) bool {
-			for _, mutator := range ap.allowlistMutators {
-				shouldBeDropped, err := mutator.ShouldBeDropped(d.Attributes())
-				if err != nil {
-					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-				}
-				if shouldBeDropped {
-					return true
-				}
-			}
-			return false
-		})
-		for i := 0; i < dps.Len(); i++ {
-			err := ap.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false)
-			if err != nil {
-				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-			}
-		}
-		if ap.limiter != nil {
-			for i := 0; i < dps.Len(); i++ {
-				if _, err := ap.limiter.Admit(m.Name(), dps.At(i).Attributes(), resourceAttribes); err != nil {
-					ap.logger.Debug(failedToProcessAttributeWithLimiter, zap.Error(err))
-				}
-			}
-		}
+		processDataPoints(ap, m.Name(), m.Gauge().DataPoints(), resourceAttribes, rs, stats)
 	case pmetric.MetricTypeSum:
-		dps := m.Sum().DataPoints()
-		for i := 0; i < dps.Len(); i++ {
-			for _, mutator := range ap.metricMutators {
-				err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false)
-				if err != nil {
-					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-				}
-			}
-		}
-		dps.RemoveIf(func(d pmetric.NumberDataPoint) bool {
-			for _, mutator := range ap.allowlistMutators {
-				shouldBeDropped, err := mutator.ShouldBeDropped(d.Attributes())
-				if err != nil {
-					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-				}
-				if shouldBeDropped {
-					return true
-				}
-			}
-			return false
-		})
-		for i := 0; i < dps.Len(); i++ {
-			err := ap.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false)
-			if err != nil {
-				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-			}
-		}
-		if ap.limiter != nil {
-			for i := 0; i < dps.Len(); i++ {
-				if _, err := ap.limiter.Admit(m.Name(), dps.At(i).Attributes(), resourceAttribes); err != nil {
-					ap.logger.Debug(failedToProcessAttributeWithLimiter, zap.Error(err))
-				}
-			}
-		}
+		processDataPoints(ap, m.Name(), m.Sum().DataPoints(), resourceAttribes, rs, stats)
 	case pmetric.MetricTypeHistogram:
-		dps := m.Histogram().DataPoints()
-		for i := 0; i < dps.Len(); i++ {
-			for _, mutator := range ap.metricMutators {
-				err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false)
-				if err != nil {
-					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-				}
+		processDataPoints(ap, m.Name(), m.Histogram().DataPoints(), resourceAttribes, rs, stats)
+	case pmetric.MetricTypeExponentialHistogram:
+		processDataPoints(ap, m.Name(), m.ExponentialHistogram().DataPoints(), resourceAttribes, rs, stats)
+	case pmetric.MetricTypeSummary:
+		processDataPoints(ap, m.Name(), m.Summary().DataPoints(), resourceAttribes, rs, stats)
+	default:
+		ap.logger.Debug("Ignore unknown metric type", zap.String("type", m.Type().String()))
+	}
+}
+
+// logDryRun logs a data point the rules in dry run would drop or change, at most once per dryRunLogInterval, so that
+// the rules can be checked in the agent log without flooding it. All of them are counted in the telemetry.
+func (ap *awsapplicationsignalsprocessor) logDryRun(metricName, reason string, attributes pcommon.Map) {
+	now := time.Now().UnixNano()
+	last := ap.dryRunLogged.Load()
+	if now-last < int64(dryRunLogInterval) || !ap.dryRunLogged.CompareAndSwap(last, now) {
+		return
+	}
+	msg := "a rule in dry run would drop the data point"
+	if reason == dryRunReasonReplace {
+		msg = "a rule in dry run would replace the dimensions of the data point"
+	}
+	ap.logger.Info(msg,
+		zap.String("metric", metricName),
+		zap.String("action", reason),
+		zap.Any("attributes", attributes.AsRaw()))
+}
+
+// logUnresolved logs that data points are processed before the resolvers are ready, because they were held for
+// longer than resolver_max_wait or did not fit with the held data points, at most once per unresolvedLogInterval.
+func (ap *awsapplicationsignalsprocessor) logUnresolved(dataPoints int) {
+	now := time.Now().UnixNano()
+	last := ap.unresolvedLogged.Load()
+	if now-last < int64(unresolvedLogInterval) || !ap.unresolvedLogged.CompareAndSwap(last, now) {
+		return
+	}
+	ap.logger.Warn("the resolvers are not ready, processing data points without resolving their attributes",
+		zap.Int("dataPoints", dataPoints),
+		zap.Duration("resolverMaxWait", ap.pending.maxWait))
+}
+
+// dataPoint is implemented by the data points of every metric type.
+type dataPoint interface {
+	Attributes() pcommon.Map
+}
+
+// callCount is the number of calls a data point counts. Only the latency histograms count the calls, the other data
+// points of an operation count none.
+func callCount(d dataPoint) int {
+	switch dp := any(d).(type) {
+	case pmetric.HistogramDataPoint:
+		return int(dp.Count())
+	case pmetric.ExponentialHistogramDataPoint:
+		return int(dp.Count())
+	}
+	return 0
+}
+
+// dataPoints is implemented by the data point slices of every metric type, which have no common type in pdata.
+type dataPoints[T dataPoint] interface {
+	Len() int
+	At(i int) T
+	RemoveIf(f func(T) bool)
+}
+
+// processDataPoints runs the mutators, the allow list, the replacements, the hashes, the operations limiter and the
+// limiter on the data points of a metric, in that order.
+func processDataPoints[T dataPoint](ap *awsapplicationsignalsprocessor, metricName string, dps dataPoints[T], resourceAttribes pcommon.Map, rs *ruleSet, stats *dataPointStats) {
+	stats.processed += int64(dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		for _, mutator := range ap.metricMutators {
+			if err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false); err != nil {
+				stats.mutatorErrors++
+				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
 			}
 		}
-		dps.RemoveIf(func(d pmetric.HistogramDataPoint) bool {
-			for _, mutator := range ap.allowlistMutators {
-				shouldBeDropped, err := mutator.ShouldBeDropped(d.Attributes())
-				if err != nil {
-					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-				}
-				if shouldBeDropped {
-					return true
-				}
-			}
-			return false
-		})
-		for i := 0; i < dps.Len(); i++ {
-			err := ap.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false)
+	}
+	dps.RemoveIf(func(d T) bool {
+		for j, rule := range rs.allowlistMutators {
+			shouldBeDropped, err := rule.ShouldBeDropped(d.Attributes(), resourceAttribes)
 			if err != nil {
+				// The pruner returns why it drops a data point as an error, which is counted as the drop instead.
+				if !shouldBeDropped {
+					stats.mutatorErrors++
+				}
 				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
 			}
-		}
-		if ap.limiter != nil {
-			for i := 0; i < dps.Len(); i++ {
-				if _, err := ap.limiter.Admit(m.Name(), dps.At(i).Attributes(), resourceAttribes); err != nil {
-					ap.logger.Debug(failedToProcessAttributeWithLimiter, zap.Error(err))
-				}
+			if shouldBeDropped {
+				stats.dropped[j]++
+				return true
 			}
 		}
-	case pmetric.MetricTypeExponentialHistogram:
-		dps := m.ExponentialHistogram().DataPoints()
-		for i := 0; i < dps.Len(); i++ {
-			for _, mutator := range ap.metricMutators {
-				err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false)
-				if err != nil {
-					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-				}
+		// the data points that are kept are counted by the first rule in dry run that would have dropped them
+		for j, rule := range rs.allowlistMutators {
+			if dr, ok := rule.allowListMutator.(dryRunner); ok && dr.WouldBeDropped(d.Attributes(), resourceAttribes) {
+				stats.dryRunDropped[j]++
+				ap.logDryRun(metricName, rule.reason, d.Attributes())
+				break
 			}
 		}
-		dps.RemoveIf(func(d pmetric.ExponentialHistogramDataPoint) bool {
-			for _, mutator := range ap.allowlistMutators {
-				shouldBeDropped, err := mutator.ShouldBeDropped(d.Attributes())
-				if err != nil {
-					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-				}
-				if shouldBeDropped {
-					return true
-				}
-			}
-			return false
-		})
-		for i := 0; i < dps.Len(); i++ {
-			err := ap.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false)
-			if err != nil {
-				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-			}
+		return false
+	})
+	for i := 0; i < dps.Len(); i++ {
+		if err := rs.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false); err != nil {
+			stats.mutatorErrors++
+			ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
 		}
-		if ap.limiter != nil {
-			for i := 0; i < dps.Len(); i++ {
-				if _, err := ap.limiter.Admit(m.Name(), dps.At(i).Attributes(), resourceAttribes); err != nil {
-					ap.logger.Debug(failedToProcessAttributeWithLimiter, zap.Error(err))
-				}
-			}
+		if rs.replaceActions.WouldBeReplaced(dps.At(i).Attributes(), resourceAttribes, false) {
+			stats.dryRunReplaced++
+			ap.logDryRun(metricName, dryRunReasonReplace, dps.At(i).Attributes())
 		}
-	case pmetric.MetricTypeSummary:
-		dps := m.Summary().DataPoints()
-		for i := 0; i < dps.Len(); i++ {
-			for _, mutator := range ap.metricMutators {
-				err := mutator.Process(dps.At(i).Attributes(), resourceAttribes, false)
-				if err != nil {
-					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-				}
-			}
+		if err := rs.hashActions.Process(dps.At(i).Attributes(), resourceAttribes, false); err != nil {
+			stats.mutatorErrors++
+			ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
 		}
-		dps.RemoveIf(func(d pmetric.SummaryDataPoint) bool {
-			for _, mutator := range ap.allowlistMutators {
-				shouldBeDropped, err := mutator.ShouldBeDropped(d.Attributes())
-				if err != nil {
-					ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-				}
-				if shouldBeDropped {
-					return true
-				}
-			}
-			return false
-		})
+	}
+	if ap.operationsLimiter != nil {
 		for i := 0; i < dps.Len(); i++ {
-			err := ap.replaceActions.Process(dps.At(i).Attributes(), resourceAttribes, false)
-			if err != nil {
-				ap.logger.Debug(failedToProcessAttribute, zap.Error(err))
-			}
+			ap.operationsLimiter.Admit(dps.At(i).Attributes(), callCount(dps.At(i)))
 		}
-		if ap.limiter != nil {
-			for i := 0; i < dps.Len(); i++ {
-				if _, err := ap.limiter.Admit(m.Name(), dps.At(i).Attributes(), resourceAttribes); err != nil {
-					ap.logger.Debug(failedToProcessAttributeWithLimiter, zap.Error(err))
-				}
+	}
+	if ap.limiter != nil {
+		for i := 0; i < dps.Len(); i++ {
+			if _, err := ap.limiter.Admit(metricName, dps.At(i).Attributes(), resourceAttribes); err != nil {
+				ap.logger.Debug(failedToProcessAttributeWithLimiter, zap.Error(err))
 			}
 		}
-	default:
-		ap.logger.Debug("Ignore unknown metric type", zap.String("type", m.Type().String()))
 	}
 }
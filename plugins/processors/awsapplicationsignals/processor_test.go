@@ -5,14 +5,20 @@ package awsapplicationsignals
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
 )
 
@@ -104,6 +110,115 @@ func TestProcessMetrics(t *testing.T) {
 	assert.True(t, isMetricNil(dropMetricsByKeep))
 }
 
+func TestProcessMetricsTelemetry(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	telemetry, err := newProcessorTelemetry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), "awsapplicationsignals")
+	require.NoError(t, err)
+	ap := &awsapplicationsignalsprocessor{
+		logger: zap.NewNop(),
+		config: &config.Config{
+			Resolvers: []config.Resolver{config.NewGenericResolver("")},
+			Rules:     testRules,
+		},
+		telemetry: telemetry,
+	}
+	ctx := context.Background()
+	require.NoError(t, ap.StartMetrics(ctx, nil))
+
+	for _, dimensions := range []map[string]string{
+		{"dim_action": "reserved", "Telemetry.Source": "UnitTest"},
+		{"dim_action": "reserved", "dim_drop": "hc", "Telemetry.Source": "UnitTest"},
+		{"dim_op": "drop", "Telemetry.Source": "UnitTest"},
+		{"dim_action": "reserved"},
+	} {
+		_, err = ap.processMetrics(ctx, generateMetrics(dimensions))
+		require.NoError(t, err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := map[string]metricdata.Sum[int64]{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m.Data.(metricdata.Sum[int64])
+	}
+	require.Len(t, got["awsapplicationsignals_datapoints_processed"].DataPoints, 1)
+	assert.EqualValues(t, 20, got["awsapplicationsignals_datapoints_processed"].DataPoints[0].Value)
+	dropped := map[string]int64{}
+	for _, dp := range got["awsapplicationsignals_datapoints_dropped"].DataPoints {
+		reason, _ := dp.Attributes.Value(attributeReason)
+		processor, _ := dp.Attributes.Value(attributeProcessor)
+		assert.Equal(t, "awsapplicationsignals", processor.AsString())
+		dropped[reason.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{dropReasonKeep: 5, dropReasonDrop: 5, dropReasonInvalid: 5}, dropped)
+	assert.NotContains(t, got, "awsapplicationsignals_mutator_errors")
+}
+
+func TestProcessMetricsDryRun(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	telemetry, err := newProcessorTelemetry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), "awsapplicationsignals")
+	require.NoError(t, err)
+	core, logs := observer.New(zap.InfoLevel)
+	ap := &awsapplicationsignalsprocessor{
+		logger: zap.New(core),
+		config: &config.Config{
+			Resolvers: []config.Resolver{config.NewGenericResolver("")},
+			Rules: []rules.Rule{
+				{
+					Selectors: []rules.Selector{{Dimension: "dim_action", Match: "reserved"}},
+					Action:    "keep",
+					DryRun:    true,
+				},
+				{
+					Selectors: []rules.Selector{{Dimension: "dim_drop", Match: "hc"}},
+					Action:    "drop",
+					DryRun:    true,
+				},
+				{
+					Selectors:    []rules.Selector{{Dimension: "dim_op", Match: "drop"}},
+					Replacements: []rules.Replacement{{TargetDimension: "dim_op", Value: "other"}},
+					Action:       "replace",
+					Audit:        true,
+				},
+			},
+		},
+		telemetry: telemetry,
+	}
+	ctx := context.Background()
+	require.NoError(t, ap.StartMetrics(ctx, nil))
+
+	for _, dimensions := range []map[string]string{
+		{"dim_action": "reserved", "Telemetry.Source": "UnitTest"},
+		{"dim_action": "reserved", "dim_drop": "hc", "Telemetry.Source": "UnitTest"},
+		{"dim_op": "drop", "Telemetry.Source": "UnitTest"},
+	} {
+		md := generateMetrics(dimensions)
+		_, err = ap.processMetrics(ctx, md)
+		require.NoError(t, err)
+		assert.False(t, isMetricNil(md))
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := map[string]metricdata.Sum[int64]{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m.Data.(metricdata.Sum[int64])
+	}
+	assert.NotContains(t, got, "awsapplicationsignals_datapoints_dropped")
+	dryRunDropped := map[string]int64{}
+	for _, dp := range got["awsapplicationsignals_datapoints_dry_run_dropped"].DataPoints {
+		reason, _ := dp.Attributes.Value(attributeReason)
+		dryRunDropped[reason.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{dropReasonKeep: 5, dropReasonDrop: 5}, dryRunDropped)
+	require.Len(t, got["awsapplicationsignals_datapoints_dry_run_replaced"].DataPoints, 1)
+	assert.Equal(t, int64(5), got["awsapplicationsignals_datapoints_dry_run_replaced"].DataPoints[0].Value)
+	// the data points are only logged once per interval
+	assert.Equal(t, 1, logs.FilterMessage("a rule in dry run would drop the data point").Len())
+}
+
 func TestProcessMetricsLowercase(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	ap := &awsapplicationsignalsprocessor{
@@ -131,6 +246,28 @@ func TestProcessMetricsLowercase(t *testing.T) {
 	assert.Equal(t, "Fault", lowercaseMetrics.ResourceMetrics().At(2).ScopeMetrics().At(0).Metrics().At(0).Name())
 }
 
+func TestStartWithInvalidPattern(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	ap := &awsapplicationsignalsprocessor{
+		logger: logger,
+		config: &config.Config{
+			Resolvers: []config.Resolver{config.NewGenericResolver("")},
+			Rules: []rules.Rule{
+				{
+					Selectors:    []rules.Selector{{Dimension: "Operation", Match: "*"}},
+					Replacements: []rules.Replacement{{TargetDimension: "Operation", Pattern: "[", Value: "Other"}},
+					Action:       "replace",
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	assert.ErrorContains(t, ap.StartMetrics(ctx, nil), "invalid pattern")
+	assert.ErrorContains(t, ap.StartTraces(ctx, nil), "invalid pattern")
+	assert.Nil(t, ap.limiter)
+}
+
 func TestProcessTraces(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	ap := &awsapplicationsignalsprocessor{
@@ -156,6 +293,54 @@ func TestProcessTraces(t *testing.T) {
 	assert.Equal(t, "test2", actualVal.AsString())
 }
 
+func TestProcessTracesSampling(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	telemetry, err := newProcessorTelemetry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), "awsapplicationsignals")
+	require.NoError(t, err)
+	percentage := 0.0
+	ap := &awsapplicationsignalsprocessor{
+		logger: zap.NewNop(),
+		config: &config.Config{
+			Resolvers: []config.Resolver{config.NewGenericResolver("")},
+			Rules:     testRules,
+			Sampling: &config.SamplingConfig{
+				Percentage: &percentage,
+				Rules: []rules.SamplingRule{
+					{StatusCode: "error", Percentage: 100},
+					// the selectors match the replaced attributes
+					{Selectors: []rules.Selector{{Dimension: "dim_val", Match: "test2"}}, Percentage: 100},
+				},
+			},
+		},
+		telemetry: telemetry,
+	}
+	ctx := context.Background()
+	require.NoError(t, ap.StartTraces(ctx, nil))
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	replaced := spans.AppendEmpty()
+	replaced.Attributes().PutStr("dim_action", "reserved")
+	replaced.Attributes().PutStr("dim_val", "test1")
+	spans.AppendEmpty().Attributes().PutStr("dim_val", "other")
+	failed := spans.AppendEmpty()
+	failed.Status().SetCode(ptrace.StatusCodeError)
+
+	_, err = ap.processTraces(ctx, traces)
+	require.NoError(t, err)
+	require.Equal(t, 2, spans.Len())
+	actualVal, _ := spans.At(0).Attributes().Get("dim_val")
+	assert.Equal(t, "test2", actualVal.AsString())
+	assert.Equal(t, ptrace.StatusCodeError, spans.At(1).Status().Code())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	assert.Equal(t, "awsapplicationsignals_spans_sampled_out", rm.ScopeMetrics[0].Metrics[0].Name)
+	assert.EqualValues(t, 1, rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64]).DataPoints[0].Value)
+}
+
 func generateMetrics(dimensions map[string]string) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 
@@ -269,3 +454,134 @@ func isMetricNil(m pmetric.Metrics) bool {
 	}
 	return true
 }
+
+func TestProcessConcurrently(t *testing.T) {
+	ctx := context.Background()
+	newProcessor := func(numWorkers int) *awsapplicationsignalsprocessor {
+		ap := &awsapplicationsignalsprocessor{
+			logger: zap.NewNop(),
+			config: &config.Config{
+				Resolvers:  []config.Resolver{config.NewGenericResolver("")},
+				Rules:      testRules,
+				NumWorkers: numWorkers,
+			},
+		}
+		assert.NoError(t, ap.StartMetrics(ctx, nil))
+		assert.NoError(t, ap.StartTraces(ctx, nil))
+		t.Cleanup(func() { assert.NoError(t, ap.Shutdown(ctx)) })
+		return ap
+	}
+	sequential := newProcessor(1)
+	concurrent := newProcessor(8)
+
+	metrics, traces := generateBatch(200, 5)
+	expectedMetrics := pmetric.NewMetrics()
+	metrics.CopyTo(expectedMetrics)
+	expectedTraces := ptrace.NewTraces()
+	traces.CopyTo(expectedTraces)
+
+	// The data points of a resource are shared by goroutines of the processor only if the resources are not
+	// partitioned between them, which the race detector reports.
+	_, err := sequential.processMetrics(ctx, expectedMetrics)
+	assert.NoError(t, err)
+	_, err = concurrent.processMetrics(ctx, metrics)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMetrics, metrics)
+	assert.Equal(t, 1000, metrics.ResourceMetrics().Len())
+	assert.Equal(t, "test2", getDimensionValue(t, metrics, "dim_val"))
+
+	_, err = sequential.processTraces(ctx, expectedTraces)
+	assert.NoError(t, err)
+	_, err = concurrent.processTraces(ctx, traces)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTraces, traces)
+	actualVal, _ := traces.ResourceSpans().At(199).ScopeSpans().At(0).Spans().At(4).Attributes().Get("dim_val")
+	assert.Equal(t, "test2", actualVal.AsString())
+}
+
+// generateBatch returns metrics with five resources for each of the given number of operations, and traces with a
+// resource of the given number of spans for each operation.
+func generateBatch(operations, spansPerResource int) (pmetric.Metrics, ptrace.Traces) {
+	metrics := pmetric.NewMetrics()
+	traces := ptrace.NewTraces()
+	for i := 0; i < operations; i++ {
+		generateMetrics(map[string]string{
+			attr.AWSLocalService:    "checkout",
+			attr.AWSLocalOperation:  fmt.Sprintf("GET /cart/%d", i%10),
+			attr.AWSRemoteService:   "payments",
+			attr.AWSRemoteOperation: "POST /charge",
+			"dim_action":            "reserved",
+			"dim_val":               "test1",
+			"Telemetry.Source":      "UnitTest",
+		}).ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
+
+		spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		for j := 0; j < spansPerResource; j++ {
+			span := spans.AppendEmpty()
+			span.SetKind(ptrace.SpanKindServer)
+			span.Attributes().PutStr(attr.AWSLocalService, "checkout")
+			span.Attributes().PutStr(attr.AWSLocalOperation, fmt.Sprintf("GET /cart/%d", i%10))
+			span.Attributes().PutStr("dim_action", "reserved")
+			span.Attributes().PutStr("dim_val", "test1")
+		}
+	}
+	return metrics, traces
+}
+
+func BenchmarkProcessMetrics(b *testing.B) {
+	template, _ := generateBatch(100, 0)
+	for _, numWorkers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("num_workers=%d", numWorkers), func(b *testing.B) {
+			ap := &awsapplicationsignalsprocessor{
+				logger: zap.NewNop(),
+				config: &config.Config{
+					Resolvers:  []config.Resolver{config.NewGenericResolver("")},
+					Rules:      testRules,
+					NumWorkers: numWorkers,
+				},
+			}
+			ctx := context.Background()
+			assert.NoError(b, ap.StartMetrics(ctx, nil))
+			defer ap.Shutdown(ctx)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				md := pmetric.NewMetrics()
+				template.CopyTo(md)
+				b.StartTimer()
+				_, _ = ap.processMetrics(ctx, md)
+			}
+		})
+	}
+}
+
+func BenchmarkProcessTraces(b *testing.B) {
+	_, template := generateBatch(100, 10)
+	for _, numWorkers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("num_workers=%d", numWorkers), func(b *testing.B) {
+			ap := &awsapplicationsignalsprocessor{
+				logger: zap.NewNop(),
+				config: &config.Config{
+					Resolvers:  []config.Resolver{config.NewGenericResolver("")},
+					Rules:      testRules,
+					NumWorkers: numWorkers,
+				},
+			}
+			ctx := context.Background()
+			assert.NoError(b, ap.StartTraces(ctx, nil))
+			defer ap.Shutdown(ctx)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				td := ptrace.NewTraces()
+				template.CopyTo(td)
+				b.StartTimer()
+				_, _ = ap.processTraces(ctx, td)
+			}
+		})
+	}
+}
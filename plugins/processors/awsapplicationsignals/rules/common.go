@@ -5,6 +5,8 @@ package rules
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
 
 	"github.com/gobwas/glob"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -19,16 +21,34 @@ const (
 	AllowListActionKeep    AllowListAction = "keep"
 	AllowListActionDrop    AllowListAction = "drop"
 	AllowListActionReplace AllowListAction = "replace"
+	AllowListActionHash    AllowListAction = "hash"
+)
+
+const (
+	// SelectorScopeDatapoint matches the dimension against the attributes of the data point or span, it is the
+	// default.
+	SelectorScopeDatapoint = "datapoint"
+	// SelectorScopeResource matches the dimension against the resource attributes, e.g. k8s.namespace.name.
+	SelectorScopeResource = "resource"
+	// SelectorDimensionSpanKind matches the kind of the span the data point or span comes from, i.e. SERVER, CLIENT,
+	// PRODUCER, CONSUMER or LOCAL_ROOT, rather than a dimension.
+	SelectorDimensionSpanKind = "SpanKind"
 )
 
 type Selector struct {
 	Dimension string `mapstructure:"dimension"`
 	Match     string `mapstructure:"match"`
+	// Scope is the attributes the dimension is looked up in, SelectorScopeDatapoint when it is empty.
+	Scope string `mapstructure:"scope,omitempty"`
 }
 
 type Replacement struct {
 	TargetDimension string `mapstructure:"target_dimension"`
 	Value           string `mapstructure:"value"`
+	// Pattern is a regex matched against the current value of the target dimension. When set, Value is the template
+	// the matches are replaced with, e.g. "GET /users/{id}" for `^GET /users/\d+$`, and can refer to the capture
+	// groups with $1 or ${name}.
+	Pattern string `mapstructure:"pattern,omitempty"`
 }
 
 type Rule struct {
@@ -36,16 +56,42 @@ type Rule struct {
 	Replacements []Replacement   `mapstructure:"replacements,omitempty"`
 	Action       AllowListAction `mapstructure:"action"`
 	RuleName     string          `mapstructure:"rule_name,omitempty"`
+	// DryRun keeps the data points a keep or drop rule would drop, and the dimensions a replace rule would replace,
+	// and only counts them, so that the rule can be checked before it is enforced.
+	DryRun bool `mapstructure:"dry_run,omitempty"`
+	// Audit is an alias of DryRun.
+	Audit bool `mapstructure:"audit,omitempty"`
+	// HashDimensions are the dimensions a hash rule replaces with the salted hash of their value.
+	HashDimensions []string `mapstructure:"hash_dimensions,omitempty"`
+	// Salt is prepended to the values before they are hashed, so that the hashes cannot be matched against the
+	// hashes of guessed values without it.
+	Salt string `mapstructure:"salt,omitempty"`
 }
 
 type SelectorMatcherItem struct {
 	Key     string
 	Matcher glob.Glob
+	// Resource is set when the key is looked up in the resource attributes.
+	Resource bool
 }
 
 type ActionItem struct {
 	SelectorMatchers []SelectorMatcherItem
 	Replacements     []Replacement `mapstructure:",omitempty"`
+	// Patterns are the compiled patterns of the replacements, nil for the ones without a pattern.
+	Patterns       []*regexp.Regexp
+	DryRun         bool
+	HashDimensions []string
+	Salt           string
+}
+
+// telemetrySourceSpanKinds maps the Telemetry.Source dimension of the metrics to the kind of the span they come from.
+var telemetrySourceSpanKinds = map[string]string{
+	"ClientSpan":    "CLIENT",
+	"ServerSpan":    "SERVER",
+	"ProducerSpan":  "PRODUCER",
+	"ConsumerSpan":  "CONSUMER",
+	"LocalRootSpan": "LOCAL_ROOT",
 }
 
 var traceKeyMap = map[string]string{
@@ -67,6 +113,8 @@ func GetAllowListAction(action string) (AllowListAction, error) {
 		return AllowListActionKeep, nil
 	case "replace":
 		return AllowListActionReplace, nil
+	case "hash":
+		return AllowListActionHash, nil
 	}
 	return "", errors.New("invalid action in rule")
 }
@@ -79,10 +127,17 @@ func convertToManagedAttributeKey(attributeKey string, isTrace bool) string {
 	return attributeKey
 }
 
-func matchesSelectors(attributes pcommon.Map, selectorMatchers []SelectorMatcherItem, isTrace bool) bool {
+func matchesSelectors(attributes, resourceAttributes pcommon.Map, selectorMatchers []SelectorMatcherItem, isTrace bool) bool {
 	for _, item := range selectorMatchers {
-		exactKey := convertToManagedAttributeKey(item.Key, isTrace)
-		value, ok := attributes.Get(exactKey)
+		var value pcommon.Value
+		var ok bool
+		if item.Resource {
+			value, ok = resourceAttributes.Get(item.Key)
+		} else if item.Key == SelectorDimensionSpanKind {
+			value, ok = spanKind(attributes, isTrace)
+		} else {
+			value, ok = attributes.Get(convertToManagedAttributeKey(item.Key, isTrace))
+		}
 		if !ok {
 			return false
 		}
@@ -93,12 +148,27 @@ func matchesSelectors(attributes pcommon.Map, selectorMatchers []SelectorMatcher
 	return true
 }
 
+// spanKind returns the kind of the span, which is the aws.span.kind attribute of the spans, and is only kept in the
+// Telemetry.Source dimension of the metrics once they are normalized.
+func spanKind(attrs pcommon.Map, isTrace bool) (pcommon.Value, bool) {
+	if value, ok := attrs.Get(attributes.AWSSpanKind); ok || isTrace {
+		return value, ok
+	}
+	source, ok := attrs.Get(common.MetricAttributeTelemetrySource)
+	if !ok {
+		return pcommon.Value{}, false
+	}
+	kind, ok := telemetrySourceSpanKinds[source.Str()]
+	return pcommon.NewValueStr(kind), ok
+}
+
 func generateSelectorMatchers(selectors []Selector) []SelectorMatcherItem {
 	var selectorMatchers []SelectorMatcherItem
 	for _, selector := range selectors {
 		selectorMatcherItem := SelectorMatcherItem{
-			selector.Dimension,
-			glob.MustCompile(selector.Match),
+			Key:      selector.Dimension,
+			Matcher:  glob.MustCompile(selector.Match),
+			Resource: selector.Scope == SelectorScopeResource,
 		}
 		selectorMatchers = append(selectorMatchers, selectorMatcherItem)
 	}
@@ -111,8 +181,11 @@ func generateActionDetails(rules []Rule, action AllowListAction) []ActionItem {
 		if rule.Action == action {
 			var selectorMatchers = generateSelectorMatchers(rule.Selectors)
 			actionItem := ActionItem{
-				selectorMatchers,
-				rule.Replacements,
+				SelectorMatchers: selectorMatchers,
+				Replacements:     rule.Replacements,
+				DryRun:           rule.DryRun || rule.Audit,
+				HashDimensions:   rule.HashDimensions,
+				Salt:             rule.Salt,
 			}
 			actionItems = append(actionItems, actionItem)
 		}
@@ -120,3 +193,34 @@ func generateActionDetails(rules []Rule, action AllowListAction) []ActionItem {
 
 	return actionItems
 }
+
+// validateScopes returns an error if a selector has an unknown scope.
+func validateScopes(selectors []Selector) error {
+	for _, selector := range selectors {
+		switch selector.Scope {
+		case "", SelectorScopeDatapoint, SelectorScopeResource:
+		default:
+			return fmt.Errorf("invalid scope %q for dimension %s, must be %s or %s", selector.Scope, selector.Dimension, SelectorScopeResource, SelectorScopeDatapoint)
+		}
+	}
+	return nil
+}
+
+// compilePatterns compiles the patterns of the replacements of each action item.
+func compilePatterns(actionItems []ActionItem) error {
+	for i := range actionItems {
+		patterns := make([]*regexp.Regexp, len(actionItems[i].Replacements))
+		for j, replacement := range actionItems[i].Replacements {
+			if replacement.Pattern == "" {
+				continue
+			}
+			pattern, err := regexp.Compile(replacement.Pattern)
+			if err != nil {
+				return fmt.Errorf("invalid pattern %q for target dimension %s: %w", replacement.Pattern, replacement.TargetDimension, err)
+			}
+			patterns[j] = pattern
+		}
+		actionItems[i].Patterns = patterns
+	}
+	return nil
+}
@@ -15,13 +15,16 @@ func NewDropper(rules []Rule) *DropActions {
 	}
 }
 
-func (d *DropActions) ShouldBeDropped(attributes pcommon.Map) (bool, error) {
+func (d *DropActions) ShouldBeDropped(attributes, resourceAttributes pcommon.Map) (bool, error) {
 	// nothing will be dropped if no rule is defined
 	if d.Actions == nil || len(d.Actions) == 0 {
 		return false, nil
 	}
 	for _, element := range d.Actions {
-		isMatched := matchesSelectors(attributes, element.SelectorMatchers, false)
+		if element.DryRun {
+			continue
+		}
+		isMatched := matchesSelectors(attributes, resourceAttributes, element.SelectorMatchers, false)
 		if isMatched {
 			// drop the datapoint as one of drop rules is matched
 			return true, nil
@@ -29,3 +32,13 @@ func (d *DropActions) ShouldBeDropped(attributes pcommon.Map) (bool, error) {
 	}
 	return false, nil
 }
+
+// WouldBeDropped reports whether one of the drop rules in dry run matches the datapoint.
+func (d *DropActions) WouldBeDropped(attributes, resourceAttributes pcommon.Map) bool {
+	for _, element := range d.Actions {
+		if element.DryRun && matchesSelectors(attributes, resourceAttributes, element.SelectorMatchers, false) {
+			return true
+		}
+	}
+	return false
+}
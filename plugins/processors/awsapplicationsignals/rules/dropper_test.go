@@ -8,6 +8,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
 )
 
 type TestCaseForDropper struct {
@@ -106,7 +109,7 @@ func TestDropperProcessor(t *testing.T) {
 	for i := range testCases {
 		tt := testCases[i]
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := testDropper.ShouldBeDropped(tt.input)
+			result, err := testDropper.ShouldBeDropped(tt.input, pcommon.NewMap())
 			assert.NoError(t, err)
 			assert.Equal(t, tt.output, result)
 		})
@@ -138,7 +141,7 @@ func TestDropperProcessorWithNilConfig(t *testing.T) {
 	for i := range testCases {
 		tt := testCases[i]
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := testDropper.ShouldBeDropped(tt.input)
+			result, err := testDropper.ShouldBeDropped(tt.input, pcommon.NewMap())
 			assert.NoError(t, err)
 			assert.Equal(t, tt.output, result)
 		})
@@ -172,9 +175,134 @@ func TestDropperProcessorWithEmptyConfig(t *testing.T) {
 	for i := range testCases {
 		tt := testCases[i]
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := testDropper.ShouldBeDropped(tt.input)
+			result, err := testDropper.ShouldBeDropped(tt.input, pcommon.NewMap())
 			assert.NoError(t, err)
 			assert.Equal(t, tt.output, result)
 		})
 	}
 }
+
+func TestDropperProcessorWithDryRun(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "Operation",
+					Match:     "GET /health",
+				},
+			},
+			Action: "drop",
+			DryRun: true,
+		},
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "RemoteService",
+					Match:     "customer-*",
+				},
+			},
+			Action: "drop",
+		},
+	}
+
+	testDropper := NewDropper(config)
+	healthCheck := generateTestAttributes("common-test", "GET /health", "visit-test-service", "GET /visit", false)
+	dropped, err := testDropper.ShouldBeDropped(healthCheck, pcommon.NewMap())
+	assert.NoError(t, err)
+	assert.False(t, dropped)
+	assert.True(t, testDropper.WouldBeDropped(healthCheck, pcommon.NewMap()))
+
+	customer := generateTestAttributes("common-test", "GET /owners", "customer-test", "GET /owners", false)
+	dropped, err = testDropper.ShouldBeDropped(customer, pcommon.NewMap())
+	assert.NoError(t, err)
+	assert.True(t, dropped)
+	assert.False(t, testDropper.WouldBeDropped(customer, pcommon.NewMap()))
+}
+
+func TestDropperProcessorWithResourceScope(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "k8s.namespace.name",
+					Match:     "dev",
+					Scope:     SelectorScopeResource,
+				},
+			},
+			Action: "drop",
+		},
+	}
+
+	testDropper := NewDropper(config)
+	attributes := generateTestAttributes("common-test", "GET /owners", "customer-test", "GET /owners", false)
+	dev := pcommon.NewMap()
+	dev.PutStr("k8s.namespace.name", "dev")
+	dropped, err := testDropper.ShouldBeDropped(attributes, dev)
+	assert.NoError(t, err)
+	assert.True(t, dropped)
+
+	prod := pcommon.NewMap()
+	prod.PutStr("k8s.namespace.name", "prod")
+	dropped, err = testDropper.ShouldBeDropped(attributes, prod)
+	assert.NoError(t, err)
+	assert.False(t, dropped)
+
+	// the datapoint attributes are not looked up for a resource selector
+	attributes.PutStr("k8s.namespace.name", "dev")
+	dropped, err = testDropper.ShouldBeDropped(attributes, pcommon.NewMap())
+	assert.NoError(t, err)
+	assert.False(t, dropped)
+}
+
+func TestDropperProcessorWithSpanKind(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "k8s.namespace.name",
+					Match:     "dev",
+					Scope:     SelectorScopeResource,
+				},
+				{
+					Dimension: SelectorDimensionSpanKind,
+					Match:     "CLIENT",
+				},
+			},
+			Action: "drop",
+		},
+	}
+
+	testDropper := NewDropper(config)
+	dev := pcommon.NewMap()
+	dev.PutStr("k8s.namespace.name", "dev")
+
+	// the normalized metrics only keep the span kind in their Telemetry.Source
+	client := generateTestAttributes("common-test", "GET /owners", "customer-test", "GET /owners", false)
+	client.PutStr(common.MetricAttributeTelemetrySource, "ClientSpan")
+	dropped, err := testDropper.ShouldBeDropped(client, dev)
+	assert.NoError(t, err)
+	assert.True(t, dropped)
+
+	server := generateTestAttributes("common-test", "GET /owners", "", "", false)
+	server.PutStr(common.MetricAttributeTelemetrySource, "ServerSpan")
+	dropped, err = testDropper.ShouldBeDropped(server, dev)
+	assert.NoError(t, err)
+	assert.False(t, dropped)
+
+	// the metrics that are not normalized yet have the aws.span.kind attribute
+	unnormalized := generateTestAttributes("common-test", "GET /owners", "customer-test", "GET /owners", false)
+	unnormalized.PutStr(attr.AWSSpanKind, "CLIENT")
+	dropped, err = testDropper.ShouldBeDropped(unnormalized, dev)
+	assert.NoError(t, err)
+	assert.True(t, dropped)
+
+	// the span kind without the namespace does not match
+	dropped, err = testDropper.ShouldBeDropped(client, pcommon.NewMap())
+	assert.NoError(t, err)
+	assert.False(t, dropped)
+
+	// neither does a data point without a span kind
+	dropped, err = testDropper.ShouldBeDropped(generateTestAttributes("common-test", "GET /owners", "", "", false), dev)
+	assert.NoError(t, err)
+	assert.False(t, dropped)
+}
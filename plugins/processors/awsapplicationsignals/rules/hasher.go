@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package rules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// hashLength is the number of hex characters of the hashes, which is enough to keep the values apart while keeping
+// the dimensions short.
+const hashLength = 16
+
+type HashActions struct {
+	Actions []ActionItem
+}
+
+// NewHasher returns an error if a hash rule has no hash dimensions, or is in dry run, which only keep, drop and
+// replace rules support.
+func NewHasher(rules []Rule) (*HashActions, error) {
+	actions := generateActionDetails(rules, AllowListActionHash)
+	for _, action := range actions {
+		if action.DryRun {
+			return nil, errors.New("dry_run is only supported by keep, drop and replace rules")
+		}
+		if len(action.HashDimensions) == 0 {
+			return nil, errors.New("hash action set, but no hash_dimensions defined for rule")
+		}
+	}
+	return &HashActions{Actions: actions}, nil
+}
+
+// Process replaces the values of the hash dimensions with their salted hash. The hash of a value is stable, so the
+// number of distinct values of a dimension, e.g. a user identifier, can still be analyzed without storing them.
+func (h *HashActions) Process(attributes, resourceAttributes pcommon.Map, isTrace bool) error {
+	// do nothing when there is no hash rule defined
+	if len(h.Actions) == 0 {
+		return nil
+	}
+	// If there are more than one rule are matched, the last one will be executed(Later one has higher priority)
+	finalSalts := make(map[string]string)
+	for i := len(h.Actions) - 1; i >= 0; i = i - 1 {
+		element := h.Actions[i]
+		if !matchesSelectors(attributes, resourceAttributes, element.SelectorMatchers, isTrace) {
+			continue
+		}
+		for _, dimension := range element.HashDimensions {
+			attr := convertToManagedAttributeKey(dimension, isTrace)
+			if _, visited := finalSalts[attr]; !visited {
+				finalSalts[attr] = element.Salt
+			}
+		}
+	}
+
+	for key, salt := range finalSalts {
+		if value, ok := attributes.Get(key); ok {
+			attributes.PutStr(key, hashValue(salt, value.AsString()))
+		}
+	}
+	return nil
+}
+
+func hashValue(salt, value string) string {
+	sum := sha256.Sum256([]byte(salt + value))
+	return hex.EncodeToString(sum[:])[:hashLength]
+}
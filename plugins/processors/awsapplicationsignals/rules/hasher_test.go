@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
+)
+
+func TestHasherProcess(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors:      []Selector{{Dimension: "Service", Match: "checkout"}},
+			Action:         AllowListActionHash,
+			HashDimensions: []string{"Operation", "user.id"},
+			Salt:           "first",
+		},
+		{
+			Selectors:    []Selector{{Dimension: "Service", Match: "*"}},
+			Replacements: []Replacement{{TargetDimension: "Operation", Value: "Other"}},
+			Action:       AllowListActionReplace,
+		},
+		{
+			Selectors:      []Selector{{Dimension: "Service", Match: "*"}},
+			Action:         AllowListActionHash,
+			HashDimensions: []string{"user.id"},
+			Salt:           "second",
+		},
+	}
+	hasher, err := NewHasher(config)
+	require.NoError(t, err)
+	assert.Len(t, hasher.Actions, 2)
+
+	attr := pcommon.NewMap()
+	attr.PutStr("Service", "checkout")
+	attr.PutStr("Operation", "GET /users/42")
+	attr.PutStr("user.id", "42")
+	assert.NoError(t, hasher.Process(attr, pcommon.NewMap(), false))
+	operation, _ := attr.Get("Operation")
+	assert.Equal(t, hashValue("first", "GET /users/42"), operation.Str())
+	assert.Len(t, operation.Str(), hashLength)
+	// the later rule has higher priority
+	userID, _ := attr.Get("user.id")
+	assert.Equal(t, hashValue("second", "42"), userID.Str())
+	service, _ := attr.Get("Service")
+	assert.Equal(t, "checkout", service.Str())
+
+	// the hash is stable, and the missing dimensions are not added
+	other := pcommon.NewMap()
+	other.PutStr("Service", "payments")
+	other.PutStr("user.id", "42")
+	assert.NoError(t, hasher.Process(other, pcommon.NewMap(), false))
+	otherUserID, _ := other.Get("user.id")
+	assert.Equal(t, userID.Str(), otherUserID.Str())
+	assert.Equal(t, 2, other.Len())
+
+	// the dimensions of the spans are looked up by their attribute
+	span := pcommon.NewMap()
+	span.PutStr(attributes.AWSLocalService, "checkout")
+	span.PutStr(attributes.AWSLocalOperation, "GET /users/42")
+	assert.NoError(t, hasher.Process(span, pcommon.NewMap(), true))
+	spanOperation, _ := span.Get(attributes.AWSLocalOperation)
+	assert.Equal(t, operation.Str(), spanOperation.Str())
+	assert.NotEqual(t, hashValue("other", "GET /users/42"), operation.Str())
+}
+
+func TestNewHasherInvalid(t *testing.T) {
+	_, err := NewHasher([]Rule{{Action: AllowListActionHash}})
+	assert.ErrorContains(t, err, "no hash_dimensions")
+	_, err = NewHasher([]Rule{{Action: AllowListActionHash, HashDimensions: []string{"user.id"}, DryRun: true}})
+	assert.ErrorContains(t, err, "dry_run")
+}
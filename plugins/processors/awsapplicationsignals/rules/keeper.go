@@ -12,22 +12,36 @@ import (
 type KeepActions struct {
 	Actions                 []ActionItem
 	markDataPointAsReserved bool
+	// enforced and dryRun are set when at least one of the keep rules is enforced or in dry run.
+	enforced bool
+	dryRun   bool
 }
 
 func NewKeeper(rules []Rule, markDataPointAsReserved bool) *KeepActions {
-	return &KeepActions{
+	k := &KeepActions{
 		Actions:                 generateActionDetails(rules, AllowListActionKeep),
 		markDataPointAsReserved: markDataPointAsReserved,
 	}
+	for _, element := range k.Actions {
+		if element.DryRun {
+			k.dryRun = true
+		} else {
+			k.enforced = true
+		}
+	}
+	return k
 }
 
-func (k *KeepActions) ShouldBeDropped(attributes pcommon.Map) (bool, error) {
-	// nothing will be dropped if no keep rule is defined
-	if k.Actions == nil || len(k.Actions) == 0 {
+func (k *KeepActions) ShouldBeDropped(attributes, resourceAttributes pcommon.Map) (bool, error) {
+	// nothing will be dropped if no keep rule is enforced
+	if !k.enforced {
 		return false, nil
 	}
 	for _, element := range k.Actions {
-		isMatched := matchesSelectors(attributes, element.SelectorMatchers, false)
+		if element.DryRun {
+			continue
+		}
+		isMatched := matchesSelectors(attributes, resourceAttributes, element.SelectorMatchers, false)
 		if k.markDataPointAsReserved {
 			attributes.PutBool(common.AttributeTmpReserved, true)
 		}
@@ -38,3 +52,17 @@ func (k *KeepActions) ShouldBeDropped(attributes pcommon.Map) (bool, error) {
 	}
 	return true, nil
 }
+
+// WouldBeDropped reports whether the datapoint would be dropped if the keep rules in dry run were enforced. Adding a
+// keep rule only keeps more datapoints, so they can only drop datapoints while no keep rule is enforced.
+func (k *KeepActions) WouldBeDropped(attributes, resourceAttributes pcommon.Map) bool {
+	if k.enforced || !k.dryRun {
+		return false
+	}
+	for _, element := range k.Actions {
+		if matchesSelectors(attributes, resourceAttributes, element.SelectorMatchers, false) {
+			return false
+		}
+	}
+	return true
+}
@@ -94,7 +94,7 @@ func TestKeeperProcessor(t *testing.T) {
 	for i := range testCases {
 		tt := testCases[i]
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := testKeeper.ShouldBeDropped(tt.input)
+			result, err := testKeeper.ShouldBeDropped(tt.input, pcommon.NewMap())
 			assert.NoError(t, err)
 			assert.Equal(t, tt.output, result)
 		})
@@ -130,7 +130,7 @@ func TestKeeperProcessorWithNilConfig(t *testing.T) {
 	for i := range testCases {
 		tt := testCases[i]
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := testKeeper.ShouldBeDropped(tt.input)
+			result, err := testKeeper.ShouldBeDropped(tt.input, pcommon.NewMap())
 			assert.NoError(t, err)
 			assert.Equal(t, tt.output, result)
 		})
@@ -169,9 +169,50 @@ func TestKeeperProcessorWithEmptyConfig(t *testing.T) {
 	for i := range testCases {
 		tt := testCases[i]
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := testKeeper.ShouldBeDropped(tt.input)
+			result, err := testKeeper.ShouldBeDropped(tt.input, pcommon.NewMap())
 			assert.NoError(t, err)
 			assert.Equal(t, tt.output, result)
 		})
 	}
 }
+
+func TestKeeperProcessorWithDryRun(t *testing.T) {
+	dryRunRule := Rule{
+		Selectors: []Selector{
+			{
+				Dimension: "Operation",
+				Match:     "PUT *",
+			},
+		},
+		Action: "keep",
+		DryRun: true,
+	}
+	kept := generateTestAttributes("common-test", "PUT /owners", "visit-test-service", "GET /visit", false)
+	other := generateTestAttributes("common-test", "GET /owners", "visit-test-service", "GET /visit", false)
+
+	// the rule in dry run is the only keep rule, so it would drop the datapoints it does not match
+	testKeeper := NewKeeper([]Rule{dryRunRule}, false)
+	for _, attributes := range []pcommon.Map{kept, other} {
+		dropped, err := testKeeper.ShouldBeDropped(attributes, pcommon.NewMap())
+		assert.NoError(t, err)
+		assert.False(t, dropped)
+	}
+	assert.False(t, testKeeper.WouldBeDropped(kept, pcommon.NewMap()))
+	assert.True(t, testKeeper.WouldBeDropped(other, pcommon.NewMap()))
+
+	// with an enforced keep rule, the rule in dry run can only keep more datapoints
+	testKeeper = NewKeeper([]Rule{dryRunRule, {
+		Selectors: []Selector{
+			{
+				Dimension: "Operation",
+				Match:     "GET *",
+			},
+		},
+		Action: "keep",
+	}}, false)
+	dropped, err := testKeeper.ShouldBeDropped(kept, pcommon.NewMap())
+	assert.NoError(t, err)
+	assert.True(t, dropped)
+	assert.False(t, testKeeper.WouldBeDropped(kept, pcommon.NewMap()))
+	assert.False(t, testKeeper.WouldBeDropped(other, pcommon.NewMap()))
+}
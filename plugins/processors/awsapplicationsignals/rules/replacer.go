@@ -14,44 +14,85 @@ type ReplaceActions struct {
 	markDataPointAsReserved bool
 }
 
-func NewReplacer(rules []Rule, markDataPointAsReserved bool) *ReplaceActions {
+// NewReplacer returns an error if the pattern of a replacement is not a valid regex. It also returns an error if a
+// selector of any rule, including the keep and drop rules, has an unknown scope, since the rules are compiled with
+// NewReplacer first.
+func NewReplacer(rules []Rule, markDataPointAsReserved bool) (*ReplaceActions, error) {
+	for _, rule := range rules {
+		if err := validateScopes(rule.Selectors); err != nil {
+			return nil, err
+		}
+	}
+	actions := generateActionDetails(rules, AllowListActionReplace)
+	if err := compilePatterns(actions); err != nil {
+		return nil, err
+	}
 	return &ReplaceActions{
-		Actions:                 generateActionDetails(rules, AllowListActionReplace),
+		Actions:                 actions,
 		markDataPointAsReserved: markDataPointAsReserved,
-	}
+	}, nil
 }
 
-func (r *ReplaceActions) Process(attributes, _ pcommon.Map, isTrace bool) error {
+func (r *ReplaceActions) Process(attributes, resourceAttributes pcommon.Map, isTrace bool) error {
 	// do nothing when there is no replace rule defined
 	if r.Actions == nil || len(r.Actions) == 0 {
 		return nil
 	}
+	finalRules := r.replacements(attributes, resourceAttributes, isTrace, false)
+	for key, value := range finalRules {
+		attributes.PutStr(key, value)
+	}
+
+	if len(finalRules) > 0 && r.markDataPointAsReserved {
+		attributes.PutBool(common.AttributeTmpReserved, true)
+	}
+	return nil
+}
+
+// WouldBeReplaced reports whether the replace rules in dry run would change a dimension of the datapoint.
+func (r *ReplaceActions) WouldBeReplaced(attributes, resourceAttributes pcommon.Map, isTrace bool) bool {
+	for key, value := range r.replacements(attributes, resourceAttributes, isTrace, true) {
+		if current, ok := attributes.Get(key); !ok || current.AsString() != value {
+			return true
+		}
+	}
+	return false
+}
+
+// replacements returns the values the matching rules, either the enforced ones or the ones in dry run, replace the
+// dimensions with.
+func (r *ReplaceActions) replacements(attributes, resourceAttributes pcommon.Map, isTrace bool, dryRun bool) map[string]string {
 	// If there are more than one rule are matched, the last one will be executed(Later one has higher priority)
 	actions := r.Actions
 	finalRules := make(map[string]string)
 	for i := len(actions) - 1; i >= 0; i = i - 1 {
 		element := actions[i]
-		isMatched := matchesSelectors(attributes, element.SelectorMatchers, isTrace)
+		if element.DryRun != dryRun {
+			continue
+		}
+		isMatched := matchesSelectors(attributes, resourceAttributes, element.SelectorMatchers, isTrace)
 		if !isMatched {
 			continue
 		}
-		for _, replacement := range element.Replacements {
+		for j, replacement := range element.Replacements {
 			targetDimension := replacement.TargetDimension
 
 			attr := convertToManagedAttributeKey(targetDimension, isTrace)
 			// every replacement in one specific dimension only will be performed once
-			if _, visited := finalRules[attr]; !visited {
-				finalRules[attr] = replacement.Value
+			if _, visited := finalRules[attr]; visited {
+				continue
+			}
+			value := replacement.Value
+			if j < len(element.Patterns) && element.Patterns[j] != nil {
+				// a pattern that does not match leaves the dimension to the rules with lower priority
+				current, ok := attributes.Get(attr)
+				if !ok || !element.Patterns[j].MatchString(current.AsString()) {
+					continue
+				}
+				value = element.Patterns[j].ReplaceAllString(current.AsString(), replacement.Value)
 			}
+			finalRules[attr] = value
 		}
 	}
-
-	for key, value := range finalRules {
-		attributes.PutStr(key, value)
-	}
-
-	if len(finalRules) > 0 && r.markDataPointAsReserved {
-		attributes.PutBool(common.AttributeTmpReserved, true)
-	}
-	return nil
+	return finalRules
 }
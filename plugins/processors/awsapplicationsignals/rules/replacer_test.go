@@ -8,6 +8,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/common"
+	attr "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/attributes"
 )
 
 type TestCaseForReplacer struct {
@@ -71,7 +74,8 @@ func TestReplacerProcess(t *testing.T) {
 		},
 	}
 
-	testReplacer := NewReplacer(config, false)
+	testReplacer, err := NewReplacer(config, false)
+	assert.NoError(t, err)
 	assert.Equal(t, 1, len(testReplacer.Actions))
 
 	testCases := []TestCaseForReplacer{
@@ -142,7 +146,8 @@ func TestAddManagedDimensionKey(t *testing.T) {
 		},
 	}
 
-	testReplacer := NewReplacer(config, false)
+	testReplacer, err := NewReplacer(config, false)
+	assert.NoError(t, err)
 	assert.Equal(t, 1, len(testReplacer.Actions))
 
 	testCases := []TestCaseForReplacer{
@@ -241,7 +246,8 @@ func TestReplacerProcessWithPriority(t *testing.T) {
 		},
 	}
 
-	testReplacer := NewReplacer(config, false)
+	testReplacer, err := NewReplacer(config, false)
+	assert.NoError(t, err)
 	testMapPlaceHolder := pcommon.NewMap()
 
 	testCases := []TestCaseForReplacer{
@@ -289,7 +295,8 @@ func TestReplacerProcessWithPriority(t *testing.T) {
 
 func TestReplacerProcessWithNilConfig(t *testing.T) {
 
-	testReplacer := NewReplacer(nil, false)
+	testReplacer, err := NewReplacer(nil, false)
+	assert.NoError(t, err)
 	testMapPlaceHolder := pcommon.NewMap()
 
 	testCases := []TestCaseForReplacer{
@@ -323,7 +330,8 @@ func TestReplacerProcessWithEmptyConfig(t *testing.T) {
 
 	config := []Rule{}
 
-	testReplacer := NewReplacer(config, false)
+	testReplacer, err := NewReplacer(config, false)
+	assert.NoError(t, err)
 	testMapPlaceHolder := pcommon.NewMap()
 
 	testCases := []TestCaseForReplacer{
@@ -352,3 +360,221 @@ func TestReplacerProcessWithEmptyConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestReplacerProcessWithPattern(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "RemoteOperation",
+					Match:     "*",
+				},
+			},
+			Replacements: []Replacement{
+				{
+					TargetDimension: "RemoteOperation",
+					Value:           "Other",
+				},
+			},
+			Action: "replace",
+		},
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "RemoteService",
+					Match:     "customer-test",
+				},
+			},
+			Replacements: []Replacement{
+				{
+					TargetDimension: "RemoteOperation",
+					Pattern:         `^(GET|PUT) /users/\d+$`,
+					Value:           "$1 /users/{id}",
+				},
+				{
+					TargetDimension: "Operation",
+					Pattern:         `/owners/\d+`,
+					Value:           "/owners/{id}",
+				},
+			},
+			Action: "replace",
+		},
+	}
+
+	testReplacer, err := NewReplacer(config, false)
+	assert.NoError(t, err)
+	testMapPlaceHolder := pcommon.NewMap()
+
+	testCases := []TestCaseForReplacer{
+		{
+			name: "test01TraceMatch",
+			input: generateTestAttributes("replace-test", "PUT /api/visits/owners/12345", "customer-test",
+				"GET /users/12345", true),
+			output: generateTestAttributes("replace-test", "PUT /api/visits/owners/{id}", "customer-test",
+				"GET /users/{id}", true),
+			isTrace: true,
+		},
+		{
+			name: "test02MetricMatch",
+			input: generateTestAttributes("replace-test", "PUT /api/visits/owners/12345", "customer-test",
+				"PUT /users/678", false),
+			output: generateTestAttributes("replace-test", "PUT /api/visits/owners/{id}", "customer-test",
+				"PUT /users/{id}", false),
+			isTrace: false,
+		},
+		{
+			// the pattern does not match, so the rule with the lower priority replaces the remote operation
+			name: "test03MetricPatternNotMatch",
+			input: generateTestAttributes("replace-test", "PUT /api/visits", "customer-test",
+				"DELETE /users/678", false),
+			output: generateTestAttributes("replace-test", "PUT /api/visits", "customer-test",
+				"Other", false),
+			isTrace: false,
+		},
+		{
+			name: "test04TraceSelectorNotMatch",
+			input: generateTestAttributes("replace-test", "PUT /api/visits/owners/12345", "another-service",
+				"GET /users/12345", true),
+			output: generateTestAttributes("replace-test", "PUT /api/visits/owners/12345", "another-service",
+				"Other", true),
+			isTrace: true,
+		},
+	}
+	for i := range testCases {
+		tt := testCases[i]
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, testReplacer.Process(tt.input, testMapPlaceHolder, tt.isTrace))
+			assert.Equal(t, tt.output, tt.input)
+		})
+	}
+}
+
+func TestReplacerWithInvalidPattern(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "RemoteOperation",
+					Match:     "*",
+				},
+			},
+			Replacements: []Replacement{
+				{
+					TargetDimension: "RemoteOperation",
+					Pattern:         `GET /users/(\d+`,
+					Value:           "GET /users/{id}",
+				},
+			},
+			Action: "replace",
+		},
+	}
+
+	_, err := NewReplacer(config, false)
+	assert.ErrorContains(t, err, `invalid pattern "GET /users/(\\d+" for target dimension RemoteOperation`)
+}
+
+func TestReplacerWithDryRun(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "RemoteOperation",
+					Match:     "*",
+				},
+			},
+			Replacements: []Replacement{
+				{
+					TargetDimension: "RemoteOperation",
+					Value:           "GET /users/{id}",
+				},
+			},
+			Action: "replace",
+			DryRun: true,
+		},
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "Operation",
+					Match:     "GET *",
+				},
+			},
+			Replacements: []Replacement{
+				{
+					TargetDimension: "Operation",
+					Value:           "GET",
+				},
+			},
+			Action: "replace",
+			Audit:  true,
+		},
+	}
+
+	testReplacer, err := NewReplacer(config, true)
+	assert.NoError(t, err)
+
+	// the rules in dry run replace nothing
+	attributes := generateTestAttributes("common-test", "GET /owners", "customer-test", "GET /users/1", false)
+	assert.NoError(t, testReplacer.Process(attributes, pcommon.NewMap(), false))
+	assert.Equal(t, generateTestAttributes("common-test", "GET /owners", "customer-test", "GET /users/1", false), attributes)
+	assert.True(t, testReplacer.WouldBeReplaced(attributes, pcommon.NewMap(), false))
+
+	// a replacement that keeps the value is not reported
+	attributes = generateTestAttributes("common-test", "POST /owners", "customer-test", "GET /users/{id}", false)
+	assert.False(t, testReplacer.WouldBeReplaced(attributes, pcommon.NewMap(), false))
+	attributes = generateTestAttributes("common-test", "GET /owners", "customer-test", "GET /users/{id}", false)
+	assert.True(t, testReplacer.WouldBeReplaced(attributes, pcommon.NewMap(), false))
+}
+
+func TestReplacerWithInvalidScope(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors: []Selector{
+				{
+					Dimension: "k8s.namespace.name",
+					Match:     "dev",
+					Scope:     "metric",
+				},
+			},
+			Action: "drop",
+		},
+	}
+
+	_, err := NewReplacer(config, false)
+	assert.ErrorContains(t, err, `invalid scope "metric"`)
+}
+
+func TestReplacerProcessWithSpanKind(t *testing.T) {
+	config := []Rule{
+		{
+			Selectors: []Selector{
+				{
+					Dimension: SelectorDimensionSpanKind,
+					Match:     "SERVER",
+				},
+			},
+			Replacements: []Replacement{
+				{
+					TargetDimension: "Operation",
+					Value:           "Other",
+				},
+			},
+			Action: "replace",
+		},
+	}
+
+	testReplacer, err := NewReplacer(config, false)
+	assert.NoError(t, err)
+
+	server := generateTestAttributes("common-test", "GET /owners/1", "", "", true)
+	server.PutStr(attr.AWSSpanKind, "SERVER")
+	assert.NoError(t, testReplacer.Process(server, pcommon.NewMap(), true))
+	operation, _ := server.Get(attr.AWSLocalOperation)
+	assert.Equal(t, "Other", operation.Str())
+
+	// the Telemetry.Source dimension is only looked up for the metrics
+	withSource := generateTestAttributes("common-test", "GET /owners/1", "", "", true)
+	withSource.PutStr(common.MetricAttributeTelemetrySource, "ServerSpan")
+	assert.NoError(t, testReplacer.Process(withSource, pcommon.NewMap(), true))
+	operation, _ = withSource.Get(attr.AWSLocalOperation)
+	assert.Equal(t, "GET /owners/1", operation.Str())
+}
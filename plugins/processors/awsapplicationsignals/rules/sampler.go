@@ -0,0 +1,105 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// samplingPrecision is the number of buckets the trace IDs are hashed into, so that the percentages can have two
+// decimals.
+const samplingPrecision = 10000
+
+// SamplingRule keeps the percentage of the spans matching all of its selectors and its status code.
+type SamplingRule struct {
+	Selectors []Selector `mapstructure:"selectors,omitempty"`
+	// StatusCode is the status of the matching spans: error, ok or unset. Any status matches when it is empty.
+	StatusCode string `mapstructure:"status_code,omitempty"`
+	// Percentage of the matching spans that are kept, from 0 to 100.
+	Percentage float64 `mapstructure:"sampling_percentage"`
+}
+
+type samplingItem struct {
+	selectorMatchers []SelectorMatcherItem
+	statusCode       ptrace.StatusCode
+	anyStatusCode    bool
+	threshold        uint32
+}
+
+// Sampler decides which spans are kept. The first rule matching a span applies, and the spans matching none of the
+// rules are kept at the default percentage. The decision only depends on the trace ID for a percentage, so that the
+// spans of a trace sampled at the same percentage are kept or dropped together.
+type Sampler struct {
+	items            []samplingItem
+	defaultThreshold uint32
+}
+
+// NewSampler creates a sampler keeping the spans matching none of the rules at the default percentage.
+func NewSampler(rules []SamplingRule, defaultPercentage float64) (*Sampler, error) {
+	defaultThreshold, err := samplingThreshold(defaultPercentage)
+	if err != nil {
+		return nil, err
+	}
+	s := &Sampler{defaultThreshold: defaultThreshold}
+	for i, rule := range rules {
+		if err = validateScopes(rule.Selectors); err != nil {
+			return nil, fmt.Errorf("sampling rule %d: %w", i, err)
+		}
+		item := samplingItem{selectorMatchers: generateSelectorMatchers(rule.Selectors)}
+		if item.threshold, err = samplingThreshold(rule.Percentage); err != nil {
+			return nil, fmt.Errorf("sampling rule %d: %w", i, err)
+		}
+		switch strings.ToLower(rule.StatusCode) {
+		case "":
+			item.anyStatusCode = true
+		case "error":
+			item.statusCode = ptrace.StatusCodeError
+		case "ok":
+			item.statusCode = ptrace.StatusCodeOk
+		case "unset":
+			item.statusCode = ptrace.StatusCodeUnset
+		default:
+			return nil, fmt.Errorf("sampling rule %d: invalid status_code %q, must be error, ok or unset", i, rule.StatusCode)
+		}
+		s.items = append(s.items, item)
+	}
+	return s, nil
+}
+
+// Keep reports whether the span is kept. It is called once the attributes of the span are resolved.
+func (s *Sampler) Keep(span ptrace.Span, resourceAttributes pcommon.Map) bool {
+	threshold := s.defaultThreshold
+	for _, item := range s.items {
+		if (item.anyStatusCode || span.Status().Code() == item.statusCode) && matchesSelectors(span.Attributes(), resourceAttributes, item.selectorMatchers, true) {
+			threshold = item.threshold
+			break
+		}
+	}
+	switch threshold {
+	case 0:
+		return false
+	case samplingPrecision:
+		return true
+	}
+	return traceIDBucket(span.TraceID()) < threshold
+}
+
+func samplingThreshold(percentage float64) (uint32, error) {
+	if percentage < 0 || percentage > 100 {
+		return 0, errors.New("sampling_percentage must be between 0 and 100")
+	}
+	return uint32(percentage * samplingPrecision / 100), nil
+}
+
+func traceIDBucket(traceID pcommon.TraceID) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write(traceID[:])
+	return h.Sum32() % samplingPrecision
+}
@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package rules
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newSpan(i int, status ptrace.StatusCode, attrs map[string]string) ptrace.Span {
+	span := ptrace.NewSpan()
+	var traceID pcommon.TraceID
+	binary.BigEndian.PutUint64(traceID[8:], uint64(i))
+	span.SetTraceID(traceID)
+	span.Status().SetCode(status)
+	for k, v := range attrs {
+		span.Attributes().PutStr(k, v)
+	}
+	return span
+}
+
+func TestSampler(t *testing.T) {
+	sampler, err := NewSampler([]SamplingRule{
+		{StatusCode: "error", Percentage: 100},
+		{Selectors: []Selector{{Dimension: "Service", Match: "checkout"}}, Percentage: 0},
+		{Selectors: []Selector{{Dimension: "Environment", Match: "eks:prod/*"}}, Percentage: 50},
+	}, 5)
+	require.NoError(t, err)
+
+	kept := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		if sampler.Keep(newSpan(i, ptrace.StatusCodeError, map[string]string{"aws.local.service": "checkout"}), pcommon.NewMap()) {
+			kept["error"]++
+		}
+		if sampler.Keep(newSpan(i, ptrace.StatusCodeOk, map[string]string{"aws.local.service": "checkout"}), pcommon.NewMap()) {
+			kept["checkout"]++
+		}
+		if sampler.Keep(newSpan(i, ptrace.StatusCodeUnset, map[string]string{"aws.local.environment": "eks:prod/default"}), pcommon.NewMap()) {
+			kept["prod"]++
+		}
+		if sampler.Keep(newSpan(i, ptrace.StatusCodeOk, nil), pcommon.NewMap()) {
+			kept["other"]++
+		}
+	}
+	assert.Equal(t, 10000, kept["error"])
+	assert.Equal(t, 0, kept["checkout"])
+	assert.InDelta(t, 5000, kept["prod"], 300)
+	assert.InDelta(t, 500, kept["other"], 100)
+}
+
+func TestSamplerConsistentForTrace(t *testing.T) {
+	sampler, err := NewSampler(nil, 50)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		parent := newSpan(i, ptrace.StatusCodeOk, map[string]string{"aws.local.service": "frontend"})
+		child := newSpan(i, ptrace.StatusCodeUnset, map[string]string{"aws.local.service": "backend"})
+		assert.Equal(t, sampler.Keep(parent, pcommon.NewMap()), sampler.Keep(child, pcommon.NewMap()))
+	}
+}
+
+func TestNewSamplerInvalid(t *testing.T) {
+	_, err := NewSampler(nil, -1)
+	assert.Error(t, err)
+	_, err = NewSampler([]SamplingRule{{Percentage: 101}}, 100)
+	assert.Error(t, err)
+	_, err = NewSampler([]SamplingRule{{StatusCode: "failed"}}, 100)
+	assert.Error(t, err)
+}
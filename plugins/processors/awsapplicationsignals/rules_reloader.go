@@ -0,0 +1,95 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awsapplicationsignals
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	appsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
+)
+
+// rulesReloader reads the rules file every interval and swaps the rules of the processor when its content changed.
+// The file is polled rather than watched, so that it can be a ConfigMap or a file replaced with a rename. When the
+// file cannot be read or its rules are invalid, the processor keeps the rules it has.
+type rulesReloader struct {
+	path     string
+	interval time.Duration
+	compile  func([]rules.Rule) (*ruleSet, error)
+	rules    *atomic.Pointer[ruleSet]
+	logger   *zap.Logger
+
+	// content is the content the current rules were compiled from.
+	content []byte
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newRulesReloader(path string, interval time.Duration, compile func([]rules.Rule) (*ruleSet, error), rs *atomic.Pointer[ruleSet], logger *zap.Logger) *rulesReloader {
+	return &rulesReloader{
+		path:     path,
+		interval: interval,
+		compile:  compile,
+		rules:    rs,
+		logger:   logger,
+		done:     make(chan struct{}),
+	}
+}
+
+// load compiles the rules of the file. The processor fails to start when the file is invalid at startup.
+func (r *rulesReloader) load() error {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, r.content) && r.rules.Load() != nil {
+		return nil
+	}
+	parsed, err := appsignalsconfig.ParseRulesFile(data)
+	if err != nil {
+		return err
+	}
+	rs, err := r.compile(parsed)
+	if err != nil {
+		return err
+	}
+	r.rules.Store(rs)
+	r.content = data
+	return nil
+}
+
+func (r *rulesReloader) start() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.done:
+				return
+			case <-ticker.C:
+				previous := r.rules.Load()
+				if err := r.load(); err != nil {
+					r.logger.Warn("failed to reload the rules file, keeping the current rules", zap.String("path", r.path), zap.Error(err))
+				} else if r.rules.Load() != previous {
+					r.logger.Info("reloaded the rules file", zap.String("path", r.path))
+				}
+			}
+		}
+	}()
+}
+
+func (r *rulesReloader) Stop(context.Context) error {
+	close(r.done)
+	r.wg.Wait()
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awsapplicationsignals
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
+)
+
+const (
+	dropHealthChecks = `
+rules:
+  - selectors:
+      - dimension: dim_drop
+        match: hc
+    action: drop
+`
+	replaceValues = `
+rules:
+  - selectors:
+      - dimension: dim_val
+        match: test1
+    replacements:
+      - target_dimension: dim_val
+        value: test2
+    action: replace
+`
+)
+
+func TestProcessMetricsWithRulesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(dropHealthChecks), 0600))
+	ap := &awsapplicationsignalsprocessor{
+		logger: zap.NewNop(),
+		config: &config.Config{
+			Resolvers:      []config.Resolver{config.NewGenericResolver("")},
+			RulesFile:      path,
+			ReloadInterval: 10 * time.Millisecond,
+		},
+	}
+	ctx := context.Background()
+	require.NoError(t, ap.StartMetrics(ctx, nil))
+	t.Cleanup(func() { assert.NoError(t, ap.Shutdown(ctx)) })
+
+	healthChecks := map[string]string{"dim_drop": "hc", "dim_val": "test1", "Telemetry.Source": "UnitTest"}
+	metrics := generateMetrics(healthChecks)
+	_, err := ap.processMetrics(ctx, metrics)
+	require.NoError(t, err)
+	assert.True(t, isMetricNil(metrics))
+
+	// The health checks are kept once the drop rule is replaced.
+	require.NoError(t, os.WriteFile(path, []byte(replaceValues), 0600))
+	assert.Eventually(t, func() bool {
+		metrics = generateMetrics(healthChecks)
+		_, err = ap.processMetrics(ctx, metrics)
+		return err == nil && !isMetricNil(metrics)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "test2", getDimensionValue(t, metrics, "dim_val"))
+
+	// An invalid file does not replace the rules.
+	current := ap.metricRules.Load()
+	require.NoError(t, os.WriteFile(path, []byte(`rules: [`), 0600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Same(t, current, ap.metricRules.Load())
+}
+
+func TestProcessTracesWithRulesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(replaceValues), 0600))
+	ap := &awsapplicationsignalsprocessor{
+		logger: zap.NewNop(),
+		config: &config.Config{
+			Resolvers: []config.Resolver{config.NewGenericResolver("")},
+			RulesFile: path,
+		},
+	}
+	ctx := context.Background()
+	require.NoError(t, ap.StartTraces(ctx, nil))
+	t.Cleanup(func() { assert.NoError(t, ap.Shutdown(ctx)) })
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("dim_val", "test1")
+	_, err := ap.processTraces(ctx, traces)
+	require.NoError(t, err)
+	actualVal, _ := span.Attributes().Get("dim_val")
+	assert.Equal(t, "test2", actualVal.AsString())
+}
+
+func TestStartWithInvalidRulesFile(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "rules.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte(`rules: [`), 0600))
+	for _, path := range []string{invalid, filepath.Join(dir, "missing.yaml")} {
+		ap := &awsapplicationsignalsprocessor{
+			logger: zap.NewNop(),
+			config: &config.Config{
+				Resolvers: []config.Resolver{config.NewGenericResolver("")},
+				RulesFile: path,
+			},
+		}
+		ctx := context.Background()
+		assert.Error(t, ap.StartMetrics(ctx, nil))
+		assert.Error(t, ap.StartTraces(ctx, nil))
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awsapplicationsignals
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const (
+	scopeName = "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals"
+
+	attributeProcessor = "processor"
+	attributeReason    = "reason"
+
+	// The reasons data points are dropped for, one for each of the allow list mutators.
+	dropReasonInvalid = "invalid"
+	dropReasonKeep    = "keep"
+	dropReasonDrop    = "drop"
+	// dryRunReasonReplace is the action logged for the data points the replace rules in dry run would change.
+	dryRunReasonReplace = "replace"
+)
+
+// processorTelemetry records how many data points the processor handled and discarded with the collector's
+// MeterProvider, so users can check how much their keep and drop rules filter out.
+type processorTelemetry struct {
+	attrs         metric.MeasurementOption
+	dropAttrs     map[string]metric.MeasurementOption
+	processed     metric.Int64Counter
+	dropped       metric.Int64Counter
+	dryRunDropped metric.Int64Counter
+	// dryRunReplaced counts the data points the replace rules in dry run would change.
+	dryRunReplaced metric.Int64Counter
+	mutatorErrors  metric.Int64Counter
+	sampledOut     metric.Int64Counter
+}
+
+// newProcessorTelemetry creates the instruments with the given provider. A nil provider records nothing.
+func newProcessorTelemetry(mp metric.MeterProvider, processor string) (*processorTelemetry, error) {
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+	meter := mp.Meter(scopeName)
+	processorAttr := attribute.String(attributeProcessor, processor)
+	t := &processorTelemetry{
+		attrs:     metric.WithAttributeSet(attribute.NewSet(processorAttr)),
+		dropAttrs: map[string]metric.MeasurementOption{},
+	}
+	for _, reason := range []string{dropReasonInvalid, dropReasonKeep, dropReasonDrop} {
+		t.dropAttrs[reason] = metric.WithAttributeSet(attribute.NewSet(processorAttr, attribute.String(attributeReason, reason)))
+	}
+	var err error
+	if t.processed, err = meter.Int64Counter("awsapplicationsignals_datapoints_processed",
+		metric.WithDescription("Number of metric data points received by the processor"),
+		metric.WithUnit("{datapoints}"),
+	); err != nil {
+		return nil, err
+	}
+	if t.dropped, err = meter.Int64Counter("awsapplicationsignals_datapoints_dropped",
+		metric.WithDescription("Number of metric data points dropped by the processor, by the rule that dropped them"),
+		metric.WithUnit("{datapoints}"),
+	); err != nil {
+		return nil, err
+	}
+	if t.dryRunDropped, err = meter.Int64Counter("awsapplicationsignals_datapoints_dry_run_dropped",
+		metric.WithDescription("Number of metric data points the rules in dry run would have dropped, by the action of the rules"),
+		metric.WithUnit("{datapoints}"),
+	); err != nil {
+		return nil, err
+	}
+	if t.dryRunReplaced, err = meter.Int64Counter("awsapplicationsignals_datapoints_dry_run_replaced",
+		metric.WithDescription("Number of metric data points whose dimensions the replace rules in dry run would have replaced"),
+		metric.WithUnit("{datapoints}"),
+	); err != nil {
+		return nil, err
+	}
+	if t.mutatorErrors, err = meter.Int64Counter("awsapplicationsignals_mutator_errors",
+		metric.WithDescription("Number of times the attributes of a data point could not be processed"),
+		metric.WithUnit("{errors}"),
+	); err != nil {
+		return nil, err
+	}
+	if t.sampledOut, err = meter.Int64Counter("awsapplicationsignals_spans_sampled_out",
+		metric.WithDescription("Number of spans dropped by the sampling of the processor"),
+		metric.WithUnit("{spans}"),
+	); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// dataPointStats counts what happened to the data points of a resource, so that the counters are updated once per
+// resource rather than for every data point.
+type dataPointStats struct {
+	processed     int64
+	mutatorErrors int64
+	// dropped and dryRunDropped are indexed like the allow list mutators of the processor.
+	dropped        []int64
+	dryRunDropped  []int64
+	dryRunReplaced int64
+}
+
+// record adds the stats to the counters, with the drop reasons of the allow list mutators.
+func (t *processorTelemetry) record(ctx context.Context, stats *dataPointStats, allowlist []allowListRule) {
+	if t == nil {
+		return
+	}
+	if stats.processed > 0 {
+		t.processed.Add(ctx, stats.processed, t.attrs)
+	}
+	if stats.mutatorErrors > 0 {
+		t.mutatorErrors.Add(ctx, stats.mutatorErrors, t.attrs)
+	}
+	if stats.dryRunReplaced > 0 {
+		t.dryRunReplaced.Add(ctx, stats.dryRunReplaced, t.attrs)
+	}
+	for i, n := range stats.dropped {
+		if n > 0 {
+			t.dropped.Add(ctx, n, t.dropAttrs[allowlist[i].reason])
+		}
+	}
+	for i, n := range stats.dryRunDropped {
+		if n > 0 {
+			t.dryRunDropped.Add(ctx, n, t.dropAttrs[allowlist[i].reason])
+		}
+	}
+}
+
+// recordSampledOut adds the spans the sampling dropped to the counter.
+func (t *processorTelemetry) recordSampledOut(ctx context.Context, spans int64) {
+	if t == nil || spans == 0 {
+		return
+	}
+	t.sampledOut.Add(ctx, spans, t.attrs)
+}
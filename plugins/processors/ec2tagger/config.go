@@ -4,6 +4,7 @@
 package ec2tagger
 
 import (
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -19,14 +20,50 @@ var SupportedAppendDimensions = map[string]string{
 const (
 	AttributeVolumeId            = "VolumeId"
 	ValueAppendDimensionVolumeId = "${aws:VolumeId}"
+
+	// An append dimension with a ${aws:Tag/<key>} value is set to the value of the <key> tag of the instance.
+	valueAppendDimensionTagPrefix = "${aws:Tag/"
+	valueAppendDimensionTagSuffix = "}"
+	// A disk append dimension with a ${aws:VolumeTag/<key>} value is set to the value of the <key> tag of the EBS
+	// volume of the disk.
+	valueAppendDimensionVolumeTagPrefix = "${aws:VolumeTag/"
 )
 
+// InstanceTagKey returns the EC2 instance tag key of an append dimension value like ${aws:Tag/team}.
+func InstanceTagKey(value string) (string, bool) {
+	return tagKey(value, valueAppendDimensionTagPrefix)
+}
+
+// VolumeTagKey returns the EBS volume tag key of a disk append dimension value like ${aws:VolumeTag/mount-owner}.
+func VolumeTagKey(value string) (string, bool) {
+	return tagKey(value, valueAppendDimensionVolumeTagPrefix)
+}
+
+func tagKey(value, prefix string) (string, bool) {
+	if !strings.HasPrefix(value, prefix) || !strings.HasSuffix(value, valueAppendDimensionTagSuffix) {
+		return "", false
+	}
+	key := strings.TrimSuffix(strings.TrimPrefix(value, prefix), valueAppendDimensionTagSuffix)
+	if key == "" || key == "*" {
+		return "", false
+	}
+	return key, true
+}
+
 type Config struct {
 	RefreshTagsInterval    time.Duration `mapstructure:"refresh_tags_interval"`
 	RefreshVolumesInterval time.Duration `mapstructure:"refresh_volumes_interval"`
 	EC2MetadataTags        []string      `mapstructure:"ec2_metadata_tags"`
 	EC2InstanceTagKeys     []string      `mapstructure:"ec2_instance_tag_keys"`
 	EBSDeviceKeys          []string      `mapstructure:"ebs_device_keys,omitempty"`
+	// EC2InstanceTagDimensions maps the keys of the EC2 instance tags that are not added under their own key to the
+	// attribute they are added as.
+	EC2InstanceTagDimensions map[string]string `mapstructure:"ec2_instance_tag_dimensions,omitempty"`
+	// EBSTagKeys are the keys of the tags of the EBS volume of the disk device added to the metrics.
+	EBSTagKeys []string `mapstructure:"ebs_tag_keys,omitempty"`
+	// EBSTagDimensions maps the keys of the EBS volume tags that are not added under their own key to the attribute
+	// they are added as.
+	EBSTagDimensions map[string]string `mapstructure:"ebs_tag_dimensions,omitempty"`
 
 	//The tag key in the metrics for disk device
 	DiskDeviceTagKey string `mapstructure:"disk_device_tag_key,omitempty"`
@@ -40,6 +77,9 @@ type Config struct {
 	Token       string `mapstructure:"token,omitempty"`
 	IMDSRetries int    `mapstructure:"imds_retries,omitempty"`
 
+	// InstanceIdentity replaces IMDS as the source of the instance ID, region and metadata of the host when it is set.
+	InstanceIdentity *InstanceIdentity `mapstructure:"instance_identity,omitempty"`
+
 	MiddlewareID *component.ID `mapstructure:"middleware,omitempty"`
 }
 
@@ -50,3 +50,26 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestInstanceTagKey(t *testing.T) {
+	tests := map[string]struct {
+		value  string
+		want   string
+		wantOk bool
+	}{
+		"Tag":        {value: "${aws:Tag/team}", want: "team", wantOk: true},
+		"TagColon":   {value: "${aws:Tag/aws:autoscaling:groupName}", want: "aws:autoscaling:groupName", wantOk: true},
+		"Metadata":   {value: "${aws:InstanceId}"},
+		"Empty":      {value: "${aws:Tag/}"},
+		"Wildcard":   {value: "${aws:Tag/*}"},
+		"Unclosed":   {value: "${aws:Tag/team"},
+		"StaticText": {value: "team"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := InstanceTagKey(tt.value)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -40,6 +40,10 @@ const sampleConfig = `
   ## If this configuration contains one entry and its value is "*", then all ebs volume for the instance are applied.
   # ebs_device_keys = ["/dev/xvda", "/dev/nvme0n1"]
   ##
+  ## Add tags retrieved from the EBS Volume Tags of the volume of the disk device, requires "disk_device_tag_key".
+  ## If this configuration contains one entry and its value is "*", then ALL EBS Volume Tags of the volume are applied.
+  # ebs_tag_keys = ["mount-owner"]
+  ##
   ## Specify which tag to use to get the specified disk device name from input Metric
   # disk_device_tag_key = "device"
   ##
@@ -7,6 +7,7 @@ import (
 	"context"
 	"hash/fnv"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
 	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger/internal/volume"
 	translatorCtx "github.com/aws/amazon-cloudwatch-agent/translator/context"
 )
@@ -50,6 +52,7 @@ type Tagger struct {
 
 	shutdownC          chan bool
 	ec2TagCache        map[string]string
+	configuredTags     map[string]string // tags of the instance identity, which are not described
 	started            bool
 	ec2MetadataLookup  ec2MetadataLookupType
 	ec2MetadataRespond ec2MetadataRespondType
@@ -64,12 +67,11 @@ type Tagger struct {
 // newTagger returns a new EC2 Tagger processor.
 func newTagger(config *Config, logger *zap.Logger) *Tagger {
 	_, cancel := context.WithCancel(context.Background())
-	mdCredentialConfig := &configaws.CredentialConfig{}
 	p := &Tagger{
 		Config:           config,
 		logger:           logger,
 		cancelFunc:       cancel,
-		metadataProvider: ec2metadataprovider.NewMetadataProvider(mdCredentialConfig.Credentials(), config.IMDSRetries),
+		metadataProvider: newMetadataProvider(config.IMDSRetries),
 		ec2Provider: func(ec2CredentialConfig *configaws.CredentialConfig) ec2iface.EC2API {
 			return ec2.New(
 				ec2CredentialConfig.Credentials(),
@@ -82,6 +84,15 @@ func newTagger(config *Config, logger *zap.Logger) *Tagger {
 	return p
 }
 
+// newMetadataProvider returns the metadata provider shared by the components of the agent, unless the tagger retries
+// the calls a different number of times.
+func newMetadataProvider(retries int) ec2metadataprovider.MetadataProvider {
+	if retries == retryer.GetDefaultRetryNumber() {
+		return ec2metadataprovider.SharedMetadataProvider()
+	}
+	return ec2metadataprovider.NewMetadataProvider((&configaws.CredentialConfig{}).Credentials(), retries)
+}
+
 func getOtelAttributes(m pmetric.Metric) []pcommon.Map {
 	attributes := []pcommon.Map{}
 	switch m.Type() {
@@ -135,6 +146,9 @@ func (t *Tagger) updateOtelAttributes(attributes []pcommon.Map) {
 	for _, attr := range attributes {
 		if t.ec2TagCache != nil {
 			for k, v := range t.ec2TagCache {
+				if dimension, ok := t.EC2InstanceTagDimensions[k]; ok {
+					k = dimension
+				}
 				attr.PutStr(k, v)
 			}
 		}
@@ -150,9 +164,12 @@ func (t *Tagger) updateOtelAttributes(attributes []pcommon.Map) {
 		if t.volumeSerialCache != nil {
 			if devName, found := attr.Get(t.DiskDeviceTagKey); found {
 				serial := t.volumeSerialCache.Serial(devName.Str())
-				if serial != "" {
+				if serial != "" && len(t.EBSDeviceKeys) > 0 {
 					attr.PutStr(AttributeVolumeId, serial)
 				}
+				if serial != "" && len(t.EBSTagKeys) > 0 {
+					t.putVolumeTags(attr, serial)
+				}
 			}
 		}
 		// If append_dimensions are applied, then remove the host dimension.
@@ -160,6 +177,20 @@ func (t *Tagger) updateOtelAttributes(attributes []pcommon.Map) {
 	}
 }
 
+// putVolumeTags adds the configured tags of the EBS volume to the attributes.
+func (t *Tagger) putVolumeTags(attr pcommon.Map, serial string) {
+	useAllTags := len(t.EBSTagKeys) == 1 && t.EBSTagKeys[0] == "*"
+	for k, v := range t.volumeSerialCache.Tags(serial) {
+		if !useAllTags && !slices.Contains(t.EBSTagKeys, k) {
+			continue
+		}
+		if dimension, ok := t.EBSTagDimensions[k]; ok {
+			k = dimension
+		}
+		attr.PutStr(k, v)
+	}
+}
+
 // updateTags calls EC2 Describe Tags and replaces the Tagger's tagCache with the newly retrieved values
 func (t *Tagger) updateTags() error {
 	tags := make(map[string]string)
@@ -185,6 +216,9 @@ func (t *Tagger) updateTags() error {
 		}
 		input.SetNextToken(*result.NextToken)
 	}
+	for key, value := range t.configuredTags {
+		tags[key] = value
+	}
 	t.Lock()
 	defer t.Unlock()
 	t.ec2TagCache = tags
@@ -242,7 +276,7 @@ func (t *Tagger) refreshLoopVolumes(refreshInterval time.Duration, stopAfterFirs
 			allVolumesRetrieved := t.ebsVolumesRetrieved()
 			t.logger.Debug("Retrieve status",
 				zap.Bool("EbsAllVolumesRetrieved", allVolumesRetrieved))
-			refreshVolumes := len(t.EBSDeviceKeys) > 0
+			refreshVolumes := t.describesVolumes()
 
 			if stopAfterFirstSuccess {
 				// need refresh volumes when it is configured and not all volumes are retrieved
@@ -286,6 +320,11 @@ func (t *Tagger) ec2TagsRetrieved() bool {
 	return allTagsRetrieved
 }
 
+// describesVolumes is true when the volume IDs or the tags of the EBS volumes are added to the metrics.
+func (t *Tagger) describesVolumes() bool {
+	return len(t.EBSDeviceKeys) > 0 || len(t.EBSTagKeys) > 0
+}
+
 // ebsVolumesRetrieved checks if all volumes are successfully retrieved
 func (t *Tagger) ebsVolumesRetrieved() bool {
 	allVolumesRetrieved := true
@@ -307,7 +346,12 @@ func (t *Tagger) ebsVolumesRetrieved() bool {
 func (t *Tagger) Start(ctx context.Context, host component.Host) error {
 	t.shutdownC = make(chan bool)
 	t.ec2TagCache = map[string]string{}
-	if err := t.deriveEC2MetadataFromIMDS(ctx); err != nil {
+	t.lookupEC2MetadataTags()
+	if t.InstanceIdentity != nil {
+		if err := t.deriveEC2MetadataFromConfig(); err != nil {
+			return err
+		}
+	} else if err := t.deriveEC2MetadataFromIMDS(ctx); err != nil {
 		return err
 	}
 	t.tagFilters = []*ec2.Filter{
@@ -336,7 +380,7 @@ func (t *Tagger) Start(ctx context.Context, host component.Host) error {
 			Values: aws.StringSlice(t.EC2InstanceTagKeys),
 		})
 	}
-	if len(t.EC2InstanceTagKeys) > 0 || len(t.EBSDeviceKeys) > 0 {
+	if len(t.EC2InstanceTagKeys) > 0 || t.describesVolumes() {
 		ec2CredentialConfig := &configaws.CredentialConfig{
 			AccessKey: t.AccessKey,
 			SecretKey: t.SecretKey,
@@ -426,7 +470,11 @@ func (t *Tagger) refreshLoopToUpdateVolumes() {
 // updateVolumes calls EC2 describe volume
 func (t *Tagger) updateVolumes() error {
 	if t.volumeSerialCache == nil {
-		t.volumeSerialCache = volume.NewCache(volume.NewProvider(t.ec2API, t.ec2MetadataRespond.instanceId))
+		var tagProvider volume.TagProvider
+		if len(t.EBSTagKeys) > 0 {
+			tagProvider = volume.NewTagProvider(t.ec2API, t.ec2MetadataRespond.instanceId)
+		}
+		t.volumeSerialCache = volume.NewCache(volume.NewProvider(t.ec2API, t.ec2MetadataRespond.instanceId), tagProvider)
 	}
 
 	if err := t.volumeSerialCache.Refresh(); err != nil {
@@ -452,19 +500,6 @@ Retrieve metadata from IMDS and use these metadata to:
 For more information on IMDS, please follow this document https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ec2-instance-metadata.html
 */
 func (t *Tagger) deriveEC2MetadataFromIMDS(ctx context.Context) error {
-	for _, tag := range t.EC2MetadataTags {
-		switch tag {
-		case mdKeyInstanceId:
-			t.ec2MetadataLookup.instanceId = true
-		case mdKeyImageId:
-			t.ec2MetadataLookup.imageId = true
-		case mdKeyInstanceType:
-			t.ec2MetadataLookup.instanceType = true
-		default:
-			t.logger.Error("ec2tagger: Unsupported EC2 Metadata key", zap.String("mdKey", tag))
-		}
-	}
-
 	t.logger.Info("ec2tagger: Check EC2 Metadata.")
 	doc, err := t.metadataProvider.Get(ctx)
 	if err != nil {
@@ -487,10 +522,54 @@ func (t *Tagger) deriveEC2MetadataFromIMDS(ctx context.Context) error {
 	return nil
 }
 
+// deriveEC2MetadataFromConfig uses the configured instance identity instead of IMDS, for hosts where IMDS is not
+// available. The autoscaling group name is added as a tag without describing the tags of the instance.
+func (t *Tagger) deriveEC2MetadataFromConfig() error {
+	identity, err := t.InstanceIdentity.resolve()
+	if err != nil {
+		t.logger.Error("ec2tagger: Unable to resolve the configured instance identity.", zap.Error(err))
+		return err
+	}
+	t.logger.Info("ec2tagger: Using the configured instance identity instead of EC2 Metadata.", zap.String("instanceId", identity.InstanceID))
+
+	t.ec2MetadataRespond.region = identity.Region
+	t.ec2MetadataRespond.instanceId = identity.InstanceID
+	if t.ec2MetadataLookup.imageId {
+		t.ec2MetadataRespond.imageId = identity.ImageID
+	}
+	if t.ec2MetadataLookup.instanceType {
+		t.ec2MetadataRespond.instanceType = identity.InstanceType
+	}
+	if identity.AutoScalingGroupName != "" {
+		t.configuredTags = map[string]string{CWDimensionASG: identity.AutoScalingGroupName}
+		t.ec2TagCache[CWDimensionASG] = identity.AutoScalingGroupName
+		t.EC2InstanceTagKeys = slices.DeleteFunc(t.EC2InstanceTagKeys, func(key string) bool {
+			return key == CWDimensionASG || key == Ec2InstanceTagKeyASG
+		})
+	}
+	return nil
+}
+
+// lookupEC2MetadataTags sets the EC2 Metadata fields that are added to the metrics.
+func (t *Tagger) lookupEC2MetadataTags() {
+	for _, tag := range t.EC2MetadataTags {
+		switch tag {
+		case mdKeyInstanceId:
+			t.ec2MetadataLookup.instanceId = true
+		case mdKeyImageId:
+			t.ec2MetadataLookup.imageId = true
+		case mdKeyInstanceType:
+			t.ec2MetadataLookup.instanceType = true
+		default:
+			t.logger.Error("ec2tagger: Unsupported EC2 Metadata key", zap.String("mdKey", tag))
+		}
+	}
+}
+
 // This function never return until calling updateTags() and updateVolumes() succeed or shutdown happen.
 func (t *Tagger) initialRetrievalOfTagsAndVolumes() {
 	tagsRetrieved := len(t.EC2InstanceTagKeys) == 0
-	volsRetrieved := len(t.EBSDeviceKeys) == 0
+	volsRetrieved := !t.describesVolumes()
 
 	retry := 0
 	for {
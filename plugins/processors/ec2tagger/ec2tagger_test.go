@@ -6,6 +6,7 @@ package ec2tagger
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -166,6 +167,7 @@ var mockedInstanceIdentityDoc = &ec2metadata.EC2InstanceIdentityDocument{
 type mockVolumeCache struct {
 	sync.RWMutex
 	cache               map[string]string
+	tags                map[string]map[string]string
 	refreshCount        int
 	volumesPartialLimit int
 	UseUpdatedVolumes   bool
@@ -193,6 +195,12 @@ func (m *mockVolumeCache) Serial(devName string) string {
 	return m.cache[devName]
 }
 
+func (m *mockVolumeCache) Tags(serial string) map[string]string {
+	m.RLock()
+	defer m.RUnlock()
+	return m.tags[serial]
+}
+
 func (m *mockVolumeCache) Devices() []string {
 	m.RLock()
 	defer m.RUnlock()
@@ -279,6 +287,66 @@ func TestStartFailWithNoMetadata(t *testing.T) {
 	assert.Contains(t, err.Error(), "No instance identity document")
 }
 
+// run Start() with a configured instance identity and check neither IMDS nor the EC2 API is called
+func TestStartWithInstanceIdentity(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.EC2MetadataTags = []string{mdKeyInstanceId, mdKeyInstanceType}
+	cfg.EC2InstanceTagKeys = []string{"AutoScalingGroupName"}
+	cfg.InstanceIdentity = &InstanceIdentity{
+		InstanceID:           "mi-0123456789abcdef0",
+		Region:               "us-west-2",
+		InstanceType:         "on-prem",
+		AutoScalingGroupName: "fleet",
+	}
+	_, cancel := context.WithCancel(context.Background())
+	tagger := &Tagger{
+		Config:           cfg,
+		logger:           processortest.NewNopSettings().Logger,
+		cancelFunc:       cancel,
+		metadataProvider: &mockMetadataProvider{InstanceIdentityDocument: nil},
+		ec2Provider: func(*configaws.CredentialConfig) ec2iface.EC2API {
+			t.Fatal("EC2 API should not be used")
+			return nil
+		},
+	}
+	err := tagger.Start(context.Background(), componenttest.NewNopHost())
+	assert.Nil(t, err)
+	assert.True(t, tagger.started)
+
+	md := createTestMetrics([]map[string]string{
+		{
+			"host": "example.org",
+		},
+	})
+	output, err := tagger.processMetrics(context.Background(), md)
+	assert.Nil(t, err)
+	expectedOutput := createTestMetrics([]map[string]string{
+		{
+			"AutoScalingGroupName": "fleet",
+			"InstanceId":           "mi-0123456789abcdef0",
+			"InstanceType":         "on-prem",
+		},
+	})
+	checkAttributes(t, expectedOutput, output)
+}
+
+func TestStartFailWithIncompleteInstanceIdentity(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.InstanceIdentity = &InstanceIdentity{
+		InstanceID:       "mi-0123456789abcdef0",
+		RegistrationFile: filepath.Join(t.TempDir(), "registration"),
+	}
+	_, cancel := context.WithCancel(context.Background())
+	tagger := &Tagger{
+		Config:           cfg,
+		logger:           processortest.NewNopSettings().Logger,
+		cancelFunc:       cancel,
+		metadataProvider: &mockMetadataProvider{InstanceIdentityDocument: mockedInstanceIdentityDoc},
+	}
+	err := tagger.Start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "SSM registration file")
+}
+
 // run Start() and check all tags/volumes are retrieved and saved
 func TestStartSuccessWithNoTagsVolumesUpdate(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
@@ -521,6 +589,73 @@ func TestApplyWithTagsVolumesUpdate(t *testing.T) {
 	checkAttributes(t, expectedUpdatedOutput, updatedOutput)
 }
 
+// Test the tags are added under the dimension names they were configured with
+func TestApplyWithInstanceTagDimensions(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.EC2InstanceTagKeys = []string{tagKey1, tagKey2}
+	cfg.EC2InstanceTagDimensions = map[string]string{tagKey2: "Renamed"}
+	tagger := &Tagger{
+		Config:      cfg,
+		logger:      processortest.NewNopSettings().Logger,
+		started:     true,
+		ec2TagCache: map[string]string{tagKey1: tagVal1, tagKey2: tagVal2},
+	}
+	md := createTestMetrics([]map[string]string{
+		{
+			"host": "example.org",
+		},
+	})
+	output, err := tagger.processMetrics(context.Background(), md)
+	assert.Nil(t, err)
+	expectedOutput := createTestMetrics([]map[string]string{
+		{
+			tagKey1:   tagVal1,
+			"Renamed": tagVal2,
+		},
+	})
+	checkAttributes(t, expectedOutput, output)
+}
+
+func TestApplyWithVolumeTags(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DiskDeviceTagKey = "device"
+	cfg.EBSTagKeys = []string{"mount-owner", "team"}
+	cfg.EBSTagDimensions = map[string]string{"mount-owner": "MountOwner"}
+	tagger := &Tagger{
+		Config:  cfg,
+		logger:  processortest.NewNopSettings().Logger,
+		started: true,
+		volumeSerialCache: &mockVolumeCache{
+			cache: map[string]string{device1: volumeId1, device2: volumeId2},
+			tags: map[string]map[string]string{
+				volumeId1: {"mount-owner": "payments", "team": "storage", "Name": "data"},
+			},
+		},
+	}
+	md := createTestMetrics([]map[string]string{
+		{
+			"device": device1,
+		},
+		{
+			"device": device2,
+		},
+	})
+	output, err := tagger.processMetrics(context.Background(), md)
+	assert.Nil(t, err)
+	// the volume ID is only added with ebs_device_keys
+	expectedOutput := createTestMetrics([]map[string]string{
+		{
+			"device":     device1,
+			"MountOwner": "payments",
+			"team":       "storage",
+		},
+		{
+			"device": device2,
+		},
+	})
+	checkAttributes(t, expectedOutput, output)
+}
+
 // Test metrics are dropped before the initial retrieval is done
 func TestMetricsDroppedBeforeStarted(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
@@ -661,3 +796,35 @@ func TestTaggerStartsWithoutTagOrVolume(t *testing.T) {
 	assert.Equal(t, tagger.started, true)
 	close(inited)
 }
+
+func BenchmarkProcessMetrics(b *testing.B) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DiskDeviceTagKey = "device"
+	tagger := &Tagger{
+		Config:            cfg,
+		logger:            processortest.NewNopSettings().Logger,
+		started:           true,
+		ec2TagCache:       map[string]string{tagKey1: tagVal1, tagKey2: tagVal2, "AutoScalingGroupName": tagVal3},
+		ec2MetadataLookup: ec2MetadataLookupType{instanceId: true, imageId: true, instanceType: true},
+		ec2MetadataRespond: ec2MetadataRespondType{
+			instanceId:   mockedInstanceIdentityDoc.InstanceID,
+			imageId:      mockedInstanceIdentityDoc.ImageID,
+			instanceType: mockedInstanceIdentityDoc.InstanceType,
+		},
+		volumeSerialCache: &mockVolumeCache{cache: map[string]string{device1: volumeId1, device2: volumeId2}},
+	}
+	metrics := make([]map[string]string, 0, 100)
+	for i := 0; i < cap(metrics); i++ {
+		metrics = append(metrics, map[string]string{"host": "example.org", "device": device2})
+	}
+	template := createTestMetrics(metrics)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		md := pmetric.NewMetrics()
+		template.CopyTo(md)
+		b.StartTimer()
+		_, _ = tagger.processMetrics(context.Background(), md)
+	}
+}
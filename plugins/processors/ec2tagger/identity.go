@@ -0,0 +1,69 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package ec2tagger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// InstanceIdentity is the identity of a host without IMDS, like an on-premises server registered as a managed instance
+// with an SSM hybrid activation. When it is configured, the tagger does not call IMDS.
+type InstanceIdentity struct {
+	InstanceID           string `mapstructure:"instance_id,omitempty"`
+	Region               string `mapstructure:"region,omitempty"`
+	InstanceType         string `mapstructure:"instance_type,omitempty"`
+	ImageID              string `mapstructure:"image_id,omitempty"`
+	AutoScalingGroupName string `mapstructure:"auto_scaling_group_name,omitempty"`
+	// RegistrationFile is the registration file of the SSM agent, which the instance ID and region are read from when
+	// they are not set. It defaults to the location the SSM agent writes it to.
+	RegistrationFile string `mapstructure:"registration_file,omitempty"`
+}
+
+// ssmRegistration is the content of the registration file the SSM agent writes for a managed instance.
+type ssmRegistration struct {
+	ManagedInstanceID string `json:"ManagedInstanceID"`
+	Region            string `json:"Region"`
+}
+
+func defaultRegistrationFile() string {
+	if runtime.GOOS == "windows" {
+		return `C:\ProgramData\Amazon\SSM\InstanceData\registration`
+	}
+	return "/var/lib/amazon/ssm/registration"
+}
+
+// resolve returns the identity with the instance ID and region of the SSM registration file when they are not set.
+func (i InstanceIdentity) resolve() (InstanceIdentity, error) {
+	if i.InstanceID == "" || i.Region == "" {
+		path := i.RegistrationFile
+		if path == "" {
+			path = defaultRegistrationFile()
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return i, fmt.Errorf("instance_id and region are not set and the SSM registration file cannot be read: %w", err)
+		}
+		var registration ssmRegistration
+		if err = json.Unmarshal(data, &registration); err != nil {
+			return i, fmt.Errorf("invalid SSM registration file %s: %w", path, err)
+		}
+		if i.InstanceID == "" {
+			i.InstanceID = registration.ManagedInstanceID
+		}
+		if i.Region == "" {
+			i.Region = registration.Region
+		}
+	}
+	if i.InstanceID == "" {
+		return i, errors.New("instance_id is not set")
+	}
+	if i.Region == "" {
+		return i, errors.New("region is not set")
+	}
+	return i, nil
+}
@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package ec2tagger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceIdentityResolve(t *testing.T) {
+	dir := t.TempDir()
+	registration := filepath.Join(dir, "registration")
+	require.NoError(t, os.WriteFile(registration, []byte(`{"ManagedInstanceID":"mi-0123456789abcdef0","Region":"eu-west-1"}`), 0600))
+	invalid := filepath.Join(dir, "invalid")
+	require.NoError(t, os.WriteFile(invalid, []byte(`mi-0123456789abcdef0`), 0600))
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(empty, []byte(`{}`), 0600))
+
+	tests := map[string]struct {
+		identity InstanceIdentity
+		want     InstanceIdentity
+		wantErr  string
+	}{
+		"Configured": {
+			identity: InstanceIdentity{InstanceID: "i-0123456789abcdef0", Region: "us-east-1", RegistrationFile: invalid},
+			want:     InstanceIdentity{InstanceID: "i-0123456789abcdef0", Region: "us-east-1", RegistrationFile: invalid},
+		},
+		"FromRegistrationFile": {
+			identity: InstanceIdentity{RegistrationFile: registration, InstanceType: "on-prem"},
+			want:     InstanceIdentity{InstanceID: "mi-0123456789abcdef0", Region: "eu-west-1", RegistrationFile: registration, InstanceType: "on-prem"},
+		},
+		"RegionOverridesRegistrationFile": {
+			identity: InstanceIdentity{RegistrationFile: registration, Region: "us-east-1"},
+			want:     InstanceIdentity{InstanceID: "mi-0123456789abcdef0", Region: "us-east-1", RegistrationFile: registration},
+		},
+		"MissingRegistrationFile": {
+			identity: InstanceIdentity{RegistrationFile: filepath.Join(dir, "missing")},
+			wantErr:  "SSM registration file cannot be read",
+		},
+		"InvalidRegistrationFile": {
+			identity: InstanceIdentity{RegistrationFile: invalid},
+			wantErr:  "invalid SSM registration file",
+		},
+		"EmptyRegistrationFile": {
+			identity: InstanceIdentity{RegistrationFile: empty},
+			wantErr:  "instance_id is not set",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.identity.resolve()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
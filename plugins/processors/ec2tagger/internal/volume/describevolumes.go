@@ -16,12 +16,47 @@ type describeVolumesProvider struct {
 	instanceID string
 }
 
-func newDescribeVolumesProvider(ec2Client ec2iface.EC2API, instanceID string) Provider {
+func newDescribeVolumesProvider(ec2Client ec2iface.EC2API, instanceID string) *describeVolumesProvider {
 	return &describeVolumesProvider{ec2Client: ec2Client, instanceID: instanceID}
 }
 
 func (p *describeVolumesProvider) DeviceToSerialMap() (map[string]string, error) {
 	result := map[string]string{}
+	err := p.describeVolumes(func(volume *ec2.Volume) {
+		for _, attachment := range volume.Attachments {
+			if attachment.Device != nil && attachment.VolumeId != nil {
+				result[aws.StringValue(attachment.Device)] = aws.StringValue(attachment.VolumeId)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p *describeVolumesProvider) VolumeToTagsMap() (map[string]map[string]string, error) {
+	result := map[string]map[string]string{}
+	err := p.describeVolumes(func(volume *ec2.Volume) {
+		if volume.VolumeId == nil || len(volume.Tags) == 0 {
+			return
+		}
+		tags := make(map[string]string, len(volume.Tags))
+		for _, tag := range volume.Tags {
+			if tag.Key != nil {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+		}
+		result[aws.StringValue(volume.VolumeId)] = tags
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// describeVolumes calls fn with each of the volumes attached to the instance.
+func (p *describeVolumesProvider) describeVolumes(fn func(volume *ec2.Volume)) error {
 	input := &ec2.DescribeVolumesInput{
 		Filters: []*ec2.Filter{
 			{
@@ -33,19 +68,14 @@ func (p *describeVolumesProvider) DeviceToSerialMap() (map[string]string, error)
 	for {
 		output, err := p.ec2Client.DescribeVolumes(input)
 		if err != nil {
-			return nil, fmt.Errorf("unable to describe volumes: %w", err)
+			return fmt.Errorf("unable to describe volumes: %w", err)
 		}
 		for _, volume := range output.Volumes {
-			for _, attachment := range volume.Attachments {
-				if attachment.Device != nil && attachment.VolumeId != nil {
-					result[aws.StringValue(attachment.Device)] = aws.StringValue(attachment.VolumeId)
-				}
-			}
+			fn(volume)
 		}
 		if output.NextToken == nil {
-			break
+			return nil
 		}
 		input.SetNextToken(*output.NextToken)
 	}
-	return result, nil
 }
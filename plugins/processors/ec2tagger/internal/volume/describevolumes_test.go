@@ -18,9 +18,13 @@ var (
 	volumeId1         = "vol-0303a1cc896c42d28"
 	volumeAttachment1 = ec2.VolumeAttachment{Device: &device1, VolumeId: &volumeId1}
 	availabilityZone  = "us-east-1a"
+	tagKey1           = "mount-owner"
+	tagValue1         = "payments"
 	volume1           = ec2.Volume{
 		Attachments:      []*ec2.VolumeAttachment{&volumeAttachment1},
 		AvailabilityZone: &availabilityZone,
+		Tags:             []*ec2.Tag{{Key: &tagKey1, Value: &tagValue1}},
+		VolumeId:         &volumeId1,
 	}
 )
 
@@ -31,6 +35,7 @@ var (
 	volume2           = ec2.Volume{
 		Attachments:      []*ec2.VolumeAttachment{&volumeAttachment2},
 		AvailabilityZone: &availabilityZone,
+		VolumeId:         &volumeId2,
 	}
 )
 
@@ -75,3 +80,16 @@ func TestDescribeVolumesProvider(t *testing.T) {
 	assert.Equal(t, 1, ec2Client.callCount)
 	assert.Nil(t, got)
 }
+
+func TestDescribeVolumesProviderTags(t *testing.T) {
+	ec2Client := &mockEC2Client{}
+	p := newDescribeVolumesProvider(ec2Client, "")
+	got, err := p.VolumeToTagsMap()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ec2Client.callCount)
+	assert.Equal(t, map[string]map[string]string{volumeId1: {tagKey1: tagValue1}}, got)
+	ec2Client.err = errors.New("test")
+	got, err = p.VolumeToTagsMap()
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
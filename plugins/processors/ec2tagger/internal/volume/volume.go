@@ -24,6 +24,12 @@ type Provider interface {
 	DeviceToSerialMap() (map[string]string, error)
 }
 
+// TagProvider provides the tags of the volumes, which are only described by the EC2 API.
+type TagProvider interface {
+	// VolumeToTagsMap provides a map with volume ID keys and tag values.
+	VolumeToTagsMap() (map[string]map[string]string, error)
+}
+
 func NewProvider(ec2Client ec2iface.EC2API, instanceID string) Provider {
 	return newMergeProvider([]Provider{
 		newHostProvider(),
@@ -31,24 +37,37 @@ func NewProvider(ec2Client ec2iface.EC2API, instanceID string) Provider {
 	})
 }
 
+func NewTagProvider(ec2Client ec2iface.EC2API, instanceID string) TagProvider {
+	return newDescribeVolumesProvider(ec2Client, instanceID)
+}
+
 type Cache interface {
 	Refresh() error
 	Serial(devName string) string
+	// Tags returns the tags of the volume with the serial, i.e. the volume ID.
+	Tags(serial string) map[string]string
 	Devices() []string
 }
 
 type cache struct {
 	sync.RWMutex
 	// device name to serial mapping
-	cache          map[string]string
+	cache map[string]string
+	// volume ID to tags mapping
+	tags           map[string]map[string]string
 	provider       Provider
+	tagProvider    TagProvider
 	fetchBlockName func(string) string
 }
 
-func NewCache(provider Provider) Cache {
+// NewCache creates a cache of the volumes from the provider. The tags of the volumes are only cached when the tag
+// provider is set.
+func NewCache(provider Provider, tagProvider TagProvider) Cache {
 	return &cache{
 		cache:          make(map[string]string),
+		tags:           make(map[string]map[string]string),
 		provider:       provider,
+		tagProvider:    tagProvider,
 		fetchBlockName: findNvmeBlockNameIfPresent,
 	}
 }
@@ -79,9 +98,25 @@ func (c *cache) Refresh() error {
 	for deviceName, serial := range result {
 		c.add(deviceName, serial)
 	}
+	if c.tagProvider == nil {
+		return nil
+	}
+	tags, err := c.tagProvider.VolumeToTagsMap()
+	if err != nil {
+		return fmt.Errorf("unable to refresh volume tags: %w", err)
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.tags = tags
 	return nil
 }
 
+func (c *cache) Tags(serial string) map[string]string {
+	c.RLock()
+	defer c.RUnlock()
+	return c.tags[serial]
+}
+
 func (c *cache) Serial(devName string) string {
 	c.RLock()
 	defer c.RUnlock()
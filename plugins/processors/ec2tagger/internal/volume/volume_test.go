@@ -32,7 +32,7 @@ func TestCache(t *testing.T) {
 		},
 		err: testErr,
 	}
-	c := NewCache(nil).(*cache)
+	c := NewCache(nil, nil).(*cache)
 	c.fetchBlockName = func(s string) string {
 		return ""
 	}
@@ -50,3 +50,29 @@ func TestCache(t *testing.T) {
 	sort.Strings(got)
 	assert.Equal(t, []string{"xvdc", "xvdc1", "xvdf"}, got)
 }
+
+type mockTagProvider struct {
+	tagsMap map[string]map[string]string
+	err     error
+}
+
+func (m *mockTagProvider) VolumeToTagsMap() (map[string]map[string]string, error) {
+	return m.tagsMap, m.err
+}
+
+func TestCacheTags(t *testing.T) {
+	testErr := errors.New("test")
+	tp := &mockTagProvider{err: testErr}
+	c := NewCache(&mockProvider{serialMap: map[string]string{"xvdf": "vol-0123"}}, tp).(*cache)
+	c.fetchBlockName = func(s string) string {
+		return ""
+	}
+	assert.ErrorIs(t, c.Refresh(), testErr)
+	assert.Equal(t, "vol-0123", c.Serial("xvdf"))
+	assert.Nil(t, c.Tags("vol-0123"))
+	tp.err = nil
+	tp.tagsMap = map[string]map[string]string{"vol-0123": {"mount-owner": "payments"}}
+	assert.NoError(t, c.Refresh())
+	assert.Equal(t, map[string]string{"mount-owner": "payments"}, c.Tags(c.Serial("xvdf")))
+	assert.Nil(t, c.Tags("vol-4567"))
+}
@@ -0,0 +1,88 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfcoverage
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultReportAfter = 5 * time.Minute
+	defaultMaxMetrics  = 100
+	defaultSeparator   = ";"
+)
+
+type Config struct {
+	// MetricDeclarations are the metric declarations of the awsemf exporter the pipeline sends the metrics to.
+	MetricDeclarations []*awsemfexporter.MetricDeclaration `mapstructure:"metric_declarations"`
+	// ReportAfter is how long after the start the metrics are counted before the report is logged.
+	ReportAfter time.Duration `mapstructure:"report_after"`
+	// MaxMetrics limits the number of metric names listed in the report.
+	MaxMetrics int `mapstructure:"max_metrics"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.ReportAfter <= 0 {
+		return errors.New("'report_after' must be greater than 0")
+	}
+	if cfg.MaxMetrics <= 0 {
+		return errors.New("'max_metrics' must be greater than 0")
+	}
+	_, err := compile(cfg.MetricDeclarations)
+	return err
+}
+
+// declaration is a metric declaration with the regular expressions compiled. It matches the same metrics as the
+// awsemf exporter does.
+type declaration struct {
+	selectors []*regexp.Regexp
+	matchers  []labelMatcher
+}
+
+type labelMatcher struct {
+	labelNames []string
+	separator  string
+	regex      *regexp.Regexp
+}
+
+func compile(declarations []*awsemfexporter.MetricDeclaration) ([]declaration, error) {
+	compiled := make([]declaration, 0, len(declarations))
+	for i, md := range declarations {
+		if len(md.MetricNameSelectors) == 0 {
+			return nil, fmt.Errorf("metric declaration %d does not have any metric name selectors", i)
+		}
+		d := declaration{}
+		for _, selector := range md.MetricNameSelectors {
+			regex, err := regexp.Compile(selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid metric name selector %q of metric declaration %d: %w", selector, i, err)
+			}
+			d.selectors = append(d.selectors, regex)
+		}
+		for _, lm := range md.LabelMatchers {
+			if len(lm.LabelNames) == 0 || lm.Regex == "" {
+				return nil, fmt.Errorf("label matcher of metric declaration %d must have label names and a regex", i)
+			}
+			regex, err := regexp.Compile(lm.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid label matcher %q of metric declaration %d: %w", lm.Regex, i, err)
+			}
+			separator := lm.Separator
+			if separator == "" {
+				separator = defaultSeparator
+			}
+			d.matchers = append(d.matchers, labelMatcher{labelNames: lm.LabelNames, separator: separator, regex: regex})
+		}
+		compiled = append(compiled, d)
+	}
+	return compiled, nil
+}
@@ -0,0 +1,41 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfcoverage
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	valid := func(declarations ...*awsemfexporter.MetricDeclaration) *Config {
+		cfg := createDefaultConfig().(*Config)
+		cfg.MetricDeclarations = declarations
+		return cfg
+	}
+	assert.NoError(t, valid().Validate())
+	assert.NoError(t, valid(&awsemfexporter.MetricDeclaration{
+		MetricNameSelectors: []string{"^nginx_.*$"},
+		LabelMatchers:       []*awsemfexporter.LabelMatcher{{LabelNames: []string{"job"}, Regex: "^nginx$"}},
+	}).Validate())
+	assert.ErrorContains(t, valid(&awsemfexporter.MetricDeclaration{}).Validate(), "does not have any metric name selectors")
+	assert.ErrorContains(t, valid(&awsemfexporter.MetricDeclaration{MetricNameSelectors: []string{"nginx_(.*"}}).Validate(), "invalid metric name selector")
+	assert.ErrorContains(t, valid(&awsemfexporter.MetricDeclaration{
+		MetricNameSelectors: []string{".*"},
+		LabelMatchers:       []*awsemfexporter.LabelMatcher{{LabelNames: []string{"job"}, Regex: "[a"}},
+	}).Validate(), "invalid label matcher")
+	assert.ErrorContains(t, valid(&awsemfexporter.MetricDeclaration{
+		MetricNameSelectors: []string{".*"},
+		LabelMatchers:       []*awsemfexporter.LabelMatcher{{Regex: ".*"}},
+	}).Validate(), "must have label names and a regex")
+
+	cfg := valid()
+	cfg.ReportAfter = 0
+	assert.Error(t, cfg.Validate())
+	cfg = valid()
+	cfg.MaxMetrics = 0
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfcoverage
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	stability = component.StabilityLevelBeta
+)
+
+var (
+	TypeStr, _            = component.NewType("emfcoverage")
+	processorCapabilities = consumer.Capabilities{MutatesData: false}
+)
+
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		TypeStr,
+		createDefaultConfig,
+		processor.WithMetrics(createMetricsProcessor, stability))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ReportAfter: defaultReportAfter,
+		MaxMetrics:  defaultMaxMetrics,
+	}
+}
+
+func createMetricsProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (processor.Metrics, error) {
+	processorConfig, ok := cfg.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("configuration parsing error")
+	}
+
+	metricsProcessor, err := newCoverageProcessor(processorConfig, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return processorhelper.NewMetrics(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		metricsProcessor.processMetrics,
+		processorhelper.WithStart(metricsProcessor.start),
+		processorhelper.WithShutdown(metricsProcessor.shutdown),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
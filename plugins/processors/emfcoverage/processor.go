@@ -0,0 +1,182 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfcoverage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+)
+
+type coverageProcessor struct {
+	*Config
+	logger       *zap.Logger
+	declarations []declaration
+	now          func() time.Time
+
+	mu        sync.Mutex
+	startTime time.Time
+	reported  bool
+	matched   int
+	unmatched map[string]int
+}
+
+func newCoverageProcessor(config *Config, logger *zap.Logger) (*coverageProcessor, error) {
+	declarations, err := compile(config.MetricDeclarations)
+	if err != nil {
+		return nil, err
+	}
+	return &coverageProcessor{
+		Config:       config,
+		logger:       logger,
+		declarations: declarations,
+		now:          time.Now,
+		unmatched:    map[string]int{},
+	}, nil
+}
+
+func (p *coverageProcessor) start(context.Context, component.Host) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.startTime = p.now()
+	return nil
+}
+
+// shutdown logs the report if the agent stops before it was due.
+func (p *coverageProcessor) shutdown(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.reported && (p.matched > 0 || len(p.unmatched) > 0) {
+		p.report()
+	}
+	return nil
+}
+
+// processMetrics counts the data points that match no metric declaration, and so are not extracted as CloudWatch
+// metrics by the awsemf exporter, until the report is logged. The metrics are passed through unchanged.
+func (p *coverageProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.reported {
+		return md, nil
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceLabels := rm.Resource().Attributes()
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				metric.RangeDataPointAttributes(m, func(attrs pcommon.Map) {
+					if p.matches(m.Name(), labels(resourceLabels, attrs)) {
+						p.matched++
+					} else {
+						p.unmatched[m.Name()]++
+					}
+				})
+			}
+		}
+	}
+	if p.now().Sub(p.startTime) >= p.ReportAfter {
+		p.report()
+	}
+	return md, nil
+}
+
+// matches is true if a declaration selects the metric name and, if it has label matchers, one of them matches the
+// labels.
+func (p *coverageProcessor) matches(name string, labels map[string]string) bool {
+	for _, d := range p.declarations {
+		if d.matchesName(name) && d.matchesLabels(labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d declaration) matchesName(name string) bool {
+	for _, selector := range d.selectors {
+		if selector.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d declaration) matchesLabels(labels map[string]string) bool {
+	if len(d.matchers) == 0 {
+		return true
+	}
+	for _, lm := range d.matchers {
+		values := make([]string, len(lm.labelNames))
+		for i, name := range lm.labelNames {
+			values[i] = labels[name]
+		}
+		if lm.regex.MatchString(strings.Join(values, lm.separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// report logs the metric names that matched no declaration with their number of data points, most frequent first.
+// The counts are discarded afterwards.
+func (p *coverageProcessor) report() {
+	p.reported = true
+	defer func() {
+		p.unmatched = nil
+	}()
+	if len(p.unmatched) == 0 {
+		p.logger.Info("All metrics matched a metric declaration", zap.Int("datapoints", p.matched))
+		return
+	}
+	names := make([]string, 0, len(p.unmatched))
+	total := 0
+	for name, count := range p.unmatched {
+		names = append(names, name)
+		total += count
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if p.unmatched[names[i]] != p.unmatched[names[j]] {
+			return p.unmatched[names[i]] > p.unmatched[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	entries := make([]string, 0, min(len(names), p.MaxMetrics))
+	for _, name := range names[:min(len(names), p.MaxMetrics)] {
+		entries = append(entries, fmt.Sprintf("%s (%d)", name, p.unmatched[name]))
+	}
+	p.logger.Warn("Metrics matched no metric declaration and are not extracted as CloudWatch metrics",
+		zap.Int("metrics", len(names)),
+		zap.Int("datapoints", total),
+		zap.Int("matched_datapoints", p.matched),
+		zap.Strings("unmatched", entries))
+}
+
+// labels are the attributes of the data point and of its resource, which the awsemf exporter matches the declarations
+// against.
+func labels(resourceAttrs, attrs pcommon.Map) map[string]string {
+	merged := make(map[string]string, resourceAttrs.Len()+attrs.Len())
+	resourceAttrs.Range(func(k string, v pcommon.Value) bool {
+		merged[k] = v.AsString()
+		return true
+	})
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		merged[k] = v.AsString()
+		return true
+	})
+	return merged
+}
@@ -0,0 +1,104 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfcoverage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func testMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("job", "nginx")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for _, name := range []string{"nginx_connections_active", "nginx_http_requests_total", "go_goroutines"} {
+		metric := ms.AppendEmpty()
+		metric.SetName(name)
+		gauge := metric.SetEmptyGauge()
+		for _, status := range []string{"200", "500"} {
+			dp := gauge.DataPoints().AppendEmpty()
+			dp.SetDoubleValue(1)
+			dp.Attributes().PutStr("status", status)
+		}
+	}
+	summary := ms.AppendEmpty()
+	summary.SetName("go_gc_duration_seconds")
+	summary.SetEmptySummary().DataPoints().AppendEmpty()
+	return md
+}
+
+func TestProcessMetrics(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxMetrics = 2
+	cfg.MetricDeclarations = []*awsemfexporter.MetricDeclaration{
+		{
+			MetricNameSelectors: []string{"^nginx_connections_active$"},
+			LabelMatchers:       []*awsemfexporter.LabelMatcher{{LabelNames: []string{"job"}, Regex: "^nginx$"}},
+		},
+		{
+			// the label matcher joins the values with the separator
+			MetricNameSelectors: []string{"^nginx_http_requests_total$"},
+			LabelMatchers:       []*awsemfexporter.LabelMatcher{{LabelNames: []string{"job", "status"}, Separator: ",", Regex: "^nginx,5..$"}},
+		},
+	}
+	p, err := newCoverageProcessor(cfg, zap.New(core))
+	require.NoError(t, err)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+
+	md := testMetrics()
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+	assert.Equal(t, 0, logs.Len())
+
+	now = now.Add(cfg.ReportAfter)
+	_, err = p.processMetrics(context.Background(), testMetrics())
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, zapcore.WarnLevel, entry.Level)
+	fields := entry.ContextMap()
+	assert.EqualValues(t, 3, fields["metrics"])
+	assert.EqualValues(t, 8, fields["datapoints"])
+	assert.EqualValues(t, 6, fields["matched_datapoints"])
+	assert.Equal(t, []any{"go_goroutines (4)", "go_gc_duration_seconds (2)"}, fields["unmatched"])
+
+	// the report is only logged once
+	_, err = p.processMetrics(context.Background(), testMetrics())
+	require.NoError(t, err)
+	require.NoError(t, p.shutdown(context.Background()))
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestReportOnShutdown(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricDeclarations = []*awsemfexporter.MetricDeclaration{{MetricNameSelectors: []string{".*"}}}
+	p, err := newCoverageProcessor(cfg, zap.New(core))
+	require.NoError(t, err)
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, p.shutdown(context.Background()))
+	assert.Equal(t, 0, logs.Len())
+
+	_, err = p.processMetrics(context.Background(), testMetrics())
+	require.NoError(t, err)
+	require.NoError(t, p.shutdown(context.Background()))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "All metrics matched a metric declaration", logs.All()[0].Message)
+	assert.EqualValues(t, 7, logs.All()[0].ContextMap()["datapoints"])
+}
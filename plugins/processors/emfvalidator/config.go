@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// PolicyTruncate shortens the names and values that are too long and removes the dimensions over the limits.
+	PolicyTruncate = "truncate"
+	// PolicyDrop drops the data points that break a limit.
+	PolicyDrop = "drop"
+	// PolicyNone only counts the data points that break a limit and passes them through unchanged.
+	PolicyNone = "none"
+
+	// MaxDimensions is the number of dimensions CloudWatch accepts for a metric.
+	MaxDimensions = 30
+
+	maxNamespaceLength      = 255
+	maxMetricNameLength     = 255
+	maxDimensionNameLength  = 255
+	maxDimensionValueLength = 1024
+
+	reservedNamespacePrefix = "AWS/"
+)
+
+type Config struct {
+	// Namespace is the namespace of the awsemf exporter the pipeline sends the metrics to.
+	Namespace string `mapstructure:"namespace"`
+	// Policy is applied to the data points that break a limit.
+	Policy string `mapstructure:"policy"`
+	// MaxDimensions is the number of dimensions a data point can have, at most MaxDimensions.
+	MaxDimensions int `mapstructure:"max_dimensions"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	switch cfg.Policy {
+	case PolicyTruncate, PolicyDrop, PolicyNone:
+	default:
+		return fmt.Errorf("'policy' must be one of %q, %q or %q", PolicyTruncate, PolicyDrop, PolicyNone)
+	}
+	if cfg.MaxDimensions <= 0 || cfg.MaxDimensions > MaxDimensions {
+		return fmt.Errorf("'max_dimensions' must be between 1 and %d", MaxDimensions)
+	}
+	return nil
+}
+
+// ValidateNamespace returns why CloudWatch does not accept metrics in the namespace, if it does not.
+func ValidateNamespace(namespace string) error {
+	if namespace == "" {
+		return errors.New("namespace is empty")
+	}
+	if len(namespace) > maxNamespaceLength {
+		return fmt.Errorf("namespace is longer than %d characters", maxNamespaceLength)
+	}
+	if strings.HasPrefix(namespace, reservedNamespacePrefix) {
+		return fmt.Errorf("namespaces starting with %q are reserved for AWS services", reservedNamespacePrefix)
+	}
+	for _, r := range namespace {
+		if !isNamespaceChar(r) {
+			return fmt.Errorf("namespace has the invalid character %q", r)
+		}
+	}
+	return nil
+}
+
+func isNamespaceChar(r rune) bool {
+	return r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || strings.ContainsRune("._-/#: ", r)
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfvalidator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, (&Config{Policy: PolicyTruncate, MaxDimensions: 30}).Validate())
+	assert.NoError(t, (&Config{Namespace: "CWAgent", Policy: PolicyNone, MaxDimensions: 10}).Validate())
+	assert.EqualError(t, (&Config{Policy: "fix", MaxDimensions: 30}).Validate(), `'policy' must be one of "truncate", "drop" or "none"`)
+	assert.EqualError(t, (&Config{Policy: PolicyDrop, MaxDimensions: 31}).Validate(), "'max_dimensions' must be between 1 and 30")
+	assert.EqualError(t, (&Config{Policy: PolicyDrop}).Validate(), "'max_dimensions' must be between 1 and 30")
+}
+
+func TestValidateNamespace(t *testing.T) {
+	testCases := map[string]struct {
+		namespace string
+		wantErr   string
+	}{
+		"WithValidNamespace": {
+			namespace: "Team/Payments-Api_v2.0#blue:east 1",
+		},
+		"WithEmptyNamespace": {
+			wantErr: "namespace is empty",
+		},
+		"WithReservedPrefix": {
+			namespace: "AWS/EC2",
+			wantErr:   `namespaces starting with "AWS/" are reserved for AWS services`,
+		},
+		"WithTooLongNamespace": {
+			namespace: strings.Repeat("a", 256),
+			wantErr:   "namespace is longer than 255 characters",
+		},
+		"WithInvalidCharacter": {
+			namespace: "Team*",
+			wantErr:   `namespace has the invalid character '*'`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateNamespace(testCase.namespace)
+			if testCase.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.wantErr)
+			}
+		})
+	}
+}
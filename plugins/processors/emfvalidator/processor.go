@@ -0,0 +1,335 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfvalidator
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+const (
+	// violationLogInterval limits how often the violations are logged.
+	violationLogInterval = time.Minute
+
+	// The attributes the awsemf exporter does not turn into dimensions.
+	storageResolutionAttribute = "aws.emf.storage_resolution"
+	entityAttributePrefix      = "com.amazonaws.cloudwatch.entity.internal."
+)
+
+type validatorProcessor struct {
+	*Config
+	logger       *zap.Logger
+	telemetry    *validatorTelemetry
+	namespaceErr error
+	now          func() time.Time
+
+	mu      sync.Mutex
+	pending violations
+	lastLog time.Time
+}
+
+func newValidatorProcessor(config *Config, logger *zap.Logger, telemetry *validatorTelemetry) *validatorProcessor {
+	p := &validatorProcessor{
+		Config:    config,
+		logger:    logger,
+		telemetry: telemetry,
+		now:       time.Now,
+	}
+	if config.Namespace != "" {
+		p.namespaceErr = ValidateNamespace(config.Namespace)
+	}
+	return p
+}
+
+func (p *validatorProcessor) start(context.Context, component.Host) error {
+	if p.namespaceErr == nil {
+		return nil
+	}
+	if p.Policy == PolicyNone {
+		p.logger.Warn("CloudWatch does not accept the metrics of the namespace",
+			zap.String("namespace", p.Namespace), zap.Error(p.namespaceErr))
+	} else {
+		p.logger.Error("CloudWatch does not accept the metrics of the namespace, they will be dropped",
+			zap.String("namespace", p.Namespace), zap.Error(p.namespaceErr))
+	}
+	return nil
+}
+
+// processMetrics checks the metrics against the limits of CloudWatch before the awsemf exporter turns them into EMF
+// logs, and applies the policy to the ones that break a limit. CloudWatch would otherwise drop them without the agent
+// knowing. The dimensions are the attributes of the data points and of their resource.
+func (p *validatorProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	v := &violations{}
+	defer p.record(ctx, v)
+	if p.namespaceErr != nil {
+		count := int64(md.DataPointCount())
+		v.add(ruleNamespace, count)
+		if p.Policy != PolicyNone {
+			v.dropped += count
+			return md, processorhelper.ErrSkipProcessingData
+		}
+	}
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		resourceAttrs := rm.Resource().Attributes()
+		if !p.validateAttributes(resourceAttrs, v) || !p.limitResourceDimensions(resourceAttrs, v) {
+			v.dropped += int64(resourceDataPointCount(rm))
+			return true
+		}
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				count := dataPointCount(m)
+				if !p.validateMetricName(m, v) {
+					v.dropped += int64(count)
+					return true
+				}
+				removeDataPoints(m, func(attrs pcommon.Map) bool {
+					keep := p.validateAttributes(attrs, v) && p.limitDimensions(resourceAttrs, attrs, v)
+					if !keep {
+						v.dropped++
+					}
+					return !keep
+				})
+				return count > 0 && dataPointCount(m) == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// validateAttributes checks the names and values of the dimensions. With the truncate policy, the names and values
+// that are too long are shortened, and the dimensions without a value are removed. It returns false if the attributes
+// broke a limit and the policy drops them.
+func (p *validatorProcessor) validateAttributes(attrs pcommon.Map, v *violations) bool {
+	valid := true
+	renames := map[string]string{}
+	attrs.RemoveIf(func(k string, val pcommon.Value) bool {
+		if !isDimension(k) {
+			return false
+		}
+		remove := false
+		if k == "" {
+			v.add(ruleDimensionName, 1)
+			valid, remove = false, true
+		} else if utf8.RuneCountInString(k) > maxDimensionNameLength {
+			v.add(ruleDimensionName, 1)
+			valid = false
+			renames[k] = truncate(k, maxDimensionNameLength)
+		}
+		if s := val.AsString(); strings.TrimSpace(s) == "" {
+			v.add(ruleDimensionValue, 1)
+			valid, remove = false, true
+		} else if utf8.RuneCountInString(s) > maxDimensionValueLength {
+			v.add(ruleDimensionValue, 1)
+			valid = false
+			if p.Policy == PolicyTruncate {
+				val.SetStr(truncate(s, maxDimensionValueLength))
+			}
+		}
+		if remove && p.Policy == PolicyTruncate {
+			delete(renames, k)
+			return true
+		}
+		return false
+	})
+	if p.Policy == PolicyTruncate {
+		for name, truncated := range renames {
+			val, _ := attrs.Get(name)
+			moved := pcommon.NewValueEmpty()
+			val.CopyTo(moved)
+			attrs.Remove(name)
+			// The dimension is dropped if another one already has the truncated name.
+			if _, exists := attrs.Get(truncated); !exists {
+				moved.CopyTo(attrs.PutEmpty(truncated))
+			}
+		}
+	}
+	return valid || p.Policy != PolicyDrop
+}
+
+// limitResourceDimensions checks the number of dimensions of the resource attributes. With the truncate policy, the
+// dimensions over the limit are removed in alphabetical order.
+func (p *validatorProcessor) limitResourceDimensions(attrs pcommon.Map, v *violations) bool {
+	names := dimensionNames(attrs, nil)
+	if len(names) <= p.MaxDimensions {
+		return true
+	}
+	v.add(ruleDimensionCount, 1)
+	switch p.Policy {
+	case PolicyTruncate:
+		for _, name := range names[p.MaxDimensions:] {
+			attrs.Remove(name)
+		}
+	case PolicyDrop:
+		return false
+	}
+	return true
+}
+
+// limitDimensions checks the number of dimensions of a data point, which has the dimensions of its resource too. With
+// the truncate policy, the data point attributes over the limit are removed in alphabetical order, so that the
+// resource attributes shared by all the data points are kept.
+func (p *validatorProcessor) limitDimensions(resourceAttrs, attrs pcommon.Map, v *violations) bool {
+	resourceCount := len(dimensionNames(resourceAttrs, nil))
+	if resourceCount > p.MaxDimensions {
+		// The resource was already counted, and is only kept with the none policy.
+		return true
+	}
+	own := dimensionNames(attrs, &resourceAttrs)
+	excess := resourceCount + len(own) - p.MaxDimensions
+	if excess <= 0 {
+		return true
+	}
+	v.add(ruleDimensionCount, 1)
+	switch p.Policy {
+	case PolicyTruncate:
+		for _, name := range own[len(own)-excess:] {
+			attrs.Remove(name)
+		}
+	case PolicyDrop:
+		return false
+	}
+	return true
+}
+
+// validateMetricName checks the length of the metric name. With the truncate policy, a name that is too long is
+// shortened.
+func (p *validatorProcessor) validateMetricName(m pmetric.Metric, v *violations) bool {
+	name := m.Name()
+	if name != "" && utf8.RuneCountInString(name) <= maxMetricNameLength {
+		return true
+	}
+	v.add(ruleMetricName, 1)
+	switch p.Policy {
+	case PolicyTruncate:
+		if name == "" {
+			return false
+		}
+		m.SetName(truncate(name, maxMetricNameLength))
+	case PolicyDrop:
+		return false
+	}
+	return true
+}
+
+// record adds the violations of a batch to the counters, and logs the violations since the last log at most once per
+// violationLogInterval.
+func (p *validatorProcessor) record(ctx context.Context, v *violations) {
+	if len(v.byRule) == 0 && v.dropped == 0 {
+		return
+	}
+	p.telemetry.record(ctx, v)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for rule, n := range v.byRule {
+		p.pending.add(rule, n)
+	}
+	p.pending.dropped += v.dropped
+	if now := p.now(); now.Sub(p.lastLog) >= violationLogInterval {
+		fields := []zap.Field{zap.String("policy", p.Policy), zap.Int64("dropped", p.pending.dropped)}
+		for _, rule := range rules {
+			if n := p.pending.byRule[rule]; n > 0 {
+				fields = append(fields, zap.Int64(rule, n))
+			}
+		}
+		p.logger.Warn("Metrics broke the limits of CloudWatch", fields...)
+		p.pending = violations{}
+		p.lastLog = now
+	}
+}
+
+// dimensionNames returns the sorted names of the attributes that the awsemf exporter turns into dimensions, except
+// the ones in exclude.
+func dimensionNames(attrs pcommon.Map, exclude *pcommon.Map) []string {
+	var names []string
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		if !isDimension(k) {
+			return true
+		}
+		if exclude != nil {
+			if _, ok := exclude.Get(k); ok {
+				return true
+			}
+		}
+		names = append(names, k)
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+func isDimension(key string) bool {
+	return key != storageResolutionAttribute && !strings.HasPrefix(key, entityAttributePrefix)
+}
+
+// truncate returns the first n characters of s.
+func truncate(s string, n int) string {
+	count := 0
+	for i := range s {
+		if count == n {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
+
+func removeDataPoints(m pmetric.Metric, remove func(attrs pcommon.Map) bool) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		m.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return remove(dp.Attributes()) })
+	case pmetric.MetricTypeSum:
+		m.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return remove(dp.Attributes()) })
+	case pmetric.MetricTypeHistogram:
+		m.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return remove(dp.Attributes()) })
+	case pmetric.MetricTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			return remove(dp.Attributes())
+		})
+	case pmetric.MetricTypeSummary:
+		m.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return remove(dp.Attributes()) })
+	}
+}
+
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
+func resourceDataPointCount(rm pmetric.ResourceMetrics) int {
+	count := 0
+	sms := rm.ScopeMetrics()
+	for i := 0; i < sms.Len(); i++ {
+		ms := sms.At(i).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			count += dataPointCount(ms.At(j))
+		}
+	}
+	return count
+}
@@ -0,0 +1,239 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfvalidator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+)
+
+var (
+	longName  = strings.Repeat("n", 300)
+	longValue = strings.Repeat("v", 1100)
+)
+
+// generateMetrics creates a resource with the two attributes and a gauge per name with a data point per set of
+// attributes.
+func generateMetrics(names []string, dataPoints ...map[string]string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host", "i-123")
+	rm.Resource().Attributes().PutStr("service", "checkout")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for _, name := range names {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		dps := m.SetEmptyGauge().DataPoints()
+		for _, attrs := range dataPoints {
+			dp := dps.AppendEmpty()
+			dp.SetDoubleValue(1)
+			for k, v := range attrs {
+				dp.Attributes().PutStr(k, v)
+			}
+		}
+	}
+	return md
+}
+
+// manyAttributes returns n attributes named dim00, dim01, etc.
+func manyAttributes(n int) map[string]string {
+	attrs := map[string]string{}
+	for i := 0; i < n; i++ {
+		attrs[fmt.Sprintf("dim%02d", i)] = "value"
+	}
+	return attrs
+}
+
+func newTestProcessor(t *testing.T, policy string) (*validatorProcessor, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+	telemetry, err := newValidatorTelemetry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), "emfvalidator")
+	require.NoError(t, err)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Namespace = "CWAgent"
+	cfg.Policy = policy
+	p := newValidatorProcessor(cfg, zap.NewNop(), telemetry)
+	require.NoError(t, p.start(context.Background(), nil))
+	return p, reader
+}
+
+// collect returns the violations by rule and the dropped data points recorded by the processor.
+func collect(t *testing.T, reader *sdkmetric.ManualReader) (map[string]int64, int64) {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	byRule := map[string]int64{}
+	var dropped int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				switch m.Name {
+				case "emfvalidator_violations":
+					rule, _ := dp.Attributes.Value(attributeRule)
+					byRule[rule.AsString()] = dp.Value
+				case "emfvalidator_datapoints_dropped":
+					dropped = dp.Value
+				}
+			}
+		}
+	}
+	return byRule, dropped
+}
+
+func dataPointAttributes(md pmetric.Metrics, metric, dataPoint int) map[string]any {
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(metric).Gauge().DataPoints().At(dataPoint).Attributes().AsRaw()
+}
+
+func TestProcessMetricsWithTruncatePolicy(t *testing.T) {
+	p, reader := newTestProcessor(t, PolicyTruncate)
+	md := generateMetrics([]string{"requests", longName},
+		map[string]string{"operation": "GET /", "empty": " ", longName: "value", "path": longValue, storageResolutionAttribute: "1"},
+		manyAttributes(31),
+	)
+
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	assert.Equal(t, "requests", ms.At(0).Name())
+	assert.Equal(t, strings.Repeat("n", 255), ms.At(1).Name())
+	assert.Equal(t, map[string]any{
+		"operation":                "GET /",
+		strings.Repeat("n", 255):   "value",
+		"path":                     strings.Repeat("v", 1024),
+		storageResolutionAttribute: "1",
+	}, dataPointAttributes(md, 0, 0))
+	// The resource has 2 dimensions, so the data point keeps the first 28 of its own.
+	attrs := dataPointAttributes(md, 0, 1)
+	assert.Len(t, attrs, 28)
+	assert.Contains(t, attrs, "dim27")
+	assert.NotContains(t, attrs, "dim28")
+
+	byRule, dropped := collect(t, reader)
+	assert.Equal(t, map[string]int64{
+		ruleMetricName:     1,
+		ruleDimensionName:  2,
+		ruleDimensionValue: 4,
+		ruleDimensionCount: 2,
+	}, byRule)
+	assert.Zero(t, dropped)
+}
+
+func TestProcessMetricsWithDropPolicy(t *testing.T) {
+	p, reader := newTestProcessor(t, PolicyDrop)
+	md := generateMetrics([]string{"requests", longName},
+		map[string]string{"operation": "GET /"},
+		map[string]string{"operation": ""},
+		manyAttributes(29),
+	)
+
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.Len())
+	assert.Equal(t, "requests", ms.At(0).Name())
+	require.Equal(t, 1, ms.At(0).Gauge().DataPoints().Len())
+	assert.Equal(t, map[string]any{"operation": "GET /"}, dataPointAttributes(md, 0, 0))
+
+	byRule, dropped := collect(t, reader)
+	assert.Equal(t, map[string]int64{
+		ruleMetricName:     1,
+		ruleDimensionValue: 1,
+		ruleDimensionCount: 1,
+	}, byRule)
+	assert.EqualValues(t, 5, dropped)
+
+	// The batch is skipped when all its data points are dropped.
+	_, err = p.processMetrics(context.Background(), generateMetrics([]string{longName}, map[string]string{"operation": "GET /"}))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func TestProcessMetricsWithNonePolicy(t *testing.T) {
+	p, reader := newTestProcessor(t, PolicyNone)
+	md := generateMetrics([]string{longName}, map[string]string{"operation": "", "path": longValue}, manyAttributes(29))
+	want := pmetric.NewMetrics()
+	md.CopyTo(want)
+
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, want, md)
+
+	byRule, dropped := collect(t, reader)
+	assert.Equal(t, map[string]int64{
+		ruleMetricName:     1,
+		ruleDimensionValue: 2,
+		ruleDimensionCount: 1,
+	}, byRule)
+	assert.Zero(t, dropped)
+}
+
+func TestProcessMetricsWithResourceOverLimit(t *testing.T) {
+	for _, policy := range []string{PolicyTruncate, PolicyDrop} {
+		t.Run(policy, func(t *testing.T) {
+			p, reader := newTestProcessor(t, policy)
+			md := generateMetrics([]string{"requests"}, map[string]string{"operation": "GET /"})
+			resource := md.ResourceMetrics().At(0).Resource().Attributes()
+			for k, v := range manyAttributes(30) {
+				resource.PutStr(k, v)
+			}
+
+			md, err := p.processMetrics(context.Background(), md)
+			byRule, dropped := collect(t, reader)
+			if policy == PolicyDrop {
+				assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+				assert.Equal(t, map[string]int64{ruleDimensionCount: 1}, byRule)
+				assert.EqualValues(t, 1, dropped)
+				return
+			}
+			require.NoError(t, err)
+			// The resource keeps its first 30 dimensions, which leaves none for the data point.
+			assert.Equal(t, map[string]int64{ruleDimensionCount: 2}, byRule)
+			resource = md.ResourceMetrics().At(0).Resource().Attributes()
+			assert.Equal(t, 30, resource.Len())
+			_, ok := resource.Get("service")
+			assert.False(t, ok)
+			assert.Empty(t, dataPointAttributes(md, 0, 0))
+			assert.Zero(t, dropped)
+		})
+	}
+}
+
+func TestProcessMetricsWithInvalidNamespace(t *testing.T) {
+	for _, policy := range []string{PolicyTruncate, PolicyNone} {
+		t.Run(policy, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			telemetry, err := newValidatorTelemetry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), "emfvalidator")
+			require.NoError(t, err)
+			p := newValidatorProcessor(&Config{Namespace: "AWS/EC2", Policy: policy, MaxDimensions: MaxDimensions}, zap.NewNop(), telemetry)
+			require.NoError(t, p.start(context.Background(), nil))
+
+			_, err = p.processMetrics(context.Background(), generateMetrics([]string{"requests", "errors"}, map[string]string{"operation": "GET /"}))
+			byRule, dropped := collect(t, reader)
+			assert.Equal(t, map[string]int64{ruleNamespace: 2}, byRule)
+			if policy == PolicyNone {
+				assert.NoError(t, err)
+				assert.Zero(t, dropped)
+			} else {
+				assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+				assert.EqualValues(t, 2, dropped)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 5))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	assert.Equal(t, "é€", truncate("é€😀", 2))
+}
@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfvalidator
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const (
+	scopeName = "github.com/aws/amazon-cloudwatch-agent/plugins/processors/emfvalidator"
+
+	attributeProcessor = "processor"
+	attributeRule      = "rule"
+
+	// The limits of CloudWatch the violations are counted for.
+	ruleNamespace      = "namespace"
+	ruleDimensionCount = "dimension_count"
+	ruleMetricName     = "metric_name"
+	ruleDimensionName  = "dimension_name"
+	ruleDimensionValue = "dimension_value"
+)
+
+var rules = []string{ruleNamespace, ruleDimensionCount, ruleMetricName, ruleDimensionName, ruleDimensionValue}
+
+// validatorTelemetry records the violations of each rule and the data points dropped for them with the collector's
+// MeterProvider, so that the metrics CloudWatch would reject can be alarmed on.
+type validatorTelemetry struct {
+	attrs      metric.MeasurementOption
+	ruleAttrs  map[string]metric.MeasurementOption
+	violations metric.Int64Counter
+	dropped    metric.Int64Counter
+}
+
+// newValidatorTelemetry creates the instruments with the given provider. A nil provider records nothing.
+func newValidatorTelemetry(mp metric.MeterProvider, processor string) (*validatorTelemetry, error) {
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+	meter := mp.Meter(scopeName)
+	processorAttr := attribute.String(attributeProcessor, processor)
+	t := &validatorTelemetry{
+		attrs:     metric.WithAttributeSet(attribute.NewSet(processorAttr)),
+		ruleAttrs: map[string]metric.MeasurementOption{},
+	}
+	for _, rule := range rules {
+		t.ruleAttrs[rule] = metric.WithAttributeSet(attribute.NewSet(processorAttr, attribute.String(attributeRule, rule)))
+	}
+	var err error
+	if t.violations, err = meter.Int64Counter("emfvalidator_violations",
+		metric.WithDescription("Number of times the metrics broke a limit of CloudWatch, by the rule they broke"),
+		metric.WithUnit("{violations}"),
+	); err != nil {
+		return nil, err
+	}
+	if t.dropped, err = meter.Int64Counter("emfvalidator_datapoints_dropped",
+		metric.WithDescription("Number of metric data points dropped because they broke a limit of CloudWatch"),
+		metric.WithUnit("{datapoints}"),
+	); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// violations counts the rules broken by a batch, so that the counters are updated once per batch.
+type violations struct {
+	byRule  map[string]int64
+	dropped int64
+}
+
+func (v *violations) add(rule string, n int64) {
+	if v.byRule == nil {
+		v.byRule = map[string]int64{}
+	}
+	v.byRule[rule] += n
+}
+
+func (t *validatorTelemetry) record(ctx context.Context, v *violations) {
+	if t == nil {
+		return
+	}
+	for rule, n := range v.byRule {
+		t.violations.Add(ctx, n, t.ruleAttrs[rule])
+	}
+	if v.dropped > 0 {
+		t.dropped.Add(ctx, v.dropped, t.attrs)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package externalprocessor
+
+import (
+	"fmt"
+)
+
+// The methods of the ExternalProcessor service of externalprocessor.proto.
+const (
+	serviceName          = "amazon.cloudwatchagent.externalprocessor.v1.ExternalProcessor"
+	methodProcessMetrics = "/" + serviceName + "/ProcessMetrics"
+	methodProcessLogs    = "/" + serviceName + "/ProcessLogs"
+	methodProcessTraces  = "/" + serviceName + "/ProcessTraces"
+)
+
+// otlpMessage is the OTLP export request of a signal, which the service receives and returns.
+type otlpMessage interface {
+	MarshalProto() ([]byte, error)
+	UnmarshalProto(data []byte) error
+}
+
+// otlpCodec encodes the OTLP export requests of pdata in the protobuf wire format, without the generated types of
+// the OTLP protos.
+type otlpCodec struct{}
+
+func (otlpCodec) Marshal(v any) ([]byte, error) {
+	message, ok := v.(otlpMessage)
+	if !ok {
+		return nil, fmt.Errorf("unable to marshal %T, it is not an OTLP export request", v)
+	}
+	return message.MarshalProto()
+}
+
+func (otlpCodec) Unmarshal(data []byte, v any) error {
+	message, ok := v.(otlpMessage)
+	if !ok {
+		return fmt.Errorf("unable to unmarshal %T, it is not an OTLP export request", v)
+	}
+	return message.UnmarshalProto(data)
+}
+
+// Name is the content subtype of the calls, which is the one of the generated protobuf clients and servers.
+func (otlpCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package externalprocessor
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// FailurePolicyPass sends the batches the service failed to process on unchanged.
+	FailurePolicyPass = "pass"
+	// FailurePolicyDrop drops the batches the service failed to process.
+	FailurePolicyDrop = "drop"
+
+	defaultTimeout = time.Second
+	unixScheme     = "unix:"
+)
+
+type Config struct {
+	// Endpoint is the address of the local gRPC service, e.g. "localhost:4320" or "unix:///run/enricher.sock".
+	Endpoint string `mapstructure:"endpoint"`
+	// Timeout bounds each call to the service.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// FailurePolicy is applied to the batches the service failed to process, or did not answer in time.
+	FailurePolicy string `mapstructure:"failure_policy"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("'endpoint' must be set")
+	}
+	if err := validateLocal(cfg.Endpoint); err != nil {
+		return err
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("'timeout' must be positive")
+	}
+	switch cfg.FailurePolicy {
+	case FailurePolicyPass, FailurePolicyDrop:
+	default:
+		return fmt.Errorf("'failure_policy' must be %q or %q", FailurePolicyPass, FailurePolicyDrop)
+	}
+	return nil
+}
+
+// validateLocal checks that the endpoint is a unix socket or a loopback address, since the batches are sent to it
+// without encryption.
+func validateLocal(endpoint string) error {
+	if strings.HasPrefix(endpoint, unixScheme) {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return fmt.Errorf("'endpoint' must be host:port or a unix socket: %w", err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("'endpoint' must be a unix socket or a loopback address, but got %q", endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package externalprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := map[string]struct {
+		cfg     Config
+		wantErr string
+	}{
+		"WithLocalhost":   {cfg: Config{Endpoint: "localhost:4320", Timeout: time.Second, FailurePolicy: FailurePolicyPass}},
+		"WithLoopbackIP":  {cfg: Config{Endpoint: "[::1]:4320", Timeout: time.Second, FailurePolicy: FailurePolicyDrop}},
+		"WithUnixSocket":  {cfg: Config{Endpoint: "unix:///run/enricher.sock", Timeout: time.Second, FailurePolicy: FailurePolicyPass}},
+		"WithoutEndpoint": {cfg: Config{Timeout: time.Second, FailurePolicy: FailurePolicyPass}, wantErr: "'endpoint' must be set"},
+		"WithRemoteEndpoint": {
+			cfg:     Config{Endpoint: "10.0.0.1:4320", Timeout: time.Second, FailurePolicy: FailurePolicyPass},
+			wantErr: `'endpoint' must be a unix socket or a loopback address, but got "10.0.0.1:4320"`,
+		},
+		"WithoutPort": {
+			cfg:     Config{Endpoint: "localhost", Timeout: time.Second, FailurePolicy: FailurePolicyPass},
+			wantErr: "'endpoint' must be host:port or a unix socket: address localhost: missing port in address",
+		},
+		"WithoutTimeout": {cfg: Config{Endpoint: "localhost:4320", FailurePolicy: FailurePolicyPass}, wantErr: "'timeout' must be positive"},
+		"WithInvalidPolicy": {
+			cfg:     Config{Endpoint: "localhost:4320", Timeout: time.Second, FailurePolicy: "retry"},
+			wantErr: `'failure_policy' must be "pass" or "drop"`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.cfg.Validate()
+			if testCase.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package externalprocessor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// failureLogInterval limits how often the failed calls are logged.
+const failureLogInterval = time.Minute
+
+// externalProcessor sends each batch to a local gRPC service and continues the pipeline with the batch the service
+// returns, so that the data can be enriched or filtered by a proprietary service without changing the agent.
+type externalProcessor struct {
+	*Config
+	logger    *zap.Logger
+	telemetry *processorTelemetry
+	now       func() time.Time
+
+	conn *grpc.ClientConn
+
+	mu       sync.Mutex
+	failures int
+	lastLog  time.Time
+}
+
+func newExternalProcessor(config *Config, logger *zap.Logger, telemetry *processorTelemetry) *externalProcessor {
+	return &externalProcessor{
+		Config:    config,
+		logger:    logger,
+		telemetry: telemetry,
+		now:       time.Now,
+	}
+}
+
+// start creates the connection to the service, which is only established on the first call, so that the agent
+// starts before the service does.
+func (p *externalProcessor) start(context.Context, component.Host) error {
+	conn, err := grpc.NewClient(p.Endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(otlpCodec{})),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create the connection to the external processor %s: %w", p.Endpoint, err)
+	}
+	p.conn = conn
+	return nil
+}
+
+func (p *externalProcessor) shutdown(context.Context) error {
+	if p.conn == nil {
+		return nil
+	}
+	return p.conn.Close()
+}
+
+func (p *externalProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	resp := pmetricotlp.NewExportRequest()
+	if err := p.call(ctx, methodProcessMetrics, pmetricotlp.NewExportRequestFromMetrics(md), resp); err != nil {
+		return md, p.handleFailure(ctx, err, md.DataPointCount())
+	}
+	if resp.Metrics().ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return resp.Metrics(), nil
+}
+
+func (p *externalProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	resp := plogotlp.NewExportRequest()
+	if err := p.call(ctx, methodProcessLogs, plogotlp.NewExportRequestFromLogs(ld), resp); err != nil {
+		return ld, p.handleFailure(ctx, err, ld.LogRecordCount())
+	}
+	if resp.Logs().ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return resp.Logs(), nil
+}
+
+func (p *externalProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	resp := ptraceotlp.NewExportRequest()
+	if err := p.call(ctx, methodProcessTraces, ptraceotlp.NewExportRequestFromTraces(td), resp); err != nil {
+		return td, p.handleFailure(ctx, err, td.SpanCount())
+	}
+	if resp.Traces().ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return resp.Traces(), nil
+}
+
+func (p *externalProcessor) call(ctx context.Context, method string, req, resp otlpMessage) error {
+	callCtx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+	start := p.now()
+	err := p.conn.Invoke(callCtx, method, req, resp)
+	p.telemetry.recordCall(ctx, p.now().Sub(start).Seconds(), err)
+	return err
+}
+
+// handleFailure applies the failure policy to a batch the service failed to process. With the pass policy the
+// batch continues unchanged, and with the drop policy it is dropped.
+func (p *externalProcessor) handleFailure(ctx context.Context, err error, items int) error {
+	p.mu.Lock()
+	p.failures++
+	if now := p.now(); now.Sub(p.lastLog) >= failureLogInterval {
+		p.logger.Warn("External processor failed to process the batches",
+			zap.String("endpoint", p.Endpoint),
+			zap.String("failure_policy", p.FailurePolicy),
+			zap.Int("failures", p.failures),
+			zap.Error(err))
+		p.failures = 0
+		p.lastLog = now
+	}
+	p.mu.Unlock()
+	if p.FailurePolicy == FailurePolicyDrop {
+		p.telemetry.recordDropped(ctx, items)
+		return processorhelper.ErrSkipProcessingData
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package externalprocessor
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// enricher is a service that adds the team attribute to the resources, drops the logs and fails the traces.
+func enricher(_ any, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	switch method {
+	case methodProcessMetrics:
+		req := pmetricotlp.NewExportRequest()
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+		rms := req.Metrics().ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rms.At(i).Resource().Attributes().PutStr("team", "payments")
+		}
+		return stream.SendMsg(req)
+	case methodProcessLogs:
+		req := plogotlp.NewExportRequest()
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+		return stream.SendMsg(plogotlp.NewExportRequest())
+	case methodProcessTraces:
+		req := ptraceotlp.NewExportRequest()
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+		time.Sleep(100 * time.Millisecond)
+		return status.Error(codes.Unavailable, "overloaded")
+	}
+	return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+}
+
+func newTestServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.ForceServerCodec(otlpCodec{}), grpc.UnknownServiceHandler(enricher))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func newTestProcessor(t *testing.T, endpoint, policy string) (*externalProcessor, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+	telemetry, err := newProcessorTelemetry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), "externalprocessor")
+	require.NoError(t, err)
+	p := newExternalProcessor(&Config{Endpoint: endpoint, Timeout: time.Second, FailurePolicy: policy}, zap.NewNop(), telemetry)
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, p.shutdown(context.Background()))
+	})
+	return p, reader
+}
+
+func sumValues(t *testing.T, reader *sdkmetric.ManualReader, name string) map[string]int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				outcome, _ := dp.Attributes.Value(attributeOutcome)
+				values[outcome.AsString()] += dp.Value
+			}
+		}
+	}
+	return values
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p, reader := newTestProcessor(t, newTestServer(t), FailurePolicyPass)
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("orders")
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	team, ok := got.ResourceMetrics().At(0).Resource().Attributes().Get("team")
+	require.True(t, ok)
+	assert.Equal(t, "payments", team.Str())
+	assert.Equal(t, "orders", got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	assert.Equal(t, map[string]int64{outcomeSuccess: 1}, sumValues(t, reader, "externalprocessor_calls"))
+}
+
+func TestProcessLogsFiltered(t *testing.T) {
+	p, _ := newTestProcessor(t, newTestServer(t), FailurePolicyPass)
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("debug")
+
+	_, err := p.processLogs(context.Background(), ld)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func TestProcessTracesFailurePolicy(t *testing.T) {
+	endpoint := newTestServer(t)
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /")
+
+	p, reader := newTestProcessor(t, endpoint, FailurePolicyPass)
+	got, err := p.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+	assert.Equal(t, map[string]int64{outcomeFailure: 1}, sumValues(t, reader, "externalprocessor_calls"))
+	assert.Empty(t, sumValues(t, reader, "externalprocessor_items_dropped"))
+
+	p, reader = newTestProcessor(t, endpoint, FailurePolicyDrop)
+	_, err = p.processTraces(context.Background(), td)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	assert.Equal(t, map[string]int64{"": 1}, sumValues(t, reader, "externalprocessor_items_dropped"))
+
+	// the calls the service does not answer in time fail
+	p.Timeout = 10 * time.Millisecond
+	start := time.Now()
+	_, err = p.processTraces(context.Background(), td)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestProcessUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	p, _ := newTestProcessor(t, endpoint, FailurePolicyPass)
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+}
@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logsdestination
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config holds the templates of the log group and log stream names. A {key} placeholder is replaced with the value
+// of the resource attribute key of the logs.
+type Config struct {
+	// LogGroupName is the template of the name of the log group the logs are sent to.
+	LogGroupName string `mapstructure:"log_group_name"`
+	// LogStreamName is the template of the name of the log stream the logs are sent to.
+	LogStreamName string `mapstructure:"log_stream_name"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.LogGroupName == "" {
+		return errors.New("'log_group_name' must be set")
+	}
+	if cfg.LogStreamName == "" {
+		return errors.New("'log_stream_name' must be set")
+	}
+	return nil
+}
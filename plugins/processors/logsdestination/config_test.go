@@ -0,0 +1,16 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logsdestination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, (&Config{LogGroupName: "/aws/otlp/{service.name}", LogStreamName: "i-123"}).Validate())
+	assert.Error(t, (&Config{LogStreamName: "i-123"}).Validate())
+	assert.Error(t, (&Config{LogGroupName: "/aws/otlp/{service.name}"}).Validate())
+}
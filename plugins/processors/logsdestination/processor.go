@@ -0,0 +1,146 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logsdestination
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	// missingValue replaces the placeholders of the resource attributes the logs do not have, like the awsemf
+	// exporter does for its log group names.
+	missingValue = "undefined"
+	// maxNameLength is the length CloudWatch Logs accepts for log group and log stream names.
+	maxNameLength = 512
+)
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+	// invalidLogGroupChars are the characters CloudWatch Logs does not accept in log group names.
+	invalidLogGroupChars = regexp.MustCompile(`[^.\-_/#A-Za-z0-9]`)
+	// invalidLogStreamChars are the characters CloudWatch Logs does not accept in log stream names.
+	invalidLogStreamChars = regexp.MustCompile(`[:*]`)
+)
+
+// HasPlaceholders is true if the template has placeholders to replace with resource attributes.
+func HasPlaceholders(template string) bool {
+	return placeholderPattern.MatchString(template)
+}
+
+type destinationProcessor struct {
+	*Config
+}
+
+func newDestinationProcessor(config *Config) *destinationProcessor {
+	return &destinationProcessor{Config: config}
+}
+
+// processLogs resolves the log group and log stream of the logs of each resource, and replaces the body of every log
+// record with the JSON the awscloudwatchlogs exporter sends for it, with the names added as log_group_name and
+// log_stream_name. The exporter sends the bodies as they are when raw_log is set, to the log group and log stream
+// they name.
+func (p *destinationProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceAttrs := rl.Resource().Attributes()
+		logGroupName := resolve(p.LogGroupName, resourceAttrs, invalidLogGroupChars)
+		logStreamName := resolve(p.LogStreamName, resourceAttrs, invalidLogStreamChars)
+		resource := attrsValue(resourceAttrs)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			var scope *scopeBody
+			// scope should have a name at least
+			if sl.Scope().Name() != "" {
+				scope = &scopeBody{
+					Name:       sl.Scope().Name(),
+					Version:    sl.Scope().Version(),
+					Attributes: attrsValue(sl.Scope().Attributes()),
+				}
+			}
+			records := sl.LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				record := records.At(k)
+				body := logBody{
+					Body:                   record.Body().AsRaw(),
+					SeverityNumber:         int32(record.SeverityNumber()),
+					SeverityText:           record.SeverityText(),
+					DroppedAttributesCount: record.DroppedAttributesCount(),
+					Flags:                  uint32(record.Flags()),
+					Attributes:             attrsValue(record.Attributes()),
+					Scope:                  scope,
+					Resource:               resource,
+					LogGroupName:           logGroupName,
+					LogStreamName:          logStreamName,
+				}
+				if traceID := record.TraceID(); !traceID.IsEmpty() {
+					body.TraceID = hex.EncodeToString(traceID[:])
+				}
+				if spanID := record.SpanID(); !spanID.IsEmpty() {
+					body.SpanID = hex.EncodeToString(spanID[:])
+				}
+				encoded, err := json.Marshal(body)
+				if err != nil {
+					return ld, err
+				}
+				record.Body().SetStr(string(encoded))
+			}
+		}
+	}
+	return ld, nil
+}
+
+// resolve replaces the placeholders of the template with the values of the resource attributes. The characters
+// CloudWatch Logs does not accept in the values are replaced with _.
+func resolve(template string, attrs pcommon.Map, invalidChars *regexp.Regexp) string {
+	name := placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value := missingValue
+		if v, ok := attrs.Get(strings.Trim(placeholder, "{}")); ok && v.AsString() != "" {
+			value = v.AsString()
+		}
+		return invalidChars.ReplaceAllString(value, "_")
+	})
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return name
+}
+
+type scopeBody struct {
+	Name       string         `json:"name,omitempty"`
+	Version    string         `json:"version,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// logBody is the JSON the awscloudwatchlogs exporter sends for a log record, with the log group and log stream it
+// is sent to.
+type logBody struct {
+	Body                   any            `json:"body,omitempty"`
+	SeverityNumber         int32          `json:"severity_number,omitempty"`
+	SeverityText           string         `json:"severity_text,omitempty"`
+	DroppedAttributesCount uint32         `json:"dropped_attributes_count,omitempty"`
+	Flags                  uint32         `json:"flags,omitempty"`
+	TraceID                string         `json:"trace_id,omitempty"`
+	SpanID                 string         `json:"span_id,omitempty"`
+	Attributes             map[string]any `json:"attributes,omitempty"`
+	Scope                  *scopeBody     `json:"scope,omitempty"`
+	Resource               map[string]any `json:"resource,omitempty"`
+	LogGroupName           string         `json:"log_group_name"`
+	LogStreamName          string         `json:"log_stream_name"`
+}
+
+func attrsValue(attrs pcommon.Map) map[string]any {
+	if attrs.Len() == 0 {
+		return nil
+	}
+	return attrs.AsRaw()
+}
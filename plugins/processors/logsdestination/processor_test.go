@@ -0,0 +1,79 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logsdestination
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestProcessLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.Resource().Attributes().PutStr("k8s.pod.name", "checkout:7d9f*")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("io.opentelemetry.logback")
+	record := sl.LogRecords().AppendEmpty()
+	record.Body().SetStr("order placed")
+	record.SetSeverityText("INFO")
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.Attributes().PutInt("order.id", 42)
+	record.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	other := ld.ResourceLogs().AppendEmpty()
+	other.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("no service")
+
+	p := newDestinationProcessor(&Config{LogGroupName: "/aws/otlp/{service.name}", LogStreamName: "{k8s.pod.name}"})
+	ld, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(record.Body().Str()), &body))
+	assert.Equal(t, map[string]any{
+		"body":            "order placed",
+		"severity_number": float64(plog.SeverityNumberInfo),
+		"severity_text":   "INFO",
+		"trace_id":        "0102030405060708090a0b0c0d0e0f10",
+		"attributes":      map[string]any{"order.id": float64(42)},
+		"scope":           map[string]any{"name": "io.opentelemetry.logback"},
+		"resource":        map[string]any{"service.name": "checkout", "k8s.pod.name": "checkout:7d9f*"},
+		"log_group_name":  "/aws/otlp/checkout",
+		"log_stream_name": "checkout_7d9f_",
+	}, body)
+
+	body = nil
+	require.NoError(t, json.Unmarshal([]byte(ld.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0).Body().Str()), &body))
+	assert.Equal(t, map[string]any{
+		"body":            "no service",
+		"log_group_name":  "/aws/otlp/undefined",
+		"log_stream_name": "undefined",
+	}, body)
+}
+
+func TestResolve(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("service.name", "checkout api")
+	attrs.PutStr("deployment.environment", "prod")
+	attrs.PutStr("empty", "")
+
+	assert.Equal(t, "/aws/otlp/checkout_api/prod", resolve("/aws/otlp/{service.name}/{deployment.environment}", attrs, invalidLogGroupChars))
+	assert.Equal(t, "checkout api", resolve("{service.name}", attrs, invalidLogStreamChars))
+	assert.Equal(t, "/static", resolve("/static", attrs, invalidLogGroupChars))
+	assert.Equal(t, "/aws/undefined", resolve("/aws/{empty}", attrs, invalidLogGroupChars))
+	attrs.PutStr("long", strings.Repeat("a", 600))
+	assert.Len(t, resolve("{long}", attrs, invalidLogGroupChars), maxNameLength)
+}
+
+func TestHasPlaceholders(t *testing.T) {
+	assert.True(t, HasPlaceholders("/aws/otlp/{service.name}"))
+	assert.False(t, HasPlaceholders("/aws/otlp/checkout"))
+}
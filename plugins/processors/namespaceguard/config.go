@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package namespaceguard
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gobwas/glob"
+	"go.opentelemetry.io/collector/component"
+)
+
+type Config struct {
+	// Namespace is the CloudWatch namespace the metrics of the pipeline are published to.
+	Namespace string `mapstructure:"namespace"`
+	// AllowedNamespaces are the namespaces, which can contain * wildcards, the agent may publish to.
+	AllowedNamespaces []string `mapstructure:"allowed_namespaces"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.Namespace == "" {
+		return errors.New("'namespace' must be set")
+	}
+	for _, pattern := range cfg.AllowedNamespaces {
+		if _, err := glob.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid allowed namespace %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// IsAllowed is true if the namespace matches one of the allowed namespaces.
+func (cfg *Config) IsAllowed() bool {
+	for _, pattern := range cfg.AllowedNamespaces {
+		if g, err := glob.Compile(pattern); err == nil && g.Match(cfg.Namespace) {
+			return true
+		}
+	}
+	return false
+}
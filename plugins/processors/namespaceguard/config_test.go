@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package namespaceguard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.Error(t, (&Config{AllowedNamespaces: []string{"CWAgent"}}).Validate())
+	assert.Error(t, (&Config{Namespace: "CWAgent", AllowedNamespaces: []string{"Team/[a"}}).Validate())
+	assert.NoError(t, (&Config{Namespace: "CWAgent"}).Validate())
+	assert.NoError(t, (&Config{Namespace: "CWAgent", AllowedNamespaces: []string{"CWAgent", "Team/*"}}).Validate())
+}
+
+func TestIsAllowed(t *testing.T) {
+	testCases := map[string]struct {
+		namespace string
+		allowed   []string
+		want      bool
+	}{
+		"WithExactMatch": {
+			namespace: "CWAgent",
+			allowed:   []string{"CWAgent"},
+			want:      true,
+		},
+		"WithWildcard": {
+			namespace: "Team/Payments/Api",
+			allowed:   []string{"CWAgent", "Team/*"},
+			want:      true,
+		},
+		"WithCaseMismatch": {
+			namespace: "cwagent",
+			allowed:   []string{"CWAgent"},
+		},
+		"WithReserved": {
+			namespace: "AWS/EC2",
+			allowed:   []string{"CWAgent", "Team/*"},
+		},
+		"WithNoneAllowed": {
+			namespace: "CWAgent",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{Namespace: testCase.namespace, AllowedNamespaces: testCase.allowed}
+			assert.Equal(t, testCase.want, cfg.IsAllowed())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package namespaceguard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+// dropLogInterval limits how often the dropped data points are logged.
+const dropLogInterval = time.Minute
+
+type namespaceGuardProcessor struct {
+	*Config
+	logger  *zap.Logger
+	allowed bool
+	now     func() time.Time
+
+	mu      sync.Mutex
+	dropped int
+	lastLog time.Time
+}
+
+func newNamespaceGuardProcessor(config *Config, logger *zap.Logger) *namespaceGuardProcessor {
+	return &namespaceGuardProcessor{
+		Config:  config,
+		logger:  logger,
+		allowed: config.IsAllowed(),
+		now:     time.Now,
+	}
+}
+
+func (p *namespaceGuardProcessor) start(context.Context, component.Host) error {
+	if !p.allowed {
+		p.logger.Error("Namespace is not in the allowed namespaces, its metrics will be dropped",
+			zap.String("namespace", p.Namespace),
+			zap.Strings("allowed_namespaces", p.AllowedNamespaces))
+	}
+	return nil
+}
+
+// processMetrics passes the metrics through if the namespace is allowed and drops them otherwise.
+func (p *namespaceGuardProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	if p.allowed {
+		return md, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dropped += md.DataPointCount()
+	if now := p.now(); now.Sub(p.lastLog) >= dropLogInterval {
+		p.logger.Warn("Dropped data points for a namespace that is not allowed",
+			zap.String("namespace", p.Namespace),
+			zap.Int("count", p.dropped))
+		p.dropped = 0
+		p.lastLog = now
+	}
+	return md, processorhelper.ErrSkipProcessingData
+}
@@ -0,0 +1,83 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package namespaceguard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func testMetrics(count int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("cpu_usage_idle")
+	gauge := metric.SetEmptyGauge()
+	for i := 0; i < count; i++ {
+		gauge.DataPoints().AppendEmpty().SetDoubleValue(float64(i))
+	}
+	return md
+}
+
+func TestProcessMetrics(t *testing.T) {
+	testCases := map[string]struct {
+		cfg       *Config
+		wantCount int
+	}{
+		"WithAllowed": {
+			cfg:       &Config{Namespace: "CWAgent", AllowedNamespaces: []string{"CWAgent"}},
+			wantCount: 2,
+		},
+		"WithDenied": {
+			cfg: &Config{Namespace: "AWS/EC2", AllowedNamespaces: []string{"CWAgent"}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			p, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(), testCase.cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			assert.NoError(t, p.ConsumeMetrics(context.Background(), testMetrics(2)))
+			assert.Equal(t, testCase.wantCount, sink.DataPointCount())
+			assert.NoError(t, p.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestDroppedLogs(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	p := newNamespaceGuardProcessor(&Config{Namespace: "AWS/EC2", AllowedNamespaces: []string{"CWAgent"}}, zap.New(core))
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	require.Equal(t, 1, logs.FilterLevelExact(zapcore.ErrorLevel).Len())
+
+	for i := 0; i < 3; i++ {
+		_, err := p.processMetrics(context.Background(), testMetrics(2))
+		assert.Error(t, err)
+	}
+	// later drops within the interval are only counted
+	warnings := logs.FilterLevelExact(zapcore.WarnLevel).All()
+	require.Len(t, warnings, 1)
+	assert.EqualValues(t, 2, warnings[0].ContextMap()["count"])
+
+	now = now.Add(dropLogInterval)
+	_, err := p.processMetrics(context.Background(), testMetrics(1))
+	assert.Error(t, err)
+	warnings = logs.FilterLevelExact(zapcore.WarnLevel).All()
+	require.Len(t, warnings, 2)
+	assert.EqualValues(t, 5, warnings[1].ContextMap()["count"])
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package outoforder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// ActionAccept passes the data points through unchanged.
+	ActionAccept = "accept"
+	// ActionReorder buffers the data points for the reorder window and sorts them by timestamp before passing them
+	// on. The data points arriving after the window are dropped like with ActionDrop.
+	ActionReorder = "reorder"
+	// ActionDrop drops the data points with a timestamp that is not newer than the last one passed on for the series.
+	ActionDrop = "drop"
+
+	defaultReorderWindow = 10 * time.Second
+)
+
+type Config struct {
+	// Action is how data points older than, or as old as, the last data point of their series are handled.
+	Action string `mapstructure:"action"`
+	// ReorderWindow is how long the reorder action buffers the data points.
+	ReorderWindow time.Duration `mapstructure:"reorder_window"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	switch cfg.Action {
+	case ActionAccept, ActionDrop:
+	case ActionReorder:
+		if cfg.ReorderWindow <= 0 {
+			return errors.New("'reorder_window' must be greater than 0")
+		}
+	default:
+		return fmt.Errorf("unsupported action %q, must be one of %q, %q or %q", cfg.Action, ActionAccept, ActionReorder, ActionDrop)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package outoforder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, createDefaultConfig().(*Config).Validate())
+	assert.NoError(t, (&Config{Action: ActionDrop}).Validate())
+	assert.NoError(t, (&Config{Action: ActionReorder, ReorderWindow: time.Second}).Validate())
+	assert.EqualError(t, (&Config{Action: ActionReorder}).Validate(), "'reorder_window' must be greater than 0")
+	assert.ErrorContains(t, (&Config{Action: "sort"}).Validate(), `unsupported action "sort"`)
+}
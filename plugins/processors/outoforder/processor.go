@@ -0,0 +1,313 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package outoforder
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+)
+
+const (
+	// dropLogInterval limits how often the drop counters are logged.
+	dropLogInterval = time.Minute
+	// seriesExpiry is how long the last timestamp of a series is kept after its last data point.
+	seriesExpiry = 15 * time.Minute
+)
+
+// counters are the number of data points dropped since they were last logged.
+type counters struct {
+	outOfOrder int
+	duplicate  int
+}
+
+type series struct {
+	timestamp pcommon.Timestamp
+	seen      time.Time
+}
+
+type outOfOrderProcessor struct {
+	*Config
+	logger *zap.Logger
+	next   consumer.Metrics
+	now    func() time.Time
+
+	mu        sync.Mutex
+	series    map[[16]byte]series
+	buffered  []pmetric.Metrics
+	total     counters
+	lastLog   time.Time
+	lastPrune time.Time
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newOutOfOrderProcessor(config *Config, logger *zap.Logger, next consumer.Metrics) *outOfOrderProcessor {
+	return &outOfOrderProcessor{
+		Config: config,
+		logger: logger,
+		next:   next,
+		now:    time.Now,
+		series: map[[16]byte]series{},
+		done:   make(chan struct{}),
+	}
+}
+
+func (p *outOfOrderProcessor) start(context.Context, component.Host) error {
+	if p.Action != ActionReorder {
+		return nil
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.ReorderWindow)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.flush(context.Background())
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// shutdown passes on the buffered data points, so that they are not lost when the agent stops.
+func (p *outOfOrderProcessor) shutdown(ctx context.Context) error {
+	if p.Action != ActionReorder {
+		return nil
+	}
+	close(p.done)
+	p.wg.Wait()
+	p.flush(ctx)
+	return nil
+}
+
+// processMetrics drops the data points that are not newer than the last data point of their series. With the reorder
+// action the metrics are buffered instead and passed on by the next flush.
+func (p *outOfOrderProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	switch p.Action {
+	case ActionDrop:
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.dropPublished(md)
+		if md.DataPointCount() == 0 {
+			return md, processorhelper.ErrSkipProcessingData
+		}
+		return md, nil
+	case ActionReorder:
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.buffered = append(p.buffered, md)
+		return md, processorhelper.ErrSkipProcessingData
+	default:
+		return md, nil
+	}
+}
+
+// flush merges the buffered metrics, sorts the data points of each metric by timestamp and passes them on. The data
+// points that arrived too late to be sorted in are dropped.
+func (p *outOfOrderProcessor) flush(ctx context.Context) {
+	p.mu.Lock()
+	buffered := p.buffered
+	p.buffered = nil
+	if len(buffered) == 0 {
+		p.mu.Unlock()
+		return
+	}
+	md := merge(buffered)
+	metric.RangeMetrics(md, sortDataPoints)
+	p.dropPublished(md)
+	p.mu.Unlock()
+	if md.DataPointCount() == 0 {
+		return
+	}
+	if err := p.next.ConsumeMetrics(ctx, md); err != nil {
+		p.logger.Error("Failed to pass on the reordered metrics", zap.Error(err))
+	}
+}
+
+// dropPublished removes the data points with a timestamp older than, or the same as, the last data point of their
+// series, and records the timestamps of the others. Must be called with the lock held.
+func (p *outOfOrderProcessor) dropPublished(md pmetric.Metrics) {
+	now := p.now()
+	p.prune(now)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceAttrs := rm.Resource().Attributes()
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					dropPublished[pmetric.NumberDataPoint](p, m.Gauge().DataPoints(), resourceAttrs, m.Name(), now)
+				case pmetric.MetricTypeSum:
+					dropPublished[pmetric.NumberDataPoint](p, m.Sum().DataPoints(), resourceAttrs, m.Name(), now)
+				case pmetric.MetricTypeHistogram:
+					dropPublished[pmetric.HistogramDataPoint](p, m.Histogram().DataPoints(), resourceAttrs, m.Name(), now)
+				case pmetric.MetricTypeExponentialHistogram:
+					dropPublished[pmetric.ExponentialHistogramDataPoint](p, m.ExponentialHistogram().DataPoints(), resourceAttrs, m.Name(), now)
+				case pmetric.MetricTypeSummary:
+					dropPublished[pmetric.SummaryDataPoint](p, m.Summary().DataPoints(), resourceAttrs, m.Name(), now)
+				}
+			}
+		}
+	}
+	p.record(now)
+}
+
+func dropPublished[T metric.DataPoint[T]](p *outOfOrderProcessor, dps metric.DataPoints[T], resourceAttrs pcommon.Map, name string, now time.Time) {
+	dps.RemoveIf(func(dp T) bool {
+		key := pdatautil.Hash(pdatautil.WithMap(resourceAttrs), pdatautil.WithString(name), pdatautil.WithMap(dp.Attributes()))
+		last, ok := p.series[key]
+		if ok && dp.Timestamp() < last.timestamp {
+			p.total.outOfOrder++
+			return true
+		}
+		if ok && dp.Timestamp() == last.timestamp {
+			p.total.duplicate++
+			return true
+		}
+		p.series[key] = series{timestamp: dp.Timestamp(), seen: now}
+		return false
+	})
+}
+
+// prune forgets the series that have not had a data point for a while, checking at most once per interval.
+func (p *outOfOrderProcessor) prune(now time.Time) {
+	if now.Sub(p.lastPrune) < dropLogInterval {
+		return
+	}
+	for key, s := range p.series {
+		if now.Sub(s.seen) >= seriesExpiry {
+			delete(p.series, key)
+		}
+	}
+	p.lastPrune = now
+}
+
+// record logs the drop counters at most once per interval.
+func (p *outOfOrderProcessor) record(now time.Time) {
+	if p.total == (counters{}) {
+		return
+	}
+	if now.Sub(p.lastLog) >= dropLogInterval {
+		p.logger.Warn("Dropped data points that are not newer than the last data point of their series",
+			zap.String("action", p.Action),
+			zap.Int("out_of_order", p.total.outOfOrder),
+			zap.Int("duplicate", p.total.duplicate))
+		p.total = counters{}
+		p.lastLog = now
+	}
+}
+
+// merge combines the metrics of the batches with the same resource, scope, name, type and unit into one metric, so
+// that their data points can be sorted together.
+func merge(batches []pmetric.Metrics) pmetric.Metrics {
+	merged := pmetric.NewMetrics()
+	resources := map[[16]byte]pmetric.ResourceMetrics{}
+	scopes := map[[16]byte]pmetric.ScopeMetrics{}
+	metrics := map[[16]byte]pmetric.Metric{}
+	for _, md := range batches {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rm := rms.At(i)
+			resourceKey := pdatautil.MapHash(rm.Resource().Attributes())
+			mergedRM, ok := resources[resourceKey]
+			if !ok {
+				mergedRM = merged.ResourceMetrics().AppendEmpty()
+				rm.Resource().CopyTo(mergedRM.Resource())
+				mergedRM.SetSchemaUrl(rm.SchemaUrl())
+				resources[resourceKey] = mergedRM
+			}
+			sms := rm.ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				sm := sms.At(j)
+				scopeKey := pdatautil.Hash(
+					pdatautil.WithString(string(resourceKey[:])),
+					pdatautil.WithString(sm.Scope().Name()),
+					pdatautil.WithString(sm.Scope().Version()),
+					pdatautil.WithMap(sm.Scope().Attributes()),
+				)
+				mergedSM, ok := scopes[scopeKey]
+				if !ok {
+					mergedSM = mergedRM.ScopeMetrics().AppendEmpty()
+					sm.Scope().CopyTo(mergedSM.Scope())
+					mergedSM.SetSchemaUrl(sm.SchemaUrl())
+					scopes[scopeKey] = mergedSM
+				}
+				ms := sm.Metrics()
+				for k := 0; k < ms.Len(); k++ {
+					m := ms.At(k)
+					metricKey := pdatautil.Hash(
+						pdatautil.WithString(string(scopeKey[:])),
+						pdatautil.WithString(m.Name()),
+						pdatautil.WithString(m.Type().String()),
+						pdatautil.WithString(m.Unit()),
+					)
+					if mergedMetric, ok := metrics[metricKey]; ok {
+						moveDataPoints(m, mergedMetric)
+						continue
+					}
+					mergedMetric := mergedSM.Metrics().AppendEmpty()
+					m.MoveTo(mergedMetric)
+					metrics[metricKey] = mergedMetric
+				}
+			}
+		}
+	}
+	return merged
+}
+
+func moveDataPoints(from, to pmetric.Metric) {
+	switch from.Type() {
+	case pmetric.MetricTypeGauge:
+		from.Gauge().DataPoints().MoveAndAppendTo(to.Gauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		from.Sum().DataPoints().MoveAndAppendTo(to.Sum().DataPoints())
+	case pmetric.MetricTypeHistogram:
+		from.Histogram().DataPoints().MoveAndAppendTo(to.Histogram().DataPoints())
+	case pmetric.MetricTypeExponentialHistogram:
+		from.ExponentialHistogram().DataPoints().MoveAndAppendTo(to.ExponentialHistogram().DataPoints())
+	case pmetric.MetricTypeSummary:
+		from.Summary().DataPoints().MoveAndAppendTo(to.Summary().DataPoints())
+	}
+}
+
+// sortDataPoints sorts the data points by timestamp. The sort is stable, so the data points with the same timestamp
+// keep the order they arrived in.
+func sortDataPoints(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		m.Gauge().DataPoints().Sort(byTimestamp[pmetric.NumberDataPoint])
+	case pmetric.MetricTypeSum:
+		m.Sum().DataPoints().Sort(byTimestamp[pmetric.NumberDataPoint])
+	case pmetric.MetricTypeHistogram:
+		m.Histogram().DataPoints().Sort(byTimestamp[pmetric.HistogramDataPoint])
+	case pmetric.MetricTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().Sort(byTimestamp[pmetric.ExponentialHistogramDataPoint])
+	case pmetric.MetricTypeSummary:
+		m.Summary().DataPoints().Sort(byTimestamp[pmetric.SummaryDataPoint])
+	}
+}
+
+func byTimestamp[T metric.DataPoint[T]](a, b T) bool {
+	return a.Timestamp() < b.Timestamp()
+}
@@ -0,0 +1,148 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package outoforder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var baseTime = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// testMetrics creates a gauge with a data point per second offset from the base time for each host.
+func testMetrics(name string, offsets map[string][]int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	dps := m.SetEmptyGauge().DataPoints()
+	for host, seconds := range offsets {
+		for _, second := range seconds {
+			dp := dps.AppendEmpty()
+			dp.Attributes().PutStr("host", host)
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(baseTime.Add(time.Duration(second) * time.Second)))
+			dp.SetDoubleValue(float64(second))
+		}
+	}
+	return md
+}
+
+func values(md pmetric.Metrics) []float64 {
+	var got []float64
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				dps := ms.At(k).Gauge().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					got = append(got, dps.At(l).DoubleValue())
+				}
+			}
+		}
+	}
+	return got
+}
+
+func TestProcessMetricsAccept(t *testing.T) {
+	p := newOutOfOrderProcessor(createDefaultConfig().(*Config), zap.NewNop(), consumertest.NewNop())
+	for i := 0; i < 2; i++ {
+		got, err := p.processMetrics(context.Background(), testMetrics("requests", map[string][]int{"a": {2, 1}}))
+		require.NoError(t, err)
+		assert.Equal(t, []float64{2, 1}, values(got))
+	}
+}
+
+func TestProcessMetricsDrop(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	p := newOutOfOrderProcessor(&Config{Action: ActionDrop}, zap.New(core), consumertest.NewNop())
+	now := baseTime
+	p.now = func() time.Time { return now }
+
+	got, err := p.processMetrics(context.Background(), testMetrics("requests", map[string][]int{"a": {1, 3}, "b": {1}}))
+	require.NoError(t, err)
+	assert.Len(t, values(got), 3)
+	assert.Equal(t, 0, logs.Len())
+
+	// a is older or a duplicate, b is new
+	got, err = p.processMetrics(context.Background(), testMetrics("requests", map[string][]int{"a": {2, 3, 4}, "b": {2}}))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []float64{4, 2}, values(got))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "Dropped data points that are not newer than the last data point of their series", logs.All()[0].Message)
+	assert.EqualValues(t, 1, logs.All()[0].ContextMap()["out_of_order"])
+	assert.EqualValues(t, 1, logs.All()[0].ContextMap()["duplicate"])
+
+	// the other metric name is another series
+	got, err = p.processMetrics(context.Background(), testMetrics("errors", map[string][]int{"a": {1}}))
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1}, values(got))
+
+	// nothing is passed on if every data point is dropped
+	_, err = p.processMetrics(context.Background(), testMetrics("requests", map[string][]int{"a": {0}}))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	// the counters are logged at most once per interval
+	_, err = p.processMetrics(context.Background(), testMetrics("requests", map[string][]int{"a": {1}}))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	assert.Equal(t, 1, logs.Len())
+	now = now.Add(dropLogInterval)
+	_, err = p.processMetrics(context.Background(), testMetrics("requests", map[string][]int{"a": {5}}))
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.Len())
+	assert.EqualValues(t, 2, logs.All()[1].ContextMap()["out_of_order"])
+	assert.EqualValues(t, 0, logs.All()[1].ContextMap()["duplicate"])
+
+	// a series is forgotten after it expires
+	now = now.Add(seriesExpiry)
+	got, err = p.processMetrics(context.Background(), testMetrics("errors", map[string][]int{"a": {0}}))
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0}, values(got))
+}
+
+func TestProcessMetricsReorder(t *testing.T) {
+	sink := &consumertest.MetricsSink{}
+	p := newOutOfOrderProcessor(&Config{Action: ActionReorder, ReorderWindow: time.Hour}, zap.NewNop(), sink)
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+
+	for _, md := range []pmetric.Metrics{
+		testMetrics("requests", map[string][]int{"a": {3, 1}}),
+		testMetrics("requests", map[string][]int{"a": {2}}),
+		testMetrics("errors", map[string][]int{"a": {1}}),
+	} {
+		_, err := p.processMetrics(context.Background(), md)
+		assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	}
+	assert.Empty(t, sink.AllMetrics())
+
+	p.flush(context.Background())
+	require.Len(t, sink.AllMetrics(), 1)
+	got := sink.AllMetrics()[0]
+	require.Equal(t, 1, got.ResourceMetrics().Len())
+	ms := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	assert.Equal(t, "requests", ms.At(0).Name())
+	assert.Equal(t, []float64{1, 2, 3, 1}, values(got))
+
+	// the data points arriving after their window are dropped, the buffer is flushed on shutdown
+	_, err := p.processMetrics(context.Background(), testMetrics("requests", map[string][]int{"a": {2, 4}}))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	require.NoError(t, p.shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 2)
+	assert.Equal(t, []float64{4}, values(sink.AllMetrics()[1]))
+}
@@ -0,0 +1,35 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spanlimits
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config holds the limits enforced on each span. A limit of 0 is not enforced.
+type Config struct {
+	// MaxAttributeValueLength is the length in bytes string attribute values of spans and span events are truncated to.
+	MaxAttributeValueLength int `mapstructure:"max_attribute_value_length"`
+	// MaxAttributesPerSpan is the number of attributes kept on a span, the ones after it are dropped.
+	MaxAttributesPerSpan int `mapstructure:"max_attributes_per_span"`
+	// MaxEventsPerSpan is the number of events kept on a span, the ones after it are dropped.
+	MaxEventsPerSpan int `mapstructure:"max_events_per_span"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.MaxAttributeValueLength < 0 {
+		return errors.New("'max_attribute_value_length' must not be negative")
+	}
+	if cfg.MaxAttributesPerSpan < 0 {
+		return errors.New("'max_attributes_per_span' must not be negative")
+	}
+	if cfg.MaxEventsPerSpan < 0 {
+		return errors.New("'max_events_per_span' must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spanlimits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, (&Config{}).Validate())
+	assert.NoError(t, (&Config{MaxAttributeValueLength: 1024, MaxAttributesPerSpan: 64, MaxEventsPerSpan: 32}).Validate())
+	assert.Error(t, (&Config{MaxAttributeValueLength: -1}).Validate())
+	assert.Error(t, (&Config{MaxAttributesPerSpan: -1}).Validate())
+	assert.Error(t, (&Config{MaxEventsPerSpan: -1}).Validate())
+}
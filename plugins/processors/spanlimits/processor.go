@@ -0,0 +1,161 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spanlimits
+
+import (
+	"context"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// truncationLogInterval limits how often the truncation counters are logged.
+const truncationLogInterval = time.Minute
+
+// counters are the number of values truncated and attributes and events dropped since they were last logged.
+type counters struct {
+	truncatedValues   int
+	droppedAttributes int
+	droppedEvents     int
+}
+
+func (c counters) isZero() bool {
+	return c == counters{}
+}
+
+type spanLimitsProcessor struct {
+	*Config
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	total   counters
+	lastLog time.Time
+}
+
+func newSpanLimitsProcessor(config *Config, logger *zap.Logger) *spanLimitsProcessor {
+	return &spanLimitsProcessor{
+		Config: config,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// processTraces enforces the limits on every span. The dropped attributes and events are added to the dropped counts
+// of the span, so they can be told apart from the ones the SDK never recorded.
+func (p *spanLimitsProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	var c counters
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				p.limitSpan(spans.At(k), &c)
+			}
+		}
+	}
+	p.record(c)
+	return td, nil
+}
+
+func (p *spanLimitsProcessor) limitSpan(span ptrace.Span, c *counters) {
+	if p.MaxAttributesPerSpan > 0 && span.Attributes().Len() > p.MaxAttributesPerSpan {
+		dropped := limitAttributes(span.Attributes(), p.MaxAttributesPerSpan)
+		span.SetDroppedAttributesCount(span.DroppedAttributesCount() + uint32(dropped))
+		c.droppedAttributes += dropped
+	}
+	if p.MaxEventsPerSpan > 0 && span.Events().Len() > p.MaxEventsPerSpan {
+		dropped := span.Events().Len() - p.MaxEventsPerSpan
+		index := 0
+		span.Events().RemoveIf(func(ptrace.SpanEvent) bool {
+			index++
+			return index > p.MaxEventsPerSpan
+		})
+		span.SetDroppedEventsCount(span.DroppedEventsCount() + uint32(dropped))
+		c.droppedEvents += dropped
+	}
+	if p.MaxAttributeValueLength > 0 {
+		c.truncatedValues += truncateMap(span.Attributes(), p.MaxAttributeValueLength)
+		events := span.Events()
+		for i := 0; i < events.Len(); i++ {
+			c.truncatedValues += truncateMap(events.At(i).Attributes(), p.MaxAttributeValueLength)
+		}
+	}
+}
+
+// record adds the counters to the totals and logs them at most once per interval.
+func (p *spanLimitsProcessor) record(c counters) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total.truncatedValues += c.truncatedValues
+	p.total.droppedAttributes += c.droppedAttributes
+	p.total.droppedEvents += c.droppedEvents
+	if p.total.isZero() {
+		return
+	}
+	if now := p.now(); now.Sub(p.lastLog) >= truncationLogInterval {
+		p.logger.Warn("Spans exceeded the configured limits",
+			zap.Int("truncated_values", p.total.truncatedValues),
+			zap.Int("dropped_attributes", p.total.droppedAttributes),
+			zap.Int("dropped_events", p.total.droppedEvents))
+		p.total = counters{}
+		p.lastLog = now
+	}
+}
+
+// limitAttributes keeps the first max attributes and returns the number of attributes dropped.
+func limitAttributes(attributes pcommon.Map, max int) int {
+	dropped := attributes.Len() - max
+	index := 0
+	attributes.RemoveIf(func(string, pcommon.Value) bool {
+		index++
+		return index > max
+	})
+	return dropped
+}
+
+// truncateMap truncates the string values of the map, including the ones nested in slices and maps, and returns
+// the number of values truncated.
+func truncateMap(attributes pcommon.Map, max int) int {
+	truncated := 0
+	attributes.Range(func(_ string, v pcommon.Value) bool {
+		truncated += truncateValue(v, max)
+		return true
+	})
+	return truncated
+}
+
+func truncateValue(v pcommon.Value, max int) int {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		if len(v.Str()) > max {
+			v.SetStr(truncateString(v.Str(), max))
+			return 1
+		}
+	case pcommon.ValueTypeSlice:
+		truncated := 0
+		slice := v.Slice()
+		for i := 0; i < slice.Len(); i++ {
+			truncated += truncateValue(slice.At(i), max)
+		}
+		return truncated
+	case pcommon.ValueTypeMap:
+		return truncateMap(v.Map(), max)
+	default:
+	}
+	return 0
+}
+
+// truncateString cuts the string to at most max bytes without splitting a multi-byte character.
+func truncateString(s string, max int) string {
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
@@ -0,0 +1,134 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spanlimits
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func testTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetDroppedAttributesCount(1)
+	attributes := span.Attributes()
+	attributes.PutStr("http.request.method", "GET")
+	attributes.PutStr("http.request.body", strings.Repeat("a", 20))
+	attributes.PutInt("http.response.status_code", 200)
+	attributes.PutEmptySlice("http.request.header.accept").AppendEmpty().SetStr(strings.Repeat("b", 20))
+	for _, name := range []string{"exception", "retry", "retry"} {
+		event := span.Events().AppendEmpty()
+		event.SetName(name)
+		event.Attributes().PutStr("exception.stacktrace", strings.Repeat("c", 20))
+	}
+	return td
+}
+
+func TestProcessTraces(t *testing.T) {
+	testCases := map[string]struct {
+		cfg                   *Config
+		wantAttributes        map[string]any
+		wantDroppedAttributes uint32
+		wantEvents            []string
+		wantDroppedEvents     uint32
+	}{
+		"WithNoLimits": {
+			cfg: &Config{},
+			wantAttributes: map[string]any{
+				"http.request.method":        "GET",
+				"http.request.body":          strings.Repeat("a", 20),
+				"http.response.status_code":  int64(200),
+				"http.request.header.accept": []any{strings.Repeat("b", 20)},
+			},
+			wantDroppedAttributes: 1,
+			wantEvents:            []string{"exception", "retry", "retry"},
+		},
+		"WithLimits": {
+			cfg: &Config{MaxAttributeValueLength: 8, MaxAttributesPerSpan: 2, MaxEventsPerSpan: 1},
+			wantAttributes: map[string]any{
+				"http.request.method": "GET",
+				"http.request.body":   strings.Repeat("a", 8),
+			},
+			wantDroppedAttributes: 3,
+			wantEvents:            []string{"exception"},
+			wantDroppedEvents:     2,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			p, err := NewFactory().CreateTraces(context.Background(), processortest.NewNopSettings(), testCase.cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			assert.NoError(t, p.ConsumeTraces(context.Background(), testTraces()))
+			require.Len(t, sink.AllTraces(), 1)
+			span := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, testCase.wantAttributes, span.Attributes().AsRaw())
+			assert.Equal(t, testCase.wantDroppedAttributes, span.DroppedAttributesCount())
+			var events []string
+			for i := 0; i < span.Events().Len(); i++ {
+				events = append(events, span.Events().At(i).Name())
+			}
+			assert.Equal(t, testCase.wantEvents, events)
+			assert.Equal(t, testCase.wantDroppedEvents, span.DroppedEventsCount())
+			if testCase.cfg.MaxAttributeValueLength > 0 {
+				stacktrace, _ := span.Events().At(0).Attributes().Get("exception.stacktrace")
+				assert.Len(t, stacktrace.Str(), testCase.cfg.MaxAttributeValueLength)
+			}
+			assert.NoError(t, p.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	assert.Equal(t, "abc", truncateString("abcdef", 3))
+	// does not split the 3 byte €
+	assert.Equal(t, "x", truncateString("x€ab", 3))
+	assert.Equal(t, "x€", truncateString("x€ab", 4))
+	assert.Equal(t, "x€a", truncateString("x€ab", 5))
+	assert.Equal(t, "", truncateString("€", 2))
+}
+
+func TestTruncationLogs(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	p := newSpanLimitsProcessor(&Config{MaxAttributeValueLength: 8, MaxEventsPerSpan: 1}, zap.New(core))
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		_, err := p.processTraces(context.Background(), testTraces())
+		assert.NoError(t, err)
+	}
+	// later truncations within the interval are only counted
+	warnings := logs.FilterLevelExact(zapcore.WarnLevel).All()
+	require.Len(t, warnings, 1)
+	assert.EqualValues(t, 3, warnings[0].ContextMap()["truncated_values"])
+	assert.EqualValues(t, 0, warnings[0].ContextMap()["dropped_attributes"])
+	assert.EqualValues(t, 2, warnings[0].ContextMap()["dropped_events"])
+
+	now = now.Add(truncationLogInterval)
+	_, err := p.processTraces(context.Background(), testTraces())
+	assert.NoError(t, err)
+	warnings = logs.FilterLevelExact(zapcore.WarnLevel).All()
+	require.Len(t, warnings, 2)
+	assert.EqualValues(t, 9, warnings[1].ContextMap()["truncated_values"])
+	assert.EqualValues(t, 6, warnings[1].ContextMap()["dropped_events"])
+
+	_, err = newSpanLimitsProcessor(&Config{}, zap.New(core)).processTraces(context.Background(), testTraces())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, logs.FilterLevelExact(zapcore.WarnLevel).Len())
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package wasmprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// FailurePolicyPass sends the batches the module failed to process on unchanged.
+	FailurePolicyPass = "pass"
+	// FailurePolicyDrop drops the batches the module failed to process.
+	FailurePolicyDrop = "drop"
+
+	defaultTimeout        = time.Second
+	defaultMemoryLimitMiB = 16
+	// maxMemoryLimitMiB is the memory a 32-bit WebAssembly module can address.
+	maxMemoryLimitMiB = 4096
+)
+
+type Config struct {
+	// Module is the path of the WebAssembly module transforming the batches.
+	Module string `mapstructure:"module"`
+	// MemoryLimitMiB bounds the linear memory of the module, which fails to grow past it.
+	MemoryLimitMiB uint32 `mapstructure:"memory_limit_mib"`
+	// Timeout bounds each call to the module, which is interrupted past it.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// FailurePolicy is applied to the batches the module failed to process, or did not process in time.
+	FailurePolicy string `mapstructure:"failure_policy"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.Module == "" {
+		return errors.New("'module' must be set")
+	}
+	if cfg.MemoryLimitMiB == 0 || cfg.MemoryLimitMiB > maxMemoryLimitMiB {
+		return fmt.Errorf("'memory_limit_mib' must be between 1 and %d", maxMemoryLimitMiB)
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("'timeout' must be positive")
+	}
+	switch cfg.FailurePolicy {
+	case FailurePolicyPass, FailurePolicyDrop:
+	default:
+		return fmt.Errorf("'failure_policy' must be %q or %q", FailurePolicyPass, FailurePolicyDrop)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package wasmprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := map[string]struct {
+		cfg     Config
+		wantErr string
+	}{
+		"WithDefaults": {cfg: Config{Module: "/opt/transforms/parse.wasm", MemoryLimitMiB: 16, Timeout: time.Second, FailurePolicy: FailurePolicyPass}},
+		"WithoutModule": {
+			cfg:     Config{MemoryLimitMiB: 16, Timeout: time.Second, FailurePolicy: FailurePolicyPass},
+			wantErr: "'module' must be set",
+		},
+		"WithoutMemoryLimit": {
+			cfg:     Config{Module: "parse.wasm", Timeout: time.Second, FailurePolicy: FailurePolicyPass},
+			wantErr: "'memory_limit_mib' must be between 1 and 4096",
+		},
+		"WithMemoryLimitTooHigh": {
+			cfg:     Config{Module: "parse.wasm", MemoryLimitMiB: 8192, Timeout: time.Second, FailurePolicy: FailurePolicyPass},
+			wantErr: "'memory_limit_mib' must be between 1 and 4096",
+		},
+		"WithoutTimeout": {
+			cfg:     Config{Module: "parse.wasm", MemoryLimitMiB: 16, FailurePolicy: FailurePolicyDrop},
+			wantErr: "'timeout' must be positive",
+		},
+		"WithInvalidPolicy": {
+			cfg:     Config{Module: "parse.wasm", MemoryLimitMiB: 16, Timeout: time.Second, FailurePolicy: "retry"},
+			wantErr: `'failure_policy' must be "pass" or "drop"`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.cfg.Validate()
+			if testCase.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package wasmprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	stability = component.StabilityLevelAlpha
+)
+
+var (
+	TypeStr, _            = component.NewType("wasmprocessor")
+	processorCapabilities = consumer.Capabilities{MutatesData: true}
+)
+
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		TypeStr,
+		createDefaultConfig,
+		processor.WithMetrics(createMetricsProcessor, stability),
+		processor.WithLogs(createLogsProcessor, stability))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MemoryLimitMiB: defaultMemoryLimitMiB,
+		Timeout:        defaultTimeout,
+		FailurePolicy:  FailurePolicyPass,
+	}
+}
+
+func newProcessor(set processor.Settings, cfg component.Config, function string) (*wasmProcessor, error) {
+	processorConfig, ok := cfg.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("configuration parsing error")
+	}
+	telemetry, err := newProcessorTelemetry(set.MeterProvider, set.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	return newWasmProcessor(processorConfig, function, set.Logger, telemetry), nil
+}
+
+func createMetricsProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (processor.Metrics, error) {
+	p, err := newProcessor(set, cfg, exportProcessMetrics)
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewMetrics(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		p.processMetrics,
+		processorhelper.WithStart(p.start),
+		processorhelper.WithShutdown(p.shutdown),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogsProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (processor.Logs, error) {
+	p, err := newProcessor(set, cfg, exportProcessLogs)
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewLogs(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		p.processLogs,
+		processorhelper.WithStart(p.start),
+		processorhelper.WithShutdown(p.shutdown),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
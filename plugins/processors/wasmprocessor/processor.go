@@ -0,0 +1,222 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package wasmprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+const (
+	// failureLogInterval limits how often the failed calls are logged.
+	failureLogInterval = time.Minute
+	// pageSize is the size of a page of the linear memory of a WebAssembly module.
+	pageSize = 64 * 1024
+
+	exportMemory         = "memory"
+	exportAlloc          = "alloc"
+	exportProcessMetrics = "process_metrics"
+	exportProcessLogs    = "process_logs"
+)
+
+var errDropped = errors.New("the module dropped the batch")
+
+// otlpMessage is the OTLP export request of a signal, which the batches are exchanged with the module as.
+type otlpMessage interface {
+	MarshalProto() ([]byte, error)
+	UnmarshalProto(data []byte) error
+}
+
+// wasmProcessor transforms each batch with a sandboxed WebAssembly module, so that the customers can run their own
+// parsing logic without running a process of their own. The module imports nothing, so it has no access to the
+// host, and it is bounded in memory and in time.
+type wasmProcessor struct {
+	*Config
+	logger    *zap.Logger
+	telemetry *processorTelemetry
+	now       func() time.Time
+	// function is the export processing the signal of the pipeline.
+	function string
+
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+
+	mu       sync.Mutex
+	failures int
+	lastLog  time.Time
+}
+
+func newWasmProcessor(config *Config, function string, logger *zap.Logger, telemetry *processorTelemetry) *wasmProcessor {
+	return &wasmProcessor{
+		Config:    config,
+		logger:    logger,
+		telemetry: telemetry,
+		now:       time.Now,
+		function:  function,
+	}
+}
+
+// start compiles the module and checks that it has the exports of the signal, so that an invalid module fails the
+// agent at startup instead of failing each batch.
+func (p *wasmProcessor) start(ctx context.Context, _ component.Host) error {
+	code, err := os.ReadFile(p.Module)
+	if err != nil {
+		return fmt.Errorf("unable to read the WebAssembly module: %w", err)
+	}
+	p.runtime = wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(p.MemoryLimitMiB*(1024*1024/pageSize)).
+		WithCloseOnContextDone(true))
+	p.compiled, err = p.runtime.CompileModule(ctx, code)
+	if err != nil {
+		return fmt.Errorf("unable to compile the WebAssembly module %s: %w", p.Module, err)
+	}
+	if err = p.validateModule(); err != nil {
+		return fmt.Errorf("invalid WebAssembly module %s: %w", p.Module, err)
+	}
+	return nil
+}
+
+func (p *wasmProcessor) validateModule() error {
+	if imports := p.compiled.ImportedFunctions(); len(imports) > 0 {
+		module, name, _ := imports[0].Import()
+		return fmt.Errorf("the module must not import functions, but imports %s.%s", module, name)
+	}
+	if _, ok := p.compiled.ExportedMemories()[exportMemory]; !ok {
+		return fmt.Errorf("the module must export its %q", exportMemory)
+	}
+	functions := p.compiled.ExportedFunctions()
+	if err := validateSignature(functions[exportAlloc], exportAlloc, []api.ValueType{api.ValueTypeI32}, api.ValueTypeI32); err != nil {
+		return err
+	}
+	return validateSignature(functions[p.function], p.function, []api.ValueType{api.ValueTypeI32, api.ValueTypeI32}, api.ValueTypeI64)
+}
+
+func validateSignature(function api.FunctionDefinition, name string, params []api.ValueType, result api.ValueType) error {
+	if function == nil {
+		return fmt.Errorf("the module must export the function %q", name)
+	}
+	results := function.ResultTypes()
+	if !slices.Equal(function.ParamTypes(), params) || len(results) != 1 || results[0] != result {
+		names := make([]string, len(params))
+		for i, param := range params {
+			names[i] = api.ValueTypeName(param)
+		}
+		return fmt.Errorf("the function %q must have the signature (%s) -> %s", name, strings.Join(names, ", "), api.ValueTypeName(result))
+	}
+	return nil
+}
+
+func (p *wasmProcessor) shutdown(ctx context.Context) error {
+	if p.runtime == nil {
+		return nil
+	}
+	return p.runtime.Close(ctx)
+}
+
+func (p *wasmProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	resp := pmetricotlp.NewExportRequest()
+	if err := p.call(ctx, pmetricotlp.NewExportRequestFromMetrics(md), resp); err != nil {
+		return md, p.handleFailure(ctx, err, md.DataPointCount())
+	}
+	return resp.Metrics(), nil
+}
+
+func (p *wasmProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	resp := plogotlp.NewExportRequest()
+	if err := p.call(ctx, plogotlp.NewExportRequestFromLogs(ld), resp); err != nil {
+		return ld, p.handleFailure(ctx, err, ld.LogRecordCount())
+	}
+	return resp.Logs(), nil
+}
+
+func (p *wasmProcessor) call(ctx context.Context, req, resp otlpMessage) error {
+	callCtx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+	start := p.now()
+	err := p.invoke(callCtx, req, resp)
+	callErr := err
+	if errors.Is(err, errDropped) {
+		callErr = nil
+	}
+	p.telemetry.recordCall(ctx, p.now().Sub(start).Seconds(), callErr)
+	return err
+}
+
+// invoke runs the module on a batch. Each batch gets a new instance of the module, so that the batches do not share
+// any state and the memory of the module is released after each of them. The module allocates the buffer of the
+// batch with alloc, and returns where the processed batch is in its memory packed in an i64, the offset in the high
+// 32 bits and the length in the low 32 bits. A result of 0 drops the batch.
+func (p *wasmProcessor) invoke(ctx context.Context, req, resp otlpMessage) error {
+	data, err := req.MarshalProto()
+	if err != nil {
+		return err
+	}
+	mod, err := p.runtime.InstantiateModule(ctx, p.compiled, wazero.NewModuleConfig().WithName(""))
+	if err != nil {
+		return err
+	}
+	defer mod.Close(ctx)
+	results, err := mod.ExportedFunction(exportAlloc).Call(ctx, uint64(len(data)))
+	if err != nil {
+		return err
+	}
+	offset := api.DecodeU32(results[0])
+	if !mod.Memory().Write(offset, data) {
+		return fmt.Errorf("the buffer of %d bytes allocated at %d is out of the memory of the module", len(data), offset)
+	}
+	results, err = mod.ExportedFunction(p.function).Call(ctx, uint64(offset), uint64(len(data)))
+	if err != nil {
+		return err
+	}
+	if results[0] == 0 {
+		return errDropped
+	}
+	offset, length := uint32(results[0]>>32), uint32(results[0])
+	out, ok := mod.Memory().Read(offset, length)
+	if !ok {
+		return fmt.Errorf("the result of %d bytes at %d is out of the memory of the module", length, offset)
+	}
+	return resp.UnmarshalProto(out)
+}
+
+// handleFailure applies the failure policy to a batch the module failed to process. With the pass policy the batch
+// continues unchanged, and with the drop policy it is dropped. The batches the module dropped are not failures.
+func (p *wasmProcessor) handleFailure(ctx context.Context, err error, items int) error {
+	if errors.Is(err, errDropped) {
+		return processorhelper.ErrSkipProcessingData
+	}
+	p.mu.Lock()
+	p.failures++
+	if now := p.now(); now.Sub(p.lastLog) >= failureLogInterval {
+		p.logger.Warn("WebAssembly processor failed to process the batches",
+			zap.String("module", p.Module),
+			zap.String("failure_policy", p.FailurePolicy),
+			zap.Int("failures", p.failures),
+			zap.Error(err))
+		p.failures = 0
+		p.lastLog = now
+	}
+	p.mu.Unlock()
+	if p.FailurePolicy == FailurePolicyDrop {
+		p.telemetry.recordDropped(ctx, items)
+		return processorhelper.ErrSkipProcessingData
+	}
+	return nil
+}
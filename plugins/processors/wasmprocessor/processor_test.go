@@ -0,0 +1,275 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package wasmprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+)
+
+const (
+	allocOffset = 1024
+	dataOffset  = 32 * 1024
+)
+
+var (
+	// echoBody returns the batch it is given.
+	echoBody = []byte{0x20, 0x00, 0xad, 0x42, 0x20, 0x86, 0x20, 0x01, 0xad, 0x84}
+	// dropBody returns 0.
+	dropBody = []byte{0x42, 0x00}
+	// loopBody never returns.
+	loopBody = []byte{0x03, 0x40, 0x0c, 0x00, 0x0b, 0x42, 0x00}
+	// trapBody hits an unreachable instruction.
+	trapBody = []byte{0x00}
+)
+
+// testModule is a WebAssembly module with a memory, an alloc function returning allocOffset and a process function,
+// assembled in the binary format since the tests cannot depend on a compiler to WebAssembly.
+type testModule struct {
+	memoryPages uint32
+	process     string
+	// body of the process function, which takes the i32 offset and length of the batch and returns an i64.
+	body []byte
+	// data written at dataOffset.
+	data []byte
+	// importHost makes the module import a function from the host.
+	importHost bool
+	// invalidAlloc gives alloc a second parameter.
+	invalidAlloc bool
+}
+
+func uleb(v uint64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+func sleb(v int64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+func vector(items ...[]byte) []byte {
+	b := uleb(uint64(len(items)))
+	for _, item := range items {
+		b = append(b, item...)
+	}
+	return b
+}
+
+func name(s string) []byte {
+	return append(uleb(uint64(len(s))), s...)
+}
+
+func section(id byte, content []byte) []byte {
+	return append(append([]byte{id}, uleb(uint64(len(content)))...), content...)
+}
+
+func code(body ...byte) []byte {
+	body = append(append([]byte{0x00}, body...), 0x0b)
+	return append(uleb(uint64(len(body))), body...)
+}
+
+// returnData is the body of a process function returning len bytes at dataOffset.
+func returnData(length int) []byte {
+	return append([]byte{0x42}, sleb(int64(dataOffset)<<32|int64(length))...)
+}
+
+func (m testModule) encode() []byte {
+	allocType := []byte{0x60, 0x01, 0x7f, 0x01, 0x7f}
+	if m.invalidAlloc {
+		allocType = []byte{0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f}
+	}
+	types := vector(allocType, []byte{0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e}, []byte{0x60, 0x00, 0x00})
+	wasm := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	wasm = append(wasm, section(1, types)...)
+	var imported uint64
+	if m.importHost {
+		imported = 1
+		wasm = append(wasm, section(2, vector(append(append(name("env"), name("log")...), 0x00, 0x02)))...)
+	}
+	wasm = append(wasm, section(3, vector([]byte{0x00}, []byte{0x01}))...)
+	wasm = append(wasm, section(5, vector(append([]byte{0x00}, uleb(uint64(m.memoryPages))...)))...)
+	wasm = append(wasm, section(7, vector(
+		append(name(exportMemory), 0x02, 0x00),
+		append(append(name(exportAlloc), 0x00), uleb(imported)...),
+		append(append(name(m.process), 0x00), uleb(imported+1)...),
+	))...)
+	alloc := append([]byte{0x41}, sleb(allocOffset)...)
+	wasm = append(wasm, section(10, vector(code(alloc...), code(m.body...)))...)
+	if m.data != nil {
+		segment := append(append([]byte{0x00, 0x41}, sleb(dataOffset)...), 0x0b)
+		segment = append(append(segment, uleb(uint64(len(m.data)))...), m.data...)
+		wasm = append(wasm, section(11, vector(segment))...)
+	}
+	return wasm
+}
+
+func newTestProcessor(t *testing.T, module testModule, function, policy string) (*wasmProcessor, *sdkmetric.ManualReader) {
+	path := filepath.Join(t.TempDir(), "transform.wasm")
+	require.NoError(t, os.WriteFile(path, module.encode(), 0600))
+	reader := sdkmetric.NewManualReader()
+	telemetry, err := newProcessorTelemetry(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), "wasmprocessor")
+	require.NoError(t, err)
+	cfg := &Config{Module: path, MemoryLimitMiB: 1, Timeout: time.Second, FailurePolicy: policy}
+	p := newWasmProcessor(cfg, function, zap.NewNop(), telemetry)
+	t.Cleanup(func() {
+		assert.NoError(t, p.shutdown(context.Background()))
+	})
+	return p, reader
+}
+
+func sumValues(t *testing.T, reader *sdkmetric.ManualReader, name string) map[string]int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				outcome, _ := dp.Attributes.Value(attributeOutcome)
+				values[outcome.AsString()] += dp.Value
+			}
+		}
+	}
+	return values
+}
+
+func newLogs(body string) plog.Logs {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	return ld
+}
+
+func TestProcessLogs(t *testing.T) {
+	p, reader := newTestProcessor(t, testModule{memoryPages: 1, process: exportProcessLogs, body: echoBody}, exportProcessLogs, FailurePolicyPass)
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	ld := newLogs("GET /orders 200")
+	got, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+	assert.Equal(t, map[string]int64{outcomeSuccess: 1}, sumValues(t, reader, "wasmprocessor_calls"))
+
+	// the module returns a batch of its own
+	parsed, err := plogotlp.NewExportRequestFromLogs(newLogs("parsed")).MarshalProto()
+	require.NoError(t, err)
+	p, _ = newTestProcessor(t, testModule{memoryPages: 1, process: exportProcessLogs, body: returnData(len(parsed)), data: parsed}, exportProcessLogs, FailurePolicyPass)
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	got, err = p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, "parsed", got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+
+	// the module drops the batch
+	p, reader = newTestProcessor(t, testModule{memoryPages: 1, process: exportProcessLogs, body: dropBody}, exportProcessLogs, FailurePolicyPass)
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	_, err = p.processLogs(context.Background(), ld)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	assert.Equal(t, map[string]int64{outcomeSuccess: 1}, sumValues(t, reader, "wasmprocessor_calls"))
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p, _ := newTestProcessor(t, testModule{memoryPages: 1, process: exportProcessMetrics, body: echoBody}, exportProcessMetrics, FailurePolicyPass)
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("orders")
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+}
+
+func TestProcessFailurePolicy(t *testing.T) {
+	testCases := map[string]testModule{
+		"WithTrap":              {memoryPages: 1, process: exportProcessLogs, body: trapBody},
+		"WithInfiniteLoop":      {memoryPages: 1, process: exportProcessLogs, body: loopBody},
+		"WithResultOutOfMemory": {memoryPages: 1, process: exportProcessLogs, body: append([]byte{0x42}, sleb(int64(1<<20)<<32|16)...)},
+	}
+	for name, module := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ld := newLogs("GET /orders 200")
+
+			p, reader := newTestProcessor(t, module, exportProcessLogs, FailurePolicyPass)
+			p.Timeout = 50 * time.Millisecond
+			require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+			got, err := p.processLogs(context.Background(), ld)
+			require.NoError(t, err)
+			assert.Equal(t, ld, got)
+			assert.Equal(t, map[string]int64{outcomeFailure: 1}, sumValues(t, reader, "wasmprocessor_calls"))
+			assert.Empty(t, sumValues(t, reader, "wasmprocessor_items_dropped"))
+
+			p, reader = newTestProcessor(t, module, exportProcessLogs, FailurePolicyDrop)
+			p.Timeout = 50 * time.Millisecond
+			require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+			start := time.Now()
+			_, err = p.processLogs(context.Background(), ld)
+			assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+			assert.Less(t, time.Since(start), time.Second)
+			assert.Equal(t, map[string]int64{"": 1}, sumValues(t, reader, "wasmprocessor_items_dropped"))
+		})
+	}
+}
+
+func TestStartInvalidModule(t *testing.T) {
+	testCases := map[string]struct {
+		module  testModule
+		wantErr string
+	}{
+		"WithImport": {
+			module:  testModule{memoryPages: 1, process: exportProcessLogs, body: echoBody, importHost: true},
+			wantErr: "the module must not import functions, but imports env.log",
+		},
+		"WithoutFunction": {
+			module:  testModule{memoryPages: 1, process: exportProcessMetrics, body: echoBody},
+			wantErr: `the module must export the function "process_logs"`,
+		},
+		"WithInvalidSignature": {
+			module:  testModule{memoryPages: 1, process: exportProcessLogs, body: echoBody, invalidAlloc: true},
+			wantErr: `the function "alloc" must have the signature (i32) -> i32`,
+		},
+		"WithMemoryOverLimit": {
+			module:  testModule{memoryPages: 32, process: exportProcessLogs, body: echoBody},
+			wantErr: "over limit",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p, _ := newTestProcessor(t, testCase.module, exportProcessLogs, FailurePolicyPass)
+			err := p.start(context.Background(), componenttest.NewNopHost())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.wantErr)
+		})
+	}
+
+	p, _ := newTestProcessor(t, testModule{}, exportProcessLogs, FailurePolicyPass)
+	p.Module = filepath.Join(t.TempDir(), "missing.wasm")
+	assert.ErrorContains(t, p.start(context.Background(), componenttest.NewNopHost()), "unable to read the WebAssembly module")
+}
@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package wasmprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const (
+	scopeName = "github.com/aws/amazon-cloudwatch-agent/plugins/processors/wasmprocessor"
+
+	attributeProcessor = "processor"
+	attributeOutcome   = "outcome"
+
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+)
+
+// processorTelemetry records the calls to the module and the items dropped when they failed with the collector's
+// MeterProvider, so that a module that traps or is too slow can be alarmed on.
+type processorTelemetry struct {
+	attrs        metric.MeasurementOption
+	outcomeAttrs map[string]metric.MeasurementOption
+	calls        metric.Int64Counter
+	duration     metric.Float64Histogram
+	dropped      metric.Int64Counter
+}
+
+// newProcessorTelemetry creates the instruments with the given provider. A nil provider records nothing.
+func newProcessorTelemetry(mp metric.MeterProvider, processor string) (*processorTelemetry, error) {
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+	meter := mp.Meter(scopeName)
+	processorAttr := attribute.String(attributeProcessor, processor)
+	t := &processorTelemetry{
+		attrs:        metric.WithAttributeSet(attribute.NewSet(processorAttr)),
+		outcomeAttrs: map[string]metric.MeasurementOption{},
+	}
+	for _, outcome := range []string{outcomeSuccess, outcomeFailure} {
+		t.outcomeAttrs[outcome] = metric.WithAttributeSet(attribute.NewSet(processorAttr, attribute.String(attributeOutcome, outcome)))
+	}
+	var err error
+	if t.calls, err = meter.Int64Counter("wasmprocessor_calls",
+		metric.WithDescription("Number of calls to the WebAssembly module, by their outcome"),
+		metric.WithUnit("{calls}"),
+	); err != nil {
+		return nil, err
+	}
+	if t.duration, err = meter.Float64Histogram("wasmprocessor_call_duration",
+		metric.WithDescription("Duration of the calls to the WebAssembly module"),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, err
+	}
+	if t.dropped, err = meter.Int64Counter("wasmprocessor_items_dropped",
+		metric.WithDescription("Number of data points or log records dropped because the WebAssembly module failed"),
+		metric.WithUnit("{items}"),
+	); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *processorTelemetry) recordCall(ctx context.Context, seconds float64, err error) {
+	if t == nil {
+		return
+	}
+	outcome := outcomeSuccess
+	if err != nil {
+		outcome = outcomeFailure
+	}
+	t.calls.Add(ctx, 1, t.outcomeAttrs[outcome])
+	t.duration.Record(ctx, seconds, t.attrs)
+}
+
+func (t *processorTelemetry) recordDropped(ctx context.Context, items int) {
+	if t == nil || items == 0 {
+		return
+	}
+	t.dropped.Add(ctx, int64(items), t.attrs)
+}
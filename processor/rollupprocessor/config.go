@@ -3,6 +3,12 @@
 
 package rollupprocessor
 
+import (
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
+)
+
 type Config struct {
 	// AttributeGroups are the groups of attribute names that will be used
 	// to create rollup data points with. The number of distinct groups will
@@ -16,4 +22,12 @@ type Config struct {
 	// CacheSize is used to store built rollup attribute groups using the base
 	// attributes as keys. Can disable by setting <= 0.
 	CacheSize int `mapstructure:"cache_size"`
+	// Staleness ends the series, original or rolled up, that stop reporting.
+	Staleness staleness.Config `mapstructure:"staleness,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	return cfg.Staleness.Validate()
 }
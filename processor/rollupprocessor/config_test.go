@@ -6,11 +6,14 @@ package rollupprocessor
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -38,6 +41,14 @@ func TestLoadConfig(t *testing.T) {
 			id:   component.NewIDWithName(component.MustNewType(typeStr), "4"),
 			want: &Config{CacheSize: -1},
 		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "5"),
+			want: &Config{
+				AttributeGroups: [][]string{{"Attr1"}},
+				CacheSize:       defaultCacheSize,
+				Staleness:       staleness.Config{Policy: staleness.PolicyAbsent, Timeout: 2 * time.Minute},
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
@@ -51,3 +62,10 @@ func TestLoadConfig(t *testing.T) {
 		assert.Equal(t, testCase.want, cfg)
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := &Config{Staleness: staleness.Config{Policy: staleness.PolicyZero}}
+	assert.Error(t, cfg.Validate())
+	cfg.Staleness.Timeout = time.Minute
+	assert.NoError(t, cfg.Validate())
+}
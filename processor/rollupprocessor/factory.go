@@ -46,7 +46,7 @@ func createMetricsProcessor(
 	if !ok {
 		return nil, fmt.Errorf("invalid configuration type: %T", cfg)
 	}
-	metricsProcessor := newProcessor(pCfg)
+	metricsProcessor := newProcessor(pCfg, set.Logger, nextConsumer)
 	return processorhelper.NewMetrics(
 		ctx,
 		set,
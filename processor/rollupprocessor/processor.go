@@ -6,14 +6,19 @@ package rollupprocessor
 import (
 	"context"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/jellydator/ttlcache/v3"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 )
 
@@ -21,28 +26,67 @@ type rollupProcessor struct {
 	attributeGroups [][]string
 	dropOriginal    collections.Set[string]
 	cache           rollupCache
+
+	staleness staleness.Config
+	logger    *zap.Logger
+	next      consumer.Metrics
+	now       func() time.Time
+
+	mu      sync.Mutex
+	tracker *staleness.MetricsTracker
+	done    chan struct{}
+	wg      sync.WaitGroup
 }
 
-func newProcessor(cfg *Config) *rollupProcessor {
+func newProcessor(cfg *Config, logger *zap.Logger, next consumer.Metrics) *rollupProcessor {
 	cacheSize := cfg.CacheSize
 	// use no-op cache if no attribute groups
 	if len(cfg.AttributeGroups) == 0 {
 		cacheSize = 0
 	}
-	return &rollupProcessor{
+	p := &rollupProcessor{
 		attributeGroups: uniqueGroups(cfg.AttributeGroups),
 		dropOriginal:    collections.NewSet(cfg.DropOriginal...),
 		cache:           buildRollupCache(cacheSize),
+		staleness:       cfg.Staleness,
+		logger:          logger,
+		next:            next,
+		now:             time.Now,
+		done:            make(chan struct{}),
+	}
+	if cfg.Staleness.Enabled() {
+		p.tracker = staleness.NewMetricsTracker(cfg.Staleness)
 	}
+	return p
 }
 
 func (p *rollupProcessor) start(context.Context, component.Host) error {
 	go p.cache.Start()
+	if p.tracker != nil {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			ticker := time.NewTicker(p.staleness.CheckInterval())
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					p.endStale(context.Background())
+				case <-p.done:
+					return
+				}
+			}
+		}()
+	}
 	return nil
 }
 
 func (p *rollupProcessor) stop(context.Context) error {
 	p.cache.Stop()
+	if p.tracker != nil {
+		close(p.done)
+		p.wg.Wait()
+	}
 	return nil
 }
 
@@ -50,9 +94,28 @@ func (p *rollupProcessor) processMetrics(_ context.Context, md pmetric.Metrics)
 	if len(p.attributeGroups) > 0 || len(p.dropOriginal) > 0 {
 		metric.RangeMetrics(md, p.processMetric)
 	}
+	if p.tracker != nil {
+		p.mu.Lock()
+		p.tracker.Observe(md, p.now())
+		p.mu.Unlock()
+	}
 	return md, nil
 }
 
+// endStale passes on the data points ending the series, original or rolled up, that stopped reporting. A rolled up
+// series goes on as long as one of the series it is rolled up from does.
+func (p *rollupProcessor) endStale(ctx context.Context) {
+	p.mu.Lock()
+	md := p.tracker.Expire(p.now())
+	p.mu.Unlock()
+	if md.DataPointCount() == 0 {
+		return
+	}
+	if err := p.next.ConsumeMetrics(ctx, md); err != nil {
+		p.logger.Error("Failed to pass on the data points ending the stale series", zap.Error(err))
+	}
+}
+
 func (p *rollupProcessor) processMetric(m pmetric.Metric) {
 	switch m.Type() {
 	case pmetric.MetricTypeGauge:
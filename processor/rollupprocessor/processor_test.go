@@ -7,14 +7,18 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
 )
 
 func TestProcessor(t *testing.T) {
@@ -293,7 +297,7 @@ func TestProcessor(t *testing.T) {
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
-			p := newProcessor(testCase.cfg)
+			p := newProcessor(testCase.cfg, zap.NewNop(), consumertest.NewNop())
 			assert.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
 			defer assert.NoError(t, p.stop(context.Background()))
 			orig := pmetric.NewMetrics()
@@ -319,6 +323,45 @@ func TestProcessor(t *testing.T) {
 	}
 }
 
+func TestProcessorStaleness(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	p := newProcessor(&Config{
+		AttributeGroups: [][]string{{"d1"}},
+		Staleness:       staleness.Config{Policy: staleness.PolicyZero, Timeout: time.Minute},
+	}, zap.NewNop(), next)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+	batch := func(rawAttributes ...map[string]any) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		buildTestMetric(t, ms.AppendEmpty(), "metric", pmetric.MetricTypeGauge, rawAttributes)
+		return md
+	}
+	_, err := p.processMetrics(context.Background(), batch(map[string]any{"d1": "v1", "host": "a"}, map[string]any{"d1": "v1", "host": "b"}))
+	require.NoError(t, err)
+	// the original series of both hosts and the rolled up one
+	assert.Equal(t, 3, p.tracker.Len())
+
+	// host b is scaled in, the rolled up series goes on with host a
+	now = now.Add(30 * time.Second)
+	_, err = p.processMetrics(context.Background(), batch(map[string]any{"d1": "v1", "host": "a"}))
+	require.NoError(t, err)
+	now = now.Add(30 * time.Second)
+	p.endStale(context.Background())
+	require.Len(t, next.AllMetrics(), 1)
+	ended := next.AllMetrics()[0]
+	require.Equal(t, 1, ended.DataPointCount())
+	dp := ended.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, map[string]any{"d1": "v1", "host": "b"}, dp.Attributes().AsRaw())
+	assert.Zero(t, dp.IntValue())
+
+	// nothing is passed on without stale series
+	p.endStale(context.Background())
+	assert.Len(t, next.AllMetrics(), 1)
+	assert.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, p.stop(context.Background()))
+}
+
 func validateMetric(t *testing.T, m pmetric.Metric) {
 	t.Helper()
 
@@ -0,0 +1,190 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package profiler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/metrics"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SourceLogFile    = "logfile"
+	SourceInput      = "input"
+	SourcePrometheus = "prometheus"
+
+	// UsagePath is where the ranked report is served on the pprof listener.
+	UsagePath = "/debug/usage"
+
+	userCPUMetric = "/cpu/classes/user:cpu-seconds"
+)
+
+var (
+	// Usage attributes the agent's work to the inputs that caused it, e.g. a log file or a scrape target.
+	Usage = &usage{sources: make(map[string]*sourceUsage)}
+)
+
+type sourceUsage struct {
+	busy     time.Duration
+	records  int64
+	egress   int64
+	inFlight int64
+}
+
+type usage struct {
+	sync.Mutex
+	sources map[string]*sourceUsage
+	// readCPU is replaced in tests.
+	readCPU func() float64
+}
+
+// SourceUsage is one row of the ranked report.
+type SourceUsage struct {
+	Source string `json:"source"`
+	// Busy is the time spent reading, parsing and processing data for the source.
+	Busy time.Duration `json:"busy_ns"`
+	// CPUShare is the fraction of the measured busy time of all sources used by this source.
+	CPUShare float64 `json:"cpu_share"`
+	// CPUSeconds estimates the process CPU used by the source by applying CPUShare to the agent's user CPU time.
+	CPUSeconds float64 `json:"cpu_seconds"`
+	// Records is the number of log events or datapoints emitted.
+	Records int64 `json:"records"`
+	// EgressBytes is the size of the data emitted towards the destinations.
+	EgressBytes int64 `json:"egress_bytes"`
+	// InFlightBytes is the size of the data emitted and not yet acknowledged by a destination, which the agent
+	// is holding in memory.
+	InFlightBytes int64 `json:"in_flight_bytes"`
+}
+
+// SourceKey builds the key used to attribute usage, e.g. logfile:/var/log/messages.
+func SourceKey(kind, name string) string {
+	return kind + ":" + name
+}
+
+func (u *usage) source(key string) *sourceUsage {
+	s, ok := u.sources[key]
+	if !ok {
+		s = &sourceUsage{}
+		u.sources[key] = s
+	}
+	return s
+}
+
+// AddBusy attributes processing time to the source.
+func (u *usage) AddBusy(key string, d time.Duration) {
+	u.Lock()
+	defer u.Unlock()
+	u.source(key).busy += d
+}
+
+// AddEmitted attributes emitted records and their size to the source.
+func (u *usage) AddEmitted(key string, records int, bytes int) {
+	u.Lock()
+	defer u.Unlock()
+	s := u.source(key)
+	s.records += int64(records)
+	s.egress += int64(bytes)
+}
+
+// AddInFlight adjusts the bytes held in memory for the source. Use a negative value once a destination is done
+// with the data.
+func (u *usage) AddInFlight(key string, bytes int) {
+	u.Lock()
+	defer u.Unlock()
+	u.source(key).inFlight += int64(bytes)
+}
+
+// Report returns the sources ranked by the given field (cpu, egress, records or memory). The limit is ignored
+// when it is not positive.
+func (u *usage) Report(sortBy string, limit int) []SourceUsage {
+	cpu := u.processCPU()
+	u.Lock()
+	report := make([]SourceUsage, 0, len(u.sources))
+	var total time.Duration
+	for _, s := range u.sources {
+		total += s.busy
+	}
+	for key, s := range u.sources {
+		row := SourceUsage{
+			Source:        key,
+			Busy:          s.busy,
+			Records:       s.records,
+			EgressBytes:   s.egress,
+			InFlightBytes: s.inFlight,
+		}
+		if total > 0 {
+			row.CPUShare = float64(s.busy) / float64(total)
+			row.CPUSeconds = row.CPUShare * cpu
+		}
+		report = append(report, row)
+	}
+	u.Unlock()
+
+	less := func(a, b SourceUsage) bool { return a.Busy > b.Busy }
+	switch sortBy {
+	case "egress":
+		less = func(a, b SourceUsage) bool { return a.EgressBytes > b.EgressBytes }
+	case "records":
+		less = func(a, b SourceUsage) bool { return a.Records > b.Records }
+	case "memory":
+		less = func(a, b SourceUsage) bool { return a.InFlightBytes > b.InFlightBytes }
+	}
+	sort.SliceStable(report, func(i, j int) bool {
+		if less(report[i], report[j]) {
+			return true
+		}
+		if less(report[j], report[i]) {
+			return false
+		}
+		return report[i].Source < report[j].Source
+	})
+	if limit > 0 && len(report) > limit {
+		report = report[:limit]
+	}
+	return report
+}
+
+// ServeHTTP writes the ranked report as JSON. The sort and limit query parameters are passed to Report.
+func (u *usage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(u.Report(r.URL.Query().Get("sort"), limit)); err != nil {
+		log.Printf("D! Unable to write usage report: %v", err)
+	}
+}
+
+// LogTop logs the sources that use the most CPU.
+func (u *usage) LogTop(limit int) {
+	report := u.Report("cpu", limit)
+	if len(report) == 0 {
+		return
+	}
+	output := make([]string, 0, len(report))
+	for _, row := range report {
+		output = append(output, fmt.Sprintf("[%s: cpu_share=%.3f egress_bytes=%d records=%d in_flight_bytes=%d]",
+			row.Source, row.CPUShare, row.EgressBytes, row.Records, row.InFlightBytes))
+	}
+	log.Printf("D! Usage by source:\n%s", strings.Join(output, "\n"))
+}
+
+func (u *usage) processCPU() float64 {
+	if u.readCPU != nil {
+		return u.readCPU()
+	}
+	sample := []metrics.Sample{{Name: userCPUMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindFloat64 {
+		return 0
+	}
+	return sample[0].Value.Float64()
+}
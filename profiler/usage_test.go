@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package profiler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUsage() *usage {
+	return &usage{
+		sources: make(map[string]*sourceUsage),
+		readCPU: func() float64 { return 10 },
+	}
+}
+
+func TestUsageReport(t *testing.T) {
+	u := newTestUsage()
+	heavy := SourceKey(SourceLogFile, "/var/log/heavy.log")
+	light := SourceKey(SourceLogFile, "/var/log/light.log")
+	target := SourceKey(SourcePrometheus, "job/localhost:9090")
+	u.AddBusy(heavy, 3*time.Second)
+	u.AddEmitted(heavy, 10, 1000)
+	u.AddInFlight(heavy, 1000)
+	u.AddInFlight(heavy, -400)
+	u.AddBusy(light, time.Second)
+	u.AddEmitted(light, 100, 5000)
+	u.AddEmitted(target, 50, 0)
+
+	report := u.Report("cpu", 0)
+	require.Len(t, report, 3)
+	assert.Equal(t, heavy, report[0].Source)
+	assert.InDelta(t, 0.75, report[0].CPUShare, 0.0001)
+	assert.InDelta(t, 7.5, report[0].CPUSeconds, 0.0001)
+	assert.EqualValues(t, 600, report[0].InFlightBytes)
+	assert.Equal(t, light, report[1].Source)
+	assert.Equal(t, target, report[2].Source)
+	assert.Zero(t, report[2].CPUShare)
+
+	report = u.Report("egress", 1)
+	require.Len(t, report, 1)
+	assert.Equal(t, light, report[0].Source)
+
+	report = u.Report("records", 0)
+	assert.Equal(t, []string{light, target, heavy}, []string{report[0].Source, report[1].Source, report[2].Source})
+
+	report = u.Report("memory", 1)
+	assert.Equal(t, heavy, report[0].Source)
+}
+
+func TestUsageServeHTTP(t *testing.T) {
+	u := newTestUsage()
+	u.AddBusy(SourceKey(SourceInput, "cpu"), time.Millisecond)
+	u.AddBusy(SourceKey(SourceInput, "mem"), time.Second)
+
+	recorder := httptest.NewRecorder()
+	u.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, UsagePath+"?limit=1", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var got []SourceUsage
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "input:mem", got[0].Source)
+}
@@ -144,6 +144,14 @@ func (o *otelAccumulator) convertToOtelMetricsAndAddMetric(m telegraf.Metric) {
 		return
 	}
 
+	// the scope names the input, for the exporters to tell which input collected the metrics
+	for i := 0; i < oMetric.ResourceMetrics().Len(); i++ {
+		scopeMetrics := oMetric.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			scopeMetrics.At(j).Scope().SetName(o.input.Config.Name)
+		}
+	}
+
 	// Gather and Start can add metrics concurrently. Therefore, a mutex ensures thread-safe access to the resource metrics
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
@@ -323,6 +323,17 @@ func Test_Accumulator_AddMetric(t *testing.T) {
 
 }
 
+func Test_Accumulator_ScopeNamesInput(t *testing.T) {
+	as := assert.New(t)
+
+	acc := newOtelAccumulatorWithConfig(as, nil, false, &models.InputConfig{Name: "cpu"})
+	acc.AddGauge("cpu", map[string]interface{}{"usage_idle": 99.5}, map[string]string{}, time.Now())
+
+	otelMetrics := acc.GetOtelMetrics()
+	as.Equal(1, otelMetrics.ResourceMetrics().Len())
+	as.Equal("cpu", otelMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Scope().Name())
+}
+
 func Test_Accumulator_AddMetric_ServiceInput(t *testing.T) {
 	t.Helper()
 
@@ -6,6 +6,8 @@ package adapter
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/schedule"
 )
 
 type Config struct {
@@ -13,10 +15,13 @@ type Config struct {
 
 	// The different name of the plugin, share the similar structure with https://github.com/influxdata/telegraf/pull/6207
 	AliasName string `mapstructure:"alias_name,omitempty"`
+
+	// CollectionWindows restricts collection to the listed recurring windows. Collection is unrestricted when empty.
+	CollectionWindows []schedule.Window `mapstructure:"collection_windows,omitempty"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 func (cfg *Config) Validate() error {
-	return nil
+	return schedule.Validate(cfg.CollectionWindows)
 }
@@ -15,6 +15,8 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 	otelscraper "go.opentelemetry.io/collector/scraper"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/schedule"
 )
 
 const (
@@ -62,6 +64,10 @@ func (a Adapter) createMetricsReceiver(ctx context.Context, settings receiver.Se
 	}
 
 	rcvr := newAdaptedReceiver(input, ctx, consumer, settings.Logger)
+	rcvr.meterProvider = settings.MeterProvider
+	if rcvr.schedule, err = schedule.New(cfg.CollectionWindows); err != nil {
+		return nil, err
+	}
 
 	scraper, err := otelscraper.NewMetrics(
 		rcvr.scrape,
@@ -5,14 +5,22 @@ package adapter
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/models"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/control"
+	"github.com/aws/amazon-cloudwatch-agent/internal/schedule"
+	"github.com/aws/amazon-cloudwatch-agent/internal/supervisor"
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter/accumulator"
 )
 
@@ -26,6 +34,44 @@ type AdaptedReceiver struct {
 	ctx         context.Context
 	consumer    consumer.Metrics
 	accumulator accumulator.OtelAccumulator
+	// meterProvider is handed to inputs that implement TelemetryInput.
+	meterProvider metric.MeterProvider
+	// schedule restricts collection to the configured collection windows.
+	schedule *schedule.Schedule
+	now      func() time.Time
+
+	mu sync.Mutex
+	// gapStart is when collection was last suspended by the schedule. It is reported on the first metrics
+	// collected once the next window opens.
+	gapStart time.Time
+	// failures is the number of consecutive scrapes that failed or panicked. Once the input panicked or failed
+	// failuresBeforeBackoff times in a row, the scrapes are skipped until retryAt, so that a broken input backs off
+	// instead of failing on every interval.
+	failures int
+	retryAt  time.Time
+
+	// serviceMu guards the start of a service input, which is retried in the background until it starts or the
+	// receiver shuts down.
+	serviceMu      sync.Mutex
+	serviceStarted bool
+	stopRetry      context.CancelFunc
+}
+
+const (
+	// AttributeGapStart and AttributeGapEnd are set on the resource of the first metrics collected after a
+	// scheduled gap, so that downstream consumers can tell a planned gap from missing data.
+	AttributeGapStart = "cwagent.collection.gap.start"
+	AttributeGapEnd   = "cwagent.collection.gap.end"
+)
+
+// failuresBeforeBackoff is the number of consecutive failed scrapes after which the input backs off. A single failure,
+// e.g. a timeout, does not skip the next interval.
+const failuresBeforeBackoff = 3
+
+// TelemetryInput is implemented by telegraf service inputs that run their own network listener and record
+// self-telemetry for it with the collector's MeterProvider.
+type TelemetryInput interface {
+	SetTelemetry(receiver string, mp metric.MeterProvider)
 }
 
 func newAdaptedReceiver(input *models.RunningInput, ctx context.Context, consumer consumer.Metrics, logger *zap.Logger) *AdaptedReceiver {
@@ -34,6 +80,7 @@ func newAdaptedReceiver(input *models.RunningInput, ctx context.Context, consume
 		ctx:      ctx,
 		consumer: consumer,
 		logger:   logger,
+		now:      time.Now,
 	}
 }
 
@@ -43,20 +90,58 @@ func (r *AdaptedReceiver) start(_ context.Context, _ component.Host) error {
 	// TODO: Add Set Precision based on agent precision and agent interval
 	// https://github.com/influxdata/telegraf/blob/3b3584b40b7c9ea10ae9cb02137fc072da202704/agent/agent.go#L316-L317
 
-	r.accumulator = accumulator.NewAccumulator(r.input, r.ctx, r.consumer, r.logger)
+	// service inputs push metrics from their own goroutines, so they are held to the same pause and schedule as Gather
+	gated, err := consumer.NewMetrics(r.consumeGated)
+	if err != nil {
+		return err
+	}
+	r.accumulator = accumulator.NewAccumulator(r.input, r.ctx, gated, r.logger)
+	control.Register(r.usageKey())
+
+	if telemetryInput, ok := r.input.Input.(TelemetryInput); ok && r.meterProvider != nil {
+		telemetryInput.SetTelemetry(TelegrafPrefix+r.input.Config.Name, r.meterProvider)
+	}
 
 	// Service Input differs from a regular plugin in that it operates a background service while Telegraf/CWAgent is running
 	// https://github.com/influxdata/telegraf/blob/d67f75e55765d364ad0aabe99382656cb5b51014/docs/INPUTS.md#service-input-plugins
+	// A service input that fails to start is restarted with backoff rather than failing the other pipelines.
 	if serviceInput, ok := r.input.Input.(telegraf.ServiceInput); ok {
-		if err := serviceInput.Start(r.accumulator); err != nil {
-			r.accumulator.AddError(err)
-			return err
+		ctx, cancel := context.WithCancel(context.Background())
+		r.stopRetry = cancel
+		if err := r.startService(ctx, serviceInput); err != nil {
+			r.logger.Error("Failed to start the service input, retrying with backoff", zap.String("receiver", r.input.Config.Name), zap.Error(err))
+			supervisor.Retry(ctx, r.usageKey(), supervisor.DefaultBackoff, func(ctx context.Context) error {
+				return r.startService(ctx, serviceInput)
+			})
 		}
 	}
 
 	return nil
 }
 
+func (r *AdaptedReceiver) startService(ctx context.Context, serviceInput telegraf.ServiceInput) error {
+	r.serviceMu.Lock()
+	defer r.serviceMu.Unlock()
+	if ctx.Err() != nil {
+		return nil
+	}
+	err := supervisor.Call(r.usageKey(), func() error {
+		return serviceInput.Start(r.accumulator)
+	})
+	if err != nil {
+		r.accumulator.AddError(err)
+		return err
+	}
+	r.serviceStarted = true
+	return nil
+}
+
+func (r *AdaptedReceiver) isServiceStarted() bool {
+	r.serviceMu.Lock()
+	defer r.serviceMu.Unlock()
+	return r.serviceStarted
+}
+
 func (r *AdaptedReceiver) scrape(_ context.Context) (pmetric.Metrics, error) {
 	r.logger.Debug("Begin scraping metrics with adapter", zap.String("receiver", r.input.Config.Name))
 
@@ -64,17 +149,120 @@ func (r *AdaptedReceiver) scrape(_ context.Context) (pmetric.Metrics, error) {
 	// the background process is the one sending the metrics further along the pipeline but there are cases where the
 	// background process can buffer the metrics and calling Gather is what flushes the buffer. An example of this is
 	// our statsd plugin: https://github.com/aws/amazon-cloudwatch-agent/blob/2e468dfd96cf9084ab76c2420262e1bbe1eca483/plugins/inputs/statsd/statsd.go
-	if err := r.input.Input.Gather(r.accumulator); err != nil {
+	now := r.now()
+	paused := control.Paused(r.usageKey()) || !r.collecting(now)
+	_, isServiceInput := r.input.Input.(telegraf.ServiceInput)
+	if (paused && !isServiceInput) || (isServiceInput && !r.isServiceStarted()) || r.backingOff(now) {
+		return pmetric.NewMetrics(), nil
+	}
+
+	start := time.Now()
+	err := supervisor.Call(r.usageKey(), func() error {
+		return r.input.Input.Gather(r.accumulator)
+	})
+	r.recordScrape(now, err)
+	if err != nil {
 		r.accumulator.AddError(err)
 		return pmetric.Metrics{}, err
 	}
 
-	return r.accumulator.GetOtelMetrics(), nil
+	metrics := r.accumulator.GetOtelMetrics()
+	if paused {
+		// service inputs keep buffering between calls to Gather, so flush and drop what they buffered
+		return pmetric.NewMetrics(), nil
+	}
+	r.markGap(metrics, now)
+	profiler.Usage.AddBusy(r.usageKey(), time.Since(start))
+	profiler.Usage.AddEmitted(r.usageKey(), metrics.DataPointCount(), 0)
+	return metrics, nil
+}
+
+// backingOff reports whether the scrape is skipped because the previous scrapes failed.
+func (r *AdaptedReceiver) backingOff(now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return now.Before(r.retryAt)
+}
+
+// recordScrape sets when the input is next scraped once it panicked or kept failing, or resets the backoff once it
+// succeeded.
+func (r *AdaptedReceiver) recordScrape(now time.Time, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err == nil {
+		r.failures = 0
+		r.retryAt = time.Time{}
+		return
+	}
+	r.failures++
+	var panicErr *supervisor.PanicError
+	if !errors.As(err, &panicErr) && r.failures < failuresBeforeBackoff {
+		return
+	}
+	delay := supervisor.DefaultBackoff.Delay(max(0, r.failures-failuresBeforeBackoff))
+	r.retryAt = now.Add(delay)
+	r.logger.Warn("Failed to scrape the input, backing off", zap.String("receiver", r.input.Config.Name),
+		zap.Int("failures", r.failures), zap.Duration("backoff", delay), zap.Error(err))
+}
+
+func (r *AdaptedReceiver) consumeGated(ctx context.Context, metrics pmetric.Metrics) error {
+	now := r.now()
+	if control.Paused(r.usageKey()) || !r.collecting(now) {
+		return nil
+	}
+	r.markGap(metrics, now)
+	return r.consumer.ConsumeMetrics(ctx, metrics)
+}
+
+// collecting reports whether now is inside a collection window and records the start of the gap when it is not.
+func (r *AdaptedReceiver) collecting(now time.Time) bool {
+	if r.schedule.Active(now) {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.gapStart.IsZero() {
+		r.gapStart = now
+		r.logger.Info("Outside of collection windows, suspending collection", zap.String("receiver", r.input.Config.Name))
+	}
+	return false
+}
+
+// markGap sets the gap markers on metrics if they are the first to be collected after a scheduled gap.
+func (r *AdaptedReceiver) markGap(metrics pmetric.Metrics, now time.Time) {
+	if metrics.ResourceMetrics().Len() == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.gapStart.IsZero() {
+		return
+	}
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		attrs := metrics.ResourceMetrics().At(i).Resource().Attributes()
+		attrs.PutStr(AttributeGapStart, r.gapStart.UTC().Format(time.RFC3339))
+		attrs.PutStr(AttributeGapEnd, now.UTC().Format(time.RFC3339))
+	}
+	r.logger.Info("Inside of collection windows, resuming collection", zap.String("receiver", r.input.Config.Name))
+	r.gapStart = time.Time{}
+}
+
+// usageKey identifies the input in the profiler usage report.
+func (r *AdaptedReceiver) usageKey() string {
+	name := r.input.Config.Name
+	if r.input.Config.Alias != "" {
+		name += "/" + r.input.Config.Alias
+	}
+	return profiler.SourceKey(profiler.SourceInput, name)
 }
 
 func (r *AdaptedReceiver) shutdown(_ context.Context) error {
 	r.logger.Debug("Shutdown adapter", zap.String("receiver", r.input.Config.Name))
-	if serviceInput, ok := r.input.Input.(telegraf.ServiceInput); ok {
+	control.Unregister(r.usageKey())
+	if r.stopRetry != nil {
+		r.stopRetry()
+	}
+	if serviceInput, ok := r.input.Input.(telegraf.ServiceInput); ok && r.isServiceStarted() {
 		serviceInput.Stop()
 	}
 
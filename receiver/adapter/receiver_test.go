@@ -5,14 +5,21 @@ package adapter
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.uber.org/zap"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/control"
+	"github.com/aws/amazon-cloudwatch-agent/internal/schedule"
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter/accumulator"
 )
 
@@ -49,3 +56,197 @@ func Test_AdaptedReceiver_WithEmptyMetrics_ServiceInput(t *testing.T) {
 	err = adaptedReceiver.shutdown(ctx)
 	as.NoError(err)
 }
+
+type countingInput struct {
+	accumulator.TestRunningInput
+	gathered int
+}
+
+func (c *countingInput) Gather(acc telegraf.Accumulator) error {
+	c.gathered++
+	acc.AddFields("counting", map[string]interface{}{"value": 1}, nil)
+	return nil
+}
+
+func Test_AdaptedReceiver_Paused(t *testing.T) {
+	ctx := context.Background()
+	input := &countingInput{}
+	ri := models.NewRunningInput(input, &models.InputConfig{Name: "counting"})
+	adaptedReceiver := newAdaptedReceiver(ri, ctx, nil, zap.NewNop())
+	assert.NoError(t, adaptedReceiver.start(ctx, componenttest.NewNopHost()))
+	assert.Contains(t, control.Sources(), control.Source{Name: "input:counting"})
+
+	assert.NoError(t, control.Pause("input:counting"))
+	metrics, err := adaptedReceiver.scrape(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, metrics.DataPointCount())
+	assert.Zero(t, input.gathered)
+
+	assert.True(t, control.Resume("input:counting"))
+	metrics, err = adaptedReceiver.scrape(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, metrics.DataPointCount())
+	assert.Equal(t, 1, input.gathered)
+
+	assert.NoError(t, adaptedReceiver.shutdown(ctx))
+	assert.NotContains(t, control.Sources(), control.Source{Name: "input:counting"})
+}
+
+func Test_AdaptedReceiver_CollectionWindows(t *testing.T) {
+	ctx := context.Background()
+	input := &countingInput{}
+	ri := models.NewRunningInput(input, &models.InputConfig{Name: "counting"})
+	adaptedReceiver := newAdaptedReceiver(ri, ctx, nil, zap.NewNop())
+	var err error
+	adaptedReceiver.schedule, err = schedule.New([]schedule.Window{{Start: "09:00", End: "17:00", Timezone: "UTC"}})
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 8, 8, 0, 0, 0, time.UTC)
+	adaptedReceiver.now = func() time.Time { return now }
+	require.NoError(t, adaptedReceiver.start(ctx, componenttest.NewNopHost()))
+
+	metrics, err := adaptedReceiver.scrape(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, metrics.DataPointCount())
+	assert.Zero(t, input.gathered)
+
+	now = now.Add(time.Hour)
+	metrics, err = adaptedReceiver.scrape(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, metrics.DataPointCount())
+	attrs := metrics.ResourceMetrics().At(0).Resource().Attributes()
+	gapStart, _ := attrs.Get(AttributeGapStart)
+	gapEnd, _ := attrs.Get(AttributeGapEnd)
+	assert.Equal(t, "2024-01-08T08:00:00Z", gapStart.Str())
+	assert.Equal(t, "2024-01-08T09:00:00Z", gapEnd.Str())
+
+	// the markers are only set once per gap
+	metrics, err = adaptedReceiver.scrape(ctx)
+	assert.NoError(t, err)
+	_, ok := metrics.ResourceMetrics().At(0).Resource().Attributes().Get(AttributeGapStart)
+	assert.False(t, ok)
+	assert.Equal(t, 2, input.gathered)
+
+	assert.NoError(t, adaptedReceiver.shutdown(ctx))
+}
+
+type panickingInput struct {
+	accumulator.TestRunningInput
+	gathered int
+}
+
+func (p *panickingInput) Gather(acc telegraf.Accumulator) error {
+	p.gathered++
+	if p.gathered == 1 {
+		panic("gather")
+	}
+	acc.AddFields("panicking", map[string]interface{}{"value": 1}, nil)
+	return nil
+}
+
+func Test_AdaptedReceiver_PanicBacksOff(t *testing.T) {
+	ctx := context.Background()
+	input := &panickingInput{}
+	ri := models.NewRunningInput(input, &models.InputConfig{Name: "panicking"})
+	adaptedReceiver := newAdaptedReceiver(ri, ctx, nil, zap.NewNop())
+	now := time.Now()
+	adaptedReceiver.now = func() time.Time { return now }
+	require.NoError(t, adaptedReceiver.start(ctx, componenttest.NewNopHost()))
+
+	_, err := adaptedReceiver.scrape(ctx)
+	assert.ErrorContains(t, err, "panic: gather")
+
+	// the input is not scraped again until the backoff elapsed
+	metrics, err := adaptedReceiver.scrape(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, metrics.DataPointCount())
+	assert.Equal(t, 1, input.gathered)
+
+	now = now.Add(time.Minute)
+	metrics, err = adaptedReceiver.scrape(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, metrics.DataPointCount())
+	assert.NoError(t, adaptedReceiver.shutdown(ctx))
+}
+
+type failingInput struct {
+	accumulator.TestRunningInput
+	gathered int
+	failures int
+}
+
+func (f *failingInput) Gather(acc telegraf.Accumulator) error {
+	f.gathered++
+	if f.gathered <= f.failures {
+		return errors.New("timed out")
+	}
+	acc.AddFields("failing", map[string]interface{}{"value": 1}, nil)
+	return nil
+}
+
+func Test_AdaptedReceiver_FailureBacksOff(t *testing.T) {
+	ctx := context.Background()
+	input := &failingInput{failures: 1}
+	ri := models.NewRunningInput(input, &models.InputConfig{Name: "failing"})
+	adaptedReceiver := newAdaptedReceiver(ri, ctx, nil, zap.NewNop())
+	now := time.Now()
+	adaptedReceiver.now = func() time.Time { return now }
+	require.NoError(t, adaptedReceiver.start(ctx, componenttest.NewNopHost()))
+
+	// a single failure does not skip the next interval
+	_, err := adaptedReceiver.scrape(ctx)
+	assert.ErrorContains(t, err, "timed out")
+	metrics, err := adaptedReceiver.scrape(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, metrics.DataPointCount())
+	assert.Equal(t, 2, input.gathered)
+
+	// the input backs off once it failed failuresBeforeBackoff times in a row
+	input.gathered, input.failures = 0, failuresBeforeBackoff
+	for i := 0; i < failuresBeforeBackoff; i++ {
+		_, err = adaptedReceiver.scrape(ctx)
+		assert.ErrorContains(t, err, "timed out")
+	}
+	metrics, err = adaptedReceiver.scrape(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, metrics.DataPointCount())
+	assert.Equal(t, failuresBeforeBackoff, input.gathered)
+
+	now = now.Add(time.Minute)
+	metrics, err = adaptedReceiver.scrape(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, metrics.DataPointCount())
+	assert.NoError(t, adaptedReceiver.shutdown(ctx))
+}
+
+type failingServiceInput struct {
+	accumulator.TestServiceRunningInput
+	starts  atomic.Int32
+	stopped atomic.Bool
+}
+
+func (f *failingServiceInput) Start(_ telegraf.Accumulator) error {
+	if f.starts.Add(1) == 1 {
+		return errors.New("address already in use")
+	}
+	return nil
+}
+
+func (f *failingServiceInput) Stop() {
+	f.stopped.Store(true)
+}
+
+func Test_AdaptedReceiver_ServiceInputRestarted(t *testing.T) {
+	ctx := context.Background()
+	input := &failingServiceInput{}
+	ri := models.NewRunningInput(input, &models.InputConfig{Name: "failing"})
+	adaptedReceiver := newAdaptedReceiver(ri, ctx, &consumertest.MetricsSink{}, zap.NewNop())
+
+	// the failed start does not fail the other pipelines
+	require.NoError(t, adaptedReceiver.start(ctx, componenttest.NewNopHost()))
+	assert.False(t, adaptedReceiver.isServiceStarted())
+	assert.Eventually(t, adaptedReceiver.isServiceStarted, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2), input.starts.Load())
+
+	assert.NoError(t, adaptedReceiver.shutdown(ctx))
+	assert.True(t, input.stopped.Load())
+}
@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package eventsreceiver
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+type Config struct {
+	// Endpoint is the address the HTTP server listens on.
+	Endpoint string `mapstructure:"endpoint"`
+	// LogGroupName is the log group the events are sent to.
+	LogGroupName string `mapstructure:"log_group_name"`
+	// LogStreamName is the log stream the events are sent to.
+	LogStreamName string `mapstructure:"log_stream_name"`
+	// Namespace is the namespace of the metrics of the events.
+	Namespace string `mapstructure:"namespace"`
+	// Metadata is added to every event, e.g. the host the agent runs on.
+	Metadata map[string]string `mapstructure:"metadata,omitempty"`
+	// Auth is the authenticator extension the requests are checked with, e.g. localauth.
+	Auth *configauth.Authentication `mapstructure:"auth,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	if c.LogGroupName == "" {
+		return errors.New("log_group_name must be set")
+	}
+	if c.LogStreamName == "" {
+		return errors.New("log_stream_name must be set")
+	}
+	if c.Namespace == "" {
+		return errors.New("namespace must be set")
+	}
+	for key := range c.Metadata {
+		if reservedKeys.Contains(key) {
+			return errors.New("metadata must not set the reserved key " + key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package eventsreceiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+)
+
+const (
+	eventKey         = "event"
+	logGroupNameKey  = "log_group_name"
+	logStreamNameKey = "log_stream_name"
+	awsKey           = "_aws"
+
+	maxNameLength           = 255
+	maxAttributes           = 100
+	maxMetrics              = 100
+	maxDimensions           = 30
+	maxDimensionValueLength = 1024
+	maxEventsAge            = 14 * 24 * time.Hour
+	maxEventsAhead          = 2 * time.Hour
+)
+
+var (
+	// reservedKeys are the fields the receiver writes itself, so the attributes and metrics of the events cannot
+	// use them.
+	reservedKeys = collections.NewSet(eventKey, logGroupNameKey, logStreamNameKey, awsKey)
+	// units are the units CloudWatch accepts for the metrics.
+	units = collections.NewSet(
+		"Seconds", "Microseconds", "Milliseconds",
+		"Bytes", "Kilobytes", "Megabytes", "Gigabytes", "Terabytes",
+		"Bits", "Kilobits", "Megabits", "Gigabits", "Terabits",
+		"Percent", "Count",
+		"Bytes/Second", "Kilobytes/Second", "Megabytes/Second", "Gigabytes/Second", "Terabytes/Second",
+		"Bits/Second", "Kilobits/Second", "Megabits/Second", "Gigabits/Second", "Terabits/Second",
+		"Count/Second", "None",
+	)
+)
+
+// event is the JSON an application posts. Only the name is required.
+type event struct {
+	Name string `json:"name"`
+	// Timestamp is in milliseconds since the epoch, the time the event is received when it is 0.
+	Timestamp  int64             `json:"timestamp"`
+	Attributes map[string]any    `json:"attributes"`
+	Metrics    map[string]metric `json:"metrics"`
+	// Dimensions are the names of the attributes the metrics are published with.
+	Dimensions []string `json:"dimensions"`
+}
+
+// metric is either a number or an object with a value and a unit.
+type metric struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit,omitempty"`
+}
+
+func (m *metric) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		type plain metric
+		var p plain
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&p); err != nil {
+			return err
+		}
+		*m = metric(p)
+		return nil
+	}
+	return json.Unmarshal(data, &m.Value)
+}
+
+// parseEvents decodes a single event or an array of them. Unknown fields are rejected, so that a misspelled one
+// is not silently dropped.
+func parseEvents(data []byte) ([]event, error) {
+	data = bytes.TrimSpace(data)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if len(data) > 0 && data[0] == '[' {
+		var events []event
+		if err := decoder.Decode(&events); err != nil {
+			return nil, err
+		}
+		return events, nil
+	}
+	var e event
+	if err := decoder.Decode(&e); err != nil {
+		return nil, err
+	}
+	return []event{e}, nil
+}
+
+// validate checks the event against the limits of CloudWatch, and sets its timestamp if it has none.
+func (e *event) validate(now time.Time, metadata map[string]string) error {
+	if e.Name == "" || len(e.Name) > maxNameLength {
+		return fmt.Errorf("name must be 1 to %d characters", maxNameLength)
+	}
+	if e.Timestamp == 0 {
+		e.Timestamp = now.UnixMilli()
+	} else if ts := time.UnixMilli(e.Timestamp); ts.Before(now.Add(-maxEventsAge)) || ts.After(now.Add(maxEventsAhead)) {
+		return errors.New("timestamp must be within the last 14 days and the next 2 hours")
+	}
+	if len(e.Attributes) > maxAttributes {
+		return fmt.Errorf("at most %d attributes are allowed", maxAttributes)
+	}
+	for key, value := range e.Attributes {
+		if err := validateKey("attribute", key, metadata); err != nil {
+			return err
+		}
+		switch value.(type) {
+		case string, float64, bool:
+		default:
+			return fmt.Errorf("attribute %q must be a string, a number or a boolean", key)
+		}
+	}
+	if len(e.Metrics) > maxMetrics {
+		return fmt.Errorf("at most %d metrics are allowed", maxMetrics)
+	}
+	for name, m := range e.Metrics {
+		if err := validateKey("metric", name, metadata); err != nil {
+			return err
+		}
+		if _, ok := e.Attributes[name]; ok {
+			return fmt.Errorf("metric %q must not have the name of an attribute", name)
+		}
+		if m.Unit != "" && !units.Contains(m.Unit) {
+			return fmt.Errorf("metric %q has an invalid unit %q", name, m.Unit)
+		}
+	}
+	if len(e.Dimensions) > 0 && len(e.Metrics) == 0 {
+		return errors.New("dimensions must not be set without metrics")
+	}
+	if len(e.Dimensions) > maxDimensions {
+		return fmt.Errorf("at most %d dimensions are allowed", maxDimensions)
+	}
+	for i, dimension := range e.Dimensions {
+		value, ok := e.Attributes[dimension].(string)
+		if !ok {
+			return fmt.Errorf("dimension %q must be a string attribute", dimension)
+		}
+		if value == "" || len(value) > maxDimensionValueLength {
+			return fmt.Errorf("dimension %q must have a value of 1 to %d characters", dimension, maxDimensionValueLength)
+		}
+		if slices.Contains(e.Dimensions[:i], dimension) {
+			return fmt.Errorf("dimension %q must not be repeated", dimension)
+		}
+	}
+	return nil
+}
+
+func validateKey(kind, key string, metadata map[string]string) error {
+	if key == "" || len(key) > maxNameLength {
+		return fmt.Errorf("%s names must be 1 to %d characters", kind, maxNameLength)
+	}
+	if _, ok := metadata[key]; ok || reservedKeys.Contains(key) {
+		return fmt.Errorf("%s %q must not have a reserved name", kind, key)
+	}
+	return nil
+}
+
+// encode returns the log the event is sent as. The attributes, metrics and metadata are fields of the log. An event
+// with metrics is an EMF log, whose _aws metadata routes it to the log group and log stream, while the others are
+// routed with the log_group_name and log_stream_name fields.
+func (e *event) encode(cfg *Config) ([]byte, error) {
+	fields := make(map[string]any, len(e.Attributes)+len(e.Metrics)+len(cfg.Metadata)+3)
+	for key, value := range cfg.Metadata {
+		fields[key] = value
+	}
+	for key, value := range e.Attributes {
+		fields[key] = value
+	}
+	fields[eventKey] = e.Name
+	if len(e.Metrics) == 0 {
+		fields[logGroupNameKey] = cfg.LogGroupName
+		fields[logStreamNameKey] = cfg.LogStreamName
+		return json.Marshal(fields)
+	}
+	metrics := make([]emfMetric, 0, len(e.Metrics))
+	for _, name := range slices.Sorted(maps.Keys(e.Metrics)) {
+		m := e.Metrics[name]
+		fields[name] = m.Value
+		metrics = append(metrics, emfMetric{Name: name, Unit: m.Unit})
+	}
+	fields[awsKey] = emfMetadata{
+		Timestamp:     e.Timestamp,
+		LogGroupName:  cfg.LogGroupName,
+		LogStreamName: cfg.LogStreamName,
+		CloudWatchMetrics: []emfDirective{{
+			Namespace:  cfg.Namespace,
+			Dimensions: [][]string{append([]string{}, e.Dimensions...)},
+			Metrics:    metrics,
+		}},
+	}
+	return json.Marshal(fields)
+}
+
+type emfMetadata struct {
+	Timestamp         int64          `json:"Timestamp"`
+	LogGroupName      string         `json:"LogGroupName"`
+	LogStreamName     string         `json:"LogStreamName"`
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+}
+
+type emfDirective struct {
+	Namespace  string      `json:"Namespace"`
+	Dimensions [][]string  `json:"Dimensions"`
+	Metrics    []emfMetric `json:"Metrics"`
+}
+
+type emfMetric struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit,omitempty"`
+}
@@ -0,0 +1,165 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package eventsreceiver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvents(t *testing.T) {
+	events, err := parseEvents([]byte(`{"name": "OrderPlaced", "metrics": {"Amount": 12.5, "Latency": {"value": 31, "unit": "Milliseconds"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []event{{
+		Name:    "OrderPlaced",
+		Metrics: map[string]metric{"Amount": {Value: 12.5}, "Latency": {Value: 31, Unit: "Milliseconds"}},
+	}}, events)
+
+	events, err = parseEvents([]byte(` [{"name": "a"}, {"name": "b"}]`))
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	_, err = parseEvents([]byte(`{"name": "a", "atributes": {}}`))
+	assert.Error(t, err)
+	_, err = parseEvents([]byte(`{"name": "a", "metrics": {"Latency": {"value": 1, "units": "Seconds"}}}`))
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	metadata := map[string]string{"host": "ip-10-0-0-1"}
+	testCases := map[string]struct {
+		event   event
+		wantErr string
+	}{
+		"Valid": {
+			event: event{
+				Name:       "OrderPlaced",
+				Attributes: map[string]any{"service": "checkout", "retry": false},
+				Metrics:    map[string]metric{"Amount": {Value: 12.5, Unit: "None"}},
+				Dimensions: []string{"service"},
+			},
+		},
+		"NoName":            {event: event{}, wantErr: "name must be"},
+		"Old":               {event: event{Name: "a", Timestamp: now.Add(-15 * 24 * time.Hour).UnixMilli()}, wantErr: "timestamp"},
+		"Future":            {event: event{Name: "a", Timestamp: now.Add(3 * time.Hour).UnixMilli()}, wantErr: "timestamp"},
+		"ReservedAttribute": {event: event{Name: "a", Attributes: map[string]any{"_aws": "x"}}, wantErr: "reserved"},
+		"MetadataAttribute": {event: event{Name: "a", Attributes: map[string]any{"host": "x"}}, wantErr: "reserved"},
+		"NestedAttribute":   {event: event{Name: "a", Attributes: map[string]any{"order": map[string]any{}}}, wantErr: "must be a string"},
+		"MetricAttribute": {
+			event:   event{Name: "a", Attributes: map[string]any{"Amount": "x"}, Metrics: map[string]metric{"Amount": {}}},
+			wantErr: "name of an attribute",
+		},
+		"InvalidUnit":          {event: event{Name: "a", Metrics: map[string]metric{"Amount": {Unit: "Dollars"}}}, wantErr: "invalid unit"},
+		"DimensionsNoMetrics":  {event: event{Name: "a", Attributes: map[string]any{"service": "x"}, Dimensions: []string{"service"}}, wantErr: "without metrics"},
+		"DimensionNotString":   {event: event{Name: "a", Attributes: map[string]any{"retry": true}, Metrics: map[string]metric{"Amount": {}}, Dimensions: []string{"retry"}}, wantErr: "string attribute"},
+		"DimensionNoAttribute": {event: event{Name: "a", Metrics: map[string]metric{"Amount": {}}, Dimensions: []string{"service"}}, wantErr: "string attribute"},
+		"DimensionEmpty":       {event: event{Name: "a", Attributes: map[string]any{"service": ""}, Metrics: map[string]metric{"Amount": {}}, Dimensions: []string{"service"}}, wantErr: "1 to 1024 characters"},
+		"DimensionTooLong": {
+			event:   event{Name: "a", Attributes: map[string]any{"service": strings.Repeat("s", 1025)}, Metrics: map[string]metric{"Amount": {}}, Dimensions: []string{"service"}},
+			wantErr: "1 to 1024 characters",
+		},
+		"DimensionRepeated": {
+			event:   event{Name: "a", Attributes: map[string]any{"service": "x"}, Metrics: map[string]metric{"Amount": {}}, Dimensions: []string{"service", "service"}},
+			wantErr: "repeated",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.event.validate(now, metadata)
+			if testCase.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.wantErr)
+			}
+		})
+	}
+	e := event{Name: "a"}
+	require.NoError(t, e.validate(now, nil))
+	assert.Equal(t, now.UnixMilli(), e.Timestamp)
+	e = event{Name: strings.Repeat("a", maxNameLength+1)}
+	assert.Error(t, e.validate(now, nil))
+}
+
+func TestEncode(t *testing.T) {
+	cfg := &Config{
+		LogGroupName:  "/aws/events/checkout",
+		LogStreamName: "i-0123456789abcdef0",
+		Namespace:     "Checkout",
+		Metadata:      map[string]string{"host": "ip-10-0-0-1"},
+	}
+	e := event{Name: "OrderPlaced", Timestamp: 1700000000000, Attributes: map[string]any{"service": "checkout"}}
+	encoded, err := e.encode(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"event": "OrderPlaced",
+		"service": "checkout",
+		"host": "ip-10-0-0-1",
+		"log_group_name": "/aws/events/checkout",
+		"log_stream_name": "i-0123456789abcdef0"
+	}`, string(encoded))
+
+	e.Metrics = map[string]metric{"Latency": {Value: 31, Unit: "Milliseconds"}, "Amount": {Value: 12.5}}
+	e.Dimensions = []string{"service"}
+	encoded, err = e.encode(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"event": "OrderPlaced",
+		"service": "checkout",
+		"host": "ip-10-0-0-1",
+		"Amount": 12.5,
+		"Latency": 31,
+		"_aws": {
+			"Timestamp": 1700000000000,
+			"LogGroupName": "/aws/events/checkout",
+			"LogStreamName": "i-0123456789abcdef0",
+			"CloudWatchMetrics": [{
+				"Namespace": "Checkout",
+				"Dimensions": [["service"]],
+				"Metrics": [{"Name": "Amount"}, {"Name": "Latency", "Unit": "Milliseconds"}]
+			}]
+		}
+	}`, string(encoded))
+	assert.True(t, json.Valid(encoded))
+}
+
+// Any request body, however malformed, should either be rejected or turn into logs that are valid JSON and, with
+// metrics, EMF logs referencing only the fields they have
+func FuzzParseEvents(f *testing.F) {
+	for _, body := range []string{
+		`{"name": "OrderPlaced", "metrics": {"Amount": 12.5, "Latency": {"value": 31, "unit": "Milliseconds"}}}`,
+		`[{"name": "a", "attributes": {"service": "checkout", "retry": false}}, {"name": "b", "timestamp": 1700000000000}]`,
+		`{"name": "a", "attributes": {"service": "x"}, "metrics": {"Amount": 1}, "dimensions": ["service"]}`,
+	} {
+		f.Add([]byte(body))
+	}
+	now := time.UnixMilli(1700000000000)
+	cfg := &Config{LogGroupName: "/aws/events", LogStreamName: "i-123", Namespace: "Events", Metadata: map[string]string{"host": "h"}}
+	f.Fuzz(func(t *testing.T, body []byte) {
+		events, err := parseEvents(body)
+		if err != nil {
+			return
+		}
+		for _, e := range events {
+			if e.validate(now, cfg.Metadata) != nil {
+				continue
+			}
+			encoded, err := e.encode(cfg)
+			require.NoError(t, err)
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(encoded, &fields), "log %s", encoded)
+			for name := range e.Metrics {
+				assert.IsType(t, float64(0), fields[name], "metric %q of %s", name, encoded)
+			}
+			for _, dimension := range e.Dimensions {
+				assert.IsType(t, "", fields[dimension], "dimension %q of %s", dimension, encoded)
+			}
+		}
+	})
+}
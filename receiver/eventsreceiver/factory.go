@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package eventsreceiver
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+const (
+	typeStr   = "events"
+	stability = component.StabilityLevelAlpha
+
+	defaultEndpoint  = "127.0.0.1:25890"
+	defaultNamespace = "CWAgent"
+)
+
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		component.MustNewType(typeStr),
+		createDefaultConfig,
+		receiver.WithLogs(createLogsReceiver, stability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Endpoint:  defaultEndpoint,
+		Namespace: defaultNamespace,
+	}
+}
+
+func createLogsReceiver(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+	rCfg, ok := cfg.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid configuration type: %T", cfg)
+	}
+	return newEventsReceiver(rCfg, set.Logger, next), nil
+}
@@ -0,0 +1,169 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package eventsreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+const (
+	eventsPath = "/v1/events"
+	// maxRequestSize bounds the body of a request, like the 1 MiB of a PutLogEvents batch.
+	maxRequestSize = 1 << 20
+	// maxEventsPerRequest bounds the number of events posted at once.
+	maxEventsPerRequest = 500
+)
+
+// eventsReceiver accepts the events applications post to a local HTTP endpoint. The events of a request are
+// validated together, and none of them is sent when one is invalid, so that the application can safely retry it.
+type eventsReceiver struct {
+	config *Config
+	logger *zap.Logger
+	next   consumer.Logs
+
+	server *http.Server
+	wg     sync.WaitGroup
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+var _ component.Component = (*eventsReceiver)(nil)
+
+func newEventsReceiver(config *Config, logger *zap.Logger, next consumer.Logs) *eventsReceiver {
+	return &eventsReceiver{
+		config: config,
+		logger: logger,
+		next:   next,
+		now:    time.Now,
+	}
+}
+
+func (r *eventsReceiver) Start(ctx context.Context, host component.Host) error {
+	var handler http.Handler = http.HandlerFunc(r.handleEvents)
+	if r.config.Auth != nil {
+		server, err := r.config.Auth.GetServerAuthenticator(ctx, host.GetExtensions())
+		if err != nil {
+			return err
+		}
+		handler = authenticate(server, handler)
+	}
+	listener, err := net.Listen("tcp", r.config.Endpoint)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %w", r.config.Endpoint, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(eventsPath, handler)
+	r.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if err := r.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			r.logger.Error("Events server stopped", zap.String("endpoint", r.config.Endpoint), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (r *eventsReceiver) Shutdown(ctx context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Shutdown(ctx)
+	r.wg.Wait()
+	return err
+}
+
+func (r *eventsReceiver) handleEvents(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, errors.New("only POST is allowed"))
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxRequestSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request must be at most %d bytes", maxRequestSize))
+		} else {
+			writeError(w, http.StatusBadRequest, err)
+		}
+		return
+	}
+	events, err := parseEvents(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON: %w", err))
+		return
+	}
+	if len(events) == 0 || len(events) > maxEventsPerRequest {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("a request must have 1 to %d events", maxEventsPerRequest))
+		return
+	}
+	ld, err := r.toLogs(events)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err = r.next.ConsumeLogs(req.Context(), ld); err != nil {
+		r.logger.Debug("Unable to consume the events", zap.Error(err))
+		writeError(w, http.StatusServiceUnavailable, errors.New("the events could not be sent, retry later"))
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// toLogs validates the events and converts each one to a log record.
+func (r *eventsReceiver) toLogs(events []event) (plog.Logs, error) {
+	now := r.now()
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.EnsureCapacity(len(events))
+	for i := range events {
+		e := &events[i]
+		if err := e.validate(now, r.config.Metadata); err != nil {
+			return ld, fmt.Errorf("event %d: %w", i, err)
+		}
+		encoded, err := e.encode(r.config)
+		if err != nil {
+			return ld, fmt.Errorf("event %d: %w", i, err)
+		}
+		record := records.AppendEmpty()
+		record.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(e.Timestamp)))
+		record.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+		record.Body().SetStr(string(encoded))
+	}
+	return ld, nil
+}
+
+// authenticate rejects the requests the authenticator does not accept before their body is read.
+func authenticate(server auth.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, err := server.Authenticate(req.Context(), req.Header)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
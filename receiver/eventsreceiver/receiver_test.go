@@ -0,0 +1,167 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package eventsreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+)
+
+func testConfig() *Config {
+	return &Config{
+		Endpoint:      "127.0.0.1:0",
+		LogGroupName:  "/aws/events",
+		LogStreamName: "stream",
+		Namespace:     defaultNamespace,
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, testConfig().Validate())
+	cfg := createDefaultConfig().(*Config)
+	assert.Error(t, cfg.Validate())
+	cfg = testConfig()
+	cfg.Metadata = map[string]string{"event": "x"}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestHandleEvents(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	testCases := map[string]struct {
+		method     string
+		body       string
+		consumeErr error
+		wantStatus int
+		wantLogs   int
+	}{
+		"Single":       {method: http.MethodPost, body: `{"name": "OrderPlaced"}`, wantStatus: http.StatusAccepted, wantLogs: 1},
+		"Array":        {method: http.MethodPost, body: `[{"name": "a"}, {"name": "b", "metrics": {"Amount": 1}}]`, wantStatus: http.StatusAccepted, wantLogs: 2},
+		"Get":          {method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+		"InvalidJSON":  {method: http.MethodPost, body: `{"name": `, wantStatus: http.StatusBadRequest},
+		"Empty":        {method: http.MethodPost, body: `[]`, wantStatus: http.StatusBadRequest},
+		"OneInvalid":   {method: http.MethodPost, body: `[{"name": "a"}, {"name": ""}]`, wantStatus: http.StatusBadRequest},
+		"TooLarge":     {method: http.MethodPost, body: `{"name": "` + strings.Repeat("a", maxRequestSize) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+		"ConsumeError": {method: http.MethodPost, body: `{"name": "a"}`, consumeErr: errors.New("full"), wantStatus: http.StatusServiceUnavailable},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sink := &consumertest.LogsSink{}
+			var next consumer.Logs = sink
+			if testCase.consumeErr != nil {
+				next = consumertest.NewErr(testCase.consumeErr)
+			}
+			r := newEventsReceiver(testConfig(), zap.NewNop(), next)
+			r.now = func() time.Time { return now }
+			req := httptest.NewRequest(testCase.method, eventsPath, strings.NewReader(testCase.body))
+			w := httptest.NewRecorder()
+			r.handleEvents(w, req)
+			assert.Equal(t, testCase.wantStatus, w.Code, w.Body.String())
+			assert.Equal(t, testCase.wantLogs, sink.LogRecordCount())
+		})
+	}
+}
+
+func TestReceiver(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	cfg := testConfig()
+	cfg.Endpoint = listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	sink := &consumertest.LogsSink{}
+	r, err := NewFactory().CreateLogs(context.Background(), receivertest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	resp, err := http.Post("http://"+cfg.Endpoint+eventsPath, "application/json", strings.NewReader(`{"name": "OrderPlaced"}`))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+type hostWithExtensions struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h hostWithExtensions) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestReceiverAuth(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	cfg := testConfig()
+	cfg.Endpoint = listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	authID := component.MustNewID("localauth")
+	cfg.Auth = &configauth.Authentication{AuthenticatorID: authID}
+	host := hostWithExtensions{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			authID: auth.NewServer(auth.WithServerAuthenticate(func(ctx context.Context, sources map[string][]string) (context.Context, error) {
+				if http.Header(sources).Get("Authorization") != "Bearer secret" {
+					return ctx, errors.New("invalid secret")
+				}
+				return ctx, nil
+			})),
+		},
+	}
+
+	sink := &consumertest.LogsSink{}
+	r, err := NewFactory().CreateLogs(context.Background(), receivertest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.Error(t, r.Start(context.Background(), componenttest.NewNopHost()), "the authenticator is missing")
+	require.NoError(t, r.Start(context.Background(), host))
+	defer func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	for token, wantStatus := range map[string]int{"": http.StatusUnauthorized, "Bearer other": http.StatusUnauthorized, "Bearer secret": http.StatusAccepted} {
+		req, err := http.NewRequest(http.MethodPost, "http://"+cfg.Endpoint+eventsPath, strings.NewReader(`{"name": "OrderPlaced"}`))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, wantStatus, resp.StatusCode, token)
+	}
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestToLogs(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	r := newEventsReceiver(testConfig(), zap.NewNop(), consumertest.NewNop())
+	r.now = func() time.Time { return now }
+	ld, err := r.toLogs([]event{{Name: "a", Timestamp: now.Add(-time.Minute).UnixMilli()}})
+	require.NoError(t, err)
+	record := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, now.Add(-time.Minute).UTC(), record.Timestamp().AsTime())
+	assert.Equal(t, now.UTC(), record.ObservedTimestamp().AsTime())
+	assert.JSONEq(t, `{"event": "a", "log_group_name": "/aws/events", "log_stream_name": "stream"}`, record.Body().Str())
+}
@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlpfilereceiver
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type Config struct {
+	// Directory is scanned for completed OTLP files written by the otlp_file exporter.
+	Directory string `mapstructure:"directory"`
+	// PollInterval is how often the directory is scanned for new files.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// KeepReplayed renames replayed files with a .replayed suffix instead of removing them.
+	KeepReplayed bool `mapstructure:"keep_replayed"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c.Directory == "" {
+		return errors.New("directory must be set")
+	}
+	if c.PollInterval <= 0 {
+		return errors.New("poll_interval must be greater than 0")
+	}
+	return nil
+}
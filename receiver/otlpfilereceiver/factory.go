@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlpfilereceiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/otlpfile"
+)
+
+const (
+	typeStr   = "otlp_file"
+	stability = component.StabilityLevelAlpha
+
+	defaultPollInterval = 10 * time.Second
+)
+
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		component.MustNewType(typeStr),
+		createDefaultConfig,
+		receiver.WithMetrics(createMetricsReceiver, stability),
+		receiver.WithTraces(createTracesReceiver, stability),
+		receiver.WithLogs(createLogsReceiver, stability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		PollInterval: defaultPollInterval,
+	}
+}
+
+func createMetricsReceiver(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+	unmarshaler := &pmetric.ProtoUnmarshaler{}
+	return newFileReceiver(set, cfg, otlpfile.SignalMetrics, func(ctx context.Context, payload []byte) error {
+		md, err := unmarshaler.UnmarshalMetrics(payload)
+		if err != nil {
+			return err
+		}
+		return next.ConsumeMetrics(ctx, md)
+	})
+}
+
+func createTracesReceiver(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+	unmarshaler := &ptrace.ProtoUnmarshaler{}
+	return newFileReceiver(set, cfg, otlpfile.SignalTraces, func(ctx context.Context, payload []byte) error {
+		td, err := unmarshaler.UnmarshalTraces(payload)
+		if err != nil {
+			return err
+		}
+		return next.ConsumeTraces(ctx, td)
+	})
+}
+
+func createLogsReceiver(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+	unmarshaler := &plog.ProtoUnmarshaler{}
+	return newFileReceiver(set, cfg, otlpfile.SignalLogs, func(ctx context.Context, payload []byte) error {
+		ld, err := unmarshaler.UnmarshalLogs(payload)
+		if err != nil {
+			return err
+		}
+		return next.ConsumeLogs(ctx, ld)
+	})
+}
+
+func newFileReceiver(set receiver.Settings, cfg component.Config, signal otlpfile.Signal, consume consumeFunc) (*fileReceiver, error) {
+	rCfg, ok := cfg.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid configuration type: %T", cfg)
+	}
+	return &fileReceiver{
+		config:   rCfg,
+		logger:   set.Logger,
+		signal:   signal,
+		consume:  consume,
+		progress: map[string]int{},
+	}, nil
+}
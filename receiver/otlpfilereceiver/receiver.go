@@ -0,0 +1,116 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlpfilereceiver
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/otlpfile"
+)
+
+const (
+	replayedExtension = ".replayed"
+	invalidExtension  = ".invalid"
+)
+
+type consumeFunc func(ctx context.Context, payload []byte) error
+
+// fileReceiver replays the files of one signal to the next consumer. Files are removed once every payload was
+// consumed. When the consumer fails, the file is retried on the next poll starting at the failed payload.
+type fileReceiver struct {
+	config  *Config
+	logger  *zap.Logger
+	signal  otlpfile.Signal
+	consume consumeFunc
+
+	// progress is the number of payloads already consumed per file.
+	progress map[string]int
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+var _ component.Component = (*fileReceiver)(nil)
+
+func (r *fileReceiver) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.run(ctx)
+	return nil
+}
+
+func (r *fileReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *fileReceiver) run(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		r.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *fileReceiver) poll(ctx context.Context) {
+	files, err := otlpfile.Files(r.config.Directory, r.signal)
+	if err != nil {
+		r.logger.Error("Unable to list OTLP files", zap.String("directory", r.config.Directory), zap.Error(err))
+		return
+	}
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return
+		}
+		if err = r.replay(ctx, file); err != nil {
+			// keep the order of the files, the rest is replayed after this one succeeds
+			r.logger.Warn("Unable to replay OTLP file, will retry", zap.String("file", file), zap.Error(err))
+			return
+		}
+	}
+}
+
+func (r *fileReceiver) replay(ctx context.Context, file string) error {
+	done := r.progress[file]
+	err := otlpfile.ReadFile(file, func(index int, payload []byte) error {
+		if index < done {
+			return nil
+		}
+		if err := r.consume(ctx, payload); err != nil {
+			return err
+		}
+		r.progress[file] = index + 1
+		return nil
+	})
+	if errors.Is(err, otlpfile.ErrInvalidFile) {
+		// set the file aside so it is not retried forever
+		r.logger.Error("Skipping invalid OTLP file", zap.String("file", file), zap.Error(err))
+		delete(r.progress, file)
+		return os.Rename(file, file+invalidExtension)
+	}
+	if err != nil {
+		return err
+	}
+	delete(r.progress, file)
+	if r.config.KeepReplayed {
+		return os.Rename(file, file+replayedExtension)
+	}
+	return os.Remove(file)
+}
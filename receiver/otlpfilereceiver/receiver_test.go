@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlpfilereceiver
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/otlpfile"
+)
+
+func writeMetrics(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	w, err := otlpfile.NewWriter(dir, otlpfile.SignalMetrics, 0, 0)
+	require.NoError(t, err)
+	for _, name := range names {
+		md := pmetric.NewMetrics()
+		md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName(name)
+		payload, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+		require.NoError(t, err)
+		require.NoError(t, w.Write(payload))
+	}
+	require.NoError(t, w.Close())
+}
+
+func TestReplayMetrics(t *testing.T) {
+	dir := t.TempDir()
+	writeMetrics(t, dir, "first", "second")
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Directory = dir
+	cfg.PollInterval = 10 * time.Millisecond
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.MetricsSink)
+	r, err := factory.CreateMetrics(context.Background(), receivertest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool { return len(sink.AllMetrics()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	assert.Equal(t, "first", sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	files, err := otlpfile.Files(dir, otlpfile.SignalMetrics)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestReplayResumesAfterFailure(t *testing.T) {
+	dir := t.TempDir()
+	writeMetrics(t, dir, "first", "second")
+	files, err := otlpfile.Files(dir, otlpfile.SignalMetrics)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	var consumed []int
+	fail := true
+	r := &fileReceiver{
+		config:   &Config{Directory: dir, PollInterval: time.Minute, KeepReplayed: true},
+		signal:   otlpfile.SignalMetrics,
+		progress: map[string]int{},
+		consume: func(_ context.Context, payload []byte) error {
+			if len(consumed) == 1 && fail {
+				fail = false
+				return errors.New("unavailable")
+			}
+			consumed = append(consumed, len(payload))
+			return nil
+		},
+	}
+	assert.Error(t, r.replay(context.Background(), files[0]))
+	assert.Len(t, consumed, 1)
+	assert.NoError(t, r.replay(context.Background(), files[0]))
+	// the first payload is not sent again
+	assert.Len(t, consumed, 2)
+	_, err = os.Stat(files[0] + replayedExtension)
+	assert.NoError(t, err)
+}
@@ -6440,6 +6440,113 @@ func (c *CloudWatchLogs) PutDestinationPolicyWithContext(ctx aws.Context, input
 	return out, req.Send()
 }
 
+const opPutIndexPolicy = "PutIndexPolicy"
+
+// PutIndexPolicyRequest generates a "aws/request.Request" representing the
+// client's request for the PutIndexPolicy operation. The "output" return
+// value will be populated with the request's response once the request completes
+// successfully.
+//
+// Use "Send" method on the returned Request to send the API call to the service.
+// the "output" return value is not valid until after Send returns without error.
+//
+// See PutIndexPolicy for more information on using the PutIndexPolicy
+// API call, and error handling.
+//
+// This method is useful when you want to inject custom logic or configuration
+// into the SDK's request lifecycle. Such as custom headers, or retry logic.
+//
+//	// Example sending a request using the PutIndexPolicyRequest method.
+//	req, resp := client.PutIndexPolicyRequest(params)
+//
+//	err := req.Send()
+//	if err == nil { // resp is now filled
+//	    fmt.Println(resp)
+//	}
+//
+// See also, https://docs.aws.amazon.com/goto/WebAPI/logs-2014-03-28/PutIndexPolicy
+func (c *CloudWatchLogs) PutIndexPolicyRequest(input *PutIndexPolicyInput) (req *request.Request, output *PutIndexPolicyOutput) {
+	op := &request.Operation{
+		Name:       opPutIndexPolicy,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+
+	if input == nil {
+		input = &PutIndexPolicyInput{}
+	}
+
+	output = &PutIndexPolicyOutput{}
+	req = c.newRequest(op, input, output)
+	return
+}
+
+// PutIndexPolicy API operation for Amazon CloudWatch Logs.
+//
+// Creates or updates a field index policy for the specified log group. Only
+// log groups in the Standard log class support field index policies.
+//
+// You can use field index policies to create field indexes on fields found
+// in log events in the log group. Creating field indexes speeds up and lowers
+// the costs for CloudWatch Logs Insights queries that reference those field
+// indexes, because these queries attempt to skip the processing of log events
+// that are known to not match the indexed field.
+//
+// To find the fields that are in your log group events, use the GetLogGroupFields
+// (https://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_GetLogGroupFields.html)
+// operation.
+//
+// Log group-level field index policies created with PutIndexPolicy override
+// account-level field index policies created with PutAccountPolicy (https://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_PutAccountPolicy.html).
+// If you use PutIndexPolicy to create a field index policy for a log group,
+// that log group uses only that policy.
+//
+// Returns awserr.Error for service API and SDK errors. Use runtime type assertions
+// with awserr.Error's Code and Message methods to get detailed information about
+// the error.
+//
+// See the AWS API reference guide for Amazon CloudWatch Logs's
+// API operation PutIndexPolicy for usage and error information.
+//
+// Returned Error Types:
+//
+//   - InvalidParameterException
+//     A parameter is specified incorrectly.
+//
+//   - LimitExceededException
+//     You have reached the maximum number of resources that can be created.
+//
+//   - OperationAbortedException
+//     Multiple concurrent requests to update the same resource were in conflict.
+//
+//   - ResourceNotFoundException
+//     The specified resource does not exist.
+//
+//   - ServiceUnavailableException
+//     The service cannot complete the request.
+//
+// See also, https://docs.aws.amazon.com/goto/WebAPI/logs-2014-03-28/PutIndexPolicy
+func (c *CloudWatchLogs) PutIndexPolicy(input *PutIndexPolicyInput) (*PutIndexPolicyOutput, error) {
+	req, out := c.PutIndexPolicyRequest(input)
+	return out, req.Send()
+}
+
+// PutIndexPolicyWithContext is the same as PutIndexPolicy with the addition of
+// the ability to pass a context and additional request options.
+//
+// See PutIndexPolicy for details on how to use this API operation.
+//
+// The context must be non-nil and will be used for request cancellation. If
+// the context is nil a panic will occur. In the future the SDK may create
+// sub-contexts for http.Requests. See https://golang.org/pkg/context/
+// for more information on using Contexts.
+func (c *CloudWatchLogs) PutIndexPolicyWithContext(ctx aws.Context, input *PutIndexPolicyInput, opts ...request.Option) (*PutIndexPolicyOutput, error) {
+	req, out := c.PutIndexPolicyRequest(input)
+	req.SetContext(ctx)
+	req.ApplyOptions(opts...)
+	return out, req.Send()
+}
+
 const opPutLogEvents = "PutLogEvents"
 
 // PutLogEventsRequest generates a "aws/request.Request" representing the
@@ -14723,6 +14830,77 @@ func (s *GetQueryResultsOutput) SetStatus(v string) *GetQueryResultsOutput {
 	return s
 }
 
+// This structure contains information about one field index policy in this
+// account.
+type IndexPolicy struct {
+	_ struct{} `type:"structure"`
+
+	// The date and time that this index policy was most recently updated.
+	LastUpdateTime *int64 `locationName:"lastUpdateTime" type:"long"`
+
+	// The ARN of the log group that this index policy applies to.
+	LogGroupIdentifier *string `locationName:"logGroupIdentifier" min:"1" type:"string"`
+
+	// The policy document for this index policy, in JSON format.
+	PolicyDocument *string `locationName:"policyDocument" min:"1" type:"string"`
+
+	// The name of this policy. Responses about log group-level field index policies
+	// don't have this field, because those policies don't have names.
+	PolicyName *string `locationName:"policyName" min:"1" type:"string"`
+
+	// This field indicates whether this is an account-level index policy or an
+	// index policy that applies only to a single log group.
+	Source *string `locationName:"source" type:"string" enum:"IndexSource"`
+}
+
+// String returns the string representation.
+//
+// API parameter values that are decorated as "sensitive" in the API will not
+// be included in the string output. The member name will be present, but the
+// value will be replaced with "sensitive".
+func (s IndexPolicy) String() string {
+	return awsutil.Prettify(s)
+}
+
+// GoString returns the string representation.
+//
+// API parameter values that are decorated as "sensitive" in the API will not
+// be included in the string output. The member name will be present, but the
+// value will be replaced with "sensitive".
+func (s IndexPolicy) GoString() string {
+	return s.String()
+}
+
+// SetLastUpdateTime sets the LastUpdateTime field's value.
+func (s *IndexPolicy) SetLastUpdateTime(v int64) *IndexPolicy {
+	s.LastUpdateTime = &v
+	return s
+}
+
+// SetLogGroupIdentifier sets the LogGroupIdentifier field's value.
+func (s *IndexPolicy) SetLogGroupIdentifier(v string) *IndexPolicy {
+	s.LogGroupIdentifier = &v
+	return s
+}
+
+// SetPolicyDocument sets the PolicyDocument field's value.
+func (s *IndexPolicy) SetPolicyDocument(v string) *IndexPolicy {
+	s.PolicyDocument = &v
+	return s
+}
+
+// SetPolicyName sets the PolicyName field's value.
+func (s *IndexPolicy) SetPolicyName(v string) *IndexPolicy {
+	s.PolicyName = &v
+	return s
+}
+
+// SetSource sets the Source field's value.
+func (s *IndexPolicy) SetSource(v string) *IndexPolicy {
+	s.Source = &v
+	return s
+}
+
 // Represents a log event, which is a record of activity that was recorded by
 // the application or resource being monitored.
 type InputLogEvent struct {
@@ -17581,6 +17759,112 @@ func (s PutDestinationPolicyOutput) GoString() string {
 	return s.String()
 }
 
+type PutIndexPolicyInput struct {
+	_ struct{} `type:"structure"`
+
+	// Specify either the log group name or log group ARN to apply this field index
+	// policy to. If you specify an ARN, use the format arn:aws:logs:region:account-id:log-group:log_group_name
+	// Don't include an * at the end.
+	//
+	// LogGroupIdentifier is a required field
+	LogGroupIdentifier *string `locationName:"logGroupIdentifier" min:"1" type:"string" required:"true"`
+
+	// The index policy document, in JSON format. The following is an example of
+	// an index policy document that creates two indexes, RequestId and TransactionId.
+	//
+	// "policyDocument": "{ "Fields": [ "RequestId", "TransactionId" ] }"
+	//
+	// The policy document must include at least one field index. For more information
+	// about the fields that can be included and other restrictions, see Field index
+	// syntax and quotas (https://docs.aws.amazon.com/AmazonCloudWatch/latest/logs/CloudWatchLogs-Field-Indexing-Syntax.html).
+	//
+	// PolicyDocument is a required field
+	PolicyDocument *string `locationName:"policyDocument" min:"1" type:"string" required:"true"`
+}
+
+// String returns the string representation.
+//
+// API parameter values that are decorated as "sensitive" in the API will not
+// be included in the string output. The member name will be present, but the
+// value will be replaced with "sensitive".
+func (s PutIndexPolicyInput) String() string {
+	return awsutil.Prettify(s)
+}
+
+// GoString returns the string representation.
+//
+// API parameter values that are decorated as "sensitive" in the API will not
+// be included in the string output. The member name will be present, but the
+// value will be replaced with "sensitive".
+func (s PutIndexPolicyInput) GoString() string {
+	return s.String()
+}
+
+// Validate inspects the fields of the type to determine if they are valid.
+func (s *PutIndexPolicyInput) Validate() error {
+	invalidParams := request.ErrInvalidParams{Context: "PutIndexPolicyInput"}
+	if s.LogGroupIdentifier == nil {
+		invalidParams.Add(request.NewErrParamRequired("LogGroupIdentifier"))
+	}
+	if s.LogGroupIdentifier != nil && len(*s.LogGroupIdentifier) < 1 {
+		invalidParams.Add(request.NewErrParamMinLen("LogGroupIdentifier", 1))
+	}
+	if s.PolicyDocument == nil {
+		invalidParams.Add(request.NewErrParamRequired("PolicyDocument"))
+	}
+	if s.PolicyDocument != nil && len(*s.PolicyDocument) < 1 {
+		invalidParams.Add(request.NewErrParamMinLen("PolicyDocument", 1))
+	}
+
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+// SetLogGroupIdentifier sets the LogGroupIdentifier field's value.
+func (s *PutIndexPolicyInput) SetLogGroupIdentifier(v string) *PutIndexPolicyInput {
+	s.LogGroupIdentifier = &v
+	return s
+}
+
+// SetPolicyDocument sets the PolicyDocument field's value.
+func (s *PutIndexPolicyInput) SetPolicyDocument(v string) *PutIndexPolicyInput {
+	s.PolicyDocument = &v
+	return s
+}
+
+type PutIndexPolicyOutput struct {
+	_ struct{} `type:"structure"`
+
+	// The index policy that you just created or updated.
+	IndexPolicy *IndexPolicy `locationName:"indexPolicy" type:"structure"`
+}
+
+// String returns the string representation.
+//
+// API parameter values that are decorated as "sensitive" in the API will not
+// be included in the string output. The member name will be present, but the
+// value will be replaced with "sensitive".
+func (s PutIndexPolicyOutput) String() string {
+	return awsutil.Prettify(s)
+}
+
+// GoString returns the string representation.
+//
+// API parameter values that are decorated as "sensitive" in the API will not
+// be included in the string output. The member name will be present, but the
+// value will be replaced with "sensitive".
+func (s PutIndexPolicyOutput) GoString() string {
+	return s.String()
+}
+
+// SetIndexPolicy sets the IndexPolicy field's value.
+func (s *PutIndexPolicyOutput) SetIndexPolicy(v *IndexPolicy) *PutIndexPolicyOutput {
+	s.IndexPolicy = v
+	return s
+}
+
 type PutLogEventsInput struct {
 	_ struct{} `type:"structure"`
 
@@ -21197,6 +21481,22 @@ func ExportTaskStatusCode_Values() []string {
 	}
 }
 
+const (
+	// IndexSourceAccount is a IndexSource enum value
+	IndexSourceAccount = "ACCOUNT"
+
+	// IndexSourceLogGroup is a IndexSource enum value
+	IndexSourceLogGroup = "LOG_GROUP"
+)
+
+// IndexSource_Values returns all elements of the IndexSource enum
+func IndexSource_Values() []string {
+	return []string{
+		IndexSourceAccount,
+		IndexSourceLogGroup,
+	}
+}
+
 const (
 	// InheritedPropertyAccountDataProtection is a InheritedProperty enum value
 	InheritedPropertyAccountDataProtection = "ACCOUNT_DATA_PROTECTION"
@@ -329,6 +329,10 @@ type CloudWatchLogsAPI interface {
 	PutDestinationPolicyWithContext(aws.Context, *cloudwatchlogs.PutDestinationPolicyInput, ...request.Option) (*cloudwatchlogs.PutDestinationPolicyOutput, error)
 	PutDestinationPolicyRequest(*cloudwatchlogs.PutDestinationPolicyInput) (*request.Request, *cloudwatchlogs.PutDestinationPolicyOutput)
 
+	PutIndexPolicy(*cloudwatchlogs.PutIndexPolicyInput) (*cloudwatchlogs.PutIndexPolicyOutput, error)
+	PutIndexPolicyWithContext(aws.Context, *cloudwatchlogs.PutIndexPolicyInput, ...request.Option) (*cloudwatchlogs.PutIndexPolicyOutput, error)
+	PutIndexPolicyRequest(*cloudwatchlogs.PutIndexPolicyInput) (*request.Request, *cloudwatchlogs.PutIndexPolicyOutput)
+
 	PutLogEvents(*cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error)
 	PutLogEventsWithContext(aws.Context, *cloudwatchlogs.PutLogEventsInput, ...request.Option) (*cloudwatchlogs.PutLogEventsOutput, error)
 	PutLogEventsRequest(*cloudwatchlogs.PutLogEventsInput) (*request.Request, *cloudwatchlogs.PutLogEventsOutput)
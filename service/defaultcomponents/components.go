@@ -7,6 +7,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awscloudwatchlogsexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/awsproxy"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
@@ -55,16 +56,33 @@ import (
 	"go.opentelemetry.io/collector/receiver/nopreceiver"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver"
 
+	"github.com/aws/amazon-cloudwatch-agent/exporter/otlpfileexporter"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth"
 	"github.com/aws/amazon-cloudwatch-agent/extension/entitystore"
+	"github.com/aws/amazon-cloudwatch-agent/extension/faultinjection"
+	"github.com/aws/amazon-cloudwatch-agent/extension/featureflags"
+	"github.com/aws/amazon-cloudwatch-agent/extension/localauth"
 	"github.com/aws/amazon-cloudwatch-agent/extension/server"
+	"github.com/aws/amazon-cloudwatch-agent/extension/sharding"
+	"github.com/aws/amazon-cloudwatch-agent/extension/standby"
+	"github.com/aws/amazon-cloudwatch-agent/extension/xraysampling"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatch"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/emfcoverage"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/emfvalidator"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/externalprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/gpuattributes"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/kueueattributes"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/logsdestination"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/namespaceguard"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/outoforder"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/spanlimits"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/wasmprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/processor/rollupprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/receiver/eventsreceiver"
+	"github.com/aws/amazon-cloudwatch-agent/receiver/otlpfilereceiver"
 )
 
 func Factories() (otelcol.Factories, error) {
@@ -76,12 +94,14 @@ func Factories() (otelcol.Factories, error) {
 		awscontainerinsightskueuereceiver.NewFactory(),
 		awsecscontainermetricsreceiver.NewFactory(),
 		awsxrayreceiver.NewFactory(),
+		eventsreceiver.NewFactory(),
 		filelogreceiver.NewFactory(),
 		jaegerreceiver.NewFactory(),
 		jmxreceiver.NewFactory(),
 		kafkareceiver.NewFactory(),
 		nopreceiver.NewFactory(),
 		otlpreceiver.NewFactory(),
+		otlpfilereceiver.NewFactory(),
 		prometheusreceiver.NewFactory(),
 		statsdreceiver.NewFactory(),
 		tcplogreceiver.NewFactory(),
@@ -100,9 +120,15 @@ func Factories() (otelcol.Factories, error) {
 		deltatocumulativeprocessor.NewFactory(),
 		deltatorateprocessor.NewFactory(),
 		ec2tagger.NewFactory(),
+		emfcoverage.NewFactory(),
+		emfvalidator.NewFactory(),
+		externalprocessor.NewFactory(),
 		filterprocessor.NewFactory(),
 		gpuattributes.NewFactory(),
 		kueueattributes.NewFactory(),
+		logsdestination.NewFactory(),
+		namespaceguard.NewFactory(),
+		outoforder.NewFactory(),
 		groupbytraceprocessor.NewFactory(),
 		k8sattributesprocessor.NewFactory(),
 		memorylimiterprocessor.NewFactory(),
@@ -113,8 +139,10 @@ func Factories() (otelcol.Factories, error) {
 		resourcedetectionprocessor.NewFactory(),
 		rollupprocessor.NewFactory(),
 		spanprocessor.NewFactory(),
+		spanlimits.NewFactory(),
 		tailsamplingprocessor.NewFactory(),
 		transformprocessor.NewFactory(),
+		wasmprocessor.NewFactory(),
 	); err != nil {
 		return otelcol.Factories{}, err
 	}
@@ -125,7 +153,9 @@ func Factories() (otelcol.Factories, error) {
 		awsxrayexporter.NewFactory(),
 		cloudwatch.NewFactory(),
 		debugexporter.NewFactory(),
+		loadbalancingexporter.NewFactory(),
 		nopexporter.NewFactory(),
+		otlpfileexporter.NewFactory(),
 		prometheusremotewriteexporter.NewFactory(),
 	); err != nil {
 		return otelcol.Factories{}, err
@@ -135,7 +165,13 @@ func Factories() (otelcol.Factories, error) {
 		agenthealth.NewFactory(),
 		awsproxy.NewFactory(),
 		entitystore.NewFactory(),
+		faultinjection.NewFactory(),
+		featureflags.NewFactory(),
+		localauth.NewFactory(),
 		server.NewFactory(),
+		sharding.NewFactory(),
+		standby.NewFactory(),
+		xraysampling.NewFactory(),
 		ecsobserver.NewFactory(),
 		filestorage.NewFactory(),
 		healthcheckextension.NewFactory(),
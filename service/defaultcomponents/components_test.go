@@ -21,12 +21,14 @@ func TestComponents(t *testing.T) {
 		"awscontainerinsightskueuereceiver",
 		"awsecscontainermetrics",
 		"awsxray",
+		"events",
 		"filelog",
 		"jaeger",
 		"jmx",
 		"kafka",
 		"nop",
 		"otlp",
+		"otlp_file",
 		"prometheus",
 		"statsd",
 		"tcplog",
@@ -48,10 +50,16 @@ func TestComponents(t *testing.T) {
 		"deltatocumulative",
 		"deltatorate",
 		"ec2tagger",
+		"emfcoverage",
+		"emfvalidator",
+		"externalprocessor",
 		"metricsgeneration",
 		"filter",
 		"gpuattributes",
 		"kueueattributes",
+		"logsdestination",
+		"namespaceguard",
+		"outoforder",
 		"groupbytrace",
 		"k8sattributes",
 		"memory_limiter",
@@ -61,8 +69,10 @@ func TestComponents(t *testing.T) {
 		"rollup",
 		"probabilistic_sampler",
 		"span",
+		"spanlimits",
 		"tail_sampling",
 		"transform",
+		"wasmprocessor",
 	}
 	gotProcessors := collections.MapSlice(maps.Keys(factories.Processors), component.Type.String)
 	assert.Equal(t, len(wantProcessors), len(gotProcessors))
@@ -76,7 +86,9 @@ func TestComponents(t *testing.T) {
 		"awscloudwatch",
 		"awsxray",
 		"debug",
+		"loadbalancing",
 		"nop",
+		"otlp_file",
 		"prometheusremotewrite",
 	}
 	gotExporters := collections.MapSlice(maps.Keys(factories.Exporters), component.Type.String)
@@ -90,11 +102,17 @@ func TestComponents(t *testing.T) {
 		"awsproxy",
 		"ecs_observer",
 		"entitystore",
+		"faultinjection",
+		"featureflags",
 		"file_storage",
 		"health_check",
+		"localauth",
 		"pprof",
 		"server",
+		"sharding",
 		"sigv4auth",
+		"standby",
+		"xraysampling",
 		"zpages",
 	}
 	gotExtensions := collections.MapSlice(maps.Keys(factories.Extensions), component.Type.String)
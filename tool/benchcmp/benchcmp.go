@@ -0,0 +1,203 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Command benchcmp compares two outputs of go test -bench and fails if any benchmark got slower or allocates more
+// than the threshold. Each benchmark should be run several times with -count, the median of the runs is compared.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// units are the metrics the regression gate applies to, as reported with -benchmem.
+var units = []string{"ns/op", "B/op", "allocs/op"}
+
+type key struct {
+	pkg, name string
+}
+
+// results are the values of each unit across the runs of a benchmark.
+type results map[key]map[string][]float64
+
+type comparison struct {
+	key
+	unit     string
+	old, new float64
+	delta    float64
+}
+
+func main() {
+	threshold := flag.Float64("threshold", 10, "percentage increase of a benchmark that fails the comparison")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: benchcmp [-threshold percent] old.txt new.txt\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	old, err := parseFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	current, err := parseFile(flag.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if regressions := compare(os.Stdout, old, current, *threshold); regressions > 0 {
+		fmt.Printf("\n%d benchmark results regressed by more than %.1f%%\n", regressions, *threshold)
+		os.Exit(1)
+	}
+}
+
+func parseFile(path string) (results, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parse(f)
+}
+
+// parse reads the benchmark lines, e.g.
+//
+//	BenchmarkProcessMetrics-8   	     100	   4344829 ns/op	  752662 B/op	   17500 allocs/op
+//
+// and keys them by the package given by the preceding pkg: line. Other lines are ignored.
+func parse(r io.Reader) (results, error) {
+	res := results{}
+	var pkg string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if p, ok := strings.CutPrefix(line, "pkg: "); ok {
+			pkg = strings.TrimSpace(p)
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+		k := key{pkg: pkg, name: trimProcs(fields[0])}
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q of %s: %w", fields[i], fields[0], err)
+			}
+			if res[k] == nil {
+				res[k] = map[string][]float64{}
+			}
+			res[k][fields[i+1]] = append(res[k][fields[i+1]], value)
+		}
+	}
+	return res, scanner.Err()
+}
+
+// trimProcs removes the GOMAXPROCS suffix, so that results from hosts with a different number of CPUs can be compared.
+func trimProcs(name string) string {
+	if i := strings.LastIndexByte(name, '-'); i > 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			return name[:i]
+		}
+	}
+	return name
+}
+
+// compare writes the medians of the benchmarks in both results and returns the number that regressed.
+func compare(w io.Writer, old, current results, threshold float64) int {
+	var comparisons []comparison
+	var missing []key
+	for k, oldValues := range old {
+		newValues, ok := current[k]
+		if !ok {
+			missing = append(missing, k)
+			continue
+		}
+		for _, unit := range units {
+			if len(oldValues[unit]) == 0 || len(newValues[unit]) == 0 {
+				continue
+			}
+			c := comparison{key: k, unit: unit, old: median(oldValues[unit]), new: median(newValues[unit])}
+			if c.old != 0 {
+				c.delta = (c.new - c.old) / c.old * 100
+			} else if c.new != 0 {
+				c.delta = 100
+			}
+			comparisons = append(comparisons, c)
+		}
+	}
+	sort.Slice(comparisons, func(i, j int) bool {
+		if comparisons[i].key != comparisons[j].key {
+			return less(comparisons[i].key, comparisons[j].key)
+		}
+		return unitIndex(comparisons[i].unit) < unitIndex(comparisons[j].unit)
+	})
+
+	regressions := 0
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "package\tbenchmark\tunit\told\tnew\tdelta\t")
+	for _, c := range comparisons {
+		status := ""
+		if c.delta > threshold {
+			status = "REGRESSION"
+			regressions++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.0f\t%.0f\t%+.1f%%\t%s\n", c.pkg, c.name, c.unit, c.old, c.new, c.delta, status)
+	}
+	_ = tw.Flush()
+
+	var added []key
+	for k := range current {
+		if _, ok := old[k]; !ok {
+			added = append(added, k)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool { return less(added[i], added[j]) })
+	for _, k := range added {
+		fmt.Fprintf(w, "new benchmark without baseline: %s %s\n", k.pkg, k.name)
+	}
+	sort.Slice(missing, func(i, j int) bool { return less(missing[i], missing[j]) })
+	for _, k := range missing {
+		fmt.Fprintf(w, "benchmark missing from the new results: %s %s\n", k.pkg, k.name)
+	}
+	return regressions
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func less(a, b key) bool {
+	if a.pkg != b.pkg {
+		return a.pkg < b.pkg
+	}
+	return a.name < b.name
+}
+
+func unitIndex(unit string) int {
+	for i, u := range units {
+		if u == unit {
+			return i
+		}
+	}
+	return len(units)
+}
@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oldOutput = `goos: linux
+goarch: amd64
+pkg: github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkProcessMetrics-8   	     200	    130000 ns/op	  102400 B/op	    1100 allocs/op
+BenchmarkProcessMetrics-8   	     200	    120000 ns/op	  102400 B/op	    1100 allocs/op
+BenchmarkProcessMetrics-8   	     200	    900000 ns/op	  102400 B/op	    1100 allocs/op
+PASS
+pkg: github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher
+BenchmarkLogEventBatch/Ordered-8         	     100	    180000 ns/op	   75152 B/op	    3025 allocs/op
+BenchmarkLogEventBatch/Unordered-8       	     100	    330000 ns/op	   75176 B/op	    3026 allocs/op
+BenchmarkRemoved-8       	     100	    1 ns/op
+ok  	github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher	0.078s
+`
+
+const newOutput = `pkg: github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger
+BenchmarkProcessMetrics-16   	     200	    135000 ns/op	  102400 B/op	    1100 allocs/op
+BenchmarkProcessMetrics-16   	     200	    125000 ns/op	  102400 B/op	    1100 allocs/op
+pkg: github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher
+BenchmarkLogEventBatch/Ordered-16         	     100	    170000 ns/op	   75152 B/op	    3025 allocs/op
+BenchmarkLogEventBatch/Unordered-16       	     100	    400000 ns/op	   90000 B/op	    3026 allocs/op
+BenchmarkAdded-16       	     100	    1 ns/op
+`
+
+func TestParse(t *testing.T) {
+	res, err := parse(strings.NewReader(oldOutput))
+	require.NoError(t, err)
+	assert.Len(t, res, 4)
+	values := res[key{pkg: "github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger", name: "BenchmarkProcessMetrics"}]
+	assert.Equal(t, []float64{130000, 120000, 900000}, values["ns/op"])
+	assert.Equal(t, []float64{1100, 1100, 1100}, values["allocs/op"])
+	assert.Equal(t, 125000.0, median([]float64{130000, 120000}))
+	assert.Equal(t, 130000.0, median(values["ns/op"]))
+
+	_, err = parse(strings.NewReader("BenchmarkInvalid-8 100 fast ns/op\n"))
+	assert.ErrorContains(t, err, `invalid value "fast"`)
+}
+
+func TestTrimProcs(t *testing.T) {
+	assert.Equal(t, "BenchmarkTranslate/standard_config_linux", trimProcs("BenchmarkTranslate/standard_config_linux-8"))
+	assert.Equal(t, "BenchmarkLogEventBatch/Ordered", trimProcs("BenchmarkLogEventBatch/Ordered"))
+	assert.Equal(t, "BenchmarkTranslate/config-linux", trimProcs("BenchmarkTranslate/config-linux"))
+}
+
+func TestCompare(t *testing.T) {
+	old, err := parse(strings.NewReader(oldOutput))
+	require.NoError(t, err)
+	current, err := parse(strings.NewReader(newOutput))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	assert.Equal(t, 2, compare(&out, old, current, 10))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 12)
+	assert.Contains(t, lines[0], "benchmark")
+	assert.Regexp(t, `Ordered\s+ns/op\s+180000\s+170000\s+-5\.6%\s*$`, lines[1])
+	assert.Regexp(t, `Unordered\s+ns/op\s+330000\s+400000\s+\+21\.2%\s+REGRESSION`, lines[4])
+	assert.Regexp(t, `Unordered\s+B/op\s+75176\s+90000\s+\+19\.7%\s+REGRESSION`, lines[5])
+	// the median ignores the outlier of the baseline
+	assert.Regexp(t, `ProcessMetrics\s+ns/op\s+130000\s+130000\s+\+0\.0%\s*$`, lines[7])
+	assert.Equal(t, "new benchmark without baseline: github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher BenchmarkAdded", lines[10])
+	assert.Equal(t, "benchmark missing from the new results: github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher BenchmarkRemoved", lines[11])
+
+	out.Reset()
+	assert.Equal(t, 0, compare(&out, old, current, 25))
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package datadogmigration
+
+// checkConverter adds the plugins that collect the metrics of the check instance and returns them, so that the interval
+// and dimensions of the instance can be set on them.
+type checkConverter func(c *converter, source string, instance map[string]any) []map[string]any
+
+// checkConverters are the Datadog checks with an equivalent in the CloudWatch agent.
+var checkConverters = map[string]checkConverter{
+	"cpu": func(c *converter, source string, instance map[string]any) []map[string]any {
+		c.reportKeys(source, "cpu", instance)
+		return []map[string]any{c.addPlugin("cpu", map[string]any{
+			"measurement": []any{"usage_idle", "usage_iowait", "usage_steal", "usage_system", "usage_user"},
+			"totalcpu":    true,
+		})}
+	},
+	"disk": func(c *converter, source string, instance map[string]any) []map[string]any {
+		plugin := map[string]any{
+			"measurement": []any{"used_percent", "inodes_free"},
+			"resources":   []any{"*"},
+		}
+		if fileSystems, ok := instance["file_system_exclude"].([]any); ok {
+			plugin["ignore_file_system_types"] = fileSystems
+		}
+		c.reportKeys(source, "disk", instance, "file_system_exclude")
+		return []map[string]any{c.addPlugin("disk", plugin)}
+	},
+	"io": func(c *converter, source string, instance map[string]any) []map[string]any {
+		c.reportKeys(source, "io", instance)
+		return []map[string]any{c.addPlugin("diskio", map[string]any{
+			"measurement": []any{"io_time", "read_bytes", "write_bytes", "reads", "writes"},
+			"resources":   []any{"*"},
+		})}
+	},
+	"memory": func(c *converter, source string, instance map[string]any) []map[string]any {
+		c.reportKeys(source, "memory", instance)
+		return []map[string]any{
+			c.addPlugin("mem", map[string]any{"measurement": []any{"mem_used_percent"}}),
+			c.addPlugin("swap", map[string]any{"measurement": []any{"swap_used_percent"}}),
+		}
+	},
+	"network": func(c *converter, source string, instance map[string]any) []map[string]any {
+		c.reportKeys(source, "network", instance)
+		return []map[string]any{
+			c.addPlugin("net", map[string]any{
+				"measurement": []any{"bytes_sent", "bytes_recv", "packets_sent", "packets_recv"},
+				"resources":   []any{"*"},
+			}),
+			c.addPlugin("netstat", map[string]any{"measurement": []any{"tcp_established", "tcp_time_wait"}}),
+		}
+	},
+	"nginx":   processCheck("nginx", "nginx", "nginx_status_url"),
+	"redisdb": processCheck("redisdb", "redis-server", "host", "port", "password"),
+}
+
+// processCheck monitors the processes of the integration with procstat, since the CloudWatch agent does not collect
+// the metrics of the integration itself.
+func processCheck(check, exe string, keys ...string) checkConverter {
+	return func(c *converter, source string, instance map[string]any) []map[string]any {
+		c.unsupported = append(c.unsupported, source+": the metrics of the integration are not supported, its processes are monitored with procstat instead")
+		c.reportKeys(source, check, instance, keys...)
+		plugin := map[string]any{
+			"exe":         exe,
+			"measurement": []any{"cpu_usage", "memory_rss", "pid_count"},
+		}
+		c.procstat = append(c.procstat, plugin)
+		return []map[string]any{plugin}
+	}
+}
+
+func (c *converter) addPlugin(name string, plugin map[string]any) map[string]any {
+	c.metrics[name] = plugin
+	return plugin
+}
+
+// reportKeys reports the keys of the instance other than the ones handled for every check and the known keys.
+func (c *converter) reportKeys(source, check string, instance map[string]any, known ...string) {
+	for _, key := range sortedKeys(instance) {
+		if key != "min_collection_interval" && key != "tags" && !contains(known, key) {
+			c.report(source, check+" "+key)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// encodings maps the log encodings of the Datadog agent to the ones of the CloudWatch agent.
+var encodings = map[string]string{
+	"utf-16-le": "utf-16le",
+	"utf-16-be": "utf-16be",
+	"shift-jis": "shift_jis",
+}
@@ -0,0 +1,302 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package datadogmigration converts the config of the Datadog agent into a best-effort config of the CloudWatch agent.
+// Whatever has no equivalent in the CloudWatch agent is listed in the result instead.
+package datadogmigration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultConfigDir is where the Datadog agent is configured on Linux.
+	DefaultConfigDir = "/etc/datadog-agent"
+
+	mainConfigFile    = "datadog.yaml"
+	checksDir         = "conf.d"
+	defaultDogStatsD  = 8125
+	checkConfigFile   = "conf.yaml"
+	defaultConfigFile = "conf.yaml.default"
+)
+
+// ignoredKeys are the keys of datadog.yaml that only concern the connection to Datadog.
+var ignoredKeys = map[string]bool{
+	"api_key": true,
+	"app_key": true,
+	"site":    true,
+	"dd_url":  true,
+}
+
+// Result is the converted config and the items of the Datadog config that were not converted.
+type Result struct {
+	Config      map[string]any
+	Unsupported []string
+}
+
+type converter struct {
+	dimensions  map[string]any
+	env         string
+	logsEnabled bool
+	metrics     map[string]any
+	procstat    []any
+	files       []any
+	unsupported []string
+}
+
+// Convert reads datadog.yaml and the checks in conf.d of the config dir.
+func Convert(configDir string) (*Result, error) {
+	c := &converter{dimensions: map[string]any{}, metrics: map[string]any{}}
+	mainConfig, err := readYAML(filepath.Join(configDir, mainConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	c.convertMainConfig(mainConfig)
+	checkFiles, err := findChecks(filepath.Join(configDir, checksDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, checkFile := range checkFiles {
+		check, err := readYAML(checkFile.path)
+		if err != nil {
+			return nil, err
+		}
+		source, _ := filepath.Rel(configDir, checkFile.path)
+		c.convertCheck(checkFile.name, filepath.ToSlash(source), check)
+	}
+	return &Result{Config: c.config(), Unsupported: c.unsupported}, nil
+}
+
+func (c *converter) convertMainConfig(mainConfig map[string]any) {
+	c.logsEnabled, _ = mainConfig["logs_enabled"].(bool)
+	c.env, _ = mainConfig["env"].(string)
+	if tags, ok := mainConfig["tags"]; ok {
+		c.addDimensions(c.dimensions, mainConfigFile, tags)
+	}
+	useDogStatsD, ok := mainConfig["use_dogstatsd"].(bool)
+	if !ok || useDogStatsD {
+		port := defaultDogStatsD
+		if p, ok := mainConfig["dogstatsd_port"].(int); ok {
+			port = p
+		}
+		address := fmt.Sprintf("127.0.0.1:%d", port)
+		if nonLocal, _ := mainConfig["dogstatsd_non_local_traffic"].(bool); nonLocal {
+			address = fmt.Sprintf(":%d", port)
+		}
+		statsd := map[string]any{"service_address": address}
+		if c.env != "" {
+			statsd["deployment.environment"] = c.env
+		}
+		c.metrics["statsd"] = statsd
+	}
+	for _, key := range sortedKeys(mainConfig) {
+		switch key {
+		case "logs_enabled", "env", "tags", "use_dogstatsd", "dogstatsd_port", "dogstatsd_non_local_traffic":
+		default:
+			if !ignoredKeys[key] {
+				c.report(mainConfigFile, key)
+			}
+		}
+	}
+}
+
+// convertCheck converts the instances of a check into metrics and its logs into log files.
+func (c *converter) convertCheck(name, source string, check map[string]any) {
+	if logs, ok := check["logs"].([]any); ok {
+		c.convertLogs(source, logs)
+	}
+	instances, _ := check["instances"].([]any)
+	if len(instances) == 0 {
+		return
+	}
+	instance, _ := instances[0].(map[string]any)
+	if len(instances) > 1 {
+		c.report(source, "instances after the first")
+	}
+	convert, ok := checkConverters[name]
+	if !ok {
+		c.unsupported = append(c.unsupported, fmt.Sprintf("%s: check %s is not supported", source, name))
+		return
+	}
+	dimensions := map[string]any{}
+	for k, v := range c.dimensions {
+		dimensions[k] = v
+	}
+	if tags, ok := instance["tags"]; ok {
+		c.addDimensions(dimensions, source, tags)
+	}
+	plugins := convert(c, source, instance)
+	for _, plugin := range plugins {
+		if interval, ok := instance["min_collection_interval"].(int); ok && interval > 0 {
+			plugin["metrics_collection_interval"] = interval
+		}
+		if len(dimensions) > 0 {
+			plugin["append_dimensions"] = dimensions
+		}
+	}
+}
+
+func (c *converter) convertLogs(source string, logs []any) {
+	if !c.logsEnabled {
+		c.unsupported = append(c.unsupported, fmt.Sprintf("%s: logs are not collected, logs_enabled is not set in %s", source, mainConfigFile))
+		return
+	}
+	for _, entry := range logs {
+		logConfig, _ := entry.(map[string]any)
+		logType, _ := logConfig["type"].(string)
+		path, _ := logConfig["path"].(string)
+		if logType != "file" || path == "" {
+			c.unsupported = append(c.unsupported, fmt.Sprintf("%s: logs of type %s are not supported", source, logType))
+			continue
+		}
+		file := map[string]any{"file_path": path}
+		if service, ok := logConfig["service"].(string); ok && service != "" {
+			file["service.name"] = service
+		}
+		if c.env != "" {
+			file["deployment.environment"] = c.env
+		}
+		if encoding, ok := logConfig["encoding"].(string); ok {
+			if normalized, ok := encodings[encoding]; ok {
+				file["encoding"] = normalized
+			} else {
+				c.report(source, "encoding "+encoding)
+			}
+		}
+		rules, _ := logConfig["log_processing_rules"].([]any)
+		var filters []any
+		for _, r := range rules {
+			rule, _ := r.(map[string]any)
+			ruleType, _ := rule["type"].(string)
+			pattern, _ := rule["pattern"].(string)
+			switch ruleType {
+			case "include_at_match":
+				filters = append(filters, map[string]any{"type": "include", "expression": pattern})
+			case "exclude_at_match":
+				filters = append(filters, map[string]any{"type": "exclude", "expression": pattern})
+			case "multi_line":
+				file["multi_line_start_pattern"] = pattern
+			default:
+				c.report(source, "log_processing_rules of type "+ruleType)
+			}
+		}
+		if len(filters) > 0 {
+			file["filters"] = filters
+		}
+		for _, key := range sortedKeys(logConfig) {
+			switch key {
+			case "type", "path", "service", "source", "encoding", "log_processing_rules":
+			default:
+				c.report(source, "logs "+key)
+			}
+		}
+		c.files = append(c.files, file)
+	}
+}
+
+func (c *converter) addDimensions(dimensions map[string]any, source string, tags any) {
+	var values []string
+	switch t := tags.(type) {
+	case []any:
+		for _, tag := range t {
+			values = append(values, fmt.Sprint(tag))
+		}
+	case string:
+		values = strings.FieldsFunc(t, func(r rune) bool { return r == ',' || r == ' ' })
+	}
+	for _, tag := range values {
+		key, value, ok := strings.Cut(tag, ":")
+		if !ok || key == "" || value == "" {
+			c.report(source, "tag "+tag)
+			continue
+		}
+		dimensions[key] = value
+	}
+}
+
+func (c *converter) report(source, item string) {
+	c.unsupported = append(c.unsupported, fmt.Sprintf("%s: %s is not supported", source, item))
+}
+
+func (c *converter) config() map[string]any {
+	config := map[string]any{}
+	if len(c.procstat) > 0 {
+		c.metrics["procstat"] = c.procstat
+	}
+	if len(c.metrics) > 0 {
+		config["metrics"] = map[string]any{"metrics_collected": c.metrics}
+	}
+	if len(c.files) > 0 {
+		config["logs"] = map[string]any{
+			"logs_collected": map[string]any{
+				"files": map[string]any{"collect_list": c.files},
+			},
+		}
+	}
+	return config
+}
+
+type checkFile struct {
+	name, path string
+}
+
+// findChecks returns the config of each check in conf.d, either <check>.d/conf.yaml, the conf.yaml.default the Datadog
+// agent falls back to, or <check>.yaml.
+func findChecks(dir string) ([]checkFile, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var checkFiles []checkFile
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			if name, ok := strings.CutSuffix(entry.Name(), ".yaml"); ok {
+				checkFiles = append(checkFiles, checkFile{name: name, path: filepath.Join(dir, entry.Name())})
+			}
+			continue
+		}
+		name, ok := strings.CutSuffix(entry.Name(), ".d")
+		if !ok {
+			continue
+		}
+		for _, file := range []string{checkConfigFile, defaultConfigFile} {
+			path := filepath.Join(dir, entry.Name(), file)
+			if _, err = os.Stat(path); err == nil {
+				checkFiles = append(checkFiles, checkFile{name: name, path: path})
+				break
+			}
+		}
+	}
+	return checkFiles, nil
+}
+
+func readYAML(path string) (map[string]any, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	result := map[string]any{}
+	if err = yaml.Unmarshal(content, &result); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	return result, nil
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,69 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package datadogmigration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+)
+
+func TestConvert(t *testing.T) {
+	result, err := Convert("testdata")
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile(filepath.Join("testdata", "expected.json"))
+	require.NoError(t, err)
+	actual, err := json.Marshal(result.Config)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+
+	var config map[string]any
+	require.NoError(t, json.Unmarshal(actual, &config))
+	validation, err := cmdutil.RunSchemaValidation(config)
+	require.NoError(t, err)
+	assert.True(t, validation.Valid(), validation.Errors())
+
+	assert.Equal(t, []string{
+		"datadog.yaml: tag canary is not supported",
+		"datadog.yaml: apm_config is not supported",
+		"datadog.yaml: hostname is not supported",
+		"conf.d/disk.d/conf.yaml: disk use_mount is not supported",
+		"conf.d/load.d/conf.yaml.default: check load is not supported",
+		"conf.d/nginx.d/conf.yaml: log_processing_rules of type mask_sequences is not supported",
+		"conf.d/nginx.d/conf.yaml: logs start_position is not supported",
+		"conf.d/nginx.d/conf.yaml: the metrics of the integration are not supported, its processes are monitored with procstat instead",
+		"conf.d/redisdb.d/conf.yaml: logs of type journald are not supported",
+		"conf.d/redisdb.d/conf.yaml: the metrics of the integration are not supported, its processes are monitored with procstat instead",
+	}, result.Unsupported)
+}
+
+func TestConvertWithoutChecks(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, mainConfigFile), []byte("use_dogstatsd: false\n"), 0644))
+	result, err := Convert(dir)
+	require.NoError(t, err)
+	assert.Empty(t, result.Config)
+	assert.Empty(t, result.Unsupported)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, checksDir, "app.d"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, checksDir, "app.d", checkConfigFile), []byte("logs:\n  - type: file\n    path: /var/log/app.log\n"), 0644))
+	result, err = Convert(dir)
+	require.NoError(t, err)
+	assert.Empty(t, result.Config)
+	assert.Equal(t, []string{"conf.d/app.d/conf.yaml: logs are not collected, logs_enabled is not set in datadog.yaml"}, result.Unsupported)
+
+	_, err = Convert(t.TempDir())
+	assert.ErrorContains(t, err, "unable to read")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, mainConfigFile), []byte("tags: [\n"), 0644))
+	_, err = Convert(dir)
+	assert.ErrorContains(t, err, "unable to parse")
+}
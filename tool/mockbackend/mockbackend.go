@@ -0,0 +1,417 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package mockbackend serves the CloudWatch, CloudWatch Logs and X-Ray APIs the agent calls from memory, so the
+// configs can be tested end to end without an AWS account. The agent is pointed at it with the endpoint_override of
+// the metrics, logs and traces sections, and the data it received is read back from the state endpoint.
+package mockbackend
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// StatePath returns the state of the backend as JSON on GET, and clears it on DELETE.
+	StatePath = "/_mock/state"
+
+	logsTargetPrefix = "Logs_20140328."
+	cloudWatchXMLNS  = "http://monitoring.amazonaws.com/doc/2010-08-01/"
+)
+
+// Datum is a metric datum received by PutMetricData.
+type Datum struct {
+	Namespace         string             `json:"namespace"`
+	MetricName        string             `json:"metric_name"`
+	Dimensions        map[string]string  `json:"dimensions,omitempty"`
+	Unit              string             `json:"unit,omitempty"`
+	Value             *float64           `json:"value,omitempty"`
+	Values            []float64          `json:"values,omitempty"`
+	Counts            []float64          `json:"counts,omitempty"`
+	StatisticValues   map[string]float64 `json:"statistic_values,omitempty"`
+	StorageResolution int                `json:"storage_resolution,omitempty"`
+	Timestamp         string             `json:"timestamp,omitempty"`
+	// Entity are the key attributes of the entity the datum was sent with.
+	Entity map[string]string `json:"entity,omitempty"`
+}
+
+// LogEvent is a log event received by PutLogEvents.
+type LogEvent struct {
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+// LogGroup is a log group created by CreateLogGroup.
+type LogGroup struct {
+	Class         string                `json:"class,omitempty"`
+	RetentionDays int                   `json:"retention_days,omitempty"`
+	Streams       map[string][]LogEvent `json:"streams"`
+}
+
+// State is everything the backend received.
+type State struct {
+	Metrics   []Datum              `json:"metrics"`
+	LogGroups map[string]*LogGroup `json:"log_groups"`
+	Segments  []json.RawMessage    `json:"segments"`
+	// Requests are the number of calls of each operation, e.g. "PutLogEvents".
+	Requests map[string]int `json:"requests"`
+}
+
+// Server is the mock backend. It is an http.Handler serving the three APIs on the same endpoint, told apart by the
+// X-Amz-Target header of CloudWatch Logs, the paths of X-Ray and the Action parameter of CloudWatch.
+type Server struct {
+	mu        sync.Mutex
+	state     State
+	requestID atomic.Int64
+}
+
+func New() *Server {
+	s := &Server{}
+	s.Reset()
+	return s
+}
+
+// Reset clears the state.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = State{
+		Metrics:   []Datum{},
+		LogGroups: map[string]*LogGroup{},
+		Segments:  []json.RawMessage{},
+		Requests:  map[string]int{},
+	}
+}
+
+// State returns a copy of the state.
+func (s *Server) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := State{
+		Metrics:   append([]Datum{}, s.state.Metrics...),
+		LogGroups: make(map[string]*LogGroup, len(s.state.LogGroups)),
+		Segments:  append([]json.RawMessage{}, s.state.Segments...),
+		Requests:  make(map[string]int, len(s.state.Requests)),
+	}
+	for name, group := range s.state.LogGroups {
+		copied := *group
+		copied.Streams = make(map[string][]LogEvent, len(group.Streams))
+		for stream, events := range group.Streams {
+			copied.Streams[stream] = append([]LogEvent{}, events...)
+		}
+		state.LogGroups[name] = &copied
+	}
+	for operation, count := range s.state.Requests {
+		state.Requests[operation] = count
+	}
+	return state
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == StatePath {
+		s.serveState(w, r)
+		return
+	}
+	body, err := readBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("x-amzn-RequestId", s.nextRequestID())
+	switch {
+	case strings.HasPrefix(r.Header.Get("X-Amz-Target"), logsTargetPrefix):
+		s.serveLogs(w, strings.TrimPrefix(r.Header.Get("X-Amz-Target"), logsTargetPrefix), body)
+	case r.URL.Path != "/" && r.URL.Path != "":
+		s.serveXRay(w, strings.Trim(r.URL.Path, "/"), body)
+	default:
+		s.serveCloudWatch(w, body)
+	}
+}
+
+func (s *Server) serveState(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.State())
+	case http.MethodDelete:
+		s.Reset()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) nextRequestID() string {
+	return fmt.Sprintf("mock-%08d", s.requestID.Add(1))
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	reader := io.Reader(r.Body)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	return io.ReadAll(reader)
+}
+
+// countRequest counts a call of the operation. The lock must be held.
+func (s *Server) countRequest(operation string) {
+	s.state.Requests[operation]++
+}
+
+type logsError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func writeLogsError(w http.ResponseWriter, code, format string, args ...interface{}) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(logsError{Type: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// logsRequest has the fields of the CloudWatch Logs requests the backend reads.
+type logsRequest struct {
+	LogGroupName    string     `json:"logGroupName"`
+	LogStreamName   string     `json:"logStreamName"`
+	LogGroupClass   string     `json:"logGroupClass"`
+	RetentionInDays int        `json:"retentionInDays"`
+	LogEvents       []LogEvent `json:"logEvents"`
+}
+
+func (s *Server) serveLogs(w http.ResponseWriter, operation string, body []byte) {
+	var req logsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeLogsError(w, "SerializationException", "%v", err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.countRequest(operation)
+	group, groupExists := s.state.LogGroups[req.LogGroupName]
+	var response interface{} = struct{}{}
+	switch operation {
+	case "CreateLogGroup":
+		if groupExists {
+			writeLogsError(w, "ResourceAlreadyExistsException", "The specified log group already exists")
+			return
+		}
+		s.state.LogGroups[req.LogGroupName] = &LogGroup{Class: req.LogGroupClass, Streams: map[string][]LogEvent{}}
+	case "CreateLogStream":
+		if !groupExists {
+			writeLogsError(w, "ResourceNotFoundException", "The specified log group does not exist.")
+			return
+		}
+		if _, ok := group.Streams[req.LogStreamName]; ok {
+			writeLogsError(w, "ResourceAlreadyExistsException", "The specified log stream already exists")
+			return
+		}
+		group.Streams[req.LogStreamName] = []LogEvent{}
+	case "PutRetentionPolicy":
+		if !groupExists {
+			writeLogsError(w, "ResourceNotFoundException", "The specified log group does not exist.")
+			return
+		}
+		group.RetentionDays = req.RetentionInDays
+	case "PutLogEvents":
+		if !groupExists {
+			writeLogsError(w, "ResourceNotFoundException", "The specified log group does not exist.")
+			return
+		}
+		events, ok := group.Streams[req.LogStreamName]
+		if !ok {
+			writeLogsError(w, "ResourceNotFoundException", "The specified log stream does not exist.")
+			return
+		}
+		group.Streams[req.LogStreamName] = append(events, req.LogEvents...)
+		response = map[string]string{"nextSequenceToken": strconv.Itoa(len(group.Streams[req.LogStreamName]))}
+	case "DescribeLogGroups":
+		response = map[string]interface{}{"logGroups": s.describeLogGroups()}
+	}
+	// the other operations, e.g. TagResource, succeed without changing the state
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// describeLogGroups returns the log groups sorted by name. The lock must be held.
+func (s *Server) describeLogGroups() []map[string]interface{} {
+	names := make([]string, 0, len(s.state.LogGroups))
+	for name := range s.state.LogGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	groups := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		group := map[string]interface{}{"logGroupName": name}
+		if days := s.state.LogGroups[name].RetentionDays; days > 0 {
+			group["retentionInDays"] = days
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+func (s *Server) serveXRay(w http.ResponseWriter, operation string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.countRequest(operation)
+	var response interface{} = struct{}{}
+	switch operation {
+	case "TraceSegments":
+		var req struct {
+			TraceSegmentDocuments []string
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, document := range req.TraceSegmentDocuments {
+			s.state.Segments = append(s.state.Segments, json.RawMessage(document))
+		}
+		response = map[string]interface{}{"UnprocessedTraceSegments": []interface{}{}}
+	case "GetSamplingRules":
+		response = map[string]interface{}{"SamplingRuleRecords": []interface{}{}}
+	case "SamplingTargets":
+		response = map[string]interface{}{"SamplingTargetDocuments": []interface{}{}, "UnprocessedStatistics": []interface{}{}}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (s *Server) serveCloudWatch(w http.ResponseWriter, body []byte) {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	action := values.Get("Action")
+	s.mu.Lock()
+	s.countRequest(action)
+	if action == "PutMetricData" {
+		s.state.Metrics = append(s.state.Metrics, parseMetricData(values)...)
+	}
+	s.mu.Unlock()
+	// the other operations, e.g. PutMetricAlarm, succeed with an empty result
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = fmt.Fprintf(w, `<%[1]sResponse xmlns="%[2]s"><%[1]sResult></%[1]sResult><ResponseMetadata><RequestId>%[3]s</RequestId></ResponseMetadata></%[1]sResponse>`,
+		action, cloudWatchXMLNS, w.Header().Get("x-amzn-RequestId"))
+}
+
+// datumPrefix matches the prefix of the parameters of a datum of PutMetricData, directly in MetricData or in the
+// MetricData of an entity.
+var datumPrefix = regexp.MustCompile(`^((?:EntityMetricData\.member\.\d+\.)?)MetricData\.member\.\d+\.`)
+
+// parseMetricData returns the datums of the query parameters of a PutMetricData request, ordered by parameter.
+func parseMetricData(values url.Values) []Datum {
+	namespace := values.Get("Namespace")
+	datums := map[string]*Datum{}
+	var prefixes []string
+	for key := range values {
+		match := datumPrefix.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		prefix := match[0]
+		if _, ok := datums[prefix]; !ok {
+			datums[prefix] = &Datum{Namespace: namespace, Entity: entityKeyAttributes(values, match[1])}
+			prefixes = append(prefixes, prefix)
+		}
+		setDatumField(datums[prefix], strings.TrimPrefix(key, prefix), values.Get(key))
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return memberOrder(prefixes[i]) < memberOrder(prefixes[j]) })
+	result := make([]Datum, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		d := datums[prefix]
+		d.Dimensions = members(values, prefix+"Dimensions.member.", "Name", "Value")
+		d.Values = floats(values, prefix+"Values.member.")
+		d.Counts = floats(values, prefix+"Counts.member.")
+		result = append(result, *d)
+	}
+	return result
+}
+
+func setDatumField(d *Datum, field, value string) {
+	switch {
+	case field == "MetricName":
+		d.MetricName = value
+	case field == "Unit":
+		d.Unit = value
+	case field == "Timestamp":
+		d.Timestamp = value
+	case field == "StorageResolution":
+		d.StorageResolution, _ = strconv.Atoi(value)
+	case field == "Value":
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			d.Value = &v
+		}
+	case strings.HasPrefix(field, "StatisticValues."):
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			if d.StatisticValues == nil {
+				d.StatisticValues = map[string]float64{}
+			}
+			d.StatisticValues[strings.TrimPrefix(field, "StatisticValues.")] = v
+		}
+	}
+}
+
+func entityKeyAttributes(values url.Values, entityPrefix string) map[string]string {
+	if entityPrefix == "" {
+		return nil
+	}
+	return members(values, entityPrefix+"Entity.KeyAttributes.entry.", "key", "value")
+}
+
+// members returns the name and value pairs of the numbered members of a list or a map, e.g. Dimensions.member.1.Name.
+func members(values url.Values, prefix, nameField, valueField string) map[string]string {
+	var result map[string]string
+	for i := 1; ; i++ {
+		name, ok := values[fmt.Sprintf("%s%d.%s", prefix, i, nameField)]
+		if !ok {
+			return result
+		}
+		if result == nil {
+			result = map[string]string{}
+		}
+		result[name[0]] = values.Get(fmt.Sprintf("%s%d.%s", prefix, i, valueField))
+	}
+}
+
+func floats(values url.Values, prefix string) []float64 {
+	var result []float64
+	for i := 1; ; i++ {
+		value, ok := values[prefix+strconv.Itoa(i)]
+		if !ok {
+			return result
+		}
+		f, _ := strconv.ParseFloat(value[0], 64)
+		result = append(result, f)
+	}
+}
+
+// memberOrder orders the datum prefixes by the numbers of their members, e.g. EntityMetricData.member.2 before
+// EntityMetricData.member.10.
+func memberOrder(prefix string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(prefix, ".") {
+		if n, err := strconv.Atoi(part); err == nil {
+			fmt.Fprintf(&b, "%010d.", n)
+		} else {
+			b.WriteString(part + ".")
+		}
+	}
+	return b.String()
+}
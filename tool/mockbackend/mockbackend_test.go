@@ -0,0 +1,167 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package mockbackend
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/xray"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/handlers"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+func newSession(t *testing.T, endpoint string) *session.Session {
+	t.Helper()
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(endpoint),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	})
+	require.NoError(t, err)
+	return sess
+}
+
+func TestCloudWatch(t *testing.T) {
+	backend := New()
+	server := httptest.NewServer(backend)
+	defer server.Close()
+	client := cloudwatch.New(newSession(t, server.URL))
+	// the agent compresses PutMetricData
+	client.Handlers.Build.PushBackNamed(handlers.NewRequestCompressionHandler([]string{"PutMetricData"}))
+
+	_, err := client.PutMetricData(&cloudwatch.PutMetricDataInput{
+		Namespace: aws.String("CWAgent"),
+		MetricData: []*cloudwatch.MetricDatum{
+			{
+				MetricName:        aws.String("mem_used_percent"),
+				Dimensions:        []*cloudwatch.Dimension{{Name: aws.String("host"), Value: aws.String("a")}},
+				Unit:              aws.String("Percent"),
+				Value:             aws.Float64(42.5),
+				StorageResolution: aws.Int64(60),
+			},
+		},
+		EntityMetricData: []*cloudwatch.EntityMetricData{
+			{
+				Entity: &cloudwatch.Entity{KeyAttributes: map[string]*string{"Type": aws.String("Service"), "Name": aws.String("app")}},
+				MetricData: []*cloudwatch.MetricDatum{
+					{
+						MetricName:      aws.String("latency"),
+						Values:          aws.Float64Slice([]float64{1, 2}),
+						Counts:          aws.Float64Slice([]float64{3, 4}),
+						StatisticValues: &cloudwatch.StatisticSet{Maximum: aws.Float64(2), Minimum: aws.Float64(1), SampleCount: aws.Float64(7), Sum: aws.Float64(11)},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	_, err = client.DescribeAlarms(&cloudwatch.DescribeAlarmsInput{})
+	require.NoError(t, err)
+
+	// the datums of the entities come first
+	state := backend.State()
+	assert.Equal(t, []Datum{
+		{
+			Namespace:       "CWAgent",
+			MetricName:      "latency",
+			Values:          []float64{1, 2},
+			Counts:          []float64{3, 4},
+			StatisticValues: map[string]float64{"Maximum": 2, "Minimum": 1, "SampleCount": 7, "Sum": 11},
+			Entity:          map[string]string{"Type": "Service", "Name": "app"},
+		},
+		{
+			Namespace:         "CWAgent",
+			MetricName:        "mem_used_percent",
+			Dimensions:        map[string]string{"host": "a"},
+			Unit:              "Percent",
+			Value:             aws.Float64(42.5),
+			StorageResolution: 60,
+		},
+	}, state.Metrics)
+	assert.Equal(t, map[string]int{"PutMetricData": 1, "DescribeAlarms": 1}, state.Requests)
+}
+
+func TestCloudWatchLogs(t *testing.T) {
+	backend := New()
+	server := httptest.NewServer(backend)
+	defer server.Close()
+	client := cloudwatchlogs.New(newSession(t, server.URL))
+
+	_, err := client.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{LogGroupName: aws.String("app"), LogStreamName: aws.String("i-123")})
+	assert.Equal(t, cloudwatchlogs.ErrCodeResourceNotFoundException, err.(awserr.Error).Code())
+	_, err = client.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{LogGroupName: aws.String("app")})
+	require.NoError(t, err)
+	_, err = client.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{LogGroupName: aws.String("app")})
+	assert.Equal(t, cloudwatchlogs.ErrCodeResourceAlreadyExistsException, err.(awserr.Error).Code())
+	_, err = client.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{LogGroupName: aws.String("app"), LogStreamName: aws.String("i-123")})
+	require.NoError(t, err)
+	_, err = client.PutRetentionPolicy(&cloudwatchlogs.PutRetentionPolicyInput{LogGroupName: aws.String("app"), RetentionInDays: aws.Int64(7)})
+	require.NoError(t, err)
+	now := time.Now().UnixMilli()
+	_, err = client.PutLogEvents(&cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String("app"),
+		LogStreamName: aws.String("i-123"),
+		LogEvents:     []*cloudwatchlogs.InputLogEvent{{Message: aws.String("started"), Timestamp: aws.Int64(now)}},
+	})
+	require.NoError(t, err)
+	_, err = client.PutLogEvents(&cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String("app"),
+		LogStreamName: aws.String("other"),
+		LogEvents:     []*cloudwatchlogs.InputLogEvent{{Message: aws.String("lost"), Timestamp: aws.Int64(now)}},
+	})
+	assert.Equal(t, cloudwatchlogs.ErrCodeResourceNotFoundException, err.(awserr.Error).Code())
+	groups, err := client.DescribeLogGroups(&cloudwatchlogs.DescribeLogGroupsInput{})
+	require.NoError(t, err)
+	require.Len(t, groups.LogGroups, 1)
+	assert.EqualValues(t, 7, *groups.LogGroups[0].RetentionInDays)
+
+	state := backend.State()
+	assert.Equal(t, map[string]*LogGroup{
+		"app": {RetentionDays: 7, Streams: map[string][]LogEvent{"i-123": {{Timestamp: now, Message: "started"}}}},
+	}, state.LogGroups)
+	assert.Equal(t, 2, state.Requests["PutLogEvents"])
+}
+
+func TestXRayAndStateEndpoint(t *testing.T) {
+	backend := New()
+	server := httptest.NewServer(backend)
+	defer server.Close()
+	client := xray.New(newSession(t, server.URL))
+
+	segment := `{"name":"app","id":"70de5b6f19ff9a0a","trace_id":"1-581cf771-a006649127e371903a2de979","start_time":1,"end_time":2}`
+	out, err := client.PutTraceSegments(&xray.PutTraceSegmentsInput{TraceSegmentDocuments: aws.StringSlice([]string{segment})})
+	require.NoError(t, err)
+	assert.Empty(t, out.UnprocessedTraceSegments)
+	_, err = client.GetSamplingRules(&xray.GetSamplingRulesInput{})
+	require.NoError(t, err)
+
+	resp, err := http.Get(server.URL + StatePath)
+	require.NoError(t, err)
+	var state State
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&state))
+	resp.Body.Close()
+	require.Len(t, state.Segments, 1)
+	assert.JSONEq(t, segment, string(state.Segments[0]))
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL+StatePath, nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, backend.State().Segments)
+	assert.Empty(t, backend.State().Requests)
+}
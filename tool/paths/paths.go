@@ -3,6 +3,15 @@
 
 package paths
 
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"syscall"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+)
+
 const (
 	COMMON_CONFIG  = "common-config.toml"
 	JSON           = "amazon-cloudwatch-agent.json"
@@ -10,7 +19,18 @@ const (
 	YAML           = "amazon-cloudwatch-agent.yaml"
 	ENV            = "env-config.json"
 	AGENT_LOG_FILE = "amazon-cloudwatch-agent.log"
+	AUDIT_LOG_FILE = "amazon-cloudwatch-agent-audit.log"
 	JMXJarName     = "opentelemetry-jmx-metrics.jar"
+	CONTROL_SOCKET = "amazon-cloudwatch-agent.sock"
+	CONTROL_DIR    = "control"
+	HELPER_SOCKET  = "amazon-cloudwatch-agent-helper.sock"
+	DEAD_LETTER    = "dead-letter"
+	QUARANTINE     = "quarantine"
+	PID_FILE       = "amazon-cloudwatch-agent.pid"
+	AGENT_ID       = "agent-id.json"
+	CRASH          = "crash"
+	FEATURE_FLAGS  = "feature-flags.json"
+	LOCAL_AUTH     = "local-auth-secret"
 )
 
 var (
@@ -21,7 +41,55 @@ var (
 	CommonConfigPath     string
 	YamlConfigPath       string
 	AgentLogFilePath     string
+	AuditLogFilePath     string
 	TranslatorBinaryPath string
 	AgentBinaryPath      string
 	JMXJarPath           string
+	ControlSocketPath    string
+	HelperSocketPath     string
+	DeadLetterDir        string
+	QuarantineDir        string
+	PidFilePath          string
+	AgentIDPath          string
+	CrashDir             string
+	FeatureFlagsPath     string
+	LocalAuthSecretPath  string
+	// StateDir is the directory set with CWAGENT_STATE_DIR. It is empty when the agent writes under its install
+	// directory.
+	StateDir string
 )
+
+// applyStateDir moves every file the agent writes under the state directory, so that the agent can run with a
+// read-only root filesystem. The configuration provided by the user, e.g. JsonConfigPath, is still read from the
+// install directory.
+func applyStateDir() {
+	StateDir = envconfig.GetStateDir()
+	if StateDir == "" {
+		return
+	}
+	etcDir := filepath.Join(StateDir, "etc")
+	varDir := filepath.Join(StateDir, "var")
+	EnvConfigPath = filepath.Join(etcDir, ENV)
+	TomlConfigPath = filepath.Join(etcDir, TOML)
+	YamlConfigPath = filepath.Join(etcDir, YAML)
+	AgentLogFilePath = filepath.Join(StateDir, "logs", AGENT_LOG_FILE)
+	AuditLogFilePath = filepath.Join(StateDir, "logs", AUDIT_LOG_FILE)
+	ControlSocketPath = filepath.Join(varDir, CONTROL_SOCKET)
+	HelperSocketPath = filepath.Join(varDir, HELPER_SOCKET)
+	DeadLetterDir = filepath.Join(varDir, DEAD_LETTER)
+	QuarantineDir = filepath.Join(varDir, QUARANTINE)
+	PidFilePath = filepath.Join(varDir, PID_FILE)
+	AgentIDPath = filepath.Join(varDir, AGENT_ID)
+	CrashDir = filepath.Join(varDir, CRASH)
+	FeatureFlagsPath = filepath.Join(varDir, FEATURE_FLAGS)
+	LocalAuthSecretPath = filepath.Join(varDir, LOCAL_AUTH)
+}
+
+// ReadOnlyHint adds to the error of writing the state of the agent how to fix it when the filesystem is read-only.
+// Other errors are returned unchanged.
+func ReadOnlyHint(err error) error {
+	if errors.Is(err, syscall.EROFS) {
+		return fmt.Errorf("%w: the filesystem is read-only, set %s to a writable directory", err, envconfig.CWAgentStateDir)
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package paths
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+)
+
+func TestApplyStateDir(t *testing.T) {
+	jsonConfigPath, tomlConfigPath, pidFilePath := JsonConfigPath, TomlConfigPath, PidFilePath
+	t.Cleanup(func() {
+		StateDir = ""
+		JsonConfigPath, TomlConfigPath, PidFilePath = jsonConfigPath, tomlConfigPath, pidFilePath
+	})
+
+	t.Setenv(envconfig.CWAgentStateDir, "")
+	applyStateDir()
+	assert.Equal(t, "", StateDir)
+	assert.Equal(t, tomlConfigPath, TomlConfigPath)
+
+	dir := t.TempDir()
+	t.Setenv(envconfig.CWAgentStateDir, dir)
+	applyStateDir()
+	assert.Equal(t, dir, StateDir)
+	assert.Equal(t, filepath.Join(dir, "etc", TOML), TomlConfigPath)
+	assert.Equal(t, filepath.Join(dir, "etc", YAML), YamlConfigPath)
+	assert.Equal(t, filepath.Join(dir, "etc", ENV), EnvConfigPath)
+	assert.Equal(t, filepath.Join(dir, "logs", AGENT_LOG_FILE), AgentLogFilePath)
+	assert.Equal(t, filepath.Join(dir, "logs", AUDIT_LOG_FILE), AuditLogFilePath)
+	assert.Equal(t, filepath.Join(dir, "var", CONTROL_SOCKET), ControlSocketPath)
+	assert.Equal(t, filepath.Join(dir, "var", DEAD_LETTER), DeadLetterDir)
+	assert.Equal(t, filepath.Join(dir, "var", PID_FILE), PidFilePath)
+	assert.Equal(t, filepath.Join(dir, "var", AGENT_ID), AgentIDPath)
+	assert.Equal(t, filepath.Join(dir, "var", CRASH), CrashDir)
+	assert.Equal(t, filepath.Join(dir, "var", FEATURE_FLAGS), FeatureFlagsPath)
+	assert.Equal(t, filepath.Join(dir, "var", LOCAL_AUTH), LocalAuthSecretPath)
+	// the configuration of the user is still read from the install directory
+	assert.Equal(t, jsonConfigPath, JsonConfigPath)
+}
+
+func TestReadOnlyHint(t *testing.T) {
+	err := ReadOnlyHint(&os.PathError{Op: "open", Path: "/opt/aws/amazon-cloudwatch-agent/var/x", Err: syscall.EROFS})
+	assert.ErrorIs(t, err, syscall.EROFS)
+	assert.ErrorContains(t, err, envconfig.CWAgentStateDir)
+
+	err = fmt.Errorf("wrapped: %w", os.ErrPermission)
+	assert.Equal(t, err, ReadOnlyHint(err))
+	assert.NoError(t, ReadOnlyHint(nil))
+}
@@ -1,8 +1,8 @@
 // Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
 // SPDX-License-Identifier: MIT
 
-//go:build linux || darwin
-// +build linux darwin
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
 
 package paths
 
@@ -28,7 +28,18 @@ func init() {
 	CommonConfigPath = filepath.Join(AgentDir, "etc", COMMON_CONFIG)
 	YamlConfigPath = filepath.Join(AgentDir, "etc", YAML)
 	AgentLogFilePath = filepath.Join(AgentDir, "logs", AGENT_LOG_FILE)
+	AuditLogFilePath = filepath.Join(AgentDir, "logs", AUDIT_LOG_FILE)
 	TranslatorBinaryPath = filepath.Join(AgentDir, "bin", TranslatorBinaryName)
 	AgentBinaryPath = filepath.Join(AgentDir, "bin", AgentBinaryName)
 	JMXJarPath = filepath.Join(AgentDir, "bin", JMXJarName)
+	ControlSocketPath = filepath.Join(AgentDir, "var", CONTROL_SOCKET)
+	HelperSocketPath = filepath.Join(AgentDir, "var", HELPER_SOCKET)
+	DeadLetterDir = filepath.Join(AgentDir, "var", DEAD_LETTER)
+	QuarantineDir = filepath.Join(AgentDir, "var", QUARANTINE)
+	PidFilePath = filepath.Join(AgentDir, "var", PID_FILE)
+	AgentIDPath = filepath.Join(AgentDir, "var", AGENT_ID)
+	CrashDir = filepath.Join(AgentDir, "var", CRASH)
+	FeatureFlagsPath = filepath.Join(AgentDir, "var", FEATURE_FLAGS)
+	LocalAuthSecretPath = filepath.Join(AgentDir, "var", LOCAL_AUTH)
+	applyStateDir()
 }
@@ -50,7 +50,17 @@ func init() {
 	YamlConfigPath = filepath.Join(AgentConfigDir, YAML)
 	CommonConfigPath = filepath.Join(AgentConfigDir, COMMON_CONFIG)
 	AgentLogFilePath = filepath.Join(AgentConfigDir, AGENT_LOG_FILE)
+	AuditLogFilePath = filepath.Join(AgentConfigDir, AUDIT_LOG_FILE)
 	TranslatorBinaryPath = filepath.Join(AgentRootDir, TranslatorBinaryName)
 	AgentBinaryPath = filepath.Join(AgentRootDir, AgentBinaryName)
 	JMXJarPath = filepath.Join(AgentRootDir, JMXJarName)
+	// the control socket has a directory of its own, which only Local System and the Administrators can access
+	ControlSocketPath = filepath.Join(AgentConfigDir, CONTROL_DIR, CONTROL_SOCKET)
+	DeadLetterDir = filepath.Join(AgentConfigDir, DEAD_LETTER)
+	QuarantineDir = filepath.Join(AgentConfigDir, QUARANTINE)
+	AgentIDPath = filepath.Join(AgentConfigDir, AGENT_ID)
+	CrashDir = filepath.Join(AgentConfigDir, CRASH)
+	FeatureFlagsPath = filepath.Join(AgentConfigDir, FEATURE_FLAGS)
+	LocalAuthSecretPath = filepath.Join(AgentConfigDir, LOCAL_AUTH)
+	applyStateDir()
 }
@@ -33,7 +33,10 @@ func (p *processor) Process(ctx *runtime.Context, config *data.Config) {
 		if filePath == "" {
 			filePath = util.AskWithDefault(filePathLinuxConfigQuestion, DefaultFilePathLinuxConfiguration)
 		}
-		processConfigFromPythonConfigParserFile(filePath, config.LogsConf())
+		stateFile := processConfigFromPythonConfigParserFile(filePath, config.LogsConf())
+		if ctx.ImportLinuxMigrationState {
+			importState(stateFile, DefaultFileStateFolder, config.LogsConf())
+		}
 	}
 }
 
@@ -41,12 +44,17 @@ func (p *processor) NextProcessor(ctx *runtime.Context, config *data.Config) int
 	return logs.Processor
 }
 
-func processConfigFromPythonConfigParserFile(filePath string, logsConfig *config.Logs) {
+// processConfigFromPythonConfigParserFile adds the log files of the config and returns the state file of the awslogs agent.
+func processConfigFromPythonConfigParserFile(filePath string, logsConfig *config.Logs) string {
 	p, err := configparser.NewConfigParserFromFile(filePath)
 	if err != nil {
 		log.Panicf("E! Error in reading old python config from file %s: %v", filePath, err)
 	}
+	stateFile := DefaultStateFilePath
 	if p.HasSection(genericSectionName) {
+		if value, err := p.Get(genericSectionName, stateFileKey); err == nil && value != "" {
+			stateFile = value
+		}
 		err := p.RemoveSection(genericSectionName)
 		if err != nil {
 			log.Panicf("E! Error in removing generic section from the config file %s: %v", filePath, err)
@@ -55,4 +63,5 @@ func processConfigFromPythonConfigParserFile(filePath string, logsConfig *config
 	for _, section := range p.Sections() {
 		addLogConfig(logsConfig, filePath, section, p)
 	}
+	return stateFile
 }
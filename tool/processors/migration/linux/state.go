@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package linux
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	// registers the pure Go sqlite driver used to read the awslogs agent state
+	_ "modernc.org/sqlite"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data/config"
+)
+
+const (
+	// DefaultStateFilePath is the awslogs agent state used when the general section does not set a state_file.
+	DefaultStateFilePath = "/var/awslogs/state/agent-state"
+	// DefaultFileStateFolder is where the agent keeps the position of each log file it publishes.
+	DefaultFileStateFolder = "/opt/aws/amazon-cloudwatch-agent/logs/state"
+
+	stateFileKey  = "state_file"
+	stateFileMode = 0644
+)
+
+// position is how far the awslogs agent has pushed a log file.
+type position struct {
+	Path   string `json:"source_path"`
+	Offset int64  `json:"end_position"`
+}
+
+// readPositions reads the pushed positions from the push_state table of the awslogs agent state, where each value is a
+// JSON document with the path of the log file and the offset after the last pushed event.
+func readPositions(stateFile string) ([]position, error) {
+	if _, err := os.Stat(stateFile); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", "file:"+stateFile+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT v FROM push_state")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var positions []position
+	for rows.Next() {
+		var v string
+		if err = rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		var p position
+		if err = json.Unmarshal([]byte(v), &p); err != nil || p.Path == "" {
+			fmt.Printf("Warning: Skipping unrecognized awslogs state %s.\n", v)
+			continue
+		}
+		positions = append(positions, p)
+	}
+	return positions, rows.Err()
+}
+
+// importState writes the positions of the awslogs agent as agent file states, so that the agent continues each migrated
+// log file where the awslogs agent stopped instead of uploading it again. Existing agent file states are kept, and a
+// position past the end of its file is left out, since the file has been rotated or truncated since.
+func importState(stateFile, stateFolder string, logsConfig *config.Logs) {
+	positions, err := readPositions(stateFile)
+	if err != nil {
+		fmt.Printf("Warning: Unable to read the awslogs agent state %s, migrated log files will be read from the beginning: %v\n", stateFile, err)
+		return
+	}
+	var filePaths []string
+	if logsConfig.LogsCollect != nil && logsConfig.LogsCollect.Files != nil {
+		for _, fileConfig := range logsConfig.LogsCollect.Files.FileConfigs {
+			filePaths = append(filePaths, fileConfig.FilePath)
+		}
+	}
+	if err = os.MkdirAll(stateFolder, 0755); err != nil {
+		fmt.Printf("Warning: Unable to create the file state folder %s: %v\n", stateFolder, err)
+		return
+	}
+	for _, p := range positions {
+		if !isMigrated(p.Path, filePaths) || p.Offset <= 0 {
+			continue
+		}
+		info, err := os.Stat(p.Path)
+		if err != nil || info.Size() < p.Offset {
+			continue
+		}
+		stateFilePath := filepath.Join(stateFolder, escapeFilePath(p.Path))
+		if _, err = os.Stat(stateFilePath); err == nil {
+			continue
+		}
+		content := []byte(strconv.FormatInt(p.Offset, 10) + "\n" + p.Path)
+		if err = os.WriteFile(stateFilePath, content, stateFileMode); err != nil {
+			fmt.Printf("Warning: Unable to import the awslogs agent state of %s: %v\n", p.Path, err)
+			continue
+		}
+		fmt.Printf("Imported the awslogs agent state of %s at offset %d.\n", p.Path, p.Offset)
+	}
+}
+
+func isMigrated(path string, filePaths []string) bool {
+	for _, filePath := range filePaths {
+		if filePath == path {
+			return true
+		}
+		if matched, err := filepath.Match(filePath, path); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeFilePath names the file state the same way as the logfile plugin does.
+func escapeFilePath(filePath string) string {
+	escapedFilePath := filepath.ToSlash(filePath)
+	escapedFilePath = strings.ReplaceAll(escapedFilePath, "/", "_")
+	escapedFilePath = strings.ReplaceAll(escapedFilePath, " ", "_")
+	escapedFilePath = strings.ReplaceAll(escapedFilePath, ":", "_")
+	return escapedFilePath
+}
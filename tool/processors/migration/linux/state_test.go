@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package linux
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/data/config"
+)
+
+func writeAwslogsState(t *testing.T, stateFile string, values ...string) {
+	db, err := sql.Open("sqlite", stateFile)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE push_state (k TEXT PRIMARY KEY NOT NULL, v TEXT NOT NULL)")
+	require.NoError(t, err)
+	for i, v := range values {
+		_, err = db.Exec("INSERT INTO push_state (k, v) VALUES (?, ?)", i, v)
+		require.NoError(t, err)
+	}
+}
+
+func TestImportState(t *testing.T) {
+	dir := t.TempDir()
+	logDir := filepath.Join(dir, "log")
+	require.NoError(t, os.Mkdir(logDir, 0755))
+	messages := filepath.Join(logDir, "messages")
+	app := filepath.Join(logDir, "app.log")
+	rotated := filepath.Join(logDir, "rotated.log")
+	existing := filepath.Join(logDir, "existing.log")
+	other := filepath.Join(dir, "other.log")
+	for _, file := range []string{messages, app, rotated, existing, other} {
+		require.NoError(t, os.WriteFile(file, []byte("0123456789\n"), 0644))
+	}
+	stateFile := filepath.Join(dir, "agent-state")
+	writeAwslogsState(t, stateFile,
+		`{"source_path": "`+messages+`", "end_position": 11}`,
+		`{"source_path": "`+app+`", "end_position": 5}`,
+		`{"source_path": "`+rotated+`", "end_position": 100}`,
+		`{"source_path": "`+existing+`", "end_position": 5}`,
+		`{"source_path": "`+other+`", "end_position": 5}`,
+		`{"source_path": "`+filepath.Join(logDir, "deleted.log")+`", "end_position": 5}`,
+		`not json`,
+	)
+	stateFolder := filepath.Join(dir, "state")
+	require.NoError(t, os.Mkdir(stateFolder, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(stateFolder, escapeFilePath(existing)), []byte("2\n"+existing), 0644))
+
+	logsConfig := new(config.Logs)
+	logsConfig.AddLogFile(messages, "messages", "", "", "", "", "", -1, "")
+	logsConfig.AddLogFile(filepath.Join(logDir, "*.log"), "app", "", "", "", "", "", -1, "")
+	importState(stateFile, stateFolder, logsConfig)
+
+	content, err := os.ReadFile(filepath.Join(stateFolder, escapeFilePath(messages)))
+	require.NoError(t, err)
+	assert.Equal(t, "11\n"+messages, string(content))
+	content, err = os.ReadFile(filepath.Join(stateFolder, escapeFilePath(app)))
+	require.NoError(t, err)
+	assert.Equal(t, "5\n"+app, string(content))
+	// the agent state is not overwritten
+	content, err = os.ReadFile(filepath.Join(stateFolder, escapeFilePath(existing)))
+	require.NoError(t, err)
+	assert.Equal(t, "2\n"+existing, string(content))
+	// rotated and not migrated files are read from the beginning
+	assert.NoFileExists(t, filepath.Join(stateFolder, escapeFilePath(rotated)))
+	assert.NoFileExists(t, filepath.Join(stateFolder, escapeFilePath(other)))
+	entries, err := os.ReadDir(stateFolder)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestImportStateWithoutStateFile(t *testing.T) {
+	stateFolder := filepath.Join(t.TempDir(), "state")
+	logsConfig := new(config.Logs)
+	logsConfig.AddLogFile("/var/log/messages", "messages", "", "", "", "", "", -1, "")
+	importState(filepath.Join(t.TempDir(), "agent-state"), stateFolder, logsConfig)
+	assert.NoDirExists(t, stateFolder)
+}
+
+func TestProcessConfigStateFile(t *testing.T) {
+	dir := t.TempDir()
+	withStateFile := filepath.Join(dir, "with.conf")
+	require.NoError(t, os.WriteFile(withStateFile, []byte("[general]\nstate_file = /var/lib/awslogs/agent-state\n\n[messages]\nfile = /var/log/messages\nlog_group_name = messages\n"), 0644))
+	withoutStateFile := filepath.Join(dir, "without.conf")
+	require.NoError(t, os.WriteFile(withoutStateFile, []byte("[messages]\nfile = /var/log/messages\nlog_group_name = messages\n"), 0644))
+
+	assert.Equal(t, "/var/lib/awslogs/agent-state", processConfigFromPythonConfigParserFile(withStateFile, new(config.Logs)))
+	assert.Equal(t, DefaultStateFilePath, processConfigFromPythonConfigParserFile(withoutStateFile, new(config.Logs)))
+}
@@ -13,8 +13,9 @@ type Context struct {
 	ConfigOutputPath          string
 
 	//linux migration
-	HasExistingLinuxConfig bool
-	ConfigFilePath         string
+	HasExistingLinuxConfig    bool
+	ConfigFilePath            string
+	ImportLinuxMigrationState bool
 
 	//windows migration
 	WindowsNonInteractiveMigration bool
@@ -0,0 +1,170 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package telegrafmigration converts a Telegraf config into a best-effort config of the CloudWatch agent. The plugins
+// and options that have no equivalent in the CloudWatch agent are listed in the result instead.
+package telegrafmigration
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// DefaultConfigFilePath is where Telegraf is configured on Linux.
+const DefaultConfigFilePath = "/etc/telegraf/telegraf.conf"
+
+// Result is the converted config and the items of the Telegraf config that were not converted.
+type Result struct {
+	Config      map[string]any
+	Unsupported []string
+}
+
+type converter struct {
+	agent       map[string]any
+	metrics     map[string]any
+	collected   map[string]any
+	procstat    []any
+	dimensions  map[string]any
+	unsupported []string
+}
+
+// Convert reads the Telegraf config file.
+func Convert(configFilePath string) (*Result, error) {
+	var config map[string]any
+	if _, err := toml.DecodeFile(configFilePath, &config); err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", configFilePath, err)
+	}
+	c := &converter{
+		agent:      map[string]any{},
+		metrics:    map[string]any{},
+		collected:  map[string]any{},
+		dimensions: map[string]any{},
+	}
+	if tags, ok := config["global_tags"].(map[string]any); ok {
+		for key, value := range tags {
+			c.dimensions[key] = fmt.Sprint(value)
+		}
+	}
+	for _, section := range sortedKeys(config) {
+		switch section {
+		case "global_tags":
+		case "agent":
+			agent, _ := config[section].(map[string]any)
+			c.convertAgent(agent)
+		case "inputs":
+			c.convertPlugins(section, config[section], c.convertInput)
+		case "outputs":
+			c.convertPlugins(section, config[section], c.convertOutput)
+		case "processors", "aggregators":
+			c.convertPlugins(section, config[section], func(name string, _ []map[string]any) {
+				c.unsupported = append(c.unsupported, fmt.Sprintf("%s.%s is not supported", section, name))
+			})
+		default:
+			c.report(section, "")
+		}
+	}
+	return &Result{Config: c.config(), Unsupported: c.unsupported}, nil
+}
+
+func (c *converter) convertAgent(agent map[string]any) {
+	for _, key := range sortedKeys(agent) {
+		switch key {
+		case "interval":
+			if interval, ok := c.interval("agent", key, agent[key]); ok {
+				c.agent["metrics_collection_interval"] = interval
+			}
+		case "flush_interval":
+			if interval, ok := c.interval("agent", key, agent[key]); ok {
+				c.metrics["force_flush_interval"] = interval
+			}
+		case "debug", "logfile", "omit_hostname":
+			c.agent[key] = agent[key]
+		default:
+			c.report("agent", key)
+		}
+	}
+}
+
+func (c *converter) convertPlugins(section string, plugins any, convert func(name string, instances []map[string]any)) {
+	byName, _ := plugins.(map[string]any)
+	for _, name := range sortedKeys(byName) {
+		instances, _ := byName[name].([]map[string]any)
+		if len(instances) == 0 {
+			c.report(section+"."+name, "")
+			continue
+		}
+		convert(name, instances)
+	}
+}
+
+func (c *converter) convertOutput(name string, instances []map[string]any) {
+	source := "outputs." + name
+	if name != "cloudwatch" {
+		c.report(source, "")
+		return
+	}
+	if len(instances) > 1 {
+		c.report(source, "instances after the first")
+	}
+	output := instances[0]
+	for _, key := range sortedKeys(output) {
+		switch key {
+		case "namespace":
+			c.metrics["namespace"] = output[key]
+		case "region":
+			c.agent["region"] = output[key]
+		case "endpoint_url":
+			c.metrics["endpoint_override"] = output[key]
+		case "role_arn":
+			c.agent["credentials"] = map[string]any{"role_arn": output[key]}
+		default:
+			c.report(source, key)
+		}
+	}
+}
+
+// interval converts a Telegraf duration into seconds.
+func (c *converter) interval(source, key string, value any) (int, bool) {
+	s, _ := value.(string)
+	d, err := time.ParseDuration(s)
+	if err != nil || d < time.Second {
+		c.report(source, fmt.Sprintf("%s %v", key, value))
+		return 0, false
+	}
+	return int(d / time.Second), true
+}
+
+func (c *converter) report(source, item string) {
+	if item == "" {
+		c.unsupported = append(c.unsupported, source+" is not supported")
+		return
+	}
+	c.unsupported = append(c.unsupported, fmt.Sprintf("%s: %s is not supported", source, item))
+}
+
+func (c *converter) config() map[string]any {
+	config := map[string]any{}
+	if len(c.agent) > 0 {
+		config["agent"] = c.agent
+	}
+	if len(c.procstat) > 0 {
+		c.collected["procstat"] = c.procstat
+	}
+	if len(c.collected) > 0 {
+		c.metrics["metrics_collected"] = c.collected
+		config["metrics"] = c.metrics
+	}
+	return config
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,69 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package telegrafmigration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+)
+
+func TestConvert(t *testing.T) {
+	result, err := Convert(filepath.Join("testdata", "telegraf.conf"))
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile(filepath.Join("testdata", "expected.json"))
+	require.NoError(t, err)
+	actual, err := json.Marshal(result.Config)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+
+	var config map[string]any
+	require.NoError(t, json.Unmarshal(actual, &config))
+	validation, err := cmdutil.RunSchemaValidation(config)
+	require.NoError(t, err)
+	assert.True(t, validation.Valid(), validation.Errors())
+
+	assert.Equal(t, []string{
+		"agent: round_interval is not supported",
+		"inputs.cpu: collect_cpu_time is not supported",
+		"inputs.procstat: user is not supported",
+		"inputs.procstat: an instance without exe, pattern or pid_file is not supported",
+		"inputs.prometheus is not supported",
+		"inputs.statsd: delete_gauges is not supported",
+		"outputs.cloudwatch: high_resolution_metrics is not supported",
+		"outputs.influxdb is not supported",
+		"processors.rename is not supported",
+	}, result.Unsupported)
+}
+
+func TestConvertInvalid(t *testing.T) {
+	dir := t.TempDir()
+	configFilePath := filepath.Join(dir, "telegraf.conf")
+	require.NoError(t, os.WriteFile(configFilePath, []byte("[agent]\n  interval = \"500ms\"\n\n[[inputs.mem]]\n  interval = \"soon\"\n\n[[inputs.mem]]\n"), 0644))
+	result, err := Convert(configFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"metrics": map[string]any{
+			"metrics_collected": map[string]any{
+				"mem": map[string]any{"measurement": []any{"used_percent"}},
+			},
+		},
+	}, result.Config)
+	assert.Equal(t, []string{
+		"agent: interval 500ms is not supported",
+		"inputs.mem: instances after the first is not supported",
+		"inputs.mem: interval soon is not supported",
+	}, result.Unsupported)
+
+	require.NoError(t, os.WriteFile(configFilePath, []byte("[agent\n"), 0644))
+	_, err = Convert(configFilePath)
+	assert.ErrorContains(t, err, "unable to read")
+}
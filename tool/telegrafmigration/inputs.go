@@ -0,0 +1,180 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package telegrafmigration
+
+import "fmt"
+
+// input is how a Telegraf input maps onto a plugin of metrics_collected.
+type input struct {
+	// name is the key of the plugin in metrics_collected.
+	name string
+	// fieldsKey is the option of the plugin the fieldpass of the input becomes, empty if the plugin has none.
+	fieldsKey string
+	// fields are used when the input does not have a fieldpass.
+	fields []any
+	// resources is the option of the input with the devices, interfaces or mount points to collect.
+	resources string
+	// options are the options of the input copied to the plugin, keyed by the option of the input.
+	options map[string]string
+	// dimensions is whether the plugin takes the tags of the input as append_dimensions.
+	dimensions bool
+}
+
+var inputs = map[string]input{
+	"cpu": {
+		name:       "cpu",
+		fieldsKey:  "measurement",
+		fields:     []any{"usage_idle", "usage_iowait", "usage_system", "usage_user"},
+		options:    map[string]string{"totalcpu": "totalcpu"},
+		dimensions: true,
+	},
+	"disk": {
+		name:       "disk",
+		fieldsKey:  "measurement",
+		fields:     []any{"used_percent", "inodes_free"},
+		resources:  "mount_points",
+		options:    map[string]string{"ignore_fs": "ignore_file_system_types"},
+		dimensions: true,
+	},
+	"diskio": {
+		name:       "diskio",
+		fieldsKey:  "measurement",
+		fields:     []any{"io_time", "read_bytes", "write_bytes", "reads", "writes"},
+		resources:  "devices",
+		dimensions: true,
+	},
+	"mem": {
+		name:       "mem",
+		fieldsKey:  "measurement",
+		fields:     []any{"used_percent"},
+		dimensions: true,
+	},
+	"swap": {
+		name:       "swap",
+		fieldsKey:  "measurement",
+		fields:     []any{"used_percent"},
+		dimensions: true,
+	},
+	"net": {
+		name:       "net",
+		fieldsKey:  "measurement",
+		fields:     []any{"bytes_sent", "bytes_recv", "packets_sent", "packets_recv"},
+		resources:  "interfaces",
+		dimensions: true,
+	},
+	"netstat": {
+		name:       "netstat",
+		fieldsKey:  "measurement",
+		fields:     []any{"tcp_established", "tcp_time_wait"},
+		dimensions: true,
+	},
+	"processes": {
+		name:       "processes",
+		fieldsKey:  "measurement",
+		fields:     []any{"running", "sleeping", "dead"},
+		dimensions: true,
+	},
+	"procstat": {
+		name:       "procstat",
+		fieldsKey:  "measurement",
+		fields:     []any{"cpu_usage", "memory_rss"},
+		options:    map[string]string{"exe": "exe", "pattern": "pattern", "pid_file": "pid_file"},
+		dimensions: true,
+	},
+	"nvidia_smi": {
+		name:      "nvidia_smi",
+		fieldsKey: "measurement",
+		fields:    []any{"utilization_gpu", "memory_used", "temperature_gpu"},
+	},
+	"ethtool": {
+		name:       "ethtool",
+		fieldsKey:  "metrics_include",
+		options:    map[string]string{"interface_include": "interface_include", "interface_exclude": "interface_exclude"},
+		dimensions: true,
+	},
+	"statsd": {
+		name: "statsd",
+		options: map[string]string{
+			"service_address":          "service_address",
+			"metric_separator":         "metric_separator",
+			"allowed_pending_messages": "allowed_pending_messages",
+		},
+	},
+}
+
+// convertInput adds the plugin of each instance of the input. Only procstat can have more than one.
+func (c *converter) convertInput(name string, instances []map[string]any) {
+	source := "inputs." + name
+	in, ok := inputs[name]
+	if !ok {
+		c.report(source, "")
+		return
+	}
+	if name != "procstat" && len(instances) > 1 {
+		c.report(source, "instances after the first")
+		instances = instances[:1]
+	}
+	for _, instance := range instances {
+		plugin := c.convertInstance(source, in, instance)
+		if name == "procstat" {
+			if plugin["exe"] == nil && plugin["pattern"] == nil && plugin["pid_file"] == nil {
+				c.report(source, "an instance without exe, pattern or pid_file")
+				continue
+			}
+			c.procstat = append(c.procstat, plugin)
+			continue
+		}
+		c.collected[in.name] = plugin
+	}
+}
+
+// convertInstance converts the options of the input and the ones Telegraf has for every input: the interval, the tags
+// and the fieldpass.
+func (c *converter) convertInstance(source string, in input, instance map[string]any) map[string]any {
+	plugin := map[string]any{}
+	if in.fields != nil {
+		plugin[in.fieldsKey] = in.fields
+	}
+	if in.resources != "" {
+		plugin["resources"] = []any{"*"}
+	}
+	dimensions := map[string]any{}
+	if in.dimensions {
+		for key, value := range c.dimensions {
+			dimensions[key] = value
+		}
+	}
+	for _, key := range sortedKeys(instance) {
+		value := instance[key]
+		if option, ok := in.options[key]; ok {
+			plugin[option] = value
+			continue
+		}
+		switch {
+		case key == in.resources && in.resources != "":
+			plugin["resources"] = value
+		case key == "percpu" && in.name == "cpu":
+			if perCPU, _ := value.(bool); perCPU {
+				plugin["resources"] = []any{"*"}
+			}
+		case key == "interval":
+			if interval, ok := c.interval(source, key, value); ok {
+				plugin["metrics_collection_interval"] = interval
+			}
+		case (key == "fieldpass" || key == "fieldinclude") && in.fieldsKey != "":
+			plugin[in.fieldsKey] = value
+		case key == "tags" && in.dimensions:
+			tags, _ := value.(map[string]any)
+			for k, v := range tags {
+				dimensions[k] = fmt.Sprint(v)
+			}
+		default:
+			c.report(source, key)
+		}
+	}
+	if len(dimensions) > 0 {
+		plugin["append_dimensions"] = dimensions
+	}
+	return plugin
+}
@@ -4,21 +4,31 @@
 package cmdutil
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+	"github.com/aws/amazon-cloudwatch-agent/internal/configaudit"
 	"github.com/aws/amazon-cloudwatch-agent/internal/constants"
 	"github.com/aws/amazon-cloudwatch-agent/internal/mapstructure"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/conditions"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/envsubst"
+	"github.com/aws/amazon-cloudwatch-agent/translator/includes"
 	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/migrations"
+	"github.com/aws/amazon-cloudwatch-agent/translator/presets"
 	_ "github.com/aws/amazon-cloudwatch-agent/translator/registerrules"
 	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toenvconfig"
 	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/totomlconfig"
@@ -43,7 +53,7 @@ func TranslateJsonMapToEnvConfigFile(jsonConfigValue map[string]interface{}, env
 	}
 	bytes := toenvconfig.ToEnvConfig(jsonConfigValue)
 	if err := os.WriteFile(envConfigPath, bytes, 0644); err != nil {
-		log.Panicf("E! Failed to create env config. Reason: %s", err.Error())
+		log.Panicf("E! Failed to create env config. Reason: %s", paths.ReadOnlyHint(err).Error())
 	}
 }
 
@@ -205,6 +215,63 @@ func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{},
 		}
 	}
 
+	// the included files are merged first so that their values are substituted and can list conditions and presets
+	for path, jsonConfigMap := range jsonConfigMapMap {
+		if err := includes.Expand(jsonConfigMap, path); err != nil {
+			return nil, fmt.Errorf("unable to expand includes in %v with error: %v", path, err)
+		}
+	}
+
+	// the deprecated keys are migrated per file, since each file declares the schema version it is written for
+	for path, jsonConfigMap := range jsonConfigMapMap {
+		if err := migrations.Migrate(jsonConfigMap); err != nil {
+			return nil, fmt.Errorf("unable to migrate %v with error: %v", path, err)
+		}
+	}
+
+	// environment variables are substituted before the conditions and presets so that they can be used in them too
+	for path, jsonConfigMap := range jsonConfigMapMap {
+		if err := envsubst.Expand(jsonConfigMap, os.LookupEnv); err != nil {
+			return nil, fmt.Errorf("unable to substitute environment variables in %v with error: %v", path, err)
+		}
+	}
+
+	// conditions are evaluated first so that their config can list presets
+	host := conditions.Host{
+		OS:   ctx.Os(),
+		Arch: runtime.GOARCH,
+		Region: sync.OnceValue(func() string {
+			if ctx.Region() != "" {
+				return ctx.Region()
+			}
+			region, _ := translatorUtil.DetectRegion(ctx.Mode(), ctx.Credentials())
+			return region
+		}),
+	}
+	if ctx.Mode() != config.ModeOnPrem && ctx.Mode() != config.ModeOnPremise {
+		host.Tags = sync.OnceValues(conditions.InstanceTags(ctx.Credentials(), ctx.Region()))
+	}
+	for path, jsonConfigMap := range jsonConfigMapMap {
+		if err := conditions.Expand(jsonConfigMap, host); err != nil {
+			return nil, fmt.Errorf("unable to evaluate conditions in %v with error: %v", path, err)
+		}
+	}
+
+	// presets are expanded per file since the merge only keeps the known sections
+	for path, jsonConfigMap := range jsonConfigMapMap {
+		if err := presets.Expand(jsonConfigMap, ctx.Os()); err != nil {
+			return nil, fmt.Errorf("unable to expand presets in %v with error: %v", path, err)
+		}
+	}
+
+	// the ownership of the sections is collected per file since the merge only keeps the known sections
+	collector := configaudit.NewCollector()
+	for path, jsonConfigMap := range jsonConfigMapMap {
+		if err := collector.Add(path, jsonConfigMap); err != nil {
+			return nil, err
+		}
+	}
+
 	defaultConfig, err := translatorUtil.GetDefaultJsonConfigMap(ctx.Os(), ctx.Mode())
 	if err != nil {
 		return nil, err
@@ -213,6 +280,11 @@ func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{},
 	if err != nil {
 		return nil, err
 	}
+	configAudit, err := collector.State(mergedJsonConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	ctx.SetConfigAudit(configAudit)
 
 	// Json Schema Validation by gojsonschema
 	checkSchema(mergedJsonConfigMap)
@@ -240,9 +312,35 @@ func TranslateJsonMapToYamlConfig(jsonConfigValue interface{}) (interface{}, err
 	return mapstructure.Marshal(cfg)
 }
 
+// TranslateJsonMapToSplitYamlConfigs translates the JSON config like TranslateJsonMapToYamlConfig, then splits its
+// pipelines into the configs of the separate processes keyed by their signals.
+func TranslateJsonMapToSplitYamlConfigs(jsonConfigValue interface{}) (map[string]interface{}, error) {
+	cfg, err := otel.Translate(jsonConfigValue, context.CurrentContext().Os())
+	if err != nil {
+		return nil, err
+	}
+	configs := map[string]interface{}{}
+	for name, partition := range otel.Split(cfg) {
+		if configs[name], err = mapstructure.Marshal(partition); err != nil {
+			return nil, err
+		}
+	}
+	return configs, nil
+}
+
+// TranslateJsonMapToPipelineGraph translates the JSON config like TranslateJsonMapToYamlConfig, then returns the graph
+// of its pipelines.
+func TranslateJsonMapToPipelineGraph(jsonConfigValue interface{}) (*otel.Graph, error) {
+	cfg, err := otel.Translate(jsonConfigValue, context.CurrentContext().Os())
+	if err != nil {
+		return nil, err
+	}
+	return otel.NewGraph(cfg)
+}
+
 func ConfigToTomlFile(config interface{}, tomlConfigFilePath string) error {
 	res := totomlconfig.ToTomlConfig(config)
-	return os.WriteFile(tomlConfigFilePath, []byte(res), fileMode)
+	return paths.ReadOnlyHint(os.WriteFile(tomlConfigFilePath, []byte(res), fileMode))
 }
 
 func ConfigToYamlFile(config interface{}, yamlConfigFilePath string) error {
@@ -253,5 +351,13 @@ func ConfigToYamlFile(config interface{}, yamlConfigFilePath string) error {
 		_ = os.Remove(yamlConfigFilePath)
 		return nil
 	}
-	return os.WriteFile(yamlConfigFilePath, []byte(res), fileMode)
+	return paths.ReadOnlyHint(os.WriteFile(yamlConfigFilePath, []byte(res), fileMode))
+}
+
+func PipelineGraphToJsonFile(graph *otel.Graph, jsonFilePath string) error {
+	res, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return err
+	}
+	return paths.ReadOnlyHint(os.WriteFile(jsonFilePath, append(res, '\n'), fileMode))
 }
@@ -0,0 +1,232 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package conditions merges the config blocks of a JSON config whose conditions, such as the EC2 tags of the
+// instance, are met. This lets one config serve a fleet of hosts with different roles.
+package conditions
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/presets"
+)
+
+const (
+	SectionKey = "conditions"
+
+	nameKey    = "name"
+	whenKey    = "when"
+	configKey  = "config"
+	osKey      = "os"
+	archKey    = "arch"
+	regionKey  = "region"
+	ec2TagsKey = "ec2_tags"
+
+	agentKey = "agent"
+)
+
+// TagsFunc returns the tags of the instance the agent runs on.
+type TagsFunc func() (map[string]string, error)
+
+// Host describes the host the config is translated for.
+type Host struct {
+	// OS is the operating system, e.g. linux or windows.
+	OS string
+	// Arch is the architecture like GOARCH, e.g. amd64 or arm64.
+	Arch string
+	// Region returns the region the agent runs in if the config does not set the agent region.
+	Region func() string
+	// Tags returns the EC2 tags of the instance.
+	Tags TagsFunc
+}
+
+// Expand merges the config of every condition in the conditions section that is met into the JSON config and
+// removes the section. Values set outside of the conditions take precedence, like for presets. The region and
+// tags are only looked up if a condition uses them. If they cannot be looked up, the conditions on them are not met.
+func Expand(jsonConfig map[string]interface{}, host Host) error {
+	section, ok := jsonConfig[SectionKey]
+	if !ok {
+		return nil
+	}
+	delete(jsonConfig, SectionKey)
+	entries, ok := section.([]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be a list, but got %v", SectionKey, section)
+	}
+	var instanceTags map[string]string
+	resolveTags := func() map[string]string {
+		if instanceTags == nil {
+			var err error
+			if host.Tags != nil {
+				instanceTags, err = host.Tags()
+			}
+			if err != nil {
+				log.Printf("W! Unable to get the EC2 tags of the instance, the conditions on %s are not met: %v", ec2TagsKey, err)
+			}
+			if instanceTags == nil {
+				instanceTags = map[string]string{}
+			}
+		}
+		return instanceTags
+	}
+	// the region of the config is the one the agent uses
+	var region string
+	if agent, ok := jsonConfig[agentKey].(map[string]interface{}); ok {
+		region, _ = agent[regionKey].(string)
+	}
+	resolveRegion := func() string {
+		if region == "" && host.Region != nil {
+			region = host.Region()
+		}
+		return region
+	}
+	for index, entry := range entries {
+		c, err := parseCondition(entry)
+		if err != nil {
+			return fmt.Errorf("%s[%d]: %w", SectionKey, index, err)
+		}
+		if c.name == "" {
+			c.name = fmt.Sprintf("%s[%d]", SectionKey, index)
+		}
+		if !c.matches(host, resolveRegion, resolveTags) {
+			log.Printf("I! Condition %s is not met, skipping its config", c.name)
+			continue
+		}
+		log.Printf("I! Condition %s is met, merging its config", c.name)
+		presets.Merge(jsonConfig, c.config)
+	}
+	return nil
+}
+
+type condition struct {
+	name string
+	// os, arch and regions are the accepted values of the host, any value if empty.
+	os      []string
+	arch    []string
+	regions []string
+	// ec2Tags are the accepted values of each tag the instance must have.
+	ec2Tags map[string][]string
+	config  map[string]interface{}
+}
+
+func (c condition) matches(host Host, region func() string, tags func() map[string]string) bool {
+	if len(c.os) > 0 && !slices.Contains(c.os, host.OS) {
+		return false
+	}
+	if len(c.arch) > 0 && !slices.Contains(c.arch, host.Arch) {
+		return false
+	}
+	if len(c.regions) > 0 && !slices.Contains(c.regions, region()) {
+		return false
+	}
+	if len(c.ec2Tags) == 0 {
+		return true
+	}
+	instanceTags := tags()
+	for key, values := range c.ec2Tags {
+		value, ok := instanceTags[key]
+		if !ok || !slices.Contains(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCondition(entry interface{}) (condition, error) {
+	m, ok := entry.(map[string]interface{})
+	if !ok {
+		return condition{}, fmt.Errorf("condition %v must be an object", entry)
+	}
+	var c condition
+	if name, ok := m[nameKey]; ok {
+		if c.name, ok = name.(string); !ok {
+			return c, fmt.Errorf("%s %v must be a string", nameKey, name)
+		}
+	}
+	if c.config, ok = m[configKey].(map[string]interface{}); !ok {
+		return c, fmt.Errorf("%s must be an object", configKey)
+	}
+	if _, ok = c.config[SectionKey]; ok {
+		return c, fmt.Errorf("%s cannot be nested", SectionKey)
+	}
+	when, ok := m[whenKey].(map[string]interface{})
+	if !ok {
+		return c, fmt.Errorf("%s must be an object", whenKey)
+	}
+	var err error
+	for key, value := range when {
+		switch key {
+		case osKey:
+			c.os, err = parseValues(key, value)
+		case archKey:
+			c.arch, err = parseValues(key, value)
+		case regionKey:
+			c.regions, err = parseValues(key, value)
+		case ec2TagsKey:
+			c.ec2Tags, err = parseTags(value)
+		default:
+			err = fmt.Errorf("unknown %s key %q, must be one of %s, %s, %s or %s", whenKey, key, osKey, archKey, regionKey, ec2TagsKey)
+		}
+		if err != nil {
+			return c, err
+		}
+	}
+	if len(c.os) == 0 && len(c.arch) == 0 && len(c.regions) == 0 && len(c.ec2Tags) == 0 {
+		return c, fmt.Errorf("%s must have at least one condition", whenKey)
+	}
+	return c, nil
+}
+
+// parseValues accepts either a value or a list of values.
+func parseValues(key string, value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		var result []string
+		for _, entry := range v {
+			s, ok := entry.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s value %v must be a string", key, entry)
+			}
+			result = append(result, s)
+		}
+		if len(result) == 0 {
+			return nil, fmt.Errorf("%s values cannot be empty", key)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%s value %v must be a string or a list of strings", key, value)
+	}
+}
+
+// parseTags accepts either a value or a list of values for each tag key.
+func parseTags(value interface{}) (map[string][]string, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object, but got %v", ec2TagsKey, value)
+	}
+	result := make(map[string][]string, len(m))
+	for key, v := range m {
+		switch values := v.(type) {
+		case string:
+			result[key] = []string{values}
+		case []interface{}:
+			for _, value := range values {
+				s, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s value %v of %q must be a string", ec2TagsKey, value, key)
+				}
+				result[key] = append(result[key], s)
+			}
+			if len(result[key]) == 0 {
+				return nil, fmt.Errorf("%s values of %q cannot be empty", ec2TagsKey, key)
+			}
+		default:
+			return nil, fmt.Errorf("%s value %v of %q must be a string or a list of strings", ec2TagsKey, v, key)
+		}
+	}
+	return result, nil
+}
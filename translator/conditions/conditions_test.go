@@ -0,0 +1,207 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package conditions
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func unmarshal(t *testing.T, content string) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(content), &m))
+	return m
+}
+
+func staticTags(tags map[string]string) TagsFunc {
+	return func() (map[string]string, error) {
+		return tags, nil
+	}
+}
+
+const fleetConfig = `{
+	"metrics": {
+		"metrics_collected": {
+			"mem": {"measurement": ["mem_used_percent"]}
+		}
+	},
+	"conditions": [
+		{
+			"name": "web",
+			"when": {"ec2_tags": {"Role": ["web", "api"]}},
+			"config": {
+				"logs": {
+					"logs_collected": {
+						"files": {
+							"collect_list": [{"file_path": "/var/log/nginx/access.log", "log_group_name": "nginx"}]
+						}
+					}
+				}
+			}
+		},
+		{
+			"name": "production",
+			"when": {"ec2_tags": {"Role": "web", "Environment": "production"}},
+			"config": {
+				"metrics": {
+					"metrics_collected": {
+						"mem": {"measurement": ["mem_available_percent"]},
+						"disk": {"measurement": ["used_percent"]}
+					}
+				}
+			}
+		}
+	]
+}`
+
+func TestExpand(t *testing.T) {
+	testCases := map[string]struct {
+		tags       map[string]string
+		wantLogs   bool
+		wantDisk   bool
+		wantMemory []interface{}
+	}{
+		"WithWebProduction": {
+			tags:       map[string]string{"Role": "web", "Environment": "production"},
+			wantLogs:   true,
+			wantDisk:   true,
+			wantMemory: []interface{}{"mem_used_percent", "mem_available_percent"},
+		},
+		"WithApi": {
+			tags:       map[string]string{"Role": "api", "Environment": "production"},
+			wantLogs:   true,
+			wantMemory: []interface{}{"mem_used_percent"},
+		},
+		"WithDatabase": {
+			tags:       map[string]string{"Role": "db"},
+			wantMemory: []interface{}{"mem_used_percent"},
+		},
+		"WithNoTags": {
+			wantMemory: []interface{}{"mem_used_percent"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			jsonConfig := unmarshal(t, fleetConfig)
+			require.NoError(t, Expand(jsonConfig, Host{Tags: staticTags(testCase.tags)}))
+			assert.NotContains(t, jsonConfig, SectionKey)
+			assert.Equal(t, testCase.wantLogs, jsonConfig["logs"] != nil)
+			metricsCollected := jsonConfig["metrics"].(map[string]interface{})["metrics_collected"].(map[string]interface{})
+			assert.Equal(t, testCase.wantDisk, metricsCollected["disk"] != nil)
+			assert.Equal(t, testCase.wantMemory, metricsCollected["mem"].(map[string]interface{})["measurement"])
+		})
+	}
+}
+
+const platformConfig = `{
+	"metrics": {
+		"endpoint_override": "https://monitoring.us-east-1.amazonaws.com"
+	},
+	"conditions": [
+		{
+			"name": "windows",
+			"when": {"os": "windows"},
+			"config": {"metrics": {"metrics_collected": {"Memory": {"measurement": ["% Committed Bytes In Use"]}}}}
+		},
+		{
+			"name": "linux-arm",
+			"when": {"os": ["linux", "darwin"], "arch": "arm64"},
+			"config": {"metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}}}
+		},
+		{
+			"name": "china",
+			"when": {"region": ["cn-north-1", "cn-northwest-1"]},
+			"config": {"metrics": {"endpoint_override": "https://monitoring.cn-north-1.amazonaws.com.cn", "namespace": "China"}}
+		}
+	]
+}`
+
+func TestExpandPlatform(t *testing.T) {
+	testCases := map[string]struct {
+		host        Host
+		agentRegion string
+		want        map[string]interface{}
+	}{
+		"WithWindows": {
+			host: Host{OS: "windows", Arch: "amd64"},
+			want: map[string]interface{}{
+				"endpoint_override": "https://monitoring.us-east-1.amazonaws.com",
+				"metrics_collected": map[string]interface{}{"Memory": map[string]interface{}{"measurement": []interface{}{"% Committed Bytes In Use"}}},
+			},
+		},
+		"WithLinuxArm": {
+			host: Host{OS: "linux", Arch: "arm64", Region: func() string { return "us-west-2" }},
+			want: map[string]interface{}{
+				"endpoint_override": "https://monitoring.us-east-1.amazonaws.com",
+				"metrics_collected": map[string]interface{}{"mem": map[string]interface{}{"measurement": []interface{}{"mem_used_percent"}}},
+			},
+		},
+		"WithLinuxAmd": {
+			host: Host{OS: "linux", Arch: "amd64"},
+			want: map[string]interface{}{"endpoint_override": "https://monitoring.us-east-1.amazonaws.com"},
+		},
+		"WithRegion": {
+			host: Host{OS: "linux", Arch: "amd64", Region: func() string { return "cn-north-1" }},
+			want: map[string]interface{}{"endpoint_override": "https://monitoring.us-east-1.amazonaws.com", "namespace": "China"},
+		},
+		"WithAgentRegion": {
+			host:        Host{OS: "linux", Arch: "amd64", Region: func() string { return "us-east-1" }},
+			agentRegion: "cn-northwest-1",
+			want:        map[string]interface{}{"endpoint_override": "https://monitoring.us-east-1.amazonaws.com", "namespace": "China"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			jsonConfig := unmarshal(t, platformConfig)
+			if testCase.agentRegion != "" {
+				jsonConfig["agent"] = map[string]interface{}{"region": testCase.agentRegion}
+			}
+			require.NoError(t, Expand(jsonConfig, testCase.host))
+			assert.Equal(t, testCase.want, jsonConfig["metrics"])
+		})
+	}
+}
+
+func TestExpandLooksUpTagsOnce(t *testing.T) {
+	var calls int
+	tags := func() (map[string]string, error) {
+		calls++
+		return nil, errors.New("no instance metadata")
+	}
+	jsonConfig := unmarshal(t, fleetConfig)
+	require.NoError(t, Expand(jsonConfig, Host{Tags: tags}))
+	assert.Equal(t, 1, calls)
+	assert.NotContains(t, jsonConfig, "logs")
+
+	// without conditions, the tags are not needed
+	require.NoError(t, Expand(unmarshal(t, `{"metrics": {}}`), Host{Tags: tags}))
+	assert.Equal(t, 1, calls)
+}
+
+func TestExpandErrors(t *testing.T) {
+	tags := staticTags(map[string]string{"Role": "web"})
+	for name, content := range map[string]string{
+		"WithSection":        `{"conditions": {"when": {"ec2_tags": {"Role": "web"}}, "config": {}}}`,
+		"WithEntry":          `{"conditions": ["web"]}`,
+		"WithMissingConfig":  `{"conditions": [{"when": {"ec2_tags": {"Role": "web"}}}]}`,
+		"WithMissingWhen":    `{"conditions": [{"config": {}}]}`,
+		"WithEmptyWhen":      `{"conditions": [{"when": {}, "config": {}}]}`,
+		"WithUnknownKey":     `{"conditions": [{"when": {"hostname": "web-1"}, "config": {}}]}`,
+		"WithInvalidTag":     `{"conditions": [{"when": {"ec2_tags": {"Role": 1}}, "config": {}}]}`,
+		"WithEmptyTagValues": `{"conditions": [{"when": {"ec2_tags": {"Role": []}}, "config": {}}]}`,
+		"WithNested":         `{"conditions": [{"when": {"ec2_tags": {"Role": "web"}}, "config": {"conditions": []}}]}`,
+		"WithInvalidName":    `{"conditions": [{"name": 1, "when": {"ec2_tags": {"Role": "web"}}, "config": {}}]}`,
+		"WithInvalidOS":      `{"conditions": [{"when": {"os": 1}, "config": {}}]}`,
+		"WithEmptyRegions":   `{"conditions": [{"when": {"region": []}, "config": {}}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, Expand(unmarshal(t, content), Host{Tags: tags}))
+		})
+	}
+}
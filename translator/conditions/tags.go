@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package conditions
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
+)
+
+const tagsTimeout = 30 * time.Second
+
+// InstanceTags looks up the tags of the EC2 instance in the instance metadata, or with DescribeTags if the
+// tags are not allowed in the instance metadata. The credentials are the ones of the common config.
+func InstanceTags(credentials map[string]string, region string) TagsFunc {
+	return func() (map[string]string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), tagsTimeout)
+		defer cancel()
+		return instanceTags(ctx, ec2metadataprovider.SharedMetadataProvider(), region, func(region string) ec2iface.EC2API {
+			credentialConfig := &configaws.CredentialConfig{
+				Region:   region,
+				Profile:  credentials[commonconfig.CredentialProfile],
+				Filename: credentials[commonconfig.CredentialFile],
+			}
+			return ec2.New(credentialConfig.Credentials(), &aws.Config{
+				LogLevel: configaws.SDKLogLevel(),
+				Logger:   configaws.SDKLogger{},
+			})
+		})
+	}
+}
+
+func instanceTags(
+	ctx context.Context,
+	metadataProvider ec2metadataprovider.MetadataProvider,
+	region string,
+	ec2Provider func(region string) ec2iface.EC2API,
+) (map[string]string, error) {
+	if keys, err := metadataProvider.InstanceTags(ctx); err == nil {
+		tags := make(map[string]string, len(keys))
+		for _, key := range keys {
+			if tags[key], err = metadataProvider.InstanceTagValue(ctx, key); err != nil {
+				return nil, err
+			}
+		}
+		return tags, nil
+	}
+	doc, err := metadataProvider.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if region == "" {
+		region = doc.Region
+	}
+	tags := make(map[string]string)
+	input := &ec2.DescribeTagsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("resource-id"),
+			Values: aws.StringSlice([]string{doc.InstanceID}),
+		}},
+	}
+	err = ec2Provider(region).DescribeTagsPagesWithContext(ctx, input, func(output *ec2.DescribeTagsOutput, _ bool) bool {
+		for _, tag := range output.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
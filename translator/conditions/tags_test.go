@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package conditions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
+)
+
+type mockMetadataProvider struct {
+	ec2metadataprovider.MetadataProvider
+	tags map[string]string
+}
+
+func (m *mockMetadataProvider) Get(context.Context) (ec2metadata.EC2InstanceIdentityDocument, error) {
+	return ec2metadata.EC2InstanceIdentityDocument{InstanceID: "i-123456789", Region: "us-west-2"}, nil
+}
+
+func (m *mockMetadataProvider) InstanceTags(context.Context) ([]string, error) {
+	if m.tags == nil {
+		return nil, errors.New("tags are not allowed in the instance metadata")
+	}
+	var keys []string
+	for key := range m.tags {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (m *mockMetadataProvider) InstanceTagValue(_ context.Context, key string) (string, error) {
+	return m.tags[key], nil
+}
+
+type mockEC2 struct {
+	ec2iface.EC2API
+	input *ec2.DescribeTagsInput
+}
+
+func (m *mockEC2) DescribeTagsPagesWithContext(_ aws.Context, input *ec2.DescribeTagsInput, fn func(*ec2.DescribeTagsOutput, bool) bool, _ ...request.Option) error {
+	m.input = input
+	if !fn(&ec2.DescribeTagsOutput{Tags: []*ec2.TagDescription{{Key: aws.String("Role"), Value: aws.String("web")}}}, false) {
+		return nil
+	}
+	fn(&ec2.DescribeTagsOutput{Tags: []*ec2.TagDescription{{Key: aws.String("Environment"), Value: aws.String("production")}}}, true)
+	return nil
+}
+
+func TestInstanceTagsFromMetadata(t *testing.T) {
+	metadataProvider := &mockMetadataProvider{tags: map[string]string{"Role": "web"}}
+	tags, err := instanceTags(context.Background(), metadataProvider, "", func(string) ec2iface.EC2API {
+		t.Fatal("DescribeTags should not be called")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Role": "web"}, tags)
+}
+
+func TestInstanceTagsFromDescribeTags(t *testing.T) {
+	client := &mockEC2{}
+	var gotRegion string
+	tags, err := instanceTags(context.Background(), &mockMetadataProvider{}, "", func(region string) ec2iface.EC2API {
+		gotRegion = region
+		return client
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Role": "web", "Environment": "production"}, tags)
+	assert.Equal(t, "us-west-2", gotRegion)
+	assert.Equal(t, []*string{aws.String("i-123456789")}, client.input.Filters[0].Values)
+}
@@ -186,7 +186,7 @@ func DefaultJsonConfig(os string, mode string) string {
 		} else {
 			return defaultWindowsOnPremConfig
 		}
-	case OS_TYPE_DARWIN:
+	case OS_TYPE_DARWIN, OS_TYPE_FREEBSD:
 		if mode == ModeEC2 {
 			return defaultDarwinEC2Config
 		} else {
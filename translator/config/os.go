@@ -9,12 +9,13 @@ import (
 	"strings"
 )
 
-var supportedOs = [...]string{OS_TYPE_LINUX, OS_TYPE_WINDOWS, OS_TYPE_DARWIN}
+var supportedOs = [...]string{OS_TYPE_LINUX, OS_TYPE_WINDOWS, OS_TYPE_DARWIN, OS_TYPE_FREEBSD}
 
 const (
 	OS_TYPE_LINUX   = "linux"
 	OS_TYPE_WINDOWS = "windows"
 	OS_TYPE_DARWIN  = "darwin"
+	OS_TYPE_FREEBSD = "freebsd"
 )
 
 func ToValidOs(os string) string {
@@ -6,6 +6,7 @@ package config
 const (
 	RegionTypeAgentConfigJson = "ACJ"
 	RegionTypeCredsMap        = "CM"
+	RegionTypeCommonConfig    = "CC"
 	RegionTypeEC2Metadata     = "EC2M"
 	RegionTypeECSMetadata     = "ECSM"
 	RegionTypeNotFound        = "RNF"
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/configaudit"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 )
 
@@ -40,12 +41,15 @@ type Context struct {
 	kubernetesMode      string
 	shortMode           string
 	credentials         map[string]string
+	region              string
 	proxy               map[string]string
 	ssl                 map[string]string
 	cloudWatchLogConfig map[string]interface{}
 	runInContainer      bool
 	agentLogFile        string
 	omitHostname        bool
+	// configAudit is the state of the merged json config, with the ownership of its sections.
+	configAudit *configaudit.State
 }
 
 func (ctx *Context) Os() string {
@@ -107,6 +111,10 @@ func (ctx *Context) Credentials() map[string]string {
 	return ctx.credentials
 }
 
+func (ctx *Context) Region() string {
+	return ctx.region
+}
+
 func (ctx *Context) SSL() map[string]string {
 	return ctx.ssl
 }
@@ -154,6 +162,10 @@ func (ctx *Context) SetCredentials(creds map[string]string) {
 	ctx.credentials = creds
 }
 
+func (ctx *Context) SetRegion(region string) {
+	ctx.region = region
+}
+
 func (ctx *Context) SetSSL(ssl map[string]string) {
 	ctx.ssl = ssl
 }
@@ -193,3 +205,11 @@ func (ctx *Context) GetOmitHostname() bool {
 func (ctx *Context) SetOmitHostname(omitHostname bool) {
 	ctx.omitHostname = omitHostname
 }
+
+func (ctx *Context) ConfigAudit() *configaudit.State {
+	return ctx.configAudit
+}
+
+func (ctx *Context) SetConfigAudit(configAudit *configaudit.State) {
+	ctx.configAudit = configAudit
+}
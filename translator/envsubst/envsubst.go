@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package envsubst replaces the ${NAME} references to environment variables in the string values of a JSON config,
+// so that every field can be set from the environment, not only the ones the agent components expand themselves.
+package envsubst
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	SectionKey = "env_substitution"
+
+	// ModeLenient leaves the references to variables that are not set unchanged and logs a warning.
+	ModeLenient = "lenient"
+	// ModeStrict fails the translation if a referenced variable is not set.
+	ModeStrict = "strict"
+)
+
+// reference matches ${NAME} and the escaped $${NAME}. The name must be a valid variable name, so the ${aws:InstanceId}
+// placeholders of the append_dimensions are not references.
+var reference = regexp.MustCompile(`\$(\$?)\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// LookupFunc returns the value of the environment variable and if it is set, like os.LookupEnv.
+type LookupFunc func(name string) (string, bool)
+
+// Expand replaces the references in the string values of the JSON config, including the ones nested in objects and
+// lists, with the values of the variables and removes the env_substitution section, which sets the mode. The keys
+// are not expanded. An escaped $${NAME} is replaced with ${NAME}.
+func Expand(jsonConfig map[string]interface{}, lookup LookupFunc) error {
+	mode := ModeLenient
+	if section, ok := jsonConfig[SectionKey]; ok {
+		delete(jsonConfig, SectionKey)
+		if mode, ok = section.(string); !ok || (mode != ModeLenient && mode != ModeStrict) {
+			return fmt.Errorf("%s must be %q or %q, but got %v", SectionKey, ModeLenient, ModeStrict, section)
+		}
+	}
+	e := expander{lookup: lookup}
+	for key, value := range jsonConfig {
+		jsonConfig[key] = e.expand(key, value)
+	}
+	if len(e.missing) == 0 {
+		return nil
+	}
+	sort.Strings(e.missing)
+	if mode == ModeStrict {
+		return fmt.Errorf("environment variables are not set: %s", strings.Join(e.missing, ", "))
+	}
+	log.Printf("W! Environment variables are not set, leaving their references unchanged: %s", strings.Join(e.missing, ", "))
+	return nil
+}
+
+type expander struct {
+	lookup LookupFunc
+	// missing are the variables that are not set with the path of the value referencing them.
+	missing []string
+}
+
+func (e *expander) expand(path string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return e.expandString(path, v)
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = e.expand(path+"."+key, child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = e.expand(fmt.Sprintf("%s[%d]", path, i), child)
+		}
+	}
+	return value
+}
+
+func (e *expander) expandString(path string, s string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	return reference.ReplaceAllStringFunc(s, func(match string) string {
+		groups := reference.FindStringSubmatch(match)
+		if groups[1] != "" {
+			return match[1:]
+		}
+		value, ok := e.lookup(groups[2])
+		if !ok {
+			e.missing = append(e.missing, fmt.Sprintf("%s (%s)", groups[2], path))
+			return match
+		}
+		return value
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package envsubst
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLookup(name string) (string, bool) {
+	value, ok := map[string]string{
+		"STAGE":     "prod",
+		"LOG_DIR":   "/var/log/app",
+		"ENDPOINT":  "https://monitoring.us-west-2.amazonaws.com",
+		"EMPTY_VAR": "",
+	}[name]
+	return value, ok
+}
+
+func TestExpand(t *testing.T) {
+	jsonConfig := map[string]interface{}{
+		"agent": map[string]interface{}{
+			"endpoint_override": "${ENDPOINT}",
+			"interval":          60.0,
+		},
+		"metrics": map[string]interface{}{
+			"namespace": "App/${STAGE}",
+			"append_dimensions": map[string]interface{}{
+				"InstanceId": "${aws:InstanceId}",
+				"${STAGE}":   "key is not expanded",
+			},
+			"aggregation_dimensions": []interface{}{[]interface{}{"${STAGE}", "Service"}},
+		},
+		"logs": map[string]interface{}{
+			"logs_collected": map[string]interface{}{
+				"files": map[string]interface{}{
+					"collect_list": []interface{}{
+						map[string]interface{}{
+							"file_path":       "${LOG_DIR}/app.log",
+							"log_group_name":  "app-${STAGE}${EMPTY_VAR}",
+							"log_stream_name": "$${STAGE}",
+						},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, Expand(jsonConfig, testLookup))
+	assert.Equal(t, map[string]interface{}{
+		"agent": map[string]interface{}{
+			"endpoint_override": "https://monitoring.us-west-2.amazonaws.com",
+			"interval":          60.0,
+		},
+		"metrics": map[string]interface{}{
+			"namespace": "App/prod",
+			"append_dimensions": map[string]interface{}{
+				"InstanceId": "${aws:InstanceId}",
+				"${STAGE}":   "key is not expanded",
+			},
+			"aggregation_dimensions": []interface{}{[]interface{}{"prod", "Service"}},
+		},
+		"logs": map[string]interface{}{
+			"logs_collected": map[string]interface{}{
+				"files": map[string]interface{}{
+					"collect_list": []interface{}{
+						map[string]interface{}{
+							"file_path":       "/var/log/app/app.log",
+							"log_group_name":  "app-prod",
+							"log_stream_name": "${STAGE}",
+						},
+					},
+				},
+			},
+		},
+	}, jsonConfig)
+}
+
+func TestExpandMissing(t *testing.T) {
+	newConfig := func(mode interface{}) map[string]interface{} {
+		jsonConfig := map[string]interface{}{
+			"metrics": map[string]interface{}{
+				"namespace":         "App/${MISSING}",
+				"endpoint_override": "${ALSO_MISSING}",
+			},
+		}
+		if mode != nil {
+			jsonConfig[SectionKey] = mode
+		}
+		return jsonConfig
+	}
+
+	jsonConfig := newConfig(nil)
+	require.NoError(t, Expand(jsonConfig, testLookup))
+	assert.Equal(t, "App/${MISSING}", jsonConfig["metrics"].(map[string]interface{})["namespace"])
+
+	jsonConfig = newConfig(ModeLenient)
+	require.NoError(t, Expand(jsonConfig, testLookup))
+	assert.NotContains(t, jsonConfig, SectionKey)
+
+	err := Expand(newConfig(ModeStrict), testLookup)
+	assert.EqualError(t, err, "environment variables are not set: ALSO_MISSING (metrics.endpoint_override), MISSING (metrics.namespace)")
+
+	err = Expand(newConfig("ignore"), testLookup)
+	assert.ErrorContains(t, err, `env_substitution must be "lenient" or "strict"`)
+}
@@ -0,0 +1,103 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package includes merges the JSON files an agent JSON config includes, so that common blocks such as the proxy,
+// the credentials or a standard set of metrics can be shared by many configs.
+package includes
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/presets"
+	translatorUtil "github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+// Key is the directive of an object that includes one file, or a list of files, into the object.
+const Key = "$include"
+
+// Expand merges the files included by the objects of the JSON config read from the path into them and removes the
+// directives. Relative paths are resolved from the directory of the file with the directive. The values of the
+// object take precedence over the included ones, like for presets, and the files listed first over the ones after
+// them. The included files can include files, but not one that includes them.
+func Expand(jsonConfig map[string]interface{}, path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	return expandObject(jsonConfig, filepath.Dir(abs), []string{abs})
+}
+
+// expandObject expands the directives of the object and its descendants. The stack holds the files being included,
+// from the config down to the current one, to detect cycles.
+func expandObject(object map[string]interface{}, dir string, stack []string) error {
+	for _, value := range object {
+		if err := expandValue(value, dir, stack); err != nil {
+			return err
+		}
+	}
+	directive, ok := object[Key]
+	if !ok {
+		return nil
+	}
+	delete(object, Key)
+	paths, err := parsePaths(directive)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		path = filepath.Clean(path)
+		for _, including := range stack {
+			if including == path {
+				return fmt.Errorf("include cycle: %s -> %s", strings.Join(stack, " -> "), path)
+			}
+		}
+		included, err := translatorUtil.GetJsonMapFromFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to include %s: %w", path, err)
+		}
+		if err = expandObject(included, filepath.Dir(path), append(stack[:len(stack):len(stack)], path)); err != nil {
+			return err
+		}
+		presets.Merge(object, included)
+	}
+	return nil
+}
+
+func expandValue(value interface{}, dir string, stack []string) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return expandObject(v, dir, stack)
+	case []interface{}:
+		for _, entry := range v {
+			if err := expandValue(entry, dir, stack); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parsePaths(directive interface{}) ([]string, error) {
+	switch d := directive.(type) {
+	case string:
+		if d != "" {
+			return []string{d}, nil
+		}
+	case []interface{}:
+		paths := make([]string, 0, len(d))
+		for _, entry := range d {
+			path, ok := entry.(string)
+			if !ok || path == "" {
+				return nil, fmt.Errorf("%s must be a path or a list of paths, but got %v", Key, directive)
+			}
+			paths = append(paths, path)
+		}
+		return paths, nil
+	}
+	return nil, fmt.Errorf("%s must be a path or a list of paths, but got %v", Key, directive)
+}
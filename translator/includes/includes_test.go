@@ -0,0 +1,98 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package includes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestExpand(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "common", "proxy.json"), `{"http_proxy": "http://proxy:3128", "no_proxy": "169.254.169.254"}`)
+	writeFile(t, filepath.Join(dir, "common", "agent.json"), `{
+		"agent": {"region": "us-west-2", "metrics_collection_interval": 60, "proxy": {"$include": "proxy.json"}}
+	}`)
+	writeFile(t, filepath.Join(dir, "common", "metrics.json"), `{
+		"mem": {"measurement": ["mem_used_percent"]},
+		"disk": {"measurement": ["used_percent"], "resources": ["/"]}
+	}`)
+	jsonConfig := map[string]interface{}{
+		"$include": "common/agent.json",
+		"agent": map[string]interface{}{
+			"metrics_collection_interval": 10.0,
+		},
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"$include": []interface{}{filepath.Join(dir, "common", "metrics.json")},
+				"disk": map[string]interface{}{
+					"resources": []interface{}{"/data"},
+				},
+			},
+		},
+	}
+	require.NoError(t, Expand(jsonConfig, filepath.Join(dir, "app.json")))
+	assert.Equal(t, map[string]interface{}{
+		"agent": map[string]interface{}{
+			"metrics_collection_interval": 10.0,
+			"region":                      "us-west-2",
+			"proxy": map[string]interface{}{
+				"http_proxy": "http://proxy:3128",
+				"no_proxy":   "169.254.169.254",
+			},
+		},
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"mem": map[string]interface{}{"measurement": []interface{}{"mem_used_percent"}},
+				"disk": map[string]interface{}{
+					"measurement": []interface{}{"used_percent"},
+					"resources":   []interface{}{"/data", "/"},
+				},
+			},
+		},
+	}, jsonConfig)
+}
+
+func TestExpandErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.json"), `{"$include": "b.json"}`)
+	writeFile(t, filepath.Join(dir, "b.json"), `{"logs": {"$include": "a.json"}}`)
+	writeFile(t, filepath.Join(dir, "self.json"), `{"$include": "./self.json"}`)
+	testCases := map[string]struct {
+		jsonConfig map[string]interface{}
+		wantErr    string
+	}{
+		"Cycle": {
+			jsonConfig: map[string]interface{}{"$include": "a.json"},
+			wantErr:    "include cycle: " + filepath.Join(dir, "app.json") + " -> " + filepath.Join(dir, "a.json") + " -> " + filepath.Join(dir, "b.json") + " -> " + filepath.Join(dir, "a.json"),
+		},
+		"Self": {
+			jsonConfig: map[string]interface{}{"$include": "self.json"},
+			wantErr:    "include cycle",
+		},
+		"MissingFile": {
+			jsonConfig: map[string]interface{}{"$include": "missing.json"},
+			wantErr:    "unable to include " + filepath.Join(dir, "missing.json"),
+		},
+		"InvalidDirective": {
+			jsonConfig: map[string]interface{}{"$include": []interface{}{"a.json", 1.0}},
+			wantErr:    "$include must be a path or a list of paths",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorContains(t, Expand(testCase.jsonConfig, filepath.Join(dir, "app.json")), testCase.wantErr)
+		})
+	}
+}
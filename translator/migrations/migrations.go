@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package migrations rewrites the deprecated keys of a JSON config to their replacements, based on the schema
+// version the config declares, so the configs written for an older agent keep being translated the same way.
+package migrations
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+const (
+	SectionKey = "schema_version"
+
+	// CurrentVersion is the schema version of the configs written for this agent. A config without a schema_version
+	// is the version 1, before the versions were introduced.
+	CurrentVersion = 2
+)
+
+// migration is a deprecated key of a schema version, with the key replacing it from the next version. A migration
+// without a replacement removes the key, for the features that were dropped.
+type migration struct {
+	// version is the last schema version the key is supported by.
+	version     int
+	path        []string
+	replacement string
+	reason      string
+}
+
+var migrations = []migration{
+	{
+		version:     1,
+		path:        []string{"traces", "traces_collected", "app_signals"},
+		replacement: "application_signals",
+	},
+	{
+		version:     1,
+		path:        []string{"logs", "metrics_collected", "app_signals"},
+		replacement: "application_signals",
+	},
+	{
+		version: 1,
+		path:    []string{"csm"},
+		reason:  "client side monitoring is no longer supported",
+	},
+}
+
+// Migrate applies the migrations of the schema versions from the one the JSON config declares to the current one,
+// logging a warning for each deprecated key rewritten, and removes the schema_version section. It fails for the
+// configs written for a newer agent, instead of ignoring the keys it does not know of.
+func Migrate(jsonConfig map[string]interface{}) error {
+	version := 1
+	if section, ok := jsonConfig[SectionKey]; ok {
+		delete(jsonConfig, SectionKey)
+		v, ok := section.(float64)
+		if !ok || v != float64(int(v)) || v < 1 {
+			return fmt.Errorf("%s must be a positive integer, but got %v", SectionKey, section)
+		}
+		version = int(v)
+	}
+	if version > CurrentVersion {
+		return fmt.Errorf("%s %d is not supported by this agent, the latest is %d", SectionKey, version, CurrentVersion)
+	}
+	for _, m := range migrations {
+		if m.version >= version {
+			m.apply(jsonConfig)
+		}
+	}
+	return nil
+}
+
+func (m migration) apply(jsonConfig map[string]interface{}) {
+	parent := jsonConfig
+	for _, key := range m.path[:len(m.path)-1] {
+		child, ok := parent[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		parent = child
+	}
+	key := m.path[len(m.path)-1]
+	value, ok := parent[key]
+	if !ok {
+		return
+	}
+	delete(parent, key)
+	name := strings.Join(m.path, ".")
+	switch {
+	case m.replacement == "":
+		log.Printf("W! %s is deprecated since schema_version %d and is removed, %s", name, m.version+1, m.reason)
+	case parent[m.replacement] != nil:
+		log.Printf("W! %s is deprecated since schema_version %d and is ignored, since %s is set", name, m.version+1, m.replacement)
+	default:
+		parent[m.replacement] = value
+		log.Printf("W! %s is deprecated since schema_version %d, it is replaced by %s", name, m.version+1, m.replacement)
+	}
+}
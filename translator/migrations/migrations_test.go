@@ -0,0 +1,83 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+)
+
+func unmarshal(t *testing.T, content string) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(content), &m))
+	return m
+}
+
+func TestMigrate(t *testing.T) {
+	testCases := map[string]struct {
+		input   string
+		want    string
+		wantErr string
+	}{
+		"WithoutVersion": {
+			input: `{
+				"csm": {"memory_limit_in_mb": 20},
+				"traces": {"traces_collected": {"app_signals": {}}},
+				"logs": {"metrics_collected": {"app_signals": {"hosted_in": "eks"}}}
+			}`,
+			want: `{
+				"traces": {"traces_collected": {"application_signals": {}}},
+				"logs": {"metrics_collected": {"application_signals": {"hosted_in": "eks"}}}
+			}`,
+		},
+		"WithReplacementSet": {
+			input: `{"logs": {"metrics_collected": {"app_signals": {"hosted_in": "a"}, "application_signals": {"hosted_in": "b"}}}}`,
+			want:  `{"logs": {"metrics_collected": {"application_signals": {"hosted_in": "b"}}}}`,
+		},
+		"WithCurrentVersion": {
+			input: `{"schema_version": 2, "csm": {}, "traces": {"traces_collected": {"app_signals": {}}}}`,
+			want:  `{"csm": {}, "traces": {"traces_collected": {"app_signals": {}}}}`,
+		},
+		"WithOldVersion": {
+			input: `{"schema_version": 1, "csm": {}}`,
+			want:  `{}`,
+		},
+		"WithNewerVersion": {
+			input:   `{"schema_version": 3}`,
+			wantErr: "schema_version 3 is not supported by this agent, the latest is 2",
+		},
+		"WithInvalidVersion": {
+			input:   `{"schema_version": "2"}`,
+			wantErr: "schema_version must be a positive integer",
+		},
+		"WithFractionalVersion": {
+			input:   `{"schema_version": 1.5}`,
+			wantErr: "schema_version must be a positive integer",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			jsonConfig := unmarshal(t, testCase.input)
+			err := Migrate(jsonConfig)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, unmarshal(t, testCase.want), jsonConfig)
+		})
+	}
+}
+
+func TestSchemaVersionMatchesSchema(t *testing.T) {
+	schema := unmarshal(t, config.GetJsonSchema())
+	property := schema["properties"].(map[string]interface{})[SectionKey].(map[string]interface{})
+	assert.EqualValues(t, CurrentVersion, property["maximum"])
+}
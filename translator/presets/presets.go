@@ -0,0 +1,174 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package presets expands the named presets of a JSON config into the curated metrics, process checks and log
+// collection for well known workloads.
+package presets
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+)
+
+const (
+	SectionKey = "presets"
+
+	SQLServer = "sql_server"
+	Exchange  = "exchange"
+
+	nameKey        = "name"
+	sqlInstanceKey = "instance"
+
+	defaultSQLInstance = "MSSQLSERVER"
+)
+
+var (
+	//go:embed sql_server.json
+	sqlServerPreset string
+	//go:embed exchange.json
+	exchangePreset string
+
+	sqlInstancePattern = regexp.MustCompile(`^[A-Za-z0-9_#$]{1,16}$`)
+)
+
+type preset struct {
+	content string
+	os      string
+	// render replaces the placeholders in the content with the preset options.
+	render func(content string, options map[string]interface{}) (string, error)
+}
+
+var registry = map[string]preset{
+	SQLServer: {content: sqlServerPreset, os: config.OS_TYPE_WINDOWS, render: renderSQLServer},
+	Exchange:  {content: exchangePreset, os: config.OS_TYPE_WINDOWS},
+}
+
+// Names returns the names of the supported presets.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Expand merges the presets listed in the presets section into the JSON config and removes the section.
+// Values set in the JSON config take precedence over the ones from the presets, and list entries from the
+// presets are appended unless the JSON config already has an identical entry.
+func Expand(jsonConfig map[string]interface{}, osType string) error {
+	section, ok := jsonConfig[SectionKey]
+	if !ok {
+		return nil
+	}
+	delete(jsonConfig, SectionKey)
+	entries, ok := section.([]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be a list, but got %v", SectionKey, section)
+	}
+	for _, entry := range entries {
+		name, options, err := parseEntry(entry)
+		if err != nil {
+			return err
+		}
+		p, ok := registry[name]
+		if !ok {
+			return fmt.Errorf("unknown preset %q, supported presets are %s", name, strings.Join(Names(), ", "))
+		}
+		if p.os != "" && p.os != osType {
+			return fmt.Errorf("preset %q is only supported on %s", name, p.os)
+		}
+		content := p.content
+		if p.render != nil {
+			if content, err = p.render(content, options); err != nil {
+				return fmt.Errorf("preset %q: %w", name, err)
+			}
+		}
+		var presetConfig map[string]interface{}
+		if err = json.Unmarshal([]byte(content), &presetConfig); err != nil {
+			return fmt.Errorf("preset %q: %w", name, err)
+		}
+		Merge(jsonConfig, presetConfig)
+	}
+	return nil
+}
+
+// parseEntry accepts either the name of the preset or an object with the name and the options of the preset.
+func parseEntry(entry interface{}) (string, map[string]interface{}, error) {
+	switch v := entry.(type) {
+	case string:
+		return v, nil, nil
+	case map[string]interface{}:
+		name, ok := v[nameKey].(string)
+		if !ok {
+			return "", nil, fmt.Errorf("preset %v is missing the %s", v, nameKey)
+		}
+		return name, v, nil
+	}
+	return "", nil, fmt.Errorf("preset %v must be a name or an object", entry)
+}
+
+func renderSQLServer(content string, options map[string]interface{}) (string, error) {
+	instance := defaultSQLInstance
+	if v, ok := options[sqlInstanceKey]; ok {
+		s, ok := v.(string)
+		if !ok || !sqlInstancePattern.MatchString(s) {
+			return "", fmt.Errorf("invalid SQL Server instance name %v", v)
+		}
+		instance = s
+	}
+	// the counters of named instances are in the MSSQL$<instance> objects
+	counterPrefix := "SQLServer"
+	if !strings.EqualFold(instance, defaultSQLInstance) {
+		counterPrefix = "MSSQL$" + instance
+	}
+	return strings.NewReplacer(
+		"{sql_instance}", instance,
+		"{sql_counter_prefix}", counterPrefix,
+	).Replace(content), nil
+}
+
+// Merge merges src into dst. The values already in dst take precedence, objects are merged and the list
+// entries of src that dst does not already have are appended.
+func Merge(dst, src map[string]interface{}) {
+	for key, srcVal := range src {
+		dstVal, ok := dst[key]
+		if !ok {
+			dst[key] = srcVal
+			continue
+		}
+		switch d := dstVal.(type) {
+		case map[string]interface{}:
+			if s, ok := srcVal.(map[string]interface{}); ok {
+				Merge(d, s)
+			}
+		case []interface{}:
+			if s, ok := srcVal.([]interface{}); ok {
+				dst[key] = mergeList(d, s)
+			}
+		}
+	}
+}
+
+func mergeList(dst, src []interface{}) []interface{} {
+	for _, srcVal := range src {
+		found := false
+		for _, dstVal := range dst {
+			if reflect.DeepEqual(dstVal, srcVal) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, srcVal)
+		}
+	}
+	return dst
+}
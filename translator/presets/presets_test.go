@@ -0,0 +1,88 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package presets
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+)
+
+func unmarshal(t *testing.T, content string) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(content), &m))
+	return m
+}
+
+func TestExpandValidatesAgainstSchema(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			jsonConfig := unmarshal(t, `{"presets": ["`+name+`"]}`)
+			require.NoError(t, Expand(jsonConfig, config.OS_TYPE_WINDOWS))
+			assert.NotContains(t, jsonConfig, SectionKey)
+			result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(config.GetJsonSchema()), gojsonschema.NewGoLoader(jsonConfig))
+			require.NoError(t, err)
+			assert.True(t, result.Valid(), "%v", result.Errors())
+		})
+	}
+}
+
+func TestExpandSQLServerNamedInstance(t *testing.T) {
+	jsonConfig := unmarshal(t, `{"presets": [{"name": "sql_server", "instance": "SQLEXPRESS"}]}`)
+	require.NoError(t, Expand(jsonConfig, config.OS_TYPE_WINDOWS))
+	metricsCollected := jsonConfig["metrics"].(map[string]interface{})["metrics_collected"].(map[string]interface{})
+	assert.Contains(t, metricsCollected, "MSSQL$SQLEXPRESS:SQL Statistics")
+	assert.NotContains(t, metricsCollected, "SQLServer:SQL Statistics")
+	files := jsonConfig["logs"].(map[string]interface{})["logs_collected"].(map[string]interface{})["files"].(map[string]interface{})
+	entry := files["collect_list"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, `C:\Program Files\Microsoft SQL Server\MSSQL*.SQLEXPRESS\MSSQL\Log\ERRORLOG`, entry["file_path"])
+
+	jsonConfig = unmarshal(t, `{"presets": [{"name": "sql_server", "instance": "bad name"}]}`)
+	assert.Error(t, Expand(jsonConfig, config.OS_TYPE_WINDOWS))
+}
+
+func TestExpandMerge(t *testing.T) {
+	jsonConfig := unmarshal(t, `{
+		"presets": ["sql_server", "exchange"],
+		"metrics": {
+			"namespace": "Custom",
+			"metrics_collected": {
+				"SQLServer:General Statistics": {"measurement": ["User Connections"], "metrics_collection_interval": 10},
+				"procstat": [{"exe": "agent", "measurement": ["cpu_usage"]}]
+			}
+		},
+		"logs": {
+			"logs_collected": {
+				"windows_events": {
+					"collect_list": [{"event_name": "System", "event_levels": ["ERROR"]}]
+				}
+			}
+		}
+	}`)
+	require.NoError(t, Expand(jsonConfig, config.OS_TYPE_WINDOWS))
+	metrics := jsonConfig["metrics"].(map[string]interface{})
+	assert.Equal(t, "Custom", metrics["namespace"])
+	metricsCollected := metrics["metrics_collected"].(map[string]interface{})
+	general := metricsCollected["SQLServer:General Statistics"].(map[string]interface{})
+	assert.EqualValues(t, 10, general["metrics_collection_interval"])
+	assert.Equal(t, []interface{}{"User Connections", "Processes blocked", "Logins/sec"}, general["measurement"])
+	assert.Len(t, metricsCollected["procstat"], 4)
+	events := jsonConfig["logs"].(map[string]interface{})["logs_collected"].(map[string]interface{})["windows_events"].(map[string]interface{})
+	// the Application event log shared by both presets is only collected once
+	assert.Len(t, events["collect_list"], 3)
+}
+
+func TestExpandErrors(t *testing.T) {
+	assert.NoError(t, Expand(map[string]interface{}{}, config.OS_TYPE_LINUX))
+	assert.ErrorContains(t, Expand(unmarshal(t, `{"presets": ["oracle"]}`), config.OS_TYPE_WINDOWS), "unknown preset")
+	assert.ErrorContains(t, Expand(unmarshal(t, `{"presets": ["sql_server"]}`), config.OS_TYPE_LINUX), "only supported on windows")
+	assert.Error(t, Expand(unmarshal(t, `{"presets": "sql_server"}`), config.OS_TYPE_WINDOWS))
+	assert.Error(t, Expand(unmarshal(t, `{"presets": [{"instance": "SQLEXPRESS"}]}`), config.OS_TYPE_WINDOWS))
+}
@@ -7,35 +7,26 @@ import (
 	"bytes"
 	_ "embed"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/fs"
+	"io"
+	"log"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"testing"
 
-	"github.com/BurntSushi/toml"
-	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
-	"github.com/kr/pretty"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"gopkg.in/yaml.v3"
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
-	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
 	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/tocwconfigtest"
 	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toenvconfig"
-	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/totomlconfig"
-	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/totomlconfig/tomlConfigTemplate"
-	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toyamlconfig"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util"
@@ -82,6 +73,37 @@ func TestGenericAppSignalsConfig(t *testing.T) {
 	checkTranslation(t, "base_appsignals_config", "linux", expectedEnvVars, "")
 	checkTranslation(t, "base_appsignals_config", "windows", expectedEnvVars, "")
 }
+
+func TestAppSignalsSamplingConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetRunInContainer(false)
+	context.CurrentContext().SetMode(config.ModeOnPremise)
+	t.Setenv(config.HOST_NAME, "host_name_from_env")
+	t.Setenv(config.HOST_IP, "127.0.0.1")
+	checkTranslation(t, "appsignals_sampling_config", "linux", nil, "")
+}
+
+func TestAppSignalsPrometheusRemoteWriteConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetRunInContainer(false)
+	context.CurrentContext().SetMode(config.ModeOnPremise)
+	t.Setenv(config.HOST_NAME, "host_name_from_env")
+	t.Setenv(config.HOST_IP, "127.0.0.1")
+	testutil.SetPrometheusRemoteWriteTestingEnv(t)
+	checkTranslation(t, "appsignals_prometheus_remote_write_config", "linux", nil, "")
+}
+
+func TestIPv6Config(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	expectedEnvVars := map[string]string{
+		"CWAGENT_IP_PREFERENCE":                  "ipv6",
+		"AWS_USE_DUALSTACK_ENDPOINT":             "true",
+		"AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE": "IPv6",
+	}
+	checkTranslation(t, "ipv6_config", "linux", expectedEnvVars, "")
+}
+
 func TestContainerInsightsJMX(t *testing.T) {
 	resetContext(t)
 	context.CurrentContext().SetRunInContainer(true)
@@ -558,6 +580,12 @@ func TestAdvancedConfig(t *testing.T) {
 			expectedEnvVars: nil,
 			appendString:    "",
 		},
+		"freebsd": {
+			filename:        "advanced_config_freebsd",
+			targetPlatform:  "freebsd",
+			expectedEnvVars: nil,
+			appendString:    "",
+		},
 		"windows": {
 			filename:        "advanced_config_windows",
 			targetPlatform:  "windows",
@@ -580,6 +608,26 @@ func TestLogOnlyConfig(t *testing.T) {
 	checkTranslation(t, "log_only_config_windows", "windows", expectedEnvVars, "")
 }
 
+func TestEventsConfig(t *testing.T) {
+	resetContext(t)
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	expectedEnvVars := map[string]string{}
+	checkTranslation(t, "events_config", "linux", expectedEnvVars, "", map[string]string{"hostname": hostname})
+}
+
+func TestOtlpLogsConfig(t *testing.T) {
+	resetContext(t)
+	expectedEnvVars := map[string]string{}
+	checkTranslation(t, "otlp_logs_config", "linux", expectedEnvVars, "")
+}
+
+func TestMetricTransformConfig(t *testing.T) {
+	resetContext(t)
+	expectedEnvVars := map[string]string{}
+	checkTranslation(t, "metric_transform_config", "linux", expectedEnvVars, "")
+}
+
 func TestSkipLogTimestampConfig(t *testing.T) {
 	testCases := map[string]testCase{
 		"default_linux": {
@@ -682,6 +730,12 @@ func TestTraceConfig(t *testing.T) {
 	}
 }
 
+func TestTraceServiceAttributesConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "trace_service_attributes_config", "linux", nil, "")
+}
+
 func TestConfigWithEnvironmentVariables(t *testing.T) {
 	resetContext(t)
 	context.CurrentContext().SetMode(config.ModeEC2)
@@ -771,6 +825,46 @@ func TestECSNodeStatsDConfig(t *testing.T) {
 	ecsSingleton.Region = ""
 }
 
+func TestLogRoutesConfig(t *testing.T) {
+	resetContext(t)
+	checkTranslation(t, "log_routes", "linux", nil, "")
+}
+
+func TestCredentialSetsConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "credential_sets_config", "linux", nil, "")
+}
+
+func TestInputNamespaceConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "input_namespace_config", "linux", nil, "")
+}
+
+func TestRecommendedAlarmsConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "recommended_alarms_config", "linux", nil, "")
+}
+
+func TestPressureNumaConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "pressure_numa_config", "linux", nil, "")
+}
+
+func TestAggregationIntervalConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "aggregation_interval_config", "linux", nil, "")
+}
+
+func TestLogLevelAggregationConfig(t *testing.T) {
+	resetContext(t)
+	checkTranslation(t, "log_level_aggregation", "linux", nil, "")
+}
+
 func TestLogFilterConfig(t *testing.T) {
 	resetContext(t)
 	checkTranslation(t, "log_filter", "linux", nil, "")
@@ -784,6 +878,12 @@ func TestIgnoreInvalidAppendDimensions(t *testing.T) {
 	checkTranslation(t, "ignore_append_dimensions", "linux", expectedEnvVars, "")
 }
 
+func TestDiskVolumeTagsConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "disk_volume_tags", "linux", nil, "")
+}
+
 func TestTomlToTomlComparison(t *testing.T) {
 	resetContext(t)
 	var jsonFilePath = "./totomlconfig/testdata/agentToml.json"
@@ -794,7 +894,7 @@ func TestTomlToTomlComparison(t *testing.T) {
 	content, err := os.ReadFile(jsonFilePath)
 	require.NoError(t, err)
 	require.NoError(t, json.Unmarshal(content, &input))
-	verifyToTomlTranslation(t, input, "./totomlconfig/testdata/agentToml.conf", map[string]string{})
+	tocwconfigtest.CheckTOML(t, input, "./totomlconfig/testdata/agentToml.conf")
 }
 
 func checkTranslation(t *testing.T, fileName string, targetPlatform string, expectedEnvVars map[string]string, appendString string, tokenReplacements ...map[string]string) {
@@ -810,15 +910,20 @@ func checkTranslation(t *testing.T, fileName string, targetPlatform string, expe
 }
 
 func checkTranslationForPaths(t *testing.T, jsonFilePath string, expectedTomlFilePath string, expectedYamlFilePath string, targetPlatform string, tokenReplacements ...map[string]string) {
-	agent.Global_Config = *new(agent.Agent)
-	translator.SetTargetPlatform(targetPlatform)
-	var input interface{}
-	blob, err := os.ReadFile(jsonFilePath)
-	content := replaceTokens(blob, tokenReplacements...)
-	require.NoError(t, err)
-	require.NoError(t, json.Unmarshal([]byte(content), &input))
-	verifyToTomlTranslation(t, input, expectedTomlFilePath, tokenReplacements...)
-	verifyToYamlTranslation(t, input, expectedYamlFilePath, tokenReplacements...)
+	t.Helper()
+	tokens := map[string]string{}
+	for _, replacements := range tokenReplacements {
+		for token, replacement := range replacements {
+			tokens[token] = replacement
+		}
+	}
+	tocwconfigtest.Check(t, tocwconfigtest.Case{
+		JSONPath:       jsonFilePath,
+		TOMLPath:       expectedTomlFilePath,
+		YAMLPath:       expectedYamlFilePath,
+		TargetPlatform: targetPlatform,
+		Tokens:         tokens,
+	})
 }
 
 func readCommonConfig(t *testing.T, commonConfigFilePath string) {
@@ -833,89 +938,7 @@ func readCommonConfig(t *testing.T, commonConfigFilePath string) {
 }
 
 func resetContext(t *testing.T) {
-	t.Setenv(envconfig.IMDS_NUMBER_RETRY, strconv.Itoa(retryer.DefaultImdsRetries))
-	util.DetectRegion = func(string, map[string]string) (string, string) {
-		return "us-west-2", "ACJ"
-	}
-	util.DetectCredentialsPath = func() string {
-		return "fake-path"
-	}
-	ecsutil.GetECSUtilSingleton().Region = ""
-	context.ResetContext()
-
-	t.Setenv("ProgramData", "c:\\ProgramData")
-}
-
-// toml files in the given path will be parsed into the config toml struct and be compared as struct
-func verifyToTomlTranslation(t *testing.T, input interface{}, desiredTomlPath string, tokenReplacements ...map[string]string) {
-	t.Helper()
-	tomlConfig, err := cmdutil.TranslateJsonMapToTomlConfig(input)
-	assert.NoError(t, err)
-
-	tomlStr := totomlconfig.ToTomlConfig(tomlConfig)
-	var expect tomlConfigTemplate.TomlConfig
-	blob, err := os.ReadFile(desiredTomlPath)
-	assert.NoError(t, err)
-	content := replaceTokens(blob, tokenReplacements...)
-	_, decodeError := toml.Decode(content, &expect)
-	assert.NoError(t, decodeError)
-
-	var actual tomlConfigTemplate.TomlConfig
-	_, decodeError2 := toml.Decode(tomlStr, &actual)
-	assert.NoError(t, decodeError2)
-
-	//assert.NoError(t, os.WriteFile(desiredTomlPath, []byte(tomlStr), 0644)) // useful for regenerating TOML
-
-	// This less function sort the content of string slice in alphabetical order so the
-	// cmp.Equal method will compare the two struct with slices in them, regardless the elements within the slices
-	opt := cmpopts.SortSlices(func(x, y interface{}) bool {
-		return pretty.Sprint(x) < pretty.Sprint(y)
-	})
-	assert.True(t, cmp.Equal(expect, actual, opt), "D! TOML diff: %s", cmp.Diff(expect, actual))
-}
-
-func verifyToYamlTranslation(t *testing.T, input interface{}, expectedYamlFilePath string, tokenReplacements ...map[string]string) {
-	t.Helper()
-
-	// if the file doesn't exist, then that means it isn't supported yet, so the
-	// YAML translation should fail.
-	if _, err := os.Stat(expectedYamlFilePath); errors.Is(err, fs.ErrNotExist) {
-		yamlConfig, err := cmdutil.TranslateJsonMapToYamlConfig(input)
-		require.Error(t, err)
-		require.Nil(t, yamlConfig)
-	} else {
-		var expected interface{}
-		bs, err := os.ReadFile(expectedYamlFilePath)
-		require.NoError(t, err)
-		content := replaceTokens(bs, tokenReplacements...)
-		content = strings.ReplaceAll(content, "\\\\", "\\")
-		require.NoError(t, yaml.Unmarshal([]byte(content), &expected))
-
-		var actual interface{}
-		yamlConfig, err := cmdutil.TranslateJsonMapToYamlConfig(input)
-		require.NoError(t, err)
-		yamlStr := toyamlconfig.ToYamlConfig(yamlConfig)
-		require.NoError(t, yaml.Unmarshal([]byte(yamlStr), &actual))
-
-		//assert.NoError(t, os.WriteFile(expectedYamlFilePath, []byte(yamlStr), 0644)) // useful for regenerating YAML
-
-		opt := cmpopts.SortSlices(func(x, y interface{}) bool {
-			return pretty.Sprint(x) < pretty.Sprint(y)
-		})
-		// assert.Equal(t, expected, actual) // this is useful for debugging differences between the YAML
-
-		require.True(t, cmp.Equal(expected, actual, opt), "D! YAML diff: %s", cmp.Diff(expected, actual))
-	}
-}
-
-func replaceTokens(base []byte, tokenReplacements ...map[string]string) string {
-	content := string(base)
-	for _, replacements := range tokenReplacements {
-		for token, replacement := range replacements {
-			content = strings.ReplaceAll(content, strings.Join([]string{"{", token, "}"}, ""), replacement)
-		}
-	}
-	return content
+	tocwconfigtest.ResetContext(t)
 }
 
 func checkIfEnvTranslateSucceed(t *testing.T, jsonStr string, targetOs string, expectedEnvVars map[string]string) {
@@ -933,3 +956,33 @@ func checkIfEnvTranslateSucceed(t *testing.T, jsonStr string, targetOs string, e
 		t.Fail()
 	}
 }
+
+func BenchmarkTranslate(b *testing.B) {
+	// the translation logs the processors it adds, which would be mixed up with the results
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	for _, fileName := range []string{"standard_config_linux", "advanced_config_linux", "complete_linux_config"} {
+		b.Run(fileName, func(b *testing.B) {
+			content, err := os.ReadFile(filepath.Join("sampleConfig", fileName+".json"))
+			require.NoError(b, err)
+			b.Setenv(envconfig.IMDS_NUMBER_RETRY, "0")
+			b.Setenv("JMX_JAR_PATH", "../../packaging/opentelemetry-jmx-metrics.jar")
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tocwconfigtest.ResetContext(b)
+				context.CurrentContext().SetMode(config.ModeEC2)
+				agent.Global_Config = *new(agent.Agent)
+				translator.SetTargetPlatform("linux")
+				var input interface{}
+				require.NoError(b, json.Unmarshal(content, &input))
+				b.StartTimer()
+				_, err = cmdutil.TranslateJsonMapToTomlConfig(input)
+				require.NoError(b, err)
+				_, err = cmdutil.TranslateJsonMapToYamlConfig(input)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
@@ -40,6 +40,13 @@ func TestAMPConfig(t *testing.T) {
 	checkTranslation(t, "amp_config_linux", "darwin", nil, "")
 }
 
+func TestGatewayConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	expectedEnvVars := map[string]string{}
+	checkTranslation(t, "gateway_config_linux", "linux", expectedEnvVars, "")
+}
+
 func TestJMXConfigLinux(t *testing.T) {
 	resetContext(t)
 	context.CurrentContext().SetMode(config.ModeEC2)
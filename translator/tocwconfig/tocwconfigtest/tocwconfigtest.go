@@ -0,0 +1,172 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package tocwconfigtest provides golden-file checks of the agent JSON configuration translation.
+// Distributions that add their own components can use it to test the TOML and OTel YAML that
+// their configurations translate to, the same way the agent tests its own.
+package tocwconfigtest
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/kr/pretty"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/totomlconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/totomlconfig/tomlConfigTemplate"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toyamlconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util/ecsutil"
+)
+
+const (
+	// UpdateEnv is the environment variable that, when set to true, makes the checks write the
+	// translated output to the golden files instead of comparing against them.
+	UpdateEnv = "CWAGENT_UPDATE_GOLDEN"
+	// Region is the region that ResetContext makes the translator detect.
+	Region = "us-west-2"
+)
+
+// Case is a single golden-file translation check.
+type Case struct {
+	// JSONPath is the agent JSON configuration to translate.
+	JSONPath string
+	// TOMLPath is the expected TOML. It is not checked if empty.
+	TOMLPath string
+	// YAMLPath is the expected OTel YAML. If the file does not exist, the YAML translation is
+	// expected to fail.
+	YAMLPath string
+	// TargetPlatform is the os to translate for, defaulting to linux.
+	TargetPlatform string
+	// Tokens replaces every {token} in the JSON and the expected files with the value.
+	Tokens map[string]string
+}
+
+// ResetContext clears the translator state left by earlier translations and stubs out the
+// region and credentials detection so the results do not depend on the host.
+func ResetContext(t testing.TB) {
+	t.Helper()
+	t.Setenv(envconfig.IMDS_NUMBER_RETRY, strconv.Itoa(retryer.DefaultImdsRetries))
+	util.DetectRegion = func(string, map[string]string) (string, string) {
+		return Region, "ACJ"
+	}
+	util.DetectCredentialsPath = func() string {
+		return "fake-path"
+	}
+	ecsutil.GetECSUtilSingleton().Region = ""
+	context.ResetContext()
+
+	t.Setenv("ProgramData", "c:\\ProgramData")
+}
+
+// Check translates the JSON configuration of the case and compares the result to its golden files.
+// ResetContext, or any context setup of the test, must be done before.
+func Check(t *testing.T, c Case) {
+	t.Helper()
+	targetPlatform := c.TargetPlatform
+	if targetPlatform == "" {
+		targetPlatform = "linux"
+	}
+	agent.Global_Config = *new(agent.Agent)
+	translator.SetTargetPlatform(targetPlatform)
+	blob, err := os.ReadFile(c.JSONPath)
+	require.NoError(t, err)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(ReplaceTokens(blob, c.Tokens)), &input))
+	if c.TOMLPath != "" {
+		CheckTOML(t, input, c.TOMLPath, c.Tokens)
+	}
+	CheckYAML(t, input, c.YAMLPath, c.Tokens)
+}
+
+// CheckTOML translates the parsed JSON configuration to TOML and compares it to the file. Both
+// are decoded into the TOML template, so the order of tables and unknown keys do not matter.
+func CheckTOML(t *testing.T, input interface{}, expectedPath string, tokenReplacements ...map[string]string) {
+	t.Helper()
+	tomlConfig, err := cmdutil.TranslateJsonMapToTomlConfig(input)
+	require.NoError(t, err)
+	tomlStr := totomlconfig.ToTomlConfig(tomlConfig)
+	if update() {
+		require.NoError(t, os.WriteFile(expectedPath, []byte(tomlStr), 0644))
+		return
+	}
+
+	var expected tomlConfigTemplate.TomlConfig
+	blob, err := os.ReadFile(expectedPath)
+	require.NoError(t, err)
+	_, err = toml.Decode(ReplaceTokens(blob, tokenReplacements...), &expected)
+	require.NoError(t, err)
+
+	var actual tomlConfigTemplate.TomlConfig
+	_, err = toml.Decode(tomlStr, &actual)
+	require.NoError(t, err)
+
+	require.True(t, cmp.Equal(expected, actual, sortSlices), "D! TOML diff: %s", cmp.Diff(expected, actual))
+}
+
+// CheckYAML translates the parsed JSON configuration to OTel YAML and compares it to the file.
+// If the file does not exist, the translation is expected to fail.
+func CheckYAML(t *testing.T, input interface{}, expectedPath string, tokenReplacements ...map[string]string) {
+	t.Helper()
+	yamlConfig, err := cmdutil.TranslateJsonMapToYamlConfig(input)
+	if _, statErr := os.Stat(expectedPath); errors.Is(statErr, fs.ErrNotExist) && (err != nil || !update()) {
+		require.Error(t, err)
+		require.Nil(t, yamlConfig)
+		return
+	}
+	require.NoError(t, err)
+	yamlStr := toyamlconfig.ToYamlConfig(yamlConfig)
+	if update() {
+		require.NoError(t, os.WriteFile(expectedPath, []byte(yamlStr), 0644))
+		return
+	}
+
+	var expected interface{}
+	blob, err := os.ReadFile(expectedPath)
+	require.NoError(t, err)
+	content := ReplaceTokens(blob, tokenReplacements...)
+	content = strings.ReplaceAll(content, "\\\\", "\\")
+	require.NoError(t, yaml.Unmarshal([]byte(content), &expected))
+
+	var actual interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(yamlStr), &actual))
+
+	require.True(t, cmp.Equal(expected, actual, sortSlices), "D! YAML diff: %s", cmp.Diff(expected, actual))
+}
+
+// ReplaceTokens replaces every {token} in base with its replacement.
+func ReplaceTokens(base []byte, tokenReplacements ...map[string]string) string {
+	content := string(base)
+	for _, replacements := range tokenReplacements {
+		for token, replacement := range replacements {
+			content = strings.ReplaceAll(content, strings.Join([]string{"{", token, "}"}, ""), replacement)
+		}
+	}
+	return content
+}
+
+// sortSlices makes the comparison ignore the order of elements within slices.
+var sortSlices = cmpopts.SortSlices(func(x, y interface{}) bool {
+	return pretty.Sprint(x) < pretty.Sprint(y)
+})
+
+func update() bool {
+	ok, _ := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return ok
+}
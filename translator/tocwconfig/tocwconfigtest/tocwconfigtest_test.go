@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tocwconfigtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	ResetContext(t)
+	Check(t, Case{
+		JSONPath: "testdata/config.json",
+		TOMLPath: "testdata/config.conf",
+		YAMLPath: "testdata/config.yaml",
+		Tokens:   map[string]string{"region": "us-east-1"},
+	})
+}
+
+func TestCheckUpdate(t *testing.T) {
+	ResetContext(t)
+	t.Setenv(UpdateEnv, "true")
+	dir := t.TempDir()
+	c := Case{
+		JSONPath: "testdata/config.json",
+		TOMLPath: filepath.Join(dir, "config.conf"),
+		YAMLPath: filepath.Join(dir, "config.yaml"),
+		Tokens:   map[string]string{"region": "us-east-1"},
+	}
+	Check(t, c)
+	content, err := os.ReadFile(c.TOMLPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "[[inputs.mem]]")
+	content, err = os.ReadFile(c.YAMLPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "region: us-east-1")
+
+	t.Setenv(UpdateEnv, "")
+	ResetContext(t)
+	Check(t, c)
+}
+
+func TestReplaceTokens(t *testing.T) {
+	got := ReplaceTokens([]byte("{a}-{b}-{c}"), map[string]string{"a": "1"}, map[string]string{"b": "2"})
+	assert.Equal(t, "1-2-{c}", got)
+}
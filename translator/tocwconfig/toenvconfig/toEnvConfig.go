@@ -6,6 +6,8 @@ package toenvconfig
 import (
 	"encoding/json"
 	"log"
+	"strconv"
+	"strings"
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
@@ -18,9 +20,26 @@ const (
 	userAgentKey      = "user_agent"
 	debugKey          = "debug"
 	awsSdkLogLevelKey = "aws_sdk_log_level"
+	logFormatKey      = "log_format"
+	logAllowedKeysKey = "log_allowed_keys"
 	usageDataKey      = "usage_data"
+	httpClientKey     = "http_client"
+	http2Key          = "http2"
+	ipPreferenceKey   = "ip_preference"
+	healthEndpointKey = "health_endpoint"
+	exportLagKey      = "export_lag_threshold"
 )
 
+// httpClientEnvVars are the environment variables of the numeric keys of the http_client section.
+var httpClientEnvVars = map[string]string{
+	"max_idle_connections":          envconfig.CWAGENT_HTTP_MAX_IDLE_CONNS,
+	"max_idle_connections_per_host": envconfig.CWAGENT_HTTP_MAX_IDLE_CONNS_PER_HOST,
+	"max_connections_per_host":      envconfig.CWAGENT_HTTP_MAX_CONNS_PER_HOST,
+	"dns_cache_ttl":                 envconfig.CWAGENT_HTTP_DNS_CACHE_TTL,
+	"dial_timeout":                  envconfig.CWAGENT_HTTP_DIAL_TIMEOUT,
+	"read_timeout":                  envconfig.CWAGENT_HTTP_READ_TIMEOUT,
+}
+
 func ToEnvConfig(jsonConfigValue map[string]interface{}) []byte {
 	envVars := make(map[string]string)
 
@@ -36,6 +55,49 @@ func ToEnvConfig(jsonConfigValue map[string]interface{}) []byte {
 		if awsSdkLogLevel, ok := agentMap[awsSdkLogLevelKey].(string); ok {
 			envVars[envconfig.AWS_SDK_LOG_LEVEL] = awsSdkLogLevel
 		}
+		if logFormat, ok := agentMap[logFormatKey].(string); ok {
+			envVars[envconfig.CWAGENT_LOG_FORMAT] = logFormat
+		}
+		if allowedKeys, ok := agentMap[logAllowedKeysKey].([]interface{}); ok && len(allowedKeys) > 0 {
+			keys := make([]string, 0, len(allowedKeys))
+			for _, key := range allowedKeys {
+				if key, ok := key.(string); ok {
+					keys = append(keys, key)
+				}
+			}
+			envVars[envconfig.CWAGENT_LOG_ALLOWED_KEYS] = strings.Join(keys, ",")
+		}
+
+		// Set the CWAGENT_HTTP_* tuning the HTTP client of the AWS clients
+		if httpClient, ok := agentMap[httpClientKey].(map[string]interface{}); ok {
+			for key, envVar := range httpClientEnvVars {
+				if value, ok := httpClient[key].(float64); ok {
+					envVars[envVar] = strconv.Itoa(int(value))
+				}
+			}
+			if http2, ok := httpClient[http2Key].(bool); ok {
+				envVars[envconfig.CWAGENT_HTTP2] = strconv.FormatBool(http2)
+			}
+		}
+
+		// Set CWAGENT_IP_PREFERENCE, and the AWS SDK settings for the dual-stack endpoints and IMDS over IPv6
+		if ipPreference, ok := agentMap[ipPreferenceKey].(string); ok {
+			envVars[envconfig.CWAGENT_IP_PREFERENCE] = ipPreference
+			switch ipPreference {
+			case envconfig.IPPreferenceIPv6:
+				envVars[envconfig.AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE] = "IPv6"
+				envVars[envconfig.AWS_USE_DUALSTACK_ENDPOINT] = "true"
+			case envconfig.IPPreferenceDualStack:
+				envVars[envconfig.AWS_USE_DUALSTACK_ENDPOINT] = "true"
+			}
+		}
+
+		if healthEndpoint, ok := agentMap[healthEndpointKey].(string); ok {
+			envVars[envconfig.CWAGENT_HEALTH_ENDPOINT] = healthEndpoint
+		}
+		if exportLag, ok := agentMap[exportLagKey].(float64); ok {
+			envVars[envconfig.CWAGENT_EXPORT_LAG_THRESHOLD] = strconv.Itoa(int(exportLag))
+		}
 
 		// Set CWAGENT_USAGE_DATA to FALSE in env config if present and false in agent section
 		if usageData, ok := agentMap[usageDataKey].(bool); ok && !usageData {
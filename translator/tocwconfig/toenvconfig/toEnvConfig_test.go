@@ -41,15 +41,116 @@ func TestToEnvConfig(t *testing.T) {
 					userAgentKey:      "custom-agent",
 					debugKey:          true,
 					awsSdkLogLevelKey: "DEBUG",
+					logFormatKey:      "json",
+					logAllowedKeysKey: []interface{}{"namespace", "pod"},
 					usageDataKey:      false,
 				},
 			},
 			envVars: map[string]string{},
 			expectedEnv: map[string]string{
-				envconfig.CWAGENT_USER_AGENT: "custom-agent",
-				envconfig.CWAGENT_LOG_LEVEL:  "DEBUG",
-				envconfig.AWS_SDK_LOG_LEVEL:  "DEBUG",
-				envconfig.CWAGENT_USAGE_DATA: "FALSE",
+				envconfig.CWAGENT_USER_AGENT:       "custom-agent",
+				envconfig.CWAGENT_LOG_LEVEL:        "DEBUG",
+				envconfig.CWAGENT_LOG_FORMAT:       "json",
+				envconfig.CWAGENT_LOG_ALLOWED_KEYS: "namespace,pod",
+				envconfig.AWS_SDK_LOG_LEVEL:        "DEBUG",
+				envconfig.CWAGENT_USAGE_DATA:       "FALSE",
+			},
+			contextSetup: func() {
+				context.CurrentContext().SetProxy(map[string]string{})
+				context.CurrentContext().SetSSL(map[string]string{})
+			},
+		},
+		{
+			name: "agent section with http client",
+			input: map[string]interface{}{
+				agent.SectionKey: map[string]interface{}{
+					httpClientKey: map[string]interface{}{
+						"max_idle_connections":          float64(500),
+						"max_idle_connections_per_host": float64(100),
+						"max_connections_per_host":      float64(0),
+						"dial_timeout":                  float64(5),
+						"read_timeout":                  float64(30),
+						"dns_cache_ttl":                 float64(60),
+						http2Key:                        false,
+					},
+				},
+			},
+			envVars: map[string]string{},
+			expectedEnv: map[string]string{
+				envconfig.CWAGENT_HTTP_MAX_IDLE_CONNS:          "500",
+				envconfig.CWAGENT_HTTP_MAX_IDLE_CONNS_PER_HOST: "100",
+				envconfig.CWAGENT_HTTP_MAX_CONNS_PER_HOST:      "0",
+				envconfig.CWAGENT_HTTP_DIAL_TIMEOUT:            "5",
+				envconfig.CWAGENT_HTTP_READ_TIMEOUT:            "30",
+				envconfig.CWAGENT_HTTP2:                        "false",
+				envconfig.CWAGENT_HTTP_DNS_CACHE_TTL:           "60",
+			},
+			contextSetup: func() {
+				context.CurrentContext().SetProxy(map[string]string{})
+				context.CurrentContext().SetSSL(map[string]string{})
+			},
+		},
+		{
+			name: "agent section with ipv6 preference",
+			input: map[string]interface{}{
+				agent.SectionKey: map[string]interface{}{
+					ipPreferenceKey: "ipv6",
+				},
+			},
+			envVars: map[string]string{},
+			expectedEnv: map[string]string{
+				envconfig.CWAGENT_IP_PREFERENCE:                  "ipv6",
+				envconfig.AWS_USE_DUALSTACK_ENDPOINT:             "true",
+				envconfig.AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE: "IPv6",
+			},
+			contextSetup: func() {
+				context.CurrentContext().SetProxy(map[string]string{})
+				context.CurrentContext().SetSSL(map[string]string{})
+			},
+		},
+		{
+			name: "agent section with dual stack preference",
+			input: map[string]interface{}{
+				agent.SectionKey: map[string]interface{}{
+					ipPreferenceKey: "dual_stack",
+				},
+			},
+			envVars: map[string]string{},
+			expectedEnv: map[string]string{
+				envconfig.CWAGENT_IP_PREFERENCE:      "dual_stack",
+				envconfig.AWS_USE_DUALSTACK_ENDPOINT: "true",
+			},
+			contextSetup: func() {
+				context.CurrentContext().SetProxy(map[string]string{})
+				context.CurrentContext().SetSSL(map[string]string{})
+			},
+		},
+		{
+			name: "agent section with health endpoint",
+			input: map[string]interface{}{
+				agent.SectionKey: map[string]interface{}{
+					healthEndpointKey: "0.0.0.0:13133",
+				},
+			},
+			envVars: map[string]string{},
+			expectedEnv: map[string]string{
+				envconfig.CWAGENT_HEALTH_ENDPOINT: "0.0.0.0:13133",
+			},
+			contextSetup: func() {
+				context.CurrentContext().SetProxy(map[string]string{})
+				context.CurrentContext().SetSSL(map[string]string{})
+			},
+		},
+		{
+			name: "agent section with export lag threshold",
+			input: map[string]interface{}{
+				agent.SectionKey: map[string]interface{}{
+					exportLagKey: float64(600),
+				},
+			},
+			envVars: map[string]string{},
+			expectedEnv: map[string]string{
+				envconfig.CWAGENT_EXPORT_LAG_THRESHOLD: "600",
 			},
 			contextSetup: func() {
 				context.CurrentContext().SetProxy(map[string]string{})
@@ -230,6 +230,7 @@ type (
 		MetricSeparator        string `toml:"metric_separator"`
 		ParseDataDogTags       bool   `toml:"parse_data_dog_tags"`
 		ServiceAddress         string `toml:"service_address"`
+		StateFile              string `toml:"state_file"`
 		Tags                   map[string]string
 	}
 
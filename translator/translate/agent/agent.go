@@ -32,8 +32,11 @@ type Agent struct {
 	Mode                  string
 	Internal              bool
 	Role_arn              string
+	CredentialSets        map[string]string
 	ServiceName           string
 	DeploymentEnvironment string
+	IPPreference          string
+	UserAgentAttribution  string
 }
 
 var (
@@ -4,34 +4,70 @@
 package agent
 
 import (
-	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
 )
 
 type GlobalCreds struct {
 }
 
 const (
-	Role_Arn_Key          = "role_arn"
-	CredentialsSectionKey = "credentials"
+	Role_Arn_Key             = "role_arn"
+	CredentialsSectionKey    = "credentials"
+	CredentialSetKey         = "credential_set"
+	CredentialSetsSectionKey = "credential_sets"
 )
 
-var credsTargetList = []string{Role_Arn_Key}
-
 func (c *GlobalCreds) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
-	result := map[string]interface{}{}
+	im := input.(map[string]interface{})
 
-	// Read fromm Json first.
-	if val, ok := input.(map[string]interface{})[CredentialsSectionKey]; ok {
-		util.SetWithSameKeyIfFound(val, credsTargetList, result)
+	// the credential sets are read first so that the credentials of the agent can name one of them
+	Global_Config.CredentialSets = map[string]string{}
+	if sets, ok := im[CredentialSetsSectionKey].(map[string]interface{}); ok {
+		for name, set := range sets {
+			if m, ok := set.(map[string]interface{}); ok {
+				if roleARN, ok := m[Role_Arn_Key].(string); ok {
+					Global_Config.CredentialSets[name] = roleARN
+				}
+			}
+		}
 	}
 
-	if role_arn, exist := result[Role_Arn_Key]; exist {
-		Global_Config.Role_arn = role_arn.(string)
+	// Read fromm Json first.
+	if val, ok := im[CredentialsSectionKey]; ok {
+		roleARN, err := RoleARN(val)
+		if err != nil {
+			translator.AddErrorMessages(GetCurPath()+CredentialsSectionKey, err.Error())
+		} else if roleARN != "" {
+			Global_Config.Role_arn = roleARN
+		}
 	}
 
 	return
 }
 
+// RoleARN returns the role of a credentials section: its role_arn, or the role of the credential set it names. It
+// returns an empty string if the section sets neither.
+func RoleARN(credentials interface{}) (string, error) {
+	m, ok := credentials.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	if roleARN, ok := m[Role_Arn_Key].(string); ok {
+		return roleARN, nil
+	}
+	name, ok := m[CredentialSetKey].(string)
+	if !ok {
+		return "", nil
+	}
+	roleARN, ok := Global_Config.CredentialSets[name]
+	if !ok {
+		return "", fmt.Errorf("credential_set %q is not defined in %s/%s", name, SectionKey, CredentialSetsSectionKey)
+	}
+	return roleARN, nil
+}
+
 func init() {
 	c := new(GlobalCreds)
 	RegisterRule(CredentialsSectionKey, c)
@@ -8,7 +8,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 )
 
@@ -27,3 +29,35 @@ func TestWithAgentConfig(t *testing.T) {
 	}
 
 }
+
+func TestWithCredentialSets(t *testing.T) {
+	t.Cleanup(func() { Global_Config = *new(Agent) })
+	translator.ResetMessages()
+	c := new(GlobalCreds)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"credential_sets": {"central": {"role_arn": "central_role"}, "security": {"role_arn": "security_role"}},
+		"credentials": {"credential_set": "central"}
+	}`), &input))
+	c.ApplyRule(input)
+	assert.Equal(t, "central_role", Global_Config.Role_arn)
+	assert.Equal(t, map[string]string{"central": "central_role", "security": "security_role"}, Global_Config.CredentialSets)
+
+	roleARN, err := RoleARN(map[string]interface{}{CredentialSetKey: "security"})
+	assert.NoError(t, err)
+	assert.Equal(t, "security_role", roleARN)
+	// the role_arn is used over the credential set
+	roleARN, err = RoleARN(map[string]interface{}{Role_Arn_Key: "role", CredentialSetKey: "security"})
+	assert.NoError(t, err)
+	assert.Equal(t, "role", roleARN)
+	roleARN, err = RoleARN(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, roleARN)
+	_, err = RoleARN(map[string]interface{}{CredentialSetKey: "unknown"})
+	assert.Error(t, err)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"credentials": {"credential_set": "unknown"}}`), &input))
+	c.ApplyRule(input)
+	assert.Len(t, translator.ErrorMessages, 1)
+	translator.ResetMessages()
+}
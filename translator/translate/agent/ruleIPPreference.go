@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"net"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const IPPreferenceKey = "ip_preference"
+
+type IPPreference struct{}
+
+// ApplyRule sets the IP family of the agent. It is passed to the AWS clients in the environment, see toenvconfig.
+func (i *IPPreference) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, result := translator.DefaultCase(IPPreferenceKey, envconfig.IPPreferenceIPv4, input)
+	Global_Config.IPPreference = result.(string)
+	return
+}
+
+// ListenAddress returns the default listen address of a receiver for the IP preference of the agent. With ipv6, the
+// IPv4 wildcard and loopback hosts are replaced by the IPv6 ones, so that the receivers can be reached on a host
+// without IPv4 addresses. The addresses configured by the user are used as is.
+func ListenAddress(address string) string {
+	if Global_Config.IPPreference != envconfig.IPPreferenceIPv6 {
+		return address
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	switch host {
+	case "0.0.0.0":
+		host = "::"
+	case "127.0.0.1":
+		host = "::1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+func init() {
+	RegisterRule(IPPreferenceKey, new(IPPreference))
+}
@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPPreference(t *testing.T) {
+	t.Cleanup(func() { Global_Config = *new(Agent) })
+	r := new(IPPreference)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{}`), &input))
+	r.ApplyRule(input)
+	assert.Equal(t, "ipv4", Global_Config.IPPreference)
+	assert.Equal(t, "127.0.0.1:2000", ListenAddress("127.0.0.1:2000"))
+	assert.Equal(t, "0.0.0.0:4315", ListenAddress("0.0.0.0:4315"))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"ip_preference": "ipv6"}`), &input))
+	r.ApplyRule(input)
+	assert.Equal(t, "ipv6", Global_Config.IPPreference)
+	assert.Equal(t, "[::1]:2000", ListenAddress("127.0.0.1:2000"))
+	assert.Equal(t, "[::]:4315", ListenAddress("0.0.0.0:4315"))
+	assert.Equal(t, ":8125", ListenAddress(":8125"))
+	assert.Equal(t, "localhost:25888", ListenAddress("localhost:25888"))
+}
@@ -6,6 +6,7 @@ package agent
 import (
 	"fmt"
 
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
@@ -30,9 +31,12 @@ func GetDefaultValue() string {
 	if context.CurrentContext().GetAgentLogFile() != "" {
 		return context.CurrentContext().GetAgentLogFile()
 	}
+	if paths.StateDir != "" {
+		return paths.AgentLogFilePath
+	}
 	targetPlatform := translator.GetTargetPlatform()
 	switch targetPlatform {
-	case config.OS_TYPE_LINUX, config.OS_TYPE_DARWIN:
+	case config.OS_TYPE_LINUX, config.OS_TYPE_DARWIN, config.OS_TYPE_FREEBSD:
 		return Linux_Darwin_Default_Log_Dir
 	case config.OS_TYPE_WINDOWS:
 		return util.GetWindowsProgramDataPath() + "\\Amazon\\AmazonCloudWatchAgent\\Logs\\amazon-cloudwatch-agent.log"
@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/internal/logrotate"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const LogRotationKey = logrotate.SectionKey
+
+type LogRotation struct {
+}
+
+// ApplyRule writes the rotation of the files the agent writes itself, e.g. its log, to the agent section, from which
+// the agent reads it on start. It is only written when set, so that the defaults stay in one place.
+func (l *LogRotation) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	section, ok := m[LogRotationKey].(map[string]interface{})
+	if !ok {
+		return
+	}
+	defaults := logrotate.DefaultConfig()
+	cfg := logrotate.Config{
+		MaxSizeMB:  intValue(section, "max_size_mb", defaults.MaxSizeMB),
+		MaxBackups: intValue(section, "max_backups", defaults.MaxBackups),
+		MaxAgeDays: intValue(section, "max_age_days", defaults.MaxAgeDays),
+		Interval:   intValue(section, "interval", defaults.Interval),
+	}
+	_, compress := translator.DefaultCase("compress", defaults.Compress, section)
+	cfg.Compress, _ = compress.(bool)
+	if err := cfg.Validate(); err != nil {
+		translator.AddErrorMessages(GetCurPath()+LogRotationKey, err.Error())
+		return
+	}
+	return LogRotationKey, map[string]interface{}{
+		"max_size_mb":  cfg.MaxSizeMB,
+		"max_backups":  cfg.MaxBackups,
+		"max_age_days": cfg.MaxAgeDays,
+		"compress":     cfg.Compress,
+		"interval":     cfg.Interval,
+	}
+}
+
+func intValue(section map[string]interface{}, key string, defaultValue int) int {
+	_, val := translator.DefaultCase(key, float64(defaultValue), section)
+	if f, ok := val.(float64); ok {
+		return int(f)
+	}
+	return defaultValue
+}
+
+func init() {
+	RegisterRule(LogRotationKey, new(LogRotation))
+}
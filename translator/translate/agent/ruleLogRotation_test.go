@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestLogRotation(t *testing.T) {
+	r := new(LogRotation)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{}`), &input))
+	key, val := r.ApplyRule(input)
+	assert.Empty(t, key)
+	assert.Nil(t, val)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"log_rotation": {"max_size_mb": 10, "compress": false, "interval": 86400}}`), &input))
+	key, val = r.ApplyRule(input)
+	assert.Equal(t, "log_rotation", key)
+	assert.Equal(t, map[string]interface{}{
+		"max_size_mb":  10,
+		"max_backups":  5,
+		"max_age_days": 7,
+		"compress":     false,
+		"interval":     86400,
+	}, val)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"log_rotation": {"max_size_mb": 0, "max_backups": -1}}`), &input))
+	key, _ = r.ApplyRule(input)
+	assert.Empty(t, key)
+	assert.Len(t, translator.ErrorMessages, 1)
+	translator.ResetMessages()
+}
@@ -0,0 +1,28 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const PrivilegedHelperKey = "privileged_helper"
+
+type PrivilegedHelper struct {
+}
+
+// The start wrapper reads this from the agent section before dropping privileges, so it is only
+// written when enabled.
+func (p *PrivilegedHelper) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(PrivilegedHelperKey, false, input)
+	if enabled, ok := returnVal.(bool); !ok || !enabled {
+		returnKey, returnVal = "", nil
+	}
+	return
+}
+
+func init() {
+	p := new(PrivilegedHelper)
+	RegisterRule(PrivilegedHelperKey, p)
+}
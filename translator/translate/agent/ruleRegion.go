@@ -30,6 +30,11 @@ func (r *Region) ApplyRule(input interface{}) (returnKey string, returnVal inter
 		Global_Config.RegionType = config.RegionTypeAgentConfigJson
 		return
 	}
+	if region := ctx.Region(); region != "" {
+		Global_Config.Region = region
+		Global_Config.RegionType = config.RegionTypeCommonConfig
+		return
+	}
 	region, regionType := util.DetectRegion(ctx.Mode(), ctx.Credentials())
 
 	if region == "" {
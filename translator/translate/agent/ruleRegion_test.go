@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+)
+
+func TestRegionFromCommonConfig(t *testing.T) {
+	defer context.ResetContext()
+	r := new(Region)
+	ctx := context.CurrentContext()
+	ctx.SetMode(config.ModeOnPrem)
+	ctx.SetRegion("eu-west-1")
+
+	r.ApplyRule(map[string]interface{}{})
+	assert.Equal(t, "eu-west-1", Global_Config.Region)
+	assert.Equal(t, config.RegionTypeCommonConfig, Global_Config.RegionType)
+
+	r.ApplyRule(map[string]interface{}{"region": "us-east-2"})
+	assert.Equal(t, "us-east-2", Global_Config.Region)
+	assert.Equal(t, config.RegionTypeAgentConfigJson, Global_Config.RegionType)
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const UserAgentAttributionKey = "user_agent_attribution"
+
+type UserAgentAttribution struct {
+}
+
+func (f *UserAgentAttribution) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, result := translator.DefaultCase(UserAgentAttributionKey, "", input)
+
+	// Set the global attribution, which the signals without their own attribution fall back to
+	Global_Config.UserAgentAttribution = result.(string)
+	return
+}
+
+func init() {
+	RegisterRule(UserAgentAttributionKey, new(UserAgentAttribution))
+}
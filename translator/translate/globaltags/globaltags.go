@@ -38,5 +38,6 @@ func init() {
 	g := new(GlobalTags)
 	parent.RegisterLinuxRule(SectionKey, g)
 	parent.RegisterDarwinRule(SectionKey, g)
+	parent.RegisterFreeBSDRule(SectionKey, g)
 	parent.RegisterWindowsRule(SectionKey, g)
 }
@@ -18,6 +18,8 @@ var ChildRule = map[string]Rule{}
 const (
 	SectionKey             = "logs"
 	Output_Cloudwatch_Logs = "cloudwatchlogs"
+	Output_Kinesis_Logs    = "kinesis_logs"
+	Output_OpenSearch_Logs = "opensearch_logs"
 )
 
 func GetCurPath() string {
@@ -48,6 +50,8 @@ func (l *Logs) ApplyRule(input interface{}) (returnKey string, returnVal interfa
 	inputs := map[string]interface{}{}
 	processors := map[string]interface{}{}
 	cloudwatchConfig := map[string]interface{}{}
+	var kinesisConfig interface{}
+	var openSearchConfig interface{}
 	GlobalLogConfig.MetadataInfo = util.GetMetadataInfo(util.Ec2MetadataInfoProvider)
 
 	//Apply Environment and ServiceName rules
@@ -84,12 +88,22 @@ func (l *Logs) ApplyRule(input interface{}) (returnKey string, returnVal interfa
 					inputs = translator.MergeTwoUniqueMaps(inputs, val.(map[string]interface{}))
 				} else if key == Output_Cloudwatch_Logs {
 					cloudwatchConfig = translator.MergeTwoUniqueMaps(cloudwatchConfig, val.(map[string]interface{}))
+				} else if key == Output_Kinesis_Logs {
+					kinesisConfig = val
+				} else if key == Output_OpenSearch_Logs {
+					openSearchConfig = val
 				}
 			}
 		}
 
 		cloudwatchInfo := map[string]interface{}{}
 		cloudwatchInfo["cloudwatchlogs"] = []interface{}{cloudwatchConfig}
+		if kinesisConfig != nil {
+			cloudwatchInfo[Output_Kinesis_Logs] = []interface{}{kinesisConfig}
+		}
+		if openSearchConfig != nil {
+			cloudwatchInfo[Output_OpenSearch_Logs] = []interface{}{openSearchConfig}
+		}
 		result["outputs"] = cloudwatchInfo
 
 		if len(inputs) > 0 {
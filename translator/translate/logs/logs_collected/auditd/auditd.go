@@ -0,0 +1,88 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package auditd
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected"
+	logUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+// SectionKey
+//
+//	"auditd": {
+//	    "source": "file",
+//	    "file_path": "/var/log/audit/audit.log",
+//	    "keys": ["identity", "privileged"],
+//	    "record_types": ["USER_LOGIN"],
+//	    "log_group_name": "audit-events",
+//	    "log_stream_name": "{instance_id}"
+//	}
+const (
+	SectionKey          = "auditd"
+	sourceKey           = "source"
+	filePathKey         = "file_path"
+	keysKey             = "keys"
+	recordTypesKey      = "record_types"
+	logGroupNameKey     = "log_group_name"
+	logStreamNameKey    = "log_stream_name"
+	retentionInDaysKey  = "retention_in_days"
+	logGroupClassKey    = "log_group_class"
+	defaultSource       = "file"
+	defaultFilePath     = "/var/log/audit/audit.log"
+	defaultLogGroupName = "audit-events"
+)
+
+type Auditd struct {
+}
+
+func GetCurPath() string {
+	return parent.GetCurPath() + SectionKey + "/"
+}
+
+func (a *Auditd) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	section, ok := im[SectionKey]
+	if !ok {
+		return "", ""
+	}
+	result := map[string]interface{}{
+		"destination": "cloudwatchlogs",
+	}
+	_, result[sourceKey] = translator.DefaultCase(sourceKey, defaultSource, section)
+	_, result[filePathKey] = translator.DefaultCase(filePathKey, defaultFilePath, section)
+	if _, keys := translator.DefaultStringArrayCase(keysKey, []interface{}{}, section); len(keys.([]string)) > 0 {
+		result[keysKey] = keys
+	}
+	if _, recordTypes := translator.DefaultStringArrayCase(recordTypesKey, []interface{}{}, section); len(recordTypes.([]string)) > 0 {
+		result[recordTypesKey] = recordTypes
+	}
+	_, logGroupName := translator.DefaultCase(logGroupNameKey, defaultLogGroupName, section)
+	result[logGroupNameKey] = util.ResolvePlaceholder(logGroupName.(string), logs.GlobalLogConfig.MetadataInfo)
+	if _, logStreamName := translator.DefaultCase(logStreamNameKey, "", section); logStreamName != "" {
+		result[logStreamNameKey] = util.ResolvePlaceholder(logStreamName.(string), logs.GlobalLogConfig.MetadataInfo)
+	}
+	_, result[retentionInDaysKey] = translator.DefaultRetentionInDaysCase(retentionInDaysKey, float64(-1), section)
+	_, result[logGroupClassKey] = translator.DefaultLogGroupClassCase(logGroupClassKey, "", section)
+	logUtil.ValidateLogGroupFields([]interface{}{result}, GetCurPath())
+	return "inputs", map[string]interface{}{
+		SectionKey: []interface{}{result},
+	}
+}
+
+var MergeRuleMap = map[string]mergeJsonRule.MergeRule{}
+
+func (a *Auditd) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeMap(source, result, SectionKey, MergeRuleMap, GetCurPath())
+}
+
+func init() {
+	obj := new(Auditd)
+	parent.RegisterLinuxRule(SectionKey, obj)
+	parent.MergeRuleMap[SectionKey] = obj
+}
@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package auditd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRule(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"Default": {
+			input: `{"auditd": {}}`,
+			want: map[string]interface{}{
+				SectionKey: []interface{}{
+					map[string]interface{}{
+						"destination":       "cloudwatchlogs",
+						"source":            "file",
+						"file_path":         "/var/log/audit/audit.log",
+						"log_group_name":    "audit-events",
+						"retention_in_days": -1,
+						"log_group_class":   "",
+					},
+				},
+			},
+		},
+		"Full": {
+			input: `{"auditd": {
+				"source": "socket",
+				"keys": ["identity", "privileged"],
+				"record_types": ["USER_LOGIN"],
+				"log_group_name": "security",
+				"log_stream_name": "audit",
+				"retention_in_days": 365,
+				"log_group_class": "standard"
+			}}`,
+			want: map[string]interface{}{
+				SectionKey: []interface{}{
+					map[string]interface{}{
+						"destination":       "cloudwatchlogs",
+						"source":            "socket",
+						"file_path":         "/var/log/audit/audit.log",
+						"keys":              []string{"identity", "privileged"},
+						"record_types":      []string{"USER_LOGIN"},
+						"log_group_name":    "security",
+						"log_stream_name":   "audit",
+						"retention_in_days": 365,
+						"log_group_class":   "STANDARD",
+					},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, got := new(Auditd).ApplyRule(input)
+			assert.Equal(t, "inputs", key)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+
+	key, _ := new(Auditd).ApplyRule(map[string]interface{}{})
+	assert.Empty(t, key)
+}
@@ -0,0 +1,86 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package connection_summary
+
+import (
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected"
+	logUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+// SectionKey
+//
+//	"connection_summary": {
+//	    "log_group_name": "connection-summary",
+//	    "log_stream_name": "{instance_id}",
+//	    "summary_interval": 60,
+//	    "top_n": 100
+//	}
+const (
+	SectionKey          = "connection_summary"
+	logGroupNameKey     = "log_group_name"
+	logStreamNameKey    = "log_stream_name"
+	summaryIntervalKey  = "summary_interval"
+	topNKey             = "top_n"
+	includeLoopbackKey  = "include_loopback"
+	retentionInDaysKey  = "retention_in_days"
+	logGroupClassKey    = "log_group_class"
+	defaultLogGroupName = "connection-summary"
+)
+
+type ConnectionSummary struct {
+}
+
+func GetCurPath() string {
+	return parent.GetCurPath() + SectionKey + "/"
+}
+
+func (c *ConnectionSummary) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	section, ok := im[SectionKey]
+	if !ok {
+		return "", ""
+	}
+	result := map[string]interface{}{
+		"destination": "cloudwatchlogs",
+	}
+	_, logGroupName := translator.DefaultCase(logGroupNameKey, defaultLogGroupName, section)
+	result[logGroupNameKey] = util.ResolvePlaceholder(logGroupName.(string), logs.GlobalLogConfig.MetadataInfo)
+	if _, logStreamName := translator.DefaultCase(logStreamNameKey, "", section); logStreamName != "" {
+		result[logStreamNameKey] = util.ResolvePlaceholder(logStreamName.(string), logs.GlobalLogConfig.MetadataInfo)
+	}
+	if _, interval := translator.DefaultCase(summaryIntervalKey, float64(60), section); interval != "" {
+		result[summaryIntervalKey] = (time.Duration(interval.(float64)) * time.Second).String()
+	}
+	if _, topN := translator.DefaultCase(topNKey, "", section); topN != "" {
+		result[topNKey] = int(topN.(float64))
+	}
+	if _, includeLoopback := translator.DefaultCase(includeLoopbackKey, false, section); includeLoopback == true {
+		result[includeLoopbackKey] = true
+	}
+	_, result[retentionInDaysKey] = translator.DefaultRetentionInDaysCase(retentionInDaysKey, float64(-1), section)
+	_, result[logGroupClassKey] = translator.DefaultLogGroupClassCase(logGroupClassKey, "", section)
+	logUtil.ValidateLogGroupFields([]interface{}{result}, GetCurPath())
+	return "inputs", map[string]interface{}{
+		SectionKey: []interface{}{result},
+	}
+}
+
+var MergeRuleMap = map[string]mergeJsonRule.MergeRule{}
+
+func (c *ConnectionSummary) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeMap(source, result, SectionKey, MergeRuleMap, GetCurPath())
+}
+
+func init() {
+	obj := new(ConnectionSummary)
+	parent.RegisterLinuxRule(SectionKey, obj)
+	parent.MergeRuleMap[SectionKey] = obj
+}
@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package connection_summary
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRule(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"Default": {
+			input: `{"connection_summary": {}}`,
+			want: map[string]interface{}{
+				SectionKey: []interface{}{
+					map[string]interface{}{
+						"destination":       "cloudwatchlogs",
+						"log_group_name":    "connection-summary",
+						"summary_interval":  "1m0s",
+						"retention_in_days": -1,
+						"log_group_class":   "",
+					},
+				},
+			},
+		},
+		"Full": {
+			input: `{"connection_summary": {
+				"log_group_name": "security",
+				"log_stream_name": "talkers",
+				"summary_interval": 300,
+				"top_n": 25,
+				"include_loopback": true,
+				"retention_in_days": 7,
+				"log_group_class": "infrequent_access"
+			}}`,
+			want: map[string]interface{}{
+				SectionKey: []interface{}{
+					map[string]interface{}{
+						"destination":       "cloudwatchlogs",
+						"log_group_name":    "security",
+						"log_stream_name":   "talkers",
+						"summary_interval":  "5m0s",
+						"top_n":             25,
+						"include_loopback":  true,
+						"retention_in_days": 7,
+						"log_group_class":   "INFREQUENT_ACCESS",
+					},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, got := new(ConnectionSummary).ApplyRule(input)
+			assert.Equal(t, "inputs", key)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+
+	key, _ := new(ConnectionSummary).ApplyRule(map[string]interface{}{})
+	assert.Empty(t, key)
+}
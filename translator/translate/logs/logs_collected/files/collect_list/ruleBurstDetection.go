@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	BurstDetectionSectionKey = "burst_detection"
+	burstThresholdKey        = "threshold"
+	burstSampleRateKey       = "sample_rate"
+	burstCooldownKey         = "cooldown"
+)
+
+type BurstDetection struct {
+}
+
+func (r *BurstDetection) ApplyRule(input interface{}) (string, interface{}) {
+	im, ok := input.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	val, ok := im[BurstDetectionSectionKey]
+	if !ok {
+		return "", nil
+	}
+	path := GetCurPath() + BurstDetectionSectionKey
+	section, ok := val.(map[string]interface{})
+	if !ok {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s must be an object, but got %v", BurstDetectionSectionKey, val))
+		return "", nil
+	}
+	res := map[string]interface{}{}
+	threshold, ok := section[burstThresholdKey].(float64)
+	if !ok || threshold != float64(int(threshold)) || threshold < 1 {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s must be a positive integer, but got %v", burstThresholdKey, section[burstThresholdKey]))
+		return "", nil
+	}
+	res[burstThresholdKey] = int(threshold)
+	if v, ok := section[burstSampleRateKey]; ok {
+		rate, ok := v.(float64)
+		if !ok || rate <= 0 || rate > 1 {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be greater than 0 and at most 1, but got %v", burstSampleRateKey, v))
+			return "", nil
+		}
+		res[burstSampleRateKey] = rate
+	}
+	if v, ok := section[burstCooldownKey]; ok {
+		cooldown, ok := v.(float64)
+		if !ok || cooldown != float64(int(cooldown)) || cooldown < 1 {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a positive integer, but got %v", burstCooldownKey, v))
+			return "", nil
+		}
+		res[burstCooldownKey] = int(cooldown)
+	}
+	return BurstDetectionSectionKey, res
+}
+
+func init() {
+	r := []Rule{new(BurstDetection)}
+	RegisterRule(BurstDetectionSectionKey, r)
+}
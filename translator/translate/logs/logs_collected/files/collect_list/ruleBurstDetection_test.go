@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestBurstDetection(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet":           {input: `{}`},
+		"ThresholdOnly":    {input: `{"burst_detection": {"threshold": 500}}`, wantKey: BurstDetectionSectionKey, wantValue: map[string]interface{}{"threshold": 500}},
+		"Full":             {input: `{"burst_detection": {"threshold": 500, "sample_rate": 0.05, "cooldown": 30}}`, wantKey: BurstDetectionSectionKey, wantValue: map[string]interface{}{"threshold": 500, "sample_rate": 0.05, "cooldown": 30}},
+		"MissingThreshold": {input: `{"burst_detection": {"sample_rate": 0.05}}`, wantErr: true},
+		"InvalidRate":      {input: `{"burst_detection": {"threshold": 500, "sample_rate": 0}}`, wantErr: true},
+		"InvalidCooldown":  {input: `{"burst_detection": {"threshold": 500, "cooldown": 1.5}}`, wantErr: true},
+		"InvalidType":      {input: `{"burst_detection": true}`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(BurstDetection).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	ContributorInsightsSectionKey = "contributor_insights"
+	insightsPatternKey            = "pattern"
+	insightsFieldsKey             = "fields"
+)
+
+// insightsFieldPattern matches the field names Contributor Insights rules reference by a JSON path, e.g. $.client_ip.
+var insightsFieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type ContributorInsights struct {
+}
+
+func (r *ContributorInsights) ApplyRule(input interface{}) (string, interface{}) {
+	im, ok := input.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	val, ok := im[ContributorInsightsSectionKey]
+	if !ok {
+		return "", nil
+	}
+	path := GetCurPath() + ContributorInsightsSectionKey
+	section, ok := val.(map[string]interface{})
+	if !ok {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s must be an object, but got %v", ContributorInsightsSectionKey, val))
+		return "", nil
+	}
+	res := map[string]interface{}{}
+	if v, ok := section[insightsPatternKey]; ok {
+		pattern, ok := v.(string)
+		if !ok {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a string, but got %v", insightsPatternKey, v))
+			return "", nil
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s %q is invalid: %v", insightsPatternKey, pattern, err))
+			return "", nil
+		}
+		res[insightsPatternKey] = pattern
+	}
+	if v, ok := section[insightsFieldsKey]; ok {
+		list, ok := v.([]interface{})
+		if !ok || len(list) == 0 {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a non-empty list, but got %v", insightsFieldsKey, v))
+			return "", nil
+		}
+		fields := make([]string, 0, len(list))
+		seen := map[string]struct{}{}
+		for _, item := range list {
+			field, ok := item.(string)
+			if !ok || !insightsFieldPattern.MatchString(field) {
+				translator.AddErrorMessages(path, fmt.Sprintf("%s must be letters, digits and _, not starting with a digit, but got %v", insightsFieldsKey, item))
+				return "", nil
+			}
+			if _, ok := seen[field]; ok {
+				translator.AddErrorMessages(path, fmt.Sprintf("%s lists %q twice", insightsFieldsKey, field))
+				return "", nil
+			}
+			seen[field] = struct{}{}
+			fields = append(fields, field)
+		}
+		res[insightsFieldsKey] = fields
+	}
+	return ContributorInsightsSectionKey, res
+}
+
+func init() {
+	r := []Rule{new(ContributorInsights)}
+	RegisterRule(ContributorInsightsSectionKey, r)
+}
@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestContributorInsights(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet":          {input: `{}`},
+		"Empty":           {input: `{"contributor_insights": {}}`, wantKey: ContributorInsightsSectionKey, wantValue: map[string]interface{}{}},
+		"Full":            {input: `{"contributor_insights": {"pattern": "ip=(?P<client_ip>\\S+)", "fields": ["client_ip", "status"]}}`, wantKey: ContributorInsightsSectionKey, wantValue: map[string]interface{}{"pattern": `ip=(?P<client_ip>\S+)`, "fields": []string{"client_ip", "status"}}},
+		"InvalidPattern":  {input: `{"contributor_insights": {"pattern": "(?P<client_ip"}}`, wantErr: true},
+		"EmptyFields":     {input: `{"contributor_insights": {"fields": []}}`, wantErr: true},
+		"InvalidField":    {input: `{"contributor_insights": {"fields": ["client.ip"]}}`, wantErr: true},
+		"DuplicateFields": {input: `{"contributor_insights": {"fields": ["status", "status"]}}`, wantErr: true},
+		"InvalidType":     {input: `{"contributor_insights": true}`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(ContributorInsights).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
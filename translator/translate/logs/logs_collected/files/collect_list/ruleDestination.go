@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+)
+
+const (
+	DestinationSectionKey = "destination"
+
+	destinationCloudWatchLogs = "cloudwatchlogs"
+	destinationKinesis        = "kinesis"
+	destinationOpenSearch     = "opensearch"
+)
+
+// Destination overrides the output the file is published to. Only set when different from the
+// default cloudwatchlogs output.
+type Destination struct {
+}
+
+func (d *Destination) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, val := translator.DefaultCase(DestinationSectionKey, destinationCloudWatchLogs, input)
+	switch val {
+	case destinationCloudWatchLogs:
+	case destinationKinesis:
+		returnKey = DestinationSectionKey
+		returnVal = logs.Output_Kinesis_Logs
+	case destinationOpenSearch:
+		returnKey = DestinationSectionKey
+		returnVal = logs.Output_OpenSearch_Logs
+	default:
+		translator.AddErrorMessages(GetCurPath()+DestinationSectionKey, "destination must be one of cloudwatchlogs, kinesis or opensearch")
+	}
+	return
+}
+
+func init() {
+	d := new(Destination)
+	r := []Rule{d}
+	RegisterRule(DestinationSectionKey, r)
+}
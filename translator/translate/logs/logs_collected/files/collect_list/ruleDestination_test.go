@@ -0,0 +1,34 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestDestination(t *testing.T) {
+	d := new(Destination)
+
+	key, val := d.ApplyRule(map[string]interface{}{})
+	assert.Equal(t, "", key)
+	assert.Nil(t, val)
+
+	key, val = d.ApplyRule(map[string]interface{}{"destination": "kinesis"})
+	assert.Equal(t, "destination", key)
+	assert.Equal(t, "kinesis_logs", val)
+
+	key, val = d.ApplyRule(map[string]interface{}{"destination": "opensearch"})
+	assert.Equal(t, "destination", key)
+	assert.Equal(t, "opensearch_logs", val)
+
+	translator.ResetMessages()
+	key, _ = d.ApplyRule(map[string]interface{}{"destination": "s3"})
+	assert.Equal(t, "", key)
+	assert.Len(t, translator.ErrorMessages, 1)
+	translator.ResetMessages()
+}
@@ -0,0 +1,33 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const DiscoveryIntervalSectionKey = "discovery_interval"
+
+type DiscoveryInterval struct {
+}
+
+func (r *DiscoveryInterval) ApplyRule(input interface{}) (string, interface{}) {
+	_, val := translator.DefaultCase(DiscoveryIntervalSectionKey, "", input)
+	if val == "" {
+		return "", nil
+	}
+	interval, ok := val.(float64)
+	if !ok || interval != float64(int(interval)) || interval < 1 {
+		translator.AddErrorMessages(GetCurPath()+DiscoveryIntervalSectionKey, fmt.Sprintf("%s must be a positive integer, but got %v", DiscoveryIntervalSectionKey, val))
+		return "", nil
+	}
+	return DiscoveryIntervalSectionKey, int(interval)
+}
+
+func init() {
+	r := []Rule{new(DiscoveryInterval)}
+	RegisterRule(DiscoveryIntervalSectionKey, r)
+}
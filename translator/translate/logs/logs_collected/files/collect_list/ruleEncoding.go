@@ -12,7 +12,11 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator"
 )
 
-const EncodingSectionKey = "encoding"
+const (
+	EncodingSectionKey = "encoding"
+	// EncodingAuto asks the logfile input to detect the encoding from the file's byte order mark.
+	EncodingAuto = "auto"
+)
 
 type Encoding struct {
 }
@@ -23,6 +27,9 @@ func (e *Encoding) ApplyRule(input interface{}) (returnKey string, returnVal int
 		return
 	}
 	if val, ok := val.(string); ok {
+		if val == EncodingAuto {
+			return key, val
+		}
 		if _, name := charset.Lookup(val); name == "" {
 			if _, err := ianaindex.IANA.Encoding(val); err != nil {
 				translator.AddErrorMessages(GetCurPath()+EncodingSectionKey, fmt.Sprintf("Encoding %s is an invalid value.", val))
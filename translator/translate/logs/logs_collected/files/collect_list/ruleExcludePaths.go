@@ -0,0 +1,45 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const ExcludePathsSectionKey = "exclude_paths"
+
+type ExcludePaths struct {
+}
+
+func (r *ExcludePaths) ApplyRule(input interface{}) (string, interface{}) {
+	im, ok := input.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	val, ok := im[ExcludePathsSectionKey]
+	if !ok {
+		return "", nil
+	}
+	list, ok := val.([]interface{})
+	if !ok {
+		translator.AddErrorMessages(GetCurPath()+ExcludePathsSectionKey, fmt.Sprintf("%s must be a list of globs, but got %v", ExcludePathsSectionKey, val))
+		return "", nil
+	}
+	res := make([]interface{}, 0, len(list))
+	for _, v := range list {
+		if s, ok := v.(string); !ok || s == "" {
+			translator.AddErrorMessages(GetCurPath()+ExcludePathsSectionKey, fmt.Sprintf("%s must only have non-empty globs, but got %v", ExcludePathsSectionKey, v))
+			return "", nil
+		}
+		res = append(res, v)
+	}
+	return ExcludePathsSectionKey, res
+}
+
+func init() {
+	r := []Rule{new(ExcludePaths)}
+	RegisterRule(ExcludePathsSectionKey, r)
+}
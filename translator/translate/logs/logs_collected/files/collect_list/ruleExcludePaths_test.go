@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestExcludePaths(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet":      {input: `{}`},
+		"Valid":       {input: `{"exclude_paths": ["/var/log/app/**/archive", "/var/log/app/**/*.gz"]}`, wantKey: ExcludePathsSectionKey, wantValue: []interface{}{"/var/log/app/**/archive", "/var/log/app/**/*.gz"}},
+		"Empty":       {input: `{"exclude_paths": []}`, wantKey: ExcludePathsSectionKey, wantValue: []interface{}{}},
+		"EmptyGlob":   {input: `{"exclude_paths": [""]}`, wantErr: true},
+		"InvalidType": {input: `{"exclude_paths": "/var/log/archive"}`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(ExcludePaths).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
+
+func TestDiscoveryInterval(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet":      {input: `{}`},
+		"Valid":       {input: `{"discovery_interval": 60}`, wantKey: DiscoveryIntervalSectionKey, wantValue: 60},
+		"Zero":        {input: `{"discovery_interval": 0}`, wantErr: true},
+		"Fractional":  {input: `{"discovery_interval": 1.5}`, wantErr: true},
+		"InvalidType": {input: `{"discovery_interval": "60"}`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(DiscoveryInterval).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
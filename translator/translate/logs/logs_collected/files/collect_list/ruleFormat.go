@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/logformat"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const FormatSectionKey = "format"
+
+// Format selects the parsing templates of a well-known log format, e.g. "nginx_access".
+type Format struct {
+}
+
+func (f *Format) ApplyRule(input interface{}) (string, interface{}) {
+	im, ok := input.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	val, ok := im[FormatSectionKey]
+	if !ok {
+		return "", nil
+	}
+	name, ok := val.(string)
+	if !ok {
+		translator.AddErrorMessages(GetCurPath()+FormatSectionKey, fmt.Sprintf("%s must be a string, but got %v", FormatSectionKey, val))
+		return "", nil
+	}
+	if _, err := logformat.Lookup(name); err != nil {
+		translator.AddErrorMessages(GetCurPath()+FormatSectionKey, err.Error())
+		return "", nil
+	}
+	return FormatSectionKey, name
+}
+
+func init() {
+	r := []Rule{new(Format)}
+	RegisterRule(FormatSectionKey, r)
+}
@@ -0,0 +1,41 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestFormat(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet":         {input: `{}`},
+		"Latest":         {input: `{"format": "nginx_access"}`, wantKey: FormatSectionKey, wantValue: "nginx_access"},
+		"Versioned":      {input: `{"format": "java@v1"}`, wantKey: FormatSectionKey, wantValue: "java@v1"},
+		"Unknown":        {input: `{"format": "iis"}`, wantErr: true},
+		"UnknownVersion": {input: `{"format": "java@v7"}`, wantErr: true},
+		"InvalidType":    {input: `{"format": 1}`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(Format).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
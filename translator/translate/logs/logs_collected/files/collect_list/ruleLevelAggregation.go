@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	LevelAggregationSectionKey = "level_aggregation"
+	levelAggregationLevelsKey  = "levels"
+	levelAggregationPatternKey = "level_pattern"
+)
+
+type LevelAggregation struct {
+}
+
+func (r *LevelAggregation) ApplyRule(input interface{}) (string, interface{}) {
+	im, ok := input.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	val, ok := im[LevelAggregationSectionKey]
+	if !ok {
+		return "", nil
+	}
+	path := GetCurPath() + LevelAggregationSectionKey
+	section, ok := val.(map[string]interface{})
+	if !ok {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s must be an object, but got %v", LevelAggregationSectionKey, val))
+		return "", nil
+	}
+	res := map[string]interface{}{}
+	if v, ok := section[levelAggregationLevelsKey]; ok {
+		levels, ok := v.([]interface{})
+		if !ok || len(levels) == 0 {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a non-empty list of levels, but got %v", levelAggregationLevelsKey, v))
+			return "", nil
+		}
+		var resLevels []string
+		for _, level := range levels {
+			s, ok := level.(string)
+			if !ok || s == "" {
+				translator.AddErrorMessages(path, fmt.Sprintf("%s must be a non-empty list of levels, but got %v", levelAggregationLevelsKey, v))
+				return "", nil
+			}
+			resLevels = append(resLevels, s)
+		}
+		res[levelAggregationLevelsKey] = resLevels
+	}
+	if v, ok := section[levelAggregationPatternKey]; ok {
+		pattern, ok := v.(string)
+		if !ok {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a string, but got %v", levelAggregationPatternKey, v))
+			return "", nil
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s %q is not a valid regex: %v", levelAggregationPatternKey, pattern, err))
+			return "", nil
+		}
+		res[levelAggregationPatternKey] = pattern
+	}
+	return LevelAggregationSectionKey, res
+}
+
+func init() {
+	r := []Rule{new(LevelAggregation)}
+	RegisterRule(LevelAggregationSectionKey, r)
+}
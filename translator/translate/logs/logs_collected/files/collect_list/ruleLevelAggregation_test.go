@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestLevelAggregation(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet":         {input: `{}`},
+		"Defaults":       {input: `{"level_aggregation": {}}`, wantKey: LevelAggregationSectionKey, wantValue: map[string]interface{}{}},
+		"Full":           {input: `{"level_aggregation": {"levels": ["DEBUG", "INFO"], "level_pattern": "level=(\\w+)"}}`, wantKey: LevelAggregationSectionKey, wantValue: map[string]interface{}{"levels": []string{"DEBUG", "INFO"}, "level_pattern": `level=(\w+)`}},
+		"EmptyLevels":    {input: `{"level_aggregation": {"levels": []}}`, wantErr: true},
+		"InvalidLevel":   {input: `{"level_aggregation": {"levels": ["INFO", 1]}}`, wantErr: true},
+		"InvalidPattern": {input: `{"level_aggregation": {"level_pattern": "level=("}}`, wantErr: true},
+		"InvalidType":    {input: `{"level_aggregation": true}`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(LevelAggregation).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
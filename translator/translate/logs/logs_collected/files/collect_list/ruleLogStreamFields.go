@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	LogStreamFieldsSectionKey = "log_stream_fields"
+	streamFieldsPatternKey    = "pattern"
+	maxLogStreamsKey          = "max_log_streams"
+	overflowLogStreamNameKey  = "overflow_log_stream_name"
+)
+
+type LogStreamFields struct {
+}
+
+func (r *LogStreamFields) ApplyRule(input interface{}) (string, interface{}) {
+	im, ok := input.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	val, ok := im[LogStreamFieldsSectionKey]
+	if !ok {
+		return "", nil
+	}
+	path := GetCurPath() + LogStreamFieldsSectionKey
+	section, ok := val.(map[string]interface{})
+	if !ok {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s must be an object, but got %v", LogStreamFieldsSectionKey, val))
+		return "", nil
+	}
+	res := map[string]interface{}{}
+	if v, ok := section[streamFieldsPatternKey]; ok {
+		pattern, ok := v.(string)
+		if !ok {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a string, but got %v", streamFieldsPatternKey, v))
+			return "", nil
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s %q is invalid: %v", streamFieldsPatternKey, pattern, err))
+			return "", nil
+		}
+		res[streamFieldsPatternKey] = pattern
+	}
+	if v, ok := section[maxLogStreamsKey]; ok {
+		maxStreams, ok := v.(float64)
+		if !ok || maxStreams != float64(int(maxStreams)) || maxStreams < 1 {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a positive integer, but got %v", maxLogStreamsKey, v))
+			return "", nil
+		}
+		res[maxLogStreamsKey] = int(maxStreams)
+	}
+	if v, ok := section[overflowLogStreamNameKey]; ok {
+		overflow, ok := v.(string)
+		if !ok || overflow == "" {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a non-empty string, but got %v", overflowLogStreamNameKey, v))
+			return "", nil
+		}
+		res[overflowLogStreamNameKey] = overflow
+	}
+	return LogStreamFieldsSectionKey, res
+}
+
+func init() {
+	r := []Rule{new(LogStreamFields)}
+	RegisterRule(LogStreamFieldsSectionKey, r)
+}
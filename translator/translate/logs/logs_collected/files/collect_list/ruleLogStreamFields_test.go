@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestLogStreamFields(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet":          {input: `{}`},
+		"Empty":           {input: `{"log_stream_fields": {}}`, wantKey: LogStreamFieldsSectionKey, wantValue: map[string]interface{}{}},
+		"Full":            {input: `{"log_stream_fields": {"pattern": "pod=(?P<pod_name>\\S+)", "max_log_streams": 50, "overflow_log_stream_name": "other"}}`, wantKey: LogStreamFieldsSectionKey, wantValue: map[string]interface{}{"pattern": `pod=(?P<pod_name>\S+)`, "max_log_streams": 50, "overflow_log_stream_name": "other"}},
+		"InvalidPattern":  {input: `{"log_stream_fields": {"pattern": "(?P<pod_name"}}`, wantErr: true},
+		"InvalidMax":      {input: `{"log_stream_fields": {"max_log_streams": 0}}`, wantErr: true},
+		"InvalidOverflow": {input: `{"log_stream_fields": {"overflow_log_stream_name": ""}}`, wantErr: true},
+		"InvalidType":     {input: `{"log_stream_fields": true}`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(LogStreamFields).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	MaxEventSizeSectionKey = "max_event_size"
+	// maxEventSizeLimit is the CloudWatch Logs limit for a single event (256KB) minus room for the 26 bytes of
+	// per-event overhead that PutLogEvents counts against the batch.
+	maxEventSizeLimit = 1024*256 - 26
+	minEventSizeLimit = 64
+)
+
+type MaxEventSize struct {
+}
+
+func (r *MaxEventSize) ApplyRule(input interface{}) (string, interface{}) {
+	_, val := translator.DefaultCase(MaxEventSizeSectionKey, "", input)
+	if val == "" {
+		return "", nil
+	}
+	size, ok := val.(float64)
+	if !ok || size != float64(int(size)) || size < minEventSizeLimit || size > maxEventSizeLimit {
+		translator.AddErrorMessages(GetCurPath()+MaxEventSizeSectionKey, fmt.Sprintf("%s must be an integer between %d and %d, but got %v", MaxEventSizeSectionKey, minEventSizeLimit, maxEventSizeLimit, val))
+		return "", nil
+	}
+	return MaxEventSizeSectionKey, int(size)
+}
+
+func init() {
+	l := new(MaxEventSize)
+	r := []Rule{l}
+	RegisterRule(MaxEventSizeSectionKey, r)
+}
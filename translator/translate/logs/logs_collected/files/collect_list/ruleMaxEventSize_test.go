@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestMaxEventSize(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet":      {input: `{}`},
+		"Valid":       {input: `{"max_event_size": 1024}`, wantKey: MaxEventSizeSectionKey, wantValue: 1024},
+		"Max":         {input: `{"max_event_size": 262118}`, wantKey: MaxEventSizeSectionKey, wantValue: 262118},
+		"TooLarge":    {input: `{"max_event_size": 262144}`, wantErr: true},
+		"TooSmall":    {input: `{"max_event_size": 1}`, wantErr: true},
+		"Fractional":  {input: `{"max_event_size": 1024.5}`, wantErr: true},
+		"InvalidType": {input: `{"max_event_size": "1024"}`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(MaxEventSize).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
+
+func TestTruncateSuffix(t *testing.T) {
+	translator.ResetMessages()
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"truncate_suffix": "...(cut)"}`), &input))
+	key, value := new(TruncateSuffix).ApplyRule(input)
+	assert.Equal(t, TruncateSuffixSectionKey, key)
+	assert.Equal(t, "...(cut)", value)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"truncate_suffix": 1}`), &input))
+	key, _ = new(TruncateSuffix).ApplyRule(input)
+	assert.Equal(t, "", key)
+	assert.Len(t, translator.ErrorMessages, 1)
+}
+
+func TestEncodingAuto(t *testing.T) {
+	translator.ResetMessages()
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"encoding": "auto"}`), &input))
+	key, value := new(Encoding).ApplyRule(input)
+	assert.Equal(t, EncodingSectionKey, key)
+	assert.Equal(t, EncodingAuto, value)
+	assert.Empty(t, translator.ErrorMessages)
+}
@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/schedule"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+const (
+	RoutesSectionKey  = "routes"
+	routeWindowsKey   = "windows"
+	windowDaysKey     = "days"
+	windowStartKey    = "start"
+	windowEndKey      = "end"
+	windowTimezoneKey = "timezone"
+)
+
+type Routes struct {
+}
+
+func (r *Routes) ApplyRule(input interface{}) (string, interface{}) {
+	im, ok := input.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	val, ok := im[RoutesSectionKey]
+	if !ok {
+		return "", nil
+	}
+	path := GetCurPath() + RoutesSectionKey
+	routes, ok := val.([]interface{})
+	if !ok {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s must be a list, but got %v", RoutesSectionKey, val))
+		return "", nil
+	}
+	var res []interface{}
+	for i, raw := range routes {
+		route, err := translateRoute(raw)
+		if err != nil {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s[%d]: %v", RoutesSectionKey, i, err))
+			return "", nil
+		}
+		res = append(res, route)
+	}
+	return RoutesSectionKey, res
+}
+
+func translateRoute(raw interface{}) (map[string]interface{}, error) {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be an object, but got %v", raw)
+	}
+	group, _ := m[LogGroupNameSectionKey].(string)
+	if group == "" {
+		return nil, fmt.Errorf("%s must be set", LogGroupNameSectionKey)
+	}
+	res := map[string]interface{}{
+		LogGroupNameSectionKey: util.ResolvePlaceholder(group, logs.GlobalLogConfig.MetadataInfo),
+	}
+	if v, ok := m[LogGroupClassSectionKey]; ok {
+		class, ok := v.(string)
+		if !ok || !translator.IsValidLogGroupClass(strings.ToUpper(class)) {
+			return nil, fmt.Errorf("%s must be one of %v, but got %v", LogGroupClassSectionKey, translator.ValidLogGroupClasses, v)
+		}
+		res[LogGroupClassSectionKey] = strings.ToUpper(class)
+	}
+	rawWindows, _ := m[routeWindowsKey].([]interface{})
+	if len(rawWindows) == 0 {
+		return nil, fmt.Errorf("%s must have at least one window", routeWindowsKey)
+	}
+	var windows []schedule.Window
+	var resWindows []interface{}
+	for _, rawWindow := range rawWindows {
+		w, ok := rawWindow.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s must be objects, but got %v", routeWindowsKey, rawWindow)
+		}
+		var window schedule.Window
+		window.Start, _ = w[windowStartKey].(string)
+		window.End, _ = w[windowEndKey].(string)
+		window.Timezone, _ = w[windowTimezoneKey].(string)
+		resWindow := map[string]interface{}{windowStartKey: window.Start, windowEndKey: window.End}
+		if days, ok := w[windowDaysKey].([]interface{}); ok {
+			for _, day := range days {
+				s, _ := day.(string)
+				window.Days = append(window.Days, s)
+			}
+			resWindow[windowDaysKey] = window.Days
+		}
+		if window.Timezone != "" {
+			resWindow[windowTimezoneKey] = window.Timezone
+		}
+		windows = append(windows, window)
+		resWindows = append(resWindows, resWindow)
+	}
+	if err := schedule.Validate(windows); err != nil {
+		return nil, err
+	}
+	res[routeWindowsKey] = resWindows
+	return res, nil
+}
+
+func init() {
+	r := []Rule{new(Routes)}
+	RegisterRule(RoutesSectionKey, r)
+}
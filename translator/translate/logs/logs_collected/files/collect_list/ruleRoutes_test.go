@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestRoutes(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet": {input: `{}`},
+		"Full": {
+			input: `{"routes": [
+				{"log_group_name": "app-ia", "log_group_class": "infrequent_access", "windows": [
+					{"start": "19:00", "end": "07:00", "timezone": "UTC"},
+					{"days": ["sat", "sun"], "start": "00:00", "end": "23:59"}
+				]}
+			]}`,
+			wantKey: RoutesSectionKey,
+			wantValue: []interface{}{
+				map[string]interface{}{
+					"log_group_name":  "app-ia",
+					"log_group_class": "INFREQUENT_ACCESS",
+					"windows": []interface{}{
+						map[string]interface{}{"start": "19:00", "end": "07:00", "timezone": "UTC"},
+						map[string]interface{}{"days": []string{"sat", "sun"}, "start": "00:00", "end": "23:59"},
+					},
+				},
+			},
+		},
+		"MissingLogGroupName":  {input: `{"routes": [{"windows": [{"start": "19:00", "end": "07:00"}]}]}`, wantErr: true},
+		"InvalidLogGroupClass": {input: `{"routes": [{"log_group_name": "app-ia", "log_group_class": "COLD", "windows": [{"start": "19:00", "end": "07:00"}]}]}`, wantErr: true},
+		"MissingWindows":       {input: `{"routes": [{"log_group_name": "app-ia"}]}`, wantErr: true},
+		"InvalidWindow":        {input: `{"routes": [{"log_group_name": "app-ia", "windows": [{"start": "19:00", "end": "7pm"}]}]}`, wantErr: true},
+		"InvalidDay":           {input: `{"routes": [{"log_group_name": "app-ia", "windows": [{"days": ["weekend"], "start": "19:00", "end": "07:00"}]}]}`, wantErr: true},
+		"InvalidType":          {input: `{"routes": {}}`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(Routes).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+)
+
+const (
+	RunAsSectionKey       = "run_as"
+	runAsUsernameKey      = "username"
+	runAsCredentialTarget = "credential_target"
+)
+
+type RunAs struct {
+}
+
+func (r *RunAs) ApplyRule(input interface{}) (string, interface{}) {
+	im, ok := input.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	val, ok := im[RunAsSectionKey]
+	if !ok {
+		return "", nil
+	}
+	path := GetCurPath() + RunAsSectionKey
+	if translator.GetTargetPlatform() != config.OS_TYPE_WINDOWS {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s is only supported on Windows", RunAsSectionKey))
+		return "", nil
+	}
+	section, ok := val.(map[string]interface{})
+	if !ok {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s must be an object, but got %v", RunAsSectionKey, val))
+		return "", nil
+	}
+	target, ok := section[runAsCredentialTarget].(string)
+	if !ok || target == "" {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s must be a non-empty string, but got %v", runAsCredentialTarget, section[runAsCredentialTarget]))
+		return "", nil
+	}
+	res := map[string]interface{}{runAsCredentialTarget: target}
+	if username, ok := section[runAsUsernameKey].(string); ok && username != "" {
+		res[runAsUsernameKey] = username
+	}
+	return RunAsSectionKey, res
+}
+
+func init() {
+	r := []Rule{new(RunAs)}
+	RegisterRule(RunAsSectionKey, r)
+}
@@ -0,0 +1,45 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+)
+
+func TestRunAs(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		os        string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet":        {input: `{}`, os: config.OS_TYPE_WINDOWS},
+		"TargetOnly":    {input: `{"run_as": {"credential_target": "CWAgent/app"}}`, os: config.OS_TYPE_WINDOWS, wantKey: RunAsSectionKey, wantValue: map[string]interface{}{"credential_target": "CWAgent/app"}},
+		"WithUsername":  {input: `{"run_as": {"credential_target": "CWAgent/app", "username": "CORP\\svc-app"}}`, os: config.OS_TYPE_WINDOWS, wantKey: RunAsSectionKey, wantValue: map[string]interface{}{"credential_target": "CWAgent/app", "username": `CORP\svc-app`}},
+		"MissingTarget": {input: `{"run_as": {"username": "svc-app"}}`, os: config.OS_TYPE_WINDOWS, wantErr: true},
+		"InvalidType":   {input: `{"run_as": "svc-app"}`, os: config.OS_TYPE_WINDOWS, wantErr: true},
+		"NotWindows":    {input: `{"run_as": {"credential_target": "CWAgent/app"}}`, os: config.OS_TYPE_LINUX, wantErr: true},
+	}
+	defer translator.SetTargetPlatform(translator.GetTargetPlatform())
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			translator.SetTargetPlatform(testCase.os)
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(RunAs).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
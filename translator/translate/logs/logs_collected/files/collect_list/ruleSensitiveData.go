@@ -0,0 +1,160 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	SensitiveDataSectionKey        = "sensitive_data"
+	sensitiveActionKey             = "action"
+	sensitiveMinScoreKey           = "min_score"
+	sensitiveQuarantineDirKey      = "quarantine_dir"
+	sensitiveQuarantineMaxSizeKey  = "quarantine_max_size_mb"
+	sensitiveDetectorsKey          = "detectors"
+	sensitiveDetectorTypeKey       = "type"
+	sensitiveDetectorNameKey       = "name"
+	sensitiveDetectorPatternKey    = "pattern"
+	sensitiveDetectorScoreKey      = "score"
+	sensitiveDetectorMinLengthKey  = "min_length"
+	sensitiveDetectorMinEntropyKey = "min_entropy"
+)
+
+var (
+	sensitiveActions       = map[string]bool{"tag": true, "redact": true, "quarantine": true}
+	sensitiveDetectorTypes = map[string]bool{"pan": true, "secret": true, "regex": true}
+)
+
+type SensitiveData struct {
+}
+
+func (r *SensitiveData) ApplyRule(input interface{}) (string, interface{}) {
+	im, ok := input.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	val, ok := im[SensitiveDataSectionKey]
+	if !ok {
+		return "", nil
+	}
+	path := GetCurPath() + SensitiveDataSectionKey
+	section, ok := val.(map[string]interface{})
+	if !ok {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s must be an object, but got %v", SensitiveDataSectionKey, val))
+		return "", nil
+	}
+	res := map[string]interface{}{}
+	if v, ok := section[sensitiveActionKey]; ok {
+		action, ok := v.(string)
+		if !ok || !sensitiveActions[action] {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be tag, redact or quarantine, but got %v", sensitiveActionKey, v))
+			return "", nil
+		}
+		res[sensitiveActionKey] = action
+	}
+	if v, ok := section[sensitiveMinScoreKey]; ok {
+		score, ok := v.(float64)
+		if !ok || score <= 0 || score > 1 {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be greater than 0 and at most 1, but got %v", sensitiveMinScoreKey, v))
+			return "", nil
+		}
+		res[sensitiveMinScoreKey] = score
+	}
+	if v, ok := section[sensitiveQuarantineDirKey]; ok {
+		dir, ok := v.(string)
+		if !ok || dir == "" {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a path, but got %v", sensitiveQuarantineDirKey, v))
+			return "", nil
+		}
+		res[sensitiveQuarantineDirKey] = dir
+	}
+	if v, ok := section[sensitiveQuarantineMaxSizeKey]; ok {
+		size, ok := v.(float64)
+		if !ok || size != float64(int(size)) || size < 1 {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a positive integer, but got %v", sensitiveQuarantineMaxSizeKey, v))
+			return "", nil
+		}
+		res[sensitiveQuarantineMaxSizeKey] = int(size)
+	}
+	if v, ok := section[sensitiveDetectorsKey]; ok {
+		detectors, ok := v.([]interface{})
+		if !ok {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s must be a list, but got %v", sensitiveDetectorsKey, v))
+			return "", nil
+		}
+		var translated []interface{}
+		for _, d := range detectors {
+			detector, err := translateDetector(d)
+			if err != nil {
+				translator.AddErrorMessages(path+"/"+sensitiveDetectorsKey, err.Error())
+				return "", nil
+			}
+			translated = append(translated, detector)
+		}
+		if len(translated) > 0 {
+			res[sensitiveDetectorsKey] = translated
+		}
+	}
+	return SensitiveDataSectionKey, res
+}
+
+func translateDetector(d interface{}) (map[string]interface{}, error) {
+	detector, ok := d.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("detector must be an object, but got %v", d)
+	}
+	res := map[string]interface{}{}
+	detectorType, _ := detector[sensitiveDetectorTypeKey].(string)
+	if !sensitiveDetectorTypes[detectorType] {
+		return nil, fmt.Errorf("detector %s must be pan, secret or regex, but got %v", sensitiveDetectorTypeKey, detector[sensitiveDetectorTypeKey])
+	}
+	res[sensitiveDetectorTypeKey] = detectorType
+	if name, ok := detector[sensitiveDetectorNameKey].(string); ok && name != "" {
+		res[sensitiveDetectorNameKey] = name
+	}
+	switch detectorType {
+	case "secret":
+		for _, key := range []string{sensitiveDetectorMinLengthKey, sensitiveDetectorMinEntropyKey} {
+			v, ok := detector[key]
+			if !ok {
+				continue
+			}
+			n, ok := v.(float64)
+			if !ok || n <= 0 || (key == sensitiveDetectorMinLengthKey && n != float64(int(n))) {
+				return nil, fmt.Errorf("detector %s must be a positive number, but got %v", key, v)
+			}
+			if key == sensitiveDetectorMinLengthKey {
+				res[key] = int(n)
+			} else {
+				res[key] = n
+			}
+		}
+	case "regex":
+		pattern, _ := detector[sensitiveDetectorPatternKey].(string)
+		if pattern == "" {
+			return nil, fmt.Errorf("regex detector needs a %s", sensitiveDetectorPatternKey)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("regex detector %s %s is invalid: %v", sensitiveDetectorPatternKey, pattern, err)
+		}
+		res[sensitiveDetectorPatternKey] = pattern
+		if v, ok := detector[sensitiveDetectorScoreKey]; ok {
+			score, ok := v.(float64)
+			if !ok || score <= 0 || score > 1 {
+				return nil, fmt.Errorf("detector %s must be greater than 0 and at most 1, but got %v", sensitiveDetectorScoreKey, v)
+			}
+			res[sensitiveDetectorScoreKey] = score
+		}
+	}
+	return res, nil
+}
+
+func init() {
+	r := []Rule{new(SensitiveData)}
+	RegisterRule(SensitiveDataSectionKey, r)
+}
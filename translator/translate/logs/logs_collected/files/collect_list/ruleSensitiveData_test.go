@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestSensitiveData(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		wantKey   string
+		wantValue interface{}
+		wantErr   bool
+	}{
+		"NotSet":  {input: `{}`},
+		"Default": {input: `{"sensitive_data": {}}`, wantKey: SensitiveDataSectionKey, wantValue: map[string]interface{}{}},
+		"Full": {
+			input: `{"sensitive_data": {"action": "quarantine", "min_score": 0.8, "quarantine_dir": "/tmp/quarantine", "quarantine_max_size_mb": 10,
+				"detectors": [{"type": "pan"}, {"type": "secret", "min_length": 32, "min_entropy": 4.5}, {"type": "regex", "name": "ssn", "pattern": "\\d{3}-\\d{2}-\\d{4}", "score": 0.9}]}}`,
+			wantKey: SensitiveDataSectionKey,
+			wantValue: map[string]interface{}{
+				"action":                 "quarantine",
+				"min_score":              0.8,
+				"quarantine_dir":         "/tmp/quarantine",
+				"quarantine_max_size_mb": 10,
+				"detectors": []interface{}{
+					map[string]interface{}{"type": "pan"},
+					map[string]interface{}{"type": "secret", "min_length": 32, "min_entropy": 4.5},
+					map[string]interface{}{"type": "regex", "name": "ssn", "pattern": `\d{3}-\d{2}-\d{4}`, "score": 0.9},
+				},
+			},
+		},
+		"InvalidAction":    {input: `{"sensitive_data": {"action": "drop"}}`, wantErr: true},
+		"InvalidScore":     {input: `{"sensitive_data": {"min_score": 0}}`, wantErr: true},
+		"InvalidSize":      {input: `{"sensitive_data": {"quarantine_max_size_mb": 1.5}}`, wantErr: true},
+		"InvalidType":      {input: `{"sensitive_data": true}`, wantErr: true},
+		"UnknownDetector":  {input: `{"sensitive_data": {"detectors": [{"type": "email"}]}}`, wantErr: true},
+		"MissingPattern":   {input: `{"sensitive_data": {"detectors": [{"type": "regex"}]}}`, wantErr: true},
+		"InvalidPattern":   {input: `{"sensitive_data": {"detectors": [{"type": "regex", "pattern": "("}]}}`, wantErr: true},
+		"InvalidMinLength": {input: `{"sensitive_data": {"detectors": [{"type": "secret", "min_length": 0}]}}`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, value := new(SensitiveData).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			assert.Equal(t, testCase.wantValue, value)
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list //nolint:revive
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const TruncateSuffixSectionKey = "truncate_suffix"
+
+type TruncateSuffix struct {
+}
+
+func (r *TruncateSuffix) ApplyRule(input interface{}) (string, interface{}) {
+	_, val := translator.DefaultCase(TruncateSuffixSectionKey, "", input)
+	if val == "" {
+		return "", nil
+	}
+	suffix, ok := val.(string)
+	if !ok {
+		translator.AddErrorMessages(GetCurPath()+TruncateSuffixSectionKey, fmt.Sprintf("value for %s must be string", TruncateSuffixSectionKey))
+		return "", nil
+	}
+	return TruncateSuffixSectionKey, suffix
+}
+
+func init() {
+	l := new(TruncateSuffix)
+	r := []Rule{l}
+	RegisterRule(TruncateSuffixSectionKey, r)
+}
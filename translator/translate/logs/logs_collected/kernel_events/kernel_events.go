@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected"
+	logUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+// SectionKey
+//
+//	"kernel_events": {
+//	    "log_group_name": "kernel-events",
+//	    "log_stream_name": "{instance_id}",
+//	    "namespace": "CWAgent"
+//	}
+const (
+	SectionKey          = "kernel_events"
+	logGroupNameKey     = "log_group_name"
+	logStreamNameKey    = "log_stream_name"
+	namespaceKey        = "namespace"
+	retentionInDaysKey  = "retention_in_days"
+	logGroupClassKey    = "log_group_class"
+	defaultLogGroupName = "kernel-events"
+	defaultNamespace    = "CWAgent"
+)
+
+type KernelEvents struct {
+}
+
+func GetCurPath() string {
+	return parent.GetCurPath() + SectionKey + "/"
+}
+
+func (k *KernelEvents) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	section, ok := im[SectionKey]
+	if !ok {
+		return "", ""
+	}
+	result := map[string]interface{}{
+		"destination": "cloudwatchlogs",
+	}
+	_, logGroupName := translator.DefaultCase(logGroupNameKey, defaultLogGroupName, section)
+	result[logGroupNameKey] = util.ResolvePlaceholder(logGroupName.(string), logs.GlobalLogConfig.MetadataInfo)
+	if _, logStreamName := translator.DefaultCase(logStreamNameKey, "", section); logStreamName != "" {
+		result[logStreamNameKey] = util.ResolvePlaceholder(logStreamName.(string), logs.GlobalLogConfig.MetadataInfo)
+	}
+	_, result[namespaceKey] = translator.DefaultCase(namespaceKey, defaultNamespace, section)
+	_, result[retentionInDaysKey] = translator.DefaultRetentionInDaysCase(retentionInDaysKey, float64(-1), section)
+	_, result[logGroupClassKey] = translator.DefaultLogGroupClassCase(logGroupClassKey, "", section)
+	logUtil.ValidateLogGroupFields([]interface{}{result}, GetCurPath())
+	return "inputs", map[string]interface{}{
+		SectionKey: []interface{}{result},
+	}
+}
+
+var MergeRuleMap = map[string]mergeJsonRule.MergeRule{}
+
+func (k *KernelEvents) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeMap(source, result, SectionKey, MergeRuleMap, GetCurPath())
+}
+
+func init() {
+	obj := new(KernelEvents)
+	parent.RegisterLinuxRule(SectionKey, obj)
+	parent.MergeRuleMap[SectionKey] = obj
+}
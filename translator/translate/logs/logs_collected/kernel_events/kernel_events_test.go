@@ -0,0 +1,67 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRule(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"Default": {
+			input: `{"kernel_events": {}}`,
+			want: map[string]interface{}{
+				SectionKey: []interface{}{
+					map[string]interface{}{
+						"destination":       "cloudwatchlogs",
+						"log_group_name":    "kernel-events",
+						"namespace":         "CWAgent",
+						"retention_in_days": -1,
+						"log_group_class":   "",
+					},
+				},
+			},
+		},
+		"Full": {
+			input: `{"kernel_events": {
+				"log_group_name": "nodes",
+				"log_stream_name": "kernel",
+				"namespace": "Nodes",
+				"retention_in_days": 7,
+				"log_group_class": "infrequent_access"
+			}}`,
+			want: map[string]interface{}{
+				SectionKey: []interface{}{
+					map[string]interface{}{
+						"destination":       "cloudwatchlogs",
+						"log_group_name":    "nodes",
+						"log_stream_name":   "kernel",
+						"namespace":         "Nodes",
+						"retention_in_days": 7,
+						"log_group_class":   "INFREQUENT_ACCESS",
+					},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, got := new(KernelEvents).ApplyRule(input)
+			assert.Equal(t, "inputs", key)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+
+	key, _ := new(KernelEvents).ApplyRule(map[string]interface{}{})
+	assert.Empty(t, key)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
@@ -165,6 +166,75 @@ func TestLogs_ForceFlushInterval(t *testing.T) {
 	ctx.SetMode(config.ModeEC2) //reset back to default mode
 }
 
+func TestLogs_BufferLimit(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","buffer_limit_mb":64}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	ctx := context.CurrentContext()
+	ctx.SetMode(config.ModeOnPrem)
+
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "OP",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+					"buffer_limit_mb":      64,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+
+	ctx.SetMode(config.ModeEC2) //reset back to default mode
+}
+
+func TestLogs_Failover(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","failover_regions":["us-west-2","eu-west-1"],"failover_after":120}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	ctx := context.CurrentContext()
+	ctx.SetMode(config.ModeOnPrem)
+
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "OP",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+					"failover_regions":     []string{"us-west-2", "eu-west-1"},
+					"failover_after":       "120s",
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+
+	ctx.SetMode(config.ModeEC2) //reset back to default mode
+}
+
 func TestLogs_EndpointOverride(t *testing.T) {
 	l := new(Logs)
 	agent.Global_Config.Region = "us-east-1"
@@ -239,3 +309,139 @@ func TestLogs_ServiceAndEnvironmentMissing(t *testing.T) {
 	assert.Equal(t, "my-service", GlobalLogConfig.ServiceName)
 	assert.Equal(t, "ec2:group", GlobalLogConfig.DeploymentEnvironment)
 }
+
+func TestLogs_Kinesis(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","kinesis":{"stream_name":"log-stream","aggregation":true}}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+				},
+			},
+			"kinesis_logs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"stream_name":          "log-stream",
+					"partition_key":        "{log_group_name}/{log_stream_name}",
+					"aggregation":          true,
+					"force_flush_interval": "5s",
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+}
+
+func TestLogs_OpenSearch(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","opensearch":{"endpoint":"https://search-logs.us-east-1.es.amazonaws.com","index":"app-{date}"}}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+				},
+			},
+			"opensearch_logs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"endpoint":             "https://search-logs.us-east-1.es.amazonaws.com",
+					"service":              "es",
+					"index":                "app-{date}",
+					"force_flush_interval": "5s",
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+}
+
+func TestLogs_LogGroupPolicies(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","log_group_policies":[
+		{"log_group_name":"/app/*","data_protection_identifiers":["EmailAddress"],"field_indexes":["RequestId","TraceId"]},
+		{"log_group_name":"/audit","data_protection_policy":{"Name":"audit","Version":"2021-06-01"}},
+		{"log_group_name":"/secure/*","kms_key_id":"arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"}]}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+					"log_group_policy": []interface{}{
+						map[string]interface{}{
+							"log_group_name":              "/app/*",
+							"data_protection_identifiers": []string{"EmailAddress"},
+							"field_indexes":               []string{"RequestId", "TraceId"},
+						},
+						map[string]interface{}{
+							"log_group_name":         "/audit",
+							"data_protection_policy": `{"Name":"audit","Version":"2021-06-01"}`,
+						},
+						map[string]interface{}{
+							"log_group_name": "/secure/*",
+							"kms_key_id":     "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+
+	for _, policies := range []string{
+		`{}`,
+		`[{"field_indexes":["RequestId"]}]`,
+		`[{"log_group_name":"/app"}]`,
+		`[{"log_group_name":"/app","field_indexes":[1]}]`,
+		`[{"log_group_name":"/app","kms_key_id":""}]`,
+		`[{"log_group_name":"/app","data_protection_identifiers":["EmailAddress"],"data_protection_policy":{}}]`,
+	} {
+		translator.ResetMessages()
+		err = json.Unmarshal([]byte(`{"log_group_policies":`+policies+`}`), &input)
+		assert.NoError(t, err)
+		key, _ := new(LogGroupPolicies).ApplyRule(input)
+		assert.Empty(t, key, policies)
+		assert.NotEmpty(t, translator.ErrorMessages, policies)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"fmt"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/relabel"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	SectionKeyMetricRelabelConfigs = "metric_relabel_configs"
+)
+
+type MetricRelabelConfigs struct {
+}
+
+// ApplyRule validates the metric_relabel_configs entries and passes them to the prometheus input with the prometheus
+// defaults filled in, so that a bad regex or a missing target_label fails the translation instead of the scrape.
+func (m *MetricRelabelConfigs) ApplyRule(input interface{}) (string, interface{}) {
+	entries, ok := input.(map[string]interface{})[SectionKeyMetricRelabelConfigs].([]interface{})
+	if !ok {
+		return "", nil
+	}
+	path := GetCurPath() + SectionKeyMetricRelabelConfigs
+	var result []interface{}
+	for i, entry := range entries {
+		em, ok := entry.(map[string]interface{})
+		if !ok {
+			translator.AddErrorMessages(path, "metric_relabel_configs entries must be objects")
+			continue
+		}
+		rc := relabel.DefaultRelabelConfig
+		if action, ok := em["action"].(string); ok {
+			rc.Action = relabel.Action(action)
+		}
+		if rc.Action != relabel.Keep && rc.Action != relabel.Drop && rc.Action != relabel.Replace {
+			translator.AddErrorMessages(path, fmt.Sprintf("entry %d has an unsupported action %q, only keep, drop and replace are supported", i, rc.Action))
+			continue
+		}
+		var sourceLabels []interface{}
+		if labels, ok := em["source_labels"].([]interface{}); ok {
+			for _, label := range labels {
+				rc.SourceLabels = append(rc.SourceLabels, model.LabelName(fmt.Sprint(label)))
+				sourceLabels = append(sourceLabels, fmt.Sprint(label))
+			}
+		}
+		if separator, ok := em["separator"].(string); ok {
+			rc.Separator = separator
+		}
+		regex := rc.Regex.String()
+		if value, ok := em["regex"].(string); ok {
+			regex = value
+			var err error
+			if rc.Regex, err = relabel.NewRegexp(value); err != nil {
+				translator.AddErrorMessages(path, fmt.Sprintf("entry %d has an invalid regex %q: %v", i, value, err))
+				continue
+			}
+		}
+		if targetLabel, ok := em["target_label"].(string); ok {
+			rc.TargetLabel = targetLabel
+		}
+		if replacement, ok := em["replacement"].(string); ok {
+			rc.Replacement = replacement
+		}
+		if err := rc.Validate(); err != nil {
+			translator.AddErrorMessages(path, fmt.Sprintf("entry %d is invalid: %v", i, err))
+			continue
+		}
+		rule := map[string]interface{}{
+			"action":      string(rc.Action),
+			"separator":   rc.Separator,
+			"regex":       regex,
+			"replacement": rc.Replacement,
+		}
+		if len(sourceLabels) > 0 {
+			rule["source_labels"] = sourceLabels
+		}
+		if rc.TargetLabel != "" {
+			rule["target_label"] = rc.TargetLabel
+		}
+		result = append(result, rule)
+	}
+	return SectionKeyMetricRelabelConfigs, result
+}
+
+func init() {
+	RegisterRule(SectionKeyMetricRelabelConfigs, new(MetricRelabelConfigs))
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	SectionKeySummaryQuantiles = "summary_quantiles"
+)
+
+var summaryQuantilesKeys = []string{"metric_selectors", "action", "quantiles"}
+
+type SummaryQuantiles struct {
+}
+
+// ApplyRule passes the summary_quantiles entries to the prometheus input, which converts or drops the quantiles of
+// the summaries they select.
+func (s *SummaryQuantiles) ApplyRule(input interface{}) (string, interface{}) {
+	entries, ok := input.(map[string]interface{})[SectionKeySummaryQuantiles].([]interface{})
+	if !ok {
+		return "", nil
+	}
+	var result []interface{}
+	for _, entry := range entries {
+		m, ok := entry.(map[string]interface{})
+		if !ok {
+			translator.AddErrorMessages(GetCurPath()+SectionKeySummaryQuantiles, "summary_quantiles entries must be objects")
+			continue
+		}
+		rule := map[string]interface{}{}
+		for _, key := range summaryQuantilesKeys {
+			if value, ok := m[key]; ok {
+				rule[key] = value
+			}
+		}
+		result = append(result, rule)
+	}
+	return SectionKeySummaryQuantiles, result
+}
+
+func init() {
+	RegisterRule(SectionKeySummaryQuantiles, new(SummaryQuantiles))
+}
@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import "github.com/aws/amazon-cloudwatch-agent/translator"
+
+const BufferLimitSectionKey = "buffer_limit_mb"
+
+// BufferLimit bounds the size of the log events read from the files and not yet sent to CloudWatch Logs. The files
+// stop being read while it is reached. It is not limited when not set.
+type BufferLimit struct {
+}
+
+func (b *BufferLimit) ApplyRule(input any) (string, any) {
+	result := map[string]interface{}{}
+	_, val := translator.DefaultCase(BufferLimitSectionKey, float64(-1), input)
+	if v, ok := val.(float64); ok && v >= 0 {
+		result[BufferLimitSectionKey] = int(v)
+	}
+	return Output_Cloudwatch_Logs, result
+}
+
+func init() {
+	RegisterRule(BufferLimitSectionKey, new(BufferLimit))
+}
@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	DeadLetterQueueSectionKey  = "dead_letter_queue"
+	defaultDeadLetterMaxSizeMB = 100
+)
+
+// DeadLetterQueue keeps the log events CloudWatch Logs permanently rejects in a local store, from which they can
+// be inspected and replayed with the -dead-letter flag of the agent.
+type DeadLetterQueue struct {
+}
+
+func (d *DeadLetterQueue) ApplyRule(input interface{}) (string, interface{}) {
+	im := input.(map[string]interface{})
+	dlq, ok := im[DeadLetterQueueSectionKey].(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	result := map[string]interface{}{}
+	_, path := translator.DefaultCase("path", paths.DeadLetterDir, dlq)
+	result["dead_letter_dir"] = path
+	_, val := translator.DefaultCase("max_size_mb", float64(defaultDeadLetterMaxSizeMB), dlq)
+	maxSize, ok := val.(float64)
+	if !ok || maxSize <= 0 {
+		translator.AddErrorMessages(GetCurPath()+DeadLetterQueueSectionKey+"/max_size_mb", "max_size_mb must be a positive number")
+		return "", nil
+	}
+	result["dead_letter_max_size_mb"] = int(maxSize)
+	return Output_Cloudwatch_Logs, result
+}
+
+func init() {
+	RegisterRule(DeadLetterQueueSectionKey, new(DeadLetterQueue))
+}
@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	FailoverRegionsSectionKey = "failover_regions"
+	FailoverAfterSectionKey   = "failover_after"
+)
+
+// Failover sends the log events to the failover regions, in order, while the endpoint of the region is unavailable
+// for failover_after seconds. The output plugin has a default period when it is not set.
+type Failover struct {
+}
+
+func (f *Failover) ApplyRule(input interface{}) (string, interface{}) {
+	im := input.(map[string]interface{})
+	if _, ok := im[FailoverRegionsSectionKey]; !ok {
+		return "", nil
+	}
+	result := map[string]interface{}{}
+	_, regions := translator.DefaultStringArrayCase(FailoverRegionsSectionKey, nil, input)
+	result[FailoverRegionsSectionKey] = regions
+	if _, ok := im[FailoverAfterSectionKey]; ok {
+		_, after := translator.DefaultTimeIntervalCase(FailoverAfterSectionKey, nil, input)
+		result[FailoverAfterSectionKey] = after
+	}
+	return Output_Cloudwatch_Logs, result
+}
+
+func init() {
+	RegisterRule(FailoverRegionsSectionKey, new(Failover))
+}
@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+)
+
+const (
+	KinesisSectionKey   = "kinesis"
+	defaultPartitionKey = "{log_group_name}/{log_stream_name}"
+)
+
+// Kinesis translates the logs.kinesis section into the kinesis_logs output, which log sources
+// select with "destination": "kinesis".
+type Kinesis struct {
+}
+
+func (k *Kinesis) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	kinesis, ok := im[KinesisSectionKey].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	result := map[string]interface{}{}
+	result = translator.MergeTwoUniqueMaps(result, agent.Global_Config.Credentials)
+	result[agent.RegionKey] = agent.Global_Config.Region
+	if agent.Global_Config.Role_arn != "" {
+		result[Role_Arn_Key] = agent.Global_Config.Role_arn
+	}
+	// an unknown credential set is reported by the credentials rule
+	if roleARN, err := agent.RoleARN(im[CredentialsSectionKey]); err == nil && roleARN != "" {
+		result[Role_Arn_Key] = roleARN
+	}
+
+	streamName, ok := kinesis["stream_name"].(string)
+	if !ok || streamName == "" {
+		translator.AddErrorMessages(GetCurPath()+KinesisSectionKey+"/stream_name", "stream_name is required for the kinesis destination")
+		return
+	}
+	result["stream_name"] = streamName
+	key, val := translator.DefaultCase("partition_key", defaultPartitionKey, kinesis)
+	result[key] = val
+	key, val = translator.DefaultCase("aggregation", false, kinesis)
+	result[key] = val
+	if key, val = translator.DefaultCase("endpoint_override", "", kinesis); val != "" {
+		result[key] = val
+	}
+	key, val = translator.DefaultTimeIntervalCase("force_flush_interval", float64(5), im)
+	result[key] = val
+
+	returnKey = Output_Kinesis_Logs
+	returnVal = result
+	return
+}
+
+func init() {
+	RegisterRule(KinesisSectionKey, new(Kinesis))
+}
@@ -4,8 +4,8 @@
 package logs
 
 import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
-	"github.com/aws/amazon-cloudwatch-agent/translator/util"
 )
 
 type LogCreds struct {
@@ -16,8 +16,6 @@ const (
 	CredentialsSectionKey = "credentials"
 )
 
-var credsTargetList = []string{Role_Arn_Key}
-
 func (c *LogCreds) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
 	result := map[string]interface{}{}
 
@@ -27,7 +25,12 @@ func (c *LogCreds) ApplyRule(input interface{}) (returnKey string, returnVal int
 
 	// Read fromm Json first.
 	if val, ok := input.(map[string]interface{})[CredentialsSectionKey]; ok {
-		util.SetWithSameKeyIfFound(val, credsTargetList, result)
+		roleARN, err := agent.RoleARN(val)
+		if err != nil {
+			translator.AddErrorMessages(GetCurPath()+CredentialsSectionKey, err.Error())
+		} else if roleARN != "" {
+			result[Role_Arn_Key] = roleARN
+		}
 	}
 
 	returnKey = Output_Cloudwatch_Logs
@@ -0,0 +1,120 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	LogGroupPoliciesSectionKey   = "log_group_policies"
+	logGroupPolicyKey            = "log_group_policy"
+	logGroupPolicyNameKey        = "log_group_name"
+	logGroupPolicyIdentifiersKey = "data_protection_identifiers"
+	logGroupPolicyDocumentKey    = "data_protection_policy"
+	logGroupPolicyIndexesKey     = "field_indexes"
+	logGroupPolicyKMSKeyIDKey    = "kms_key_id"
+)
+
+// LogGroupPolicies attaches a data protection policy and field indexes to the log groups the agent creates whose
+// name matches the log_group_name pattern, and encrypts them with a KMS key.
+type LogGroupPolicies struct {
+}
+
+func (l *LogGroupPolicies) ApplyRule(input interface{}) (string, interface{}) {
+	im := input.(map[string]interface{})
+	val, ok := im[LogGroupPoliciesSectionKey]
+	if !ok {
+		return "", nil
+	}
+	path := GetCurPath() + LogGroupPoliciesSectionKey
+	entries, ok := val.([]interface{})
+	if !ok {
+		translator.AddErrorMessages(path, fmt.Sprintf("%s must be a list, but got %v", LogGroupPoliciesSectionKey, val))
+		return "", nil
+	}
+	var policies []interface{}
+	for _, entry := range entries {
+		policy, err := translateLogGroupPolicy(entry)
+		if err != nil {
+			translator.AddErrorMessages(path, err.Error())
+			return "", nil
+		}
+		policies = append(policies, policy)
+	}
+	if len(policies) == 0 {
+		return "", nil
+	}
+	return Output_Cloudwatch_Logs, map[string]interface{}{logGroupPolicyKey: policies}
+}
+
+func translateLogGroupPolicy(entry interface{}) (map[string]interface{}, error) {
+	policy, ok := entry.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("log group policy must be an object, but got %v", entry)
+	}
+	name, _ := policy[logGroupPolicyNameKey].(string)
+	if name == "" {
+		return nil, fmt.Errorf("log group policy needs a %s", logGroupPolicyNameKey)
+	}
+	res := map[string]interface{}{logGroupPolicyNameKey: name}
+	for _, key := range []string{logGroupPolicyIdentifiersKey, logGroupPolicyIndexesKey} {
+		v, ok := policy[key]
+		if !ok {
+			continue
+		}
+		values, err := nonEmptyStrings(v)
+		if err != nil {
+			return nil, fmt.Errorf("log group policy %s %v", key, err)
+		}
+		res[key] = values
+	}
+	if v, ok := policy[logGroupPolicyDocumentKey]; ok {
+		if _, ok := v.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("log group policy %s must be an object, but got %v", logGroupPolicyDocumentKey, v)
+		}
+		if _, ok := res[logGroupPolicyIdentifiersKey]; ok {
+			return nil, fmt.Errorf("log group policy cannot set both %s and %s", logGroupPolicyIdentifiersKey, logGroupPolicyDocumentKey)
+		}
+		document, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("log group policy %s is invalid: %v", logGroupPolicyDocumentKey, err)
+		}
+		res[logGroupPolicyDocumentKey] = string(document)
+	}
+	if v, ok := policy[logGroupPolicyKMSKeyIDKey]; ok {
+		keyID, ok := v.(string)
+		if !ok || keyID == "" {
+			return nil, fmt.Errorf("log group policy %s must be a non-empty string, but got %v", logGroupPolicyKMSKeyIDKey, v)
+		}
+		res[logGroupPolicyKMSKeyIDKey] = keyID
+	}
+	if len(res) == 1 {
+		return nil, fmt.Errorf("log group policy for %s needs %s, %s, %s or %s", name, logGroupPolicyIdentifiersKey, logGroupPolicyDocumentKey, logGroupPolicyIndexesKey, logGroupPolicyKMSKeyIDKey)
+	}
+	return res, nil
+}
+
+func nonEmptyStrings(v interface{}) ([]string, error) {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("must be a non-empty list, but got %v", v)
+	}
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok || s == "" {
+			return nil, fmt.Errorf("must only contain non-empty strings, but got %v", item)
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
+
+func init() {
+	RegisterRule(LogGroupPoliciesSectionKey, new(LogGroupPolicies))
+}
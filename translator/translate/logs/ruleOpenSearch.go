@@ -0,0 +1,60 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+)
+
+const (
+	OpenSearchSectionKey     = "opensearch"
+	defaultOpenSearchIndex   = "{log_group_name}-{date}"
+	defaultOpenSearchService = "es"
+)
+
+// OpenSearch translates the logs.opensearch section into the opensearch_logs output, which log sources
+// select with "destination": "opensearch".
+type OpenSearch struct {
+}
+
+func (o *OpenSearch) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	openSearch, ok := im[OpenSearchSectionKey].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	result := map[string]interface{}{}
+	result = translator.MergeTwoUniqueMaps(result, agent.Global_Config.Credentials)
+	result[agent.RegionKey] = agent.Global_Config.Region
+	if agent.Global_Config.Role_arn != "" {
+		result[Role_Arn_Key] = agent.Global_Config.Role_arn
+	}
+	// an unknown credential set is reported by the credentials rule
+	if roleARN, err := agent.RoleARN(im[CredentialsSectionKey]); err == nil && roleARN != "" {
+		result[Role_Arn_Key] = roleARN
+	}
+
+	endpoint, ok := openSearch["endpoint"].(string)
+	if !ok || endpoint == "" {
+		translator.AddErrorMessages(GetCurPath()+OpenSearchSectionKey+"/endpoint", "endpoint is required for the opensearch destination")
+		return
+	}
+	result["endpoint"] = endpoint
+	key, val := translator.DefaultCase("service", defaultOpenSearchService, openSearch)
+	result[key] = val
+	key, val = translator.DefaultCase("index", defaultOpenSearchIndex, openSearch)
+	result[key] = val
+	key, val = translator.DefaultTimeIntervalCase("force_flush_interval", float64(5), im)
+	result[key] = val
+
+	returnKey = Output_OpenSearch_Logs
+	returnVal = result
+	return
+}
+
+func init() {
+	RegisterRule(OpenSearchSectionKey, new(OpenSearch))
+}
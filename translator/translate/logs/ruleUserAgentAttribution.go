@@ -0,0 +1,30 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+)
+
+// UserAgentAttribution is appended to the User-Agent of the calls to CloudWatch Logs, falling back to the one of the
+// agent section.
+type UserAgentAttribution struct {
+}
+
+func (u *UserAgentAttribution) ApplyRule(input interface{}) (string, interface{}) {
+	_, result := translator.DefaultCase(agent.UserAgentAttributionKey, "", input)
+	attribution, _ := result.(string)
+	if attribution == "" {
+		attribution = agent.Global_Config.UserAgentAttribution
+	}
+	if attribution == "" {
+		return "", nil
+	}
+	return Output_Cloudwatch_Logs, map[string]interface{}{agent.UserAgentAttributionKey: attribution}
+}
+
+func init() {
+	RegisterRule(agent.UserAgentAttributionKey, new(UserAgentAttribution))
+}
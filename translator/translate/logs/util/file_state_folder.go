@@ -4,6 +4,9 @@
 package util
 
 import (
+	"path/filepath"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util"
@@ -12,7 +15,9 @@ import (
 const File_State_Folder_Linux = "/opt/aws/amazon-cloudwatch-agent/logs/state"
 
 func GetFileStateFolder() (fileStateFolder string) {
-	if translator.GetTargetPlatform() == config.OS_TYPE_WINDOWS {
+	if paths.StateDir != "" {
+		fileStateFolder = filepath.Join(paths.StateDir, "logs", "state")
+	} else if translator.GetTargetPlatform() == config.OS_TYPE_WINDOWS {
 		fileStateFolder = util.GetWindowsProgramDataPath() + "\\Amazon\\AmazonCloudWatchAgent\\Logs\\state"
 	} else {
 		fileStateFolder = File_State_Folder_Linux
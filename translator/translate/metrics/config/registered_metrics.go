@@ -10,8 +10,8 @@ var Registered_Metrics_Linux = map[string][]string{
 		"usage_active", "usage_guest", "usage_guest_nice", "usage_idle", "usage_iowait", "usage_irq", "usage_nice", "usage_softirq", "usage_steal", "usage_system", "usage_user"},
 	"disk":      {"free", "inodes_free", "inodes_total", "inodes_used", "total", "used", "used_percent"},
 	"diskio":    {"iops_in_progress", "io_time", "reads", "read_bytes", "read_time", "writes", "write_bytes", "write_time"},
-	"swap":      {"free", "used", "used_percent"},
-	"mem":       {"active", "available", "available_percent", "buffered", "cached", "free", "inactive", "total", "used", "used_percent"},
+	"swap":      {"free", "used", "used_percent", "in", "out"},
+	"mem":       {"active", "available", "available_percent", "buffered", "cached", "free", "inactive", "total", "used", "used_percent", "huge_pages_total", "huge_pages_free", "huge_page_size", "dirty", "write_back", "write_back_tmp"},
 	"net":       {"bytes_sent", "bytes_recv", "drop_in", "drop_out", "err_in", "err_out", "packets_sent", "packets_recv"},
 	"netstat":   {"tcp_close", "tcp_close_wait", "tcp_closing", "tcp_established", "tcp_fin_wait1", "tcp_fin_wait2", "tcp_last_ack", "tcp_listen", "tcp_none", "tcp_syn_sent", "tcp_syn_recv", "tcp_time_wait", "udp_socket"},
 	"processes": {"blocked", "dead", "idle", "paging", "running", "sleeping", "stopped", "total", "total_threads", "wait", "zombies"},
@@ -20,6 +20,9 @@ var Registered_Metrics_Linux = map[string][]string{
 		"read_bytes", "read_count", "realtime_priority", "rlimit_cpu_time_hard", "rlimit_cpu_time_soft", "rlimit_file_locks_hard", "rlimit_file_locks_soft", "rlimit_memory_data_hard", "rlimit_memory_data_soft", "rlimit_memory_locked_hard", "rlimit_memory_locked_soft",
 		"rlimit_memory_rss_hard", "rlimit_memory_rss_soft", "rlimit_memory_stack_hard", "rlimit_memory_stack_soft", "rlimit_memory_vms_hard", "rlimit_memory_vms_soft", "rlimit_nice_priority_hard", "rlimit_nice_priority_soft", "rlimit_num_fds_hard", "rlimit_num_fds_soft",
 		"rlimit_realtime_priority_hard", "rlimit_realtime_priority_soft", "rlimit_signals_pending_hard", "rlimit_signals_pending_soft", "signals_pending", "voluntary_context_switches", "write_bytes", "write_count", "pid_count"},
+	"pressure": {"some_avg10", "some_avg60", "some_avg300", "some_stall_time", "full_avg10", "full_avg60", "full_avg300", "full_stall_time"},
+	"cgroup":   {"cpu_limit", "cpu_usage_percent", "cpu_throttled_percent", "memory_limit", "memory_used", "memory_working_set", "memory_used_percent"},
+	"numa":     {"mem_total", "mem_free", "mem_used", "mem_used_percent", "file_pages", "anon_pages", "hit", "miss", "foreign", "local_node", "other_node"},
 	"nvidia_smi": {"utilization_gpu", "temperature_gpu", "power_draw", "utilization_memory", "fan_speed", "memory_total", "memory_used", "memory_free", "temperature_gpu", "pcie_link_gen_current", "pcie_link_width_current",
 		"encoder_stats_session_count", "encoder_stats_average_fps", "encoder_stats_average_latency", "clocks_current_graphics", "clocks_current_sm", "clocks_current_memory", "clocks_current_video"},
 }
@@ -43,6 +46,16 @@ var Registered_Metrics_Darwin = map[string][]string{
 		"encoder_stats_session_count", "encoder_stats_average_fps", "encoder_stats_average_latency", "clocks_current_graphics", "clocks_current_sm", "clocks_current_memory", "clocks_current_video"},
 }
 
+// FreeBSD only supports the core host metrics. gopsutil reports no iowait, steal or guest
+// time there, and adds laundry and wired memory.
+var Registered_Metrics_FreeBSD = map[string][]string{
+	"cpu": {"time_active", "time_idle", "time_irq", "time_nice", "time_system", "time_user",
+		"usage_active", "usage_idle", "usage_irq", "usage_nice", "usage_system", "usage_user"},
+	"disk": {"free", "inodes_free", "inodes_total", "inodes_used", "total", "used", "used_percent"},
+	"mem":  {"active", "available", "available_percent", "buffered", "cached", "free", "inactive", "laundry", "total", "used", "used_percent", "wired"},
+	"net":  {"bytes_sent", "bytes_recv", "drop_in", "drop_out", "err_in", "err_out", "packets_sent", "packets_recv"},
+}
+
 var Registered_Metrics_Windows = map[string][]string{
 	"Processor":         {"% Idle Time", "% Interrupt Time", "% User Time", "% Processor Time"},
 	"LogicalDisk":       {"% Idle Time", "% Disk Read Time", "% Disk Write Time", "% User Time"},
@@ -52,10 +65,17 @@ var Registered_Metrics_Windows = map[string][]string{
 }
 
 var DisableWinPerfCounters = map[string]bool{
-	"statsd":     true,
-	"procstat":   true,
-	"nvidia_smi": true,
-	"jmx":        true,
-	"otlp":       true,
-	"prometheus": true,
+	"statsd":           true,
+	"procstat":         true,
+	"nvidia_smi":       true,
+	"jmx":              true,
+	"otlp":             true,
+	"prometheus":       true,
+	"file_stats":       true,
+	"certificates":     true,
+	"network_mesh":     true,
+	"http_json":        true,
+	"job_heartbeat":    true,
+	"windows_security": true,
+	"smart":            true,
 }
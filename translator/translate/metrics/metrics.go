@@ -109,6 +109,7 @@ func init() {
 	m := new(Metrics)
 	parent.RegisterLinuxRule(SectionKey, m)
 	parent.RegisterDarwinRule(SectionKey, m)
+	parent.RegisterFreeBSDRule(SectionKey, m)
 	parent.RegisterWindowsRule(SectionKey, m)
 	ChildRule["globalcredentials"] = util.GetCredsRule(OutputsKey)
 	ChildRule["region"] = util.GetRegionRule(OutputsKey)
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"certificates" : {
+//	    "paths": ["/etc/pki/tls/certs/*.pem"],
+//	    "stores": ["My"],
+//	    "metrics_collection_interval": 3600,
+//	    "append_dimensions":{
+//		key:value
+//	     }
+//
+// }
+
+const SectionKey = "certificates"
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type Certificates struct {
+}
+
+func (c *Certificates) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		//If exists, process it
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+		//Process tags
+		util.ProcessAppendDimensions(m[SectionKey].(map[string]interface{}), SectionKey, result)
+		returnKey = SectionKey
+		returnVal = []interface{}{result}
+	}
+	return
+}
+
+func init() {
+	c := new(Certificates)
+	parent.RegisterLinuxRule(SectionKey, c)
+	parent.RegisterDarwinRule(SectionKey, c)
+	parent.RegisterFreeBSDRule(SectionKey, c)
+	parent.RegisterWindowsRule(SectionKey, c)
+}
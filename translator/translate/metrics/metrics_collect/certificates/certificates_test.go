@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFullConfig(t *testing.T) {
+	c := new(Certificates)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"certificates": {
+					"paths": ["/etc/pki/tls/certs/*.pem"],
+					"stores": ["My", "WebHosting"],
+					"metrics_collection_interval": 3600,
+					"append_dimensions": {
+						"name": "sampleName"
+					}
+					}}`), &input))
+	key, actual := c.ApplyRule(input)
+
+	assert.Equal(t, SectionKey, key)
+	expected := []interface{}{map[string]interface{}{
+		"paths":  []string{"/etc/pki/tls/certs/*.pem"},
+		"stores": []string{"My", "WebHosting"},
+		"tags":   map[string]interface{}{"name": "sampleName"},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestPathsOnly(t *testing.T) {
+	c := new(Certificates)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"certificates": {"paths": ["/etc/ssl/certs/*.pem"]}}`), &input))
+	key, actual := c.ApplyRule(input)
+
+	assert.Equal(t, SectionKey, key)
+	expected := []interface{}{map[string]interface{}{
+		"paths":  []string{"/etc/ssl/certs/*.pem"},
+		"stores": []string{},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestNotConfigured(t *testing.T) {
+	c := new(Certificates)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cpu": {}}`), &input))
+	key, _ := c.ApplyRule(input)
+	assert.Equal(t, "", key)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Paths struct {
+}
+
+const SectionKey_Paths = "paths"
+
+func (obj *Paths) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultStringArrayCase(SectionKey_Paths, []interface{}{}, input)
+	return
+}
+
+func init() {
+	obj := new(Paths)
+	RegisterRule(SectionKey_Paths, obj)
+}
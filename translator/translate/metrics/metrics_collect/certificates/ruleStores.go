@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Stores struct {
+}
+
+const SectionKey_Stores = "stores"
+
+func (obj *Stores) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultStringArrayCase(SectionKey_Stores, []interface{}{}, input)
+	return
+}
+
+func init() {
+	obj := new(Stores)
+	RegisterRule(SectionKey_Stores, obj)
+}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroup
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"cgroup" : {
+//	    "measurement": ["cpu_usage_percent", "memory_used_percent"],
+//	    "paths": ["kubepods.slice/*"],
+//	    "include_self": true
+//	}
+const SectionKey = "cgroup"
+
+func GetCurPath() string {
+	curPath := parent.GetCurPath() + SectionKey + "/"
+	return curPath
+}
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type Cgroup struct {
+}
+
+func (c *Cgroup) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	res := []interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		//Check if there are some config entry with rules applied
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+
+		//Process common config, like measurement
+		hasValidMetric := util.ProcessLinuxCommonConfig(m[SectionKey], SectionKey, GetCurPath(), result)
+		if hasValidMetric {
+			res = append(res, result)
+			returnKey = SectionKey
+			returnVal = res
+		} else {
+			returnKey = ""
+		}
+	}
+	return
+}
+
+// The cgroup input reads the cgroup v2 hierarchy, so it is only registered for Linux.
+func init() {
+	c := new(Cgroup)
+	parent.RegisterLinuxRule(SectionKey, c)
+}
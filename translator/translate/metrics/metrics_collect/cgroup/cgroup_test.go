@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroup
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCgroupSpecificConfig(t *testing.T) {
+	c := new(Cgroup)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cgroup":{"metrics_collection_interval":"10s"}}`), &input))
+	actualReturnKey, _ := c.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+
+	require.NoError(t, json.Unmarshal([]byte(`{"cgroup":{"measurement": ["cpu_usage_percent","cgroup_memory_used_percent"]}}`), &input))
+	actualReturnKey, actualVal := c.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"fieldpass":    []string{"cpu_usage_percent", "memory_used_percent"},
+		"include_self": true,
+		"slices":       false,
+		"users":        false,
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
+
+func TestCgroupPaths(t *testing.T) {
+	c := new(Cgroup)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cgroup":{
+		"measurement": ["cpu_throttled_percent"],
+		"paths": ["kubepods.slice/*", "system.slice/docker-*.scope"],
+		"include_self": false
+	}}`), &input))
+	actualReturnKey, actualVal := c.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"fieldpass":    []string{"cpu_throttled_percent"},
+		"paths":        []string{"kubepods.slice/*", "system.slice/docker-*.scope"},
+		"include_self": false,
+		"slices":       false,
+		"users":        false,
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
+
+func TestCgroupSlicesAndUsers(t *testing.T) {
+	c := new(Cgroup)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cgroup":{
+		"measurement": ["cpu_usage_percent", "memory_used"],
+		"slices": true,
+		"users": true
+	}}`), &input))
+	actualReturnKey, actualVal := c.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"fieldpass":    []string{"cpu_usage_percent", "memory_used"},
+		"include_self": true,
+		"slices":       true,
+		"users":        true,
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroup
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type IncludeSelf struct {
+}
+
+const SectionKey_IncludeSelf = "include_self"
+
+func (i *IncludeSelf) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_IncludeSelf, true, input)
+	return
+}
+
+func init() {
+	i := new(IncludeSelf)
+	RegisterRule(SectionKey_IncludeSelf, i)
+}
@@ -0,0 +1,27 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroup
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Paths struct {
+}
+
+const SectionKey_Paths = "paths"
+
+// ApplyRule leaves the paths out when they are not set, only the cgroup of the agent is reported on then.
+func (p *Paths) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	if _, ok := input.(map[string]interface{})[SectionKey_Paths]; !ok {
+		return
+	}
+	returnKey, returnVal = translator.DefaultStringArrayCase(SectionKey_Paths, []interface{}{}, input)
+	return
+}
+
+func init() {
+	p := new(Paths)
+	RegisterRule(SectionKey_Paths, p)
+}
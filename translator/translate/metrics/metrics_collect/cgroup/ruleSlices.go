@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroup
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Slices struct {
+}
+
+const SectionKey_Slices = "slices"
+
+func (s *Slices) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_Slices, false, input)
+	return
+}
+
+func init() {
+	s := new(Slices)
+	RegisterRule(SectionKey_Slices, s)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroup
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Users struct {
+}
+
+const SectionKey_Users = "users"
+
+func (u *Users) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_Users, false, input)
+	return
+}
+
+func init() {
+	u := new(Users)
+	RegisterRule(SectionKey_Users, u)
+}
@@ -61,4 +61,5 @@ func init() {
 	c := new(Cpu)
 	parent.RegisterLinuxRule(SectionKey_CPU, c)
 	parent.RegisterDarwinRule(SectionKey_CPU, c)
+	parent.RegisterFreeBSDRule(SectionKey_CPU, c)
 }
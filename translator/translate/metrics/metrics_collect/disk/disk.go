@@ -59,4 +59,5 @@ func init() {
 	d := new(Disk)
 	parent.RegisterLinuxRule(SectionKey_Disk_Linux, d)
 	parent.RegisterDarwinRule(SectionKey_Disk_Linux, d)
+	parent.RegisterFreeBSDRule(SectionKey_Disk_Linux, d)
 }
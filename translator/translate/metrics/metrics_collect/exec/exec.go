@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"exec" : {
+//	    "commands": ["/opt/checks/queue_depth.sh --queue orders"],
+//	    "data_format": "json",
+//	    "metric_name": "queue",
+//	    "tag_keys": ["queue"],
+//	    "timeout": 5,
+//	    "max_concurrency": 4,
+//	    "run_as_user": "cwagent",
+//	    "run_as_group": "cwagent",
+//	    "metrics_collection_interval": 60,
+//	    "append_dimensions":{
+//		key:value
+//	     }
+//
+// }
+
+const SectionKey = "exec"
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type Exec struct {
+}
+
+func (e *Exec) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		//If exists, process it
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+		//Process tags
+		util.ProcessAppendDimensions(m[SectionKey].(map[string]interface{}), SectionKey, result)
+		returnKey = SectionKey
+		returnVal = []interface{}{result}
+	}
+	return
+}
+
+func init() {
+	e := new(Exec)
+	parent.RegisterLinuxRule(SectionKey, e)
+	parent.RegisterDarwinRule(SectionKey, e)
+	parent.RegisterFreeBSDRule(SectionKey, e)
+}
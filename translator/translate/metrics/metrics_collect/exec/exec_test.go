@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	e := new(Exec)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"exec": {"commands": ["/opt/checks/queue_depth.sh"]}}`), &input))
+	key, actual := e.ApplyRule(input)
+
+	assert.Equal(t, SectionKey, key)
+	expected := []interface{}{map[string]interface{}{
+		"commands":        []string{"/opt/checks/queue_depth.sh"},
+		"data_format":     "json",
+		"metric_name":     "exec",
+		"tag_keys":        []string{},
+		"timeout":         "5s",
+		"max_concurrency": 4,
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFullConfig(t *testing.T) {
+	e := new(Exec)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"exec": {
+					"commands": ["/opt/checks/queue_depth.sh --queue orders", "/opt/checks/jobs.sh"],
+					"data_format": "prometheus",
+					"metric_name": "queue",
+					"tag_keys": ["queue"],
+					"timeout": 10,
+					"max_concurrency": 2,
+					"run_as_user": "cwagent",
+					"run_as_group": "checks",
+					"metrics_collection_interval": 30,
+					"append_dimensions": {
+						"name": "sampleName"
+					}
+					}}`), &input))
+	_, actual := e.ApplyRule(input)
+
+	expected := []interface{}{map[string]interface{}{
+		"commands":        []string{"/opt/checks/queue_depth.sh --queue orders", "/opt/checks/jobs.sh"},
+		"data_format":     "prometheus",
+		"metric_name":     "queue",
+		"tag_keys":        []string{"queue"},
+		"timeout":         "10s",
+		"max_concurrency": 2,
+		"run_as_user":     "cwagent",
+		"run_as_group":    "checks",
+		"tags":            map[string]interface{}{"name": "sampleName"},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestNotConfigured(t *testing.T) {
+	e := new(Exec)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cpu": {}}`), &input))
+	key, _ := e.ApplyRule(input)
+	assert.Equal(t, "", key)
+}
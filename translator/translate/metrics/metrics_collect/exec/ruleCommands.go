@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Commands struct {
+}
+
+const SectionKey_Commands = "commands"
+
+func (obj *Commands) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultStringArrayCase(SectionKey_Commands, []interface{}{}, input)
+	return
+}
+
+func init() {
+	obj := new(Commands)
+	RegisterRule(SectionKey_Commands, obj)
+}
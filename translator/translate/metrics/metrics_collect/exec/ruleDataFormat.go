@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type DataFormat struct {
+}
+
+const SectionKey_DataFormat = "data_format"
+
+func (obj *DataFormat) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_DataFormat, "json", input)
+	return
+}
+
+func init() {
+	obj := new(DataFormat)
+	RegisterRule(SectionKey_DataFormat, obj)
+}
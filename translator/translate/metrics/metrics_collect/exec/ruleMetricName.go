@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type MetricName struct {
+}
+
+const SectionKey_MetricName = "metric_name"
+
+func (obj *MetricName) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_MetricName, "exec", input)
+	return
+}
+
+func init() {
+	obj := new(MetricName)
+	RegisterRule(SectionKey_MetricName, obj)
+}
@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+type RunAsGroup struct {
+}
+
+const SectionKey_RunAsGroup = "run_as_group"
+
+// The commands run with the group of run_as_user, or of the agent, unless it is set.
+func (obj *RunAsGroup) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_RunAsGroup]; ok {
+		returnKey = SectionKey_RunAsGroup
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(RunAsGroup)
+	RegisterRule(SectionKey_RunAsGroup, obj)
+}
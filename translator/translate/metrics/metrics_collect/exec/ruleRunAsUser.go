@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+type RunAsUser struct {
+}
+
+const SectionKey_RunAsUser = "run_as_user"
+
+// The commands run as the user of the agent unless it is set.
+func (obj *RunAsUser) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_RunAsUser]; ok {
+		returnKey = SectionKey_RunAsUser
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(RunAsUser)
+	RegisterRule(SectionKey_RunAsUser, obj)
+}
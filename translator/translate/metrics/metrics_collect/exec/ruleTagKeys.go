@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type TagKeys struct {
+}
+
+const SectionKey_TagKeys = "tag_keys"
+
+func (obj *TagKeys) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultStringArrayCase(SectionKey_TagKeys, []interface{}{}, input)
+	return
+}
+
+func init() {
+	obj := new(TagKeys)
+	RegisterRule(SectionKey_TagKeys, obj)
+}
@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package file_stats
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"file_stats" : {
+//	    "files": ["/var/spool/postfix/deferred/**", "/backup/*.tar.gz"],
+//	    "metrics_collection_interval": 60,
+//	    "append_dimensions":{
+//		key:value
+//	     }
+//
+// }
+
+const SectionKey = "file_stats"
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type FileStats struct {
+}
+
+func (f *FileStats) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		//If exists, process it
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+		//Process tags
+		util.ProcessAppendDimensions(m[SectionKey].(map[string]interface{}), SectionKey, result)
+		returnKey = SectionKey
+		returnVal = []interface{}{result}
+	}
+	return
+}
+
+func init() {
+	f := new(FileStats)
+	parent.RegisterLinuxRule(SectionKey, f)
+	parent.RegisterDarwinRule(SectionKey, f)
+	parent.RegisterFreeBSDRule(SectionKey, f)
+	parent.RegisterWindowsRule(SectionKey, f)
+}
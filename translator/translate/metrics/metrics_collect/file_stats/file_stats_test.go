@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package file_stats
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFullConfig(t *testing.T) {
+	f := new(FileStats)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"file_stats": {
+					"files": ["/var/spool/postfix/deferred/**", "/backup/*.tar.gz"],
+					"metrics_collection_interval": 30,
+					"append_dimensions": {
+						"name": "sampleName"
+					}
+					}}`), &input))
+	key, actual := f.ApplyRule(input)
+
+	assert.Equal(t, SectionKey, key)
+	expected := []interface{}{map[string]interface{}{
+		"files": []string{"/var/spool/postfix/deferred/**", "/backup/*.tar.gz"},
+		"tags":  map[string]interface{}{"name": "sampleName"},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestNotConfigured(t *testing.T) {
+	f := new(FileStats)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cpu": {}}`), &input))
+	key, _ := f.ApplyRule(input)
+	assert.Equal(t, "", key)
+}
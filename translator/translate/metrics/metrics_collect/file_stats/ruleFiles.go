@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package file_stats
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Files struct {
+}
+
+const SectionKey_Files = "files"
+
+func (obj *Files) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultStringArrayCase(SectionKey_Files, []interface{}{}, input)
+	return
+}
+
+func init() {
+	obj := new(Files)
+	RegisterRule(SectionKey_Files, obj)
+}
@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"http_json" : {
+//	    "urls": ["http://localhost:8080/admin/stats"],
+//	    "headers": {"X-Api-Key": "secret"},
+//	    "username": "cwagent",
+//	    "password": "secret",
+//	    "bearer_token_file": "/etc/cwagent/token",
+//	    "tls_ca": "/etc/cwagent/ca.pem",
+//	    "tls_cert": "/etc/cwagent/cert.pem",
+//	    "tls_key": "/etc/cwagent/key.pem",
+//	    "insecure_skip_verify": false,
+//	    "timeout": 5,
+//	    "metric_name": "admin",
+//	    "metrics": [
+//	        {"name": "queue_depth", "query": "queues[?name=='orders'].depth | [0]"}
+//	    ],
+//	    "dimensions": {"version": "build.version"},
+//	    "metrics_collection_interval": 60,
+//	    "append_dimensions":{
+//		key:value
+//	     }
+//
+// }
+
+const SectionKey = "http_json"
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type HTTPJSON struct {
+}
+
+func (h *HTTPJSON) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		//If exists, process it
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+		//Process tags
+		util.ProcessAppendDimensions(m[SectionKey].(map[string]interface{}), SectionKey, result)
+		returnKey = SectionKey
+		returnVal = []interface{}{result}
+	}
+	return
+}
+
+func init() {
+	h := new(HTTPJSON)
+	parent.RegisterLinuxRule(SectionKey, h)
+	parent.RegisterDarwinRule(SectionKey, h)
+	parent.RegisterFreeBSDRule(SectionKey, h)
+	parent.RegisterWindowsRule(SectionKey, h)
+}
@@ -0,0 +1,82 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	h := new(HTTPJSON)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"http_json": {
+					"urls": ["http://localhost:8080/admin/stats"],
+					"metrics": [{"name": "healthy", "query": "healthy"}]
+					}}`), &input))
+	key, actual := h.ApplyRule(input)
+
+	assert.Equal(t, SectionKey, key)
+	expected := []interface{}{map[string]interface{}{
+		"urls":        []string{"http://localhost:8080/admin/stats"},
+		"timeout":     "5s",
+		"metric_name": "http_json",
+		"metrics":     []interface{}{map[string]interface{}{"name": "healthy", "query": "healthy"}},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFullConfig(t *testing.T) {
+	h := new(HTTPJSON)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"http_json": {
+					"urls": ["https://localhost:8443/admin/stats"],
+					"headers": {"X-Api-Key": "secret"},
+					"username": "cwagent",
+					"password": "pass",
+					"bearer_token_file": "/etc/cwagent/token",
+					"tls_ca": "/etc/cwagent/ca.pem",
+					"tls_cert": "/etc/cwagent/cert.pem",
+					"tls_key": "/etc/cwagent/key.pem",
+					"insecure_skip_verify": true,
+					"timeout": 10,
+					"metric_name": "admin",
+					"metrics": [{"name": "queue_depth", "query": "queues[?name=='orders'].depth | [0]"}],
+					"dimensions": {"version": "build.version"},
+					"metrics_collection_interval": 30,
+					"append_dimensions": {
+						"name": "sampleName"
+					}
+					}}`), &input))
+	_, actual := h.ApplyRule(input)
+
+	expected := []interface{}{map[string]interface{}{
+		"urls":                 []string{"https://localhost:8443/admin/stats"},
+		"headers":              map[string]interface{}{"X-Api-Key": "secret"},
+		"username":             "cwagent",
+		"password":             "pass",
+		"bearer_token_file":    "/etc/cwagent/token",
+		"tls_ca":               "/etc/cwagent/ca.pem",
+		"tls_cert":             "/etc/cwagent/cert.pem",
+		"tls_key":              "/etc/cwagent/key.pem",
+		"insecure_skip_verify": true,
+		"timeout":              "10s",
+		"metric_name":          "admin",
+		"metrics":              []interface{}{map[string]interface{}{"name": "queue_depth", "query": "queues[?name=='orders'].depth | [0]"}},
+		"dimensions":           map[string]interface{}{"version": "build.version"},
+		"tags":                 map[string]interface{}{"name": "sampleName"},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestNotConfigured(t *testing.T) {
+	h := new(HTTPJSON)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cpu": {}}`), &input))
+	key, _ := h.ApplyRule(input)
+	assert.Equal(t, "", key)
+}
@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+type BearerTokenFile struct {
+}
+
+const SectionKey_BearerTokenFile = "bearer_token_file"
+
+// The token is read from the file on every request, so that it can be rotated without a restart.
+func (obj *BearerTokenFile) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_BearerTokenFile]; ok {
+		returnKey = SectionKey_BearerTokenFile
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(BearerTokenFile)
+	RegisterRule(SectionKey_BearerTokenFile, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+type Dimensions struct {
+}
+
+const SectionKey_Dimensions = "dimensions"
+
+func (obj *Dimensions) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_Dimensions]; ok {
+		returnKey = SectionKey_Dimensions
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(Dimensions)
+	RegisterRule(SectionKey_Dimensions, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+type Headers struct {
+}
+
+const SectionKey_Headers = "headers"
+
+func (obj *Headers) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_Headers]; ok {
+		returnKey = SectionKey_Headers
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(Headers)
+	RegisterRule(SectionKey_Headers, obj)
+}
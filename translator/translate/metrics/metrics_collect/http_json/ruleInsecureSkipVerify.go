@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+type InsecureSkipVerify struct {
+}
+
+const SectionKey_InsecureSkipVerify = "insecure_skip_verify"
+
+func (obj *InsecureSkipVerify) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_InsecureSkipVerify]; ok {
+		returnKey = SectionKey_InsecureSkipVerify
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(InsecureSkipVerify)
+	RegisterRule(SectionKey_InsecureSkipVerify, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type MetricName struct {
+}
+
+const SectionKey_MetricName = "metric_name"
+
+func (obj *MetricName) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_MetricName, "http_json", input)
+	return
+}
+
+func init() {
+	obj := new(MetricName)
+	RegisterRule(SectionKey_MetricName, obj)
+}
@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+type Metrics struct {
+}
+
+const SectionKey_Metrics = "metrics"
+
+// The metrics are passed as they are, one table of name and query each.
+func (obj *Metrics) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_Metrics]; ok {
+		returnKey = SectionKey_Metrics
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(Metrics)
+	RegisterRule(SectionKey_Metrics, obj)
+}
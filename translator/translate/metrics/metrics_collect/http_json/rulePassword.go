@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+type Password struct {
+}
+
+const SectionKey_Password = "password"
+
+func (obj *Password) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_Password]; ok {
+		returnKey = SectionKey_Password
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(Password)
+	RegisterRule(SectionKey_Password, obj)
+}
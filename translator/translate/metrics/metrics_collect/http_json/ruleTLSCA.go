@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+type TLSCA struct {
+}
+
+const SectionKey_TLSCA = "tls_ca"
+
+func (obj *TLSCA) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_TLSCA]; ok {
+		returnKey = SectionKey_TLSCA
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(TLSCA)
+	RegisterRule(SectionKey_TLSCA, obj)
+}
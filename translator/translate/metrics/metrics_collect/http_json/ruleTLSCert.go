@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+type TLSCert struct {
+}
+
+const SectionKey_TLSCert = "tls_cert"
+
+func (obj *TLSCert) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_TLSCert]; ok {
+		returnKey = SectionKey_TLSCert
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(TLSCert)
+	RegisterRule(SectionKey_TLSCert, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+type TLSKey struct {
+}
+
+const SectionKey_TLSKey = "tls_key"
+
+func (obj *TLSKey) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_TLSKey]; ok {
+		returnKey = SectionKey_TLSKey
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(TLSKey)
+	RegisterRule(SectionKey_TLSKey, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type URLs struct {
+}
+
+const SectionKey_URLs = "urls"
+
+func (obj *URLs) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultStringArrayCase(SectionKey_URLs, []interface{}{}, input)
+	return
+}
+
+func init() {
+	obj := new(URLs)
+	RegisterRule(SectionKey_URLs, obj)
+}
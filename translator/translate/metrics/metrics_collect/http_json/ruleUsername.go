@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package http_json
+
+type Username struct {
+}
+
+const SectionKey_Username = "username"
+
+func (obj *Username) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_Username]; ok {
+		returnKey = SectionKey_Username
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(Username)
+	RegisterRule(SectionKey_Username, obj)
+}
@@ -0,0 +1,59 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package job_heartbeat
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"job_heartbeat" : {
+//	    "service_address": "127.0.0.1:25891",
+//	    "drop_directory": "/var/run/amazon-cloudwatch-agent/jobs",
+//	    "timeout": 86400,
+//	    "metrics_collection_interval": 60,
+//	    "append_dimensions":{
+//		key:value
+//	     }
+//
+// }
+
+const SectionKey = "job_heartbeat"
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type JobHeartbeat struct {
+}
+
+func (j *JobHeartbeat) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		//If exists, process it
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+		//Process tags
+		util.ProcessAppendDimensions(m[SectionKey].(map[string]interface{}), SectionKey, result)
+		returnKey = SectionKey
+		returnVal = []interface{}{result}
+	}
+	return
+}
+
+func init() {
+	j := new(JobHeartbeat)
+	parent.RegisterLinuxRule(SectionKey, j)
+	parent.RegisterDarwinRule(SectionKey, j)
+	parent.RegisterFreeBSDRule(SectionKey, j)
+	parent.RegisterWindowsRule(SectionKey, j)
+}
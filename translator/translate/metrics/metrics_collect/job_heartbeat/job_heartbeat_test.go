@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package job_heartbeat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	j := new(JobHeartbeat)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"job_heartbeat": {}}`), &input))
+	key, actual := j.ApplyRule(input)
+
+	assert.Equal(t, SectionKey, key)
+	expected := []interface{}{map[string]interface{}{
+		"service_address": "127.0.0.1:25891",
+		"timeout":         "86400s",
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestDropDirectoryOnly(t *testing.T) {
+	j := new(JobHeartbeat)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"job_heartbeat": {
+					"drop_directory": "/var/run/amazon-cloudwatch-agent/jobs"
+					}}`), &input))
+	_, actual := j.ApplyRule(input)
+
+	expected := []interface{}{map[string]interface{}{
+		"drop_directory": "/var/run/amazon-cloudwatch-agent/jobs",
+		"timeout":        "86400s",
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFullConfig(t *testing.T) {
+	j := new(JobHeartbeat)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"job_heartbeat": {
+					"service_address": "127.0.0.1:9100",
+					"drop_directory": "/var/run/amazon-cloudwatch-agent/jobs",
+					"timeout": 3600,
+					"metrics_collection_interval": 30,
+					"append_dimensions": {
+						"team": "storage"
+					}
+					}}`), &input))
+	_, actual := j.ApplyRule(input)
+
+	expected := []interface{}{map[string]interface{}{
+		"service_address": "127.0.0.1:9100",
+		"drop_directory":  "/var/run/amazon-cloudwatch-agent/jobs",
+		"timeout":         "3600s",
+		"tags":            map[string]interface{}{"team": "storage"},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestNotConfigured(t *testing.T) {
+	j := new(JobHeartbeat)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cpu": {}}`), &input))
+	key, _ := j.ApplyRule(input)
+	assert.Equal(t, "", key)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package job_heartbeat
+
+type DropDirectory struct {
+}
+
+const SectionKey_DropDirectory = "drop_directory"
+
+func (obj *DropDirectory) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_DropDirectory]; ok {
+		returnKey = SectionKey_DropDirectory
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(DropDirectory)
+	RegisterRule(SectionKey_DropDirectory, obj)
+}
@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package job_heartbeat
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+)
+
+type ServiceAddress struct {
+}
+
+const (
+	SectionKey_ServiceAddress = "service_address"
+
+	defaultServiceAddress = "127.0.0.1:25891"
+)
+
+// ApplyRule only defaults the service address when no drop directory is configured, so that the markers can be
+// received from files alone.
+func (obj *ServiceAddress) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[SectionKey_DropDirectory]; ok {
+		if val, ok := m[SectionKey_ServiceAddress]; ok {
+			returnKey = SectionKey_ServiceAddress
+			returnVal = val
+		}
+		return
+	}
+	returnKey, returnVal = translator.DefaultCase(SectionKey_ServiceAddress, agent.ListenAddress(defaultServiceAddress), input)
+	return
+}
+
+func init() {
+	obj := new(ServiceAddress)
+	RegisterRule(SectionKey_ServiceAddress, obj)
+}
@@ -60,4 +60,5 @@ func init() {
 	m := new(Mem)
 	parent.RegisterLinuxRule(SectionKey_Mem_Linux, m)
 	parent.RegisterDarwinRule(SectionKey_Mem_Linux, m)
+	parent.RegisterFreeBSDRule(SectionKey_Mem_Linux, m)
 }
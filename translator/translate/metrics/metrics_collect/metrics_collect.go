@@ -21,6 +21,7 @@ var (
 	windowsMetricCollectRule = map[string]Rule{}
 	linuxMetricCollectRule   = map[string]Rule{}
 	darwinMetricCollectRule  = map[string]Rule{}
+	freebsdMetricCollectRule = map[string]Rule{}
 )
 
 const SectionKey = "metrics_collected"
@@ -39,6 +40,10 @@ func RegisterDarwinRule(ruleName string, r Rule) {
 	darwinMetricCollectRule[ruleName] = r
 }
 
+func RegisterFreeBSDRule(ruleName string, r Rule) {
+	freebsdMetricCollectRule[ruleName] = r
+}
+
 func RegisterWindowsRule(ruleName string, r Rule) {
 	windowsMetricCollectRule[ruleName] = r
 }
@@ -56,6 +61,8 @@ func (c *CollectMetrics) ApplyRule(input interface{}) (returnKey string, returnV
 		c.targetRuleMap = linuxMetricCollectRule
 	case config.OS_TYPE_DARWIN:
 		c.targetRuleMap = darwinMetricCollectRule
+	case config.OS_TYPE_FREEBSD:
+		c.targetRuleMap = freebsdMetricCollectRule
 	case config.OS_TYPE_WINDOWS:
 		c.targetRuleMap = windowsMetricCollectRule
 	default:
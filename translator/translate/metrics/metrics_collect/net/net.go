@@ -62,4 +62,5 @@ func init() {
 	n := new(Net)
 	parent.RegisterLinuxRule(SectionKey_Net_Linux, n)
 	parent.RegisterDarwinRule(SectionKey_Net_Linux, n)
+	parent.RegisterFreeBSDRule(SectionKey_Net_Linux, n)
 }
@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"network_mesh" : {
+//	    "peer_tag_key": "MeshGroup",
+//	    "peer_tag_value": "checkout",
+//	    "method": "tcp",
+//	    "port": 22,
+//	    "count": 5,
+//	    "timeout": 1,
+//	    "discovery_interval": 300,
+//	    "max_concurrency": 16,
+//	    "metrics_collection_interval": 60,
+//	    "append_dimensions":{
+//		key:value
+//	     }
+//
+// }
+
+const (
+	SectionKey = "network_mesh"
+	regionKey  = "region"
+)
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type NetworkMesh struct {
+}
+
+func (n *NetworkMesh) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		//If exists, process it
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+		//The peers are discovered with the region and credentials of the agent
+		result[regionKey] = agent.Global_Config.Region
+		if agent.Global_Config.Role_arn != "" {
+			result[agent.Role_Arn_Key] = agent.Global_Config.Role_arn
+		}
+		for _, key := range []string{agent.Profile_Key, agent.CredentialsFile_Key} {
+			if val, ok := agent.Global_Config.Credentials[key]; ok {
+				result[key] = val
+			}
+		}
+		//Process tags
+		util.ProcessAppendDimensions(m[SectionKey].(map[string]interface{}), SectionKey, result)
+		returnKey = SectionKey
+		returnVal = []interface{}{result}
+	}
+	return
+}
+
+func init() {
+	n := new(NetworkMesh)
+	parent.RegisterLinuxRule(SectionKey, n)
+	parent.RegisterDarwinRule(SectionKey, n)
+	parent.RegisterFreeBSDRule(SectionKey, n)
+	parent.RegisterWindowsRule(SectionKey, n)
+}
@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	setGlobalConfig(t, "us-east-1", "", nil)
+	n := new(NetworkMesh)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"network_mesh": {"peer_tag_key": "MeshGroup"}}`), &input))
+	key, actual := n.ApplyRule(input)
+
+	assert.Equal(t, SectionKey, key)
+	expected := []interface{}{map[string]interface{}{
+		"peer_tag_key":       "MeshGroup",
+		"method":             "tcp",
+		"port":               22,
+		"count":              5,
+		"timeout":            "1s",
+		"discovery_interval": "300s",
+		"max_concurrency":    16,
+		"region":             "us-east-1",
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFullConfig(t *testing.T) {
+	setGlobalConfig(t, "eu-west-1", "arn:aws:iam::123456789012:role/mesh", map[string]interface{}{
+		agent.Profile_Key:         "mesh",
+		agent.CredentialsFile_Key: "/root/.aws/credentials",
+	})
+	n := new(NetworkMesh)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"network_mesh": {
+					"peer_parameter": "/network-mesh/checkout",
+					"method": "icmp",
+					"count": 10,
+					"timeout": 2,
+					"discovery_interval": 60,
+					"max_concurrency": 4,
+					"metrics_collection_interval": 30,
+					"append_dimensions": {
+						"name": "sampleName"
+					}
+					}}`), &input))
+	_, actual := n.ApplyRule(input)
+
+	expected := []interface{}{map[string]interface{}{
+		"peer_parameter":         "/network-mesh/checkout",
+		"method":                 "icmp",
+		"port":                   22,
+		"count":                  10,
+		"timeout":                "2s",
+		"discovery_interval":     "60s",
+		"max_concurrency":        4,
+		"region":                 "eu-west-1",
+		"role_arn":               "arn:aws:iam::123456789012:role/mesh",
+		"profile":                "mesh",
+		"shared_credential_file": "/root/.aws/credentials",
+		"tags":                   map[string]interface{}{"name": "sampleName"},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestNotConfigured(t *testing.T) {
+	n := new(NetworkMesh)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cpu": {}}`), &input))
+	key, _ := n.ApplyRule(input)
+	assert.Equal(t, "", key)
+}
+
+func setGlobalConfig(t *testing.T, region, roleARN string, credentials map[string]interface{}) {
+	previous := agent.Global_Config
+	t.Cleanup(func() { agent.Global_Config = previous })
+	agent.Global_Config.Region = region
+	agent.Global_Config.Role_arn = roleARN
+	agent.Global_Config.Credentials = credentials
+}
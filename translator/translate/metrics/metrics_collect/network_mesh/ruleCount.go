@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Count struct {
+}
+
+const SectionKey_Count = "count"
+
+func (obj *Count) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultIntegralCase(SectionKey_Count, float64(5), input)
+	return
+}
+
+func init() {
+	obj := new(Count)
+	RegisterRule(SectionKey_Count, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type DiscoveryInterval struct {
+}
+
+const SectionKey_DiscoveryInterval = "discovery_interval"
+
+func (obj *DiscoveryInterval) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultTimeIntervalCase(SectionKey_DiscoveryInterval, float64(300), input)
+	return
+}
+
+func init() {
+	obj := new(DiscoveryInterval)
+	RegisterRule(SectionKey_DiscoveryInterval, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type MaxConcurrency struct {
+}
+
+const SectionKey_MaxConcurrency = "max_concurrency"
+
+func (obj *MaxConcurrency) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultIntegralCase(SectionKey_MaxConcurrency, float64(16), input)
+	return
+}
+
+func init() {
+	obj := new(MaxConcurrency)
+	RegisterRule(SectionKey_MaxConcurrency, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Method struct {
+}
+
+const SectionKey_Method = "method"
+
+func (obj *Method) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_Method, "tcp", input)
+	return
+}
+
+func init() {
+	obj := new(Method)
+	RegisterRule(SectionKey_Method, obj)
+}
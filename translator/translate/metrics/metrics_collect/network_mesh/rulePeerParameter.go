@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+type PeerParameter struct {
+}
+
+const SectionKey_PeerParameter = "peer_parameter"
+
+// The peers are discovered with either the tag or the parameter.
+func (obj *PeerParameter) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_PeerParameter]; ok {
+		returnKey = SectionKey_PeerParameter
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(PeerParameter)
+	RegisterRule(SectionKey_PeerParameter, obj)
+}
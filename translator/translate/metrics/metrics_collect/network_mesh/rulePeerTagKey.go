@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+type PeerTagKey struct {
+}
+
+const SectionKey_PeerTagKey = "peer_tag_key"
+
+// The peers are discovered with either the tag or the parameter.
+func (obj *PeerTagKey) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_PeerTagKey]; ok {
+		returnKey = SectionKey_PeerTagKey
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(PeerTagKey)
+	RegisterRule(SectionKey_PeerTagKey, obj)
+}
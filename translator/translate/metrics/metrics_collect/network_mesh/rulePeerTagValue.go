@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+type PeerTagValue struct {
+}
+
+const SectionKey_PeerTagValue = "peer_tag_value"
+
+// All the instances with the tag key are peers unless the value is set.
+func (obj *PeerTagValue) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_PeerTagValue]; ok {
+		returnKey = SectionKey_PeerTagValue
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(PeerTagValue)
+	RegisterRule(SectionKey_PeerTagValue, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package network_mesh
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Port struct {
+}
+
+const SectionKey_Port = "port"
+
+func (obj *Port) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultIntegralCase(SectionKey_Port, float64(22), input)
+	return
+}
+
+func init() {
+	obj := new(Port)
+	RegisterRule(SectionKey_Port, obj)
+}
@@ -0,0 +1,28 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package numa
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumaSpecificConfig(t *testing.T) {
+	p := new(Numa)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"numa":{"metrics_collection_interval":"10s"}}`), &input))
+	actualReturnKey, _ := p.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+
+	require.NoError(t, json.Unmarshal([]byte(`{"numa":{"measurement": ["mem_used_percent","numa_miss"]}}`), &input))
+	actualReturnKey, actualVal := p.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"fieldpass": []string{"mem_used_percent", "miss"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+const SectionKey = "pressure"
+
+func GetCurPath() string {
+	curPath := parent.GetCurPath() + SectionKey + "/"
+	return curPath
+}
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type Pressure struct {
+}
+
+func (p *Pressure) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	result := map[string]interface{}{}
+	res := []interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+
+		/*
+		  In JSON config file, it represent as "pressure" : {"measurement": ["some_avg10", "full_avg10"]}
+		  To check the specification config entry
+		*/
+		//Check if there are some config entry with rules applied
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+
+		//Process common config, like measurement
+		hasValidMetric := util.ProcessLinuxCommonConfig(m[SectionKey], SectionKey, GetCurPath(), result)
+		if hasValidMetric {
+			res = append(res, result)
+			returnKey = SectionKey
+			returnVal = res
+		} else {
+			returnKey = ""
+		}
+	}
+	return
+}
+
+// The pressure input reads Linux-only files, so it is only registered for Linux.
+func init() {
+	p := new(Pressure)
+	parent.RegisterLinuxRule(SectionKey, p)
+}
@@ -0,0 +1,28 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPressureSpecificConfig(t *testing.T) {
+	p := new(Pressure)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"pressure":{"metrics_collection_interval":"10s"}}`), &input))
+	actualReturnKey, _ := p.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey, "return key should be empty")
+
+	require.NoError(t, json.Unmarshal([]byte(`{"pressure":{"measurement": ["some_avg10","full_stall_time"]}}`), &input))
+	actualReturnKey, actualVal := p.ApplyRule(input)
+	assert.Equal(t, SectionKey, actualReturnKey)
+	expectedVal := []interface{}{map[string]interface{}{
+		"fieldpass": []string{"some_avg10", "full_stall_time"},
+	}}
+	assert.Equal(t, expectedVal, actualVal)
+}
@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus_textfile
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	p := new(PrometheusTextfile)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"prometheus_textfile": {}}`), &input))
+	key, actual := p.ApplyRule(input)
+
+	assert.Equal(t, SectionKey, key)
+	expected := []interface{}{map[string]interface{}{
+		"directory": defaultDirectory,
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFullConfig(t *testing.T) {
+	p := new(PrometheusTextfile)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"prometheus_textfile": {
+					"directory": "/opt/metrics",
+					"metrics_collection_interval": 30,
+					"append_dimensions": {
+						"name": "sampleName"
+					}
+					}}`), &input))
+	_, actual := p.ApplyRule(input)
+
+	expected := []interface{}{map[string]interface{}{
+		"directory": "/opt/metrics",
+		"tags":      map[string]interface{}{"name": "sampleName"},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestNotConfigured(t *testing.T) {
+	p := new(PrometheusTextfile)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cpu": {}}`), &input))
+	key, _ := p.ApplyRule(input)
+	assert.Equal(t, "", key)
+}
@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus_textfile
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+// The default directory of the textfile collector in the node_exporter packages.
+const defaultDirectory = "/var/lib/node_exporter/textfile_collector"
+
+type Directory struct {
+}
+
+const SectionKey_Directory = "directory"
+
+func (obj *Directory) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_Directory, defaultDirectory, input)
+	return
+}
+
+func init() {
+	obj := new(Directory)
+	RegisterRule(SectionKey_Directory, obj)
+}
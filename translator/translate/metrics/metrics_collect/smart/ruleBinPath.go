@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package smart
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type BinPath struct {
+}
+
+const SectionKey_BinPath = "bin_path"
+
+func (obj *BinPath) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_BinPath, "", input)
+	return
+}
+
+func init() {
+	obj := new(BinPath)
+	RegisterRule(SectionKey_BinPath, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package smart
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type Devices struct {
+}
+
+const SectionKey_Devices = "devices"
+
+func (obj *Devices) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultStringArrayCase(SectionKey_Devices, []interface{}{}, input)
+	return
+}
+
+func init() {
+	obj := new(Devices)
+	RegisterRule(SectionKey_Devices, obj)
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package smart
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	s := new(Smart)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"smart": {}}`), &input))
+	key, actual := s.ApplyRule(input)
+
+	assert.Equal(t, SectionKey, key)
+	expected := []interface{}{map[string]interface{}{
+		"bin_path": "",
+		"use_sudo": false,
+		"devices":  []string{},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFullConfig(t *testing.T) {
+	s := new(Smart)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"smart": {
+					"bin_path": "/usr/sbin/smartctl",
+					"use_sudo": true,
+					"devices": ["/dev/sda -d sat", "/dev/nvme0"],
+					"metrics_collection_interval": 3600,
+					"append_dimensions": {
+						"rack": "r12"
+					}
+					}}`), &input))
+	_, actual := s.ApplyRule(input)
+
+	expected := []interface{}{map[string]interface{}{
+		"bin_path": "/usr/sbin/smartctl",
+		"use_sudo": true,
+		"devices":  []string{"/dev/sda -d sat", "/dev/nvme0"},
+		"tags":     map[string]interface{}{"rack": "r12"},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestNotConfigured(t *testing.T) {
+	s := new(Smart)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cpu": {}}`), &input))
+	key, _ := s.ApplyRule(input)
+	assert.Equal(t, "", key)
+}
@@ -0,0 +1,27 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type StateFile struct {
+}
+
+const SectionKey_StateFile = "state_file"
+
+// StateFile keeps the delta counters the agent has not published across restarts. It is only set when configured.
+func (obj *StateFile) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_StateFile, "", input)
+	if returnVal != "" {
+		return returnKey, returnVal
+	}
+	return "", nil
+}
+
+func init() {
+	obj := new(StateFile)
+	RegisterRule(SectionKey_StateFile, obj)
+}
@@ -15,6 +15,7 @@ func TestStatsD_HappyCase(t *testing.T) {
 	var input interface{}
 	err := json.Unmarshal([]byte(`{"statsd": {
 					"service_address": ":12345",
+					"state_file": "/opt/aws/amazon-cloudwatch-agent/logs/state/statsd_counters.json",
 					"metrics_collection_interval": 5,
 					"metrics_aggregation_interval": 30,
 					"allowed_pending_messages": 10000
@@ -27,6 +28,7 @@ func TestStatsD_HappyCase(t *testing.T) {
 		map[string]interface{}{
 			"allowed_pending_messages": 10000,
 			"service_address":          ":12345",
+			"state_file":               "/opt/aws/amazon-cloudwatch-agent/logs/state/statsd_counters.json",
 			"interval":                 "5s",
 			"parse_data_dog_tags":      true,
 			"tags":                     map[string]interface{}{"aws:AggregationInterval": "30s"},
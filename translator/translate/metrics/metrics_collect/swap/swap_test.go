@@ -33,3 +33,20 @@ func TestSwapSpecificConfig(t *testing.T) {
 		panic(e)
 	}
 }
+
+// Check the swap activity, which is collected along with the usage
+func TestSwapActivity(t *testing.T) {
+	s := new(Swap)
+	var input interface{}
+	e := json.Unmarshal([]byte(`{"swap":{"measurement": ["swap_used_percent","swap_in","swap_out"]}}`), &input)
+	if e == nil {
+		_, actualVal := s.ApplyRule(input)
+		expectedVal := []interface{}{map[string]interface{}{
+			"fieldpass": []string{"used_percent", "in", "out"},
+		},
+		}
+		assert.Equal(t, expectedVal, actualVal, "Expect to be equal")
+	} else {
+		panic(e)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_security
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type SearchOnline struct {
+}
+
+const SectionKey_SearchOnline = "search_online"
+
+func (obj *SearchOnline) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultCase(SectionKey_SearchOnline, false, input)
+	return
+}
+
+func init() {
+	obj := new(SearchOnline)
+	RegisterRule(SectionKey_SearchOnline, obj)
+}
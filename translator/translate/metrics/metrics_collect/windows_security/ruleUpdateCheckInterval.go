@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_security
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type UpdateCheckInterval struct {
+}
+
+const SectionKey_UpdateCheckInterval = "update_check_interval"
+
+func (obj *UpdateCheckInterval) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultTimeIntervalCase(SectionKey_UpdateCheckInterval, float64(3600), input)
+	return
+}
+
+func init() {
+	obj := new(UpdateCheckInterval)
+	RegisterRule(SectionKey_UpdateCheckInterval, obj)
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_security
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	w := new(WindowsSecurity)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"windows_security": {}}`), &input))
+	key, actual := w.ApplyRule(input)
+
+	assert.Equal(t, SectionKey, key)
+	expected := []interface{}{map[string]interface{}{
+		"update_check_interval": "3600s",
+		"search_online":         false,
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFullConfig(t *testing.T) {
+	w := new(WindowsSecurity)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"windows_security": {
+					"update_check_interval": 21600,
+					"search_online": true,
+					"metrics_collection_interval": 300,
+					"append_dimensions": {
+						"fleet": "web"
+					}
+					}}`), &input))
+	_, actual := w.ApplyRule(input)
+
+	expected := []interface{}{map[string]interface{}{
+		"update_check_interval": "21600s",
+		"search_online":         true,
+		"tags":                  map[string]interface{}{"fleet": "web"},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestNotConfigured(t *testing.T) {
+	w := new(WindowsSecurity)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cpu": {}}`), &input))
+	key, _ := w.ApplyRule(input)
+	assert.Equal(t, "", key)
+}
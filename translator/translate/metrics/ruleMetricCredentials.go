@@ -4,8 +4,8 @@
 package metrics
 
 import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
-	"github.com/aws/amazon-cloudwatch-agent/translator/util"
 )
 
 type MetricsCreds struct {
@@ -16,8 +16,6 @@ const (
 	CredentialsSectionKey = "credentials"
 )
 
-var credsTargetList = []string{Role_Arn_Key}
-
 func (c *MetricsCreds) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
 	result := map[string]interface{}{}
 
@@ -27,7 +25,12 @@ func (c *MetricsCreds) ApplyRule(input interface{}) (returnKey string, returnVal
 
 	// Read fromm Json first.
 	if val, ok := input.(map[string]interface{})[CredentialsSectionKey]; ok {
-		util.SetWithSameKeyIfFound(val, credsTargetList, result)
+		roleARN, err := agent.RoleARN(val)
+		if err != nil {
+			translator.AddErrorMessages(GetCurPath()+CredentialsSectionKey, err.Error())
+		} else if roleARN != "" {
+			result[Role_Arn_Key] = roleARN
+		}
 	}
 
 	returnKey = OutputsKey
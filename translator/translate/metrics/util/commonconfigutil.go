@@ -5,6 +5,7 @@ package util
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
@@ -25,17 +26,20 @@ const (
 	Windows_Measurement_Key      = "Measurement"
 	Windows_WarnOnMissing_Key    = "WarnOnMissing"
 	Windows_Disable_Replacer_Key = "DisableReplacer"
+	Windows_Counters_Exclude_Key = "CountersExclude"
+	Exclude_Measurement_Key      = "exclude_measurement"
 )
 
-// ProcessLinuxCommonConfig is used by both Linux and Darwin.
+// ProcessLinuxCommonConfig is used by Linux, Darwin and FreeBSD.
 func ProcessLinuxCommonConfig(input interface{}, pluginName string, path string, result map[string]interface{}) bool {
 	inputMap := input.(map[string]interface{})
 	// Generate allowlisted metric list, process only if Measurement_Key exist
 	if translator.IsValid(inputMap, Measurement_Key, path) {
 		// NOTE: the logic here is a bit tricky, even windows uses linux config for metric like procstat, NvidiaGPU.
 		os := config.OS_TYPE_LINUX
-		if translator.GetTargetPlatform() == config.OS_TYPE_DARWIN {
-			os = config.OS_TYPE_DARWIN
+		switch translator.GetTargetPlatform() {
+		case config.OS_TYPE_DARWIN, config.OS_TYPE_FREEBSD:
+			os = translator.GetTargetPlatform()
 		}
 		returnKey, returnVal := ApplyMeasurementRule(inputMap[Measurement_Key], pluginName, os, path)
 		if returnKey != "" {
@@ -52,6 +56,7 @@ func ProcessLinuxCommonConfig(input interface{}, pluginName string, path string,
 
 	isHighResolution := IsHighResolution(agent.Global_Config.Interval)
 	isHighResolution = setTimeInterval(inputMap, result, isHighResolution, pluginName)
+	isHighResolution = setAggregationInterval(inputMap, result, isHighResolution, pluginName)
 	// Add HighResolution tags
 	if isHighResolution {
 		if result[Append_Dimensions_Mapped_Key] != nil {
@@ -90,6 +95,7 @@ func ProcessWindowsCommonConfig(input interface{}, pluginName string, path strin
 
 	// 1. Set input plugin specific interval
 	isHighRsolution = setTimeInterval(inputMap, returnVal, isHighRsolution, pluginName)
+	isHighRsolution = setAggregationInterval(inputMap, returnVal, isHighRsolution, pluginName)
 
 	// 2. Set append_dimensions as tags
 	if val, ok := inputMap[Append_Dimensions_Key]; ok {
@@ -104,6 +110,10 @@ func ProcessWindowsCommonConfig(input interface{}, pluginName string, path strin
 			objectConfig[returnKey] = returnVal
 		}
 	}
+	// Counter patterns dropped from the wildcard counters in the measurement
+	if val, ok := inputMap[Exclude_Measurement_Key]; ok {
+		objectConfig[Windows_Counters_Exclude_Key] = val
+	}
 
 	// 4. Generate a alias name for each windows plugin since every win performance counter plugin will generate
 	// a duplicate plugin but with different configuration https://github.com/aws/amazon-cloudwatch-agent/blob/a791b1484fbc0611e515ccbb9bd24bea469cb9fb/translator/translate/metrics/metrics_collect/customizedmetrics/customizedmetric.go#L39-L40
@@ -181,6 +191,43 @@ func ProcessMetricsCollectionInterval(input interface{}, defaultValue, pluginNam
 	return
 }
 
+// setAggregationInterval tags the metrics with the interval they are aggregated over before they are published, so
+// they can be collected more often for the local processing than they are published to CloudWatch. The resolution
+// they are published at follows the aggregation interval instead of the collection interval.
+func setAggregationInterval(inputMap map[string]interface{}, returnVal map[string]interface{}, isHighResolution bool, pluginName string) bool {
+	val, ok := inputMap[Aggregation_Interval_Key]
+	if !ok {
+		return isHighResolution
+	}
+	floatVal, ok := val.(float64)
+	if !ok || floatVal < 0 {
+		translator.AddErrorMessages(
+			fmt.Sprintf("metrics plugin %s", pluginName),
+			fmt.Sprintf("metrics_aggregation_interval value (%v) in json is not valid for time interval.", val))
+		return isHighResolution
+	}
+	if floatVal == 0 {
+		// 0 publishes every metric collected, like without the aggregation interval
+		return isHighResolution
+	}
+	interval := fmt.Sprintf("%ds", int(floatVal))
+	collectionInterval := agent.Global_Config.Interval
+	if s, ok := returnVal[Collect_Interval_Mapped_Key].(string); ok {
+		collectionInterval = s
+	}
+	if collection, err := time.ParseDuration(collectionInterval); err == nil && time.Duration(floatVal)*time.Second < collection {
+		translator.AddErrorMessages(
+			fmt.Sprintf("metrics plugin %s", pluginName),
+			fmt.Sprintf("metrics_aggregation_interval value (%v) in json must not be shorter than the metrics_collection_interval (%v).", val, collectionInterval))
+		return isHighResolution
+	}
+	if returnVal[Append_Dimensions_Mapped_Key] == nil {
+		returnVal[Append_Dimensions_Mapped_Key] = map[string]interface{}{}
+	}
+	returnVal[Append_Dimensions_Mapped_Key].(map[string]interface{})[util.Aggregation_Interval_Tag_Key] = interval
+	return IsHighResolution(interval)
+}
+
 func ProcessMetricsAggregationInterval(input interface{}, defaultValue, pluginName string) (returnKey string, returnVal interface{}) {
 	if inputMap, ok := input.(map[string]interface{}); ok {
 		if val, ok := inputMap[Aggregation_Interval_Key]; ok {
@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
 )
 
 func TestProcessLinuxCommonConfigNoValidMetrics(t *testing.T) {
@@ -61,3 +64,71 @@ func TestProcessLinuxCommonConfigHappy(t *testing.T) {
 		panic(err)
 	}
 }
+
+func TestProcessLinuxCommonConfigAggregationInterval(t *testing.T) {
+	testCases := map[string]struct {
+		input      string
+		want       map[string]interface{}
+		wantErrors bool
+	}{
+		"Collected5sPublished60s": {
+			input: `{"measurement": ["usage_idle"], "metrics_collection_interval": 5, "metrics_aggregation_interval": 60}`,
+			want: map[string]interface{}{
+				"fieldpass": []string{"usage_idle"},
+				"interval":  "5s",
+				"tags":      map[string]interface{}{"aws:AggregationInterval": "60s"},
+			},
+		},
+		"PublishedHighResolution": {
+			input: `{"measurement": ["usage_idle"], "metrics_collection_interval": 1, "metrics_aggregation_interval": 10, "append_dimensions": {"team": "a"}}`,
+			want: map[string]interface{}{
+				"fieldpass": []string{"usage_idle"},
+				"interval":  "1s",
+				"tags":      map[string]interface{}{"team": "a", "aws:AggregationInterval": "10s", "aws:StorageResolution": "true"},
+			},
+		},
+		"Disabled": {
+			input: `{"measurement": ["usage_idle"], "metrics_collection_interval": 1, "metrics_aggregation_interval": 0}`,
+			want: map[string]interface{}{
+				"fieldpass": []string{"usage_idle"},
+				"interval":  "1s",
+				"tags":      map[string]interface{}{"aws:StorageResolution": "true"},
+			},
+		},
+		"ShorterThanCollection": {
+			input: `{"measurement": ["usage_idle"], "metrics_collection_interval": 60, "metrics_aggregation_interval": 10}`,
+			want: map[string]interface{}{
+				"fieldpass": []string{"usage_idle"},
+				"interval":  "60s",
+			},
+			wantErrors: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			got := map[string]interface{}{}
+			assert.True(t, ProcessLinuxCommonConfig(input, "cpu", "", got))
+			assert.Equal(t, testCase.want, got)
+			assert.Equal(t, testCase.wantErrors, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
+
+func TestProcessWindowsCommonConfigWildcardCounters(t *testing.T) {
+	var input interface{}
+	err := json.Unmarshal([]byte(`{
+					"measurement": ["*"],
+					"exclude_measurement": ["*Re-Compilations*"]
+				}`), &input)
+	assert.NoError(t, err)
+	result := ProcessWindowsCommonConfig(input, "SQLServer:SQL Statistics", "")
+	objects := result["object"].([]interface{})
+	assert.Len(t, objects, 1)
+	object := objects[0].(map[string]interface{})
+	assert.Equal(t, []string{"*"}, object["Counters"])
+	assert.Equal(t, []interface{}{"*Re-Compilations*"}, object["CountersExclude"])
+	assert.Equal(t, []string{"------"}, object["Instances"])
+}
@@ -35,7 +35,7 @@ func ApplyMeasurementRule(inputs interface{}, pluginName string, targetOs string
 	inputList := inputs.([]interface{})
 	returnKey = ""
 	switch targetOs {
-	case translatorConfig.OS_TYPE_LINUX, translatorConfig.OS_TYPE_DARWIN:
+	case translatorConfig.OS_TYPE_LINUX, translatorConfig.OS_TYPE_DARWIN, translatorConfig.OS_TYPE_FREEBSD:
 		returnKey = field_pass_key
 	case translatorConfig.OS_TYPE_WINDOWS:
 		returnKey = windows_measurement_key
@@ -122,6 +122,8 @@ func GetValidMetric(targetOs string, pluginName string, metricName string) strin
 		registeredMetrics = config.Registered_Metrics_Linux
 	case translatorConfig.OS_TYPE_DARWIN:
 		registeredMetrics = config.Registered_Metrics_Darwin
+	case translatorConfig.OS_TYPE_FREEBSD:
+		registeredMetrics = config.Registered_Metrics_FreeBSD
 	case translatorConfig.OS_TYPE_WINDOWS:
 		return metricName
 	default:
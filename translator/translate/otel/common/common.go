@@ -19,6 +19,7 @@ import (
 
 const (
 	AgentKey                           = "agent"
+	InstanceIdentityKey                = "instance_identity"
 	DebugKey                           = "debug"
 	MetricsKey                         = "metrics"
 	LogsKey                            = "logs"
@@ -34,8 +35,10 @@ const (
 	PrometheusKey                      = "prometheus"
 	PrometheusConfigPathKey            = "prometheus_config_path"
 	AMPKey                             = "amp"
+	GatewayKey                         = "gateway"
 	WorkspaceIDKey                     = "workspace_id"
 	EMFProcessorKey                    = "emf_processor"
+	EMFMetricsKey                      = "emf_metrics"
 	DisableMetricExtraction            = "disable_metric_extraction"
 	XrayKey                            = "xray"
 	OtlpKey                            = "otlp"
@@ -49,8 +52,11 @@ const (
 	LocalModeKey                       = "local_mode"
 	CredentialsKey                     = "credentials"
 	RoleARNKey                         = "role_arn"
+	CredentialSetKey                   = "credential_set"
 	SigV4Auth                          = "sigv4auth"
 	MetricsCollectionIntervalKey       = "metrics_collection_interval"
+	CollectionWindowsKey               = "collection_windows"
+	NamespaceKey                       = "namespace"
 	AggregationDimensionsKey           = "aggregation_dimensions"
 	MeasurementKey                     = "measurement"
 	DropOriginalMetricsKey             = "drop_original_metrics"
@@ -61,12 +67,15 @@ const (
 	EnableAcceleratedComputeMetric     = "accelerated_compute_metrics"
 	EnableKueueContainerInsights       = "kueue_container_insights"
 	AppendDimensionsKey                = "append_dimensions"
+	RefreshTagsIntervalKey             = "refresh_tags_interval"
 	Console                            = "console"
 	DiskKey                            = "disk"
 	DiskIOKey                          = "diskio"
 	NetKey                             = "net"
+	SwapKey                            = "swap"
 	Emf                                = "emf"
 	StructuredLog                      = "structuredlog"
+	EventsKey                          = "events"
 	ServiceAddress                     = "service_address"
 	Udp                                = "udp"
 	Tcp                                = "tcp"
@@ -78,6 +87,9 @@ const (
 	NameKey                            = "name"
 	RenameKey                          = "rename"
 	UnitKey                            = "unit"
+	StalenessKey                       = "staleness"
+	PolicyKey                          = "policy"
+	TimeoutKey                         = "timeout"
 )
 
 const (
@@ -115,11 +127,21 @@ const (
 	PipelineNameJmx                  = "jmx"
 	PipelineNameContainerInsightsJmx = "containerinsightsjmx"
 	PipelineNameEmfLogs              = "emf_logs"
+	PipelineNameOtlpLogs             = "otlp_logs"
 	PipelineNamePrometheus           = "prometheus"
 	PipelineNameKueue                = "kueueContainerInsights"
 	AppSignals                       = "application_signals"
 	AppSignalsFallback               = "app_signals"
 	AppSignalsRules                  = "rules"
+	AppSignalsExceptionMetrics       = "exception_metrics"
+	AppSignalsRulesFile              = "rules_file"
+	AppSignalsReloadInterval         = "reload_interval"
+	AppSignalsDestinations           = "destinations"
+	AppSignalsSampling               = "sampling"
+	AppSignalsPrometheus             = "application_signals_prometheus"
+	PrometheusRemoteWriteKey         = "prometheus_remote_write"
+	PrometheusJobsKey                = "jobs"
+	PrometheusJobNamesKey            = "job_names"
 )
 
 var (
@@ -134,11 +156,18 @@ var (
 	}
 	JmxConfigKey               = ConfigKey(MetricsKey, MetricsCollectedKey, JmxKey)
 	ContainerInsightsConfigKey = ConfigKey(LogsKey, MetricsCollectedKey, KubernetesKey)
+	PrometheusEMFJobsKey       = ConfigKey(LogsKey, MetricsCollectedKey, PrometheusKey, EMFProcessorKey, PrometheusJobsKey)
+	EMFMetricsConfigKey        = ConfigKey(LogsKey, EMFMetricsKey)
+	OtlpLogsConfigKey          = ConfigKey(LogsKey, LogsCollectedKey, OtlpKey)
+	// EnrichServiceAttributesKey resolves the environment and platform of the spans that are not from App Signals
+	// like App Signals does.
+	EnrichServiceAttributesKey = ConfigKey(TracesKey, "enrich_service_attributes")
 
 	JmxTargets = []string{"activemq", "cassandra", "hbase", "hadoop", "jetty", "jvm", "kafka", "kafka-consumer", "kafka-producer", "solr", "tomcat", "wildfly"}
 
 	AgentDebugConfigKey             = ConfigKey(AgentKey, DebugKey)
 	MetricsAggregationDimensionsKey = ConfigKey(MetricsKey, AggregationDimensionsKey)
+	MetricsStalenessKey             = ConfigKey(MetricsKey, StalenessKey)
 )
 
 type TranslatorID interface {
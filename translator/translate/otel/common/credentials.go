@@ -0,0 +1,27 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+)
+
+// GetRoleARN returns the role the exporters of the section assume: the role_arn of the credentials of the
+// section, or the role of the credential set they name, and the role of the agent otherwise.
+func GetRoleARN(conf *confmap.Conf, sectionKey string) (string, error) {
+	var credentials any
+	if conf != nil {
+		credentials = conf.Get(ConfigKey(sectionKey, CredentialsKey))
+	}
+	roleARN, err := agent.RoleARN(credentials)
+	if err != nil {
+		return "", err
+	}
+	if roleARN == "" {
+		roleARN = agent.Global_Config.Role_arn
+	}
+	return roleARN, nil
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+)
+
+func TestGetRoleARN(t *testing.T) {
+	previous := agent.Global_Config
+	t.Cleanup(func() { agent.Global_Config = previous })
+	agent.Global_Config.Role_arn = "agent_role"
+	agent.Global_Config.CredentialSets = map[string]string{"central": "central_role"}
+
+	testCases := map[string]struct {
+		input   map[string]any
+		want    string
+		wantErr bool
+	}{
+		"WithoutCredentials": {
+			input: map[string]any{"metrics": map[string]any{}},
+			want:  "agent_role",
+		},
+		"WithRoleARN": {
+			input: map[string]any{"metrics": map[string]any{"credentials": map[string]any{"role_arn": "metrics_role"}}},
+			want:  "metrics_role",
+		},
+		"WithCredentialSet": {
+			input: map[string]any{"metrics": map[string]any{"credentials": map[string]any{"credential_set": "central"}}},
+			want:  "central_role",
+		},
+		"WithUnknownCredentialSet": {
+			input:   map[string]any{"metrics": map[string]any{"credentials": map[string]any{"credential_set": "unknown"}}},
+			wantErr: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetRoleARN(confmap.NewFromStringMap(testCase.input), MetricsKey)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -21,6 +21,9 @@ func GetMetricsDestinations(conf *confmap.Conf) []string {
 	if conf.IsSet(ConfigKey(metricsDestinationsKey, AMPKey)) {
 		destinations = append(destinations, AMPKey)
 	}
+	if conf.IsSet(ConfigKey(metricsDestinationsKey, GatewayKey)) {
+		destinations = append(destinations, GatewayKey)
+	}
 	if conf.IsSet(MetricsKey) && len(destinations) == 0 {
 		destinations = append(destinations, DefaultDestination)
 	}
@@ -57,6 +57,16 @@ func TestGetMetricsDestinations(t *testing.T) {
 			},
 			want: []string{CloudWatchKey, AMPKey},
 		},
+		"WithMetrics/Gateway": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_destinations": map[string]any{
+						"gateway": map[string]any{},
+					},
+				},
+			},
+			want: []string{GatewayKey},
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
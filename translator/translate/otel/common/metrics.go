@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/config"
 )
@@ -63,6 +64,19 @@ func isValidRollupList(aggregates []interface{}) bool {
 	return true
 }
 
+// GetStaleness returns how the series that stop reporting are ended, with the default timeout if the policy needs one
+// and none is set.
+func GetStaleness(conf *confmap.Conf) staleness.Config {
+	var cfg staleness.Config
+	if policy, ok := GetString(conf, ConfigKey(MetricsStalenessKey, PolicyKey)); ok {
+		cfg.Policy = staleness.Policy(policy)
+	}
+	if cfg.Enabled() {
+		cfg.Timeout = GetOrDefaultDuration(conf, []string{ConfigKey(MetricsStalenessKey, TimeoutKey)}, staleness.DefaultTimeout)
+	}
+	return cfg
+}
+
 func GetDropOriginalMetrics(conf *confmap.Conf) map[string]bool {
 	key := ConfigKey(MetricsKey, MetricsCollectedKey)
 	value := conf.Get(key)
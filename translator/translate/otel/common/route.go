@@ -0,0 +1,142 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
+)
+
+const (
+	RoutesKey = "routes"
+
+	// RouteNamePrefix is added to the names of the pipelines and components created for a route.
+	RouteNamePrefix = "route_"
+	// RouteNameDefault is used for the components that remove routed data from the default pipelines.
+	RouteNameDefault = RouteNamePrefix + "default"
+	// NamespaceNamePrefix is added to the names of the pipelines and exporters created for the inputs that publish
+	// to their own namespace.
+	NamespaceNamePrefix = "namespace_"
+
+	routeMatchKey              = "match"
+	routeAttributesKey         = "attributes"
+	routeResourceAttributesKey = "resource_attributes"
+	routeMetricNameKey         = "metric_name"
+	routeRegionKey             = "region"
+	routeNamespaceKey          = "namespace"
+	routeCopyKey               = "copy"
+)
+
+var metricsRoutesKey = ConfigKey(MetricsKey, RoutesKey)
+
+// Route sends the metrics matching all of its conditions to a dedicated destination instead of
+// the default one.
+type Route struct {
+	Name string
+	// Attributes maps data point attribute names to the regex their value has to match.
+	Attributes map[string]string
+	// ResourceAttributes maps resource attribute names to the regex their value has to match.
+	ResourceAttributes map[string]string
+	// MetricName is a regex the metric name has to match.
+	MetricName       string
+	Region           string
+	RoleARN          string
+	Namespace        string
+	EndpointOverride string
+	// CredentialSet names the agent.credential_sets entry whose role is assumed when RoleARN is not set.
+	CredentialSet string
+	// Copy keeps the matched metrics in the default destination as well.
+	Copy bool
+}
+
+// ComponentName is the name used for the pipeline and components of the route.
+func (r Route) ComponentName() string {
+	return RouteNamePrefix + r.Name
+}
+
+// NamespaceComponentName is the name used for the pipelines and exporter of the inputs publishing to the namespace
+// instead of metrics.namespace. The namespace is hashed since it can contain characters component names cannot.
+func NamespaceComponentName(namespace string) string {
+	return NamespaceNamePrefix + hash.HashName(namespace)
+}
+
+// Condition is the OTTL data point condition matching the route.
+func (r Route) Condition() string {
+	var conditions []string
+	for _, key := range sortedKeys(r.ResourceAttributes) {
+		conditions = append(conditions, fmt.Sprintf("IsMatch(resource.attributes[%q], %q)", key, r.ResourceAttributes[key]))
+	}
+	for _, key := range sortedKeys(r.Attributes) {
+		conditions = append(conditions, fmt.Sprintf("IsMatch(attributes[%q], %q)", key, r.Attributes[key]))
+	}
+	if r.MetricName != "" {
+		conditions = append(conditions, fmt.Sprintf("IsMatch(metric.name, %q)", r.MetricName))
+	}
+	if len(conditions) == 0 {
+		return "true"
+	}
+	return strings.Join(conditions, " and ")
+}
+
+// GetMetricsRoutes returns the routes in the metrics section in the order they are configured.
+func GetMetricsRoutes(conf *confmap.Conf) []Route {
+	var routes []Route
+	for _, raw := range GetArray[any](conf, metricsRoutesKey) {
+		m, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		route := Route{
+			Name:               toString(m["name"]),
+			Region:             toString(m[routeRegionKey]),
+			Namespace:          toString(m[routeNamespaceKey]),
+			EndpointOverride:   toString(m[EndpointOverrideKey]),
+			Attributes:         map[string]string{},
+			ResourceAttributes: map[string]string{},
+		}
+		if credentials, ok := m[CredentialsKey].(map[string]any); ok {
+			route.RoleARN = toString(credentials[RoleARNKey])
+			route.CredentialSet = toString(credentials[CredentialSetKey])
+		}
+		route.Copy, _ = m[routeCopyKey].(bool)
+		if match, ok := m[routeMatchKey].(map[string]any); ok {
+			route.MetricName = toString(match[routeMetricNameKey])
+			copyStringMap(match[routeAttributesKey], route.Attributes)
+			copyStringMap(match[routeResourceAttributesKey], route.ResourceAttributes)
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+func toString(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func copyStringMap(src any, dst map[string]string) {
+	m, ok := src.(map[string]any)
+	if !ok {
+		return
+	}
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			dst[k] = s
+		}
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
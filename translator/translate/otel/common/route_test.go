@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestGetMetricsRoutes(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"routes": []any{
+				map[string]any{
+					"name":        "team_a",
+					"region":      "us-west-2",
+					"namespace":   "TeamA",
+					"credentials": map[string]any{"role_arn": "arn:aws:iam::123456789012:role/team-a"},
+					"match": map[string]any{
+						"attributes":          map[string]any{"team": "^a$", "env": "prod"},
+						"resource_attributes": map[string]any{"k8s.namespace.name": "payments"},
+						"metric_name":         "^cpu_",
+					},
+				},
+				map[string]any{
+					"name": "all",
+					"copy": true,
+				},
+			},
+		},
+	})
+	routes := GetMetricsRoutes(conf)
+	require.Len(t, routes, 2)
+	assert.Equal(t, "route_team_a", routes[0].ComponentName())
+	assert.Equal(t, "us-west-2", routes[0].Region)
+	assert.Equal(t, "TeamA", routes[0].Namespace)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/team-a", routes[0].RoleARN)
+	assert.False(t, routes[0].Copy)
+	assert.Equal(t, `IsMatch(resource.attributes["k8s.namespace.name"], "payments") and IsMatch(attributes["env"], "prod") and IsMatch(attributes["team"], "^a$") and IsMatch(metric.name, "^cpu_")`, routes[0].Condition())
+	assert.True(t, routes[1].Copy)
+	assert.Equal(t, "true", routes[1].Condition())
+
+	assert.Empty(t, GetMetricsRoutes(confmap.New()))
+}
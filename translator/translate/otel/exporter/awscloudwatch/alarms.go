@@ -0,0 +1,269 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awscloudwatch
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	logsutil "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+const (
+	alarmsKey             = "alarms"
+	alarmsRecommendedKey  = "recommended"
+	alarmsNamePrefixKey   = "name_prefix"
+	alarmsActionsKey      = "alarm_actions"
+	alarmsCompositeKey    = "composite"
+	alarmsTagsKey         = "tags"
+	alarmsTemplatesKey    = "templates"
+	alarmsStateFileName   = "cloudwatch-alarms"
+	alarmHostTagKey       = "amazon-cloudwatch-agent:host"
+	instanceIDDimension   = "InstanceId"
+	hostDimension         = "host"
+	instanceIDPlaceholder = "{instance_id}"
+	hostnamePlaceholder   = "{local_hostname}"
+
+	defaultAlarmStatistic         = "Average"
+	defaultAlarmComparison        = "GreaterThanThreshold"
+	defaultAlarmPeriod            = 5 * time.Minute
+	defaultAlarmEvaluationPeriods = 1
+)
+
+var (
+	alarmsConfigKey = common.ConfigKey(common.MetricsKey, alarmsKey)
+
+	// alarmFunctions maps the CloudWatch statistics to the Metrics Insights functions.
+	alarmFunctions = map[string]string{
+		"Average":     "AVG",
+		"Maximum":     "MAX",
+		"Minimum":     "MIN",
+		"Sum":         "SUM",
+		"SampleCount": "COUNT",
+	}
+)
+
+// alarmTemplate is a template of metrics.alarms.templates.
+type alarmTemplate struct {
+	name               string
+	description        string
+	metricName         string
+	namespace          string
+	statistic          string
+	dimensions         map[string]string
+	comparisonOperator string
+	threshold          float64
+	period             time.Duration
+	evaluationPeriods  int
+	treatMissingData   string
+}
+
+// recommendedAlarms are created with metrics.alarms.recommended for the metrics collected on the host. A template
+// configured with the same name replaces the recommended one.
+func recommendedAlarms(conf *confmap.Conf) []alarmTemplate {
+	hasDisk := conf.IsSet(common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, "disk"))
+	hasMem := conf.IsSet(common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, "mem"))
+	var templates []alarmTemplate
+	if hasDisk {
+		templates = append(templates, alarmTemplate{
+			name:               "disk_full",
+			description:        "The root volume of the host is almost full",
+			metricName:         "disk_used_percent",
+			statistic:          "Maximum",
+			dimensions:         map[string]string{"path": "/"},
+			comparisonOperator: defaultAlarmComparison,
+			threshold:          90,
+			period:             defaultAlarmPeriod,
+			evaluationPeriods:  2,
+		})
+	}
+	if hasMem {
+		templates = append(templates, alarmTemplate{
+			name:               "memory",
+			description:        "The memory of the host is almost exhausted",
+			metricName:         "mem_used_percent",
+			statistic:          defaultAlarmStatistic,
+			comparisonOperator: defaultAlarmComparison,
+			threshold:          90,
+			period:             defaultAlarmPeriod,
+			evaluationPeriods:  3,
+		})
+	}
+	if hasDisk || hasMem {
+		// the agent is unhealthy when the host stops reporting its metrics
+		metricName := "mem_used_percent"
+		if !hasMem {
+			metricName = "disk_used_percent"
+		}
+		templates = append(templates, alarmTemplate{
+			name:               "agent_health",
+			description:        "The CloudWatch agent on the host stopped publishing metrics",
+			metricName:         metricName,
+			statistic:          "SampleCount",
+			comparisonOperator: "LessThanThreshold",
+			threshold:          1,
+			period:             defaultAlarmPeriod,
+			evaluationPeriods:  3,
+			treatMissingData:   "breaching",
+		})
+	}
+	return templates
+}
+
+// getAlarms translates metrics.alarms into the alarms the exporter publishing to the namespace creates for the
+// host. The metrics of the host are selected by their InstanceId dimension when it is appended, or else by their
+// host dimension.
+func getAlarms(conf *confmap.Conf, namespace string) (*cloudwatch.AlarmsConfig, error) {
+	m, ok := conf.Get(alarmsConfigKey).(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	filterKey, placeholder := hostDimension, hostnamePlaceholder
+	if conf.IsSet(common.ConfigKey(common.MetricsKey, common.AppendDimensionsKey, instanceIDDimension)) {
+		filterKey, placeholder = instanceIDDimension, instanceIDPlaceholder
+	}
+	hostID := util.ResolvePlaceholder(placeholder, logs.GlobalLogConfig.MetadataInfo)
+	namePrefix := "CWAgent-" + placeholder
+	if prefix, ok := m[alarmsNamePrefixKey].(string); ok && prefix != "" {
+		namePrefix = prefix
+	}
+	cfg := &cloudwatch.AlarmsConfig{
+		NamePrefix: util.ResolvePlaceholder(namePrefix, logs.GlobalLogConfig.MetadataInfo),
+		Tags:       map[string]string{alarmHostTagKey: hostID},
+		StateFile:  filepath.Join(logsutil.GetFileStateFolder(), alarmsStateFileName),
+	}
+	cfg.Composite, _ = m[alarmsCompositeKey].(bool)
+	for _, action := range common.GetArray[any](conf, common.ConfigKey(alarmsConfigKey, alarmsActionsKey)) {
+		if s, ok := action.(string); ok {
+			cfg.AlarmActions = append(cfg.AlarmActions, s)
+		}
+	}
+	if tags, ok := m[alarmsTagsKey].(map[string]any); ok {
+		for k, v := range tags {
+			if s, ok := v.(string); ok {
+				cfg.Tags[k] = s
+			}
+		}
+	}
+
+	var templates []alarmTemplate
+	if recommended, _ := m[alarmsRecommendedKey].(bool); recommended {
+		templates = recommendedAlarms(conf)
+	}
+	for _, raw := range common.GetArray[any](conf, common.ConfigKey(alarmsConfigKey, alarmsTemplatesKey)) {
+		template, err := toAlarmTemplate(raw)
+		if err != nil {
+			return nil, err
+		}
+		templates = replaceOrAppend(templates, template)
+	}
+	for _, template := range templates {
+		if template.namespace == "" {
+			template.namespace = namespace
+		}
+		dimensions := map[string]string{filterKey: hostID}
+		for k, v := range template.dimensions {
+			dimensions[k] = v
+		}
+		query, err := alarmQuery(template.statistic, template.metricName, template.namespace, dimensions)
+		if err != nil {
+			return nil, fmt.Errorf("alarm template %q: %w", template.name, err)
+		}
+		cfg.Templates = append(cfg.Templates, cloudwatch.AlarmTemplate{
+			Name:               template.name,
+			Description:        template.description,
+			Query:              query,
+			Period:             template.period,
+			EvaluationPeriods:  template.evaluationPeriods,
+			ComparisonOperator: template.comparisonOperator,
+			Threshold:          template.threshold,
+			TreatMissingData:   template.treatMissingData,
+		})
+	}
+	return cfg, nil
+}
+
+// replaceOrAppend replaces the template with the same name, or appends the template.
+func replaceOrAppend(templates []alarmTemplate, template alarmTemplate) []alarmTemplate {
+	for i := range templates {
+		if templates[i].name == template.name {
+			templates[i] = template
+			return templates
+		}
+	}
+	return append(templates, template)
+}
+
+func toAlarmTemplate(raw any) (alarmTemplate, error) {
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return alarmTemplate{}, fmt.Errorf("invalid alarm template: %v", raw)
+	}
+	template := alarmTemplate{
+		statistic:          defaultAlarmStatistic,
+		comparisonOperator: defaultAlarmComparison,
+		period:             defaultAlarmPeriod,
+		evaluationPeriods:  defaultAlarmEvaluationPeriods,
+		dimensions:         map[string]string{},
+	}
+	template.name, _ = m["name"].(string)
+	template.description, _ = m["description"].(string)
+	template.metricName, _ = m["metric_name"].(string)
+	template.namespace, _ = m["namespace"].(string)
+	template.treatMissingData, _ = m["treat_missing_data"].(string)
+	if template.name == "" || template.metricName == "" {
+		return alarmTemplate{}, fmt.Errorf("alarm template %v must set name and metric_name", raw)
+	}
+	if statistic, ok := m["statistic"].(string); ok {
+		template.statistic = statistic
+	}
+	if comparison, ok := m["comparison_operator"].(string); ok {
+		template.comparisonOperator = comparison
+	}
+	if threshold, ok := m["threshold"].(float64); ok {
+		template.threshold = threshold
+	}
+	if period, ok := m["period"].(float64); ok {
+		template.period = time.Duration(period) * time.Second
+	}
+	if evaluationPeriods, ok := m["evaluation_periods"].(float64); ok {
+		template.evaluationPeriods = int(evaluationPeriods)
+	}
+	if dimensions, ok := m["dimensions"].(map[string]any); ok {
+		for k, v := range dimensions {
+			if s, ok := v.(string); ok {
+				template.dimensions[k] = s
+			}
+		}
+	}
+	return template, nil
+}
+
+// alarmQuery is the Metrics Insights query of the statistic of the metric over the dimensions. Insights does not
+// require the dimensions to be the exact set of the metric, so the alarm does not break when a dimension is appended.
+func alarmQuery(statistic, metricName, namespace string, dimensions map[string]string) (string, error) {
+	function, ok := alarmFunctions[statistic]
+	if !ok {
+		return "", fmt.Errorf("unsupported statistic %q", statistic)
+	}
+	keys := make([]string, 0, len(dimensions))
+	for k := range dimensions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	conditions := make([]string, 0, len(keys))
+	for _, k := range keys {
+		conditions = append(conditions, fmt.Sprintf("%q = '%s'", k, strings.ReplaceAll(dimensions[k], "'", "\\'")))
+	}
+	return fmt.Sprintf("SELECT %s(%q) FROM %q WHERE %s", function, metricName, namespace, strings.Join(conditions, " AND ")), nil
+}
@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awscloudwatch
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+const (
+	metadataExportKey        = "metadata_export"
+	metadataFilePathKey      = "file_path"
+	metadataLogGroupNameKey  = "log_group_name"
+	metadataLogStreamNameKey = "log_stream_name"
+	metadataIntervalKey      = "interval"
+)
+
+var metadataExportConfigKey = common.ConfigKey(common.MetricsKey, metadataExportKey)
+
+// getMetadataExport returns where the catalog of the metrics is exported to. Every exporter gets the same config,
+// so that they share the catalog. The log stream defaults to the instance ID.
+func getMetadataExport(conf *confmap.Conf) *cloudwatch.MetadataExportConfig {
+	if !conf.IsSet(metadataExportConfigKey) {
+		return nil
+	}
+	cfg := &cloudwatch.MetadataExportConfig{}
+	cfg.FilePath, _ = common.GetString(conf, common.ConfigKey(metadataExportConfigKey, metadataFilePathKey))
+	cfg.LogGroupName, _ = common.GetString(conf, common.ConfigKey(metadataExportConfigKey, metadataLogGroupNameKey))
+	if cfg.LogGroupName != "" {
+		stream, ok := common.GetString(conf, common.ConfigKey(metadataExportConfigKey, metadataLogStreamNameKey))
+		if !ok || stream == "" {
+			stream = instanceIDPlaceholder
+		}
+		cfg.LogStreamName = util.ResolvePlaceholder(stream, logs.GlobalLogConfig.MetadataInfo)
+	}
+	cfg.Interval, _ = common.GetDuration(conf, common.ConfigKey(metadataExportConfigKey, metadataIntervalKey))
+	return cfg
+}
@@ -17,14 +17,22 @@ import (
 const (
 	namespaceKey          = "namespace"
 	forceFlushIntervalKey = "force_flush_interval"
+	aggregationOffsetKey  = "aggregation_offset"
+	aggregationJitterKey  = "aggregation_jitter"
+	failoverRegionsKey    = "failover_regions"
+	failoverAfterKey      = "failover_after"
 	dropOriginalWildcard  = "*"
+	defaultNamespace      = "CWAgent"
 
 	internalMaxValuesPerDatum = 5000
 )
 
 type translator struct {
-	name    string
-	factory exporter.Factory
+	name  string
+	route *common.Route
+	// namespace overrides metrics.namespace for the inputs that set their own.
+	namespace string
+	factory   exporter.Factory
 }
 
 var _ common.ComponentTranslator = (*translator)(nil)
@@ -34,7 +42,19 @@ func NewTranslator() common.ComponentTranslator {
 }
 
 func NewTranslatorWithName(name string) common.ComponentTranslator {
-	return &translator{name, cloudwatch.NewFactory()}
+	return &translator{name: name, factory: cloudwatch.NewFactory()}
+}
+
+// NewTranslatorWithRoute creates an exporter for the route, overriding the region, credentials,
+// namespace and endpoint set on the route.
+func NewTranslatorWithRoute(route common.Route) common.ComponentTranslator {
+	return &translator{name: route.ComponentName(), route: &route, factory: cloudwatch.NewFactory()}
+}
+
+// NewTranslatorWithNamespace creates an exporter publishing to the namespace instead of metrics.namespace, for the
+// inputs that set their own.
+func NewTranslatorWithNamespace(namespace string) common.ComponentTranslator {
+	return &translator{name: common.NamespaceComponentName(namespace), namespace: namespace, factory: cloudwatch.NewFactory()}
 }
 
 func (t *translator) ID() component.ID {
@@ -51,10 +71,15 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	cfg := t.factory.CreateDefaultConfig().(*cloudwatch.Config)
 	credentials := confmap.NewFromStringMap(agent.Global_Config.Credentials)
 	_ = credentials.Unmarshal(cfg)
-	cfg.RoleARN = getRoleARN(conf)
+	roleARN, err := common.GetRoleARN(conf, common.MetricsKey)
+	if err != nil {
+		return nil, err
+	}
+	cfg.RoleARN = roleARN
 	cfg.Region = agent.Global_Config.Region
-	if namespace, ok := common.GetString(conf, common.ConfigKey(common.MetricsKey, namespaceKey)); ok {
-		cfg.Namespace = namespace
+	cfg.Namespace = GetNamespace(conf, nil)
+	if t.namespace != "" {
+		cfg.Namespace = t.namespace
 	}
 	if endpointOverride, ok := common.GetString(conf, common.ConfigKey(common.MetricsKey, common.EndpointOverrideKey)); ok {
 		cfg.EndpointOverride = endpointOverride
@@ -62,6 +87,16 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if forceFlushInterval, ok := common.GetDuration(conf, common.ConfigKey(common.MetricsKey, forceFlushIntervalKey)); ok {
 		cfg.ForceFlushInterval = forceFlushInterval
 	}
+	if aggregationOffset, ok := common.GetDuration(conf, common.ConfigKey(common.MetricsKey, aggregationOffsetKey)); ok {
+		cfg.AggregationOffset = aggregationOffset
+	}
+	if aggregationJitter, ok := common.GetDuration(conf, common.ConfigKey(common.MetricsKey, aggregationJitterKey)); ok {
+		cfg.AggregationJitter = aggregationJitter
+	}
+	cfg.FailoverRegions = common.GetArray[string](conf, common.ConfigKey(common.MetricsKey, failoverRegionsKey))
+	if failoverAfter, ok := common.GetDuration(conf, common.ConfigKey(common.MetricsKey, failoverAfterKey)); ok {
+		cfg.FailoverAfter = failoverAfter
+	}
 	if agent.Global_Config.Internal {
 		cfg.MaxValuesPerDatum = internalMaxValuesPerDatum
 	}
@@ -71,15 +106,56 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if dropOriginalMetrics := common.GetDropOriginalMetrics(conf); len(dropOriginalMetrics) != 0 {
 		cfg.DropOriginalConfigs = dropOriginalMetrics
 	}
+	cfg.Staleness = common.GetStaleness(conf)
+	cfg.MetadataExport = getMetadataExport(conf)
+	if t.route != nil {
+		if err = applyRoute(cfg, t.route); err != nil {
+			return nil, err
+		}
+	} else if t.name == "" && t.namespace == "" {
+		// the alarms are only created once, by the default exporter
+		if cfg.Alarms, err = getAlarms(conf, cfg.Namespace); err != nil {
+			return nil, err
+		}
+	}
 	cfg.MiddlewareID = &agenthealth.MetricsID
 	return cfg, nil
 }
 
-func getRoleARN(conf *confmap.Conf) string {
-	key := common.ConfigKey(common.MetricsKey, common.CredentialsKey, common.RoleARNKey)
-	roleARN, ok := common.GetString(conf, key)
-	if !ok {
-		roleARN = agent.Global_Config.Role_arn
+// GetNamespace returns the namespace the exporter for the route, or the default exporter if the
+// route is nil, publishes to.
+func GetNamespace(conf *confmap.Conf, route *common.Route) string {
+	if route != nil && route.Namespace != "" {
+		return route.Namespace
+	}
+	if namespace, ok := common.GetString(conf, common.ConfigKey(common.MetricsKey, namespaceKey)); ok {
+		return namespace
+	}
+	return defaultNamespace
+}
+
+func applyRoute(cfg *cloudwatch.Config, route *common.Route) error {
+	if route.Region != "" || route.EndpointOverride != "" {
+		// the failover regions are the ones of the region of metrics
+		cfg.FailoverRegions = nil
+	}
+	if route.Region != "" {
+		cfg.Region = route.Region
+	}
+	if route.RoleARN != "" {
+		cfg.RoleARN = route.RoleARN
+	} else if route.CredentialSet != "" {
+		roleARN, err := agent.RoleARN(map[string]interface{}{agent.CredentialSetKey: route.CredentialSet})
+		if err != nil {
+			return err
+		}
+		cfg.RoleARN = roleARN
+	}
+	if route.Namespace != "" {
+		cfg.Namespace = route.Namespace
+	}
+	if route.EndpointOverride != "" {
+		cfg.EndpointOverride = route.EndpointOverride
 	}
-	return roleARN
+	return nil
 }
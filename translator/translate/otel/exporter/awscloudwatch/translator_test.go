@@ -13,9 +13,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/confmap"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/testutil"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatch"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 )
 
@@ -49,6 +51,49 @@ func TestTranslator(t *testing.T) {
 				RoleARN:            "global_arn",
 			},
 		},
+		"WithAggregationAlignment": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"aggregation_offset": 15,
+				"aggregation_jitter": 10,
+			}},
+			want: &cloudwatch.Config{
+				Namespace:          "CWAgent",
+				Region:             "us-east-1",
+				ForceFlushInterval: time.Minute,
+				MaxValuesPerDatum:  150,
+				RoleARN:            "global_arn",
+				AggregationOffset:  15 * time.Second,
+				AggregationJitter:  10 * time.Second,
+			},
+		},
+		"WithFailoverRegions": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"failover_regions": []interface{}{"us-west-2", "eu-west-1"},
+				"failover_after":   120,
+			}},
+			want: &cloudwatch.Config{
+				Namespace:          "CWAgent",
+				Region:             "us-east-1",
+				ForceFlushInterval: time.Minute,
+				MaxValuesPerDatum:  150,
+				RoleARN:            "global_arn",
+				FailoverRegions:    []string{"us-west-2", "eu-west-1"},
+				FailoverAfter:      2 * time.Minute,
+			},
+		},
+		"WithStaleness": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"staleness": map[string]interface{}{"policy": "zero"},
+			}},
+			want: &cloudwatch.Config{
+				Namespace:          "CWAgent",
+				Region:             "us-east-1",
+				ForceFlushInterval: time.Minute,
+				MaxValuesPerDatum:  150,
+				RoleARN:            "global_arn",
+				Staleness:          staleness.Config{Policy: staleness.PolicyZero, Timeout: staleness.DefaultTimeout},
+			},
+		},
 		"WithEndpointOverride": {
 			input: map[string]interface{}{"metrics": map[string]interface{}{
 				"endpoint_override": "https://monitoring-fips.us-east-1.amazonaws.com",
@@ -156,6 +201,8 @@ func TestTranslator(t *testing.T) {
 				assert.Equal(t, testCase.want.Namespace, gotCfg.Namespace)
 				assert.Equal(t, testCase.want.Region, gotCfg.Region)
 				assert.Equal(t, testCase.want.ForceFlushInterval, gotCfg.ForceFlushInterval)
+				assert.Equal(t, testCase.want.AggregationOffset, gotCfg.AggregationOffset)
+				assert.Equal(t, testCase.want.AggregationJitter, gotCfg.AggregationJitter)
 				assert.Equal(t, testCase.want.RoleARN, gotCfg.RoleARN)
 				assert.Equal(t, testCase.want.AccessKey, gotCfg.AccessKey)
 				assert.Equal(t, testCase.want.SecretKey, gotCfg.SecretKey)
@@ -164,6 +211,9 @@ func TestTranslator(t *testing.T) {
 				assert.Equal(t, testCase.want.SharedCredentialFilename, gotCfg.SharedCredentialFilename)
 				assert.Equal(t, testCase.want.MaxValuesPerDatum, gotCfg.MaxValuesPerDatum)
 				assert.Equal(t, testCase.want.RollupDimensions, gotCfg.RollupDimensions)
+				assert.Equal(t, testCase.want.FailoverRegions, gotCfg.FailoverRegions)
+				assert.Equal(t, testCase.want.FailoverAfter, gotCfg.FailoverAfter)
+				assert.Equal(t, testCase.want.Staleness, gotCfg.Staleness)
 				assert.NotNil(t, gotCfg.MiddlewareID)
 				assert.Equal(t, "agenthealth/metrics", gotCfg.MiddlewareID.String())
 				if testCase.wantWindows != nil && runtime.GOOS == "windows" {
@@ -175,3 +225,225 @@ func TestTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslatorWithRoute(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.Role_arn = "global_arn"
+	conf := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"namespace":        "Default",
+			"failover_regions": []any{"us-west-2"},
+			"routes": []any{
+				map[string]any{
+					"name":        "team_a",
+					"region":      "us-west-2",
+					"namespace":   "TeamA",
+					"credentials": map[string]any{"role_arn": "team_arn"},
+				},
+			},
+		},
+	})
+	cwt := NewTranslatorWithRoute(common.GetMetricsRoutes(conf)[0])
+	assert.Equal(t, "awscloudwatch/route_team_a", cwt.ID().String())
+	got, err := cwt.Translate(conf)
+	require.NoError(t, err)
+	cfg := got.(*cloudwatch.Config)
+	assert.Equal(t, "us-west-2", cfg.Region)
+	assert.Equal(t, "team_arn", cfg.RoleARN)
+	assert.Equal(t, "TeamA", cfg.Namespace)
+	assert.Nil(t, cfg.FailoverRegions, "the failover regions are the ones of the region of metrics")
+}
+
+func TestTranslatorWithNamespace(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	conf := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"namespace": "Default",
+		},
+	})
+	cwt := NewTranslatorWithNamespace("Team/Compute")
+	assert.Equal(t, "awscloudwatch/"+common.NamespaceComponentName("Team/Compute"), cwt.ID().String())
+	got, err := cwt.Translate(conf)
+	require.NoError(t, err)
+	cfg := got.(*cloudwatch.Config)
+	assert.Equal(t, "us-east-1", cfg.Region)
+	assert.Equal(t, "Team/Compute", cfg.Namespace)
+}
+
+func TestTranslatorWithCredentialSets(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.Role_arn = "global_arn"
+	agent.Global_Config.CredentialSets = map[string]string{"central": "central_arn", "team": "team_arn"}
+	t.Cleanup(func() {
+		agent.Global_Config.Role_arn = ""
+		agent.Global_Config.CredentialSets = nil
+	})
+	conf := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"credentials": map[string]any{"credential_set": "central"},
+			"routes": []any{
+				map[string]any{
+					"name":        "team_a",
+					"credentials": map[string]any{"credential_set": "team"},
+				},
+				map[string]any{
+					"name":        "team_b",
+					"credentials": map[string]any{"credential_set": "unknown"},
+				},
+			},
+		},
+	})
+	got, err := NewTranslator().Translate(conf)
+	require.NoError(t, err)
+	assert.Equal(t, "central_arn", got.(*cloudwatch.Config).RoleARN)
+	routes := common.GetMetricsRoutes(conf)
+	got, err = NewTranslatorWithRoute(routes[0]).Translate(conf)
+	require.NoError(t, err)
+	assert.Equal(t, "team_arn", got.(*cloudwatch.Config).RoleARN)
+	_, err = NewTranslatorWithRoute(routes[1]).Translate(conf)
+	assert.Error(t, err)
+}
+
+func TestTranslatorWithAlarms(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	logs.GlobalLogConfig.MetadataInfo = map[string]string{"{instance_id}": "i-123", "{local_hostname}": "host-a"}
+	t.Cleanup(func() { logs.GlobalLogConfig.MetadataInfo = nil })
+	conf := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"append_dimensions": map[string]any{"InstanceId": "${aws:InstanceId}"},
+			"metrics_collected": map[string]any{"mem": map[string]any{}},
+			"alarms": map[string]any{
+				"recommended":   true,
+				"composite":     true,
+				"alarm_actions": []any{"arn:aws:sns:us-east-1:123456789012:alerts"},
+				"tags":          map[string]any{"team": "compute"},
+				"templates": []any{
+					map[string]any{
+						"name":        "memory",
+						"metric_name": "mem_used_percent",
+						"threshold":   80.0,
+					},
+					map[string]any{
+						"name":               "swap",
+						"metric_name":        "swap_used_percent",
+						"namespace":          "Team/Compute",
+						"statistic":          "Maximum",
+						"dimensions":         map[string]any{"role": "it's"},
+						"period":             60.0,
+						"evaluation_periods": 5.0,
+					},
+				},
+			},
+		},
+	})
+	got, err := NewTranslator().Translate(conf)
+	require.NoError(t, err)
+	alarms := got.(*cloudwatch.Config).Alarms
+	require.NotNil(t, alarms)
+	assert.Equal(t, "CWAgent-i-123", alarms.NamePrefix)
+	assert.True(t, alarms.Composite)
+	assert.Equal(t, []string{"arn:aws:sns:us-east-1:123456789012:alerts"}, alarms.AlarmActions)
+	assert.Equal(t, map[string]string{"amazon-cloudwatch-agent:host": "i-123", "team": "compute"}, alarms.Tags)
+	assert.Equal(t, []cloudwatch.AlarmTemplate{
+		{
+			Name:               "memory",
+			Query:              `SELECT AVG("mem_used_percent") FROM "CWAgent" WHERE "InstanceId" = 'i-123'`,
+			Period:             5 * time.Minute,
+			EvaluationPeriods:  1,
+			ComparisonOperator: "GreaterThanThreshold",
+			Threshold:          80,
+		},
+		{
+			Name:               "agent_health",
+			Description:        "The CloudWatch agent on the host stopped publishing metrics",
+			Query:              `SELECT COUNT("mem_used_percent") FROM "CWAgent" WHERE "InstanceId" = 'i-123'`,
+			Period:             5 * time.Minute,
+			EvaluationPeriods:  3,
+			ComparisonOperator: "LessThanThreshold",
+			Threshold:          1,
+			TreatMissingData:   "breaching",
+		},
+		{
+			Name:               "swap",
+			Query:              `SELECT MAX("swap_used_percent") FROM "Team/Compute" WHERE "InstanceId" = 'i-123' AND "role" = 'it\'s'`,
+			Period:             time.Minute,
+			EvaluationPeriods:  5,
+			ComparisonOperator: "GreaterThanThreshold",
+		},
+	}, alarms.Templates)
+
+	// only the default exporter creates the alarms
+	got, err = NewTranslatorWithNamespace("Team/Compute").Translate(conf)
+	require.NoError(t, err)
+	assert.Nil(t, got.(*cloudwatch.Config).Alarms)
+
+	// the metrics of the host are selected by their host dimension without the InstanceId one
+	conf = confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"alarms": map[string]any{
+				"templates": []any{
+					map[string]any{"name": "cpu", "metric_name": "cpu_usage_active"},
+				},
+			},
+		},
+	})
+	got, err = NewTranslator().Translate(conf)
+	require.NoError(t, err)
+	alarms = got.(*cloudwatch.Config).Alarms
+	assert.Equal(t, "CWAgent-host-a", alarms.NamePrefix)
+	assert.Equal(t, `SELECT AVG("cpu_usage_active") FROM "CWAgent" WHERE "host" = 'host-a'`, alarms.Templates[0].Query)
+
+	conf = confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"alarms": map[string]any{
+				"templates": []any{
+					map[string]any{"name": "cpu", "metric_name": "cpu_usage_active", "statistic": "p99"},
+				},
+			},
+		},
+	})
+	_, err = NewTranslator().Translate(conf)
+	assert.ErrorContains(t, err, `unsupported statistic "p99"`)
+}
+
+func TestTranslatorWithMetadataExport(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	logs.GlobalLogConfig.MetadataInfo = map[string]string{"{instance_id}": "i-123", "{local_hostname}": "host-a"}
+	t.Cleanup(func() { logs.GlobalLogConfig.MetadataInfo = nil })
+	conf := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"metrics_collected": map[string]any{"mem": map[string]any{}},
+			"metadata_export": map[string]any{
+				"file_path":      "/tmp/metrics.json",
+				"log_group_name": "metric-catalog",
+				"interval":       "10m",
+			},
+		},
+	})
+	want := &cloudwatch.MetadataExportConfig{
+		FilePath:      "/tmp/metrics.json",
+		LogGroupName:  "metric-catalog",
+		LogStreamName: "i-123",
+		Interval:      10 * time.Minute,
+	}
+	got, err := NewTranslator().Translate(conf)
+	require.NoError(t, err)
+	assert.Equal(t, want, got.(*cloudwatch.Config).MetadataExport)
+	// every exporter shares the catalog
+	got, err = NewTranslatorWithNamespace("Team/Compute").Translate(conf)
+	require.NoError(t, err)
+	assert.Equal(t, want, got.(*cloudwatch.Config).MetadataExport)
+
+	conf = confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"metadata_export": map[string]any{"log_group_name": "metric-catalog", "log_stream_name": "{local_hostname}"},
+		},
+	})
+	got, err = NewTranslator().Translate(conf)
+	require.NoError(t, err)
+	assert.Equal(t, &cloudwatch.MetadataExportConfig{LogGroupName: "metric-catalog", LogStreamName: "host-a"}, got.(*cloudwatch.Config).MetadataExport)
+
+	got, err = NewTranslator().Translate(confmap.NewFromStringMap(map[string]any{"metrics": map[string]any{}}))
+	require.NoError(t, err)
+	assert.Nil(t, got.(*cloudwatch.Config).MetadataExport)
+}
@@ -15,12 +15,14 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
 	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/logsdestination"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
 )
 
 const (
@@ -29,9 +31,12 @@ const (
 
 var (
 	emfBasePathKey      = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.Emf)
-	roleARNPathKey      = common.ConfigKey(common.LogsKey, common.CredentialsKey, common.RoleARNKey)
+	eventsBasePathKey   = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.EventsKey)
 	endpointOverrideKey = common.ConfigKey(common.LogsKey, common.EndpointOverrideKey)
 	streamNameKey       = common.ConfigKey(common.LogsKey, common.LogStreamName)
+	otlpLogGroupKey     = common.ConfigKey(common.OtlpLogsConfigKey, common.LogGroupName)
+	otlpLogStreamKey    = common.ConfigKey(common.OtlpLogsConfigKey, common.LogStreamName)
+	otlpRetentionKey    = common.ConfigKey(common.OtlpLogsConfigKey, "retention_in_days")
 )
 
 type translator struct {
@@ -59,6 +64,10 @@ func (t *translator) Translate(c *confmap.Conf) (component.Config, error) {
 		if err := t.setEmfFields(c, cfg); err != nil {
 			return nil, err
 		}
+	} else if t.name == common.PipelineNameOtlpLogs {
+		if err := t.setOtlpLogsFields(c, cfg); err != nil {
+			return nil, err
+		}
 	}
 
 	cfg.AWSSessionSettings.CertificateFilePath = os.Getenv(envconfig.AWS_CA_BUNDLE)
@@ -73,10 +82,11 @@ func (t *translator) Translate(c *confmap.Conf) (component.Config, error) {
 		cfg.AWSSessionSettings.Profile = fmt.Sprintf("%v", profileKey)
 	}
 	cfg.AWSSessionSettings.Region = agent.Global_Config.Region
-	cfg.AWSSessionSettings.RoleARN = agent.Global_Config.Role_arn
-	if c.IsSet(roleARNPathKey) {
-		cfg.AWSSessionSettings.RoleARN, _ = common.GetString(c, roleARNPathKey)
+	roleARN, err := common.GetRoleARN(c, common.LogsKey)
+	if err != nil {
+		return nil, err
 	}
+	cfg.AWSSessionSettings.RoleARN = roleARN
 	if credentialsFileKey, ok := agent.Global_Config.Credentials[agent.CredentialsFile_Key]; ok {
 		cfg.AWSSessionSettings.SharedCredentialsFile = []string{fmt.Sprintf("%v", credentialsFileKey)}
 	}
@@ -86,8 +96,9 @@ func (t *translator) Translate(c *confmap.Conf) (component.Config, error) {
 	return cfg, nil
 }
 
+// isEmf is true if the pipeline sends EMF logs, or events which are routed like them.
 func (t *translator) isEmf(conf *confmap.Conf) bool {
-	return conf.IsSet(emfBasePathKey)
+	return conf.IsSet(emfBasePathKey) || conf.IsSet(eventsBasePathKey)
 }
 
 func (t *translator) setEmfFields(conf *confmap.Conf, cfg *awscloudwatchlogsexporter.Config) error {
@@ -105,3 +116,42 @@ func (t *translator) setEmfFields(conf *confmap.Conf, cfg *awscloudwatchlogsexpo
 	}
 	return nil
 }
+
+// setOtlpLogsFields sends the OTLP logs to the configured log group and log stream. When the names have placeholders
+// of resource attributes, the logsdestination processor of the pipeline resolves them for each log record and the
+// exporter sends the bodies it writes as they are.
+func (t *translator) setOtlpLogsFields(conf *confmap.Conf, cfg *awscloudwatchlogsexporter.Config) error {
+	logGroupName, logStreamName, err := OtlpLogsNames(t.ID(), conf)
+	if err != nil {
+		return err
+	}
+	cfg.Region = agent.Global_Config.Region
+	cfg.LogGroupName = logGroupName
+	cfg.LogStreamName = logStreamName
+	cfg.RawLog = logsdestination.HasPlaceholders(logGroupName) || logsdestination.HasPlaceholders(logStreamName)
+	if retention, ok := common.GetNumber(conf, otlpRetentionKey); ok && retention > 0 {
+		cfg.LogRetention = int64(retention)
+	}
+	return nil
+}
+
+// OtlpLogsNames returns the log group and log stream names of the OTLP logs, with the placeholders of the agent,
+// e.g. {instance_id}, resolved. The log stream name defaults to the one of the logs section.
+func OtlpLogsNames(id component.ID, conf *confmap.Conf) (string, string, error) {
+	logGroupName, ok := common.GetString(conf, otlpLogGroupKey)
+	if !ok || logGroupName == "" {
+		return "", "", &common.MissingKeyError{ID: id, JsonKey: otlpLogGroupKey}
+	}
+	logGroupName = util.ResolvePlaceholder(logGroupName, logs.GlobalLogConfig.MetadataInfo)
+	if logStreamName, ok := common.GetString(conf, otlpLogStreamKey); ok && logStreamName != "" {
+		return logGroupName, util.ResolvePlaceholder(logStreamName, logs.GlobalLogConfig.MetadataInfo), nil
+	}
+	rule := logs.LogStreamName{}
+	_, val := rule.ApplyRule(conf.Get(common.LogsKey))
+	if res, ok := val.(map[string]any); ok {
+		if logStreamName, _ := res[common.LogStreamName].(string); logStreamName != "" {
+			return logGroupName, logStreamName, nil
+		}
+	}
+	return "", "", &common.MissingKeyError{ID: id, JsonKey: streamNameKey}
+}
@@ -169,3 +169,88 @@ func TestTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslatorOtlpLogs(t *testing.T) {
+	t.Setenv(envconfig.AWS_CA_BUNDLE, "/ca/bundle")
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.Role_arn = ""
+	agent.Global_Config.Credentials = map[string]any{}
+	globallogs.GlobalLogConfig.MetadataInfo = logsutil.GetMetadataInfo(testMetadata)
+	tt := NewTranslatorWithName(common.PipelineNameOtlpLogs)
+	require.EqualValues(t, "awscloudwatchlogs/otlp_logs", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		want    *confmap.Conf
+		wantErr error
+	}{
+		"WithoutLogGroupName": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{},
+					},
+				},
+			},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: "logs::logs_collected::otlp::log_group_name"},
+		},
+		"WithStaticNames": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{
+							"log_group_name":    "/aws/otlp/{instance_id}",
+							"retention_in_days": 30,
+						},
+					},
+				},
+			},
+			want: confmap.NewFromStringMap(map[string]any{
+				"certificate_file_path": "/ca/bundle",
+				"imds_retries":          1,
+				"log_group_name":        "/aws/otlp/some_instance_id",
+				"log_stream_name":       "some_instance_id",
+				"log_retention":         30,
+				"middleware":            "agenthealth/logs",
+				"region":                "us-east-1",
+			}),
+		},
+		"WithResourceAttributes": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{
+							"log_group_name":  "/aws/otlp/{service.name}",
+							"log_stream_name": "{hostname}/{k8s.pod.name}",
+						},
+					},
+				},
+			},
+			want: confmap.NewFromStringMap(map[string]any{
+				"certificate_file_path": "/ca/bundle",
+				"imds_retries":          1,
+				"log_group_name":        "/aws/otlp/{service.name}",
+				"log_stream_name":       "some_hostname/{k8s.pod.name}",
+				"middleware":            "agenthealth/logs",
+				"raw_log":               true,
+				"region":                "us-east-1",
+			}),
+		},
+	}
+	factory := awscloudwatchlogsexporter.NewFactory()
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translatorcontext.CurrentContext().SetMode(config.ModeEC2)
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			require.Equal(t, testCase.wantErr, err)
+			if err == nil {
+				require.NotNil(t, got)
+				gotCfg, ok := got.(*awscloudwatchlogsexporter.Config)
+				require.True(t, ok)
+				wantCfg := factory.CreateDefaultConfig()
+				require.NoError(t, testCase.want.Unmarshal(wantCfg))
+				assert.Equal(t, wantCfg, gotCfg)
+			}
+		})
+	}
+}
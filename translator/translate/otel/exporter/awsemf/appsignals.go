@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awsemf
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func setAppSignalsFields(conf *confmap.Conf, cfg *awsemfexporter.Config) error {
+	if isAppSignalsExceptionMetricsEnabled(conf) {
+		cfg.MetricDeclarations = append(cfg.MetricDeclarations, getAppSignalsExceptionMetricDeclaration())
+	}
+	return nil
+}
+
+func isAppSignalsExceptionMetricsEnabled(conf *confmap.Conf) bool {
+	return conf.IsSet(common.ConfigKey(common.AppSignalsMetrics, common.AppSignalsExceptionMetrics)) ||
+		conf.IsSet(common.ConfigKey(common.AppSignalsMetricsFallback, common.AppSignalsExceptionMetrics))
+}
+
+func getAppSignalsExceptionMetricDeclaration() *awsemfexporter.MetricDeclaration {
+	return &awsemfexporter.MetricDeclaration{
+		Dimensions: [][]string{
+			{"Environment", "Service", "Operation", "ExceptionType"},
+			{"Environment", "Service", "Operation"},
+			{"Environment", "Service", "ExceptionType"},
+		},
+		LabelMatchers: []*awsemfexporter.LabelMatcher{
+			{
+				LabelNames: []string{"Telemetry.Source"},
+				Regex:      "^SpanEvent$",
+			},
+		},
+		MetricNameSelectors: []string{"Exception"},
+	}
+}
@@ -6,9 +6,13 @@ package awsemf
 import (
 	"errors"
 	"fmt"
+	"log"
+	"regexp"
+	"sort"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
 	"go.opentelemetry.io/collector/confmap"
+	"golang.org/x/exp/maps"
 
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
@@ -79,11 +83,13 @@ func setPrometheusMetricDescriptors(conf *confmap.Conf, cfg *awsemfexporter.Conf
 
 	mus := conf.Get(metricUnitKey)
 	metricUnits := mus.(map[string]interface{})
+	metricNames := maps.Keys(metricUnits)
+	sort.Strings(metricNames)
 	var metricDescriptors []map[string]string
-	for mName, unit := range metricUnits {
+	for _, mName := range metricNames {
 		metricDescriptors = append(metricDescriptors, map[string]string{
 			"metric_name": mName,
-			"unit":        unit.(string),
+			"unit":        metricUnits[mName].(string),
 		})
 	}
 	c := confmap.NewFromStringMap(map[string]interface{}{
@@ -101,26 +107,60 @@ func setPrometheusMetricDeclarations(conf *confmap.Conf, cfg *awsemfexporter.Con
 	if !conf.IsSet(metricDeclarationKey) {
 		return nil
 	}
-	metricDeclarations := conf.Get(metricDeclarationKey)
+	declarations, err := prometheusMetricDeclarations(conf)
+	if err != nil {
+		return err
+	}
+	cfg.MetricDeclarations = declarations
+	return nil
+}
+
+// PrometheusMetricDeclarations returns the metric declarations of the exporter of the prometheus pipeline of the
+// emf_processor.jobs entry at the index, or of the default prometheus pipeline if the index is -1.
+func PrometheusMetricDeclarations(conf *confmap.Conf, index int) ([]*awsemfexporter.MetricDeclaration, error) {
+	if index != -1 {
+		var err error
+		if conf, err = prometheusJobConf(conf, index); err != nil {
+			return nil, err
+		}
+	}
+	return prometheusMetricDeclarations(conf)
+}
+
+// prometheusMetricDeclarations converts the emf_processor.metric_declaration entries. The regular expressions are
+// compiled, since the exporter would fail to start with an invalid one.
+func prometheusMetricDeclarations(conf *confmap.Conf) ([]*awsemfexporter.MetricDeclaration, error) {
+	metricDeclarationKey := common.ConfigKey(emfProcessorBasePathKey, metricDeclartion)
+	metricDeclarations, _ := conf.Get(metricDeclarationKey).([]interface{})
 	var declarations []map[string]interface{}
-	for _, md := range metricDeclarations.([]interface{}) {
+	for i, md := range metricDeclarations {
 		metricDeclaration := md.(map[string]interface{})
 		declaration := map[string]interface{}{}
 		if dimensions, ok := metricDeclaration["dimensions"]; ok {
 			declaration["dimensions"] = dimensions
 		}
-		if metricSelectors, ok := metricDeclaration["metric_selectors"]; ok {
-			declaration["metric_name_selectors"] = metricSelectors
-		} else {
+		metricSelectors, ok := metricDeclaration["metric_selectors"]
+		if !ok {
 			// If no metric selectors are provided, that particular metric declaration is invalid
+			log.Printf("W! Ignoring %s[%d] without metric_selectors", metricDeclarationKey, i)
 			continue
 		}
+		selectors, _ := metricSelectors.([]interface{})
+		for _, selector := range selectors {
+			if _, err := regexp.Compile(fmt.Sprint(selector)); err != nil {
+				return nil, fmt.Errorf("invalid metric_selectors %q in %s[%d]: %w", selector, metricDeclarationKey, i, err)
+			}
+		}
+		declaration["metric_name_selectors"] = metricSelectors
 		labelMatcher, ok := metricDeclaration["label_matcher"]
 		if !ok {
 			labelMatcher = ".*"
 		}
 		sourceLabels, ok := metricDeclaration["source_labels"]
 		if ok {
+			if _, err := regexp.Compile(fmt.Sprint(labelMatcher)); err != nil {
+				return nil, fmt.Errorf("invalid label_matcher %q in %s[%d]: %w", labelMatcher, metricDeclarationKey, i, err)
+			}
 			// OTel awsemfexporter allows specifying multiple label_matchers but CWA only allows specifying one
 			declaration["label_matchers"] = [...]map[string]interface{}{
 				{
@@ -130,6 +170,7 @@ func setPrometheusMetricDeclarations(conf *confmap.Conf, cfg *awsemfexporter.Con
 			}
 		} else {
 			// If no source labels or label matchers are provided, that particular metric declaration is invalid
+			log.Printf("W! Ignoring %s[%d] without source_labels", metricDeclarationKey, i)
 			continue
 		}
 		declarations = append(declarations, declaration)
@@ -137,9 +178,40 @@ func setPrometheusMetricDeclarations(conf *confmap.Conf, cfg *awsemfexporter.Con
 	c := confmap.NewFromStringMap(map[string]interface{}{
 		"metric_declarations": declarations,
 	})
-	cfg.MetricDeclarations = []*awsemfexporter.MetricDeclaration{} // Clear out any existing declarations
+	var cfg struct {
+		MetricDeclarations []*awsemfexporter.MetricDeclaration `mapstructure:"metric_declarations"`
+	}
+	cfg.MetricDeclarations = []*awsemfexporter.MetricDeclaration{}
 	if err := c.Unmarshal(&cfg); err != nil {
-		return fmt.Errorf("unable to unmarshal metric_declarations: %w", err)
+		return nil, fmt.Errorf("unable to unmarshal metric_declarations: %w", err)
 	}
-	return nil
+	return cfg.MetricDeclarations, nil
+}
+
+// prometheusJobConf replaces the log group and the emf_processor settings of the prometheus section with the ones set
+// in the emf_processor.jobs entry at the index, so that the exporter of the jobs is translated like the default one.
+// The settings the entry does not have are kept.
+func prometheusJobConf(conf *confmap.Conf, index int) (*confmap.Conf, error) {
+	job := common.GetIndexedMap(conf, common.PrometheusEMFJobsKey, index)
+	if job == nil {
+		return nil, fmt.Errorf("%s does not have an entry %d", common.PrometheusEMFJobsKey, index)
+	}
+	raw := conf.ToStringMap()
+	logs, _ := raw[common.LogsKey].(map[string]any)
+	metricsCollected, _ := logs[common.MetricsCollectedKey].(map[string]any)
+	prometheus, _ := metricsCollected[common.PrometheusKey].(map[string]any)
+	emfProcessor, _ := prometheus[common.EMFProcessorKey].(map[string]any)
+	if emfProcessor == nil {
+		return nil, fmt.Errorf("%s does not have an entry %d", common.PrometheusEMFJobsKey, index)
+	}
+	delete(emfProcessor, common.PrometheusJobsKey)
+	for _, key := range []string{metricNamespace, metricUnit, metricDeclartion} {
+		if value, ok := job[key]; ok {
+			emfProcessor[key] = value
+		}
+	}
+	if logGroupName, ok := job[common.LogGroupName]; ok {
+		prometheus[common.LogGroupName] = logGroupName
+	}
+	return confmap.NewFromStringMap(raw), nil
 }
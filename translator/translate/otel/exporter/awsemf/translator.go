@@ -7,6 +7,7 @@ import (
 	_ "embed"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
 	"go.opentelemetry.io/collector/component"
@@ -52,12 +53,14 @@ var (
 	prometheusBasePathKey      = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.PrometheusKey)
 	emfProcessorBasePathKey    = common.ConfigKey(prometheusBasePathKey, common.EMFProcessorKey)
 	endpointOverrideKey        = common.ConfigKey(common.LogsKey, common.EndpointOverrideKey)
-	roleARNPathKey             = common.ConfigKey(common.LogsKey, common.CredentialsKey, common.RoleARNKey)
+	emfMetricsNamespaceKey     = common.ConfigKey(common.EMFMetricsConfigKey, "namespace")
 )
 
 type translator struct {
 	name    string
 	factory exporter.Factory
+	// prometheusJobIndex is the emf_processor.jobs entry the exporter sends the metrics of, or -1
+	prometheusJobIndex int
 }
 
 var _ common.ComponentTranslator = (*translator)(nil)
@@ -67,7 +70,17 @@ func NewTranslator() common.ComponentTranslator {
 }
 
 func NewTranslatorWithName(name string) common.ComponentTranslator {
-	return &translator{name, awsemfexporter.NewFactory()}
+	return &translator{name: name, factory: awsemfexporter.NewFactory(), prometheusJobIndex: -1}
+}
+
+// NewPrometheusJobTranslator creates an exporter for the prometheus metrics of the scrape jobs in the
+// emf_processor.jobs entry at the index.
+func NewPrometheusJobTranslator(index int) common.ComponentTranslator {
+	return &translator{
+		name:               common.PipelineNamePrometheus + "/" + strconv.Itoa(index),
+		factory:            awsemfexporter.NewFactory(),
+		prometheusJobIndex: index,
+	}
 }
 
 func (t *translator) ID() component.ID {
@@ -76,6 +89,12 @@ func (t *translator) ID() component.ID {
 
 // Translate creates an awsemf exporter config based on the input json config
 func (t *translator) Translate(c *confmap.Conf) (component.Config, error) {
+	if t.prometheusJobIndex != -1 {
+		var err error
+		if c, err = prometheusJobConf(c, t.prometheusJobIndex); err != nil {
+			return nil, err
+		}
+	}
 	cfg := t.factory.CreateDefaultConfig().(*awsemfexporter.Config)
 	cfg.MiddlewareID = &agenthealth.LogsID
 
@@ -113,10 +132,11 @@ func (t *translator) Translate(c *confmap.Conf) (component.Config, error) {
 		cfg.AWSSessionSettings.Profile = fmt.Sprintf("%v", profileKey)
 	}
 	cfg.AWSSessionSettings.Region = agent.Global_Config.Region
-	cfg.AWSSessionSettings.RoleARN = agent.Global_Config.Role_arn
-	if c.IsSet(roleARNPathKey) {
-		cfg.AWSSessionSettings.RoleARN, _ = common.GetString(c, roleARNPathKey)
+	roleARN, err := common.GetRoleARN(c, common.LogsKey)
+	if err != nil {
+		return nil, err
 	}
+	cfg.AWSSessionSettings.RoleARN = roleARN
 	if credentialsFileKey, ok := agent.Global_Config.Credentials[agent.CredentialsFile_Key]; ok {
 		cfg.AWSSessionSettings.SharedCredentialsFile = []string{fmt.Sprintf("%v", credentialsFileKey)}
 	}
@@ -148,6 +168,8 @@ func (t *translator) Translate(c *confmap.Conf) (component.Config, error) {
 		if err := setPrometheusFields(c, cfg); err != nil {
 			return nil, err
 		}
+	} else if namespace, ok := common.GetString(c, emfMetricsNamespaceKey); ok {
+		cfg.Namespace = namespace
 	}
 	return cfg, nil
 }
@@ -176,10 +198,6 @@ func isPrometheus(conf *confmap.Conf) bool {
 	return conf.IsSet(prometheusBasePathKey)
 }
 
-func setAppSignalsFields(_ *confmap.Conf, _ *awsemfexporter.Config) error {
-	return nil
-}
-
 func setEcsFields(conf *confmap.Conf, cfg *awsemfexporter.Config) error {
 	setDisableMetricExtraction(ecsBasePathKey, conf, cfg)
 	return nil
@@ -774,6 +774,32 @@ func TestTranslator(t *testing.T) {
 				"local_mode":         false,
 			},
 		},
+		"GenerateAwsEmfExporterConfigEMFMetricsNamespace": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"emf_metrics": map[string]any{
+						"namespace": "Team/Service",
+					},
+				},
+			},
+			want: map[string]any{
+				"namespace":                              "Team/Service",
+				"log_group_name":                         "/aws/cwagent",
+				"log_stream_name":                        "",
+				"dimension_rollup_option":                "NoDimensionRollup",
+				"disable_metric_extraction":              false,
+				"enhanced_container_insights":            false,
+				"parse_json_encoded_attr_values":         []string(nil),
+				"output_destination":                     "cloudwatch",
+				"eks_fargate_container_insights_enabled": false,
+				"resource_to_telemetry_conversion": resourcetotelemetry.Settings{
+					Enabled: true,
+				},
+				"metric_declarations": []*awsemfexporter.MetricDeclaration(nil),
+				"metric_descriptors":  nilMetricDescriptorsSlice,
+				"local_mode":          false,
+			},
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -808,6 +834,158 @@ func TestTranslator(t *testing.T) {
 	}
 }
 
+func TestTranslatePrometheusJobs(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	input := confmap.NewFromStringMap(map[string]any{
+		"logs": map[string]any{
+			"metrics_collected": map[string]any{
+				"prometheus": map[string]any{
+					"log_group_name": "/test/log/group",
+					"emf_processor": map[string]any{
+						"metric_namespace": "Default",
+						"metric_unit": map[string]any{
+							"jvm_gc_collection_seconds_sum": "Milliseconds",
+						},
+						"jobs": []any{
+							map[string]any{
+								"job_names":        []any{"nginx"},
+								"metric_namespace": "Nginx",
+								"log_group_name":   "/test/log/group/nginx",
+								"metric_declaration": []any{
+									map[string]any{
+										"source_labels":    []any{"job"},
+										"label_matcher":    "^nginx$",
+										"dimensions":       []any{[]any{"job"}},
+										"metric_selectors": []any{"^nginx_connections_active$"},
+									},
+								},
+							},
+							map[string]any{
+								"job_names": []any{"redis"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	tt := NewTranslatorWithName(common.PipelineNamePrometheus)
+	got, err := tt.Translate(input)
+	require.NoError(t, err)
+	gotCfg := got.(*awsemfexporter.Config)
+	assert.Equal(t, "Default", gotCfg.Namespace)
+	assert.Equal(t, "/test/log/group", gotCfg.LogGroupName)
+
+	tt = NewPrometheusJobTranslator(0)
+	assert.Equal(t, "awsemf/prometheus/0", tt.ID().String())
+	got, err = tt.Translate(input)
+	require.NoError(t, err)
+	gotCfg = got.(*awsemfexporter.Config)
+	assert.Equal(t, "Nginx", gotCfg.Namespace)
+	assert.Equal(t, "/test/log/group/nginx", gotCfg.LogGroupName)
+	assert.Equal(t, []*awsemfexporter.MetricDeclaration{
+		{
+			Dimensions:          [][]string{{"job"}},
+			MetricNameSelectors: []string{"^nginx_connections_active$"},
+			LabelMatchers: []*awsemfexporter.LabelMatcher{
+				{
+					LabelNames: []string{"job"},
+					Regex:      "^nginx$",
+				},
+			},
+		},
+	}, gotCfg.MetricDeclarations)
+	assert.Equal(t, []awsemfexporter.MetricDescriptor{{MetricName: "jvm_gc_collection_seconds_sum", Unit: "Milliseconds"}}, gotCfg.MetricDescriptors)
+
+	// the settings the entry does not have are the ones of the prometheus section
+	got, err = NewPrometheusJobTranslator(1).Translate(input)
+	require.NoError(t, err)
+	gotCfg = got.(*awsemfexporter.Config)
+	assert.Equal(t, "Default", gotCfg.Namespace)
+	assert.Equal(t, "/test/log/group", gotCfg.LogGroupName)
+
+	_, err = NewPrometheusJobTranslator(2).Translate(input)
+	assert.ErrorContains(t, err, "does not have an entry 2")
+}
+
+func TestPrometheusMetricDeclarations(t *testing.T) {
+	conf := func(declarations ...any) *confmap.Conf {
+		return confmap.NewFromStringMap(map[string]any{
+			"logs": map[string]any{"metrics_collected": map[string]any{"prometheus": map[string]any{
+				"log_group_name": "/test/log/group",
+				"emf_processor": map[string]any{
+					"metric_declaration": declarations,
+					"jobs": []any{
+						map[string]any{"job_names": []any{"nginx"}},
+					},
+				},
+			}}},
+		})
+	}
+	testCases := map[string]struct {
+		input   *confmap.Conf
+		index   int
+		want    []*awsemfexporter.MetricDeclaration
+		wantErr string
+	}{
+		"WithIgnored": {
+			input: conf(
+				map[string]any{"source_labels": []any{"job"}, "metric_selectors": []any{"^nginx_.*$"}},
+				map[string]any{"source_labels": []any{"job"}},
+				map[string]any{"metric_selectors": []any{".*"}},
+			),
+			index: -1,
+			want: []*awsemfexporter.MetricDeclaration{
+				{
+					MetricNameSelectors: []string{"^nginx_.*$"},
+					LabelMatchers:       []*awsemfexporter.LabelMatcher{{LabelNames: []string{"job"}, Regex: ".*"}},
+				},
+			},
+		},
+		"WithJob": {
+			input: conf(map[string]any{"source_labels": []any{"job"}, "label_matcher": "^nginx$", "metric_selectors": []any{".*"}}),
+			index: 0,
+			want: []*awsemfexporter.MetricDeclaration{
+				{
+					MetricNameSelectors: []string{".*"},
+					LabelMatchers:       []*awsemfexporter.LabelMatcher{{LabelNames: []string{"job"}, Regex: "^nginx$"}},
+				},
+			},
+		},
+		"WithInvalidMetricSelector": {
+			input:   conf(map[string]any{"source_labels": []any{"job"}, "metric_selectors": []any{"^nginx_(.*$"}}),
+			index:   -1,
+			wantErr: `invalid metric_selectors "^nginx_(.*$" in logs::metrics_collected::prometheus::emf_processor::metric_declaration[0]`,
+		},
+		"WithInvalidLabelMatcher": {
+			input:   conf(map[string]any{"source_labels": []any{"job"}, "label_matcher": "[a", "metric_selectors": []any{".*"}}),
+			index:   -1,
+			wantErr: `invalid label_matcher "[a"`,
+		},
+		"WithMissingJob": {
+			input:   conf(),
+			index:   1,
+			wantErr: "does not have an entry 1",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := PrometheusMetricDeclarations(testCase.input, testCase.index)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+
+	// the exporter would fail to start with the invalid regex
+	_, err := NewTranslatorWithName(common.PipelineNamePrometheus).Translate(conf(map[string]any{"source_labels": []any{"job"}, "metric_selectors": []any{"("}}))
+	assert.ErrorContains(t, err, "invalid metric_selectors")
+}
+
 func TestTranslatorForKueue(t *testing.T) {
 	t.Setenv(envconfig.AWS_CA_BUNDLE, "/ca/bundle")
 	agent.Global_Config.Region = "us-east-1"
@@ -1069,3 +1247,29 @@ func TestTranslateAppSignals(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateAppSignalsExceptionMetrics(t *testing.T) {
+	t.Setenv(envconfig.IMDS_NUMBER_RETRY, "0")
+	agent.Global_Config.Region = "us-east-1"
+	context.CurrentContext().SetKubernetesMode("")
+	context.CurrentContext().SetMode(config.ModeEC2)
+	tt := NewTranslatorWithName(common.AppSignals)
+	factory := awsemfexporter.NewFactory()
+	defaultCfg := factory.CreateDefaultConfig().(*awsemfexporter.Config)
+	require.NoError(t, testutil.GetConf(t, "awsemf_default_appsignals.yaml").Unmarshal(defaultCfg))
+
+	got, err := tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"logs": map[string]any{
+			"metrics_collected": map[string]any{
+				"application_signals": map[string]any{
+					"exception_metrics": map[string]any{},
+				},
+			},
+		}}))
+	require.NoError(t, err)
+	gotCfg, ok := got.(*awsemfexporter.Config)
+	require.True(t, ok)
+	require.Len(t, gotCfg.MetricDeclarations, len(defaultCfg.MetricDeclarations)+1)
+	assert.Equal(t, defaultCfg.MetricDeclarations, gotCfg.MetricDeclarations[:len(defaultCfg.MetricDeclarations)])
+	assert.Equal(t, getAppSignalsExceptionMetricDeclaration(), gotCfg.MetricDeclarations[len(defaultCfg.MetricDeclarations)])
+}
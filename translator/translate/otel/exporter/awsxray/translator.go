@@ -7,6 +7,7 @@ import (
 	_ "embed"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter"
 	"go.opentelemetry.io/collector/component"
@@ -23,6 +24,9 @@ import (
 )
 
 const (
+	annotationsKey              = "annotations"
+	attributesKey               = "attributes"
+	indexAllKey                 = "index_all"
 	concurrencyKey              = "concurrency"
 	resourceARNKey              = "resource_arn"
 	transitSpansInOtlpFormatKey = "transit_spans_in_otlp_format"
@@ -35,6 +39,9 @@ type translator struct {
 
 var _ common.ComponentTranslator = (*translator)(nil)
 
+// maxIndexedAttributes is the number of annotations X-Ray indexes per trace.
+const maxIndexedAttributes = 50
+
 var (
 	indexedAttributes = []string{
 		"aws.local.service", "aws.local.operation", "aws.local.environment",
@@ -64,9 +71,13 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	}
 	cfg := t.factory.CreateDefaultConfig().(*awsxrayexporter.Config)
 
-	if isAppSignals(conf) {
+	// the spans enriched like the App Signals ones are indexed the same way
+	if enriched, _ := common.GetBool(conf, common.EnrichServiceAttributesKey); enriched || isAppSignals(conf) {
 		cfg.IndexedAttributes = indexedAttributes
 	}
+	if err := setAnnotations(conf, cfg); err != nil {
+		return nil, err
+	}
 
 	c := confmap.NewFromStringMap(map[string]interface{}{
 		"telemetry": map[string]interface{}{
@@ -107,7 +118,11 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		cfg.TransitSpansInOtlpFormat = transitOtlp
 	}
 	cfg.AWSSessionSettings.Region = getRegion(conf)
-	cfg.AWSSessionSettings.RoleARN = getRoleARN(conf)
+	roleARN, err := common.GetRoleARN(conf, common.TracesKey)
+	if err != nil {
+		return nil, err
+	}
+	cfg.AWSSessionSettings.RoleARN = roleARN
 	if credentialsFileKey, ok := agent.Global_Config.Credentials[agent.CredentialsFile_Key]; ok {
 		cfg.AWSSessionSettings.SharedCredentialsFile = []string{fmt.Sprintf("%v", credentialsFileKey)}
 	}
@@ -115,13 +130,26 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	return cfg, nil
 }
 
-func getRoleARN(conf *confmap.Conf) string {
-	key := common.ConfigKey(common.TracesKey, common.CredentialsKey, common.RoleARNKey)
-	roleARN, ok := common.GetString(conf, key)
-	if !ok {
-		roleARN = agent.Global_Config.Role_arn
+// setAnnotations adds the span attributes that are converted to X-Ray annotations instead of metadata.
+func setAnnotations(conf *confmap.Conf, cfg *awsxrayexporter.Config) error {
+	key := common.ConfigKey(common.TracesKey, annotationsKey)
+	if !conf.IsSet(key) {
+		return nil
+	}
+	if indexAll, ok := common.GetBool(conf, common.ConfigKey(key, indexAllKey)); ok {
+		cfg.IndexAllAttributes = indexAll
+	}
+	attributes := slices.Clone(cfg.IndexedAttributes)
+	for _, attribute := range common.GetArray[string](conf, common.ConfigKey(key, attributesKey)) {
+		if !slices.Contains(attributes, attribute) {
+			attributes = append(attributes, attribute)
+		}
+	}
+	if len(attributes) > maxIndexedAttributes {
+		return fmt.Errorf("%s has %d indexed attributes, X-Ray indexes at most %d annotations", key, len(attributes), maxIndexedAttributes)
 	}
-	return roleARN
+	cfg.IndexedAttributes = attributes
+	return nil
 }
 
 func getRegion(conf *confmap.Conf) string {
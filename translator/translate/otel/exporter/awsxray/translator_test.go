@@ -4,6 +4,7 @@
 package awsxray
 
 import (
+	"fmt"
 	"path/filepath"
 	"testing"
 
@@ -57,6 +58,40 @@ func TestTranslator(t *testing.T) {
 			}),
 			mode: config.ModeOnPrem,
 		},
+		"WithAnnotations": {
+			input: map[string]any{
+				"traces": map[string]any{
+					"annotations": map[string]any{
+						"attributes": []any{"order.id", "customer.tier"},
+					},
+				},
+			},
+			want: confmap.NewFromStringMap(map[string]any{
+				"indexed_attributes":    []string{"order.id", "customer.tier"},
+				"certificate_file_path": "/ca/bundle",
+				"region":                "us-east-1",
+				"local_mode":            true,
+				"role_arn":              "global_arn",
+				"imds_retries":          1,
+				"telemetry": map[string]any{
+					"enabled":          true,
+					"include_metadata": true,
+				},
+				"middleware": "agenthealth/traces",
+			}),
+			mode: config.ModeOnPrem,
+		},
+		"WithTooManyAnnotations": {
+			input: map[string]any{
+				"traces": map[string]any{
+					"annotations": map[string]any{
+						"attributes": tooManyAttributes(),
+					},
+				},
+			},
+			wantErr: fmt.Errorf("traces::annotations has 51 indexed attributes, X-Ray indexes at most 50 annotations"),
+			mode:    config.ModeOnPrem,
+		},
 		"WithCompleteConfig": {
 			input: testutil.GetJson(t, filepath.Join("testdata", "config.json")),
 			want:  testutil.GetConf(t, filepath.Join("testdata", "config.yaml")),
@@ -154,6 +189,37 @@ func TestTranslator(t *testing.T) {
 			}),
 			mode: config.ModeEC2,
 		},
+		"WithEnrichServiceAttributes": {
+			input: map[string]any{
+				"traces": map[string]any{
+					"traces_collected": map[string]any{
+						"otlp": map[string]any{},
+					},
+					"enrich_service_attributes": true,
+				}},
+			want: confmap.NewFromStringMap(map[string]any{
+				"indexed_attributes": []string{
+					"aws.local.service",
+					"aws.local.operation",
+					"aws.local.environment",
+					"aws.remote.service",
+					"aws.remote.operation",
+					"aws.remote.environment",
+					"aws.remote.resource.identifier",
+					"aws.remote.resource.type",
+				},
+				"certificate_file_path": "/ca/bundle",
+				"region":                "us-east-1",
+				"role_arn":              "global_arn",
+				"imds_retries":          1,
+				"telemetry": map[string]any{
+					"enabled":          true,
+					"include_metadata": true,
+				},
+				"middleware": "agenthealth/traces",
+			}),
+			mode: config.ModeEC2,
+		},
 	}
 	factory := awsxrayexporter.NewFactory()
 	for name, testCase := range testCases {
@@ -174,3 +240,11 @@ func TestTranslator(t *testing.T) {
 		})
 	}
 }
+
+func tooManyAttributes() []any {
+	var attributes []any
+	for i := 0; i <= maxIndexedAttributes; i++ {
+		attributes = append(attributes, fmt.Sprintf("attribute%d", i))
+	}
+	return attributes
+}
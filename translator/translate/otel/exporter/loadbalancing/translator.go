@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package loadbalancing
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	endpointsKey   = "endpoints"
+	dnsNameKey     = "dns_name"
+	portKey        = "port"
+	compressionKey = "compression"
+	caFileKey      = "ca_file"
+	routingKeyKey  = "routing_key"
+
+	defaultPort       = "4317"
+	defaultRoutingKey = "resource"
+)
+
+var (
+	// GatewaySectionKey sends the metrics to gateway agents over OTLP instead of to CloudWatch.
+	GatewaySectionKey = common.ConfigKey(common.MetricsKey, common.MetricsDestinationsKey, common.GatewayKey)
+)
+
+type translator struct {
+	name    string
+	factory exporter.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return NewTranslatorWithName(common.GatewayKey)
+}
+
+func NewTranslatorWithName(name string) common.ComponentTranslator {
+	return &translator{name, loadbalancingexporter.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// Translate creates an exporter that balances the metrics between the gateway agents, either a
+// static list or every address of a DNS name. The metrics of a resource always go to the same
+// gateway while it is available.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(GatewaySectionKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: GatewaySectionKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*loadbalancingexporter.Config)
+	endpoints := common.GetArray[string](conf, common.ConfigKey(GatewaySectionKey, endpointsKey))
+	dnsName, hasDNSName := common.GetString(conf, common.ConfigKey(GatewaySectionKey, dnsNameKey))
+	switch {
+	case len(endpoints) > 0 && hasDNSName:
+		return nil, fmt.Errorf("only one of %s and %s can be set in %s", endpointsKey, dnsNameKey, GatewaySectionKey)
+	case len(endpoints) > 0:
+		cfg.Resolver.Static = &loadbalancingexporter.StaticResolver{Hostnames: endpoints}
+	case hasDNSName:
+		port := defaultPort
+		if value, ok := common.GetString(conf, common.ConfigKey(GatewaySectionKey, portKey)); ok {
+			port = value
+		}
+		cfg.Resolver.DNS = &loadbalancingexporter.DNSResolver{Hostname: dnsName, Port: port}
+	default:
+		return nil, fmt.Errorf("one of %s and %s must be set in %s", endpointsKey, dnsNameKey, GatewaySectionKey)
+	}
+
+	cfg.RoutingKey = defaultRoutingKey
+	if routingKey, ok := common.GetString(conf, common.ConfigKey(GatewaySectionKey, routingKeyKey)); ok {
+		cfg.RoutingKey = routingKey
+	}
+	// the gateway can be restarted or scaled, so retry and queue like the CloudWatch exporters do
+	cfg.BackOffConfig = configretry.NewDefaultBackOffConfig()
+	cfg.QueueSettings = exporterhelper.NewDefaultQueueConfig()
+
+	otlp := &cfg.Protocol.OTLP
+	if compression, ok := common.GetString(conf, common.ConfigKey(GatewaySectionKey, compressionKey)); ok {
+		otlp.Compression = configcompression.Type(compression)
+	}
+	if insecure, ok := common.GetBool(conf, common.ConfigKey(GatewaySectionKey, common.InsecureKey)); ok {
+		otlp.TLSSetting.Insecure = insecure
+	}
+	if caFile, ok := common.GetString(conf, common.ConfigKey(GatewaySectionKey, common.TLSKey, caFileKey)); ok {
+		otlp.TLSSetting.CAFile = caFile
+	}
+	return cfg, nil
+}
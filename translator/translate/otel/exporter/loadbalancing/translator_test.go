@@ -0,0 +1,95 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package loadbalancing
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func gatewayConfig(gateway map[string]any) map[string]any {
+	return map[string]any{"metrics": map[string]any{"metrics_destinations": map[string]any{"gateway": gateway}}}
+}
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslator()
+	assert.EqualValues(t, "loadbalancing/gateway", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		check   func(t *testing.T, cfg *loadbalancingexporter.Config)
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input:   map[string]any{"metrics": map[string]any{}},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: GatewaySectionKey},
+		},
+		"WithEndpoints": {
+			input: gatewayConfig(map[string]any{"endpoints": []any{"gw-1.internal:4317", "gw-2.internal:4317"}}),
+			check: func(t *testing.T, cfg *loadbalancingexporter.Config) {
+				assert.Equal(t, []string{"gw-1.internal:4317", "gw-2.internal:4317"}, cfg.Resolver.Static.Hostnames)
+				assert.Nil(t, cfg.Resolver.DNS)
+				assert.Equal(t, "resource", cfg.RoutingKey)
+				assert.Equal(t, configcompression.TypeGzip, cfg.Protocol.OTLP.Compression)
+				assert.True(t, cfg.BackOffConfig.Enabled)
+				assert.True(t, cfg.QueueSettings.Enabled)
+			},
+		},
+		"WithDNSName": {
+			input: gatewayConfig(map[string]any{
+				"dns_name":    "cwagent-gateway.amazon-cloudwatch.svc.cluster.local",
+				"compression": "zstd",
+				"routing_key": "metric",
+				"insecure":    true,
+			}),
+			check: func(t *testing.T, cfg *loadbalancingexporter.Config) {
+				assert.Nil(t, cfg.Resolver.Static)
+				assert.Equal(t, &loadbalancingexporter.DNSResolver{Hostname: "cwagent-gateway.amazon-cloudwatch.svc.cluster.local", Port: "4317"}, cfg.Resolver.DNS)
+				assert.Equal(t, "metric", cfg.RoutingKey)
+				assert.Equal(t, configcompression.TypeZstd, cfg.Protocol.OTLP.Compression)
+				assert.True(t, cfg.Protocol.OTLP.TLSSetting.Insecure)
+			},
+		},
+		"WithTLS": {
+			input: gatewayConfig(map[string]any{
+				"dns_name": "gateway.internal",
+				"port":     "4318",
+				"tls":      map[string]any{"ca_file": "/etc/cwagent/ca.pem"},
+			}),
+			check: func(t *testing.T, cfg *loadbalancingexporter.Config) {
+				assert.Equal(t, "4318", cfg.Resolver.DNS.Port)
+				assert.Equal(t, "/etc/cwagent/ca.pem", cfg.Protocol.OTLP.TLSSetting.CAFile)
+				assert.False(t, cfg.Protocol.OTLP.TLSSetting.Insecure)
+			},
+		},
+		"WithBoth": {
+			input:   gatewayConfig(map[string]any{"endpoints": []any{"gw:4317"}, "dns_name": "gateway.internal"}),
+			wantErr: assert.AnError,
+		},
+		"WithNeither": {
+			input:   gatewayConfig(map[string]any{}),
+			wantErr: assert.AnError,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			if testCase.wantErr == assert.AnError {
+				assert.Error(t, err)
+				return
+			}
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.check != nil {
+				require.NotNil(t, got)
+				testCase.check(t, got.(*loadbalancingexporter.Config))
+			}
+		})
+	}
+}
@@ -8,13 +8,20 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/resourcetotelemetry"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pipeline"
 
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 )
 
+const (
+	headersKey = "headers"
+	sigV4Key   = "sigv4"
+)
+
 var (
 	AMPSectionKey = common.ConfigKey(common.MetricsKey, common.MetricsDestinationsKey, common.AMPKey)
 )
@@ -41,6 +48,9 @@ func (t *translator) ID() component.ID {
 // Translate creates an exporter config based on the fields in the
 // amp or prometheus section of the JSON config.
 func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if t.name == common.AppSignals {
+		return t.translateAppSignals(conf)
+	}
 	if conf == nil || !(conf.IsSet(AMPSectionKey) && conf.IsSet(common.ConfigKey(AMPSectionKey, common.WorkspaceIDKey))) {
 		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: AMPSectionKey + " or " + common.ConfigKey(AMPSectionKey, common.WorkspaceIDKey)}
 	}
@@ -53,3 +63,58 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	cfg.ClientConfig.Endpoint = ampEndpoint
 	return cfg, nil
 }
+
+// AppSignalsSectionKey returns the key of the prometheus_remote_write destination of the App Signals metrics, if it
+// is set.
+func AppSignalsSectionKey(conf *confmap.Conf) (string, bool) {
+	if conf == nil {
+		return "", false
+	}
+	for _, key := range common.AppSignalsConfigKeys[pipeline.SignalMetrics] {
+		sectionKey := common.ConfigKey(key, common.AppSignalsDestinations, common.PrometheusRemoteWriteKey)
+		if conf.IsSet(sectionKey) {
+			return sectionKey, true
+		}
+	}
+	return "", false
+}
+
+// IsAppSignalsSigV4 returns true if the requests to the prometheus_remote_write destination of the App Signals
+// metrics are signed with SigV4, e.g. for Amazon Managed Service for Prometheus.
+func IsAppSignalsSigV4(conf *confmap.Conf) bool {
+	sectionKey, ok := AppSignalsSectionKey(conf)
+	if !ok {
+		return false
+	}
+	enabled, _ := common.GetBool(conf, common.ConfigKey(sectionKey, sigV4Key))
+	return enabled
+}
+
+// translateAppSignals creates an exporter config from the prometheus_remote_write destination of the App Signals
+// metrics.
+func (t *translator) translateAppSignals(conf *confmap.Conf) (component.Config, error) {
+	sectionKey, ok := AppSignalsSectionKey(conf)
+	endpoint, _ := common.GetString(conf, common.ConfigKey(sectionKey, common.Endpoint))
+	if !ok || endpoint == "" {
+		return nil, &common.MissingKeyError{
+			ID:      t.ID(),
+			JsonKey: common.ConfigKey(common.AppSignalsMetrics, common.AppSignalsDestinations, common.PrometheusRemoteWriteKey, common.Endpoint),
+		}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*prometheusremotewriteexporter.Config)
+	cfg.ClientConfig.Endpoint = endpoint
+	if IsAppSignalsSigV4(conf) {
+		cfg.ClientConfig.Auth = &configauth.Authentication{AuthenticatorID: component.NewID(component.MustNewType(common.SigV4Auth))}
+	}
+	if headers, ok := conf.Get(common.ConfigKey(sectionKey, headersKey)).(map[string]interface{}); ok && len(headers) > 0 {
+		cfg.ClientConfig.Headers = make(map[string]configopaque.String, len(headers))
+		for name, value := range headers {
+			if value, ok := value.(string); ok {
+				cfg.ClientConfig.Headers[name] = configopaque.String(value)
+			}
+		}
+	}
+	// the resource attributes, e.g. the ones of the hosting platform, are labels of the series
+	cfg.ResourceToTelemetrySettings = resourcetotelemetry.Settings{Enabled: true, ClearAfterCopy: true}
+	return cfg, nil
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/testutil"
@@ -66,3 +69,98 @@ func TestTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestAppSignalsTranslator(t *testing.T) {
+	tt := NewTranslatorWithName(common.AppSignals)
+	require.EqualValues(t, "prometheusremotewrite/application_signals", tt.ID().String())
+	factory := prometheusremotewriteexporter.NewFactory()
+
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    func(cfg *prometheusremotewriteexporter.Config)
+		wantErr error
+	}{
+		"WithoutDestination": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{},
+					},
+				},
+			},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: common.ConfigKey(common.AppSignalsMetrics, "destinations", "prometheus_remote_write", "endpoint")},
+		},
+		"WithMissingEndpoint": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"destinations": map[string]interface{}{
+								"prometheus_remote_write": map[string]interface{}{},
+							},
+						},
+					},
+				},
+			},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: common.ConfigKey(common.AppSignalsMetrics, "destinations", "prometheus_remote_write", "endpoint")},
+		},
+		"WithEndpointAndHeaders": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"destinations": map[string]interface{}{
+								"prometheus_remote_write": map[string]interface{}{
+									"endpoint": "https://prometheus.example.com/api/v1/write",
+									"headers": map[string]interface{}{
+										"X-Scope-OrgID": "tenant",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: func(cfg *prometheusremotewriteexporter.Config) {
+				cfg.ClientConfig.Endpoint = "https://prometheus.example.com/api/v1/write"
+				cfg.ClientConfig.Headers = map[string]configopaque.String{"X-Scope-OrgID": "tenant"}
+			},
+		},
+		"WithSigV4AndAppSignalsKey": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"app_signals": map[string]interface{}{
+							"destinations": map[string]interface{}{
+								"prometheus_remote_write": map[string]interface{}{
+									"endpoint": "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-12345/api/v1/remote_write",
+									"sigv4":    true,
+								},
+							},
+						},
+					},
+				},
+			},
+			want: func(cfg *prometheusremotewriteexporter.Config) {
+				cfg.ClientConfig.Endpoint = "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-12345/api/v1/remote_write"
+				cfg.ClientConfig.Auth = &configauth.Authentication{AuthenticatorID: component.MustNewID(common.SigV4Auth)}
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if err == nil {
+				require.NotNil(t, got)
+				wantCfg := factory.CreateDefaultConfig().(*prometheusremotewriteexporter.Config)
+				wantCfg.ResourceToTelemetrySettings.Enabled = true
+				wantCfg.ResourceToTelemetrySettings.ClearAfterCopy = true
+				testCase.want(wantCfg)
+				assert.Equal(t, wantCfg, got)
+				assert.NoError(t, component.ValidateConfig(got))
+			}
+		})
+	}
+}
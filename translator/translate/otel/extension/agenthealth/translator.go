@@ -15,6 +15,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 	translateagent "github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/faultinjection"
 )
 
 const (
@@ -25,6 +26,13 @@ const (
 	usageDataKey = "usage_data"
 )
 
+// attributionSections are the sections of the signals whose attribution overrides the one of the agent section.
+var attributionSections = map[string]string{
+	pipeline.SignalMetrics.String(): common.MetricsKey,
+	pipeline.SignalLogs.String():    common.LogsKey,
+	pipeline.SignalTraces.String():  common.TracesKey,
+}
+
 var (
 	MetricsID    = component.NewIDWithName(agenthealth.TypeStr, pipeline.SignalMetrics.String())
 	LogsID       = component.NewIDWithName(agenthealth.TypeStr, pipeline.SignalLogs.String())
@@ -82,6 +90,8 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		cfg.IsUsageDataEnabled = cfg.IsUsageDataEnabled && usageData
 	}
 	cfg.IsStatusCodeEnabled = t.isStatusCodeEnabled
+	cfg.UserAgentAttribution = t.attribution(conf)
+	cfg.IsFaultInjectionEnabled = conf.IsSet(faultinjection.FaultInjectionKey)
 	cfg.Stats = &agent.StatsConfig{
 		Operations: t.operations,
 		UsageFlags: map[agent.Flag]any{
@@ -91,3 +101,14 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	}
 	return cfg, nil
 }
+
+// attribution returns the attribution of the section of the signal, or of the agent section if it does not have one.
+func (t *translator) attribution(conf *confmap.Conf) string {
+	if section, ok := attributionSections[t.name]; ok {
+		if attribution, ok := common.GetString(conf, common.ConfigKey(section, translateagent.UserAgentAttributionKey)); ok && attribution != "" {
+			return attribution
+		}
+	}
+	attribution, _ := common.GetString(conf, common.ConfigKey(common.AgentKey, translateagent.UserAgentAttributionKey))
+	return attribution
+}
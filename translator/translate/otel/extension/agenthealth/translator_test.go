@@ -86,3 +86,66 @@ func TestTranslate(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateUserAgentAttribution(t *testing.T) {
+	testCases := map[string]struct {
+		name  Name
+		input map[string]interface{}
+		want  string
+	}{
+		"WithoutAttribution": {
+			name:  LogsName,
+			input: map[string]interface{}{"agent": map[string]interface{}{}},
+		},
+		"WithAgentAttribution": {
+			name:  MetricsName,
+			input: map[string]interface{}{"agent": map[string]interface{}{"user_agent_attribution": "team-a"}},
+			want:  "team-a",
+		},
+		"WithSignalAttribution": {
+			name: TracesName,
+			input: map[string]interface{}{
+				"agent":  map[string]interface{}{"user_agent_attribution": "team-a"},
+				"traces": map[string]interface{}{"user_agent_attribution": "team-b"},
+			},
+			want: "team-b",
+		},
+		"WithOtherSignalAttribution": {
+			name: LogsName,
+			input: map[string]interface{}{
+				"agent":   map[string]interface{}{"user_agent_attribution": "team-a"},
+				"metrics": map[string]interface{}{"user_agent_attribution": "team-b"},
+			},
+			want: "team-a",
+		},
+		"StatusCode": {
+			name: StatusCodeName,
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{"user_agent_attribution": "team-a"},
+				"logs":  map[string]interface{}{"user_agent_attribution": "team-b"},
+			},
+			want: "team-a",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator(testCase.name, nil)
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got.(*agenthealth.Config).UserAgentAttribution)
+		})
+	}
+}
+
+func TestTranslateFaultInjection(t *testing.T) {
+	tt := NewTranslator(LogsName, nil)
+	got, err := tt.Translate(confmap.NewFromStringMap(map[string]interface{}{"agent": map[string]interface{}{}}))
+	assert.NoError(t, err)
+	assert.False(t, got.(*agenthealth.Config).IsFaultInjectionEnabled)
+
+	got, err = tt.Translate(confmap.NewFromStringMap(map[string]interface{}{
+		"agent": map[string]interface{}{"fault_injection": map[string]interface{}{}},
+	}))
+	assert.NoError(t, err)
+	assert.True(t, got.(*agenthealth.Config).IsFaultInjectionEnabled)
+}
@@ -51,7 +51,7 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.TracesKey}
 	}
 	cfg := t.factory.CreateDefaultConfig().(*awsproxy.Config)
-	cfg.ProxyConfig.Endpoint = defaultEndpoint
+	cfg.ProxyConfig.Endpoint = agent.ListenAddress(defaultEndpoint)
 	cfg.ProxyConfig.CertificateFilePath = os.Getenv(envconfig.AWS_CA_BUNDLE)
 	if conf.IsSet(endpointOverrideKey) {
 		cfg.ProxyConfig.AWSEndpoint, _ = common.GetString(conf, endpointOverrideKey)
@@ -67,22 +67,17 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		cfg.ProxyConfig.Profile = fmt.Sprintf("%v", profileKey)
 	}
 	cfg.ProxyConfig.Region = getRegion(conf)
-	cfg.ProxyConfig.RoleARN = getRoleARN(conf)
+	roleARN, err := common.GetRoleARN(conf, common.TracesKey)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ProxyConfig.RoleARN = roleARN
 	if credentialsFileKey, ok := agent.Global_Config.Credentials[agent.CredentialsFile_Key]; ok {
 		cfg.ProxyConfig.SharedCredentialsFile = []string{fmt.Sprintf("%v", credentialsFileKey)}
 	}
 	return cfg, nil
 }
 
-func getRoleARN(conf *confmap.Conf) string {
-	key := common.ConfigKey(common.TracesKey, common.CredentialsKey, common.RoleARNKey)
-	roleARN, ok := common.GetString(conf, key)
-	if !ok {
-		roleARN = agent.Global_Config.Role_arn
-	}
-	return roleARN
-}
-
 func getRegion(conf *confmap.Conf) string {
 	key := common.ConfigKey(common.TracesKey, common.RegionOverrideKey)
 	region, ok := common.GetString(conf, key)
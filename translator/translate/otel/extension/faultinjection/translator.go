@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinjection
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/faultinjection"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	maxLatencyKey  = "max_latency"
+	maxDurationKey = "max_duration"
+)
+
+// FaultInjectionKey lets faults be injected into the AWS API calls of the agent through the control socket. It is
+// meant for testing, and is not set by default.
+var FaultInjectionKey = common.ConfigKey(common.AgentKey, "fault_injection")
+
+type translator struct {
+	factory extension.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: faultinjection.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewID(t.factory.Type())
+}
+
+// Translate creates the faultinjection configuration from the limits of the faults, in seconds.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(FaultInjectionKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: FaultInjectionKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*faultinjection.Config)
+	if maxLatency, ok := common.GetDuration(conf, common.ConfigKey(FaultInjectionKey, maxLatencyKey)); ok {
+		cfg.MaxLatency = maxLatency
+	}
+	if maxDuration, ok := common.GetDuration(conf, common.ConfigKey(FaultInjectionKey, maxDurationKey)); ok {
+		cfg.MaxDuration = maxDuration
+	}
+	return cfg, cfg.Validate()
+}
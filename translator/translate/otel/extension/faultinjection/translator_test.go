@@ -0,0 +1,59 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package faultinjection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/faultinjection"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *faultinjection.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input: map[string]interface{}{"agent": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{
+				ID:      NewTranslator().ID(),
+				JsonKey: FaultInjectionKey,
+			},
+		},
+		"WithDefaults": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"fault_injection": map[string]interface{}{}}},
+			want: &faultinjection.Config{
+				MaxLatency:  time.Minute,
+				MaxDuration: time.Hour,
+			},
+		},
+		"WithLimits": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"fault_injection": map[string]interface{}{
+				"max_latency":  5,
+				"max_duration": 600,
+			}}},
+			want: &faultinjection.Config{
+				MaxLatency:  5 * time.Second,
+				MaxDuration: 10 * time.Minute,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "faultinjection", tt.ID().String())
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package featureflags
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/featureflags"
+	"github.com/aws/amazon-cloudwatch-agent/internal/featureflag"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	flagsKey           = "flags"
+	ssmParameterKey    = "ssm_parameter"
+	refreshIntervalKey = "refresh_interval"
+	enabledKey         = "enabled"
+	rolloutPercentKey  = "rollout_percent"
+)
+
+// FeatureFlagsKey sets the feature flags of the agent.
+var FeatureFlagsKey = common.ConfigKey(common.AgentKey, "feature_flags")
+
+type translator struct {
+	factory extension.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: featureflags.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewID(t.factory.Type())
+}
+
+// Translate creates the featureflags configuration. Each flag is either a boolean or an object with the enabled and
+// rollout_percent fields, and the SSM parameter is read with the agent region and credentials.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(FeatureFlagsKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: FeatureFlagsKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*featureflags.Config)
+	if flags, ok := conf.Get(common.ConfigKey(FeatureFlagsKey, flagsKey)).(map[string]interface{}); ok {
+		cfg.Flags = make(map[string]featureflag.Setting, len(flags))
+		for name, value := range flags {
+			setting, err := toSetting(value)
+			if err != nil {
+				return nil, fmt.Errorf("feature flag %s: %w", name, err)
+			}
+			cfg.Flags[name] = setting
+		}
+	}
+	cfg.SSMParameter, _ = common.GetString(conf, common.ConfigKey(FeatureFlagsKey, ssmParameterKey))
+	if refreshInterval, ok := common.GetDuration(conf, common.ConfigKey(FeatureFlagsKey, refreshIntervalKey)); ok {
+		cfg.RefreshInterval = refreshInterval
+	}
+	cfg.CachePath = paths.FeatureFlagsPath
+	cfg.Region = agent.Global_Config.Region
+	credentials := confmap.NewFromStringMap(agent.Global_Config.Credentials)
+	_ = credentials.Unmarshal(cfg)
+	return cfg, cfg.Validate()
+}
+
+func toSetting(value interface{}) (featureflag.Setting, error) {
+	switch v := value.(type) {
+	case bool:
+		return featureflag.Setting{Enabled: v}, nil
+	case map[string]interface{}:
+		var setting featureflag.Setting
+		if enabled, ok := v[enabledKey].(bool); ok {
+			setting.Enabled = enabled
+		}
+		switch percent := v[rolloutPercentKey].(type) {
+		case nil:
+		case float64:
+			setting.RolloutPercent = &percent
+		case int:
+			p := float64(percent)
+			setting.RolloutPercent = &p
+		default:
+			return setting, fmt.Errorf("invalid rollout_percent %v", percent)
+		}
+		return setting, nil
+	}
+	return featureflag.Setting{}, fmt.Errorf("invalid setting %v", value)
+}
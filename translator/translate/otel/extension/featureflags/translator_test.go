@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package featureflags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/featureflags"
+	"github.com/aws/amazon-cloudwatch-agent/internal/featureflag"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	agent.Global_Config.Region = "us-west-2"
+	agent.Global_Config.Credentials = map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/fleet"}
+	t.Cleanup(func() {
+		agent.Global_Config.Region = ""
+		agent.Global_Config.Credentials = nil
+	})
+	percent := 10.0
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *featureflags.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input: map[string]interface{}{"agent": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{
+				ID:      NewTranslator().ID(),
+				JsonKey: FeatureFlagsKey,
+			},
+		},
+		"WithFlags": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"feature_flags": map[string]interface{}{
+				"flags": map[string]interface{}{
+					"request_compression": false,
+					"other":               map[string]interface{}{"enabled": true, "rollout_percent": 10},
+				},
+			}}},
+			want: &featureflags.Config{
+				Flags: map[string]featureflag.Setting{
+					"request_compression": {Enabled: false},
+					"other":               {Enabled: true, RolloutPercent: &percent},
+				},
+				RefreshInterval: 5 * time.Minute,
+				CachePath:       paths.FeatureFlagsPath,
+				Region:          "us-west-2",
+				RoleARN:         "arn:aws:iam::123456789012:role/fleet",
+			},
+		},
+		"WithSSMParameter": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"feature_flags": map[string]interface{}{
+				"ssm_parameter":    "/cwagent/flags",
+				"refresh_interval": 60,
+			}}},
+			want: &featureflags.Config{
+				SSMParameter:    "/cwagent/flags",
+				RefreshInterval: time.Minute,
+				CachePath:       paths.FeatureFlagsPath,
+				Region:          "us-west-2",
+				RoleARN:         "arn:aws:iam::123456789012:role/fleet",
+			},
+		},
+		"WithInvalidFlag": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"feature_flags": map[string]interface{}{
+				"flags": map[string]interface{}{"request_compression": "yes"},
+			}}},
+			wantErr: assert.AnError,
+		},
+		"WithInvalidRollout": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"feature_flags": map[string]interface{}{
+				"flags": map[string]interface{}{"other": map[string]interface{}{"enabled": true, "rollout_percent": 200}},
+			}}},
+			wantErr: assert.AnError,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "featureflags", tt.ID().String())
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			if testCase.wantErr == assert.AnError {
+				assert.Error(t, err)
+				return
+			}
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
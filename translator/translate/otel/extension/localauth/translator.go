@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package localauth
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/localauth"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const rotationIntervalKey = "rotation_interval"
+
+// LocalAuthKey requires the clients of the local endpoints to present the shared secret of the agent.
+var LocalAuthKey = common.ConfigKey(common.AgentKey, "local_auth")
+
+type translator struct {
+	factory extension.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: localauth.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewID(t.factory.Type())
+}
+
+// Translate creates the localauth configuration. The secret is written to the state directory of the agent.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(LocalAuthKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: LocalAuthKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*localauth.Config)
+	cfg.SecretPath = paths.LocalAuthSecretPath
+	if rotationInterval, ok := common.GetDuration(conf, common.ConfigKey(LocalAuthKey, rotationIntervalKey)); ok {
+		cfg.RotationInterval = rotationInterval
+	}
+	return cfg, cfg.Validate()
+}
+
+// Authentication returns the authenticator of the local endpoints, or nil if local_auth is not set.
+func Authentication(conf *confmap.Conf) *configauth.Authentication {
+	if conf == nil || !conf.IsSet(LocalAuthKey) {
+		return nil
+	}
+	return &configauth.Authentication{AuthenticatorID: component.NewID(localauth.TypeStr)}
+}
@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package localauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/localauth"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *localauth.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input: map[string]interface{}{"agent": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{
+				ID:      NewTranslator().ID(),
+				JsonKey: LocalAuthKey,
+			},
+		},
+		"WithDefaults": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"local_auth": map[string]interface{}{}}},
+			want: &localauth.Config{
+				SecretPath:       paths.LocalAuthSecretPath,
+				RotationInterval: time.Hour,
+			},
+		},
+		"WithRotationInterval": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"local_auth": map[string]interface{}{
+				"rotation_interval": 600,
+			}}},
+			want: &localauth.Config{
+				SecretPath:       paths.LocalAuthSecretPath,
+				RotationInterval: 10 * time.Minute,
+			},
+		},
+		"WithShortRotationInterval": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"local_auth": map[string]interface{}{
+				"rotation_interval": 10,
+			}}},
+			wantErr: assert.AnError,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "localauth", tt.ID().String())
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			if testCase.wantErr == assert.AnError {
+				assert.Error(t, err)
+				return
+			}
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	assert.Nil(t, Authentication(confmap.NewFromStringMap(map[string]interface{}{"agent": map[string]interface{}{}})))
+	got := Authentication(confmap.NewFromStringMap(map[string]interface{}{"agent": map[string]interface{}{"local_auth": map[string]interface{}{}}}))
+	assert.Equal(t, &configauth.Authentication{AuthenticatorID: NewTranslator().ID()}, got)
+}
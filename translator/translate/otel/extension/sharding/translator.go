@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package sharding
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/sharding"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	peersKey           = "peers"
+	replicasKey        = "replicas"
+	replicaKey         = "replica"
+	refreshIntervalKey = "refresh_interval"
+	labelsKey          = "labels"
+)
+
+// ShardingKey splits the targets of the prometheus input between the replicas of a deployment.
+var ShardingKey = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.PrometheusKey, "sharding")
+
+type translator struct {
+	factory extension.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: sharding.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewID(t.factory.Type())
+}
+
+// Translate creates the sharding configuration.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(ShardingKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: ShardingKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*sharding.Config)
+	cfg.Peers, _ = common.GetString(conf, common.ConfigKey(ShardingKey, peersKey))
+	cfg.Replicas = common.GetArray[string](conf, common.ConfigKey(ShardingKey, replicasKey))
+	cfg.Replica, _ = common.GetString(conf, common.ConfigKey(ShardingKey, replicaKey))
+	if refreshInterval, ok := common.GetDuration(conf, common.ConfigKey(ShardingKey, refreshIntervalKey)); ok {
+		cfg.RefreshInterval = refreshInterval
+	}
+	if labels := common.GetArray[string](conf, common.ConfigKey(ShardingKey, labelsKey)); len(labels) > 0 {
+		cfg.Labels = labels
+	}
+	return cfg, cfg.Validate()
+}
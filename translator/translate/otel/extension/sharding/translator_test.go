@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package sharding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/sharding"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	prometheusSection := func(section map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"logs": map[string]interface{}{"metrics_collected": map[string]interface{}{
+			"prometheus": map[string]interface{}{"sharding": section},
+		}}}
+	}
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *sharding.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input: map[string]interface{}{"logs": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{
+				ID:      NewTranslator().ID(),
+				JsonKey: ShardingKey,
+			},
+		},
+		"WithPeers": {
+			input: prometheusSection(map[string]interface{}{"peers": "cwagent-headless.amazon-cloudwatch.svc"}),
+			want: &sharding.Config{
+				Peers:           "cwagent-headless.amazon-cloudwatch.svc",
+				RefreshInterval: 30 * time.Second,
+				Labels:          []string{"__address__"},
+			},
+		},
+		"WithReplicas": {
+			input: prometheusSection(map[string]interface{}{
+				"replicas":         []interface{}{"gateway-a", "gateway-b"},
+				"replica":          "gateway-a",
+				"refresh_interval": 60,
+				"labels":           []interface{}{"__address__", "__metrics_path__"},
+			}),
+			want: &sharding.Config{
+				Replicas:        []string{"gateway-a", "gateway-b"},
+				Replica:         "gateway-a",
+				RefreshInterval: time.Minute,
+				Labels:          []string{"__address__", "__metrics_path__"},
+			},
+		},
+		"WithNeither": {
+			input:   prometheusSection(map[string]interface{}{}),
+			wantErr: assert.AnError,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "sharding", tt.ID().String())
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			if testCase.wantErr == assert.AnError {
+				assert.Error(t, err)
+				return
+			}
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package standby
+
+import (
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/standby"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	lockKey          = "lock"
+	ownerKey         = "owner"
+	leaseDurationKey = "lease_duration"
+	renewIntervalKey = "renew_interval"
+	sourcesKey       = "sources"
+)
+
+// StandbyKey enables the active/standby mode of the agent.
+var StandbyKey = common.ConfigKey(common.AgentKey, "standby")
+
+type translator struct {
+	factory extension.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: standby.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewID(t.factory.Type())
+}
+
+// Translate creates the standby configuration. The owner defaults to the hostname and the lock
+// uses the agent region and credentials when it is an SSM parameter.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(StandbyKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: StandbyKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*standby.Config)
+	cfg.Lock, _ = common.GetString(conf, common.ConfigKey(StandbyKey, lockKey))
+	if owner, ok := common.GetString(conf, common.ConfigKey(StandbyKey, ownerKey)); ok {
+		cfg.Owner = owner
+	} else {
+		cfg.Owner, _ = os.Hostname()
+	}
+	if leaseDuration, ok := common.GetDuration(conf, common.ConfigKey(StandbyKey, leaseDurationKey)); ok {
+		cfg.LeaseDuration = leaseDuration
+	}
+	if renewInterval, ok := common.GetDuration(conf, common.ConfigKey(StandbyKey, renewIntervalKey)); ok {
+		cfg.RenewInterval = renewInterval
+	}
+	if sources := common.GetArray[string](conf, common.ConfigKey(StandbyKey, sourcesKey)); len(sources) > 0 {
+		cfg.Sources = sources
+	}
+	cfg.Region = agent.Global_Config.Region
+	credentials := confmap.NewFromStringMap(agent.Global_Config.Credentials)
+	_ = credentials.Unmarshal(cfg)
+	return cfg, cfg.Validate()
+}
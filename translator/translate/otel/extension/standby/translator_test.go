@@ -0,0 +1,91 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package standby
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/standby"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	agent.Global_Config.Region = "us-west-2"
+	agent.Global_Config.Credentials = map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/gateway"}
+	t.Cleanup(func() {
+		agent.Global_Config.Region = ""
+		agent.Global_Config.Credentials = nil
+	})
+	hostname, _ := os.Hostname()
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *standby.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input: map[string]interface{}{"agent": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{
+				ID:      NewTranslator().ID(),
+				JsonKey: StandbyKey,
+			},
+		},
+		"WithDefault": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"standby": map[string]interface{}{
+				"lock": "/mnt/shared/cwagent.lease",
+			}}},
+			want: &standby.Config{
+				Lock:          "/mnt/shared/cwagent.lease",
+				Owner:         hostname,
+				LeaseDuration: 30 * time.Second,
+				RenewInterval: 10 * time.Second,
+				Sources:       []string{"input:statsd*", "input:socket_listener*"},
+				Region:        "us-west-2",
+				RoleARN:       "arn:aws:iam::123456789012:role/gateway",
+			},
+		},
+		"WithCustom": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"standby": map[string]interface{}{
+				"lock":           "ssm:/cwagent/gateway",
+				"owner":          "gateway-a",
+				"lease_duration": 60,
+				"renew_interval": 20,
+				"sources":        []interface{}{"input:statsd"},
+			}}},
+			want: &standby.Config{
+				Lock:          "ssm:/cwagent/gateway",
+				Owner:         "gateway-a",
+				LeaseDuration: time.Minute,
+				RenewInterval: 20 * time.Second,
+				Sources:       []string{"input:statsd"},
+				Region:        "us-west-2",
+				RoleARN:       "arn:aws:iam::123456789012:role/gateway",
+			},
+		},
+		"WithMissingLock": {
+			input:   map[string]interface{}{"agent": map[string]interface{}{"standby": map[string]interface{}{}}},
+			wantErr: assert.AnError,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "standby", tt.ID().String())
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			if testCase.wantErr == assert.AnError {
+				assert.Error(t, err)
+				return
+			}
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package xraysampling
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/xraysampling"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	bindAddressKey         = "bind_address"
+	internalBindAddressKey = "internal_bind_address"
+	maxRecentDecisionsKey  = "max_recent_decisions"
+
+	defaultEndpoint         = "127.0.0.1:2000"
+	defaultInternalEndpoint = "127.0.0.1:2001"
+)
+
+var (
+	tcpProxyKey = common.ConfigKey(common.TracesKey, common.TracesCollectedKey, common.XrayKey, "tcp_proxy")
+	// SamplingDebugKey enables the sampling inspector in front of the X-Ray TCP proxy.
+	SamplingDebugKey = common.ConfigKey(tcpProxyKey, "sampling_debug")
+)
+
+type translator struct {
+	factory extension.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: xraysampling.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewID(t.factory.Type())
+}
+
+// Translate creates the sampling inspector configuration. The inspector takes over the public TCP proxy
+// address and forwards to the awsxray receiver proxy on the internal address.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(SamplingDebugKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: SamplingDebugKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*xraysampling.Config)
+	cfg.Endpoint = agent.ListenAddress(defaultEndpoint)
+	if endpoint, ok := common.GetString(conf, common.ConfigKey(tcpProxyKey, bindAddressKey)); ok {
+		cfg.Endpoint = endpoint
+	}
+	cfg.ProxyEndpoint = InternalEndpoint(conf)
+	if maxRecent, ok := common.GetNumber(conf, common.ConfigKey(SamplingDebugKey, maxRecentDecisionsKey)); ok {
+		cfg.MaxRecentDecisions = int(maxRecent)
+	}
+	return cfg, cfg.Validate()
+}
+
+// InternalEndpoint returns the address the awsxray receiver proxy listens on when the sampling inspector
+// is enabled.
+func InternalEndpoint(conf *confmap.Conf) string {
+	if endpoint, ok := common.GetString(conf, common.ConfigKey(SamplingDebugKey, internalBindAddressKey)); ok {
+		return endpoint
+	}
+	return agent.ListenAddress(defaultInternalEndpoint)
+}
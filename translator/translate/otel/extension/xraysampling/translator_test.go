@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package xraysampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/xraysampling"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *xraysampling.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input: map[string]interface{}{"traces": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{
+				ID:      NewTranslator().ID(),
+				JsonKey: SamplingDebugKey,
+			},
+		},
+		"WithDefault": {
+			input: map[string]interface{}{"traces": map[string]interface{}{"traces_collected": map[string]interface{}{"xray": map[string]interface{}{
+				"tcp_proxy": map[string]interface{}{"sampling_debug": map[string]interface{}{}},
+			}}}},
+			want: &xraysampling.Config{Endpoint: "127.0.0.1:2000", ProxyEndpoint: "127.0.0.1:2001", MaxRecentDecisions: 100},
+		},
+		"WithCustom": {
+			input: map[string]interface{}{"traces": map[string]interface{}{"traces_collected": map[string]interface{}{"xray": map[string]interface{}{
+				"tcp_proxy": map[string]interface{}{
+					"bind_address": "0.0.0.0:2000",
+					"sampling_debug": map[string]interface{}{
+						"internal_bind_address": "127.0.0.1:3000",
+						"max_recent_decisions":  10,
+					},
+				},
+			}}}},
+			want: &xraysampling.Config{Endpoint: "0.0.0.0:2000", ProxyEndpoint: "127.0.0.1:3000", MaxRecentDecisions: 10},
+		},
+		"WithSameAddress": {
+			input: map[string]interface{}{"traces": map[string]interface{}{"traces_collected": map[string]interface{}{"xray": map[string]interface{}{
+				"tcp_proxy": map[string]interface{}{
+					"bind_address":   "127.0.0.1:2001",
+					"sampling_debug": map[string]interface{}{},
+				},
+			}}}},
+			wantErr: assert.AnError,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "xraysampling", tt.ID().String())
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			if testCase.wantErr == assert.AnError {
+				assert.Error(t, err)
+				return
+			}
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
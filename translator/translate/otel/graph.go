@@ -0,0 +1,125 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otel
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/otelcol"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/mapstructure"
+)
+
+// Graph is the pipelines of a translated config, with the components they connect and a hash of the config of each
+// component. It only depends on the config, not on the order in which it was translated, so that the graphs of the
+// agents of a fleet can be diffed to find the ones whose config drifted.
+type Graph struct {
+	// Hash changes whenever a pipeline, a component or the config of a component does.
+	Hash       string           `json:"hash"`
+	Pipelines  []GraphPipeline  `json:"pipelines"`
+	Components []GraphComponent `json:"components"`
+}
+
+// GraphPipeline is a pipeline of the graph. Its receivers and exporters are sorted, since their order does not
+// matter, while its processors are in the order they process the telemetry.
+type GraphPipeline struct {
+	ID         string   `json:"id"`
+	Receivers  []string `json:"receivers"`
+	Processors []string `json:"processors"`
+	Exporters  []string `json:"exporters"`
+}
+
+// GraphComponent is a component of the graph, with the hash of its config.
+type GraphComponent struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+	Hash string `json:"hash"`
+}
+
+// NewGraph returns the pipeline graph of the config. The pipelines are sorted by ID, and the components by kind and ID.
+func NewGraph(cfg *otelcol.Config) (*Graph, error) {
+	graph := &Graph{Pipelines: []GraphPipeline{}, Components: []GraphComponent{}}
+	for id, p := range cfg.Service.Pipelines {
+		graph.Pipelines = append(graph.Pipelines, GraphPipeline{
+			ID:         id.String(),
+			Receivers:  sortedIDs(p.Receivers),
+			Processors: idStrings(p.Processors),
+			Exporters:  sortedIDs(p.Exporters),
+		})
+	}
+	sort.Slice(graph.Pipelines, func(i, j int) bool {
+		return graph.Pipelines[i].ID < graph.Pipelines[j].ID
+	})
+	for _, kind := range []struct {
+		name       string
+		components map[component.ID]component.Config
+	}{
+		{name: "exporter", components: cfg.Exporters},
+		{name: "extension", components: cfg.Extensions},
+		{name: "processor", components: cfg.Processors},
+		{name: "receiver", components: cfg.Receivers},
+	} {
+		ids := make([]component.ID, 0, len(kind.components))
+		for id := range kind.components {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i].String() < ids[j].String()
+		})
+		for _, id := range ids {
+			hash, err := configHash(kind.components[id])
+			if err != nil {
+				return nil, fmt.Errorf("unable to hash the config of %s %s: %w", kind.name, id, err)
+			}
+			graph.Components = append(graph.Components, GraphComponent{Kind: kind.name, ID: id.String(), Hash: hash})
+		}
+	}
+	hash, err := jsonHash(graph)
+	if err != nil {
+		return nil, err
+	}
+	graph.Hash = hash
+	return graph, nil
+}
+
+// configHash is the hash of the config of a component, encoded like in the YAML config.
+func configHash(cfg component.Config) (string, error) {
+	if cfg == nil {
+		return jsonHash(nil)
+	}
+	m, err := mapstructure.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return jsonHash(m)
+}
+
+// jsonHash is the SHA-256 of the value encoded in JSON, whose objects have their keys sorted.
+func jsonHash(value any) (string, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func idStrings(ids []component.ID) []string {
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s = append(s, id.String())
+	}
+	return s
+}
+
+func sortedIDs(ids []component.ID) []string {
+	s := idStrings(ids)
+	sort.Strings(s)
+	return s
+}
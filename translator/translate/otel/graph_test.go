@@ -0,0 +1,123 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/pipelines"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+)
+
+type testComponentConfig struct {
+	Value string `mapstructure:"value"`
+}
+
+func TestNewGraph(t *testing.T) {
+	cpu := component.MustNewID("telegraf_cpu")
+	mem := component.MustNewID("telegraf_mem")
+	batch := component.MustNewID("batch")
+	filter := component.MustNewID("filter")
+	cloudwatch := component.MustNewID("awscloudwatch")
+	agenthealth := component.MustNewIDWithName("agenthealth", "metrics")
+	newConfig := func(cpuValue string) *otelcol.Config {
+		return &otelcol.Config{
+			Receivers: map[component.ID]component.Config{
+				cpu: &testComponentConfig{Value: cpuValue},
+				mem: &testComponentConfig{Value: "mem"},
+			},
+			Processors: map[component.ID]component.Config{
+				batch:  &testComponentConfig{Value: "batch"},
+				filter: &testComponentConfig{Value: "filter"},
+			},
+			Exporters:  map[component.ID]component.Config{cloudwatch: &testComponentConfig{Value: "cloudwatch"}},
+			Extensions: map[component.ID]component.Config{agenthealth: nil},
+			Service: service.Config{
+				Extensions: []component.ID{agenthealth},
+				Pipelines: pipelines.Config{
+					pipeline.NewIDWithName(pipeline.SignalMetrics, "host"): {
+						Receivers:  []component.ID{mem, cpu},
+						Processors: []component.ID{filter, batch},
+						Exporters:  []component.ID{cloudwatch},
+					},
+					pipeline.NewIDWithName(pipeline.SignalMetrics, "cpu"): {
+						Receivers: []component.ID{cpu},
+						Exporters: []component.ID{cloudwatch},
+					},
+				},
+			},
+		}
+	}
+
+	graph, err := NewGraph(newConfig("cpu"))
+	require.NoError(t, err)
+	assert.Equal(t, []GraphPipeline{
+		{ID: "metrics/cpu", Receivers: []string{"telegraf_cpu"}, Processors: []string{}, Exporters: []string{"awscloudwatch"}},
+		{ID: "metrics/host", Receivers: []string{"telegraf_cpu", "telegraf_mem"}, Processors: []string{"filter", "batch"}, Exporters: []string{"awscloudwatch"}},
+	}, graph.Pipelines)
+	var ids []string
+	for _, c := range graph.Components {
+		ids = append(ids, c.Kind+":"+c.ID)
+		assert.Len(t, c.Hash, 64)
+	}
+	assert.Equal(t, []string{
+		"exporter:awscloudwatch",
+		"extension:agenthealth/metrics",
+		"processor:batch",
+		"processor:filter",
+		"receiver:telegraf_cpu",
+		"receiver:telegraf_mem",
+	}, ids)
+
+	for i := 0; i < 10; i++ {
+		again, err := NewGraph(newConfig("cpu"))
+		require.NoError(t, err)
+		assert.Equal(t, graph, again)
+	}
+
+	changed, err := NewGraph(newConfig("changed"))
+	require.NoError(t, err)
+	assert.NotEqual(t, graph.Hash, changed.Hash)
+	assert.NotEqual(t, graph.Components[4].Hash, changed.Components[4].Hash, "the hash of the changed receiver changes")
+	assert.Equal(t, graph.Components[5].Hash, changed.Components[5].Hash, "the hash of the other receivers does not")
+}
+
+func TestTranslateIsDeterministic(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	input := map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu":       map[string]interface{}{"measurement": []interface{}{"usage_idle"}},
+				"disk":      map[string]interface{}{"measurement": []interface{}{"used_percent"}},
+				"diskio":    map[string]interface{}{"measurement": []interface{}{"reads"}},
+				"mem":       map[string]interface{}{"measurement": []interface{}{"used_percent"}},
+				"net":       map[string]interface{}{"measurement": []interface{}{"bytes_sent"}},
+				"netstat":   map[string]interface{}{"measurement": []interface{}{"tcp_established"}},
+				"processes": map[string]interface{}{"measurement": []interface{}{"running"}},
+				"swap":      map[string]interface{}{"measurement": []interface{}{"used_percent"}},
+				"statsd":    map[string]interface{}{},
+			},
+		},
+	}
+	cfg, err := Translate(input, "linux")
+	require.NoError(t, err)
+	wantPipelines := cfg.Service.Pipelines
+	want, err := NewGraph(cfg)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		cfg, err = Translate(input, "linux")
+		require.NoError(t, err)
+		assert.Equal(t, wantPipelines, cfg.Service.Pipelines)
+		got, err := NewGraph(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
@@ -14,12 +14,16 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awsemf"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awsxray"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/debug"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/prometheusremotewrite"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/awsproxy"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/sigv4auth"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/awsapplicationsignals"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/awsentity"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/deltatocumulativeprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/metricstransformprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/resourcedetection"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/transformprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/otlp"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util/ecsutil"
 )
@@ -86,3 +90,46 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 	}
 	return translators, nil
 }
+
+type prometheusTranslator struct{}
+
+var _ common.PipelineTranslator = (*prometheusTranslator)(nil)
+
+// NewPrometheusTranslator creates the pipeline sending the App Signals metrics to the prometheus_remote_write
+// destination, in addition to the CloudWatch pipeline. It shares the receiver of the CloudWatch pipeline.
+func NewPrometheusTranslator() common.PipelineTranslator {
+	return &prometheusTranslator{}
+}
+
+func (t *prometheusTranslator) ID() pipeline.ID {
+	return pipeline.NewIDWithName(pipeline.SignalMetrics, common.AppSignalsPrometheus)
+}
+
+func (t *prometheusTranslator) Translate(conf *confmap.Conf) (*common.ComponentTranslators, error) {
+	if conf == nil {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.AppSignalsMetrics}
+	}
+	if _, ok := prometheusremotewrite.AppSignalsSectionKey(conf); !ok {
+		return nil, &common.MissingKeyError{
+			ID:      t.ID(),
+			JsonKey: common.ConfigKey(common.AppSignalsMetrics, common.AppSignalsDestinations, common.PrometheusRemoteWriteKey),
+		}
+	}
+	translators := &common.ComponentTranslators{
+		Receivers: common.NewTranslatorMap(otlp.NewTranslator(common.WithName(common.AppSignals), otlp.WithSignal(pipeline.SignalMetrics))),
+		Processors: common.NewTranslatorMap(
+			metricstransformprocessor.NewTranslatorWithName(common.AppSignals),
+			resourcedetection.NewTranslator(resourcedetection.WithSignal(pipeline.SignalMetrics)),
+			awsapplicationsignals.NewTranslator(awsapplicationsignals.WithSignal(pipeline.SignalMetrics)),
+			// prometheusremotewrite doesn't support delta metrics so convert them to cumulative metrics
+			deltatocumulativeprocessor.NewTranslator(common.WithName(common.AppSignalsPrometheus)),
+			transformprocessor.NewTranslatorWithName(common.AppSignalsPrometheus),
+		),
+		Exporters:  common.NewTranslatorMap(prometheusremotewrite.NewTranslatorWithName(common.AppSignals)),
+		Extensions: common.NewTranslatorMap[component.Config, component.ID](),
+	}
+	if prometheusremotewrite.IsAppSignalsSigV4(conf) {
+		translators.Extensions.Set(sigv4auth.NewTranslator())
+	}
+	return translators, nil
+}
@@ -351,3 +351,89 @@ func TestTranslatorMetricsForECS(t *testing.T) {
 		})
 	}
 }
+
+func TestPrometheusTranslator(t *testing.T) {
+	type want struct {
+		receivers  []string
+		processors []string
+		exporters  []string
+		extensions []string
+	}
+	tt := NewPrometheusTranslator()
+	assert.EqualValues(t, "metrics/application_signals_prometheus", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *want
+		wantErr error
+	}{
+		"WithoutDestinations": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{},
+					},
+				},
+			},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: common.ConfigKey(common.AppSignalsMetrics, common.AppSignalsDestinations, common.PrometheusRemoteWriteKey)},
+		},
+		"WithPrometheusRemoteWrite": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"destinations": map[string]interface{}{
+								"prometheus_remote_write": map[string]interface{}{
+									"endpoint": "https://prometheus.example.com/api/v1/write",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &want{
+				receivers:  []string{"otlp/application_signals"},
+				processors: []string{"metricstransform/application_signals", "resourcedetection", "awsapplicationsignals", "deltatocumulative/application_signals_prometheus", "transform/application_signals_prometheus"},
+				exporters:  []string{"prometheusremotewrite/application_signals"},
+				extensions: []string{},
+			},
+		},
+		"WithSigV4": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"app_signals": map[string]interface{}{
+							"destinations": map[string]interface{}{
+								"prometheus_remote_write": map[string]interface{}{
+									"endpoint": "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-12345/api/v1/remote_write",
+									"sigv4":    true,
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &want{
+				receivers:  []string{"otlp/application_signals"},
+				processors: []string{"metricstransform/application_signals", "resourcedetection", "awsapplicationsignals", "deltatocumulative/application_signals_prometheus", "transform/application_signals_prometheus"},
+				exporters:  []string{"prometheusremotewrite/application_signals"},
+				extensions: []string{"sigv4auth"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want == nil {
+				assert.Nil(t, got)
+			} else {
+				require.NotNil(t, got)
+				assert.Equal(t, testCase.want.receivers, collections.MapSlice(got.Receivers.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.processors, collections.MapSlice(got.Processors.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.exporters, collections.MapSlice(got.Exporters.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.extensions, collections.MapSlice(got.Extensions.Keys(), component.ID.String))
+			}
+		})
+	}
+}
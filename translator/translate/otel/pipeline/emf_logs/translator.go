@@ -14,6 +14,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awscloudwatchlogs"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/batchprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/events"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/tcplog"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/udplog"
 )
@@ -38,9 +39,9 @@ func (t *translator) ID() pipeline.ID {
 }
 
 // Translate creates a pipeline for emf if emf logs are collected
-// section is present.
+// section is present. The events posted by applications share it.
 func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators, error) {
-	if conf == nil || !(conf.IsSet(emfKey) || conf.IsSet(structuredLogKey)) {
+	if conf == nil || !(conf.IsSet(emfKey) || conf.IsSet(structuredLogKey) || conf.IsSet(events.BaseKey)) {
 		// Using EMF since EMF is recommended with public document
 		// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch_Embedded_Metric_Format_Generation_CloudWatch_Agent.html#CloudWatch_Embedded_Metric_Format_Generation_Install_Agent
 		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: emfKey}
@@ -53,6 +54,12 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 			agenthealth.NewTranslatorWithStatusCode(agenthealth.StatusCodeName, nil, true),
 		),
 	}
+	if conf.IsSet(events.BaseKey) {
+		translators.Receivers.Set(events.NewTranslatorWithName(common.PipelineNameEmfLogs))
+	}
+	if !conf.IsSet(emfKey) && !conf.IsSet(structuredLogKey) {
+		return &translators, nil
+	}
 	if serviceAddress, ok := common.GetString(conf, serviceAddressEMFKey); ok {
 		if strings.Contains(serviceAddress, common.Udp) {
 			translators.Receivers.Set(udplog.NewTranslatorWithName(common.PipelineNameEmfLogs))
@@ -66,11 +73,8 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 			translators.Receivers.Set(tcplog.NewTranslatorWithName(common.PipelineNameEmfLogs))
 		}
 	} else {
-		translators.Receivers = common.NewTranslatorMap(
-			tcplog.NewTranslatorWithName(common.PipelineNameEmfLogs),
-			udplog.NewTranslatorWithName(common.PipelineNameEmfLogs),
-		)
-
+		translators.Receivers.Set(tcplog.NewTranslatorWithName(common.PipelineNameEmfLogs))
+		translators.Receivers.Set(udplog.NewTranslatorWithName(common.PipelineNameEmfLogs))
 	}
 	return &translators, nil
 }
@@ -67,6 +67,45 @@ func TestTranslator(t *testing.T) {
 				extensions:   []string{"agenthealth/logs", "agenthealth/statuscode"},
 			},
 		},
+		"WithEvents": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"events": map[string]interface{}{
+							"log_group_name": "/aws/events",
+						},
+					},
+				},
+			},
+			want: &want{
+				pipelineType: "logs/emf_logs",
+				receivers:    []string{"events/emf_logs"},
+				processors:   []string{"batch/emf_logs"},
+				exporters:    []string{"awscloudwatchlogs/emf_logs"},
+				extensions:   []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+		"WithEmfAndEvents": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"emf": map[string]interface{}{
+							"service_address": "udp:1000",
+						},
+						"events": map[string]interface{}{
+							"log_group_name": "/aws/events",
+						},
+					},
+				},
+			},
+			want: &want{
+				pipelineType: "logs/emf_logs",
+				receivers:    []string{"events/emf_logs", "udplog/emf_logs"},
+				processors:   []string{"batch/emf_logs"},
+				exporters:    []string{"awscloudwatchlogs/emf_logs"},
+				extensions:   []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
 		"WithUdpServiceAddress": {
 			input: map[string]interface{}{
 				"logs": map[string]interface{}{
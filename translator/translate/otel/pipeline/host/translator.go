@@ -18,6 +18,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awscloudwatch"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awsemf"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/loadbalancing"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/prometheusremotewrite"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/sigv4auth"
@@ -26,8 +27,16 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/cumulativetodeltaprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/deltatocumulativeprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/ec2taggerprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/emfvalidator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/externalprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/filterprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/metricsdecorator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/metrictransform"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/namespaceguard"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/outoforder"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/rollupprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/transformprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/wasmprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util/ecsutil"
 )
 
@@ -35,6 +44,11 @@ type translator struct {
 	name string
 	common.DestinationProvider
 	receivers common.ComponentTranslatorMap
+	route     *common.Route
+	// namespace is the namespace of the inputs of the pipeline, which publish to it instead of metrics.namespace.
+	namespace string
+	// resourceProcessor sets the resource attributes of the receivers of the pipeline
+	resourceProcessor common.ComponentTranslator
 }
 
 var _ common.PipelineTranslator = (*translator)(nil)
@@ -43,6 +57,7 @@ var supportedEntityProcessorDestinations = [...]string{
 	common.DefaultDestination,
 	common.CloudWatchKey,
 	common.CloudWatchLogsKey,
+	common.GatewayKey,
 }
 
 // NewTranslator creates a new host pipeline translator. The receiver types
@@ -60,9 +75,42 @@ func NewTranslator(
 	if t.Destination() != "" {
 		t.name += "/" + t.Destination()
 	}
+	if t.route != nil {
+		t.name += "/" + t.route.ComponentName()
+	}
+	if t.namespace != "" {
+		t.name += "/" + common.NamespaceComponentName(t.namespace)
+	}
 	return t
 }
 
+// WithResourceProcessor adds a processor that sets the resource attributes of the data received by the pipeline.
+func WithResourceProcessor(processor common.ComponentTranslator) common.TranslatorOption {
+	return func(target any) {
+		if t, ok := target.(*translator); ok {
+			t.resourceProcessor = processor
+		}
+	}
+}
+
+// WithRoute makes the pipeline only send the metrics matching the route to the route's destination.
+func WithRoute(route common.Route) common.TranslatorOption {
+	return func(target any) {
+		if t, ok := target.(*translator); ok {
+			t.route = &route
+		}
+	}
+}
+
+// WithNamespace makes the pipeline publish to the namespace instead of metrics.namespace.
+func WithNamespace(namespace string) common.TranslatorOption {
+	return func(target any) {
+		if t, ok := target.(*translator); ok {
+			t.namespace = namespace
+		}
+	}
+}
+
 func (t translator) ID() pipeline.ID {
 	return pipeline.NewIDWithName(pipeline.SignalMetrics, t.name)
 }
@@ -83,8 +131,20 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 		Extensions: common.NewTranslatorMap[component.Config, component.ID](),
 	}
 
+	if t.resourceProcessor != nil {
+		translators.Processors.Set(t.resourceProcessor)
+	}
+
+	// the pushed metrics are reordered before the deltas are calculated, so that late data points do not leave gaps
+	if pipelineName := determinePipeline(t.name); pipelineName == common.PipelineNameHostOtlpMetrics || pipelineName == common.PipelineNameHostCustomMetrics {
+		if outoforder.IsSet(conf) {
+			log.Printf("D! out of order processor required because out_of_order is set")
+			translators.Processors.Set(outoforder.NewTranslatorWithName(t.name))
+		}
+	}
+
 	if strings.HasPrefix(t.name, common.PipelineNameHostDeltaMetrics) || strings.HasPrefix(t.name, common.PipelineNameHostOtlpMetrics) {
-		log.Printf("D! delta processor required because metrics with diskio or net are set")
+		log.Printf("D! delta processor required because metrics with diskio, net or swap activity are set")
 		translators.Processors.Set(cumulativetodeltaprocessor.NewTranslator(common.WithName(t.name), cumulativetodeltaprocessor.WithDefaultKeys()))
 	}
 
@@ -100,6 +160,29 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 			log.Printf("D! metric decorator required because measurement fields are set")
 			translators.Processors.Set(mdt)
 		}
+
+		if transformprocessor.IsSet(conf, pipeline.SignalMetrics) {
+			log.Printf("D! transform processor required because transform statements are set")
+			translators.Processors.Set(transformprocessor.NewTranslatorWithSignal(pipeline.SignalMetrics))
+		}
+	}
+
+	// the metrics are dropped before the transforms, so the drop list has the names from before metric_transform renames them
+	if metrictransform.HasDrops(conf) {
+		log.Printf("D! filter processor required because metric_transform drops metrics")
+		translators.Processors.Set(metrictransform.NewFilterTranslator())
+	}
+	if metrictransform.HasTransforms(conf) {
+		log.Printf("D! metricstransform processor required because metric_transform renames metrics")
+		translators.Processors.Set(metrictransform.NewTransformTranslator())
+	}
+	if externalprocessor.IsSet(conf, pipeline.SignalMetrics) {
+		log.Printf("D! external processor required because external_processor is set")
+		translators.Processors.Set(externalprocessor.NewTranslatorWithSignal(pipeline.SignalMetrics))
+	}
+	if wasmprocessor.IsSet(conf, pipeline.SignalMetrics) {
+		log.Printf("D! wasm processor required because wasm_processor is set")
+		translators.Processors.Set(wasmprocessor.NewTranslatorWithSignal(pipeline.SignalMetrics))
 	}
 
 	currentContext := context.CurrentContext()
@@ -129,11 +212,30 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 
 	switch t.Destination() {
 	case common.DefaultDestination, common.CloudWatchKey:
-		translators.Exporters.Set(awscloudwatch.NewTranslator())
+		if namespaceguard.IsSet(conf) {
+			namespace := awscloudwatch.GetNamespace(conf, t.route)
+			if t.namespace != "" {
+				namespace = t.namespace
+			}
+			translators.Processors.Set(namespaceguard.NewTranslatorWithName(t.name, namespace))
+		}
+		if t.route != nil {
+			translators.Processors.Set(filterprocessor.NewRouteTranslator(t.route))
+			translators.Exporters.Set(awscloudwatch.NewTranslatorWithRoute(*t.route))
+		} else {
+			if hasExclusiveRoute(common.GetMetricsRoutes(conf)) {
+				translators.Processors.Set(filterprocessor.NewRouteTranslator(nil))
+			}
+			if t.namespace != "" {
+				translators.Exporters.Set(awscloudwatch.NewTranslatorWithNamespace(t.namespace))
+			} else {
+				translators.Exporters.Set(awscloudwatch.NewTranslator())
+			}
+		}
 		translators.Extensions.Set(agenthealth.NewTranslator(agenthealth.MetricsName, []string{agenthealth.OperationPutMetricData}))
 		translators.Extensions.Set(agenthealth.NewTranslatorWithStatusCode(agenthealth.StatusCodeName, nil, true))
 	case common.AMPKey:
-		if conf.IsSet(common.MetricsAggregationDimensionsKey) {
+		if rollupprocessor.IsSet(conf) {
 			translators.Processors.Set(rollupprocessor.NewTranslator())
 		}
 		translators.Processors.Set(batchprocessor.NewTranslatorWithNameAndSection(t.name, common.MetricsKey))
@@ -141,7 +243,14 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 		translators.Processors.Set(deltatocumulativeprocessor.NewTranslator(common.WithName(t.name)))
 		translators.Exporters.Set(prometheusremotewrite.NewTranslatorWithName(common.AMPKey))
 		translators.Extensions.Set(sigv4auth.NewTranslator())
+	case common.GatewayKey:
+		// the gateway exports to CloudWatch, so only the enrichment happens here
+		translators.Processors.Set(batchprocessor.NewTranslatorWithNameAndSection(t.name, common.MetricsKey))
+		translators.Exporters.Set(loadbalancing.NewTranslator())
 	case common.CloudWatchLogsKey:
+		if emfvalidator.IsSet(conf) {
+			translators.Processors.Set(emfvalidator.NewTranslatorWithName(t.name))
+		}
 		translators.Processors.Set(batchprocessor.NewTranslatorWithNameAndSection(t.name, common.LogsKey))
 		translators.Exporters.Set(awsemf.NewTranslator())
 		translators.Extensions.Set(agenthealth.NewTranslator(agenthealth.LogsName, []string{agenthealth.OperationPutLogEvents}))
@@ -153,6 +262,16 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 	return &translators, nil
 }
 
+// hasExclusiveRoute is true if any route takes its metrics out of the default pipelines.
+func hasExclusiveRoute(routes []common.Route) bool {
+	for _, route := range routes {
+		if !route.Copy {
+			return true
+		}
+	}
+	return false
+}
+
 func determinePipeline(name string) string {
 	// The conditionals have to be done in a certain order because PipelineNameHost is just "host", whereas
 	// the other constants are prefixed with "host"
@@ -82,6 +82,25 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
 			},
 		},
+		"WithAllowedNamespaces": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"allowed_namespaces": []interface{}{"Team/*"},
+				},
+				"metrics": map[string]interface{}{
+					"namespace": "Team/Api",
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"awsentity/resource", "namespaceguard/host"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
 		"WithOtlpMetricsEC2": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
@@ -100,6 +119,46 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
 			},
 		},
+		"WithOutOfOrder": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"otlp": map[string]interface{}{},
+					},
+					"out_of_order": map[string]interface{}{
+						"action": "reorder",
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHostOtlpMetrics,
+			mode:         config.ModeEC2,
+			isECS:        true,
+			want: &want{
+				pipelineID: "metrics/hostOtlpMetrics",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"outoforder/hostOtlpMetrics", "cumulativetodelta/hostOtlpMetrics"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
+		"WithOutOfOrderOnHostMetrics": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"out_of_order": map[string]interface{}{
+						"action": "drop",
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"awsentity/resource"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
 		"WithOtlpMetricsECS": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
@@ -176,6 +235,31 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
 			},
 		},
+		"WithOtlpMetrics/CloudWatchLogsEMFMetrics": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"emf_metrics": map[string]interface{}{
+						"policy": "drop",
+					},
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"otlp": map[string]interface{}{},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHostOtlpMetrics,
+			destination:  common.CloudWatchLogsKey,
+			mode:         config.ModeEC2,
+			isECS:        true,
+			want: &want{
+				pipelineID: "metrics/hostOtlpMetrics/cloudwatchlogs",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"cumulativetodelta/hostOtlpMetrics/cloudwatchlogs", "emfvalidator/hostOtlpMetrics/cloudwatchlogs", "batch/hostOtlpMetrics/cloudwatchlogs"},
+				exporters:  []string{"awsemf"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
 		"WithOtlpMetrics/CloudWatchLogsKubernetes": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
@@ -279,6 +363,66 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
 			},
 		},
+		"WithMetricTransform": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metric_transform": map[string]interface{}{
+						"drop": []interface{}{
+							map[string]interface{}{"name": "cpu_usage_guest"},
+						},
+						"transforms": []interface{}{
+							map[string]interface{}{"name": "mem_used_percent", "new_name": "MemoryUtilization"},
+						},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"filter/metric_transform", "metricstransform/metric_transform", "awsentity/resource"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
+		"WithExternalProcessor": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"external_processor": map[string]interface{}{
+						"endpoint":       "localhost:4320",
+						"failure_policy": "drop",
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"externalprocessor/metrics", "awsentity/resource"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
+		"WithWasmProcessor": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"wasm_processor": map[string]interface{}{
+						"module": "/opt/transforms/rename.wasm",
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"wasmprocessor/metrics", "awsentity/resource"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
 		"WithAppendDimensions": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
@@ -312,6 +456,23 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"sigv4auth"},
 			},
 		},
+		"WithPRWExporter/Staleness": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"staleness": map[string]interface{}{"policy": "absent"},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			destination:  common.AMPKey,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host/amp",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"rollup", "batch/host/amp", "deltatocumulative/host/amp"},
+				exporters:  []string{"prometheusremotewrite/amp"},
+				extensions: []string{"sigv4auth"},
+			},
+		},
 		"WithPRWExporter/NoAggregation": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{},
@@ -327,6 +488,23 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"sigv4auth"},
 			},
 		},
+		"WithGatewayExporter": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"append_dimensions": map[string]interface{}{},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			destination:  common.GatewayKey,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host/gateway",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"ec2tagger", "awsentity/resource", "batch/host/gateway"},
+				exporters:  []string{"loadbalancing/gateway"},
+				extensions: []string{},
+			},
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -5,13 +5,17 @@ package host
 
 import (
 	"fmt"
+	"slices"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/pipeline"
+	"golang.org/x/exp/maps"
 
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awscloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/resourceprocessor"
 	adaptertranslator "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/adapter"
 	otlpreceiver "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/otlp"
 )
@@ -26,7 +30,8 @@ func NewTranslators(conf *confmap.Conf, configSection, os string) (common.Transl
 	hostReceivers := common.NewTranslatorMap[component.Config, component.ID]()
 	hostCustomReceivers := common.NewTranslatorMap[component.Config, component.ID]()
 	deltaReceivers := common.NewTranslatorMap[component.Config, component.ID]()
-	otlpReceivers := common.NewTranslatorMap[component.Config, component.ID]()
+	// namespaces maps the receivers of the inputs publishing to their own namespace to it
+	namespaces := map[component.ID]string{}
 
 	// Gather adapter receivers
 	if configSection == MetricsKey {
@@ -34,8 +39,13 @@ func NewTranslators(conf *confmap.Conf, configSection, os string) (common.Transl
 		if err != nil {
 			return nil, fmt.Errorf("error finding receivers in config: %w", err)
 		}
+		defaultNamespace := awscloudwatch.GetNamespace(conf, nil)
 		adapterReceivers.Range(func(translator common.ComponentTranslator) {
-			if translator.ID().Type() == adapter.Type(common.DiskIOKey) || translator.ID().Type() == adapter.Type(common.NetKey) {
+			if namespace := adaptertranslator.Namespace(conf, translator); namespace != "" && namespace != defaultNamespace {
+				namespaces[translator.ID()] = namespace
+			}
+			if translator.ID().Type() == adapter.Type(common.DiskIOKey) || translator.ID().Type() == adapter.Type(common.NetKey) ||
+				(translator.ID().Type() == adapter.Type(common.SwapKey) && hasSwapActivity(conf)) {
 				deltaReceivers.Set(translator)
 			} else if translator.ID().Type() == adapter.Type(common.StatsDMetricKey) || translator.ID().Type() == adapter.Type(common.CollectDPluginKey) {
 				hostCustomReceivers.Set(translator)
@@ -45,26 +55,11 @@ func NewTranslators(conf *confmap.Conf, configSection, os string) (common.Transl
 		})
 	}
 
-	// Gather OTLP receivers
-	switch v := conf.Get(common.ConfigKey(configSection, common.OtlpKey)).(type) {
-	case []any:
-		for index := range v {
-			otlpReceivers.Set(otlpreceiver.NewTranslator(
-				otlpreceiver.WithSignal(pipeline.SignalMetrics),
-				otlpreceiver.WithConfigKey(common.ConfigKey(configSection, common.OtlpKey)),
-				common.WithIndex(index),
-			))
-		}
-	case map[string]any:
-		otlpReceivers.Set(otlpreceiver.NewTranslator(
-			otlpreceiver.WithSignal(pipeline.SignalMetrics),
-			otlpreceiver.WithConfigKey(common.ConfigKey(configSection, common.OtlpKey)),
-		))
-	}
+	// Gather OTLP receivers, the named ones each get their own pipeline
+	otlpConfigKey := common.ConfigKey(configSection, common.OtlpKey)
+	otlpReceivers := otlpreceiver.NewTranslators(conf, otlpConfigKey, pipeline.SignalMetrics, "")
+	otlpPipelineNames := otlpreceiver.PipelineNames(conf, otlpConfigKey)
 
-	hasHostPipeline := hostReceivers.Len() != 0
-	hasHostCustomPipeline := hostCustomReceivers.Len() != 0
-	hasDeltaPipeline := deltaReceivers.Len() != 0
 	hasOtlpPipeline := otlpReceivers.Len() != 0
 
 	var destinations []string
@@ -88,36 +83,139 @@ func NewTranslators(conf *confmap.Conf, configSection, os string) (common.Transl
 				receivers,
 				common.WithDestination(destination),
 			))
-		default:
-			if hasHostPipeline {
+			for _, name := range otlpPipelineNames {
 				translators.Set(NewTranslator(
-					common.PipelineNameHost,
-					hostReceivers,
-					common.WithDestination(destination),
+					common.PipelineNameHost+"/"+name,
+					otlpreceiver.NewTranslators(conf, otlpConfigKey, pipeline.SignalMetrics, name),
+					otlpPipelineOpts(conf, otlpConfigKey, common.PipelineNameHost+"/"+name, name, common.WithDestination(destination))...,
 				))
 			}
-			if hasHostCustomPipeline {
-				translators.Set(NewTranslator(
-					common.PipelineNameHostCustomMetrics,
-					hostCustomReceivers,
-					common.WithDestination(destination)))
-			}
-			if hasDeltaPipeline {
-				translators.Set(NewTranslator(
-					common.PipelineNameHostDeltaMetrics,
-					deltaReceivers,
-					common.WithDestination(destination),
-				))
+		default:
+			// routes and namespaces only apply to the CloudWatch destination, each route gets a copy of the pipelines
+			isCloudWatch := configSection == MetricsKey && destination != common.CloudWatchLogsKey && destination != common.GatewayKey
+			routeOpts := [][]common.TranslatorOption{nil}
+			if isCloudWatch {
+				for _, route := range common.GetMetricsRoutes(conf) {
+					routeOpts = append(routeOpts, []common.TranslatorOption{WithRoute(route)})
+				}
 			}
-			if hasOtlpPipeline {
-				translators.Set(NewTranslator(
-					common.PipelineNameHostOtlpMetrics,
-					otlpReceivers,
-					common.WithDestination(destination),
-				))
+			for i, opts := range routeOpts {
+				opts = append([]common.TranslatorOption{common.WithDestination(destination)}, opts...)
+				host, hostCustom, delta := hostReceivers, hostCustomReceivers, deltaReceivers
+				// the inputs publishing to their own namespace are taken out of the default pipelines, the routes
+				// still apply to them
+				if i == 0 && isCloudWatch && len(namespaces) != 0 {
+					host = filterNamespace(hostReceivers, namespaces, "")
+					hostCustom = filterNamespace(hostCustomReceivers, namespaces, "")
+					delta = filterNamespace(deltaReceivers, namespaces, "")
+				}
+				if host.Len() != 0 {
+					translators.Set(NewTranslator(
+						common.PipelineNameHost,
+						host,
+						opts...,
+					))
+				}
+				if hostCustom.Len() != 0 {
+					translators.Set(NewTranslator(
+						common.PipelineNameHostCustomMetrics,
+						hostCustom,
+						opts...))
+				}
+				if delta.Len() != 0 {
+					translators.Set(NewTranslator(
+						common.PipelineNameHostDeltaMetrics,
+						delta,
+						opts...,
+					))
+				}
+				if hasOtlpPipeline {
+					translators.Set(NewTranslator(
+						common.PipelineNameHostOtlpMetrics,
+						otlpReceivers,
+						opts...,
+					))
+				}
+				for _, name := range otlpPipelineNames {
+					translators.Set(NewTranslator(
+						common.PipelineNameHostOtlpMetrics+"/"+name,
+						otlpreceiver.NewTranslators(conf, otlpConfigKey, pipeline.SignalMetrics, name),
+						otlpPipelineOpts(conf, otlpConfigKey, common.PipelineNameHostOtlpMetrics+"/"+name, name, opts...)...,
+					))
+				}
 			}
 		}
 	}
 
+	if len(namespaces) != 0 {
+		setNamespacePipelines(translators, destinations, namespaces, map[string]common.ComponentTranslatorMap{
+			common.PipelineNameHost:              hostReceivers,
+			common.PipelineNameHostCustomMetrics: hostCustomReceivers,
+			common.PipelineNameHostDeltaMetrics:  deltaReceivers,
+		})
+	}
+
 	return translators, nil
 }
+
+// hasSwapActivity is true if the swap measurements have the pages swapped in or out, which are cumulative like the
+// diskio and net ones. The swap is otherwise left in the host pipeline.
+func hasSwapActivity(conf *confmap.Conf) bool {
+	swap, ok := conf.Get(common.ConfigKey(MetricsKey, common.SwapKey)).(map[string]any)
+	if !ok {
+		return false
+	}
+	for _, measurement := range common.GetMeasurements(swap) {
+		switch measurement {
+		case "in", "out", "swap_in", "swap_out":
+			return true
+		}
+	}
+	return false
+}
+
+// setNamespacePipelines creates a copy of the pipelines for each namespace the inputs publish to instead of
+// metrics.namespace, with only the receivers of these inputs.
+func setNamespacePipelines(
+	translators common.TranslatorMap[*common.ComponentTranslators, pipeline.ID],
+	destinations []string,
+	namespaces map[component.ID]string,
+	receiversByPipeline map[string]common.ComponentTranslatorMap,
+) {
+	uniqueNamespaces := maps.Values(namespaces)
+	slices.Sort(uniqueNamespaces)
+	uniqueNamespaces = slices.Compact(uniqueNamespaces)
+	for _, destination := range destinations {
+		if destination != common.DefaultDestination && destination != common.CloudWatchKey {
+			continue
+		}
+		for _, namespace := range uniqueNamespaces {
+			for _, pipelineName := range []string{common.PipelineNameHost, common.PipelineNameHostCustomMetrics, common.PipelineNameHostDeltaMetrics} {
+				receivers := filterNamespace(receiversByPipeline[pipelineName], namespaces, namespace)
+				if receivers.Len() != 0 {
+					translators.Set(NewTranslator(pipelineName, receivers, common.WithDestination(destination), WithNamespace(namespace)))
+				}
+			}
+		}
+	}
+}
+
+// filterNamespace returns the receivers whose input publishes to the namespace, "" being metrics.namespace.
+func filterNamespace(receivers common.ComponentTranslatorMap, namespaces map[component.ID]string, namespace string) common.ComponentTranslatorMap {
+	filtered := common.NewTranslatorMap[component.Config, component.ID]()
+	receivers.Range(func(translator common.ComponentTranslator) {
+		if namespaces[translator.ID()] == namespace {
+			filtered.Set(translator)
+		}
+	})
+	return filtered
+}
+
+// otlpPipelineOpts adds the resource processor to the options of the pipeline of the named otlp entries if they set
+// resource attributes.
+func otlpPipelineOpts(conf *confmap.Conf, configKey, pipelineName, otlpPipelineName string, opts ...common.TranslatorOption) []common.TranslatorOption {
+	if !otlpreceiver.HasResourceAttributes(conf, configKey, otlpPipelineName) {
+		return opts
+	}
+	return append(slices.Clone(opts), WithResourceProcessor(resourceprocessor.NewOtlpTranslator(pipelineName, configKey, otlpPipelineName)))
+}
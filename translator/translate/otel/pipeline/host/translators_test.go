@@ -49,6 +49,95 @@ func TestTranslators(t *testing.T) {
 				},
 			},
 		},
+		"WithRoutes": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"routes": []any{
+						map[string]any{
+							"name":   "team_a",
+							"region": "us-west-2",
+							"match": map[string]any{
+								"attributes": map[string]any{"team": "^a$"},
+							},
+						},
+					},
+					"metrics_collected": map[string]any{
+						"cpu": map[string]any{},
+					},
+				},
+			},
+			configSection: MetricsKey,
+			want: map[string]want{
+				"metrics/host": {
+					receivers: []string{"telegraf_cpu"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/host/route_team_a": {
+					receivers: []string{"telegraf_cpu"},
+					exporters: []string{"awscloudwatch/route_team_a"},
+				},
+			},
+		},
+		"WithNamespaces": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"namespace": "Default",
+					"routes": []any{
+						map[string]any{
+							"name":      "team_a",
+							"namespace": "TeamA",
+							"match": map[string]any{
+								"attributes": map[string]any{"team": "^a$"},
+							},
+						},
+					},
+					"metrics_collected": map[string]any{
+						"cpu":    map[string]any{"namespace": "Team/Compute"},
+						"mem":    map[string]any{"namespace": "Default"},
+						"diskio": map[string]any{"namespace": "Team/Compute"},
+						"statsd": map[string]any{"namespace": "Team/Apps"},
+						"procstat": []any{
+							map[string]any{"exe": "nginx", "namespace": "Team/Apps"},
+						},
+					},
+				},
+			},
+			configSection: MetricsKey,
+			want: map[string]want{
+				"metrics/host": {
+					receivers: []string{"telegraf_mem"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/host/route_team_a": {
+					receivers: []string{"telegraf_cpu", "telegraf_mem", "telegraf_procstat/2531612879"},
+					exporters: []string{"awscloudwatch/route_team_a"},
+				},
+				"metrics/hostCustomMetrics/route_team_a": {
+					receivers: []string{"telegraf_statsd"},
+					exporters: []string{"awscloudwatch/route_team_a"},
+				},
+				"metrics/hostDeltaMetrics/route_team_a": {
+					receivers: []string{"telegraf_diskio"},
+					exporters: []string{"awscloudwatch/route_team_a"},
+				},
+				"metrics/host/" + common.NamespaceComponentName("Team/Compute"): {
+					receivers: []string{"telegraf_cpu"},
+					exporters: []string{"awscloudwatch/" + common.NamespaceComponentName("Team/Compute")},
+				},
+				"metrics/hostDeltaMetrics/" + common.NamespaceComponentName("Team/Compute"): {
+					receivers: []string{"telegraf_diskio"},
+					exporters: []string{"awscloudwatch/" + common.NamespaceComponentName("Team/Compute")},
+				},
+				"metrics/host/" + common.NamespaceComponentName("Team/Apps"): {
+					receivers: []string{"telegraf_procstat/2531612879"},
+					exporters: []string{"awscloudwatch/" + common.NamespaceComponentName("Team/Apps")},
+				},
+				"metrics/hostCustomMetrics/" + common.NamespaceComponentName("Team/Apps"): {
+					receivers: []string{"telegraf_statsd"},
+					exporters: []string{"awscloudwatch/" + common.NamespaceComponentName("Team/Apps")},
+				},
+			},
+		},
 		"WithAMPDestination": {
 			input: map[string]any{
 				"metrics": map[string]any{
@@ -122,6 +211,49 @@ func TestTranslators(t *testing.T) {
 				},
 			},
 		},
+		"WithSwapActivity": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_collected": map[string]any{
+						"swap": map[string]any{
+							"measurement": []any{"swap_used_percent", map[string]any{"name": "swap_out"}},
+						},
+						"mem": map[string]any{
+							"measurement": []any{"mem_huge_pages_free", "mem_dirty"},
+						},
+					},
+				},
+			},
+			configSection: MetricsKey,
+			want: map[string]want{
+				"metrics/host": {
+					receivers: []string{"telegraf_mem"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/hostDeltaMetrics": {
+					receivers: []string{"telegraf_swap"},
+					exporters: []string{"awscloudwatch"},
+				},
+			},
+		},
+		"WithSwapWithoutActivity": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_collected": map[string]any{
+						"swap": map[string]any{
+							"measurement": []any{"swap_used_percent"},
+						},
+					},
+				},
+			},
+			configSection: MetricsKey,
+			want: map[string]want{
+				"metrics/host": {
+					receivers: []string{"telegraf_swap"},
+					exporters: []string{"awscloudwatch"},
+				},
+			},
+		},
 		"WithOtlpMetrics/CloudWatch": {
 			input: map[string]any{
 				"metrics": map[string]any{
@@ -154,6 +286,29 @@ func TestTranslators(t *testing.T) {
 				},
 			},
 		},
+		"WithNamedOtlpMetrics": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"otlp": []interface{}{
+							map[string]interface{}{"grpc_endpoint": "127.0.0.1:4317"},
+							map[string]interface{}{"name": "appsignals", "grpc_endpoint": "0.0.0.0:4315"},
+						},
+					},
+				},
+			},
+			configSection: LogsKey,
+			want: map[string]want{
+				"metrics/hostOtlpMetrics/cloudwatchlogs": {
+					receivers: []string{"otlp/metrics/0"},
+					exporters: []string{"awsemf"},
+				},
+				"metrics/hostOtlpMetrics/appsignals/cloudwatchlogs": {
+					receivers: []string{"otlp/metrics/appsignals/1"},
+					exporters: []string{"awsemf"},
+				},
+			},
+		},
 		"WithCustomMetrics": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
@@ -187,7 +342,8 @@ func TestTranslators(t *testing.T) {
 					require.True(t, ok)
 					g, err := tr.Translate(conf)
 					assert.NoError(t, err)
-					assert.Equal(t, w.receivers, collections.MapSlice(g.Receivers.Keys(), component.ID.String))
+					// the receivers are gathered from the config map, so their order is not set
+					assert.ElementsMatch(t, w.receivers, collections.MapSlice(g.Receivers.Keys(), component.ID.String))
 					assert.Equal(t, w.exporters, collections.MapSlice(g.Exporters.Keys(), component.ID.String))
 				})
 			}
@@ -200,3 +356,47 @@ func TestTranslatorsError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, got)
 }
+
+func TestTranslatorsWithOtlpResourceAttributes(t *testing.T) {
+	translatorcontext.SetTargetPlatform("linux")
+	conf := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"metrics_destinations": map[string]any{
+				"amp":        map[string]any{"workspace_id": "ws-12345"},
+				"cloudwatch": map[string]any{},
+			},
+			"metrics_collected": map[string]any{
+				"otlp": []any{
+					map[string]any{"grpc_endpoint": "127.0.0.1:4317"},
+					map[string]any{
+						"name":                "payments",
+						"grpc_endpoint":       "0.0.0.0:5317",
+						"resource_attributes": map[string]any{"team": "payments", "deployment.environment": "prod"},
+					},
+				},
+			},
+		},
+	})
+	got, err := NewTranslators(conf, MetricsKey, "linux")
+	require.NoError(t, err)
+	want := map[string][]string{
+		"metrics/host/amp":                            nil,
+		"metrics/host/payments/amp":                   {"resource/host/payments"},
+		"metrics/hostOtlpMetrics/cloudwatch":          nil,
+		"metrics/hostOtlpMetrics/payments/cloudwatch": {"resource/hostOtlpMetrics/payments"},
+	}
+	assert.Equal(t, len(want), got.Len())
+	got.Range(func(tr common.Translator[*common.ComponentTranslators, pipeline.ID]) {
+		wantProcessors, ok := want[tr.ID().String()]
+		require.True(t, ok, tr.ID().String())
+		g, err := tr.Translate(conf)
+		require.NoError(t, err)
+		var resourceProcessors []string
+		for _, id := range g.Processors.Keys() {
+			if id.Type().String() == "resource" {
+				resourceProcessors = append(resourceProcessors, id.String())
+			}
+		}
+		assert.Equal(t, wantProcessors, resourceProcessors, tr.ID().String())
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlp_logs
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	logsdestinationprocessor "github.com/aws/amazon-cloudwatch-agent/plugins/processors/logsdestination"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awscloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/batchprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/externalprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/logsdestination"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/wasmprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/otlp"
+)
+
+type translator struct{}
+
+var _ common.PipelineTranslator = (*translator)(nil)
+
+func NewTranslator() common.PipelineTranslator {
+	return &translator{}
+}
+
+func (t *translator) ID() pipeline.ID {
+	return pipeline.NewIDWithName(pipeline.SignalLogs, common.PipelineNameOtlpLogs)
+}
+
+// Translate creates a pipeline sending the logs received with OTLP to CloudWatch Logs if the otlp section of the
+// logs_collected is present. The logsdestination processor is only added when the log group or log stream name
+// has placeholders of resource attributes.
+func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators, error) {
+	if conf == nil || !conf.IsSet(common.OtlpLogsConfigKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.OtlpLogsConfigKey}
+	}
+	exporter := awscloudwatchlogs.NewTranslatorWithName(common.PipelineNameOtlpLogs)
+	logGroupName, logStreamName, err := awscloudwatchlogs.OtlpLogsNames(exporter.ID(), conf)
+	if err != nil {
+		return nil, err
+	}
+	translators := common.ComponentTranslators{
+		Receivers: common.NewTranslatorMap(otlp.NewTranslator(
+			otlp.WithSignal(pipeline.SignalLogs),
+			otlp.WithConfigKey(common.OtlpLogsConfigKey),
+		)),
+		Processors: common.NewTranslatorMap[component.Config, component.ID](),
+		Exporters:  common.NewTranslatorMap(exporter),
+		Extensions: common.NewTranslatorMap(agenthealth.NewTranslator(agenthealth.LogsName, []string{agenthealth.OperationPutLogEvents}),
+			agenthealth.NewTranslatorWithStatusCode(agenthealth.StatusCodeName, nil, true),
+		),
+	}
+	// the batches are enriched before the log group and log stream names are resolved from their attributes
+	if externalprocessor.IsSet(conf, pipeline.SignalLogs) {
+		translators.Processors.Set(externalprocessor.NewTranslatorWithSignal(pipeline.SignalLogs))
+	}
+	if wasmprocessor.IsSet(conf, pipeline.SignalLogs) {
+		translators.Processors.Set(wasmprocessor.NewTranslatorWithSignal(pipeline.SignalLogs))
+	}
+	if logsdestinationprocessor.HasPlaceholders(logGroupName) || logsdestinationprocessor.HasPlaceholders(logStreamName) {
+		translators.Processors.Set(logsdestination.NewTranslatorWithName(common.PipelineNameOtlpLogs))
+	}
+	translators.Processors.Set(batchprocessor.NewTranslatorWithNameAndSection(common.PipelineNameOtlpLogs, common.LogsKey))
+	return &translators, nil
+}
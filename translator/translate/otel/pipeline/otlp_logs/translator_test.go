@@ -0,0 +1,111 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlp_logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	type want struct {
+		receivers  []string
+		processors []string
+		exporters  []string
+		extensions []string
+	}
+	tt := NewTranslator()
+	require.EqualValues(t, "logs/otlp_logs", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		want    *want
+		wantErr error
+	}{
+		"WithoutOtlpKey": {
+			input:   map[string]any{"logs": map[string]any{"logs_collected": map[string]any{}}},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: common.OtlpLogsConfigKey},
+		},
+		"WithoutLogGroupName": {
+			input: map[string]any{"logs": map[string]any{"logs_collected": map[string]any{"otlp": map[string]any{}}}},
+			wantErr: &common.MissingKeyError{
+				ID:      component.MustNewIDWithName("awscloudwatchlogs", common.PipelineNameOtlpLogs),
+				JsonKey: common.ConfigKey(common.OtlpLogsConfigKey, common.LogGroupName),
+			},
+		},
+		"WithStaticNames": {
+			input: map[string]any{"logs": map[string]any{"logs_collected": map[string]any{"otlp": map[string]any{
+				"log_group_name":  "/aws/otlp/checkout",
+				"log_stream_name": "checkout",
+			}}}},
+			want: &want{
+				receivers:  []string{"otlp/logs"},
+				processors: []string{"batch/otlp_logs"},
+				exporters:  []string{"awscloudwatchlogs/otlp_logs"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+		"WithResourceAttributes": {
+			input: map[string]any{"logs": map[string]any{"logs_collected": map[string]any{"otlp": map[string]any{
+				"log_group_name":  "/aws/otlp/{service.name}",
+				"log_stream_name": "{instance_id}",
+			}}}},
+			want: &want{
+				receivers:  []string{"otlp/logs"},
+				processors: []string{"logsdestination/otlp_logs", "batch/otlp_logs"},
+				exporters:  []string{"awscloudwatchlogs/otlp_logs"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+		"WithExternalProcessor": {
+			input: map[string]any{"logs": map[string]any{
+				"logs_collected": map[string]any{"otlp": map[string]any{
+					"log_group_name":  "/aws/otlp/{team}",
+					"log_stream_name": "{instance_id}",
+				}},
+				"external_processor": map[string]any{"endpoint": "unix:///run/enricher.sock"},
+			}},
+			want: &want{
+				receivers:  []string{"otlp/logs"},
+				processors: []string{"externalprocessor/logs", "logsdestination/otlp_logs", "batch/otlp_logs"},
+				exporters:  []string{"awscloudwatchlogs/otlp_logs"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+		"WithWasmProcessor": {
+			input: map[string]any{"logs": map[string]any{
+				"logs_collected": map[string]any{"otlp": map[string]any{"log_group_name": "/aws/otlp/app"}},
+				"wasm_processor": map[string]any{"module": "/opt/transforms/parse.wasm", "memory_limit_mib": 32},
+			}},
+			want: &want{
+				receivers:  []string{"otlp/logs"},
+				processors: []string{"wasmprocessor/logs", "logsdestination/otlp_logs", "batch/otlp_logs"},
+				exporters:  []string{"awscloudwatchlogs/otlp_logs"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			require.Equal(t, testCase.wantErr, err)
+			if testCase.want == nil {
+				require.Nil(t, got)
+			} else {
+				require.NotNil(t, got)
+				assert.Equal(t, testCase.want.receivers, collections.MapSlice(got.Receivers.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.processors, collections.MapSlice(got.Processors.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.exporters, collections.MapSlice(got.Exporters.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.extensions, collections.MapSlice(got.Extensions.Keys(), component.ID.String))
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package prometheus
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/collector/confmap"
@@ -15,9 +16,12 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awsemf"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/prometheusremotewrite"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/sharding"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/sigv4auth"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/batchprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/deltatocumulativeprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/emfcoverage"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/filterprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/rollupprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/adapter"
 	otelprom "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/prometheus"
@@ -30,6 +34,7 @@ var (
 
 type translator struct {
 	name string
+	common.IndexProvider
 	common.DestinationProvider
 }
 
@@ -37,12 +42,16 @@ var _ common.PipelineTranslator = (*translator)(nil)
 
 func NewTranslator(opts ...common.TranslatorOption) common.PipelineTranslator {
 	t := &translator{name: common.PipelineNamePrometheus}
+	t.SetIndex(-1)
 	for _, opt := range opts {
 		opt(t)
 	}
 	if t.Destination() != "" {
 		t.name += "/" + t.Destination()
 	}
+	if t.Index() != -1 {
+		t.name += "/" + strconv.Itoa(t.Index())
+	}
 	return t
 }
 
@@ -66,7 +75,7 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 		if !conf.IsSet(LogsKey) {
 			return nil, fmt.Errorf("pipeline (%s) is missing prometheus configuration under logs section with destination (%s)", t.name, t.Destination())
 		}
-		return &common.ComponentTranslators{
+		translators := &common.ComponentTranslators{
 			Receivers: common.NewTranslatorMap(adapter.NewTranslator(prometheus.SectionKey, LogsKey, time.Minute)),
 			Processors: common.NewTranslatorMap(
 				batchprocessor.NewTranslatorWithNameAndSection(t.name, common.LogsKey), // prometheus sits under metrics_collected in "logs"
@@ -74,7 +83,21 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 			Exporters: common.NewTranslatorMap(awsemf.NewTranslatorWithName(common.PipelineNamePrometheus)),
 			Extensions: common.NewTranslatorMap(agenthealth.NewTranslator(agenthealth.LogsName, []string{agenthealth.OperationPutLogEvents}),
 				agenthealth.NewTranslatorWithStatusCode(agenthealth.StatusCodeName, nil, true)),
-		}, nil
+		}
+		// each emf_processor.jobs entry has its own pipeline and exporter, which the other pipelines filter out
+		if conf.IsSet(common.PrometheusEMFJobsKey) {
+			translators.Processors.Set(filterprocessor.NewPrometheusJobsTranslator(t.name, t.Index()))
+		}
+		if emfcoverage.IsSet(conf, t.Index()) {
+			translators.Processors.Set(emfcoverage.NewTranslatorWithName(t.name, t.Index()))
+		}
+		if t.Index() != -1 {
+			translators.Exporters = common.NewTranslatorMap(awsemf.NewPrometheusJobTranslator(t.Index()))
+		}
+		if conf.IsSet(sharding.ShardingKey) {
+			translators.Extensions.Set(sharding.NewTranslator())
+		}
+		return translators, nil
 	case common.AMPKey:
 		if !conf.IsSet(MetricsKey) {
 			return nil, fmt.Errorf("pipeline (%s) is missing prometheus configuration under metrics section with destination (%s)", t.name, t.Destination())
@@ -89,7 +112,7 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 			Exporters:  common.NewTranslatorMap(prometheusremotewrite.NewTranslatorWithName(common.AMPKey)),
 			Extensions: common.NewTranslatorMap(sigv4auth.NewTranslator()),
 		}
-		if conf.IsSet(common.MetricsAggregationDimensionsKey) {
+		if rollupprocessor.IsSet(conf) {
 			translators.Processors.Set(rollupprocessor.NewTranslator())
 		}
 		return translators, nil
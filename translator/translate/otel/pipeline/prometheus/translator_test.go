@@ -112,6 +112,80 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"sigv4auth"},
 			},
 		},
+		"WithSharding": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"metrics_collected": map[string]any{
+						"prometheus": map[string]any{
+							"sharding": map[string]any{
+								"peers": "cwagent-headless.amazon-cloudwatch.svc",
+							},
+						},
+					},
+				},
+			},
+			destination: common.CloudWatchLogsKey,
+			want: &want{
+				pipelineID: "metrics/prometheus/cloudwatchlogs",
+				receivers:  []string{"telegraf_prometheus"},
+				processors: []string{"batch/prometheus/cloudwatchlogs"},
+				exporters:  []string{"awsemf/prometheus"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode", "sharding"},
+			},
+		},
+		"WithMetricDeclaration": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"metrics_collected": map[string]any{
+						"prometheus": map[string]any{
+							"emf_processor": map[string]any{
+								"metric_declaration": []any{
+									map[string]any{
+										"source_labels":    []any{"job"},
+										"metric_selectors": []any{"^nginx_.*$"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			destination: common.CloudWatchLogsKey,
+			want: &want{
+				pipelineID: "metrics/prometheus/cloudwatchlogs",
+				receivers:  []string{"telegraf_prometheus"},
+				processors: []string{"batch/prometheus/cloudwatchlogs"},
+				exporters:  []string{"awsemf/prometheus"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+		"WithReportUnmatchedMetrics": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"metrics_collected": map[string]any{
+						"prometheus": map[string]any{
+							"emf_processor": map[string]any{
+								"metric_declaration": []any{
+									map[string]any{
+										"source_labels":    []any{"job"},
+										"metric_selectors": []any{"^nginx_.*$"},
+									},
+								},
+								"report_unmatched_metrics": true,
+							},
+						},
+					},
+				},
+			},
+			destination: common.CloudWatchLogsKey,
+			want: &want{
+				pipelineID: "metrics/prometheus/cloudwatchlogs",
+				receivers:  []string{"telegraf_prometheus"},
+				processors: []string{"batch/prometheus/cloudwatchlogs", "emfcoverage/prometheus/cloudwatchlogs"},
+				exporters:  []string{"awsemf/prometheus"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
 		"WithValidCloudWatch": {
 			input: map[string]any{
 				"logs": map[string]any{
@@ -149,3 +223,59 @@ func TestTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslatorWithPrometheusJobs(t *testing.T) {
+	input := map[string]any{
+		"logs": map[string]any{
+			"metrics_collected": map[string]any{
+				"prometheus": map[string]any{
+					"log_group_name": "prometheus",
+					"emf_processor": map[string]any{
+						"metric_namespace": "Default",
+						"jobs": []any{
+							map[string]any{
+								"job_names":        []any{"nginx"},
+								"metric_namespace": "Nginx",
+							},
+							map[string]any{
+								"job_names":      []any{"redis", "memcached"},
+								"log_group_name": "cache",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	testCases := map[string]struct {
+		index          int
+		wantPipelineID string
+		wantProcessors []string
+		wantExporters  []string
+	}{
+		"Default": {
+			index:          -1,
+			wantPipelineID: "metrics/prometheus/cloudwatchlogs",
+			wantProcessors: []string{"batch/prometheus/cloudwatchlogs", "filter/prometheus/cloudwatchlogs"},
+			wantExporters:  []string{"awsemf/prometheus"},
+		},
+		"Job": {
+			index:          1,
+			wantPipelineID: "metrics/prometheus/cloudwatchlogs/1",
+			wantProcessors: []string{"batch/prometheus/cloudwatchlogs/1", "filter/prometheus/cloudwatchlogs/1"},
+			wantExporters:  []string{"awsemf/prometheus/1"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator(common.WithDestination(common.CloudWatchLogsKey), common.WithIndex(testCase.index))
+			got, err := tt.Translate(confmap.NewFromStringMap(input))
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantPipelineID, tt.ID().String())
+			assert.Equal(t, []string{"telegraf_prometheus"}, collections.MapSlice(got.Receivers.Keys(), component.ID.String))
+			assert.Equal(t, testCase.wantProcessors, collections.MapSlice(got.Processors.Keys(), component.ID.String))
+			assert.Equal(t, testCase.wantExporters, collections.MapSlice(got.Exporters.Keys(), component.ID.String))
+			assert.Equal(t, []string{"agenthealth/logs", "agenthealth/statuscode"}, collections.MapSlice(got.Extensions.Keys(), component.ID.String))
+		})
+	}
+}
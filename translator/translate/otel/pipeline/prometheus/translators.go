@@ -23,5 +23,9 @@ func NewTranslators(conf *confmap.Conf) common.PipelineTranslatorMap {
 	for _, destination := range destinations {
 		translators.Set(NewTranslator(common.WithDestination(destination)))
 	}
+	jobs := common.GetArray[any](conf, common.PrometheusEMFJobsKey)
+	for index := range jobs {
+		translators.Set(NewTranslator(common.WithDestination(common.CloudWatchLogsKey), common.WithIndex(index)))
+	}
 	return translators
 }
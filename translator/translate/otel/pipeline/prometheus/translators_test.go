@@ -97,6 +97,27 @@ func TestTranslators(t *testing.T) {
 				pipeline.MustNewIDWithName("metrics", "prometheus/cloudwatchlogs"),
 			},
 		},
+		"WithLogsWithPrometheusJobs": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"metrics_collected": map[string]any{
+						"prometheus": map[string]any{
+							"emf_processor": map[string]any{
+								"jobs": []any{
+									map[string]any{"job_names": []any{"nginx"}},
+									map[string]any{"job_names": []any{"redis"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []pipeline.ID{
+				pipeline.MustNewIDWithName("metrics", "prometheus/cloudwatchlogs"),
+				pipeline.MustNewIDWithName("metrics", "prometheus/cloudwatchlogs/0"),
+				pipeline.MustNewIDWithName("metrics", "prometheus/cloudwatchlogs/1"),
+			},
+		},
 		"WithMultiple/Destinations": {
 			input: map[string]any{
 				"metrics": map[string]any{
@@ -14,7 +14,17 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 	awsxrayexporter "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awsxray"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/xraysampling"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/awsapplicationsignals"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/externalprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/filterprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/probabilisticsamplerprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/resourcedetection"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/resourceprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/spanlimits"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/tailsamplingprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/transformprocessor"
 	awsxrayreceiver "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/awsxray"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/otlp"
 )
@@ -29,6 +39,9 @@ var (
 )
 
 type translator struct {
+	// otlpPipelineName is the name of the otlp entries received by the pipeline, which is empty for the default
+	// pipeline that also receives the X-Ray segments.
+	otlpPipelineName string
 }
 
 var _ common.PipelineTranslator = (*translator)(nil)
@@ -37,7 +50,15 @@ func NewTranslator() common.PipelineTranslator {
 	return &translator{}
 }
 
+// NewTranslatorWithName creates a pipeline for the otlp entries routed to it by name.
+func NewTranslatorWithName(name string) common.PipelineTranslator {
+	return &translator{otlpPipelineName: name}
+}
+
 func (t *translator) ID() pipeline.ID {
+	if t.otlpPipelineName != "" {
+		return pipeline.NewIDWithName(pipeline.SignalTraces, pipelineName+"/"+t.otlpPipelineName)
+	}
 	return pipeline.NewIDWithName(pipeline.SignalTraces, pipelineName)
 }
 
@@ -45,21 +66,49 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 	if conf == nil || !(conf.IsSet(xrayKey) || conf.IsSet(otlpKey)) {
 		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: fmt.Sprint(xrayKey, " or ", otlpKey)}
 	}
+	otlpReceivers := otlp.NewTranslators(conf, otlpKey, pipeline.SignalTraces, t.otlpPipelineName)
+	if otlpReceivers.Len() == 0 && (t.otlpPipelineName != "" || !conf.IsSet(xrayKey)) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: fmt.Sprint(xrayKey, " or ", otlpKey)}
+	}
 	translators := &common.ComponentTranslators{
 		Receivers:  common.NewTranslatorMap[component.Config, component.ID](),
-		Processors: common.NewTranslatorMap(processor.NewDefaultTranslatorWithName(pipelineName, batchprocessor.NewFactory())),
+		Processors: common.NewTranslatorMap[component.Config, component.ID](),
 		Exporters:  common.NewTranslatorMap(awsxrayexporter.NewTranslator()),
 		Extensions: common.NewTranslatorMap(agenthealth.NewTranslator(agenthealth.TracesName, []string{agenthealth.OperationPutTraceSegments}),
 			agenthealth.NewTranslatorWithStatusCode(agenthealth.StatusCodeName, nil, true)),
 	}
-	if conf.IsSet(xrayKey) {
-		translators.Receivers.Set(awsxrayreceiver.NewTranslator())
+	if otlp.HasResourceAttributes(conf, otlpKey, t.otlpPipelineName) {
+		translators.Processors.Set(resourceprocessor.NewOtlpTranslator(t.ID().Name(), otlpKey, t.otlpPipelineName))
+	}
+	if enabled, _ := common.GetBool(conf, common.EnrichServiceAttributesKey); enabled {
+		translators.Processors.Set(resourcedetection.NewTranslator(resourcedetection.WithSignal(pipeline.SignalTraces)))
+		translators.Processors.Set(awsapplicationsignals.NewTranslator(awsapplicationsignals.WithAttributesOnly(pipelineName)))
 	}
-	if conf.IsSet(otlpKey) {
-		translators.Receivers.Set(otlp.NewTranslator(
-			otlp.WithSignal(pipeline.SignalTraces),
-			otlp.WithConfigKey(otlpKey)),
-		)
+	if conf.IsSet(probabilisticsamplerprocessor.SamplingRatioKey) {
+		translators.Processors.Set(probabilisticsamplerprocessor.NewTranslatorWithName(pipelineName))
+	}
+	if conf.IsSet(filterprocessor.DropSpansKey) {
+		translators.Processors.Set(filterprocessor.NewSpanTranslatorWithName(pipelineName))
+	}
+	if conf.IsSet(tailsamplingprocessor.DropTracesKey) {
+		translators.Processors.Set(tailsamplingprocessor.NewTranslatorWithName(pipelineName))
+	}
+	if transformprocessor.IsSet(conf, pipeline.SignalTraces) {
+		translators.Processors.Set(transformprocessor.NewTranslatorWithSignal(pipeline.SignalTraces))
+	}
+	if spanlimits.IsSet(conf) {
+		translators.Processors.Set(spanlimits.NewTranslatorWithName(pipelineName))
+	}
+	if externalprocessor.IsSet(conf, pipeline.SignalTraces) {
+		translators.Processors.Set(externalprocessor.NewTranslatorWithSignal(pipeline.SignalTraces))
+	}
+	translators.Processors.Set(processor.NewDefaultTranslatorWithName(pipelineName, batchprocessor.NewFactory()))
+	if conf.IsSet(xrayKey) && t.otlpPipelineName == "" {
+		translators.Receivers.Set(awsxrayreceiver.NewTranslator())
+		if conf.IsSet(xraysampling.SamplingDebugKey) {
+			translators.Extensions.Set(xraysampling.NewTranslator())
+		}
 	}
+	translators.Receivers.Merge(otlpReceivers)
 	return translators, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
@@ -64,6 +65,121 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"agenthealth/traces", "agenthealth/statuscode"},
 			},
 		},
+		"WithFilter": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"xray": nil,
+					},
+					"filter": map[string]interface{}{
+						"drop_spans": map[string]interface{}{
+							"status": []interface{}{"unset"},
+						},
+						"drop_traces": map[string]interface{}{
+							"attributes": map[string]interface{}{"url.path": "^/health$"},
+						},
+					},
+				},
+			},
+			want: &want{
+				receivers:  []string{"awsxray"},
+				processors: []string{"filter/xray", "tail_sampling/xray", "batch/xray"},
+				exporters:  []string{"awsxray"},
+				extensions: []string{"agenthealth/traces", "agenthealth/statuscode"},
+			},
+		},
+		"WithLimits": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"otlp": nil,
+					},
+					"limits": map[string]interface{}{
+						"max_attribute_value_length": 4096,
+					},
+				},
+			},
+			want: &want{
+				receivers:  []string{"otlp/traces"},
+				processors: []string{"spanlimits/xray", "batch/xray"},
+				exporters:  []string{"awsxray"},
+				extensions: []string{"agenthealth/traces", "agenthealth/statuscode"},
+			},
+		},
+		"WithExternalProcessor": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"otlp": nil,
+					},
+					"external_processor": map[string]interface{}{
+						"endpoint": "localhost:4320",
+					},
+				},
+			},
+			want: &want{
+				receivers:  []string{"otlp/traces"},
+				processors: []string{"externalprocessor/traces", "batch/xray"},
+				exporters:  []string{"awsxray"},
+				extensions: []string{"agenthealth/traces", "agenthealth/statuscode"},
+			},
+		},
+		"WithSamplingRatio": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"xray": nil,
+					},
+					"sampling_ratio": 0.1,
+					"filter": map[string]interface{}{
+						"drop_spans": map[string]interface{}{
+							"status": []interface{}{"unset"},
+						},
+					},
+				},
+			},
+			want: &want{
+				receivers:  []string{"awsxray"},
+				processors: []string{"probabilistic_sampler/xray", "filter/xray", "batch/xray"},
+				exporters:  []string{"awsxray"},
+				extensions: []string{"agenthealth/traces", "agenthealth/statuscode"},
+			},
+		},
+		"WithSamplingDebug": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"xray": map[string]interface{}{
+							"tcp_proxy": map[string]interface{}{
+								"sampling_debug": map[string]interface{}{},
+							},
+						},
+					},
+				},
+			},
+			want: &want{
+				receivers:  []string{"awsxray"},
+				processors: []string{"batch/xray"},
+				exporters:  []string{"awsxray"},
+				extensions: []string{"agenthealth/traces", "agenthealth/statuscode", "xraysampling"},
+			},
+		},
+		"WithEnrichServiceAttributes": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"otlp": nil,
+					},
+					"enrich_service_attributes": true,
+				},
+			},
+			want: &want{
+				receivers:  []string{"otlp/traces"},
+				processors: []string{"resourcedetection", "awsapplicationsignals/xray", "batch/xray"},
+				exporters:  []string{"awsxray"},
+				extensions: []string{"agenthealth/traces", "agenthealth/statuscode"},
+			},
+		},
 		"WithXrayAndOtlpKey": {
 			input: map[string]interface{}{
 				"traces": map[string]interface{}{
@@ -98,3 +214,47 @@ func TestTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslators(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]interface{}{
+		"traces": map[string]interface{}{
+			"traces_collected": map[string]interface{}{
+				"xray": nil,
+				"otlp": []interface{}{
+					map[string]interface{}{"grpc_endpoint": "127.0.0.1:4317", "http_endpoint": "127.0.0.1:4318"},
+					map[string]interface{}{
+						"name":                "appsignals",
+						"grpc_endpoint":       "0.0.0.0:4315",
+						"http_endpoint":       "0.0.0.0:4316",
+						"resource_attributes": map[string]interface{}{"deployment.environment": "prod"},
+					},
+				},
+			},
+		},
+	})
+	translators := NewTranslators(conf)
+	assert.Equal(t, []string{"traces/xray", "traces/xray/appsignals"}, collections.MapSlice(translators.Keys(), pipeline.ID.String))
+
+	got, err := NewTranslator().Translate(conf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"awsxray", "otlp/traces/0"}, collections.MapSlice(got.Receivers.Keys(), component.ID.String))
+
+	got, err = NewTranslatorWithName("appsignals").Translate(conf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"otlp/traces/appsignals/1"}, collections.MapSlice(got.Receivers.Keys(), component.ID.String))
+	assert.Equal(t, []string{"resource/xray/appsignals", "batch/xray"}, collections.MapSlice(got.Processors.Keys(), component.ID.String))
+	assert.Equal(t, []string{"awsxray"}, collections.MapSlice(got.Exporters.Keys(), component.ID.String))
+
+	// only named entries, without the X-Ray receiver
+	conf = confmap.NewFromStringMap(map[string]interface{}{
+		"traces": map[string]interface{}{
+			"traces_collected": map[string]interface{}{
+				"otlp": []interface{}{map[string]interface{}{"name": "appsignals"}},
+			},
+		},
+	})
+	_, err = NewTranslator().Translate(conf)
+	assert.Error(t, err)
+	_, err = NewTranslatorWithName("appsignals").Translate(conf)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,21 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package xray
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/otlp"
+)
+
+// NewTranslators creates the default pipeline and a pipeline for each name the otlp entries are routed to.
+func NewTranslators(conf *confmap.Conf) common.PipelineTranslatorMap {
+	translators := common.NewTranslatorMap[*common.ComponentTranslators, pipeline.ID](NewTranslator())
+	for _, name := range otlp.PipelineNames(conf, otlpKey) {
+		translators.Set(NewTranslatorWithName(name))
+	}
+	return translators
+}
@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor"
 
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals"
 	appsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/rules"
@@ -24,9 +25,11 @@ import (
 )
 
 type translator struct {
-	name    string
-	signal  pipeline.Signal
-	factory processor.Factory
+	name   string
+	signal pipeline.Signal
+	// attributesOnly leaves out everything but the resolvers.
+	attributesOnly bool
+	factory        processor.Factory
 }
 
 type Option interface {
@@ -47,6 +50,17 @@ func WithSignal(signal pipeline.Signal) Option {
 	})
 }
 
+// WithAttributesOnly creates a processor that only resolves the attributes of the spans like Application Signals does,
+// without its rules, limiter, sampling and exception metrics. It is used for the traces that do not come from
+// Application Signals, so the service map groups their nodes the same way.
+func WithAttributesOnly(name string) Option {
+	return optionFunc(func(t *translator) {
+		t.name = name
+		t.signal = pipeline.SignalTraces
+		t.attributesOnly = true
+	})
+}
+
 var _ common.ComponentTranslator = (*translator)(nil)
 
 func NewTranslator(opts ...Option) common.ComponentTranslator {
@@ -86,6 +100,10 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 			mode = config.ModeECS
 		}
 	}
+	// the agent running as a Lambda extension has none of the metadata of the platform it runs on
+	if envconfig.IsRunningInLambda() {
+		mode = appsignalsconfig.PlatformLambda
+	}
 	switch mode {
 	case config.ModeEKS:
 		cfg.Resolvers = []appsignalsconfig.Resolver{
@@ -103,18 +121,56 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		cfg.Resolvers = []appsignalsconfig.Resolver{
 			appsignalsconfig.NewECSResolver(hostedIn),
 		}
+	case appsignalsconfig.PlatformLambda:
+		cfg.Resolvers = []appsignalsconfig.Resolver{
+			appsignalsconfig.NewLambdaResolver(hostedIn),
+		}
 	default:
 		cfg.Resolvers = []appsignalsconfig.Resolver{
 			appsignalsconfig.NewGenericResolver(hostedIn),
 		}
 	}
+	if t.attributesOnly {
+		return cfg, nil
+	}
 
 	limiterConfig, _ := t.translateMetricLimiterConfig(conf, configKey)
 	cfg.Limiter = limiterConfig
 
+	// both the traces and metrics processors need the exception metrics config, since one counts the exceptions
+	// that the other emits
+	exceptionMetricsConfig, err := t.translateExceptionMetricsConfig(conf, common.AppSignalsConfigKeys[pipeline.SignalMetrics])
+	if err != nil {
+		return nil, err
+	}
+	cfg.ExceptionMetrics = exceptionMetricsConfig
+
+	// the spans are only sampled by the traces processor, but the config is the same for both so that it doesn't
+	// depend on which pipeline translated it
+	samplingConfig, err := t.translateSamplingConfig(conf, common.AppSignalsConfigKeys[pipeline.SignalTraces])
+	if err != nil {
+		return nil, err
+	}
+	cfg.Sampling = samplingConfig
+
+	t.translateRulesFile(conf, configKey, cfg)
+
 	return t.translateCustomRules(conf, configKey, cfg)
 }
 
+// translateRulesFile sets the file the rules are read and reloaded from. The reload interval is in seconds.
+func (t *translator) translateRulesFile(conf *confmap.Conf, configKey []string, cfg *appsignalsconfig.Config) {
+	for _, key := range configKey {
+		if rulesFile, ok := common.GetString(conf, common.ConfigKey(key, common.AppSignalsRulesFile)); ok {
+			cfg.RulesFile = rulesFile
+			if interval, ok := common.GetDuration(conf, common.ConfigKey(key, common.AppSignalsReloadInterval)); ok {
+				cfg.ReloadInterval = interval
+			}
+			return
+		}
+	}
+}
+
 func (t *translator) translateMetricLimiterConfig(conf *confmap.Conf, configKey []string) (*appsignalsconfig.LimiterConfig, error) {
 	limiterConfigKey := common.ConfigKey(configKey[0], "limiter")
 	if !conf.IsSet(limiterConfigKey) {
@@ -137,6 +193,13 @@ func (t *translator) translateMetricLimiterConfig(conf *confmap.Conf, configKey
 			limiterConfig.Threshold = int(val)
 		}
 	}
+	if rawVal, exists := configJson["max_operations"]; exists {
+		if val, ok := rawVal.(float64); !ok {
+			return nil, errors.New("type conversion error: max_operations is not a number")
+		} else {
+			limiterConfig.MaxOperations = int(val)
+		}
+	}
 	if rawVal, exists := configJson["disabled"]; exists {
 		if val, ok := rawVal.(bool); !ok {
 			return nil, errors.New("type conversion error: disabled is not a boolean")
@@ -177,6 +240,81 @@ func (t *translator) translateMetricLimiterConfig(conf *confmap.Conf, configKey
 
 }
 
+func (t *translator) translateExceptionMetricsConfig(conf *confmap.Conf, configKey []string) (*appsignalsconfig.ExceptionMetricsConfig, error) {
+	exceptionMetricsConfigKey := common.ConfigKey(configKey[0], common.AppSignalsExceptionMetrics)
+	if !conf.IsSet(exceptionMetricsConfigKey) {
+		exceptionMetricsConfigKey = common.ConfigKey(configKey[1], common.AppSignalsExceptionMetrics)
+		if !conf.IsSet(exceptionMetricsConfigKey) {
+			return nil, nil
+		}
+	}
+
+	configJson, ok := conf.Get(exceptionMetricsConfigKey).(map[string]interface{})
+	if !ok {
+		return nil, errors.New("type conversion error: exception_metrics is not an object")
+	}
+
+	exceptionMetricsConfig := appsignalsconfig.NewDefaultExceptionMetricsConfig()
+	if rawVal, exists := configJson["max_exception_types"]; exists {
+		if val, ok := rawVal.(float64); !ok {
+			return nil, errors.New("type conversion error: max_exception_types is not a number")
+		} else {
+			exceptionMetricsConfig.MaxExceptionTypes = int(val)
+		}
+	}
+	return exceptionMetricsConfig, nil
+}
+
+func (t *translator) translateSamplingConfig(conf *confmap.Conf, configKey []string) (*appsignalsconfig.SamplingConfig, error) {
+	samplingConfigKey := common.ConfigKey(configKey[0], common.AppSignalsSampling)
+	if !conf.IsSet(samplingConfigKey) {
+		samplingConfigKey = common.ConfigKey(configKey[1], common.AppSignalsSampling)
+		if !conf.IsSet(samplingConfigKey) {
+			return nil, nil
+		}
+	}
+
+	configJson, ok := conf.Get(samplingConfigKey).(map[string]interface{})
+	if !ok {
+		return nil, errors.New("type conversion error: sampling is not an object")
+	}
+
+	samplingConfig := &appsignalsconfig.SamplingConfig{}
+	if rawVal, exists := configJson["sampling_percentage"]; exists {
+		if val, ok := rawVal.(float64); !ok {
+			return nil, errors.New("type conversion error: sampling_percentage is not a number")
+		} else {
+			samplingConfig.Percentage = &val
+		}
+	}
+	if rawVal, exists := configJson["rules"]; exists {
+		rulesList, ok := rawVal.([]interface{})
+		if !ok {
+			return nil, errors.New("type conversion error: sampling rules is not an array")
+		}
+		for _, rule := range rulesList {
+			ruleMap, ok := rule.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("type conversion error: sampling rule is not an object")
+			}
+			ruleConfig := rules.SamplingRule{}
+			if selectors, ok := ruleMap["selectors"].([]interface{}); ok {
+				ruleConfig.Selectors = getServiceSelectors(selectors)
+			}
+			if statusCode, ok := ruleMap["status_code"].(string); ok {
+				ruleConfig.StatusCode = statusCode
+			}
+			if val, ok := ruleMap["sampling_percentage"].(float64); !ok {
+				return nil, errors.New("type conversion error: sampling_percentage of sampling rule is not a number")
+			} else {
+				ruleConfig.Percentage = val
+			}
+			samplingConfig.Rules = append(samplingConfig.Rules, ruleConfig)
+		}
+	}
+	return samplingConfig, nil
+}
+
 func (t *translator) translateCustomRules(conf *confmap.Conf, configKey []string, cfg *appsignalsconfig.Config) (component.Config, error) {
 	var rulesList []rules.Rule
 	rulesConfigKey := common.ConfigKey(configKey[0], common.AppSignalsRules)
@@ -194,6 +332,12 @@ func (t *translator) translateCustomRules(conf *confmap.Conf, configKey []string
 			if ruleName, ok := ruleMap["rule_name"]; ok {
 				ruleConfig.RuleName = ruleName.(string)
 			}
+			if dryRun, ok := ruleMap["dry_run"]; ok {
+				ruleConfig.DryRun = dryRun.(bool)
+			}
+			if audit, ok := ruleMap["audit"]; ok {
+				ruleConfig.Audit = audit.(bool)
+			}
 
 			var err error
 			ruleConfig.Action, err = rules.GetAllowListAction(action)
@@ -207,6 +351,21 @@ func (t *translator) translateCustomRules(conf *confmap.Conf, configKey []string
 				}
 				ruleConfig.Replacements = getServiceReplacements(replacements)
 			}
+			if ruleConfig.Action == rules.AllowListActionHash {
+				if ruleConfig.DryRun || ruleConfig.Audit {
+					return nil, errors.New("dry_run set for service rule, but only keep, drop and replace rules support it")
+				}
+				hashDimensions, ok := ruleMap["hash_dimensions"].([]interface{})
+				if !ok || len(hashDimensions) == 0 {
+					return nil, errors.New("hash action set, but no hash_dimensions defined for service rule")
+				}
+				for _, dimension := range hashDimensions {
+					ruleConfig.HashDimensions = append(ruleConfig.HashDimensions, dimension.(string))
+				}
+				if salt, ok := ruleMap["salt"].(string); ok {
+					ruleConfig.Salt = salt
+				}
+			}
 
 			rulesList = append(rulesList, ruleConfig)
 		}
@@ -224,6 +383,9 @@ func getServiceSelectors(selectorsList []interface{}) []rules.Selector {
 
 		selectorConfig.Dimension = selectorsMap["dimension"].(string)
 		selectorConfig.Match = selectorsMap["match"].(string)
+		if scope, ok := selectorsMap["scope"].(string); ok {
+			selectorConfig.Scope = scope
+		}
 		selectors = append(selectors, selectorConfig)
 	}
 	return selectors
@@ -237,6 +399,9 @@ func getServiceReplacements(replacementsList interface{}) []rules.Replacement {
 
 		replacementConfig.TargetDimension = replacementMap["target_dimension"].(string)
 		replacementConfig.Value = replacementMap["value"].(string)
+		if pattern, ok := replacementMap["pattern"].(string); ok {
+			replacementConfig.Pattern = pattern
+		}
 		replacements = append(replacements, replacementConfig)
 	}
 	return replacements
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/pipeline"
 
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
 	translatorConfig "github.com/aws/amazon-cloudwatch-agent/translator/config"
@@ -28,8 +29,16 @@ var (
 	validAppSignalsYamlK8s string
 	//go:embed testdata/config_ec2.yaml
 	validAppSignalsYamlEC2 string
+	//go:embed testdata/config_lambda.yaml
+	validAppSignalsYamlLambda string
 	//go:embed testdata/config_generic.yaml
 	validAppSignalsYamlGeneric string
+	//go:embed testdata/config_exception_metrics.yaml
+	validAppSignalsExceptionMetricsYaml string
+	//go:embed testdata/config_sampling.yaml
+	validAppSignalsSamplingYaml string
+	//go:embed testdata/config_rules_file.yaml
+	validAppSignalsRulesFileYaml string
 	//go:embed testdata/validRulesConfig.json
 	validAppSignalsRulesConfig string
 	//go:embed testdata/validRulesConfigEKS.yaml
@@ -53,6 +62,7 @@ func TestTranslate(t *testing.T) {
 		isKubernetes   bool
 		kubernetesMode string
 		mode           string
+		isLambda       bool
 	}{
 		//The config for the awsapplicationsignals processor is https://code.amazon.com/packages/AWSTracingSamplePetClinic/blobs/97ce3c409986ac8ae014de1e3fe71fdb98080f22/--/eks/appsignals/auto-instrumentation-new.yaml#L20
 		//The awsapplicationsignals processor config does not have a platform field, instead it gets added to resolvers when marshalled
@@ -113,6 +123,46 @@ func TestTranslate(t *testing.T) {
 			wantErr: errors.New("replace action set, but no replacements defined for service rule"),
 			mode:    translatorConfig.ModeOnPrem,
 		},
+		"WithAppSignalsAuditHashRule": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"app_signals": map[string]interface{}{
+							"rules": []interface{}{
+								map[string]interface{}{
+									"selectors": []interface{}{
+										map[string]interface{}{"dimension": "Operation", "match": "*"},
+									},
+									"hash_dimensions": []interface{}{"Operation"},
+									"action":          "hash",
+									"audit":           true,
+								},
+							},
+						},
+					},
+				}},
+			wantErr: errors.New("dry_run set for service rule, but only keep, drop and replace rules support it"),
+			mode:    translatorConfig.ModeOnPrem,
+		},
+		"WithAppSignalsHashRuleWithoutDimensions": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"app_signals": map[string]interface{}{
+							"rules": []interface{}{
+								map[string]interface{}{
+									"selectors": []interface{}{
+										map[string]interface{}{"dimension": "Service", "match": "*"},
+									},
+									"action": "hash",
+								},
+							},
+						},
+					},
+				}},
+			wantErr: errors.New("hash action set, but no hash_dimensions defined for service rule"),
+			mode:    translatorConfig.ModeOnPrem,
+		},
 		"WithAppSignalsEnabledEC2": {
 			input: map[string]interface{}{
 				"logs": map[string]interface{}{
@@ -125,6 +175,103 @@ func TestTranslate(t *testing.T) {
 			want: validAppSignalsYamlEC2,
 			mode: translatorConfig.ModeEC2,
 		},
+		"WithAppSignalsExceptionMetricsEnabled": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"hosted_in": "test",
+							"exception_metrics": map[string]interface{}{
+								"max_exception_types": 5.0,
+							},
+						},
+					},
+				}},
+			want: validAppSignalsExceptionMetricsYaml,
+			mode: translatorConfig.ModeEC2,
+		},
+		"WithAppSignalsSampling": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"hosted_in": "test",
+						},
+					},
+				},
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"sampling": map[string]interface{}{
+								"sampling_percentage": 5.0,
+								"rules": []interface{}{
+									map[string]interface{}{
+										"status_code":         "error",
+										"sampling_percentage": 100.0,
+									},
+									map[string]interface{}{
+										"selectors": []interface{}{
+											map[string]interface{}{
+												"dimension": "Service",
+												"match":     "checkout",
+											},
+										},
+										"sampling_percentage": 50.0,
+									},
+								},
+							},
+						},
+					},
+				}},
+			want: validAppSignalsSamplingYaml,
+			mode: translatorConfig.ModeEC2,
+		},
+		"WithInvalidAppSignalsSampling": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"sampling": map[string]interface{}{
+								"rules": []interface{}{
+									map[string]interface{}{
+										"status_code": "error",
+									},
+								},
+							},
+						},
+					},
+				}},
+			wantErr: errors.New("type conversion error: sampling_percentage of sampling rule is not a number"),
+			mode:    translatorConfig.ModeEC2,
+		},
+		"WithAppSignalsRulesFile": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"hosted_in":       "test",
+							"rules_file":      "/opt/aws/amazon-cloudwatch-agent/etc/appsignals-rules.yaml",
+							"reload_interval": 30.0,
+						},
+					},
+				}},
+			want: validAppSignalsRulesFileYaml,
+			mode: translatorConfig.ModeEC2,
+		},
+		"WithInvalidAppSignalsExceptionMetrics": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{
+							"exception_metrics": map[string]interface{}{
+								"max_exception_types": "5",
+							},
+						},
+					},
+				}},
+			wantErr: errors.New("type conversion error: max_exception_types is not a number"),
+			mode:    translatorConfig.ModeEC2,
+		},
 		"WithAppSignalsFallbackEnabledK8S": {
 			input: map[string]interface{}{
 				"logs": map[string]interface{}{
@@ -176,6 +323,19 @@ func TestTranslate(t *testing.T) {
 			want: validAppSignalsYamlEC2,
 			mode: translatorConfig.ModeEC2,
 		},
+		"WithAppSignalsEnabledLambda": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"app_signals": map[string]interface{}{
+							"hosted_in": "test",
+						},
+					},
+				}},
+			want:     validAppSignalsYamlLambda,
+			mode:     translatorConfig.ModeOnPrem,
+			isLambda: true,
+		},
 	}
 	factory := awsapplicationsignals.NewFactory()
 	for name, testCase := range testCases {
@@ -183,6 +343,9 @@ func TestTranslate(t *testing.T) {
 			if testCase.isKubernetes {
 				t.Setenv(common.KubernetesEnvVar, "TEST")
 			}
+			if testCase.isLambda {
+				t.Setenv(envconfig.AWSLambdaFunctionName, "checkout")
+			}
 			context.CurrentContext().SetKubernetesMode(testCase.kubernetesMode)
 			context.CurrentContext().SetMode(testCase.mode)
 			conf := confmap.NewFromStringMap(testCase.input)
@@ -200,3 +363,62 @@ func TestTranslate(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateAttributesOnly(t *testing.T) {
+	context.CurrentContext().SetKubernetesMode("")
+	context.CurrentContext().SetMode(translatorConfig.ModeEC2)
+	tt := NewTranslator(WithAttributesOnly("xray"))
+	assert.Equal(t, "awsapplicationsignals/xray", tt.ID().String())
+	conf := confmap.NewFromStringMap(map[string]any{
+		"traces": map[string]any{
+			"traces_collected": map[string]any{
+				"application_signals": map[string]any{
+					"sampling": map[string]any{"sampling_percentage": 10.0},
+				},
+			},
+		},
+		"logs": map[string]any{
+			"metrics_collected": map[string]any{
+				"application_signals": map[string]any{
+					"hosted_in":         "test",
+					"exception_metrics": map[string]any{},
+				},
+			},
+		},
+	})
+	got, err := tt.Translate(conf)
+	require.NoError(t, err)
+	gotCfg, ok := got.(*config.Config)
+	require.True(t, ok)
+	// only the resolvers are kept, the traces must not be sampled or counted like the Application Signals ones
+	assert.Equal(t, []config.Resolver{config.NewEC2Resolver("test")}, gotCfg.Resolvers)
+	assert.Nil(t, gotCfg.Sampling)
+	assert.Nil(t, gotCfg.ExceptionMetrics)
+	assert.Nil(t, gotCfg.Limiter)
+	assert.Empty(t, gotCfg.Rules)
+}
+
+func TestTranslateMaxOperations(t *testing.T) {
+	context.CurrentContext().SetKubernetesMode("")
+	context.CurrentContext().SetMode(translatorConfig.ModeEC2)
+	tt := NewTranslator(WithSignal(pipeline.SignalMetrics))
+	conf := confmap.NewFromStringMap(map[string]any{
+		"logs": map[string]any{
+			"metrics_collected": map[string]any{
+				"application_signals": map[string]any{
+					"limiter": map[string]any{
+						"drop_threshold": 20.0,
+						"max_operations": 50.0,
+					},
+				},
+			},
+		},
+	})
+	got, err := tt.Translate(conf)
+	require.NoError(t, err)
+	gotCfg, ok := got.(*config.Config)
+	require.True(t, ok)
+	require.NotNil(t, gotCfg.Limiter)
+	assert.Equal(t, 20, gotCfg.Limiter.Threshold)
+	assert.Equal(t, 50, gotCfg.Limiter.MaxOperations)
+}
@@ -26,11 +26,12 @@ const (
 var (
 	netKey     = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.NetKey)
 	diskioKey  = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.DiskIOKey)
+	swapKey    = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.SwapKey)
 	otlpKey    = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.OtlpKey)
 	otlpEmfKey = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.OtlpKey)
 
 	exclusions = map[string][]string{
-		// DiskIO and Net Metrics are cumulative metrics
+		// DiskIO and Net Metrics are cumulative metrics, and so are the swap in and out
 		// DiskIO: https://github.com/shirou/gopsutil/blob/master/disk/disk.go#L32-L47
 		// Net: https://github.com/shirou/gopsutil/blob/master/net/net.go#L13-L25
 		// https://github.com/aws/amazon-cloudwatch-agent/blob/5ace5aa6d817684cf82f4e6aa82d9596fb56d74b/translator/translate/metrics/util/deltasutil.go#L33-L65
@@ -39,7 +40,7 @@ var (
 )
 
 func WithDefaultKeys() common.TranslatorOption {
-	return WithConfigKeys(diskioKey, netKey, otlpKey, otlpEmfKey)
+	return WithConfigKeys(diskioKey, netKey, swapKey, otlpKey, otlpEmfKey)
 }
 
 func WithConfigKeys(keys ...string) common.TranslatorOption {
@@ -4,11 +4,14 @@
 package ec2taggerprocessor
 
 import (
+	"slices"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/processor"
+	"golang.org/x/exp/maps"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger"
@@ -17,7 +20,10 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
 )
 
-var Ec2taggerKey = common.ConfigKey(common.MetricsKey, common.AppendDimensionsKey)
+var (
+	Ec2taggerKey        = common.ConfigKey(common.MetricsKey, common.AppendDimensionsKey)
+	InstanceIdentityKey = common.ConfigKey(common.AgentKey, common.InstanceIdentityKey)
+)
 
 type translator struct {
 	name    string
@@ -48,27 +54,98 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	cfg := t.factory.CreateDefaultConfig().(*ec2tagger.Config)
 	credentials := confmap.NewFromStringMap(agent.Global_Config.Credentials)
 	_ = credentials.Unmarshal(cfg)
-	for k, v := range ec2tagger.SupportedAppendDimensions {
-		value, ok := common.GetString(conf, common.ConfigKey(Ec2taggerKey, k))
-		if ok && v == value {
-			if k == "AutoScalingGroupName" {
-				cfg.EC2InstanceTagKeys = append(cfg.EC2InstanceTagKeys, k)
+	appendDimensions, _ := conf.Get(Ec2taggerKey).(map[string]any)
+	dimensions := maps.Keys(appendDimensions)
+	sort.Strings(dimensions)
+	for _, dimension := range dimensions {
+		value, _ := appendDimensions[dimension].(string)
+		if v, ok := ec2tagger.SupportedAppendDimensions[dimension]; ok && v == value {
+			if dimension == ec2tagger.CWDimensionASG {
+				cfg.EC2InstanceTagKeys = appendTagKey(cfg.EC2InstanceTagKeys, dimension)
 			} else {
-				cfg.EC2MetadataTags = append(cfg.EC2MetadataTags, k)
+				cfg.EC2MetadataTags = append(cfg.EC2MetadataTags, dimension)
+			}
+			continue
+		}
+		// Only the tags used as dimensions are described, so that a refresh does not pull all the tags of the
+		// instance and the tagger can tell when all of them have been retrieved.
+		tagKey, ok := ec2tagger.InstanceTagKey(value)
+		if !ok {
+			continue
+		}
+		// The tagger adds the autoscaling group tag as AutoScalingGroupName.
+		if tagKey == ec2tagger.Ec2InstanceTagKeyASG {
+			tagKey = ec2tagger.CWDimensionASG
+		}
+		cfg.EC2InstanceTagKeys = appendTagKey(cfg.EC2InstanceTagKeys, tagKey)
+		if tagKey != dimension {
+			if cfg.EC2InstanceTagDimensions == nil {
+				cfg.EC2InstanceTagDimensions = map[string]string{}
 			}
+			cfg.EC2InstanceTagDimensions[tagKey] = dimension
 		}
 	}
 
 	cfg.RefreshTagsInterval = time.Duration(0)
+	if interval, ok := common.GetDuration(conf, common.ConfigKey(common.MetricsKey, common.RefreshTagsIntervalKey)); ok {
+		cfg.RefreshTagsInterval = interval
+	}
 	cfg.RefreshVolumesInterval = time.Duration(0)
-	if value, ok := common.GetString(conf, common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.DiskKey, common.AppendDimensionsKey, ec2tagger.AttributeVolumeId)); ok && value == ec2tagger.ValueAppendDimensionVolumeId {
+	diskAppendDimensionsKey := common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.DiskKey, common.AppendDimensionsKey)
+	if value, ok := common.GetString(conf, common.ConfigKey(diskAppendDimensionsKey, ec2tagger.AttributeVolumeId)); ok && value == ec2tagger.ValueAppendDimensionVolumeId {
 		cfg.RefreshVolumesInterval = 5 * time.Minute
 		cfg.EBSDeviceKeys = []string{"*"}
 		cfg.DiskDeviceTagKey = "device"
 	}
+	diskAppendDimensions, _ := conf.Get(diskAppendDimensionsKey).(map[string]any)
+	diskDimensions := maps.Keys(diskAppendDimensions)
+	sort.Strings(diskDimensions)
+	for _, dimension := range diskDimensions {
+		value, _ := diskAppendDimensions[dimension].(string)
+		tagKey, ok := ec2tagger.VolumeTagKey(value)
+		if !ok {
+			continue
+		}
+		cfg.RefreshVolumesInterval = 5 * time.Minute
+		cfg.DiskDeviceTagKey = "device"
+		cfg.EBSTagKeys = appendTagKey(cfg.EBSTagKeys, tagKey)
+		if tagKey != dimension {
+			if cfg.EBSTagDimensions == nil {
+				cfg.EBSTagDimensions = map[string]string{}
+			}
+			cfg.EBSTagDimensions[tagKey] = dimension
+		}
+	}
 
 	cfg.MiddlewareID = &agenthealth.StatusCodeID
 	cfg.IMDSRetries = retryer.GetDefaultRetryNumber()
 
+	if conf.IsSet(InstanceIdentityKey) {
+		cfg.InstanceIdentity = translateInstanceIdentity(conf)
+	}
+
 	return cfg, nil
 }
+
+// translateInstanceIdentity reads the identity of a host without IMDS. An instance ID without a region is in the
+// region of the agent, otherwise they are read from the SSM registration file when they are missing.
+func translateInstanceIdentity(conf *confmap.Conf) *ec2tagger.InstanceIdentity {
+	identity := &ec2tagger.InstanceIdentity{}
+	identity.InstanceID, _ = common.GetString(conf, common.ConfigKey(InstanceIdentityKey, "instance_id"))
+	identity.Region, _ = common.GetString(conf, common.ConfigKey(InstanceIdentityKey, "region"))
+	identity.InstanceType, _ = common.GetString(conf, common.ConfigKey(InstanceIdentityKey, "instance_type"))
+	identity.ImageID, _ = common.GetString(conf, common.ConfigKey(InstanceIdentityKey, "image_id"))
+	identity.AutoScalingGroupName, _ = common.GetString(conf, common.ConfigKey(InstanceIdentityKey, "auto_scaling_group_name"))
+	identity.RegistrationFile, _ = common.GetString(conf, common.ConfigKey(InstanceIdentityKey, "registration_file"))
+	if identity.InstanceID != "" && identity.Region == "" {
+		identity.Region = agent.Global_Config.Region
+	}
+	return identity
+}
+
+func appendTagKey(keys []string, key string) []string {
+	if slices.Contains(keys, key) {
+		return keys
+	}
+	return append(keys, key)
+}
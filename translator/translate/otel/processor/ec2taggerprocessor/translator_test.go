@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 )
 
@@ -74,7 +75,107 @@ func TestTranslator(t *testing.T) {
 				EBSDeviceKeys:          []string{"*"},
 			},
 		},
+		"WithVolumeTagAppendDimensions": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"append_dimensions": map[string]interface{}{
+						"InstanceId": "${aws:InstanceId}",
+					},
+					"metrics_collected": map[string]interface{}{
+						"disk": map[string]interface{}{
+							"append_dimensions": map[string]interface{}{
+								"MountOwner":  "${aws:VolumeTag/mount-owner}",
+								"team":        "${aws:VolumeTag/team}",
+								"environment": "prod",
+								"Wildcard":    "${aws:VolumeTag/*}",
+							},
+						},
+					},
+				},
+			},
+			want: &ec2tagger.Config{
+				RefreshTagsInterval:    0 * time.Second,
+				RefreshVolumesInterval: 5 * time.Minute,
+				EC2MetadataTags:        []string{"InstanceId"},
+				DiskDeviceTagKey:       "device",
+				EBSTagKeys:             []string{"mount-owner", "team"},
+				EBSTagDimensions:       map[string]string{"mount-owner": "MountOwner"},
+			},
+		},
+		"WithInstanceTagAppendDimensions": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"refresh_tags_interval": 300,
+					"append_dimensions": map[string]interface{}{
+						"ASG":        "${aws:Tag/aws:autoscaling:groupName}",
+						"CostCenter": "${aws:Tag/cost-center}",
+						"InstanceId": "${aws:InstanceId}",
+						"team":       "${aws:Tag/team}",
+						"Wildcard":   "${aws:Tag/*}",
+					},
+				},
+			},
+			want: &ec2tagger.Config{
+				RefreshTagsInterval:      5 * time.Minute,
+				RefreshVolumesInterval:   0 * time.Minute,
+				EC2MetadataTags:          []string{"InstanceId"},
+				EC2InstanceTagKeys:       []string{"AutoScalingGroupName", "cost-center", "team"},
+				EC2InstanceTagDimensions: map[string]string{"AutoScalingGroupName": "ASG", "cost-center": "CostCenter"},
+			},
+		},
+		"WithInstanceIdentity": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"instance_identity": map[string]interface{}{
+						"instance_id":             "mi-0123456789abcdef0",
+						"instance_type":           "on-prem",
+						"auto_scaling_group_name": "fleet",
+					},
+				},
+				"metrics": map[string]interface{}{
+					"append_dimensions": map[string]interface{}{
+						"AutoScalingGroupName": "${aws:AutoScalingGroupName}",
+						"InstanceId":           "${aws:InstanceId}",
+						"InstanceType":         "${aws:InstanceType}",
+					},
+				},
+			},
+			want: &ec2tagger.Config{
+				EC2MetadataTags:    []string{"InstanceId", "InstanceType"},
+				EC2InstanceTagKeys: []string{"AutoScalingGroupName"},
+				InstanceIdentity: &ec2tagger.InstanceIdentity{
+					InstanceID:           "mi-0123456789abcdef0",
+					Region:               "us-east-1",
+					InstanceType:         "on-prem",
+					AutoScalingGroupName: "fleet",
+				},
+			},
+		},
+		"WithInstanceIdentityFromRegistrationFile": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"instance_identity": map[string]interface{}{
+						"registration_file": "/var/lib/amazon/ssm/registration",
+					},
+				},
+				"metrics": map[string]interface{}{
+					"append_dimensions": map[string]interface{}{
+						"InstanceId": "${aws:InstanceId}",
+					},
+				},
+			},
+			want: &ec2tagger.Config{
+				EC2MetadataTags: []string{"InstanceId"},
+				InstanceIdentity: &ec2tagger.InstanceIdentity{
+					RegistrationFile: "/var/lib/amazon/ssm/registration",
+				},
+			},
+		},
 	}
+	agent.Global_Config.Region = "us-east-1"
+	t.Cleanup(func() {
+		agent.Global_Config.Region = ""
+	})
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			conf := confmap.NewFromStringMap(tc.input)
@@ -89,8 +190,12 @@ func TestTranslator(t *testing.T) {
 				sort.Strings(gotCfg.EC2MetadataTags)
 				require.Equal(t, tc.want.EC2MetadataTags, gotCfg.EC2MetadataTags)
 				require.Equal(t, tc.want.EC2InstanceTagKeys, gotCfg.EC2InstanceTagKeys)
+				require.Equal(t, tc.want.EC2InstanceTagDimensions, gotCfg.EC2InstanceTagDimensions)
+				require.Equal(t, tc.want.InstanceIdentity, gotCfg.InstanceIdentity)
 				require.Equal(t, tc.want.DiskDeviceTagKey, gotCfg.DiskDeviceTagKey)
 				require.Equal(t, tc.want.EBSDeviceKeys, gotCfg.EBSDeviceKeys)
+				require.Equal(t, tc.want.EBSTagKeys, gotCfg.EBSTagKeys)
+				require.Equal(t, tc.want.EBSTagDimensions, gotCfg.EBSTagDimensions)
 			}
 		})
 	}
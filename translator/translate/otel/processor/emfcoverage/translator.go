@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfcoverage
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/emfcoverage"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awsemf"
+)
+
+var (
+	// MetricDeclarationKey is the metric declarations of the prometheus metrics sent to CloudWatch Logs.
+	MetricDeclarationKey = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.PrometheusKey, common.EMFProcessorKey, "metric_declaration")
+	// ReportUnmatchedKey opts in to reporting the prometheus metrics matching none of the metric declarations.
+	ReportUnmatchedKey = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.PrometheusKey, common.EMFProcessorKey, "report_unmatched_metrics")
+)
+
+type translator struct {
+	name    string
+	index   int
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+// NewTranslatorWithName creates a processor that reports the prometheus metrics matching none of the metric
+// declarations of the exporter of the pipeline. The index is the emf_processor.jobs entry of the pipeline, or -1 for
+// the default prometheus pipeline.
+func NewTranslatorWithName(name string, index int) common.ComponentTranslator {
+	return &translator{name, index, emfcoverage.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// IsSet is true if emf_processor.report_unmatched_metrics is true and the exporter of the pipeline of the
+// emf_processor.jobs entry at the index has metric declarations. The entries without their own declarations use the
+// ones of the emf_processor.
+func IsSet(conf *confmap.Conf, index int) bool {
+	if conf == nil || !common.GetOrDefaultBool(conf, ReportUnmatchedKey, false) {
+		return false
+	}
+	if index != -1 {
+		if job := common.GetIndexedMap(conf, common.PrometheusEMFJobsKey, index); job != nil {
+			if _, ok := job["metric_declaration"]; ok {
+				return true
+			}
+		}
+	}
+	return conf.IsSet(MetricDeclarationKey)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !common.GetOrDefaultBool(conf, ReportUnmatchedKey, false) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: ReportUnmatchedKey}
+	}
+	if !IsSet(conf, t.index) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: MetricDeclarationKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*emfcoverage.Config)
+	declarations, err := awsemf.PrometheusMetricDeclarations(conf, t.index)
+	if err != nil {
+		return nil, err
+	}
+	cfg.MetricDeclarations = declarations
+	return cfg, nil
+}
@@ -0,0 +1,88 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfcoverage
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/emfcoverage"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	input := func(emfProcessor map[string]any) *confmap.Conf {
+		return confmap.NewFromStringMap(map[string]any{
+			"logs": map[string]any{"metrics_collected": map[string]any{"prometheus": map[string]any{
+				"emf_processor": emfProcessor,
+			}}},
+		})
+	}
+	nginx := map[string]any{"source_labels": []any{"job"}, "label_matcher": "^nginx$", "metric_selectors": []any{"^nginx_.*$"}}
+	testCases := map[string]struct {
+		input   *confmap.Conf
+		index   int
+		want    []*awsemfexporter.MetricDeclaration
+		wantErr error
+	}{
+		"WithoutReportUnmatched": {
+			input: input(map[string]any{"metric_declaration": []any{nginx}}),
+			index: -1,
+			wantErr: &common.MissingKeyError{
+				ID:      component.MustNewIDWithName("emfcoverage", "prometheus/cloudwatchlogs"),
+				JsonKey: ReportUnmatchedKey,
+			},
+		},
+		"WithoutDeclarations": {
+			input: input(map[string]any{"metric_namespace": "Prometheus", "report_unmatched_metrics": true}),
+			index: -1,
+			wantErr: &common.MissingKeyError{
+				ID:      component.MustNewIDWithName("emfcoverage", "prometheus/cloudwatchlogs"),
+				JsonKey: MetricDeclarationKey,
+			},
+		},
+		"WithDeclarations": {
+			input: input(map[string]any{"metric_declaration": []any{nginx}, "report_unmatched_metrics": true}),
+			index: -1,
+			want: []*awsemfexporter.MetricDeclaration{
+				{
+					MetricNameSelectors: []string{"^nginx_.*$"},
+					LabelMatchers:       []*awsemfexporter.LabelMatcher{{LabelNames: []string{"job"}, Regex: "^nginx$"}},
+				},
+			},
+		},
+		"WithJobDeclarations": {
+			input: input(map[string]any{
+				"jobs":                     []any{map[string]any{"job_names": []any{"nginx"}, "metric_declaration": []any{nginx}}},
+				"report_unmatched_metrics": true,
+			}),
+			index: 0,
+			want: []*awsemfexporter.MetricDeclaration{
+				{
+					MetricNameSelectors: []string{"^nginx_.*$"},
+					LabelMatchers:       []*awsemfexporter.LabelMatcher{{LabelNames: []string{"job"}, Regex: "^nginx$"}},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslatorWithName("prometheus/cloudwatchlogs", testCase.index)
+			assert.Equal(t, "emfcoverage/prometheus/cloudwatchlogs", tt.ID().String())
+			got, err := tt.Translate(testCase.input)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.wantErr == nil {
+				require.NoError(t, err)
+				cfg := got.(*emfcoverage.Config)
+				assert.Equal(t, testCase.want, cfg.MetricDeclarations)
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
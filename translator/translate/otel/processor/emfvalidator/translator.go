@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfvalidator
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/emfvalidator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var (
+	namespaceKey     = common.ConfigKey(common.EMFMetricsConfigKey, "namespace")
+	policyKey        = common.ConfigKey(common.EMFMetricsConfigKey, "policy")
+	maxDimensionsKey = common.ConfigKey(common.EMFMetricsConfigKey, "max_dimensions")
+)
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+// NewTranslatorWithName creates a processor that checks the metrics of the pipeline against the
+// limits of CloudWatch before they are sent as EMF logs.
+func NewTranslatorWithName(name string) common.ComponentTranslator {
+	return &translator{name, emfvalidator.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// IsSet is true if the EMF metrics are configured.
+func IsSet(conf *confmap.Conf) bool {
+	return conf != nil && conf.IsSet(common.EMFMetricsConfigKey)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if !IsSet(conf) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.EMFMetricsConfigKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*emfvalidator.Config)
+	if namespace, ok := common.GetString(conf, namespaceKey); ok {
+		cfg.Namespace = namespace
+	}
+	if policy, ok := common.GetString(conf, policyKey); ok {
+		cfg.Policy = policy
+	}
+	if maxDimensions, ok := common.GetNumber(conf, maxDimensionsKey); ok {
+		cfg.MaxDimensions = int(maxDimensions)
+	}
+	return cfg, nil
+}
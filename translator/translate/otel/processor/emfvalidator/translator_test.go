@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package emfvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/emfvalidator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslatorWithName("hostOtlpMetrics/cloudwatchlogs")
+	assert.EqualValues(t, "emfvalidator/hostOtlpMetrics/cloudwatchlogs", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		want    *emfvalidator.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input:   map[string]any{"logs": map[string]any{}},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: common.EMFMetricsConfigKey},
+		},
+		"WithDefaults": {
+			input: map[string]any{"logs": map[string]any{"emf_metrics": map[string]any{}}},
+			want:  &emfvalidator.Config{Policy: emfvalidator.PolicyTruncate, MaxDimensions: 30},
+		},
+		"WithAll": {
+			input: map[string]any{"logs": map[string]any{"emf_metrics": map[string]any{
+				"namespace":      "Team/Service",
+				"policy":         "drop",
+				"max_dimensions": 10,
+			}}},
+			want: &emfvalidator.Config{Namespace: "Team/Service", Policy: emfvalidator.PolicyDrop, MaxDimensions: 10},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
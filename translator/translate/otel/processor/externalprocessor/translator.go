@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package externalprocessor
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/externalprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	// ExternalProcessorKey is the section of metrics, logs or traces with the local gRPC service their batches are
+	// sent to before they are exported.
+	ExternalProcessorKey = "external_processor"
+
+	endpointKey      = "endpoint"
+	timeoutKey       = "timeout"
+	failurePolicyKey = "failure_policy"
+)
+
+type translator struct {
+	signal  pipeline.Signal
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+// NewTranslatorWithSignal creates a processor calling the external processing service configured for the signal.
+func NewTranslatorWithSignal(signal pipeline.Signal) common.ComponentTranslator {
+	return &translator{signal: signal, factory: externalprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.signal.String())
+}
+
+// IsSet returns true if the external processor is configured for the signal.
+func IsSet(conf *confmap.Conf, signal pipeline.Signal) bool {
+	return conf != nil && conf.IsSet(configKey(signal))
+}
+
+func configKey(signal pipeline.Signal) string {
+	switch signal {
+	case pipeline.SignalTraces:
+		return common.ConfigKey(common.TracesKey, ExternalProcessorKey)
+	case pipeline.SignalLogs:
+		return common.ConfigKey(common.LogsKey, ExternalProcessorKey)
+	default:
+		return common.ConfigKey(common.MetricsKey, ExternalProcessorKey)
+	}
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	key := configKey(t.signal)
+	if !IsSet(conf, t.signal) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: key}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*externalprocessor.Config)
+	cfg.Endpoint, _ = common.GetString(conf, common.ConfigKey(key, endpointKey))
+	if timeout, ok := common.GetDuration(conf, common.ConfigKey(key, timeoutKey)); ok {
+		cfg.Timeout = timeout
+	}
+	if policy, ok := common.GetString(conf, common.ConfigKey(key, failurePolicyKey)); ok {
+		cfg.FailurePolicy = policy
+	}
+	return cfg, nil
+}
@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package externalprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/externalprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	testCases := map[string]struct {
+		signal  pipeline.Signal
+		input   map[string]any
+		wantID  string
+		want    *externalprocessor.Config
+		wantErr bool
+	}{
+		"WithMissingKey": {
+			signal:  pipeline.SignalMetrics,
+			input:   map[string]any{"logs": map[string]any{"external_processor": map[string]any{"endpoint": "localhost:4320"}}},
+			wantID:  "externalprocessor/metrics",
+			wantErr: true,
+		},
+		"WithDefaults": {
+			signal: pipeline.SignalMetrics,
+			input:  map[string]any{"metrics": map[string]any{"external_processor": map[string]any{"endpoint": "localhost:4320"}}},
+			wantID: "externalprocessor/metrics",
+			want:   &externalprocessor.Config{Endpoint: "localhost:4320", Timeout: time.Second, FailurePolicy: externalprocessor.FailurePolicyPass},
+		},
+		"WithLogs": {
+			signal: pipeline.SignalLogs,
+			input: map[string]any{"logs": map[string]any{"external_processor": map[string]any{
+				"endpoint":       "unix:///run/enricher.sock",
+				"timeout":        "250ms",
+				"failure_policy": "drop",
+			}}},
+			wantID: "externalprocessor/logs",
+			want:   &externalprocessor.Config{Endpoint: "unix:///run/enricher.sock", Timeout: 250 * time.Millisecond, FailurePolicy: externalprocessor.FailurePolicyDrop},
+		},
+		"WithTraces": {
+			signal: pipeline.SignalTraces,
+			input: map[string]any{"traces": map[string]any{"external_processor": map[string]any{
+				"endpoint": "127.0.0.1:4320",
+				"timeout":  2,
+			}}},
+			wantID: "externalprocessor/traces",
+			want:   &externalprocessor.Config{Endpoint: "127.0.0.1:4320", Timeout: 2 * time.Second, FailurePolicy: externalprocessor.FailurePolicyPass},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslatorWithSignal(testCase.signal)
+			assert.Equal(t, testCase.wantID, tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			assert.Equal(t, testCase.want != nil, IsSet(conf, testCase.signal))
+			got, err := tt.Translate(conf)
+			if testCase.wantErr {
+				assert.Equal(t, &common.MissingKeyError{ID: tt.ID(), JsonKey: configKey(testCase.signal)}, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filterprocessor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+// prometheusJobLabel is the label prometheus sets to the job_name of the scrape config of the target.
+const prometheusJobLabel = "job"
+
+type prometheusJobsTranslator struct {
+	name    string
+	index   int
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*prometheusJobsTranslator)(nil)
+
+// NewPrometheusJobsTranslator creates a filter processor that only keeps the prometheus metrics of the scrape jobs in
+// the emf_processor.jobs entry at the index. An index of -1 drops the metrics of the jobs listed in any entry instead,
+// so that the default prometheus pipeline does not send them twice.
+func NewPrometheusJobsTranslator(name string, index int) common.ComponentTranslator {
+	return &prometheusJobsTranslator{name: name, index: index, factory: filterprocessor.NewFactory()}
+}
+
+func (t *prometheusJobsTranslator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+func (t *prometheusJobsTranslator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(common.PrometheusEMFJobsKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.PrometheusEMFJobsKey}
+	}
+	entries := common.GetArray[map[string]any](conf, common.PrometheusEMFJobsKey)
+	seen := map[string]int{}
+	var jobNames []string
+	for i, entry := range entries {
+		names, _ := entry[common.PrometheusJobNamesKey].([]any)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("%s[%d] does not have any %s", common.PrometheusEMFJobsKey, i, common.PrometheusJobNamesKey)
+		}
+		for _, name := range names {
+			jobName := fmt.Sprint(name)
+			if other, ok := seen[jobName]; ok && other != i {
+				return nil, fmt.Errorf("job %q is in both %s[%d] and %s[%d]", jobName, common.PrometheusEMFJobsKey, other, common.PrometheusEMFJobsKey, i)
+			}
+			seen[jobName] = i
+			if t.index == -1 || t.index == i {
+				jobNames = append(jobNames, jobName)
+			}
+		}
+	}
+	if len(jobNames) == 0 {
+		return nil, fmt.Errorf("%s does not have an entry %d", common.PrometheusEMFJobsKey, t.index)
+	}
+
+	conditions := make([]string, 0, len(jobNames))
+	for _, jobName := range jobNames {
+		conditions = append(conditions, fmt.Sprintf("attributes[%q] == %s", prometheusJobLabel, strconv.Quote(jobName)))
+	}
+	// the conditions select the data points to drop
+	condition := strings.Join(conditions, " or ")
+	if t.index != -1 {
+		condition = "not (" + condition + ")"
+	}
+	cfg := t.factory.CreateDefaultConfig().(*filterprocessor.Config)
+	c := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"datapoint": []any{condition},
+		},
+	})
+	if err := c.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal filter processor (%s): %w", t.ID(), err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,93 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filterprocessor
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestPrometheusJobsTranslator(t *testing.T) {
+	jobs := func(entries ...any) *confmap.Conf {
+		return confmap.NewFromStringMap(map[string]any{
+			"logs": map[string]any{"metrics_collected": map[string]any{"prometheus": map[string]any{
+				"emf_processor": map[string]any{"jobs": entries},
+			}}},
+		})
+	}
+	conf := jobs(
+		map[string]any{"job_names": []any{"nginx"}},
+		map[string]any{"job_names": []any{"redis", "memcached"}},
+	)
+	testCases := map[string]struct {
+		name    string
+		index   int
+		conf    *confmap.Conf
+		wantID  string
+		want    []string
+		wantErr string
+	}{
+		"Default": {
+			name:   "prometheus/cloudwatchlogs",
+			index:  -1,
+			conf:   conf,
+			wantID: "filter/prometheus/cloudwatchlogs",
+			want:   []string{`attributes["job"] == "nginx" or attributes["job"] == "redis" or attributes["job"] == "memcached"`},
+		},
+		"Job": {
+			name:   "prometheus/cloudwatchlogs/1",
+			index:  1,
+			conf:   conf,
+			wantID: "filter/prometheus/cloudwatchlogs/1",
+			want:   []string{`not (attributes["job"] == "redis" or attributes["job"] == "memcached")`},
+		},
+		"MissingJobs": {
+			name:    "prometheus/cloudwatchlogs",
+			index:   -1,
+			conf:    confmap.New(),
+			wantID:  "filter/prometheus/cloudwatchlogs",
+			wantErr: `missing key in JSON: "logs::metrics_collected::prometheus::emf_processor::jobs"`,
+		},
+		"MissingEntry": {
+			name:    "prometheus/cloudwatchlogs/2",
+			index:   2,
+			conf:    conf,
+			wantID:  "filter/prometheus/cloudwatchlogs/2",
+			wantErr: "does not have an entry 2",
+		},
+		"WithoutJobNames": {
+			name:    "prometheus/cloudwatchlogs/0",
+			index:   0,
+			conf:    jobs(map[string]any{"metric_namespace": "Nginx"}),
+			wantID:  "filter/prometheus/cloudwatchlogs/0",
+			wantErr: "jobs[0] does not have any job_names",
+		},
+		"DuplicateJob": {
+			name:    "prometheus/cloudwatchlogs/0",
+			index:   0,
+			conf:    jobs(map[string]any{"job_names": []any{"nginx"}}, map[string]any{"job_names": []any{"nginx"}}),
+			wantID:  "filter/prometheus/cloudwatchlogs/0",
+			wantErr: `job "nginx" is in both`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewPrometheusJobsTranslator(testCase.name, testCase.index)
+			assert.Equal(t, testCase.wantID, tt.ID().String())
+			got, err := tt.Translate(testCase.conf)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			cfg := got.(*filterprocessor.Config)
+			assert.Equal(t, testCase.want, cfg.Metrics.DataPointConditions)
+			assert.NoError(t, cfg.Validate())
+		})
+	}
+}
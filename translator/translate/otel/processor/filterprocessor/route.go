@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filterprocessor
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+type routeTranslator struct {
+	route   *common.Route
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*routeTranslator)(nil)
+
+// NewRouteTranslator creates a filter that only keeps the data points matching the route. If route is nil,
+// the filter instead drops the data points taken by any route that isn't copying, for the default pipelines.
+func NewRouteTranslator(route *common.Route) common.ComponentTranslator {
+	return &routeTranslator{route: route, factory: filterprocessor.NewFactory()}
+}
+
+func (t *routeTranslator) ID() component.ID {
+	if t.route == nil {
+		return component.NewIDWithName(t.factory.Type(), common.RouteNameDefault)
+	}
+	return component.NewIDWithName(t.factory.Type(), t.route.ComponentName())
+}
+
+func (t *routeTranslator) Translate(conf *confmap.Conf) (component.Config, error) {
+	routes := common.GetMetricsRoutes(conf)
+	if len(routes) == 0 {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.ConfigKey(common.MetricsKey, common.RoutesKey)}
+	}
+
+	// the filter processor drops the data points matching any of the conditions
+	var conditions []string
+	if t.route != nil {
+		conditions = append(conditions, fmt.Sprintf("not (%s)", t.route.Condition()))
+	} else {
+		for _, route := range routes {
+			if !route.Copy {
+				conditions = append(conditions, route.Condition())
+			}
+		}
+	}
+
+	cfg := t.factory.CreateDefaultConfig().(*filterprocessor.Config)
+	c := confmap.NewFromStringMap(map[string]any{
+		"error_mode": "ignore",
+		"metrics": map[string]any{
+			"datapoint": conditions,
+		},
+	})
+	if err := c.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal filter processor (%s): %w", t.ID(), err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filterprocessor
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestRouteTranslator(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"routes": []any{
+				map[string]any{
+					"name":  "team_a",
+					"match": map[string]any{"attributes": map[string]any{"team": "a"}},
+				},
+				map[string]any{
+					"name":  "copied",
+					"copy":  true,
+					"match": map[string]any{"metric_name": "^mem_"},
+				},
+			},
+		},
+	})
+	routes := common.GetMetricsRoutes(conf)
+
+	tt := NewRouteTranslator(&routes[0])
+	assert.Equal(t, "filter/route_team_a", tt.ID().String())
+	got, err := tt.Translate(conf)
+	require.NoError(t, err)
+	cfg := got.(*filterprocessor.Config)
+	assert.Equal(t, []string{`not (IsMatch(attributes["team"], "a"))`}, cfg.Metrics.DataPointConditions)
+	assert.NoError(t, cfg.Validate())
+
+	tt = NewRouteTranslator(nil)
+	assert.Equal(t, "filter/route_default", tt.ID().String())
+	got, err = tt.Translate(conf)
+	require.NoError(t, err)
+	cfg = got.(*filterprocessor.Config)
+	// copied routes stay in the default pipelines
+	assert.Equal(t, []string{`IsMatch(attributes["team"], "a")`}, cfg.Metrics.DataPointConditions)
+	assert.NoError(t, cfg.Validate())
+
+	_, err = tt.Translate(confmap.New())
+	assert.Error(t, err)
+}
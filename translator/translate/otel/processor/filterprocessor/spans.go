@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filterprocessor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	namesKey      = "names"
+	attributesKey = "attributes"
+	statusKey     = "status"
+)
+
+// DropSpansKey holds the conditions for spans that are dropped before export.
+var DropSpansKey = common.ConfigKey(common.TracesKey, "filter", "drop_spans")
+
+var spanStatusCodes = map[string]string{
+	"unset": "STATUS_CODE_UNSET",
+	"ok":    "STATUS_CODE_OK",
+	"error": "STATUS_CODE_ERROR",
+}
+
+type spanTranslator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*spanTranslator)(nil)
+
+// NewSpanTranslatorWithName creates a filter that drops the spans matching any of the names,
+// attributes or statuses in the drop_spans section.
+func NewSpanTranslatorWithName(name string) common.ComponentTranslator {
+	return &spanTranslator{name: name, factory: filterprocessor.NewFactory()}
+}
+
+func (t *spanTranslator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+func (t *spanTranslator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(DropSpansKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: DropSpansKey}
+	}
+
+	var conditions []string
+	for _, name := range common.GetArray[string](conf, common.ConfigKey(DropSpansKey, namesKey)) {
+		conditions = append(conditions, fmt.Sprintf("IsMatch(name, %q)", name))
+	}
+	if attributes, ok := conf.Get(common.ConfigKey(DropSpansKey, attributesKey)).(map[string]any); ok {
+		keys := make([]string, 0, len(attributes))
+		for key := range attributes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			conditions = append(conditions, fmt.Sprintf("IsMatch(attributes[%q], %q)", key, fmt.Sprint(attributes[key])))
+		}
+	}
+	for _, status := range common.GetArray[string](conf, common.ConfigKey(DropSpansKey, statusKey)) {
+		code, ok := spanStatusCodes[strings.ToLower(status)]
+		if !ok {
+			return nil, fmt.Errorf("invalid span status %q in %s", status, DropSpansKey)
+		}
+		conditions = append(conditions, "status.code == "+code)
+	}
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("%s must have at least one of %s, %s or %s", DropSpansKey, namesKey, attributesKey, statusKey)
+	}
+
+	cfg := t.factory.CreateDefaultConfig().(*filterprocessor.Config)
+	c := confmap.NewFromStringMap(map[string]any{
+		"error_mode": "ignore",
+		"traces": map[string]any{
+			"span": conditions,
+		},
+	})
+	if err := c.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal filter processor (%s): %w", t.ID(), err)
+	}
+	return cfg, nil
+}
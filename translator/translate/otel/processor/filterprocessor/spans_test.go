@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filterprocessor
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestSpanTranslator(t *testing.T) {
+	tt := NewSpanTranslatorWithName("xray")
+	assert.Equal(t, "filter/xray", tt.ID().String())
+
+	_, err := tt.Translate(confmap.New())
+	assert.Error(t, err)
+
+	got, err := tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"traces": map[string]any{"filter": map[string]any{"drop_spans": map[string]any{
+			"names":      []any{"^HealthCheck$"},
+			"attributes": map[string]any{"user_agent.original": "ELB-HealthChecker"},
+			"status":     []any{"Unset"},
+		}}},
+	}))
+	require.NoError(t, err)
+	cfg := got.(*filterprocessor.Config)
+	assert.Equal(t, []string{
+		`IsMatch(name, "^HealthCheck$")`,
+		`IsMatch(attributes["user_agent.original"], "ELB-HealthChecker")`,
+		"status.code == STATUS_CODE_UNSET",
+	}, cfg.Traces.SpanConditions)
+	assert.NoError(t, cfg.Validate())
+
+	_, err = tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"traces": map[string]any{"filter": map[string]any{"drop_spans": map[string]any{
+			"status": []any{"failed"},
+		}}},
+	}))
+	assert.Error(t, err)
+}
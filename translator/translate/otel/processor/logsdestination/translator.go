@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logsdestination
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/logsdestination"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awscloudwatchlogs"
+)
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+// NewTranslatorWithName creates a processor that resolves the log group and log stream of the OTLP logs from their
+// resource attributes.
+func NewTranslatorWithName(name string) common.ComponentTranslator {
+	return &translator{name, logsdestination.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	logGroupName, logStreamName, err := awscloudwatchlogs.OtlpLogsNames(t.ID(), conf)
+	if err != nil {
+		return nil, err
+	}
+	cfg := t.factory.CreateDefaultConfig().(*logsdestination.Config)
+	cfg.LogGroupName = logGroupName
+	cfg.LogStreamName = logStreamName
+	return cfg, nil
+}
@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logsdestination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/logsdestination"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslatorWithName(common.PipelineNameOtlpLogs)
+	assert.EqualValues(t, "logsdestination/otlp_logs", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		want    *logsdestination.Config
+		wantErr error
+	}{
+		"WithoutLogGroupName": {
+			input: map[string]any{"logs": map[string]any{"logs_collected": map[string]any{"otlp": map[string]any{}}}},
+			wantErr: &common.MissingKeyError{
+				ID:      tt.ID(),
+				JsonKey: common.ConfigKey(common.OtlpLogsConfigKey, common.LogGroupName),
+			},
+		},
+		"WithNames": {
+			input: map[string]any{"logs": map[string]any{"logs_collected": map[string]any{"otlp": map[string]any{
+				"log_group_name":  "/aws/otlp/{service.name}",
+				"log_stream_name": "{k8s.pod.name}",
+			}}}},
+			want: &logsdestination.Config{LogGroupName: "/aws/otlp/{service.name}", LogStreamName: "{k8s.pod.name}"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
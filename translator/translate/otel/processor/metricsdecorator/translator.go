@@ -5,6 +5,7 @@ package metricsdecorator
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -12,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/processor"
+	"golang.org/x/exp/maps"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
@@ -145,7 +147,10 @@ func (t *translator) IsSet(conf *confmap.Conf) bool {
 func (t *translator) getContextStatement(conf *confmap.Conf) (ContextStatement, error) {
 	var statements []string
 	measurementMaps := t.getMeasurementsByPlugin(conf)
-	for plugin, measurementMap := range measurementMaps {
+	plugins := maps.Keys(measurementMaps)
+	sort.Strings(plugins)
+	for _, plugin := range plugins {
+		measurementMap := measurementMaps[plugin]
 		plugin = metricsconfig.GetRealPluginName(plugin)
 		var standardizeNameFn transformFn
 		if t.configKey == defaultConfigKey {
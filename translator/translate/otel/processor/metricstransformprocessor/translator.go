@@ -6,11 +6,13 @@ package metricstransformprocessor
 import (
 	_ "embed"
 	"fmt"
+	"sort"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/processor"
+	"golang.org/x/exp/maps"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/containerinsightscommon"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
@@ -104,7 +106,10 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 			//			<additional operations>...
 			//      ]
 			//	},
-			for old, new := range renameMapForDcgm {
+			dcgmMetrics := maps.Keys(renameMapForDcgm)
+			sort.Strings(dcgmMetrics)
+			for _, old := range dcgmMetrics {
+				new := renameMapForDcgm[old]
 				var operations []map[string]interface{}
 				// convert decimals to percent
 				if new == containerinsightscommon.GpuMemUtilization {
@@ -134,7 +139,10 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 				}
 			}
 
-			for oldName, newName := range renameMapForNeuronMonitor {
+			neuronMetrics := maps.Keys(renameMapForNeuronMonitor)
+			sort.Strings(neuronMetrics)
+			for _, oldName := range neuronMetrics {
+				newName := renameMapForNeuronMonitor[oldName]
 				var operations []map[string]interface{}
 				if newName == containerinsightscommon.NeuronCoreUtilization {
 					operations = append(operations, map[string]interface{}{
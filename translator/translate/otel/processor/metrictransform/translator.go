@@ -0,0 +1,178 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metrictransform
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	// MetricTransformKey is the section of metrics holding the metrics to drop and the metrics and dimensions to
+	// rename before export.
+	MetricTransformKey = "metric_transform"
+
+	dropKey             = "drop"
+	transformsKey       = "transforms"
+	nameKey             = "name"
+	regexKey            = "regex"
+	newNameKey          = "new_name"
+	renameDimensionsKey = "rename_dimensions"
+	keepDimensionsKey   = "keep_dimensions"
+	aggregationTypeKey  = "aggregation_type"
+
+	defaultAggregationType = "sum"
+)
+
+var (
+	baseKey           = common.ConfigKey(common.MetricsKey, MetricTransformKey)
+	dropKeyPath       = common.ConfigKey(baseKey, dropKey)
+	transformsKeyPath = common.ConfigKey(baseKey, transformsKey)
+)
+
+// HasDrops is true if the metric_transform section has metrics to drop.
+func HasDrops(conf *confmap.Conf) bool {
+	return conf != nil && len(common.GetArray[any](conf, dropKeyPath)) > 0
+}
+
+// HasTransforms is true if the metric_transform section has metrics or dimensions to rename.
+func HasTransforms(conf *confmap.Conf) bool {
+	return conf != nil && len(common.GetArray[any](conf, transformsKeyPath)) > 0
+}
+
+type filterTranslator struct {
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*filterTranslator)(nil)
+
+// NewFilterTranslator creates a filter processor dropping the metrics matching a name or a regex of the drop list.
+func NewFilterTranslator() common.ComponentTranslator {
+	return &filterTranslator{factory: filterprocessor.NewFactory()}
+}
+
+func (t *filterTranslator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), MetricTransformKey)
+}
+
+func (t *filterTranslator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if !HasDrops(conf) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: dropKeyPath}
+	}
+	var conditions []string
+	for i, raw := range common.GetArray[any](conf, dropKeyPath) {
+		m, _ := raw.(map[string]any)
+		if name, ok := m[nameKey].(string); ok {
+			conditions = append(conditions, fmt.Sprintf("name == %q", name))
+		} else if regex, ok := m[regexKey].(string); ok {
+			conditions = append(conditions, fmt.Sprintf("IsMatch(name, %q)", regex))
+		} else {
+			return nil, fmt.Errorf("%s[%d] must have a %s or a %s", dropKeyPath, i, nameKey, regexKey)
+		}
+	}
+
+	cfg := t.factory.CreateDefaultConfig().(*filterprocessor.Config)
+	c := confmap.NewFromStringMap(map[string]any{
+		"error_mode": "ignore",
+		"metrics": map[string]any{
+			"metric": conditions,
+		},
+	})
+	if err := c.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal filter processor (%s): %w", t.ID(), err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", dropKeyPath, err)
+	}
+	return cfg, nil
+}
+
+type transformTranslator struct {
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*transformTranslator)(nil)
+
+// NewTransformTranslator creates a metricstransform processor renaming the metrics matching a name or a regex of
+// the transforms, and renaming or removing their dimensions.
+func NewTransformTranslator() common.ComponentTranslator {
+	return &transformTranslator{factory: metricstransformprocessor.NewFactory()}
+}
+
+func (t *transformTranslator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), MetricTransformKey)
+}
+
+func (t *transformTranslator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if !HasTransforms(conf) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: transformsKeyPath}
+	}
+	var transforms []map[string]any
+	for i, raw := range common.GetArray[any](conf, transformsKeyPath) {
+		m, _ := raw.(map[string]any)
+		transform := map[string]any{"action": "update"}
+		if name, ok := m[nameKey].(string); ok {
+			transform["include"] = name
+			transform["match_type"] = "strict"
+		} else if regex, ok := m[regexKey].(string); ok {
+			// the processor only compiles the regex when the agent starts, so it is checked here
+			if _, err := regexp.Compile(regex); err != nil {
+				return nil, fmt.Errorf("invalid %s in %s[%d]: %w", regexKey, transformsKeyPath, i, err)
+			}
+			transform["include"] = regex
+			transform["match_type"] = "regexp"
+		} else {
+			return nil, fmt.Errorf("%s[%d] must have a %s or a %s", transformsKeyPath, i, nameKey, regexKey)
+		}
+		if newName, ok := m[newNameKey].(string); ok {
+			transform["new_name"] = newName
+		}
+		// the dimensions are renamed first, so the kept dimensions are the renamed ones
+		var operations []map[string]any
+		renames, _ := m[renameDimensionsKey].(map[string]any)
+		labels := make([]string, 0, len(renames))
+		for label := range renames {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+		for _, label := range labels {
+			operations = append(operations, map[string]any{
+				"action":    "update_label",
+				"label":     label,
+				"new_label": renames[label],
+			})
+		}
+		if keep, ok := m[keepDimensionsKey].([]any); ok {
+			aggregationType, ok := m[aggregationTypeKey].(string)
+			if !ok {
+				aggregationType = defaultAggregationType
+			}
+			operations = append(operations, map[string]any{
+				"action":           "aggregate_labels",
+				"label_set":        keep,
+				"aggregation_type": aggregationType,
+			})
+		}
+		if len(operations) > 0 {
+			transform["operations"] = operations
+		}
+		transforms = append(transforms, transform)
+	}
+
+	cfg := t.factory.CreateDefaultConfig().(*metricstransformprocessor.Config)
+	c := confmap.NewFromStringMap(map[string]any{"transforms": transforms})
+	if err := c.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal metricstransform processor (%s): %w", t.ID(), err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,120 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metrictransform
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestFilterTranslator(t *testing.T) {
+	tt := NewFilterTranslator()
+	assert.Equal(t, "filter/metric_transform", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		want    []string
+		wantErr bool
+	}{
+		"WithMissingKey": {
+			input:   map[string]any{"metrics": map[string]any{"metric_transform": map[string]any{}}},
+			wantErr: true,
+		},
+		"WithoutMatcher": {
+			input: map[string]any{"metrics": map[string]any{"metric_transform": map[string]any{
+				"drop": []any{map[string]any{}},
+			}}},
+			wantErr: true,
+		},
+		"WithInvalidRegex": {
+			input: map[string]any{"metrics": map[string]any{"metric_transform": map[string]any{
+				"drop": []any{map[string]any{"regex": "diskio_("}},
+			}}},
+			wantErr: true,
+		},
+		"WithDrops": {
+			input: map[string]any{"metrics": map[string]any{"metric_transform": map[string]any{
+				"drop": []any{
+					map[string]any{"name": "cpu_usage_guest"},
+					map[string]any{"regex": `^diskio_.*_time$`},
+				},
+			}}},
+			want: []string{`name == "cpu_usage_guest"`, `IsMatch(name, "^diskio_.*_time$")`},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			cfg := got.(*filterprocessor.Config)
+			assert.Equal(t, "ignore", string(cfg.ErrorMode))
+			assert.Equal(t, testCase.want, cfg.Metrics.MetricConditions)
+		})
+	}
+}
+
+func TestTransformTranslator(t *testing.T) {
+	tt := NewTransformTranslator()
+	assert.Equal(t, "metricstransform/metric_transform", tt.ID().String())
+
+	_, err := tt.Translate(confmap.NewFromStringMap(map[string]any{"metrics": map[string]any{}}))
+	assert.Equal(t, &common.MissingKeyError{ID: tt.ID(), JsonKey: "metrics::metric_transform::transforms"}, err)
+	_, err = tt.Translate(confmap.NewFromStringMap(map[string]any{"metrics": map[string]any{"metric_transform": map[string]any{
+		"transforms": []any{map[string]any{"regex": "mem_(", "new_name": "memory"}},
+	}}}))
+	assert.Error(t, err)
+
+	got, err := tt.Translate(confmap.NewFromStringMap(map[string]any{"metrics": map[string]any{"metric_transform": map[string]any{
+		"transforms": []any{
+			map[string]any{"name": "mem_used_percent", "new_name": "MemoryUtilization"},
+			map[string]any{
+				"regex":             "^cpu_(.*)$",
+				"rename_dimensions": map[string]any{"host": "InstanceName", "cpu": "Core"},
+				"keep_dimensions":   []any{"InstanceName"},
+				"aggregation_type":  "max",
+			},
+		},
+	}}}))
+	require.NoError(t, err)
+	cfg := got.(*metricstransformprocessor.Config)
+	require.Len(t, cfg.Transforms, 2)
+
+	assert.Equal(t, "mem_used_percent", cfg.Transforms[0].MetricIncludeFilter.Include)
+	assert.EqualValues(t, "strict", cfg.Transforms[0].MetricIncludeFilter.MatchType)
+	assert.Equal(t, metricstransformprocessor.Update, cfg.Transforms[0].Action)
+	assert.Equal(t, "MemoryUtilization", cfg.Transforms[0].NewName)
+	assert.Empty(t, cfg.Transforms[0].Operations)
+
+	assert.Equal(t, "^cpu_(.*)$", cfg.Transforms[1].MetricIncludeFilter.Include)
+	assert.EqualValues(t, "regexp", cfg.Transforms[1].MetricIncludeFilter.MatchType)
+	operations := cfg.Transforms[1].Operations
+	require.Len(t, operations, 3)
+	assert.EqualValues(t, "update_label", operations[0].Action)
+	assert.Equal(t, "cpu", operations[0].Label)
+	assert.Equal(t, "Core", operations[0].NewLabel)
+	assert.Equal(t, "host", operations[1].Label)
+	assert.Equal(t, "InstanceName", operations[1].NewLabel)
+	assert.EqualValues(t, "aggregate_labels", operations[2].Action)
+	assert.Equal(t, []string{"InstanceName"}, operations[2].LabelSet)
+	assert.EqualValues(t, "max", operations[2].AggregationType)
+}
+
+func TestHasKeys(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{"metrics": map[string]any{"metric_transform": map[string]any{
+		"drop": []any{map[string]any{"name": "cpu_usage_guest"}},
+	}}})
+	assert.True(t, HasDrops(conf))
+	assert.False(t, HasTransforms(conf))
+	assert.False(t, HasDrops(nil))
+}
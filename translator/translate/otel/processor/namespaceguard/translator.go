@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package namespaceguard
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/namespaceguard"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var (
+	// AllowedNamespacesKey restricts the CloudWatch namespaces the agent publishes metrics to.
+	AllowedNamespacesKey = common.ConfigKey(common.AgentKey, "allowed_namespaces")
+)
+
+type translator struct {
+	name      string
+	namespace string
+	factory   processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+// NewTranslatorWithName creates a processor that only lets the metrics of the pipeline through if
+// the namespace they are published to is allowed.
+func NewTranslatorWithName(name string, namespace string) common.ComponentTranslator {
+	return &translator{name, namespace, namespaceguard.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// IsSet is true if the allowed namespaces are configured.
+func IsSet(conf *confmap.Conf) bool {
+	return conf != nil && conf.IsSet(AllowedNamespacesKey)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if !IsSet(conf) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: AllowedNamespacesKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*namespaceguard.Config)
+	cfg.Namespace = t.namespace
+	cfg.AllowedNamespaces = common.GetArray[string](conf, AllowedNamespacesKey)
+	return cfg, nil
+}
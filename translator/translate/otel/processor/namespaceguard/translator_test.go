@@ -0,0 +1,47 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package namespaceguard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/namespaceguard"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslatorWithName("host", "CWAgent")
+	assert.EqualValues(t, "namespaceguard/host", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		want    *namespaceguard.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input:   map[string]any{"agent": map[string]any{}},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: AllowedNamespacesKey},
+		},
+		"WithAllowedNamespaces": {
+			input: map[string]any{"agent": map[string]any{"allowed_namespaces": []any{"CWAgent", "Team/*"}}},
+			want:  &namespaceguard.Config{Namespace: "CWAgent", AllowedNamespaces: []string{"CWAgent", "Team/*"}},
+		},
+		"WithEmpty": {
+			input: map[string]any{"agent": map[string]any{"allowed_namespaces": []any{}}},
+			want:  &namespaceguard.Config{Namespace: "CWAgent"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
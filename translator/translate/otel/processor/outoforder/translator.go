@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package outoforder
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/outoforder"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var (
+	// OutOfOrderKey holds how the pushed data points older than the already published ones are handled.
+	OutOfOrderKey = common.ConfigKey(common.MetricsKey, "out_of_order")
+)
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+// NewTranslatorWithName creates a processor that drops or reorders the data points of the pipeline that are not
+// newer than the last data point of their series.
+func NewTranslatorWithName(name string) common.ComponentTranslator {
+	return &translator{name, outoforder.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// IsSet is true if an action other than accept, which leaves the data points unchanged, is configured.
+func IsSet(conf *confmap.Conf) bool {
+	if conf == nil || !conf.IsSet(OutOfOrderKey) {
+		return false
+	}
+	action, _ := common.GetString(conf, common.ConfigKey(OutOfOrderKey, "action"))
+	return action != "" && action != outoforder.ActionAccept
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if !IsSet(conf) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: OutOfOrderKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*outoforder.Config)
+	cfg.Action, _ = common.GetString(conf, common.ConfigKey(OutOfOrderKey, "action"))
+	if value, ok := common.GetNumber(conf, common.ConfigKey(OutOfOrderKey, "reorder_window")); ok {
+		cfg.ReorderWindow = time.Duration(value * float64(time.Second))
+	}
+	return cfg, nil
+}
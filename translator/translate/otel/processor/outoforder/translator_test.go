@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package outoforder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/outoforder"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslatorWithName("hostOtlpMetrics")
+	assert.EqualValues(t, "outoforder/hostOtlpMetrics", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		want    *outoforder.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input:   map[string]any{"metrics": map[string]any{}},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: OutOfOrderKey},
+		},
+		"WithAccept": {
+			input:   map[string]any{"metrics": map[string]any{"out_of_order": map[string]any{"action": "accept"}}},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: OutOfOrderKey},
+		},
+		"WithDrop": {
+			input: map[string]any{"metrics": map[string]any{"out_of_order": map[string]any{"action": "drop"}}},
+			want:  &outoforder.Config{Action: outoforder.ActionDrop, ReorderWindow: 10 * time.Second},
+		},
+		"WithReorder": {
+			input: map[string]any{"metrics": map[string]any{"out_of_order": map[string]any{
+				"action":         "reorder",
+				"reorder_window": 2.5,
+			}}},
+			want: &outoforder.Config{Action: outoforder.ActionReorder, ReorderWindow: 2500 * time.Millisecond},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
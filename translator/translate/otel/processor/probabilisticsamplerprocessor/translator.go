@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package probabilisticsamplerprocessor
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+// SamplingRatioKey holds the ratio of traces, from 0 to 1, that are kept.
+var SamplingRatioKey = common.ConfigKey(common.TracesKey, "sampling_ratio")
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslatorWithName(name string) common.ComponentTranslator {
+	return &translator{name: name, factory: probabilisticsamplerprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// Translate creates a probabilistic sampler processor that keeps the configured ratio of traces. The
+// proportional mode decides from the randomness of the trace ID, so every agent keeps or drops all
+// the spans of the same trace and the sampling is uniform across services.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(SamplingRatioKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: SamplingRatioKey}
+	}
+	ratio, ok := common.GetNumber(conf, SamplingRatioKey)
+	if !ok || ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("%s must be a number between 0 and 1", SamplingRatioKey)
+	}
+	cfg := t.factory.CreateDefaultConfig().(*probabilisticsamplerprocessor.Config)
+	cfg.SamplingPercentage = float32(ratio * 100)
+	cfg.Mode = probabilisticsamplerprocessor.Proportional
+	return cfg, nil
+}
@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package probabilisticsamplerprocessor
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslatorWithName("xray")
+	assert.Equal(t, "probabilistic_sampler/xray", tt.ID().String())
+
+	_, err := tt.Translate(confmap.New())
+	assert.Error(t, err)
+
+	got, err := tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"traces": map[string]any{"sampling_ratio": 0.25},
+	}))
+	require.NoError(t, err)
+	cfg := got.(*probabilisticsamplerprocessor.Config)
+	assert.EqualValues(t, 25, cfg.SamplingPercentage)
+	assert.Equal(t, probabilisticsamplerprocessor.Proportional, cfg.Mode)
+	assert.NoError(t, cfg.Validate())
+
+	for _, ratio := range []any{1.5, -0.1, "half"} {
+		_, err = tt.Translate(confmap.NewFromStringMap(map[string]any{
+			"traces": map[string]any{"sampling_ratio": ratio},
+		}))
+		assert.Error(t, err, ratio)
+	}
+}
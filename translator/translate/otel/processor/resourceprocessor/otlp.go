@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resourceprocessor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourceprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/otlp"
+)
+
+type otlpTranslator struct {
+	name             string
+	configKey        string
+	otlpPipelineName string
+	factory          processor.Factory
+}
+
+var _ common.ComponentTranslator = (*otlpTranslator)(nil)
+
+// NewOtlpTranslator creates a processor that sets the resource attributes of the otlp entries at the config key that
+// are routed to the named pipeline.
+func NewOtlpTranslator(name string, configKey string, otlpPipelineName string) common.ComponentTranslator {
+	return &otlpTranslator{
+		name:             name,
+		configKey:        configKey,
+		otlpPipelineName: otlpPipelineName,
+		factory:          resourceprocessor.NewFactory(),
+	}
+}
+
+func (t *otlpTranslator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+func (t *otlpTranslator) Translate(conf *confmap.Conf) (component.Config, error) {
+	resourceAttributes, err := otlp.ResourceAttributes(conf, t.configKey, t.otlpPipelineName)
+	if err != nil {
+		return nil, err
+	}
+	if len(resourceAttributes) == 0 {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.ConfigKey(t.configKey, "resource_attributes")}
+	}
+	keys := make([]string, 0, len(resourceAttributes))
+	for key := range resourceAttributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attributes := make([]any, 0, len(keys))
+	for _, key := range keys {
+		attributes = append(attributes, map[string]any{
+			"action": "upsert",
+			"key":    key,
+			"value":  resourceAttributes[key],
+		})
+	}
+
+	cfg := t.factory.CreateDefaultConfig().(*resourceprocessor.Config)
+	c := confmap.NewFromStringMap(map[string]any{
+		"attributes": attributes,
+	})
+	if err := c.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal resource processor (%s): %w", t.ID(), err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resourceprocessor
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourceprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestOtlpTranslator(t *testing.T) {
+	configKey := common.ConfigKey(common.TracesKey, common.TracesCollectedKey, common.OtlpKey)
+	tt := NewOtlpTranslator("xray/payments", configKey, "payments")
+	assert.Equal(t, "resource/xray/payments", tt.ID().String())
+	testCases := map[string]struct {
+		input   []any
+		want    []map[string]any
+		wantErr bool
+	}{
+		"WithResourceAttributes": {
+			input: []any{
+				map[string]any{"name": "payments", "grpc_endpoint": "0.0.0.0:5317", "resource_attributes": map[string]any{
+					"team":                   "payments",
+					"deployment.environment": "prod",
+				}},
+				map[string]any{"grpc_endpoint": "127.0.0.1:4317"},
+			},
+			want: []map[string]any{
+				{"action": "upsert", "key": "deployment.environment", "value": "prod"},
+				{"action": "upsert", "key": "team", "value": "payments"},
+			},
+		},
+		"WithoutResourceAttributes": {
+			input:   []any{map[string]any{"name": "payments"}},
+			wantErr: true,
+		},
+		"WithMismatchedResourceAttributes": {
+			input: []any{
+				map[string]any{"name": "payments", "resource_attributes": map[string]any{"team": "payments"}},
+				map[string]any{"name": "payments", "resource_attributes": map[string]any{"team": "billing"}},
+			},
+			wantErr: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(map[string]any{
+				"traces": map[string]any{"traces_collected": map[string]any{"otlp": testCase.input}},
+			})
+			got, err := tt.Translate(conf)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			cfg := got.(*resourceprocessor.Config)
+			require.Len(t, cfg.AttributesActions, len(testCase.want))
+			for i, want := range testCase.want {
+				assert.EqualValues(t, want["action"], cfg.AttributesActions[i].Action)
+				assert.Equal(t, want["key"], cfg.AttributesActions[i].Key)
+				assert.Equal(t, want["value"], cfg.AttributesActions[i].Value)
+			}
+		})
+	}
+}
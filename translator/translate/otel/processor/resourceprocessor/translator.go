@@ -6,6 +6,7 @@ package resourceprocessor
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -13,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/processor"
+	"golang.org/x/exp/maps"
 
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
@@ -101,12 +103,14 @@ func (t *translator) getJMXAttributes(conf *confmap.Conf) []any {
 	if !ok {
 		return nil
 	}
+	keys := maps.Keys(appendDimensions)
+	sort.Strings(keys)
 	var attributes []any
-	for key, value := range appendDimensions {
+	for _, key := range keys {
 		attributes = append(attributes, map[string]any{
 			"action": "upsert",
 			"key":    key,
-			"value":  value,
+			"value":  appendDimensions[key],
 		})
 	}
 	return attributes
@@ -34,8 +34,13 @@ func (t *translator) ID() component.ID {
 	return component.NewIDWithName(t.factory.Type(), t.name)
 }
 
+// IsSet is true if the metrics are rolled up or their series are ended once they stop reporting.
+func IsSet(conf *confmap.Conf) bool {
+	return conf != nil && (conf.IsSet(common.MetricsAggregationDimensionsKey) || conf.IsSet(common.MetricsStalenessKey))
+}
+
 func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
-	if conf == nil || !conf.IsSet(common.MetricsAggregationDimensionsKey) {
+	if !IsSet(conf) {
 		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.MetricsAggregationDimensionsKey}
 	}
 	cfg := t.factory.CreateDefaultConfig().(*rollupprocessor.Config)
@@ -46,5 +51,6 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		cfg.DropOriginal = maps.Keys(dropOriginalMetrics)
 		sort.Strings(cfg.DropOriginal)
 	}
+	cfg.Staleness = common.GetStaleness(conf)
 	return cfg, nil
 }
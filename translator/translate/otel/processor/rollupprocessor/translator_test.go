@@ -6,12 +6,14 @@ package rollupprocessor
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+	"github.com/aws/amazon-cloudwatch-agent/internal/staleness"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/testutil"
 	"github.com/aws/amazon-cloudwatch-agent/processor/rollupprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
@@ -43,6 +45,30 @@ func TestTranslator(t *testing.T) {
 				CacheSize:       1000,
 			},
 		},
+		"WithOnlyStaleness": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"staleness": map[string]interface{}{"policy": "absent"},
+				},
+			},
+			want: &rollupprocessor.Config{
+				CacheSize: 1000,
+				Staleness: staleness.Config{Policy: staleness.PolicyAbsent, Timeout: staleness.DefaultTimeout},
+			},
+		},
+		"WithStalenessTimeout": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"aggregation_dimensions": []interface{}{[]interface{}{"d1"}},
+					"staleness":              map[string]interface{}{"policy": "zero", "timeout": 120},
+				},
+			},
+			want: &rollupprocessor.Config{
+				AttributeGroups: [][]string{{"d1"}},
+				CacheSize:       1000,
+				Staleness:       staleness.Config{Policy: staleness.PolicyZero, Timeout: 2 * time.Minute},
+			},
+		},
 		"WithFull": {
 			input: testutil.GetJson(t, filepath.Join("testdata", "config.json")),
 			want: &rollupprocessor.Config{
@@ -63,6 +89,7 @@ func TestTranslator(t *testing.T) {
 				require.True(t, ok)
 				assert.Equal(t, testCase.want.AttributeGroups, gotCfg.AttributeGroups)
 				assert.Equal(t, testCase.want.DropOriginal, gotCfg.DropOriginal)
+				assert.Equal(t, testCase.want.Staleness, gotCfg.Staleness)
 			}
 		})
 	}
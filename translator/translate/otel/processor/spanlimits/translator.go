@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spanlimits
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/spanlimits"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var (
+	// LimitsKey holds the limits enforced on the spans before they are exported.
+	LimitsKey = common.ConfigKey(common.TracesKey, "limits")
+)
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+// NewTranslatorWithName creates a processor that truncates the attribute values and drops the attributes and
+// events of spans exceeding the configured limits.
+func NewTranslatorWithName(name string) common.ComponentTranslator {
+	return &translator{name, spanlimits.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// IsSet is true if the span limits are configured.
+func IsSet(conf *confmap.Conf) bool {
+	return conf != nil && conf.IsSet(LimitsKey)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if !IsSet(conf) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: LimitsKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*spanlimits.Config)
+	if value, ok := common.GetNumber(conf, common.ConfigKey(LimitsKey, "max_attribute_value_length")); ok {
+		cfg.MaxAttributeValueLength = int(value)
+	}
+	if value, ok := common.GetNumber(conf, common.ConfigKey(LimitsKey, "max_attributes_per_span")); ok {
+		cfg.MaxAttributesPerSpan = int(value)
+	}
+	if value, ok := common.GetNumber(conf, common.ConfigKey(LimitsKey, "max_events_per_span")); ok {
+		cfg.MaxEventsPerSpan = int(value)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spanlimits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/spanlimits"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslatorWithName("xray")
+	assert.EqualValues(t, "spanlimits/xray", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		want    *spanlimits.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input:   map[string]any{"traces": map[string]any{}},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: LimitsKey},
+		},
+		"WithLimits": {
+			input: map[string]any{"traces": map[string]any{"limits": map[string]any{
+				"max_attribute_value_length": 4096.0,
+				"max_attributes_per_span":    128,
+				"max_events_per_span":        64,
+			}}},
+			want: &spanlimits.Config{MaxAttributeValueLength: 4096, MaxAttributesPerSpan: 128, MaxEventsPerSpan: 64},
+		},
+		"WithPartialLimits": {
+			input: map[string]any{"traces": map[string]any{"limits": map[string]any{
+				"max_events_per_span": 10,
+			}}},
+			want: &spanlimits.Config{MaxEventsPerSpan: 10},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tailsamplingprocessor
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	attributesKey   = "attributes"
+	decisionWaitKey = "decision_wait"
+
+	defaultDecisionWait = 10 * time.Second
+)
+
+// DropTracesKey holds the attributes that cause the entire trace to be dropped, for example
+// the path of health check requests.
+var DropTracesKey = common.ConfigKey(common.TracesKey, "filter", "drop_traces")
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslatorWithName(name string) common.ComponentTranslator {
+	return &translator{name: name, factory: tailsamplingprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// Translate creates a tail sampling processor that keeps every trace except the ones with a span
+// matching one of the attributes. Each attribute is an inverted policy, which takes precedence over
+// the always_sample policy.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(DropTracesKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: DropTracesKey}
+	}
+	attributes, _ := conf.Get(common.ConfigKey(DropTracesKey, attributesKey)).(map[string]any)
+	if len(attributes) == 0 {
+		return nil, fmt.Errorf("%s must have at least one attribute", DropTracesKey)
+	}
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	policies := []any{
+		map[string]any{"name": "keep", "type": "always_sample"},
+	}
+	for _, key := range keys {
+		policies = append(policies, map[string]any{
+			"name": "drop_" + key,
+			"type": "string_attribute",
+			"string_attribute": map[string]any{
+				"key":                    key,
+				"values":                 []string{fmt.Sprint(attributes[key])},
+				"enabled_regex_matching": true,
+				"invert_match":           true,
+			},
+		})
+	}
+
+	decisionWait := defaultDecisionWait
+	if wait, ok := common.GetNumber(conf, common.ConfigKey(DropTracesKey, decisionWaitKey)); ok {
+		decisionWait = time.Duration(wait * float64(time.Second))
+	}
+
+	cfg := t.factory.CreateDefaultConfig().(*tailsamplingprocessor.Config)
+	c := confmap.NewFromStringMap(map[string]any{
+		"decision_wait": decisionWait,
+		"policies":      policies,
+	})
+	if err := c.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal tail sampling processor (%s): %w", t.ID(), err)
+	}
+	return cfg, nil
+}
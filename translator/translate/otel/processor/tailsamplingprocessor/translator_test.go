@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslatorWithName("xray")
+	assert.Equal(t, "tail_sampling/xray", tt.ID().String())
+
+	_, err := tt.Translate(confmap.New())
+	assert.Error(t, err)
+
+	got, err := tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"traces": map[string]any{"filter": map[string]any{"drop_traces": map[string]any{
+			"attributes":    map[string]any{"url.path": "^/health", "http.target": "^/ping$"},
+			"decision_wait": 5,
+		}}},
+	}))
+	require.NoError(t, err)
+	cfg := got.(*tailsamplingprocessor.Config)
+	assert.Equal(t, 5*time.Second, cfg.DecisionWait)
+	require.Len(t, cfg.PolicyCfgs, 3)
+	assert.EqualValues(t, "always_sample", cfg.PolicyCfgs[0].Type)
+	assert.Equal(t, "drop_http.target", cfg.PolicyCfgs[1].Name)
+	assert.True(t, cfg.PolicyCfgs[1].StringAttributeCfg.InvertMatch)
+	assert.True(t, cfg.PolicyCfgs[1].StringAttributeCfg.EnabledRegexMatching)
+	assert.Equal(t, []string{"^/health"}, cfg.PolicyCfgs[2].StringAttributeCfg.Values)
+
+	_, err = tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"traces": map[string]any{"filter": map[string]any{"drop_traces": map[string]any{}}},
+	}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package transformprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	// TransformKey is the section of metrics or traces holding user provided OTTL statements.
+	TransformKey = "transform"
+
+	// experimentalKey has to be set to acknowledge the statements are not validated beyond their syntax.
+	experimentalKey = "experimental"
+	errorModeKey    = "error_mode"
+	statementsKey   = "statements"
+
+	defaultErrorMode = "ignore"
+)
+
+var (
+	errNotAcknowledged = errors.New("OTTL statements are experimental, set \"experimental\": true to use them")
+)
+
+type statementsTranslator struct {
+	signal  pipeline.Signal
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*statementsTranslator)(nil)
+
+// NewTranslatorWithSignal creates a transform processor from the OTTL statements in the transform
+// section of the metrics or traces section of the JSON config.
+func NewTranslatorWithSignal(signal pipeline.Signal) common.ComponentTranslator {
+	return &statementsTranslator{signal: signal, factory: transformprocessor.NewFactory()}
+}
+
+func (t *statementsTranslator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.signal.String())
+}
+
+// IsSet returns true if the transform section is present for the signal.
+func IsSet(conf *confmap.Conf, signal pipeline.Signal) bool {
+	return conf.IsSet(configKey(signal))
+}
+
+func configKey(signal pipeline.Signal) string {
+	if signal == pipeline.SignalTraces {
+		return common.ConfigKey(common.TracesKey, TransformKey)
+	}
+	return common.ConfigKey(common.MetricsKey, TransformKey)
+}
+
+func (t *statementsTranslator) Translate(conf *confmap.Conf) (component.Config, error) {
+	key := configKey(t.signal)
+	if conf == nil || !conf.IsSet(key) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: key}
+	}
+	if experimental, _ := conf.Get(common.ConfigKey(key, experimentalKey)).(bool); !experimental {
+		return nil, fmt.Errorf("%s: %w", key, errNotAcknowledged)
+	}
+	errorMode, ok := common.GetString(conf, common.ConfigKey(key, errorModeKey))
+	if !ok {
+		errorMode = defaultErrorMode
+	}
+
+	statementsField := "metric_statements"
+	if t.signal == pipeline.SignalTraces {
+		statementsField = "trace_statements"
+	}
+	cfg := t.factory.CreateDefaultConfig().(*transformprocessor.Config)
+	c := confmap.NewFromStringMap(map[string]any{
+		errorModeKey:    errorMode,
+		statementsField: conf.Get(common.ConfigKey(key, statementsKey)),
+	})
+	if err := c.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal transform processor (%s): %w", t.ID(), err)
+	}
+	// parse the statements now so mistakes are reported by the translator instead of at agent startup
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid OTTL statements in %s: %w", key, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package transformprocessor
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestStatementsTranslator(t *testing.T) {
+	testCases := map[string]struct {
+		signal  pipeline.Signal
+		input   map[string]any
+		wantErr bool
+		check   func(t *testing.T, cfg *transformprocessor.Config)
+	}{
+		"WithMissingKey": {
+			signal:  pipeline.SignalMetrics,
+			input:   map[string]any{"metrics": map[string]any{}},
+			wantErr: true,
+		},
+		"WithoutExperimental": {
+			signal: pipeline.SignalMetrics,
+			input: map[string]any{"metrics": map[string]any{"transform": map[string]any{
+				"statements": []any{map[string]any{"context": "datapoint", "statements": []any{`set(attributes["team"], "a")`}}},
+			}}},
+			wantErr: true,
+		},
+		"WithInvalidStatement": {
+			signal: pipeline.SignalMetrics,
+			input: map[string]any{"metrics": map[string]any{"transform": map[string]any{
+				"experimental": true,
+				"statements":   []any{map[string]any{"context": "datapoint", "statements": []any{`set(attributes["team"]`}}},
+			}}},
+			wantErr: true,
+		},
+		"WithMetrics": {
+			signal: pipeline.SignalMetrics,
+			input: map[string]any{"metrics": map[string]any{"transform": map[string]any{
+				"experimental": true,
+				"statements": []any{map[string]any{
+					"context":    "datapoint",
+					"conditions": []any{`metric.name == "mem_used"`},
+					"statements": []any{`set(value_double, value_double / 1024)`},
+				}},
+			}}},
+			check: func(t *testing.T, cfg *transformprocessor.Config) {
+				assert.Equal(t, "ignore", string(cfg.ErrorMode))
+				require.Len(t, cfg.MetricStatements, 1)
+				assert.Equal(t, []string{`set(value_double, value_double / 1024)`}, cfg.MetricStatements[0].Statements)
+				assert.Empty(t, cfg.TraceStatements)
+			},
+		},
+		"WithTraces": {
+			signal: pipeline.SignalTraces,
+			input: map[string]any{"traces": map[string]any{"transform": map[string]any{
+				"experimental": true,
+				"error_mode":   "propagate",
+				"statements":   []any{map[string]any{"context": "span", "statements": []any{`set(name, "renamed") where name == "old"`}}},
+			}}},
+			check: func(t *testing.T, cfg *transformprocessor.Config) {
+				assert.Equal(t, "propagate", string(cfg.ErrorMode))
+				require.Len(t, cfg.TraceStatements, 1)
+				assert.Empty(t, cfg.MetricStatements)
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslatorWithSignal(testCase.signal)
+			assert.Equal(t, "transform/"+testCase.signal.String(), tt.ID().String())
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			testCase.check(t, got.(*transformprocessor.Config))
+		})
+	}
+}
@@ -21,6 +21,9 @@ var transformJmxConfig string
 //go:embed transform_jmx_drop_config.yaml
 var transformJmxDropConfig string
 
+//go:embed transform_app_signals_prometheus_config.yaml
+var transformAppSignalsPrometheusConfig string
+
 type translator struct {
 	name    string
 	factory processor.Factory
@@ -41,6 +44,9 @@ func (t *translator) Translate(_ *confmap.Conf) (component.Config, error) {
 	if t.name == common.PipelineNameContainerInsightsJmx {
 		return common.GetYamlFileToYamlConfig(cfg, transformJmxConfig)
 	}
+	if t.name == common.AppSignalsPrometheus {
+		return common.GetYamlFileToYamlConfig(cfg, transformAppSignalsPrometheusConfig)
+	}
 	if strings.HasPrefix(t.name, common.PipelineNameJmx) { // For JMX on EKS
 		return common.GetYamlFileToYamlConfig(cfg, transformJmxDropConfig)
 	}
@@ -34,6 +34,19 @@ func TestContainerInsightsJmx(t *testing.T) {
 	assert.Equal(t, len(expectedCfg.MetricStatements), len(actualCfg.MetricStatements))
 }
 
+func TestAppSignalsPrometheusTranslate(t *testing.T) {
+	transl := NewTranslatorWithName(common.AppSignalsPrometheus).(*translator)
+	translatedCfg, err := transl.Translate(confmap.New())
+	require.NoError(t, err)
+	actualCfg, ok := translatedCfg.(*transformprocessor.Config)
+	require.True(t, ok)
+	require.Len(t, actualCfg.MetricStatements, 2)
+	assert.EqualValues(t, "resource", actualCfg.MetricStatements[0].Context)
+	assert.EqualValues(t, "datapoint", actualCfg.MetricStatements[1].Context)
+	assert.Equal(t, []string{`replace_all_patterns(attributes, "key", "[^a-zA-Z0-9_]", "_")`}, actualCfg.MetricStatements[1].Statements)
+	assert.NoError(t, actualCfg.Validate())
+}
+
 func TestJmxTranslate(t *testing.T) {
 	translatorcontext.CurrentContext().SetOs(translatorconfig.OS_TYPE_LINUX)
 	transl := NewTranslatorWithName(common.PipelineNameJmx + "/drop").(*translator)
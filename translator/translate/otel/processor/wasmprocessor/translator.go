@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package wasmprocessor
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/wasmprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	// WasmProcessorKey is the section of metrics or logs with the WebAssembly module their batches are transformed
+	// with before they are exported.
+	WasmProcessorKey = "wasm_processor"
+
+	moduleKey         = "module"
+	memoryLimitMiBKey = "memory_limit_mib"
+	timeoutKey        = "timeout"
+	failurePolicyKey  = "failure_policy"
+)
+
+type translator struct {
+	signal  pipeline.Signal
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+// NewTranslatorWithSignal creates a processor running the WebAssembly module configured for the signal.
+func NewTranslatorWithSignal(signal pipeline.Signal) common.ComponentTranslator {
+	return &translator{signal: signal, factory: wasmprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.signal.String())
+}
+
+// IsSet returns true if a WebAssembly module is configured for the signal.
+func IsSet(conf *confmap.Conf, signal pipeline.Signal) bool {
+	return conf != nil && conf.IsSet(configKey(signal))
+}
+
+func configKey(signal pipeline.Signal) string {
+	if signal == pipeline.SignalLogs {
+		return common.ConfigKey(common.LogsKey, WasmProcessorKey)
+	}
+	return common.ConfigKey(common.MetricsKey, WasmProcessorKey)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	key := configKey(t.signal)
+	if !IsSet(conf, t.signal) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: key}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*wasmprocessor.Config)
+	cfg.Module, _ = common.GetString(conf, common.ConfigKey(key, moduleKey))
+	if limit, ok := common.GetNumber(conf, common.ConfigKey(key, memoryLimitMiBKey)); ok {
+		cfg.MemoryLimitMiB = uint32(limit)
+	}
+	if timeout, ok := common.GetDuration(conf, common.ConfigKey(key, timeoutKey)); ok {
+		cfg.Timeout = timeout
+	}
+	if policy, ok := common.GetString(conf, common.ConfigKey(key, failurePolicyKey)); ok {
+		cfg.FailurePolicy = policy
+	}
+	return cfg, nil
+}
@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package wasmprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/wasmprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	testCases := map[string]struct {
+		signal  pipeline.Signal
+		input   map[string]any
+		wantID  string
+		want    *wasmprocessor.Config
+		wantErr bool
+	}{
+		"WithMissingKey": {
+			signal:  pipeline.SignalMetrics,
+			input:   map[string]any{"logs": map[string]any{"wasm_processor": map[string]any{"module": "/opt/transforms/parse.wasm"}}},
+			wantID:  "wasmprocessor/metrics",
+			wantErr: true,
+		},
+		"WithDefaults": {
+			signal: pipeline.SignalMetrics,
+			input:  map[string]any{"metrics": map[string]any{"wasm_processor": map[string]any{"module": "/opt/transforms/rename.wasm"}}},
+			wantID: "wasmprocessor/metrics",
+			want: &wasmprocessor.Config{
+				Module:         "/opt/transforms/rename.wasm",
+				MemoryLimitMiB: 16,
+				Timeout:        time.Second,
+				FailurePolicy:  wasmprocessor.FailurePolicyPass,
+			},
+		},
+		"WithLogs": {
+			signal: pipeline.SignalLogs,
+			input: map[string]any{"logs": map[string]any{"wasm_processor": map[string]any{
+				"module":           "/opt/transforms/parse.wasm",
+				"memory_limit_mib": 64,
+				"timeout":          "250ms",
+				"failure_policy":   "drop",
+			}}},
+			wantID: "wasmprocessor/logs",
+			want: &wasmprocessor.Config{
+				Module:         "/opt/transforms/parse.wasm",
+				MemoryLimitMiB: 64,
+				Timeout:        250 * time.Millisecond,
+				FailurePolicy:  wasmprocessor.FailurePolicyDrop,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslatorWithSignal(testCase.signal)
+			assert.Equal(t, testCase.wantID, tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			assert.Equal(t, testCase.want != nil, IsSet(conf, testCase.signal))
+			got, err := tt.Translate(conf)
+			if testCase.wantErr {
+				assert.Equal(t, &common.MissingKeyError{ID: tt.ID(), JsonKey: configKey(testCase.signal)}, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
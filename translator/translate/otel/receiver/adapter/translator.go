@@ -4,12 +4,14 @@
 package adapter
 
 import (
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/schedule"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
@@ -31,6 +33,14 @@ type translator struct {
 	// defaultMetricCollectionInterval is the fallback interval if it
 	// it is not present in the interval keychain.
 	defaultMetricCollectionInterval time.Duration
+
+	// preferCollectionWindows are used instead of the collection windows
+	// in the section for inputs that share a section (e.g. procstat).
+	preferCollectionWindows any
+
+	// preferNamespace is used instead of the namespace in the section for
+	// inputs that share a section (e.g. procstat).
+	preferNamespace string
 }
 
 var _ common.ComponentTranslator = (*translator)(nil)
@@ -41,7 +51,35 @@ func NewTranslator(inputName, cfgKey string, defaultMetricCollectionInterval tim
 }
 
 func NewTranslatorWithName(name, inputName, cfgKey string, preferMetricCollectionInterval, defaultMetricCollectionInterval time.Duration) common.ComponentTranslator {
-	return &translator{name, adapter.Type(inputName), cfgKey, preferMetricCollectionInterval, defaultMetricCollectionInterval}
+	return newTranslator(name, inputName, cfgKey, preferMetricCollectionInterval, defaultMetricCollectionInterval, nil)
+}
+
+func newTranslator(name, inputName, cfgKey string, preferMetricCollectionInterval, defaultMetricCollectionInterval time.Duration, preferCollectionWindows any) *translator {
+	return &translator{
+		name:                            name,
+		cfgType:                         adapter.Type(inputName),
+		cfgKey:                          cfgKey,
+		preferMetricCollectionInterval:  preferMetricCollectionInterval,
+		defaultMetricCollectionInterval: defaultMetricCollectionInterval,
+		preferCollectionWindows:         preferCollectionWindows,
+	}
+}
+
+// Namespace returns the CloudWatch namespace the input of the adapter receiver translator publishes to instead of
+// metrics.namespace, or "" if it does not set one or is not an adapter receiver translator.
+func Namespace(conf *confmap.Conf, componentTranslator common.ComponentTranslator) string {
+	t, ok := componentTranslator.(*translator)
+	if !ok {
+		return ""
+	}
+	if t.preferNamespace != "" {
+		return t.preferNamespace
+	}
+	if conf == nil {
+		return ""
+	}
+	namespace, _ := conf.Get(common.ConfigKey(t.cfgKey, common.NamespaceKey)).(string)
+	return namespace
 }
 
 func (t *translator) ID() component.ID {
@@ -57,6 +95,7 @@ func (t *translator) ID() component.ID {
 // Translate creates an adapter receiver config if the section set on
 // the translator exists. Tries to get the collection interval from
 // the section key. Falls back on the agent section if it is not present.
+// Collection windows are only read from the section.
 func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if conf == nil || !conf.IsSet(t.cfgKey) {
 		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: t.cfgKey}
@@ -80,5 +119,23 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		cfg.CollectionInterval = common.GetOrDefaultDuration(conf, intervalKeyChain, t.defaultMetricCollectionInterval)
 	}
 
+	collectionWindows := t.preferCollectionWindows
+	if collectionWindows == nil {
+		collectionWindows = conf.Get(common.ConfigKey(t.cfgKey, common.CollectionWindowsKey))
+	}
+	if windows, ok := collectionWindows.([]any); ok {
+		var parsed struct {
+			CollectionWindows []schedule.Window `mapstructure:"collection_windows"`
+		}
+		c := confmap.NewFromStringMap(map[string]any{common.CollectionWindowsKey: windows})
+		if err := c.Unmarshal(&parsed); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal collection windows (%s): %w", t.ID(), err)
+		}
+		if err := schedule.Validate(parsed.CollectionWindows); err != nil {
+			return nil, fmt.Errorf("invalid collection windows (%s): %w", t.ID(), err)
+		}
+		cfg.CollectionWindows = parsed.CollectionWindows
+	}
+
 	return cfg, nil
 }
@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/schedule"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
@@ -26,6 +27,7 @@ func TestTranslator(t *testing.T) {
 		cfgPreferInterval time.Duration
 		wantErr           error
 		wantInterval      time.Duration
+		wantWindows       []schedule.Window
 	}{
 		"WithoutKeyInConfig": {
 			input:   map[string]interface{}{},
@@ -99,6 +101,30 @@ func TestTranslator(t *testing.T) {
 			cfgPreferInterval: time.Duration(0),
 			wantInterval:      10 * time.Second,
 		},
+		"WithCollectionWindows": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"procstat": map[string]interface{}{
+							"collection_windows": []interface{}{
+								map[string]interface{}{
+									"days":     []interface{}{"mon", "fri"},
+									"start":    "09:00",
+									"end":      "17:00",
+									"timezone": "America/New_York",
+								},
+							},
+						},
+					},
+				},
+			},
+			cfgType:      "test",
+			cfgKey:       "metrics::metrics_collected::procstat",
+			wantInterval: time.Minute,
+			wantWindows: []schedule.Window{
+				{Days: []string{"mon", "fri"}, Start: "09:00", End: "17:00", Timezone: "America/New_York"},
+			},
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -114,7 +140,24 @@ func TestTranslator(t *testing.T) {
 				require.Equal(t, adapter.Type(testCase.cfgType), tt.ID().Type())
 				require.Equal(t, testCase.wantInterval, gotCfg.CollectionInterval)
 				require.Equal(t, testCase.cfgName, gotCfg.AliasName)
+				require.Equal(t, testCase.wantWindows, gotCfg.CollectionWindows)
 			}
 		})
 	}
 }
+
+func TestTranslatorWithInvalidCollectionWindows(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]interface{}{
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{
+					"collection_windows": []interface{}{
+						map[string]interface{}{"start": "09:00", "end": "09:00"},
+					},
+				},
+			},
+		},
+	})
+	_, err := NewTranslator("test", "metrics::metrics_collected::cpu", time.Minute).Translate(conf)
+	require.ErrorContains(t, err, "start and end must differ")
+}
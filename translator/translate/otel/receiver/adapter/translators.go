@@ -6,21 +6,33 @@ package adapter
 import (
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+	"golang.org/x/exp/maps"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	translatorconfig "github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/auditd"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/connection_summary"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/files"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/kernel_events"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/windows_events"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/certificates"
 	collectd "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/collectd"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/customizedmetrics"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/file_stats"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/gpu"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/http_json"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/job_heartbeat"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/network_mesh"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/procstat"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/smart"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/statsd"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/windows_security"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 )
 
@@ -31,7 +43,7 @@ const (
 var (
 	logKey           = common.ConfigKey(common.LogsKey, common.LogsCollectedKey)
 	metricKey        = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey)
-	skipInputSet     = collections.NewSet[string](files.SectionKey, windows_events.SectionKey)
+	skipInputSet     = collections.NewSet[string](files.SectionKey, windows_events.SectionKey, connection_summary.SectionKey, kernel_events.SectionKey, auditd.SectionKey)
 	multipleInputSet = collections.NewSet[string](procstat.SectionKey)
 	// Order by PidFile, ExeKey, Pattern Key according to the public documents
 	// if multiple configuration is specified
@@ -44,8 +56,15 @@ var (
 	// windowsInputSet contains all the supported metric input plugins. All others are considered custom metrics.
 	// An exception would be procstat metrics
 	windowsInputSet = collections.NewSet[string](
+		certificates.SectionKey,
+		file_stats.SectionKey,
 		gpu.SectionKey,
+		http_json.SectionKey,
+		job_heartbeat.SectionKey,
+		network_mesh.SectionKey,
+		smart.SectionKey,
 		statsd.SectionKey,
+		windows_security.SectionKey,
 	)
 	// skipWindowsInputSet contains all the supported metric input plugins that should not be included in telegraf windows plugins
 	skipWindowsInputSet = collections.NewSet[string](
@@ -87,7 +106,7 @@ func FindReceiversInConfig(conf *confmap.Conf, os string) (common.TranslatorMap[
 func fromMetrics(conf *confmap.Conf, os string) (common.TranslatorMap[component.Config, component.ID], error) {
 	translators := common.NewTranslatorMap[component.Config, component.ID]()
 	switch os {
-	case translatorconfig.OS_TYPE_LINUX, translatorconfig.OS_TYPE_DARWIN:
+	case translatorconfig.OS_TYPE_LINUX, translatorconfig.OS_TYPE_DARWIN, translatorconfig.OS_TYPE_FREEBSD:
 		translators.Merge(fromLinuxMetrics(conf))
 	case translatorconfig.OS_TYPE_WINDOWS:
 		translators.Merge(fromWindowsMetrics(conf))
@@ -116,7 +135,7 @@ func fromLinuxMetrics(conf *confmap.Conf) common.TranslatorMap[component.Config,
 func fromWindowsMetrics(conf *confmap.Conf) common.TranslatorMap[component.Config, component.ID] {
 	translators := common.NewTranslatorMap[component.Config, component.ID]()
 	if inputs, ok := conf.Get(metricKey).(map[string]interface{}); ok {
-		for inputName := range inputs {
+		for _, inputName := range sortedInputNames(inputs) {
 			if otelReceivers.Contains(inputName) {
 				continue
 			}
@@ -146,15 +165,16 @@ func fromLogs(conf *confmap.Conf) common.TranslatorMap[component.Config, compone
 func fromInputs(conf *confmap.Conf, validInputs map[string]bool, baseKey string) common.TranslatorMap[component.Config, component.ID] {
 	translators := common.NewTranslatorMap[component.Config, component.ID]()
 	if inputs, ok := conf.Get(baseKey).(map[string]interface{}); ok {
-		for inputName := range inputs {
+		for _, inputName := range sortedInputNames(inputs) {
 			if skipInputSet.Contains(inputName) {
 				// logs agent is separate from otel agent
 				continue
 			}
+			if otelReceivers.Contains(inputName) {
+				continue
+			}
 			if validInputs != nil {
-				if otelReceivers.Contains(inputName) {
-					continue
-				} else if _, ok := validInputs[inputName]; !ok {
+				if _, ok := validInputs[inputName]; !ok {
 					log.Printf("W! Ignoring unrecognized input %s", inputName)
 					continue
 				}
@@ -210,12 +230,15 @@ func fromMultipleInput(conf *confmap.Conf, inputName, os string) common.Translat
 			// Array type validation needs to be specific https://stackoverflow.com/a/47989212
 			for _, procstatMonitored := range procstatMonitoredSet {
 				if componentPsValue, ok := psKey[procstatMonitored]; ok {
-					translators.Set(NewTranslatorWithName(
+					t := newTranslator(
 						componentPsValue.(string),
 						procstat.SectionKey,
 						cfgKey,
 						psCollectionInterval,
-						defaultMetricsCollectionInterval))
+						defaultMetricsCollectionInterval,
+						psKey[common.CollectionWindowsKey])
+					t.preferNamespace, _ = psKey[common.NamespaceKey].(string)
+					translators.Set(t)
 					break
 				}
 			}
@@ -245,6 +268,14 @@ func fromMultipleInput(conf *confmap.Conf, inputName, os string) common.Translat
 	return translators
 }
 
+// sortedInputNames returns the names of the inputs in order, so that the receivers are in the same order in the
+// pipelines on every translation.
+func sortedInputNames(inputs map[string]interface{}) []string {
+	names := maps.Keys(inputs)
+	sort.Strings(names)
+	return names
+}
+
 // toAlias gets the alias for the input name if it has one.
 func toAlias(inputName string) string {
 	return collections.GetOrDefault(aliasMap, inputName, inputName)
@@ -118,6 +118,7 @@ func TestFindReceiversInConfig(t *testing.T) {
 					"logs_collected": map[string]interface{}{
 						"files":          map[string]interface{}{},
 						"windows_events": map[string]interface{}{},
+						"otlp":           map[string]interface{}{},
 					},
 				},
 			},
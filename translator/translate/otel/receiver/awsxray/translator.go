@@ -18,6 +18,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/xraysampling"
 )
 
 const (
@@ -55,9 +56,13 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: baseKey}
 	}
 	cfg := t.factory.CreateDefaultConfig().(*awsxrayreceiver.Config)
-	cfg.Endpoint = defaultEndpoint
-	cfg.ProxyServer.Endpoint = defaultEndpoint
-	cfg.ProxyServer.RoleARN = getRoleARN(conf)
+	cfg.Endpoint = agent.ListenAddress(defaultEndpoint)
+	cfg.ProxyServer.Endpoint = agent.ListenAddress(defaultEndpoint)
+	roleARN, err := common.GetRoleARN(conf, common.TracesKey)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ProxyServer.RoleARN = roleARN
 	cfg.ProxyServer.Region = getRegion(conf)
 	if endpoint, ok := common.GetString(conf, common.ConfigKey(baseKey, bindAddressKey)); ok {
 		cfg.Endpoint = endpoint
@@ -65,6 +70,9 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if endpoint, ok := common.GetString(conf, common.ConfigKey(baseKey, tcpProxyKey, bindAddressKey)); ok {
 		cfg.ProxyServer.Endpoint = endpoint
 	}
+	if conf.IsSet(xraysampling.SamplingDebugKey) {
+		cfg.ProxyServer.Endpoint = xraysampling.InternalEndpoint(conf)
+	}
 	if insecure, ok := common.GetBool(conf, common.ConfigKey(common.TracesKey, common.InsecureKey)); ok {
 		cfg.ProxyServer.TLSSetting.Insecure = insecure
 	}
@@ -91,15 +99,6 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	return cfg, nil
 }
 
-func getRoleARN(conf *confmap.Conf) string {
-	key := common.ConfigKey(common.TracesKey, common.CredentialsKey, common.RoleARNKey)
-	roleARN, ok := common.GetString(conf, key)
-	if !ok {
-		roleARN = agent.Global_Config.Role_arn
-	}
-	return roleARN
-}
-
 func getRegion(conf *confmap.Conf) string {
 	key := common.ConfigKey(common.TracesKey, common.RegionOverrideKey)
 	region, ok := common.GetString(conf, key)
@@ -47,6 +47,21 @@ func TestTranslator(t *testing.T) {
 				},
 			}),
 		},
+		"WithSamplingDebug": {
+			input: map[string]interface{}{"traces": map[string]interface{}{"traces_collected": map[string]interface{}{"xray": map[string]interface{}{
+				"tcp_proxy": map[string]interface{}{"sampling_debug": map[string]interface{}{}},
+			}}}},
+			want: confmap.NewFromStringMap(map[string]interface{}{
+				"endpoint":  "127.0.0.1:2000",
+				"transport": "udp",
+				"proxy_server": map[string]interface{}{
+					"endpoint":     "127.0.0.1:2001",
+					"region":       "us-east-1",
+					"role_arn":     "global_arn",
+					"imds_retries": 1,
+				},
+			}),
+		},
 		"WithCompleteConfig": {
 			input: testutil.GetJson(t, filepath.Join("testdata", "config.json")),
 			want:  testutil.GetConf(t, filepath.Join("testdata", "config.yaml")),
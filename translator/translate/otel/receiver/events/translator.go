@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/aws/amazon-cloudwatch-agent/receiver/eventsreceiver"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/localauth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+var (
+	BaseKey          = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.EventsKey)
+	httpEndpointKey  = common.ConfigKey(BaseKey, "http_endpoint")
+	logGroupNameKey  = common.ConfigKey(BaseKey, common.LogGroupName)
+	logStreamNameKey = common.ConfigKey(BaseKey, common.LogStreamName)
+	namespaceKey     = common.ConfigKey(BaseKey, "namespace")
+)
+
+const (
+	hostMetadataKey       = "host"
+	instanceIDMetadataKey = "instance_id"
+)
+
+type translator struct {
+	name    string
+	factory receiver.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+// NewTranslatorWithName creates a receiver for the events applications post to a local HTTP endpoint.
+func NewTranslatorWithName(name string) common.ComponentTranslator {
+	return &translator{name, eventsreceiver.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// Translate creates the events receiver config. The events are sent to the log stream of the logs section unless
+// the events section has its own, and are enriched with the host, and the instance ID on EC2.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(BaseKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: BaseKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*eventsreceiver.Config)
+	logGroupName, ok := common.GetString(conf, logGroupNameKey)
+	if !ok || logGroupName == "" {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: logGroupNameKey}
+	}
+	cfg.LogGroupName = util.ResolvePlaceholder(logGroupName, logs.GlobalLogConfig.MetadataInfo)
+	if logStreamName, ok := common.GetString(conf, logStreamNameKey); ok && logStreamName != "" {
+		cfg.LogStreamName = util.ResolvePlaceholder(logStreamName, logs.GlobalLogConfig.MetadataInfo)
+	} else {
+		rule := logs.LogStreamName{}
+		_, val := rule.ApplyRule(conf.Get(common.LogsKey))
+		if res, ok := val.(map[string]any); ok {
+			cfg.LogStreamName, _ = res[common.LogStreamName].(string)
+		}
+	}
+	if endpoint, ok := common.GetString(conf, httpEndpointKey); ok {
+		cfg.Endpoint = endpoint
+	}
+	if namespace, ok := common.GetString(conf, namespaceKey); ok {
+		cfg.Namespace = namespace
+	}
+	cfg.Metadata = map[string]string{}
+	if host, ok := logs.GlobalLogConfig.MetadataInfo["{hostname}"]; ok {
+		cfg.Metadata[hostMetadataKey] = host
+	}
+	if context.CurrentContext().Mode() == config.ModeEC2 {
+		if instanceID, ok := logs.GlobalLogConfig.MetadataInfo["{instance_id}"]; ok {
+			cfg.Metadata[instanceIDMetadataKey] = instanceID
+		}
+	}
+	cfg.Auth = localauth.Authentication(conf)
+	return cfg, nil
+}
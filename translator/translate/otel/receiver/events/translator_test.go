@@ -0,0 +1,98 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/receiver/eventsreceiver"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	context.ResetContext()
+	context.CurrentContext().SetMode(config.ModeEC2)
+	logs.GlobalLogConfig.MetadataInfo = map[string]string{"{instance_id}": "i-0123456789abcdef0", "{hostname}": "ip-10-0-0-1"}
+	t.Cleanup(func() {
+		logs.GlobalLogConfig.MetadataInfo = nil
+		context.ResetContext()
+	})
+	tt := NewTranslatorWithName(common.PipelineNameEmfLogs)
+	assert.EqualValues(t, "events/emf_logs", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]any
+		want    *eventsreceiver.Config
+		wantErr error
+	}{
+		"WithoutEvents": {
+			input:   map[string]any{"logs": map[string]any{"metrics_collected": map[string]any{}}},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: BaseKey},
+		},
+		"WithoutLogGroupName": {
+			input:   map[string]any{"logs": map[string]any{"metrics_collected": map[string]any{"events": map[string]any{}}}},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: logGroupNameKey},
+		},
+		"WithDefaults": {
+			input: map[string]any{"logs": map[string]any{"metrics_collected": map[string]any{"events": map[string]any{
+				"log_group_name": "/aws/events/{instance_id}",
+			}}}},
+			want: &eventsreceiver.Config{
+				Endpoint:      "127.0.0.1:25890",
+				LogGroupName:  "/aws/events/i-0123456789abcdef0",
+				LogStreamName: "i-0123456789abcdef0",
+				Namespace:     "CWAgent",
+				Metadata:      map[string]string{"host": "ip-10-0-0-1", "instance_id": "i-0123456789abcdef0"},
+			},
+		},
+		"WithAll": {
+			input: map[string]any{"logs": map[string]any{"metrics_collected": map[string]any{"events": map[string]any{
+				"http_endpoint":   "127.0.0.1:8080",
+				"log_group_name":  "/aws/events",
+				"log_stream_name": "{hostname}",
+				"namespace":       "Checkout",
+			}}}},
+			want: &eventsreceiver.Config{
+				Endpoint:      "127.0.0.1:8080",
+				LogGroupName:  "/aws/events",
+				LogStreamName: "ip-10-0-0-1",
+				Namespace:     "Checkout",
+				Metadata:      map[string]string{"host": "ip-10-0-0-1", "instance_id": "i-0123456789abcdef0"},
+			},
+		},
+		"WithLocalAuth": {
+			input: map[string]any{
+				"agent": map[string]any{"local_auth": map[string]any{}},
+				"logs": map[string]any{"metrics_collected": map[string]any{"events": map[string]any{
+					"log_group_name": "/aws/events",
+				}}},
+			},
+			want: &eventsreceiver.Config{
+				Endpoint:      "127.0.0.1:25890",
+				LogGroupName:  "/aws/events",
+				LogStreamName: "i-0123456789abcdef0",
+				Namespace:     "CWAgent",
+				Metadata:      map[string]string{"host": "ip-10-0-0-1", "instance_id": "i-0123456789abcdef0"},
+				Auth:          &configauth.Authentication{AuthenticatorID: component.MustNewID("localauth")},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlp
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	// pipelineNameKey names the pipeline an otlp entry is routed to. Entries without one share the default pipeline.
+	pipelineNameKey = "name"
+	// resourceAttributesKey holds the resource attributes set on all the data received by the entries of a named
+	// pipeline.
+	resourceAttributesKey = "resource_attributes"
+)
+
+// PipelineNames returns the sorted names of the pipelines the otlp entries at the config key are routed to,
+// excluding the default pipeline.
+func PipelineNames(conf *confmap.Conf, configKey string) []string {
+	seen := map[string]struct{}{}
+	var names []string
+	for _, entry := range entries(conf, configKey) {
+		name, _ := entry[pipelineNameKey].(string)
+		if _, ok := seen[name]; name == "" || ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewTranslators creates the receivers of the otlp entries at the config key that are routed to the named pipeline,
+// or to the default pipeline if the name is empty. Receivers of the default pipeline keep their original IDs, e.g.
+// otlp/metrics or otlp/metrics/0, while the others have the pipeline name in theirs, e.g. otlp/metrics/appsignals
+// or otlp/metrics/appsignals/0.
+func NewTranslators(conf *confmap.Conf, configKey string, signal pipeline.Signal, pipelineName string) common.TranslatorMap[component.Config, component.ID] {
+	translators := common.NewTranslatorMap[component.Config, component.ID]()
+	_, isArray := conf.Get(configKey).([]any)
+	for index, entry := range entries(conf, configKey) {
+		if name, _ := entry[pipelineNameKey].(string); name != pipelineName {
+			continue
+		}
+		opts := []common.TranslatorOption{WithSignal(signal), WithConfigKey(configKey)}
+		if isArray {
+			opts = append(opts, common.WithIndex(index))
+		}
+		if pipelineName != "" {
+			name := signal.String() + "/" + pipelineName
+			if isArray {
+				name += "/" + strconv.Itoa(index)
+			}
+			opts = append(opts, common.WithName(name))
+		}
+		translators.Set(NewTranslator(opts...))
+	}
+	return translators
+}
+
+// HasResourceAttributes is true if the otlp entries routed to the named pipeline set resource attributes.
+func HasResourceAttributes(conf *confmap.Conf, configKey string, pipelineName string) bool {
+	if pipelineName == "" {
+		return false
+	}
+	for _, entry := range entries(conf, configKey) {
+		if name, _ := entry[pipelineNameKey].(string); name == pipelineName {
+			if attributes, _ := entry[resourceAttributesKey].(map[string]any); len(attributes) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResourceAttributes returns the resource attributes of the otlp entries routed to the named pipeline. Since they
+// are set by a processor of the pipeline, all the entries of the pipeline must have the same ones.
+func ResourceAttributes(conf *confmap.Conf, configKey string, pipelineName string) (map[string]string, error) {
+	var result map[string]string
+	for _, entry := range entries(conf, configKey) {
+		if name, _ := entry[pipelineNameKey].(string); name != pipelineName || pipelineName == "" {
+			continue
+		}
+		attributes := map[string]string{}
+		if raw, ok := entry[resourceAttributesKey].(map[string]any); ok {
+			for key, value := range raw {
+				attributes[key] = fmt.Sprint(value)
+			}
+		}
+		if result == nil {
+			result = attributes
+		} else if !maps.Equal(result, attributes) {
+			return nil, fmt.Errorf("%s entries named %q must have the same %s", configKey, pipelineName, resourceAttributesKey)
+		}
+	}
+	return result, nil
+}
+
+// entries returns the otlp entries at the config key, which is either a single object or an array of them.
+func entries(conf *confmap.Conf, configKey string) []map[string]any {
+	if conf == nil {
+		return nil
+	}
+	switch v := conf.Get(configKey).(type) {
+	case []any:
+		result := make([]map[string]any, len(v))
+		for i, entry := range v {
+			result[i], _ = entry.(map[string]any)
+		}
+		return result
+	case map[string]any:
+		return []map[string]any{v}
+	case nil:
+		// an empty otlp section, e.g. "otlp": {}, which is set but has no value
+		if conf.IsSet(configKey) {
+			return []map[string]any{nil}
+		}
+	}
+	return nil
+}
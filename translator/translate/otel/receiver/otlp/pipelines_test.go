@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestPipelines(t *testing.T) {
+	configKey := common.ConfigKey(common.TracesKey, common.TracesCollectedKey, common.OtlpKey)
+	testCases := map[string]struct {
+		input     any
+		wantNames []string
+		want      map[string][]string
+	}{
+		"WithObject": {
+			input: map[string]any{"grpc_endpoint": "0.0.0.0:4317"},
+			want:  map[string][]string{"": {"otlp/traces"}},
+		},
+		"WithNamedObject": {
+			input:     map[string]any{"name": "general"},
+			wantNames: []string{"general"},
+			want:      map[string][]string{"": {}, "general": {"otlp/traces/general"}},
+		},
+		"WithArray": {
+			input: []any{
+				map[string]any{"name": "general", "grpc_endpoint": "0.0.0.0:4317", "http_endpoint": "0.0.0.0:4318"},
+				map[string]any{"grpc_endpoint": "127.0.0.1:5317", "http_endpoint": "127.0.0.1:5318"},
+				map[string]any{"name": "appsignals", "grpc_endpoint": "0.0.0.0:4315", "http_endpoint": "0.0.0.0:4316"},
+				map[string]any{"name": "general", "grpc_endpoint": "0.0.0.0:6317", "http_endpoint": "0.0.0.0:6318"},
+			},
+			wantNames: []string{"appsignals", "general"},
+			want: map[string][]string{
+				"":           {"otlp/traces/1"},
+				"appsignals": {"otlp/traces/appsignals/2"},
+				"general":    {"otlp/traces/general/0", "otlp/traces/general/3"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(map[string]any{
+				"traces": map[string]any{"traces_collected": map[string]any{"otlp": testCase.input}},
+			})
+			assert.Equal(t, testCase.wantNames, PipelineNames(conf, configKey))
+			for pipelineName, wantIDs := range testCase.want {
+				translators := NewTranslators(conf, configKey, pipeline.SignalTraces, pipelineName)
+				assert.Equal(t, wantIDs, collections.MapSlice(translators.Keys(), component.ID.String))
+			}
+		})
+	}
+}
+
+func TestPipelinesEndpoints(t *testing.T) {
+	configKey := common.ConfigKey(common.TracesKey, common.TracesCollectedKey, common.OtlpKey)
+	conf := confmap.NewFromStringMap(map[string]any{
+		"traces": map[string]any{"traces_collected": map[string]any{"otlp": []any{
+			map[string]any{"grpc_endpoint": "127.0.0.1:4317"},
+			map[string]any{"name": "appsignals", "grpc_endpoint": "0.0.0.0:4315", "http_endpoint": "0.0.0.0:4316"},
+		}}},
+	})
+	translators := NewTranslators(conf, configKey, pipeline.SignalTraces, "appsignals")
+	require.Equal(t, 1, translators.Len())
+	translators.Range(func(tt common.ComponentTranslator) {
+		got, err := tt.Translate(conf)
+		require.NoError(t, err)
+		cfg := got.(*otlpreceiver.Config)
+		assert.Equal(t, "0.0.0.0:4315", cfg.GRPC.NetAddr.Endpoint)
+		assert.Equal(t, "0.0.0.0:4316", cfg.HTTP.Endpoint)
+	})
+}
+
+func TestResourceAttributes(t *testing.T) {
+	configKey := common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.OtlpKey)
+	conf := confmap.NewFromStringMap(map[string]any{
+		"logs": map[string]any{"metrics_collected": map[string]any{"otlp": []any{
+			map[string]any{"resource_attributes": map[string]any{"team": "ignored"}},
+			map[string]any{"name": "payments", "resource_attributes": map[string]any{"team": "payments", "tier": 1}},
+			map[string]any{"name": "payments", "resource_attributes": map[string]any{"team": "payments", "tier": "1"}},
+			map[string]any{"name": "general"},
+			map[string]any{"name": "mixed", "resource_attributes": map[string]any{"team": "a"}},
+			map[string]any{"name": "mixed"},
+		}}},
+	})
+	assert.False(t, HasResourceAttributes(conf, configKey, ""))
+	assert.True(t, HasResourceAttributes(conf, configKey, "payments"))
+	assert.False(t, HasResourceAttributes(conf, configKey, "general"))
+	assert.True(t, HasResourceAttributes(conf, configKey, "mixed"))
+
+	got, err := ResourceAttributes(conf, configKey, "payments")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "payments", "tier": "1"}, got)
+	got, err = ResourceAttributes(conf, configKey, "general")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	_, err = ResourceAttributes(conf, configKey, "mixed")
+	assert.Error(t, err)
+}
@@ -7,15 +7,20 @@ import (
 	_ "embed"
 	"fmt"
 	"strconv"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver"
 
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/localauth"
 )
 
 const (
@@ -24,6 +29,18 @@ const (
 	defaultAppSignalsGrpcEndpoint = "0.0.0.0:4315"
 	defaultAppSignalsHttpEndpoint = "0.0.0.0:4316"
 	defaultJMXHttpEndpoint        = "0.0.0.0:4314"
+
+	grpcKey                  = "grpc"
+	maxRecvMsgSizeKey        = "max_recv_msg_size_mib"
+	maxConcurrentStreamsKey  = "max_concurrent_streams"
+	keepaliveKey             = "keepalive"
+	permitWithoutStreamKey   = "permit_without_stream"
+	keepaliveMinTimeKey      = "min_time"
+	keepaliveTimeKey         = "time"
+	keepaliveTimeoutKey      = "timeout"
+	maxConnectionIdleKey     = "max_connection_idle"
+	maxConnectionAgeKey      = "max_connection_age"
+	maxConnectionAgeGraceKey = "max_connection_age_grace"
 )
 
 type translator struct {
@@ -78,14 +95,14 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 
 	if t.Name() == common.PipelineNameJmx {
 		cfg.GRPC = nil
-		cfg.HTTP.Endpoint = defaultJMXHttpEndpoint
+		cfg.HTTP.Endpoint = agent.ListenAddress(defaultJMXHttpEndpoint)
 		return cfg, nil
 	}
 
 	// init default configuration
 	configKey := t.configKey
-	cfg.GRPC.NetAddr.Endpoint = defaultGrpcEndpoint
-	cfg.HTTP.Endpoint = defaultHttpEndpoint
+	cfg.GRPC.NetAddr.Endpoint = agent.ListenAddress(defaultGrpcEndpoint)
+	cfg.HTTP.Endpoint = agent.ListenAddress(defaultHttpEndpoint)
 
 	if t.Name() == common.AppSignals {
 		appSignalsConfigKeys, ok := common.AppSignalsConfigKeys[t.signal]
@@ -97,8 +114,8 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		} else {
 			configKey = appSignalsConfigKeys[1]
 		}
-		cfg.GRPC.NetAddr.Endpoint = defaultAppSignalsGrpcEndpoint
-		cfg.HTTP.Endpoint = defaultAppSignalsHttpEndpoint
+		cfg.GRPC.NetAddr.Endpoint = agent.ListenAddress(defaultAppSignalsGrpcEndpoint)
+		cfg.HTTP.Endpoint = agent.ListenAddress(defaultAppSignalsHttpEndpoint)
 	}
 
 	if conf == nil || !conf.IsSet(configKey) {
@@ -123,5 +140,46 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if httpOk {
 		cfg.HTTP.Endpoint = httpEndpoint.(string)
 	}
+	if grpc, ok := otlpMap[grpcKey].(map[string]any); ok {
+		applyGRPCSettings(cfg.GRPC, confmap.NewFromStringMap(grpc))
+	}
+	// the Application Signals endpoints receive from the SDKs, which cannot present the secret
+	if authentication := localauth.Authentication(conf); authentication != nil && t.Name() != common.AppSignals {
+		cfg.GRPC.Auth = authentication
+		cfg.HTTP.Auth = &confighttp.AuthConfig{Authentication: *authentication}
+	}
 	return cfg, nil
 }
+
+// applyGRPCSettings tunes the gRPC server, for the busy services whose batches are larger than the default limits
+// accept, and the clients that keep their connections alive.
+func applyGRPCSettings(cfg *configgrpc.ServerConfig, conf *confmap.Conf) {
+	if size, ok := common.GetNumber(conf, maxRecvMsgSizeKey); ok {
+		cfg.MaxRecvMsgSizeMiB = int(size)
+	}
+	if streams, ok := common.GetNumber(conf, maxConcurrentStreamsKey); ok {
+		cfg.MaxConcurrentStreams = uint32(streams)
+	}
+	if !conf.IsSet(keepaliveKey) {
+		return
+	}
+	if cfg.Keepalive == nil {
+		cfg.Keepalive = configgrpc.NewDefaultKeepaliveServerConfig()
+	}
+	params := cfg.Keepalive.ServerParameters
+	for key, target := range map[string]*time.Duration{
+		maxConnectionIdleKey:     &params.MaxConnectionIdle,
+		maxConnectionAgeKey:      &params.MaxConnectionAge,
+		maxConnectionAgeGraceKey: &params.MaxConnectionAgeGrace,
+		keepaliveTimeKey:         &params.Time,
+		keepaliveTimeoutKey:      &params.Timeout,
+		keepaliveMinTimeKey:      &cfg.Keepalive.EnforcementPolicy.MinTime,
+	} {
+		if duration, ok := common.GetDuration(conf, common.ConfigKey(keepaliveKey, key)); ok {
+			*target = duration
+		}
+	}
+	if permit, ok := common.GetBool(conf, common.ConfigKey(keepaliveKey, permitWithoutStreamKey)); ok {
+		cfg.Keepalive.EnforcementPolicy.PermitWithoutStream = permit
+	}
+}
@@ -75,6 +75,28 @@ func TestTracesTranslator(t *testing.T) {
 			input: testutil.GetJson(t, filepath.Join("testdata", "traces", "config.json")),
 			want:  testutil.GetConf(t, filepath.Join("testdata", "traces", "config.yaml")),
 		},
+		"WithGRPCSettings": {
+			input: testutil.GetJson(t, filepath.Join("testdata", "traces", "grpc_config.json")),
+			want:  testutil.GetConf(t, filepath.Join("testdata", "traces", "grpc_config.yaml")),
+		},
+		"WithLocalAuth": {
+			input: map[string]interface{}{
+				"agent":  map[string]interface{}{"local_auth": map[string]interface{}{}},
+				"traces": map[string]interface{}{"traces_collected": map[string]interface{}{"otlp": map[string]interface{}{}}},
+			},
+			want: confmap.NewFromStringMap(map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{
+						"endpoint": "127.0.0.1:4317",
+						"auth":     map[string]interface{}{"authenticator": "localauth"},
+					},
+					"http": map[string]interface{}{
+						"endpoint": "127.0.0.1:4318",
+						"auth":     map[string]interface{}{"authenticator": "localauth"},
+					},
+				},
+			}),
+		},
 	}
 	factory := otlpreceiver.NewFactory()
 	for name, testCase := range testCases {
@@ -314,6 +336,25 @@ func TestTranslateAppSignals(t *testing.T) {
 				},
 			}),
 		},
+		"WithAppSignalsEnabledTracesWithLocalAuth": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{"local_auth": map[string]interface{}{}},
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"application_signals": map[string]interface{}{},
+					},
+				}},
+			want: confmap.NewFromStringMap(map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{
+						"endpoint": "0.0.0.0:4315",
+					},
+					"http": map[string]interface{}{
+						"endpoint": "0.0.0.0:4316",
+					},
+				},
+			}),
+		},
 		"WithAppSignalsEnabledTracesWithTLS": {
 			input: map[string]interface{}{
 				"traces": map[string]interface{}{
@@ -6,6 +6,7 @@ package tcplog
 import (
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tcplogreceiver"
@@ -13,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 )
 
@@ -29,8 +31,9 @@ var (
 )
 
 const (
-	addressSplit        = ":"
-	telegrafDoubleSlash = "//"
+	addressSplit         = ":"
+	telegrafDoubleSlash  = "//"
+	defaultListenAddress = "0.0.0.0:25888"
 )
 
 // NewTranslator creates a new tcp logs receiver translator.
@@ -59,6 +62,7 @@ func (t *translator) ID() component.ID {
 // tcp://127.0.0.1:25888
 // tcp:0.0.0.0:25888
 // tcp:localhost:25888
+// tcp:[::1]:25888
 func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if !conf.IsSet(baseKey) ||
 		(conf.IsSet(common.ConfigKey(serviceAddressKey)) && !strings.Contains(fmt.Sprintf("%v", conf.Get(serviceAddressKey)), common.Tcp)) {
@@ -66,18 +70,24 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	}
 	cfg := t.factory.CreateDefaultConfig().(*tcplogreceiver.TCPLogConfig)
 	if !conf.IsSet(common.ConfigKey(serviceAddressKey)) {
-		cfg.InputConfig.BaseConfig.ListenAddress = "0.0.0.0:25888"
+		cfg.InputConfig.BaseConfig.ListenAddress = agent.ListenAddress(defaultListenAddress)
 	} else {
 		serviceAddress := fmt.Sprintf("%v", conf.Get(serviceAddressKey))
-		serviceSplit := strings.Split(serviceAddress, addressSplit)
-		if len(serviceSplit) != 3 {
+		// split the scheme only, the host can be an IPv6 address, e.g. [::1]
+		serviceSplit := strings.SplitN(serviceAddress, addressSplit, 2)
+		if len(serviceSplit) != 2 {
 			return nil, errors.New("invalid service split")
-		} else if serviceSplit[1] == telegrafDoubleSlash {
-			serviceSplit[1] = strings.Replace(serviceSplit[1], telegrafDoubleSlash, "0.0.0.0", 1)
-		} else if strings.Contains(serviceAddress, telegrafDoubleSlash) {
-			serviceSplit[1] = strings.Replace(serviceSplit[1], telegrafDoubleSlash, "", 1)
 		}
-		cfg.InputConfig.BaseConfig.ListenAddress = serviceSplit[1] + addressSplit + serviceSplit[2]
+		address := serviceSplit[1]
+		if strings.HasPrefix(address, telegrafDoubleSlash+addressSplit) {
+			address = agent.ListenAddress("0.0.0.0" + strings.TrimPrefix(address, telegrafDoubleSlash))
+		} else {
+			address = strings.TrimPrefix(address, telegrafDoubleSlash)
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return nil, errors.New("invalid service split")
+		}
+		cfg.InputConfig.BaseConfig.ListenAddress = address
 	}
 	return cfg, nil
 }
@@ -4,6 +4,7 @@
 package udplog
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -115,6 +116,36 @@ func TestTranslator(t *testing.T) {
 				},
 			},
 		},
+		"UdpIPv6ServiceAddress": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"emf": map[string]interface{}{
+							"service_address": "udp://[::1]:25888",
+						},
+					},
+				},
+			},
+			want: &udplogreceiver.UDPLogConfig{
+				InputConfig: udp.Config{
+					BaseConfig: udp.BaseConfig{
+						ListenAddress: "[::1]:25888",
+					},
+				},
+			},
+		},
+		"UdpInvalidServiceAddress": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"emf": map[string]interface{}{
+							"service_address": "udp:::1:25888",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid service split"),
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otel
+
+import (
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+// signalOrder is the order of the partitions, the first one keeps the self telemetry.
+var signalOrder = []pipeline.Signal{pipeline.SignalMetrics, pipeline.SignalLogs, pipeline.SignalTraces}
+
+// Split partitions the pipelines of the config by signal, e.g. the host metrics apart from the logs, so that each
+// partition can run in its own collector process. The signals whose pipelines share a receiver stay in the same
+// partition, since a receiver listening on an endpoint can only run in one process. The partitions are keyed by their
+// signals joined with "-", e.g. "metrics" or "metrics-traces", and keep all the extensions. Only the first partition
+// keeps the self telemetry, so that the processes do not serve it on the same endpoint.
+func Split(cfg *otelcol.Config) map[string]*otelcol.Config {
+	// group the signals sharing a receiver
+	groups := map[pipeline.Signal]pipeline.Signal{}
+	var find func(signal pipeline.Signal) pipeline.Signal
+	find = func(signal pipeline.Signal) pipeline.Signal {
+		if parent, ok := groups[signal]; ok && parent != signal {
+			root := find(parent)
+			groups[signal] = root
+			return root
+		}
+		return signal
+	}
+	receiverSignals := map[component.ID]pipeline.Signal{}
+	for id, p := range cfg.Service.Pipelines {
+		signal := id.Signal()
+		if _, ok := groups[signal]; !ok {
+			groups[signal] = signal
+		}
+		for _, receiver := range p.Receivers {
+			if other, ok := receiverSignals[receiver]; ok {
+				groups[find(signal)] = find(other)
+			} else {
+				receiverSignals[receiver] = signal
+			}
+		}
+	}
+
+	members := map[pipeline.Signal][]string{}
+	var roots []pipeline.Signal
+	for _, signal := range signalOrder {
+		if _, ok := groups[signal]; !ok {
+			continue
+		}
+		root := find(signal)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], signal.String())
+	}
+
+	partitions := make(map[string]*otelcol.Config, len(roots))
+	for i, root := range roots {
+		partition := &otelcol.Config{
+			Receivers:  map[component.ID]component.Config{},
+			Exporters:  map[component.ID]component.Config{},
+			Processors: map[component.ID]component.Config{},
+			Extensions: cfg.Extensions,
+			Service:    cfg.Service,
+		}
+		partition.Service.Pipelines = pipelines.Config{}
+		for id, p := range cfg.Service.Pipelines {
+			if find(id.Signal()) != root {
+				continue
+			}
+			partition.Service.Pipelines[id] = p
+			copyComponents(p.Receivers, cfg.Receivers, partition.Receivers)
+			copyComponents(p.Processors, cfg.Processors, partition.Processors)
+			copyComponents(p.Exporters, cfg.Exporters, partition.Exporters)
+		}
+		if i > 0 {
+			partition.Service.Telemetry.Metrics = telemetry.MetricsConfig{Level: configtelemetry.LevelNone}
+		}
+		sort.Strings(members[root])
+		partitions[strings.Join(members[root], "-")] = partition
+	}
+	return partitions
+}
+
+func copyComponents(ids []component.ID, from, to map[component.ID]component.Config) {
+	for _, id := range ids {
+		if c, ok := from[id]; ok {
+			to[id] = c
+		}
+	}
+}
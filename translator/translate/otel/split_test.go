@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestSplit(t *testing.T) {
+	cpu := component.MustNewID("telegraf_cpu")
+	otlp := component.MustNewIDWithName("otlp", "application_signals")
+	tcplog := component.MustNewID("tcplog")
+	batch := component.MustNewID("batch")
+	cloudwatch := component.MustNewID("awscloudwatch")
+	emf := component.MustNewIDWithName("awsemf", "application_signals")
+	xray := component.MustNewID("awsxray")
+	cwlogs := component.MustNewID("awscloudwatchlogs")
+	agenthealth := component.MustNewIDWithName("agenthealth", "metrics")
+	cfg := &otelcol.Config{
+		Receivers: map[component.ID]component.Config{
+			cpu: "cpu", otlp: "otlp", tcplog: "tcplog",
+		},
+		Processors: map[component.ID]component.Config{batch: "batch"},
+		Exporters: map[component.ID]component.Config{
+			cloudwatch: "cloudwatch", emf: "emf", xray: "xray", cwlogs: "cwlogs",
+		},
+		Extensions: map[component.ID]component.Config{agenthealth: "agenthealth"},
+		Service: service.Config{
+			Telemetry: telemetry.Config{Metrics: telemetry.MetricsConfig{Level: configtelemetry.LevelNormal}},
+			Pipelines: pipelines.Config{
+				pipeline.NewIDWithName(pipeline.SignalMetrics, "host"): {
+					Receivers: []component.ID{cpu},
+					Exporters: []component.ID{cloudwatch},
+				},
+				pipeline.NewIDWithName(pipeline.SignalMetrics, "application_signals"): {
+					Receivers:  []component.ID{otlp},
+					Processors: []component.ID{batch},
+					Exporters:  []component.ID{emf},
+				},
+				pipeline.NewIDWithName(pipeline.SignalTraces, "application_signals"): {
+					Receivers:  []component.ID{otlp},
+					Processors: []component.ID{batch},
+					Exporters:  []component.ID{xray},
+				},
+				pipeline.NewIDWithName(pipeline.SignalLogs, "emf_logs"): {
+					Receivers:  []component.ID{tcplog},
+					Processors: []component.ID{batch},
+					Exporters:  []component.ID{cwlogs},
+				},
+			},
+			Extensions: []component.ID{agenthealth},
+		},
+	}
+
+	got := Split(cfg)
+	require.Len(t, got, 2)
+	// the traces share the OTLP receiver of the Application Signals metrics
+	metrics := got["metrics-traces"]
+	require.NotNil(t, metrics)
+	assert.Len(t, metrics.Service.Pipelines, 3)
+	assert.Equal(t, map[component.ID]component.Config{cpu: "cpu", otlp: "otlp"}, metrics.Receivers)
+	assert.Equal(t, map[component.ID]component.Config{cloudwatch: "cloudwatch", emf: "emf", xray: "xray"}, metrics.Exporters)
+	assert.Equal(t, map[component.ID]component.Config{batch: "batch"}, metrics.Processors)
+	assert.Equal(t, configtelemetry.LevelNormal, metrics.Service.Telemetry.Metrics.Level)
+
+	logs := got["logs"]
+	require.NotNil(t, logs)
+	assert.Len(t, logs.Service.Pipelines, 1)
+	assert.Equal(t, map[component.ID]component.Config{tcplog: "tcplog"}, logs.Receivers)
+	assert.Equal(t, map[component.ID]component.Config{cwlogs: "cwlogs"}, logs.Exporters)
+	assert.Equal(t, map[component.ID]component.Config{batch: "batch"}, logs.Processors)
+	assert.Equal(t, cfg.Service.Extensions, logs.Service.Extensions)
+	assert.Equal(t, cfg.Extensions, logs.Extensions)
+	// only the first partition serves the self telemetry
+	assert.Equal(t, configtelemetry.LevelNone, logs.Service.Telemetry.Metrics.Level)
+	// the config is not modified
+	assert.Len(t, cfg.Service.Pipelines, 4)
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -16,13 +18,19 @@ import (
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service"
 	"go.opentelemetry.io/collector/service/telemetry"
+	otelconf "go.opentelemetry.io/contrib/config"
 	"go.uber.org/multierr"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/entitystore"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/faultinjection"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/featureflags"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/localauth"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/server"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/standby"
 	pipelinetranslator "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/applicationsignals"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/containerinsights"
@@ -31,11 +39,20 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/host"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/jmx"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/nop"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/otlp_logs"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/prometheus"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/xray"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util/ecsutil"
 )
 
+const (
+	defaultSelfTelemetryEndpoint = "127.0.0.1:8888"
+)
+
+var (
+	selfTelemetryKey = common.ConfigKey(common.AgentKey, "self_telemetry")
+)
+
 var registry = common.NewTranslatorMap[*common.ComponentTranslators, pipeline.ID]()
 
 func RegisterPipeline(translators ...pipelinetranslator.Translator) {
@@ -71,9 +88,11 @@ func Translate(jsonConfig interface{}, os string) (*otelcol.Config, error) {
 	translators.Merge(containerInsightsTranslators)
 	translators.Set(applicationsignals.NewTranslator(pipeline.SignalTraces))
 	translators.Set(applicationsignals.NewTranslator(pipeline.SignalMetrics))
+	translators.Set(applicationsignals.NewPrometheusTranslator())
 	translators.Merge(prometheus.NewTranslators(conf))
 	translators.Set(emf_logs.NewTranslator())
-	translators.Set(xray.NewTranslator())
+	translators.Set(otlp_logs.NewTranslator())
+	translators.Merge(xray.NewTranslators(conf))
 	translators.Set(containerinsightsjmx.NewTranslator())
 	translators.Merge(jmx.NewTranslators(conf))
 	translators.Merge(registry)
@@ -92,7 +111,23 @@ func Translate(jsonConfig interface{}, os string) (*otelcol.Config, error) {
 	if context.CurrentContext().KubernetesMode() != "" {
 		pipelines.Translators.Extensions.Set(server.NewTranslator())
 	}
+	if conf.IsSet(standby.StandbyKey) {
+		pipelines.Translators.Extensions.Set(standby.NewTranslator())
+	}
+	if conf.IsSet(featureflags.FeatureFlagsKey) {
+		pipelines.Translators.Extensions.Set(featureflags.NewTranslator())
+	}
+	if conf.IsSet(localauth.LocalAuthKey) {
+		pipelines.Translators.Extensions.Set(localauth.NewTranslator())
+	}
+	if conf.IsSet(faultinjection.FaultInjectionKey) {
+		pipelines.Translators.Extensions.Set(faultinjection.NewTranslator())
+	}
 
+	metricsConfig, err := getMetricsConfig(conf)
+	if err != nil {
+		return nil, err
+	}
 	cfg := &otelcol.Config{
 		Receivers:  map[component.ID]component.Config{},
 		Exporters:  map[component.ID]component.Config{},
@@ -101,7 +136,7 @@ func Translate(jsonConfig interface{}, os string) (*otelcol.Config, error) {
 		Service: service.Config{
 			Telemetry: telemetry.Config{
 				Logs:    getLoggingConfig(conf),
-				Metrics: telemetry.MetricsConfig{Level: configtelemetry.LevelNone},
+				Metrics: metricsConfig,
 				Traces:  telemetry.TracesConfig{Level: configtelemetry.LevelNone},
 			},
 			Pipelines:  pipelines.Pipelines,
@@ -156,6 +191,42 @@ func getLoggingConfig(conf *confmap.Conf) telemetry.LogsConfig {
 	}
 }
 
+// getMetricsConfig enables the collector's own metrics when agent.self_telemetry is set. They include the
+// request counts, sizes and errors of the receivers, and are served in the Prometheus format on the endpoint.
+func getMetricsConfig(conf *confmap.Conf) (telemetry.MetricsConfig, error) {
+	if !conf.IsSet(selfTelemetryKey) {
+		return telemetry.MetricsConfig{Level: configtelemetry.LevelNone}, nil
+	}
+	level := configtelemetry.LevelNormal
+	if value, ok := common.GetString(conf, common.ConfigKey(selfTelemetryKey, "level")); ok {
+		if err := level.UnmarshalText([]byte(value)); err != nil {
+			return telemetry.MetricsConfig{}, fmt.Errorf("invalid %s: %w", common.ConfigKey(selfTelemetryKey, "level"), err)
+		}
+	}
+	endpoint := agent.ListenAddress(defaultSelfTelemetryEndpoint)
+	if value, ok := common.GetString(conf, common.ConfigKey(selfTelemetryKey, common.Endpoint)); ok {
+		endpoint = value
+	}
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return telemetry.MetricsConfig{}, fmt.Errorf("invalid %s: %w", common.ConfigKey(selfTelemetryKey, common.Endpoint), err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return telemetry.MetricsConfig{}, fmt.Errorf("invalid %s: %w", common.ConfigKey(selfTelemetryKey, common.Endpoint), err)
+	}
+	return telemetry.MetricsConfig{
+		Level: level,
+		Readers: []otelconf.MetricReader{{
+			Pull: &otelconf.PullMetricReader{
+				Exporter: otelconf.MetricExporter{
+					Prometheus: &otelconf.Prometheus{Host: &host, Port: &port},
+				},
+			},
+		}},
+	}, nil
+}
+
 // build uses the pipelines and extensions defined in the config to build the components.
 func build(conf *confmap.Conf, cfg *otelcol.Config, translators common.ComponentTranslators) error {
 	errs := buildComponents(conf, cfg.Service.Extensions, cfg.Extensions, translators.Extensions.Get)
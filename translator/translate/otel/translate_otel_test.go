@@ -8,8 +8,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/telemetry"
+	otelconf "go.opentelemetry.io/contrib/config"
 
 	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
@@ -63,6 +66,71 @@ func TestTranslator(t *testing.T) {
 				},
 			},
 		},
+		"WithStandby": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"standby": map[string]interface{}{
+						"lock": "/mnt/shared/cwagent.lease",
+					},
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"statsd": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		"WithInvalidStandby": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"standby": map[string]interface{}{},
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"statsd": map[string]interface{}{},
+					},
+				},
+			},
+			wantErrContains: "lock must be set",
+		},
+		"WithFeatureFlags": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"feature_flags": map[string]interface{}{
+						"flags": map[string]interface{}{"request_compression": false},
+					},
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"statsd": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		"WithLocalAuth": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"local_auth": map[string]interface{}{"rotation_interval": 600},
+				},
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"otlp": map[string]interface{}{},
+					},
+				},
+			},
+		},
+		"WithFaultInjection": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"fault_injection": map[string]interface{}{"max_latency": 5},
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"statsd": map[string]interface{}{},
+					},
+				},
+			},
+		},
 		"WithAppSignalsMetricsEnabled": {
 			input: map[string]interface{}{
 				"logs": map[string]interface{}{
@@ -261,3 +329,58 @@ func TestRegisterPipeline(t *testing.T) {
 	assert.NotEqual(t, first.version, got.(*testTranslator).version)
 	assert.NotEqual(t, original.version, got.(*testTranslator).version)
 }
+
+func TestGetMetricsConfig(t *testing.T) {
+	host, port := "0.0.0.0", 9999
+	defaultHost, defaultPort := "127.0.0.1", 8888
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    telemetry.MetricsConfig
+		wantErr bool
+	}{
+		"WithoutSelfTelemetry": {
+			input: map[string]interface{}{"agent": map[string]interface{}{}},
+			want:  telemetry.MetricsConfig{Level: configtelemetry.LevelNone},
+		},
+		"WithDefault": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"self_telemetry": map[string]interface{}{}}},
+			want: telemetry.MetricsConfig{
+				Level: configtelemetry.LevelNormal,
+				Readers: []otelconf.MetricReader{{Pull: &otelconf.PullMetricReader{Exporter: otelconf.MetricExporter{
+					Prometheus: &otelconf.Prometheus{Host: &defaultHost, Port: &defaultPort},
+				}}}},
+			},
+		},
+		"WithCustom": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"self_telemetry": map[string]interface{}{
+				"endpoint": "0.0.0.0:9999",
+				"level":    "detailed",
+			}}},
+			want: telemetry.MetricsConfig{
+				Level: configtelemetry.LevelDetailed,
+				Readers: []otelconf.MetricReader{{Pull: &otelconf.PullMetricReader{Exporter: otelconf.MetricExporter{
+					Prometheus: &otelconf.Prometheus{Host: &host, Port: &port},
+				}}}},
+			},
+		},
+		"WithInvalidLevel": {
+			input:   map[string]interface{}{"agent": map[string]interface{}{"self_telemetry": map[string]interface{}{"level": "verbose"}}},
+			wantErr: true,
+		},
+		"WithInvalidEndpoint": {
+			input:   map[string]interface{}{"agent": map[string]interface{}{"self_telemetry": map[string]interface{}{"endpoint": "localhost"}}},
+			wantErr: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := getMetricsConfig(confmap.NewFromStringMap(testCase.input))
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -22,6 +22,7 @@ func GetCurPath() string {
 var (
 	linuxTranslateRule   = map[string]Rule{}
 	darwinTranslateRule  = map[string]Rule{}
+	freebsdTranslateRule = map[string]Rule{}
 	windowsTranslateRule = map[string]Rule{}
 )
 
@@ -33,6 +34,10 @@ func RegisterDarwinRule(fieldname string, r Rule) {
 	darwinTranslateRule[fieldname] = r
 }
 
+func RegisterFreeBSDRule(fieldname string, r Rule) {
+	freebsdTranslateRule[fieldname] = r
+}
+
 func RegisterWindowsRule(fieldname string, r Rule) {
 	windowsTranslateRule[fieldname] = r
 }
@@ -54,6 +59,8 @@ func (t *Translator) ApplyRule(input interface{}) (returnKey string, returnVal i
 		targetRuleMap = linuxTranslateRule
 	case config.OS_TYPE_DARWIN:
 		targetRuleMap = darwinTranslateRule
+	case config.OS_TYPE_FREEBSD:
+		targetRuleMap = freebsdTranslateRule
 	case config.OS_TYPE_WINDOWS:
 		targetRuleMap = windowsTranslateRule
 	default:
@@ -20,13 +20,19 @@ func AddHighResolutionTag(tags interface{}) {
 	tagMap[High_Resolution_Tag_Key] = "true"
 }
 
-// FilterReservedKeys out reserved tag keys
+// FilterReservedKeys out reserved tag keys and the EBS volume tags, which are added by the ec2tagger processor.
 func FilterReservedKeys(input any) any {
 	result := map[string]any{}
 	for k, v := range input.(map[string]interface{}) {
-		if !ReservedTagKeySet.Contains(k) {
-			result[k] = v
+		if ReservedTagKeySet.Contains(k) {
+			continue
 		}
+		if value, ok := v.(string); ok {
+			if _, ok = ec2tagger.VolumeTagKey(value); ok {
+				continue
+			}
+		}
+		result[k] = v
 	}
 	return result
 }
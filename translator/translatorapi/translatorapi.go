@@ -0,0 +1,245 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package translatorapi translates agent JSON configurations the same way the config-translator
+// command does, for tools that generate or validate configurations without running the agent.
+//
+// The package follows the compatibility rules of Version. Fields may be added to its types within
+// a version, but existing ones are not removed or changed in meaning.
+package translatorapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/otelcol"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/mapstructure"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/conditions"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/presets"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/totomlconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toyamlconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+// Version is the version of this API.
+const Version = "v1"
+
+// Options controls how a configuration is translated.
+type Options struct {
+	// OS is the platform the agent runs on: linux, darwin, windows or freebsd. It defaults to the
+	// platform of the caller.
+	OS string
+	// Arch is the architecture the agent runs on, e.g. amd64 or arm64, which the conditions of the
+	// configuration are evaluated against. It defaults to the architecture of the caller.
+	Arch string
+	// Mode is ec2, onPremise, onPrem or auto. It defaults to ec2. The auto mode queries the
+	// instance metadata service of the caller.
+	Mode string
+	// Region is used when the configuration does not set agent.region. If both are empty, the
+	// region is detected on the caller the same way the agent would.
+	Region string
+	// Credentials are the shared credential settings of the common-config file, e.g. profile and
+	// shared_credential_file.
+	Credentials map[string]string
+	// InstanceTags are the EC2 tags the conditions of the configuration are evaluated against. The
+	// tags of the caller are never looked up.
+	InstanceTags map[string]string
+}
+
+// Result is the output of a successful translation.
+type Result struct {
+	// Config is the collector configuration, or nil if the configuration has no OTel pipelines.
+	Config *otelcol.Config
+	// YAML is Config as written to amazon-cloudwatch-agent.yaml, or nil with Config.
+	YAML []byte
+	// TOML is the telegraf configuration as written to amazon-cloudwatch-agent.toml.
+	TOML []byte
+	// Warnings are the problems that did not stop the translation, in the order they were found.
+	Warnings []Message
+}
+
+// Message is a problem found in the configuration.
+type Message struct {
+	// Path is the JSON path of the problem, e.g. /metrics/metrics_collected/cpu/, if known.
+	Path    string
+	Message string
+}
+
+func (m Message) String() string {
+	if m.Path == "" {
+		return m.Message
+	}
+	return fmt.Sprintf("%s: %s", m.Path, m.Message)
+}
+
+// ValidationError is returned when the configuration is rejected.
+type ValidationError struct {
+	Errors []Message
+}
+
+func (e *ValidationError) Error() string {
+	s := make([]string, len(e.Errors))
+	for i, m := range e.Errors {
+		s[i] = m.String()
+	}
+	return "invalid agent configuration: " + strings.Join(s, "; ")
+}
+
+// The translator keeps its state in package variables, so translations run one at a time.
+var mu sync.Mutex
+
+// Translate translates an agent JSON configuration. A configuration that is rejected returns a
+// *ValidationError. It is safe for concurrent use, but translations are serialized, and the
+// standard logger is redirected while one runs.
+func Translate(jsonConfig []byte, opts Options) (result *Result, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var logs bytes.Buffer
+	writer, flags := log.Writer(), log.Flags()
+	log.SetOutput(&logs)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(writer)
+		log.SetFlags(flags)
+	}()
+
+	translator.ResetMessages()
+	context.ResetContext()
+	agent.Global_Config = *new(agent.Agent)
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = validationError(r)
+		}
+		translator.ResetMessages()
+		context.ResetContext()
+	}()
+
+	if opts.OS == "" {
+		opts.OS = runtime.GOOS
+	}
+	if opts.Arch == "" {
+		opts.Arch = runtime.GOARCH
+	}
+	if opts.Mode == "" {
+		opts.Mode = config.ModeEC2
+	}
+	ctx := context.CurrentContext()
+	ctx.SetOs(opts.OS)
+	ctx.SetRegion(opts.Region)
+	if opts.Credentials != nil {
+		ctx.SetCredentials(opts.Credentials)
+	}
+	mode := util.DetectAgentMode(opts.Mode)
+	ctx.SetMode(mode)
+	ctx.SetKubernetesMode(util.DetectKubernetesMode(mode))
+
+	input, err := util.GetJsonMapFromJsonBytes(jsonConfig)
+	if err != nil {
+		return nil, &ValidationError{Errors: []Message{{Message: err.Error()}}}
+	}
+	host := conditions.Host{
+		OS:   opts.OS,
+		Arch: opts.Arch,
+		Region: sync.OnceValue(func() string {
+			if opts.Region != "" {
+				return opts.Region
+			}
+			region, _ := util.DetectRegion(mode, opts.Credentials)
+			return region
+		}),
+		Tags: func() (map[string]string, error) { return opts.InstanceTags, nil },
+	}
+	if err = conditions.Expand(input, host); err != nil {
+		return nil, &ValidationError{Errors: []Message{{Message: err.Error()}}}
+	}
+	if err = presets.Expand(input, ctx.Os()); err != nil {
+		return nil, &ValidationError{Errors: []Message{{Message: err.Error()}}}
+	}
+	merged, err := jsonconfig.MergeJsonConfigMaps(map[string]map[string]interface{}{"config": input}, nil, "default")
+	if err != nil {
+		return nil, &ValidationError{Errors: []Message{{Message: err.Error()}}}
+	}
+	schemaResult, err := cmdutil.RunSchemaValidation(merged)
+	if err != nil {
+		return nil, err
+	}
+	if !schemaResult.Valid() {
+		var errs []Message
+		for _, e := range schemaResult.Errors() {
+			errs = append(errs, Message{Path: config.GetFormattedPath(e.Context().String()), Message: e.Description()})
+		}
+		return nil, &ValidationError{Errors: errs}
+	}
+
+	tomlConfig, err := cmdutil.TranslateJsonMapToTomlConfig(merged)
+	if err != nil {
+		return nil, validationError(nil)
+	}
+	result = &Result{TOML: []byte(totomlconfig.ToTomlConfig(tomlConfig))}
+
+	result.Config, err = otel.Translate(merged, ctx.Os())
+	if err != nil && !errors.Is(err, pipeline.ErrNoPipelines) {
+		return nil, &ValidationError{Errors: []Message{{Message: err.Error()}}}
+	}
+	if result.Config != nil {
+		yamlConfig, err := mapstructure.Marshal(result.Config)
+		if err != nil {
+			return nil, err
+		}
+		result.YAML = []byte(toyamlconfig.ToYamlConfig(yamlConfig))
+	}
+	result.Warnings = warnings(logs.String())
+	return result, nil
+}
+
+// The rules record their errors in one of these forms.
+var (
+	underPathPattern = regexp.MustCompile(`^Under path : (.*) \| Error : (.*)$`)
+	errorPathPattern = regexp.MustCompile(`^The path of the error is : (.*) \| Errors : (.*)$`)
+)
+
+// validationError collects the errors recorded by the rules, falling back to the panic value.
+func validationError(r interface{}) *ValidationError {
+	var errs []Message
+	for _, s := range translator.ErrorMessages {
+		if m := underPathPattern.FindStringSubmatch(s); m != nil {
+			errs = append(errs, Message{Path: m[1], Message: m[2]})
+		} else if m = errorPathPattern.FindStringSubmatch(s); m != nil {
+			errs = append(errs, Message{Path: m[1], Message: m[2]})
+		} else {
+			errs = append(errs, Message{Message: s})
+		}
+	}
+	if len(errs) == 0 && r != nil {
+		errs = append(errs, Message{Message: strings.TrimPrefix(fmt.Sprint(r), "E! ")})
+	}
+	return &ValidationError{Errors: errs}
+}
+
+// warnings returns the W! lines logged during the translation.
+func warnings(logs string) []Message {
+	var result []Message
+	for _, line := range strings.Split(logs, "\n") {
+		if _, message, ok := strings.Cut(line, "W! "); ok {
+			result = append(result, Message{Message: message})
+		}
+	}
+	return result
+}
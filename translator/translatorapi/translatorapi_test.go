@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package translatorapi
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslate(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	result, err := Translate([]byte(`{
+		"agent": {"region": "us-east-1"},
+		"metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}}
+	}`), Options{OS: "linux"})
+	require.NoError(t, err)
+	assert.Contains(t, string(result.TOML), "[[inputs.mem]]")
+	require.NotNil(t, result.Config)
+	assert.Contains(t, string(result.YAML), "telegraf_mem")
+	assert.Contains(t, string(result.YAML), "region: us-east-1")
+	assert.Empty(t, result.Warnings)
+	// the standard logger is restored afterwards
+	log.Print("after")
+	assert.Contains(t, logs.String(), "after")
+}
+
+func TestTranslateRegionOption(t *testing.T) {
+	result, err := Translate([]byte(`{"metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}}}`),
+		Options{OS: "linux", Mode: "onPremise", Region: "eu-west-1"})
+	require.NoError(t, err)
+	assert.Contains(t, string(result.YAML), "region: eu-west-1")
+}
+
+func TestTranslateInstanceTagsOption(t *testing.T) {
+	input := []byte(`{
+		"agent": {"region": "us-east-1"},
+		"metrics": {"metrics_collected": {"mem": {"measurement": ["mem_used_percent"]}}},
+		"conditions": [{
+			"when": {"ec2_tags": {"Role": "web"}},
+			"config": {"metrics": {"metrics_collected": {"disk": {"measurement": ["used_percent"]}}}}
+		}]
+	}`)
+	result, err := Translate(input, Options{OS: "linux", InstanceTags: map[string]string{"Role": "web"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(result.TOML), "[[inputs.disk]]")
+
+	result, err = Translate(input, Options{OS: "linux"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(result.TOML), "[[inputs.disk]]")
+}
+
+func TestTranslateWarnings(t *testing.T) {
+	result, err := Translate([]byte(`{
+		"agent": {"region": "us-east-1"},
+		"metrics": {"metrics_collected": {
+			"disk": {"measurement": ["used_percent"]},
+			"diskio": {"measurement": ["reads"]}
+		}}
+	}`), Options{OS: "freebsd"})
+	require.NoError(t, err)
+	assert.Contains(t, result.Warnings, Message{Message: "Ignoring unrecognized input diskio"})
+}
+
+func TestTranslateInvalid(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		opts  Options
+		want  Message
+	}{
+		"Syntax": {
+			input: `{"agent":`,
+			want:  Message{Message: "unable to parse json, error: unexpected end of JSON input"},
+		},
+		"Schema": {
+			input: `{"agent": {"region": "us-east-1"}, "metrics": {"metrics_collected": {"mem": {"measurement": "mem_used_percent"}}}}`,
+			want:  Message{Path: "/metrics/metrics_collected/mem/measurement", Message: "Invalid type. Expected: array, given: string"},
+		},
+		"Rule": {
+			input: `{"agent": {"region": "us-east-1"}, "metrics": {"metrics_collected": {"cpu": {"measurement": ["cpu_usage_iowait"]}}}}`,
+			opts:  Options{OS: "freebsd"},
+			want:  Message{Path: "/metrics/metrics_collected/cpu/", Message: "measurement name cpu_usage_iowait is invalid"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if testCase.opts.OS == "" {
+				testCase.opts.OS = "linux"
+			}
+			result, err := Translate([]byte(testCase.input), testCase.opts)
+			assert.Nil(t, result)
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr), err)
+			assert.Equal(t, []Message{testCase.want}, validationErr.Errors)
+		})
+	}
+}
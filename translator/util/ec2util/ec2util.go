@@ -4,20 +4,15 @@
 package ec2util
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"sync"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/ec2metadata"
-	"github.com/aws/aws-sdk-go/aws/session"
-
-	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
-	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
-	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/internal/ec2metadataprovider"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
-	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	translatorcontext "github.com/aws/amazon-cloudwatch-agent/translator/context"
 )
 
 // this is a singleton struct
@@ -46,7 +41,7 @@ func GetEC2UtilSingleton() *ec2Util {
 func initEC2UtilSingleton() (newInstance *ec2Util) {
 	newInstance = &ec2Util{Region: "", PrivateIP: ""}
 
-	if (context.CurrentContext().Mode() == config.ModeOnPrem) || (context.CurrentContext().Mode() == config.ModeOnPremise) {
+	if (translatorcontext.CurrentContext().Mode() == config.ModeOnPrem) || (translatorcontext.CurrentContext().Mode() == config.ModeOnPremise) {
 		return
 	}
 
@@ -90,56 +85,26 @@ func initEC2UtilSingleton() (newInstance *ec2Util) {
 }
 
 func (e *ec2Util) deriveEC2MetadataFromIMDS() error {
-	ses, err := session.NewSession()
-
-	if err != nil {
-		return err
-	}
-
-	mdDisableFallback := ec2metadata.New(ses, &aws.Config{
-		LogLevel:                  configaws.SDKLogLevel(),
-		Logger:                    configaws.SDKLogger{},
-		Retryer:                   retryer.NewIMDSRetryer(retryer.GetDefaultRetryNumber()),
-		EC2MetadataEnableFallback: aws.Bool(false),
-	})
-	mdEnableFallback := ec2metadata.New(ses, &aws.Config{
-		LogLevel: configaws.SDKLogLevel(),
-		Logger:   configaws.SDKLogger{},
-	})
+	// the provider falls back to IMDSv1 when needed, and is shared with the components of the agent
+	md := ec2metadataprovider.SharedMetadataProvider()
+	ctx := context.Background()
 
 	// ec2 and ecs treats retries for getting host name differently
 	// More information on API: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/instancedata-data-retrieval.html#instance-metadata-ex-2
-	if hostname, err := mdDisableFallback.GetMetadata("hostname"); err == nil {
+	if hostname, err := md.Hostname(ctx); err == nil {
 		e.Hostname = hostname
 	} else {
-		fmt.Println("D! could not get hostname without imds v1 fallback enable thus enable fallback")
-		hostnameInner, errInner := mdEnableFallback.GetMetadata("hostname")
-		if errInner == nil {
-			e.Hostname = hostnameInner
-			agent.UsageFlags().Set(agent.FlagIMDSFallbackSuccess)
-		} else {
-			fmt.Println("E! [EC2] Fetch hostname from EC2 metadata fail:", errInner)
-		}
+		fmt.Println("E! [EC2] Fetch hostname from EC2 metadata fail:", err)
 	}
 
 	// More information on API: https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/instance-identity-documents.html
-	if instanceIdentityDocument, err := mdDisableFallback.GetInstanceIdentityDocument(); err == nil {
+	if instanceIdentityDocument, err := md.Get(ctx); err == nil {
 		e.Region = instanceIdentityDocument.Region
 		e.AccountID = instanceIdentityDocument.AccountID
 		e.PrivateIP = instanceIdentityDocument.PrivateIP
 		e.InstanceID = instanceIdentityDocument.InstanceID
 	} else {
-		fmt.Println("D! could not get instance document without imds v1 fallback enable thus enable fallback")
-		instanceIdentityDocumentInner, errInner := mdEnableFallback.GetInstanceIdentityDocument()
-		if errInner == nil {
-			e.Region = instanceIdentityDocumentInner.Region
-			e.AccountID = instanceIdentityDocumentInner.AccountID
-			e.PrivateIP = instanceIdentityDocumentInner.PrivateIP
-			e.InstanceID = instanceIdentityDocumentInner.InstanceID
-			agent.UsageFlags().Set(agent.FlagIMDSFallbackSuccess)
-		} else {
-			fmt.Println("E! [EC2] Fetch identity document from EC2 metadata fail:", errInner)
-		}
+		fmt.Println("E! [EC2] Fetch identity document from EC2 metadata fail:", err)
 	}
 
 	return nil
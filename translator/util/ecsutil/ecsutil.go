@@ -23,13 +23,18 @@ const (
 type ecsMetadataResponse struct {
 	Cluster string
 	TaskARN string
+	// ServiceName and LaunchType are only in the responses of the v4 endpoint.
+	ServiceName string
+	LaunchType  string
 }
 
 type ecsUtil struct {
-	Cluster    string
-	Region     string
-	TaskARN    string
-	httpClient *httpclient.HttpClient
+	Cluster     string
+	Region      string
+	TaskARN     string
+	ServiceName string
+	LaunchType  string
+	httpClient  *httpclient.HttpClient
 }
 
 var ecsUtilInstance *ecsUtil
@@ -59,6 +64,8 @@ func initECSUtilSingleton() (newInstance *ecsUtil) {
 	newInstance.parseRegion(ecsMetadataResponse)
 	newInstance.parseClusterName(ecsMetadataResponse)
 	newInstance.TaskARN = ecsMetadataResponse.TaskARN
+	newInstance.ServiceName = ecsMetadataResponse.ServiceName
+	newInstance.LaunchType = ecsMetadataResponse.LaunchType
 	return
 
 }